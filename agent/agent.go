@@ -163,6 +163,15 @@ const (
 	MongoOplogSize         = "MONGO_OPLOG_SIZE"
 	NumaCtlPreference      = "NUMA_CTL_PREFERENCE"
 	AllowsSecureConnection = "SECURE_CONTROLLER_CONNECTION"
+
+	// The following keys override the API server's login throttling
+	// limits; see apiserver.LoginThrottleConfig.
+	LoginMaxFailuresPerSource = "LOGIN_MAX_FAILURES_PER_SOURCE"
+	LoginMaxFailuresPerUser   = "LOGIN_MAX_FAILURES_PER_USER"
+	LoginFailureWindow        = "LOGIN_FAILURE_WINDOW"
+	LoginInitialLockout       = "LOGIN_INITIAL_LOCKOUT"
+	LoginMaxLockout           = "LOGIN_MAX_LOCKOUT"
+	LoginMaxConcurrent        = "LOGIN_MAX_CONCURRENT"
 )
 
 // The Config interface is the sole way that the agent gets access to the
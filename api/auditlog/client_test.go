@@ -0,0 +1,65 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package auditlog_test
+
+import (
+	"time"
+
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/api/auditlog"
+	basetesting "github.com/juju/juju/api/base/testing"
+	"github.com/juju/juju/apiserver/params"
+	coretesting "github.com/juju/juju/testing"
+)
+
+type ClientSuite struct {
+	coretesting.BaseSuite
+}
+
+var _ = gc.Suite(&ClientSuite{})
+
+func (s *ClientSuite) TestEntries(c *gc.C) {
+	filter := params.AuditLogFilter{
+		UserTag: "user-bob@local",
+		Method:  "Service.Destroy",
+		After:   time.Date(2016, 6, 1, 0, 0, 0, 0, time.UTC),
+		Limit:   10,
+	}
+	entry := params.AuditLogEntry{
+		Timestamp: time.Date(2016, 6, 1, 12, 0, 0, 0, time.UTC),
+		UserTag:   "user-bob@local",
+		ModelUUID: "deadbeef-0bad-400d-8000-4b1d0d06f00d",
+		Facade:    "Service",
+		Version:   3,
+		Method:    "Destroy",
+	}
+	apiCaller := basetesting.APICallerFunc(func(objType string, version int, id, request string, arg, result interface{}) error {
+		c.Check(objType, gc.Equals, "AuditLog")
+		c.Check(id, gc.Equals, "")
+		c.Check(request, gc.Equals, "Entries")
+		c.Check(arg, jc.DeepEquals, filter)
+		*(result.(*params.AuditLogEntries)) = params.AuditLogEntries{
+			Entries: []params.AuditLogEntry{entry},
+		}
+		return nil
+	})
+	client := auditlog.NewClient(apiCaller)
+
+	entries, err := client.Entries(filter)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(entries, jc.DeepEquals, []params.AuditLogEntry{entry})
+}
+
+func (s *ClientSuite) TestEntriesError(c *gc.C) {
+	apiCaller := basetesting.APICallerFunc(func(string, int, string, string, interface{}, interface{}) error {
+		return errors.New("boom")
+	})
+	client := auditlog.NewClient(apiCaller)
+
+	_, err := client.Entries(params.AuditLogFilter{})
+	c.Check(err, gc.ErrorMatches, "boom")
+}
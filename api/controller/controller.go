@@ -11,6 +11,7 @@ import (
 	"github.com/juju/juju/api"
 	"github.com/juju/juju/api/base"
 	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/juju/permission"
 )
 
 var logger = loggo.GetLogger("juju.api.controller")
@@ -203,3 +204,56 @@ func (c *Client) InitiateModelMigration(spec ModelMigrationSpec) (string, error)
 	}
 	return result.Id, nil
 }
+
+// GrantController grants a user access to the controller.
+func (c *Client) GrantController(user, access string) error {
+	return c.modifyControllerUser(params.GrantControllerAccess, user, access)
+}
+
+// RevokeController revokes a user's access to the controller.
+func (c *Client) RevokeController(user, access string) error {
+	return c.modifyControllerUser(params.RevokeControllerAccess, user, access)
+}
+
+func (c *Client) modifyControllerUser(action params.ControllerAction, user, access string) error {
+	if !names.IsValidUser(user) {
+		return errors.Errorf("invalid username: %q", user)
+	}
+	userTag := names.NewUserTag(user)
+
+	accessPermission, err := ParseControllerAccess(access)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	args := params.ModifyControllerAccessRequest{
+		Changes: []params.ModifyControllerAccess{{
+			UserTag: userTag.String(),
+			Action:  action,
+			Access:  accessPermission,
+		}},
+	}
+	var result params.ErrorResults
+	err = c.facade.FacadeCall("ModifyControllerAccess", args, &result)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	return result.OneError()
+}
+
+// ParseControllerAccess parses an access permission argument into
+// a type suitable for making an API facade call.
+func ParseControllerAccess(access string) (params.ControllerAccessPermission, error) {
+	controllerAccess, err := permission.ParseControllerAccess(access)
+	if err != nil {
+		return "", errors.Trace(err)
+	}
+	switch controllerAccess {
+	case permission.ControllerLoginAccess:
+		return params.ControllerLoginAccess, nil
+	case permission.ControllerAddModelAccess:
+		return params.ControllerAddModelAccess, nil
+	case permission.ControllerSuperuserAccess:
+		return params.ControllerSuperuserAccess, nil
+	}
+	return "", errors.Errorf("unsupported controller access permission %v", controllerAccess)
+}
@@ -209,6 +209,29 @@ func (s *controllerSuite) TestInitiateModelMigrationError(c *gc.C) {
 	c.Check(err, gc.ErrorMatches, "unable to read model: .+")
 }
 
+func (s *controllerSuite) TestGrantRevokeController(c *gc.C) {
+	user := s.Factory.MakeUser(c, &factory.UserParams{NoModelUser: true})
+
+	controller := s.OpenAPI(c)
+	err := controller.GrantController(user.Name(), "addmodel")
+	c.Assert(err, jc.ErrorIsNil)
+	access, err := s.State.ControllerAccess(user.UserTag())
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(access, gc.Equals, state.ControllerAddModelAccess)
+
+	err = controller.RevokeController(user.Name(), "addmodel")
+	c.Assert(err, jc.ErrorIsNil)
+	access, err = s.State.ControllerAccess(user.UserTag())
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(access, gc.Equals, state.ControllerLoginAccess)
+}
+
+func (s *controllerSuite) TestGrantControllerInvalidAccess(c *gc.C) {
+	controller := s.OpenAPI(c)
+	err := controller.GrantController("bob", "write")
+	c.Assert(err, gc.ErrorMatches, `invalid controller access permission "write"`)
+}
+
 func randomUUID() string {
 	return utils.MustNewUUID().String()
 }
@@ -22,6 +22,7 @@ var facadeVersions = map[string]int{
 	"AllModelWatcher":              2,
 	"AllWatcher":                   1,
 	"Annotations":                  2,
+	"AuditLog":                     1,
 	"Backups":                      1,
 	"Block":                        2,
 	"CharmRevisionUpdater":         1,
@@ -34,8 +35,9 @@ var facadeVersions = map[string]int{
 	"DiskManager":                  2,
 	"EntityWatcher":                2,
 	"FilesystemAttachmentsWatcher": 2,
-	"Firewaller":                   2,
+	"Firewaller":                   3,
 	"HighAvailability":             2,
+	"HostFirewaller":               1,
 	"HostKeyReporter":              1,
 	"ImageManager":                 2,
 	"ImageMetadata":                2,
@@ -72,7 +74,7 @@ var facadeVersions = map[string]int{
 	"Spaces":                       2,
 	"SSHClient":                    1,
 	"StatusHistory":                2,
-	"Storage":                      2,
+	"Storage":                      3,
 	"StorageProvisioner":           2,
 	"StringsWatcher":               1,
 	"Subnets":                      2,
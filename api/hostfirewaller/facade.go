@@ -0,0 +1,97 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+// Package hostfirewaller implements the client-side API facade used
+// by the hostfirewaller worker.
+package hostfirewaller
+
+import (
+	"github.com/juju/errors"
+	"github.com/juju/names"
+
+	"github.com/juju/juju/api/base"
+	apiwatcher "github.com/juju/juju/api/watcher"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/network"
+	"github.com/juju/juju/watcher"
+)
+
+// Rules holds the rules a machine's host firewall should enforce.
+type Rules struct {
+	// IngressRules holds the rules for the ports of exposed services.
+	IngressRules []network.IngressRule
+
+	// OpenedPorts holds all the port ranges opened by units on the
+	// machine.
+	OpenedPorts []network.PortRange
+
+	// InternalCIDRs holds the addresses of the machines in the model,
+	// which may reach the opened ports whether or not the services are
+	// exposed.
+	InternalCIDRs []string
+}
+
+// Facade provides access to the HostFirewaller API facade.
+type Facade struct {
+	caller base.FacadeCaller
+}
+
+// NewFacade creates a new client-side HostFirewaller facade.
+func NewFacade(caller base.APICaller) *Facade {
+	return &Facade{
+		caller: base.NewFacadeCaller(caller, "HostFirewaller"),
+	}
+}
+
+// WatchHostFirewallRules returns a NotifyWatcher that notifies when
+// the host firewall rules of the machine may have changed.
+func (f *Facade) WatchHostFirewallRules(machineId string) (watcher.NotifyWatcher, error) {
+	args := params.Entities{Entities: []params.Entity{
+		{Tag: names.NewMachineTag(machineId).String()},
+	}}
+	var results params.NotifyWatchResults
+	err := f.caller.FacadeCall("WatchHostFirewallRules", args, &results)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if len(results.Results) != 1 {
+		return nil, errors.Errorf("expected 1 result, got %d", len(results.Results))
+	}
+	result := results.Results[0]
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	return apiwatcher.NewNotifyWatcher(f.caller.RawAPICaller(), result), nil
+}
+
+// HostFirewallRules returns the rules the host firewall of the machine
+// should enforce.
+func (f *Facade) HostFirewallRules(machineId string) (Rules, error) {
+	args := params.Entities{Entities: []params.Entity{
+		{Tag: names.NewMachineTag(machineId).String()},
+	}}
+	var results params.HostFirewallRulesResults
+	err := f.caller.FacadeCall("HostFirewallRules", args, &results)
+	if err != nil {
+		return Rules{}, errors.Trace(err)
+	}
+	if len(results.Results) != 1 {
+		return Rules{}, errors.Errorf("expected 1 result, got %d", len(results.Results))
+	}
+	result := results.Results[0]
+	if result.Error != nil {
+		return Rules{}, result.Error
+	}
+	rules := Rules{
+		IngressRules:  make([]network.IngressRule, len(result.IngressRules)),
+		OpenedPorts:   make([]network.PortRange, len(result.OpenedPorts)),
+		InternalCIDRs: result.InternalCIDRs,
+	}
+	for i, rule := range result.IngressRules {
+		rules.IngressRules[i] = rule.NetworkIngressRule()
+	}
+	for i, portRange := range result.OpenedPorts {
+		rules.OpenedPorts[i] = portRange.NetworkPortRange()
+	}
+	return rules, nil
+}
@@ -0,0 +1,80 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package hostfirewaller_test
+
+import (
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	basetesting "github.com/juju/juju/api/base/testing"
+	"github.com/juju/juju/api/hostfirewaller"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/network"
+)
+
+type facadeSuite struct {
+	testing.IsolationSuite
+}
+
+var _ = gc.Suite(&facadeSuite{})
+
+func (s *facadeSuite) TestHostFirewallRules(c *gc.C) {
+	stub := new(testing.Stub)
+	apiCaller := basetesting.APICallerFunc(func(
+		objType string, version int,
+		id, request string,
+		args, response interface{},
+	) error {
+		c.Check(objType, gc.Equals, "HostFirewaller")
+		c.Check(id, gc.Equals, "")
+		stub.AddCall(request, args)
+		*response.(*params.HostFirewallRulesResults) = params.HostFirewallRulesResults{
+			Results: []params.HostFirewallRulesResult{{
+				IngressRules: []params.IngressRule{{
+					PortRange:  params.PortRange{80, 80, "tcp"},
+					SourceCIDR: "10.0.0.0/8",
+				}},
+				OpenedPorts:   []params.PortRange{{80, 80, "tcp"}},
+				InternalCIDRs: []string{"10.0.0.1/32"},
+			}},
+		}
+		return nil
+	})
+	facade := hostfirewaller.NewFacade(apiCaller)
+
+	rules, err := facade.HostFirewallRules("42")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(rules, jc.DeepEquals, hostfirewaller.Rules{
+		IngressRules: []network.IngressRule{
+			{network.MustParsePortRange("80/tcp"), "10.0.0.0/8"},
+		},
+		OpenedPorts:   []network.PortRange{network.MustParsePortRange("80/tcp")},
+		InternalCIDRs: []string{"10.0.0.1/32"},
+	})
+	stub.CheckCalls(c, []testing.StubCall{{
+		"HostFirewallRules", []interface{}{params.Entities{
+			Entities: []params.Entity{{Tag: "machine-42"}},
+		}},
+	}})
+}
+
+func (s *facadeSuite) TestHostFirewallRulesError(c *gc.C) {
+	apiCaller := basetesting.APICallerFunc(func(
+		objType string, version int,
+		id, request string,
+		args, response interface{},
+	) error {
+		*response.(*params.HostFirewallRulesResults) = params.HostFirewallRulesResults{
+			Results: []params.HostFirewallRulesResult{{
+				Error: &params.Error{Message: "boom"},
+			}},
+		}
+		return nil
+	})
+	facade := hostfirewaller.NewFacade(apiCaller)
+
+	_, err := facade.HostFirewallRules("42")
+	c.Assert(err, gc.ErrorMatches, "boom")
+}
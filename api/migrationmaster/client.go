@@ -4,6 +4,11 @@
 package migrationmaster
 
 import (
+	"io"
+	"net/http"
+	"net/url"
+	"time"
+
 	"github.com/juju/errors"
 	"github.com/juju/names"
 
@@ -29,18 +34,83 @@ type Client interface {
 	// migration.
 	SetPhase(migration.Phase) error
 
+	// SetStatusMessage sets a human readable message regarding the
+	// progress of a migration.
+	SetStatusMessage(string) error
+
+	// ModelInfo returns basic information about the model to be
+	// migrated.
+	ModelInfo() (migration.ModelInfo, error)
+
+	// Prechecks performs pre-migration checks on the model and
+	// source controller. A *migration.PrecheckError is returned if
+	// any of the checks fail.
+	Prechecks() error
+
 	// Export returns a serialized representation of the model
 	// associated with the API connection.
 	Export() ([]byte, error)
+
+	// WatchMinionReports returns a watcher which reports when a
+	// migration minion has made a report for the current migration
+	// phase.
+	WatchMinionReports() (watcher.NotifyWatcher, error)
+
+	// MinionReports returns details of the reports made by migration
+	// minions to the controller for the current migration phase.
+	MinionReports() (MinionReports, error)
+
+	// OpenResource returns a reader for the content of the named
+	// resource of a service in the model being migrated.
+	OpenResource(service, name string) (io.ReadCloser, error)
+
+	// StreamModelLog returns a LogStream which reports the log
+	// records of the model being migrated, starting at the given
+	// time. The stream ends once all existing records have been
+	// sent.
+	StreamModelLog(time.Time) (LogStream, error)
+}
+
+// LogStream allows the log records of a model to be read.
+type LogStream interface {
+	// Next returns the next log record in the stream. io.EOF is
+	// returned once all records have been read.
+	Next() (params.LogRecord, error)
+
+	// Close releases the resources used by the stream.
+	Close() error
 }
 
 // MigrationStatus returns the details for a migration as needed by
 // the migration master worker.
 type MigrationStatus struct {
-	ModelUUID  string
-	Attempt    int
-	Phase      migration.Phase
-	TargetInfo migration.TargetInfo
+	MigrationId string
+	ModelUUID   string
+	Attempt     int
+	Phase       migration.Phase
+	TargetInfo  migration.TargetInfo
+}
+
+// MinionReports returns information about the migration minion
+// reports received so far for a given migration phase.
+type MinionReports struct {
+	// MigrationId holds the id of the migration the reports relate to.
+	MigrationId string
+
+	// Phase indicates the migration phase the reports relate to.
+	Phase migration.Phase
+
+	// SuccessCount indicates how many agents have successfully
+	// completed the migration phase.
+	SuccessCount int
+
+	// Unknown holds the tags of the agents which are still to
+	// report for the migration phase.
+	Unknown []names.Tag
+
+	// Failed holds the tags of the agents which reported that they
+	// failed to complete the migration phase.
+	Failed []names.Tag
 }
 
 // NewClient returns a new Client based on an existing API connection.
@@ -98,9 +168,10 @@ func (c *client) GetMigrationStatus() (MigrationStatus, error) {
 	}
 
 	return MigrationStatus{
-		ModelUUID: modelTag.Id(),
-		Attempt:   status.Attempt,
-		Phase:     phase,
+		MigrationId: status.MigrationId,
+		ModelUUID:   modelTag.Id(),
+		Attempt:     status.Attempt,
+		Phase:       phase,
 		TargetInfo: migration.TargetInfo{
 			ControllerTag: controllerTag,
 			Addrs:         target.Addrs,
@@ -119,6 +190,49 @@ func (c *client) SetPhase(phase migration.Phase) error {
 	return c.caller.FacadeCall("SetPhase", args, nil)
 }
 
+// SetStatusMessage implements Client.
+func (c *client) SetStatusMessage(message string) error {
+	args := params.SetMigrationStatusMessageArgs{
+		Message: message,
+	}
+	return c.caller.FacadeCall("SetStatusMessage", args, nil)
+}
+
+// ModelInfo implements Client.
+func (c *client) ModelInfo() (migration.ModelInfo, error) {
+	var info params.MigrationModelInfo
+	err := c.caller.FacadeCall("ModelInfo", nil, &info)
+	if err != nil {
+		return migration.ModelInfo{}, errors.Trace(err)
+	}
+	owner, err := names.ParseUserTag(info.OwnerTag)
+	if err != nil {
+		return migration.ModelInfo{}, errors.Trace(err)
+	}
+	return migration.ModelInfo{
+		UUID:            info.UUID,
+		Name:            info.Name,
+		Owner:           owner,
+		AgentVersion:    info.AgentVersion,
+		ProviderType:    info.ProviderType,
+		CloudAttrs:      info.CloudAttrs,
+		CredentialAttrs: info.CredentialAttrs,
+	}, nil
+}
+
+// Prechecks implements Client.
+func (c *client) Prechecks() error {
+	var result params.MigrationPrecheckResult
+	err := c.caller.FacadeCall("Prechecks", nil, &result)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if len(result.Failures) > 0 {
+		return &migration.PrecheckError{Reasons: result.Failures}
+	}
+	return nil
+}
+
 // Export implements Client.
 func (c *client) Export() ([]byte, error) {
 	var serialized params.SerializedModel
@@ -128,3 +242,128 @@ func (c *client) Export() ([]byte, error) {
 	}
 	return serialized.Bytes, nil
 }
+
+// WatchMinionReports implements Client.
+func (c *client) WatchMinionReports() (watcher.NotifyWatcher, error) {
+	var result params.NotifyWatchResult
+	err := c.caller.FacadeCall("WatchMinionReports", nil, &result)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	w := apiwatcher.NewNotifyWatcher(c.caller.RawAPICaller(), result)
+	return w, nil
+}
+
+// MinionReports implements Client.
+func (c *client) MinionReports() (MinionReports, error) {
+	var in params.MinionReports
+	var out MinionReports
+
+	err := c.caller.FacadeCall("MinionReports", nil, &in)
+	if err != nil {
+		return out, errors.Trace(err)
+	}
+
+	phase, ok := migration.ParsePhase(in.Phase)
+	if !ok {
+		return out, errors.Errorf("invalid phase: %q", in.Phase)
+	}
+
+	unknown, err := parseAgentTags(in.Unknown)
+	if err != nil {
+		return out, errors.Annotate(err, "processing unknown agents")
+	}
+
+	failed, err := parseAgentTags(in.Failed)
+	if err != nil {
+		return out, errors.Annotate(err, "processing failed agents")
+	}
+
+	out.MigrationId = in.MigrationId
+	out.Phase = phase
+	out.SuccessCount = in.SuccessCount
+	out.Unknown = unknown
+	out.Failed = failed
+	return out, nil
+}
+
+func parseAgentTags(tagStrs []string) ([]names.Tag, error) {
+	var out []names.Tag
+	for _, tagStr := range tagStrs {
+		tag, err := names.ParseTag(tagStr)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		switch tag.(type) {
+		case names.MachineTag, names.UnitTag:
+		default:
+			return nil, errors.Errorf("%s is not an agent", tag)
+		}
+		out = append(out, tag)
+	}
+	return out, nil
+}
+
+// StreamModelLog implements Client.
+func (c *client) StreamModelLog(start time.Time) (LogStream, error) {
+	attrs := url.Values{
+		"format":    {"json"},
+		"replay":    {"true"},
+		"noTail":    {"true"},
+		"startTime": {start.Format(time.RFC3339Nano)},
+	}
+	stream, err := c.caller.RawAPICaller().ConnectStream("/log", attrs)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &logStream{stream}, nil
+}
+
+// logStream implements LogStream.
+type logStream struct {
+	stream base.Stream
+}
+
+// Next implements LogStream.
+func (s *logStream) Next() (params.LogRecord, error) {
+	var record params.LogRecord
+	if err := s.stream.ReadJSON(&record); err != nil {
+		if errors.Cause(err) == io.EOF {
+			return params.LogRecord{}, io.EOF
+		}
+		return params.LogRecord{}, errors.Annotate(err, "reading log record")
+	}
+	return record, nil
+}
+
+// Close implements LogStream.
+func (s *logStream) Close() error {
+	return s.stream.Close()
+}
+
+// OpenResource implements Client.
+func (c *client) OpenResource(service, name string) (io.ReadCloser, error) {
+	query := url.Values{}
+	query.Set("service", service)
+	query.Set("name", name)
+	endpoint := url.URL{
+		Path:     "/migrate/resources",
+		RawQuery: query.Encode(),
+	}
+	req, err := http.NewRequest("GET", endpoint.String(), nil)
+	if err != nil {
+		return nil, errors.Annotate(err, "cannot create resource request")
+	}
+	httpClient, err := c.caller.RawAPICaller().HTTPClient()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	var resp *http.Response
+	if err := httpClient.Do(req, nil, &resp); err != nil {
+		return nil, errors.Annotatef(err, "opening resource %s/%s", service, name)
+	}
+	return resp.Body, nil
+}
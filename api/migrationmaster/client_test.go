@@ -4,15 +4,24 @@
 package migrationmaster_test
 
 import (
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"strings"
 	"time"
 
 	"github.com/juju/errors"
+	"github.com/juju/httprequest"
+	"github.com/juju/loggo"
 	"github.com/juju/names"
 	jujutesting "github.com/juju/testing"
 	jc "github.com/juju/testing/checkers"
 	"github.com/juju/utils"
+	"github.com/juju/version"
 	gc "gopkg.in/check.v1"
 
+	"github.com/juju/juju/api/base"
 	apitesting "github.com/juju/juju/api/base/testing"
 	"github.com/juju/juju/api/migrationmaster"
 	"github.com/juju/juju/apiserver/params"
@@ -101,8 +110,9 @@ func (s *ClientSuite) TestGetMigrationStatus(c *gc.C) {
 					Password:      "secret",
 				},
 			},
-			Attempt: 3,
-			Phase:   "READONLY",
+			MigrationId: "id",
+			Attempt:     3,
+			Phase:       "READONLY",
 		}
 		return nil
 	})
@@ -111,9 +121,10 @@ func (s *ClientSuite) TestGetMigrationStatus(c *gc.C) {
 	status, err := client.GetMigrationStatus()
 	c.Assert(err, jc.ErrorIsNil)
 	c.Assert(status, gc.DeepEquals, migrationmaster.MigrationStatus{
-		ModelUUID: modelUUID,
-		Attempt:   3,
-		Phase:     migration.READONLY,
+		MigrationId: "id",
+		ModelUUID:   modelUUID,
+		Attempt:     3,
+		Phase:       migration.READONLY,
 		TargetInfo: migration.TargetInfo{
 			ControllerTag: names.NewModelTag(controllerUUID),
 			Addrs:         []string{"2.2.2.2:2"},
@@ -173,3 +184,360 @@ func (s *ClientSuite) TestExportError(c *gc.C) {
 	_, err := client.Export()
 	c.Assert(err, gc.ErrorMatches, "blam")
 }
+
+func (s *ClientSuite) TestSetStatusMessage(c *gc.C) {
+	var stub jujutesting.Stub
+	apiCaller := apitesting.APICallerFunc(func(objType string, version int, id, request string, arg, result interface{}) error {
+		stub.AddCall(objType+"."+request, id, arg)
+		return nil
+	})
+	client := migrationmaster.NewClient(apiCaller)
+	err := client.SetStatusMessage("foo")
+	c.Assert(err, jc.ErrorIsNil)
+	expectedArg := params.SetMigrationStatusMessageArgs{Message: "foo"}
+	stub.CheckCalls(c, []jujutesting.StubCall{
+		{"MigrationMaster.SetStatusMessage", []interface{}{"", expectedArg}},
+	})
+}
+
+func (s *ClientSuite) TestSetStatusMessageError(c *gc.C) {
+	apiCaller := apitesting.APICallerFunc(func(string, int, string, string, interface{}, interface{}) error {
+		return errors.New("boom")
+	})
+	client := migrationmaster.NewClient(apiCaller)
+	err := client.SetStatusMessage("foo")
+	c.Assert(err, gc.ErrorMatches, "boom")
+}
+
+func (s *ClientSuite) TestModelInfo(c *gc.C) {
+	var stub jujutesting.Stub
+	owner := names.NewUserTag("owner")
+	apiCaller := apitesting.APICallerFunc(func(objType string, v int, id, request string, arg, result interface{}) error {
+		stub.AddCall(objType+"."+request, id, arg)
+		*(result.(*params.MigrationModelInfo)) = params.MigrationModelInfo{
+			UUID:            "uuid",
+			Name:            "name",
+			OwnerTag:        owner.String(),
+			AgentVersion:    version.MustParse("1.2.3"),
+			ProviderType:    "dummy",
+			CloudAttrs:      map[string]string{"region": "east"},
+			CredentialAttrs: []string{"password", "username"},
+		}
+		return nil
+	})
+	client := migrationmaster.NewClient(apiCaller)
+	model, err := client.ModelInfo()
+	stub.CheckCalls(c, []jujutesting.StubCall{
+		{"MigrationMaster.ModelInfo", []interface{}{"", nil}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(model, gc.DeepEquals, migration.ModelInfo{
+		UUID:            "uuid",
+		Name:            "name",
+		Owner:           owner,
+		AgentVersion:    version.MustParse("1.2.3"),
+		ProviderType:    "dummy",
+		CloudAttrs:      map[string]string{"region": "east"},
+		CredentialAttrs: []string{"password", "username"},
+	})
+}
+
+func (s *ClientSuite) TestPrechecks(c *gc.C) {
+	var stub jujutesting.Stub
+	apiCaller := apitesting.APICallerFunc(func(objType string, version int, id, request string, arg, result interface{}) error {
+		stub.AddCall(objType+"."+request, id, arg)
+		return nil
+	})
+	client := migrationmaster.NewClient(apiCaller)
+	err := client.Prechecks()
+	c.Assert(err, jc.ErrorIsNil)
+	stub.CheckCalls(c, []jujutesting.StubCall{
+		{"MigrationMaster.Prechecks", []interface{}{"", nil}},
+	})
+}
+
+func (s *ClientSuite) TestPrechecksFailures(c *gc.C) {
+	apiCaller := apitesting.APICallerFunc(func(_ string, _ int, _, _ string, _, result interface{}) error {
+		*(result.(*params.MigrationPrecheckResult)) = params.MigrationPrecheckResult{
+			Failures: []string{"machine 0 is dying", "unit foo/0 is dead"},
+		}
+		return nil
+	})
+	client := migrationmaster.NewClient(apiCaller)
+	err := client.Prechecks()
+	c.Assert(err, gc.ErrorMatches, "prechecks failed: machine 0 is dying; unit foo/0 is dead")
+	c.Assert(migration.IsPrecheckError(err), jc.IsTrue)
+}
+
+func (s *ClientSuite) TestPrechecksError(c *gc.C) {
+	apiCaller := apitesting.APICallerFunc(func(string, int, string, string, interface{}, interface{}) error {
+		return errors.New("boom")
+	})
+	client := migrationmaster.NewClient(apiCaller)
+	err := client.Prechecks()
+	c.Assert(err, gc.ErrorMatches, "boom")
+	c.Assert(migration.IsPrecheckError(err), jc.IsFalse)
+}
+
+func (s *ClientSuite) TestWatchMinionReports(c *gc.C) {
+	var stub jujutesting.Stub
+	apiCaller := apitesting.APICallerFunc(func(objType string, version int, id, request string, arg, result interface{}) error {
+		stub.AddCall(objType+"."+request, id, arg)
+		switch request {
+		case "WatchMinionReports":
+			*(result.(*params.NotifyWatchResult)) = params.NotifyWatchResult{
+				NotifyWatcherId: "abc",
+			}
+		case "Next":
+			// The full success case is tested in api/watcher.
+			return errors.New("boom")
+		case "Stop":
+		}
+		return nil
+	})
+
+	client := migrationmaster.NewClient(apiCaller)
+	w, err := client.WatchMinionReports()
+	c.Assert(err, jc.ErrorIsNil)
+	defer worker.Stop(w)
+
+	errC := make(chan error)
+	go func() {
+		errC <- w.Wait()
+	}()
+
+	select {
+	case err := <-errC:
+		c.Assert(err, gc.ErrorMatches, "boom")
+		expectedCalls := []jujutesting.StubCall{
+			{"MigrationMaster.WatchMinionReports", []interface{}{"", nil}},
+			{"NotifyWatcher.Next", []interface{}{"abc", nil}},
+			{"NotifyWatcher.Stop", []interface{}{"abc", nil}},
+		}
+		// The Stop API call happens in a separate goroutine which
+		// might execute after the worker has exited so wait for the
+		// expected calls to arrive.
+		for a := coretesting.LongAttempt.Start(); a.Next(); {
+			if len(stub.Calls()) >= len(expectedCalls) {
+				return
+			}
+		}
+		stub.CheckCalls(c, expectedCalls)
+	case <-time.After(coretesting.LongWait):
+		c.Fatal("timed out waiting for watcher to die")
+	}
+}
+
+func (s *ClientSuite) TestWatchMinionReportsError(c *gc.C) {
+	apiCaller := apitesting.APICallerFunc(func(string, int, string, string, interface{}, interface{}) error {
+		return errors.New("boom")
+	})
+	client := migrationmaster.NewClient(apiCaller)
+	_, err := client.WatchMinionReports()
+	c.Assert(err, gc.ErrorMatches, "boom")
+}
+
+func (s *ClientSuite) TestMinionReports(c *gc.C) {
+	var stub jujutesting.Stub
+	apiCaller := apitesting.APICallerFunc(func(objType string, version int, id, request string, arg, result interface{}) error {
+		stub.AddCall(objType+"."+request, id, arg)
+		out := result.(*params.MinionReports)
+		*out = params.MinionReports{
+			MigrationId:  "id",
+			Phase:        "IMPORT",
+			SuccessCount: 4,
+			Unknown:      []string{"machine-3", "unit-foo-2"},
+			Failed:       []string{"machine-1", "unit-bar-1"},
+		}
+		return nil
+	})
+	client := migrationmaster.NewClient(apiCaller)
+	out, err := client.MinionReports()
+	c.Assert(err, jc.ErrorIsNil)
+	stub.CheckCalls(c, []jujutesting.StubCall{
+		{"MigrationMaster.MinionReports", []interface{}{"", nil}},
+	})
+	c.Assert(out, gc.DeepEquals, migrationmaster.MinionReports{
+		MigrationId:  "id",
+		Phase:        migration.IMPORT,
+		SuccessCount: 4,
+		Unknown: []names.Tag{
+			names.NewMachineTag("3"),
+			names.NewUnitTag("foo/2"),
+		},
+		Failed: []names.Tag{
+			names.NewMachineTag("1"),
+			names.NewUnitTag("bar/1"),
+		},
+	})
+}
+
+func (s *ClientSuite) TestMinionReportsFailedCall(c *gc.C) {
+	apiCaller := apitesting.APICallerFunc(func(string, int, string, string, interface{}, interface{}) error {
+		return errors.New("blam")
+	})
+	client := migrationmaster.NewClient(apiCaller)
+	_, err := client.MinionReports()
+	c.Assert(err, gc.ErrorMatches, "blam")
+}
+
+func (s *ClientSuite) TestMinionReportsInvalidPhase(c *gc.C) {
+	apiCaller := apitesting.APICallerFunc(func(_ string, _ int, _ string, _ string, _ interface{}, result interface{}) error {
+		out := result.(*params.MinionReports)
+		*out = params.MinionReports{
+			Phase: "BLARGH",
+		}
+		return nil
+	})
+	client := migrationmaster.NewClient(apiCaller)
+	_, err := client.MinionReports()
+	c.Assert(err, gc.ErrorMatches, `invalid phase: "BLARGH"`)
+}
+
+func (s *ClientSuite) TestMinionReportsBadUnknownTag(c *gc.C) {
+	apiCaller := apitesting.APICallerFunc(func(_ string, _ int, _ string, _ string, _ interface{}, result interface{}) error {
+		out := result.(*params.MinionReports)
+		*out = params.MinionReports{
+			Phase:   "IMPORT",
+			Unknown: []string{"carl"},
+		}
+		return nil
+	})
+	client := migrationmaster.NewClient(apiCaller)
+	_, err := client.MinionReports()
+	c.Assert(err, gc.ErrorMatches, `processing unknown agents: "carl" is not a valid tag`)
+}
+
+func (s *ClientSuite) TestMinionReportsBadFailedTag(c *gc.C) {
+	apiCaller := apitesting.APICallerFunc(func(_ string, _ int, _ string, _ string, _ interface{}, result interface{}) error {
+		out := result.(*params.MinionReports)
+		*out = params.MinionReports{
+			Phase:  "IMPORT",
+			Failed: []string{"user-bob"},
+		}
+		return nil
+	})
+	client := migrationmaster.NewClient(apiCaller)
+	_, err := client.MinionReports()
+	c.Assert(err, gc.ErrorMatches, `processing failed agents: user-bob is not an agent`)
+}
+
+func (s *ClientSuite) TestStreamModelLog(c *gc.C) {
+	expected := params.LogRecord{
+		Time:     time.Date(2016, 6, 1, 10, 2, 3, 0, time.UTC),
+		Entity:   "machine-0",
+		Module:   "some.where",
+		Location: "foo.go:42",
+		Level:    loggo.INFO,
+		Message:  "all is well",
+	}
+	stream := &fakeStream{records: []params.LogRecord{expected}}
+	caller := fakeConnector{Stub: &jujutesting.Stub{}, stream: stream}
+	client := migrationmaster.NewClient(caller)
+	start := time.Date(2016, 6, 1, 10, 0, 0, 5, time.UTC)
+	logs, err := client.StreamModelLog(start)
+	c.Assert(err, jc.ErrorIsNil)
+
+	caller.Stub.CheckCalls(c, []jujutesting.StubCall{
+		{"ConnectStream", []interface{}{"/log", url.Values{
+			"format":    {"json"},
+			"replay":    {"true"},
+			"noTail":    {"true"},
+			"startTime": {"2016-06-01T10:00:00.000000005Z"},
+		}}},
+	})
+
+	record, err := logs.Next()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(record, gc.DeepEquals, expected)
+	_, err = logs.Next()
+	c.Assert(err, gc.Equals, io.EOF)
+
+	err = logs.Close()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(stream.closed, jc.IsTrue)
+}
+
+func (s *ClientSuite) TestStreamModelLogError(c *gc.C) {
+	caller := fakeConnector{Stub: &jujutesting.Stub{}}
+	caller.Stub.SetErrors(errors.New("no stream for you"))
+	client := migrationmaster.NewClient(caller)
+	logs, err := client.StreamModelLog(time.Time{})
+	c.Assert(logs, gc.IsNil)
+	c.Assert(err, gc.ErrorMatches, "no stream for you")
+}
+
+func (s *ClientSuite) TestOpenResource(c *gc.C) {
+	doer := &fakeDoer{
+		Stub: &jujutesting.Stub{},
+		body: "resource content",
+	}
+	caller := fakeConnector{Stub: &jujutesting.Stub{}, doer: doer}
+	client := migrationmaster.NewClient(caller)
+	r, err := client.OpenResource("mysql", "blob")
+	c.Assert(err, jc.ErrorIsNil)
+	defer r.Close()
+	content, err := ioutil.ReadAll(r)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(string(content), gc.Equals, "resource content")
+	doer.Stub.CheckCalls(c, []jujutesting.StubCall{
+		{"Do", []interface{}{"GET", "/migrate/resources?name=blob&service=mysql"}},
+	})
+}
+
+type fakeConnector struct {
+	base.APICaller
+	*jujutesting.Stub
+	stream base.Stream
+	doer   *fakeDoer
+}
+
+func (c fakeConnector) HTTPClient() (*httprequest.Client, error) {
+	return &httprequest.Client{Doer: c.doer}, nil
+}
+
+func (fakeConnector) BestFacadeVersion(string) int {
+	return 0
+}
+
+func (c fakeConnector) ConnectStream(path string, attrs url.Values) (base.Stream, error) {
+	c.Stub.AddCall("ConnectStream", path, attrs)
+	if err := c.Stub.NextErr(); err != nil {
+		return nil, err
+	}
+	return c.stream, nil
+}
+
+type fakeStream struct {
+	base.Stream
+	records []params.LogRecord
+	closed  bool
+}
+
+func (s *fakeStream) ReadJSON(v interface{}) error {
+	if len(s.records) == 0 {
+		return io.EOF
+	}
+	*(v.(*params.LogRecord)) = s.records[0]
+	s.records = s.records[1:]
+	return nil
+}
+
+func (s *fakeStream) Close() error {
+	s.closed = true
+	return nil
+}
+
+type fakeDoer struct {
+	*jujutesting.Stub
+	body string
+}
+
+func (d *fakeDoer) Do(req *http.Request) (*http.Response, error) {
+	d.Stub.AddCall("Do", req.Method, req.URL.String())
+	return &http.Response{
+		StatusCode: http.StatusOK,
+		Header:     http.Header{"Content-Type": {params.ContentTypeRaw}},
+		Body:       ioutil.NopCloser(strings.NewReader(d.body)),
+	}, nil
+}
@@ -9,6 +9,7 @@ import (
 	"github.com/juju/juju/api/base"
 	apiwatcher "github.com/juju/juju/api/watcher"
 	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/core/migration"
 	"github.com/juju/juju/watcher"
 )
 
@@ -19,6 +20,10 @@ type Client interface {
 	// for the migration for the model associated with the API
 	// connection.
 	Watch() (watcher.MigrationStatusWatcher, error)
+
+	// Report allows a migration minion to report if it successfully
+	// completed its activities for a given migration phase.
+	Report(migrationId string, phase migration.Phase, success bool) error
 }
 
 // NewClient returns a new Client based on an existing API connection.
@@ -44,3 +49,14 @@ func (c *client) Watch() (watcher.MigrationStatusWatcher, error) {
 	w := apiwatcher.NewMigrationStatusWatcher(c.caller.RawAPICaller(), result.NotifyWatcherId)
 	return w, nil
 }
+
+// Report implements Client.
+func (c *client) Report(migrationId string, phase migration.Phase, success bool) error {
+	args := params.MinionReport{
+		MigrationId: migrationId,
+		Phase:       phase.String(),
+		Success:     success,
+	}
+	err := c.caller.FacadeCall("Report", args, nil)
+	return errors.Trace(err)
+}
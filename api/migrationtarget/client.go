@@ -4,16 +4,28 @@
 package migrationtarget
 
 import (
+	"io"
+	"net/http"
+	"net/url"
+	"time"
+
+	"github.com/juju/errors"
 	"github.com/juju/names"
 
 	"github.com/juju/juju/api/base"
 	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/core/migration"
 )
 
 // Client describes the client side API for the MigrationTarget
 // facade. It is called by the migration master worker to talk to the
 // target controller during a migration.
 type Client interface {
+	// Prechecks checks that the target controller is able to accept
+	// the model being migrated. A *migration.PrecheckError is
+	// returned if any of the checks fail.
+	Prechecks(migration.ModelInfo) error
+
 	// Import takes a serialized model and imports it into the target
 	// controller.
 	Import([]byte) error
@@ -24,6 +36,20 @@ type Client interface {
 
 	// Activate marks a migrated model as being ready to use.
 	Activate(string) error
+
+	// LatestLogTime returns the time of the most recent log record
+	// transferred to the target controller for the model. The zero
+	// time is returned if no logs have been transferred.
+	LatestLogTime(string) (time.Time, error)
+
+	// OpenLogTransferStream opens a stream to the target controller
+	// over which the logs of the model being migrated can be sent.
+	OpenLogTransferStream(string) (base.Stream, error)
+
+	// UploadResource sends the content of a resource of a service
+	// in the model being imported. The resource metadata must have
+	// been imported already.
+	UploadResource(modelUUID, service, name string, content io.ReadSeeker) error
 }
 
 // NewClient returns a new Client based on an existing API connection.
@@ -36,6 +62,28 @@ type client struct {
 	caller base.FacadeCaller
 }
 
+// Prechecks implements Client.
+func (c *client) Prechecks(model migration.ModelInfo) error {
+	args := params.MigrationModelInfo{
+		UUID:            model.UUID,
+		Name:            model.Name,
+		OwnerTag:        model.Owner.String(),
+		AgentVersion:    model.AgentVersion,
+		ProviderType:    model.ProviderType,
+		CloudAttrs:      model.CloudAttrs,
+		CredentialAttrs: model.CredentialAttrs,
+	}
+	var result params.MigrationPrecheckResult
+	err := c.caller.FacadeCall("Prechecks", args, &result)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if len(result.Failures) > 0 {
+		return &migration.PrecheckError{Reasons: result.Failures}
+	}
+	return nil
+}
+
 // Import implements Client.
 func (c *client) Import(bytes []byte) error {
 	serialized := params.SerializedModel{Bytes: bytes}
@@ -53,3 +101,55 @@ func (c *client) Activate(modelUUID string) error {
 	args := params.ModelArgs{ModelTag: names.NewModelTag(modelUUID).String()}
 	return c.caller.FacadeCall("Activate", args, nil)
 }
+
+// LatestLogTime implements Client.
+func (c *client) LatestLogTime(modelUUID string) (time.Time, error) {
+	var result time.Time
+	args := params.ModelArgs{ModelTag: names.NewModelTag(modelUUID).String()}
+	err := c.caller.FacadeCall("LatestLogTime", args, &result)
+	if err != nil {
+		return time.Time{}, errors.Trace(err)
+	}
+	return result, nil
+}
+
+// OpenLogTransferStream implements Client.
+func (c *client) OpenLogTransferStream(modelUUID string) (base.Stream, error) {
+	attrs := url.Values{}
+	attrs.Set("model-uuid", modelUUID)
+	stream, err := c.caller.RawAPICaller().ConnectStream("/migrate/logtransfer", attrs)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return stream, nil
+}
+
+// UploadResource implements Client.
+func (c *client) UploadResource(modelUUID, service, name string, content io.ReadSeeker) error {
+	query := url.Values{}
+	query.Set("model-uuid", modelUUID)
+	query.Set("service", service)
+	query.Set("name", name)
+	endpoint := url.URL{
+		Path:     "/migrate/resourceupload",
+		RawQuery: query.Encode(),
+	}
+	req, err := http.NewRequest("PUT", endpoint.String(), nil)
+	if err != nil {
+		return errors.Annotate(err, "cannot create upload request")
+	}
+	req.Header.Set("Content-Type", params.ContentTypeRaw)
+
+	httpClient, err := c.caller.RawAPICaller().HTTPClient()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	var result params.ErrorResult
+	if err := httpClient.Do(req, content, &result); err != nil {
+		return errors.Annotatef(err, "uploading resource %s/%s", service, name)
+	}
+	if result.Error != nil {
+		return errors.Trace(result.Error)
+	}
+	return nil
+}
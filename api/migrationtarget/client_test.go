@@ -4,14 +4,26 @@
 package migrationtarget_test
 
 import (
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
 	"github.com/juju/errors"
+	"github.com/juju/httprequest"
 	"github.com/juju/names"
 	jujutesting "github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	"github.com/juju/version"
 	gc "gopkg.in/check.v1"
 
+	"github.com/juju/juju/api/base"
 	apitesting "github.com/juju/juju/api/base/testing"
 	"github.com/juju/juju/api/migrationtarget"
 	"github.com/juju/juju/apiserver/params"
+	coremigration "github.com/juju/juju/core/migration"
 )
 
 type ClientSuite struct {
@@ -47,7 +59,7 @@ func (s *ClientSuite) TestAbort(c *gc.C) {
 
 	uuid := "fake"
 	err := client.Abort(uuid)
-	s.AssertModelCall(c, stub, names.NewModelTag(uuid), "Abort", err)
+	s.AssertModelCall(c, stub, names.NewModelTag(uuid), "Abort", err, true)
 }
 
 func (s *ClientSuite) TestActivate(c *gc.C) {
@@ -55,13 +67,173 @@ func (s *ClientSuite) TestActivate(c *gc.C) {
 
 	uuid := "fake"
 	err := client.Activate(uuid)
-	s.AssertModelCall(c, stub, names.NewModelTag(uuid), "Activate", err)
+	s.AssertModelCall(c, stub, names.NewModelTag(uuid), "Activate", err, true)
 }
 
-func (s *ClientSuite) AssertModelCall(c *gc.C, stub *jujutesting.Stub, tag names.ModelTag, call string, err error) {
+func (s *ClientSuite) AssertModelCall(c *gc.C, stub *jujutesting.Stub, tag names.ModelTag, call string, err error, expectError bool) {
 	expectedArg := params.ModelArgs{ModelTag: tag.String()}
 	stub.CheckCalls(c, []jujutesting.StubCall{
 		{"MigrationTarget." + call, []interface{}{"", expectedArg}},
 	})
+	if expectError {
+		c.Assert(err, gc.ErrorMatches, "boom")
+	} else {
+		c.Assert(err, jc.ErrorIsNil)
+	}
+}
+
+func (s *ClientSuite) TestPrechecks(c *gc.C) {
+	client, stub := s.getClientAndStub(c)
+
+	ownerTag := names.NewUserTag("owner")
+	vers := version.MustParse("1.2.3")
+
+	err := client.Prechecks(coremigration.ModelInfo{
+		UUID:            "uuid",
+		Owner:           ownerTag,
+		Name:            "model",
+		AgentVersion:    vers,
+		ProviderType:    "dummy",
+		CloudAttrs:      map[string]string{"region": "east"},
+		CredentialAttrs: []string{"password", "username"},
+	})
 	c.Assert(err, gc.ErrorMatches, "boom")
+
+	expectedArg := params.MigrationModelInfo{
+		UUID:            "uuid",
+		Name:            "model",
+		OwnerTag:        ownerTag.String(),
+		AgentVersion:    vers,
+		ProviderType:    "dummy",
+		CloudAttrs:      map[string]string{"region": "east"},
+		CredentialAttrs: []string{"password", "username"},
+	}
+	stub.CheckCalls(c, []jujutesting.StubCall{
+		{"MigrationTarget.Prechecks", []interface{}{"", expectedArg}},
+	})
+}
+
+func (s *ClientSuite) TestPrechecksFailures(c *gc.C) {
+	apiCaller := apitesting.APICallerFunc(func(_ string, _ int, _, _ string, _, result interface{}) error {
+		*(result.(*params.MigrationPrecheckResult)) = params.MigrationPrecheckResult{
+			Failures: []string{"target controller is being upgraded"},
+		}
+		return nil
+	})
+	client := migrationtarget.NewClient(apiCaller)
+	err := client.Prechecks(coremigration.ModelInfo{Owner: names.NewUserTag("owner")})
+	c.Assert(err, gc.ErrorMatches, "prechecks failed: target controller is being upgraded")
+	c.Assert(coremigration.IsPrecheckError(err), gc.Equals, true)
+}
+
+func (s *ClientSuite) TestLatestLogTime(c *gc.C) {
+	var stub jujutesting.Stub
+	t1 := time.Date(2016, 12, 1, 10, 31, 0, 0, time.UTC)
+
+	apiCaller := apitesting.APICallerFunc(func(objType string, version int, id, request string, arg, result interface{}) error {
+		target, ok := result.(*time.Time)
+		c.Assert(ok, jc.IsTrue)
+		*target = t1
+		stub.AddCall(objType+"."+request, id, arg)
+		return nil
+	})
+	client := migrationtarget.NewClient(apiCaller)
+	result, err := client.LatestLogTime("fake")
+
+	c.Assert(result, gc.Equals, t1)
+	s.AssertModelCall(c, &stub, names.NewModelTag("fake"), "LatestLogTime", err, false)
+}
+
+func (s *ClientSuite) TestLatestLogTimeError(c *gc.C) {
+	client, stub := s.getClientAndStub(c)
+	result, err := client.LatestLogTime("fake")
+
+	c.Assert(result, gc.Equals, time.Time{})
+	s.AssertModelCall(c, stub, names.NewModelTag("fake"), "LatestLogTime", err, true)
+}
+
+func (s *ClientSuite) TestOpenLogTransferStream(c *gc.C) {
+	caller := fakeConnector{Stub: &jujutesting.Stub{}}
+	client := migrationtarget.NewClient(caller)
+	stream, err := client.OpenLogTransferStream("bad-dad")
+	c.Assert(stream, gc.IsNil)
+	c.Assert(err, gc.ErrorMatches, "sorry, not implemented")
+	caller.Stub.CheckCalls(c, []jujutesting.StubCall{
+		{"ConnectStream", []interface{}{"/migrate/logtransfer", url.Values{"model-uuid": {"bad-dad"}}}},
+	})
+}
+
+func (s *ClientSuite) TestUploadResource(c *gc.C) {
+	doer := &fakeDoer{Stub: &jujutesting.Stub{}}
+	caller := fakeConnector{Stub: &jujutesting.Stub{}, doer: doer}
+	client := migrationtarget.NewClient(caller)
+	err := client.UploadResource("bad-dad", "mysql", "blob", strings.NewReader("resource content"))
+	c.Assert(err, jc.ErrorIsNil)
+	doer.Stub.CheckCalls(c, []jujutesting.StubCall{{
+		"DoWithBody", []interface{}{
+			"PUT",
+			"/migrate/resourceupload?model-uuid=bad-dad&name=blob&service=mysql",
+			"resource content",
+		},
+	}})
+}
+
+func (s *ClientSuite) TestUploadResourceError(c *gc.C) {
+	doer := &fakeDoer{
+		Stub: &jujutesting.Stub{},
+		body: `{"Error": {"Message": "boom"}}`,
+	}
+	caller := fakeConnector{Stub: &jujutesting.Stub{}, doer: doer}
+	client := migrationtarget.NewClient(caller)
+	err := client.UploadResource("bad-dad", "mysql", "blob", strings.NewReader("resource content"))
+	c.Assert(err, gc.ErrorMatches, "boom")
+}
+
+type fakeConnector struct {
+	base.APICaller
+	*jujutesting.Stub
+	doer *fakeDoer
+}
+
+func (c fakeConnector) HTTPClient() (*httprequest.Client, error) {
+	return &httprequest.Client{Doer: c.doer}, nil
+}
+
+func (fakeConnector) BestFacadeVersion(string) int {
+	return 0
+}
+
+func (c fakeConnector) ConnectStream(path string, attrs url.Values) (base.Stream, error) {
+	c.Stub.AddCall("ConnectStream", path, attrs)
+	return nil, errors.New("sorry, not implemented")
+}
+
+type fakeDoer struct {
+	*jujutesting.Stub
+	body string
+}
+
+func (d *fakeDoer) Do(req *http.Request) (*http.Response, error) {
+	return d.DoWithBody(req, nil)
+}
+
+func (d *fakeDoer) DoWithBody(req *http.Request, body io.ReadSeeker) (*http.Response, error) {
+	var content []byte
+	if body != nil {
+		var err error
+		content, err = ioutil.ReadAll(body)
+		if err != nil {
+			return nil, err
+		}
+	}
+	d.Stub.AddCall("DoWithBody", req.Method, req.URL.String(), string(content))
+	respBody := d.body
+	if respBody == "" {
+		respBody = "{}"
+	}
+	return &http.Response{
+		StatusCode: http.StatusOK,
+		Header:     http.Header{"Content-Type": {params.ContentTypeJSON}},
+		Body:       ioutil.NopCloser(strings.NewReader(respBody)),
+	}, nil
 }
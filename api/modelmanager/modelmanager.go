@@ -133,12 +133,33 @@ func ParseModelAccess(access string) (params.ModelAccessPermission, error) {
 		accessPermission = params.ModelReadAccess
 	case permission.ModelWriteAccess:
 		accessPermission = params.ModelWriteAccess
+	case permission.ModelAdminAccess:
+		accessPermission = params.ModelAdminAccess
 	default:
 		return fail, errors.Errorf("unsupported model access permission %v", modelAccess)
 	}
 	return accessPermission, nil
 }
 
+// ParseServiceAccess parses an access permission argument into a type
+// suitable for making an API facade call.
+func ParseServiceAccess(access string) (params.ServiceAccessPermission, error) {
+	var fail params.ServiceAccessPermission
+
+	serviceAccess, err := permission.ParseServiceAccess(access)
+	if err != nil {
+		return fail, errors.Trace(err)
+	}
+	var accessPermission params.ServiceAccessPermission
+	switch serviceAccess {
+	case permission.ServiceOperateAccess:
+		accessPermission = params.ServiceOperateAccess
+	default:
+		return fail, errors.Errorf("unsupported service access permission %v", serviceAccess)
+	}
+	return accessPermission, nil
+}
+
 // GrantModel grants a user access to the specified models.
 func (c *Client) GrantModel(user, access string, modelUUIDs ...string) error {
 	return c.modifyModelUser(params.GrantModelAccess, user, access, modelUUIDs)
@@ -191,3 +212,55 @@ func (c *Client) modifyModelUser(action params.ModelAction, user, access string,
 	}
 	return result.Combine()
 }
+
+// GrantService grants a user access to the specified services in the
+// model.
+func (c *Client) GrantService(user, access, modelUUID string, services ...string) error {
+	return c.modifyServiceUser(params.GrantModelAccess, user, access, modelUUID, services)
+}
+
+// RevokeService revokes a user's access to the specified services in
+// the model.
+func (c *Client) RevokeService(user, access, modelUUID string, services ...string) error {
+	return c.modifyServiceUser(params.RevokeModelAccess, user, access, modelUUID, services)
+}
+
+func (c *Client) modifyServiceUser(action params.ModelAction, user, access, modelUUID string, services []string) error {
+	var args params.ModifyServiceAccessRequest
+
+	if !names.IsValidUser(user) {
+		return errors.Errorf("invalid username: %q", user)
+	}
+	userTag := names.NewUserTag(user)
+	if !names.IsValidModel(modelUUID) {
+		return errors.Errorf("invalid model: %q", modelUUID)
+	}
+	modelTag := names.NewModelTag(modelUUID)
+
+	accessPermission, err := ParseServiceAccess(access)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	for _, service := range services {
+		if !names.IsValidService(service) {
+			return errors.Errorf("invalid service: %q", service)
+		}
+		args.Changes = append(args.Changes, params.ModifyServiceAccess{
+			UserTag:    userTag.String(),
+			Action:     action,
+			Access:     accessPermission,
+			ModelTag:   modelTag.String(),
+			ServiceTag: names.NewServiceTag(service).String(),
+		})
+	}
+
+	var result params.ErrorResults
+	err = c.facade.FacadeCall("ModifyServiceAccess", args, &result)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if len(result.Results) != len(args.Changes) {
+		return errors.Errorf("expected %d results, got %d", len(args.Changes), len(result.Results))
+	}
+	return result.Combine()
+}
@@ -182,7 +182,17 @@ func (c *Client) AddUnits(service string, numUnits int, placement []*instance.Pl
 
 // DestroyUnits decreases the number of units dedicated to a service.
 func (c *Client) DestroyUnits(unitNames ...string) error {
-	params := params.DestroyServiceUnits{unitNames}
+	params := params.DestroyServiceUnits{UnitNames: unitNames}
+	return c.facade.FacadeCall("DestroyUnits", params, nil)
+}
+
+// DestroyUnitsKeepStorage decreases the number of units dedicated to
+// a service, detaching their storage rather than destroying it.
+func (c *Client) DestroyUnitsKeepStorage(unitNames ...string) error {
+	params := params.DestroyServiceUnits{
+		UnitNames:   unitNames,
+		KeepStorage: true,
+	}
 	return c.facade.FacadeCall("DestroyUnits", params, nil)
 }
 
@@ -217,6 +227,17 @@ func (c *Client) Expose(service string) error {
 	return c.facade.FacadeCall("Expose", params, nil)
 }
 
+// ExposeToCIDRs changes the juju-managed firewall to expose any ports
+// that were also explicitly marked by units as open, but only to
+// traffic from the given source CIDRs.
+func (c *Client) ExposeToCIDRs(service string, cidrs []string) error {
+	params := params.ServiceExpose{
+		ServiceName: service,
+		CIDRs:       cidrs,
+	}
+	return c.facade.FacadeCall("Expose", params, nil)
+}
+
 // Unexpose changes the juju-managed firewall to unexpose any ports that
 // were also explicitly marked by units as open.
 func (c *Client) Unexpose(service string) error {
@@ -232,6 +253,19 @@ func (c *Client) Get(service string) (*params.ServiceGetResults, error) {
 	return &results, err
 }
 
+// ExportBundle returns a bundle, in YAML format, that describes the
+// current model.
+func (c *Client) ExportBundle() (string, error) {
+	var result params.StringResult
+	if err := c.facade.FacadeCall("ExportBundle", nil, &result); err != nil {
+		return "", errors.Trace(err)
+	}
+	if result.Error != nil {
+		return "", result.Error
+	}
+	return result.Result, nil
+}
+
 // Set sets configuration options on a service.
 func (c *Client) Set(service string, options map[string]string) error {
 	p := params.ServiceSet{
@@ -163,3 +163,35 @@ func (s *serviceSuite) TestServiceSetCharm(c *gc.C) {
 	c.Assert(err, jc.ErrorIsNil)
 	c.Assert(called, jc.IsTrue)
 }
+
+func (s *serviceSuite) TestExportBundle(c *gc.C) {
+	var called bool
+	service.PatchFacadeCall(s, s.client, func(request string, a, response interface{}) error {
+		called = true
+		c.Assert(request, gc.Equals, "ExportBundle")
+		c.Assert(a, gc.IsNil)
+		result := response.(*params.StringResult)
+		result.Result = "services: {}\n"
+		return nil
+	})
+	bundle, err := s.client.ExportBundle()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(bundle, gc.Equals, "services: {}\n")
+	c.Assert(called, jc.IsTrue)
+}
+
+func (s *serviceSuite) TestDestroyUnitsKeepStorage(c *gc.C) {
+	var called bool
+	service.PatchFacadeCall(s, s.client, func(request string, a, response interface{}) error {
+		called = true
+		c.Assert(request, gc.Equals, "DestroyUnits")
+		c.Assert(a, jc.DeepEquals, params.DestroyServiceUnits{
+			UnitNames:   []string{"postgresql/0", "postgresql/1"},
+			KeepStorage: true,
+		})
+		return nil
+	})
+	err := s.client.DestroyUnitsKeepStorage("postgresql/0", "postgresql/1")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(called, jc.IsTrue)
+}
@@ -147,3 +147,120 @@ func (c *Client) AddToUnit(storages []params.StorageAddParams) ([]params.ErrorRe
 	}
 	return out.Results, nil
 }
+
+// Attach attaches existing, detached storage instances to the
+// specified unit.
+func (c *Client) Attach(unitId string, storageIds []string) ([]params.ErrorResult, error) {
+	in := params.StorageAttachmentIds{
+		Ids: make([]params.StorageAttachmentId, len(storageIds)),
+	}
+	unitTag := names.NewUnitTag(unitId).String()
+	for i, storageId := range storageIds {
+		in.Ids[i] = params.StorageAttachmentId{
+			StorageTag: names.NewStorageTag(storageId).String(),
+			UnitTag:    unitTag,
+		}
+	}
+	out := params.ErrorResults{}
+	if err := c.facade.FacadeCall("Attach", in, &out); err != nil {
+		return nil, errors.Trace(err)
+	}
+	return out.Results, nil
+}
+
+// Detach detaches the specified storage instances from the units that
+// own them, without destroying them.
+func (c *Client) Detach(storageIds []string) ([]params.ErrorResult, error) {
+	in := params.StorageAttachmentIds{
+		Ids: make([]params.StorageAttachmentId, len(storageIds)),
+	}
+	for i, storageId := range storageIds {
+		in.Ids[i] = params.StorageAttachmentId{
+			StorageTag: names.NewStorageTag(storageId).String(),
+		}
+	}
+	out := params.ErrorResults{}
+	if err := c.facade.FacadeCall("Detach", in, &out); err != nil {
+		return nil, errors.Trace(err)
+	}
+	return out.Results, nil
+}
+
+// Import imports a volume that already exists in the cloud into the
+// model, creating a storage instance with the given storage name that
+// may then be attached to a unit.
+func (c *Client) Import(pool, providerId, storageName string) (names.StorageTag, error) {
+	in := params.BulkImportStorageParams{[]params.ImportStorageParams{{
+		Pool:        pool,
+		ProviderId:  providerId,
+		StorageName: storageName,
+	}}}
+	var out params.ImportStorageResults
+	if err := c.facade.FacadeCall("Import", in, &out); err != nil {
+		return names.StorageTag{}, errors.Trace(err)
+	}
+	if len(out.Results) != 1 {
+		return names.StorageTag{}, errors.Errorf("expected 1 result, got %d", len(out.Results))
+	}
+	if err := out.Results[0].Error; err != nil {
+		return names.StorageTag{}, errors.Trace(err)
+	}
+	return names.ParseStorageTag(out.Results[0].Result.StorageTag)
+}
+
+// CreateSnapshots creates snapshots of the volumes backing the specified
+// storage instances.
+func (c *Client) CreateSnapshots(storageIds []string) ([]params.VolumeSnapshotResult, error) {
+	in := params.Entities{
+		Entities: make([]params.Entity, len(storageIds)),
+	}
+	for i, storageId := range storageIds {
+		in.Entities[i].Tag = names.NewStorageTag(storageId).String()
+	}
+	var out params.VolumeSnapshotResults
+	if err := c.facade.FacadeCall("CreateSnapshots", in, &out); err != nil {
+		return nil, errors.Trace(err)
+	}
+	if len(out.Results) != len(storageIds) {
+		return nil, errors.Errorf("expected %d result(s), got %d", len(storageIds), len(out.Results))
+	}
+	return out.Results, nil
+}
+
+// ListSnapshots returns the details of all volume snapshots in the
+// model.
+func (c *Client) ListSnapshots() ([]params.VolumeSnapshotDetails, error) {
+	var out params.VolumeSnapshotDetailsList
+	if err := c.facade.FacadeCall("ListSnapshots", nil, &out); err != nil {
+		return nil, errors.Trace(err)
+	}
+	return out.Snapshots, nil
+}
+
+// DestroySnapshots destroys the volume snapshots with the specified IDs,
+// and removes them from the model.
+func (c *Client) DestroySnapshots(ids []string) ([]params.ErrorResult, error) {
+	in := params.VolumeSnapshotIds{Ids: ids}
+	var out params.ErrorResults
+	if err := c.facade.FacadeCall("DestroySnapshots", in, &out); err != nil {
+		return nil, errors.Trace(err)
+	}
+	if len(out.Results) != len(ids) {
+		return nil, errors.Errorf("expected %d result(s), got %d", len(ids), len(out.Results))
+	}
+	return out.Results, nil
+}
+
+// Resize requests that the volume backing the specified storage
+// instance be grown to the given size, in MiB.
+func (c *Client) Resize(storageId string, size uint64) error {
+	in := params.BulkResizeStorageParams{[]params.ResizeStorageParams{{
+		StorageTag: names.NewStorageTag(storageId).String(),
+		Size:       size,
+	}}}
+	var out params.ErrorResults
+	if err := c.facade.FacadeCall("Resize", in, &out); err != nil {
+		return errors.Trace(err)
+	}
+	return out.OneError()
+}
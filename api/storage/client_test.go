@@ -581,3 +581,248 @@ func (s *storageMockSuite) TestAddToUnitFacadeCallError(c *gc.C) {
 	c.Assert(errors.Cause(err), gc.ErrorMatches, msg)
 	c.Assert(found, gc.HasLen, 0)
 }
+
+func (s *storageMockSuite) TestAttach(c *gc.C) {
+	apiCaller := basetesting.APICallerFunc(
+		func(objType string,
+			version int,
+			id, request string,
+			a, result interface{},
+		) error {
+			c.Check(objType, gc.Equals, "Storage")
+			c.Check(id, gc.Equals, "")
+			c.Check(request, gc.Equals, "Attach")
+			c.Check(a, jc.DeepEquals, params.StorageAttachmentIds{[]params.StorageAttachmentId{
+				{StorageTag: "storage-data-0", UnitTag: "unit-mysql-1"},
+				{StorageTag: "storage-logs-1", UnitTag: "unit-mysql-1"},
+			}})
+			c.Assert(result, gc.FitsTypeOf, &params.ErrorResults{})
+			*(result.(*params.ErrorResults)) = params.ErrorResults{
+				Results: []params.ErrorResult{
+					{},
+					{Error: &params.Error{Message: "boom"}},
+				},
+			}
+			return nil
+		})
+	storageClient := storage.NewClient(apiCaller)
+	results, err := storageClient.Attach("mysql/1", []string{"data/0", "logs/1"})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, jc.DeepEquals, []params.ErrorResult{
+		{},
+		{Error: &params.Error{Message: "boom"}},
+	})
+}
+
+func (s *storageMockSuite) TestDetach(c *gc.C) {
+	apiCaller := basetesting.APICallerFunc(
+		func(objType string,
+			version int,
+			id, request string,
+			a, result interface{},
+		) error {
+			c.Check(objType, gc.Equals, "Storage")
+			c.Check(id, gc.Equals, "")
+			c.Check(request, gc.Equals, "Detach")
+			c.Check(a, jc.DeepEquals, params.StorageAttachmentIds{[]params.StorageAttachmentId{
+				{StorageTag: "storage-data-0"},
+			}})
+			c.Assert(result, gc.FitsTypeOf, &params.ErrorResults{})
+			*(result.(*params.ErrorResults)) = params.ErrorResults{
+				Results: []params.ErrorResult{{}},
+			}
+			return nil
+		})
+	storageClient := storage.NewClient(apiCaller)
+	results, err := storageClient.Detach([]string{"data/0"})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, jc.DeepEquals, []params.ErrorResult{{}})
+}
+
+func (s *storageMockSuite) TestImport(c *gc.C) {
+	apiCaller := basetesting.APICallerFunc(
+		func(objType string,
+			version int,
+			id, request string,
+			a, result interface{},
+		) error {
+			c.Check(objType, gc.Equals, "Storage")
+			c.Check(id, gc.Equals, "")
+			c.Check(request, gc.Equals, "Import")
+			c.Check(a, jc.DeepEquals, params.BulkImportStorageParams{[]params.ImportStorageParams{{
+				Pool:        "ebs",
+				ProviderId:  "vol-123",
+				StorageName: "pgdata",
+			}}})
+			c.Assert(result, gc.FitsTypeOf, &params.ImportStorageResults{})
+			*(result.(*params.ImportStorageResults)) = params.ImportStorageResults{
+				Results: []params.ImportStorageResult{{
+					Result: &params.ImportStorageDetails{StorageTag: "storage-pgdata-0"},
+				}},
+			}
+			return nil
+		})
+	storageClient := storage.NewClient(apiCaller)
+	storageTag, err := storageClient.Import("ebs", "vol-123", "pgdata")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(storageTag, gc.Equals, names.NewStorageTag("pgdata/0"))
+}
+
+func (s *storageMockSuite) TestImportError(c *gc.C) {
+	apiCaller := basetesting.APICallerFunc(
+		func(objType string,
+			version int,
+			id, request string,
+			a, result interface{},
+		) error {
+			*(result.(*params.ImportStorageResults)) = params.ImportStorageResults{
+				Results: []params.ImportStorageResult{{
+					Error: &params.Error{Message: "volume is in use"},
+				}},
+			}
+			return nil
+		})
+	storageClient := storage.NewClient(apiCaller)
+	_, err := storageClient.Import("ebs", "vol-123", "pgdata")
+	c.Assert(err, gc.ErrorMatches, "volume is in use")
+}
+
+func (s *storageMockSuite) TestCreateSnapshots(c *gc.C) {
+	apiCaller := basetesting.APICallerFunc(
+		func(objType string,
+			version int,
+			id, request string,
+			a, result interface{},
+		) error {
+			c.Check(objType, gc.Equals, "Storage")
+			c.Check(id, gc.Equals, "")
+			c.Check(request, gc.Equals, "CreateSnapshots")
+			c.Check(a, jc.DeepEquals, params.Entities{[]params.Entity{
+				{Tag: "storage-data-0"},
+				{Tag: "storage-data-1"},
+			}})
+			c.Assert(result, gc.FitsTypeOf, &params.VolumeSnapshotResults{})
+			*(result.(*params.VolumeSnapshotResults)) = params.VolumeSnapshotResults{
+				Results: []params.VolumeSnapshotResult{{
+					Result: &params.VolumeSnapshotDetails{Id: "0", SnapshotId: "snap-0"},
+				}, {
+					Error: &params.Error{Message: "volume-1 not provisioned"},
+				}},
+			}
+			return nil
+		})
+	storageClient := storage.NewClient(apiCaller)
+	results, err := storageClient.CreateSnapshots([]string{"data/0", "data/1"})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, jc.DeepEquals, []params.VolumeSnapshotResult{{
+		Result: &params.VolumeSnapshotDetails{Id: "0", SnapshotId: "snap-0"},
+	}, {
+		Error: &params.Error{Message: "volume-1 not provisioned"},
+	}})
+}
+
+func (s *storageMockSuite) TestCreateSnapshotsArity(c *gc.C) {
+	apiCaller := basetesting.APICallerFunc(
+		func(objType string,
+			version int,
+			id, request string,
+			a, result interface{},
+		) error {
+			return nil
+		})
+	storageClient := storage.NewClient(apiCaller)
+	_, err := storageClient.CreateSnapshots([]string{"data/0"})
+	c.Assert(err, gc.ErrorMatches, `expected 1 result\(s\), got 0`)
+}
+
+func (s *storageMockSuite) TestListSnapshots(c *gc.C) {
+	apiCaller := basetesting.APICallerFunc(
+		func(objType string,
+			version int,
+			id, request string,
+			a, result interface{},
+		) error {
+			c.Check(objType, gc.Equals, "Storage")
+			c.Check(id, gc.Equals, "")
+			c.Check(request, gc.Equals, "ListSnapshots")
+			c.Check(a, gc.IsNil)
+			c.Assert(result, gc.FitsTypeOf, &params.VolumeSnapshotDetailsList{})
+			*(result.(*params.VolumeSnapshotDetailsList)) = params.VolumeSnapshotDetailsList{
+				Snapshots: []params.VolumeSnapshotDetails{{Id: "0", SnapshotId: "snap-0"}},
+			}
+			return nil
+		})
+	storageClient := storage.NewClient(apiCaller)
+	snapshots, err := storageClient.ListSnapshots()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(snapshots, jc.DeepEquals, []params.VolumeSnapshotDetails{{Id: "0", SnapshotId: "snap-0"}})
+}
+
+func (s *storageMockSuite) TestDestroySnapshots(c *gc.C) {
+	apiCaller := basetesting.APICallerFunc(
+		func(objType string,
+			version int,
+			id, request string,
+			a, result interface{},
+		) error {
+			c.Check(objType, gc.Equals, "Storage")
+			c.Check(id, gc.Equals, "")
+			c.Check(request, gc.Equals, "DestroySnapshots")
+			c.Check(a, jc.DeepEquals, params.VolumeSnapshotIds{Ids: []string{"0", "1"}})
+			c.Assert(result, gc.FitsTypeOf, &params.ErrorResults{})
+			*(result.(*params.ErrorResults)) = params.ErrorResults{
+				Results: []params.ErrorResult{{}, {Error: &params.Error{Message: "snapshot is busy"}}},
+			}
+			return nil
+		})
+	storageClient := storage.NewClient(apiCaller)
+	results, err := storageClient.DestroySnapshots([]string{"0", "1"})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, jc.DeepEquals, []params.ErrorResult{
+		{}, {Error: &params.Error{Message: "snapshot is busy"}},
+	})
+}
+
+func (s *storageMockSuite) TestResize(c *gc.C) {
+	apiCaller := basetesting.APICallerFunc(
+		func(objType string,
+			version int,
+			id, request string,
+			a, result interface{},
+		) error {
+			c.Check(objType, gc.Equals, "Storage")
+			c.Check(id, gc.Equals, "")
+			c.Check(request, gc.Equals, "Resize")
+			c.Check(a, jc.DeepEquals, params.BulkResizeStorageParams{[]params.ResizeStorageParams{{
+				StorageTag: "storage-data-0",
+				Size:       2048,
+			}}})
+			c.Assert(result, gc.FitsTypeOf, &params.ErrorResults{})
+			*(result.(*params.ErrorResults)) = params.ErrorResults{
+				Results: []params.ErrorResult{{}},
+			}
+			return nil
+		})
+	storageClient := storage.NewClient(apiCaller)
+	err := storageClient.Resize("data/0", 2048)
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *storageMockSuite) TestResizeError(c *gc.C) {
+	apiCaller := basetesting.APICallerFunc(
+		func(objType string,
+			version int,
+			id, request string,
+			a, result interface{},
+		) error {
+			*(result.(*params.ErrorResults)) = params.ErrorResults{
+				Results: []params.ErrorResult{{
+					Error: &params.Error{Message: "volume-0 not provisioned"},
+				}},
+			}
+			return nil
+		})
+	storageClient := storage.NewClient(apiCaller)
+	err := storageClient.Resize("data/0", 2048)
+	c.Assert(err, gc.ErrorMatches, "volume-0 not provisioned")
+}
@@ -217,8 +217,9 @@ func (st *State) Action(tag names.ActionTag) (*Action, error) {
 		return nil, err
 	}
 	return &Action{
-		name:   result.Action.Name,
-		params: result.Action.Parameters,
+		name:    result.Action.Name,
+		params:  result.Action.Parameters,
+		timeout: result.Action.Timeout,
 	}, nil
 }
 
@@ -117,6 +117,13 @@ func (a *ActionAPI) Enqueue(arg params.Actions) (params.ActionResults, error) {
 		return params.ActionResults{}, errors.Trace(err)
 	}
 
+	// Users with read only access to the model may only run actions
+	// on the units of services they have been granted operate access to.
+	canOperate, err := common.AuthServiceOperator(a.state, a.authorizer)()
+	if err != nil {
+		return params.ActionResults{}, errors.Trace(err)
+	}
+
 	tagToActionReceiver := common.TagToActionReceiverFn(a.state.FindEntity)
 	response := params.ActionResults{Results: make([]params.ActionResult, len(arg.Actions))}
 	for i, action := range arg.Actions {
@@ -126,7 +133,11 @@ func (a *ActionAPI) Enqueue(arg params.Actions) (params.ActionResults, error) {
 			currentResult.Error = common.ServerError(err)
 			continue
 		}
-		enqueued, err := receiver.AddAction(action.Name, action.Parameters)
+		if !canOperate(receiver.Tag()) {
+			currentResult.Error = common.ServerError(common.ErrPerm)
+			continue
+		}
+		enqueued, err := receiver.AddActionWithTimeout(action.Name, action.Parameters, action.Timeout)
 		if err != nil {
 			currentResult.Error = common.ServerError(err)
 			continue
@@ -165,7 +176,9 @@ func (a *ActionAPI) ListCompleted(arg params.Entities) (params.ActionsByReceiver
 	return a.internalList(arg, completedActions)
 }
 
-// Cancel attempts to cancel enqueued Actions from running.
+// Cancel attempts to cancel enqueued Actions from running. Pending
+// Actions are cancelled immediately; running Actions are marked as
+// aborting, and are stopped by their receivers.
 func (a *ActionAPI) Cancel(arg params.Entities) (params.ActionResults, error) {
 	if err := a.check.ChangeAllowed(); err != nil {
 		return params.ActionResults{}, errors.Trace(err)
@@ -189,7 +202,7 @@ func (a *ActionAPI) Cancel(arg params.Entities) (params.ActionResults, error) {
 			currentResult.Error = common.ServerError(err)
 			continue
 		}
-		result, err := action.Finish(state.ActionResults{Status: state.ActionCancelled, Message: "action cancelled via the API"})
+		result, err := action.Cancel()
 		if err != nil {
 			currentResult.Error = common.ServerError(err)
 			continue
@@ -277,6 +277,36 @@ func (s *actionSuite) TestEnqueue(c *gc.C) {
 	c.Assert(actions, gc.HasLen, 0)
 }
 
+func (s *actionSuite) TestEnqueueServiceOperator(c *gc.C) {
+	factory := jujuFactory.NewFactory(s.State)
+	user := factory.MakeModelUser(c, &jujuFactory.ModelUserParams{Access: state.ModelReadAccess})
+	err := s.State.SetServiceAccess("wordpress", user.UserTag(), state.ServiceOperateAccess, s.AdminUserTag(c))
+	c.Assert(err, jc.ErrorIsNil)
+	actionAPI, err := action.NewActionAPI(s.State, nil, apiservertesting.FakeAuthorizer{
+		Tag: user.UserTag(),
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	arg := params.Actions{
+		Actions: []params.Action{
+			{Receiver: s.wordpressUnit.Tag().String(), Name: "fakeaction"},
+			{Receiver: s.mysqlUnit.Tag().String(), Name: "fakeaction"},
+		},
+	}
+	res, err := actionAPI.Enqueue(arg)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(res.Results, gc.HasLen, 2)
+	c.Assert(res.Results[0].Error, gc.IsNil)
+	c.Assert(res.Results[1].Error, gc.DeepEquals, &params.Error{
+		Message: "permission denied",
+		Code:    params.CodeUnauthorized,
+	})
+
+	actions, err := s.mysqlUnit.Actions()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(actions, gc.HasLen, 0)
+}
+
 type testCaseAction struct {
 	Name       string
 	Parameters map[string]interface{}
@@ -634,6 +664,32 @@ func (s *actionSuite) TestCancel(c *gc.C) {
 	c.Assert(myActions[1].Status, gc.Equals, params.ActionCancelled)
 }
 
+func (s *actionSuite) TestCancelRunning(c *gc.C) {
+	results, err := s.action.Enqueue(params.Actions{
+		Actions: []params.Action{{
+			Receiver: s.wordpressUnit.Tag().String(),
+			Name:     "fakeaction",
+		}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results, gc.HasLen, 1)
+	c.Assert(results.Results[0].Error, gc.IsNil)
+	actionTag, err := names.ParseActionTag(results.Results[0].Action.Tag)
+	c.Assert(err, jc.ErrorIsNil)
+	action, err := s.State.ActionByTag(actionTag)
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = action.Begin()
+	c.Assert(err, jc.ErrorIsNil)
+
+	results, err = s.action.Cancel(params.Entities{
+		Entities: []params.Entity{{Tag: actionTag.String()}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results, gc.HasLen, 1)
+	c.Assert(results.Results[0].Error, gc.IsNil)
+	c.Assert(results.Results[0].Status, gc.Equals, params.ActionAborting)
+}
+
 func (s *actionSuite) TestServicesCharmActions(c *gc.C) {
 	actionSchemas := map[string]map[string]interface{}{
 		"snapshot": {
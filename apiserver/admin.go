@@ -78,7 +78,8 @@ func (a *admin) doLogin(req params.LoginRequest, loginVersion int) (params.Login
 		kind, err = names.TagKind(req.AuthTag)
 		if err != nil || kind != names.UserTagKind {
 			isUser = false
-			// Users are not rate limited, all other entities are.
+			// Agents are limited separately from users, so that
+			// agents reconnecting en masse cannot lock users out.
 			if !a.srv.limiter.Acquire() {
 				logger.Debugf("rate limiting for agent %s", req.AuthTag)
 				return fail, common.ErrTryAgain
@@ -86,6 +87,28 @@ func (a *admin) doLogin(req params.LoginRequest, loginVersion int) (params.Login
 			defer a.srv.limiter.Release()
 		}
 	}
+	// userTag is nil if the user is not yet known, as when logging
+	// in with a macaroon.
+	var userTag *names.UserTag
+	if isUser {
+		userName := ""
+		if req.AuthTag != "" {
+			tag, err := names.ParseUserTag(req.AuthTag)
+			if err != nil {
+				return fail, errors.Trace(err)
+			}
+			userTag = &tag
+			userName = tag.Canonical()
+		}
+		if !a.srv.userLimiter.Acquire() {
+			logger.Debugf("rate limiting for user %s", req.AuthTag)
+			return fail, common.ErrTryAgain
+		}
+		defer a.srv.userLimiter.Release()
+		if err := a.srv.loginThrottle.check(a.root.remoteAddr, userName); err != nil {
+			return fail, errors.Trace(err)
+		}
+	}
 
 	serverOnlyLogin := a.root.modelUUID == ""
 
@@ -107,6 +130,9 @@ func (a *admin) doLogin(req params.LoginRequest, loginVersion int) (params.Login
 			// logins with a more helpful one.
 			return fail, MaintenanceNoLoginError
 		}
+		if isUser && errors.Cause(err) == common.ErrBadCreds {
+			a.recordFailedLogin(userTag)
+		}
 		// Here we have a special case.  The machine agents that manage
 		// models in the controller model need to be able to
 		// open API connections to other models.  In those cases, we
@@ -129,10 +155,25 @@ func (a *admin) doLogin(req params.LoginRequest, loginVersion int) (params.Login
 		// worker for the controller model.
 		agentPingerNeeded = false
 	}
+	if tag, ok := entity.Tag().(names.UserTag); ok && tag.IsLocal() {
+		// Local users may only log in while they have access to
+		// the controller.
+		if _, err := a.srv.state.ControllerAccess(tag); errors.IsNotFound(err) {
+			return fail, errors.Trace(common.ErrPerm)
+		} else if err != nil {
+			return fail, errors.Trace(err)
+		}
+	}
 	a.root.entity = entity
+	if tag, ok := entity.Tag().(names.UserTag); ok {
+		a.srv.loginThrottle.succeeded(tag.Canonical())
+	}
 
 	if a.reqNotifier != nil {
 		a.reqNotifier.login(entity.Tag().String())
+		if userTag, ok := entity.Tag().(names.UserTag); ok {
+			a.reqNotifier.audit(newAuditor(a.srv.state, a.srv.clock, userTag, a.root.state.ModelUUID()))
+		}
 	}
 
 	// We have authenticated the user; enable the appropriate API
@@ -209,6 +250,31 @@ func (a *admin) doLogin(req params.LoginRequest, loginVersion int) (params.Login
 	return loginResult, nil
 }
 
+// recordFailedLogin records a failed attempt to log in as the user,
+// so that repeated failures lock out the user and the client's
+// address, and so that the attempt is reported with the user's
+// details.
+func (a *admin) recordFailedLogin(userTag *names.UserTag) {
+	if userTag == nil {
+		a.srv.loginThrottle.failed(a.root.remoteAddr, "")
+		return
+	}
+	a.srv.loginThrottle.failed(a.root.remoteAddr, userTag.Canonical())
+	if !userTag.IsLocal() {
+		return
+	}
+	user, err := a.srv.state.User(*userTag)
+	if errors.IsNotFound(err) {
+		return
+	} else if err != nil {
+		logger.Warningf("cannot record failed login for %s: %v", userTag.Canonical(), err)
+		return
+	}
+	if err := user.RecordFailedLogin(sourceHost(a.root.remoteAddr)); err != nil {
+		logger.Warningf("cannot record failed login for %s: %v", userTag.Canonical(), err)
+	}
+}
+
 // checkCredsOfControllerMachine checks the special case of a controller
 // machine creating an API connection for a different model so it can
 // run API workers for that model to do things like provisioning
@@ -0,0 +1,36 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package apiserver
+
+import (
+	"github.com/juju/utils/set"
+)
+
+// adminOnlyCalls specify the API calls that may only be made by users
+// with admin access to the model; users with write access may make
+// any other call. The format of the calls is "<facade>.<method>".
+// At this stage, we are explicitly ignoring the facade version.
+var adminOnlyCalls = set.NewStrings(
+	"Backups.Create",
+	"Backups.FinishRestore",
+	"Backups.PrepareRestore",
+	"Backups.Remove",
+	"Backups.Restore",
+	"Block.SwitchBlockOff",
+	"Block.SwitchBlockOn",
+	"Client.AbortCurrentUpgrade",
+	"Client.DestroyModel",
+	"Client.ModelSet",
+	"Client.ModelUnset",
+	"Client.SetModelAgentVersion",
+	"KeyManager.AddKeys",
+	"KeyManager.DeleteKeys",
+	"KeyManager.ImportKeys",
+)
+
+// isCallAdminOnly returns whether or not the method on the facade
+// requires admin access to the model.
+func isCallAdminOnly(facade, method string) bool {
+	return adminOnlyCalls.Contains(facade + "." + method)
+}
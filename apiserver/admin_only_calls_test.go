@@ -0,0 +1,58 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package apiserver
+
+import (
+	"strings"
+
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/apiserver/common"
+)
+
+type adminOnlyCallsSuite struct {
+}
+
+var _ = gc.Suite(&adminOnlyCallsSuite{})
+
+func (*adminOnlyCallsSuite) TestAdminOnlyCallsExist(c *gc.C) {
+	// Iterate through the list of adminOnlyCalls and make sure
+	// that the facades are reachable.
+	maxVersion := map[string]int{}
+	for _, facade := range common.Facades.List() {
+		for _, ver := range facade.Versions {
+			if ver > maxVersion[facade.Name] {
+				maxVersion[facade.Name] = ver
+			}
+		}
+	}
+
+	for _, name := range adminOnlyCalls.Values() {
+		parts := strings.Split(name, ".")
+		facade, method := parts[0], parts[1]
+		_, _, err := lookupMethod(facade, maxVersion[facade], method)
+		c.Check(err, jc.ErrorIsNil)
+	}
+}
+
+func (*adminOnlyCallsSuite) TestAdminOnlyCallsAreNotReadOnly(c *gc.C) {
+	for _, name := range adminOnlyCalls.Values() {
+		c.Check(readOnlyCalls.Contains(name), jc.IsFalse, gc.Commentf("%s", name))
+	}
+}
+
+func (*adminOnlyCallsSuite) TestWriteCalls(c *gc.C) {
+	for _, test := range []struct {
+		facade string
+		method string
+	}{
+		{"Action", "Enqueue"},
+		{"Client", "AddMachines"},
+		{"Service", "Deploy"},
+	} {
+		c.Logf("check %s.%s", test.facade, test.method)
+		c.Check(isCallAdminOnly(test.facade, test.method), jc.IsFalse)
+	}
+}
@@ -151,6 +151,37 @@ func (s *loginSuite) TestBadLogin(c *gc.C) {
 	}
 }
 
+func (s *loginSuite) TestFailedLoginsLockOutUser(c *gc.C) {
+	info, cleanup := s.setupServerWithValidator(c, nil)
+	defer cleanup()
+
+	st := s.openAPIWithoutLogin(c, info)
+	defer st.Close()
+	u := s.Factory.MakeUser(c, &factory.UserParams{Password: "password"})
+
+	badCreds := &rpc.RequestError{
+		Message: "invalid entity name or password",
+		Code:    "unauthorized access",
+	}
+	for i := 0; i < 5; i++ {
+		err := st.Login(u.Tag(), "wrong password", "", nil)
+		c.Assert(errors.Cause(err), gc.DeepEquals, badCreds)
+	}
+
+	// The user is now locked out, even with the right password.
+	err := st.Login(u.Tag(), "password", "", nil)
+	c.Assert(errors.Cause(err), gc.DeepEquals, &rpc.RequestError{
+		Message: "too many failed login attempts; try again later",
+		Code:    "unauthorized access",
+	})
+
+	// The failures are recorded against the user.
+	failures, err := u.FailedLogins()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(failures, gc.HasLen, 5)
+	c.Assert(failures[0].RemoteAddress, gc.Equals, "127.0.0.1")
+}
+
 func (s *loginSuite) TestLoginAsDeactivatedUser(c *gc.C) {
 	info, cleanup := s.setupServerWithValidator(c, nil)
 	defer cleanup()
@@ -180,6 +211,24 @@ func (s *loginSuite) TestLoginAsDeactivatedUser(c *gc.C) {
 	})
 }
 
+func (s *loginSuite) TestLoginWithoutControllerAccess(c *gc.C) {
+	info, cleanup := s.setupServerWithValidator(c, nil)
+	defer cleanup()
+
+	st := s.openAPIWithoutLogin(c, info)
+	defer st.Close()
+	password := "password"
+	u := s.Factory.MakeUser(c, &factory.UserParams{Password: password})
+	err := s.State.RemoveControllerAccess(u.UserTag())
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = st.Login(u.Tag(), password, "", nil)
+	c.Assert(errors.Cause(err), gc.DeepEquals, &rpc.RequestError{
+		Message: "permission denied",
+		Code:    "unauthorized access",
+	})
+}
+
 func (s *baseLoginSuite) runLoginSetsLogIdentifier(c *gc.C) {
 	info, cleanup := s.setupServerWithValidator(c, nil)
 	defer cleanup()
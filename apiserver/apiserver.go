@@ -18,6 +18,7 @@ import (
 	"github.com/juju/loggo"
 	"github.com/juju/names"
 	"github.com/juju/utils"
+	"github.com/juju/utils/clock"
 	"golang.org/x/net/websocket"
 	"launchpad.net/tomb"
 
@@ -27,12 +28,13 @@ import (
 	"github.com/juju/juju/rpc"
 	"github.com/juju/juju/rpc/jsoncodec"
 	"github.com/juju/juju/state"
+	"github.com/juju/juju/utils/prometheus"
 )
 
 var logger = loggo.GetLogger("juju.apiserver")
 
-// loginRateLimit defines how many concurrent Login requests we will
-// accept
+// loginRateLimit defines how many concurrent agent Login requests we
+// will accept. User logins are limited by the LoginThrottleConfig.
 const loginRateLimit = 10
 
 // Server holds the server side of the API.
@@ -46,10 +48,13 @@ type Server struct {
 	dataDir           string
 	logDir            string
 	limiter           utils.Limiter
+	userLimiter       utils.Limiter
+	loginThrottle     *loginThrottle
 	validator         LoginValidator
 	adminApiFactories map[int]adminApiFactory
 	modelUUID         string
 	authCtxt          *authContext
+	clock             clock.Clock
 	connections       int32 // count of active websocket connections
 }
 
@@ -68,6 +73,14 @@ type ServerConfig struct {
 	Validator   LoginValidator
 	CertChanged chan params.StateServingInfo
 
+	// LoginThrottle holds the limits applied to failed user logins,
+	// and to concurrent user logins.
+	LoginThrottle LoginThrottleConfig
+
+	// Clock is used to timestamp audit log entries. If nil, the
+	// wall clock is used.
+	Clock clock.Clock
+
 	// This field only exists to support testing.
 	StatePool *state.StatePool
 }
@@ -195,15 +208,23 @@ func newServer(s *state.State, lis *net.TCPListener, cfg ServerConfig) (_ *Serve
 		stPool = state.NewStatePool(s)
 	}
 
+	loginThrottle := newLoginThrottle(cfg.LoginThrottle)
+	clk := cfg.Clock
+	if clk == nil {
+		clk = clock.WallClock
+	}
 	srv := &Server{
-		state:     s,
-		statePool: stPool,
-		lis:       newChangeCertListener(lis, cfg.CertChanged, tlsConfig),
-		tag:       cfg.Tag,
-		dataDir:   cfg.DataDir,
-		logDir:    cfg.LogDir,
-		limiter:   utils.NewLimiter(loginRateLimit),
-		validator: cfg.Validator,
+		state:         s,
+		statePool:     stPool,
+		lis:           newChangeCertListener(lis, cfg.CertChanged, tlsConfig),
+		tag:           cfg.Tag,
+		dataDir:       cfg.DataDir,
+		logDir:        cfg.LogDir,
+		limiter:       utils.NewLimiter(loginRateLimit),
+		userLimiter:   utils.NewLimiter(loginThrottle.config.MaxConcurrentLogins),
+		loginThrottle: loginThrottle,
+		validator:     cfg.Validator,
+		clock:         clk,
 		adminApiFactories: map[int]adminApiFactory{
 			3: newAdminApiV3,
 		},
@@ -242,8 +263,9 @@ type requestNotifier struct {
 	id    int64
 	start time.Time
 
-	mu   sync.Mutex
-	tag_ string
+	mu       sync.Mutex
+	tag_     string
+	auditor_ *auditor
 
 	// count is incremented by calls to join, and deincremented
 	// by calls to leave.
@@ -275,10 +297,31 @@ func (n *requestNotifier) tag() (tag string) {
 	return
 }
 
+// audit causes the calls made over the connection to be recorded
+// by the given auditor.
+func (n *requestNotifier) audit(a *auditor) {
+	n.mu.Lock()
+	n.auditor_ = a
+	n.mu.Unlock()
+}
+
+func (n *requestNotifier) auditor() (a *auditor) {
+	n.mu.Lock()
+	a = n.auditor_
+	n.mu.Unlock()
+	return
+}
+
 func (n *requestNotifier) ServerRequest(hdr *rpc.Header, body interface{}) {
+	if a := n.auditor(); a != nil {
+		a.request(hdr, body)
+	}
 	if hdr.Request.Type == "Pinger" && hdr.Request.Action == "Ping" {
 		return
 	}
+	if logger.EffectiveLogLevel() > loggo.DEBUG {
+		return
+	}
 	// TODO(rog) 2013-10-11 remove secrets from some requests.
 	// Until secrets are removed, we only log the body of the requests at trace level
 	// which is below the default level of debug.
@@ -290,9 +333,16 @@ func (n *requestNotifier) ServerRequest(hdr *rpc.Header, body interface{}) {
 }
 
 func (n *requestNotifier) ServerReply(req rpc.Request, hdr *rpc.Header, body interface{}, timeSpent time.Duration) {
+	if a := n.auditor(); a != nil {
+		a.reply(hdr, body)
+	}
+	recordRequest(req, hdr, timeSpent)
 	if req.Type == "Pinger" && req.Action == "Ping" {
 		return
 	}
+	if logger.EffectiveLogLevel() > loggo.DEBUG {
+		return
+	}
 	// TODO(rog) 2013-10-11 remove secrets from some responses.
 	// Until secrets are removed, we only log the body of the requests at trace level
 	// which is below the default level of debug.
@@ -305,11 +355,13 @@ func (n *requestNotifier) ServerReply(req rpc.Request, hdr *rpc.Header, body int
 
 func (n *requestNotifier) join(req *http.Request) {
 	active := atomic.AddInt32(n.count, 1)
+	apiConnections.Inc()
 	logger.Infof("[%X] API connection from %s, active connections: %d", n.id, req.RemoteAddr, active)
 }
 
 func (n *requestNotifier) leave() {
 	active := atomic.AddInt32(n.count, -1)
+	apiConnections.Dec()
 	logger.Infof("[%X] %s API connection terminated after %v, active connections: %d", n.id, n.tag(), time.Since(n.start), active)
 }
 
@@ -405,6 +457,9 @@ func (srv *Server) endpoints() []apihttp.Endpoint {
 			ctxt: httpCtxt,
 		},
 	)
+	add("/model/:modeluuid/migrate/resources",
+		srv.trackRequests(newMigrationResourceDownloadHandler(httpCtxt)),
+	)
 	strictCtxt := httpCtxt
 	strictCtxt.strictValidation = true
 	strictCtxt.controllerModelOnly = true
@@ -413,6 +468,12 @@ func (srv *Server) endpoints() []apihttp.Endpoint {
 			ctxt: strictCtxt,
 		},
 	)
+	add("/model/:modeluuid/migrate/logtransfer",
+		srv.trackRequests(newLogTransferHandler(strictCtxt)),
+	)
+	add("/model/:modeluuid/migrate/resourceupload",
+		srv.trackRequests(newMigrationResourceUploadHandler(strictCtxt)),
+	)
 	add("/model/:modeluuid/api", mainAPIHandler)
 
 	add("/model/:modeluuid/images/:kind/:series/:arch/:filename",
@@ -430,6 +491,10 @@ func (srv *Server) endpoints() []apihttp.Endpoint {
 	add("/gui-version", &guiVersionHandler{
 		ctxt: httpCtxt,
 	})
+	add("/introspection/metrics", &metricsHandler{
+		ctxt:     httpCtxt,
+		registry: prometheus.DefaultRegistry,
+	})
 
 	// For backwards compatibility we register all the old paths
 	add("/log", debugLogHandler)
@@ -553,15 +618,11 @@ func (srv *Server) serveConn(wsConn *websocket.Conn, reqNotifier *requestNotifie
 	if loggo.GetLogger("juju.rpc.jsoncodec").EffectiveLogLevel() <= loggo.TRACE {
 		codec.SetLogging(true)
 	}
-	var notifier rpc.RequestNotifier
-	if logger.EffectiveLogLevel() <= loggo.DEBUG {
-		// Incur request monitoring overhead only if we
-		// know we'll need it.
-		notifier = reqNotifier
-	}
-	conn := rpc.NewConn(codec, notifier)
+	// The request notifier is always installed, as it records
+	// the audit log as well as logging requests at debug level.
+	conn := rpc.NewConn(codec, reqNotifier)
 
-	h, err := srv.newAPIHandler(conn, reqNotifier, modelUUID)
+	h, err := srv.newAPIHandler(conn, reqNotifier, modelUUID, wsConn.Request().RemoteAddr)
 	if err != nil {
 		conn.ServeFinder(&errRoot{err}, serverError)
 	} else {
@@ -579,7 +640,7 @@ func (srv *Server) serveConn(wsConn *websocket.Conn, reqNotifier *requestNotifie
 	return conn.Close()
 }
 
-func (srv *Server) newAPIHandler(conn *rpc.Conn, reqNotifier *requestNotifier, modelUUID string) (*apiHandler, error) {
+func (srv *Server) newAPIHandler(conn *rpc.Conn, reqNotifier *requestNotifier, modelUUID, remoteAddr string) (*apiHandler, error) {
 	// Note that we don't overwrite modelUUID here because
 	// newAPIHandler treats an empty modelUUID as signifying
 	// the API version used.
@@ -594,7 +655,7 @@ func (srv *Server) newAPIHandler(conn *rpc.Conn, reqNotifier *requestNotifier, m
 	if err != nil {
 		return nil, errors.Trace(err)
 	}
-	return newApiHandler(srv, st, conn, reqNotifier, modelUUID)
+	return newApiHandler(srv, st, conn, reqNotifier, modelUUID, remoteAddr)
 }
 
 func (srv *Server) mongoPinger() error {
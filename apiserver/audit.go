@@ -0,0 +1,115 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package apiserver
+
+import (
+	"strings"
+	"sync"
+
+	"github.com/juju/names"
+	"github.com/juju/utils/clock"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/audit"
+	"github.com/juju/juju/rpc"
+)
+
+// auditSink records audit log entries.
+type auditSink interface {
+	PutAuditEntry(audit.Entry) error
+}
+
+// auditor records the API calls made by a logged-in user over a
+// single connection that may change the controller or a model.
+type auditor struct {
+	sink      auditSink
+	clock     clock.Clock
+	user      names.UserTag
+	modelUUID string
+
+	mu      sync.Mutex
+	pending map[uint64]audit.Entry
+}
+
+func newAuditor(sink auditSink, clock clock.Clock, user names.UserTag, modelUUID string) *auditor {
+	return &auditor{
+		sink:      sink,
+		clock:     clock,
+		user:      user,
+		modelUUID: modelUUID,
+		pending:   make(map[uint64]audit.Entry),
+	}
+}
+
+// request notes the arguments of an auditable call, to be recorded
+// once the call completes.
+func (a *auditor) request(hdr *rpc.Header, body interface{}) {
+	if !isAuditable(hdr.Request) {
+		return
+	}
+	args, err := audit.RedactArgs(body)
+	if err != nil {
+		logger.Warningf("cannot record arguments of %s.%s: %v", hdr.Request.Type, hdr.Request.Action, err)
+		args = ""
+	}
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	a.pending[hdr.RequestId] = audit.Entry{
+		Timestamp: a.clock.Now(),
+		User:      a.user.String(),
+		ModelUUID: a.modelUUID,
+		Facade:    hdr.Request.Type,
+		Version:   hdr.Request.Version,
+		Method:    hdr.Request.Action,
+		Args:      args,
+	}
+}
+
+// reply records the outcome of an auditable call.
+func (a *auditor) reply(hdr *rpc.Header, body interface{}) {
+	a.mu.Lock()
+	entry, ok := a.pending[hdr.RequestId]
+	delete(a.pending, hdr.RequestId)
+	a.mu.Unlock()
+	if !ok {
+		return
+	}
+	entry.Error = hdr.Error
+	entry.ErrorCode = hdr.ErrorCode
+	if entry.Error == "" {
+		entry.Error, entry.ErrorCode = resultError(body)
+	}
+	if err := a.sink.PutAuditEntry(entry); err != nil {
+		logger.Errorf("cannot record audit log entry for %s.%s by %s: %v", entry.Facade, entry.Method, entry.User, err)
+	}
+}
+
+// resultError returns the error reported in the result of a call
+// that returns errors in its results rather than failing outright.
+func resultError(body interface{}) (message, code string) {
+	switch body := body.(type) {
+	case params.ErrorResult:
+		if body.Error != nil {
+			return body.Error.Message, body.Error.Code
+		}
+	case params.ErrorResults:
+		if err := body.Combine(); err != nil {
+			return err.Error(), ""
+		}
+	}
+	return "", ""
+}
+
+// isAuditable returns whether the given call should be recorded in
+// the audit log. Any call that is not known to be read only is
+// recorded, apart from logins, pings and calls to watchers.
+func isAuditable(req rpc.Request) bool {
+	switch {
+	case req.Type == "Admin", req.Type == "Pinger":
+		return false
+	case strings.HasSuffix(req.Type, "Watcher"):
+		return false
+	}
+	return !isCallReadOnly(req.Type, req.Action)
+}
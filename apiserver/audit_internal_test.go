@@ -0,0 +1,69 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package apiserver
+
+import (
+	"time"
+
+	"github.com/juju/names"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/audit"
+	"github.com/juju/juju/rpc"
+	coretesting "github.com/juju/juju/testing"
+)
+
+type auditInternalSuite struct{}
+
+var _ = gc.Suite(&auditInternalSuite{})
+
+func (*auditInternalSuite) TestIsAuditable(c *gc.C) {
+	for _, test := range []struct {
+		facade    string
+		method    string
+		auditable bool
+	}{
+		{"Service", "Destroy", true},
+		{"Client", "AddMachines", true},
+		{"Service", "Get", false},
+		{"Admin", "Login", false},
+		{"Pinger", "Ping", false},
+		{"AllWatcher", "Next", false},
+		{"NotifyWatcher", "Stop", false},
+		{"AuditLog", "Entries", false},
+	} {
+		c.Logf("check %s.%s", test.facade, test.method)
+		req := rpc.Request{Type: test.facade, Action: test.method}
+		c.Check(isAuditable(req), gc.Equals, test.auditable)
+	}
+}
+
+func (*auditInternalSuite) TestRedactsArguments(c *gc.C) {
+	sink := &recordingSink{}
+	now := time.Date(2016, 6, 1, 12, 0, 0, 0, time.UTC)
+	a := newAuditor(sink, coretesting.NewClock(now), names.NewUserTag("bob"), "deadbeef-0bad-400d-8000-4b1d0d06f00d")
+
+	a.request(&rpc.Header{
+		RequestId: 1,
+		Request:   rpc.Request{Type: "UserManager", Version: 1, Action: "SetPassword"},
+	}, map[string]interface{}{"tag": "user-bob", "password": "sekrit"})
+	a.reply(&rpc.Header{RequestId: 1}, struct{}{})
+
+	c.Assert(sink.entries, gc.HasLen, 1)
+	c.Check(sink.entries[0].Timestamp, gc.Equals, now)
+	c.Check(sink.entries[0].Args, jc.JSONEquals, map[string]interface{}{
+		"tag":      "user-bob",
+		"password": audit.RedactedValue,
+	})
+}
+
+type recordingSink struct {
+	entries []audit.Entry
+}
+
+func (s *recordingSink) PutAuditEntry(entry audit.Entry) error {
+	s.entries = append(s.entries, entry)
+	return nil
+}
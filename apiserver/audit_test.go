@@ -0,0 +1,96 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package apiserver_test
+
+import (
+	"time"
+
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/api/block"
+	"github.com/juju/juju/api/service"
+	"github.com/juju/juju/audit"
+	jujutesting "github.com/juju/juju/juju/testing"
+	"github.com/juju/juju/state"
+	"github.com/juju/juju/testing/factory"
+)
+
+type auditSuite struct {
+	jujutesting.JujuConnSuite
+}
+
+var _ = gc.Suite(&auditSuite{})
+
+func (s *auditSuite) TestRecordsMutatingCalls(c *gc.C) {
+	client := block.NewClient(s.APIState)
+	err := client.SwitchBlockOn("BlockDestroy", "don't")
+	c.Assert(err, jc.ErrorIsNil)
+
+	entries, err := s.State.AuditEntries(state.AuditLogFilter{Method: "Block.SwitchBlockOn"})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(entries, gc.HasLen, 1)
+	entry := entries[0]
+	c.Check(entry.Timestamp.IsZero(), jc.IsFalse)
+	c.Check(entry.Timestamp.After(time.Now()), jc.IsFalse)
+	entry.Timestamp = time.Time{}
+	c.Check(entry, jc.DeepEquals, audit.Entry{
+		User:      s.AdminUserTag(c).String(),
+		ModelUUID: s.State.ModelUUID(),
+		Facade:    "Block",
+		Version:   2,
+		Method:    "SwitchBlockOn",
+		Args:      `{"message":"don't","type":"BlockDestroy"}`,
+	})
+}
+
+func (s *auditSuite) TestRecordsErrors(c *gc.C) {
+	client := service.NewClient(s.APIState)
+	err := client.Destroy("no-such-service")
+	c.Assert(err, gc.ErrorMatches, `service "no-such-service" not found`)
+
+	entries, err := s.State.AuditEntries(state.AuditLogFilter{Method: "Service.Destroy"})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(entries, gc.HasLen, 1)
+	c.Check(entries[0].Error, gc.Equals, `service "no-such-service" not found`)
+	c.Check(entries[0].ErrorCode, gc.Equals, "not found")
+}
+
+func (s *auditSuite) TestRecordsResultErrors(c *gc.C) {
+	client := block.NewClient(s.APIState)
+	err := client.SwitchBlockOn("BlockDestroy", "")
+	c.Assert(err, jc.ErrorIsNil)
+	err = client.SwitchBlockOn("BlockDestroy", "")
+	c.Assert(err, gc.NotNil)
+
+	entries, err := s.State.AuditEntries(state.AuditLogFilter{Method: "Block.SwitchBlockOn"})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(entries, gc.HasLen, 2)
+	c.Check(entries[0].Error, gc.Equals, "")
+	c.Check(entries[1].Error, gc.Matches, "block .* is already ON")
+}
+
+func (s *auditSuite) TestIgnoresReadOnlyCalls(c *gc.C) {
+	client := block.NewClient(s.APIState)
+	_, err := client.List()
+	c.Assert(err, jc.ErrorIsNil)
+
+	entries, err := s.State.AuditEntries(state.AuditLogFilter{Method: "Block.List"})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(entries, gc.HasLen, 0)
+}
+
+func (s *auditSuite) TestIgnoresAgents(c *gc.C) {
+	machine, password := s.Factory.MakeMachineReturningPassword(c, &factory.MachineParams{
+		Nonce: "fake_nonce",
+	})
+	st := s.OpenAPIAsMachine(c, machine.Tag(), password, "fake_nonce")
+	defer st.Close()
+	err := block.NewClient(st).SwitchBlockOn("BlockDestroy", "")
+	c.Assert(err, gc.NotNil)
+
+	entries, err := s.State.AuditEntries(state.AuditLogFilter{Method: "Block.SwitchBlockOn"})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(entries, gc.HasLen, 0)
+}
@@ -0,0 +1,89 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+// Package auditlog defines the API facade used to query the
+// controller's audit log.
+package auditlog
+
+import (
+	"github.com/juju/errors"
+	"github.com/juju/names"
+
+	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/audit"
+	"github.com/juju/juju/state"
+)
+
+func init() {
+	common.RegisterStandardFacade("AuditLog", 1, NewAPI)
+}
+
+// Backend defines the State functionality used by the AuditLog
+// facade.
+type Backend interface {
+	IsControllerAdministrator(names.UserTag) (bool, error)
+	AuditEntries(state.AuditLogFilter) ([]audit.Entry, error)
+}
+
+// API implements the AuditLog facade. It is only available to
+// controller administrators.
+type API struct {
+	backend Backend
+}
+
+// NewAPI returns a new AuditLog API facade.
+func NewAPI(
+	st *state.State,
+	resources *common.Resources,
+	authorizer common.Authorizer,
+) (*API, error) {
+	return newAPI(st, authorizer)
+}
+
+func newAPI(backend Backend, authorizer common.Authorizer) (*API, error) {
+	if !authorizer.AuthClient() {
+		return nil, errors.Trace(common.ErrPerm)
+	}
+	// Type assertion is fine because AuthClient is true.
+	apiUser := authorizer.GetAuthTag().(names.UserTag)
+	if isAdmin, err := backend.IsControllerAdministrator(apiUser); err != nil {
+		return nil, errors.Trace(err)
+	} else if !isAdmin {
+		return nil, errors.Trace(common.ErrPerm)
+	}
+	return &API{backend: backend}, nil
+}
+
+// Entries returns the audit log entries that match the given filter,
+// oldest first.
+func (api *API) Entries(args params.AuditLogFilter) (params.AuditLogEntries, error) {
+	entries, err := api.backend.AuditEntries(state.AuditLogFilter{
+		User:      args.UserTag,
+		ModelUUID: args.ModelUUID,
+		Method:    args.Method,
+		After:     args.After,
+		Before:    args.Before,
+		Limit:     args.Limit,
+	})
+	if err != nil {
+		return params.AuditLogEntries{}, errors.Trace(err)
+	}
+	result := params.AuditLogEntries{
+		Entries: make([]params.AuditLogEntry, len(entries)),
+	}
+	for i, entry := range entries {
+		result.Entries[i] = params.AuditLogEntry{
+			Timestamp: entry.Timestamp,
+			UserTag:   entry.User,
+			ModelUUID: entry.ModelUUID,
+			Facade:    entry.Facade,
+			Version:   entry.Version,
+			Method:    entry.Method,
+			Args:      entry.Args,
+			Error:     entry.Error,
+			ErrorCode: entry.ErrorCode,
+		}
+	}
+	return result, nil
+}
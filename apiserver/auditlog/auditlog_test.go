@@ -0,0 +1,103 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package auditlog_test
+
+import (
+	"time"
+
+	"github.com/juju/errors"
+	"github.com/juju/names"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/apiserver/auditlog"
+	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/apiserver/params"
+	apiservertesting "github.com/juju/juju/apiserver/testing"
+	"github.com/juju/juju/audit"
+	statetesting "github.com/juju/juju/state/testing"
+)
+
+type Suite struct {
+	statetesting.StateSuite
+	resources  *common.Resources
+	authorizer apiservertesting.FakeAuthorizer
+}
+
+var _ = gc.Suite(&Suite{})
+
+func (s *Suite) SetUpTest(c *gc.C) {
+	s.StateSuite.SetUpTest(c)
+	s.resources = common.NewResources()
+	s.AddCleanup(func(*gc.C) { s.resources.StopAll() })
+	s.authorizer = apiservertesting.FakeAuthorizer{
+		Tag: s.Owner,
+	}
+}
+
+func (s *Suite) newAPI() (*auditlog.API, error) {
+	return auditlog.NewAPI(s.State, s.resources, s.authorizer)
+}
+
+func (s *Suite) mustNewAPI(c *gc.C) *auditlog.API {
+	api, err := s.newAPI()
+	c.Assert(err, jc.ErrorIsNil)
+	return api
+}
+
+func (s *Suite) TestFacadeRegistered(c *gc.C) {
+	factory, err := common.Facades.GetFactory("AuditLog", 1)
+	c.Assert(err, jc.ErrorIsNil)
+
+	api, err := factory(s.State, s.resources, s.authorizer, "")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(api, gc.FitsTypeOf, new(auditlog.API))
+}
+
+func (s *Suite) TestNotUser(c *gc.C) {
+	s.authorizer.Tag = names.NewMachineTag("0")
+	_, err := s.newAPI()
+	c.Assert(errors.Cause(err), gc.Equals, common.ErrPerm)
+}
+
+func (s *Suite) TestNotControllerAdmin(c *gc.C) {
+	s.authorizer.Tag = names.NewUserTag("jrandomuser")
+	_, err := s.newAPI()
+	c.Assert(errors.Cause(err), gc.Equals, common.ErrPerm)
+}
+
+func (s *Suite) TestEntries(c *gc.C) {
+	t0 := time.Date(2016, 6, 1, 12, 0, 0, 0, time.UTC)
+	for i, method := range []string{"Deploy", "Destroy"} {
+		err := s.State.PutAuditEntry(audit.Entry{
+			Timestamp: t0.Add(time.Duration(i) * time.Minute),
+			User:      "user-bob@local",
+			ModelUUID: s.State.ModelUUID(),
+			Facade:    "Service",
+			Version:   3,
+			Method:    method,
+			Args:      `{"service":"mysql"}`,
+		})
+		c.Assert(err, jc.ErrorIsNil)
+	}
+	api := s.mustNewAPI(c)
+
+	result, err := api.Entries(params.AuditLogFilter{
+		UserTag: "user-bob@local",
+		Method:  "Service.Destroy",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	c.Check(result, jc.DeepEquals, params.AuditLogEntries{
+		Entries: []params.AuditLogEntry{{
+			Timestamp: t0.Add(time.Minute),
+			UserTag:   "user-bob@local",
+			ModelUUID: s.State.ModelUUID(),
+			Facade:    "Service",
+			Version:   3,
+			Method:    "Destroy",
+			Args:      `{"service":"mysql"}`,
+		}},
+	})
+}
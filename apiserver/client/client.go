@@ -89,6 +89,15 @@ func (c *Client) Resolved(p params.Resolved) error {
 	if err := c.check.ChangeAllowed(); err != nil {
 		return errors.Trace(err)
 	}
+	if names.IsValidUnit(p.UnitName) {
+		canOperate, err := common.AuthServiceOperator(c.api.stateAccessor, c.api.auth)()
+		if err != nil {
+			return errors.Trace(err)
+		}
+		if !canOperate(names.NewUnitTag(p.UnitName)) {
+			return common.ErrPerm
+		}
+	}
 	unit, err := c.api.stateAccessor.Unit(p.UnitName)
 	if err != nil {
 		return err
@@ -57,8 +57,10 @@ type stateInterface interface {
 	Charm(*charm.URL) (*state.Charm, error)
 	LatestPlaceholderCharm(*charm.URL) (*state.Charm, error)
 	AddRelation(...state.Endpoint) (*state.Relation, error)
+	ModelUser(names.UserTag) (*state.ModelUser, error)
 	AddModelUser(state.ModelUserSpec) (*state.ModelUser, error)
 	RemoveModelUser(names.UserTag) error
+	UserServiceAccess(names.UserTag) ([]*state.ServiceUser, error)
 	Watch() *state.Multiwatcher
 	AbortCurrentUpgrade() error
 	APIHostPorts() ([][]network.HostPort, error)
@@ -567,9 +567,10 @@ func (context *statusContext) processServices() map[string]params.ServiceStatus
 func (context *statusContext) processService(service *state.Service) params.ServiceStatus {
 	serviceCharmURL, _ := service.CharmURL()
 	var processedStatus = params.ServiceStatus{
-		Charm:   serviceCharmURL.String(),
-		Exposed: service.IsExposed(),
-		Life:    processLife(service),
+		Charm:        serviceCharmURL.String(),
+		Exposed:      service.IsExposed(),
+		ExposedCIDRs: service.ExposedCIDRs(),
+		Life:         processLife(service),
 	}
 
 	if latestCharm, ok := context.latestCharms[*serviceCharmURL.WithRevision(-1)]; ok && latestCharm != nil {
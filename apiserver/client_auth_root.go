@@ -12,9 +12,11 @@ import (
 	"github.com/juju/juju/state"
 )
 
-// clientAuthRoot restricts API calls for users of a model. Initially the
-// authorisation checks are only for read only access to the model, but in the
-// near future, full ACL support is desirable.
+// clientAuthRoot restricts API calls for users of a model according to
+// their access: read only users may only make calls that do not change
+// the model, or that change the services they have been granted operate
+// access to, and users with write access may make any call except those
+// that require admin access.
 type clientAuthRoot struct {
 	finder rpc.MethodFinder
 	user   *state.ModelUser
@@ -36,10 +38,13 @@ func (r *clientAuthRoot) FindMethod(rootName string, version int, methodName str
 	}
 	if r.user.ReadOnly() {
 		canCall := isCallAllowableByReadOnlyUser(rootName, methodName) ||
-			isCallReadOnly(rootName, methodName)
+			isCallReadOnly(rootName, methodName) ||
+			isCallServiceOperator(rootName, methodName)
 		if !canCall {
 			return nil, errors.Trace(common.ErrPerm)
 		}
+	} else if r.user.Access() != state.ModelAdminAccess && isCallAdminOnly(rootName, methodName) {
+		return nil, errors.Trace(common.ErrPerm)
 	}
 
 	return caller, nil
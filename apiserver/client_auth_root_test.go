@@ -58,12 +58,36 @@ func (s *clientAuthRootSuite) TestReadOnlyUser(c *gc.C) {
 	s.AssertCallErrPerm(c, client, "Service", 3, "Deploy")
 	// read only commands are fine
 	s.AssertCallGood(c, client, "Client", 1, "FullStatus")
+	// service operator calls are fine; the facades check the service
+	s.AssertCallGood(c, client, "Service", 3, "Set")
+	s.AssertCallGood(c, client, "Action", 1, "Enqueue")
 	// calls on the restricted root is also fine
 	s.AssertCallGood(c, client, "UserManager", 1, "AddUser")
 	s.AssertCallNotImplemented(c, client, "Client", 1, "Unknown")
 	s.AssertCallNotImplemented(c, client, "Unknown", 1, "Method")
 }
 
+func (s *clientAuthRootSuite) TestWriteUser(c *gc.C) {
+	envUser := s.Factory.MakeModelUser(c, &factory.ModelUserParams{Access: state.ModelWriteAccess})
+	client := newClientAuthRoot(&fakeFinder{}, envUser)
+	// deploys are fine
+	s.AssertCallGood(c, client, "Service", 3, "Deploy")
+	s.AssertCallGood(c, client, "Client", 1, "FullStatus")
+	// but destroying or reconfiguring the model is not
+	s.AssertCallErrPerm(c, client, "Client", 1, "DestroyModel")
+	s.AssertCallErrPerm(c, client, "Client", 1, "ModelSet")
+	s.AssertCallErrPerm(c, client, "KeyManager", 1, "AddKeys")
+	s.AssertCallNotImplemented(c, client, "Client", 1, "Unknown")
+}
+
+func (s *clientAuthRootSuite) TestAdminUser(c *gc.C) {
+	envUser := s.Factory.MakeModelUser(c, &factory.ModelUserParams{Access: state.ModelAdminAccess})
+	client := newClientAuthRoot(&fakeFinder{}, envUser)
+	s.AssertCallGood(c, client, "Client", 1, "DestroyModel")
+	s.AssertCallGood(c, client, "Client", 1, "ModelSet")
+	s.AssertCallGood(c, client, "KeyManager", 1, "AddKeys")
+}
+
 func isCallNotImplementedError(err error) bool {
 	_, ok := err.(*rpcreflect.CallNotImplementedError)
 	return ok
@@ -152,6 +152,7 @@ func Actions(args params.Entities, actionFn func(string) (state.Action, error))
 		results.Results[i].Action = &params.Action{
 			Name:       action.Name(),
 			Parameters: action.Parameters(),
+			Timeout:    action.Timeout(),
 		}
 	}
 
@@ -238,6 +239,7 @@ func MakeActionResult(actionReceiverTag names.Tag, action state.Action) params.A
 			Tag:        action.ActionTag().String(),
 			Name:       action.Name(),
 			Parameters: action.Parameters(),
+			Timeout:    action.Timeout(),
 		},
 		Status:    string(action.Status()),
 		Message:   message,
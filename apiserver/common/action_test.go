@@ -5,6 +5,8 @@
 package common_test
 
 import (
+	"time"
+
 	"github.com/juju/errors"
 	"github.com/juju/names"
 	jc "github.com/juju/testing/checkers"
@@ -143,7 +145,7 @@ func (s *actionsSuite) TestBeginActions(c *gc.C) {
 func (s *actionsSuite) TestGetActions(c *gc.C) {
 	args := entities("success", "fail", "notPending")
 	actionFn := makeGetActionByTagString(map[string]state.Action{
-		"success":    fakeAction{name: "floosh", status: state.ActionPending},
+		"success":    fakeAction{name: "floosh", status: state.ActionPending, timeout: time.Minute},
 		"notPending": fakeAction{status: state.ActionCancelled},
 	})
 
@@ -151,7 +153,7 @@ func (s *actionsSuite) TestGetActions(c *gc.C) {
 
 	c.Assert(results, jc.DeepEquals, params.ActionResults{
 		[]params.ActionResult{
-			{Action: &params.Action{Name: "floosh"}},
+			{Action: &params.Action{Name: "floosh", Timeout: time.Minute}},
 			{Error: common.ServerError(actionNotFoundErr)},
 			{Error: common.ServerError(common.ErrActionNotAvailable)},
 		},
@@ -334,6 +336,7 @@ type fakeAction struct {
 	beginErr  error
 	finishErr error
 	status    state.ActionStatus
+	timeout   time.Duration
 }
 
 func (mock fakeAction) Status() state.ActionStatus {
@@ -356,6 +359,14 @@ func (mock fakeAction) Parameters() map[string]interface{} {
 	return nil
 }
 
+func (mock fakeAction) Timeout() time.Duration {
+	return mock.timeout
+}
+
+func (mock fakeAction) Cancel() (state.Action, error) {
+	return nil, nil
+}
+
 func (mock fakeAction) Finish(state.ActionResults) (state.Action, error) {
 	return nil, mock.finishErr
 }
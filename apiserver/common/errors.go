@@ -97,6 +97,7 @@ var (
 	ErrBadRequest         = errors.New("invalid request")
 	ErrTryAgain           = errors.New("try again")
 	ErrActionNotAvailable = errors.New("action no longer available")
+	ErrLoginLockedOut     = errors.New("too many failed login attempts; try again later")
 )
 
 // OperationBlockedError returns an error which signifies that
@@ -128,6 +129,7 @@ var singletonErrorCodes = map[error]string{
 	ErrStoppedWatcher:            params.CodeStopped,
 	ErrTryAgain:                  params.CodeTryAgain,
 	ErrActionNotAvailable:        params.CodeActionNotAvailable,
+	ErrLoginLockedOut:            params.CodeUnauthorized,
 }
 
 func singletonCode(err error) (string, bool) {
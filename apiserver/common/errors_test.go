@@ -128,6 +128,11 @@ var errorTransformTests = []struct {
 	code:       params.CodeTryAgain,
 	status:     http.StatusInternalServerError,
 	helperFunc: params.IsCodeTryAgain,
+}, {
+	err:        common.ErrLoginLockedOut,
+	code:       params.CodeUnauthorized,
+	status:     http.StatusUnauthorized,
+	helperFunc: params.IsCodeUnauthorized,
 }, {
 	err:        leadership.ErrClaimDenied,
 	code:       params.CodeLeadershipClaimDenied,
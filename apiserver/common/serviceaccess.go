@@ -0,0 +1,65 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package common
+
+import (
+	"github.com/juju/errors"
+	"github.com/juju/names"
+	"github.com/juju/utils/set"
+
+	"github.com/juju/juju/state"
+)
+
+// ServiceAccessGetter provides the access users have to the model and
+// to its services.
+type ServiceAccessGetter interface {
+	ModelUser(names.UserTag) (*state.ModelUser, error)
+	UserServiceAccess(names.UserTag) ([]*state.ServiceUser, error)
+}
+
+// AuthServiceOperator returns an authentication function that allows
+// the authenticated user to change service and unit entities. Users
+// that may change the model may change any service; users with read
+// only access to the model may only change the services they have been
+// granted operate access to, and their units.
+func AuthServiceOperator(st ServiceAccessGetter, authorizer Authorizer) GetAuthFunc {
+	return func() (AuthFunc, error) {
+		user, ok := authorizer.GetAuthTag().(names.UserTag)
+		if !ok {
+			return nil, ErrPerm
+		}
+		modelUser, err := st.ModelUser(user)
+		if err != nil && !errors.IsNotFound(err) {
+			return nil, errors.Trace(err)
+		}
+		if err == nil && !modelUser.ReadOnly() {
+			return func(names.Tag) bool {
+				return true
+			}, nil
+		}
+		serviceUsers, err := st.UserServiceAccess(user)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		operated := set.NewStrings()
+		for _, serviceUser := range serviceUsers {
+			if serviceUser.Access() == state.ServiceOperateAccess {
+				operated.Add(serviceUser.ServiceName())
+			}
+		}
+		return func(tag names.Tag) bool {
+			switch tag := tag.(type) {
+			case names.ServiceTag:
+				return operated.Contains(tag.Id())
+			case names.UnitTag:
+				serviceName, err := names.UnitService(tag.Id())
+				if err != nil {
+					return false
+				}
+				return operated.Contains(serviceName)
+			}
+			return false
+		}, nil
+	}
+}
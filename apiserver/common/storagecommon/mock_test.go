@@ -23,6 +23,7 @@ type fakeStorage struct {
 	blockDevices           func(names.MachineTag) ([]state.BlockDeviceInfo, error)
 	watchVolumeAttachment  func(names.MachineTag, names.VolumeTag) state.NotifyWatcher
 	watchBlockDevices      func(names.MachineTag) state.NotifyWatcher
+	watchVolume            func(names.VolumeTag) state.NotifyWatcher
 	watchStorageAttachment func(names.StorageTag, names.UnitTag) state.NotifyWatcher
 }
 
@@ -56,6 +57,11 @@ func (s *fakeStorage) WatchBlockDevices(m names.MachineTag) state.NotifyWatcher
 	return s.watchBlockDevices(m)
 }
 
+func (s *fakeStorage) WatchVolume(v names.VolumeTag) state.NotifyWatcher {
+	s.MethodCall(s, "WatchVolume", v)
+	return s.watchVolume(v)
+}
+
 func (s *fakeStorage) WatchStorageAttachment(st names.StorageTag, u names.UnitTag) state.NotifyWatcher {
 	s.MethodCall(s, "WatchStorageAttachment", st, u)
 	return s.watchStorageAttachment(st, u)
@@ -76,8 +82,8 @@ func (i *fakeStorageInstance) Tag() names.Tag {
 	return i.tag
 }
 
-func (i *fakeStorageInstance) Owner() names.Tag {
-	return i.owner
+func (i *fakeStorageInstance) Owner() (names.Tag, bool) {
+	return i.owner, i.owner != nil
 }
 
 func (i *fakeStorageInstance) Kind() state.StorageKind {
@@ -31,6 +31,9 @@ type StorageInterface interface {
 	// storage instance with the specified storage tag.
 	StorageInstanceVolume(names.StorageTag) (state.Volume, error)
 
+	// Volume returns the state.Volume with the specified tag.
+	Volume(names.VolumeTag) (state.Volume, error)
+
 	// FilesystemAttachment returns the state.FilesystemAttachment
 	// corresponding to the identified machine and filesystem.
 	FilesystemAttachment(names.MachineTag, names.FilesystemTag) (state.FilesystemAttachment, error)
@@ -51,6 +54,9 @@ type StorageInterface interface {
 	// corresponding to the identfified machine and volume.
 	WatchVolumeAttachment(names.MachineTag, names.VolumeTag) state.NotifyWatcher
 
+	// WatchVolume watches for changes to the specified volume.
+	WatchVolume(names.VolumeTag) state.NotifyWatcher
+
 	// WatchBlockDevices watches for changes to block devices associated
 	// with the specified machine.
 	WatchBlockDevices(names.MachineTag) state.NotifyWatcher
@@ -134,6 +140,7 @@ func volumeStorageAttachmentInfo(
 	return &storage.StorageAttachmentInfo{
 		storage.StorageKindBlock,
 		devicePath,
+		volumeInfo.Size,
 	}, nil
 }
 
@@ -155,15 +162,33 @@ func filesystemStorageAttachmentInfo(
 	if err != nil {
 		return nil, errors.Annotate(err, "getting filesystem attachment info")
 	}
+	// Only filesystems backed by volumes may be resized, so we
+	// only report the size of the backing volume, if any.
+	var size uint64
+	volumeTag, err := filesystem.Volume()
+	if err == nil {
+		volume, err := st.Volume(volumeTag)
+		if err != nil {
+			return nil, errors.Annotate(err, "getting backing volume")
+		}
+		volumeInfo, err := volume.Info()
+		if err != nil {
+			return nil, errors.Annotate(err, "getting backing volume info")
+		}
+		size = volumeInfo.Size
+	} else if errors.Cause(err) != state.ErrNoBackingVolume {
+		return nil, errors.Annotate(err, "getting backing volume")
+	}
 	return &storage.StorageAttachmentInfo{
 		storage.StorageKindFilesystem,
 		filesystemAttachmentInfo.MountPoint,
+		size,
 	}, nil
 }
 
 // WatchStorageAttachment returns a state.NotifyWatcher that reacts to changes
 // to the VolumeAttachmentInfo or FilesystemAttachmentInfo corresponding to the
-// tags specified.
+// tags specified, or to the volume backing the storage (e.g. when it is resized).
 func WatchStorageAttachment(
 	st StorageInterface,
 	storageTag names.StorageTag,
@@ -194,6 +219,7 @@ func WatchStorageAttachment(
 			// or have the filter ignore changes until the volume
 			// attachment is provisioned.
 			st.WatchBlockDevices(machineTag),
+			st.WatchVolume(volume.VolumeTag()),
 		}
 	case state.StorageKindFilesystem:
 		filesystem, err := st.StorageInstanceFilesystem(storageTag)
@@ -203,6 +229,12 @@ func WatchStorageAttachment(
 		watchers = []state.NotifyWatcher{
 			st.WatchFilesystemAttachment(machineTag, filesystem.FilesystemTag()),
 		}
+		volumeTag, err := filesystem.Volume()
+		if err == nil {
+			watchers = append(watchers, st.WatchVolume(volumeTag))
+		} else if errors.Cause(err) != state.ErrNoBackingVolume {
+			return nil, errors.Annotate(err, "getting backing volume")
+		}
 	default:
 		return nil, errors.Errorf("invalid storage kind %v", storageInstance.Kind())
 	}
@@ -263,7 +295,9 @@ func storageTags(
 	)
 	if storageInstance != nil {
 		storageTags[tags.JujuStorageInstance] = storageInstance.Tag().Id()
-		storageTags[tags.JujuStorageOwner] = storageInstance.Owner().Id()
+		if owner, ok := storageInstance.Owner(); ok {
+			storageTags[tags.JujuStorageOwner] = owner.Id()
+		}
 	}
 	return storageTags, nil
 }
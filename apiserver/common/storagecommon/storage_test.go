@@ -84,6 +84,7 @@ func (s *storageAttachmentInfoSuite) TestStorageAttachmentInfoPersistentDeviceNa
 	c.Assert(info, jc.DeepEquals, &storage.StorageAttachmentInfo{
 		Kind:     storage.StorageKindBlock,
 		Location: filepath.FromSlash("/dev/sda"),
+		Size:     1024,
 	})
 }
 
@@ -105,6 +106,7 @@ func (s *storageAttachmentInfoSuite) TestStorageAttachmentInfoPersistentDeviceLi
 	c.Assert(info, jc.DeepEquals, &storage.StorageAttachmentInfo{
 		Kind:     storage.StorageKindBlock,
 		Location: "/dev/disk/by-id/verbatim",
+		Size:     1024,
 	})
 }
 
@@ -116,6 +118,7 @@ func (s *storageAttachmentInfoSuite) TestStorageAttachmentInfoPersistentHardware
 	c.Assert(info, jc.DeepEquals, &storage.StorageAttachmentInfo{
 		Kind:     storage.StorageKindBlock,
 		Location: filepath.FromSlash("/dev/disk/by-id/whatever"),
+		Size:     1024,
 	})
 }
 
@@ -136,6 +139,7 @@ func (s *storageAttachmentInfoSuite) TestStorageAttachmentInfoMatchingBlockDevic
 	c.Assert(info, jc.DeepEquals, &storage.StorageAttachmentInfo{
 		Kind:     storage.StorageKindBlock,
 		Location: filepath.FromSlash("/dev/sdb"),
+		Size:     1024,
 	})
 }
 
@@ -158,6 +162,7 @@ type watchStorageAttachmentSuite struct {
 	volume                   *fakeVolume
 	volumeAttachmentWatcher  *apiservertesting.FakeNotifyWatcher
 	blockDevicesWatcher      *apiservertesting.FakeNotifyWatcher
+	volumeWatcher            *apiservertesting.FakeNotifyWatcher
 	storageAttachmentWatcher *apiservertesting.FakeNotifyWatcher
 }
 
@@ -177,6 +182,8 @@ func (s *watchStorageAttachmentSuite) SetUpTest(c *gc.C) {
 	s.volumeAttachmentWatcher.C <- struct{}{}
 	s.blockDevicesWatcher = apiservertesting.NewFakeNotifyWatcher()
 	s.blockDevicesWatcher.C <- struct{}{}
+	s.volumeWatcher = apiservertesting.NewFakeNotifyWatcher()
+	s.volumeWatcher.C <- struct{}{}
 	s.storageAttachmentWatcher = apiservertesting.NewFakeNotifyWatcher()
 	s.storageAttachmentWatcher.C <- struct{}{}
 	s.st = &fakeStorage{
@@ -192,6 +199,9 @@ func (s *watchStorageAttachmentSuite) SetUpTest(c *gc.C) {
 		watchBlockDevices: func(names.MachineTag) state.NotifyWatcher {
 			return s.blockDevicesWatcher
 		},
+		watchVolume: func(names.VolumeTag) state.NotifyWatcher {
+			return s.volumeWatcher
+		},
 		watchStorageAttachment: func(names.StorageTag, names.UnitTag) state.NotifyWatcher {
 			return s.storageAttachmentWatcher
 		},
@@ -216,6 +226,12 @@ func (s *watchStorageAttachmentSuite) TestWatchStorageAttachmentBlockDevicesChan
 	})
 }
 
+func (s *watchStorageAttachmentSuite) TestWatchStorageAttachmentVolumeChanges(c *gc.C) {
+	s.testWatchBlockStorageAttachment(c, func() {
+		s.volumeWatcher.C <- struct{}{}
+	})
+}
+
 func (s *watchStorageAttachmentSuite) testWatchBlockStorageAttachment(c *gc.C, change func()) {
 	s.testWatchStorageAttachment(c, change)
 	s.st.CheckCallNames(c,
@@ -223,6 +239,7 @@ func (s *watchStorageAttachmentSuite) testWatchBlockStorageAttachment(c *gc.C, c
 		"StorageInstanceVolume",
 		"WatchVolumeAttachment",
 		"WatchBlockDevices",
+		"WatchVolume",
 		"WatchStorageAttachment",
 	)
 }
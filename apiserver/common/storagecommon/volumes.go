@@ -37,9 +37,11 @@ func VolumeParams(
 
 	var pool string
 	var size uint64
+	var snapshotId string
 	if stateVolumeParams, ok := v.Params(); ok {
 		pool = stateVolumeParams.Pool
 		size = stateVolumeParams.Size
+		snapshotId = stateVolumeParams.SnapshotId
 	} else {
 		volumeInfo, err := v.Info()
 		if err != nil {
@@ -64,6 +66,7 @@ func VolumeParams(
 		string(providerType),
 		cfg.Attrs(),
 		volumeTags,
+		snapshotId,
 		nil, // attachment params set by the caller
 	}, nil
 }
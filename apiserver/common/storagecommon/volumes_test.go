@@ -57,6 +57,19 @@ func (*volumesSuite) testVolumeParams(c *gc.C, volumeParams *state.VolumeParams,
 	})
 }
 
+func (*volumesSuite) TestVolumeParamsSnapshot(c *gc.C) {
+	p, err := storagecommon.VolumeParams(
+		&fakeVolume{tag: names.NewVolumeTag("100"), params: &state.VolumeParams{
+			Pool: "loop", Size: 1024, SnapshotId: "snap-0",
+		}},
+		nil, // StorageInstance
+		testing.CustomModelConfig(c, nil),
+		&fakePoolManager{},
+	)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(p.SnapshotId, gc.Equals, "snap-0")
+}
+
 func (*volumesSuite) TestVolumeParamsStorageTags(c *gc.C) {
 	volumeTag := names.NewVolumeTag("100")
 	storageTag := names.NewStorageTag("mystore/0")
@@ -0,0 +1,100 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package controller
+
+import (
+	"github.com/juju/errors"
+	"github.com/juju/names"
+
+	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/state"
+)
+
+// ModifyControllerAccess changes the controller access granted to users.
+func (c *ControllerAPI) ModifyControllerAccess(args params.ModifyControllerAccessRequest) (params.ErrorResults, error) {
+	result := params.ErrorResults{
+		Results: make([]params.ErrorResult, len(args.Changes)),
+	}
+	for i, arg := range args.Changes {
+		err := c.modifyOneControllerAccess(arg)
+		result.Results[i].Error = common.ServerError(err)
+	}
+	return result, nil
+}
+
+func (c *ControllerAPI) modifyOneControllerAccess(arg params.ModifyControllerAccess) error {
+	userTag, err := names.ParseUserTag(arg.UserTag)
+	if err != nil {
+		return errors.Annotate(err, "could not modify controller access")
+	}
+	access, err := fromControllerAccessParam(arg.Access)
+	if err != nil {
+		return errors.Annotate(err, "could not modify controller access")
+	}
+	current, err := c.state.ControllerAccess(userTag)
+	if err != nil && !errors.IsNotFound(err) {
+		return errors.Annotate(err, "could not look up controller access for user")
+	}
+	hasAccess := err == nil
+
+	switch arg.Action {
+	case params.GrantControllerAccess:
+		// Only set access if greater access is being granted.
+		if hasAccess && current.EqualOrGreaterThan(access) {
+			return errors.Errorf("user already has %q access", current)
+		}
+		if userTag.IsLocal() {
+			// Don't grant access to local users that don't exist.
+			if _, err := c.state.User(userTag); err != nil {
+				return errors.Annotate(err, "could not grant controller access")
+			}
+		}
+		err := c.state.SetControllerAccess(userTag, access, c.apiUser)
+		return errors.Annotate(err, "could not grant controller access")
+
+	case params.RevokeControllerAccess:
+		if !hasAccess {
+			return errors.Errorf("user %q has no controller access", userTag.Canonical())
+		}
+		if userTag.Canonical() == c.apiUser.Canonical() {
+			return errors.New("cannot revoke your own controller access")
+		}
+		// Revoking an access level leaves the user with the level
+		// below it; revoking login access removes all access.
+		var remainingAccess state.ControllerAccess
+		switch access {
+		case state.ControllerLoginAccess:
+			err := c.state.RemoveControllerAccess(userTag)
+			return errors.Annotate(err, "could not revoke controller access")
+		case state.ControllerAddModelAccess:
+			remainingAccess = state.ControllerLoginAccess
+		case state.ControllerSuperuserAccess:
+			remainingAccess = state.ControllerAddModelAccess
+		}
+		if !current.EqualOrGreaterThan(access) {
+			// The user does not have the access being revoked.
+			return nil
+		}
+		err := c.state.SetControllerAccess(userTag, remainingAccess, c.apiUser)
+		return errors.Annotate(err, "could not revoke controller access")
+
+	default:
+		return errors.Errorf("unknown action %q", arg.Action)
+	}
+}
+
+// fromControllerAccessParam returns the state controller access from
+// the API wireformat type.
+func fromControllerAccessParam(access params.ControllerAccessPermission) (state.ControllerAccess, error) {
+	switch access {
+	case params.ControllerLoginAccess:
+		return state.ControllerLoginAccess, nil
+	case params.ControllerAddModelAccess:
+		return state.ControllerAddModelAccess, nil
+	case params.ControllerSuperuserAccess:
+		return state.ControllerSuperuserAccess, nil
+	}
+	return "", errors.Errorf("invalid controller access permission %q", access)
+}
@@ -0,0 +1,102 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package controller_test
+
+import (
+	"github.com/juju/errors"
+	"github.com/juju/names"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/state"
+	"github.com/juju/juju/testing/factory"
+)
+
+func (s *controllerSuite) modifyControllerAccess(c *gc.C, user names.UserTag, action params.ControllerAction, access params.ControllerAccessPermission) error {
+	args := params.ModifyControllerAccessRequest{
+		Changes: []params.ModifyControllerAccess{{
+			UserTag: user.String(),
+			Action:  action,
+			Access:  access,
+		}}}
+	result, err := s.controller.ModifyControllerAccess(args)
+	c.Assert(err, jc.ErrorIsNil)
+	return result.OneError()
+}
+
+func (s *controllerSuite) assertControllerAccess(c *gc.C, user names.UserTag, expect state.ControllerAccess) {
+	access, err := s.State.ControllerAccess(user)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(access, gc.Equals, expect)
+}
+
+func (s *controllerSuite) TestGrantControllerAccess(c *gc.C) {
+	user := s.Factory.MakeUser(c, &factory.UserParams{NoModelUser: true})
+
+	err := s.modifyControllerAccess(c, user.UserTag(), params.GrantControllerAccess, params.ControllerAddModelAccess)
+	c.Assert(err, jc.ErrorIsNil)
+	s.assertControllerAccess(c, user.UserTag(), state.ControllerAddModelAccess)
+
+	err = s.modifyControllerAccess(c, user.UserTag(), params.GrantControllerAccess, params.ControllerSuperuserAccess)
+	c.Assert(err, jc.ErrorIsNil)
+	s.assertControllerAccess(c, user.UserTag(), state.ControllerSuperuserAccess)
+}
+
+func (s *controllerSuite) TestGrantControllerAccessRemoteUser(c *gc.C) {
+	user := names.NewUserTag("bob@remote")
+	err := s.modifyControllerAccess(c, user, params.GrantControllerAccess, params.ControllerLoginAccess)
+	c.Assert(err, jc.ErrorIsNil)
+	s.assertControllerAccess(c, user, state.ControllerLoginAccess)
+}
+
+func (s *controllerSuite) TestGrantControllerAccessMissingLocalUser(c *gc.C) {
+	err := s.modifyControllerAccess(c, names.NewLocalUserTag("foobar"), params.GrantControllerAccess, params.ControllerLoginAccess)
+	c.Assert(err, gc.ErrorMatches, `could not grant controller access: user "foobar" not found`)
+}
+
+func (s *controllerSuite) TestGrantOnlyGreaterControllerAccess(c *gc.C) {
+	user := s.Factory.MakeUser(c, &factory.UserParams{NoModelUser: true})
+	err := s.modifyControllerAccess(c, user.UserTag(), params.GrantControllerAccess, params.ControllerLoginAccess)
+	c.Assert(err, gc.ErrorMatches, `user already has "login" access`)
+}
+
+func (s *controllerSuite) TestGrantInvalidControllerAccess(c *gc.C) {
+	user := s.Factory.MakeUser(c, &factory.UserParams{NoModelUser: true})
+	err := s.modifyControllerAccess(c, user.UserTag(), params.GrantControllerAccess, "root")
+	c.Assert(err, gc.ErrorMatches, `could not modify controller access: invalid controller access permission "root"`)
+}
+
+func (s *controllerSuite) TestRevokeSuperuserLeavesAddModel(c *gc.C) {
+	user := s.Factory.MakeUser(c, &factory.UserParams{NoModelUser: true})
+	err := s.State.SetControllerAccess(user.UserTag(), state.ControllerSuperuserAccess, s.AdminUserTag(c))
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = s.modifyControllerAccess(c, user.UserTag(), params.RevokeControllerAccess, params.ControllerSuperuserAccess)
+	c.Assert(err, jc.ErrorIsNil)
+	s.assertControllerAccess(c, user.UserTag(), state.ControllerAddModelAccess)
+
+	err = s.modifyControllerAccess(c, user.UserTag(), params.RevokeControllerAccess, params.ControllerAddModelAccess)
+	c.Assert(err, jc.ErrorIsNil)
+	s.assertControllerAccess(c, user.UserTag(), state.ControllerLoginAccess)
+}
+
+func (s *controllerSuite) TestRevokeLoginRemovesAccess(c *gc.C) {
+	user := s.Factory.MakeUser(c, &factory.UserParams{NoModelUser: true})
+	err := s.modifyControllerAccess(c, user.UserTag(), params.RevokeControllerAccess, params.ControllerLoginAccess)
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = s.State.ControllerAccess(user.UserTag())
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+}
+
+func (s *controllerSuite) TestRevokeOwnControllerAccess(c *gc.C) {
+	err := s.modifyControllerAccess(c, s.AdminUserTag(c), params.RevokeControllerAccess, params.ControllerSuperuserAccess)
+	c.Assert(err, gc.ErrorMatches, "cannot revoke your own controller access")
+	s.assertControllerAccess(c, s.AdminUserTag(c), state.ControllerSuperuserAccess)
+}
+
+func (s *controllerSuite) TestRevokeControllerAccessNoAccess(c *gc.C) {
+	err := s.modifyControllerAccess(c, names.NewUserTag("bob@remote"), params.RevokeControllerAccess, params.ControllerLoginAccess)
+	c.Assert(err, gc.ErrorMatches, `user "bob@remote" has no controller access`)
+}
@@ -10,6 +10,7 @@ import (
 	"net/url"
 	"strconv"
 	"syscall"
+	"time"
 
 	"github.com/juju/errors"
 	"github.com/juju/loggo"
@@ -67,6 +68,10 @@ func newDebugLogHandler(
 //   replay -> string - one of [true, false], if true, start the file from the start
 //   noTail -> string - one of [true, false], if true, existing logs are sent back,
 //      - but the command does not wait for new ones.
+//   startTime -> string - an RFC3339 timestamp
+//      - only logs at or after this time are sent
+//   format -> string - one of [text, json]
+//      - if json, each log record is sent as a JSON-encoded params.LogRecord
 func (h *debugLogHandler) ServeHTTP(w http.ResponseWriter, req *http.Request) {
 	server := websocket.Server{
 		Handler: func(conn *websocket.Conn) {
@@ -77,7 +82,7 @@ func (h *debugLogHandler) ServeHTTP(w http.ResponseWriter, req *http.Request) {
 			// Validate before authenticate because the authentication is
 			// dependent on the state connection that is determined during the
 			// validation.
-			st, _, err := h.ctxt.stateForRequestAuthenticatedUser(req)
+			st, _, err := h.ctxt.stateForRequestAuthenticatedUserOrController(req)
 			if err != nil {
 				socket.sendError(err)
 				return
@@ -145,6 +150,8 @@ type debugLogParams struct {
 	fromTheStart  bool
 	noTail        bool
 	backlog       uint
+	startTime     time.Time
+	jsonFormat    bool
 	filterLevel   loggo.Level
 	includeEntity []string
 	excludeEntity []string
@@ -197,6 +204,24 @@ func readDebugLogParams(queryMap url.Values) (*debugLogParams, error) {
 		params.filterLevel = level
 	}
 
+	if value := queryMap.Get("startTime"); value != "" {
+		startTime, err := time.Parse(time.RFC3339Nano, value)
+		if err != nil {
+			return nil, errors.Errorf("startTime value %q is not a valid RFC3339 time", value)
+		}
+		params.startTime = startTime
+	}
+
+	if value := queryMap.Get("format"); value != "" {
+		switch value {
+		case "text":
+		case "json":
+			params.jsonFormat = true
+		default:
+			return nil, errors.Errorf("format value %q is not one of %q, %q", value, "text", "json")
+		}
+	}
+
 	params.includeEntity = queryMap["includeEntity"]
 	params.excludeEntity = queryMap["excludeEntity"]
 	params.includeModule = queryMap["includeModule"]
@@ -4,12 +4,15 @@
 package apiserver
 
 import (
+	"encoding/json"
 	"fmt"
+	"io"
 	"net/http"
 	"time"
 
 	"github.com/juju/errors"
 
+	"github.com/juju/juju/apiserver/params"
 	"github.com/juju/juju/state"
 )
 
@@ -43,8 +46,12 @@ func handleDebugLogDBRequest(
 				return errors.Annotate(tailer.Err(), "tailer stopped")
 			}
 
-			line := formatLogRecord(rec)
-			_, err := socket.Write([]byte(line))
+			var err error
+			if reqParams.jsonFormat {
+				err = sendLogRecordJSON(socket, rec)
+			} else {
+				_, err = socket.Write([]byte(formatLogRecord(rec)))
+			}
 			if err != nil {
 				return errors.Annotate(err, "sending failed")
 			}
@@ -59,6 +66,7 @@ func handleDebugLogDBRequest(
 
 func makeLogTailerParams(reqParams *debugLogParams) *state.LogTailerParams {
 	params := &state.LogTailerParams{
+		StartTime:     reqParams.startTime,
 		MinLevel:      reqParams.filterLevel,
 		NoTail:        reqParams.noTail,
 		InitialLines:  int(reqParams.backlog),
@@ -84,6 +92,22 @@ func formatLogRecord(r *state.LogRecord) string {
 	)
 }
 
+func sendLogRecordJSON(w io.Writer, r *state.LogRecord) error {
+	body, err := json.Marshal(&params.LogRecord{
+		Time:     r.Time,
+		Entity:   r.Entity,
+		Module:   r.Module,
+		Location: r.Location,
+		Level:    r.Level,
+		Message:  r.Message,
+	})
+	if err != nil {
+		return errors.Trace(err)
+	}
+	_, err = w.Write(append(body, '\n'))
+	return errors.Trace(err)
+}
+
 func formatTime(t time.Time) string {
 	return t.In(time.UTC).Format("2006-01-02 15:04:05")
 }
@@ -124,6 +124,52 @@ func (s *debugLogDBIntSuite) TestFullRequest(c *gc.C) {
 	s.assertStops(c, done, tailer)
 }
 
+func (s *debugLogDBIntSuite) TestParamConversionStartTime(c *gc.C) {
+	startTime := time.Date(2016, 5, 4, 3, 2, 1, 0, time.UTC)
+	reqParams := &debugLogParams{
+		startTime: startTime,
+	}
+
+	called := false
+	s.PatchValue(&newLogTailer, func(_ state.LoggingState, params *state.LogTailerParams) (state.LogTailer, error) {
+		called = true
+		c.Assert(params.StartTime, gc.Equals, startTime)
+		return newFakeLogTailer(), nil
+	})
+
+	stop := make(chan struct{})
+	close(stop) // Stop the request immediately.
+	err := handleDebugLogDBRequest(nil, reqParams, s.sock, stop)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(called, jc.IsTrue)
+}
+
+func (s *debugLogDBIntSuite) TestFullRequestJSON(c *gc.C) {
+	tailer := newFakeLogTailer()
+	tailer.logsCh <- &state.LogRecord{
+		Time:     time.Date(2015, 6, 19, 15, 34, 37, 0, time.UTC),
+		Entity:   "machine-99",
+		Module:   "some.where",
+		Location: "code.go:42",
+		Level:    loggo.INFO,
+		Message:  "stuff happened",
+	}
+	s.PatchValue(&newLogTailer, func(_ state.LoggingState, params *state.LogTailerParams) (state.LogTailer, error) {
+		return tailer, nil
+	})
+
+	stop := make(chan struct{})
+	done := s.runRequest(&debugLogParams{jsonFormat: true}, stop)
+
+	s.assertOutput(c, []string{
+		"ok", // sendOk() call needs to happen first.
+		`{"t":"2015-06-19T15:34:37Z","e":"machine-99","m":"some.where","l":"code.go:42","v":3,"x":"stuff happened"}` + "\n",
+	})
+
+	close(stop)
+	s.assertStops(c, done, tailer)
+}
+
 func (s *debugLogDBIntSuite) TestRequestStopsWhenTailerStops(c *gc.C) {
 	tailer := newFakeLogTailer()
 	s.PatchValue(&newLogTailer, func(_ state.LoggingState, params *state.LogTailerParams) (state.LogTailer, error) {
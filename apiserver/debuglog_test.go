@@ -13,6 +13,7 @@ import (
 	gc "gopkg.in/check.v1"
 
 	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/state"
 	"github.com/juju/juju/testing/factory"
 )
 
@@ -28,6 +29,18 @@ func (s *debugLogBaseSuite) TestBadParams(c *gc.C) {
 	s.assertWebsocketClosed(c, reader)
 }
 
+func (s *debugLogBaseSuite) TestBadStartTime(c *gc.C) {
+	reader := s.openWebsocket(c, url.Values{"startTime": {"yesterday"}})
+	assertJSONError(c, reader, `startTime value "yesterday" is not a valid RFC3339 time`)
+	s.assertWebsocketClosed(c, reader)
+}
+
+func (s *debugLogBaseSuite) TestBadFormat(c *gc.C) {
+	reader := s.openWebsocket(c, url.Values{"format": {"xml"}})
+	assertJSONError(c, reader, `format value "xml" is not one of "text", "json"`)
+	s.assertWebsocketClosed(c, reader)
+}
+
 func (s *debugLogBaseSuite) TestWithHTTP(c *gc.C) {
 	uri := s.logURL(c, "http", nil).String()
 	s.sendRequest(c, httpRequestParams{
@@ -66,6 +79,21 @@ func (s *debugLogBaseSuite) TestAgentLoginsRejected(c *gc.C) {
 	s.assertWebsocketClosed(c, reader)
 }
 
+func (s *debugLogBaseSuite) TestControllerAgentLoginsAccepted(c *gc.C) {
+	m, password := s.Factory.MakeMachineReturningPassword(c, &factory.MachineParams{
+		Nonce: "foo-nonce",
+		Jobs:  []state.MachineJob{state.JobManageModel},
+	})
+	header := utils.BasicAuthHeader(m.Tag().String(), password)
+	header.Add(params.MachineNonceHeader, "foo-nonce")
+	conn := s.dialWebsocketInternal(c, url.Values{"noTail": {"true"}}, header)
+	defer conn.Close()
+	reader := bufio.NewReader(conn)
+
+	errResult := readJSONErrorLine(c, reader)
+	c.Assert(errResult.Error, gc.IsNil)
+}
+
 func (s *debugLogBaseSuite) openWebsocket(c *gc.C, values url.Values) *bufio.Reader {
 	conn := s.dialWebsocket(c, values)
 	s.AddCleanup(func(_ *gc.C) { conn.Close() })
@@ -98,7 +98,7 @@ func TestingApiHandler(c *gc.C, srvSt, st *state.State) (*apiHandler, *common.Re
 		state:    srvSt,
 		tag:      names.NewMachineTag("0"),
 	}
-	h, err := newApiHandler(srv, st, nil, nil, st.ModelUUID())
+	h, err := newApiHandler(srv, st, nil, nil, st.ModelUUID(), "")
 	c.Assert(err, jc.ErrorIsNil)
 	return h, h.getResources()
 }
@@ -0,0 +1,175 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+// Package hostfirewaller implements the API facade used by the
+// hostfirewaller worker.
+package hostfirewaller
+
+import (
+	"github.com/juju/errors"
+	"github.com/juju/names"
+
+	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/network"
+	"github.com/juju/juju/state"
+	"github.com/juju/juju/state/watcher"
+)
+
+func init() {
+	common.RegisterStandardFacade("HostFirewaller", 1, newFacade)
+}
+
+// Backend defines the State API used by the hostfirewaller facade.
+type Backend interface {
+	Machine(id string) (Machine, error)
+	MachineAddresses() ([]network.Address, error)
+}
+
+// Machine defines the machine methods used by the hostfirewaller
+// facade.
+type Machine interface {
+	IngressRules() ([]network.IngressRule, error)
+	OpenedPortRanges() ([]network.PortRange, error)
+	WatchIngressRules() state.NotifyWatcher
+}
+
+// Facade implements the API required by the hostfirewaller worker.
+type Facade struct {
+	backend      Backend
+	resources    *common.Resources
+	getCanAccess common.GetAuthFunc
+}
+
+// New returns a new API facade for the hostfirewaller worker.
+func New(backend Backend, resources *common.Resources, authorizer common.Authorizer) (*Facade, error) {
+	if !authorizer.AuthMachineAgent() {
+		return nil, common.ErrPerm
+	}
+	return &Facade{
+		backend:   backend,
+		resources: resources,
+		getCanAccess: func() (common.AuthFunc, error) {
+			return authorizer.AuthOwner, nil
+		},
+	}, nil
+}
+
+// WatchHostFirewallRules returns a NotifyWatcher for each given
+// machine, which notifies when the machine's host firewall rules may
+// have changed.
+func (facade *Facade) WatchHostFirewallRules(args params.Entities) (params.NotifyWatchResults, error) {
+	results := params.NotifyWatchResults{
+		Results: make([]params.NotifyWatchResult, len(args.Entities)),
+	}
+	canAccess, err := facade.getCanAccess()
+	if err != nil {
+		return results, errors.Trace(err)
+	}
+	for i, entity := range args.Entities {
+		machine, err := facade.machine(canAccess, entity.Tag)
+		if err != nil {
+			results.Results[i].Error = common.ServerError(err)
+			continue
+		}
+		w := machine.WatchIngressRules()
+		// Consume the initial event; the worker reads the rules
+		// when it starts.
+		if _, ok := <-w.Changes(); ok {
+			results.Results[i].NotifyWatcherId = facade.resources.Register(w)
+		} else {
+			results.Results[i].Error = common.ServerError(watcher.EnsureErr(w))
+		}
+	}
+	return results, nil
+}
+
+// HostFirewallRules returns the rules the host firewall of each given
+// machine should enforce.
+func (facade *Facade) HostFirewallRules(args params.Entities) (params.HostFirewallRulesResults, error) {
+	results := params.HostFirewallRulesResults{
+		Results: make([]params.HostFirewallRulesResult, len(args.Entities)),
+	}
+	canAccess, err := facade.getCanAccess()
+	if err != nil {
+		return results, errors.Trace(err)
+	}
+	var internalCIDRs []string
+	for i, entity := range args.Entities {
+		machine, err := facade.machine(canAccess, entity.Tag)
+		if err != nil {
+			results.Results[i].Error = common.ServerError(err)
+			continue
+		}
+		if internalCIDRs == nil {
+			internalCIDRs, err = facade.internalCIDRs()
+			if err != nil {
+				return results, errors.Trace(err)
+			}
+		}
+		result, err := hostFirewallRules(machine)
+		if err != nil {
+			results.Results[i].Error = common.ServerError(err)
+			continue
+		}
+		result.InternalCIDRs = internalCIDRs
+		results.Results[i] = result
+	}
+	return results, nil
+}
+
+func (facade *Facade) machine(canAccess common.AuthFunc, tagString string) (Machine, error) {
+	tag, err := names.ParseMachineTag(tagString)
+	if err != nil || !canAccess(tag) {
+		return nil, common.ErrPerm
+	}
+	return facade.backend.Machine(tag.Id())
+}
+
+// internalCIDRs returns a single-address CIDR for each address of the
+// machines in the model.
+func (facade *Facade) internalCIDRs() ([]string, error) {
+	addresses, err := facade.backend.MachineAddresses()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	cidrs := []string{}
+	seen := make(map[string]bool)
+	for _, address := range addresses {
+		var cidr string
+		switch address.Type {
+		case network.IPv4Address:
+			cidr = address.Value + "/32"
+		case network.IPv6Address:
+			cidr = address.Value + "/128"
+		default:
+			continue
+		}
+		if !seen[cidr] {
+			seen[cidr] = true
+			cidrs = append(cidrs, cidr)
+		}
+	}
+	return cidrs, nil
+}
+
+func hostFirewallRules(machine Machine) (params.HostFirewallRulesResult, error) {
+	var result params.HostFirewallRulesResult
+	rules, err := machine.IngressRules()
+	if err != nil {
+		return result, errors.Trace(err)
+	}
+	ports, err := machine.OpenedPortRanges()
+	if err != nil {
+		return result, errors.Trace(err)
+	}
+	result.IngressRules = make([]params.IngressRule, len(rules))
+	for i, rule := range rules {
+		result.IngressRules[i] = params.FromNetworkIngressRule(rule)
+	}
+	result.OpenedPorts = make([]params.PortRange, len(ports))
+	for i, portRange := range ports {
+		result.OpenedPorts[i] = params.FromNetworkPortRange(portRange)
+	}
+	return result, nil
+}
@@ -0,0 +1,150 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package hostfirewaller_test
+
+import (
+	"github.com/juju/errors"
+	"github.com/juju/names"
+	jujutesting "github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/apiserver/hostfirewaller"
+	"github.com/juju/juju/apiserver/params"
+	apiservertesting "github.com/juju/juju/apiserver/testing"
+	"github.com/juju/juju/network"
+	"github.com/juju/juju/state"
+	"github.com/juju/juju/testing"
+)
+
+type facadeSuite struct {
+	testing.BaseSuite
+	backend    *mockBackend
+	resources  *common.Resources
+	authorizer *apiservertesting.FakeAuthorizer
+	facade     *hostfirewaller.Facade
+}
+
+var _ = gc.Suite(&facadeSuite{})
+
+func (s *facadeSuite) SetUpTest(c *gc.C) {
+	s.BaseSuite.SetUpTest(c)
+	s.backend = &mockBackend{
+		machine: &mockMachine{
+			rules: []network.IngressRule{
+				{network.MustParsePortRange("80/tcp"), "10.0.0.0/8"},
+			},
+			ports: []network.PortRange{
+				network.MustParsePortRange("80/tcp"),
+				network.MustParsePortRange("3306/tcp"),
+			},
+			watcher: apiservertesting.NewFakeNotifyWatcher(),
+		},
+		addresses: []network.Address{
+			network.NewAddress("10.0.0.1"),
+			network.NewAddress("2001:db8::1"),
+			network.NewAddress("example.com"),
+			network.NewAddress("10.0.0.1"),
+		},
+	}
+	s.resources = common.NewResources()
+	s.AddCleanup(func(*gc.C) { s.resources.StopAll() })
+	s.authorizer = &apiservertesting.FakeAuthorizer{Tag: names.NewMachineTag("1")}
+	facade, err := hostfirewaller.New(s.backend, s.resources, s.authorizer)
+	c.Assert(err, jc.ErrorIsNil)
+	s.facade = facade
+}
+
+func (s *facadeSuite) TestNewRequiresMachineAgent(c *gc.C) {
+	s.authorizer.Tag = names.NewUnitTag("mysql/0")
+	_, err := hostfirewaller.New(s.backend, s.resources, s.authorizer)
+	c.Assert(err, gc.Equals, common.ErrPerm)
+}
+
+func (s *facadeSuite) TestHostFirewallRules(c *gc.C) {
+	results, err := s.facade.HostFirewallRules(params.Entities{
+		Entities: []params.Entity{{"machine-0"}, {"machine-1"}, {"unit-mysql-0"}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, jc.DeepEquals, params.HostFirewallRulesResults{
+		Results: []params.HostFirewallRulesResult{{
+			Error: apiservertesting.ErrUnauthorized,
+		}, {
+			IngressRules: []params.IngressRule{{
+				PortRange:  params.PortRange{80, 80, "tcp"},
+				SourceCIDR: "10.0.0.0/8",
+			}},
+			OpenedPorts: []params.PortRange{
+				{80, 80, "tcp"},
+				{3306, 3306, "tcp"},
+			},
+			InternalCIDRs: []string{"10.0.0.1/32", "2001:db8::1/128"},
+		}, {
+			Error: apiservertesting.ErrUnauthorized,
+		}},
+	})
+	s.backend.stub.CheckCallNames(c, "Machine", "MachineAddresses")
+	s.backend.stub.CheckCall(c, 0, "Machine", "1")
+}
+
+func (s *facadeSuite) TestHostFirewallRulesError(c *gc.C) {
+	s.backend.machine.err = errors.New("boom")
+	results, err := s.facade.HostFirewallRules(params.Entities{
+		Entities: []params.Entity{{"machine-1"}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results[0].Error, gc.ErrorMatches, "boom")
+}
+
+func (s *facadeSuite) TestWatchHostFirewallRules(c *gc.C) {
+	s.backend.machine.watcher.C <- struct{}{}
+	results, err := s.facade.WatchHostFirewallRules(params.Entities{
+		Entities: []params.Entity{{"machine-0"}, {"machine-1"}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, jc.DeepEquals, params.NotifyWatchResults{
+		Results: []params.NotifyWatchResult{{
+			Error: apiservertesting.ErrUnauthorized,
+		}, {
+			NotifyWatcherId: "1",
+		}},
+	})
+	c.Assert(s.resources.Get("1"), gc.Equals, s.backend.machine.watcher)
+}
+
+type mockBackend struct {
+	stub      jujutesting.Stub
+	machine   *mockMachine
+	addresses []network.Address
+}
+
+func (b *mockBackend) Machine(id string) (hostfirewaller.Machine, error) {
+	b.stub.AddCall("Machine", id)
+	return b.machine, b.stub.NextErr()
+}
+
+func (b *mockBackend) MachineAddresses() ([]network.Address, error) {
+	b.stub.AddCall("MachineAddresses")
+	return b.addresses, b.stub.NextErr()
+}
+
+type mockMachine struct {
+	rules   []network.IngressRule
+	ports   []network.PortRange
+	watcher *apiservertesting.FakeNotifyWatcher
+	err     error
+}
+
+func (m *mockMachine) IngressRules() ([]network.IngressRule, error) {
+	return m.rules, m.err
+}
+
+func (m *mockMachine) OpenedPortRanges() ([]network.PortRange, error) {
+	return m.ports, m.err
+}
+
+func (m *mockMachine) WatchIngressRules() state.NotifyWatcher {
+	return m.watcher
+}
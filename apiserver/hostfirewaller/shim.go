@@ -0,0 +1,67 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package hostfirewaller
+
+import (
+	"github.com/juju/errors"
+
+	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/network"
+	"github.com/juju/juju/state"
+)
+
+// newFacade wraps New to express the supplied *state.State as a Backend.
+func newFacade(st *state.State, res *common.Resources, auth common.Authorizer) (*Facade, error) {
+	return New(backendShim{st}, res, auth)
+}
+
+type backendShim struct {
+	st *state.State
+}
+
+// Machine is part of the Backend interface.
+func (b backendShim) Machine(id string) (Machine, error) {
+	machine, err := b.st.Machine(id)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return machineShim{machine}, nil
+}
+
+// MachineAddresses is part of the Backend interface.
+func (b backendShim) MachineAddresses() ([]network.Address, error) {
+	machines, err := b.st.AllMachines()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	var addresses []network.Address
+	for _, machine := range machines {
+		addresses = append(addresses, machine.Addresses()...)
+	}
+	return addresses, nil
+}
+
+type machineShim struct {
+	*state.Machine
+}
+
+// OpenedPortRanges is part of the Machine interface.
+func (m machineShim) OpenedPortRanges() ([]network.PortRange, error) {
+	allPorts, err := m.AllPorts()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	seen := make(map[network.PortRange]bool)
+	var ports []network.PortRange
+	for _, p := range allPorts {
+		for portRange := range p.AllPortRanges() {
+			if !seen[portRange] {
+				seen[portRange] = true
+				ports = append(ports, portRange)
+			}
+		}
+	}
+	network.SortPortRanges(ports)
+	return ports, nil
+}
@@ -89,6 +89,46 @@ func (ctxt *httpContext) stateForRequestAuthenticatedUser(r *http.Request) (*sta
 	}
 }
 
+// stateForRequestAuthenticatedUserOrController is like
+// stateForRequestAuthenticated except that it also verifies that the
+// authenticated entity is either a user or a controller machine
+// agent. As with API logins, controller machines are checked against
+// the controller model so that they can access hosted models.
+func (ctxt *httpContext) stateForRequestAuthenticatedUserOrController(r *http.Request) (*state.State, state.Entity, error) {
+	st, err := ctxt.stateForRequestUnauthenticated(r)
+	if err != nil {
+		return nil, nil, errors.Trace(err)
+	}
+	req, err := ctxt.loginRequest(r)
+	if err != nil {
+		return nil, nil, errors.NewUnauthorized(err, "")
+	}
+	tag, err := names.ParseTag(req.AuthTag)
+	if err != nil {
+		return nil, nil, errors.NewUnauthorized(err, "")
+	}
+	switch tag.(type) {
+	case names.UserTag:
+		entity, _, err := checkCreds(st, req, true, ctxt.srv.authCtxt)
+		if err != nil {
+			if !common.IsDischargeRequiredError(err) {
+				err = errors.NewUnauthorized(err, "")
+			}
+			return nil, nil, errors.Trace(err)
+		}
+		return st, entity, nil
+	case names.MachineTag:
+		entity, _, err := checkCreds(ctxt.srv.state, req, false, ctxt.srv.authCtxt)
+		if err != nil {
+			return nil, nil, errors.NewUnauthorized(err, "")
+		}
+		if machine, ok := entity.(*state.Machine); ok && machine.IsManager() {
+			return st, entity, nil
+		}
+	}
+	return nil, nil, errors.Trace(common.ErrBadCreds)
+}
+
 // stateForRequestAuthenticatedUser is like stateForRequestAuthenticated
 // except that it also verifies that the authenticated entity is a user.
 func (ctxt *httpContext) stateForRequestAuthenticatedAgent(r *http.Request) (*state.State, state.Entity, error) {
@@ -0,0 +1,129 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package apiserver
+
+import (
+	"net/http"
+	"reflect"
+	"time"
+
+	"github.com/juju/errors"
+
+	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/rpc"
+	"github.com/juju/juju/rpc/rpcreflect"
+	"github.com/juju/juju/utils/prometheus"
+)
+
+// The API server's own metrics. Metrics from the rest of the
+// controller (state, the dependency engine) are registered with the
+// same default registry by the packages that record them.
+var (
+	apiConnections = prometheus.NewGauge(
+		"juju_apiserver_connections",
+		"Number of open API connections.",
+	)
+	apiRequests = prometheus.NewCounterVec(
+		"juju_apiserver_requests_total",
+		"Number of API requests served, by facade and method.",
+		"facade", "method",
+	)
+	apiRequestErrors = prometheus.NewCounterVec(
+		"juju_apiserver_request_errors_total",
+		"Number of API requests that returned an error, by facade and method.",
+		"facade", "method",
+	)
+	apiRequestDuration = prometheus.NewHistogramVec(
+		"juju_apiserver_request_duration_seconds",
+		"Time spent serving API requests, by facade and method.",
+		nil,
+		"facade", "method",
+	)
+	logSinkConnections = prometheus.NewGauge(
+		"juju_apiserver_logsink_connections",
+		"Number of agents connected to the logsink.",
+	)
+	logSinkRecords = prometheus.NewCounter(
+		"juju_apiserver_logsink_records_total",
+		"Number of log records received by the logsink.",
+	)
+	logSinkBytes = prometheus.NewCounter(
+		"juju_apiserver_logsink_message_bytes_total",
+		"Total size of the messages of log records received by the logsink.",
+	)
+)
+
+func init() {
+	prometheus.MustRegister(
+		apiConnections,
+		apiRequests,
+		apiRequestErrors,
+		apiRequestDuration,
+		logSinkConnections,
+		logSinkRecords,
+		logSinkBytes,
+	)
+}
+
+// unknownLabel is recorded in place of the names of facades and
+// methods that the API server does not serve. The names come from the
+// client, so recording them unchecked would allow a client to create
+// any number of metrics.
+const unknownLabel = "unknown"
+
+// recordRequest records the metrics for an API request that has been
+// replied to.
+func recordRequest(req rpc.Request, hdr *rpc.Header, timeSpent time.Duration) {
+	facade, method := requestLabels(req)
+	apiRequests.With(facade, method).Inc()
+	if hdr.Error != "" {
+		apiRequestErrors.With(facade, method).Inc()
+	}
+	apiRequestDuration.With(facade, method).Observe(timeSpent.Seconds())
+}
+
+// requestLabels returns the facade and method labels to record for
+// the given request. Facades that are not registered, and methods
+// that the facade does not have, are recorded as unknownLabel.
+func requestLabels(req rpc.Request) (facade, method string) {
+	var facadeType reflect.Type
+	if req.Type == "Admin" {
+		// The Admin facade is served before login, and is not
+		// registered with the other facades.
+		facadeType = reflect.TypeOf((*adminApiV3)(nil))
+	} else {
+		var err error
+		facadeType, err = common.Facades.GetType(req.Type, req.Version)
+		if err != nil {
+			return unknownLabel, unknownLabel
+		}
+	}
+	if _, err := rpcreflect.ObjTypeOf(facadeType).Method(req.Action); err != nil {
+		return req.Type, unknownLabel
+	}
+	return req.Type, req.Action
+}
+
+// metricsHandler serves the metrics in the default registry in the
+// Prometheus text exposition format, to authenticated users.
+type metricsHandler struct {
+	ctxt     httpContext
+	registry *prometheus.Registry
+}
+
+// ServeHTTP implements the http.Handler interface.
+func (h *metricsHandler) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	if req.Method != "GET" {
+		sendError(w, errors.MethodNotAllowedf("unsupported method: %q", req.Method))
+		return
+	}
+	if _, _, err := h.ctxt.stateForRequestAuthenticatedUser(req); err != nil {
+		sendError(w, errors.Trace(err))
+		return
+	}
+	w.Header().Set("Content-Type", prometheus.ContentType)
+	if err := h.registry.Write(w); err != nil {
+		logger.Errorf("cannot write metrics: %v", err)
+	}
+}
@@ -0,0 +1,88 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package apiserver_test
+
+import (
+	"encoding/json"
+	"net/http"
+
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/utils/prometheus"
+)
+
+type metricsSuite struct {
+	authHttpSuite
+}
+
+var _ = gc.Suite(&metricsSuite{})
+
+func (s *metricsSuite) metricsURL(c *gc.C) string {
+	u := s.baseURL(c)
+	u.Path = "/introspection/metrics"
+	return u.String()
+}
+
+func (s *metricsSuite) TestMetrics(c *gc.C) {
+	// Make an API request so that there is something to report.
+	err := s.APIState.Ping()
+	c.Assert(err, jc.ErrorIsNil)
+
+	resp := s.authRequest(c, httpRequestParams{
+		method: "GET",
+		url:    s.metricsURL(c),
+	})
+	body := string(assertResponse(c, resp, http.StatusOK, prometheus.ContentType))
+	c.Check(body, jc.Contains, "# TYPE juju_apiserver_connections gauge\n")
+	c.Check(body, jc.Contains, `juju_apiserver_requests_total{facade="Pinger",method="Ping"} `)
+	c.Check(body, jc.Contains, "# TYPE juju_apiserver_request_duration_seconds histogram\n")
+	c.Check(body, jc.Contains, "# TYPE juju_apiserver_logsink_records_total counter\n")
+	c.Check(body, jc.Contains, "# TYPE juju_state_txn_retries_total counter\n")
+	c.Check(body, jc.Contains, "# TYPE juju_state_allwatcher_entries gauge\n")
+	c.Check(body, jc.Contains, "# TYPE juju_dependency_worker_restarts_total counter\n")
+}
+
+func (s *metricsSuite) TestMetricsUnknownRequests(c *gc.C) {
+	// The facade and method names come from the client, so unknown
+	// ones are not recorded as given.
+	err := s.APIState.APICall("NoSuchFacade", 1, "", "NoSuchMethod", nil, nil)
+	c.Assert(err, gc.NotNil)
+	err = s.APIState.APICall("Pinger", 1, "", "NoSuchMethod", nil, nil)
+	c.Assert(err, gc.NotNil)
+
+	resp := s.authRequest(c, httpRequestParams{
+		method: "GET",
+		url:    s.metricsURL(c),
+	})
+	body := string(assertResponse(c, resp, http.StatusOK, prometheus.ContentType))
+	c.Check(body, jc.Contains, `juju_apiserver_requests_total{facade="unknown",method="unknown"} `)
+	c.Check(body, jc.Contains, `juju_apiserver_requests_total{facade="Pinger",method="unknown"} `)
+	c.Check(body, gc.Not(jc.Contains), "NoSuch")
+}
+
+func (s *metricsSuite) TestMetricsUnauthorized(c *gc.C) {
+	resp := s.sendRequest(c, httpRequestParams{
+		method: "GET",
+		url:    s.metricsURL(c),
+	})
+	body := assertResponse(c, resp, http.StatusUnauthorized, params.ContentTypeJSON)
+	var jsonResp params.ErrorResult
+	err := json.Unmarshal(body, &jsonResp)
+	c.Assert(err, jc.ErrorIsNil, gc.Commentf("body: %s", body))
+	c.Assert(jsonResp.Error.Message, gc.Matches, ".*no credentials provided")
+}
+
+func (s *metricsSuite) TestMetricsMethodNotAllowed(c *gc.C) {
+	resp := s.authRequest(c, httpRequestParams{
+		method: "POST",
+		url:    s.metricsURL(c),
+	})
+	body := assertResponse(c, resp, http.StatusMethodNotAllowed, params.ContentTypeJSON)
+	var jsonResp params.ErrorResult
+	err := json.Unmarshal(body, &jsonResp)
+	c.Assert(err, jc.ErrorIsNil, gc.Commentf("body: %s", body))
+	c.Assert(jsonResp.Error.Message, gc.Matches, `unsupported method: "POST"`)
+}
@@ -0,0 +1,233 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package apiserver
+
+import (
+	"net"
+	"sync"
+	"time"
+
+	"github.com/juju/errors"
+	"github.com/juju/utils/clock"
+
+	"github.com/juju/juju/apiserver/common"
+)
+
+// LoginThrottleConfig holds the limits applied to user logins. Zero
+// values are replaced by the defaults below.
+type LoginThrottleConfig struct {
+	// MaxFailuresPerSource is the number of failed user logins from a
+	// single IP address, within FailureWindow, that will lock out
+	// further user logins from that address.
+	MaxFailuresPerSource int
+
+	// MaxFailuresPerUser is the number of failed logins as a single
+	// user, within FailureWindow, that will lock out further logins
+	// as that user.
+	MaxFailuresPerUser int
+
+	// FailureWindow is the period over which failed logins are
+	// counted.
+	FailureWindow time.Duration
+
+	// InitialLockout is the duration of the first lockout. Each
+	// subsequent lockout of the same address or user is twice as
+	// long as the last, up to MaxLockout.
+	InitialLockout time.Duration
+
+	// MaxLockout is the longest a lockout will last.
+	MaxLockout time.Duration
+
+	// MaxConcurrentLogins is the number of user logins that may be
+	// processed at once. Agent logins are limited separately.
+	MaxConcurrentLogins int
+
+	// Clock is used to time failures and lockouts.
+	Clock clock.Clock
+}
+
+// The per-user limit is deliberately low to slow down password
+// guessing that is spread across many addresses. The trade-off is that
+// anyone who can reach the API server can lock a user out, including
+// the controller admin, by failing to log in as them; the lockout
+// expires after InitialLockout (doubling up to MaxLockout) and agent
+// logins are unaffected. Controllers exposed to untrusted networks may
+// raise MaxFailuresPerUser via the agent configuration and rely on the
+// per-source limit instead.
+const (
+	defaultMaxFailuresPerSource = 20
+	defaultMaxFailuresPerUser   = 5
+	defaultFailureWindow        = 10 * time.Minute
+	defaultInitialLockout       = time.Minute
+	defaultMaxLockout           = time.Hour
+	defaultMaxConcurrentLogins  = 10
+)
+
+// withDefaults returns a copy of the config with the defaults filled in.
+func (config LoginThrottleConfig) withDefaults() LoginThrottleConfig {
+	if config.MaxFailuresPerSource <= 0 {
+		config.MaxFailuresPerSource = defaultMaxFailuresPerSource
+	}
+	if config.MaxFailuresPerUser <= 0 {
+		config.MaxFailuresPerUser = defaultMaxFailuresPerUser
+	}
+	if config.FailureWindow <= 0 {
+		config.FailureWindow = defaultFailureWindow
+	}
+	if config.InitialLockout <= 0 {
+		config.InitialLockout = defaultInitialLockout
+	}
+	if config.MaxLockout <= 0 {
+		config.MaxLockout = defaultMaxLockout
+	}
+	if config.MaxLockout < config.InitialLockout {
+		config.MaxLockout = config.InitialLockout
+	}
+	if config.MaxConcurrentLogins <= 0 {
+		config.MaxConcurrentLogins = defaultMaxConcurrentLogins
+	}
+	if config.Clock == nil {
+		config.Clock = clock.WallClock
+	}
+	return config
+}
+
+// loginThrottle counts failed user logins by source address and by
+// user, and locks out those that fail too often.
+type loginThrottle struct {
+	config LoginThrottleConfig
+
+	mu        sync.Mutex
+	sources   map[string]*failureRecord
+	users     map[string]*failureRecord
+	lastPrune time.Time
+}
+
+// failureRecord holds the recent failures of a single source address
+// or user.
+type failureRecord struct {
+	// failures holds the times of the failures since the last
+	// lockout, within the failure window.
+	failures []time.Time
+
+	// lockouts holds the number of times the record has been locked
+	// out without being forgotten; it determines the length of the
+	// next lockout.
+	lockouts uint
+
+	// lockedUntil holds when the current or last lockout ends.
+	lockedUntil time.Time
+}
+
+func newLoginThrottle(config LoginThrottleConfig) *loginThrottle {
+	config = config.withDefaults()
+	return &loginThrottle{
+		config:    config,
+		sources:   make(map[string]*failureRecord),
+		users:     make(map[string]*failureRecord),
+		lastPrune: config.Clock.Now(),
+	}
+}
+
+// sourceHost returns the host part of a remote address, so that all
+// connections from one host share the same record.
+func sourceHost(remoteAddr string) string {
+	host, _, err := net.SplitHostPort(remoteAddr)
+	if err != nil {
+		return remoteAddr
+	}
+	return host
+}
+
+// check returns ErrLoginLockedOut if logins from the remote address,
+// or as the user, are currently locked out. The user may be empty if
+// it is not known, as when logging in with a macaroon.
+func (t *loginThrottle) check(remoteAddr, user string) error {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	now := t.config.Clock.Now()
+	if record, ok := t.sources[sourceHost(remoteAddr)]; ok && now.Before(record.lockedUntil) {
+		logger.Debugf("logins from %s locked out until %v", remoteAddr, record.lockedUntil)
+		return errors.Trace(common.ErrLoginLockedOut)
+	}
+	if record, ok := t.users[user]; ok && user != "" && now.Before(record.lockedUntil) {
+		logger.Debugf("logins as %s locked out until %v", user, record.lockedUntil)
+		return errors.Trace(common.ErrLoginLockedOut)
+	}
+	return nil
+}
+
+// failed records a failed login from the remote address as the user,
+// locking out either if they have failed too often.
+func (t *loginThrottle) failed(remoteAddr, user string) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	now := t.config.Clock.Now()
+	t.prune(now)
+	t.addFailure(t.sources, sourceHost(remoteAddr), t.config.MaxFailuresPerSource, now)
+	if user != "" {
+		t.addFailure(t.users, user, t.config.MaxFailuresPerUser, now)
+	}
+}
+
+// succeeded records a successful login as the user, which resets the
+// user's failures. Failures from the remote address are kept, so that
+// a valid login cannot be used to reset the count for an address that
+// is trying the passwords of other users.
+func (t *loginThrottle) succeeded(user string) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	delete(t.users, user)
+}
+
+func (t *loginThrottle) addFailure(records map[string]*failureRecord, key string, maxFailures int, now time.Time) {
+	record, ok := records[key]
+	if !ok {
+		record = &failureRecord{}
+		records[key] = record
+	}
+	record.failures = append(t.recent(record.failures, now), now)
+	if len(record.failures) < maxFailures {
+		return
+	}
+	lockout := t.config.InitialLockout << record.lockouts
+	if lockout > t.config.MaxLockout || lockout <= 0 {
+		lockout = t.config.MaxLockout
+	} else {
+		record.lockouts++
+	}
+	record.lockedUntil = now.Add(lockout)
+	record.failures = nil
+	logger.Warningf("too many failed logins for %s; locked out for %v", key, lockout)
+}
+
+// recent returns the failures that are within the failure window.
+func (t *loginThrottle) recent(failures []time.Time, now time.Time) []time.Time {
+	cutoff := now.Add(-t.config.FailureWindow)
+	for i, failure := range failures {
+		if failure.After(cutoff) {
+			return failures[i:]
+		}
+	}
+	return nil
+}
+
+// prune forgets the records that have no recent failures, and whose
+// last lockout ended more than MaxLockout ago; the next lockout of an
+// address or user that has been forgotten starts again at
+// InitialLockout. Pruning happens at most once per failure window.
+func (t *loginThrottle) prune(now time.Time) {
+	if now.Sub(t.lastPrune) < t.config.FailureWindow {
+		return
+	}
+	t.lastPrune = now
+	for _, records := range []map[string]*failureRecord{t.sources, t.users} {
+		for key, record := range records {
+			record.failures = t.recent(record.failures, now)
+			if len(record.failures) == 0 && now.Sub(record.lockedUntil) > t.config.MaxLockout {
+				delete(records, key)
+			}
+		}
+	}
+}
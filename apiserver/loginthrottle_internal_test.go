@@ -0,0 +1,126 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package apiserver
+
+import (
+	"time"
+
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/apiserver/common"
+	coretesting "github.com/juju/juju/testing"
+)
+
+type loginThrottleSuite struct {
+	clock    *coretesting.Clock
+	throttle *loginThrottle
+}
+
+var _ = gc.Suite(&loginThrottleSuite{})
+
+func (s *loginThrottleSuite) SetUpTest(c *gc.C) {
+	s.clock = coretesting.NewClock(time.Now())
+	s.throttle = newLoginThrottle(LoginThrottleConfig{
+		MaxFailuresPerSource: 4,
+		MaxFailuresPerUser:   2,
+		FailureWindow:        time.Minute,
+		InitialLockout:       time.Minute,
+		MaxLockout:           4 * time.Minute,
+		Clock:                s.clock,
+	})
+}
+
+func (s *loginThrottleSuite) assertLockedOut(c *gc.C, remoteAddr, user string) {
+	err := s.throttle.check(remoteAddr, user)
+	c.Assert(errors.Cause(err), gc.Equals, common.ErrLoginLockedOut)
+}
+
+func (s *loginThrottleSuite) TestDefaults(c *gc.C) {
+	throttle := newLoginThrottle(LoginThrottleConfig{})
+	c.Assert(throttle.config.MaxFailuresPerSource, gc.Equals, defaultMaxFailuresPerSource)
+	c.Assert(throttle.config.MaxFailuresPerUser, gc.Equals, defaultMaxFailuresPerUser)
+	c.Assert(throttle.config.FailureWindow, gc.Equals, defaultFailureWindow)
+	c.Assert(throttle.config.InitialLockout, gc.Equals, defaultInitialLockout)
+	c.Assert(throttle.config.MaxLockout, gc.Equals, defaultMaxLockout)
+	c.Assert(throttle.config.MaxConcurrentLogins, gc.Equals, defaultMaxConcurrentLogins)
+	c.Assert(throttle.config.Clock, gc.NotNil)
+}
+
+func (s *loginThrottleSuite) TestUserLockout(c *gc.C) {
+	s.throttle.failed("10.0.0.1:1234", "bob@local")
+	c.Assert(s.throttle.check("10.0.0.1:1234", "bob@local"), jc.ErrorIsNil)
+	s.throttle.failed("10.0.0.2:1234", "bob@local")
+
+	// The user is locked out from any address, while other users
+	// are not.
+	s.assertLockedOut(c, "10.0.0.3:1234", "bob@local")
+	c.Assert(s.throttle.check("10.0.0.1:1234", "mary@local"), jc.ErrorIsNil)
+
+	s.clock.Advance(time.Minute)
+	c.Assert(s.throttle.check("10.0.0.1:1234", "bob@local"), jc.ErrorIsNil)
+}
+
+func (s *loginThrottleSuite) TestSourceLockout(c *gc.C) {
+	for _, user := range []string{"a@local", "b@local", "c@local"} {
+		s.throttle.failed("10.0.0.1:1234", user)
+	}
+	c.Assert(s.throttle.check("10.0.0.1:1234", "d@local"), jc.ErrorIsNil)
+	// The port is ignored.
+	s.throttle.failed("10.0.0.1:5678", "d@local")
+
+	s.assertLockedOut(c, "10.0.0.1:9999", "e@local")
+	s.assertLockedOut(c, "10.0.0.1:9999", "")
+	c.Assert(s.throttle.check("10.0.0.2:1234", "e@local"), jc.ErrorIsNil)
+}
+
+func (s *loginThrottleSuite) TestFailuresOutsideWindow(c *gc.C) {
+	s.throttle.failed("10.0.0.1:1234", "bob@local")
+	s.clock.Advance(time.Minute)
+	s.throttle.failed("10.0.0.1:1234", "bob@local")
+	c.Assert(s.throttle.check("10.0.0.1:1234", "bob@local"), jc.ErrorIsNil)
+}
+
+func (s *loginThrottleSuite) TestLockoutBackoff(c *gc.C) {
+	for _, expect := range []time.Duration{
+		time.Minute, 2 * time.Minute, 4 * time.Minute, 4 * time.Minute,
+	} {
+		s.throttle.failed("10.0.0.1:1234", "bob@local")
+		s.throttle.failed("10.0.0.2:1234", "bob@local")
+		s.clock.Advance(expect - time.Second)
+		s.assertLockedOut(c, "10.0.0.3:1234", "bob@local")
+		s.clock.Advance(time.Second)
+		c.Assert(s.throttle.check("10.0.0.3:1234", "bob@local"), jc.ErrorIsNil)
+	}
+}
+
+func (s *loginThrottleSuite) TestSucceededResetsUser(c *gc.C) {
+	s.throttle.failed("10.0.0.1:1234", "bob@local")
+	s.throttle.succeeded("bob@local")
+	s.throttle.failed("10.0.0.1:1234", "bob@local")
+	c.Assert(s.throttle.check("10.0.0.1:1234", "bob@local"), jc.ErrorIsNil)
+}
+
+func (s *loginThrottleSuite) TestUnknownUser(c *gc.C) {
+	// Failures with no user only count against the address.
+	for i := 0; i < 3; i++ {
+		s.throttle.failed("10.0.0.1:1234", "")
+	}
+	c.Assert(s.throttle.check("10.0.0.1:1234", ""), jc.ErrorIsNil)
+	s.throttle.failed("10.0.0.1:1234", "")
+	s.assertLockedOut(c, "10.0.0.1:1234", "")
+}
+
+func (s *loginThrottleSuite) TestPrune(c *gc.C) {
+	s.throttle.failed("10.0.0.1:1234", "bob@local")
+	s.throttle.failed("10.0.0.2:1234", "bob@local")
+	// Once the lockout has been over for MaxLockout, the user is
+	// forgotten, and the next lockout starts again at InitialLockout.
+	s.clock.Advance(6 * time.Minute)
+	s.throttle.failed("10.0.0.3:1234", "bob@local")
+	s.throttle.failed("10.0.0.4:1234", "bob@local")
+	s.clock.Advance(time.Minute)
+	c.Assert(s.throttle.check("10.0.0.5:1234", "bob@local"), jc.ErrorIsNil)
+}
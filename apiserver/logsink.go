@@ -78,6 +78,8 @@ func (h *logSinkHandler) ServeHTTP(w http.ResponseWriter, req *http.Request) {
 			// error.  This way the first line of the socket is always a json
 			// formatted simple error.
 			h.sendError(socket, req, nil)
+			logSinkConnections.Inc()
+			defer logSinkConnections.Dec()
 
 			logCh := h.receiveLogs(socket)
 			for {
@@ -85,6 +87,8 @@ func (h *logSinkHandler) ServeHTTP(w http.ResponseWriter, req *http.Request) {
 				case <-h.ctxt.stop():
 					return
 				case m := <-logCh:
+					logSinkRecords.Inc()
+					logSinkBytes.Add(uint64(len(m.Message)))
 					fileErr := h.logToFile(filePrefix, m)
 					if fileErr != nil {
 						logger.Errorf("logging to logsink.log failed: %v", fileErr)
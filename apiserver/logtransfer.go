@@ -0,0 +1,242 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package apiserver
+
+import (
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/juju/errors"
+	"github.com/juju/names"
+	"golang.org/x/net/websocket"
+
+	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/core/migration"
+	"github.com/juju/juju/state"
+)
+
+const (
+	// logTransferBatchSize is the maximum number of log records
+	// which are written to the database in one go.
+	logTransferBatchSize = 1000
+
+	// logTransferFlushInterval is the maximum time that received log
+	// records are held before being written to the database.
+	logTransferFlushInterval = 2 * time.Second
+)
+
+// logTransferHandler receives the logs of a model being migrated to
+// this controller and writes them to the logs database, recording
+// the time of the latest record received so that an interrupted
+// transfer can be resumed.
+type logTransferHandler struct {
+	ctxt httpContext
+}
+
+func newLogTransferHandler(ctxt httpContext) http.Handler {
+	return &logTransferHandler{ctxt: ctxt}
+}
+
+// ServeHTTP implements the http.Handler interface.
+//
+// The model whose logs are being transferred is identified by the
+// "model-uuid" query parameter. Once a nil error has been sent back,
+// the client sends a JSON-encoded params.LogRecord for each log
+// message.
+func (h *logTransferHandler) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	server := websocket.Server{
+		Handler: func(socket *websocket.Conn) {
+			defer socket.Close()
+
+			st, err := h.modelState(req)
+			if err != nil {
+				h.sendError(socket, req, err)
+				return
+			}
+
+			importer := state.NewLogImporter(st)
+			defer importer.Close()
+			lastSent := state.NewLastSentLogger(st, migration.LogTransferSink)
+
+			// If we get to here, no more errors to report, so we
+			// report a nil error. This way the first line of the
+			// socket is always a json formatted simple error.
+			h.sendError(socket, req, nil)
+
+			if err := h.importLogs(socket, importer, lastSent); err != nil {
+				logger.Errorf("log transfer for model %s failed: %v", st.ModelUUID(), err)
+			}
+		},
+	}
+	server.ServeHTTP(w, req)
+}
+
+// modelState checks that the request has been made by a controller
+// administrator and returns the State for the model whose logs are
+// being transferred.
+func (h *logTransferHandler) modelState(req *http.Request) (*state.State, error) {
+	st, entity, err := h.ctxt.stateForRequestAuthenticatedUser(req)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	isAdmin, err := st.IsControllerAdministrator(entity.Tag().(names.UserTag))
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if !isAdmin {
+		return nil, errors.Trace(common.ErrPerm)
+	}
+
+	modelUUID := req.URL.Query().Get("model-uuid")
+	if !names.IsValidModel(modelUUID) {
+		return nil, errors.NotValidf("model UUID %q", modelUUID)
+	}
+	// Logs are transferred after the model has been activated, so
+	// the model's migration mode isn't checked.
+	if _, err := st.GetModel(names.NewModelTag(modelUUID)); err != nil {
+		return nil, errors.Trace(err)
+	}
+	modelSt, err := h.ctxt.srv.statePool.Get(modelUUID)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return modelSt, nil
+}
+
+// importLogs writes the log records received over the socket to the
+// database in batches until the client closes the connection.
+//
+// The client sends records in time order, and a transfer is resumed
+// after the recorded time, so the recorded time must only cover
+// complete times: until the client is done, records with the same
+// time as the last record received are held back. If the connection
+// fails, the held-back records are dropped so that they are sent
+// again when the transfer is resumed.
+func (h *logTransferHandler) importLogs(
+	socket *websocket.Conn,
+	importer *state.LogImporter,
+	lastSent *state.DbLoggerLastSent,
+) error {
+	var batch []*state.LogRecord
+	flush := func(final bool) error {
+		n := len(batch)
+		if !final {
+			n = completeLogRecords(batch)
+		}
+		if n == 0 {
+			if len(batch) < logTransferBatchSize {
+				return nil
+			}
+			// A full batch of records all have the same time.
+			// Write them rather than let the batch grow without
+			// bound, but don't record the time: if the transfer
+			// is interrupted, they will be sent again.
+			if err := importer.Import(batch); err != nil {
+				return errors.Annotate(err, "writing logs")
+			}
+			batch = batch[:0]
+			return nil
+		}
+		if err := importer.Import(batch[:n]); err != nil {
+			return errors.Annotate(err, "writing logs")
+		}
+		if err := lastSent.Set(batch[n-1].Time); err != nil {
+			return errors.Annotate(err, "recording last transferred log time")
+		}
+		batch = append(batch[:0], batch[n:]...)
+		return nil
+	}
+
+	done := make(chan struct{})
+	defer close(done)
+	logCh, errCh := h.receiveLogs(socket, done)
+	timer := time.NewTimer(logTransferFlushInterval)
+	defer timer.Stop()
+	for {
+		select {
+		case <-h.ctxt.stop():
+			return errors.Trace(flush(false))
+		case <-timer.C:
+			if err := flush(false); err != nil {
+				return errors.Trace(err)
+			}
+			timer.Reset(logTransferFlushInterval)
+		case err := <-errCh:
+			if flushErr := flush(false); flushErr != nil {
+				logger.Errorf("log transfer flush failed: %v", flushErr)
+			}
+			return errors.Annotate(err, "receiving logs")
+		case m, ok := <-logCh:
+			if !ok {
+				return errors.Trace(flush(true))
+			}
+			batch = append(batch, &state.LogRecord{
+				Time:     m.Time,
+				Entity:   m.Entity,
+				Module:   m.Module,
+				Location: m.Location,
+				Level:    m.Level,
+				Message:  m.Message,
+			})
+			if len(batch) >= logTransferBatchSize {
+				if err := flush(false); err != nil {
+					return errors.Trace(err)
+				}
+			}
+		}
+	}
+}
+
+// completeLogRecords returns the number of records at the start of
+// the batch whose time is before that of the last record in the
+// batch. More records with the last record's time may yet be received.
+func completeLogRecords(batch []*state.LogRecord) int {
+	n := len(batch)
+	for n > 0 && batch[n-1].Time.Equal(batch[len(batch)-1].Time) {
+		n--
+	}
+	return n
+}
+
+// receiveLogs returns a channel which reports the log records sent
+// by the client, and a channel which reports any error receiving
+// them. The record channel is closed when the client is done; it is
+// left open if an error is reported, or if done is closed.
+func (h *logTransferHandler) receiveLogs(socket *websocket.Conn, done <-chan struct{}) (<-chan params.LogRecord, <-chan error) {
+	logCh := make(chan params.LogRecord)
+	errCh := make(chan error, 1)
+
+	go func() {
+		for {
+			var m params.LogRecord
+			if err := websocket.JSON.Receive(socket, &m); err != nil {
+				if err == io.EOF {
+					close(logCh)
+				} else {
+					errCh <- err
+				}
+				return
+			}
+			select {
+			case <-done:
+				return
+			case logCh <- m:
+			}
+		}
+	}()
+
+	return logCh, errCh
+}
+
+// sendError sends a JSON-encoded error response.
+func (h *logTransferHandler) sendError(w io.Writer, req *http.Request, err error) {
+	if err != nil {
+		logger.Errorf("returning error from %s %s: %s", req.Method, req.URL.Path, errors.Details(err))
+	}
+	sendJSON(w, &params.ErrorResult{
+		Error: common.ServerError(err),
+	})
+}
@@ -0,0 +1,33 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package apiserver
+
+import (
+	"time"
+
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/state"
+)
+
+type logTransferIntSuite struct{}
+
+var _ = gc.Suite(&logTransferIntSuite{})
+
+func (s *logTransferIntSuite) TestCompleteLogRecords(c *gc.C) {
+	t0 := time.Date(2016, 6, 1, 10, 2, 3, 0, time.UTC)
+	t1 := t0.Add(time.Millisecond)
+	records := func(times ...time.Time) []*state.LogRecord {
+		var batch []*state.LogRecord
+		for _, t := range times {
+			batch = append(batch, &state.LogRecord{Time: t})
+		}
+		return batch
+	}
+	c.Check(completeLogRecords(nil), gc.Equals, 0)
+	c.Check(completeLogRecords(records(t0)), gc.Equals, 0)
+	c.Check(completeLogRecords(records(t0, t0)), gc.Equals, 0)
+	c.Check(completeLogRecords(records(t0, t1)), gc.Equals, 1)
+	c.Check(completeLogRecords(records(t0, t0, t1, t1)), gc.Equals, 2)
+}
@@ -0,0 +1,200 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package apiserver_test
+
+import (
+	"bufio"
+	"net/http"
+	"net/url"
+	"time"
+
+	"github.com/juju/errors"
+	"github.com/juju/loggo"
+	jc "github.com/juju/testing/checkers"
+	"github.com/juju/utils"
+	"golang.org/x/net/websocket"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/mgo.v2/bson"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/core/migration"
+	jujutesting "github.com/juju/juju/juju/testing"
+	"github.com/juju/juju/state"
+	coretesting "github.com/juju/juju/testing"
+	"github.com/juju/juju/testing/factory"
+)
+
+type logTransferSuite struct {
+	authHttpSuite
+	importSt *state.State
+}
+
+var _ = gc.Suite(&logTransferSuite{})
+
+func (s *logTransferSuite) SetUpTest(c *gc.C) {
+	s.authHttpSuite.SetUpTest(c)
+	s.importSt = s.Factory.MakeModel(c, nil)
+	s.AddCleanup(func(*gc.C) { s.importSt.Close() })
+}
+
+func (s *logTransferSuite) TestRejectsNonAdmin(c *gc.C) {
+	header := utils.BasicAuthHeader(s.userTag.String(), s.password)
+	reader := s.openWebsocket(c, s.importSt.ModelUUID(), header)
+	assertJSONError(c, reader, "permission denied")
+	s.assertWebsocketClosed(c, reader)
+}
+
+func (s *logTransferSuite) TestRejectsAgents(c *gc.C) {
+	m, password := s.Factory.MakeMachineReturningPassword(c, &factory.MachineParams{
+		Nonce: "foo-nonce",
+	})
+	header := utils.BasicAuthHeader(m.Tag().String(), password)
+	header.Add(params.MachineNonceHeader, "foo-nonce")
+	reader := s.openWebsocket(c, s.importSt.ModelUUID(), header)
+	assertJSONError(c, reader, "invalid entity name or password")
+	s.assertWebsocketClosed(c, reader)
+}
+
+func (s *logTransferSuite) TestRejectsBadModelUUID(c *gc.C) {
+	reader := s.openWebsocket(c, "foo", s.adminHeader(c))
+	assertJSONError(c, reader, `model UUID "foo" not valid`)
+	s.assertWebsocketClosed(c, reader)
+}
+
+func (s *logTransferSuite) TestRejectsMissingModel(c *gc.C) {
+	reader := s.openWebsocket(c, utils.MustNewUUID().String(), s.adminHeader(c))
+	assertJSONError(c, reader, "model not found")
+	s.assertWebsocketClosed(c, reader)
+}
+
+func (s *logTransferSuite) TestTransfer(c *gc.C) {
+	conn := s.dialWebsocket(c, s.importSt.ModelUUID(), s.adminHeader(c))
+	defer conn.Close()
+	reader := bufio.NewReader(conn)
+
+	// Read back the nil error, indicating that all is well.
+	errResult := readJSONErrorLine(c, reader)
+	c.Assert(errResult.Error, gc.IsNil)
+
+	t0 := time.Date(2016, time.June, 1, 23, 2, 1, 0, time.UTC)
+	err := websocket.JSON.Send(conn, &params.LogRecord{
+		Time:     t0,
+		Entity:   "machine-3",
+		Module:   "some.where",
+		Location: "foo.go:42",
+		Level:    loggo.INFO,
+		Message:  "all is well",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	t1 := time.Date(2016, time.June, 1, 23, 2, 2, 0, time.UTC)
+	err = websocket.JSON.Send(conn, &params.LogRecord{
+		Time:     t1,
+		Entity:   "unit-foo-2",
+		Module:   "else.where",
+		Location: "bar.go:99",
+		Level:    loggo.ERROR,
+		Message:  "oh noes",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	// Closing the connection causes the records to be written.
+	err = conn.Close()
+	c.Assert(err, jc.ErrorIsNil)
+
+	logsColl := s.State.MongoSession().DB("logs").C("logs")
+	var docs []bson.M
+	for a := coretesting.LongAttempt.Start(); a.Next(); {
+		err := logsColl.Find(bson.M{"e": s.importSt.ModelUUID()}).Sort("t").All(&docs)
+		c.Assert(err, jc.ErrorIsNil)
+		if len(docs) == 2 {
+			break
+		}
+		if !a.HasNext() {
+			c.Fatalf("timed out waiting for log writes")
+		}
+	}
+	c.Assert(docs[0]["t"].(time.Time).Sub(t0), gc.Equals, time.Duration(0))
+	c.Assert(docs[0]["n"], gc.Equals, "machine-3")
+	c.Assert(docs[0]["x"], gc.Equals, "all is well")
+	c.Assert(docs[1]["t"].(time.Time).Sub(t1), gc.Equals, time.Duration(0))
+	c.Assert(docs[1]["n"], gc.Equals, "unit-foo-2")
+	c.Assert(docs[1]["x"], gc.Equals, "oh noes")
+
+	// The time of the last record transferred is recorded so that
+	// an interrupted transfer can be resumed.
+	lastSent := state.NewLastSentLogger(s.importSt, migration.LogTransferSink)
+	for a := coretesting.LongAttempt.Start(); a.Next(); {
+		last, err := lastSent.Get()
+		if err == nil {
+			c.Assert(last.Sub(t1), gc.Equals, time.Duration(0))
+			break
+		}
+		c.Assert(errors.Cause(err), gc.Equals, state.ErrNeverForwarded)
+		if !a.HasNext() {
+			c.Fatalf("timed out waiting for last transferred time")
+		}
+	}
+}
+
+func (s *logTransferSuite) TestTransferReceiveError(c *gc.C) {
+	conn := s.dialWebsocket(c, s.importSt.ModelUUID(), s.adminHeader(c))
+	defer conn.Close()
+	reader := bufio.NewReader(conn)
+	errResult := readJSONErrorLine(c, reader)
+	c.Assert(errResult.Error, gc.IsNil)
+
+	t0 := time.Date(2016, time.June, 1, 23, 2, 1, 0, time.UTC)
+	t1 := t0.Add(time.Second)
+	for _, t := range []time.Time{t0, t1} {
+		err := websocket.JSON.Send(conn, &params.LogRecord{
+			Time:    t,
+			Entity:  "machine-3",
+			Level:   loggo.INFO,
+			Message: "all is well",
+		})
+		c.Assert(err, jc.ErrorIsNil)
+	}
+	// A record that can't be decoded fails the transfer.
+	err := websocket.Message.Send(conn, "not a log record")
+	c.Assert(err, jc.ErrorIsNil)
+
+	// Only the records before the last time received are written;
+	// more records with that time may not have been received.
+	lastSent := state.NewLastSentLogger(s.importSt, migration.LogTransferSink)
+	for a := coretesting.LongAttempt.Start(); a.Next(); {
+		last, err := lastSent.Get()
+		if err == nil {
+			c.Assert(last.Sub(t0), gc.Equals, time.Duration(0))
+			break
+		}
+		c.Assert(errors.Cause(err), gc.Equals, state.ErrNeverForwarded)
+		if !a.HasNext() {
+			c.Fatalf("timed out waiting for last transferred time")
+		}
+	}
+	var docs []bson.M
+	logsColl := s.State.MongoSession().DB("logs").C("logs")
+	err = logsColl.Find(bson.M{"e": s.importSt.ModelUUID()}).All(&docs)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(docs, gc.HasLen, 1)
+	c.Assert(docs[0]["t"].(time.Time).Sub(t0), gc.Equals, time.Duration(0))
+}
+
+func (s *logTransferSuite) adminHeader(c *gc.C) http.Header {
+	return utils.BasicAuthHeader(s.AdminUserTag(c).String(), jujutesting.AdminSecret)
+}
+
+func (s *logTransferSuite) openWebsocket(c *gc.C, modelUUID string, header http.Header) *bufio.Reader {
+	conn := s.dialWebsocket(c, modelUUID, header)
+	s.AddCleanup(func(_ *gc.C) { conn.Close() })
+	return bufio.NewReader(conn)
+}
+
+func (s *logTransferSuite) dialWebsocket(c *gc.C, modelUUID string, header http.Header) *websocket.Conn {
+	server := s.makeURL(c, "wss",
+		"/model/"+s.State.ModelUUID()+"/migrate/logtransfer",
+		url.Values{"model-uuid": {modelUUID}},
+	)
+	return s.dialWebsocketFromURL(c, server.String(), header)
+}
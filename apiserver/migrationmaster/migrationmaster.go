@@ -92,8 +92,9 @@ func (api *API) GetMigrationStatus() (params.FullMigrationStatus, error) {
 				Password:      target.Password,
 			},
 		},
-		Attempt: attempt,
-		Phase:   phase.String(),
+		MigrationId: mig.Id(),
+		Attempt:     attempt,
+		Phase:       phase.String(),
 	}, nil
 }
 
@@ -129,3 +130,104 @@ func (api *API) Export() (params.SerializedModel, error) {
 	serialized.Bytes = bytes
 	return serialized, nil
 }
+
+// SetStatusMessage sets a human readable status message containing
+// information about the migration's progress. This will be shown in
+// status output shown to the end user.
+func (api *API) SetStatusMessage(args params.SetMigrationStatusMessageArgs) error {
+	mig, err := api.backend.GetModelMigration()
+	if err != nil {
+		return errors.Annotate(err, "could not get migration")
+	}
+	err = mig.SetStatusMessage(args.Message)
+	return errors.Annotate(err, "failed to set status message")
+}
+
+// ModelInfo returns essential information about the model to be
+// migrated.
+func (api *API) ModelInfo() (params.MigrationModelInfo, error) {
+	empty := params.MigrationModelInfo{}
+
+	info, err := api.backend.ModelInfo()
+	if err != nil {
+		return empty, errors.Annotate(err, "retrieving model info")
+	}
+	return params.MigrationModelInfo{
+		UUID:            info.UUID,
+		Name:            info.Name,
+		OwnerTag:        info.Owner.String(),
+		AgentVersion:    info.AgentVersion,
+		ProviderType:    info.ProviderType,
+		CloudAttrs:      info.CloudAttrs,
+		CredentialAttrs: info.CredentialAttrs,
+	}, nil
+}
+
+// Prechecks performs pre-migration checks on the model and
+// (source) controller. Problems which prevent the migration from
+// going ahead are reported in the result's Failures. An error is
+// returned only if the checks could not be run.
+func (api *API) Prechecks() (params.MigrationPrecheckResult, error) {
+	err := api.backend.Prechecks()
+	if precheckErr, ok := errors.Cause(err).(*coremigration.PrecheckError); ok {
+		return params.MigrationPrecheckResult{Failures: precheckErr.Reasons}, nil
+	} else if err != nil {
+		return params.MigrationPrecheckResult{}, errors.Annotate(err, "running prechecks")
+	}
+	return params.MigrationPrecheckResult{}, nil
+}
+
+// WatchMinionReports sets up a watcher which reports when a report
+// for a migration minion has arrived for the current migration
+// phase.
+func (api *API) WatchMinionReports() (params.NotifyWatchResult, error) {
+	mig, err := api.backend.GetModelMigration()
+	if err != nil {
+		return params.NotifyWatchResult{}, errors.Annotate(err, "retrieving model migration")
+	}
+
+	w, err := mig.WatchMinionReports()
+	if err != nil {
+		return params.NotifyWatchResult{}, errors.Trace(err)
+	}
+
+	return params.NotifyWatchResult{
+		NotifyWatcherId: api.resources.Register(w),
+	}, nil
+}
+
+// MinionReports returns details of the reports made by migration
+// minions to the controller for the current migration phase.
+func (api *API) MinionReports() (params.MinionReports, error) {
+	var out params.MinionReports
+
+	mig, err := api.backend.GetModelMigration()
+	if err != nil {
+		return out, errors.Annotate(err, "retrieving model migration")
+	}
+
+	phase, err := mig.Phase()
+	if err != nil {
+		return out, errors.Annotate(err, "retrieving phase")
+	}
+
+	reports, err := mig.MinionReports()
+	if err != nil {
+		return out, errors.Annotate(err, "retrieving minion reports")
+	}
+
+	out.MigrationId = mig.Id()
+	out.Phase = phase.String()
+	out.SuccessCount = len(reports.Succeeded)
+	out.Unknown = tagsToStrings(reports.Unknown)
+	out.Failed = tagsToStrings(reports.Failed)
+	return out, nil
+}
+
+func tagsToStrings(tags []names.Tag) []string {
+	out := make([]string, len(tags))
+	for i, tag := range tags {
+		out[i] = tag.String()
+	}
+	return out
+}
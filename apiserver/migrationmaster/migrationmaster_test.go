@@ -8,6 +8,7 @@ import (
 	"github.com/juju/names"
 	jc "github.com/juju/testing/checkers"
 	"github.com/juju/utils"
+	"github.com/juju/version"
 	gc "gopkg.in/check.v1"
 
 	"github.com/juju/juju/apiserver/common"
@@ -20,9 +21,6 @@ import (
 	"github.com/juju/juju/testing"
 )
 
-// Ensure that Backend remains compatible with *state.State
-var _ migrationmaster.Backend = (*state.State)(nil)
-
 type Suite struct {
 	testing.BaseSuite
 
@@ -90,8 +88,9 @@ func (s *Suite) TestGetMigrationStatus(c *gc.C) {
 				Password:      "secret",
 			},
 		},
-		Attempt: 1,
-		Phase:   "READONLY",
+		MigrationId: "id",
+		Attempt:     1,
+		Phase:       "READONLY",
 	})
 }
 
@@ -142,6 +141,152 @@ func (s *Suite) TestExport(c *gc.C) {
 	})
 }
 
+func (s *Suite) TestSetStatusMessage(c *gc.C) {
+	api := s.mustMakeAPI(c)
+
+	err := api.SetStatusMessage(params.SetMigrationStatusMessageArgs{Message: "foo"})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(s.backend.migration.messageSet, gc.Equals, "foo")
+}
+
+func (s *Suite) TestSetStatusMessageNoMigration(c *gc.C) {
+	s.backend.getErr = errors.New("boom")
+	api := s.mustMakeAPI(c)
+
+	err := api.SetStatusMessage(params.SetMigrationStatusMessageArgs{Message: "foo"})
+	c.Check(err, gc.ErrorMatches, "could not get migration: boom")
+}
+
+func (s *Suite) TestSetStatusMessageError(c *gc.C) {
+	s.backend.migration.setMessageErr = errors.New("blam")
+	api := s.mustMakeAPI(c)
+
+	err := api.SetStatusMessage(params.SetMigrationStatusMessageArgs{Message: "foo"})
+	c.Assert(err, gc.ErrorMatches, "failed to set status message: blam")
+}
+
+func (s *Suite) TestModelInfo(c *gc.C) {
+	api := s.mustMakeAPI(c)
+
+	info, err := api.ModelInfo()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(info, gc.DeepEquals, params.MigrationModelInfo{
+		UUID:            modelUUID,
+		Name:            "model-name",
+		OwnerTag:        names.NewUserTag("owner").String(),
+		AgentVersion:    version.MustParse("1.2.3"),
+		ProviderType:    "dummy",
+		CloudAttrs:      map[string]string{"region": "east"},
+		CredentialAttrs: []string{"password", "username"},
+	})
+}
+
+func (s *Suite) TestModelInfoError(c *gc.C) {
+	s.backend.modelInfoErr = errors.New("boom")
+	api := s.mustMakeAPI(c)
+
+	_, err := api.ModelInfo()
+	c.Assert(err, gc.ErrorMatches, "retrieving model info: boom")
+}
+
+func (s *Suite) TestPrechecks(c *gc.C) {
+	api := s.mustMakeAPI(c)
+
+	result, err := api.Prechecks()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, gc.DeepEquals, params.MigrationPrecheckResult{})
+}
+
+func (s *Suite) TestPrechecksFailures(c *gc.C) {
+	s.backend.precheckErr = &coremigration.PrecheckError{
+		Reasons: []string{"machine 0 is dying", "model has pending cleanups"},
+	}
+	api := s.mustMakeAPI(c)
+
+	result, err := api.Prechecks()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, gc.DeepEquals, params.MigrationPrecheckResult{
+		Failures: []string{"machine 0 is dying", "model has pending cleanups"},
+	})
+}
+
+func (s *Suite) TestPrechecksError(c *gc.C) {
+	s.backend.precheckErr = errors.New("boom")
+	api := s.mustMakeAPI(c)
+
+	_, err := api.Prechecks()
+	c.Assert(err, gc.ErrorMatches, "running prechecks: boom")
+}
+
+func (s *Suite) TestWatchMinionReports(c *gc.C) {
+	api := s.mustMakeAPI(c)
+
+	result, err := api.WatchMinionReports()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.resources.Get(result.NotifyWatcherId), gc.NotNil)
+}
+
+func (s *Suite) TestWatchMinionReportsNoMigration(c *gc.C) {
+	s.backend.getErr = errors.New("boom")
+	api := s.mustMakeAPI(c)
+
+	_, err := api.WatchMinionReports()
+	c.Assert(err, gc.ErrorMatches, "retrieving model migration: boom")
+	c.Assert(s.resources.Count(), gc.Equals, 0)
+}
+
+func (s *Suite) TestWatchMinionReportsError(c *gc.C) {
+	s.backend.migration.watchMinionReportsErr = errors.New("blam")
+	api := s.mustMakeAPI(c)
+
+	_, err := api.WatchMinionReports()
+	c.Assert(err, gc.ErrorMatches, "blam")
+	c.Assert(s.resources.Count(), gc.Equals, 0)
+}
+
+func (s *Suite) TestMinionReports(c *gc.C) {
+	s.backend.migration.minionReports = &state.MinionReports{
+		Succeeded: []names.Tag{
+			names.NewMachineTag("0"),
+			names.NewUnitTag("foo/0"),
+		},
+		Failed: []names.Tag{
+			names.NewMachineTag("1"),
+		},
+		Unknown: []names.Tag{
+			names.NewMachineTag("2"),
+			names.NewUnitTag("bar/1"),
+		},
+	}
+	api := s.mustMakeAPI(c)
+
+	reports, err := api.MinionReports()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(reports, gc.DeepEquals, params.MinionReports{
+		MigrationId:  "id",
+		Phase:        "READONLY",
+		SuccessCount: 2,
+		Unknown:      []string{"machine-2", "unit-bar-1"},
+		Failed:       []string{"machine-1"},
+	})
+}
+
+func (s *Suite) TestMinionReportsNoMigration(c *gc.C) {
+	s.backend.getErr = errors.New("boom")
+	api := s.mustMakeAPI(c)
+
+	_, err := api.MinionReports()
+	c.Assert(err, gc.ErrorMatches, "retrieving model migration: boom")
+}
+
+func (s *Suite) TestMinionReportsError(c *gc.C) {
+	s.backend.migration.minionReportsErr = errors.New("blam")
+	api := s.mustMakeAPI(c)
+
+	_, err := api.MinionReports()
+	c.Assert(err, gc.ErrorMatches, "retrieving minion reports: blam")
+}
+
 func (s *Suite) makeAPI() (*migrationmaster.API, error) {
 	return migrationmaster.NewAPI(nil, s.resources, s.authorizer)
 }
@@ -155,9 +300,11 @@ func (s *Suite) mustMakeAPI(c *gc.C) *migrationmaster.API {
 type stubBackend struct {
 	migrationmaster.Backend
 
-	watchError error
-	getErr     error
-	migration  *stubMigration
+	watchError   error
+	getErr       error
+	modelInfoErr error
+	precheckErr  error
+	migration    *stubMigration
 }
 
 func (b *stubBackend) WatchForModelMigration() (state.NotifyWatcher, error) {
@@ -174,10 +321,47 @@ func (b *stubBackend) GetModelMigration() (state.ModelMigration, error) {
 	return b.migration, nil
 }
 
+func (b *stubBackend) ModelInfo() (coremigration.ModelInfo, error) {
+	if b.modelInfoErr != nil {
+		return coremigration.ModelInfo{}, b.modelInfoErr
+	}
+	return coremigration.ModelInfo{
+		UUID:            modelUUID,
+		Name:            "model-name",
+		Owner:           names.NewUserTag("owner"),
+		AgentVersion:    version.MustParse("1.2.3"),
+		ProviderType:    "dummy",
+		CloudAttrs:      map[string]string{"region": "east"},
+		CredentialAttrs: []string{"password", "username"},
+	}, nil
+}
+
+func (b *stubBackend) Prechecks() error {
+	return b.precheckErr
+}
+
 type stubMigration struct {
 	state.ModelMigration
-	setPhaseErr error
-	phaseSet    coremigration.Phase
+
+	setPhaseErr           error
+	phaseSet              coremigration.Phase
+	setMessageErr         error
+	messageSet            string
+	watchMinionReportsErr error
+	minionReports         *state.MinionReports
+	minionReportsErr      error
+}
+
+func (m *stubMigration) Id() string {
+	return "id"
+}
+
+func (m *stubMigration) SetStatusMessage(message string) error {
+	if m.setMessageErr != nil {
+		return m.setMessageErr
+	}
+	m.messageSet = message
+	return nil
 }
 
 func (m *stubMigration) Phase() (coremigration.Phase, error) {
@@ -210,6 +394,23 @@ func (m *stubMigration) SetPhase(phase coremigration.Phase) error {
 	return nil
 }
 
+func (m *stubMigration) WatchMinionReports() (state.NotifyWatcher, error) {
+	if m.watchMinionReportsErr != nil {
+		return nil, m.watchMinionReportsErr
+	}
+	return apiservertesting.NewFakeNotifyWatcher(), nil
+}
+
+func (m *stubMigration) MinionReports() (*state.MinionReports, error) {
+	if m.minionReportsErr != nil {
+		return nil, m.minionReportsErr
+	}
+	if m.minionReports == nil {
+		return new(state.MinionReports), nil
+	}
+	return m.minionReports, nil
+}
+
 var modelUUID string
 var controllerUUID string
 
@@ -4,6 +4,9 @@
 package migrationmaster
 
 import (
+	"github.com/juju/errors"
+
+	coremigration "github.com/juju/juju/core/migration"
 	"github.com/juju/juju/migration"
 	"github.com/juju/juju/state"
 )
@@ -15,8 +18,74 @@ type Backend interface {
 
 	WatchForModelMigration() (state.NotifyWatcher, error)
 	GetModelMigration() (state.ModelMigration, error)
+
+	// ModelInfo returns the basic details of the model being
+	// migrated.
+	ModelInfo() (coremigration.ModelInfo, error)
+
+	// Prechecks runs the migration prechecks against the model and
+	// its controller.
+	Prechecks() error
 }
 
 var getBackend = func(st *state.State) Backend {
-	return st
+	return &backendShim{st}
+}
+
+// backendShim implements Backend using a *state.State.
+type backendShim struct {
+	*state.State
+}
+
+// ModelInfo implements Backend.
+func (s *backendShim) ModelInfo() (coremigration.ModelInfo, error) {
+	var empty coremigration.ModelInfo
+
+	model, err := s.State.Model()
+	if err != nil {
+		return empty, errors.Trace(err)
+	}
+	cfg, err := model.Config()
+	if err != nil {
+		return empty, errors.Trace(err)
+	}
+	vers, ok := cfg.AgentVersion()
+	if !ok {
+		return empty, errors.New("no agent version in model config")
+	}
+	cloudAttrs, err := migration.CloudAttrs(cfg)
+	if err != nil {
+		return empty, errors.Trace(err)
+	}
+	credentialAttrs, err := migration.CredentialAttrs(cfg)
+	if err != nil {
+		return empty, errors.Trace(err)
+	}
+	return coremigration.ModelInfo{
+		UUID:            model.UUID(),
+		Owner:           model.Owner(),
+		Name:            model.Name(),
+		AgentVersion:    vers,
+		ProviderType:    cfg.Type(),
+		CloudAttrs:      cloudAttrs,
+		CredentialAttrs: credentialAttrs,
+	}, nil
+}
+
+// Prechecks implements Backend.
+func (s *backendShim) Prechecks() error {
+	controllerModel, err := s.State.ControllerModel()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	controllerState, err := s.State.ForModel(controllerModel.ModelTag())
+	if err != nil {
+		return errors.Trace(err)
+	}
+	defer controllerState.Close()
+
+	return migration.SourcePrecheck(
+		migration.PrecheckShim(s.State),
+		migration.PrecheckShim(controllerState),
+	)
 }
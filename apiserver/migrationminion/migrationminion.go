@@ -8,6 +8,7 @@ import (
 
 	"github.com/juju/juju/apiserver/common"
 	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/core/migration"
 	"github.com/juju/juju/state"
 )
 
@@ -56,3 +57,24 @@ func (api *API) Watch() (params.NotifyWatchResult, error) {
 		NotifyWatcherId: api.resources.Register(w),
 	}, nil
 }
+
+// Report allows a migration minion to submit whether it succeeded or
+// failed for a specific migration phase.
+func (api *API) Report(info params.MinionReport) error {
+	phase, ok := migration.ParsePhase(info.Phase)
+	if !ok {
+		return errors.New("unable to parse phase")
+	}
+
+	mig, err := api.backend.GetModelMigration()
+	if err != nil {
+		return errors.Annotate(err, "unable to load migration")
+	}
+
+	if mig.Id() != info.MigrationId {
+		return errors.NotValidf("migration id %q", info.MigrationId)
+	}
+
+	err = mig.MinionReport(api.authorizer.GetAuthTag(), phase, info.Success)
+	return errors.Annotate(err, "unable to record report")
+}
@@ -6,12 +6,15 @@ package migrationminion_test
 import (
 	"github.com/juju/errors"
 	"github.com/juju/names"
+	jujutesting "github.com/juju/testing"
 	jc "github.com/juju/testing/checkers"
 	gc "gopkg.in/check.v1"
 
 	"github.com/juju/juju/apiserver/common"
 	"github.com/juju/juju/apiserver/migrationminion"
+	"github.com/juju/juju/apiserver/params"
 	apiservertesting "github.com/juju/juju/apiserver/testing"
+	"github.com/juju/juju/core/migration"
 	"github.com/juju/juju/state"
 	"github.com/juju/juju/testing"
 )
@@ -74,6 +77,66 @@ func (s *Suite) TestWatch(c *gc.C) {
 	c.Assert(s.resources.Get(result.NotifyWatcherId), gc.NotNil)
 }
 
+func (s *Suite) TestReport(c *gc.C) {
+	api := s.mustMakeAPI(c)
+	err := api.Report(params.MinionReport{
+		MigrationId: "id",
+		Phase:       "IMPORT",
+		Success:     true,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	s.backend.stub.CheckCalls(c, []jujutesting.StubCall{
+		{"GetModelMigration", nil},
+		{"ModelMigration.MinionReport", []interface{}{
+			s.authorizer.Tag,
+			migration.IMPORT,
+			true,
+		}},
+	})
+}
+
+func (s *Suite) TestReportInvalidPhase(c *gc.C) {
+	api := s.mustMakeAPI(c)
+	err := api.Report(params.MinionReport{
+		MigrationId: "id",
+		Phase:       "WTF",
+		Success:     true,
+	})
+	c.Assert(err, gc.ErrorMatches, "unable to parse phase")
+}
+
+func (s *Suite) TestReportNoMigration(c *gc.C) {
+	s.backend.modelLookupErr = errors.NotFoundf("migration")
+	api := s.mustMakeAPI(c)
+	err := api.Report(params.MinionReport{
+		MigrationId: "id",
+		Phase:       "QUIESCE",
+		Success:     true,
+	})
+	c.Assert(err, gc.ErrorMatches, "unable to load migration: migration not found")
+}
+
+func (s *Suite) TestReportWrongMigration(c *gc.C) {
+	api := s.mustMakeAPI(c)
+	err := api.Report(params.MinionReport{
+		MigrationId: "unknown",
+		Phase:       "QUIESCE",
+		Success:     true,
+	})
+	c.Assert(err, gc.ErrorMatches, `migration id "unknown" not valid`)
+}
+
+func (s *Suite) TestReportError(c *gc.C) {
+	s.backend.stub.SetErrors(errors.New("boom"))
+	api := s.mustMakeAPI(c)
+	err := api.Report(params.MinionReport{
+		MigrationId: "id",
+		Phase:       "QUIESCE",
+		Success:     true,
+	})
+	c.Assert(err, gc.ErrorMatches, "unable to record report: boom")
+}
+
 func (s *Suite) makeAPI() (*migrationminion.API, error) {
 	return migrationminion.NewAPI(nil, s.resources, s.authorizer)
 }
@@ -86,7 +149,9 @@ func (s *Suite) mustMakeAPI(c *gc.C) *migrationminion.API {
 
 type stubBackend struct {
 	migrationminion.Backend
-	watchError error
+	stub           jujutesting.Stub
+	watchError     error
+	modelLookupErr error
 }
 
 func (b *stubBackend) WatchMigrationStatus() (state.NotifyWatcher, error) {
@@ -95,3 +160,25 @@ func (b *stubBackend) WatchMigrationStatus() (state.NotifyWatcher, error) {
 	}
 	return apiservertesting.NewFakeNotifyWatcher(), nil
 }
+
+func (b *stubBackend) GetModelMigration() (state.ModelMigration, error) {
+	b.stub.AddCall("GetModelMigration")
+	if b.modelLookupErr != nil {
+		return nil, b.modelLookupErr
+	}
+	return &stubModelMigration{stub: &b.stub}, nil
+}
+
+type stubModelMigration struct {
+	state.ModelMigration
+	stub *jujutesting.Stub
+}
+
+func (m *stubModelMigration) Id() string {
+	return "id"
+}
+
+func (m *stubModelMigration) MinionReport(tag names.Tag, phase migration.Phase, success bool) error {
+	m.stub.AddCall("ModelMigration.MinionReport", tag, phase, success)
+	return m.stub.NextErr()
+}
@@ -0,0 +1,194 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package apiserver
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+
+	"github.com/juju/errors"
+	"github.com/juju/names"
+
+	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/state"
+)
+
+// migrationResourceDownloadHandler serves the content of a service's
+// resources to the migration master, so that it can be copied to the
+// target controller of a migration.
+type migrationResourceDownloadHandler struct {
+	ctxt httpContext
+}
+
+func newMigrationResourceDownloadHandler(ctxt httpContext) http.Handler {
+	return &migrationResourceDownloadHandler{ctxt: ctxt}
+}
+
+// ServeHTTP implements the http.Handler interface.
+//
+// The resource is identified by the "service" and "name" query
+// parameters.
+func (h *migrationResourceDownloadHandler) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	var err error
+	switch req.Method {
+	case "GET":
+		err = h.serveGet(w, req)
+	default:
+		err = errors.MethodNotAllowedf("unsupported method: %q", req.Method)
+	}
+	if err != nil {
+		sendMigrationResourceError(w, req, err)
+	}
+}
+
+func (h *migrationResourceDownloadHandler) serveGet(w http.ResponseWriter, req *http.Request) error {
+	st, entity, err := h.ctxt.stateForRequestAuthenticatedUserOrController(req)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if userTag, ok := entity.Tag().(names.UserTag); ok {
+		isAdmin, err := h.ctxt.srv.state.IsControllerAdministrator(userTag)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		if !isAdmin {
+			return errors.Trace(common.ErrPerm)
+		}
+	}
+
+	service, name, err := migrationResourceQuery(req)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	resources, err := st.Resources()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	res, reader, err := resources.OpenResource(service, name)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	defer reader.Close()
+
+	w.Header().Set("Content-Type", params.ContentTypeRaw)
+	w.Header().Set("Content-Length", fmt.Sprint(res.Size))
+	w.WriteHeader(http.StatusOK)
+	if _, err := io.Copy(w, reader); err != nil {
+		// The headers have already been sent, so all we can do
+		// is log the failure; the client will see a short read.
+		logger.Errorf("resource download for %s/%s failed: %v", service, name, err)
+	}
+	return nil
+}
+
+// migrationResourceUploadHandler receives the content of a service's
+// resources for a model being imported into this controller. The
+// resource metadata has already been imported along with the model,
+// so only the content is sent.
+type migrationResourceUploadHandler struct {
+	ctxt httpContext
+}
+
+func newMigrationResourceUploadHandler(ctxt httpContext) http.Handler {
+	return &migrationResourceUploadHandler{ctxt: ctxt}
+}
+
+// ServeHTTP implements the http.Handler interface.
+//
+// The model being imported is identified by the "model-uuid" query
+// parameter, and the resource by the "service" and "name" parameters.
+func (h *migrationResourceUploadHandler) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	var err error
+	switch req.Method {
+	case "PUT":
+		err = h.servePut(w, req)
+	default:
+		err = errors.MethodNotAllowedf("unsupported method: %q", req.Method)
+	}
+	if err != nil {
+		sendMigrationResourceError(w, req, err)
+	}
+}
+
+func (h *migrationResourceUploadHandler) servePut(w http.ResponseWriter, req *http.Request) error {
+	st, err := h.modelState(req)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	service, name, err := migrationResourceQuery(req)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	resources, err := st.Resources()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	res, err := resources.GetResource(service, name)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	// The stored fingerprint and size are checked against the
+	// uploaded content.
+	if _, err := resources.SetResource(service, res.Username, res.Resource, req.Body); err != nil {
+		return errors.Annotatef(err, "storing resource %s/%s", service, name)
+	}
+	sendStatusAndJSON(w, http.StatusOK, &params.ErrorResult{})
+	return nil
+}
+
+// modelState checks that the request has been made by a controller
+// administrator and returns the State for the model being imported.
+func (h *migrationResourceUploadHandler) modelState(req *http.Request) (*state.State, error) {
+	st, entity, err := h.ctxt.stateForRequestAuthenticatedUser(req)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	isAdmin, err := st.IsControllerAdministrator(entity.Tag().(names.UserTag))
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if !isAdmin {
+		return nil, errors.Trace(common.ErrPerm)
+	}
+
+	modelUUID := req.URL.Query().Get("model-uuid")
+	if !names.IsValidModel(modelUUID) {
+		return nil, errors.BadRequestf("model UUID %q not valid", modelUUID)
+	}
+	model, err := st.GetModel(names.NewModelTag(modelUUID))
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if model.MigrationMode() != state.MigrationModeImporting {
+		return nil, errors.BadRequestf("model %q is not being imported", modelUUID)
+	}
+	modelSt, err := h.ctxt.srv.statePool.Get(modelUUID)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return modelSt, nil
+}
+
+// migrationResourceQuery returns the service and resource names
+// given in the request's query parameters.
+func migrationResourceQuery(req *http.Request) (service, name string, err error) {
+	query := req.URL.Query()
+	service = query.Get("service")
+	if !names.IsValidService(service) {
+		return "", "", errors.BadRequestf("service name %q not valid", service)
+	}
+	name = query.Get("name")
+	if name == "" {
+		return "", "", errors.BadRequestf("empty resource name")
+	}
+	return service, name, nil
+}
+
+// sendMigrationResourceError sends a JSON-encoded error response.
+func sendMigrationResourceError(w http.ResponseWriter, req *http.Request, err error) {
+	logger.Errorf("returning error from %s %s: %s", req.Method, req.URL.Path, errors.Details(err))
+	sendError(w, err)
+}
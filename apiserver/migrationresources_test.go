@@ -0,0 +1,106 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package apiserver_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/url"
+	"strings"
+
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/apiserver/params"
+	jujutesting "github.com/juju/juju/juju/testing"
+	"github.com/juju/juju/state"
+)
+
+type migrationResourcesSuite struct {
+	authHttpSuite
+	importSt *state.State
+}
+
+var _ = gc.Suite(&migrationResourcesSuite{})
+
+func (s *migrationResourcesSuite) SetUpTest(c *gc.C) {
+	s.authHttpSuite.SetUpTest(c)
+	s.importSt = s.Factory.MakeModel(c, nil)
+	s.AddCleanup(func(*gc.C) { s.importSt.Close() })
+}
+
+func (s *migrationResourcesSuite) TestDownloadRejectsNonAdmin(c *gc.C) {
+	resp := s.authRequest(c, httpRequestParams{
+		method: "GET",
+		url:    s.downloadURL(c, "mysql", "blob"),
+	})
+	s.assertErrorResponse(c, resp, http.StatusUnauthorized, "permission denied")
+}
+
+func (s *migrationResourcesSuite) TestDownloadRejectsBadService(c *gc.C) {
+	resp := s.adminRequest(c, "GET", s.downloadURL(c, "Bad", "blob"))
+	s.assertErrorResponse(c, resp, http.StatusBadRequest, `service name "Bad" not valid`)
+}
+
+func (s *migrationResourcesSuite) TestDownloadRejectsPut(c *gc.C) {
+	resp := s.adminRequest(c, "PUT", s.downloadURL(c, "mysql", "blob"))
+	s.assertErrorResponse(c, resp, http.StatusMethodNotAllowed, `unsupported method: "PUT"`)
+}
+
+func (s *migrationResourcesSuite) TestUploadRejectsNonAdmin(c *gc.C) {
+	resp := s.authRequest(c, httpRequestParams{
+		method: "PUT",
+		url:    s.uploadURL(c, s.importSt.ModelUUID(), "mysql", "blob"),
+		body:   strings.NewReader("content"),
+	})
+	s.assertErrorResponse(c, resp, http.StatusUnauthorized, "permission denied")
+}
+
+func (s *migrationResourcesSuite) TestUploadRejectsBadModelUUID(c *gc.C) {
+	resp := s.adminRequest(c, "PUT", s.uploadURL(c, "foo", "mysql", "blob"))
+	s.assertErrorResponse(c, resp, http.StatusBadRequest, `model UUID "foo" not valid`)
+}
+
+func (s *migrationResourcesSuite) TestUploadRejectsModelNotImporting(c *gc.C) {
+	resp := s.adminRequest(c, "PUT", s.uploadURL(c, s.importSt.ModelUUID(), "mysql", "blob"))
+	s.assertErrorResponse(c, resp, http.StatusBadRequest, `model ".*" is not being imported`)
+}
+
+func (s *migrationResourcesSuite) TestUploadRejectsGet(c *gc.C) {
+	resp := s.adminRequest(c, "GET", s.uploadURL(c, s.importSt.ModelUUID(), "mysql", "blob"))
+	s.assertErrorResponse(c, resp, http.StatusMethodNotAllowed, `unsupported method: "GET"`)
+}
+
+func (s *migrationResourcesSuite) adminRequest(c *gc.C, method, url string) *http.Response {
+	return s.sendRequest(c, httpRequestParams{
+		method:   method,
+		url:      url,
+		tag:      s.AdminUserTag(c).String(),
+		password: jujutesting.AdminSecret,
+		body:     strings.NewReader("content"),
+	})
+}
+
+func (s *migrationResourcesSuite) downloadURL(c *gc.C, service, name string) string {
+	return s.makeURL(c, "https",
+		"/model/"+s.State.ModelUUID()+"/migrate/resources",
+		url.Values{"service": {service}, "name": {name}},
+	).String()
+}
+
+func (s *migrationResourcesSuite) uploadURL(c *gc.C, modelUUID, service, name string) string {
+	return s.makeURL(c, "https",
+		"/model/"+s.State.ModelUUID()+"/migrate/resourceupload",
+		url.Values{"model-uuid": {modelUUID}, "service": {service}, "name": {name}},
+	).String()
+}
+
+func (s *migrationResourcesSuite) assertErrorResponse(c *gc.C, resp *http.Response, expCode int, expError string) {
+	body := assertResponse(c, resp, expCode, params.ContentTypeJSON)
+	var result params.ErrorResult
+	err := json.Unmarshal(body, &result)
+	c.Assert(err, jc.ErrorIsNil, gc.Commentf("body: %s", body))
+	c.Assert(result.Error, gc.NotNil)
+	c.Assert(result.Error.Message, gc.Matches, expError)
+}
@@ -4,11 +4,14 @@
 package migrationtarget
 
 import (
+	"time"
+
 	"github.com/juju/errors"
 	"github.com/juju/names"
 
 	"github.com/juju/juju/apiserver/common"
 	"github.com/juju/juju/apiserver/params"
+	coremigration "github.com/juju/juju/core/migration"
 	"github.com/juju/juju/migration"
 	"github.com/juju/juju/state"
 )
@@ -57,6 +60,37 @@ func checkAuth(authorizer common.Authorizer, st *state.State) error {
 	return nil
 }
 
+// Prechecks ensure that the target controller is ready to accept a
+// model migration. Problems which prevent the migration from going
+// ahead are reported in the result's Failures. An error is returned
+// only if the checks could not be run.
+func (api *API) Prechecks(model params.MigrationModelInfo) (params.MigrationPrecheckResult, error) {
+	var empty params.MigrationPrecheckResult
+
+	ownerTag, err := names.ParseUserTag(model.OwnerTag)
+	if err != nil {
+		return empty, errors.Trace(err)
+	}
+	err = migration.TargetPrecheck(
+		migration.PrecheckShim(api.state),
+		coremigration.ModelInfo{
+			UUID:            model.UUID,
+			Name:            model.Name,
+			Owner:           ownerTag,
+			AgentVersion:    model.AgentVersion,
+			ProviderType:    model.ProviderType,
+			CloudAttrs:      model.CloudAttrs,
+			CredentialAttrs: model.CredentialAttrs,
+		},
+	)
+	if precheckErr, ok := errors.Cause(err).(*coremigration.PrecheckError); ok {
+		return params.MigrationPrecheckResult{Failures: precheckErr.Reasons}, nil
+	} else if err != nil {
+		return empty, errors.Annotate(err, "running prechecks")
+	}
+	return empty, nil
+}
+
 // Import takes a serialized Juju model, deserializes it, and
 // recreates it in the receiving controller.
 func (api *API) Import(serialized params.SerializedModel) error {
@@ -111,3 +145,36 @@ func (api *API) Activate(args params.ModelArgs) error {
 
 	return model.SetMigrationMode(state.MigrationModeActive)
 }
+
+// LatestLogTime returns the time of the most recent log record
+// received by the logtransfer endpoint for the model being imported.
+// This is used by the source controller to resume an interrupted log
+// transfer. The zero time is returned if no logs have been
+// transferred yet.
+func (api *API) LatestLogTime(args params.ModelArgs) (time.Time, error) {
+	// The model will usually have been activated by the time logs
+	// are transferred, so its migration mode isn't checked here.
+	tag, err := names.ParseModelTag(args.ModelTag)
+	if err != nil {
+		return time.Time{}, errors.Trace(err)
+	}
+	model, err := api.state.GetModel(tag)
+	if err != nil {
+		return time.Time{}, errors.Trace(err)
+	}
+	st, err := api.state.ForModel(model.ModelTag())
+	if err != nil {
+		return time.Time{}, errors.Trace(err)
+	}
+	defer st.Close()
+
+	tracker := state.NewLastSentLogger(st, coremigration.LogTransferSink)
+	timestamp, err := tracker.Get()
+	if errors.Cause(err) == state.ErrNeverForwarded {
+		return time.Time{}, nil
+	}
+	if err != nil {
+		return time.Time{}, errors.Trace(err)
+	}
+	return timestamp, nil
+}
@@ -4,6 +4,9 @@
 package migrationtarget_test
 
 import (
+	"fmt"
+	"time"
+
 	"github.com/juju/errors"
 	"github.com/juju/names"
 	jc "github.com/juju/testing/checkers"
@@ -16,6 +19,7 @@ import (
 	apiservertesting "github.com/juju/juju/apiserver/testing"
 	"github.com/juju/juju/cmd/modelcmd"
 	"github.com/juju/juju/core/description"
+	coremigration "github.com/juju/juju/core/migration"
 	"github.com/juju/juju/environs"
 	"github.com/juju/juju/jujuclient/jujuclienttesting"
 	"github.com/juju/juju/provider/dummy"
@@ -170,6 +174,98 @@ func (s *Suite) TestActivateNotImportingEnv(c *gc.C) {
 	c.Assert(err, gc.ErrorMatches, `migration mode for the model is not importing`)
 }
 
+func (s *Suite) TestLatestLogTime(c *gc.C) {
+	api := s.mustNewAPI(c)
+	tag := s.importModel(c, api)
+
+	st, err := s.State.ForModel(tag)
+	c.Assert(err, jc.ErrorIsNil)
+	defer st.Close()
+	t := time.Date(2016, 11, 30, 18, 14, 0, 100, time.UTC)
+	tracker := state.NewLastSentLogger(st, coremigration.LogTransferSink)
+	err = tracker.Set(t)
+	c.Assert(err, jc.ErrorIsNil)
+
+	latest, err := api.LatestLogTime(params.ModelArgs{ModelTag: tag.String()})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(latest.Sub(t), gc.Equals, time.Duration(0))
+}
+
+func (s *Suite) TestLatestLogTimeNeverSet(c *gc.C) {
+	api := s.mustNewAPI(c)
+	tag := s.importModel(c, api)
+
+	latest, err := api.LatestLogTime(params.ModelArgs{ModelTag: tag.String()})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(latest, gc.Equals, time.Time{})
+}
+
+func (s *Suite) TestLatestLogTimeMissingEnv(c *gc.C) {
+	api := s.mustNewAPI(c)
+	newUUID := utils.MustNewUUID().String()
+	_, err := api.LatestLogTime(params.ModelArgs{ModelTag: names.NewModelTag(newUUID).String()})
+	c.Assert(err, gc.ErrorMatches, `model not found`)
+}
+
+func (s *Suite) modelInfo(c *gc.C) params.MigrationModelInfo {
+	cfg, err := s.State.ModelConfig()
+	c.Assert(err, jc.ErrorIsNil)
+	vers, ok := cfg.AgentVersion()
+	c.Assert(ok, jc.IsTrue)
+	return params.MigrationModelInfo{
+		UUID:         utils.MustNewUUID().String(),
+		Name:         "migrating-model",
+		OwnerTag:     s.Owner.String(),
+		AgentVersion: vers,
+		ProviderType: cfg.Type(),
+	}
+}
+
+func (s *Suite) TestPrechecks(c *gc.C) {
+	api := s.mustNewAPI(c)
+	result, err := api.Prechecks(s.modelInfo(c))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Failures, gc.HasLen, 0)
+}
+
+func (s *Suite) TestPrechecksModelNameTaken(c *gc.C) {
+	model, err := s.State.Model()
+	c.Assert(err, jc.ErrorIsNil)
+	info := s.modelInfo(c)
+	info.Name = model.Name()
+
+	api := s.mustNewAPI(c)
+	result, err := api.Prechecks(info)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Failures, gc.DeepEquals, []string{
+		fmt.Sprintf("model named %q already exists for %s on target controller",
+			model.Name(), s.Owner.Canonical()),
+	})
+}
+
+func (s *Suite) TestPrechecksNewerModelVersion(c *gc.C) {
+	info := s.modelInfo(c)
+	controllerVersion := info.AgentVersion
+	info.AgentVersion.Major++
+
+	api := s.mustNewAPI(c)
+	result, err := api.Prechecks(info)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Failures, gc.DeepEquals, []string{
+		fmt.Sprintf("target controller version (%s) is older than model version (%s)",
+			controllerVersion, info.AgentVersion),
+	})
+}
+
+func (s *Suite) TestPrechecksBadOwner(c *gc.C) {
+	info := s.modelInfo(c)
+	info.OwnerTag = "not-a-tag"
+
+	api := s.mustNewAPI(c)
+	_, err := api.Prechecks(info)
+	c.Assert(err, gc.ErrorMatches, `"not-a-tag" is not a valid tag`)
+}
+
 func (s *Suite) newAPI() (*migrationtarget.API, error) {
 	return migrationtarget.NewAPI(s.State, s.resources, s.authorizer)
 }
@@ -93,7 +93,7 @@ func (s *modelInfoSuite) TestModelInfo(c *gc.C) {
 		Users: []params.ModelUserInfo{{
 			UserName:       "admin",
 			LastConnection: &time.Time{},
-			Access:         params.ModelWriteAccess,
+			Access:         params.ModelAdminAccess,
 		}, {
 			UserName:       "bob@local",
 			DisplayName:    "Bob",
@@ -215,6 +215,11 @@ func (st *mockState) IsControllerAdministrator(user names.UserTag) (bool, error)
 	return user.Canonical() == "admin@local", st.NextErr()
 }
 
+func (st *mockState) ControllerAccess(user names.UserTag) (state.ControllerAccess, error) {
+	st.MethodCall(st, "ControllerAccess", user)
+	return state.ControllerLoginAccess, st.NextErr()
+}
+
 func (st *mockState) NewModel(args state.ModelArgs) (*state.Model, *state.State, error) {
 	st.MethodCall(st, "NewModel", args)
 	return nil, nil, st.NextErr()
@@ -255,6 +260,21 @@ func (st *mockState) ModelUser(tag names.UserTag) (*state.ModelUser, error) {
 	return nil, st.NextErr()
 }
 
+func (st *mockState) ServiceAccess(service string, tag names.UserTag) (state.ServiceAccess, error) {
+	st.MethodCall(st, "ServiceAccess", service, tag)
+	return "", st.NextErr()
+}
+
+func (st *mockState) SetServiceAccess(service string, tag names.UserTag, access state.ServiceAccess, createdBy names.UserTag) error {
+	st.MethodCall(st, "SetServiceAccess", service, tag, access, createdBy)
+	return st.NextErr()
+}
+
+func (st *mockState) RemoveServiceAccess(service string, tag names.UserTag) error {
+	st.MethodCall(st, "RemoveServiceAccess", service, tag)
+	return st.NextErr()
+}
+
 type mockModel struct {
 	gitjujutesting.Stub
 	owner  names.UserTag
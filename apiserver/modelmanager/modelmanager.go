@@ -96,6 +96,24 @@ func (m *ModelManagerAPI) authCheck(user names.UserTag) error {
 	return common.ErrPerm
 }
 
+// addModelCheck checks if the user is an administrator, or has been
+// granted access to add models to the controller.
+func (m *ModelManagerAPI) addModelCheck() error {
+	if m.isAdmin {
+		return nil
+	}
+	access, err := m.state.ControllerAccess(m.apiUser)
+	if errors.IsNotFound(err) {
+		return common.ErrPerm
+	} else if err != nil {
+		return errors.Trace(err)
+	}
+	if !access.EqualOrGreaterThan(state.ControllerAddModelAccess) {
+		return common.ErrPerm
+	}
+	return nil
+}
+
 // ConfigSource describes a type that is able to provide config.
 // Abstracted primarily for testing.
 type ConfigSource interface {
@@ -200,9 +218,12 @@ func (mm *ModelManagerAPI) CreateModel(args params.ModelCreateArgs) (params.Mode
 		return result, errors.Trace(err)
 	}
 
-	// Any user is able to create themselves an model (until real fine
-	// grain permissions are available), and admins (the creator of the state
-	// server model) are able to create models for other people.
+	// Users with add-model access to the controller are able to
+	// create themselves a model, and controller administrators are
+	// able to create models for other people.
+	if err := mm.addModelCheck(); err != nil {
+		return result, errors.Trace(err)
+	}
 	err = mm.authCheck(ownerTag)
 	if err != nil {
 		return result, errors.Trace(err)
@@ -394,6 +415,45 @@ func (m *ModelManagerAPI) ModifyModelAccess(args params.ModifyModelAccessRequest
 	return result, nil
 }
 
+// ModifyServiceAccess changes the access granted to users on services.
+func (m *ModelManagerAPI) ModifyServiceAccess(args params.ModifyServiceAccessRequest) (result params.ErrorResults, err error) {
+	result = params.ErrorResults{
+		Results: make([]params.ErrorResult, len(args.Changes)),
+	}
+	if len(args.Changes) == 0 {
+		return result, nil
+	}
+
+	for i, arg := range args.Changes {
+		serviceAccess, err := FromServiceAccessParam(arg.Access)
+		if err != nil {
+			err = errors.Annotate(err, "could not modify service access")
+			result.Results[i].Error = common.ServerError(err)
+			continue
+		}
+
+		targetUserTag, err := names.ParseUserTag(arg.UserTag)
+		if err != nil {
+			result.Results[i].Error = common.ServerError(errors.Annotate(err, "could not modify service access"))
+			continue
+		}
+		modelTag, err := names.ParseModelTag(arg.ModelTag)
+		if err != nil {
+			result.Results[i].Error = common.ServerError(errors.Annotate(err, "could not modify service access"))
+			continue
+		}
+		serviceTag, err := names.ParseServiceTag(arg.ServiceTag)
+		if err != nil {
+			result.Results[i].Error = common.ServerError(errors.Annotate(err, "could not modify service access"))
+			continue
+		}
+
+		result.Results[i].Error = common.ServerError(
+			ChangeServiceAccess(m.state, modelTag, serviceTag, m.apiUser, targetUserTag, arg.Action, serviceAccess, m.isAdmin))
+	}
+	return result, nil
+}
+
 // resolveStateAccess returns the state representation of the logical model
 // access type.
 func resolveStateAccess(access permission.ModelAccess) (state.ModelAccess, error) {
@@ -402,9 +462,8 @@ func resolveStateAccess(access permission.ModelAccess) (state.ModelAccess, error
 	case permission.ModelReadAccess:
 		return state.ModelReadAccess, nil
 	case permission.ModelWriteAccess:
-		// TODO: Initially, we'll map "write" access to admin-level access.
-		// Post Juju-2.0, support for more nuanced access will be added to the
-		// permission business logic and state model.
+		return state.ModelWriteAccess, nil
+	case permission.ModelAdminAccess:
 		return state.ModelAdminAccess, nil
 	}
 	logger.Errorf("invalid access permission: %+v", access)
@@ -413,15 +472,8 @@ func resolveStateAccess(access permission.ModelAccess) (state.ModelAccess, error
 
 // isGreaterAccess returns whether the new access provides more permissions
 // than the current access.
-// TODO(cmars): If/when more access types are implemented in state,
-//   the implementation of this function will certainly need to change, and it
-//   should be abstracted away to juju/permission as pure business logic
-//   instead of operating on state values.
 func isGreaterAccess(currentAccess, newAccess state.ModelAccess) bool {
-	if currentAccess == state.ModelReadAccess && newAccess == state.ModelAdminAccess {
-		return true
-	}
-	return false
+	return !currentAccess.EqualOrGreaterThan(newAccess)
 }
 
 func userAuthorizedToChangeAccess(st Backend, userIsAdmin bool, userTag names.UserTag) error {
@@ -495,29 +547,101 @@ func ChangeModelAccess(accessor Backend, modelTag names.ModelTag, apiUser, targe
 		return errors.Annotate(err, "could not grant model access")
 
 	case params.RevokeModelAccess:
-		if stateAccess == state.ModelReadAccess {
-			// Revoking read access removes all access.
+		// Revoking an access level leaves the user with the level
+		// below it; revoking read access removes all access.
+		var remainingAccess state.ModelAccess
+		switch stateAccess {
+		case state.ModelReadAccess:
 			err := st.RemoveModelUser(targetUserTag)
 			return errors.Annotate(err, "could not revoke model access")
+		case state.ModelWriteAccess:
+			remainingAccess = state.ModelReadAccess
+		case state.ModelAdminAccess:
+			remainingAccess = state.ModelWriteAccess
+		default:
+			return errors.Errorf("don't know how to revoke %q access", stateAccess)
+		}
+		modelUser, err := st.ModelUser(targetUserTag)
+		if err != nil {
+			return errors.Annotate(err, "could not look up model access for user")
+		}
+		if !modelUser.Access().EqualOrGreaterThan(stateAccess) {
+			// The user does not have the access being revoked.
+			return nil
+		}
+		err = modelUser.SetAccess(remainingAccess)
+		return errors.Annotatef(err, "could not set model access to %q", remainingAccess)
 
-		} else if stateAccess == state.ModelAdminAccess {
-			// Revoking admin access sets read-only.
-			modelUser, err := st.ModelUser(targetUserTag)
-			if err != nil {
-				return errors.Annotate(err, "could not look up model access for user")
-			}
-			err = modelUser.SetAccess(state.ModelReadAccess)
-			return errors.Annotate(err, "could not set model access to read-only")
+	default:
+		return errors.Errorf("unknown action %q", action)
+	}
+}
 
-		} else {
-			return errors.Errorf("don't know how to revoke %q access", stateAccess)
+// ChangeServiceAccess performs the requested access grant or revoke
+// action for the specified user on the specified service. Users who
+// are granted access to a service are given read access to its model
+// if they do not already have access to it, which lets them see the
+// whole model; that read access is revoked along with the user's last
+// access to a service in the model.
+func ChangeServiceAccess(accessor Backend, modelTag names.ModelTag, serviceTag names.ServiceTag, apiUser, targetUserTag names.UserTag, action params.ModelAction, access permission.ServiceAccess, userIsAdmin bool) error {
+	st, err := accessor.ForModel(modelTag)
+	if err != nil {
+		return errors.Annotate(err, "could not lookup model")
+	}
+	defer st.Close()
+
+	if err := userAuthorizedToChangeAccess(st, userIsAdmin, apiUser); err != nil {
+		return errors.Trace(err)
+	}
+
+	stateAccess, err := resolveStateServiceAccess(access)
+	if err != nil {
+		return errors.Annotate(err, "could not resolve service access")
+	}
+	serviceName := serviceTag.Id()
+
+	switch action {
+	case params.GrantModelAccess:
+		currentAccess, err := st.ServiceAccess(serviceName, targetUserTag)
+		if err == nil && currentAccess == stateAccess {
+			return errors.Errorf("user already has %q access", currentAccess)
+		} else if err != nil && !errors.IsNotFound(err) {
+			return errors.Annotate(err, "could not look up service access for user")
 		}
+		err = st.SetServiceAccess(serviceName, targetUserTag, stateAccess, apiUser)
+		return errors.Annotate(err, "could not grant service access")
+
+	case params.RevokeModelAccess:
+		err := st.RemoveServiceAccess(serviceName, targetUserTag)
+		return errors.Annotate(err, "could not revoke service access")
 
 	default:
 		return errors.Errorf("unknown action %q", action)
 	}
 }
 
+// resolveStateServiceAccess returns the state representation of the
+// logical service access type.
+func resolveStateServiceAccess(access permission.ServiceAccess) (state.ServiceAccess, error) {
+	switch access {
+	case permission.ServiceOperateAccess:
+		return state.ServiceOperateAccess, nil
+	}
+	logger.Errorf("invalid access permission: %+v", access)
+	return "", errors.Errorf("invalid access permission")
+}
+
+// FromServiceAccessParam returns the logical service access type from
+// the API wireformat type.
+func FromServiceAccessParam(paramAccess params.ServiceAccessPermission) (permission.ServiceAccess, error) {
+	var fail permission.ServiceAccess
+	switch paramAccess {
+	case params.ServiceOperateAccess:
+		return permission.ServiceOperateAccess, nil
+	}
+	return fail, errors.Errorf("invalid service access permission %q", paramAccess)
+}
+
 // FromModelAccessParam returns the logical model access type from the API wireformat type.
 func FromModelAccessParam(paramAccess params.ModelAccessPermission) (permission.ModelAccess, error) {
 	var fail permission.ModelAccess
@@ -526,6 +650,8 @@ func FromModelAccessParam(paramAccess params.ModelAccessPermission) (permission.
 		return permission.ModelReadAccess, nil
 	case params.ModelWriteAccess:
 		return permission.ModelWriteAccess, nil
+	case params.ModelAdminAccess:
+		return permission.ModelAdminAccess, nil
 	}
 	return fail, errors.Errorf("invalid model access permission %q", paramAccess)
 }
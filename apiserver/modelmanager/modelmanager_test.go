@@ -101,6 +101,8 @@ func (s *modelManagerSuite) createArgsForVersion(c *gc.C, owner names.UserTag, v
 
 func (s *modelManagerSuite) TestUserCanCreateModel(c *gc.C) {
 	owner := names.NewUserTag("external@remote")
+	err := s.State.SetControllerAccess(owner, state.ControllerAddModelAccess, s.AdminUserTag(c))
+	c.Assert(err, jc.ErrorIsNil)
 	s.setAPIUser(c, owner)
 	model, err := s.modelmanager.CreateModel(s.createArgs(c, owner))
 	c.Assert(err, jc.ErrorIsNil)
@@ -108,6 +110,13 @@ func (s *modelManagerSuite) TestUserCanCreateModel(c *gc.C) {
 	c.Assert(model.Name, gc.Equals, "test-model")
 }
 
+func (s *modelManagerSuite) TestUserWithoutAddModelAccessCannotCreateModel(c *gc.C) {
+	user := s.Factory.MakeUser(c, &factory.UserParams{NoModelUser: true})
+	s.setAPIUser(c, user.UserTag())
+	_, err := s.modelmanager.CreateModel(s.createArgs(c, user.UserTag()))
+	c.Assert(err, gc.ErrorMatches, "permission denied")
+}
+
 func (s *modelManagerSuite) TestAdminCanCreateModelForSomeoneElse(c *gc.C) {
 	s.setAPIUser(c, s.AdminUserTag(c))
 	owner := names.NewUserTag("external@remote")
@@ -174,6 +183,8 @@ func (s *modelManagerSuite) TestCreateModelValidatesConfig(c *gc.C) {
 
 func (s *modelManagerSuite) TestCreateModelBadConfig(c *gc.C) {
 	owner := names.NewUserTag("external@remote")
+	err := s.State.SetControllerAccess(owner, state.ControllerAddModelAccess, s.AdminUserTag(c))
+	c.Assert(err, jc.ErrorIsNil)
 	s.setAPIUser(c, owner)
 	for i, test := range []struct {
 		key      string
@@ -375,6 +386,18 @@ func (s *modelManagerSuite) TestRevokeAdminLeavesReadAccess(c *gc.C) {
 	c.Assert(modelUser.ReadOnly(), jc.IsTrue)
 }
 
+func (s *modelManagerSuite) TestRevokeAdminLeavesWriteAccess(c *gc.C) {
+	s.setAPIUser(c, s.AdminUserTag(c))
+	user := s.Factory.MakeModelUser(c, &factory.ModelUserParams{Access: state.ModelAdminAccess})
+
+	err := s.revoke(c, user.UserTag(), params.ModelAdminAccess, user.ModelTag())
+	c.Assert(err, gc.IsNil)
+
+	modelUser, err := s.State.ModelUser(user.UserTag())
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(modelUser.Access(), gc.Equals, state.ModelWriteAccess)
+}
+
 func (s *modelManagerSuite) TestRevokeReadRemovesModelUser(c *gc.C) {
 	s.setAPIUser(c, s.AdminUserTag(c))
 	user := s.Factory.MakeModelUser(c, nil)
@@ -452,7 +475,7 @@ func (s *modelManagerSuite) TestGrantModelAddRemoteUser(c *gc.C) {
 	c.Assert(modelUser.ReadOnly(), jc.IsTrue)
 }
 
-func (s *modelManagerSuite) TestGrantModelAddAdminUser(c *gc.C) {
+func (s *modelManagerSuite) TestGrantModelAddWriteUser(c *gc.C) {
 	user := s.Factory.MakeUser(c, &factory.UserParams{Name: "foobar", NoModelUser: true})
 	apiUser := s.AdminUserTag(c)
 	s.setAPIUser(c, apiUser)
@@ -460,11 +483,30 @@ func (s *modelManagerSuite) TestGrantModelAddAdminUser(c *gc.C) {
 	defer st.Close()
 
 	err := s.grant(c, user.UserTag(), params.ModelWriteAccess, st.ModelTag())
+	c.Assert(err, jc.ErrorIsNil)
+
+	modelUser, err := st.ModelUser(user.UserTag())
+	c.Assert(err, jc.ErrorIsNil)
+	s.assertNewUser(c, modelUser, user.UserTag(), apiUser)
+	c.Assert(modelUser.ReadOnly(), jc.IsFalse)
+	c.Assert(modelUser.Access(), gc.Equals, state.ModelWriteAccess)
+}
+
+func (s *modelManagerSuite) TestGrantModelAddAdminUser(c *gc.C) {
+	user := s.Factory.MakeUser(c, &factory.UserParams{Name: "foobar", NoModelUser: true})
+	apiUser := s.AdminUserTag(c)
+	s.setAPIUser(c, apiUser)
+	st := s.Factory.MakeModel(c, nil)
+	defer st.Close()
+
+	err := s.grant(c, user.UserTag(), params.ModelAdminAccess, st.ModelTag())
+	c.Assert(err, jc.ErrorIsNil)
 
 	modelUser, err := st.ModelUser(user.UserTag())
 	c.Assert(err, jc.ErrorIsNil)
 	s.assertNewUser(c, modelUser, user.UserTag(), apiUser)
 	c.Assert(modelUser.ReadOnly(), jc.IsFalse)
+	c.Assert(modelUser.Access(), gc.Equals, state.ModelAdminAccess)
 }
 
 func (s *modelManagerSuite) TestGrantModelIncreaseAccess(c *gc.C) {
@@ -479,6 +521,13 @@ func (s *modelManagerSuite) TestGrantModelIncreaseAccess(c *gc.C) {
 
 	modelUser, err := st.ModelUser(user.UserTag())
 	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(modelUser.Access(), gc.Equals, state.ModelWriteAccess)
+
+	err = s.grant(c, user.UserTag(), params.ModelAdminAccess, st.ModelTag())
+	c.Assert(err, jc.ErrorIsNil)
+
+	modelUser, err = st.ModelUser(user.UserTag())
+	c.Assert(err, jc.ErrorIsNil)
 	c.Assert(modelUser.Access(), gc.Equals, state.ModelAdminAccess)
 }
 
@@ -513,6 +562,21 @@ func (s *modelManagerSuite) TestGrantToModelWriteAccess(c *gc.C) {
 	apiUser := names.NewUserTag("bob@remote")
 	s.setAPIUser(c, apiUser)
 
+	st := s.Factory.MakeModel(c, nil)
+	defer st.Close()
+	stFactory := factory.NewFactory(st)
+	stFactory.MakeModelUser(c, &factory.ModelUserParams{
+		User: apiUser.Canonical(), Access: state.ModelWriteAccess})
+
+	other := names.NewUserTag("other@remote")
+	err := s.grant(c, other, params.ModelReadAccess, st.ModelTag())
+	c.Assert(err, gc.ErrorMatches, "permission denied")
+}
+
+func (s *modelManagerSuite) TestGrantToModelAdminAccess(c *gc.C) {
+	apiUser := names.NewUserTag("bob@remote")
+	s.setAPIUser(c, apiUser)
+
 	st := s.Factory.MakeModel(c, nil)
 	defer st.Close()
 	stFactory := factory.NewFactory(st)
@@ -623,6 +687,96 @@ func (s *modelManagerSuite) TestModifyModelAccessInvalidAction(c *gc.C) {
 	c.Assert(result.OneError(), gc.ErrorMatches, expectedErr)
 }
 
+func (s *modelManagerSuite) modifyServiceAccess(c *gc.C, user names.UserTag, action params.ModelAction, access params.ServiceAccessPermission, service names.ServiceTag) error {
+	args := params.ModifyServiceAccessRequest{
+		Changes: []params.ModifyServiceAccess{{
+			UserTag:    user.String(),
+			Action:     action,
+			Access:     access,
+			ModelTag:   s.State.ModelTag().String(),
+			ServiceTag: service.String(),
+		}}}
+	result, err := s.modelmanager.ModifyServiceAccess(args)
+	c.Assert(err, jc.ErrorIsNil)
+	return result.OneError()
+}
+
+func (s *modelManagerSuite) TestGrantServiceAddsModelUser(c *gc.C) {
+	apiUser := s.AdminUserTag(c)
+	s.setAPIUser(c, apiUser)
+	service := s.Factory.MakeService(c, nil)
+	user := names.NewUserTag("bob@remote")
+
+	err := s.modifyServiceAccess(c, user, params.GrantModelAccess, params.ServiceOperateAccess, service.ServiceTag())
+	c.Assert(err, jc.ErrorIsNil)
+
+	access, err := s.State.ServiceAccess(service.Name(), user)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(access, gc.Equals, state.ServiceOperateAccess)
+	modelUser, err := s.State.ModelUser(user)
+	c.Assert(err, jc.ErrorIsNil)
+	s.assertNewUser(c, modelUser, user, apiUser)
+	c.Assert(modelUser.ReadOnly(), jc.IsTrue)
+
+	err = s.modifyServiceAccess(c, user, params.GrantModelAccess, params.ServiceOperateAccess, service.ServiceTag())
+	c.Assert(err, gc.ErrorMatches, `user already has "operate" access`)
+}
+
+func (s *modelManagerSuite) TestGrantServiceKeepsModelAccess(c *gc.C) {
+	s.setAPIUser(c, s.AdminUserTag(c))
+	service := s.Factory.MakeService(c, nil)
+	user := s.Factory.MakeModelUser(c, &factory.ModelUserParams{Access: state.ModelWriteAccess})
+
+	err := s.modifyServiceAccess(c, user.UserTag(), params.GrantModelAccess, params.ServiceOperateAccess, service.ServiceTag())
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.modifyServiceAccess(c, user.UserTag(), params.RevokeModelAccess, params.ServiceOperateAccess, service.ServiceTag())
+	c.Assert(err, jc.ErrorIsNil)
+
+	modelUser, err := s.State.ModelUser(user.UserTag())
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(modelUser.Access(), gc.Equals, state.ModelWriteAccess)
+}
+
+func (s *modelManagerSuite) TestGrantServiceNoAccess(c *gc.C) {
+	s.setAPIUser(c, names.NewUserTag("bob@remote"))
+	service := s.Factory.MakeService(c, nil)
+
+	err := s.modifyServiceAccess(c, names.NewUserTag("other@remote"), params.GrantModelAccess, params.ServiceOperateAccess, service.ServiceTag())
+	c.Assert(err, gc.ErrorMatches, "permission denied")
+}
+
+func (s *modelManagerSuite) TestGrantServiceMissingService(c *gc.C) {
+	s.setAPIUser(c, s.AdminUserTag(c))
+
+	err := s.modifyServiceAccess(c, names.NewUserTag("bob@remote"), params.GrantModelAccess, params.ServiceOperateAccess, names.NewServiceTag("foo"))
+	c.Assert(err, gc.ErrorMatches, `could not grant service access: cannot set access for "bob@remote" on service "foo": service "foo" not found`)
+}
+
+func (s *modelManagerSuite) TestRevokeServiceRemovesGrantedModelAccess(c *gc.C) {
+	s.setAPIUser(c, s.AdminUserTag(c))
+	service := s.Factory.MakeService(c, nil)
+	user := names.NewUserTag("bob@remote")
+	err := s.modifyServiceAccess(c, user, params.GrantModelAccess, params.ServiceOperateAccess, service.ServiceTag())
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = s.modifyServiceAccess(c, user, params.RevokeModelAccess, params.ServiceOperateAccess, service.ServiceTag())
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = s.State.ServiceAccess(service.Name(), user)
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+	_, err = s.State.ModelUser(user)
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+}
+
+func (s *modelManagerSuite) TestModifyServiceAccessInvalidAccess(c *gc.C) {
+	s.setAPIUser(c, s.AdminUserTag(c))
+	service := s.Factory.MakeService(c, nil)
+
+	err := s.modifyServiceAccess(c, names.NewUserTag("bob@remote"), params.GrantModelAccess, "admin", service.ServiceTag())
+	c.Assert(err, gc.ErrorMatches, `could not modify service access: invalid service access permission "admin"`)
+	err = s.modifyServiceAccess(c, names.NewUserTag("bob@remote"), params.GrantModelAccess, "read", service.ServiceTag())
+	c.Assert(err, gc.ErrorMatches, `could not modify service access: invalid service access permission "read"`)
+}
+
 type fakeProvider struct {
 	environs.EnvironProvider
 }
@@ -20,6 +20,7 @@ type Backend interface {
 	ModelUUID() string
 	ModelsForUser(names.UserTag) ([]*state.UserModel, error)
 	IsControllerAdministrator(user names.UserTag) (bool, error)
+	ControllerAccess(user names.UserTag) (state.ControllerAccess, error)
 	NewModel(state.ModelArgs) (*state.Model, *state.State, error)
 	ControllerModel() (*state.Model, error)
 	ForModel(tag names.ModelTag) (Backend, error)
@@ -27,6 +28,9 @@ type Backend interface {
 	AddModelUser(state.ModelUserSpec) (*state.ModelUser, error)
 	RemoveModelUser(names.UserTag) error
 	ModelUser(names.UserTag) (*state.ModelUser, error)
+	ServiceAccess(string, names.UserTag) (state.ServiceAccess, error)
+	SetServiceAccess(string, names.UserTag, state.ServiceAccess, names.UserTag) error
+	RemoveServiceAccess(string, names.UserTag) error
 	Close() error
 }
 
@@ -30,6 +30,10 @@ const (
 	// ActionRunning is the status of an Action that has been started but
 	// not completed yet.
 	ActionRunning string = "running"
+
+	// ActionAborting is the status of a running Action that has been
+	// cancelled, but not yet stopped.
+	ActionAborting string = "aborting"
 )
 
 // Actions is a slice of Action for bulk requests.
@@ -43,6 +47,7 @@ type Action struct {
 	Receiver   string                 `json:"receiver"`
 	Name       string                 `json:"name"`
 	Parameters map[string]interface{} `json:"parameters,omitempty"`
+	Timeout    time.Duration          `json:"timeout,omitempty"`
 }
 
 // ActionResults is a slice of ActionResult for bulk requests.
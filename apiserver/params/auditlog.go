@@ -0,0 +1,35 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package params
+
+import "time"
+
+// AuditLogFilter holds the criteria used to select audit log
+// entries. Empty fields do not restrict the entries returned.
+type AuditLogFilter struct {
+	UserTag   string    `json:"user-tag,omitempty"`
+	ModelUUID string    `json:"model-uuid,omitempty"`
+	Method    string    `json:"method,omitempty"`
+	After     time.Time `json:"after"`
+	Before    time.Time `json:"before"`
+	Limit     int       `json:"limit,omitempty"`
+}
+
+// AuditLogEntry holds a single audit log entry.
+type AuditLogEntry struct {
+	Timestamp time.Time `json:"timestamp"`
+	UserTag   string    `json:"user-tag"`
+	ModelUUID string    `json:"model-uuid"`
+	Facade    string    `json:"facade"`
+	Version   int       `json:"version"`
+	Method    string    `json:"method"`
+	Args      string    `json:"args,omitempty"`
+	Error     string    `json:"error,omitempty"`
+	ErrorCode string    `json:"error-code,omitempty"`
+}
+
+// AuditLogEntries holds the results of an audit log query.
+type AuditLogEntries struct {
+	Entries []AuditLogEntry `json:"entries"`
+}
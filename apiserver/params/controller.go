@@ -46,3 +46,38 @@ type ModelStatus struct {
 type ModelStatusResults struct {
 	Results []ModelStatus `json:"models"`
 }
+
+// ModifyControllerAccessRequest holds the parameters for making grant
+// and revoke controller calls.
+type ModifyControllerAccessRequest struct {
+	Changes []ModifyControllerAccess `json:"changes"`
+}
+
+// ModifyControllerAccess holds a single change to a user's access to
+// the controller.
+type ModifyControllerAccess struct {
+	UserTag string                     `json:"user-tag"`
+	Action  ControllerAction           `json:"action"`
+	Access  ControllerAccessPermission `json:"access"`
+}
+
+// ControllerAction is an action that can be performed on a user's
+// access to the controller.
+type ControllerAction string
+
+// Actions that can be performed on a user's access to the controller.
+const (
+	GrantControllerAccess  ControllerAction = "grant"
+	RevokeControllerAccess ControllerAction = "revoke"
+)
+
+// ControllerAccessPermission is the type of permission that a user has
+// on the controller.
+type ControllerAccessPermission string
+
+// Controller access permissions that may be set on a user.
+const (
+	ControllerLoginAccess     ControllerAccessPermission = "login"
+	ControllerAddModelAccess  ControllerAccessPermission = "addmodel"
+	ControllerSuperuserAccess ControllerAccessPermission = "superuser"
+)
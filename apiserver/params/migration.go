@@ -3,6 +3,10 @@
 
 package params
 
+import (
+	"github.com/juju/version"
+)
+
 // InitiateModelMigrationArgs holds the details required to start one
 // or more model migrations.
 type InitiateModelMigrationArgs struct {
@@ -46,6 +50,32 @@ type SetMigrationPhaseArgs struct {
 	Phase string `json:"phase"`
 }
 
+// SetMigrationStatusMessageArgs provides a migration status message
+// to the migrationmaster.SetStatusMessage API method.
+type SetMigrationStatusMessageArgs struct {
+	Message string `json:"message"`
+}
+
+// MigrationModelInfo is used to report basic model information to
+// the migrationmaster worker and to the target controller's
+// migrationtarget facade.
+type MigrationModelInfo struct {
+	UUID            string            `json:"uuid"`
+	Name            string            `json:"name"`
+	OwnerTag        string            `json:"owner-tag"`
+	AgentVersion    version.Number    `json:"agent-version"`
+	ProviderType    string            `json:"provider-type"`
+	CloudAttrs      map[string]string `json:"cloud-attrs,omitempty"`
+	CredentialAttrs []string          `json:"credential-attrs,omitempty"`
+}
+
+// MigrationPrecheckResult holds the outcome of the checks performed
+// on a controller before a model is migrated. Each entry in Failures
+// describes one reason why the migration can't proceed.
+type MigrationPrecheckResult struct {
+	Failures []string `json:"failures,omitempty"`
+}
+
 // SerializedModel wraps a buffer contain a serialised Juju model.
 type SerializedModel struct {
 	Bytes []byte `json:"bytes"`
@@ -58,8 +88,9 @@ type ModelArgs struct {
 
 // MigrationStatus reports the current status of a model migration.
 type MigrationStatus struct {
-	Attempt int    `json:"attempt"`
-	Phase   string `json:"phase"`
+	MigrationId string `json:"migration-id"`
+	Attempt     int    `json:"attempt"`
+	Phase       string `json:"phase"`
 
 	// TODO(mjs): I'm not convinced these Source fields will get used.
 	SourceAPIAddrs []string `json:"source-api-addrs"`
@@ -73,9 +104,48 @@ type MigrationStatus struct {
 // migration, including authentication details for the remote
 // controller.
 type FullMigrationStatus struct {
-	Spec    ModelMigrationSpec `json:"spec"`
-	Attempt int                `json:"attempt"`
-	Phase   string             `json:"phase"`
+	Spec        ModelMigrationSpec `json:"spec"`
+	MigrationId string             `json:"migration-id"`
+	Attempt     int                `json:"attempt"`
+	Phase       string             `json:"phase"`
+}
+
+// MinionReport holds the details of whether a migration minion
+// succeeded or failed for a specific migration phase.
+type MinionReport struct {
+	// MigrationId holds the id of the migration the agent is
+	// reporting about.
+	MigrationId string `json:"migration-id"`
+
+	// Phase holds the phase of the migration the agent is
+	// reporting about.
+	Phase string `json:"phase"`
+
+	// Success is true if the agent successfully completed its actions
+	// for the migration phase, false otherwise.
+	Success bool `json:"success"`
+}
+
+// MinionReports holds the details of the reports made by the
+// migration minions of a model for a specific migration phase.
+type MinionReports struct {
+	// MigrationId holds the id of the migration the reports relate to.
+	MigrationId string `json:"migration-id"`
+
+	// Phase holds the phase of the migration the reports relate to.
+	Phase string `json:"phase"`
+
+	// SuccessCount holds the number of agents which have successfully
+	// completed the migration phase.
+	SuccessCount int `json:"success-count"`
+
+	// Unknown contains the tags of the agents which are still to
+	// report for the migration phase.
+	Unknown []string `json:"unknown"`
+
+	// Failed contains the tags of the agents which have reported
+	// that they failed to complete the migration phase.
+	Failed []string `json:"failed"`
 }
 
 type PhaseResult struct {
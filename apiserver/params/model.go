@@ -138,4 +138,30 @@ type ModelAccessPermission string
 const (
 	ModelReadAccess  ModelAccessPermission = "read"
 	ModelWriteAccess ModelAccessPermission = "write"
+	ModelAdminAccess ModelAccessPermission = "admin"
+)
+
+// ModifyServiceAccessRequest holds the parameters for making grant and
+// revoke service calls.
+type ModifyServiceAccessRequest struct {
+	Changes []ModifyServiceAccess `json:"changes"`
+}
+
+// ModifyServiceAccess holds a single change to a user's access to a
+// service in a model.
+type ModifyServiceAccess struct {
+	UserTag    string                  `json:"user-tag"`
+	Action     ModelAction             `json:"action"`
+	Access     ServiceAccessPermission `json:"access"`
+	ModelTag   string                  `json:"model-tag"`
+	ServiceTag string                  `json:"service-tag"`
+}
+
+// ServiceAccessPermission is the type of permission that a user has
+// on a service.
+type ServiceAccessPermission string
+
+// Service access permissions that may be set on a user.
+const (
+	ServiceOperateAccess ServiceAccessPermission = "operate"
 )
@@ -203,6 +203,32 @@ func (pr PortRange) NetworkPortRange() network.PortRange {
 	}
 }
 
+// IngressRule represents a port range open to traffic from a source
+// CIDR. It is used in API requests/responses. See also
+// network.IngressRule, from/to which this is transformed.
+type IngressRule struct {
+	PortRange  PortRange `json:"PortRange"`
+	SourceCIDR string    `json:"SourceCIDR"`
+}
+
+// FromNetworkIngressRule is a convenience helper to create a parameter
+// out of the network type, here for IngressRule.
+func FromNetworkIngressRule(rule network.IngressRule) IngressRule {
+	return IngressRule{
+		PortRange:  FromNetworkPortRange(rule.PortRange),
+		SourceCIDR: rule.SourceCIDR,
+	}
+}
+
+// NetworkIngressRule is a convenience helper to return the parameter
+// as network type, here for IngressRule.
+func (rule IngressRule) NetworkIngressRule() network.IngressRule {
+	return network.IngressRule{
+		PortRange:  rule.PortRange.NetworkPortRange(),
+		SourceCIDR: rule.SourceCIDR,
+	}
+}
+
 // EntityPort holds an entity's tag, a protocol and a port.
 type EntityPort struct {
 	Tag      string `json:"Tag"`
@@ -406,6 +432,30 @@ type MachinePorts struct {
 // API request / response types.
 // -----
 
+// HostFirewallRulesResults holds the bulk operation result of an API
+// call that returns the host firewall rules of machines.
+type HostFirewallRulesResults struct {
+	Results []HostFirewallRulesResult `json:"Results"`
+}
+
+// HostFirewallRulesResult holds the rules a machine's host firewall
+// should enforce, or an error.
+type HostFirewallRulesResult struct {
+	Error *Error `json:"Error,omitempty"`
+
+	// IngressRules holds the rules for the ports of exposed services.
+	IngressRules []IngressRule `json:"IngressRules"`
+
+	// OpenedPorts holds all the port ranges opened by units on the
+	// machine.
+	OpenedPorts []PortRange `json:"OpenedPorts"`
+
+	// InternalCIDRs holds the addresses of the machines in the model,
+	// which may reach the opened ports whether or not the services are
+	// exposed.
+	InternalCIDRs []string `json:"InternalCIDRs"`
+}
+
 // PortsResults holds the bulk operation result of an API call
 // that returns a slice of Port.
 type PortsResults struct {
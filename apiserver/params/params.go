@@ -248,8 +248,11 @@ type ServiceSetCharm struct {
 }
 
 // ServiceExpose holds the parameters for making the service Expose call.
+// If CIDRs is not empty, the service is exposed only to traffic from
+// those source CIDRs.
 type ServiceExpose struct {
 	ServiceName string
+	CIDRs       []string `json:",omitempty"`
 }
 
 // ServiceSet holds the parameters for a service Set
@@ -356,6 +359,10 @@ type AddServiceUnits struct {
 // DestroyServiceUnits holds parameters for the DestroyUnits call.
 type DestroyServiceUnits struct {
 	UnitNames []string
+
+	// KeepStorage, if true, causes the units' storage to be detached
+	// rather than destroyed along with the units.
+	KeepStorage bool `json:",omitempty"`
 }
 
 // ServiceDestroy holds the parameters for making the service Destroy call.
@@ -753,8 +760,14 @@ type RebootActionResult struct {
 // LogRecord is used to transmit log messages to the logsink API
 // endpoint.  Single character field names are used for serialisation
 // to keep the size down. These messages are going to be sent a lot.
+//
+// Entity is not sent by agents writing to the logsink (the entity is
+// implied by the authenticated connection) but is included when logs
+// are streamed from the debug-log endpoint in JSON format and when
+// they are transferred between controllers during a model migration.
 type LogRecord struct {
 	Time     time.Time   `json:"t"`
+	Entity   string      `json:"e,omitempty"`
 	Module   string      `json:"m"`
 	Location string      `json:"l"`
 	Level    loggo.Level `json:"v"`
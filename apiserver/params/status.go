@@ -52,6 +52,7 @@ type ServiceStatus struct {
 	Err           error
 	Charm         string
 	Exposed       bool
+	ExposedCIDRs  []string
 	Life          string
 	Relations     map[string][]string
 	CanUpgradeTo  string
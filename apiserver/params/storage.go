@@ -3,7 +3,11 @@
 
 package params
 
-import "github.com/juju/juju/storage"
+import (
+	"time"
+
+	"github.com/juju/juju/storage"
+)
 
 // MachineBlockDevices holds a machine tag and the block devices present
 // on that machine.
@@ -94,6 +98,10 @@ type StorageAttachment struct {
 	Kind     StorageKind
 	Location string
 	Life     Life
+
+	// Size is the size of the volume backing the storage, in MiB,
+	// or zero if the storage is not backed by a volume.
+	Size uint64
 }
 
 // StorageAttachmentId identifies a storage attachment by the tags of the
@@ -209,18 +217,20 @@ type VolumeParams struct {
 	Provider   string                  `json:"provider"`
 	Attributes map[string]interface{}  `json:"attributes,omitempty"`
 	Tags       map[string]string       `json:"tags,omitempty"`
+	SnapshotId string                  `json:"snapshotid,omitempty"`
 	Attachment *VolumeAttachmentParams `json:"attachment,omitempty"`
 }
 
 // VolumeAttachmentParams holds the parameters for creating a volume
 // attachment.
 type VolumeAttachmentParams struct {
-	VolumeTag  string `json:"volumetag"`
-	MachineTag string `json:"machinetag"`
-	VolumeId   string `json:"volumeid,omitempty"`
-	InstanceId string `json:"instanceid,omitempty"`
-	Provider   string `json:"provider"`
-	ReadOnly   bool   `json:"read-only,omitempty"`
+	VolumeTag  string                 `json:"volumetag"`
+	MachineTag string                 `json:"machinetag"`
+	VolumeId   string                 `json:"volumeid,omitempty"`
+	InstanceId string                 `json:"instanceid,omitempty"`
+	Provider   string                 `json:"provider"`
+	ReadOnly   bool                   `json:"read-only,omitempty"`
+	Attributes map[string]interface{} `json:"attributes,omitempty"`
 }
 
 // VolumeAttachmentsResult holds the volume attachments for a single
@@ -270,6 +280,26 @@ type VolumeParamsResults struct {
 	Results []VolumeParamsResult `json:"results,omitempty"`
 }
 
+// VolumeResizeParams holds the parameters for resizing a volume.
+type VolumeResizeParams struct {
+	VolumeTag string `json:"volumetag"`
+	VolumeId  string `json:"volumeid"`
+	Size      uint64 `json:"size"`
+	Provider  string `json:"provider"`
+}
+
+// VolumeResizeParamsResult holds resizing parameters for a volume.
+type VolumeResizeParamsResult struct {
+	Result VolumeResizeParams `json:"result"`
+	Error  *Error             `json:"error,omitempty"`
+}
+
+// VolumeResizeParamsResults holds resizing parameters for multiple
+// volumes.
+type VolumeResizeParamsResults struct {
+	Results []VolumeResizeParamsResult `json:"results,omitempty"`
+}
+
 // VolumeAttachmentParamsResults holds provisioning parameters for a volume
 // attachment.
 type VolumeAttachmentParamsResult struct {
@@ -334,13 +364,14 @@ type FilesystemParams struct {
 // FilesystemAttachmentParams holds the parameters for creating a filesystem
 // attachment.
 type FilesystemAttachmentParams struct {
-	FilesystemTag string `json:"filesystemtag"`
-	MachineTag    string `json:"machinetag"`
-	FilesystemId  string `json:"filesystemid,omitempty"`
-	InstanceId    string `json:"instanceid,omitempty"`
-	Provider      string `json:"provider"`
-	MountPoint    string `json:"mountpoint,omitempty"`
-	ReadOnly      bool   `json:"read-only,omitempty"`
+	FilesystemTag string                 `json:"filesystemtag"`
+	MachineTag    string                 `json:"machinetag"`
+	FilesystemId  string                 `json:"filesystemid,omitempty"`
+	InstanceId    string                 `json:"instanceid,omitempty"`
+	Provider      string                 `json:"provider"`
+	MountPoint    string                 `json:"mountpoint,omitempty"`
+	ReadOnly      bool                   `json:"read-only,omitempty"`
+	Attributes    map[string]interface{} `json:"attributes,omitempty"`
 }
 
 // FilesystemAttachmentResult holds the details of a single filesystem attachment,
@@ -396,6 +427,7 @@ type StorageDetails struct {
 	StorageTag string `json:"storagetag"`
 
 	// OwnerTag holds tag for the owner of this storage, unit or service.
+	// It is empty if the storage has been detached from its unit.
 	OwnerTag string `json:"ownertag"`
 
 	// Kind holds what kind of storage this instance is.
@@ -662,9 +694,122 @@ type StorageAddParams struct {
 
 	// Constraints are specified storage constraints.
 	Constraints StorageConstraints `json:"storage"`
+
+	// FromSnapshot, if non-empty, is the ID of the volume snapshot
+	// from which the storage's volume will be created.
+	FromSnapshot string `json:"fromsnapshot,omitempty"`
 }
 
 // StoragesAddParams holds storage details to add to units dynamically.
 type StoragesAddParams struct {
 	Storages []StorageAddParams `json:"storages"`
 }
+
+// ImportStorageParams contains the parameters for importing a storage
+// entity that already exists in the cloud into the model.
+type ImportStorageParams struct {
+	// Pool is the name of the storage pool that the storage will be
+	// managed by.
+	Pool string `json:"pool"`
+
+	// ProviderId is the storage provider's unique ID for the volume.
+	ProviderId string `json:"providerid"`
+
+	// StorageName is the name of the storage to create for the
+	// imported volume.
+	StorageName string `json:"storagename"`
+}
+
+// BulkImportStorageParams contains the parameters for importing a
+// collection of storage entities.
+type BulkImportStorageParams struct {
+	Storage []ImportStorageParams `json:"storage"`
+}
+
+// ImportStorageDetails contains the details of an imported storage
+// entity.
+type ImportStorageDetails struct {
+	// StorageTag contains the string representation of the storage
+	// tag assigned to the imported storage entity.
+	StorageTag string `json:"storagetag"`
+}
+
+// ImportStorageResult contains the result of importing a storage
+// entity.
+type ImportStorageResult struct {
+	Result *ImportStorageDetails `json:"result,omitempty"`
+	Error  *Error                `json:"error,omitempty"`
+}
+
+// ImportStorageResults contains the results of importing a
+// collection of storage entities.
+type ImportStorageResults struct {
+	Results []ImportStorageResult `json:"results"`
+}
+
+// VolumeSnapshotDetails contains the details of a volume snapshot.
+type VolumeSnapshotDetails struct {
+	// Id is the ID of the snapshot, unique within the model.
+	Id string `json:"id"`
+
+	// StorageTag, if non-empty, is the tag of the storage instance
+	// that the snapshotted volume was assigned to.
+	StorageTag string `json:"storagetag,omitempty"`
+
+	// VolumeTag is the tag of the snapshotted volume.
+	VolumeTag string `json:"volumetag"`
+
+	// SnapshotId is the storage provider's unique ID for the snapshot.
+	SnapshotId string `json:"snapshotid"`
+
+	// Pool is the name of the storage pool that the snapshotted
+	// volume was created in.
+	Pool string `json:"pool"`
+
+	// Size is the size of the snapshotted volume, in MiB.
+	Size uint64 `json:"size"`
+
+	// Created is the time at which the snapshot was recorded.
+	Created time.Time `json:"created"`
+}
+
+// VolumeSnapshotResult contains the result of creating a volume
+// snapshot.
+type VolumeSnapshotResult struct {
+	Result *VolumeSnapshotDetails `json:"result,omitempty"`
+	Error  *Error                 `json:"error,omitempty"`
+}
+
+// VolumeSnapshotResults contains the results of creating a collection
+// of volume snapshots.
+type VolumeSnapshotResults struct {
+	Results []VolumeSnapshotResult `json:"results"`
+}
+
+// VolumeSnapshotDetailsList contains the details of a collection of
+// volume snapshots.
+type VolumeSnapshotDetailsList struct {
+	Snapshots []VolumeSnapshotDetails `json:"snapshots"`
+}
+
+// VolumeSnapshotIds contains the IDs of a collection of volume
+// snapshots.
+type VolumeSnapshotIds struct {
+	Ids []string `json:"ids"`
+}
+
+// ResizeStorageParams contains the parameters for resizing the volume
+// backing a storage instance.
+type ResizeStorageParams struct {
+	// StorageTag is the tag of the storage instance to resize.
+	StorageTag string `json:"storagetag"`
+
+	// Size is the requested new size of the storage, in MiB.
+	Size uint64 `json:"size"`
+}
+
+// BulkResizeStorageParams contains the parameters for resizing a
+// collection of storage instances.
+type BulkResizeStorageParams struct {
+	Storage []ResizeStorageParams `json:"storage"`
+}
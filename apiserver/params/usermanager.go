@@ -15,6 +15,16 @@ type UserInfo struct {
 	DateCreated    time.Time  `json:"date-created"`
 	LastConnection *time.Time `json:"last-connection,omitempty"`
 	Disabled       bool       `json:"disabled"`
+
+	// FailedLogins holds the most recent failed attempts to log in
+	// as the user, oldest first.
+	FailedLogins []FailedLogin `json:"failed-logins,omitempty"`
+}
+
+// FailedLogin holds the details of a failed attempt to log in.
+type FailedLogin struct {
+	Time          time.Time `json:"time"`
+	RemoteAddress string    `json:"remote-address"`
 }
 
 // UserInfoResult holds the result of a UserInfo call.
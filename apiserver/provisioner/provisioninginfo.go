@@ -160,6 +160,7 @@ func (p *ProvisionerAPI) machineVolumeParams(m *state.Machine) ([]params.VolumeP
 			"", // we're creating the machine, so it has no instance ID.
 			volumeParams.Provider,
 			volumeAttachmentParams.ReadOnly,
+			volumeParams.Attributes,
 		}
 		allVolumeParams = append(allVolumeParams, volumeParams)
 	}
@@ -82,6 +82,7 @@ func (s *withoutControllerSuite) TestProvisioningInfoWithStorage(c *gc.C) {
 						MachineTag: placementMachine.Tag().String(),
 						VolumeTag:  "volume-0",
 						Provider:   "static",
+						Attributes: map[string]interface{}{"foo": "bar"},
 					},
 				}, {
 					VolumeTag:  "volume-1",
@@ -96,6 +97,7 @@ func (s *withoutControllerSuite) TestProvisioningInfoWithStorage(c *gc.C) {
 						MachineTag: placementMachine.Tag().String(),
 						VolumeTag:  "volume-1",
 						Provider:   "static",
+						Attributes: map[string]interface{}{"foo": "bar"},
 					},
 				}},
 			}},
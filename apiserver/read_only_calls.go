@@ -19,6 +19,7 @@ var readOnlyCalls = set.NewStrings(
 	"Action.ListCompleted",
 	"Action.ServicesCharmActions",
 	"Annotations.Get",
+	"AuditLog.Entries",
 	"Block.List",
 	"Charms.CharmInfo",
 	"Charms.IsMetered",
@@ -48,6 +49,7 @@ var readOnlyCalls = set.NewStrings(
 	"ModelManager.ModelInfo",
 	"Service.GetConstraints",
 	"Service.CharmRelations",
+	"Service.ExportBundle",
 	"Service.Get",
 	"Spaces.ListSpaces",
 	"Storage.ListStorageDetails",
@@ -27,6 +27,7 @@ func newRestrictedRoot(finder rpc.MethodFinder) *restrictedRoot {
 // boundaries.
 var restrictedRootNames = set.NewStrings(
 	"AllModelWatcher",
+	"AuditLog",
 	"Controller",
 	"MigrationTarget",
 	"ModelManager",
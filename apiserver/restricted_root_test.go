@@ -36,6 +36,8 @@ func (r *restrictedRootSuite) TestFindAllowedMethod(c *gc.C) {
 	r.assertMethodAllowed(c, "AllModelWatcher", 2, "Next")
 	r.assertMethodAllowed(c, "AllModelWatcher", 2, "Stop")
 
+	r.assertMethodAllowed(c, "AuditLog", 1, "Entries")
+
 	r.assertMethodAllowed(c, "ModelManager", 2, "CreateModel")
 	r.assertMethodAllowed(c, "ModelManager", 2, "ListModels")
 
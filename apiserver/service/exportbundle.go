@@ -0,0 +1,263 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package service
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/juju/errors"
+	"gopkg.in/juju/charm.v6-unstable"
+	goyaml "gopkg.in/yaml.v2"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/constraints"
+	"github.com/juju/juju/state"
+)
+
+// ExportBundle returns a bundle, in YAML format, that describes the
+// services, relations and machines in the model, so that the model
+// can be recreated by deploying the bundle.
+func (api *API) ExportBundle() (params.StringResult, error) {
+	data, err := exportBundle(api.state)
+	if err != nil {
+		return params.StringResult{}, errors.Annotate(err, "cannot export bundle")
+	}
+	out, err := goyaml.Marshal(data)
+	if err != nil {
+		return params.StringResult{}, errors.Trace(err)
+	}
+	return params.StringResult{Result: string(out)}, nil
+}
+
+// exportBundle builds the bundle data for the model. Units are placed
+// on the machines they currently occupy; each container is created by
+// the first unit placed in it, and the other units in the container are
+// placed alongside that unit.
+func exportBundle(st *state.State) (*charm.BundleData, error) {
+	services, err := st.AllServices()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	sort.Sort(servicesByName(services))
+	data := &charm.BundleData{
+		Services: make(map[string]*charm.ServiceSpec),
+		Machines: make(map[string]*charm.MachineSpec),
+	}
+	// containerUnits records, for each container, the bundle placement
+	// of the first unit placed in it.
+	containerUnits := make(map[string]string)
+	for _, service := range services {
+		spec, err := exportService(st, service)
+		if err != nil {
+			return nil, errors.Annotatef(err, "service %q", service.Name())
+		}
+		if service.IsPrincipal() {
+			units, err := service.AllUnits()
+			if err != nil {
+				return nil, errors.Trace(err)
+			}
+			sort.Sort(unitsByNumber(units))
+			spec.NumUnits = len(units)
+			for i, unit := range units {
+				machineId, err := unit.AssignedMachineId()
+				if errors.IsNotAssigned(err) {
+					spec.To = append(spec.To, "new")
+					continue
+				} else if err != nil {
+					return nil, errors.Trace(err)
+				}
+				data.Machines[state.TopParentId(machineId)] = nil
+				spec.To = append(spec.To, unitPlacement(machineId, service.Name(), i, containerUnits))
+			}
+		}
+		data.Services[service.Name()] = spec
+	}
+
+	for id := range data.Machines {
+		machine, err := st.Machine(id)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		spec, err := exportMachine(st, machine)
+		if err != nil {
+			return nil, errors.Annotatef(err, "machine %s", id)
+		}
+		data.Machines[id] = spec
+	}
+
+	relations, err := st.AllRelations()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	for _, relation := range relations {
+		endpoints := relation.Endpoints()
+		if len(endpoints) != 2 {
+			// Peer relations are established by deploying the
+			// service, so they are not part of the bundle.
+			continue
+		}
+		data.Relations = append(data.Relations, []string{
+			endpoints[0].String(),
+			endpoints[1].String(),
+		})
+	}
+	sort.Sort(relationsByEndpoints(data.Relations))
+	return data, nil
+}
+
+// unitPlacement returns the bundle placement directive for the unit
+// with the given index, which is assigned to the given machine.
+func unitPlacement(machineId, serviceName string, index int, containerUnits map[string]string) string {
+	parentId := state.ParentId(machineId)
+	if parentId == "" {
+		return machineId
+	}
+	if placement, ok := containerUnits[machineId]; ok {
+		return placement
+	}
+	containerUnits[machineId] = fmt.Sprintf("%s/%d", serviceName, index)
+	return fmt.Sprintf("%s:%s", state.ContainerTypeFromId(machineId), state.TopParentId(machineId))
+}
+
+// exportService returns the bundle description of the service, without
+// its units.
+func exportService(st *state.State, service *state.Service) (*charm.ServiceSpec, error) {
+	curl, _ := service.CharmURL()
+	spec := &charm.ServiceSpec{
+		Charm:  curl.String(),
+		Expose: service.IsExposed(),
+	}
+	if curl.Series == "" {
+		spec.Series = service.Series()
+	}
+
+	ch, _, err := service.Charm()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	settings, err := service.ConfigSettings()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	for name, value := range settings {
+		if option, ok := ch.Config().Options[name]; ok && option.Default == value {
+			continue
+		}
+		if spec.Options == nil {
+			spec.Options = make(map[string]interface{})
+		}
+		spec.Options[name] = value
+	}
+
+	if service.IsPrincipal() {
+		cons, err := service.Constraints()
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		if !constraints.IsEmpty(&cons) {
+			spec.Constraints = cons.String()
+		}
+	}
+
+	storageConstraints, err := service.StorageConstraints()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	for name, cons := range storageConstraints {
+		if spec.Storage == nil {
+			spec.Storage = make(map[string]string)
+		}
+		spec.Storage[name] = formatStorageConstraints(cons)
+	}
+
+	bindings, err := service.EndpointBindings()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	for endpoint, space := range bindings {
+		if space == "" {
+			// Bound to the default space.
+			continue
+		}
+		if spec.EndpointBindings == nil {
+			spec.EndpointBindings = make(map[string]string)
+		}
+		spec.EndpointBindings[endpoint] = space
+	}
+
+	annotations, err := st.Annotations(service)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if len(annotations) > 0 {
+		spec.Annotations = annotations
+	}
+	return spec, nil
+}
+
+// exportMachine returns the bundle description of the machine.
+func exportMachine(st *state.State, machine *state.Machine) (*charm.MachineSpec, error) {
+	spec := &charm.MachineSpec{
+		Series: machine.Series(),
+	}
+	cons, err := machine.Constraints()
+	if err != nil && !errors.IsNotFound(err) {
+		return nil, errors.Trace(err)
+	}
+	if !constraints.IsEmpty(&cons) {
+		spec.Constraints = cons.String()
+	}
+	annotations, err := st.Annotations(machine)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if len(annotations) > 0 {
+		spec.Annotations = annotations
+	}
+	return spec, nil
+}
+
+// formatStorageConstraints returns the storage constraints in the form
+// accepted by storage.ParseConstraints.
+func formatStorageConstraints(cons state.StorageConstraints) string {
+	s := fmt.Sprintf("%d,%dM", cons.Count, cons.Size)
+	if cons.Pool != "" {
+		s = cons.Pool + "," + s
+	}
+	return s
+}
+
+type servicesByName []*state.Service
+
+func (s servicesByName) Len() int           { return len(s) }
+func (s servicesByName) Swap(i, j int)      { s[i], s[j] = s[j], s[i] }
+func (s servicesByName) Less(i, j int) bool { return s[i].Name() < s[j].Name() }
+
+type unitsByNumber []*state.Unit
+
+func (u unitsByNumber) Len() int      { return len(u) }
+func (u unitsByNumber) Swap(i, j int) { u[i], u[j] = u[j], u[i] }
+func (u unitsByNumber) Less(i, j int) bool {
+	return unitNumber(u[i].Name()) < unitNumber(u[j].Name())
+}
+
+func unitNumber(unitName string) int {
+	// Unit names have been validated, so the number is always valid.
+	n, _ := strconv.Atoi(unitName[strings.LastIndex(unitName, "/")+1:])
+	return n
+}
+
+type relationsByEndpoints [][]string
+
+func (r relationsByEndpoints) Len() int      { return len(r) }
+func (r relationsByEndpoints) Swap(i, j int) { r[i], r[j] = r[j], r[i] }
+func (r relationsByEndpoints) Less(i, j int) bool {
+	if r[i][0] != r[j][0] {
+		return r[i][0] < r[j][0]
+	}
+	return r[i][1] < r[j][1]
+}
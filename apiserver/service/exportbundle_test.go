@@ -0,0 +1,118 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package service_test
+
+import (
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/charm.v6-unstable"
+	goyaml "gopkg.in/yaml.v2"
+
+	"github.com/juju/juju/apiserver/service"
+	apiservertesting "github.com/juju/juju/apiserver/testing"
+	"github.com/juju/juju/constraints"
+	jujutesting "github.com/juju/juju/juju/testing"
+	"github.com/juju/juju/testing/factory"
+)
+
+type exportBundleSuite struct {
+	jujutesting.JujuConnSuite
+
+	serviceApi *service.API
+}
+
+var _ = gc.Suite(&exportBundleSuite{})
+
+func (s *exportBundleSuite) SetUpTest(c *gc.C) {
+	s.JujuConnSuite.SetUpTest(c)
+	authorizer := apiservertesting.FakeAuthorizer{
+		Tag: s.AdminUserTag(c),
+	}
+	var err error
+	s.serviceApi, err = service.NewAPI(s.State, nil, authorizer)
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *exportBundleSuite) exportBundle(c *gc.C) *charm.BundleData {
+	result, err := s.serviceApi.ExportBundle()
+	c.Assert(err, jc.ErrorIsNil)
+	var data charm.BundleData
+	err = goyaml.Unmarshal([]byte(result.Result), &data)
+	c.Assert(err, jc.ErrorIsNil)
+	return &data
+}
+
+func (s *exportBundleSuite) TestExportEmptyModel(c *gc.C) {
+	data := s.exportBundle(c)
+	c.Assert(data, jc.DeepEquals, &charm.BundleData{})
+}
+
+func (s *exportBundleSuite) TestExportBundle(c *gc.C) {
+	wordpress := s.Factory.MakeService(c, &factory.ServiceParams{
+		Charm:       s.Factory.MakeCharm(c, &factory.CharmParams{Name: "wordpress"}),
+		Settings:    map[string]interface{}{"blog-title": "Hello"},
+		Constraints: constraints.MustParse("mem=4G"),
+	})
+	err := wordpress.SetExposed()
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.State.SetAnnotations(wordpress, map[string]string{"gui-x": "10"})
+	c.Assert(err, jc.ErrorIsNil)
+	mysql := s.Factory.MakeService(c, &factory.ServiceParams{
+		Charm: s.Factory.MakeCharm(c, &factory.CharmParams{Name: "mysql"}),
+	})
+
+	// wordpress/0 is on a machine of its own; wordpress/1 and mysql/0
+	// share a container on that machine.
+	machine := s.Factory.MakeMachine(c, nil)
+	container := s.Factory.MakeMachineNested(c, machine.Id(), nil)
+	s.Factory.MakeUnit(c, &factory.UnitParams{Service: wordpress, Machine: machine})
+	s.Factory.MakeUnit(c, &factory.UnitParams{Service: wordpress, Machine: container})
+	s.Factory.MakeUnit(c, &factory.UnitParams{Service: mysql, Machine: container})
+
+	eps, err := s.State.InferEndpoints("wordpress", "mysql")
+	c.Assert(err, jc.ErrorIsNil)
+	relation, err := s.State.AddRelation(eps...)
+	c.Assert(err, jc.ErrorIsNil)
+	relationEps := relation.Endpoints()
+
+	wordpressURL, _ := wordpress.CharmURL()
+	mysqlURL, _ := mysql.CharmURL()
+	cons, err := wordpress.Constraints()
+	c.Assert(err, jc.ErrorIsNil)
+
+	data := s.exportBundle(c)
+	c.Assert(data, jc.DeepEquals, &charm.BundleData{
+		Services: map[string]*charm.ServiceSpec{
+			"mysql": {
+				Charm:    mysqlURL.String(),
+				NumUnits: 1,
+				To:       []string{"wordpress/1"},
+			},
+			"wordpress": {
+				Charm:       wordpressURL.String(),
+				NumUnits:    2,
+				To:          []string{machine.Id(), "lxc:" + machine.Id()},
+				Expose:      true,
+				Options:     map[string]interface{}{"blog-title": "Hello"},
+				Annotations: map[string]string{"gui-x": "10"},
+				Constraints: cons.String(),
+			},
+		},
+		Machines: map[string]*charm.MachineSpec{
+			machine.Id(): {Series: "quantal"},
+		},
+		Relations: [][]string{
+			{relationEps[0].String(), relationEps[1].String()},
+		},
+	})
+}
+
+func (s *exportBundleSuite) TestExportBundleOmitsDefaultOptions(c *gc.C) {
+	s.Factory.MakeService(c, &factory.ServiceParams{
+		Charm:    s.Factory.MakeCharm(c, &factory.CharmParams{Name: "wordpress"}),
+		Settings: map[string]interface{}{"blog-title": "My Title"},
+	})
+	data := s.exportBundle(c)
+	c.Assert(data.Services["wordpress"].Options, gc.IsNil)
+}
@@ -9,6 +9,7 @@ package service
 import (
 	"github.com/juju/errors"
 	"github.com/juju/loggo"
+	"github.com/juju/names"
 	"gopkg.in/juju/charm.v6-unstable"
 	csparams "gopkg.in/juju/charmrepo.v2-unstable/csclient/params"
 	goyaml "gopkg.in/yaml.v2"
@@ -42,6 +43,10 @@ type API struct {
 	check      *common.BlockChecker
 	state      *state.State
 	authorizer common.Authorizer
+
+	// getCanOperate returns an AuthFunc that reports whether the
+	// user may change a service or its units.
+	getCanOperate common.GetAuthFunc
 }
 
 // NewAPI returns a new service API facade.
@@ -55,12 +60,31 @@ func NewAPI(
 	}
 
 	return &API{
-		state:      st,
-		authorizer: authorizer,
-		check:      common.NewBlockChecker(st),
+		state:         st,
+		authorizer:    authorizer,
+		check:         common.NewBlockChecker(st),
+		getCanOperate: common.AuthServiceOperator(st, authorizer),
 	}, nil
 }
 
+// checkCanOperate returns an error if the user may not change the
+// service. Users that may not change the model may still change the
+// services they have been granted operate access to.
+func (api *API) checkCanOperate(serviceName string) error {
+	if !names.IsValidService(serviceName) {
+		// Leave it to the caller to report the bad name.
+		return nil
+	}
+	canOperate, err := api.getCanOperate()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if !canOperate(names.NewServiceTag(serviceName)) {
+		return common.ErrPerm
+	}
+	return nil
+}
+
 // SetMetricCredentials sets credentials on the service.
 func (api *API) SetMetricCredentials(args params.ServiceMetricCredentials) (params.ErrorResults, error) {
 	result := params.ErrorResults{
@@ -234,6 +258,9 @@ func (api *API) Update(args params.ServiceUpdate) error {
 			return errors.Trace(err)
 		}
 	}
+	if err := api.checkCanOperate(args.ServiceName); err != nil {
+		return errors.Trace(err)
+	}
 	svc, err := api.state.Service(args.ServiceName)
 	if err != nil {
 		return errors.Trace(err)
@@ -278,6 +305,9 @@ func (api *API) SetCharm(args params.ServiceSetCharm) error {
 			return errors.Trace(err)
 		}
 	}
+	if err := api.checkCanOperate(args.ServiceName); err != nil {
+		return errors.Trace(err)
+	}
 	service, err := api.state.Service(args.ServiceName)
 	if err != nil {
 		return errors.Trace(err)
@@ -381,6 +411,9 @@ func (api *API) Set(p params.ServiceSet) error {
 	if err := api.check.ChangeAllowed(); err != nil {
 		return errors.Trace(err)
 	}
+	if err := api.checkCanOperate(p.ServiceName); err != nil {
+		return errors.Trace(err)
+	}
 	svc, err := api.state.Service(p.ServiceName)
 	if err != nil {
 		return err
@@ -404,6 +437,9 @@ func (api *API) Unset(p params.ServiceUnset) error {
 	if err := api.check.ChangeAllowed(); err != nil {
 		return errors.Trace(err)
 	}
+	if err := api.checkCanOperate(p.ServiceName); err != nil {
+		return errors.Trace(err)
+	}
 	svc, err := api.state.Service(p.ServiceName)
 	if err != nil {
 		return err
@@ -434,7 +470,8 @@ func (api *API) CharmRelations(p params.ServiceCharmRelations) (params.ServiceCh
 }
 
 // Expose changes the juju-managed firewall to expose any ports that
-// were also explicitly marked by units as open.
+// were also explicitly marked by units as open. If source CIDRs are
+// given, the ports are exposed only to traffic from those CIDRs.
 func (api *API) Expose(args params.ServiceExpose) error {
 	if err := api.check.ChangeAllowed(); err != nil {
 		return errors.Trace(err)
@@ -443,6 +480,9 @@ func (api *API) Expose(args params.ServiceExpose) error {
 	if err != nil {
 		return err
 	}
+	if len(args.CIDRs) > 0 {
+		return svc.SetExposedCIDRs(args.CIDRs)
+	}
 	return svc.SetExposed()
 }
 
@@ -476,6 +516,9 @@ func (api *API) AddUnits(args params.AddServiceUnits) (params.AddServiceUnitsRes
 	if err := api.check.ChangeAllowed(); err != nil {
 		return params.AddServiceUnitsResults{}, errors.Trace(err)
 	}
+	if err := api.checkCanOperate(args.ServiceName); err != nil {
+		return params.AddServiceUnitsResults{}, errors.Trace(err)
+	}
 	units, err := addServiceUnits(api.state, args)
 	if err != nil {
 		return params.AddServiceUnitsResults{}, err
@@ -492,8 +535,16 @@ func (api *API) DestroyUnits(args params.DestroyServiceUnits) error {
 	if err := api.check.RemoveAllowed(); err != nil {
 		return errors.Trace(err)
 	}
+	canOperate, err := api.getCanOperate()
+	if err != nil {
+		return errors.Trace(err)
+	}
 	var errs []string
 	for _, name := range args.UnitNames {
+		if names.IsValidUnit(name) && !canOperate(names.NewUnitTag(name)) {
+			errs = append(errs, common.ErrPerm.Error())
+			continue
+		}
 		unit, err := api.state.Unit(name)
 		switch {
 		case errors.IsNotFound(err):
@@ -502,7 +553,7 @@ func (api *API) DestroyUnits(args params.DestroyServiceUnits) error {
 		case unit.Life() != state.Alive:
 			continue
 		case unit.IsPrincipal():
-			err = unit.Destroy()
+			err = api.destroyUnit(unit, args.KeepStorage)
 		default:
 			err = errors.Errorf("unit %q is a subordinate", name)
 		}
@@ -513,6 +564,18 @@ func (api *API) DestroyUnits(args params.DestroyServiceUnits) error {
 	return common.DestroyErr("units", args.UnitNames, errs)
 }
 
+// destroyUnit destroys the unit. If keepStorage is true, the unit's
+// storage is first detached, so that it is not destroyed along with
+// the unit; the unit is left alone if that cannot be done.
+func (api *API) destroyUnit(unit *state.Unit, keepStorage bool) error {
+	if keepStorage {
+		if err := api.state.DetachUnitStorage(unit.UnitTag()); err != nil {
+			return errors.Trace(err)
+		}
+	}
+	return unit.Destroy()
+}
+
 // Destroy destroys a given service.
 func (api *API) Destroy(args params.ServiceDestroy) error {
 	if err := api.check.RemoveAllowed(); err != nil {
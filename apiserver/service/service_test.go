@@ -1542,6 +1542,50 @@ func (s *serviceSuite) TestServiceSet(c *gc.C) {
 	})
 }
 
+func (s *serviceSuite) TestServiceSetReadOnlyUser(c *gc.C) {
+	s.AddTestingService(c, "dummy", s.AddTestingCharm(c, "dummy"))
+	user := s.Factory.MakeModelUser(c, &factory.ModelUserParams{Access: state.ModelReadAccess})
+	serviceApi, err := service.NewAPI(s.State, nil, apiservertesting.FakeAuthorizer{
+		Tag: user.UserTag(),
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	args := params.ServiceSet{ServiceName: "dummy", Options: map[string]string{
+		"title": "foobar",
+	}}
+
+	err = serviceApi.Set(args)
+	c.Assert(err, gc.ErrorMatches, "permission denied")
+
+	err = s.State.SetServiceAccess("dummy", user.UserTag(), state.ServiceOperateAccess, s.AdminUserTag(c))
+	c.Assert(err, jc.ErrorIsNil)
+	err = serviceApi.Set(args)
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *serviceSuite) TestDestroyUnitsServiceOperator(c *gc.C) {
+	wordpress := s.AddTestingService(c, "wordpress", s.AddTestingCharm(c, "wordpress"))
+	wordpress0, err := wordpress.AddUnit()
+	c.Assert(err, jc.ErrorIsNil)
+	mysql := s.AddTestingService(c, "mysql", s.AddTestingCharm(c, "mysql"))
+	mysql0, err := mysql.AddUnit()
+	c.Assert(err, jc.ErrorIsNil)
+
+	user := s.Factory.MakeModelUser(c, &factory.ModelUserParams{Access: state.ModelReadAccess})
+	err = s.State.SetServiceAccess("wordpress", user.UserTag(), state.ServiceOperateAccess, s.AdminUserTag(c))
+	c.Assert(err, jc.ErrorIsNil)
+	serviceApi, err := service.NewAPI(s.State, nil, apiservertesting.FakeAuthorizer{
+		Tag: user.UserTag(),
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = serviceApi.DestroyUnits(params.DestroyServiceUnits{
+		UnitNames: []string{"wordpress/0", "mysql/0"},
+	})
+	c.Assert(err, gc.ErrorMatches, `some units were not destroyed: permission denied`)
+	assertLife(c, wordpress0, state.Dying)
+	assertLife(c, mysql0, state.Alive)
+}
+
 func (s *serviceSuite) assertServiceSetBlocked(c *gc.C, dummy *state.Service, msg string) {
 	err := s.serviceApi.Set(params.ServiceSet{
 		ServiceName: "dummy",
@@ -1887,7 +1931,7 @@ func (s *serviceSuite) TestServiceExpose(c *gc.C) {
 	c.Assert(svcs[1].IsExposed(), jc.IsTrue)
 	for i, t := range serviceExposeTests {
 		c.Logf("test %d. %s", i, t.about)
-		err = s.serviceApi.Expose(params.ServiceExpose{t.service})
+		err = s.serviceApi.Expose(params.ServiceExpose{ServiceName: t.service})
 		if t.err != "" {
 			c.Assert(err, gc.ErrorMatches, t.err)
 		} else {
@@ -1899,6 +1943,25 @@ func (s *serviceSuite) TestServiceExpose(c *gc.C) {
 	}
 }
 
+func (s *serviceSuite) TestServiceExposeToCIDRs(c *gc.C) {
+	svc := s.AddTestingService(c, "dummy-service", s.AddTestingCharm(c, "dummy"))
+	err := s.serviceApi.Expose(params.ServiceExpose{
+		ServiceName: "dummy-service",
+		CIDRs:       []string{"10.0.0.0/8"},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	err = svc.Refresh()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(svc.IsExposed(), jc.IsTrue)
+	c.Assert(svc.ExposedCIDRs(), jc.DeepEquals, []string{"10.0.0.0/8"})
+
+	err = s.serviceApi.Expose(params.ServiceExpose{
+		ServiceName: "dummy-service",
+		CIDRs:       []string{"10.1.2.3/8"},
+	})
+	c.Assert(err, gc.ErrorMatches, `cannot expose service "dummy-service": source CIDR "10.1.2.3/8" .* not valid`)
+}
+
 func (s *serviceSuite) setupServiceExpose(c *gc.C) {
 	charm := s.AddTestingCharm(c, "dummy")
 	serviceNames := []string{"dummy-service", "exposed-service"}
@@ -1939,7 +2002,7 @@ var serviceExposeTests = []struct {
 func (s *serviceSuite) assertServiceExpose(c *gc.C) {
 	for i, t := range serviceExposeTests {
 		c.Logf("test %d. %s", i, t.about)
-		err := s.serviceApi.Expose(params.ServiceExpose{t.service})
+		err := s.serviceApi.Expose(params.ServiceExpose{ServiceName: t.service})
 		if t.err != "" {
 			c.Assert(err, gc.ErrorMatches, t.err)
 		} else {
@@ -1954,7 +2017,7 @@ func (s *serviceSuite) assertServiceExpose(c *gc.C) {
 func (s *serviceSuite) assertServiceExposeBlocked(c *gc.C, msg string) {
 	for i, t := range serviceExposeTests {
 		c.Logf("test %d. %s", i, t.about)
-		err := s.serviceApi.Expose(params.ServiceExpose{t.service})
+		err := s.serviceApi.Expose(params.ServiceExpose{ServiceName: t.service})
 		s.AssertBlocked(c, err, msg)
 	}
 }
@@ -2269,6 +2332,30 @@ func (s *serviceSuite) TestBlockDestroyDestroyPrincipalUnits(c *gc.C) {
 	assertLife(c, units[1], state.Dying)
 }
 
+func (s *serviceSuite) TestDestroyPrincipalUnitsKeepStorage(c *gc.C) {
+	ch := s.AddTestingCharm(c, "storage-block")
+	svc := s.AddTestingServiceWithStorage(c, "storage-block", ch, map[string]state.StorageConstraints{
+		"data": {Pool: "loop", Size: 1024, Count: 1},
+	})
+	unit, err := svc.AddUnit()
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = s.serviceApi.DestroyUnits(params.DestroyServiceUnits{
+		UnitNames:   []string{"storage-block/0"},
+		KeepStorage: true,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	assertLife(c, unit, state.Dying)
+
+	// The storage outlives the unit, with no owner.
+	storageInstances, err := s.State.AllStorageInstances()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(storageInstances, gc.HasLen, 1)
+	c.Assert(storageInstances[0].Life(), gc.Equals, state.Alive)
+	_, ok := storageInstances[0].Owner()
+	c.Assert(ok, jc.IsFalse)
+}
+
 func (s *serviceSuite) assertDestroySubordinateUnits(c *gc.C, wordpress0, logging0 *state.Unit) {
 	// Try to destroy the principal and the subordinate together; check it warns
 	// about the subordinate, but destroys the one it can. (The principal unit
@@ -0,0 +1,36 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package apiserver
+
+import (
+	"github.com/juju/utils/set"
+)
+
+// serviceOperatorCalls specify the API calls that users with read only
+// access to the model may make if they have been granted operate
+// access to a service. The facades check that the service, or unit,
+// being changed is one the user may operate. The format of the calls
+// is "<facade>.<method>". At this stage, we are explicitly ignoring
+// the facade version.
+var serviceOperatorCalls = set.NewStrings(
+	"Action.Enqueue",
+	// Adding a charm to the model does not change any service, but
+	// is needed to upgrade a service's charm.
+	"Client.AddCharm",
+	"Client.AddCharmWithAuthorization",
+	"Client.ResolveCharms",
+	"Client.Resolved",
+	"Service.AddUnits",
+	"Service.DestroyUnits",
+	"Service.Set",
+	"Service.SetCharm",
+	"Service.Unset",
+	"Service.Update",
+)
+
+// isCallServiceOperator returns whether or not the method on the
+// facade may be made by a user with operate access to a service.
+func isCallServiceOperator(facade, method string) bool {
+	return serviceOperatorCalls.Contains(facade + "." + method)
+}
@@ -0,0 +1,44 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package apiserver
+
+import (
+	"strings"
+
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/apiserver/common"
+)
+
+type serviceOperatorCallsSuite struct {
+}
+
+var _ = gc.Suite(&serviceOperatorCallsSuite{})
+
+func (*serviceOperatorCallsSuite) TestServiceOperatorCallsExist(c *gc.C) {
+	// Iterate through the list of serviceOperatorCalls and make sure
+	// that the facades are reachable.
+	maxVersion := map[string]int{}
+	for _, facade := range common.Facades.List() {
+		for _, ver := range facade.Versions {
+			if ver > maxVersion[facade.Name] {
+				maxVersion[facade.Name] = ver
+			}
+		}
+	}
+
+	for _, name := range serviceOperatorCalls.Values() {
+		parts := strings.Split(name, ".")
+		facade, method := parts[0], parts[1]
+		_, _, err := lookupMethod(facade, maxVersion[facade], method)
+		c.Check(err, jc.ErrorIsNil)
+	}
+}
+
+func (*serviceOperatorCallsSuite) TestServiceOperatorCallsAreNotAdminOnly(c *gc.C) {
+	for _, name := range serviceOperatorCalls.Values() {
+		c.Check(adminOnlyCalls.Contains(name), jc.IsFalse, gc.Commentf("%s", name))
+	}
+}
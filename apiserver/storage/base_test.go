@@ -13,6 +13,7 @@ import (
 	"github.com/juju/juju/apiserver/params"
 	"github.com/juju/juju/apiserver/storage"
 	"github.com/juju/juju/apiserver/testing"
+	"github.com/juju/juju/environs/config"
 	"github.com/juju/juju/state"
 	jujustorage "github.com/juju/juju/storage"
 	coretesting "github.com/juju/juju/testing"
@@ -82,6 +83,16 @@ const (
 	filesystemAttachmentsCall               = "filesystemAttachments"
 	allFilesystemsCall                      = "allFilesystems"
 	addStorageForUnitCall                   = "addStorageForUnit"
+	addStorageForUnitFromSnapshotCall       = "addStorageForUnitFromSnapshot"
+	attachStorageCall                       = "attachStorage"
+	detachStorageCall                       = "detachStorage"
+	addExistingFilesystemCall               = "addExistingFilesystem"
+	modelConfigCall                         = "modelConfig"
+	addVolumeSnapshotCall                   = "addVolumeSnapshot"
+	allVolumeSnapshotsCall                  = "allVolumeSnapshots"
+	volumeSnapshotCall                      = "volumeSnapshot"
+	removeVolumeSnapshotCall                = "removeVolumeSnapshot"
+	resizeVolumeCall                        = "resizeVolume"
 	getBlockForTypeCall                     = "getBlockForType"
 	volumeAttachmentCall                    = "volumeAttachment"
 )
@@ -222,6 +233,60 @@ func (s *baseStorageSuite) constructState() *mockState {
 			s.calls = append(s.calls, addStorageForUnitCall)
 			return nil
 		},
+		addStorageForUnitFromSnapshot: func(u names.UnitTag, name string, cons state.StorageConstraints, snapshotId string) error {
+			s.calls = append(s.calls, addStorageForUnitFromSnapshotCall)
+			return nil
+		},
+		attachStorage: func(names.StorageTag, names.UnitTag) error {
+			s.calls = append(s.calls, attachStorageCall)
+			return nil
+		},
+		detachStorage: func(names.StorageTag, names.UnitTag) error {
+			s.calls = append(s.calls, detachStorageCall)
+			return nil
+		},
+		addExistingFilesystem: func(state.FilesystemInfo, *state.VolumeInfo, string) (names.StorageTag, error) {
+			s.calls = append(s.calls, addExistingFilesystemCall)
+			return s.storageTag, nil
+		},
+		modelConfig: func() (*config.Config, error) {
+			s.calls = append(s.calls, modelConfigCall)
+			return config.New(config.UseDefaults, coretesting.FakeConfig())
+		},
+		addVolumeSnapshot: func(v names.VolumeTag, info state.VolumeSnapshotInfo) (state.VolumeSnapshot, error) {
+			s.calls = append(s.calls, addVolumeSnapshotCall)
+			return &mockVolumeSnapshot{
+				id:         "0",
+				volume:     v,
+				storage:    &s.storageTag,
+				pool:       "radiance",
+				snapshotId: info.SnapshotId,
+				size:       info.Size,
+			}, nil
+		},
+		allVolumeSnapshots: func() ([]state.VolumeSnapshot, error) {
+			s.calls = append(s.calls, allVolumeSnapshotsCall)
+			return nil, nil
+		},
+		volumeSnapshot: func(id string) (state.VolumeSnapshot, error) {
+			s.calls = append(s.calls, volumeSnapshotCall)
+			return &mockVolumeSnapshot{
+				id:         id,
+				volume:     s.volumeTag,
+				storage:    &s.storageTag,
+				pool:       "radiance",
+				snapshotId: "snap-" + id,
+				size:       1024,
+			}, nil
+		},
+		removeVolumeSnapshot: func(string) error {
+			s.calls = append(s.calls, removeVolumeSnapshotCall)
+			return nil
+		},
+		resizeVolume: func(names.VolumeTag, uint64) error {
+			s.calls = append(s.calls, resizeVolumeCall)
+			return nil
+		},
 		getBlockForType: func(t state.BlockType) (state.Block, bool, error) {
 			s.calls = append(s.calls, getBlockForTypeCall)
 			val, found := s.blocks[t]
@@ -4,10 +4,13 @@
 package storage_test
 
 import (
+	"time"
+
 	"github.com/juju/errors"
 	"github.com/juju/names"
 	"gopkg.in/juju/charm.v6-unstable"
 
+	"github.com/juju/juju/environs/config"
 	"github.com/juju/juju/state"
 	"github.com/juju/juju/status"
 	jujustorage "github.com/juju/juju/storage"
@@ -48,6 +51,7 @@ type mockState struct {
 	watchStorageAttachment              func(names.StorageTag, names.UnitTag) state.NotifyWatcher
 	watchFilesystemAttachment           func(names.MachineTag, names.FilesystemTag) state.NotifyWatcher
 	watchVolumeAttachment               func(names.MachineTag, names.VolumeTag) state.NotifyWatcher
+	watchVolume                         func(names.VolumeTag) state.NotifyWatcher
 	watchBlockDevices                   func(names.MachineTag) state.NotifyWatcher
 	modelName                           string
 	volume                              func(tag names.VolumeTag) (state.Volume, error)
@@ -59,6 +63,16 @@ type mockState struct {
 	filesystemAttachments               func(filesystem names.FilesystemTag) ([]state.FilesystemAttachment, error)
 	allFilesystems                      func() ([]state.Filesystem, error)
 	addStorageForUnit                   func(u names.UnitTag, name string, cons state.StorageConstraints) error
+	addStorageForUnitFromSnapshot       func(u names.UnitTag, name string, cons state.StorageConstraints, snapshotId string) error
+	attachStorage                       func(names.StorageTag, names.UnitTag) error
+	detachStorage                       func(names.StorageTag, names.UnitTag) error
+	addExistingFilesystem               func(state.FilesystemInfo, *state.VolumeInfo, string) (names.StorageTag, error)
+	modelConfig                         func() (*config.Config, error)
+	addVolumeSnapshot                   func(names.VolumeTag, state.VolumeSnapshotInfo) (state.VolumeSnapshot, error)
+	allVolumeSnapshots                  func() ([]state.VolumeSnapshot, error)
+	volumeSnapshot                      func(string) (state.VolumeSnapshot, error)
+	removeVolumeSnapshot                func(string) error
+	resizeVolume                        func(names.VolumeTag, uint64) error
 	getBlockForType                     func(t state.BlockType) (state.Block, bool, error)
 	blockDevices                        func(names.MachineTag) ([]state.BlockDeviceInfo, error)
 }
@@ -107,6 +121,10 @@ func (st *mockState) WatchVolumeAttachment(mtag names.MachineTag, v names.Volume
 	return st.watchVolumeAttachment(mtag, v)
 }
 
+func (st *mockState) WatchVolume(v names.VolumeTag) state.NotifyWatcher {
+	return st.watchVolume(v)
+}
+
 func (st *mockState) WatchBlockDevices(mtag names.MachineTag) state.NotifyWatcher {
 	return st.watchBlockDevices(mtag)
 }
@@ -151,6 +169,46 @@ func (st *mockState) AddStorageForUnit(u names.UnitTag, name string, cons state.
 	return st.addStorageForUnit(u, name, cons)
 }
 
+func (st *mockState) AddStorageForUnitFromSnapshot(u names.UnitTag, name string, cons state.StorageConstraints, snapshotId string) error {
+	return st.addStorageForUnitFromSnapshot(u, name, cons, snapshotId)
+}
+
+func (st *mockState) AttachStorage(s names.StorageTag, u names.UnitTag) error {
+	return st.attachStorage(s, u)
+}
+
+func (st *mockState) DetachStorage(s names.StorageTag, u names.UnitTag) error {
+	return st.detachStorage(s, u)
+}
+
+func (st *mockState) AddExistingFilesystem(f state.FilesystemInfo, v *state.VolumeInfo, storageName string) (names.StorageTag, error) {
+	return st.addExistingFilesystem(f, v, storageName)
+}
+
+func (st *mockState) ModelConfig() (*config.Config, error) {
+	return st.modelConfig()
+}
+
+func (st *mockState) AddVolumeSnapshot(v names.VolumeTag, info state.VolumeSnapshotInfo) (state.VolumeSnapshot, error) {
+	return st.addVolumeSnapshot(v, info)
+}
+
+func (st *mockState) AllVolumeSnapshots() ([]state.VolumeSnapshot, error) {
+	return st.allVolumeSnapshots()
+}
+
+func (st *mockState) VolumeSnapshot(id string) (state.VolumeSnapshot, error) {
+	return st.volumeSnapshot(id)
+}
+
+func (st *mockState) RemoveVolumeSnapshot(id string) error {
+	return st.removeVolumeSnapshot(id)
+}
+
+func (st *mockState) ResizeVolume(v names.VolumeTag, size uint64) error {
+	return st.resizeVolume(v, size)
+}
+
 func (st *mockState) GetBlockForType(t state.BlockType) (state.Block, bool, error) {
 	return st.getBlockForType(t)
 }
@@ -277,8 +335,8 @@ func (m *mockStorageInstance) Kind() state.StorageKind {
 	return m.kind
 }
 
-func (m *mockStorageInstance) Owner() names.Tag {
-	return m.owner
+func (m *mockStorageInstance) Owner() (names.Tag, bool) {
+	return m.owner, m.owner != nil
 }
 
 func (m *mockStorageInstance) Tag() names.Tag {
@@ -303,7 +361,7 @@ func (m *mockStorageAttachment) StorageInstance() names.StorageTag {
 }
 
 func (m *mockStorageAttachment) Unit() names.UnitTag {
-	return m.storage.Owner().(names.UnitTag)
+	return m.storage.owner.(names.UnitTag)
 }
 
 type mockVolumeAttachment struct {
@@ -335,6 +393,48 @@ func (va *mockVolumeAttachment) Params() (state.VolumeAttachmentParams, bool) {
 	panic("not implemented for test")
 }
 
+type mockVolumeSnapshot struct {
+	state.VolumeSnapshot
+	id         string
+	volume     names.VolumeTag
+	storage    *names.StorageTag
+	pool       string
+	snapshotId string
+	size       uint64
+	created    time.Time
+}
+
+func (m *mockVolumeSnapshot) Id() string {
+	return m.id
+}
+
+func (m *mockVolumeSnapshot) VolumeTag() names.VolumeTag {
+	return m.volume
+}
+
+func (m *mockVolumeSnapshot) StorageInstance() (names.StorageTag, bool) {
+	if m.storage != nil {
+		return *m.storage, true
+	}
+	return names.StorageTag{}, false
+}
+
+func (m *mockVolumeSnapshot) Pool() string {
+	return m.pool
+}
+
+func (m *mockVolumeSnapshot) SnapshotId() string {
+	return m.snapshotId
+}
+
+func (m *mockVolumeSnapshot) Size() uint64 {
+	return m.size
+}
+
+func (m *mockVolumeSnapshot) Created() time.Time {
+	return m.created
+}
+
 type mockBlock struct {
 	state.Block
 	t   state.BlockType
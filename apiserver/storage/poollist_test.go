@@ -56,7 +56,8 @@ func (s *poolSuite) TestListManyResults(c *gc.C) {
 	assertPoolNames(c, results.Results[0].Result,
 		"testpool0", "testpool1",
 		"dummy", "loop",
-		"tmpfs", "rootfs")
+		"tmpfs", "rootfs",
+		"nfs", "rbd")
 }
 
 func (s *poolSuite) TestListByName(c *gc.C) {
@@ -165,7 +166,7 @@ func (s *poolSuite) TestListNoPools(c *gc.C) {
 	results, err := s.api.ListPools(params.StoragePoolFilters{[]params.StoragePoolFilter{{}}})
 	c.Assert(err, jc.ErrorIsNil)
 	c.Assert(results.Results, gc.HasLen, 1)
-	assertPoolNames(c, results.Results[0].Result, "dummy", "rootfs", "loop", "tmpfs")
+	assertPoolNames(c, results.Results[0].Result, "dummy", "rootfs", "loop", "tmpfs", "nfs", "rbd")
 }
 
 func (s *poolSuite) TestListFilterEmpty(c *gc.C) {
@@ -7,6 +7,7 @@ import (
 	"github.com/juju/errors"
 	"github.com/juju/names"
 
+	"github.com/juju/juju/environs/config"
 	"github.com/juju/juju/state"
 )
 
@@ -44,6 +45,9 @@ type storageAccess interface {
 	// WatchVolumeAttachment is required for storage functionality.
 	WatchVolumeAttachment(names.MachineTag, names.VolumeTag) state.NotifyWatcher
 
+	// WatchVolume is required for storage functionality.
+	WatchVolume(names.VolumeTag) state.NotifyWatcher
+
 	// WatchBlockDevices is required for storage functionality.
 	WatchBlockDevices(names.MachineTag) state.NotifyWatcher
 
@@ -80,6 +84,36 @@ type storageAccess interface {
 	// AddStorageForUnit is required for storage add functionality.
 	AddStorageForUnit(tag names.UnitTag, name string, cons state.StorageConstraints) error
 
+	// AddStorageForUnitFromSnapshot is required for storage add functionality.
+	AddStorageForUnitFromSnapshot(tag names.UnitTag, name string, cons state.StorageConstraints, snapshotId string) error
+
+	// AttachStorage is required for storage attach functionality.
+	AttachStorage(names.StorageTag, names.UnitTag) error
+
+	// DetachStorage is required for storage detach functionality.
+	DetachStorage(names.StorageTag, names.UnitTag) error
+
+	// AddExistingFilesystem is required for storage import functionality.
+	AddExistingFilesystem(state.FilesystemInfo, *state.VolumeInfo, string) (names.StorageTag, error)
+
+	// ModelConfig is required for storage import functionality.
+	ModelConfig() (*config.Config, error)
+
+	// AddVolumeSnapshot is required for storage snapshot functionality.
+	AddVolumeSnapshot(names.VolumeTag, state.VolumeSnapshotInfo) (state.VolumeSnapshot, error)
+
+	// AllVolumeSnapshots is required for storage snapshot functionality.
+	AllVolumeSnapshots() ([]state.VolumeSnapshot, error)
+
+	// VolumeSnapshot is required for storage snapshot functionality.
+	VolumeSnapshot(id string) (state.VolumeSnapshot, error)
+
+	// RemoveVolumeSnapshot is required for storage snapshot functionality.
+	RemoveVolumeSnapshot(id string) error
+
+	// ResizeVolume is required for storage resize functionality.
+	ResizeVolume(names.VolumeTag, uint64) error
+
 	// GetBlockForType is required to block operations.
 	GetBlockForType(t state.BlockType) (state.Block, bool, error)
 }
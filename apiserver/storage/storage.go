@@ -13,6 +13,8 @@ import (
 	"github.com/juju/juju/apiserver/common"
 	"github.com/juju/juju/apiserver/common/storagecommon"
 	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/environs/config"
+	"github.com/juju/juju/environs/tags"
 	"github.com/juju/juju/state"
 	"github.com/juju/juju/status"
 	"github.com/juju/juju/storage"
@@ -21,7 +23,7 @@ import (
 )
 
 func init() {
-	common.RegisterStandardFacade("Storage", 2, NewAPI)
+	common.RegisterStandardFacade("Storage", 3, NewAPI)
 }
 
 // API implements the storage interface and is the concrete
@@ -181,9 +183,15 @@ func createStorageDetails(st storageAccess, si state.StorageInstance) (*params.S
 		}
 	}
 
+	// Storage that has been detached from its unit has no owner.
+	var ownerTag string
+	if owner, ok := si.Owner(); ok {
+		ownerTag = owner.String()
+	}
+
 	return &params.StorageDetails{
 		StorageTag:  si.Tag().String(),
-		OwnerTag:    si.Owner().String(),
+		OwnerTag:    ownerTag,
 		Kind:        params.StorageKind(si.Kind()),
 		Status:      common.EntityStatusFromState(status),
 		Persistent:  persistent,
@@ -695,9 +703,16 @@ func (a *API) AddToUnit(args params.StoragesAddParams) (params.ErrorResults, err
 			continue
 		}
 
-		err = a.storage.AddStorageForUnit(u,
-			one.StorageName,
-			paramsToState(one.Constraints))
+		if one.FromSnapshot != "" {
+			err = a.storage.AddStorageForUnitFromSnapshot(u,
+				one.StorageName,
+				paramsToState(one.Constraints),
+				one.FromSnapshot)
+		} else {
+			err = a.storage.AddStorageForUnit(u,
+				one.StorageName,
+				paramsToState(one.Constraints))
+		}
 		if err != nil {
 			result[i] = serverErr(
 				errors.Annotatef(err, "adding storage %v for %v", one.StorageName, one.UnitTag))
@@ -705,3 +720,469 @@ func (a *API) AddToUnit(args params.StoragesAddParams) (params.ErrorResults, err
 	}
 	return params.ErrorResults{Results: result}, nil
 }
+
+// Attach attaches existing, detached storage instances to units.
+// A failure on one individual storage attachment does not block the
+// remaining attachments from being processed.
+// A "CHANGE" block can block this operation.
+func (a *API) Attach(args params.StorageAttachmentIds) (params.ErrorResults, error) {
+	blockChecker := common.NewBlockChecker(a.storage)
+	if err := blockChecker.ChangeAllowed(); err != nil {
+		return params.ErrorResults{}, errors.Trace(err)
+	}
+
+	result := make([]params.ErrorResult, len(args.Ids))
+	for i, id := range args.Ids {
+		storageTag, err := names.ParseStorageTag(id.StorageTag)
+		if err != nil {
+			result[i].Error = common.ServerError(err)
+			continue
+		}
+		unitTag, err := names.ParseUnitTag(id.UnitTag)
+		if err != nil {
+			result[i].Error = common.ServerError(err)
+			continue
+		}
+		if err := a.storage.AttachStorage(storageTag, unitTag); err != nil {
+			result[i].Error = common.ServerError(err)
+		}
+	}
+	return params.ErrorResults{Results: result}, nil
+}
+
+// Detach detaches storage instances from the units that own them,
+// leaving the storage in the model so that it may later be attached
+// to another unit. If a unit tag is not specified, the storage is
+// detached from the unit that owns it.
+// A failure on one individual storage attachment does not block the
+// remaining attachments from being processed.
+// A "CHANGE" block can block this operation.
+func (a *API) Detach(args params.StorageAttachmentIds) (params.ErrorResults, error) {
+	blockChecker := common.NewBlockChecker(a.storage)
+	if err := blockChecker.ChangeAllowed(); err != nil {
+		return params.ErrorResults{}, errors.Trace(err)
+	}
+
+	result := make([]params.ErrorResult, len(args.Ids))
+	for i, id := range args.Ids {
+		if err := a.detachStorage(id); err != nil {
+			result[i].Error = common.ServerError(err)
+		}
+	}
+	return params.ErrorResults{Results: result}, nil
+}
+
+func (a *API) detachStorage(id params.StorageAttachmentId) error {
+	storageTag, err := names.ParseStorageTag(id.StorageTag)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if id.UnitTag != "" {
+		unitTag, err := names.ParseUnitTag(id.UnitTag)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		return a.storage.DetachStorage(storageTag, unitTag)
+	}
+	storageInstance, err := a.storage.StorageInstance(storageTag)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	owner, ok := storageInstance.Owner()
+	if !ok {
+		return errors.Errorf("storage %s is not attached", storageTag.Id())
+	}
+	unitTag, ok := owner.(names.UnitTag)
+	if !ok {
+		return errors.Errorf(
+			"cannot detach storage %s from %s",
+			storageTag.Id(), names.ReadableString(owner),
+		)
+	}
+	return a.storage.DetachStorage(storageTag, unitTag)
+}
+
+// Import imports existing storage into the model. Each volume is
+// tagged as belonging to the model, and recorded in the model as a
+// filesystem backed by the volume, along with a detached storage
+// instance that may then be attached to a unit.
+// A "CHANGE" block can block this operation.
+func (a *API) Import(args params.BulkImportStorageParams) (params.ImportStorageResults, error) {
+	blockChecker := common.NewBlockChecker(a.storage)
+	if err := blockChecker.ChangeAllowed(); err != nil {
+		return params.ImportStorageResults{}, errors.Trace(err)
+	}
+
+	results := make([]params.ImportStorageResult, len(args.Storage))
+	for i, arg := range args.Storage {
+		details, err := a.importStorage(arg)
+		if err != nil {
+			results[i].Error = common.ServerError(err)
+			continue
+		}
+		results[i].Result = details
+	}
+	return params.ImportStorageResults{Results: results}, nil
+}
+
+func (a *API) importStorage(arg params.ImportStorageParams) (*params.ImportStorageDetails, error) {
+	cfg, err := a.poolConfig(arg.Pool)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	provider, err := registry.StorageProvider(cfg.Provider())
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	modelConfig, err := a.storage.ModelConfig()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	volumeSource, err := provider.VolumeSource(modelConfig, cfg)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	volumeImporter, ok := volumeSource.(storage.VolumeImporter)
+	if !ok {
+		return nil, errors.NotSupportedf(
+			"importing volume with storage provider %q",
+			cfg.Provider(),
+		)
+	}
+	resourceTags := tags.ResourceTags(
+		names.NewModelTag(modelConfig.UUID()),
+		names.NewModelTag(modelConfig.ControllerUUID()),
+		modelConfig,
+	)
+	volumeInfo, err := volumeImporter.ImportVolume(arg.ProviderId, resourceTags)
+	if err != nil {
+		return nil, errors.Annotate(err, "importing volume")
+	}
+	storageTag, err := a.storage.AddExistingFilesystem(
+		state.FilesystemInfo{
+			Pool: arg.Pool,
+			Size: volumeInfo.Size,
+		},
+		&state.VolumeInfo{
+			HardwareId: volumeInfo.HardwareId,
+			Size:       volumeInfo.Size,
+			Pool:       arg.Pool,
+			VolumeId:   volumeInfo.VolumeId,
+			Persistent: volumeInfo.Persistent,
+		},
+		arg.StorageName,
+	)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &params.ImportStorageDetails{
+		StorageTag: storageTag.String(),
+	}, nil
+}
+
+// CreateSnapshots creates snapshots of the volumes backing the specified
+// storage instances, using the storage providers that manage them, and
+// records the snapshots in the model. Volumes may later be created from
+// the snapshots when adding storage to units.
+// A "CHANGE" block can block this operation.
+func (a *API) CreateSnapshots(args params.Entities) (params.VolumeSnapshotResults, error) {
+	blockChecker := common.NewBlockChecker(a.storage)
+	if err := blockChecker.ChangeAllowed(); err != nil {
+		return params.VolumeSnapshotResults{}, errors.Trace(err)
+	}
+
+	results := make([]params.VolumeSnapshotResult, len(args.Entities))
+	for i, arg := range args.Entities {
+		snapshot, err := a.createSnapshot(arg.Tag)
+		if err != nil {
+			results[i].Error = common.ServerError(err)
+			continue
+		}
+		details := createVolumeSnapshotDetails(snapshot)
+		results[i].Result = &details
+	}
+	return params.VolumeSnapshotResults{Results: results}, nil
+}
+
+func (a *API) createSnapshot(tag string) (state.VolumeSnapshot, error) {
+	storageTag, err := names.ParseStorageTag(tag)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	volumeTag, err := a.storageInstanceVolumeTag(storageTag, "snapshots of")
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	volume, err := a.storage.Volume(volumeTag)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	volumeInfo, err := volume.Info()
+	if err != nil {
+		return nil, errors.Annotatef(err, "cannot snapshot storage %s", storageTag.Id())
+	}
+
+	snapshotter, modelConfig, err := a.volumeSnapshotter(volumeInfo.Pool)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	resourceTags := tags.ResourceTags(
+		names.NewModelTag(modelConfig.UUID()),
+		names.NewModelTag(modelConfig.ControllerUUID()),
+		modelConfig,
+	)
+	results, err := snapshotter.CreateSnapshots([]storage.SnapshotParams{{
+		VolumeId:     volumeInfo.VolumeId,
+		ResourceTags: resourceTags,
+	}})
+	if err != nil {
+		return nil, errors.Annotate(err, "creating snapshot")
+	}
+	if len(results) != 1 {
+		return nil, errors.Errorf("expected 1 result, got %d", len(results))
+	}
+	if results[0].Error != nil {
+		return nil, errors.Annotate(results[0].Error, "creating snapshot")
+	}
+	return a.storage.AddVolumeSnapshot(volumeTag, state.VolumeSnapshotInfo{
+		SnapshotId: results[0].Snapshot.SnapshotId,
+		Size:       results[0].Snapshot.Size,
+	})
+}
+
+// volumeSnapshotter returns the storage.VolumeSnapshotter for the
+// named pool, along with the model config it was created with.
+func (a *API) volumeSnapshotter(pool string) (storage.VolumeSnapshotter, *config.Config, error) {
+	cfg, err := a.poolConfig(pool)
+	if err != nil {
+		return nil, nil, errors.Trace(err)
+	}
+	provider, err := registry.StorageProvider(cfg.Provider())
+	if err != nil {
+		return nil, nil, errors.Trace(err)
+	}
+	if provider.Scope() != storage.ScopeEnviron {
+		return nil, nil, errors.NotSupportedf(
+			"snapshots with machine-scoped storage provider %q",
+			cfg.Provider(),
+		)
+	}
+	modelConfig, err := a.storage.ModelConfig()
+	if err != nil {
+		return nil, nil, errors.Trace(err)
+	}
+	volumeSource, err := provider.VolumeSource(modelConfig, cfg)
+	if err != nil {
+		return nil, nil, errors.Trace(err)
+	}
+	snapshotter, ok := volumeSource.(storage.VolumeSnapshotter)
+	if !ok {
+		return nil, nil, errors.NotSupportedf(
+			"snapshots with storage provider %q",
+			cfg.Provider(),
+		)
+	}
+	return snapshotter, modelConfig, nil
+}
+
+// storageInstanceVolumeTag returns the tag of the volume backing the
+// specified storage instance. Filesystem storage is supported only if
+// the filesystem is backed by a volume; operation describes what is
+// being done to the volume, for use in the error returned otherwise.
+func (a *API) storageInstanceVolumeTag(tag names.StorageTag, operation string) (names.VolumeTag, error) {
+	storageInstance, err := a.storage.StorageInstance(tag)
+	if err != nil {
+		return names.VolumeTag{}, errors.Trace(err)
+	}
+	switch storageInstance.Kind() {
+	case state.StorageKindBlock:
+		volume, err := a.storage.StorageInstanceVolume(tag)
+		if err != nil {
+			return names.VolumeTag{}, errors.Trace(err)
+		}
+		return volume.VolumeTag(), nil
+	case state.StorageKindFilesystem:
+		filesystem, err := a.storage.StorageInstanceFilesystem(tag)
+		if err != nil {
+			return names.VolumeTag{}, errors.Trace(err)
+		}
+		volumeTag, err := filesystem.Volume()
+		if errors.Cause(err) == state.ErrNoBackingVolume {
+			return names.VolumeTag{}, errors.NotSupportedf(
+				"%s filesystem storage not backed by a volume", operation,
+			)
+		} else if err != nil {
+			return names.VolumeTag{}, errors.Trace(err)
+		}
+		return volumeTag, nil
+	}
+	return names.VolumeTag{}, errors.NotSupportedf(
+		"%s %s storage", operation, storageInstance.Kind(),
+	)
+}
+
+// Resize requests that the volumes backing the specified storage
+// instances be grown to the specified sizes. The resize is carried
+// out by the storage provisioner, which records the new size once
+// the storage provider has completed it.
+// A "CHANGE" block can block this operation.
+func (a *API) Resize(args params.BulkResizeStorageParams) (params.ErrorResults, error) {
+	blockChecker := common.NewBlockChecker(a.storage)
+	if err := blockChecker.ChangeAllowed(); err != nil {
+		return params.ErrorResults{}, errors.Trace(err)
+	}
+
+	results := make([]params.ErrorResult, len(args.Storage))
+	for i, arg := range args.Storage {
+		err := a.resizeStorage(arg)
+		results[i].Error = common.ServerError(err)
+	}
+	return params.ErrorResults{Results: results}, nil
+}
+
+func (a *API) resizeStorage(arg params.ResizeStorageParams) error {
+	storageTag, err := names.ParseStorageTag(arg.StorageTag)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	volumeTag, err := a.storageInstanceVolumeTag(storageTag, "resizing")
+	if err != nil {
+		return errors.Trace(err)
+	}
+	volume, err := a.storage.Volume(volumeTag)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	volumeInfo, err := volume.Info()
+	if err != nil {
+		return errors.Annotatef(err, "cannot resize storage %s", storageTag.Id())
+	}
+
+	cfg, err := a.poolConfig(volumeInfo.Pool)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	provider, err := registry.StorageProvider(cfg.Provider())
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if !provider.Dynamic() {
+		return errors.NotSupportedf(
+			"resizing volumes with non-dynamic storage provider %q",
+			cfg.Provider(),
+		)
+	}
+	if provider.Scope() == storage.ScopeEnviron {
+		// Machine-scoped volume sources can only be obtained on
+		// the machine, so we leave it to the storage provisioner
+		// to report whether they support resizing.
+		modelConfig, err := a.storage.ModelConfig()
+		if err != nil {
+			return errors.Trace(err)
+		}
+		volumeSource, err := provider.VolumeSource(modelConfig, cfg)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		if _, ok := volumeSource.(storage.VolumeResizer); !ok {
+			return errors.NotSupportedf(
+				"resizing volumes with storage provider %q",
+				cfg.Provider(),
+			)
+		}
+	}
+	return a.storage.ResizeVolume(volumeTag, arg.Size)
+}
+
+// ListSnapshots returns the details of all volume snapshots recorded
+// in the model.
+func (a *API) ListSnapshots() (params.VolumeSnapshotDetailsList, error) {
+	snapshots, err := a.storage.AllVolumeSnapshots()
+	if err != nil {
+		return params.VolumeSnapshotDetailsList{}, common.ServerError(err)
+	}
+	details := make([]params.VolumeSnapshotDetails, len(snapshots))
+	for i, snapshot := range snapshots {
+		details[i] = createVolumeSnapshotDetails(snapshot)
+	}
+	return params.VolumeSnapshotDetailsList{Snapshots: details}, nil
+}
+
+// DestroySnapshots destroys the specified volume snapshots, using the
+// storage providers that created them, and removes them from the model.
+// A snapshot cannot be destroyed while storage that is to be created
+// from it remains.
+// A "CHANGE" block can block this operation.
+func (a *API) DestroySnapshots(args params.VolumeSnapshotIds) (params.ErrorResults, error) {
+	blockChecker := common.NewBlockChecker(a.storage)
+	if err := blockChecker.ChangeAllowed(); err != nil {
+		return params.ErrorResults{}, errors.Trace(err)
+	}
+
+	results := make([]params.ErrorResult, len(args.Ids))
+	for i, id := range args.Ids {
+		err := a.destroySnapshot(id)
+		results[i].Error = common.ServerError(err)
+	}
+	return params.ErrorResults{Results: results}, nil
+}
+
+func (a *API) destroySnapshot(id string) error {
+	snapshot, err := a.storage.VolumeSnapshot(id)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	snapshotter, _, err := a.volumeSnapshotter(snapshot.Pool())
+	if err != nil {
+		return errors.Trace(err)
+	}
+	// Remove the record first, so the snapshot is not destroyed while
+	// storage that is to be created from it remains. Should destroying
+	// the snapshot then fail, it is left to be destroyed with the model.
+	if err := a.storage.RemoveVolumeSnapshot(id); err != nil {
+		return errors.Trace(err)
+	}
+	errs, err := snapshotter.DestroySnapshots([]string{snapshot.SnapshotId()})
+	if err != nil {
+		return errors.Annotatef(err, "destroying snapshot %q", snapshot.SnapshotId())
+	}
+	if len(errs) != 1 {
+		return errors.Errorf("expected 1 result, got %d", len(errs))
+	}
+	if errs[0] != nil {
+		return errors.Annotatef(errs[0], "destroying snapshot %q", snapshot.SnapshotId())
+	}
+	return nil
+}
+
+func createVolumeSnapshotDetails(snapshot state.VolumeSnapshot) params.VolumeSnapshotDetails {
+	details := params.VolumeSnapshotDetails{
+		Id:         snapshot.Id(),
+		VolumeTag:  snapshot.VolumeTag().String(),
+		SnapshotId: snapshot.SnapshotId(),
+		Pool:       snapshot.Pool(),
+		Size:       snapshot.Size(),
+		Created:    snapshot.Created(),
+	}
+	if storageTag, ok := snapshot.StorageInstance(); ok {
+		details.StorageTag = storageTag.String()
+	}
+	return details
+}
+
+// poolConfig returns the storage configuration for the named pool. If
+// there is no such pool, the name is treated as a storage provider
+// type, as it is when adding storage.
+func (a *API) poolConfig(poolName string) (*storage.Config, error) {
+	pool, err := a.poolManager.Get(poolName)
+	if errors.IsNotFound(err) {
+		providerType := storage.ProviderType(poolName)
+		if _, err1 := registry.StorageProvider(providerType); err1 != nil {
+			return nil, errors.Trace(err)
+		}
+		return storage.NewConfig(poolName, providerType, map[string]interface{}{})
+	} else if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return pool, nil
+}
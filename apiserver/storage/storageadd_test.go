@@ -50,6 +50,23 @@ func (s *storageAddSuite) TestStorageAddUnit(c *gc.C) {
 	s.assertCalls(c, []string{getBlockForTypeCall, addStorageForUnitCall})
 }
 
+func (s *storageAddSuite) TestStorageAddUnitFromSnapshot(c *gc.C) {
+	var snapshotId string
+	s.state.addStorageForUnitFromSnapshot = func(u names.UnitTag, name string, cons state.StorageConstraints, id string) error {
+		s.calls = append(s.calls, addStorageForUnitFromSnapshotCall)
+		snapshotId = id
+		return nil
+	}
+	args := params.StorageAddParams{
+		UnitTag:      s.unitTag.String(),
+		StorageName:  "data",
+		FromSnapshot: "0",
+	}
+	s.assertStorageAddedNoErrors(c, args)
+	s.assertCalls(c, []string{getBlockForTypeCall, addStorageForUnitFromSnapshotCall})
+	c.Assert(snapshotId, gc.Equals, "0")
+}
+
 func (s *storageAddSuite) TestStorageAddUnitBlocked(c *gc.C) {
 	s.blockAllChanges(c, "TestStorageAddUnitBlocked")
 
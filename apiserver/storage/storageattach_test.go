@@ -0,0 +1,127 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package storage_test
+
+import (
+	"github.com/juju/errors"
+	"github.com/juju/names"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/apiserver/params"
+)
+
+type storageAttachSuite struct {
+	baseStorageSuite
+}
+
+var _ = gc.Suite(&storageAttachSuite{})
+
+func (s *storageAttachSuite) TestAttach(c *gc.C) {
+	var attached []string
+	s.state.attachStorage = func(storage names.StorageTag, unit names.UnitTag) error {
+		s.calls = append(s.calls, attachStorageCall)
+		attached = append(attached, storage.Id()+"->"+unit.Id())
+		return nil
+	}
+	results, err := s.api.Attach(params.StorageAttachmentIds{[]params.StorageAttachmentId{{
+		StorageTag: s.storageTag.String(),
+		UnitTag:    "unit-mysql-1",
+	}, {
+		StorageTag: "volume-0",
+		UnitTag:    "unit-mysql-1",
+	}, {
+		StorageTag: s.storageTag.String(),
+		UnitTag:    "machine-0",
+	}}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results, jc.DeepEquals, []params.ErrorResult{
+		{},
+		{Error: &params.Error{Message: `"volume-0" is not a valid storage tag`}},
+		{Error: &params.Error{Message: `"machine-0" is not a valid unit tag`}},
+	})
+	c.Assert(attached, jc.DeepEquals, []string{"data/0->mysql/1"})
+	s.assertCalls(c, []string{getBlockForTypeCall, attachStorageCall})
+}
+
+func (s *storageAttachSuite) TestAttachError(c *gc.C) {
+	s.state.attachStorage = func(names.StorageTag, names.UnitTag) error {
+		return errors.New("storage is owned by unit mysql/0")
+	}
+	results, err := s.api.Attach(params.StorageAttachmentIds{[]params.StorageAttachmentId{{
+		StorageTag: s.storageTag.String(),
+		UnitTag:    "unit-mysql-1",
+	}}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results, gc.HasLen, 1)
+	c.Assert(results.Results[0].Error, gc.ErrorMatches, "storage is owned by unit mysql/0")
+}
+
+func (s *storageAttachSuite) TestAttachBlocked(c *gc.C) {
+	s.blockAllChanges(c, "TestAttachBlocked")
+	_, err := s.api.Attach(params.StorageAttachmentIds{[]params.StorageAttachmentId{{
+		StorageTag: s.storageTag.String(),
+		UnitTag:    "unit-mysql-1",
+	}}})
+	s.assertBlocked(c, err, "TestAttachBlocked")
+}
+
+func (s *storageAttachSuite) TestDetach(c *gc.C) {
+	var detached []string
+	s.state.detachStorage = func(storage names.StorageTag, unit names.UnitTag) error {
+		s.calls = append(s.calls, detachStorageCall)
+		detached = append(detached, storage.Id()+"->"+unit.Id())
+		return nil
+	}
+	results, err := s.api.Detach(params.StorageAttachmentIds{[]params.StorageAttachmentId{{
+		StorageTag: s.storageTag.String(),
+		UnitTag:    s.unitTag.String(),
+	}, {
+		// The owner of the storage is used if no unit is specified.
+		StorageTag: s.storageTag.String(),
+	}, {
+		StorageTag: "storage-data-1",
+	}}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results, jc.DeepEquals, []params.ErrorResult{
+		{}, {},
+		{Error: &params.Error{Message: `storage data/1 not found`, Code: params.CodeNotFound}},
+	})
+	c.Assert(detached, jc.DeepEquals, []string{"data/0->mysql/0", "data/0->mysql/0"})
+	s.assertCalls(c, []string{
+		getBlockForTypeCall,
+		detachStorageCall,
+		storageInstanceCall,
+		detachStorageCall,
+		storageInstanceCall,
+	})
+}
+
+func (s *storageAttachSuite) TestDetachNotAttached(c *gc.C) {
+	s.storageInstance.owner = nil
+	results, err := s.api.Detach(params.StorageAttachmentIds{[]params.StorageAttachmentId{{
+		StorageTag: s.storageTag.String(),
+	}}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results, gc.HasLen, 1)
+	c.Assert(results.Results[0].Error, gc.ErrorMatches, "storage data/0 is not attached")
+}
+
+func (s *storageAttachSuite) TestDetachShared(c *gc.C) {
+	s.storageInstance.owner = names.NewServiceTag("mysql")
+	results, err := s.api.Detach(params.StorageAttachmentIds{[]params.StorageAttachmentId{{
+		StorageTag: s.storageTag.String(),
+	}}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results, gc.HasLen, 1)
+	c.Assert(results.Results[0].Error, gc.ErrorMatches, "cannot detach storage data/0 from service mysql")
+}
+
+func (s *storageAttachSuite) TestDetachBlocked(c *gc.C) {
+	s.blockAllChanges(c, "TestDetachBlocked")
+	_, err := s.api.Detach(params.StorageAttachmentIds{[]params.StorageAttachmentId{{
+		StorageTag: s.storageTag.String(),
+	}}})
+	s.assertBlocked(c, err, "TestDetachBlocked")
+}
@@ -0,0 +1,159 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package storage_test
+
+import (
+	"github.com/juju/errors"
+	"github.com/juju/names"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/environs/config"
+	"github.com/juju/juju/state"
+	jujustorage "github.com/juju/juju/storage"
+	"github.com/juju/juju/storage/provider/dummy"
+	"github.com/juju/juju/storage/provider/registry"
+)
+
+type storageImportSuite struct {
+	baseStorageSuite
+	volumeSource *dummy.VolumeSource
+	provider     *dummy.StorageProvider
+}
+
+var _ = gc.Suite(&storageImportSuite{})
+
+func (s *storageImportSuite) SetUpTest(c *gc.C) {
+	s.baseStorageSuite.SetUpTest(c)
+	s.volumeSource = &dummy.VolumeSource{
+		ImportVolumeFunc: func(volumeId string, resourceTags map[string]string) (jujustorage.VolumeInfo, error) {
+			return jujustorage.VolumeInfo{
+				VolumeId:   volumeId,
+				HardwareId: "hw",
+				Size:       1024,
+				Persistent: true,
+			}, nil
+		},
+	}
+	s.provider = &dummy.StorageProvider{
+		VolumeSourceFunc: func(*config.Config, *jujustorage.Config) (jujustorage.VolumeSource, error) {
+			return s.volumeSource, nil
+		},
+	}
+	registry.RegisterProvider("importer", s.provider)
+	s.AddCleanup(func(*gc.C) {
+		registry.RegisterProvider("importer", nil)
+	})
+	var err error
+	s.pools["radiance"], err = jujustorage.NewConfig("radiance", "importer", map[string]interface{}{
+		"foo": "bar",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *storageImportSuite) TestImport(c *gc.C) {
+	var fsInfo state.FilesystemInfo
+	var volInfo *state.VolumeInfo
+	var storageName string
+	s.state.addExistingFilesystem = func(f state.FilesystemInfo, v *state.VolumeInfo, name string) (names.StorageTag, error) {
+		s.calls = append(s.calls, addExistingFilesystemCall)
+		fsInfo, volInfo, storageName = f, v, name
+		return names.NewStorageTag("pgdata/0"), nil
+	}
+	results, err := s.api.Import(params.BulkImportStorageParams{[]params.ImportStorageParams{{
+		Pool:        "radiance",
+		ProviderId:  "foo",
+		StorageName: "pgdata",
+	}}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results, jc.DeepEquals, []params.ImportStorageResult{{
+		Result: &params.ImportStorageDetails{StorageTag: "storage-pgdata-0"},
+	}})
+	c.Assert(fsInfo, jc.DeepEquals, state.FilesystemInfo{
+		Pool: "radiance",
+		Size: 1024,
+	})
+	c.Assert(volInfo, jc.DeepEquals, &state.VolumeInfo{
+		HardwareId: "hw",
+		Size:       1024,
+		Pool:       "radiance",
+		VolumeId:   "foo",
+		Persistent: true,
+	})
+	c.Assert(storageName, gc.Equals, "pgdata")
+	s.assertCalls(c, []string{getBlockForTypeCall, modelConfigCall, addExistingFilesystemCall})
+
+	s.provider.CheckCallNames(c, "VolumeSource")
+	s.volumeSource.CheckCallNames(c, "ImportVolume")
+	resourceTags := s.volumeSource.Calls()[0].Args[1].(map[string]string)
+	c.Assert(resourceTags["juju-model-uuid"], gc.Equals, "deadbeef-0bad-400d-8000-4b1d0d06f00d")
+}
+
+func (s *storageImportSuite) TestImportProviderType(c *gc.C) {
+	results, err := s.api.Import(params.BulkImportStorageParams{[]params.ImportStorageParams{{
+		Pool:        "importer",
+		ProviderId:  "foo",
+		StorageName: "pgdata",
+	}}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results, gc.HasLen, 1)
+	c.Assert(results.Results[0].Error, gc.IsNil)
+	s.provider.CheckCallNames(c, "VolumeSource")
+	storageConfig := s.provider.Calls()[0].Args[1].(*jujustorage.Config)
+	c.Assert(storageConfig.Name(), gc.Equals, "importer")
+	c.Assert(storageConfig.Provider(), gc.Equals, jujustorage.ProviderType("importer"))
+}
+
+func (s *storageImportSuite) TestImportPoolNotFound(c *gc.C) {
+	results, err := s.api.Import(params.BulkImportStorageParams{[]params.ImportStorageParams{{
+		Pool:        "nope",
+		ProviderId:  "foo",
+		StorageName: "pgdata",
+	}}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results, gc.HasLen, 1)
+	c.Assert(results.Results[0].Error, gc.ErrorMatches, "mock pool manager: get pool nope not found")
+}
+
+func (s *storageImportSuite) TestImportNotSupported(c *gc.C) {
+	s.provider.VolumeSourceFunc = func(*config.Config, *jujustorage.Config) (jujustorage.VolumeSource, error) {
+		// Hide the dummy volume source's ImportVolume method.
+		return struct{ jujustorage.VolumeSource }{s.volumeSource}, nil
+	}
+	results, err := s.api.Import(params.BulkImportStorageParams{[]params.ImportStorageParams{{
+		Pool:        "radiance",
+		ProviderId:  "foo",
+		StorageName: "pgdata",
+	}}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results, gc.HasLen, 1)
+	c.Assert(results.Results[0].Error, gc.ErrorMatches, `importing volume with storage provider "importer" not supported`)
+	c.Assert(results.Results[0].Error, jc.Satisfies, params.IsCodeNotSupported)
+}
+
+func (s *storageImportSuite) TestImportVolumeError(c *gc.C) {
+	s.volumeSource.ImportVolumeFunc = func(string, map[string]string) (jujustorage.VolumeInfo, error) {
+		return jujustorage.VolumeInfo{}, errors.New("volume is in use")
+	}
+	results, err := s.api.Import(params.BulkImportStorageParams{[]params.ImportStorageParams{{
+		Pool:        "radiance",
+		ProviderId:  "foo",
+		StorageName: "pgdata",
+	}}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results, gc.HasLen, 1)
+	c.Assert(results.Results[0].Error, gc.ErrorMatches, "importing volume: volume is in use")
+	s.assertCalls(c, []string{getBlockForTypeCall, modelConfigCall})
+}
+
+func (s *storageImportSuite) TestImportBlocked(c *gc.C) {
+	s.blockAllChanges(c, "TestImportBlocked")
+	_, err := s.api.Import(params.BulkImportStorageParams{[]params.ImportStorageParams{{
+		Pool:        "radiance",
+		ProviderId:  "foo",
+		StorageName: "pgdata",
+	}}})
+	s.assertBlocked(c, err, "TestImportBlocked")
+}
@@ -0,0 +1,161 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package storage_test
+
+import (
+	"github.com/juju/errors"
+	"github.com/juju/names"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/environs/config"
+	"github.com/juju/juju/state"
+	jujustorage "github.com/juju/juju/storage"
+	"github.com/juju/juju/storage/provider/dummy"
+	"github.com/juju/juju/storage/provider/registry"
+)
+
+type storageResizeSuite struct {
+	baseStorageSuite
+	volumeSource *dummy.VolumeSource
+	provider     *dummy.StorageProvider
+}
+
+var _ = gc.Suite(&storageResizeSuite{})
+
+func (s *storageResizeSuite) SetUpTest(c *gc.C) {
+	s.baseStorageSuite.SetUpTest(c)
+	s.volumeSource = &dummy.VolumeSource{}
+	s.provider = &dummy.StorageProvider{
+		IsDynamic: true,
+		VolumeSourceFunc: func(*config.Config, *jujustorage.Config) (jujustorage.VolumeSource, error) {
+			return s.volumeSource, nil
+		},
+	}
+	registry.RegisterProvider("resizer", s.provider)
+	s.AddCleanup(func(*gc.C) {
+		registry.RegisterProvider("resizer", nil)
+	})
+	var err error
+	s.pools["elastic"], err = jujustorage.NewConfig("elastic", "resizer", map[string]interface{}{})
+	c.Assert(err, jc.ErrorIsNil)
+
+	s.storageInstance.kind = state.StorageKindBlock
+	s.volume.info = &state.VolumeInfo{
+		VolumeId: "vol-0",
+		Pool:     "elastic",
+		Size:     1024,
+	}
+}
+
+func (s *storageResizeSuite) resize(c *gc.C, size uint64) params.ErrorResult {
+	results, err := s.api.Resize(params.BulkResizeStorageParams{
+		[]params.ResizeStorageParams{{
+			StorageTag: s.storageTag.String(),
+			Size:       size,
+		}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results, gc.HasLen, 1)
+	return results.Results[0]
+}
+
+func (s *storageResizeSuite) TestResizeBlock(c *gc.C) {
+	var volumeTag names.VolumeTag
+	var size uint64
+	s.state.resizeVolume = func(v names.VolumeTag, sz uint64) error {
+		s.calls = append(s.calls, resizeVolumeCall)
+		volumeTag, size = v, sz
+		return nil
+	}
+	result := s.resize(c, 2048)
+	c.Assert(result.Error, gc.IsNil)
+	c.Assert(volumeTag, gc.Equals, s.volumeTag)
+	c.Assert(size, gc.Equals, uint64(2048))
+	s.assertCalls(c, []string{
+		getBlockForTypeCall, storageInstanceCall, storageInstanceVolumeCall,
+		volumeCall, modelConfigCall, resizeVolumeCall,
+	})
+	// The resize itself is performed by the storage provisioner.
+	s.volumeSource.CheckNoCalls(c)
+}
+
+func (s *storageResizeSuite) TestResizeFilesystem(c *gc.C) {
+	s.storageInstance.kind = state.StorageKindFilesystem
+	s.filesystem.volume = &s.volumeTag
+	result := s.resize(c, 2048)
+	c.Assert(result.Error, gc.IsNil)
+	s.assertCalls(c, []string{
+		getBlockForTypeCall, storageInstanceCall, storageInstanceFilesystemCall,
+		volumeCall, modelConfigCall, resizeVolumeCall,
+	})
+}
+
+func (s *storageResizeSuite) TestResizeFilesystemNoBackingVolume(c *gc.C) {
+	s.storageInstance.kind = state.StorageKindFilesystem
+	result := s.resize(c, 2048)
+	c.Assert(result.Error, gc.ErrorMatches, "resizing filesystem storage not backed by a volume not supported")
+	c.Assert(result.Error, jc.Satisfies, params.IsCodeNotSupported)
+}
+
+func (s *storageResizeSuite) TestResizeNotProvisioned(c *gc.C) {
+	s.volume.info = nil
+	result := s.resize(c, 2048)
+	c.Assert(result.Error, gc.ErrorMatches, "cannot resize storage data/0: volume-22 not provisioned")
+}
+
+func (s *storageResizeSuite) TestResizeNotSupported(c *gc.C) {
+	s.provider.VolumeSourceFunc = func(*config.Config, *jujustorage.Config) (jujustorage.VolumeSource, error) {
+		// Hide the dummy volume source's ResizeVolumes method.
+		return struct{ jujustorage.VolumeSource }{s.volumeSource}, nil
+	}
+	result := s.resize(c, 2048)
+	c.Assert(result.Error, gc.ErrorMatches, `resizing volumes with storage provider "resizer" not supported`)
+	c.Assert(result.Error, jc.Satisfies, params.IsCodeNotSupported)
+	s.assertCalls(c, []string{
+		getBlockForTypeCall, storageInstanceCall, storageInstanceVolumeCall,
+		volumeCall, modelConfigCall,
+	})
+}
+
+func (s *storageResizeSuite) TestResizeNonDynamic(c *gc.C) {
+	s.provider.IsDynamic = false
+	result := s.resize(c, 2048)
+	c.Assert(result.Error, gc.ErrorMatches, `resizing volumes with non-dynamic storage provider "resizer" not supported`)
+	c.Assert(result.Error, jc.Satisfies, params.IsCodeNotSupported)
+}
+
+func (s *storageResizeSuite) TestResizeMachineScoped(c *gc.C) {
+	// Machine-scoped volume sources are checked by the storage
+	// provisioner, so the request is recorded without consulting
+	// the provider's volume source.
+	s.provider.StorageScope = jujustorage.ScopeMachine
+	result := s.resize(c, 2048)
+	c.Assert(result.Error, gc.IsNil)
+	s.assertCalls(c, []string{
+		getBlockForTypeCall, storageInstanceCall, storageInstanceVolumeCall,
+		volumeCall, resizeVolumeCall,
+	})
+	s.provider.CheckCallNames(c, "Dynamic", "Scope")
+}
+
+func (s *storageResizeSuite) TestResizeStateError(c *gc.C) {
+	s.state.resizeVolume = func(names.VolumeTag, uint64) error {
+		return errors.New("new size 512MiB must be greater than current size 1024MiB")
+	}
+	result := s.resize(c, 512)
+	c.Assert(result.Error, gc.ErrorMatches, "new size 512MiB must be greater than current size 1024MiB")
+}
+
+func (s *storageResizeSuite) TestResizeBlocked(c *gc.C) {
+	s.blockAllChanges(c, "TestResizeBlocked")
+	_, err := s.api.Resize(params.BulkResizeStorageParams{
+		[]params.ResizeStorageParams{{
+			StorageTag: s.storageTag.String(),
+			Size:       2048,
+		}},
+	})
+	s.assertBlocked(c, err, "TestResizeBlocked")
+}
@@ -0,0 +1,261 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package storage_test
+
+import (
+	"time"
+
+	"github.com/juju/errors"
+	"github.com/juju/names"
+	gitjujutesting "github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/environs/config"
+	"github.com/juju/juju/state"
+	jujustorage "github.com/juju/juju/storage"
+	"github.com/juju/juju/storage/provider/dummy"
+	"github.com/juju/juju/storage/provider/registry"
+)
+
+type storageSnapshotSuite struct {
+	baseStorageSuite
+	volumeSource *dummy.VolumeSource
+	provider     *dummy.StorageProvider
+}
+
+var _ = gc.Suite(&storageSnapshotSuite{})
+
+func (s *storageSnapshotSuite) SetUpTest(c *gc.C) {
+	s.baseStorageSuite.SetUpTest(c)
+	s.volumeSource = &dummy.VolumeSource{
+		CreateSnapshotsFunc: func(params []jujustorage.SnapshotParams) ([]jujustorage.CreateSnapshotsResult, error) {
+			results := make([]jujustorage.CreateSnapshotsResult, len(params))
+			for i, p := range params {
+				results[i].Snapshot = &jujustorage.SnapshotInfo{
+					SnapshotId: "snap-0",
+					VolumeId:   p.VolumeId,
+					Size:       1024,
+				}
+			}
+			return results, nil
+		},
+	}
+	s.provider = &dummy.StorageProvider{
+		VolumeSourceFunc: func(*config.Config, *jujustorage.Config) (jujustorage.VolumeSource, error) {
+			return s.volumeSource, nil
+		},
+	}
+	registry.RegisterProvider("snapshotter", s.provider)
+	s.AddCleanup(func(*gc.C) {
+		registry.RegisterProvider("snapshotter", nil)
+	})
+	var err error
+	s.pools["radiance"], err = jujustorage.NewConfig("radiance", "snapshotter", map[string]interface{}{})
+	c.Assert(err, jc.ErrorIsNil)
+
+	s.storageInstance.kind = state.StorageKindBlock
+	s.volume.info = &state.VolumeInfo{
+		VolumeId: "vol-0",
+		Pool:     "radiance",
+		Size:     1024,
+	}
+}
+
+func (s *storageSnapshotSuite) createSnapshots(c *gc.C) params.VolumeSnapshotResult {
+	results, err := s.api.CreateSnapshots(params.Entities{[]params.Entity{
+		{Tag: s.storageTag.String()},
+	}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results, gc.HasLen, 1)
+	return results.Results[0]
+}
+
+func (s *storageSnapshotSuite) TestCreateSnapshotsBlock(c *gc.C) {
+	var volumeTag names.VolumeTag
+	var info state.VolumeSnapshotInfo
+	addVolumeSnapshot := s.state.addVolumeSnapshot
+	s.state.addVolumeSnapshot = func(v names.VolumeTag, i state.VolumeSnapshotInfo) (state.VolumeSnapshot, error) {
+		volumeTag, info = v, i
+		return addVolumeSnapshot(v, i)
+	}
+	result := s.createSnapshots(c)
+	c.Assert(result.Error, gc.IsNil)
+	c.Assert(result.Result, jc.DeepEquals, &params.VolumeSnapshotDetails{
+		Id:         "0",
+		StorageTag: "storage-data-0",
+		VolumeTag:  "volume-22",
+		SnapshotId: "snap-0",
+		Pool:       "radiance",
+		Size:       1024,
+	})
+	c.Assert(volumeTag, gc.Equals, s.volumeTag)
+	c.Assert(info, jc.DeepEquals, state.VolumeSnapshotInfo{
+		SnapshotId: "snap-0",
+		Size:       1024,
+	})
+	s.assertCalls(c, []string{
+		getBlockForTypeCall, storageInstanceCall, storageInstanceVolumeCall,
+		volumeCall, modelConfigCall, addVolumeSnapshotCall,
+	})
+
+	s.volumeSource.CheckCallNames(c, "CreateSnapshots")
+	snapshotParams := s.volumeSource.Calls()[0].Args[0].([]jujustorage.SnapshotParams)
+	c.Assert(snapshotParams, gc.HasLen, 1)
+	c.Assert(snapshotParams[0].VolumeId, gc.Equals, "vol-0")
+	c.Assert(snapshotParams[0].ResourceTags["juju-model-uuid"], gc.Equals, "deadbeef-0bad-400d-8000-4b1d0d06f00d")
+}
+
+func (s *storageSnapshotSuite) TestCreateSnapshotsFilesystem(c *gc.C) {
+	s.storageInstance.kind = state.StorageKindFilesystem
+	s.filesystem.volume = &s.volumeTag
+	result := s.createSnapshots(c)
+	c.Assert(result.Error, gc.IsNil)
+	c.Assert(result.Result.VolumeTag, gc.Equals, "volume-22")
+	s.assertCalls(c, []string{
+		getBlockForTypeCall, storageInstanceCall, storageInstanceFilesystemCall,
+		volumeCall, modelConfigCall, addVolumeSnapshotCall,
+	})
+}
+
+func (s *storageSnapshotSuite) TestCreateSnapshotsFilesystemNoBackingVolume(c *gc.C) {
+	s.storageInstance.kind = state.StorageKindFilesystem
+	result := s.createSnapshots(c)
+	c.Assert(result.Error, gc.ErrorMatches, "snapshots of filesystem storage not backed by a volume not supported")
+	c.Assert(result.Error, jc.Satisfies, params.IsCodeNotSupported)
+}
+
+func (s *storageSnapshotSuite) TestCreateSnapshotsNotProvisioned(c *gc.C) {
+	s.volume.info = nil
+	result := s.createSnapshots(c)
+	c.Assert(result.Error, gc.ErrorMatches, "cannot snapshot storage data/0: volume-22 not provisioned")
+	s.volumeSource.CheckNoCalls(c)
+}
+
+func (s *storageSnapshotSuite) TestCreateSnapshotsNotSupported(c *gc.C) {
+	s.provider.VolumeSourceFunc = func(*config.Config, *jujustorage.Config) (jujustorage.VolumeSource, error) {
+		// Hide the dummy volume source's snapshot methods.
+		return struct{ jujustorage.VolumeSource }{s.volumeSource}, nil
+	}
+	result := s.createSnapshots(c)
+	c.Assert(result.Error, gc.ErrorMatches, `snapshots with storage provider "snapshotter" not supported`)
+	c.Assert(result.Error, jc.Satisfies, params.IsCodeNotSupported)
+}
+
+func (s *storageSnapshotSuite) TestCreateSnapshotsMachineScoped(c *gc.C) {
+	s.provider.StorageScope = jujustorage.ScopeMachine
+	result := s.createSnapshots(c)
+	c.Assert(result.Error, gc.ErrorMatches, `snapshots with machine-scoped storage provider "snapshotter" not supported`)
+	s.provider.CheckCallNames(c, "Scope")
+}
+
+func (s *storageSnapshotSuite) TestCreateSnapshotsError(c *gc.C) {
+	s.volumeSource.CreateSnapshotsFunc = func(params []jujustorage.SnapshotParams) ([]jujustorage.CreateSnapshotsResult, error) {
+		return []jujustorage.CreateSnapshotsResult{{Error: errors.New("volume is busy")}}, nil
+	}
+	result := s.createSnapshots(c)
+	c.Assert(result.Error, gc.ErrorMatches, "creating snapshot: volume is busy")
+	s.assertCalls(c, []string{
+		getBlockForTypeCall, storageInstanceCall, storageInstanceVolumeCall,
+		volumeCall, modelConfigCall,
+	})
+}
+
+func (s *storageSnapshotSuite) TestCreateSnapshotsBlocked(c *gc.C) {
+	s.blockAllChanges(c, "TestCreateSnapshotsBlocked")
+	_, err := s.api.CreateSnapshots(params.Entities{[]params.Entity{
+		{Tag: s.storageTag.String()},
+	}})
+	s.assertBlocked(c, err, "TestCreateSnapshotsBlocked")
+}
+
+func (s *storageSnapshotSuite) TestListSnapshots(c *gc.C) {
+	created := time.Date(2016, 6, 1, 12, 0, 0, 0, time.UTC)
+	s.state.allVolumeSnapshots = func() ([]state.VolumeSnapshot, error) {
+		s.calls = append(s.calls, allVolumeSnapshotsCall)
+		return []state.VolumeSnapshot{
+			&mockVolumeSnapshot{
+				id:         "0",
+				volume:     s.volumeTag,
+				storage:    &s.storageTag,
+				pool:       "radiance",
+				snapshotId: "snap-0",
+				size:       1024,
+				created:    created,
+			},
+			&mockVolumeSnapshot{
+				id:         "1",
+				volume:     names.NewVolumeTag("23"),
+				pool:       "radiance",
+				snapshotId: "snap-1",
+				size:       2048,
+				created:    created,
+			},
+		}, nil
+	}
+	list, err := s.api.ListSnapshots()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(list.Snapshots, jc.DeepEquals, []params.VolumeSnapshotDetails{{
+		Id:         "0",
+		StorageTag: "storage-data-0",
+		VolumeTag:  "volume-22",
+		SnapshotId: "snap-0",
+		Pool:       "radiance",
+		Size:       1024,
+		Created:    created,
+	}, {
+		Id:         "1",
+		VolumeTag:  "volume-23",
+		SnapshotId: "snap-1",
+		Pool:       "radiance",
+		Size:       2048,
+		Created:    created,
+	}})
+	s.assertCalls(c, []string{allVolumeSnapshotsCall})
+}
+
+func (s *storageSnapshotSuite) TestDestroySnapshots(c *gc.C) {
+	s.volumeSource.DestroySnapshotsFunc = func(ids []string) ([]error, error) {
+		return make([]error, len(ids)), nil
+	}
+	results, err := s.api.DestroySnapshots(params.VolumeSnapshotIds{Ids: []string{"0"}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results, gc.HasLen, 1)
+	c.Assert(results.Results[0].Error, gc.IsNil)
+	s.assertCalls(c, []string{
+		getBlockForTypeCall, volumeSnapshotCall, modelConfigCall, removeVolumeSnapshotCall,
+	})
+	s.volumeSource.CheckCalls(c, []gitjujutesting.StubCall{
+		{"DestroySnapshots", []interface{}{[]string{"snap-0"}}},
+	})
+}
+
+func (s *storageSnapshotSuite) TestDestroySnapshotsInUse(c *gc.C) {
+	s.state.removeVolumeSnapshot = func(string) error {
+		s.calls = append(s.calls, removeVolumeSnapshotCall)
+		return errors.New(`snapshot is used by storage "data/1"`)
+	}
+	results, err := s.api.DestroySnapshots(params.VolumeSnapshotIds{Ids: []string{"0"}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results, gc.HasLen, 1)
+	c.Assert(results.Results[0].Error, gc.ErrorMatches, `snapshot is used by storage "data/1"`)
+	s.volumeSource.CheckNoCalls(c)
+}
+
+func (s *storageSnapshotSuite) TestDestroySnapshotsError(c *gc.C) {
+	s.volumeSource.DestroySnapshotsFunc = func(ids []string) ([]error, error) {
+		return []error{errors.New("snapshot is busy")}, nil
+	}
+	results, err := s.api.DestroySnapshots(params.VolumeSnapshotIds{Ids: []string{"0"}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results, gc.HasLen, 1)
+	c.Assert(results.Results[0].Error, gc.ErrorMatches, `destroying snapshot "snap-0": snapshot is busy`)
+}
+
+func (s *storageSnapshotSuite) TestDestroySnapshotsBlocked(c *gc.C) {
+	s.blockAllChanges(c, "TestDestroySnapshotsBlocked")
+	_, err := s.api.DestroySnapshots(params.VolumeSnapshotIds{Ids: []string{"0"}})
+	s.assertBlocked(c, err, "TestDestroySnapshotsBlocked")
+}
@@ -261,6 +261,13 @@ func (s *StorageProvisionerAPI) WatchVolumes(args params.Entities) (params.Strin
 	return s.watchStorageEntities(args, s.st.WatchModelVolumes, s.st.WatchMachineVolumes)
 }
 
+// WatchVolumeResizes watches for changes to volumes scoped to the
+// entity with the tag passed to NewState, including requests to
+// resize them.
+func (s *StorageProvisionerAPI) WatchVolumeResizes(args params.Entities) (params.StringsWatchResults, error) {
+	return s.watchStorageEntities(args, s.st.WatchModelVolumeResizes, s.st.WatchMachineVolumeResizes)
+}
+
 // WatchFilesystems watches for changes to filesystems scoped
 // to the entity with the tag passed to NewState.
 func (s *StorageProvisionerAPI) WatchFilesystems(args params.Entities) (params.StringsWatchResults, error) {
@@ -606,6 +613,7 @@ func (s *StorageProvisionerAPI) VolumeParams(args params.Entities) (params.Volum
 				string(instanceId),
 				volumeParams.Provider,
 				volumeAttachmentParams.ReadOnly,
+				volumeParams.Attributes,
 			}
 		}
 		return volumeParams, nil
@@ -623,6 +631,63 @@ func (s *StorageProvisionerAPI) VolumeParams(args params.Entities) (params.Volum
 	return results, nil
 }
 
+// VolumeResizeParams returns the parameters for resizing the volumes
+// with the specified tags. If a volume has no pending request to resize
+// it, the result for that volume will contain a NotFound error.
+func (s *StorageProvisionerAPI) VolumeResizeParams(args params.Entities) (params.VolumeResizeParamsResults, error) {
+	canAccess, err := s.getStorageEntityAuthFunc()
+	if err != nil {
+		return params.VolumeResizeParamsResults{}, err
+	}
+	results := params.VolumeResizeParamsResults{
+		Results: make([]params.VolumeResizeParamsResult, len(args.Entities)),
+	}
+	poolManager := poolmanager.New(s.settings)
+	one := func(arg params.Entity) (params.VolumeResizeParams, error) {
+		tag, err := names.ParseVolumeTag(arg.Tag)
+		if err != nil || !canAccess(tag) {
+			return params.VolumeResizeParams{}, common.ErrPerm
+		}
+		volume, err := s.st.Volume(tag)
+		if errors.IsNotFound(err) {
+			return params.VolumeResizeParams{}, common.ErrPerm
+		} else if err != nil {
+			return params.VolumeResizeParams{}, err
+		}
+		size, ok := volume.RequestedSize()
+		if !ok || volume.Life() != state.Alive {
+			return params.VolumeResizeParams{}, errors.NotFoundf(
+				"resize request for volume %s", tag.Id(),
+			)
+		}
+		info, err := volume.Info()
+		if err != nil {
+			return params.VolumeResizeParams{}, err
+		}
+		providerType, _, err := storagecommon.StoragePoolConfig(info.Pool, poolManager)
+		if err != nil {
+			return params.VolumeResizeParams{}, errors.Trace(err)
+		}
+		return params.VolumeResizeParams{
+			VolumeTag: tag.String(),
+			VolumeId:  info.VolumeId,
+			Size:      size,
+			Provider:  string(providerType),
+		}, nil
+	}
+	for i, arg := range args.Entities {
+		var result params.VolumeResizeParamsResult
+		resizeParams, err := one(arg)
+		if err != nil {
+			result.Error = common.ServerError(err)
+		} else {
+			result.Result = resizeParams
+		}
+		results.Results[i] = result
+	}
+	return results, nil
+}
+
 // FilesystemParams returns the parameters for creating the filesystems
 // with the specified tags.
 func (s *StorageProvisionerAPI) FilesystemParams(args params.Entities) (params.FilesystemParamsResults, error) {
@@ -718,7 +783,7 @@ func (s *StorageProvisionerAPI) VolumeAttachmentParams(
 			volumeId = volumeInfo.VolumeId
 			pool = volumeInfo.Pool
 		}
-		providerType, _, err := storagecommon.StoragePoolConfig(pool, poolManager)
+		providerType, cfg, err := storagecommon.StoragePoolConfig(pool, poolManager)
 		if err != nil {
 			return params.VolumeAttachmentParams{}, errors.Trace(err)
 		}
@@ -741,6 +806,7 @@ func (s *StorageProvisionerAPI) VolumeAttachmentParams(
 			string(instanceId),
 			string(providerType),
 			readOnly,
+			cfg.Attrs(),
 		}, nil
 	}
 	for i, arg := range args.Ids {
@@ -797,7 +863,7 @@ func (s *StorageProvisionerAPI) FilesystemAttachmentParams(
 			filesystemId = filesystemInfo.FilesystemId
 			pool = filesystemInfo.Pool
 		}
-		providerType, _, err := storagecommon.StoragePoolConfig(pool, poolManager)
+		providerType, cfg, err := storagecommon.StoragePoolConfig(pool, poolManager)
 		if err != nil {
 			return params.FilesystemAttachmentParams{}, errors.Trace(err)
 		}
@@ -827,6 +893,7 @@ func (s *StorageProvisionerAPI) FilesystemAttachmentParams(
 			// parts of the codebase.
 			location,
 			readOnly,
+			cfg.Attrs(),
 		}, nil
 	}
 	for i, arg := range args.Ids {
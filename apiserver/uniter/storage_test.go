@@ -84,6 +84,10 @@ func (s *storageSuite) TestWatchStorageAttachmentVolume(c *gc.C) {
 		changes: make(chan struct{}, 1),
 	}
 	blockDevicesWatcher.changes <- struct{}{}
+	volumeChangesWatcher := &mockNotifyWatcher{
+		changes: make(chan struct{}, 1),
+	}
+	volumeChangesWatcher.changes <- struct{}{}
 	var calls []string
 	state := &mockStorageState{
 		storageInstance: func(s names.StorageTag) (state.StorageInstance, error) {
@@ -118,6 +122,11 @@ func (s *storageSuite) TestWatchStorageAttachmentVolume(c *gc.C) {
 			c.Assert(m, gc.DeepEquals, machineTag)
 			return blockDevicesWatcher
 		},
+		watchVolume: func(v names.VolumeTag) state.NotifyWatcher {
+			calls = append(calls, "WatchVolume")
+			c.Assert(v, gc.DeepEquals, volumeTag)
+			return volumeChangesWatcher
+		},
 	}
 
 	storage, err := uniter.NewStorageAPI(state, resources, getCanAccess)
@@ -140,6 +149,7 @@ func (s *storageSuite) TestWatchStorageAttachmentVolume(c *gc.C) {
 		"StorageInstanceVolume",
 		"WatchVolumeAttachment",
 		"WatchBlockDevices",
+		"WatchVolume",
 		"WatchStorageAttachment",
 	})
 }
@@ -486,6 +496,7 @@ type mockStorageState struct {
 	watchFilesystemAttachment     func(names.MachineTag, names.FilesystemTag) state.NotifyWatcher
 	watchVolumeAttachment         func(names.MachineTag, names.VolumeTag) state.NotifyWatcher
 	watchBlockDevices             func(names.MachineTag) state.NotifyWatcher
+	watchVolume                   func(names.VolumeTag) state.NotifyWatcher
 	addUnitStorage                func(u names.UnitTag, name string, cons state.StorageConstraints) error
 	unitStorageConstraints        func(u names.UnitTag) (map[string]state.StorageConstraints, error)
 }
@@ -534,6 +545,10 @@ func (m *mockStorageState) WatchBlockDevices(mtag names.MachineTag) state.Notify
 	return m.watchBlockDevices(mtag)
 }
 
+func (m *mockStorageState) WatchVolume(v names.VolumeTag) state.NotifyWatcher {
+	return m.watchVolume(v)
+}
+
 func (m *mockStorageState) AddStorageForUnit(tag names.UnitTag, name string, cons state.StorageConstraints) error {
 	return m.addUnitStorage(tag, name, cons)
 }
@@ -595,6 +610,10 @@ func (m *mockFilesystem) FilesystemTag() names.FilesystemTag {
 	return m.tag
 }
 
+func (m *mockFilesystem) Volume() (names.VolumeTag, error) {
+	return names.VolumeTag{}, state.ErrNoBackingVolume
+}
+
 type mockStorageInstance struct {
 	state.StorageInstance
 	kind state.StorageKind
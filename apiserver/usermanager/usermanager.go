@@ -206,6 +206,17 @@ func (api *UserManagerAPI) UserInfo(request params.UserInfoRequest) (params.User
 		} else {
 			lastLogin = &userLastLogin
 		}
+		failures, err := user.FailedLogins()
+		if err != nil {
+			logger.Debugf("error getting failed logins: %v", err)
+		}
+		var failedLogins []params.FailedLogin
+		for _, failure := range failures {
+			failedLogins = append(failedLogins, params.FailedLogin{
+				Time:          failure.Time,
+				RemoteAddress: failure.RemoteAddress,
+			})
+		}
 		return params.UserInfoResult{
 			Result: &params.UserInfo{
 				Username:       user.Name(),
@@ -214,6 +225,7 @@ func (api *UserManagerAPI) UserInfo(request params.UserInfoRequest) (params.User
 				DateCreated:    user.DateCreated(),
 				LastConnection: lastLogin,
 				Disabled:       user.IsDisabled(),
+				FailedLogins:   failedLogins,
 			},
 		}
 	}
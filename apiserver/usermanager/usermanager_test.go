@@ -445,6 +445,27 @@ func (s *userManagerSuite) TestUserInfo(c *gc.C) {
 	c.Assert(results, jc.DeepEquals, expected)
 }
 
+func (s *userManagerSuite) TestUserInfoFailedLogins(c *gc.C) {
+	userFoo := s.Factory.MakeUser(c, &factory.UserParams{Name: "foobar"})
+	err := userFoo.RecordFailedLogin("10.0.0.1")
+	c.Assert(err, jc.ErrorIsNil)
+	err = userFoo.RecordFailedLogin("10.0.0.2")
+	c.Assert(err, jc.ErrorIsNil)
+
+	args := params.UserInfoRequest{
+		Entities: []params.Entity{{Tag: userFoo.Tag().String()}},
+	}
+	results, err := s.usermanager.UserInfo(args)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results, gc.HasLen, 1)
+	c.Assert(results.Results[0].Error, gc.IsNil)
+	failures := results.Results[0].Result.FailedLogins
+	c.Assert(failures, gc.HasLen, 2)
+	c.Assert(failures[0].RemoteAddress, gc.Equals, "10.0.0.1")
+	c.Assert(failures[1].RemoteAddress, gc.Equals, "10.0.0.2")
+	c.Assert(failures[0].Time.IsZero(), jc.IsFalse)
+}
+
 func (s *userManagerSuite) TestUserInfoAll(c *gc.C) {
 	admin, err := s.State.User(s.AdminUserTag(c))
 	c.Assert(err, jc.ErrorIsNil)
@@ -93,6 +93,7 @@ func (s *watcherSuite) TestMigrationStatusWatcher(c *gc.C) {
 	result, err := facade.Next()
 	c.Assert(err, jc.ErrorIsNil)
 	c.Assert(result, jc.DeepEquals, params.MigrationStatus{
+		MigrationId:    "id",
 		Attempt:        2,
 		Phase:          "READONLY",
 		SourceAPIAddrs: []string{"1.2.3.4:5", "2.3.4.5:6", "3.4.5.6:7"},
@@ -179,6 +180,10 @@ type fakeModelMigration struct {
 	state.ModelMigration
 }
 
+func (m *fakeModelMigration) Id() string {
+	return "id"
+}
+
 func (m *fakeModelMigration) Attempt() (int, error) {
 	return 2, nil
 }
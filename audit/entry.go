@@ -0,0 +1,128 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package audit
+
+import (
+	"encoding/json"
+	"strings"
+	"time"
+
+	"github.com/juju/errors"
+	"github.com/juju/names"
+)
+
+// Entry is a structured record of a single API call, made by a user,
+// that may have changed the state of the controller or of a model.
+type Entry struct {
+	// Timestamp is the time at which the call was made.
+	Timestamp time.Time
+
+	// User is the tag of the user who made the call.
+	User string
+
+	// ModelUUID identifies the model the connection was made to.
+	ModelUUID string
+
+	// Facade, Version and Method identify the API call.
+	Facade  string
+	Version int
+	Method  string
+
+	// Args holds the JSON-encoded arguments of the call, with the
+	// values of any sensitive fields redacted.
+	Args string
+
+	// Error and ErrorCode hold the error returned by the call, if
+	// any. They are empty if the call succeeded.
+	Error     string
+	ErrorCode string
+}
+
+// Validate returns an error if the entry is missing required
+// information.
+func (e Entry) Validate() error {
+	if e.Timestamp.IsZero() {
+		return errors.NotValidf("zero Timestamp")
+	}
+	if _, err := names.ParseUserTag(e.User); err != nil {
+		return errors.NewNotValid(err, "bad User")
+	}
+	if !names.IsValidModel(e.ModelUUID) {
+		return errors.NotValidf("ModelUUID %q", e.ModelUUID)
+	}
+	if e.Facade == "" {
+		return errors.NotValidf("empty Facade")
+	}
+	if e.Method == "" {
+		return errors.NotValidf("empty Method")
+	}
+	return nil
+}
+
+// RedactedValue replaces the values of sensitive call arguments.
+const RedactedValue = "<redacted>"
+
+// secretKeyFragments holds the (normalised) fragments of argument
+// field names whose values must not be recorded.
+var secretKeyFragments = []string{
+	"password",
+	"secret",
+	"privatekey",
+	"clientkey",
+	"token",
+	"macaroon",
+	"credential",
+}
+
+// RedactArgs returns the JSON encoding of the given API call
+// arguments, with the values of any fields that may hold secrets
+// replaced by RedactedValue.
+func RedactArgs(args interface{}) (string, error) {
+	if args == nil {
+		return "", nil
+	}
+	data, err := json.Marshal(args)
+	if err != nil {
+		return "", errors.Trace(err)
+	}
+	var decoded interface{}
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		return "", errors.Trace(err)
+	}
+	data, err = json.Marshal(redact(decoded))
+	if err != nil {
+		return "", errors.Trace(err)
+	}
+	return string(data), nil
+}
+
+func redact(value interface{}) interface{} {
+	switch value := value.(type) {
+	case map[string]interface{}:
+		for key, v := range value {
+			if isSecretKey(key) {
+				value[key] = RedactedValue
+			} else {
+				value[key] = redact(v)
+			}
+		}
+	case []interface{}:
+		for i, v := range value {
+			value[i] = redact(v)
+		}
+	}
+	return value
+}
+
+func isSecretKey(key string) bool {
+	key = strings.ToLower(key)
+	key = strings.Replace(key, "-", "", -1)
+	key = strings.Replace(key, "_", "", -1)
+	for _, fragment := range secretKeyFragments {
+		if strings.Contains(key, fragment) {
+			return true
+		}
+	}
+	return false
+}
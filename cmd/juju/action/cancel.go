@@ -0,0 +1,86 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package action
+
+import (
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	"launchpad.net/gnuflag"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/cmd/modelcmd"
+)
+
+func NewCancelCommand() cmd.Command {
+	return modelcmd.Wrap(&cancelCommand{})
+}
+
+// cancelCommand cancels pending or running Actions by ID.
+type cancelCommand struct {
+	ActionCommandBase
+	out          cmd.Output
+	requestedIds []string
+}
+
+const cancelDoc = `
+Cancel actions matching the given IDs or partial ID prefixes. Each
+prefix must match exactly one action.
+
+A pending action is cancelled immediately. A running action is marked
+as "aborting" until the unit running it stops the action, at which
+point it is reported as "cancelled".
+
+Examples:
+
+    juju cancel-action 5b7ad4a1
+    juju cancel-action 5b7ad4a1 9f33a0c2
+`
+
+// Set up the output.
+func (c *cancelCommand) SetFlags(f *gnuflag.FlagSet) {
+	c.out.AddFlags(f, "smart", cmd.DefaultFormatters)
+}
+
+func (c *cancelCommand) Info() *cmd.Info {
+	return &cmd.Info{
+		Name:    "cancel-action",
+		Args:    "<action ID>|<action ID prefix> [...]",
+		Purpose: "cancel pending or running actions",
+		Doc:     cancelDoc,
+	}
+}
+
+func (c *cancelCommand) Init(args []string) error {
+	if len(args) == 0 {
+		return errors.New("no action ID specified")
+	}
+	c.requestedIds = args
+	return nil
+}
+
+func (c *cancelCommand) Run(ctx *cmd.Context) error {
+	api, err := c.NewActionAPIClient()
+	if err != nil {
+		return err
+	}
+	defer api.Close()
+
+	var entities []params.Entity
+	for _, id := range c.requestedIds {
+		tag, err := getActionTagByPrefix(api, id)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		entities = append(entities, params.Entity{Tag: tag.String()})
+	}
+
+	results, err := api.Cancel(params.Entities{Entities: entities})
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if len(results.Results) != len(entities) {
+		return errors.Errorf("expected %d results, got %d", len(entities), len(results.Results))
+	}
+	return c.out.Write(ctx, resultsToMap(results.Results))
+}
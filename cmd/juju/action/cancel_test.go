@@ -0,0 +1,84 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package action_test
+
+import (
+	"bytes"
+	"time"
+
+	"github.com/juju/cmd"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/cmd/juju/action"
+	"github.com/juju/juju/testing"
+)
+
+type CancelSuite struct {
+	BaseActionSuite
+}
+
+var _ = gc.Suite(&CancelSuite{})
+
+func (s *CancelSuite) TestInit(c *gc.C) {
+	command, _ := action.NewCancelCommandForTest(s.store)
+	err := testing.InitCommand(command, nil)
+	c.Assert(err, gc.ErrorMatches, "no action ID specified")
+}
+
+func (s *CancelSuite) TestRun(c *gc.C) {
+	fakeid := "deadbeef-0000-4000-8000-feedfacebeef"
+	fakeid2 := "cafebabe-0000-4000-8000-feedfacebeef"
+	tags := params.FindTagsResults{Matches: map[string][]params.Entity{
+		"deadbeef": {{Tag: "action-" + fakeid}},
+		"cafebabe": {{Tag: "action-" + fakeid2}},
+	}}
+	results := []params.ActionResult{
+		{Status: params.ActionCancelled},
+		{Status: params.ActionAborting},
+	}
+
+	for _, modelFlag := range s.modelFlags {
+		fakeClient := makeFakeClient(0, 5*time.Second, tags, results, params.ActionsByNames{}, "")
+		restore := s.patchAPIClient(fakeClient)
+		defer restore()
+
+		command, _ := action.NewCancelCommandForTest(s.store)
+		ctx, err := testing.RunCommand(c, command, modelFlag, "admin", "deadbeef", "cafebabe")
+		c.Assert(err, jc.ErrorIsNil)
+		c.Check(fakeClient.cancelledActions, jc.DeepEquals, params.Entities{
+			Entities: []params.Entity{
+				{Tag: "action-" + fakeid},
+				{Tag: "action-" + fakeid2},
+			},
+		})
+
+		buf, err := cmd.DefaultFormatters["yaml"](action.ActionResultsToMap(results))
+		c.Check(err, jc.ErrorIsNil)
+		c.Check(ctx.Stdout.(*bytes.Buffer).String(), gc.Equals, string(buf)+"\n")
+	}
+}
+
+func (s *CancelSuite) TestRunUnknownPrefix(c *gc.C) {
+	fakeClient := makeFakeClient(0, 5*time.Second, params.FindTagsResults{}, nil, params.ActionsByNames{}, "")
+	restore := s.patchAPIClient(fakeClient)
+	defer restore()
+
+	command, _ := action.NewCancelCommandForTest(s.store)
+	_, err := testing.RunCommand(c, command, "-m", "admin", "deadbeef")
+	c.Assert(err, gc.ErrorMatches, `actions for identifier "deadbeef" not found`)
+	c.Assert(fakeClient.cancelledActions.Entities, gc.HasLen, 0)
+}
+
+func (s *CancelSuite) TestRunAPIError(c *gc.C) {
+	tags := tagsForIdPrefix("deadbeef", "action-deadbeef-0000-4000-8000-feedfacebeef")
+	fakeClient := makeFakeClient(0, 5*time.Second, tags, nil, params.ActionsByNames{}, "boom")
+	restore := s.patchAPIClient(fakeClient)
+	defer restore()
+
+	command, _ := action.NewCancelCommandForTest(s.store)
+	_, err := testing.RunCommand(c, command, "-m", "admin", "deadbeef")
+	c.Assert(err, gc.ErrorMatches, "boom")
+}
@@ -4,6 +4,8 @@
 package action
 
 import (
+	"time"
+
 	"github.com/juju/cmd"
 	"github.com/juju/names"
 
@@ -15,12 +17,18 @@ import (
 var (
 	NewActionAPIClient = &newAPIClient
 	AddValueToMap      = addValueToMap
+	GetServiceUnits    = &getServiceUnits
+	PollInterval       = &pollInterval
 )
 
 type ShowOutputCommand struct {
 	*showOutputCommand
 }
 
+type CancelCommand struct {
+	*cancelCommand
+}
+
 type StatusCommand struct {
 	*statusCommand
 }
@@ -30,7 +38,34 @@ type RunCommand struct {
 }
 
 func (c *RunCommand) UnitTag() names.UnitTag {
-	return c.unitTag
+	if len(c.unitTags) == 0 {
+		return names.UnitTag{}
+	}
+	return c.unitTags[0]
+}
+
+func (c *RunCommand) UnitTags() []names.UnitTag {
+	return c.unitTags
+}
+
+func (c *RunCommand) ServiceName() string {
+	return c.serviceName
+}
+
+func (c *RunCommand) Timeout() time.Duration {
+	return c.timeout
+}
+
+func (c *RunCommand) MaxParallel() int {
+	return c.maxParallel
+}
+
+func (c *RunCommand) Batch() int {
+	return c.batch
+}
+
+func (c *RunCommand) ContinueOnError() bool {
+	return c.continueOnError
 }
 
 func (c *RunCommand) ActionName() string {
@@ -67,6 +102,12 @@ func NewShowOutputCommandForTest(store jujuclient.ClientStore) (cmd.Command, *Sh
 	return modelcmd.Wrap(c), &ShowOutputCommand{c}
 }
 
+func NewCancelCommandForTest(store jujuclient.ClientStore) (cmd.Command, *CancelCommand) {
+	c := &cancelCommand{}
+	c.SetClientStore(store)
+	return modelcmd.Wrap(c), &CancelCommand{c}
+}
+
 func NewStatusCommandForTest(store jujuclient.ClientStore) (cmd.Command, *StatusCommand) {
 	c := &statusCommand{}
 	c.SetClientStore(store)
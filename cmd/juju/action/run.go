@@ -6,7 +6,10 @@ package action
 import (
 	"fmt"
 	"regexp"
+	"sort"
 	"strings"
+	"text/tabwriter"
+	"time"
 
 	"github.com/juju/cmd"
 	"github.com/juju/errors"
@@ -29,18 +32,36 @@ func NewRunCommand() cmd.Command {
 // params
 type runCommand struct {
 	ActionCommandBase
-	unitTag      names.UnitTag
-	actionName   string
-	paramsYAML   cmd.FileVar
-	parseStrings bool
-	out          cmd.Output
-	args         [][]string
+	unitTags        []names.UnitTag
+	serviceName     string
+	actionName      string
+	paramsYAML      cmd.FileVar
+	parseStrings    bool
+	timeout         time.Duration
+	maxParallel     int
+	batch           int
+	continueOnError bool
+	out             cmd.Output
+	args            [][]string
 }
 
 const runDoc = `
-Queue an Action for execution on a given unit, with a given set of params.
+Queue an Action for execution on the given units, with a given set of params.
 Displays the ID of the Action for use with 'juju kill', 'juju status', etc.
 
+The Action may be run on one or more named units, or on every unit of a
+service by naming the service instead.
+
+If --timeout is given, the Action will be stopped and marked as failed if it
+runs for longer than the given duration once started.
+
+By default the Action is queued on every unit at once. With --max-parallel N
+it is rolled out gradually, keeping at most N Actions queued or running at a
+time; with --batch N it is run in waves of N units, each wave waiting for the
+previous one to finish. In either mode the command waits for the Actions to
+finish, stops queueing new ones after the first failure unless
+--continue-on-error is given, and prints a table of the results.
+
 Params are validated according to the charm for the unit's service.  The 
 valid params can be seen using "juju action defined <service> --schema".
 Params may be in a yaml file which is passed with the --params flag, or they
@@ -103,6 +124,15 @@ $ juju run-action sleeper/0 pause time=1000
 $ juju run-action sleeper/0 pause --string-args time=1000
 ...
 The value for the "time" param will be the string literal "1000".
+
+$ juju run-action mysql/0 mysql/1 backup --timeout 30m
+...
+
+$ juju run-action mysql backup --max-parallel 2 --continue-on-error
+UNIT     ID                                    STATUS     MESSAGE
+mysql/0  f47ac10b-58cc-4372-a567-0e02b2c3d479  completed
+mysql/1  3b241101-e2bb-4255-8caf-4136c566a962  completed
+...
 `
 
 // ActionNameRule describes the format an action name must match to be valid.
@@ -113,42 +143,71 @@ func (c *runCommand) SetFlags(f *gnuflag.FlagSet) {
 	c.out.AddFlags(f, "smart", cmd.DefaultFormatters)
 	f.Var(&c.paramsYAML, "params", "path to yaml-formatted params file")
 	f.BoolVar(&c.parseStrings, "string-args", false, "use raw string values of CLI args")
+	f.DurationVar(&c.timeout, "timeout", 0, "stop the action if it runs for longer than this (0 for no limit)")
+	f.IntVar(&c.maxParallel, "max-parallel", 0, "keep at most this many actions queued or running at once, and wait for them to finish")
+	f.IntVar(&c.batch, "batch", 0, "run the action on this many units at a time, and wait for each batch to finish")
+	f.BoolVar(&c.continueOnError, "continue-on-error", false, "keep queueing actions after one fails, with --max-parallel or --batch")
 }
 
 func (c *runCommand) Info() *cmd.Info {
 	return &cmd.Info{
 		Name:    "run-action",
-		Args:    "<unit> <action name> [key.key.key...=value]",
+		Args:    "(<unit> [<unit> ...] | <service>) <action name> [key.key.key...=value]",
 		Purpose: "queue an action for execution",
 		Doc:     runDoc,
 	}
 }
 
-// Init gets the unit tag, and checks for other correct args.
+// Init gets the unit tags or service name, and checks for other correct args.
 func (c *runCommand) Init(args []string) error {
+	if c.timeout < 0 {
+		return errors.New("--timeout must not be negative")
+	}
+	if c.maxParallel < 0 {
+		return errors.New("--max-parallel must not be negative")
+	}
+	if c.batch < 0 {
+		return errors.New("--batch must not be negative")
+	}
+	if c.maxParallel > 0 && c.batch > 0 {
+		return errors.New("cannot specify both --max-parallel and --batch")
+	}
+	if c.continueOnError && !c.batched() {
+		return errors.New("--continue-on-error requires --max-parallel or --batch")
+	}
 	switch len(args) {
 	case 0:
 		return errors.New("no unit specified")
 	case 1:
 		return errors.New("no action specified")
 	default:
-		// Grab and verify the unit and action names.
-		unitName := args[0]
-		if !names.IsValidUnit(unitName) {
-			return errors.Errorf("invalid unit name %q", unitName)
+		// Grab and verify the unit or service names, which are
+		// followed by the action name.
+		next := 0
+		for ; next < len(args) && names.IsValidUnit(args[next]); next++ {
+			c.unitTags = append(c.unitTags, names.NewUnitTag(args[next]))
+		}
+		if next == 0 {
+			if !names.IsValidService(args[0]) {
+				return errors.Errorf("invalid unit name %q", args[0])
+			}
+			c.serviceName = args[0]
+			next = 1
 		}
-		ActionName := args[1]
+		if next == len(args) {
+			return errors.New("no action specified")
+		}
+		ActionName := args[next]
 		if valid := ActionNameRule.MatchString(ActionName); !valid {
 			return fmt.Errorf("invalid action name %q", ActionName)
 		}
-		c.unitTag = names.NewUnitTag(unitName)
 		c.actionName = ActionName
-		if len(args) == 2 {
+		if len(args) == next+1 {
 			return nil
 		}
 		// Parse CLI key-value args if they exist.
 		c.args = make([][]string, 0)
-		for _, arg := range args[2:] {
+		for _, arg := range args[next+1:] {
 			thisArg := strings.SplitN(arg, "=", 2)
 			if len(thisArg) != 2 {
 				return fmt.Errorf("argument %q must be of the form key...=value", arg)
@@ -227,37 +286,258 @@ func (c *runCommand) Run(ctx *cmd.Context) error {
 		return errors.Errorf("params must be a map, got %T", typedConformantParams)
 	}
 
-	actionParam := params.Actions{
-		Actions: []params.Action{{
-			Receiver:   c.unitTag.String(),
-			Name:       c.actionName,
-			Parameters: actionParams,
-		}},
+	unitTags := c.unitTags
+	if c.serviceName != "" {
+		unitTags, err = getServiceUnits(&c.ActionCommandBase, c.serviceName)
+		if err != nil {
+			return errors.Annotatef(err, "cannot get units of service %q", c.serviceName)
+		}
+		if len(unitTags) == 0 {
+			return errors.Errorf("service %q has no units", c.serviceName)
+		}
 	}
 
-	results, err := api.Enqueue(actionParam)
+	if c.batched() {
+		return c.runBatched(ctx, api, unitTags, actionParams)
+	}
+
+	tags, errs, err := c.enqueue(api, unitTags, actionParams)
 	if err != nil {
 		return err
 	}
-	if len(results.Results) != 1 {
-		return errors.New("illegal number of results returned")
+	if len(unitTags) == 1 {
+		if errs[0] != nil {
+			return errs[0]
+		}
+		output := map[string]string{"Action queued with id": tags[0].Id()}
+		return c.out.Write(ctx, output)
+	}
+	output := make(map[string]string)
+	var failed int
+	for i, unitTag := range unitTags {
+		if errs[i] != nil {
+			fmt.Fprintf(ctx.Stderr, "unit %s: %v\n", unitTag.Id(), errs[i])
+			failed++
+			continue
+		}
+		output[unitTag.Id()] = tags[i].Id()
+	}
+	if len(output) > 0 {
+		if err := c.out.Write(ctx, output); err != nil {
+			return errors.Trace(err)
+		}
+	}
+	if failed > 0 {
+		return errors.Errorf("%d of %d actions failed to enqueue", failed, len(unitTags))
 	}
+	return nil
+}
 
-	result := results.Results[0]
+// batched reports whether the actions should be rolled out gradually.
+func (c *runCommand) batched() bool {
+	return c.maxParallel > 0 || c.batch > 0
+}
 
-	if result.Error != nil {
-		return result.Error
+// enqueue queues the action on each of the given units, and returns
+// the tags of the queued actions in the same order, along with the
+// error, if any, that prevented the action being queued on each unit.
+func (c *runCommand) enqueue(api APIClient, unitTags []names.UnitTag, actionParams map[string]interface{}) ([]names.ActionTag, []error, error) {
+	actions := make([]params.Action, len(unitTags))
+	for i, unitTag := range unitTags {
+		actions[i] = params.Action{
+			Receiver:   unitTag.String(),
+			Name:       c.actionName,
+			Parameters: actionParams,
+			Timeout:    c.timeout,
+		}
+	}
+	results, err := api.Enqueue(params.Actions{Actions: actions})
+	if err != nil {
+		return nil, nil, err
+	}
+	if len(results.Results) != len(unitTags) {
+		return nil, nil, errors.New("illegal number of results returned")
 	}
 
-	if result.Action == nil {
-		return errors.New("action failed to enqueue")
+	tags := make([]names.ActionTag, len(unitTags))
+	errs := make([]error, len(unitTags))
+	for i, result := range results.Results {
+		if result.Error != nil {
+			errs[i] = result.Error
+			continue
+		}
+		if result.Action == nil {
+			errs[i] = errors.New("action failed to enqueue")
+			continue
+		}
+		tag, err := names.ParseActionTag(result.Action.Tag)
+		if err != nil {
+			errs[i] = err
+			continue
+		}
+		tags[i] = tag
 	}
+	return tags, errs, nil
+}
 
-	tag, err := names.ParseActionTag(result.Action.Tag)
-	if err != nil {
-		return err
+// unitActionResult holds the outcome of running the action on one
+// unit in batched mode.
+type unitActionResult struct {
+	Unit    string `yaml:"unit" json:"unit"`
+	Id      string `yaml:"id,omitempty" json:"id,omitempty"`
+	Status  string `yaml:"status" json:"status"`
+	Message string `yaml:"message,omitempty" json:"message,omitempty"`
+}
+
+// actionSkipped is the status reported for units on which the action
+// was never queued because an earlier action failed.
+const actionSkipped = "skipped"
+
+// runBatched queues the action on the given units a few at a time,
+// waiting for the queued actions to finish before queueing more, and
+// prints the results once all of the actions have finished.
+func (c *runCommand) runBatched(ctx *cmd.Context, api APIClient, unitTags []names.UnitTag, actionParams map[string]interface{}) error {
+	results := make([]unitActionResult, len(unitTags))
+	for i, unitTag := range unitTags {
+		results[i] = unitActionResult{Unit: unitTag.Id(), Status: actionSkipped}
+	}
+	// running maps the tags of unfinished actions to the index of
+	// their units.
+	running := make(map[string]int)
+	next := 0
+	failed := false
+	for {
+		if !failed || c.continueOnError {
+			count := c.maxParallel - len(running)
+			if c.batch > 0 {
+				count = 0
+				if len(running) == 0 {
+					count = c.batch
+				}
+			}
+			if count > len(unitTags)-next {
+				count = len(unitTags) - next
+			}
+			if count > 0 {
+				tags, errs, err := c.enqueue(api, unitTags[next:next+count], actionParams)
+				if err != nil {
+					return errors.Trace(err)
+				}
+				for i, tag := range tags {
+					if errs[i] != nil {
+						results[next+i].Status = params.ActionFailed
+						results[next+i].Message = errs[i].Error()
+						failed = true
+						continue
+					}
+					results[next+i].Id = tag.Id()
+					results[next+i].Status = params.ActionPending
+					running[tag.String()] = next + i
+				}
+				next += count
+			}
+		}
+		if len(running) == 0 {
+			if next < len(unitTags) && (!failed || c.continueOnError) {
+				// Every action in the last wave failed to
+				// enqueue; move on to the next wave.
+				continue
+			}
+			break
+		}
+
+		<-time.After(pollInterval)
+		entities := params.Entities{}
+		for tag := range running {
+			entities.Entities = append(entities.Entities, params.Entity{Tag: tag})
+		}
+		actions, err := api.Actions(entities)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		for i, result := range actions.Results {
+			if result.Error != nil {
+				return errors.Trace(result.Error)
+			}
+			index := running[entities.Entities[i].Tag]
+			results[index].Status = result.Status
+			results[index].Message = result.Message
+			switch result.Status {
+			case params.ActionPending, params.ActionRunning, params.ActionAborting:
+				continue
+			case params.ActionCompleted:
+			default:
+				failed = true
+			}
+			delete(running, entities.Entities[i].Tag)
+		}
+	}
+
+	if err := c.writeBatchResults(ctx, results); err != nil {
+		return errors.Trace(err)
+	}
+	var incomplete int
+	for _, result := range results {
+		if result.Status != params.ActionCompleted {
+			incomplete++
+		}
+	}
+	if incomplete > 0 {
+		return errors.Errorf("%d of %d actions did not complete", incomplete, len(results))
+	}
+	return nil
+}
+
+// writeBatchResults writes the results of a batched run, as a table
+// unless another output format was requested.
+func (c *runCommand) writeBatchResults(ctx *cmd.Context, results []unitActionResult) error {
+	if c.out.Name() != "smart" {
+		return c.out.Write(ctx, results)
+	}
+	const (
+		// To format things into columns.
+		minwidth = 0
+		tabwidth = 1
+		padding  = 2
+		padchar  = ' '
+		flags    = 0
+	)
+	tw := tabwriter.NewWriter(ctx.Stdout, minwidth, tabwidth, padding, padchar, flags)
+	fmt.Fprintf(tw, "UNIT\tID\tSTATUS\tMESSAGE\n")
+	for _, result := range results {
+		fmt.Fprintf(tw, "%s\t%s\t%s\t%s\n", result.Unit, result.Id, result.Status, result.Message)
 	}
+	return tw.Flush()
+}
+
+// pollInterval is how often the status of running actions is checked
+// in batched mode.
+var pollInterval = 2 * time.Second
 
-	output := map[string]string{"Action queued with id": tag.Id()}
-	return c.out.Write(ctx, output)
+// getServiceUnits returns the tags of the units of the named service,
+// sorted by name.
+var getServiceUnits = func(c *ActionCommandBase, serviceName string) ([]names.UnitTag, error) {
+	root, err := c.NewAPIRoot()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	defer root.Close()
+	status, err := root.Client().Status([]string{serviceName})
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	service, ok := status.Services[serviceName]
+	if !ok {
+		return nil, errors.NotFoundf("service %q", serviceName)
+	}
+	unitNames := make([]string, 0, len(service.Units))
+	for unitName := range service.Units {
+		unitNames = append(unitNames, unitName)
+	}
+	sort.Strings(unitNames)
+	unitTags := make([]names.UnitTag, len(unitNames))
+	for i, unitName := range unitNames {
+		unitTags[i] = names.NewUnitTag(unitName)
+	}
+	return unitTags, nil
 }
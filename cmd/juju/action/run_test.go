@@ -6,9 +6,12 @@ package action_test
 import (
 	"bytes"
 	"errors"
+	"fmt"
 	"strings"
+	"time"
 	"unicode/utf8"
 
+	"github.com/juju/cmd"
 	"github.com/juju/names"
 	jc "github.com/juju/testing/checkers"
 	"github.com/juju/utils"
@@ -399,3 +402,276 @@ func (s *RunSuite) TestRun(c *gc.C) {
 		}
 	}
 }
+
+func (s *RunSuite) TestInitReceiversAndFlags(c *gc.C) {
+	tests := []struct {
+		should            string
+		args              []string
+		expectUnits       []names.UnitTag
+		expectService     string
+		expectAction      string
+		expectTimeout     time.Duration
+		expectMaxParallel int
+		expectBatch       int
+		expectContinue    bool
+		expectError       string
+	}{{
+		should:       "accept several units",
+		args:         []string{"mysql/0", "mysql/1", "backup"},
+		expectUnits:  []names.UnitTag{names.NewUnitTag("mysql/0"), names.NewUnitTag("mysql/1")},
+		expectAction: "backup",
+	}, {
+		should:        "accept a service",
+		args:          []string{validServiceId, "backup"},
+		expectService: validServiceId,
+		expectAction:  "backup",
+	}, {
+		should:      "fail with a service and no action",
+		args:        []string{validServiceId},
+		expectError: "no action specified",
+	}, {
+		should:      "fail with units and no action",
+		args:        []string{"mysql/0", "mysql/1"},
+		expectError: "no action specified",
+	}, {
+		should:        "handle --timeout",
+		args:          []string{validUnitId, "backup", "--timeout", "5m"},
+		expectUnits:   []names.UnitTag{names.NewUnitTag(validUnitId)},
+		expectAction:  "backup",
+		expectTimeout: 5 * time.Minute,
+	}, {
+		should:            "handle --max-parallel and --continue-on-error",
+		args:              []string{validServiceId, "backup", "--max-parallel", "2", "--continue-on-error"},
+		expectService:     validServiceId,
+		expectAction:      "backup",
+		expectMaxParallel: 2,
+		expectContinue:    true,
+	}, {
+		should:        "handle --batch",
+		args:          []string{validServiceId, "backup", "--batch", "3"},
+		expectService: validServiceId,
+		expectAction:  "backup",
+		expectBatch:   3,
+	}, {
+		should:      "fail with negative --timeout",
+		args:        []string{validUnitId, "backup", "--timeout", "-1s"},
+		expectError: "--timeout must not be negative",
+	}, {
+		should:      "fail with negative --max-parallel",
+		args:        []string{validUnitId, "backup", "--max-parallel", "-1"},
+		expectError: "--max-parallel must not be negative",
+	}, {
+		should:      "fail with negative --batch",
+		args:        []string{validUnitId, "backup", "--batch", "-1"},
+		expectError: "--batch must not be negative",
+	}, {
+		should:      "fail with --max-parallel and --batch",
+		args:        []string{validUnitId, "backup", "--max-parallel", "1", "--batch", "1"},
+		expectError: "cannot specify both --max-parallel and --batch",
+	}, {
+		should:      "fail with --continue-on-error alone",
+		args:        []string{validUnitId, "backup", "--continue-on-error"},
+		expectError: "--continue-on-error requires --max-parallel or --batch",
+	}}
+
+	for i, t := range tests {
+		wrappedCommand, command := action.NewRunCommandForTest(s.store)
+		c.Logf("test %d: should %s:\n$ juju run-action %s\n", i,
+			t.should, strings.Join(t.args, " "))
+		args := append([]string{"-m", "admin"}, t.args...)
+		err := testing.InitCommand(wrappedCommand, args)
+		if t.expectError != "" {
+			c.Check(err, gc.ErrorMatches, t.expectError)
+			continue
+		}
+		c.Assert(err, jc.ErrorIsNil)
+		c.Check(command.UnitTags(), jc.DeepEquals, t.expectUnits)
+		c.Check(command.ServiceName(), gc.Equals, t.expectService)
+		c.Check(command.ActionName(), gc.Equals, t.expectAction)
+		c.Check(command.Timeout(), gc.Equals, t.expectTimeout)
+		c.Check(command.MaxParallel(), gc.Equals, t.expectMaxParallel)
+		c.Check(command.Batch(), gc.Equals, t.expectBatch)
+		c.Check(command.ContinueOnError(), gc.Equals, t.expectContinue)
+	}
+}
+
+func (s *RunSuite) TestRunMultipleUnits(c *gc.C) {
+	client := newBatchAPIClient(nil)
+	s.PatchValue(action.NewActionAPIClient, func(*action.ActionCommandBase) (action.APIClient, error) {
+		return client, nil
+	})
+
+	wrappedCommand, _ := action.NewRunCommandForTest(s.store)
+	ctx, err := testing.RunCommand(c, wrappedCommand,
+		"-m", "admin", "mysql/0", "mysql/1", "backup", "--timeout", "10m",
+	)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(client.waves, jc.DeepEquals, [][]string{{"mysql/0", "mysql/1"}})
+	for _, enqueued := range client.enqueuedActions.Actions {
+		c.Check(enqueued.Timeout, gc.Equals, 10*time.Minute)
+	}
+	c.Assert(testing.Stdout(ctx), gc.Equals, ""+
+		"mysql/0: 00000001-0000-4000-8000-000000000000\n"+
+		"mysql/1: 00000002-0000-4000-8000-000000000000\n")
+}
+
+func (s *RunSuite) TestRunMultipleUnitsEnqueueErrors(c *gc.C) {
+	client := newBatchAPIClient(nil)
+	client.rejected = map[string]string{"mysql/1": "no such unit"}
+	s.PatchValue(action.NewActionAPIClient, func(*action.ActionCommandBase) (action.APIClient, error) {
+		return client, nil
+	})
+
+	wrappedCommand, _ := action.NewRunCommandForTest(s.store)
+	ctx, err := testing.RunCommand(c, wrappedCommand,
+		"-m", "admin", "mysql/0", "mysql/1", "mysql/2", "backup",
+	)
+	c.Assert(err, gc.ErrorMatches, "1 of 3 actions failed to enqueue")
+	c.Assert(client.waves, jc.DeepEquals, [][]string{{"mysql/0", "mysql/1", "mysql/2"}})
+	c.Assert(testing.Stdout(ctx), gc.Equals, ""+
+		"mysql/0: 00000001-0000-4000-8000-000000000000\n"+
+		"mysql/2: 00000002-0000-4000-8000-000000000000\n")
+	c.Assert(testing.Stderr(ctx), gc.Equals, "unit mysql/1: no such unit\n")
+}
+
+func (s *RunSuite) TestRunServiceMaxParallel(c *gc.C) {
+	client := s.patchBatchAPIClient(nil)
+	ctx, err := s.runBatched(c, "--max-parallel", "2")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(client.waves, jc.DeepEquals, [][]string{{"mysql/0", "mysql/1"}, {"mysql/2"}})
+	c.Assert(client.maxRunning, gc.Equals, 2)
+	c.Assert(testing.Stdout(ctx), gc.Equals, ""+
+		"UNIT     ID                                    STATUS     MESSAGE\n"+
+		"mysql/0  00000001-0000-4000-8000-000000000000  completed  \n"+
+		"mysql/1  00000002-0000-4000-8000-000000000000  completed  \n"+
+		"mysql/2  00000003-0000-4000-8000-000000000000  completed  \n")
+}
+
+func (s *RunSuite) TestRunBatchStopsOnFailure(c *gc.C) {
+	client := s.patchBatchAPIClient(map[string]string{"mysql/1": params.ActionFailed})
+	ctx, err := s.runBatched(c, "--batch", "1")
+	c.Assert(err, gc.ErrorMatches, "2 of 3 actions did not complete")
+	c.Assert(client.waves, jc.DeepEquals, [][]string{{"mysql/0"}, {"mysql/1"}})
+	c.Assert(client.maxRunning, gc.Equals, 1)
+	c.Assert(testing.Stdout(ctx), gc.Equals, ""+
+		"UNIT     ID                                    STATUS     MESSAGE\n"+
+		"mysql/0  00000001-0000-4000-8000-000000000000  completed  \n"+
+		"mysql/1  00000002-0000-4000-8000-000000000000  failed     boom\n"+
+		"mysql/2                                        skipped    \n")
+}
+
+func (s *RunSuite) TestRunBatchContinueOnError(c *gc.C) {
+	client := s.patchBatchAPIClient(map[string]string{"mysql/0": params.ActionFailed})
+	ctx, err := s.runBatched(c, "--batch", "2", "--continue-on-error", "--format", "yaml")
+	c.Assert(err, gc.ErrorMatches, "1 of 3 actions did not complete")
+	c.Assert(client.waves, jc.DeepEquals, [][]string{{"mysql/0", "mysql/1"}, {"mysql/2"}})
+	c.Assert(testing.Stdout(ctx), gc.Equals, ""+
+		"- unit: mysql/0\n"+
+		"  id: 00000001-0000-4000-8000-000000000000\n"+
+		"  status: failed\n"+
+		"  message: boom\n"+
+		"- unit: mysql/1\n"+
+		"  id: 00000002-0000-4000-8000-000000000000\n"+
+		"  status: completed\n"+
+		"- unit: mysql/2\n"+
+		"  id: 00000003-0000-4000-8000-000000000000\n"+
+		"  status: completed\n")
+}
+
+func (s *RunSuite) TestRunBatchContinuesPastEnqueueErrors(c *gc.C) {
+	client := s.patchBatchAPIClient(nil)
+	client.rejected = map[string]string{"mysql/0": "no such unit"}
+	ctx, err := s.runBatched(c, "--batch", "1", "--continue-on-error")
+	c.Assert(err, gc.ErrorMatches, "1 of 3 actions did not complete")
+	c.Assert(client.waves, jc.DeepEquals, [][]string{{"mysql/0"}, {"mysql/1"}, {"mysql/2"}})
+	c.Assert(testing.Stdout(ctx), gc.Equals, ""+
+		"UNIT     ID                                    STATUS     MESSAGE\n"+
+		"mysql/0                                        failed     no such unit\n"+
+		"mysql/1  00000001-0000-4000-8000-000000000000  completed  \n"+
+		"mysql/2  00000002-0000-4000-8000-000000000000  completed  \n")
+}
+
+func (s *RunSuite) patchBatchAPIClient(statuses map[string]string) *batchAPIClient {
+	client := newBatchAPIClient(statuses)
+	s.PatchValue(action.NewActionAPIClient, func(*action.ActionCommandBase) (action.APIClient, error) {
+		return client, nil
+	})
+	s.PatchValue(action.GetServiceUnits, func(_ *action.ActionCommandBase, service string) ([]names.UnitTag, error) {
+		return []names.UnitTag{
+			names.NewUnitTag(service + "/0"),
+			names.NewUnitTag(service + "/1"),
+			names.NewUnitTag(service + "/2"),
+		}, nil
+	})
+	s.PatchValue(action.PollInterval, time.Duration(0))
+	return client
+}
+
+func (s *RunSuite) runBatched(c *gc.C, args ...string) (*cmd.Context, error) {
+	wrappedCommand, _ := action.NewRunCommandForTest(s.store)
+	args = append([]string{"-m", "admin", validServiceId, "backup"}, args...)
+	return testing.RunCommand(c, wrappedCommand, args...)
+}
+
+// batchAPIClient is a fake APIClient whose queued actions finish, with
+// the status given for their unit, the first time they are polled.
+// Actions for units in rejected fail to enqueue with the given message.
+type batchAPIClient struct {
+	*fakeAPIClient
+	statuses   map[string]string
+	rejected   map[string]string
+	receivers  map[string]string
+	waves      [][]string
+	running    int
+	maxRunning int
+}
+
+func newBatchAPIClient(statuses map[string]string) *batchAPIClient {
+	return &batchAPIClient{
+		fakeAPIClient: &fakeAPIClient{},
+		statuses:      statuses,
+		receivers:     make(map[string]string),
+	}
+}
+
+func (c *batchAPIClient) Enqueue(args params.Actions) (params.ActionResults, error) {
+	c.enqueuedActions.Actions = append(c.enqueuedActions.Actions, args.Actions...)
+	var wave []string
+	results := make([]params.ActionResult, len(args.Actions))
+	for i, arg := range args.Actions {
+		unitTag, err := names.ParseUnitTag(arg.Receiver)
+		if err != nil {
+			return params.ActionResults{}, err
+		}
+		tag := names.NewActionTag(fmt.Sprintf("%08x-0000-4000-8000-000000000000", len(c.receivers)+1))
+		wave = append(wave, unitTag.Id())
+		if message, ok := c.rejected[unitTag.Id()]; ok {
+			results[i].Error = &params.Error{Message: message}
+			continue
+		}
+		c.receivers[tag.String()] = unitTag.Id()
+		results[i].Action = &params.Action{Tag: tag.String()}
+		c.running++
+	}
+	c.waves = append(c.waves, wave)
+	if c.running > c.maxRunning {
+		c.maxRunning = c.running
+	}
+	return params.ActionResults{Results: results}, nil
+}
+
+func (c *batchAPIClient) Actions(args params.Entities) (params.ActionResults, error) {
+	results := make([]params.ActionResult, len(args.Entities))
+	for i, entity := range args.Entities {
+		status, ok := c.statuses[c.receivers[entity.Tag]]
+		if !ok {
+			status = params.ActionCompleted
+		}
+		results[i].Status = status
+		if status == params.ActionFailed {
+			results[i].Message = "boom"
+		}
+	}
+	c.running -= len(args.Entities)
+	return params.ActionResults{Results: results}, nil
+}
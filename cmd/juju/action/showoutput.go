@@ -142,7 +142,7 @@ func timerLoop(api APIClient, requestedId string, wait, tick *time.Timer) (param
 		// Whether or not we're waiting for a result, if a completed
 		// result arrives, we're done.
 		switch result.Status {
-		case params.ActionRunning, params.ActionPending:
+		case params.ActionRunning, params.ActionPending, params.ActionAborting:
 		default:
 			return result, nil
 		}
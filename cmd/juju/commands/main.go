@@ -306,6 +306,7 @@ func registerCommands(r commandRegistry, ctx *cmd.Context) {
 
 	// Manage and control actions
 	r.Register(action.NewStatusCommand())
+	r.Register(action.NewCancelCommand())
 	r.Register(action.NewRunCommand())
 	r.Register(action.NewShowOutputCommand())
 	r.Register(action.NewListCommand())
@@ -316,6 +317,7 @@ func registerCommands(r commandRegistry, ctx *cmd.Context) {
 	// Manage and control services
 	r.Register(service.NewAddUnitCommand())
 	r.Register(service.NewGetCommand())
+	r.Register(service.NewExportBundleCommand())
 	r.Register(service.NewSetCommand())
 	r.Register(service.NewDeployCommand())
 	r.Register(service.NewExposeCommand())
@@ -329,9 +331,16 @@ func registerCommands(r commandRegistry, ctx *cmd.Context) {
 
 	// Manage storage
 	r.Register(storage.NewAddCommand())
+	r.Register(storage.NewAttachCommand())
+	r.Register(storage.NewCreateSnapshotCommand())
+	r.Register(storage.NewDetachCommand())
+	r.Register(storage.NewImportFilesystemCommand())
 	r.Register(storage.NewListCommand())
+	r.Register(storage.NewListSnapshotsCommand())
 	r.Register(storage.NewPoolCreateCommand())
 	r.Register(storage.NewPoolListCommand())
+	r.Register(storage.NewRemoveSnapshotCommand())
+	r.Register(storage.NewResizeCommand())
 	r.Register(storage.NewShowCommand())
 
 	// Manage spaces
@@ -353,6 +362,7 @@ func registerCommands(r commandRegistry, ctx *cmd.Context) {
 
 	// Manage controllers
 	r.Register(controller.NewAddModelCommand())
+	r.Register(controller.NewAuditLogCommand())
 	r.Register(controller.NewDestroyCommand())
 	r.Register(controller.NewListModelsCommand())
 	r.Register(controller.NewKillCommand())
@@ -369,17 +369,21 @@ var commandNames = []string{
 	"add-user",
 	"agree",
 	"allocate",
+	"attach-storage",
+	"audit-log",
 	"autoload-credentials",
 	"backups",
 	"block",
 	"bootstrap",
 	"cached-images",
+	"cancel-action",
 	"change-user-password",
 	"charm",
 	"collect-metrics",
 	"create-backup",
 	"create-budget",
 	"create-storage-pool",
+	"create-storage-snapshot",
 	"debug-hooks",
 	"debug-log",
 	"debug-metrics",
@@ -389,10 +393,12 @@ var commandNames = []string{
 	"destroy-relation",
 	"destroy-service",
 	"destroy-unit",
+	"detach-storage",
 	"disable-user",
 	"download-backup",
 	"enable-ha",
 	"enable-user",
+	"export-bundle",
 	"expose",
 	"get-config",
 	"get-configs",
@@ -403,6 +409,7 @@ var commandNames = []string{
 	"gui",
 	"help",
 	"help-tool",
+	"import-filesystem",
 	"import-ssh-key",
 	"import-ssh-keys",
 	"kill-controller",
@@ -425,6 +432,7 @@ var commandNames = []string{
 	"list-spaces",
 	"list-storage",
 	"list-storage-pools",
+	"list-storage-snapshots",
 	"list-subnets",
 	"list-users",
 	"login",
@@ -443,7 +451,9 @@ var commandNames = []string{
 	"remove-service",  // alias for destroy-service
 	"remove-ssh-key",
 	"remove-ssh-keys",
+	"remove-storage-snapshot",
 	"remove-unit", // alias for destroy-unit
+	"resize-storage",
 	"resolved",
 	"restore-backup",
 	"retry-provisioning",
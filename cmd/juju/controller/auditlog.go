@@ -0,0 +1,234 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package controller
+
+import (
+	"bytes"
+	"fmt"
+	"text/tabwriter"
+	"time"
+
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	"github.com/juju/names"
+	"launchpad.net/gnuflag"
+
+	"github.com/juju/juju/api/auditlog"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/cmd/modelcmd"
+)
+
+// NewAuditLogCommand returns a command to show the controller's
+// audit log.
+func NewAuditLogCommand() cmd.Command {
+	return modelcmd.WrapController(&auditLogCommand{})
+}
+
+// auditLogCommand shows the API calls recorded in the controller's
+// audit log.
+type auditLogCommand struct {
+	modelcmd.ControllerCommandBase
+	out cmd.Output
+	api auditLogAPI
+
+	user   string
+	model  string
+	method string
+	after  string
+	before string
+	limit  int
+}
+
+const auditLogDoc = `
+Show the record of API calls that changed the controller or its models.
+
+Only controller administrators may view the audit log. Entries are shown
+oldest first; by default the 100 most recent entries that match the
+given filters are shown.
+
+Times given to --after and --before are in RFC3339 format
+(e.g. 2016-06-30T12:00:00Z), or a date (e.g. 2016-06-30) which is
+taken to be midnight UTC.
+
+Examples:
+
+    juju audit-log
+    juju audit-log --user bob --model mymodel
+    juju audit-log --method Service.Deploy --after 2016-06-30
+    juju audit-log --limit 0 --format yaml
+`
+
+// auditLogAPI defines the methods on the audit log API endpoint
+// that the audit-log command calls.
+type auditLogAPI interface {
+	Close() error
+	Entries(params.AuditLogFilter) ([]params.AuditLogEntry, error)
+}
+
+// Info implements Command.Info.
+func (c *auditLogCommand) Info() *cmd.Info {
+	return &cmd.Info{
+		Name:    "audit-log",
+		Purpose: "show API calls recorded in the controller's audit log",
+		Doc:     auditLogDoc,
+	}
+}
+
+// SetFlags implements Command.SetFlags.
+func (c *auditLogCommand) SetFlags(f *gnuflag.FlagSet) {
+	f.StringVar(&c.user, "user", "", "only show calls made by this user")
+	f.StringVar(&c.model, "m", "", "only show calls made on this model")
+	f.StringVar(&c.model, "model", "", "")
+	f.StringVar(&c.method, "method", "", `only show calls to this method ("Method" or "Facade.Method")`)
+	f.StringVar(&c.after, "after", "", "only show calls made at or after this time")
+	f.StringVar(&c.before, "before", "", "only show calls made at or before this time")
+	f.IntVar(&c.limit, "limit", 100, "show at most this many entries (0 for no limit)")
+	c.out.AddFlags(f, "tabular", map[string]cmd.Formatter{
+		"yaml":    cmd.FormatYaml,
+		"json":    cmd.FormatJson,
+		"tabular": formatAuditLogTabular,
+	})
+}
+
+// Init implements Command.Init.
+func (c *auditLogCommand) Init(args []string) error {
+	if c.user != "" && !names.IsValidUser(c.user) {
+		return errors.NotValidf("user name %q", c.user)
+	}
+	if _, err := parseAuditLogTime(c.after); err != nil {
+		return errors.Annotate(err, "invalid --after")
+	}
+	if _, err := parseAuditLogTime(c.before); err != nil {
+		return errors.Annotate(err, "invalid --before")
+	}
+	if c.limit < 0 {
+		return errors.New("--limit must not be negative")
+	}
+	return cmd.CheckEmpty(args)
+}
+
+func (c *auditLogCommand) getAPI() (auditLogAPI, error) {
+	if c.api != nil {
+		return c.api, nil
+	}
+	root, err := c.NewAPIRoot()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return auditlog.NewClient(root), nil
+}
+
+// Run implements Command.Run.
+func (c *auditLogCommand) Run(ctx *cmd.Context) error {
+	filter := params.AuditLogFilter{
+		Method: c.method,
+		Limit:  c.limit,
+	}
+	if c.user != "" {
+		filter.UserTag = names.NewUserTag(c.user).String()
+	}
+	if c.model != "" {
+		uuids, err := c.ModelUUIDs([]string{c.model})
+		if err != nil {
+			return errors.Trace(err)
+		}
+		filter.ModelUUID = uuids[0]
+	}
+	// The times have been validated in Init.
+	filter.After, _ = parseAuditLogTime(c.after)
+	filter.Before, _ = parseAuditLogTime(c.before)
+
+	api, err := c.getAPI()
+	if err != nil {
+		return errors.Annotate(err, "cannot connect to the API")
+	}
+	defer api.Close()
+
+	entries, err := api.Entries(filter)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	out := make([]auditLogEntry, len(entries))
+	for i, entry := range entries {
+		out[i] = auditLogEntry{
+			Timestamp: entry.Timestamp.UTC().Format(time.RFC3339),
+			User:      userFromTag(entry.UserTag),
+			ModelUUID: entry.ModelUUID,
+			Facade:    entry.Facade,
+			Version:   entry.Version,
+			Method:    entry.Method,
+			Args:      entry.Args,
+			Error:     entry.Error,
+			ErrorCode: entry.ErrorCode,
+		}
+	}
+	return c.out.Write(ctx, out)
+}
+
+// auditLogEntry is the serialised form of an audit log entry.
+type auditLogEntry struct {
+	Timestamp string `yaml:"timestamp" json:"timestamp"`
+	User      string `yaml:"user" json:"user"`
+	ModelUUID string `yaml:"model-uuid" json:"model-uuid"`
+	Facade    string `yaml:"facade" json:"facade"`
+	Version   int    `yaml:"version" json:"version"`
+	Method    string `yaml:"method" json:"method"`
+	Args      string `yaml:"args,omitempty" json:"args,omitempty"`
+	Error     string `yaml:"error,omitempty" json:"error,omitempty"`
+	ErrorCode string `yaml:"error-code,omitempty" json:"error-code,omitempty"`
+}
+
+// parseAuditLogTime parses a time given on the command line. The
+// empty string yields the zero time, which does not restrict the
+// entries returned.
+func parseAuditLogTime(value string) (time.Time, error) {
+	if value == "" {
+		return time.Time{}, nil
+	}
+	if t, err := time.Parse(time.RFC3339, value); err == nil {
+		return t, nil
+	}
+	t, err := time.Parse("2006-01-02", value)
+	if err != nil {
+		return time.Time{}, errors.NotValidf("time %q", value)
+	}
+	return t, nil
+}
+
+// userFromTag returns the user name for the given tag string, or the
+// string itself if it is not a valid user tag.
+func userFromTag(tag string) string {
+	userTag, err := names.ParseUserTag(tag)
+	if err != nil {
+		return tag
+	}
+	return userTag.Canonical()
+}
+
+func formatAuditLogTabular(value interface{}) ([]byte, error) {
+	entries, ok := value.([]auditLogEntry)
+	if !ok {
+		return nil, errors.Errorf("expected value of type %T, got %T", entries, value)
+	}
+
+	var out bytes.Buffer
+	const (
+		// To format things into columns.
+		minwidth = 0
+		tabwidth = 1
+		padding  = 2
+		padchar  = ' '
+		flags    = 0
+	)
+	tw := tabwriter.NewWriter(&out, minwidth, tabwidth, padding, padchar, flags)
+	fmt.Fprintf(tw, "TIME\tUSER\tMODEL UUID\tMETHOD\tERROR\n")
+	for _, entry := range entries {
+		fmt.Fprintf(tw, "%s\t%s\t%s\t%s.%s\t%s\n",
+			entry.Timestamp, entry.User, entry.ModelUUID,
+			entry.Facade, entry.Method, entry.Error,
+		)
+	}
+	tw.Flush()
+	return out.Bytes(), nil
+}
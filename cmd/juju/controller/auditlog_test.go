@@ -0,0 +1,175 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package controller_test
+
+import (
+	"time"
+
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/cmd/juju/controller"
+	"github.com/juju/juju/jujuclient"
+	"github.com/juju/juju/jujuclient/jujuclienttesting"
+	"github.com/juju/juju/testing"
+)
+
+type AuditLogSuite struct {
+	testing.FakeJujuXDGDataHomeSuite
+	api   *fakeAuditLogAPI
+	store *jujuclienttesting.MemStore
+}
+
+var _ = gc.Suite(&AuditLogSuite{})
+
+type fakeAuditLogAPI struct {
+	filter  params.AuditLogFilter
+	entries []params.AuditLogEntry
+	err     error
+}
+
+func (f *fakeAuditLogAPI) Close() error { return nil }
+
+func (f *fakeAuditLogAPI) Entries(filter params.AuditLogFilter) ([]params.AuditLogEntry, error) {
+	f.filter = filter
+	return f.entries, f.err
+}
+
+func (s *AuditLogSuite) SetUpTest(c *gc.C) {
+	s.FakeJujuXDGDataHomeSuite.SetUpTest(c)
+	s.api = &fakeAuditLogAPI{
+		entries: []params.AuditLogEntry{{
+			Timestamp: time.Date(2016, 6, 30, 12, 0, 0, 0, time.UTC),
+			UserTag:   "user-bob@local",
+			ModelUUID: "model-uuid",
+			Facade:    "Service",
+			Version:   1,
+			Method:    "Deploy",
+			Args:      `{"services":[]}`,
+		}, {
+			Timestamp: time.Date(2016, 6, 30, 12, 5, 0, 0, time.UTC),
+			UserTag:   "user-bob@local",
+			ModelUUID: "model-uuid",
+			Facade:    "Service",
+			Version:   1,
+			Method:    "Destroy",
+			Error:     `service "foo" not found`,
+			ErrorCode: "not found",
+		}},
+	}
+	s.store = jujuclienttesting.NewMemStore()
+	s.store.Controllers["dummysys"] = jujuclient.ControllerDetails{}
+	s.store.Accounts["dummysys"] = &jujuclient.ControllerAccounts{
+		Accounts: map[string]jujuclient.AccountDetails{
+			"admin@local": {User: "admin@local"},
+		},
+		CurrentAccount: "admin@local",
+	}
+	s.store.Models["dummysys"] = jujuclient.ControllerAccountModels{
+		AccountModels: map[string]*jujuclient.AccountModels{
+			"admin@local": {
+				Models: map[string]jujuclient.ModelDetails{
+					"mymodel": {ModelUUID: "mymodel-uuid"},
+				},
+			},
+		},
+	}
+}
+
+func (s *AuditLogSuite) run(c *gc.C, args ...string) (*cmd.Context, error) {
+	command := controller.NewAuditLogCommandForTest(s.api, s.store)
+	args = append(args, "-c", "dummysys")
+	return testing.RunCommand(c, command, args...)
+}
+
+func (s *AuditLogSuite) TestDefaultFilter(c *gc.C) {
+	_, err := s.run(c)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.api.filter, jc.DeepEquals, params.AuditLogFilter{Limit: 100})
+}
+
+func (s *AuditLogSuite) TestFilter(c *gc.C) {
+	_, err := s.run(c,
+		"--user", "bob",
+		"--model", "mymodel",
+		"--method", "Service.Deploy",
+		"--after", "2016-06-30",
+		"--before", "2016-06-30T13:00:00Z",
+		"--limit", "0",
+	)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.api.filter, jc.DeepEquals, params.AuditLogFilter{
+		UserTag:   "user-bob",
+		ModelUUID: "mymodel-uuid",
+		Method:    "Service.Deploy",
+		After:     time.Date(2016, 6, 30, 0, 0, 0, 0, time.UTC),
+		Before:    time.Date(2016, 6, 30, 13, 0, 0, 0, time.UTC),
+	})
+}
+
+func (s *AuditLogSuite) TestInvalidArgs(c *gc.C) {
+	for i, test := range []struct {
+		args []string
+		err  string
+	}{{
+		args: []string{"--user", "not/valid"},
+		err:  `user name "not/valid" not valid`,
+	}, {
+		args: []string{"--after", "yesterday"},
+		err:  `invalid --after: time "yesterday" not valid`,
+	}, {
+		args: []string{"--before", "tomorrow"},
+		err:  `invalid --before: time "tomorrow" not valid`,
+	}, {
+		args: []string{"--limit", "-1"},
+		err:  `--limit must not be negative`,
+	}, {
+		args: []string{"extra"},
+		err:  `unrecognized args: \["extra"\]`,
+	}} {
+		c.Logf("test %d: %v", i, test.args)
+		_, err := s.run(c, test.args...)
+		c.Check(err, gc.ErrorMatches, test.err)
+	}
+}
+
+func (s *AuditLogSuite) TestAPIError(c *gc.C) {
+	s.api.err = errors.New("permission denied")
+	_, err := s.run(c)
+	c.Assert(err, gc.ErrorMatches, "permission denied")
+}
+
+func (s *AuditLogSuite) TestTabular(c *gc.C) {
+	ctx, err := s.run(c)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(testing.Stdout(ctx), gc.Equals, ""+
+		"TIME                  USER       MODEL UUID  METHOD           ERROR\n"+
+		"2016-06-30T12:00:00Z  bob@local  model-uuid  Service.Deploy   \n"+
+		"2016-06-30T12:05:00Z  bob@local  model-uuid  Service.Destroy  service \"foo\" not found\n"+
+		"\n")
+}
+
+func (s *AuditLogSuite) TestYAML(c *gc.C) {
+	ctx, err := s.run(c, "--format", "yaml")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(testing.Stdout(ctx), gc.Equals, ""+
+		"- timestamp: \"2016-06-30T12:00:00Z\"\n"+
+		"  user: bob@local\n"+
+		"  model-uuid: model-uuid\n"+
+		"  facade: Service\n"+
+		"  version: 1\n"+
+		"  method: Deploy\n"+
+		"  args: '{\"services\":[]}'\n"+
+		"- timestamp: \"2016-06-30T12:05:00Z\"\n"+
+		"  user: bob@local\n"+
+		"  model-uuid: model-uuid\n"+
+		"  facade: Service\n"+
+		"  version: 1\n"+
+		"  method: Destroy\n"+
+		"  error: service \"foo\" not found\n"+
+		"  error-code: not found\n")
+}
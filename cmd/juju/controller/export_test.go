@@ -129,6 +129,14 @@ func NewListBlocksCommandForTest(api listBlocksAPI, apierr error, store jujuclie
 	return modelcmd.WrapController(c)
 }
 
+// NewAuditLogCommandForTest returns an audit-log command with the
+// API mocked out.
+func NewAuditLogCommandForTest(api auditLogAPI, store jujuclient.ClientStore) cmd.Command {
+	c := &auditLogCommand{api: api}
+	c.SetClientStore(store)
+	return modelcmd.WrapController(c)
+}
+
 type CtrData ctrData
 type ModelData modelData
 
@@ -4,8 +4,11 @@
 package model
 
 import (
+	"strings"
+
 	"github.com/juju/cmd"
 	"github.com/juju/errors"
+	"github.com/juju/names"
 	"launchpad.net/gnuflag"
 
 	"github.com/juju/juju/cmd/juju/block"
@@ -14,12 +17,31 @@ import (
 )
 
 var usageGrantSummary = `
-Grants access to a Juju user for a model.`[1:]
+Grants access to a Juju user for a model or the controller.`[1:]
 
 var usageGrantDetails = `
 By default, the controller is the current controller.
 Model access can also be granted at user-addition time with the `[1:] + "`juju add-\nuser`" + ` command.
 Users with read access are limited in what they can do with models: ` + "`juju \nlist-models`, `juju list-machines`, and `juju status`" + `.
+Users with write access may change models, such as by deploying and
+scaling services and running actions, but may not destroy them or change
+who may access them; users with admin access may.
+
+If no model is specified, access to the controller is granted instead,
+and --acl must be one of 'login', 'addmodel' or 'superuser'. Users with
+login access may log in to the controller, users with addmodel access may
+also add models, and superusers have full control of the controller and
+all of its models.
+
+Operate access may also be granted to individual services, named as
+<model>/<service>. Users with operate access to a service may change its
+config, add and remove its units, run actions on its units and upgrade
+its charm, even if they have only read access to its model. The access
+may be given before the services instead of with --acl. Users granted
+access to a service who have no access to its model are given read
+access to the model, which lets them see the whole model, not only the
+service; that read access is removed again when their last service
+access in the model is revoked.
 
 Examples:
 Grant user 'joe' default (read) access to model 'mymodel':
@@ -34,18 +56,37 @@ Grant user 'sam' default (read) access to models 'model1' and 'model2':
 
     juju grant sam model1 model2
 
+Grant user 'ann' access to add models to the controller:
+
+    juju grant --acl=addmodel ann
+
+Grant the 'webdev' user operate access to the 'wordpress' service in
+model 'mymodel':
+
+    juju grant webdev operate mymodel/wordpress
+
 See also: 
     revoke
     add-user`
 
 var usageRevokeSummary = `
-Revokes access from a Juju user for a model.`[1:]
+Revokes access from a Juju user for a model or the controller.`[1:]
 
 var usageRevokeDetails = `
 By default, the controller is the current controller.
-Revoking write access, from a user who has that permission, will leave
-that user with read access. Revoking read access, however, also revokes
-write access.
+Revoking an access level leaves the user with the level below it: revoking
+admin access leaves write access, and revoking write access leaves read
+access. Revoking read access, however, revokes all access to the model.
+
+If no model is specified, access to the controller is revoked instead.
+Revoking superuser access leaves addmodel access, revoking addmodel access
+leaves login access, and revoking login access prevents the user from
+logging in to the controller.
+
+If services are specified, as <model>/<service>, operate access to them
+is revoked instead. If the user was given read access to the model only
+because they were granted access to its services, the read access to the
+model is revoked along with the last of those services.
 
 Examples:
 Revoke read (and write) access from user 'joe' for model 'mymodel':
@@ -56,20 +97,34 @@ Revoke write access from user 'sam' for models 'model1' and 'model2':
 
     juju revoke --acl=write sam model1 model2
 
+Revoke the ability to add models from user 'ann':
+
+    juju revoke --acl=addmodel ann
+
+Revoke operate access from user 'webdev' for the 'wordpress' service in
+model 'mymodel':
+
+    juju revoke webdev operate mymodel/wordpress
+
 See also: 
     grant`[1:]
 
 type accessCommand struct {
 	modelcmd.ControllerCommandBase
 
-	User        string
-	ModelNames  []string
-	ModelAccess string
+	User       string
+	ModelNames []string
+	Access     string
+
+	// ServiceNames holds the services, as <model>/<service>, when
+	// the command acts on access to services rather than models.
+	ServiceNames []string
 }
 
 // SetFlags implements cmd.Command.
 func (c *accessCommand) SetFlags(f *gnuflag.FlagSet) {
-	f.StringVar(&c.ModelAccess, "acl", "read", "Access control ('read' or 'write')")
+	f.StringVar(&c.Access, "acl", "",
+		"Access control ('read', 'write' or 'admin' for models, defaulting to 'read'; 'operate' for services; 'login', 'addmodel' or 'superuser' for the controller)")
 }
 
 // Init implements cmd.Command.
@@ -78,18 +133,104 @@ func (c *accessCommand) Init(args []string) error {
 		return errors.New("no user specified")
 	}
 
-	if len(args) < 2 {
-		return errors.New("no model specified")
+	c.User = args[0]
+	targets := args[1:]
+
+	// The access to services may be given before them, as in
+	// "juju grant bob operate mymodel/wordpress".
+	if len(targets) > 1 && isServiceTarget(targets[1]) {
+		if _, err := permission.ParseServiceAccess(targets[0]); err == nil {
+			c.Access = targets[0]
+			targets = targets[1:]
+		}
+	}
+	c.ModelNames, c.ServiceNames = nil, nil
+	for _, target := range targets {
+		if isServiceTarget(target) {
+			c.ServiceNames = append(c.ServiceNames, target)
+		} else {
+			c.ModelNames = append(c.ModelNames, target)
+		}
 	}
 
-	_, err := permission.ParseModelAccess(c.ModelAccess)
-	if err != nil {
+	if c.Access == "" {
+		// Operate is the only access that may be granted on a
+		// service; otherwise the default is read access.
+		c.Access = "read"
+		if len(c.ServiceNames) > 0 {
+			c.Access = "operate"
+		}
+	}
+
+	if len(c.ServiceNames) > 0 {
+		if len(c.ModelNames) > 0 {
+			return errors.New("cannot specify both models and services")
+		}
+		for _, target := range c.ServiceNames {
+			if _, _, err := splitServiceTarget(target); err != nil {
+				return err
+			}
+		}
+		_, err := permission.ParseServiceAccess(c.Access)
 		return err
 	}
 
-	c.User = args[0]
-	c.ModelNames = args[1:]
-	return nil
+	if len(c.ModelNames) == 0 {
+		// Without models, the command acts on controller access.
+		if _, err := permission.ParseControllerAccess(c.Access); err != nil {
+			return errors.New("no model specified")
+		}
+		return nil
+	}
+	_, err := permission.ParseModelAccess(c.Access)
+	return err
+}
+
+// isControllerAccess returns whether the command acts on the user's
+// access to the controller rather than to models.
+func (c *accessCommand) isControllerAccess() bool {
+	return len(c.ModelNames) == 0 && len(c.ServiceNames) == 0
+}
+
+// isServiceTarget returns whether the target names a service, as
+// <model>/<service>, rather than a model.
+func isServiceTarget(target string) bool {
+	return strings.Contains(target, "/")
+}
+
+// splitServiceTarget splits a <model>/<service> target into its model
+// and service names.
+func splitServiceTarget(target string) (modelName, serviceName string, err error) {
+	i := strings.LastIndex(target, "/")
+	modelName, serviceName = target[:i], target[i+1:]
+	if modelName == "" || !names.IsValidService(serviceName) {
+		return "", "", errors.Errorf("invalid service %q, expected <model>/<service>", target)
+	}
+	return modelName, serviceName, nil
+}
+
+// modelServices returns the UUIDs of the models of the services the
+// command acts on, in the order they were given, along with the names
+// of the services in each model.
+func (c *accessCommand) modelServices() ([]string, map[string][]string, error) {
+	var modelUUIDs []string
+	services := make(map[string][]string)
+	for _, target := range c.ServiceNames {
+		modelName, serviceName, err := splitServiceTarget(target)
+		if err != nil {
+			return nil, nil, err
+		}
+		uuids, err := c.ModelUUIDs([]string{modelName})
+		if err != nil {
+			return nil, nil, err
+		}
+		modelUUID := uuids[0]
+		if _, ok := services[modelUUID]; !ok {
+			modelUUIDs = append(modelUUIDs, modelUUID)
+		}
+		services[modelUUID] = append(services[modelUUID], serviceName)
+	}
+	return modelUUIDs, services, nil
 }
 
 // NewGrantCommand returns a new grant command.
@@ -100,14 +241,15 @@ func NewGrantCommand() cmd.Command {
 // grantCommand represents the command to grant a user access to one or more models.
 type grantCommand struct {
 	accessCommand
-	api GrantModelAPI
+	api           GrantModelAPI
+	controllerAPI GrantControllerAPI
 }
 
 // Info implements Command.Info.
 func (c *grantCommand) Info() *cmd.Info {
 	return &cmd.Info{
 		Name:    "grant",
-		Args:    "<user name> <model name> ...",
+		Args:    "<user name> [<model name> ... | [<access>] <model name>/<service name> ...]",
 		Purpose: usageGrantSummary,
 		Doc:     usageGrantDetails,
 	}
@@ -120,25 +262,63 @@ func (c *grantCommand) getAPI() (GrantModelAPI, error) {
 	return c.NewModelManagerAPIClient()
 }
 
+func (c *grantCommand) getControllerAPI() (GrantControllerAPI, error) {
+	if c.controllerAPI != nil {
+		return c.controllerAPI, nil
+	}
+	return c.NewControllerAPIClient()
+}
+
 // GrantModelAPI defines the API functions used by the grant command.
 type GrantModelAPI interface {
 	Close() error
 	GrantModel(user, access string, modelUUIDs ...string) error
+	GrantService(user, access, modelUUID string, services ...string) error
+}
+
+// GrantControllerAPI defines the API functions used by the grant
+// command to grant access to the controller.
+type GrantControllerAPI interface {
+	Close() error
+	GrantController(user, access string) error
 }
 
 // Run implements cmd.Command.
 func (c *grantCommand) Run(ctx *cmd.Context) error {
+	if c.isControllerAccess() {
+		client, err := c.getControllerAPI()
+		if err != nil {
+			return err
+		}
+		defer client.Close()
+		return client.GrantController(c.User, c.Access)
+	}
+
 	client, err := c.getAPI()
 	if err != nil {
 		return err
 	}
 	defer client.Close()
 
+	if len(c.ServiceNames) > 0 {
+		modelUUIDs, services, err := c.modelServices()
+		if err != nil {
+			return err
+		}
+		for _, modelUUID := range modelUUIDs {
+			err := client.GrantService(c.User, c.Access, modelUUID, services[modelUUID]...)
+			if err != nil {
+				return block.ProcessBlockedError(err, block.BlockChange)
+			}
+		}
+		return nil
+	}
+
 	models, err := c.ModelUUIDs(c.ModelNames)
 	if err != nil {
 		return err
 	}
-	return block.ProcessBlockedError(client.GrantModel(c.User, c.ModelAccess, models...), block.BlockChange)
+	return block.ProcessBlockedError(client.GrantModel(c.User, c.Access, models...), block.BlockChange)
 }
 
 // NewRevokeCommand returns a new revoke command.
@@ -149,14 +329,15 @@ func NewRevokeCommand() cmd.Command {
 // revokeCommand revokes a user's access to models.
 type revokeCommand struct {
 	accessCommand
-	api RevokeModelAPI
+	api           RevokeModelAPI
+	controllerAPI RevokeControllerAPI
 }
 
 // Info implements cmd.Command.
 func (c *revokeCommand) Info() *cmd.Info {
 	return &cmd.Info{
 		Name:    "revoke",
-		Args:    "<user> <model name> ...",
+		Args:    "<user> [<model name> ... | [<access>] <model name>/<service name> ...]",
 		Purpose: usageRevokeSummary,
 		Doc:     usageRevokeDetails,
 	}
@@ -169,23 +350,61 @@ func (c *revokeCommand) getAPI() (RevokeModelAPI, error) {
 	return c.NewModelManagerAPIClient()
 }
 
+func (c *revokeCommand) getControllerAPI() (RevokeControllerAPI, error) {
+	if c.controllerAPI != nil {
+		return c.controllerAPI, nil
+	}
+	return c.NewControllerAPIClient()
+}
+
 // RevokeModelAPI defines the API functions used by the revoke command.
 type RevokeModelAPI interface {
 	Close() error
 	RevokeModel(user, access string, modelUUIDs ...string) error
+	RevokeService(user, access, modelUUID string, services ...string) error
+}
+
+// RevokeControllerAPI defines the API functions used by the revoke
+// command to revoke access to the controller.
+type RevokeControllerAPI interface {
+	Close() error
+	RevokeController(user, access string) error
 }
 
 // Run implements cmd.Command.
 func (c *revokeCommand) Run(ctx *cmd.Context) error {
+	if c.isControllerAccess() {
+		client, err := c.getControllerAPI()
+		if err != nil {
+			return err
+		}
+		defer client.Close()
+		return client.RevokeController(c.User, c.Access)
+	}
+
 	client, err := c.getAPI()
 	if err != nil {
 		return err
 	}
 	defer client.Close()
 
+	if len(c.ServiceNames) > 0 {
+		modelUUIDs, services, err := c.modelServices()
+		if err != nil {
+			return err
+		}
+		for _, modelUUID := range modelUUIDs {
+			err := client.RevokeService(c.User, c.Access, modelUUID, services[modelUUID]...)
+			if err != nil {
+				return block.ProcessBlockedError(err, block.BlockChange)
+			}
+		}
+		return nil
+	}
+
 	modelUUIDs, err := c.ModelUUIDs(c.ModelNames)
 	if err != nil {
 		return err
 	}
-	return block.ProcessBlockedError(client.RevokeModel(c.User, c.ModelAccess, modelUUIDs...), block.BlockChange)
+	return block.ProcessBlockedError(client.RevokeModel(c.User, c.Access, modelUUIDs...), block.BlockChange)
 }
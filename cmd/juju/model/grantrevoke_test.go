@@ -90,6 +90,59 @@ func (s *grantRevokeSuite) TestAccess(c *gc.C) {
 	c.Assert(s.fake.access, gc.Equals, "write")
 }
 
+func (s *grantRevokeSuite) TestControllerAccess(c *gc.C) {
+	_, err := s.run(c, "--acl", "addmodel", "sam")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.fake.user, gc.Equals, "sam")
+	c.Assert(s.fake.modelUUIDs, gc.HasLen, 0)
+	c.Assert(s.fake.access, gc.Equals, "addmodel")
+	c.Assert(s.fake.controller, jc.IsTrue)
+}
+
+func (s *grantRevokeSuite) TestControllerAccessWithModel(c *gc.C) {
+	_, err := s.run(c, "--acl", "superuser", "sam", "foo")
+	c.Assert(err, gc.ErrorMatches, `invalid model access permission "superuser"`)
+}
+
+func (s *grantRevokeSuite) TestServiceAccess(c *gc.C) {
+	_, err := s.run(c, "sam", "operate", "foo/wordpress", "foo/mysql")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.fake.user, gc.Equals, "sam")
+	c.Assert(s.fake.access, gc.Equals, "operate")
+	c.Assert(s.fake.modelUUIDs, jc.DeepEquals, []string{fooModelUUID})
+	c.Assert(s.fake.services, jc.DeepEquals, []string{"wordpress", "mysql"})
+}
+
+func (s *grantRevokeSuite) TestServiceAccessFlag(c *gc.C) {
+	_, err := s.run(c, "--acl", "operate", "sam", "foo/wordpress", "bar/wordpress")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.fake.access, gc.Equals, "operate")
+	c.Assert(s.fake.modelUUIDs, jc.DeepEquals, []string{fooModelUUID, barModelUUID})
+	c.Assert(s.fake.services, jc.DeepEquals, []string{"wordpress", "wordpress"})
+}
+
+func (s *grantRevokeSuite) TestServiceAccessDefault(c *gc.C) {
+	_, err := s.run(c, "sam", "foo/wordpress")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.fake.access, gc.Equals, "operate")
+	c.Assert(s.fake.services, jc.DeepEquals, []string{"wordpress"})
+}
+
+func (s *grantRevokeSuite) TestServiceAccessRead(c *gc.C) {
+	_, err := s.run(c, "--acl", "read", "sam", "foo/wordpress")
+	c.Assert(err, gc.ErrorMatches, `invalid service access permission "read"`)
+}
+
+func (s *grantRevokeSuite) TestServiceAccessInvalid(c *gc.C) {
+	_, err := s.run(c, "--acl", "write", "sam", "foo/wordpress")
+	c.Assert(err, gc.ErrorMatches, `invalid service access permission "write"`)
+}
+
+func (s *grantRevokeSuite) TestModelsAndServices(c *gc.C) {
+	_, err := s.run(c, "sam", "foo", "bar/wordpress")
+	c.Assert(err, gc.ErrorMatches, "cannot specify both models and services")
+}
+
 func (s *grantRevokeSuite) TestBlockGrant(c *gc.C) {
 	s.fake.err = &params.Error{Code: params.CodeOperationBlocked}
 	_, err := s.run(c, "sam", "foo")
@@ -106,13 +159,13 @@ var _ = gc.Suite(&grantSuite{})
 func (s *grantSuite) SetUpTest(c *gc.C) {
 	s.grantRevokeSuite.SetUpTest(c)
 	s.cmdFactory = func(fake *fakeGrantRevokeAPI) cmd.Command {
-		c, _ := model.NewGrantCommandForTest(fake, s.store)
+		c, _ := model.NewGrantCommandForTest(fake, fake, s.store)
 		return c
 	}
 }
 
 func (s *grantSuite) TestInit(c *gc.C) {
-	wrappedCmd, grantCmd := model.NewGrantCommandForTest(s.fake, s.store)
+	wrappedCmd, grantCmd := model.NewGrantCommandForTest(s.fake, s.fake, s.store)
 	err := testing.InitCommand(wrappedCmd, []string{})
 	c.Assert(err, gc.ErrorMatches, "no user specified")
 
@@ -127,6 +180,22 @@ func (s *grantSuite) TestInit(c *gc.C) {
 
 	err = testing.InitCommand(wrappedCmd, []string{"nomodel"})
 	c.Assert(err, gc.ErrorMatches, `no model specified`)
+
+	err = testing.InitCommand(wrappedCmd, []string{"--acl", "login", "bob"})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(grantCmd.User, gc.Equals, "bob")
+	c.Assert(grantCmd.ModelNames, gc.HasLen, 0)
+	c.Assert(grantCmd.Access, gc.Equals, "login")
+
+	err = testing.InitCommand(wrappedCmd, []string{"bob", "operate", "model1/wordpress"})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(grantCmd.User, gc.Equals, "bob")
+	c.Assert(grantCmd.ModelNames, gc.HasLen, 0)
+	c.Assert(grantCmd.ServiceNames, jc.DeepEquals, []string{"model1/wordpress"})
+	c.Assert(grantCmd.Access, gc.Equals, "operate")
+
+	err = testing.InitCommand(wrappedCmd, []string{"bob", "model1/"})
+	c.Assert(err, gc.ErrorMatches, `invalid service "model1/", expected <model>/<service>`)
 }
 
 type revokeSuite struct {
@@ -138,13 +207,13 @@ var _ = gc.Suite(&revokeSuite{})
 func (s *revokeSuite) SetUpTest(c *gc.C) {
 	s.grantRevokeSuite.SetUpTest(c)
 	s.cmdFactory = func(fake *fakeGrantRevokeAPI) cmd.Command {
-		c, _ := model.NewRevokeCommandForTest(fake, s.store)
+		c, _ := model.NewRevokeCommandForTest(fake, fake, s.store)
 		return c
 	}
 }
 
 func (s *revokeSuite) TestInit(c *gc.C) {
-	wrappedCmd, revokeCmd := model.NewRevokeCommandForTest(s.fake, s.store)
+	wrappedCmd, revokeCmd := model.NewRevokeCommandForTest(s.fake, s.fake, s.store)
 	err := testing.InitCommand(wrappedCmd, []string{})
 	c.Assert(err, gc.ErrorMatches, "no user specified")
 
@@ -166,6 +235,8 @@ type fakeGrantRevokeAPI struct {
 	user       string
 	access     string
 	modelUUIDs []string
+	services   []string
+	controller bool
 }
 
 func (f *fakeGrantRevokeAPI) Close() error { return nil }
@@ -178,9 +249,35 @@ func (f *fakeGrantRevokeAPI) RevokeModel(user, access string, modelUUIDs ...stri
 	return f.fake(user, access, modelUUIDs...)
 }
 
+func (f *fakeGrantRevokeAPI) GrantService(user, access, modelUUID string, services ...string) error {
+	return f.fakeService(user, access, modelUUID, services...)
+}
+
+func (f *fakeGrantRevokeAPI) RevokeService(user, access, modelUUID string, services ...string) error {
+	return f.fakeService(user, access, modelUUID, services...)
+}
+
+func (f *fakeGrantRevokeAPI) GrantController(user, access string) error {
+	f.controller = true
+	return f.fake(user, access)
+}
+
+func (f *fakeGrantRevokeAPI) RevokeController(user, access string) error {
+	f.controller = true
+	return f.fake(user, access)
+}
+
 func (f *fakeGrantRevokeAPI) fake(user, access string, modelUUIDs ...string) error {
 	f.user = user
 	f.access = access
 	f.modelUUIDs = modelUUIDs
 	return f.err
 }
+
+func (f *fakeGrantRevokeAPI) fakeService(user, access, modelUUID string, services ...string) error {
+	f.user = user
+	f.access = access
+	f.modelUUIDs = append(f.modelUUIDs, modelUUID)
+	f.services = append(f.services, services...)
+	return f.err
+}
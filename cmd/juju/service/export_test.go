@@ -25,6 +25,14 @@ func NewGetCommandForTest(api getServiceAPI) cmd.Command {
 	})
 }
 
+// NewExportBundleCommandForTest returns an ExportBundleCommand with the
+// api provided as specified.
+func NewExportBundleCommandForTest(api exportBundleAPI) cmd.Command {
+	return modelcmd.Wrap(&exportBundleCommand{
+		api: api,
+	})
+}
+
 // NewAddUnitCommandForTest returns an AddUnitCommand with the api provided as specified.
 func NewAddUnitCommandForTest(api serviceAddUnitAPI) cmd.Command {
 	return modelcmd.Wrap(&addUnitCommand{
@@ -0,0 +1,107 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package service
+
+import (
+	"fmt"
+	"io/ioutil"
+
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	"launchpad.net/gnuflag"
+
+	"github.com/juju/juju/api/service"
+	"github.com/juju/juju/cmd/modelcmd"
+)
+
+var usageExportBundleSummary = `
+Exports the current model as a bundle.`[1:]
+
+var usageExportBundleDetails = `
+Writes a bundle describing the services, relations and machines in the
+current model, in the format accepted by "juju deploy". The bundle
+records each service's charm, number of units, configuration options that
+differ from the charm's defaults, constraints, storage, endpoint bindings,
+expose flag and annotations. Units are placed on machines and containers
+so that services sharing a machine or container in the model share one
+when the bundle is deployed.
+
+The bundle is written to standard output unless --filename is given.
+
+Examples:
+    juju export-bundle
+    juju export-bundle --filename mymodel.yaml
+
+See also:
+    deploy`
+
+// NewExportBundleCommand returns a command that exports the current
+// model as a bundle.
+func NewExportBundleCommand() cmd.Command {
+	return modelcmd.Wrap(&exportBundleCommand{})
+}
+
+// exportBundleCommand writes a bundle describing the current model.
+type exportBundleCommand struct {
+	modelcmd.ModelCommandBase
+	filename string
+	api      exportBundleAPI
+}
+
+func (c *exportBundleCommand) Info() *cmd.Info {
+	return &cmd.Info{
+		Name:    "export-bundle",
+		Purpose: usageExportBundleSummary,
+		Doc:     usageExportBundleDetails,
+	}
+}
+
+func (c *exportBundleCommand) SetFlags(f *gnuflag.FlagSet) {
+	f.StringVar(&c.filename, "filename", "", "write the bundle to this file")
+}
+
+func (c *exportBundleCommand) Init(args []string) error {
+	return cmd.CheckEmpty(args)
+}
+
+// exportBundleAPI defines the methods on the service API
+// that the export-bundle command calls.
+type exportBundleAPI interface {
+	Close() error
+	ExportBundle() (string, error)
+}
+
+func (c *exportBundleCommand) getAPI() (exportBundleAPI, error) {
+	if c.api != nil {
+		return c.api, nil
+	}
+	root, err := c.NewAPIRoot()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return service.NewClient(root), nil
+}
+
+// Run fetches the bundle from the controller and writes it out.
+func (c *exportBundleCommand) Run(ctx *cmd.Context) error {
+	apiclient, err := c.getAPI()
+	if err != nil {
+		return err
+	}
+	defer apiclient.Close()
+
+	bundle, err := apiclient.ExportBundle()
+	if err != nil {
+		return err
+	}
+	if c.filename == "" {
+		_, err := fmt.Fprint(ctx.Stdout, bundle)
+		return err
+	}
+	if err := ioutil.WriteFile(ctx.AbsPath(c.filename), []byte(bundle), 0644); err != nil {
+		return errors.Annotate(err, "cannot write bundle")
+	}
+	ctx.Infof("bundle written to %s", c.filename)
+	return nil
+}
@@ -0,0 +1,69 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package service_test
+
+import (
+	"io/ioutil"
+	"path/filepath"
+
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/cmd/juju/service"
+	coretesting "github.com/juju/juju/testing"
+)
+
+type ExportBundleSuite struct {
+	coretesting.FakeJujuXDGDataHomeSuite
+	fake *fakeExportBundleAPI
+}
+
+var _ = gc.Suite(&ExportBundleSuite{})
+
+type fakeExportBundleAPI struct {
+	bundle string
+	err    error
+}
+
+func (f *fakeExportBundleAPI) Close() error { return nil }
+
+func (f *fakeExportBundleAPI) ExportBundle() (string, error) {
+	return f.bundle, f.err
+}
+
+func (s *ExportBundleSuite) SetUpTest(c *gc.C) {
+	s.FakeJujuXDGDataHomeSuite.SetUpTest(c)
+	s.fake = &fakeExportBundleAPI{
+		bundle: "services:\n  mysql:\n    charm: cs:trusty/mysql-1\n    num_units: 1\n",
+	}
+}
+
+func (s *ExportBundleSuite) TestInit(c *gc.C) {
+	err := coretesting.InitCommand(service.NewExportBundleCommandForTest(s.fake), []string{"extra"})
+	c.Assert(err, gc.ErrorMatches, `unrecognized args: \["extra"\]`)
+}
+
+func (s *ExportBundleSuite) TestExportToStdout(c *gc.C) {
+	ctx, err := coretesting.RunCommand(c, service.NewExportBundleCommandForTest(s.fake))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(coretesting.Stdout(ctx), gc.Equals, s.fake.bundle)
+}
+
+func (s *ExportBundleSuite) TestExportToFile(c *gc.C) {
+	dir := c.MkDir()
+	path := filepath.Join(dir, "bundle.yaml")
+	ctx, err := coretesting.RunCommand(c, service.NewExportBundleCommandForTest(s.fake), "--filename", path)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(coretesting.Stdout(ctx), gc.Equals, "")
+	data, err := ioutil.ReadFile(path)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(string(data), gc.Equals, s.fake.bundle)
+}
+
+func (s *ExportBundleSuite) TestExportError(c *gc.C) {
+	s.fake.err = errors.New("boom")
+	_, err := coretesting.RunCommand(c, service.NewExportBundleCommandForTest(s.fake))
+	c.Assert(err, gc.ErrorMatches, "boom")
+}
@@ -6,10 +6,12 @@ package service
 import (
 	"github.com/juju/cmd"
 	"github.com/juju/errors"
+	"launchpad.net/gnuflag"
 
 	"github.com/juju/juju/api/service"
 	"github.com/juju/juju/cmd/juju/block"
 	"github.com/juju/juju/cmd/modelcmd"
+	"github.com/juju/juju/network"
 )
 
 var usageExposeSummary = `
@@ -19,8 +21,13 @@ var usageExposeDetails = `
 Adjusts the firewall rules and any relevant security mechanisms of the
 cloud to allow public access to the service.
 
+The --to-cidrs option restricts access to traffic from the given
+comma-separated source CIDRs. Exposing the service again without the
+option allows access from anywhere.
+
 Examples:
     juju expose wordpress
+    juju expose --to-cidrs 10.0.0.0/8,192.168.1.0/24 wordpress
 
 See also: 
     unexpose`[1:]
@@ -34,6 +41,7 @@ func NewExposeCommand() cmd.Command {
 type exposeCommand struct {
 	modelcmd.ModelCommandBase
 	ServiceName string
+	CIDRs       []string
 }
 
 func (c *exposeCommand) Info() *cmd.Info {
@@ -45,17 +53,27 @@ func (c *exposeCommand) Info() *cmd.Info {
 	}
 }
 
+func (c *exposeCommand) SetFlags(f *gnuflag.FlagSet) {
+	f.Var(cmd.NewStringsValue(nil, &c.CIDRs), "to-cidrs", "only allow access from these source CIDRs")
+}
+
 func (c *exposeCommand) Init(args []string) error {
 	if len(args) == 0 {
 		return errors.New("no service name specified")
 	}
 	c.ServiceName = args[0]
+	for _, cidr := range c.CIDRs {
+		if err := network.ValidateSourceCIDR(cidr); err != nil {
+			return errors.Trace(err)
+		}
+	}
 	return cmd.CheckEmpty(args[1:])
 }
 
 type serviceExposeAPI interface {
 	Close() error
 	Expose(serviceName string) error
+	ExposeToCIDRs(serviceName string, cidrs []string) error
 	Unexpose(serviceName string) error
 }
 
@@ -75,5 +93,10 @@ func (c *exposeCommand) Run(_ *cmd.Context) error {
 		return err
 	}
 	defer client.Close()
-	return block.ProcessBlockedError(client.Expose(c.ServiceName), block.BlockChange)
+	if len(c.CIDRs) > 0 {
+		err = client.ExposeToCIDRs(c.ServiceName, c.CIDRs)
+	} else {
+		err = client.Expose(c.ServiceName)
+	}
+	return block.ProcessBlockedError(err, block.BlockChange)
 }
@@ -72,6 +72,7 @@ type ServiceAPI interface {
 	Close() error
 	Destroy(serviceName string) error
 	DestroyUnits(unitNames ...string) error
+	DestroyUnitsKeepStorage(unitNames ...string) error
 	GetCharmURL(serviceName string) (*charm.URL, error)
 	ModelUUID() string
 }
@@ -9,6 +9,7 @@ import (
 	"github.com/juju/cmd"
 	"github.com/juju/errors"
 	"github.com/juju/names"
+	"launchpad.net/gnuflag"
 
 	apiservice "github.com/juju/juju/api/service"
 	"github.com/juju/juju/cmd/juju/block"
@@ -23,7 +24,8 @@ func NewRemoveUnitCommand() cmd.Command {
 // removeUnitCommand is responsible for destroying service units.
 type removeUnitCommand struct {
 	modelcmd.ModelCommandBase
-	UnitNames []string
+	UnitNames   []string
+	KeepStorage bool
 }
 
 const removeUnitDoc = `
@@ -34,6 +36,11 @@ the unit is hosted will also be destroyed, if possible.
 The machine will be destroyed if:
 - it is not a controller
 - it is not hosting any Juju managed containers
+
+The units' storage is destroyed along with them, unless --keep-storage
+is specified. With --keep-storage, the storage is detached from the
+units and left in the model, so that it may be attached to another
+unit with juju attach-storage. Only persistent storage may be kept.
 `
 
 func (c *removeUnitCommand) Info() *cmd.Info {
@@ -46,6 +53,10 @@ func (c *removeUnitCommand) Info() *cmd.Info {
 	}
 }
 
+func (c *removeUnitCommand) SetFlags(f *gnuflag.FlagSet) {
+	f.BoolVar(&c.KeepStorage, "keep-storage", false, "detach the units' storage rather than destroying it")
+}
+
 func (c *removeUnitCommand) Init(args []string) error {
 	c.UnitNames = args
 	if len(c.UnitNames) == 0 {
@@ -75,5 +86,10 @@ func (c *removeUnitCommand) Run(_ *cmd.Context) error {
 		return err
 	}
 	defer client.Close()
-	return block.ProcessBlockedError(client.DestroyUnits(c.UnitNames...), block.BlockRemove)
+	if c.KeepStorage {
+		err = client.DestroyUnitsKeepStorage(c.UnitNames...)
+	} else {
+		err = client.DestroyUnits(c.UnitNames...)
+	}
+	return block.ProcessBlockedError(err, block.BlockRemove)
 }
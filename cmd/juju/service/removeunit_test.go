@@ -57,6 +57,19 @@ func (s *RemoveUnitSuite) TestRemoveUnit(c *gc.C) {
 		c.Assert(u.Life(), gc.Equals, state.Dying)
 	}
 }
+
+func (s *RemoveUnitSuite) TestRemoveUnitKeepStorage(c *gc.C) {
+	svc := s.setupUnitForRemove(c)
+
+	err := runRemoveUnit(c, "--keep-storage", "dummy/0", "dummy/1")
+	c.Assert(err, jc.ErrorIsNil)
+	units, err := svc.AllUnits()
+	c.Assert(err, jc.ErrorIsNil)
+	for _, u := range units {
+		c.Assert(u.Life(), gc.Equals, state.Dying)
+	}
+}
+
 func (s *RemoveUnitSuite) TestBlockRemoveUnit(c *gc.C) {
 	svc := s.setupUnitForRemove(c)
 
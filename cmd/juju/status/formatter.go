@@ -109,6 +109,7 @@ func (sf *statusFormatter) formatService(name string, service params.ServiceStat
 		Err:           service.Err,
 		Charm:         service.Charm,
 		Exposed:       service.Exposed,
+		ExposedCIDRs:  service.ExposedCIDRs,
 		Life:          service.Life,
 		Relations:     service.Relations,
 		CanUpgradeTo:  service.CanUpgradeTo,
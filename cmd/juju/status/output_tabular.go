@@ -118,7 +118,12 @@ func FormatTabular(value interface{}) ([]byte, error) {
 		}
 
 		subs := set.NewStrings(svc.SubordinateTo...)
-		p(svcName, svc.StatusInfo.Current, fmt.Sprintf("%t", svc.Exposed), svc.Charm)
+		exposed := fmt.Sprintf("%t", svc.Exposed)
+		if svc.Exposed && len(svc.ExposedCIDRs) > 0 {
+			// Show the source CIDRs the service is restricted to.
+			exposed = strings.Join(svc.ExposedCIDRs, ",")
+		}
+		p(svcName, svc.StatusInfo.Current, exposed, svc.Charm)
 		for relType, relatedUnits := range svc.Relations {
 			for _, related := range relatedUnits {
 				relations.add(related, svcName, relType, subs.Contains(related))
@@ -3241,6 +3241,47 @@ ID         STATE DNS INS-ID SERIES AZ
 `[1:])
 }
 
+func (s *StatusSuite) TestFormatTabularExposedCIDRs(c *gc.C) {
+	status := formattedStatus{
+		Services: map[string]serviceStatus{
+			"foo": serviceStatus{
+				Charm:        "cs:quantal/foo-1",
+				Exposed:      true,
+				ExposedCIDRs: []string{"10.0.0.0/8", "192.168.1.0/24"},
+			},
+		},
+	}
+	out, err := FormatTabular(status)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(string(out), gc.Equals, `
+[Services] 
+NAME       STATUS EXPOSED                   CHARM            
+foo               10.0.0.0/8,192.168.1.0/24 cs:quantal/foo-1 
+
+[Units] 
+ID      WORKLOAD-STATUS JUJU-STATUS VERSION MACHINE PORTS PUBLIC-ADDRESS MESSAGE 
+
+[Machines] 
+ID         STATE DNS INS-ID SERIES AZ 
+`[1:])
+}
+
+func (s *StatusSuite) TestFormatExposedCIDRs(c *gc.C) {
+	status := &params.FullStatus{
+		Services: map[string]params.ServiceStatus{
+			"foo": params.ServiceStatus{
+				Charm:        "cs:quantal/foo-1",
+				Exposed:      true,
+				ExposedCIDRs: []string{"10.0.0.0/8"},
+			},
+		},
+	}
+	formatter := NewStatusFormatter(status, true)
+	formatted := formatter.format()
+	c.Check(formatted.Services["foo"].Exposed, jc.IsTrue)
+	c.Check(formatted.Services["foo"].ExposedCIDRs, jc.DeepEquals, []string{"10.0.0.0/8"})
+}
+
 func (s *StatusSuite) TestStatusWithNilStatusApi(c *gc.C) {
 	ctx := s.newContext(c)
 	defer s.resetContext(c, ctx)
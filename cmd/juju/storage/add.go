@@ -10,6 +10,7 @@ import (
 	"github.com/juju/cmd"
 	"github.com/juju/errors"
 	"github.com/juju/names"
+	"launchpad.net/gnuflag"
 
 	"github.com/juju/juju/apiserver/params"
 	"github.com/juju/juju/cmd/modelcmd"
@@ -70,6 +71,11 @@ Example:
       juju add-storage u/0 data=1 
     or
       juju add-storage u/0 data 
+
+    Add "data" storage to unit u/0, creating its volume from
+    the storage snapshot with ID 3:
+
+      juju add-storage u/0 data --from-snapshot 3
 `
 	addCommandAgs = `
 <unit name> <storage directive> ...
@@ -89,6 +95,16 @@ type addCommand struct {
 	// defined in charm storage metadata.
 	storageCons map[string]storage.Constraints
 	newAPIFunc  func() (StorageAddAPI, error)
+
+	// fromSnapshot is the ID of the storage snapshot from which
+	// the storage's volume will be created, if any.
+	fromSnapshot string
+}
+
+// SetFlags implements Command.SetFlags.
+func (c *addCommand) SetFlags(f *gnuflag.FlagSet) {
+	c.StorageCommandBase.SetFlags(f)
+	f.StringVar(&c.fromSnapshot, "from-snapshot", "", "create the storage from the storage snapshot with this ID")
 }
 
 // Init implements Command.Init.
@@ -104,7 +120,13 @@ func (c *addCommand) Init(args []string) (err error) {
 	c.unitTag = names.NewUnitTag(u).String()
 
 	c.storageCons, err = storage.ParseConstraintsMap(args[1:], false)
-	return
+	if err != nil {
+		return err
+	}
+	if c.fromSnapshot != "" && len(c.storageCons) != 1 {
+		return errors.New("--from-snapshot requires a single storage directive")
+	}
+	return nil
 }
 
 // Info implements Command.Info.
@@ -173,6 +195,7 @@ func (c *addCommand) createStorageAddParams() []params.StorageAddParams {
 					&cons.Size,
 					&cons.Count,
 				},
+				FromSnapshot: c.fromSnapshot,
 			})
 	}
 	return all
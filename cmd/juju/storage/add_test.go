@@ -82,6 +82,20 @@ func (s *addSuite) TestAddSuccess(c *gc.C) {
 	}
 }
 
+func (s *addSuite) TestAddFromSnapshot(c *gc.C) {
+	s.args = []string{"tst/123", "data=ebs", "--from-snapshot", "3"}
+	s.assertAddOutput(c, "", "")
+	c.Assert(s.mockAPI.storages, gc.HasLen, 1)
+	c.Assert(s.mockAPI.storages[0].StorageName, gc.Equals, "data")
+	c.Assert(s.mockAPI.storages[0].Constraints.Pool, gc.Equals, "ebs")
+	c.Assert(s.mockAPI.storages[0].FromSnapshot, gc.Equals, "3")
+}
+
+func (s *addSuite) TestAddFromSnapshotMultipleDirectives(c *gc.C) {
+	s.args = []string{"tst/123", "data", "logs", "--from-snapshot", "3"}
+	s.assertAddErrorOutput(c, "--from-snapshot requires a single storage directive")
+}
+
 func (s *addSuite) TestAddOperationAborted(c *gc.C) {
 	s.args = []string{"tst/123", "data=676"}
 	s.mockAPI.abort = true
@@ -117,17 +131,19 @@ func (s *addSuite) assertAddOutput(c *gc.C, expectedValid, expectedErr string) {
 }
 
 type mockAddAPI struct {
-	abort bool
+	abort    bool
+	storages []params.StorageAddParams
 }
 
-func (s mockAddAPI) Close() error {
+func (s *mockAddAPI) Close() error {
 	return nil
 }
 
-func (s mockAddAPI) AddToUnit(storages []params.StorageAddParams) ([]params.ErrorResult, error) {
+func (s *mockAddAPI) AddToUnit(storages []params.StorageAddParams) ([]params.ErrorResult, error) {
 	if s.abort {
 		return nil, errors.New("aborted")
 	}
+	s.storages = storages
 	result := make([]params.ErrorResult, len(storages))
 	for i, one := range storages {
 		if strings.HasPrefix(one.StorageName, "err") {
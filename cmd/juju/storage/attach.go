@@ -0,0 +1,98 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package storage
+
+import (
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	"github.com/juju/names"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/cmd/juju/block"
+	"github.com/juju/juju/cmd/modelcmd"
+)
+
+// NewAttachCommand returns a command used to attach detached storage
+// to a unit.
+func NewAttachCommand() cmd.Command {
+	cmd := &attachCommand{}
+	cmd.newAPIFunc = func() (StorageAttachAPI, error) {
+		return cmd.NewStorageAPI()
+	}
+	return modelcmd.Wrap(cmd)
+}
+
+const attachCommandDoc = `
+Attach existing storage instances to a unit.
+
+The storage instances must have been detached from the units that
+previously owned them, with juju detach-storage or juju remove-unit
+--keep-storage, and the unit's charm must declare storage with the
+same name and kind. The storage's volumes and filesystems are attached
+to the unit's machine, and the storage-attached hook is then run on
+the unit.
+
+Example:
+    Attach storage instance data/0 to unit postgresql/1:
+
+      juju attach-storage postgresql/1 data/0
+`
+
+// attachCommand attaches storage instances to a unit.
+type attachCommand struct {
+	StorageCommandBase
+	unitId     string
+	storageIds []string
+	newAPIFunc func() (StorageAttachAPI, error)
+}
+
+// Init implements Command.Init.
+func (c *attachCommand) Init(args []string) error {
+	if len(args) < 2 {
+		return errors.New("attach-storage requires a unit and at least one storage ID")
+	}
+	if !names.IsValidUnit(args[0]) {
+		return errors.NotValidf("unit name %q", args[0])
+	}
+	for _, id := range args[1:] {
+		if !names.IsValidStorage(id) {
+			return errors.NotValidf("storage ID %q", id)
+		}
+	}
+	c.unitId = args[0]
+	c.storageIds = args[1:]
+	return nil
+}
+
+// Info implements Command.Info.
+func (c *attachCommand) Info() *cmd.Info {
+	return &cmd.Info{
+		Name:    "attach-storage",
+		Purpose: "attaches existing storage instances to a unit",
+		Doc:     attachCommandDoc,
+		Args:    "<unit name> <storage ID> ...",
+	}
+}
+
+// Run implements Command.Run.
+func (c *attachCommand) Run(ctx *cmd.Context) error {
+	api, err := c.newAPIFunc()
+	if err != nil {
+		return err
+	}
+	defer api.Close()
+
+	results, err := api.Attach(c.unitId, c.storageIds)
+	if err != nil {
+		return block.ProcessBlockedError(err, block.BlockChange)
+	}
+	return reportStorageErrors(ctx, c.storageIds, results)
+}
+
+// StorageAttachAPI defines the API methods that the attach-storage
+// command uses.
+type StorageAttachAPI interface {
+	Close() error
+	Attach(unitId string, storageIds []string) ([]params.ErrorResult, error)
+}
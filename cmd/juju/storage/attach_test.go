@@ -0,0 +1,97 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package storage_test
+
+import (
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/cmd/juju/storage"
+	"github.com/juju/juju/testing"
+)
+
+type attachSuite struct {
+	SubStorageSuite
+	mockAPI *mockAttachAPI
+}
+
+var _ = gc.Suite(&attachSuite{})
+
+func (s *attachSuite) SetUpTest(c *gc.C) {
+	s.SubStorageSuite.SetUpTest(c)
+	s.mockAPI = &mockAttachAPI{}
+}
+
+func (s *attachSuite) runAttach(c *gc.C, args ...string) (*cmd.Context, error) {
+	return testing.RunCommand(c, storage.NewAttachCommandForTest(s.mockAPI, s.store), args...)
+}
+
+func (s *attachSuite) TestAttachInitErrors(c *gc.C) {
+	for i, t := range []struct {
+		args        []string
+		expectedErr string
+	}{
+		{nil, "attach-storage requires a unit and at least one storage ID"},
+		{[]string{"mysql/0"}, "attach-storage requires a unit and at least one storage ID"},
+		{[]string{"mysql", "data/0"}, `unit name "mysql" not valid`},
+		{[]string{"mysql/0", "data"}, `storage ID "data" not valid`},
+	} {
+		c.Logf("test %d for %q", i, t.args)
+		_, err := s.runAttach(c, t.args...)
+		c.Check(err, gc.ErrorMatches, t.expectedErr)
+	}
+}
+
+func (s *attachSuite) TestAttach(c *gc.C) {
+	context, err := s.runAttach(c, "mysql/1", "data/0", "logs/1")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.mockAPI.unitId, gc.Equals, "mysql/1")
+	c.Assert(s.mockAPI.storageIds, jc.DeepEquals, []string{"data/0", "logs/1"})
+	c.Assert(testing.Stdout(context), gc.Equals, "")
+	c.Assert(testing.Stderr(context), gc.Equals, "")
+}
+
+func (s *attachSuite) TestAttachFailure(c *gc.C) {
+	s.mockAPI.results = []params.ErrorResult{
+		{},
+		{Error: &params.Error{Message: "storage is owned by unit mysql/0"}},
+	}
+	context, err := s.runAttach(c, "mysql/1", "data/0", "logs/1")
+	c.Assert(err, gc.Equals, cmd.ErrSilent)
+	c.Assert(testing.Stderr(context), gc.Equals,
+		"fail: storage \"logs/1\": storage is owned by unit mysql/0\n",
+	)
+}
+
+func (s *attachSuite) TestAttachError(c *gc.C) {
+	s.mockAPI.err = errors.New("boom")
+	_, err := s.runAttach(c, "mysql/1", "data/0")
+	c.Assert(err, gc.ErrorMatches, "boom")
+}
+
+type mockAttachAPI struct {
+	unitId     string
+	storageIds []string
+	results    []params.ErrorResult
+	err        error
+}
+
+func (m *mockAttachAPI) Close() error {
+	return nil
+}
+
+func (m *mockAttachAPI) Attach(unitId string, storageIds []string) ([]params.ErrorResult, error) {
+	if m.err != nil {
+		return nil, m.err
+	}
+	m.unitId = unitId
+	m.storageIds = storageIds
+	if m.results != nil {
+		return m.results, nil
+	}
+	return make([]params.ErrorResult, len(storageIds)), nil
+}
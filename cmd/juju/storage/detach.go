@@ -0,0 +1,113 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package storage
+
+import (
+	"fmt"
+
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	"github.com/juju/names"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/cmd/juju/block"
+	"github.com/juju/juju/cmd/modelcmd"
+)
+
+// NewDetachCommand returns a command used to detach storage from
+// the units that own it.
+func NewDetachCommand() cmd.Command {
+	cmd := &detachCommand{}
+	cmd.newAPIFunc = func() (StorageDetachAPI, error) {
+		return cmd.NewStorageAPI()
+	}
+	return modelcmd.Wrap(cmd)
+}
+
+const detachCommandDoc = `
+Detach storage instances from the units they are attached to.
+
+The storage-detaching hook is run on each unit before its storage is
+detached. The storage is not destroyed: its volumes and filesystems
+are kept, and it may later be attached to another unit of the same
+service with juju attach-storage. Only persistent storage may be
+detached.
+
+Example:
+    Detach storage instance data/0 from the unit it is attached to:
+
+      juju detach-storage data/0
+`
+
+// detachCommand detaches storage instances from their units.
+type detachCommand struct {
+	StorageCommandBase
+	storageIds []string
+	newAPIFunc func() (StorageDetachAPI, error)
+}
+
+// Init implements Command.Init.
+func (c *detachCommand) Init(args []string) error {
+	if len(args) == 0 {
+		return errors.New("detach-storage requires at least one storage ID")
+	}
+	for _, id := range args {
+		if !names.IsValidStorage(id) {
+			return errors.NotValidf("storage ID %q", id)
+		}
+	}
+	c.storageIds = args
+	return nil
+}
+
+// Info implements Command.Info.
+func (c *detachCommand) Info() *cmd.Info {
+	return &cmd.Info{
+		Name:    "detach-storage",
+		Purpose: "detaches storage instances from their units",
+		Doc:     detachCommandDoc,
+		Args:    "<storage ID> ...",
+	}
+}
+
+// Run implements Command.Run.
+func (c *detachCommand) Run(ctx *cmd.Context) error {
+	api, err := c.newAPIFunc()
+	if err != nil {
+		return err
+	}
+	defer api.Close()
+
+	results, err := api.Detach(c.storageIds)
+	if err != nil {
+		return block.ProcessBlockedError(err, block.BlockChange)
+	}
+	return reportStorageErrors(ctx, c.storageIds, results)
+}
+
+// StorageDetachAPI defines the API methods that the detach-storage
+// command uses.
+type StorageDetachAPI interface {
+	Close() error
+	Detach(storageIds []string) ([]params.ErrorResult, error)
+}
+
+// reportStorageErrors writes any errors in results, which correspond
+// to storageIds, to stderr. It returns cmd.ErrSilent if there were any.
+func reportStorageErrors(ctx *cmd.Context, storageIds []string, results []params.ErrorResult) error {
+	if len(results) != len(storageIds) {
+		return errors.Errorf("expected %d results, got %d", len(storageIds), len(results))
+	}
+	var failed bool
+	for i, result := range results {
+		if result.Error != nil {
+			fmt.Fprintf(ctx.Stderr, fail+": %v\n", storageIds[i], result.Error)
+			failed = true
+		}
+	}
+	if failed {
+		return cmd.ErrSilent
+	}
+	return nil
+}
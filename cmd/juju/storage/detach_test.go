@@ -0,0 +1,93 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package storage_test
+
+import (
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/cmd/juju/storage"
+	"github.com/juju/juju/testing"
+)
+
+type detachSuite struct {
+	SubStorageSuite
+	mockAPI *mockDetachAPI
+}
+
+var _ = gc.Suite(&detachSuite{})
+
+func (s *detachSuite) SetUpTest(c *gc.C) {
+	s.SubStorageSuite.SetUpTest(c)
+	s.mockAPI = &mockDetachAPI{}
+}
+
+func (s *detachSuite) runDetach(c *gc.C, args ...string) (*cmd.Context, error) {
+	return testing.RunCommand(c, storage.NewDetachCommandForTest(s.mockAPI, s.store), args...)
+}
+
+func (s *detachSuite) TestDetachInitErrors(c *gc.C) {
+	for i, t := range []struct {
+		args        []string
+		expectedErr string
+	}{
+		{nil, "detach-storage requires at least one storage ID"},
+		{[]string{"data"}, `storage ID "data" not valid`},
+		{[]string{"data/0", "foo"}, `storage ID "foo" not valid`},
+	} {
+		c.Logf("test %d for %q", i, t.args)
+		_, err := s.runDetach(c, t.args...)
+		c.Check(err, gc.ErrorMatches, t.expectedErr)
+	}
+}
+
+func (s *detachSuite) TestDetach(c *gc.C) {
+	context, err := s.runDetach(c, "data/0", "logs/1")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.mockAPI.storageIds, jc.DeepEquals, []string{"data/0", "logs/1"})
+	c.Assert(testing.Stdout(context), gc.Equals, "")
+	c.Assert(testing.Stderr(context), gc.Equals, "")
+}
+
+func (s *detachSuite) TestDetachFailure(c *gc.C) {
+	s.mockAPI.results = []params.ErrorResult{
+		{Error: &params.Error{Message: "storage is not owned by unit mysql/0"}},
+		{},
+	}
+	context, err := s.runDetach(c, "data/0", "logs/1")
+	c.Assert(err, gc.Equals, cmd.ErrSilent)
+	c.Assert(testing.Stderr(context), gc.Equals,
+		"fail: storage \"data/0\": storage is not owned by unit mysql/0\n",
+	)
+}
+
+func (s *detachSuite) TestDetachError(c *gc.C) {
+	s.mockAPI.err = errors.New("boom")
+	_, err := s.runDetach(c, "data/0")
+	c.Assert(err, gc.ErrorMatches, "boom")
+}
+
+type mockDetachAPI struct {
+	storageIds []string
+	results    []params.ErrorResult
+	err        error
+}
+
+func (m *mockDetachAPI) Close() error {
+	return nil
+}
+
+func (m *mockDetachAPI) Detach(storageIds []string) ([]params.ErrorResult, error) {
+	if m.err != nil {
+		return nil, m.err
+	}
+	m.storageIds = storageIds
+	if m.results != nil {
+		return m.results, nil
+	}
+	return make([]params.ErrorResult, len(storageIds)), nil
+}
@@ -54,3 +54,59 @@ func NewAddCommandForTest(api StorageAddAPI, store jujuclient.ClientStore) cmd.C
 	cmd.SetClientStore(store)
 	return modelcmd.Wrap(cmd)
 }
+
+func NewAttachCommandForTest(api StorageAttachAPI, store jujuclient.ClientStore) cmd.Command {
+	cmd := &attachCommand{newAPIFunc: func() (StorageAttachAPI, error) {
+		return api, nil
+	}}
+	cmd.SetClientStore(store)
+	return modelcmd.Wrap(cmd)
+}
+
+func NewDetachCommandForTest(api StorageDetachAPI, store jujuclient.ClientStore) cmd.Command {
+	cmd := &detachCommand{newAPIFunc: func() (StorageDetachAPI, error) {
+		return api, nil
+	}}
+	cmd.SetClientStore(store)
+	return modelcmd.Wrap(cmd)
+}
+
+func NewImportFilesystemCommandForTest(api StorageImportFilesystemAPI, store jujuclient.ClientStore) cmd.Command {
+	cmd := &importFilesystemCommand{newAPIFunc: func() (StorageImportFilesystemAPI, error) {
+		return api, nil
+	}}
+	cmd.SetClientStore(store)
+	return modelcmd.Wrap(cmd)
+}
+
+func NewCreateSnapshotCommandForTest(api StorageCreateSnapshotAPI, store jujuclient.ClientStore) cmd.Command {
+	cmd := &createSnapshotCommand{newAPIFunc: func() (StorageCreateSnapshotAPI, error) {
+		return api, nil
+	}}
+	cmd.SetClientStore(store)
+	return modelcmd.Wrap(cmd)
+}
+
+func NewRemoveSnapshotCommandForTest(api StorageRemoveSnapshotAPI, store jujuclient.ClientStore) cmd.Command {
+	cmd := &removeSnapshotCommand{newAPIFunc: func() (StorageRemoveSnapshotAPI, error) {
+		return api, nil
+	}}
+	cmd.SetClientStore(store)
+	return modelcmd.Wrap(cmd)
+}
+
+func NewListSnapshotsCommandForTest(api StorageListSnapshotsAPI, store jujuclient.ClientStore) cmd.Command {
+	cmd := &listSnapshotsCommand{newAPIFunc: func() (StorageListSnapshotsAPI, error) {
+		return api, nil
+	}}
+	cmd.SetClientStore(store)
+	return modelcmd.Wrap(cmd)
+}
+
+func NewResizeCommandForTest(api StorageResizeAPI, store jujuclient.ClientStore) cmd.Command {
+	cmd := &resizeCommand{newAPIFunc: func() (StorageResizeAPI, error) {
+		return api, nil
+	}}
+	cmd.SetClientStore(store)
+	return modelcmd.Wrap(cmd)
+}
@@ -0,0 +1,100 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package storage
+
+import (
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	"github.com/juju/names"
+
+	"github.com/juju/juju/cmd/juju/block"
+	"github.com/juju/juju/cmd/modelcmd"
+)
+
+// NewImportFilesystemCommand returns a command used to import a
+// filesystem backed by an existing volume.
+func NewImportFilesystemCommand() cmd.Command {
+	cmd := &importFilesystemCommand{}
+	cmd.newAPIFunc = func() (StorageImportFilesystemAPI, error) {
+		return cmd.NewStorageAPI()
+	}
+	return modelcmd.Wrap(cmd)
+}
+
+const importFilesystemCommandDoc = `
+Import an existing volume into the model as a filesystem.
+
+The volume is identified by the ID the storage provider assigned to
+it, and is managed by the specified storage pool, which must use a
+storage provider that supports importing volumes. The volume must not
+be in use. A storage instance with the given storage name is added to
+the model, and may then be attached to a unit whose charm declares
+filesystem storage with the same name, using juju attach-storage.
+
+Example:
+    Import an existing EBS volume as storage "pgdata":
+
+      juju import-filesystem ebs vol-123456 pgdata
+`
+
+// importFilesystemCommand imports a filesystem backed by an existing
+// volume into the model.
+type importFilesystemCommand struct {
+	StorageCommandBase
+	newAPIFunc  func() (StorageImportFilesystemAPI, error)
+	pool        string
+	providerId  string
+	storageName string
+}
+
+// Init implements Command.Init.
+func (c *importFilesystemCommand) Init(args []string) error {
+	if len(args) < 3 {
+		return errors.New("import-filesystem requires a storage pool, provider ID and storage name")
+	}
+	if err := cmd.CheckEmpty(args[3:]); err != nil {
+		return errors.Trace(err)
+	}
+	if !names.IsValidStorage(args[2] + "/0") {
+		return errors.NotValidf("storage name %q", args[2])
+	}
+	c.pool = args[0]
+	c.providerId = args[1]
+	c.storageName = args[2]
+	return nil
+}
+
+// Info implements Command.Info.
+func (c *importFilesystemCommand) Info() *cmd.Info {
+	return &cmd.Info{
+		Name:    "import-filesystem",
+		Purpose: "imports a filesystem backed by an existing volume into the model",
+		Doc:     importFilesystemCommandDoc,
+		Args:    "<storage pool> <provider ID> <storage name>",
+	}
+}
+
+// Run implements Command.Run.
+func (c *importFilesystemCommand) Run(ctx *cmd.Context) error {
+	api, err := c.newAPIFunc()
+	if err != nil {
+		return err
+	}
+	defer api.Close()
+
+	ctx.Infof("importing %q from storage pool %q as storage %q", c.providerId, c.pool, c.storageName)
+	storageTag, err := api.Import(c.pool, c.providerId, c.storageName)
+	if err != nil {
+		return block.ProcessBlockedError(err, block.BlockChange)
+	}
+	ctx.Infof("imported storage %s", storageTag.Id())
+	return nil
+}
+
+// StorageImportFilesystemAPI defines the API methods that the
+// import-filesystem command uses.
+type StorageImportFilesystemAPI interface {
+	Close() error
+	Import(pool, providerId, storageName string) (names.StorageTag, error)
+}
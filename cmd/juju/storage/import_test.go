@@ -0,0 +1,87 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package storage_test
+
+import (
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	"github.com/juju/names"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/cmd/juju/storage"
+	"github.com/juju/juju/testing"
+)
+
+type importFilesystemSuite struct {
+	SubStorageSuite
+	mockAPI *mockImportFilesystemAPI
+}
+
+var _ = gc.Suite(&importFilesystemSuite{})
+
+func (s *importFilesystemSuite) SetUpTest(c *gc.C) {
+	s.SubStorageSuite.SetUpTest(c)
+	s.mockAPI = &mockImportFilesystemAPI{}
+}
+
+func (s *importFilesystemSuite) runImportFilesystem(c *gc.C, args ...string) (*cmd.Context, error) {
+	return testing.RunCommand(c, storage.NewImportFilesystemCommandForTest(s.mockAPI, s.store), args...)
+}
+
+func (s *importFilesystemSuite) TestImportFilesystemInitErrors(c *gc.C) {
+	for i, t := range []struct {
+		args        []string
+		expectedErr string
+	}{
+		{nil, "import-filesystem requires a storage pool, provider ID and storage name"},
+		{[]string{"ebs", "vol-123"}, "import-filesystem requires a storage pool, provider ID and storage name"},
+		{[]string{"ebs", "vol-123", "pgdata/0"}, `storage name "pgdata/0" not valid`},
+		{[]string{"ebs", "vol-123", "pgdata", "extra"}, `unrecognized args: \["extra"\]`},
+	} {
+		c.Logf("test %d for %q", i, t.args)
+		_, err := s.runImportFilesystem(c, t.args...)
+		c.Check(err, gc.ErrorMatches, t.expectedErr)
+	}
+}
+
+func (s *importFilesystemSuite) TestImportFilesystem(c *gc.C) {
+	context, err := s.runImportFilesystem(c, "ebs", "vol-123", "pgdata")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.mockAPI.pool, gc.Equals, "ebs")
+	c.Assert(s.mockAPI.providerId, gc.Equals, "vol-123")
+	c.Assert(s.mockAPI.storageName, gc.Equals, "pgdata")
+	c.Assert(testing.Stdout(context), gc.Equals, "")
+	c.Assert(testing.Stderr(context), gc.Equals, `
+importing "vol-123" from storage pool "ebs" as storage "pgdata"
+imported storage pgdata/0
+`[1:])
+}
+
+func (s *importFilesystemSuite) TestImportFilesystemError(c *gc.C) {
+	s.mockAPI.err = errors.New("volume is in use")
+	_, err := s.runImportFilesystem(c, "ebs", "vol-123", "pgdata")
+	c.Assert(err, gc.ErrorMatches, "volume is in use")
+}
+
+type mockImportFilesystemAPI struct {
+	pool        string
+	providerId  string
+	storageName string
+	err         error
+}
+
+func (m *mockImportFilesystemAPI) Close() error {
+	return nil
+}
+
+func (m *mockImportFilesystemAPI) Import(pool, providerId, storageName string) (names.StorageTag, error) {
+	if m.err != nil {
+		return names.StorageTag{}, m.err
+	}
+	m.pool = pool
+	m.providerId = providerId
+	m.storageName = storageName
+	return names.NewStorageTag(storageName + "/0"), nil
+}
@@ -0,0 +1,104 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package storage
+
+import (
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	"github.com/juju/names"
+	"github.com/juju/utils"
+
+	"github.com/juju/juju/cmd/juju/block"
+	"github.com/juju/juju/cmd/modelcmd"
+)
+
+// NewResizeCommand returns a command used to resize storage.
+func NewResizeCommand() cmd.Command {
+	cmd := &resizeCommand{}
+	cmd.newAPIFunc = func() (StorageResizeAPI, error) {
+		return cmd.NewStorageAPI()
+	}
+	return modelcmd.Wrap(cmd)
+}
+
+const resizeCommandDoc = `
+Grow the volume backing a storage instance to a new size.
+
+The size is given in the same form as for juju add-storage, and must
+be larger than the current size of the storage; storage cannot be
+shrunk. Filesystem storage may be resized only if the filesystem is
+backed by a volume. The volume is resized online by the storage
+provider that manages it, which must support resizing volumes. Once
+the volume has been resized, the "storage-resized" hook is run for
+the units the storage is attached to, so that their charms may grow
+the filesystem to fill the volume.
+
+Examples:
+    Grow storage pgdata/0 to 100GiB:
+
+      juju resize-storage pgdata/0 100G
+`
+
+// resizeCommand resizes a storage instance.
+type resizeCommand struct {
+	StorageCommandBase
+	newAPIFunc func() (StorageResizeAPI, error)
+	storageId  string
+	size       uint64
+}
+
+// Init implements Command.Init.
+func (c *resizeCommand) Init(args []string) error {
+	if len(args) < 2 {
+		return errors.New("resize-storage requires a storage ID and a new size")
+	}
+	if err := cmd.CheckEmpty(args[2:]); err != nil {
+		return errors.Trace(err)
+	}
+	if !names.IsValidStorage(args[0]) {
+		return errors.NotValidf("storage ID %q", args[0])
+	}
+	size, err := utils.ParseSize(args[1])
+	if err != nil {
+		return errors.Annotatef(err, "cannot parse size %q", args[1])
+	}
+	if size == 0 {
+		return errors.NotValidf("size %q", args[1])
+	}
+	c.storageId = args[0]
+	c.size = size
+	return nil
+}
+
+// Info implements Command.Info.
+func (c *resizeCommand) Info() *cmd.Info {
+	return &cmd.Info{
+		Name:    "resize-storage",
+		Purpose: "grows storage to a new size",
+		Doc:     resizeCommandDoc,
+		Args:    "<storage ID> <new size>",
+	}
+}
+
+// Run implements Command.Run.
+func (c *resizeCommand) Run(ctx *cmd.Context) error {
+	api, err := c.newAPIFunc()
+	if err != nil {
+		return err
+	}
+	defer api.Close()
+
+	if err := api.Resize(c.storageId, c.size); err != nil {
+		return block.ProcessBlockedError(err, block.BlockChange)
+	}
+	ctx.Infof("resizing storage %s to %dMiB", c.storageId, c.size)
+	return nil
+}
+
+// StorageResizeAPI defines the API methods that the resize-storage
+// command uses.
+type StorageResizeAPI interface {
+	Close() error
+	Resize(storageId string, size uint64) error
+}
@@ -0,0 +1,79 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package storage_test
+
+import (
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/cmd/juju/storage"
+	"github.com/juju/juju/testing"
+)
+
+type resizeSuite struct {
+	SubStorageSuite
+	mockAPI *mockResizeAPI
+}
+
+var _ = gc.Suite(&resizeSuite{})
+
+func (s *resizeSuite) SetUpTest(c *gc.C) {
+	s.SubStorageSuite.SetUpTest(c)
+	s.mockAPI = &mockResizeAPI{}
+}
+
+func (s *resizeSuite) runResize(c *gc.C, args ...string) (*cmd.Context, error) {
+	return testing.RunCommand(c, storage.NewResizeCommandForTest(s.mockAPI, s.store), args...)
+}
+
+func (s *resizeSuite) TestResizeInitErrors(c *gc.C) {
+	for i, t := range []struct {
+		args        []string
+		expectedErr string
+	}{
+		{nil, "resize-storage requires a storage ID and a new size"},
+		{[]string{"pgdata/0"}, "resize-storage requires a storage ID and a new size"},
+		{[]string{"pgdata", "10G"}, `storage ID "pgdata" not valid`},
+		{[]string{"pgdata/0", "big"}, `cannot parse size "big": .*`},
+		{[]string{"pgdata/0", "0"}, `size "0" not valid`},
+		{[]string{"pgdata/0", "10G", "extra"}, `unrecognized args: \["extra"\]`},
+	} {
+		c.Logf("test %d for %q", i, t.args)
+		_, err := s.runResize(c, t.args...)
+		c.Check(err, gc.ErrorMatches, t.expectedErr)
+	}
+}
+
+func (s *resizeSuite) TestResize(c *gc.C) {
+	context, err := s.runResize(c, "pgdata/0", "10G")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.mockAPI.storageId, gc.Equals, "pgdata/0")
+	c.Assert(s.mockAPI.size, gc.Equals, uint64(10*1024))
+	c.Assert(testing.Stdout(context), gc.Equals, "")
+	c.Assert(testing.Stderr(context), gc.Equals, "resizing storage pgdata/0 to 10240MiB\n")
+}
+
+func (s *resizeSuite) TestResizeError(c *gc.C) {
+	s.mockAPI.err = errors.New(`resizing volumes with storage provider "maas" not supported`)
+	_, err := s.runResize(c, "pgdata/0", "10G")
+	c.Assert(err, gc.ErrorMatches, `resizing volumes with storage provider "maas" not supported`)
+}
+
+type mockResizeAPI struct {
+	storageId string
+	size      uint64
+	err       error
+}
+
+func (m *mockResizeAPI) Close() error {
+	return nil
+}
+
+func (m *mockResizeAPI) Resize(storageId string, size uint64) error {
+	m.storageId = storageId
+	m.size = size
+	return m.err
+}
@@ -0,0 +1,319 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package storage
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	"github.com/juju/names"
+	"launchpad.net/gnuflag"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/cmd/juju/block"
+	"github.com/juju/juju/cmd/modelcmd"
+)
+
+// NewCreateSnapshotCommand returns a command used to create snapshots
+// of storage.
+func NewCreateSnapshotCommand() cmd.Command {
+	cmd := &createSnapshotCommand{}
+	cmd.newAPIFunc = func() (StorageCreateSnapshotAPI, error) {
+		return cmd.NewStorageAPI()
+	}
+	return modelcmd.Wrap(cmd)
+}
+
+const createSnapshotCommandDoc = `
+Create snapshots of the volumes backing storage instances.
+
+Snapshots are made by the storage provider that manages the volume,
+which must support snapshots. Filesystem storage may be snapshotted
+only if the filesystem is backed by a volume. The snapshots are
+recorded in the model, and may be listed with juju list-storage-snapshots.
+New storage may be created from a snapshot by passing the snapshot ID
+to juju add-storage --from-snapshot. Snapshots that are no longer
+needed may be removed with juju remove-storage-snapshot.
+
+Examples:
+    Create a snapshot of storage pgdata/0:
+
+      juju create-storage-snapshot pgdata/0
+`
+
+// createSnapshotCommand creates snapshots of storage instances.
+type createSnapshotCommand struct {
+	StorageCommandBase
+	newAPIFunc func() (StorageCreateSnapshotAPI, error)
+	storageIds []string
+}
+
+// Init implements Command.Init.
+func (c *createSnapshotCommand) Init(args []string) error {
+	if len(args) < 1 {
+		return errors.New("create-storage-snapshot requires a storage ID")
+	}
+	for _, storageId := range args {
+		if !names.IsValidStorage(storageId) {
+			return errors.NotValidf("storage ID %q", storageId)
+		}
+	}
+	c.storageIds = args
+	return nil
+}
+
+// Info implements Command.Info.
+func (c *createSnapshotCommand) Info() *cmd.Info {
+	return &cmd.Info{
+		Name:    "create-storage-snapshot",
+		Purpose: "creates snapshots of storage",
+		Doc:     createSnapshotCommandDoc,
+		Args:    "<storage ID> [<storage ID> ...]",
+	}
+}
+
+// Run implements Command.Run.
+func (c *createSnapshotCommand) Run(ctx *cmd.Context) error {
+	api, err := c.newAPIFunc()
+	if err != nil {
+		return err
+	}
+	defer api.Close()
+
+	results, err := api.CreateSnapshots(c.storageIds)
+	if err != nil {
+		return block.ProcessBlockedError(err, block.BlockChange)
+	}
+	if len(results) != len(c.storageIds) {
+		return errors.Errorf("expected %d results, got %d", len(c.storageIds), len(results))
+	}
+	var failed bool
+	for i, result := range results {
+		if result.Error != nil {
+			fmt.Fprintf(ctx.Stderr, fail+": %v\n", c.storageIds[i], result.Error)
+			failed = true
+			continue
+		}
+		ctx.Infof("created snapshot %s of storage %s", result.Result.Id, c.storageIds[i])
+	}
+	if failed {
+		return cmd.ErrSilent
+	}
+	return nil
+}
+
+// StorageCreateSnapshotAPI defines the API methods that the
+// create-storage-snapshot command uses.
+type StorageCreateSnapshotAPI interface {
+	Close() error
+	CreateSnapshots(storageIds []string) ([]params.VolumeSnapshotResult, error)
+}
+
+// NewListSnapshotsCommand returns a command used to list storage
+// snapshots.
+func NewListSnapshotsCommand() cmd.Command {
+	cmd := &listSnapshotsCommand{}
+	cmd.newAPIFunc = func() (StorageListSnapshotsAPI, error) {
+		return cmd.NewStorageAPI()
+	}
+	return modelcmd.Wrap(cmd)
+}
+
+const listSnapshotsCommandDoc = `
+List the snapshots of storage recorded in the model.
+
+options:
+-m, --model (= "")
+   juju model to operate in
+-o, --output (= "")
+   specify an output file
+--format (= tabular)
+   specify output format (json|tabular|yaml)
+`
+
+// listSnapshotsCommand lists storage snapshots.
+type listSnapshotsCommand struct {
+	StorageCommandBase
+	newAPIFunc func() (StorageListSnapshotsAPI, error)
+	out        cmd.Output
+}
+
+// SnapshotInfo defines the serialization behaviour of storage
+// snapshot information.
+type SnapshotInfo struct {
+	Storage    string    `yaml:"storage,omitempty" json:"storage,omitempty"`
+	Volume     string    `yaml:"volume" json:"volume"`
+	Pool       string    `yaml:"pool" json:"pool"`
+	ProviderId string    `yaml:"provider-id" json:"provider-id"`
+	Size       uint64    `yaml:"size" json:"size"`
+	Created    time.Time `yaml:"created" json:"created"`
+}
+
+// Init implements Command.Init.
+func (c *listSnapshotsCommand) Init(args []string) error {
+	return cmd.CheckEmpty(args)
+}
+
+// Info implements Command.Info.
+func (c *listSnapshotsCommand) Info() *cmd.Info {
+	return &cmd.Info{
+		Name:    "list-storage-snapshots",
+		Purpose: "lists storage snapshots",
+		Doc:     listSnapshotsCommandDoc,
+	}
+}
+
+// SetFlags implements Command.SetFlags.
+func (c *listSnapshotsCommand) SetFlags(f *gnuflag.FlagSet) {
+	c.StorageCommandBase.SetFlags(f)
+	c.out.AddFlags(f, "tabular", map[string]cmd.Formatter{
+		"yaml":    cmd.FormatYaml,
+		"json":    cmd.FormatJson,
+		"tabular": formatSnapshotListTabular,
+	})
+}
+
+// Run implements Command.Run.
+func (c *listSnapshotsCommand) Run(ctx *cmd.Context) error {
+	api, err := c.newAPIFunc()
+	if err != nil {
+		return err
+	}
+	defer api.Close()
+
+	snapshots, err := api.ListSnapshots()
+	if err != nil {
+		return err
+	}
+	if len(snapshots) == 0 {
+		return nil
+	}
+	output, err := formatSnapshotInfo(snapshots)
+	if err != nil {
+		return err
+	}
+	return c.out.Write(ctx, output)
+}
+
+func formatSnapshotInfo(all []params.VolumeSnapshotDetails) (map[string]SnapshotInfo, error) {
+	output := make(map[string]SnapshotInfo)
+	for _, one := range all {
+		volumeTag, err := names.ParseVolumeTag(one.VolumeTag)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		info := SnapshotInfo{
+			Volume:     volumeTag.Id(),
+			Pool:       one.Pool,
+			ProviderId: one.SnapshotId,
+			Size:       one.Size,
+			Created:    one.Created,
+		}
+		if one.StorageTag != "" {
+			storageTag, err := names.ParseStorageTag(one.StorageTag)
+			if err != nil {
+				return nil, errors.Trace(err)
+			}
+			info.Storage = storageTag.Id()
+		}
+		output[one.Id] = info
+	}
+	return output, nil
+}
+
+// StorageListSnapshotsAPI defines the API methods that the
+// list-storage-snapshots command uses.
+type StorageListSnapshotsAPI interface {
+	Close() error
+	ListSnapshots() ([]params.VolumeSnapshotDetails, error)
+}
+
+// NewRemoveSnapshotCommand returns a command used to remove storage
+// snapshots.
+func NewRemoveSnapshotCommand() cmd.Command {
+	cmd := &removeSnapshotCommand{}
+	cmd.newAPIFunc = func() (StorageRemoveSnapshotAPI, error) {
+		return cmd.NewStorageAPI()
+	}
+	return modelcmd.Wrap(cmd)
+}
+
+const removeSnapshotCommandDoc = `
+Remove snapshots of storage.
+
+The snapshots are destroyed by the storage provider that created them,
+and removed from the model. A snapshot cannot be removed while storage
+that is to be created from it remains. Snapshot IDs are listed by
+juju list-storage-snapshots.
+
+Examples:
+    Remove snapshot 3:
+
+      juju remove-storage-snapshot 3
+`
+
+// removeSnapshotCommand removes storage snapshots.
+type removeSnapshotCommand struct {
+	StorageCommandBase
+	newAPIFunc  func() (StorageRemoveSnapshotAPI, error)
+	snapshotIds []string
+}
+
+// Init implements Command.Init.
+func (c *removeSnapshotCommand) Init(args []string) error {
+	if len(args) < 1 {
+		return errors.New("remove-storage-snapshot requires a snapshot ID")
+	}
+	c.snapshotIds = args
+	return nil
+}
+
+// Info implements Command.Info.
+func (c *removeSnapshotCommand) Info() *cmd.Info {
+	return &cmd.Info{
+		Name:    "remove-storage-snapshot",
+		Purpose: "removes snapshots of storage",
+		Doc:     removeSnapshotCommandDoc,
+		Args:    "<snapshot ID> [<snapshot ID> ...]",
+	}
+}
+
+// Run implements Command.Run.
+func (c *removeSnapshotCommand) Run(ctx *cmd.Context) error {
+	api, err := c.newAPIFunc()
+	if err != nil {
+		return err
+	}
+	defer api.Close()
+
+	results, err := api.DestroySnapshots(c.snapshotIds)
+	if err != nil {
+		return block.ProcessBlockedError(err, block.BlockChange)
+	}
+	if len(results) != len(c.snapshotIds) {
+		return errors.Errorf("expected %d results, got %d", len(c.snapshotIds), len(results))
+	}
+	var failed bool
+	for i, result := range results {
+		if result.Error != nil {
+			fmt.Fprintf(ctx.Stderr, "fail: snapshot %q: %v\n", c.snapshotIds[i], result.Error)
+			failed = true
+			continue
+		}
+		ctx.Infof("removed snapshot %s", c.snapshotIds[i])
+	}
+	if failed {
+		return cmd.ErrSilent
+	}
+	return nil
+}
+
+// StorageRemoveSnapshotAPI defines the API methods that the
+// remove-storage-snapshot command uses.
+type StorageRemoveSnapshotAPI interface {
+	Close() error
+	DestroySnapshots(ids []string) ([]params.ErrorResult, error)
+}
@@ -0,0 +1,274 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package storage_test
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/cmd/juju/storage"
+	"github.com/juju/juju/testing"
+)
+
+type createSnapshotSuite struct {
+	SubStorageSuite
+	mockAPI *mockCreateSnapshotAPI
+}
+
+var _ = gc.Suite(&createSnapshotSuite{})
+
+func (s *createSnapshotSuite) SetUpTest(c *gc.C) {
+	s.SubStorageSuite.SetUpTest(c)
+	s.mockAPI = &mockCreateSnapshotAPI{}
+}
+
+func (s *createSnapshotSuite) runCreateSnapshot(c *gc.C, args ...string) (*cmd.Context, error) {
+	return testing.RunCommand(c, storage.NewCreateSnapshotCommandForTest(s.mockAPI, s.store), args...)
+}
+
+func (s *createSnapshotSuite) TestCreateSnapshotInitErrors(c *gc.C) {
+	for i, t := range []struct {
+		args        []string
+		expectedErr string
+	}{
+		{nil, "create-storage-snapshot requires a storage ID"},
+		{[]string{"pgdata"}, `storage ID "pgdata" not valid`},
+	} {
+		c.Logf("test %d for %q", i, t.args)
+		_, err := s.runCreateSnapshot(c, t.args...)
+		c.Check(err, gc.ErrorMatches, t.expectedErr)
+	}
+}
+
+func (s *createSnapshotSuite) TestCreateSnapshot(c *gc.C) {
+	context, err := s.runCreateSnapshot(c, "pgdata/0", "pgdata/1")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.mockAPI.storageIds, jc.DeepEquals, []string{"pgdata/0", "pgdata/1"})
+	c.Assert(testing.Stdout(context), gc.Equals, "")
+	c.Assert(testing.Stderr(context), gc.Equals, `
+created snapshot 0 of storage pgdata/0
+created snapshot 1 of storage pgdata/1
+`[1:])
+}
+
+func (s *createSnapshotSuite) TestCreateSnapshotFailure(c *gc.C) {
+	s.mockAPI.results = []params.VolumeSnapshotResult{{
+		Result: &params.VolumeSnapshotDetails{Id: "0"},
+	}, {
+		Error: &params.Error{Message: "volume-1 not provisioned"},
+	}}
+	context, err := s.runCreateSnapshot(c, "pgdata/0", "pgdata/1")
+	c.Assert(err, gc.Equals, cmd.ErrSilent)
+	c.Assert(testing.Stderr(context), gc.Equals, `
+created snapshot 0 of storage pgdata/0
+fail: storage "pgdata/1": volume-1 not provisioned
+`[1:])
+}
+
+func (s *createSnapshotSuite) TestCreateSnapshotError(c *gc.C) {
+	s.mockAPI.err = errors.New("snapshots not supported")
+	_, err := s.runCreateSnapshot(c, "pgdata/0")
+	c.Assert(err, gc.ErrorMatches, "snapshots not supported")
+}
+
+type mockCreateSnapshotAPI struct {
+	storageIds []string
+	results    []params.VolumeSnapshotResult
+	err        error
+}
+
+func (m *mockCreateSnapshotAPI) Close() error {
+	return nil
+}
+
+func (m *mockCreateSnapshotAPI) CreateSnapshots(storageIds []string) ([]params.VolumeSnapshotResult, error) {
+	if m.err != nil {
+		return nil, m.err
+	}
+	m.storageIds = storageIds
+	if m.results != nil {
+		return m.results, nil
+	}
+	results := make([]params.VolumeSnapshotResult, len(storageIds))
+	for i := range storageIds {
+		results[i].Result = &params.VolumeSnapshotDetails{
+			Id: fmt.Sprint(i),
+		}
+	}
+	return results, nil
+}
+
+type listSnapshotsSuite struct {
+	SubStorageSuite
+	mockAPI *mockListSnapshotsAPI
+}
+
+var _ = gc.Suite(&listSnapshotsSuite{})
+
+func (s *listSnapshotsSuite) SetUpTest(c *gc.C) {
+	s.SubStorageSuite.SetUpTest(c)
+	created := time.Date(2016, 6, 1, 12, 0, 0, 0, time.UTC)
+	s.mockAPI = &mockListSnapshotsAPI{
+		snapshots: []params.VolumeSnapshotDetails{{
+			Id:         "10",
+			VolumeTag:  "volume-1",
+			SnapshotId: "snap-10",
+			Pool:       "ebs",
+			Size:       2048,
+			Created:    created,
+		}, {
+			Id:         "2",
+			StorageTag: "storage-pgdata-0",
+			VolumeTag:  "volume-0",
+			SnapshotId: "snap-2",
+			Pool:       "ebs",
+			Size:       1024,
+			Created:    created,
+		}},
+	}
+}
+
+func (s *listSnapshotsSuite) runListSnapshots(c *gc.C, args ...string) (*cmd.Context, error) {
+	return testing.RunCommand(c, storage.NewListSnapshotsCommandForTest(s.mockAPI, s.store), args...)
+}
+
+func (s *listSnapshotsSuite) TestListSnapshotsTabular(c *gc.C) {
+	context, err := s.runListSnapshots(c)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(testing.Stdout(context), gc.Equals, `
+ID  STORAGE   VOLUME  POOL  PROVIDER-ID  SIZE    CREATED
+2   pgdata/0  0       ebs   snap-2       1.0GiB  2016-06-01T12:00:00Z
+10            1       ebs   snap-10      2.0GiB  2016-06-01T12:00:00Z
+
+`[1:])
+}
+
+func (s *listSnapshotsSuite) TestListSnapshotsJSON(c *gc.C) {
+	context, err := s.runListSnapshots(c, "--format", "json")
+	c.Assert(err, jc.ErrorIsNil)
+	var result map[string]storage.SnapshotInfo
+	err = json.Unmarshal([]byte(testing.Stdout(context)), &result)
+	c.Assert(err, jc.ErrorIsNil)
+	created := time.Date(2016, 6, 1, 12, 0, 0, 0, time.UTC)
+	c.Assert(result, jc.DeepEquals, map[string]storage.SnapshotInfo{
+		"2": {
+			Storage:    "pgdata/0",
+			Volume:     "0",
+			Pool:       "ebs",
+			ProviderId: "snap-2",
+			Size:       1024,
+			Created:    created,
+		},
+		"10": {
+			Volume:     "1",
+			Pool:       "ebs",
+			ProviderId: "snap-10",
+			Size:       2048,
+			Created:    created,
+		},
+	})
+}
+
+func (s *listSnapshotsSuite) TestListSnapshotsEmpty(c *gc.C) {
+	s.mockAPI.snapshots = nil
+	context, err := s.runListSnapshots(c)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(testing.Stdout(context), gc.Equals, "")
+}
+
+func (s *listSnapshotsSuite) TestListSnapshotsError(c *gc.C) {
+	s.mockAPI.err = errors.New("no snapshots for you")
+	_, err := s.runListSnapshots(c)
+	c.Assert(err, gc.ErrorMatches, "no snapshots for you")
+}
+
+type mockListSnapshotsAPI struct {
+	snapshots []params.VolumeSnapshotDetails
+	err       error
+}
+
+func (m *mockListSnapshotsAPI) Close() error {
+	return nil
+}
+
+func (m *mockListSnapshotsAPI) ListSnapshots() ([]params.VolumeSnapshotDetails, error) {
+	return m.snapshots, m.err
+}
+
+type removeSnapshotSuite struct {
+	SubStorageSuite
+	mockAPI *mockRemoveSnapshotAPI
+}
+
+var _ = gc.Suite(&removeSnapshotSuite{})
+
+func (s *removeSnapshotSuite) SetUpTest(c *gc.C) {
+	s.SubStorageSuite.SetUpTest(c)
+	s.mockAPI = &mockRemoveSnapshotAPI{}
+}
+
+func (s *removeSnapshotSuite) runRemoveSnapshot(c *gc.C, args ...string) (*cmd.Context, error) {
+	return testing.RunCommand(c, storage.NewRemoveSnapshotCommandForTest(s.mockAPI, s.store), args...)
+}
+
+func (s *removeSnapshotSuite) TestRemoveSnapshotNoArgs(c *gc.C) {
+	_, err := s.runRemoveSnapshot(c)
+	c.Assert(err, gc.ErrorMatches, "remove-storage-snapshot requires a snapshot ID")
+}
+
+func (s *removeSnapshotSuite) TestRemoveSnapshot(c *gc.C) {
+	context, err := s.runRemoveSnapshot(c, "0", "1")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.mockAPI.ids, jc.DeepEquals, []string{"0", "1"})
+	c.Assert(testing.Stderr(context), gc.Equals, `
+removed snapshot 0
+removed snapshot 1
+`[1:])
+}
+
+func (s *removeSnapshotSuite) TestRemoveSnapshotFailure(c *gc.C) {
+	s.mockAPI.results = []params.ErrorResult{
+		{}, {Error: &params.Error{Message: `snapshot is used by storage "pgdata/1"`}},
+	}
+	context, err := s.runRemoveSnapshot(c, "0", "1")
+	c.Assert(err, gc.Equals, cmd.ErrSilent)
+	c.Assert(testing.Stderr(context), gc.Equals, `
+removed snapshot 0
+fail: snapshot "1": snapshot is used by storage "pgdata/1"
+`[1:])
+}
+
+func (s *removeSnapshotSuite) TestRemoveSnapshotError(c *gc.C) {
+	s.mockAPI.err = errors.New("snapshots not supported")
+	_, err := s.runRemoveSnapshot(c, "0")
+	c.Assert(err, gc.ErrorMatches, "snapshots not supported")
+}
+
+type mockRemoveSnapshotAPI struct {
+	ids     []string
+	results []params.ErrorResult
+	err     error
+}
+
+func (m *mockRemoveSnapshotAPI) Close() error {
+	return nil
+}
+
+func (m *mockRemoveSnapshotAPI) DestroySnapshots(ids []string) ([]params.ErrorResult, error) {
+	if m.err != nil {
+		return nil, m.err
+	}
+	m.ids = ids
+	if m.results != nil {
+		return m.results, nil
+	}
+	return make([]params.ErrorResult, len(ids)), nil
+}
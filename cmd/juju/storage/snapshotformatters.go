@@ -0,0 +1,58 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package storage
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/dustin/go-humanize"
+	"github.com/juju/errors"
+
+	"github.com/juju/juju/cmd/juju/common"
+)
+
+// formatSnapshotListTabular returns a tabular summary of storage
+// snapshots or errors out if parameter is not a map of SnapshotInfo.
+func formatSnapshotListTabular(value interface{}) ([]byte, error) {
+	snapshots, ok := value.(map[string]SnapshotInfo)
+	if !ok {
+		return nil, errors.Errorf("expected value of type %T, got %T", snapshots, value)
+	}
+	var out bytes.Buffer
+	const (
+		// To format things into columns.
+		minwidth = 0
+		tabwidth = 1
+		padding  = 2
+		padchar  = ' '
+		flags    = 0
+	)
+	tw := tabwriter.NewWriter(&out, minwidth, tabwidth, padding, padchar, flags)
+	print := func(values ...string) {
+		fmt.Fprintln(tw, strings.Join(values, "\t"))
+	}
+
+	print("ID", "STORAGE", "VOLUME", "POOL", "PROVIDER-ID", "SIZE", "CREATED")
+
+	ids := make([]string, 0, len(snapshots))
+	for id := range snapshots {
+		ids = append(ids, id)
+	}
+	for _, id := range common.SortStringsNaturally(ids) {
+		snapshot := snapshots[id]
+		print(
+			id, snapshot.Storage, snapshot.Volume, snapshot.Pool,
+			snapshot.ProviderId,
+			humanize.IBytes(snapshot.Size*humanize.MiByte),
+			snapshot.Created.Format(time.RFC3339),
+		)
+	}
+	tw.Flush()
+
+	return out.Bytes(), nil
+}
@@ -21,7 +21,8 @@ Show information about a user.`[1:]
 
 var helpDetails = `
 By default, the YAML format is used and the user name is the current
-user.
+user. The most recent failed attempts to log in as the user, if any,
+are shown with the addresses they were made from.
 
 
 Examples:
@@ -70,6 +71,15 @@ type UserInfo struct {
 	DateCreated    string `yaml:"date-created" json:"date-created"`
 	LastConnection string `yaml:"last-connection" json:"last-connection"`
 	Disabled       bool   `yaml:"disabled,omitempty" json:"disabled,omitempty"`
+
+	FailedLogins []FailedLogin `yaml:"failed-logins,omitempty" json:"failed-logins,omitempty"`
+}
+
+// FailedLogin defines the serialization behaviour of a failed login
+// attempt.
+type FailedLogin struct {
+	Time    string `yaml:"time" json:"time"`
+	Address string `yaml:"address" json:"address"`
 }
 
 // Info implements Command.Info.
@@ -147,6 +157,15 @@ func (c *infoCommandBase) apiUsersToUserInfoSlice(users []params.UserInfo) []Use
 		} else {
 			outInfo.DateCreated = common.UserFriendlyDuration(info.DateCreated, now)
 		}
+		for _, failure := range info.FailedLogins {
+			failedLogin := FailedLogin{Address: failure.RemoteAddress}
+			if c.exactTime {
+				failedLogin.Time = failure.Time.String()
+			} else {
+				failedLogin.Time = common.UserFriendlyDuration(failure.Time, now)
+			}
+			outInfo.FailedLogins = append(outInfo.FailedLogins, failedLogin)
+		}
 
 		output = append(output, outInfo)
 	}
@@ -32,6 +32,7 @@ var (
 	// Mock out timestamps
 	dateCreated    = time.Unix(352138205, 0).UTC()
 	lastConnection = time.Unix(1388534400, 0).UTC()
+	failedLogin    = time.Unix(1388620800, 0).UTC()
 )
 
 func (s *UserInfoCommandSuite) NewShowUserCommand() cmd.Command {
@@ -56,6 +57,12 @@ func (*fakeUserInfoAPI) UserInfo(usernames []string, all usermanager.IncludeDisa
 	case "foobar":
 		info.Username = "foobar"
 		info.DisplayName = "Foo Bar"
+	case "locked":
+		info.Username = "locked"
+		info.FailedLogins = []params.FailedLogin{
+			{Time: failedLogin, RemoteAddress: "10.0.0.1"},
+			{Time: failedLogin, RemoteAddress: "10.0.0.2"},
+		}
 	default:
 		return nil, common.ErrPerm
 	}
@@ -92,6 +99,29 @@ last-connection: 2014-01-01
 `)
 }
 
+func (s *UserInfoCommandSuite) TestUserInfoFailedLogins(c *gc.C) {
+	context, err := testing.RunCommand(c, s.NewShowUserCommand(), "locked")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(testing.Stdout(context), gc.Equals, `user-name: locked
+display-name: ""
+date-created: 1981-02-27
+last-connection: 2014-01-01
+failed-logins:
+- time: 2014-01-02
+  address: 10.0.0.1
+- time: 2014-01-02
+  address: 10.0.0.2
+`)
+}
+
+func (s *UserInfoCommandSuite) TestUserInfoFailedLoginsExactTime(c *gc.C) {
+	context, err := testing.RunCommand(c, s.NewShowUserCommand(), "locked", "--exact-time", "--format", "json")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(testing.Stdout(context), gc.Equals, `
+{"user-name":"locked","display-name":"","date-created":"1981-02-27 16:10:05 +0000 UTC","last-connection":"2014-01-01 00:00:00 +0000 UTC","failed-logins":[{"time":"2014-01-02 00:00:00 +0000 UTC","address":"10.0.0.1"},{"time":"2014-01-02 00:00:00 +0000 UTC","address":"10.0.0.2"}]}
+`[1:])
+}
+
 func (s *UserInfoCommandSuite) TestUserInfoUserDoesNotExist(c *gc.C) {
 	_, err := testing.RunCommand(c, s.NewShowUserCommand(), "barfoo")
 	c.Assert(err, gc.ErrorMatches, "permission denied")
@@ -0,0 +1,64 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package agent
+
+import (
+	"github.com/juju/juju/agent"
+	"github.com/juju/juju/cmd/pprof"
+	"github.com/juju/juju/worker"
+)
+
+// registerIntrospection makes the report of the worker, if it has one,
+// available on the introspection socket under the given kind and name
+// until the worker stops.
+func registerIntrospection(kind, name string, w worker.Worker) {
+	reporter, ok := w.(pprof.Reporter)
+	if !ok {
+		return
+	}
+	unregister := pprof.Register(kind, name, reporter)
+	go func() {
+		w.Wait()
+		unregister()
+	}()
+}
+
+// registerAgentConfig makes a sanitised copy of the agent's current
+// configuration available on the introspection socket. It returns a
+// function that unregisters it.
+func registerAgentConfig(currentConfig func() agent.Config) func() {
+	return pprof.Register(pprof.KindAgent, "config", pprof.ReporterFunc(func() map[string]interface{} {
+		return agentConfigReport(currentConfig())
+	}))
+}
+
+// agentConfigReport returns a report of the agent configuration that
+// leaves out its secrets: passwords, keys, the CA certificate and the
+// machine nonce.
+func agentConfigReport(config agent.Config) map[string]interface{} {
+	jobs := make([]string, len(config.Jobs()))
+	for i, job := range config.Jobs() {
+		jobs[i] = string(job)
+	}
+	report := map[string]interface{}{
+		"tag":                 config.Tag().String(),
+		"model":               config.Model().String(),
+		"data-dir":            config.DataDir(),
+		"log-dir":             config.LogDir(),
+		"jobs":                jobs,
+		"upgraded-to-version": config.UpgradedToVersion().String(),
+	}
+	if addrs, err := config.APIAddresses(); err == nil {
+		report["api-addresses"] = addrs
+	}
+	if info, ok := config.StateServingInfo(); ok {
+		report["api-port"] = info.APIPort
+		report["state-port"] = info.StatePort
+		report["mongo-version"] = config.MongoVersion().String()
+	}
+	if providerType := config.Value(agent.ProviderType); providerType != "" {
+		report["provider-type"] = providerType
+	}
+	return report
+}
@@ -0,0 +1,61 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package agent
+
+import (
+	"github.com/juju/names"
+	jc "github.com/juju/testing/checkers"
+	"github.com/juju/version"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/agent"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/mongo"
+	"github.com/juju/juju/state/multiwatcher"
+	coretesting "github.com/juju/juju/testing"
+)
+
+type introspectionSuite struct {
+	coretesting.BaseSuite
+}
+
+var _ = gc.Suite(&introspectionSuite{})
+
+func (s *introspectionSuite) TestAgentConfigReport(c *gc.C) {
+	config, err := agent.NewAgentConfig(agent.AgentConfigParams{
+		Paths:             agent.Paths{DataDir: "/var/lib/juju", LogDir: "/var/log/juju"},
+		Jobs:              []multiwatcher.MachineJob{multiwatcher.JobManageModel},
+		UpgradedToVersion: version.MustParse("2.0.0"),
+		Tag:               names.NewMachineTag("0"),
+		Password:          "sekrit",
+		Nonce:             "a-nonce",
+		Model:             coretesting.ModelTag,
+		APIAddresses:      []string{"10.0.0.1:17070"},
+		CACert:            coretesting.CACert,
+		Values:            map[string]string{agent.ProviderType: "maas"},
+		MongoVersion:      mongo.Mongo32wt,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	config.SetStateServingInfo(params.StateServingInfo{
+		APIPort:      17070,
+		StatePort:    37017,
+		Cert:         "cert",
+		PrivateKey:   "private-key",
+		SharedSecret: "shared-secret",
+	})
+
+	c.Assert(agentConfigReport(config), jc.DeepEquals, map[string]interface{}{
+		"tag":                 "machine-0",
+		"model":               coretesting.ModelTag.String(),
+		"data-dir":            "/var/lib/juju",
+		"log-dir":             "/var/log/juju",
+		"jobs":                []string{"JobManageModel"},
+		"upgraded-to-version": "2.0.0",
+		"api-addresses":       []string{"10.0.0.1:17070"},
+		"api-port":            17070,
+		"state-port":          37017,
+		"mongo-version":       "3.2/wiredTiger",
+		"provider-type":       "maas",
+	})
+}
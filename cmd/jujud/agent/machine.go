@@ -47,6 +47,7 @@ import (
 	"github.com/juju/juju/cmd/jujud/agent/model"
 	"github.com/juju/juju/cmd/jujud/reboot"
 	cmdutil "github.com/juju/juju/cmd/jujud/util"
+	"github.com/juju/juju/cmd/pprof"
 	"github.com/juju/juju/container"
 	"github.com/juju/juju/container/kvm"
 	"github.com/juju/juju/environs"
@@ -73,6 +74,7 @@ import (
 	"github.com/juju/juju/worker/deployer"
 	"github.com/juju/juju/worker/gate"
 	"github.com/juju/juju/worker/imagemetadataworker"
+	"github.com/juju/juju/worker/logforwarder"
 	"github.com/juju/juju/worker/logsender"
 	"github.com/juju/juju/worker/modelworkermanager"
 	"github.com/juju/juju/worker/mongoupgrader"
@@ -383,6 +385,8 @@ func (a *MachineAgent) Run(*cmd.Context) error {
 	}
 
 	logger.Infof("machine agent %v start (%s [%s])", a.Tag(), jujuversion.Current, runtime.Compiler)
+	defer registerAgentConfig(a.CurrentConfig)()
+	registerIntrospection(pprof.KindRunner, "agent", a.runner)
 	if flags := featureflag.String(); flags != "" {
 		logger.Warningf("developer feature flags enabled: %s", flags)
 	}
@@ -440,6 +444,7 @@ func (a *MachineAgent) makeEngineCreator(previousAgentVersion version.Number) fu
 		if err != nil {
 			return nil, err
 		}
+		registerIntrospection(pprof.KindEngine, "machine", engine)
 		manifolds := machine.Manifolds(machine.ManifoldsConfig{
 			PreviousAgentVersion: previousAgentVersion,
 			Agent:                agent.APIHostPortsSetter{Agent: a},
@@ -639,6 +644,7 @@ func (a *MachineAgent) startAPIWorkers(apiConn api.Connection) (_ worker.Worker,
 	}
 
 	runner := newConnRunner(apiConn)
+	registerIntrospection(pprof.KindRunner, "api-workers", runner)
 	defer func() {
 		// If startAPIWorkers exits early with an error, stop the
 		// runner so that any already started runners aren't leaked.
@@ -877,6 +883,7 @@ func (a *MachineAgent) startStateWorkers(st *state.State) (worker.Worker, error)
 	}
 
 	runner := newConnRunner(st)
+	registerIntrospection(pprof.KindRunner, "state-workers", runner)
 	singularRunner, err := newSingularStateRunner(runner, st, m)
 	if err != nil {
 		return nil, errors.Trace(err)
@@ -892,8 +899,10 @@ func (a *MachineAgent) startStateWorkers(st *state.State) (worker.Worker, error)
 			useMultipleCPUs()
 			a.startWorkerAfterUpgrade(runner, "model worker manager", func() (worker.Worker, error) {
 				w, err := modelworkermanager.New(modelworkermanager.Config{
-					Backend:    st,
-					NewWorker:  a.startModelWorkers,
+					Backend: st,
+					NewWorker: func(uuid string) (worker.Worker, error) {
+						return a.startModelWorkers(st, uuid)
+					},
 					ErrorDelay: worker.RestartDelay,
 				})
 				if err != nil {
@@ -962,6 +971,20 @@ func (a *MachineAgent) startStateWorkers(st *state.State) (worker.Worker, error)
 				return dblogpruner.New(st, dblogpruner.NewLogPruneParams()), nil
 			})
 
+			a.startWorkerAfterUpgrade(singularRunner, "logforwarder", func() (worker.Worker, error) {
+				w, err := modelworkermanager.New(modelworkermanager.Config{
+					Backend: st,
+					NewWorker: func(uuid string) (worker.Worker, error) {
+						return logforwarder.NewForModel(st, uuid)
+					},
+					ErrorDelay: worker.RestartDelay,
+				})
+				if err != nil {
+					return nil, errors.Annotate(err, "cannot start log forwarder")
+				}
+				return w, nil
+			})
+
 			a.startWorkerAfterUpgrade(singularRunner, "txnpruner", func() (worker.Worker, error) {
 				return txnpruner.New(st, time.Hour*2), nil
 			})
@@ -973,12 +996,17 @@ func (a *MachineAgent) startStateWorkers(st *state.State) (worker.Worker, error)
 }
 
 // startModelWorkers starts the set of workers that run for every model
-// in each controller.
-func (a *MachineAgent) startModelWorkers(uuid string) (worker.Worker, error) {
+// in each controller. The controller's state is used to read the
+// controller's configuration.
+func (a *MachineAgent) startModelWorkers(st *state.State, uuid string) (worker.Worker, error) {
 	modelAgent, err := model.WrapAgent(a, uuid)
 	if err != nil {
 		return nil, errors.Trace(err)
 	}
+	controllerConfig, err := st.ModelConfig()
+	if err != nil {
+		return nil, errors.Annotate(err, "cannot read controller config")
+	}
 
 	engine, err := dependency.NewEngine(dependency.EngineConfig{
 		IsFatal:     model.IsFatal,
@@ -992,14 +1020,15 @@ func (a *MachineAgent) startModelWorkers(uuid string) (worker.Worker, error) {
 	}
 
 	manifolds := modelManifolds(model.ManifoldsConfig{
-		Agent:                       modelAgent,
-		AgentConfigChanged:          a.configChangedVal,
-		Clock:                       clock.WallClock,
-		RunFlagDuration:             time.Minute,
-		CharmRevisionUpdateInterval: 24 * time.Hour,
-		EntityStatusHistoryCount:    100,
-		EntityStatusHistoryInterval: 5 * time.Minute,
-		SpacesImportedGate:          a.discoverSpacesComplete,
+		Agent:                        modelAgent,
+		AgentConfigChanged:           a.configChangedVal,
+		Clock:                        clock.WallClock,
+		RunFlagDuration:              time.Minute,
+		CharmRevisionUpdateInterval:  24 * time.Hour,
+		EntityStatusHistoryCount:     100,
+		EntityStatusHistoryInterval:  5 * time.Minute,
+		MigrationMinionReportTimeout: controllerConfig.MigrationMinionReportTimeout(),
+		SpacesImportedGate:           a.discoverSpacesComplete,
 	})
 	if err := dependency.Install(engine, manifolds); err != nil {
 		if err := worker.Stop(engine); err != nil {
@@ -1049,19 +1078,25 @@ func (a *MachineAgent) newApiserverWorker(st *state.State, certChanged chan para
 	dataDir := agentConfig.DataDir()
 	logDir := agentConfig.LogDir()
 
+	loginThrottle, err := cmdutil.NewLoginThrottleConfig(agentConfig)
+	if err != nil {
+		return nil, &cmdutil.FatalError{err.Error()}
+	}
+
 	endpoint := net.JoinHostPort("", strconv.Itoa(info.APIPort))
 	listener, err := net.Listen("tcp", endpoint)
 	if err != nil {
 		return nil, err
 	}
 	w, err := apiserver.NewServer(st, listener, apiserver.ServerConfig{
-		Cert:        cert,
-		Key:         key,
-		Tag:         tag,
-		DataDir:     dataDir,
-		LogDir:      logDir,
-		Validator:   a.limitLogins,
-		CertChanged: certChanged,
+		Cert:          cert,
+		Key:           key,
+		Tag:           tag,
+		DataDir:       dataDir,
+		LogDir:        logDir,
+		Validator:     a.limitLogins,
+		CertChanged:   certChanged,
+		LoginThrottle: loginThrottle,
 	})
 	if err != nil {
 		return nil, errors.Annotate(err, "cannot start api server worker")
@@ -23,6 +23,7 @@ import (
 	"github.com/juju/juju/worker/diskmanager"
 	"github.com/juju/juju/worker/fortress"
 	"github.com/juju/juju/worker/gate"
+	"github.com/juju/juju/worker/hostfirewaller"
 	"github.com/juju/juju/worker/hostkeyreporter"
 	"github.com/juju/juju/worker/identityfilewriter"
 	"github.com/juju/juju/worker/logger"
@@ -262,6 +263,7 @@ func Manifolds(config ManifoldsConfig) dependency.Manifolds {
 			APICallerName: apiCallerName,
 			FortressName:  migrationFortressName,
 
+			APIOpen:   apicaller.APIOpen,
 			NewFacade: migrationminion.NewFacade,
 			NewWorker: migrationminion.NewWorker,
 		})),
@@ -397,6 +399,16 @@ func Manifolds(config ManifoldsConfig) dependency.Manifolds {
 			NewFacade:     hostkeyreporter.NewFacade,
 			NewWorker:     hostkeyreporter.NewWorker,
 		})),
+
+		// The host firewaller applies the model's firewall rules on
+		// machines whose provider has no firewall of its own.
+		hostFirewallerName: ifFullyUpgraded(hostfirewaller.Manifold(hostfirewaller.ManifoldConfig{
+			AgentName:     agentName,
+			APICallerName: apiCallerName,
+			NewFacade:     hostfirewaller.NewFacade,
+			NewFirewall:   hostfirewaller.NewFirewall,
+			NewWorker:     hostfirewaller.NewWorker,
+		})),
 	}
 }
 
@@ -443,4 +455,5 @@ const (
 	apiConfigWatcherName     = "api-config-watcher"
 	machineActionName        = "machine-action-runner"
 	hostKeyReporterName      = "host-key-reporter"
+	hostFirewallerName       = "host-firewaller"
 )
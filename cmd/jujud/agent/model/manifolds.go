@@ -76,6 +76,11 @@ type ManifoldsConfig struct {
 	EntityStatusHistoryCount    uint
 	EntityStatusHistoryInterval time.Duration
 
+	// MigrationMinionReportTimeout is how long the migration master
+	// will wait for the model's agents to report back during a
+	// model migration before aborting it.
+	MigrationMinionReportTimeout time.Duration
+
 	// SpacesImportedGate will be unlocked when spaces are known to
 	// have been imported.
 	SpacesImportedGate gate.Lock
@@ -144,6 +149,9 @@ func Manifolds(config ManifoldsConfig) dependency.Manifolds {
 		migrationMasterName: ifNotDead(migrationmaster.Manifold(migrationmaster.ManifoldConfig{
 			APICallerName: apiCallerName,
 			FortressName:  migrationFortressName,
+			ClockName:     clockName,
+
+			MinionReportTimeout: config.MigrationMinionReportTimeout,
 
 			NewFacade: migrationmaster.NewFacade,
 			NewWorker: migrationmaster.NewWorker,
@@ -21,6 +21,7 @@ import (
 	"github.com/juju/juju/agent"
 	"github.com/juju/juju/cmd/jujud/agent/unit"
 	cmdutil "github.com/juju/juju/cmd/jujud/util"
+	"github.com/juju/juju/cmd/pprof"
 	"github.com/juju/juju/network"
 	jujuversion "github.com/juju/juju/version"
 	"github.com/juju/juju/worker"
@@ -128,6 +129,8 @@ func (a *UnitAgent) Run(ctx *cmd.Context) error {
 		logger.Warningf("developer feature flags enabled: %s", flags)
 	}
 	network.SetPreferIPv6(agentConfig.PreferIPv6())
+	defer registerAgentConfig(a.CurrentConfig)()
+	registerIntrospection(pprof.KindRunner, "agent", a.runner)
 
 	// Sometimes there are upgrade steps that are needed for each unit.
 	// There are plans afoot to unify the unit and machine agents. When
@@ -186,6 +189,7 @@ func (a *UnitAgent) APIWorkers() (worker.Worker, error) {
 	if err != nil {
 		return nil, err
 	}
+	registerIntrospection(pprof.KindEngine, "unit", engine)
 	if err := dependency.Install(engine, manifolds); err != nil {
 		if err := worker.Stop(engine); err != nil {
 			logger.Errorf("while stopping engine with bad manifolds: %v", err)
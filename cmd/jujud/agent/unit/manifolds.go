@@ -134,6 +134,7 @@ func Manifolds(config ManifoldsConfig) dependency.Manifolds {
 			APICallerName: apiCallerName,
 			FortressName:  migrationFortressName,
 
+			APIOpen:   apicaller.APIOpen,
 			NewFacade: migrationminion.NewFacade,
 			NewWorker: migrationminion.NewWorker,
 		}),
@@ -0,0 +1,215 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package main
+
+import (
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	"gopkg.in/yaml.v2"
+	"launchpad.net/gnuflag"
+
+	"github.com/juju/juju/cmd/pprof"
+	"github.com/juju/juju/juju/names"
+)
+
+const introspectDoc = `
+Query the introspection socket of a running jujud agent on this machine.
+
+The path defaults to "/", which lists the paths available. These include:
+
+    /depengine    the dependency engine reports: the state, start count,
+                  last error and inputs of each manifold
+    /runners      the workers running in each runner
+    /agent        the agent's configuration, with secrets removed
+    /debug/pprof/ Go profiling data
+
+If more than one agent is running, the agent to query must be selected
+with --agent or --pid.
+
+Examples:
+    jujud introspect --agent machine-0 /depengine
+    jujud introspect --agent unit-mysql-0 /runners
+`
+
+// NewIntrospectCommand returns a command that queries the
+// introspection socket of a running agent.
+func NewIntrospectCommand() cmd.Command {
+	return &introspectCommand{
+		socketDir: os.TempDir(),
+		pid:       os.Getpid(),
+	}
+}
+
+type introspectCommand struct {
+	cmd.CommandBase
+
+	// socketDir holds the directory containing the agents' sockets,
+	// and pid the pid of this process, whose own socket is ignored.
+	socketDir string
+	pid       int
+
+	agent    string
+	agentPid int
+	path     string
+}
+
+// Info returns usage information for the command.
+func (c *introspectCommand) Info() *cmd.Info {
+	return &cmd.Info{
+		Name:    "introspect",
+		Args:    "[<path>]",
+		Purpose: "query the introspection socket of a running agent",
+		Doc:     introspectDoc,
+	}
+}
+
+// SetFlags adds the flags for this command to the passed gnuflag.FlagSet.
+func (c *introspectCommand) SetFlags(f *gnuflag.FlagSet) {
+	f.StringVar(&c.agent, "agent", "", "the tag of the agent to query, e.g. machine-0")
+	f.IntVar(&c.agentPid, "pid", 0, "the pid of the agent to query")
+}
+
+// Init initializes the command for running.
+func (c *introspectCommand) Init(args []string) error {
+	if c.agent != "" && c.agentPid != 0 {
+		return errors.New("cannot specify both --agent and --pid")
+	}
+	c.path = "/"
+	if len(args) > 0 {
+		c.path = args[0]
+		args = args[1:]
+	}
+	if !strings.HasPrefix(c.path, "/") {
+		c.path = "/" + c.path
+	}
+	return cmd.CheckEmpty(args)
+}
+
+// Run queries the introspection socket, writing the response to
+// standard output.
+func (c *introspectCommand) Run(ctx *cmd.Context) error {
+	socketPath, err := c.socketPath()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	resp, err := introspectGet(socketPath, c.path)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		body, _ := ioutil.ReadAll(resp.Body)
+		return errors.Errorf("%s: %s", resp.Status, strings.TrimSpace(string(body)))
+	}
+	_, err = io.Copy(ctx.Stdout, resp.Body)
+	return errors.Trace(err)
+}
+
+// socketPath returns the path of the socket of the agent to query.
+func (c *introspectCommand) socketPath() (string, error) {
+	if c.agentPid != 0 {
+		return filepath.Join(c.socketDir, pprof.SocketName(names.Jujud, c.agentPid)), nil
+	}
+	candidates, err := c.agentSockets()
+	if err != nil {
+		return "", errors.Trace(err)
+	}
+	if c.agent == "" {
+		switch len(candidates) {
+		case 0:
+			return "", errors.NotFoundf("running agent")
+		case 1:
+			return candidates[0], nil
+		}
+		return "", errors.New("more than one agent is running; specify --agent or --pid")
+	}
+	for _, socketPath := range candidates {
+		tag, err := agentTag(socketPath)
+		if err != nil {
+			// The agent may have stopped, or not have read its
+			// configuration yet.
+			logger.Debugf("cannot get agent tag from %q: %v", socketPath, err)
+			continue
+		}
+		if tag == c.agent {
+			return socketPath, nil
+		}
+	}
+	return "", errors.NotFoundf("running agent %q", c.agent)
+}
+
+// agentSockets returns the paths of the sockets of the jujud processes
+// other than this one.
+func (c *introspectCommand) agentSockets() ([]string, error) {
+	infos, err := ioutil.ReadDir(c.socketDir)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	var paths []string
+	for _, info := range infos {
+		if info.Mode()&os.ModeSocket == 0 {
+			continue
+		}
+		// The socket name ends with the pid of the process.
+		name := info.Name()
+		pid, err := strconv.Atoi(name[strings.LastIndex(name, ".")+1:])
+		if err != nil || pid == c.pid || name != pprof.SocketName(names.Jujud, pid) {
+			continue
+		}
+		paths = append(paths, filepath.Join(c.socketDir, name))
+	}
+	return paths, nil
+}
+
+// agentTag returns the tag of the agent listening on the socket.
+func agentTag(socketPath string) (string, error) {
+	resp, err := introspectGet(socketPath, "/"+pprof.KindAgent)
+	if err != nil {
+		return "", errors.Trace(err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return "", errors.New(resp.Status)
+	}
+	data, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return "", errors.Trace(err)
+	}
+	var report struct {
+		Config struct {
+			Tag string `yaml:"tag"`
+		} `yaml:"config"`
+	}
+	if err := yaml.Unmarshal(data, &report); err != nil {
+		return "", errors.Trace(err)
+	}
+	return report.Config.Tag, nil
+}
+
+// introspectGet makes a GET request for the path over the unix socket.
+func introspectGet(socketPath, path string) (*http.Response, error) {
+	client := http.Client{
+		Transport: &http.Transport{
+			Dial: func(string, string) (net.Conn, error) {
+				return net.Dial("unix", socketPath)
+			},
+		},
+	}
+	// The host is ignored, as every connection is made to the socket.
+	resp, err := client.Get(fmt.Sprintf("http://unix.socket%s", path))
+	if err != nil {
+		return nil, errors.Annotatef(err, "cannot query %q", socketPath)
+	}
+	return resp, nil
+}
@@ -0,0 +1,107 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package main
+
+import (
+	"fmt"
+	"net"
+	"net/http"
+	"path/filepath"
+	"runtime"
+
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/testing"
+)
+
+type IntrospectSuite struct {
+	testing.BaseSuite
+
+	socketDir string
+}
+
+var _ = gc.Suite(&IntrospectSuite{})
+
+func (s *IntrospectSuite) SetUpTest(c *gc.C) {
+	if runtime.GOOS != "linux" {
+		c.Skip(fmt.Sprintf("introspection socket not supported on %q", runtime.GOOS))
+	}
+	s.BaseSuite.SetUpTest(c)
+	s.socketDir = c.MkDir()
+}
+
+// startAgent starts a fake agent introspection server listening on
+// the socket for the given pid, and reporting the given agent tag.
+func (s *IntrospectSuite) startAgent(c *gc.C, pid int, tag string) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/agent", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprintf(w, "config:\n  tag: %s\n", tag)
+	})
+	mux.HandleFunc("/depengine", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprintf(w, "%s engine report\n", tag)
+	})
+	path := filepath.Join(s.socketDir, fmt.Sprintf("pprof.jujud.%d", pid))
+	l, err := net.Listen("unix", path)
+	c.Assert(err, jc.ErrorIsNil)
+	s.AddCleanup(func(*gc.C) { l.Close() })
+	go http.Serve(l, mux)
+}
+
+func (s *IntrospectSuite) run(c *gc.C, args ...string) (string, error) {
+	command := &introspectCommand{
+		socketDir: s.socketDir,
+		pid:       1,
+	}
+	ctx, err := testing.RunCommand(c, command, args...)
+	if err != nil {
+		return "", err
+	}
+	return testing.Stdout(ctx), nil
+}
+
+func (s *IntrospectSuite) TestInitErrors(c *gc.C) {
+	_, err := s.run(c, "--agent", "machine-0", "--pid", "123")
+	c.Assert(err, gc.ErrorMatches, "cannot specify both --agent and --pid")
+	_, err = s.run(c, "/depengine", "extra")
+	c.Assert(err, gc.ErrorMatches, `unrecognized args: \["extra"\]`)
+}
+
+func (s *IntrospectSuite) TestOnlyAgent(c *gc.C) {
+	s.startAgent(c, 123, "machine-0")
+	// This process's own socket is ignored.
+	s.startAgent(c, 1, "machine-1")
+	out, err := s.run(c, "depengine")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(out, gc.Equals, "machine-0 engine report\n")
+}
+
+func (s *IntrospectSuite) TestNoAgent(c *gc.C) {
+	_, err := s.run(c, "/depengine")
+	c.Assert(err, gc.ErrorMatches, "running agent not found")
+}
+
+func (s *IntrospectSuite) TestMultipleAgents(c *gc.C) {
+	s.startAgent(c, 123, "machine-0")
+	s.startAgent(c, 456, "unit-mysql-0")
+	_, err := s.run(c, "/depengine")
+	c.Assert(err, gc.ErrorMatches, "more than one agent is running; specify --agent or --pid")
+
+	out, err := s.run(c, "--agent", "unit-mysql-0", "/depengine")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(out, gc.Equals, "unit-mysql-0 engine report\n")
+
+	out, err = s.run(c, "--pid", "123", "/depengine")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(out, gc.Equals, "machine-0 engine report\n")
+
+	_, err = s.run(c, "--agent", "machine-1", "/depengine")
+	c.Assert(err, gc.ErrorMatches, `running agent "machine-1" not found`)
+}
+
+func (s *IntrospectSuite) TestNotFound(c *gc.C) {
+	s.startAgent(c, 123, "machine-0")
+	_, err := s.run(c, "/missing")
+	c.Assert(err, gc.ErrorMatches, "404 Not Found: 404 page not found")
+}
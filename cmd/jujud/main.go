@@ -158,6 +158,8 @@ func jujuDMain(args []string, ctx *cmd.Context) (code int, err error) {
 
 	jujud.Register(NewUpgradeMongoCommand())
 
+	jujud.Register(NewIntrospectCommand())
+
 	code = cmd.Main(jujud, ctx, args[1:])
 	return code, nil
 }
@@ -8,6 +8,7 @@ import (
 	"io"
 	"path/filepath"
 	"strconv"
+	"time"
 
 	"github.com/juju/errors"
 	"github.com/juju/loggo"
@@ -15,6 +16,7 @@ import (
 	"github.com/juju/utils/series"
 
 	"github.com/juju/juju/agent"
+	"github.com/juju/juju/apiserver"
 	"github.com/juju/juju/apiserver/params"
 	"github.com/juju/juju/juju/paths"
 	"github.com/juju/juju/mongo"
@@ -197,6 +199,52 @@ func NewEnsureServerParams(agentConfig agent.Config) (mongo.EnsureServerParams,
 	return params, nil
 }
 
+// NewLoginThrottleConfig creates an apiserver.LoginThrottleConfig from
+// an agent configuration. Limits that are not specified are left as
+// zero values, so the API server uses its defaults.
+func NewLoginThrottleConfig(agentConfig agent.Config) (apiserver.LoginThrottleConfig, error) {
+	var config apiserver.LoginThrottleConfig
+	ints := []struct {
+		key   string
+		value *int
+	}{
+		{agent.LoginMaxFailuresPerSource, &config.MaxFailuresPerSource},
+		{agent.LoginMaxFailuresPerUser, &config.MaxFailuresPerUser},
+		{agent.LoginMaxConcurrent, &config.MaxConcurrentLogins},
+	}
+	for _, item := range ints {
+		s := agentConfig.Value(item.key)
+		if s == "" {
+			continue
+		}
+		n, err := strconv.Atoi(s)
+		if err != nil || n < 0 {
+			return apiserver.LoginThrottleConfig{}, errors.Errorf("invalid %s: %q", item.key, s)
+		}
+		*item.value = n
+	}
+	durations := []struct {
+		key   string
+		value *time.Duration
+	}{
+		{agent.LoginFailureWindow, &config.FailureWindow},
+		{agent.LoginInitialLockout, &config.InitialLockout},
+		{agent.LoginMaxLockout, &config.MaxLockout},
+	}
+	for _, item := range durations {
+		s := agentConfig.Value(item.key)
+		if s == "" {
+			continue
+		}
+		d, err := time.ParseDuration(s)
+		if err != nil || d < 0 {
+			return apiserver.LoginThrottleConfig{}, errors.Errorf("invalid %s: %q", item.key, s)
+		}
+		*item.value = d
+	}
+	return config, nil
+}
+
 // NewCloseWorker returns a task that wraps the given task,
 // closing the given closer when it finishes.
 func NewCloseWorker(logger loggo.Logger, worker worker.Worker, closer io.Closer) worker.Worker {
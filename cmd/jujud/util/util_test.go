@@ -5,11 +5,14 @@ package util
 
 import (
 	stderrors "errors"
+	"time"
 
 	"github.com/juju/errors"
 	jc "github.com/juju/testing/checkers"
 	gc "gopkg.in/check.v1"
 
+	"github.com/juju/juju/agent"
+	"github.com/juju/juju/apiserver"
 	"github.com/juju/juju/apiserver/params"
 	coretesting "github.com/juju/juju/testing"
 	"github.com/juju/juju/worker"
@@ -136,3 +139,38 @@ type testPinger func() error
 func (f testPinger) Ping() error {
 	return f()
 }
+
+type fakeAgentConfig struct {
+	agent.Config
+	values map[string]string
+}
+
+func (c fakeAgentConfig) Value(key string) string {
+	return c.values[key]
+}
+
+func (*toolSuite) TestNewLoginThrottleConfig(c *gc.C) {
+	config, err := NewLoginThrottleConfig(fakeAgentConfig{values: map[string]string{
+		agent.LoginMaxFailuresPerSource: "50",
+		agent.LoginMaxFailuresPerUser:   "100",
+		agent.LoginMaxLockout:           "10m",
+	}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(config, jc.DeepEquals, apiserver.LoginThrottleConfig{
+		MaxFailuresPerSource: 50,
+		MaxFailuresPerUser:   100,
+		MaxLockout:           10 * time.Minute,
+	})
+}
+
+func (*toolSuite) TestNewLoginThrottleConfigInvalid(c *gc.C) {
+	_, err := NewLoginThrottleConfig(fakeAgentConfig{values: map[string]string{
+		agent.LoginMaxFailuresPerUser: "many",
+	}})
+	c.Assert(err, gc.ErrorMatches, `invalid LOGIN_MAX_FAILURES_PER_USER: "many"`)
+
+	_, err = NewLoginThrottleConfig(fakeAgentConfig{values: map[string]string{
+		agent.LoginFailureWindow: "-1m",
+	}})
+	c.Assert(err, gc.ErrorMatches, `invalid LOGIN_FAILURE_WINDOW: "-1m"`)
+}
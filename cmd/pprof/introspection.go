@@ -0,0 +1,160 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package pprof
+
+import (
+	"fmt"
+	"net/http"
+	"sort"
+	"sync"
+
+	"gopkg.in/yaml.v2"
+)
+
+// The kinds of report served by the introspection socket. Each kind
+// is served at a path of the same name, e.g. /depengine.
+const (
+	// KindEngine is used for dependency engines.
+	KindEngine = "depengine"
+
+	// KindRunner is used for worker runners.
+	KindRunner = "runners"
+
+	// KindAgent is used for information about the agent, such as its
+	// configuration.
+	KindAgent = "agent"
+)
+
+// Kinds holds all the kinds of report served by the introspection
+// socket.
+var Kinds = []string{KindEngine, KindRunner, KindAgent}
+
+// Reporter is implemented by values whose state can be reported on
+// the introspection socket. It matches dependency.Reporter, which is
+// implemented by engines and runners.
+type Reporter interface {
+	Report() map[string]interface{}
+}
+
+// ReporterFunc adapts a function to the Reporter interface.
+type ReporterFunc func() map[string]interface{}
+
+// Report is part of the Reporter interface.
+func (f ReporterFunc) Report() map[string]interface{} {
+	return f()
+}
+
+var registry = struct {
+	mu        sync.Mutex
+	reporters map[string]map[string]Reporter
+}{
+	reporters: make(map[string]map[string]Reporter),
+}
+
+// Register makes the report of the given reporter available on the
+// introspection socket under the given kind and name, replacing any
+// reporter already registered there. It returns a function that
+// unregisters the reporter, if it has not since been replaced.
+func Register(kind, name string, reporter Reporter) (unregister func()) {
+	registry.mu.Lock()
+	defer registry.mu.Unlock()
+	// Wrap the reporter, so that unregistering can tell it apart
+	// from any reporter that replaces it, even an identical one.
+	entry := &registeredReporter{reporter}
+	if registry.reporters[kind] == nil {
+		registry.reporters[kind] = make(map[string]Reporter)
+	}
+	registry.reporters[kind][name] = entry
+	return func() {
+		registry.mu.Lock()
+		defer registry.mu.Unlock()
+		if registry.reporters[kind][name] == entry {
+			delete(registry.reporters[kind], name)
+		}
+	}
+}
+
+type registeredReporter struct {
+	Reporter
+}
+
+// reports returns the reports of all the reporters registered under
+// the given kind, keyed by name.
+func reports(kind string) map[string]interface{} {
+	registry.mu.Lock()
+	reporters := make(map[string]Reporter)
+	for name, reporter := range registry.reporters[kind] {
+		reporters[name] = reporter
+	}
+	registry.mu.Unlock()
+
+	// Reporters may block while their owners respond, so they are
+	// not called with the lock held.
+	result := make(map[string]interface{})
+	for name, reporter := range reporters {
+		result[name] = sanitise(reporter.Report())
+	}
+	return result
+}
+
+// sanitise returns a copy of v in which errors have been replaced by
+// their messages, so that they survive being marshalled.
+func sanitise(v interface{}) interface{} {
+	switch v := v.(type) {
+	case error:
+		return v.Error()
+	case map[string]interface{}:
+		result := make(map[string]interface{}, len(v))
+		for key, value := range v {
+			result[key] = sanitise(value)
+		}
+		return result
+	case []map[string]interface{}:
+		result := make([]interface{}, len(v))
+		for i, value := range v {
+			result[i] = sanitise(value)
+		}
+		return result
+	case []interface{}:
+		result := make([]interface{}, len(v))
+		for i, value := range v {
+			result[i] = sanitise(value)
+		}
+		return result
+	}
+	return v
+}
+
+// reportHandler serves the reports of the given kind as YAML.
+func reportHandler(kind string) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		data, err := yaml.Marshal(reports(kind))
+		if err != nil {
+			w.Header().Set("Content-Type", "text/plain; charset=utf-8")
+			w.WriteHeader(http.StatusInternalServerError)
+			fmt.Fprintf(w, "cannot marshal %s report: %v\n", kind, err)
+			return
+		}
+		w.Header().Set("Content-Type", "application/x-yaml")
+		w.Write(data)
+	})
+}
+
+// introspectionIndex lists the paths served by the introspection
+// socket.
+func introspectionIndex(w http.ResponseWriter, r *http.Request) {
+	if r.URL.Path != "/" {
+		http.NotFound(w, r)
+		return
+	}
+	paths := []string{"/debug/pprof/"}
+	for _, kind := range Kinds {
+		paths = append(paths, "/"+kind)
+	}
+	sort.Strings(paths)
+	w.Header().Set("Content-Type", "text/plain; charset=utf-8")
+	for _, path := range paths {
+		fmt.Fprintln(w, path)
+	}
+}
@@ -0,0 +1,87 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package pprof
+
+import (
+	"errors"
+
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+)
+
+func reporterFunc(report map[string]interface{}) Reporter {
+	return ReporterFunc(func() map[string]interface{} {
+		return report
+	})
+}
+
+func (s *suite) TestRegister(c *gc.C) {
+	unregister := Register(KindRunner, "test", reporterFunc(map[string]interface{}{
+		"state": "started",
+	}))
+	c.Assert(reports(KindRunner), jc.DeepEquals, map[string]interface{}{
+		"test": map[string]interface{}{"state": "started"},
+	})
+	c.Assert(reports(KindEngine), jc.DeepEquals, map[string]interface{}{})
+
+	unregister()
+	c.Assert(reports(KindRunner), jc.DeepEquals, map[string]interface{}{})
+}
+
+func (s *suite) TestUnregisterReplaced(c *gc.C) {
+	unregister := Register(KindEngine, "test", reporterFunc(map[string]interface{}{
+		"state": "stopped",
+	}))
+	defer Register(KindEngine, "test", reporterFunc(map[string]interface{}{
+		"state": "started",
+	}))()
+
+	// Unregistering the replaced reporter leaves the new one alone.
+	unregister()
+	c.Assert(reports(KindEngine), jc.DeepEquals, map[string]interface{}{
+		"test": map[string]interface{}{"state": "started"},
+	})
+}
+
+func (s *suite) TestSanitise(c *gc.C) {
+	report := map[string]interface{}{
+		"error": errors.New("boom"),
+		"none":  nil,
+		"manifolds": map[string]interface{}{
+			"task": map[string]interface{}{
+				"error":        errors.New("bang"),
+				"inputs":       []string{"agent"},
+				"resource-log": []map[string]interface{}{{"error": errors.New("missing")}},
+			},
+		},
+	}
+	c.Assert(sanitise(report), jc.DeepEquals, map[string]interface{}{
+		"error": "boom",
+		"none":  nil,
+		"manifolds": map[string]interface{}{
+			"task": map[string]interface{}{
+				"error":        "bang",
+				"inputs":       []string{"agent"},
+				"resource-log": []interface{}{map[string]interface{}{"error": "missing"}},
+			},
+		},
+	})
+}
+
+func (s *pprofSuite) TestIndex(c *gc.C) {
+	buf := s.call(c, "/")
+	matches(c, buf, "^/depengine$")
+	matches(c, buf, "^/debug/pprof/$")
+}
+
+func (s *pprofSuite) TestReport(c *gc.C) {
+	defer Register(KindAgent, "config", reporterFunc(map[string]interface{}{
+		"tag":   "machine-0",
+		"error": errors.New("boom"),
+	}))()
+	buf := s.call(c, "/agent")
+	matches(c, buf, "^config:$")
+	matches(c, buf, "^  tag: machine-0$")
+	matches(c, buf, "^  error: boom$")
+}
@@ -21,6 +21,10 @@ var logger = loggo.GetLogger("juju.cmd.pprof")
 // provided by os.Args[0], and the pid of the process.
 // Start returns a function which will stop the pprof server and clean
 // up the socket file.
+//
+// As well as the Go profiling data, the server serves the reports of
+// everything registered with Register, as YAML, at /depengine,
+// /runners and /agent.
 func Start() func() error {
 	if runtime.GOOS != "linux" {
 		logger.Infof("pprof debugging not supported on %q", runtime.GOOS)
@@ -32,6 +36,10 @@ func Start() func() error {
 	mux.Handle("/debug/pprof/cmdline", http.HandlerFunc(Cmdline))
 	mux.Handle("/debug/pprof/profile", http.HandlerFunc(Profile))
 	mux.Handle("/debug/pprof/symbol", http.HandlerFunc(Symbol))
+	for _, kind := range Kinds {
+		mux.Handle("/"+kind, reportHandler(kind))
+	}
+	mux.Handle("/", http.HandlerFunc(introspectionIndex))
 
 	srv := http.Server{
 		Handler: mux,
@@ -65,8 +73,13 @@ func Start() func() error {
 
 // socketpath returns the path for this processes' pprof socket.
 func socketpath() string {
-	cmd := filepath.Base(os.Args[0])
-	name := fmt.Sprintf("pprof.%s.%d", cmd, os.Getpid())
-	path := filepath.Join(os.TempDir(), name)
-	return path
+	name := SocketName(filepath.Base(os.Args[0]), os.Getpid())
+	return filepath.Join(os.TempDir(), name)
+}
+
+// SocketName returns the name of the pprof socket, within the
+// temporary directory, of the process with the given command name
+// and pid.
+func SocketName(cmd string, pid int) string {
+	return fmt.Sprintf("pprof.%s.%d", cmd, pid)
 }
@@ -30,6 +30,7 @@ const (
 	InstanceType = "instance-type"
 	Spaces       = "spaces"
 	VirtType     = "virt-type"
+	Zones        = "zones"
 )
 
 // Value describes a user's requirements of the hardware on which units
@@ -83,6 +84,11 @@ type Value struct {
 	// VirtType, if not nil or empty, indicates that a machine must run the named
 	// virtual type. Only valid for clouds with multi-hypervisor support.
 	VirtType *string `json:"virt-type,omitempty" yaml:"virt-type,omitempty"`
+
+	// Zones, if not nil, holds a list of availability zones limiting
+	// where the machine can be located. An empty list is treated the
+	// same as a nil list, except that it overrides any default zones.
+	Zones *[]string `json:"zones,omitempty" yaml:"zones,omitempty"`
 }
 
 // fieldNames records a mapping from the constraint tag to struct field name.
@@ -167,6 +173,26 @@ func (v *Value) HaveSpaces() bool {
 	return v.Spaces != nil && len(*v.Spaces) > 0
 }
 
+// HasZones returns whether the zones constraint limits the
+// availability zones a machine may be located in.
+func (v *Value) HasZones() bool {
+	return v.Zones != nil && len(*v.Zones) > 0
+}
+
+// IncludesZone reports whether a machine may be located in the named
+// availability zone.
+func (v *Value) IncludesZone(zone string) bool {
+	if !v.HasZones() {
+		return true
+	}
+	for _, z := range *v.Zones {
+		if z == zone {
+			return true
+		}
+	}
+	return false
+}
+
 // HasVirtType returns true if the constraints.Value specifies an virtual type.
 func (v *Value) HasVirtType() bool {
 	return v.VirtType != nil && *v.VirtType != ""
@@ -215,6 +241,10 @@ func (v Value) String() string {
 	if v.VirtType != nil {
 		strs = append(strs, "virt-type="+string(*v.VirtType))
 	}
+	if v.Zones != nil {
+		s := strings.Join(*v.Zones, ",")
+		strs = append(strs, "zones="+s)
+	}
 	return strings.Join(strs, " ")
 }
 
@@ -256,6 +286,11 @@ func (v Value) GoString() string {
 	if v.VirtType != nil {
 		values = append(values, fmt.Sprintf("VirtType: %q", *v.VirtType))
 	}
+	if v.Zones != nil && *v.Zones != nil {
+		values = append(values, fmt.Sprintf("Zones: %q", *v.Zones))
+	} else if v.Zones != nil {
+		values = append(values, "Zones: (*[]string)(nil)")
+	}
 	return fmt.Sprintf("{%s}", strings.Join(values, ", "))
 }
 
@@ -397,6 +432,8 @@ func (v *Value) setRaw(raw string) error {
 		err = v.setSpaces(str)
 	case VirtType:
 		err = v.setVirtType(str)
+	case Zones:
+		err = v.setZones(str)
 	default:
 		return errors.Errorf("unknown constraint %q", name)
 	}
@@ -449,6 +486,8 @@ func (v *Value) UnmarshalYAML(unmarshal func(interface{}) error) error {
 			}
 		case VirtType:
 			v.VirtType = &vstr
+		case Zones:
+			v.Zones, err = parseYamlStrings("zones", val)
 		default:
 			return errors.Errorf("unknown constraint value: %v", k)
 		}
@@ -573,6 +612,14 @@ func (v *Value) setVirtType(str string) error {
 	return nil
 }
 
+func (v *Value) setZones(str string) error {
+	if v.Zones != nil {
+		return errors.Errorf("already set")
+	}
+	v.Zones = parseCommaDelimited(str)
+	return nil
+}
+
 func parseUint64(str string) (*uint64, error) {
 	var value uint64
 	if str != "" {
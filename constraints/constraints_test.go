@@ -283,6 +283,22 @@ var parseConstraintsTests = []struct {
 		args:    []string{"spaces="},
 	},
 
+	// zones
+	{
+		summary: "single zone",
+		args:    []string{"zones=az1"},
+	}, {
+		summary: "multiple zones",
+		args:    []string{"zones=az1,az2"},
+	}, {
+		summary: "no zones",
+		args:    []string{"zones="},
+	}, {
+		summary: "double set zones separately",
+		args:    []string{"zones=az1", "zones=az2"},
+		err:     `bad "zones" constraint: already set`,
+	},
+
 	// instance type
 	{
 		summary: "set instance type",
@@ -324,7 +340,7 @@ var parseConstraintsTests = []struct {
 		args: []string{
 			"root-disk=8G", "mem=2T", "cpu-cores=4096", "cpu-power=9001", "arch=armhf",
 			"container=lxc", "tags=foo,bar", "spaces=space1,^space2",
-			"instance-type=foo", "virt-type=kvm"},
+			"instance-type=foo", "virt-type=kvm", "zones=az1,az2"},
 	},
 }
 
@@ -394,6 +410,22 @@ func (s *ConstraintsSuite) TestParseNoTagsNoSpaces(c *gc.C) {
 	c.Check(*con.Spaces, gc.HasLen, 0)
 }
 
+func (s *ConstraintsSuite) TestHasAndIncludesZones(c *gc.C) {
+	con := constraints.MustParse("mem=4G")
+	c.Check(con.HasZones(), jc.IsFalse)
+	c.Check(con.IncludesZone("az1"), jc.IsTrue)
+
+	con = constraints.MustParse("zones=")
+	c.Check(con.HasZones(), jc.IsFalse)
+	c.Check(con.IncludesZone("az1"), jc.IsTrue)
+
+	con = constraints.MustParse("zones=az1,az2")
+	c.Check(con.HasZones(), jc.IsTrue)
+	c.Check(con.IncludesZone("az1"), jc.IsTrue)
+	c.Check(con.IncludesZone("az2"), jc.IsTrue)
+	c.Check(con.IncludesZone("az3"), jc.IsFalse)
+}
+
 func (s *ConstraintsSuite) TestIncludeExcludeAndHaveSpaces(c *gc.C) {
 	con := constraints.MustParse("spaces=space1,^space2,space3,^space4")
 	c.Assert(con.Spaces, gc.Not(gc.IsNil))
@@ -228,9 +228,20 @@ func withFallbacks(v Value, vFallback Value) Value {
 	return result
 }
 
+// restrictiveAttributes holds the attributes which limit where a machine
+// may be located. Ignoring them could put a machine somewhere it must not
+// run, so unlike other attributes it is an error to use them when they
+// are unsupported.
+var restrictiveAttributes = set.NewStrings(Zones)
+
 // Validate is defined on Validator.
 func (v *validator) Validate(cons Value) ([]string, error) {
 	unsupported := v.checkUnsupported(cons)
+	for _, attr := range unsupported {
+		if restrictiveAttributes.Contains(attr) {
+			return unsupported, fmt.Errorf("constraint %q is not supported", attr)
+		}
+	}
 	if err := v.checkConflicts(cons); err != nil {
 		return unsupported, err
 	}
@@ -35,6 +35,15 @@ var validationTests = []struct {
 		cons:        "root-disk=8G mem=4G arch=amd64 cpu-power=1000 cpu-cores=4 instance-type=foo",
 		unsupported: []string{"cpu-power", "instance-type"},
 	},
+	{
+		cons:        "mem=4G zones=az1,az2",
+		unsupported: []string{"zones"},
+		err:         `constraint "zones" is not supported`,
+	},
+	{
+		cons:        "mem=4G tags=foo zones=az1,az2",
+		unsupported: []string{"tags"},
+	},
 	{
 		// Ambiguous constraint errors take precedence over unsupported errors.
 		cons:        "root-disk=8G mem=4G cpu-cores=4 instance-type=foo",
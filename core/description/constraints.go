@@ -20,6 +20,7 @@ type ConstraintsArgs struct {
 
 	Spaces []string
 	Tags   []string
+	Zones  []string
 }
 
 func newConstraints(args ConstraintsArgs) *constraints {
@@ -33,6 +34,8 @@ func newConstraints(args ConstraintsArgs) *constraints {
 	copy(tags, args.Tags)
 	spaces := make([]string, len(args.Spaces))
 	copy(spaces, args.Spaces)
+	zones := make([]string, len(args.Zones))
+	copy(zones, args.Zones)
 	return &constraints{
 		Version:       1,
 		Architecture_: args.Architecture,
@@ -44,6 +47,7 @@ func newConstraints(args ConstraintsArgs) *constraints {
 		RootDisk_:     args.RootDisk,
 		Spaces_:       spaces,
 		Tags_:         tags,
+		Zones_:        zones,
 	}
 }
 
@@ -60,6 +64,7 @@ type constraints struct {
 
 	Spaces_ []string `yaml:"spaces,omitempty"`
 	Tags_   []string `yaml:"tags,omitempty"`
+	Zones_  []string `yaml:"zones,omitempty"`
 }
 
 // Architecture implements Constraints.
@@ -117,6 +122,16 @@ func (c *constraints) Tags() []string {
 	return tags
 }
 
+// Zones implements Constraints.
+func (c *constraints) Zones() []string {
+	var zones []string
+	if count := len(c.Zones_); count > 0 {
+		zones = make([]string, count)
+		copy(zones, c.Zones_)
+	}
+	return zones
+}
+
 func importConstraints(source map[string]interface{}) (*constraints, error) {
 	version, err := getVersion(source)
 	if err != nil {
@@ -149,6 +164,7 @@ func importConstraintsV1(source map[string]interface{}) (*constraints, error) {
 
 		"spaces": schema.List(schema.String()),
 		"tags":   schema.List(schema.String()),
+		"zones":  schema.List(schema.String()),
 	}
 	// Some values don't have to be there.
 	defaults := schema.Defaults{
@@ -162,6 +178,7 @@ func importConstraintsV1(source map[string]interface{}) (*constraints, error) {
 
 		"spaces": schema.Omit,
 		"tags":   schema.Omit,
+		"zones":  schema.Omit,
 	}
 	checker := schema.FieldMap(fields, defaults)
 
@@ -185,6 +202,7 @@ func importConstraintsV1(source map[string]interface{}) (*constraints, error) {
 
 		Spaces_: convertToStringSlice(valid["spaces"]),
 		Tags_:   convertToStringSlice(valid["tags"]),
+		Zones_:  convertToStringSlice(valid["zones"]),
 	}, nil
 }
 
@@ -202,5 +220,6 @@ func (c ConstraintsArgs) empty() bool {
 		c.Memory == 0 &&
 		c.RootDisk == 0 &&
 		c.Spaces == nil &&
-		c.Tags == nil
+		c.Tags == nil &&
+		c.Zones == nil
 }
@@ -34,6 +34,7 @@ func (s *ConstraintsSerializationSuite) allArgs() ConstraintsArgs {
 		RootDisk:     200 * gig,
 		Spaces:       []string{"my", "own"},
 		Tags:         []string{"much", "strong"},
+		Zones:        []string{"az1", "az2"},
 	}
 }
 
@@ -63,6 +64,12 @@ func (s *ConstraintsSerializationSuite) TestNewConstraints(c *gc.C) {
 	tags[0] = "weird"
 	c.Assert(instance.Spaces(), jc.DeepEquals, []string{"my", "own"})
 	c.Assert(instance.Tags(), jc.DeepEquals, []string{"much", "strong"})
+
+	args.Zones[0] = "weird"
+	zones := instance.Zones()
+	c.Assert(zones, jc.DeepEquals, []string{"az1", "az2"})
+	zones[0] = "weird"
+	c.Assert(instance.Zones(), jc.DeepEquals, []string{"az1", "az2"})
 }
 
 func (s *ConstraintsSerializationSuite) TestNewConstraintsEmpty(c *gc.C) {
@@ -75,6 +82,7 @@ func (s *ConstraintsSerializationSuite) TestEmptyTagsAndSpaces(c *gc.C) {
 	// We actually want them to be nil, not empty slices.
 	c.Assert(instance.Tags(), gc.IsNil)
 	c.Assert(instance.Spaces(), gc.IsNil)
+	c.Assert(instance.Zones(), gc.IsNil)
 }
 
 func (s *ConstraintsSerializationSuite) TestParsingSerializedData(c *gc.C) {
@@ -60,6 +60,33 @@ type Model interface {
 	Relations() []Relation
 	AddRelation(RelationArgs) Relation
 
+	Storages() []Storage
+	AddStorage(StorageArgs) Storage
+
+	StoragePools() []StoragePool
+	AddStoragePool(StoragePoolArgs) StoragePool
+
+	Volumes() []Volume
+	AddVolume(VolumeArgs) Volume
+
+	VolumeSnapshots() []VolumeSnapshot
+	AddVolumeSnapshot(VolumeSnapshotArgs) VolumeSnapshot
+
+	Filesystems() []Filesystem
+	AddFilesystem(FilesystemArgs) Filesystem
+
+	Spaces() []Space
+	AddSpace(SpaceArgs) Space
+
+	Subnets() []Subnet
+	AddSubnet(SubnetArgs) Subnet
+
+	LinkLayerDevices() []LinkLayerDevice
+	AddLinkLayerDevice(LinkLayerDeviceArgs) LinkLayerDevice
+
+	IPAddresses() []IPAddress
+	AddIPAddress(IPAddressArgs) IPAddress
+
 	Sequences() map[string]int
 	SetSequence(name string, value int)
 
@@ -67,7 +94,7 @@ type Model interface {
 }
 
 // User represents a user of the model. Users are able to connect to, and
-// depending on the read only flag, modify the model.
+// depending on their access, modify the model.
 type User interface {
 	Name() names.UserTag
 	DisplayName() string
@@ -75,6 +102,7 @@ type User interface {
 	DateCreated() time.Time
 	LastConnection() time.Time
 	ReadOnly() bool
+	Access() string
 }
 
 // Address represents an IP Address of some form.
@@ -191,6 +219,7 @@ type Constraints interface {
 
 	Spaces() []string
 	Tags() []string
+	Zones() []string
 }
 
 // Status represents an agent, service, or workload status.
@@ -216,6 +245,7 @@ type Service interface {
 	CharmModifiedVersion() int
 	ForceCharm() bool
 	Exposed() bool
+	ExposedCIDRs() []string
 	MinUnits() int
 
 	Settings() map[string]interface{}
@@ -225,6 +255,15 @@ type Service interface {
 	LeadershipSettings() map[string]interface{}
 
 	MetricsCredentials() []byte
+	StorageConstraints() map[string]StorageConstraint
+
+	// EndpointBindings returns a map of charm endpoint name to the
+	// name of the space that endpoint is bound to. An empty space
+	// name means the endpoint is bound to the default space.
+	EndpointBindings() map[string]string
+
+	Resources() []Resource
+	AddResource(ResourceArgs) Resource
 
 	Status() Status
 	SetStatus(StatusArgs)
@@ -232,9 +271,24 @@ type Service interface {
 	Units() []Unit
 	AddUnit(UnitArgs) Unit
 
+	// Users returns the access that has been granted to users on
+	// the service, in addition to their access to the model.
+	Users() []ServiceUser
+	AddUser(ServiceUserArgs) ServiceUser
+
 	Validate() error
 }
 
+// ServiceUser represents the access a user has been granted on a
+// service.
+type ServiceUser interface {
+	Name() names.UserTag
+	Access() string
+	CreatedBy() names.UserTag
+	DateCreated() time.Time
+	GrantedModelAccess() bool
+}
+
 // Unit represents an instance of a service in a model.
 type Unit interface {
 	HasAnnotations
@@ -252,8 +306,6 @@ type Unit interface {
 	MeterStatusCode() string
 	MeterStatusInfo() string
 
-	// TODO: storage
-
 	Tools() AgentTools
 	SetTools(AgentToolsArgs)
 
@@ -269,6 +321,12 @@ type Unit interface {
 	AgentStatusHistory() []Status
 	SetAgentStatusHistory([]StatusArgs)
 
+	Resources() []UnitResource
+	AddResource(UnitResourceArgs) UnitResource
+
+	Payloads() []Payload
+	AddPayload(PayloadArgs) Payload
+
 	Validate() error
 }
 
@@ -304,3 +362,217 @@ type Endpoint interface {
 	Settings(unitName string) map[string]interface{}
 	SetUnitSettings(unitName string, settings map[string]interface{})
 }
+
+// StorageConstraint represents the user-specified constraints for
+// provisioning storage instances for a service unit.
+type StorageConstraint interface {
+	// Pool is the name of the storage pool from which to provision the
+	// storage instances.
+	Pool() string
+	// Size is the required size of the storage instances, in MiB.
+	Size() uint64
+	// Count is the required number of storage instances.
+	Count() uint64
+}
+
+// Storage represents the state of a unit or service-wide storage instance
+// in the model.
+type Storage interface {
+	Tag() names.StorageTag
+	Kind() string
+	// Owner returns the tag of the service or unit that owns this storage
+	// instance.
+	Owner() (names.Tag, error)
+	Name() string
+
+	Attachments() []names.UnitTag
+
+	Validate() error
+}
+
+// StoragePool represents a named storage pool and its settings.
+type StoragePool interface {
+	Name() string
+	Provider() string
+	Attributes() map[string]interface{}
+}
+
+// Volume represents a volume (disk, logical volume, etc.) in the model.
+type Volume interface {
+	HasStatusHistory
+
+	Tag() names.VolumeTag
+	Storage() names.StorageTag
+	// Binding returns the tag of the entity that the volume's
+	// lifecycle is bound to, if any.
+	Binding() (names.Tag, error)
+
+	Provisioned() bool
+
+	Size() uint64
+	// RequestedSize returns the size that the volume has been
+	// requested to grow to, or zero if no resize is pending.
+	RequestedSize() uint64
+	Pool() string
+
+	HardwareID() string
+	VolumeID() string
+	Persistent() bool
+
+	Status() Status
+	SetStatus(StatusArgs)
+
+	Attachments() []VolumeAttachment
+	AddAttachment(VolumeAttachmentArgs) VolumeAttachment
+
+	Validate() error
+}
+
+// VolumeAttachment represents a volume attached to a machine.
+type VolumeAttachment interface {
+	Machine() names.MachineTag
+	Provisioned() bool
+	ReadOnly() bool
+	DeviceName() string
+	DeviceLink() string
+	BusAddress() string
+}
+
+// VolumeSnapshot represents a provider snapshot of a volume in the model.
+type VolumeSnapshot interface {
+	ID() string
+	Volume() names.VolumeTag
+	Storage() names.StorageTag
+	Pool() string
+	SnapshotID() string
+	Size() uint64
+	Created() time.Time
+}
+
+// Filesystem represents a filesystem in the model.
+type Filesystem interface {
+	HasStatusHistory
+
+	Tag() names.FilesystemTag
+	Storage() names.StorageTag
+	// Volume returns the tag of the volume backing the filesystem,
+	// if any.
+	Volume() names.VolumeTag
+	// Binding returns the tag of the entity that the filesystem's
+	// lifecycle is bound to, if any.
+	Binding() (names.Tag, error)
+
+	Provisioned() bool
+
+	Size() uint64
+	Pool() string
+
+	FilesystemID() string
+
+	Status() Status
+	SetStatus(StatusArgs)
+
+	Attachments() []FilesystemAttachment
+	AddAttachment(FilesystemAttachmentArgs) FilesystemAttachment
+
+	Validate() error
+}
+
+// FilesystemAttachment represents a filesystem attached to a machine.
+type FilesystemAttachment interface {
+	Machine() names.MachineTag
+	Provisioned() bool
+	MountPoint() string
+	ReadOnly() bool
+}
+
+// Resource represents a charm resource of a service. The Revision is
+// the resource in use by the service, and the CharmStoreRevision, if
+// set, is the latest revision known to be available in the charm store.
+type Resource interface {
+	Name() string
+
+	Revision() ResourceRevision
+	SetRevision(ResourceRevisionArgs)
+
+	CharmStoreRevision() ResourceRevision
+	SetCharmStoreRevision(ResourceRevisionArgs)
+
+	Validate() error
+}
+
+// UnitResource represents the revision of a service resource that
+// a particular unit is using.
+type UnitResource interface {
+	Name() string
+	Revision() ResourceRevision
+}
+
+// ResourceRevision holds the details of a specific revision of a
+// charm resource.
+type ResourceRevision interface {
+	Revision() int
+	Type() string
+	Path() string
+	Description() string
+	Origin() string
+	FingerprintHex() string
+	Size() int64
+	Timestamp() time.Time
+	Username() string
+}
+
+// Payload represents a workload payload being tracked by a unit.
+type Payload interface {
+	Name() string
+	Type() string
+	RawID() string
+	State() string
+	Labels() []string
+}
+
+// Space represents a network space, which is a named collection
+// of subnets.
+type Space interface {
+	Name() string
+	Public() bool
+	ProviderID() string
+}
+
+// Subnet represents a network subnet known to the model.
+type Subnet interface {
+	CIDR() string
+	ProviderID() string
+	VLANTag() int
+	AvailabilityZone() string
+	SpaceName() string
+	AllocatableIPHigh() string
+	AllocatableIPLow() string
+}
+
+// LinkLayerDevice represents a network device on a machine.
+type LinkLayerDevice interface {
+	Name() string
+	MTU() uint
+	ProviderID() string
+	MachineID() string
+	Type() string
+	MACAddress() string
+	IsAutoStart() bool
+	IsUp() bool
+	ParentName() string
+}
+
+// IPAddress represents an IP address assigned to a link-layer
+// device on a machine.
+type IPAddress interface {
+	ProviderID() string
+	DeviceName() string
+	MachineID() string
+	SubnetCIDR() string
+	ConfigMethod() string
+	Value() string
+	DNSServers() []string
+	DNSSearchDomains() []string
+	GatewayAddress() string
+}
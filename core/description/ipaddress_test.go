@@ -0,0 +1,87 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package description
+
+import (
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/yaml.v2"
+)
+
+type IPAddressSerializationSuite struct {
+	SliceSerializationSuite
+}
+
+var _ = gc.Suite(&IPAddressSerializationSuite{})
+
+func (s *IPAddressSerializationSuite) SetUpTest(c *gc.C) {
+	s.SliceSerializationSuite.SetUpTest(c)
+	s.importName = "ip addresses"
+	s.sliceName = "ip-addresses"
+	s.importFunc = func(m map[string]interface{}) (interface{}, error) {
+		return importIPAddresses(m)
+	}
+	s.testFields = func(m map[string]interface{}) {
+		m["ip-addresses"] = []interface{}{}
+	}
+}
+
+func testIPAddressArgs() IPAddressArgs {
+	return IPAddressArgs{
+		ProviderID:       "magic",
+		DeviceName:       "eth0",
+		MachineID:        "0",
+		SubnetCIDR:       "10.0.0.0/24",
+		ConfigMethod:     "static",
+		Value:            "10.0.0.4",
+		DNSServers:       []string{"10.1.0.1", "10.2.0.1"},
+		DNSSearchDomains: []string{"bam", "mam"},
+		GatewayAddress:   "10.0.0.1",
+	}
+}
+
+func (s *IPAddressSerializationSuite) TestNewIPAddress(c *gc.C) {
+	addr := newIPAddress(testIPAddressArgs())
+	c.Check(addr.ProviderID(), gc.Equals, "magic")
+	c.Check(addr.DeviceName(), gc.Equals, "eth0")
+	c.Check(addr.MachineID(), gc.Equals, "0")
+	c.Check(addr.SubnetCIDR(), gc.Equals, "10.0.0.0/24")
+	c.Check(addr.ConfigMethod(), gc.Equals, "static")
+	c.Check(addr.Value(), gc.Equals, "10.0.0.4")
+	c.Check(addr.DNSServers(), jc.DeepEquals, []string{"10.1.0.1", "10.2.0.1"})
+	c.Check(addr.DNSSearchDomains(), jc.DeepEquals, []string{"bam", "mam"})
+	c.Check(addr.GatewayAddress(), gc.Equals, "10.0.0.1")
+}
+
+func (s *IPAddressSerializationSuite) TestInvalidValue(c *gc.C) {
+	addr := newIPAddress(IPAddressArgs{Value: "bogus", DeviceName: "eth0"})
+	c.Assert(addr.Validate(), gc.ErrorMatches, `ip address value "bogus" not valid`)
+}
+
+func (s *IPAddressSerializationSuite) TestParsingSerializedData(c *gc.C) {
+	initial := ipaddresses{
+		Version: 1,
+		IPAddresses_: []*ipaddress{
+			newIPAddress(testIPAddressArgs()),
+			newIPAddress(IPAddressArgs{
+				DeviceName:   "lo",
+				MachineID:    "0",
+				SubnetCIDR:   "127.0.0.0/8",
+				ConfigMethod: "loopback",
+				Value:        "127.0.0.1",
+			}),
+		},
+	}
+
+	bytes, err := yaml.Marshal(initial)
+	c.Assert(err, jc.ErrorIsNil)
+
+	var source map[string]interface{}
+	err = yaml.Unmarshal(bytes, &source)
+	c.Assert(err, jc.ErrorIsNil)
+
+	addresses, err := importIPAddresses(source)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(addresses, jc.DeepEquals, initial.IPAddresses_)
+}
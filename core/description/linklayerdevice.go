@@ -0,0 +1,190 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package description
+
+import (
+	"github.com/juju/errors"
+	"github.com/juju/names"
+	"github.com/juju/schema"
+)
+
+type linklayerdevices struct {
+	Version           int                `yaml:"version"`
+	LinkLayerDevices_ []*linklayerdevice `yaml:"link-layer-devices"`
+}
+
+type linklayerdevice struct {
+	Name_        string `yaml:"name"`
+	MTU_         uint   `yaml:"mtu"`
+	ProviderID_  string `yaml:"provider-id,omitempty"`
+	MachineID_   string `yaml:"machine-id"`
+	Type_        string `yaml:"type"`
+	MACAddress_  string `yaml:"mac-address"`
+	IsAutoStart_ bool   `yaml:"is-autostart"`
+	IsUp_        bool   `yaml:"is-up"`
+	ParentName_  string `yaml:"parent-name,omitempty"`
+}
+
+// LinkLayerDeviceArgs is an argument struct used to create a new
+// internal linklayerdevice type that supports the LinkLayerDevice
+// interface.
+type LinkLayerDeviceArgs struct {
+	Name        string
+	MTU         uint
+	ProviderID  string
+	MachineID   string
+	Type        string
+	MACAddress  string
+	IsAutoStart bool
+	IsUp        bool
+	ParentName  string
+}
+
+func newLinkLayerDevice(args LinkLayerDeviceArgs) *linklayerdevice {
+	return &linklayerdevice{
+		Name_:        args.Name,
+		MTU_:         args.MTU,
+		ProviderID_:  args.ProviderID,
+		MachineID_:   args.MachineID,
+		Type_:        args.Type,
+		MACAddress_:  args.MACAddress,
+		IsAutoStart_: args.IsAutoStart,
+		IsUp_:        args.IsUp,
+		ParentName_:  args.ParentName,
+	}
+}
+
+// Name implements LinkLayerDevice.
+func (d *linklayerdevice) Name() string {
+	return d.Name_
+}
+
+// MTU implements LinkLayerDevice.
+func (d *linklayerdevice) MTU() uint {
+	return d.MTU_
+}
+
+// ProviderID implements LinkLayerDevice.
+func (d *linklayerdevice) ProviderID() string {
+	return d.ProviderID_
+}
+
+// MachineID implements LinkLayerDevice.
+func (d *linklayerdevice) MachineID() string {
+	return d.MachineID_
+}
+
+// Type implements LinkLayerDevice.
+func (d *linklayerdevice) Type() string {
+	return d.Type_
+}
+
+// MACAddress implements LinkLayerDevice.
+func (d *linklayerdevice) MACAddress() string {
+	return d.MACAddress_
+}
+
+// IsAutoStart implements LinkLayerDevice.
+func (d *linklayerdevice) IsAutoStart() bool {
+	return d.IsAutoStart_
+}
+
+// IsUp implements LinkLayerDevice.
+func (d *linklayerdevice) IsUp() bool {
+	return d.IsUp_
+}
+
+// ParentName implements LinkLayerDevice.
+func (d *linklayerdevice) ParentName() string {
+	return d.ParentName_
+}
+
+// Validate implements LinkLayerDevice.
+func (d *linklayerdevice) Validate() error {
+	if d.Name_ == "" {
+		return errors.NotValidf("link-layer device missing name")
+	}
+	if !names.IsValidMachine(d.MachineID_) {
+		return errors.NotValidf("link-layer device %q machine id %q", d.Name_, d.MachineID_)
+	}
+	return nil
+}
+
+func importLinkLayerDevices(source map[string]interface{}) ([]*linklayerdevice, error) {
+	checker := versionedChecker("link-layer-devices")
+	coerced, err := checker.Coerce(source, nil)
+	if err != nil {
+		return nil, errors.Annotatef(err, "link-layer devices version schema check failed")
+	}
+	valid := coerced.(map[string]interface{})
+
+	version := int(valid["version"].(int64))
+	importFunc, ok := linkLayerDeviceDeserializationFuncs[version]
+	if !ok {
+		return nil, errors.NotValidf("version %d", version)
+	}
+	sourceList := valid["link-layer-devices"].([]interface{})
+	return importLinkLayerDeviceList(sourceList, importFunc)
+}
+
+func importLinkLayerDeviceList(sourceList []interface{}, importFunc linkLayerDeviceDeserializationFunc) ([]*linklayerdevice, error) {
+	result := make([]*linklayerdevice, 0, len(sourceList))
+	for i, value := range sourceList {
+		source, ok := value.(map[string]interface{})
+		if !ok {
+			return nil, errors.Errorf("unexpected value for link-layer device %d, %T", i, value)
+		}
+		device, err := importFunc(source)
+		if err != nil {
+			return nil, errors.Annotatef(err, "link-layer device %d", i)
+		}
+		result = append(result, device)
+	}
+	return result, nil
+}
+
+type linkLayerDeviceDeserializationFunc func(map[string]interface{}) (*linklayerdevice, error)
+
+var linkLayerDeviceDeserializationFuncs = map[int]linkLayerDeviceDeserializationFunc{
+	1: importLinkLayerDeviceV1,
+}
+
+func importLinkLayerDeviceV1(source map[string]interface{}) (*linklayerdevice, error) {
+	fields := schema.Fields{
+		"name":         schema.String(),
+		"mtu":          schema.Int(),
+		"provider-id":  schema.String(),
+		"machine-id":   schema.String(),
+		"type":         schema.String(),
+		"mac-address":  schema.String(),
+		"is-autostart": schema.Bool(),
+		"is-up":        schema.Bool(),
+		"parent-name":  schema.String(),
+	}
+	defaults := schema.Defaults{
+		"provider-id": "",
+		"parent-name": "",
+	}
+	checker := schema.FieldMap(fields, defaults)
+
+	coerced, err := checker.Coerce(source, nil)
+	if err != nil {
+		return nil, errors.Annotatef(err, "link-layer device v1 schema check failed")
+	}
+	valid := coerced.(map[string]interface{})
+	// From here we know that the map returned from the schema coercion
+	// contains fields of the right type.
+
+	return &linklayerdevice{
+		Name_:        valid["name"].(string),
+		MTU_:         uint(valid["mtu"].(int64)),
+		ProviderID_:  valid["provider-id"].(string),
+		MachineID_:   valid["machine-id"].(string),
+		Type_:        valid["type"].(string),
+		MACAddress_:  valid["mac-address"].(string),
+		IsAutoStart_: valid["is-autostart"].(bool),
+		IsUp_:        valid["is-up"].(bool),
+		ParentName_:  valid["parent-name"].(string),
+	}, nil
+}
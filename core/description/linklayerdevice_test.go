@@ -0,0 +1,80 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package description
+
+import (
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/yaml.v2"
+)
+
+type LinkLayerDeviceSerializationSuite struct {
+	SliceSerializationSuite
+}
+
+var _ = gc.Suite(&LinkLayerDeviceSerializationSuite{})
+
+func (s *LinkLayerDeviceSerializationSuite) SetUpTest(c *gc.C) {
+	s.SliceSerializationSuite.SetUpTest(c)
+	s.importName = "link-layer devices"
+	s.sliceName = "link-layer-devices"
+	s.importFunc = func(m map[string]interface{}) (interface{}, error) {
+		return importLinkLayerDevices(m)
+	}
+	s.testFields = func(m map[string]interface{}) {
+		m["link-layer-devices"] = []interface{}{}
+	}
+}
+
+func testLinkLayerDeviceArgs() LinkLayerDeviceArgs {
+	return LinkLayerDeviceArgs{
+		Name:        "eth0",
+		MTU:         1500,
+		ProviderID:  "magic",
+		MachineID:   "0",
+		Type:        "ethernet",
+		MACAddress:  "00:16:3e:00:00:01",
+		IsAutoStart: true,
+		IsUp:        true,
+		ParentName:  "br-eth0",
+	}
+}
+
+func (s *LinkLayerDeviceSerializationSuite) TestNewLinkLayerDevice(c *gc.C) {
+	device := newLinkLayerDevice(testLinkLayerDeviceArgs())
+	c.Check(device.Name(), gc.Equals, "eth0")
+	c.Check(device.MTU(), gc.Equals, uint(1500))
+	c.Check(device.ProviderID(), gc.Equals, "magic")
+	c.Check(device.MachineID(), gc.Equals, "0")
+	c.Check(device.Type(), gc.Equals, "ethernet")
+	c.Check(device.MACAddress(), gc.Equals, "00:16:3e:00:00:01")
+	c.Check(device.IsAutoStart(), jc.IsTrue)
+	c.Check(device.IsUp(), jc.IsTrue)
+	c.Check(device.ParentName(), gc.Equals, "br-eth0")
+}
+
+func (s *LinkLayerDeviceSerializationSuite) TestParsingSerializedData(c *gc.C) {
+	initial := linklayerdevices{
+		Version: 1,
+		LinkLayerDevices_: []*linklayerdevice{
+			newLinkLayerDevice(testLinkLayerDeviceArgs()),
+			newLinkLayerDevice(LinkLayerDeviceArgs{
+				Name:      "lo",
+				MachineID: "0/lxc/0",
+				Type:      "loopback",
+			}),
+		},
+	}
+
+	bytes, err := yaml.Marshal(initial)
+	c.Assert(err, jc.ErrorIsNil)
+
+	var source map[string]interface{}
+	err = yaml.Unmarshal(bytes, &source)
+	c.Assert(err, jc.ErrorIsNil)
+
+	devices, err := importLinkLayerDevices(source)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(devices, jc.DeepEquals, initial.LinkLayerDevices_)
+}
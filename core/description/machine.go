@@ -7,6 +7,7 @@ import (
 	"github.com/juju/errors"
 	"github.com/juju/names"
 	"github.com/juju/schema"
+	"github.com/juju/utils/set"
 	"github.com/juju/version"
 )
 
@@ -320,6 +321,16 @@ func (m *machine) Validate() error {
 	return nil
 }
 
+// machineIds returns the ids of the machine and all of its containers,
+// recursively.
+func (m *machine) machineIds() set.Strings {
+	result := set.NewStrings(m.Id_)
+	for _, container := range m.Containers_ {
+		result = result.Union(container.machineIds())
+	}
+	return result
+}
+
 func importMachines(source map[string]interface{}) ([]*machine, error) {
 	checker := versionedChecker("machines")
 	coerced, err := checker.Coerce(source, nil)
@@ -40,6 +40,15 @@ func NewModel(args ModelArgs) Model {
 	m.setMachines(nil)
 	m.setServices(nil)
 	m.setRelations(nil)
+	m.setStorages(nil)
+	m.setStoragePools(nil)
+	m.setVolumes(nil)
+	m.setVolumeSnapshots(nil)
+	m.setFilesystems(nil)
+	m.setSpaces(nil)
+	m.setSubnets(nil)
+	m.setLinkLayerDevices(nil)
+	m.setIPAddresses(nil)
 	return m
 }
 
@@ -80,15 +89,22 @@ type model struct {
 	Services_  services  `yaml:"services"`
 	Relations_ relations `yaml:"relations"`
 
+	Storages_        storages        `yaml:"storages"`
+	StoragePools_    storagepools    `yaml:"storage-pools"`
+	Volumes_         volumes         `yaml:"volumes"`
+	VolumeSnapshots_ volumesnapshots `yaml:"volume-snapshots"`
+	Filesystems_     filesystems     `yaml:"filesystems"`
+
+	Spaces_           spaces           `yaml:"spaces"`
+	Subnets_          subnets          `yaml:"subnets"`
+	LinkLayerDevices_ linklayerdevices `yaml:"link-layer-devices"`
+	IPAddresses_      ipaddresses      `yaml:"ip-addresses"`
+
 	Sequences_ map[string]int `yaml:"sequences"`
 
 	Annotations_ `yaml:"annotations,omitempty"`
 
 	Constraints_ *constraints `yaml:"constraints,omitempty"`
-
-	// TODO:
-	// Spaces
-	// Storage
 }
 
 func (m *model) Tag() names.ModelTag {
@@ -237,6 +253,213 @@ func (m *model) setRelations(relationList []*relation) {
 	}
 }
 
+// Storages implements Model.
+func (m *model) Storages() []Storage {
+	var result []Storage
+	for _, storage := range m.Storages_.Storages_ {
+		result = append(result, storage)
+	}
+	return result
+}
+
+// AddStorage implements Model.
+func (m *model) AddStorage(args StorageArgs) Storage {
+	storage := newStorage(args)
+	m.Storages_.Storages_ = append(m.Storages_.Storages_, storage)
+	return storage
+}
+
+func (m *model) setStorages(storageList []*storage) {
+	m.Storages_ = storages{
+		Version:   1,
+		Storages_: storageList,
+	}
+}
+
+// StoragePools implements Model.
+func (m *model) StoragePools() []StoragePool {
+	var result []StoragePool
+	for _, pool := range m.StoragePools_.Pools_ {
+		result = append(result, pool)
+	}
+	return result
+}
+
+// AddStoragePool implements Model.
+func (m *model) AddStoragePool(args StoragePoolArgs) StoragePool {
+	pool := newStoragePool(args)
+	m.StoragePools_.Pools_ = append(m.StoragePools_.Pools_, pool)
+	return pool
+}
+
+func (m *model) setStoragePools(poolList []*storagepool) {
+	m.StoragePools_ = storagepools{
+		Version: 1,
+		Pools_:  poolList,
+	}
+}
+
+// Volumes implements Model.
+func (m *model) Volumes() []Volume {
+	var result []Volume
+	for _, volume := range m.Volumes_.Volumes_ {
+		result = append(result, volume)
+	}
+	return result
+}
+
+// AddVolume implements Model.
+func (m *model) AddVolume(args VolumeArgs) Volume {
+	volume := newVolume(args)
+	m.Volumes_.Volumes_ = append(m.Volumes_.Volumes_, volume)
+	return volume
+}
+
+func (m *model) setVolumes(volumeList []*volume) {
+	m.Volumes_ = volumes{
+		Version:  1,
+		Volumes_: volumeList,
+	}
+}
+
+// VolumeSnapshots implements Model.
+func (m *model) VolumeSnapshots() []VolumeSnapshot {
+	var result []VolumeSnapshot
+	for _, snapshot := range m.VolumeSnapshots_.Snapshots_ {
+		result = append(result, snapshot)
+	}
+	return result
+}
+
+// AddVolumeSnapshot implements Model.
+func (m *model) AddVolumeSnapshot(args VolumeSnapshotArgs) VolumeSnapshot {
+	snapshot := newVolumeSnapshot(args)
+	m.VolumeSnapshots_.Snapshots_ = append(m.VolumeSnapshots_.Snapshots_, snapshot)
+	return snapshot
+}
+
+func (m *model) setVolumeSnapshots(snapshotList []*volumesnapshot) {
+	m.VolumeSnapshots_ = volumesnapshots{
+		Version:    1,
+		Snapshots_: snapshotList,
+	}
+}
+
+// Filesystems implements Model.
+func (m *model) Filesystems() []Filesystem {
+	var result []Filesystem
+	for _, filesystem := range m.Filesystems_.Filesystems_ {
+		result = append(result, filesystem)
+	}
+	return result
+}
+
+// AddFilesystem implements Model.
+func (m *model) AddFilesystem(args FilesystemArgs) Filesystem {
+	filesystem := newFilesystem(args)
+	m.Filesystems_.Filesystems_ = append(m.Filesystems_.Filesystems_, filesystem)
+	return filesystem
+}
+
+func (m *model) setFilesystems(filesystemList []*filesystem) {
+	m.Filesystems_ = filesystems{
+		Version:      1,
+		Filesystems_: filesystemList,
+	}
+}
+
+// Spaces implements Model.
+func (m *model) Spaces() []Space {
+	var result []Space
+	for _, space := range m.Spaces_.Spaces_ {
+		result = append(result, space)
+	}
+	return result
+}
+
+// AddSpace implements Model.
+func (m *model) AddSpace(args SpaceArgs) Space {
+	space := newSpace(args)
+	m.Spaces_.Spaces_ = append(m.Spaces_.Spaces_, space)
+	return space
+}
+
+func (m *model) setSpaces(spaceList []*space) {
+	m.Spaces_ = spaces{
+		Version: 1,
+		Spaces_: spaceList,
+	}
+}
+
+// Subnets implements Model.
+func (m *model) Subnets() []Subnet {
+	var result []Subnet
+	for _, subnet := range m.Subnets_.Subnets_ {
+		result = append(result, subnet)
+	}
+	return result
+}
+
+// AddSubnet implements Model.
+func (m *model) AddSubnet(args SubnetArgs) Subnet {
+	subnet := newSubnet(args)
+	m.Subnets_.Subnets_ = append(m.Subnets_.Subnets_, subnet)
+	return subnet
+}
+
+func (m *model) setSubnets(subnetList []*subnet) {
+	m.Subnets_ = subnets{
+		Version:  1,
+		Subnets_: subnetList,
+	}
+}
+
+// LinkLayerDevices implements Model.
+func (m *model) LinkLayerDevices() []LinkLayerDevice {
+	var result []LinkLayerDevice
+	for _, device := range m.LinkLayerDevices_.LinkLayerDevices_ {
+		result = append(result, device)
+	}
+	return result
+}
+
+// AddLinkLayerDevice implements Model.
+func (m *model) AddLinkLayerDevice(args LinkLayerDeviceArgs) LinkLayerDevice {
+	device := newLinkLayerDevice(args)
+	m.LinkLayerDevices_.LinkLayerDevices_ = append(m.LinkLayerDevices_.LinkLayerDevices_, device)
+	return device
+}
+
+func (m *model) setLinkLayerDevices(deviceList []*linklayerdevice) {
+	m.LinkLayerDevices_ = linklayerdevices{
+		Version:           1,
+		LinkLayerDevices_: deviceList,
+	}
+}
+
+// IPAddresses implements Model.
+func (m *model) IPAddresses() []IPAddress {
+	var result []IPAddress
+	for _, addr := range m.IPAddresses_.IPAddresses_ {
+		result = append(result, addr)
+	}
+	return result
+}
+
+// AddIPAddress implements Model.
+func (m *model) AddIPAddress(args IPAddressArgs) IPAddress {
+	addr := newIPAddress(args)
+	m.IPAddresses_.IPAddresses_ = append(m.IPAddresses_.IPAddresses_, addr)
+	return addr
+}
+
+func (m *model) setIPAddresses(addressList []*ipaddress) {
+	m.IPAddresses_ = ipaddresses{
+		Version:      1,
+		IPAddresses_: addressList,
+	}
+}
+
 // Sequences implements Model.
 func (m *model) Sequences() map[string]int {
 	return m.Sequences_
@@ -268,10 +491,12 @@ func (m *model) Validate() error {
 	}
 
 	unitsWithOpenPorts := set.NewStrings()
+	allMachines := set.NewStrings()
 	for _, machine := range m.Machines_.Machines_ {
 		if err := machine.Validate(); err != nil {
 			return errors.Trace(err)
 		}
+		allMachines = allMachines.Union(machine.machineIds())
 		for _, op := range machine.OpenedPorts() {
 			for _, pr := range op.OpenPorts() {
 				unitsWithOpenPorts.Add(pr.UnitName())
@@ -292,9 +517,136 @@ func (m *model) Validate() error {
 		return errors.Errorf("unknown unit names in open ports: %s", unknownUnitsWithPorts.SortedValues())
 	}
 
+	if err := m.validateStorage(allMachines, allUnits); err != nil {
+		return errors.Trace(err)
+	}
+
+	if err := m.validateNetworking(allMachines); err != nil {
+		return errors.Trace(err)
+	}
+
 	return m.validateRelations()
 }
 
+// validateStorage makes sure that the storage instances, volumes and
+// filesystems are valid, and that they only refer to entities that
+// exist in the model.
+func (m *model) validateStorage(allMachines, allUnits set.Strings) error {
+	allServices := set.NewStrings()
+	for _, service := range m.Services_.Services_ {
+		allServices.Add(service.Name())
+	}
+	allStorage := set.NewStrings()
+	for _, storage := range m.Storages_.Storages_ {
+		if err := storage.Validate(); err != nil {
+			return errors.Trace(err)
+		}
+		allStorage.Add(storage.ID_)
+		owner, err := storage.Owner()
+		if err != nil {
+			return errors.Trace(err)
+		}
+		switch owner := owner.(type) {
+		case nil:
+		case names.UnitTag:
+			if !allUnits.Contains(owner.Id()) {
+				return errors.NotValidf("storage %q owned by unknown unit %q", storage.ID_, owner.Id())
+			}
+		case names.ServiceTag:
+			if !allServices.Contains(owner.Id()) {
+				return errors.NotValidf("storage %q owned by unknown service %q", storage.ID_, owner.Id())
+			}
+		default:
+			return errors.NotValidf("storage %q owned by %s", storage.ID_, owner)
+		}
+		for _, unit := range storage.Attachments_ {
+			if !allUnits.Contains(unit) {
+				return errors.NotValidf("storage %q attached to unknown unit %q", storage.ID_, unit)
+			}
+		}
+	}
+	allVolumes := set.NewStrings()
+	for _, volume := range m.Volumes_.Volumes_ {
+		if err := volume.Validate(); err != nil {
+			return errors.Trace(err)
+		}
+		allVolumes.Add(volume.ID_)
+		if volume.StorageID_ != "" && !allStorage.Contains(volume.StorageID_) {
+			return errors.NotValidf("volume %q referencing unknown storage %q", volume.ID_, volume.StorageID_)
+		}
+		for _, attachment := range volume.Attachments_.Attachments_ {
+			if !allMachines.Contains(attachment.MachineID_) {
+				return errors.NotValidf("volume %q attached to unknown machine %q", volume.ID_, attachment.MachineID_)
+			}
+		}
+	}
+	for _, filesystem := range m.Filesystems_.Filesystems_ {
+		if err := filesystem.Validate(); err != nil {
+			return errors.Trace(err)
+		}
+		if filesystem.StorageID_ != "" && !allStorage.Contains(filesystem.StorageID_) {
+			return errors.NotValidf("filesystem %q referencing unknown storage %q", filesystem.ID_, filesystem.StorageID_)
+		}
+		if filesystem.VolumeID_ != "" && !allVolumes.Contains(filesystem.VolumeID_) {
+			return errors.NotValidf("filesystem %q referencing unknown volume %q", filesystem.ID_, filesystem.VolumeID_)
+		}
+		for _, attachment := range filesystem.Attachments_.Attachments_ {
+			if !allMachines.Contains(attachment.MachineID_) {
+				return errors.NotValidf("filesystem %q attached to unknown machine %q", filesystem.ID_, attachment.MachineID_)
+			}
+		}
+	}
+	return nil
+}
+
+// validateNetworking makes sure that the spaces, subnets, link-layer
+// devices and IP addresses are valid, that they only refer to entities
+// that exist in the model, and that the service endpoint bindings refer
+// to known spaces.
+func (m *model) validateNetworking(allMachines set.Strings) error {
+	allSpaces := set.NewStrings()
+	for _, space := range m.Spaces_.Spaces_ {
+		if err := space.Validate(); err != nil {
+			return errors.Trace(err)
+		}
+		allSpaces.Add(space.Name_)
+	}
+	for _, subnet := range m.Subnets_.Subnets_ {
+		if err := subnet.Validate(); err != nil {
+			return errors.Trace(err)
+		}
+		if subnet.SpaceName_ != "" && !allSpaces.Contains(subnet.SpaceName_) {
+			return errors.NotValidf("subnet %q referencing unknown space %q", subnet.CIDR_, subnet.SpaceName_)
+		}
+	}
+	allDevices := set.NewStrings()
+	for _, device := range m.LinkLayerDevices_.LinkLayerDevices_ {
+		if err := device.Validate(); err != nil {
+			return errors.Trace(err)
+		}
+		if !allMachines.Contains(device.MachineID_) {
+			return errors.NotValidf("link-layer device %q on unknown machine %q", device.Name_, device.MachineID_)
+		}
+		allDevices.Add(device.MachineID_ + "/" + device.Name_)
+	}
+	for _, addr := range m.IPAddresses_.IPAddresses_ {
+		if err := addr.Validate(); err != nil {
+			return errors.Trace(err)
+		}
+		if !allDevices.Contains(addr.MachineID_ + "/" + addr.DeviceName_) {
+			return errors.NotValidf("ip address %q on unknown device %q of machine %q", addr.Value_, addr.DeviceName_, addr.MachineID_)
+		}
+	}
+	for _, service := range m.Services_.Services_ {
+		for endpoint, space := range service.EndpointBindings_ {
+			if space != "" && !allSpaces.Contains(space) {
+				return errors.NotValidf("service %q endpoint %q bound to unknown space %q", service.Name_, endpoint, space)
+			}
+		}
+	}
+	return nil
+}
+
 // validateRelations makes sure that for each endpoint in each relation there
 // are settings for all units of that service for that endpoint.
 func (m *model) validateRelations() error {
@@ -345,20 +697,40 @@ var modelDeserializationFuncs = map[int]modelDeserializationFunc{
 
 func importModelV1(source map[string]interface{}) (*model, error) {
 	fields := schema.Fields{
-		"owner":        schema.String(),
-		"config":       schema.StringMap(schema.Any()),
-		"latest-tools": schema.String(),
-		"blocks":       schema.StringMap(schema.String()),
-		"users":        schema.StringMap(schema.Any()),
-		"machines":     schema.StringMap(schema.Any()),
-		"services":     schema.StringMap(schema.Any()),
-		"relations":    schema.StringMap(schema.Any()),
-		"sequences":    schema.StringMap(schema.Int()),
+		"owner":              schema.String(),
+		"config":             schema.StringMap(schema.Any()),
+		"latest-tools":       schema.String(),
+		"blocks":             schema.StringMap(schema.String()),
+		"users":              schema.StringMap(schema.Any()),
+		"machines":           schema.StringMap(schema.Any()),
+		"services":           schema.StringMap(schema.Any()),
+		"relations":          schema.StringMap(schema.Any()),
+		"storages":           schema.StringMap(schema.Any()),
+		"volumes":            schema.StringMap(schema.Any()),
+		"volume-snapshots":   schema.StringMap(schema.Any()),
+		"filesystems":        schema.StringMap(schema.Any()),
+		"storage-pools":      schema.StringMap(schema.Any()),
+		"spaces":             schema.StringMap(schema.Any()),
+		"subnets":            schema.StringMap(schema.Any()),
+		"link-layer-devices": schema.StringMap(schema.Any()),
+		"ip-addresses":       schema.StringMap(schema.Any()),
+		"sequences":          schema.StringMap(schema.Int()),
 	}
 	// Some values don't have to be there.
 	defaults := schema.Defaults{
 		"latest-tools": schema.Omit,
 		"blocks":       schema.Omit,
+		// Storage was added after v1 was first released.
+		"storages":         schema.Omit,
+		"volumes":          schema.Omit,
+		"volume-snapshots": schema.Omit,
+		"filesystems":      schema.Omit,
+		"storage-pools":    schema.Omit,
+		// So was network configuration.
+		"spaces":             schema.Omit,
+		"subnets":            schema.Omit,
+		"link-layer-devices": schema.Omit,
+		"ip-addresses":       schema.Omit,
 	}
 	addAnnotationSchema(fields, defaults)
 	addConstraintsSchema(fields, defaults)
@@ -379,6 +751,17 @@ func importModelV1(source map[string]interface{}) (*model, error) {
 		Sequences_: make(map[string]int),
 		Blocks_:    convertToStringMap(valid["blocks"]),
 	}
+	// Collections added after v1 was first released may be missing
+	// from the source, in which case they are left empty.
+	result.setStorages(nil)
+	result.setStoragePools(nil)
+	result.setVolumes(nil)
+	result.setVolumeSnapshots(nil)
+	result.setFilesystems(nil)
+	result.setSpaces(nil)
+	result.setSubnets(nil)
+	result.setLinkLayerDevices(nil)
+	result.setIPAddresses(nil)
 	result.importAnnotations(valid)
 	sequences := valid["sequences"].(map[string]interface{})
 	for key, value := range sequences {
@@ -429,5 +812,77 @@ func importModelV1(source map[string]interface{}) (*model, error) {
 	}
 	result.setRelations(relations)
 
+	if storageMap, ok := valid["storages"]; ok {
+		storages, err := importStorages(storageMap.(map[string]interface{}))
+		if err != nil {
+			return nil, errors.Annotate(err, "storages")
+		}
+		result.setStorages(storages)
+	}
+
+	if poolMap, ok := valid["storage-pools"]; ok {
+		pools, err := importStoragePools(poolMap.(map[string]interface{}))
+		if err != nil {
+			return nil, errors.Annotate(err, "storage-pools")
+		}
+		result.setStoragePools(pools)
+	}
+
+	if volumeMap, ok := valid["volumes"]; ok {
+		volumes, err := importVolumes(volumeMap.(map[string]interface{}))
+		if err != nil {
+			return nil, errors.Annotate(err, "volumes")
+		}
+		result.setVolumes(volumes)
+	}
+
+	if snapshotMap, ok := valid["volume-snapshots"]; ok {
+		snapshots, err := importVolumeSnapshots(snapshotMap.(map[string]interface{}))
+		if err != nil {
+			return nil, errors.Annotate(err, "volume-snapshots")
+		}
+		result.setVolumeSnapshots(snapshots)
+	}
+
+	if filesystemMap, ok := valid["filesystems"]; ok {
+		filesystems, err := importFilesystems(filesystemMap.(map[string]interface{}))
+		if err != nil {
+			return nil, errors.Annotate(err, "filesystems")
+		}
+		result.setFilesystems(filesystems)
+	}
+
+	if spaceMap, ok := valid["spaces"]; ok {
+		spaces, err := importSpaces(spaceMap.(map[string]interface{}))
+		if err != nil {
+			return nil, errors.Annotate(err, "spaces")
+		}
+		result.setSpaces(spaces)
+	}
+
+	if subnetMap, ok := valid["subnets"]; ok {
+		subnets, err := importSubnets(subnetMap.(map[string]interface{}))
+		if err != nil {
+			return nil, errors.Annotate(err, "subnets")
+		}
+		result.setSubnets(subnets)
+	}
+
+	if deviceMap, ok := valid["link-layer-devices"]; ok {
+		devices, err := importLinkLayerDevices(deviceMap.(map[string]interface{}))
+		if err != nil {
+			return nil, errors.Annotate(err, "link-layer-devices")
+		}
+		result.setLinkLayerDevices(devices)
+	}
+
+	if addressMap, ok := valid["ip-addresses"]; ok {
+		addresses, err := importIPAddresses(addressMap.(map[string]interface{}))
+		if err != nil {
+			return nil, errors.Annotate(err, "ip-addresses")
+		}
+		result.setIPAddresses(addresses)
+	}
+
 	return result, nil
 }
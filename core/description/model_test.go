@@ -326,3 +326,322 @@ func (s *ModelSerializationSuite) TestModelSerializationWithRelations(c *gc.C) {
 	c.Assert(err, jc.ErrorIsNil)
 	c.Assert(model, jc.DeepEquals, initial)
 }
+
+func (s *ModelSerializationSuite) addStorageToModel(model Model) {
+	model.AddStoragePool(StoragePoolArgs{
+		Name:     "fast",
+		Provider: "loop",
+		Attributes: map[string]interface{}{
+			"speed": "fast",
+		},
+	})
+	model.AddStorage(StorageArgs{
+		Tag:         names.NewStorageTag("data/0"),
+		Kind:        "block",
+		Owner:       names.NewUnitTag("wordpress/0"),
+		Name:        "data",
+		Attachments: []names.UnitTag{names.NewUnitTag("wordpress/0")},
+	})
+	volume := model.AddVolume(VolumeArgs{
+		Tag:     names.NewVolumeTag("0/0"),
+		Storage: names.NewStorageTag("data/0"),
+		Binding: names.NewMachineTag("0"),
+		Size:    1024,
+		Pool:    "fast",
+	})
+	volume.SetStatus(minimalStatusArgs())
+	volume.AddAttachment(VolumeAttachmentArgs{
+		Machine: names.NewMachineTag("0"),
+	})
+	model.AddVolumeSnapshot(VolumeSnapshotArgs{
+		ID:         "0",
+		Volume:     names.NewVolumeTag("0/0"),
+		Storage:    names.NewStorageTag("data/0"),
+		Pool:       "fast",
+		SnapshotID: "snap-0",
+		Size:       1024,
+		Created:    time.Date(2016, 6, 1, 12, 34, 56, 0, time.UTC),
+	})
+	filesystem := model.AddFilesystem(FilesystemArgs{
+		Tag:     names.NewFilesystemTag("0/0"),
+		Storage: names.NewStorageTag("data/0"),
+		Volume:  names.NewVolumeTag("0/0"),
+		Size:    1024,
+		Pool:    "fast",
+	})
+	filesystem.SetStatus(minimalStatusArgs())
+	filesystem.AddAttachment(FilesystemAttachmentArgs{
+		Machine:    names.NewMachineTag("0"),
+		MountPoint: "/srv/data",
+	})
+}
+
+func (s *ModelSerializationSuite) TestModelValidationChecksStorage(c *gc.C) {
+	model := s.wordpressModelWithSettings()
+	s.addStorageToModel(model)
+	err := model.Validate()
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *ModelSerializationSuite) TestModelValidationChecksStorageOwner(c *gc.C) {
+	model := s.wordpressModelWithSettings()
+	model.AddStorage(StorageArgs{
+		Tag:   names.NewStorageTag("data/0"),
+		Owner: names.NewUnitTag("missing/0"),
+	})
+	err := model.Validate()
+	c.Assert(err, gc.ErrorMatches, `storage "data/0" owned by unknown unit "missing/0" not valid`)
+	c.Assert(err, jc.Satisfies, errors.IsNotValid)
+}
+
+func (s *ModelSerializationSuite) TestModelValidationChecksStorageAttachments(c *gc.C) {
+	model := s.wordpressModelWithSettings()
+	model.AddStorage(StorageArgs{
+		Tag:         names.NewStorageTag("data/0"),
+		Owner:       names.NewServiceTag("wordpress"),
+		Attachments: []names.UnitTag{names.NewUnitTag("missing/0")},
+	})
+	err := model.Validate()
+	c.Assert(err, gc.ErrorMatches, `storage "data/0" attached to unknown unit "missing/0" not valid`)
+}
+
+func (s *ModelSerializationSuite) TestModelValidationChecksVolumeStorage(c *gc.C) {
+	model := s.wordpressModelWithSettings()
+	volume := model.AddVolume(VolumeArgs{
+		Tag:     names.NewVolumeTag("0/0"),
+		Storage: names.NewStorageTag("missing/0"),
+	})
+	volume.SetStatus(minimalStatusArgs())
+	err := model.Validate()
+	c.Assert(err, gc.ErrorMatches, `volume "0/0" referencing unknown storage "missing/0" not valid`)
+}
+
+func (s *ModelSerializationSuite) TestModelValidationChecksVolumeAttachments(c *gc.C) {
+	model := s.wordpressModelWithSettings()
+	volume := model.AddVolume(VolumeArgs{
+		Tag: names.NewVolumeTag("0/0"),
+	})
+	volume.SetStatus(minimalStatusArgs())
+	volume.AddAttachment(VolumeAttachmentArgs{
+		Machine: names.NewMachineTag("42"),
+	})
+	err := model.Validate()
+	c.Assert(err, gc.ErrorMatches, `volume "0/0" attached to unknown machine "42" not valid`)
+}
+
+func (s *ModelSerializationSuite) TestModelValidationChecksFilesystemVolume(c *gc.C) {
+	model := s.wordpressModelWithSettings()
+	filesystem := model.AddFilesystem(FilesystemArgs{
+		Tag:    names.NewFilesystemTag("0/0"),
+		Volume: names.NewVolumeTag("0/1"),
+	})
+	filesystem.SetStatus(minimalStatusArgs())
+	err := model.Validate()
+	c.Assert(err, gc.ErrorMatches, `filesystem "0/0" referencing unknown volume "0/1" not valid`)
+}
+
+func (s *ModelSerializationSuite) TestModelValidationChecksFilesystemAttachments(c *gc.C) {
+	model := s.wordpressModelWithSettings()
+	filesystem := model.AddFilesystem(FilesystemArgs{
+		Tag: names.NewFilesystemTag("0/0"),
+	})
+	filesystem.SetStatus(minimalStatusArgs())
+	filesystem.AddAttachment(FilesystemAttachmentArgs{
+		Machine: names.NewMachineTag("42"),
+	})
+	err := model.Validate()
+	c.Assert(err, gc.ErrorMatches, `filesystem "0/0" attached to unknown machine "42" not valid`)
+}
+
+func (s *ModelSerializationSuite) TestModelSerializationWithStorage(c *gc.C) {
+	initial := s.wordpressModelWithSettings()
+	s.addStorageToModel(initial)
+	bytes, err := yaml.Marshal(initial)
+	c.Assert(err, jc.ErrorIsNil)
+	model, err := Deserialize(bytes)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(model, jc.DeepEquals, initial)
+
+	c.Assert(model.StoragePools(), gc.HasLen, 1)
+	c.Assert(model.Storages(), gc.HasLen, 1)
+	c.Assert(model.Volumes(), gc.HasLen, 1)
+	c.Assert(model.VolumeSnapshots(), gc.HasLen, 1)
+	c.Assert(model.Filesystems(), gc.HasLen, 1)
+}
+
+func (s *ModelSerializationSuite) TestModelSerializationWithoutVolumeSnapshots(c *gc.C) {
+	// Models serialized before volume snapshots were migrated do not
+	// have the volume-snapshots key.
+	initial := s.wordpressModelWithSettings()
+	s.addStorageToModel(initial)
+	bytes, err := yaml.Marshal(initial)
+	c.Assert(err, jc.ErrorIsNil)
+
+	var source map[string]interface{}
+	err = yaml.Unmarshal(bytes, &source)
+	c.Assert(err, jc.ErrorIsNil)
+	delete(source, "volume-snapshots")
+	bytes, err = yaml.Marshal(source)
+	c.Assert(err, jc.ErrorIsNil)
+
+	model, err := Deserialize(bytes)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(model.Volumes(), gc.HasLen, 1)
+	c.Assert(model.VolumeSnapshots(), gc.HasLen, 0)
+}
+
+func (s *ModelSerializationSuite) TestModelSerializationWithoutStorage(c *gc.C) {
+	// Models serialized before storage was migrated do not have
+	// the storage keys.
+	initial := s.wordpressModelWithSettings()
+	bytes, err := yaml.Marshal(initial)
+	c.Assert(err, jc.ErrorIsNil)
+
+	var source map[string]interface{}
+	err = yaml.Unmarshal(bytes, &source)
+	c.Assert(err, jc.ErrorIsNil)
+	for _, key := range []string{
+		"storages", "volumes", "volume-snapshots", "filesystems", "storage-pools",
+	} {
+		delete(source, key)
+	}
+	bytes, err = yaml.Marshal(source)
+	c.Assert(err, jc.ErrorIsNil)
+
+	model, err := Deserialize(bytes)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(model.Services(), gc.HasLen, 1)
+	c.Assert(model.Storages(), gc.HasLen, 0)
+	c.Assert(model.Volumes(), gc.HasLen, 0)
+	c.Assert(model.Filesystems(), gc.HasLen, 0)
+	c.Assert(model.StoragePools(), gc.HasLen, 0)
+
+	// The model can be serialized again in the current format.
+	bytes, err = Serialize(model)
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = Deserialize(bytes)
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *ModelSerializationSuite) TestModelSerializationWithoutNetworking(c *gc.C) {
+	// Models serialized before network configuration was migrated
+	// do not have the networking keys.
+	initial := s.wordpressModelWithSettings()
+	bytes, err := yaml.Marshal(initial)
+	c.Assert(err, jc.ErrorIsNil)
+
+	var source map[string]interface{}
+	err = yaml.Unmarshal(bytes, &source)
+	c.Assert(err, jc.ErrorIsNil)
+	for _, key := range []string{
+		"spaces", "subnets", "link-layer-devices", "ip-addresses",
+	} {
+		delete(source, key)
+	}
+	bytes, err = yaml.Marshal(source)
+	c.Assert(err, jc.ErrorIsNil)
+
+	model, err := Deserialize(bytes)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(model.Services(), gc.HasLen, 1)
+	c.Assert(model.Spaces(), gc.HasLen, 0)
+	c.Assert(model.Subnets(), gc.HasLen, 0)
+	c.Assert(model.LinkLayerDevices(), gc.HasLen, 0)
+	c.Assert(model.IPAddresses(), gc.HasLen, 0)
+
+	// The model can be serialized again in the current format.
+	bytes, err = Serialize(model)
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = Deserialize(bytes)
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *ModelSerializationSuite) addNetworkingToModel(model Model) {
+	model.AddSpace(SpaceArgs{Name: "internal", ProviderID: "magic"})
+	model.AddSubnet(SubnetArgs{
+		CIDR:      "10.0.0.0/24",
+		SpaceName: "internal",
+	})
+	model.AddLinkLayerDevice(LinkLayerDeviceArgs{
+		Name:       "eth0",
+		MachineID:  "0",
+		Type:       "ethernet",
+		MACAddress: "00:16:3e:00:00:01",
+		IsUp:       true,
+	})
+	model.AddIPAddress(IPAddressArgs{
+		DeviceName:   "eth0",
+		MachineID:    "0",
+		SubnetCIDR:   "10.0.0.0/24",
+		ConfigMethod: "static",
+		Value:        "10.0.0.4",
+	})
+}
+
+func (s *ModelSerializationSuite) TestModelValidationChecksNetworking(c *gc.C) {
+	model := s.wordpressModelWithSettings()
+	s.addNetworkingToModel(model)
+	err := model.Validate()
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *ModelSerializationSuite) TestModelValidationChecksSubnetSpace(c *gc.C) {
+	model := s.wordpressModelWithSettings()
+	model.AddSubnet(SubnetArgs{
+		CIDR:      "10.0.0.0/24",
+		SpaceName: "missing",
+	})
+	err := model.Validate()
+	c.Assert(err, gc.ErrorMatches, `subnet "10.0.0.0/24" referencing unknown space "missing" not valid`)
+}
+
+func (s *ModelSerializationSuite) TestModelValidationChecksLinkLayerDeviceMachine(c *gc.C) {
+	model := s.wordpressModelWithSettings()
+	model.AddLinkLayerDevice(LinkLayerDeviceArgs{
+		Name:      "eth0",
+		MachineID: "42",
+	})
+	err := model.Validate()
+	c.Assert(err, gc.ErrorMatches, `link-layer device "eth0" on unknown machine "42" not valid`)
+}
+
+func (s *ModelSerializationSuite) TestModelValidationChecksIPAddressDevice(c *gc.C) {
+	model := s.wordpressModelWithSettings()
+	model.AddIPAddress(IPAddressArgs{
+		DeviceName: "eth1",
+		MachineID:  "0",
+		Value:      "10.0.0.4",
+	})
+	err := model.Validate()
+	c.Assert(err, gc.ErrorMatches, `ip address "10.0.0.4" on unknown device "eth1" of machine "0" not valid`)
+}
+
+func (s *ModelSerializationSuite) TestModelValidationChecksEndpointBindings(c *gc.C) {
+	model := s.wordpressModelWithSettings()
+	service := model.AddService(ServiceArgs{
+		Tag:                names.NewServiceTag("haproxy"),
+		Settings:           map[string]interface{}{},
+		LeadershipSettings: map[string]interface{}{},
+		EndpointBindings: map[string]string{
+			"website": "missing",
+		},
+	})
+	service.SetStatus(minimalStatusArgs())
+	err := model.Validate()
+	c.Assert(err, gc.ErrorMatches, `service "haproxy" endpoint "website" bound to unknown space "missing" not valid`)
+}
+
+func (s *ModelSerializationSuite) TestModelSerializationWithNetworking(c *gc.C) {
+	initial := s.wordpressModelWithSettings()
+	s.addNetworkingToModel(initial)
+	bytes, err := yaml.Marshal(initial)
+	c.Assert(err, jc.ErrorIsNil)
+	model, err := Deserialize(bytes)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(model, jc.DeepEquals, initial)
+
+	c.Assert(model.Spaces(), gc.HasLen, 1)
+	c.Assert(model.Subnets(), gc.HasLen, 1)
+	c.Assert(model.LinkLayerDevices(), gc.HasLen, 1)
+	c.Assert(model.IPAddresses(), gc.HasLen, 1)
+}
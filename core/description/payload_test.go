@@ -0,0 +1,73 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package description
+
+import (
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/yaml.v2"
+)
+
+type PayloadSerializationSuite struct {
+	SliceSerializationSuite
+}
+
+var _ = gc.Suite(&PayloadSerializationSuite{})
+
+func (s *PayloadSerializationSuite) SetUpTest(c *gc.C) {
+	s.SliceSerializationSuite.SetUpTest(c)
+	s.importName = "payloads"
+	s.sliceName = "payloads"
+	s.importFunc = func(m map[string]interface{}) (interface{}, error) {
+		return importPayloads(m)
+	}
+	s.testFields = func(m map[string]interface{}) {
+		m["payloads"] = []interface{}{}
+	}
+}
+
+func testPayloadArgs() PayloadArgs {
+	return PayloadArgs{
+		Name:   "spam",
+		Type:   "docker",
+		RawID:  "d06f00d",
+		State:  "running",
+		Labels: []string{"a", "b"},
+	}
+}
+
+func (s *PayloadSerializationSuite) TestNewPayload(c *gc.C) {
+	p := newPayload(testPayloadArgs())
+	c.Check(p.Name(), gc.Equals, "spam")
+	c.Check(p.Type(), gc.Equals, "docker")
+	c.Check(p.RawID(), gc.Equals, "d06f00d")
+	c.Check(p.State(), gc.Equals, "running")
+	c.Check(p.Labels(), jc.DeepEquals, []string{"a", "b"})
+}
+
+func (s *PayloadSerializationSuite) TestParsingSerializedData(c *gc.C) {
+	initial := payloads{
+		Version: 1,
+		Payloads_: []*payload{
+			newPayload(testPayloadArgs()),
+			newPayload(PayloadArgs{
+				Name:  "eggs",
+				Type:  "kvm",
+				RawID: "1234",
+				State: "stopped",
+			}),
+		},
+	}
+
+	bytes, err := yaml.Marshal(initial)
+	c.Assert(err, jc.ErrorIsNil)
+
+	var source map[string]interface{}
+	err = yaml.Unmarshal(bytes, &source)
+	c.Assert(err, jc.ErrorIsNil)
+
+	payloads, err := importPayloads(source)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(payloads, jc.DeepEquals, initial.Payloads_)
+}
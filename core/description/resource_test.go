@@ -0,0 +1,126 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package description
+
+import (
+	"time"
+
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/yaml.v2"
+)
+
+type ResourceSerializationSuite struct {
+	SliceSerializationSuite
+}
+
+var _ = gc.Suite(&ResourceSerializationSuite{})
+
+func (s *ResourceSerializationSuite) SetUpTest(c *gc.C) {
+	s.SliceSerializationSuite.SetUpTest(c)
+	s.importName = "resources"
+	s.sliceName = "resources"
+	s.importFunc = func(m map[string]interface{}) (interface{}, error) {
+		return importResources(m)
+	}
+	s.testFields = func(m map[string]interface{}) {
+		m["resources"] = []interface{}{}
+	}
+}
+
+func testResourceRevisionArgs() ResourceRevisionArgs {
+	return ResourceRevisionArgs{
+		Revision:       3,
+		Type:           "file",
+		Path:           "config.tgz",
+		Description:    "the config",
+		Origin:         "upload",
+		FingerprintHex: "deadbeef",
+		Size:           1234,
+		Timestamp:      time.Date(2016, 5, 10, 12, 34, 56, 0, time.UTC),
+		Username:       "bob",
+	}
+}
+
+func (s *ResourceSerializationSuite) TestNewResource(c *gc.C) {
+	r := newResource(ResourceArgs{Name: "config"})
+	c.Check(r.Name(), gc.Equals, "config")
+	c.Check(r.Revision(), gc.IsNil)
+	c.Check(r.CharmStoreRevision(), gc.IsNil)
+
+	r.SetRevision(testResourceRevisionArgs())
+	rev := r.Revision()
+	c.Check(rev.Revision(), gc.Equals, 3)
+	c.Check(rev.Type(), gc.Equals, "file")
+	c.Check(rev.Path(), gc.Equals, "config.tgz")
+	c.Check(rev.Description(), gc.Equals, "the config")
+	c.Check(rev.Origin(), gc.Equals, "upload")
+	c.Check(rev.FingerprintHex(), gc.Equals, "deadbeef")
+	c.Check(rev.Size(), gc.Equals, int64(1234))
+	c.Check(rev.Timestamp(), gc.Equals, time.Date(2016, 5, 10, 12, 34, 56, 0, time.UTC))
+	c.Check(rev.Username(), gc.Equals, "bob")
+}
+
+func (s *ResourceSerializationSuite) exportImport(c *gc.C, r *resource) *resource {
+	initial := resources{
+		Version:    1,
+		Resources_: []*resource{r},
+	}
+
+	bytes, err := yaml.Marshal(initial)
+	c.Assert(err, jc.ErrorIsNil)
+
+	var source map[string]interface{}
+	err = yaml.Unmarshal(bytes, &source)
+	c.Assert(err, jc.ErrorIsNil)
+
+	resources, err := importResources(source)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(resources, gc.HasLen, 1)
+	return resources[0]
+}
+
+func (s *ResourceSerializationSuite) TestParsingSerializedData(c *gc.C) {
+	initial := newResource(ResourceArgs{Name: "config"})
+	initial.SetRevision(testResourceRevisionArgs())
+	initial.SetCharmStoreRevision(ResourceRevisionArgs{
+		Revision: 4,
+		Type:     "file",
+		Path:     "config.tgz",
+		Origin:   "store",
+		Size:     4321,
+	})
+
+	r := s.exportImport(c, initial)
+	c.Assert(r, jc.DeepEquals, initial)
+}
+
+func (s *ResourceSerializationSuite) TestParsingNoTimestamp(c *gc.C) {
+	initial := newResource(ResourceArgs{Name: "config"})
+	args := testResourceRevisionArgs()
+	args.Timestamp = time.Time{}
+	initial.SetRevision(args)
+
+	r := s.exportImport(c, initial)
+	c.Assert(r, jc.DeepEquals, initial)
+	c.Assert(r.Revision().Timestamp().IsZero(), jc.IsTrue)
+}
+
+type UnitResourceSerializationSuite struct {
+	SliceSerializationSuite
+}
+
+var _ = gc.Suite(&UnitResourceSerializationSuite{})
+
+func (s *UnitResourceSerializationSuite) SetUpTest(c *gc.C) {
+	s.SliceSerializationSuite.SetUpTest(c)
+	s.importName = "unit resources"
+	s.sliceName = "resources"
+	s.importFunc = func(m map[string]interface{}) (interface{}, error) {
+		return importUnitResources(m)
+	}
+	s.testFields = func(m map[string]interface{}) {
+		m["resources"] = []interface{}{}
+	}
+}
@@ -28,9 +28,10 @@ type service struct {
 
 	// ForceCharm is true if an upgrade charm is forced.
 	// It means upgrade even if the charm is in an error state.
-	ForceCharm_ bool `yaml:"force-charm,omitempty"`
-	Exposed_    bool `yaml:"exposed,omitempty"`
-	MinUnits_   int  `yaml:"min-units,omitempty"`
+	ForceCharm_   bool     `yaml:"force-charm,omitempty"`
+	Exposed_      bool     `yaml:"exposed,omitempty"`
+	ExposedCIDRs_ []string `yaml:"exposed-cidrs,omitempty"`
+	MinUnits_     int      `yaml:"min-units,omitempty"`
 
 	Status_        *status `yaml:"status"`
 	StatusHistory_ `yaml:"status-history"`
@@ -50,7 +51,13 @@ type service struct {
 
 	Constraints_ *constraints `yaml:"constraints,omitempty"`
 
-	// Storage Constraints
+	StorageConstraints_ map[string]*storageconstraint `yaml:"storage-constraints,omitempty"`
+
+	EndpointBindings_ map[string]string `yaml:"endpoint-bindings,omitempty"`
+
+	Resources_ resources `yaml:"resources"`
+
+	Users_ []*serviceUser `yaml:"users,omitempty"`
 }
 
 // ServiceArgs is an argument struct used to add a service to the Model.
@@ -63,12 +70,15 @@ type ServiceArgs struct {
 	CharmModifiedVersion int
 	ForceCharm           bool
 	Exposed              bool
+	ExposedCIDRs         []string
 	MinUnits             int
 	Settings             map[string]interface{}
 	SettingsRefCount     int
 	Leader               string
 	LeadershipSettings   map[string]interface{}
 	MetricsCredentials   []byte
+	StorageConstraints   map[string]StorageConstraintArgs
+	EndpointBindings     map[string]string
 }
 
 func newService(args ServiceArgs) *service {
@@ -82,15 +92,24 @@ func newService(args ServiceArgs) *service {
 		CharmModifiedVersion_: args.CharmModifiedVersion,
 		ForceCharm_:           args.ForceCharm,
 		Exposed_:              args.Exposed,
+		ExposedCIDRs_:         args.ExposedCIDRs,
 		MinUnits_:             args.MinUnits,
 		Settings_:             args.Settings,
 		SettingsRefCount_:     args.SettingsRefCount,
 		Leader_:               args.Leader,
 		LeadershipSettings_:   args.LeadershipSettings,
 		MetricsCredentials_:   creds,
+		EndpointBindings_:     args.EndpointBindings,
 		StatusHistory_:        newStatusHistory(),
 	}
 	svc.setUnits(nil)
+	svc.setResources(nil)
+	if len(args.StorageConstraints) > 0 {
+		svc.StorageConstraints_ = make(map[string]*storageconstraint)
+		for key, value := range args.StorageConstraints {
+			svc.StorageConstraints_[key] = newStorageConstraint(value)
+		}
+	}
 	return svc
 }
 
@@ -139,6 +158,11 @@ func (s *service) Exposed() bool {
 	return s.Exposed_
 }
 
+// ExposedCIDRs implements Service.
+func (s *service) ExposedCIDRs() []string {
+	return s.ExposedCIDRs_
+}
+
 // MinUnits implements Service.
 func (s *service) MinUnits() int {
 	return s.MinUnits_
@@ -174,6 +198,59 @@ func (s *service) MetricsCredentials() []byte {
 	return creds
 }
 
+// StorageConstraints implements Service.
+func (s *service) StorageConstraints() map[string]StorageConstraint {
+	result := make(map[string]StorageConstraint)
+	for key, value := range s.StorageConstraints_ {
+		result[key] = value
+	}
+	return result
+}
+
+// EndpointBindings implements Service.
+func (s *service) EndpointBindings() map[string]string {
+	return s.EndpointBindings_
+}
+
+// Resources implements Service.
+func (s *service) Resources() []Resource {
+	result := make([]Resource, len(s.Resources_.Resources_))
+	for i, r := range s.Resources_.Resources_ {
+		result[i] = r
+	}
+	return result
+}
+
+// AddResource implements Service.
+func (s *service) AddResource(args ResourceArgs) Resource {
+	r := newResource(args)
+	s.Resources_.Resources_ = append(s.Resources_.Resources_, r)
+	return r
+}
+
+// Users implements Service.
+func (s *service) Users() []ServiceUser {
+	result := make([]ServiceUser, len(s.Users_))
+	for i, u := range s.Users_ {
+		result[i] = u
+	}
+	return result
+}
+
+// AddUser implements Service.
+func (s *service) AddUser(args ServiceUserArgs) ServiceUser {
+	u := newServiceUser(args)
+	s.Users_ = append(s.Users_, u)
+	return u
+}
+
+func (s *service) setResources(resourceList []*resource) {
+	s.Resources_ = resources{
+		Version:    1,
+		Resources_: resourceList,
+	}
+}
+
 // Status implements Service.
 func (s *service) Status() Status {
 	// To avoid typed nils check nil here.
@@ -240,6 +317,13 @@ func (s *service) Validate() error {
 	if s.Status_ == nil {
 		return errors.NotValidf("service %q missing status", s.Name_)
 	}
+	resourceNames := set.NewStrings()
+	for _, r := range s.Resources_.Resources_ {
+		if err := r.Validate(); err != nil {
+			return errors.Trace(err)
+		}
+		resourceNames.Add(r.Name_)
+	}
 	// If leader is set, it must match one of the units.
 	var leaderFound bool
 	// All of the services units should also be valid.
@@ -247,6 +331,12 @@ func (s *service) Validate() error {
 		if err := u.Validate(); err != nil {
 			return errors.Trace(err)
 		}
+		// Units can only use resources that the service has.
+		for _, r := range u.Resources() {
+			if !resourceNames.Contains(r.Name()) {
+				return errors.NotValidf("unit %q using unknown resource %q", u.Name(), r.Name())
+			}
+		}
 		// We know that the unit has a name, because it validated correctly.
 		if u.Name() == s.Leader_ {
 			leaderFound = true
@@ -307,6 +397,7 @@ func importServiceV1(source map[string]interface{}) (*service, error) {
 		"charm-mod-version":   schema.Int(),
 		"force-charm":         schema.Bool(),
 		"exposed":             schema.Bool(),
+		"exposed-cidrs":       schema.List(schema.String()),
 		"min-units":           schema.Int(),
 		"status":              schema.StringMap(schema.Any()),
 		"settings":            schema.StringMap(schema.Any()),
@@ -315,6 +406,10 @@ func importServiceV1(source map[string]interface{}) (*service, error) {
 		"leadership-settings": schema.StringMap(schema.Any()),
 		"metrics-creds":       schema.String(),
 		"units":               schema.StringMap(schema.Any()),
+		"storage-constraints": schema.StringMap(schema.StringMap(schema.Any())),
+		"endpoint-bindings":   schema.StringMap(schema.String()),
+		"resources":           schema.StringMap(schema.Any()),
+		"users":               schema.List(schema.StringMap(schema.Any())),
 	}
 
 	defaults := schema.Defaults{
@@ -324,6 +419,11 @@ func importServiceV1(source map[string]interface{}) (*service, error) {
 		"min-units":     int64(0),
 		"leader":        "",
 		"metrics-creds": "",
+
+		"exposed-cidrs":       schema.Omit,
+		"storage-constraints": schema.Omit,
+		"endpoint-bindings":   schema.Omit,
+		"users":               schema.Omit,
 	}
 	addAnnotationSchema(fields, defaults)
 	addConstraintsSchema(fields, defaults)
@@ -346,11 +446,13 @@ func importServiceV1(source map[string]interface{}) (*service, error) {
 		CharmModifiedVersion_: int(valid["charm-mod-version"].(int64)),
 		ForceCharm_:           valid["force-charm"].(bool),
 		Exposed_:              valid["exposed"].(bool),
+		ExposedCIDRs_:         convertToStringSlice(valid["exposed-cidrs"]),
 		MinUnits_:             int(valid["min-units"].(int64)),
 		Settings_:             valid["settings"].(map[string]interface{}),
 		SettingsRefCount_:     int(valid["settings-refcount"].(int64)),
 		Leader_:               valid["leader"].(string),
 		LeadershipSettings_:   valid["leadership-settings"].(map[string]interface{}),
+		EndpointBindings_:     convertToStringMap(valid["endpoint-bindings"]),
 		StatusHistory_:        newStatusHistory(),
 	}
 	result.importAnnotations(valid)
@@ -366,6 +468,14 @@ func importServiceV1(source map[string]interface{}) (*service, error) {
 		result.Constraints_ = constraints
 	}
 
+	if constraintsMap, ok := valid["storage-constraints"]; ok {
+		constraints, err := importStorageConstraints(constraintsMap.(map[string]interface{}))
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		result.StorageConstraints_ = constraints
+	}
+
 	encodedCreds := valid["metrics-creds"].(string)
 	// The model stores the creds encoded, but we want to make sure that
 	// we are storing something that can be decoded.
@@ -386,5 +496,19 @@ func importServiceV1(source map[string]interface{}) (*service, error) {
 	}
 	result.setUnits(units)
 
+	resources, err := importResources(valid["resources"].(map[string]interface{}))
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	result.setResources(resources)
+
+	if userList, ok := valid["users"]; ok {
+		users, err := importServiceUsers(userList.([]interface{}), importServiceUserV1)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		result.Users_ = users
+	}
+
 	return result, nil
 }
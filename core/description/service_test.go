@@ -4,6 +4,8 @@
 package description
 
 import (
+	"time"
+
 	"github.com/juju/names"
 	jc "github.com/juju/testing/checkers"
 	gc "gopkg.in/check.v1"
@@ -59,6 +61,10 @@ func minimalServiceMap() map[interface{}]interface{} {
 				minimalUnitMap(),
 			},
 		},
+		"resources": map[interface{}]interface{}{
+			"version":   1,
+			"resources": []interface{}{},
+		},
 	}
 }
 
@@ -110,6 +116,7 @@ func (s *ServiceSerializationSuite) TestNewService(c *gc.C) {
 		CharmModifiedVersion: 1,
 		ForceCharm:           true,
 		Exposed:              true,
+		ExposedCIDRs:         []string{"10.0.0.0/8"},
 		MinUnits:             42, // no judgement is made by the migration code
 		Settings: map[string]interface{}{
 			"key": "value",
@@ -132,6 +139,7 @@ func (s *ServiceSerializationSuite) TestNewService(c *gc.C) {
 	c.Assert(service.CharmModifiedVersion(), gc.Equals, 1)
 	c.Assert(service.ForceCharm(), jc.IsTrue)
 	c.Assert(service.Exposed(), jc.IsTrue)
+	c.Assert(service.ExposedCIDRs(), jc.DeepEquals, []string{"10.0.0.0/8"})
 	c.Assert(service.MinUnits(), gc.Equals, 42)
 	c.Assert(service.Settings(), jc.DeepEquals, args.Settings)
 	c.Assert(service.SettingsRefCount(), gc.Equals, 1)
@@ -214,3 +222,98 @@ func (s *ServiceSerializationSuite) TestLeaderValid(c *gc.C) {
 	err := service.Validate()
 	c.Assert(err, gc.ErrorMatches, `missing unit for leader "ubuntu/1" not valid`)
 }
+
+func (s *ServiceSerializationSuite) TestStorageConstraints(c *gc.C) {
+	args := minimalServiceArgs()
+	args.StorageConstraints = map[string]StorageConstraintArgs{
+		"first":  {Pool: "first", Size: 1234, Count: 1},
+		"second": {Pool: "second", Size: 4321, Count: 7},
+	}
+	initial := newService(args)
+	initial.SetStatus(minimalStatusArgs())
+
+	service := s.exportImport(c, initial)
+
+	constraints := service.StorageConstraints()
+	c.Assert(constraints, gc.HasLen, 2)
+	first, found := constraints["first"]
+	c.Assert(found, jc.IsTrue)
+	c.Check(first.Pool(), gc.Equals, "first")
+	c.Check(first.Size(), gc.Equals, uint64(1234))
+	c.Check(first.Count(), gc.Equals, uint64(1))
+
+	second, found := constraints["second"]
+	c.Assert(found, jc.IsTrue)
+	c.Check(second.Pool(), gc.Equals, "second")
+	c.Check(second.Size(), gc.Equals, uint64(4321))
+	c.Check(second.Count(), gc.Equals, uint64(7))
+}
+
+func (s *ServiceSerializationSuite) TestEndpointBindings(c *gc.C) {
+	args := minimalServiceArgs()
+	args.EndpointBindings = map[string]string{
+		"db":      "internal",
+		"website": "",
+	}
+	initial := newService(args)
+	initial.SetStatus(minimalStatusArgs())
+
+	service := s.exportImport(c, initial)
+	c.Assert(service.EndpointBindings(), jc.DeepEquals, args.EndpointBindings)
+}
+
+func (s *ServiceSerializationSuite) TestUsers(c *gc.C) {
+	initial := minimalService()
+	args := ServiceUserArgs{
+		Name:        names.NewUserTag("bob@external"),
+		Access:      "operate",
+		CreatedBy:   names.NewUserTag("admin"),
+		DateCreated: time.Date(2016, 6, 1, 12, 0, 0, 0, time.UTC),
+
+		GrantedModelAccess: true,
+	}
+	initial.AddUser(args)
+
+	service := s.exportImport(c, initial)
+	users := service.Users()
+	c.Assert(users, gc.HasLen, 1)
+	user := users[0]
+	c.Check(user.Name(), gc.Equals, args.Name)
+	c.Check(user.Access(), gc.Equals, "operate")
+	c.Check(user.CreatedBy(), gc.Equals, args.CreatedBy)
+	c.Check(user.DateCreated().Equal(args.DateCreated), jc.IsTrue)
+	c.Check(user.GrantedModelAccess(), jc.IsTrue)
+}
+
+func (s *ServiceSerializationSuite) TestResources(c *gc.C) {
+	initial := minimalService()
+	resource := initial.AddResource(ResourceArgs{Name: "config"})
+	resource.SetRevision(testResourceRevisionArgs())
+	csArgs := testResourceRevisionArgs()
+	csArgs.Revision = 4
+	resource.SetCharmStoreRevision(csArgs)
+
+	service := s.exportImport(c, initial)
+	resources := service.Resources()
+	c.Assert(resources, gc.HasLen, 1)
+	c.Check(resources[0].Name(), gc.Equals, "config")
+	c.Check(resources[0].Revision(), jc.DeepEquals, newResourceRevision(testResourceRevisionArgs()))
+	c.Check(resources[0].CharmStoreRevision(), jc.DeepEquals, newResourceRevision(csArgs))
+}
+
+func (s *ServiceSerializationSuite) TestResourceMissingRevision(c *gc.C) {
+	initial := minimalService()
+	initial.AddResource(ResourceArgs{Name: "config"})
+	err := initial.Validate()
+	c.Assert(err, gc.ErrorMatches, `resource "config" missing revision not valid`)
+}
+
+func (s *ServiceSerializationSuite) TestUnitResourceUnknown(c *gc.C) {
+	initial := minimalService()
+	initial.Units_.Units_[0].AddResource(UnitResourceArgs{
+		Name:     "config",
+		Revision: testResourceRevisionArgs(),
+	})
+	err := initial.Validate()
+	c.Assert(err, gc.ErrorMatches, `unit "ubuntu/0" using unknown resource "config" not valid`)
+}
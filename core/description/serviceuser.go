@@ -0,0 +1,119 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package description
+
+import (
+	"time"
+
+	"github.com/juju/errors"
+	"github.com/juju/names"
+	"github.com/juju/schema"
+)
+
+// ServiceUserArgs is an argument struct used to add the access a user
+// has been granted to a service.
+type ServiceUserArgs struct {
+	Name        names.UserTag
+	Access      string
+	CreatedBy   names.UserTag
+	DateCreated time.Time
+
+	// GrantedModelAccess is true if the user was given read access
+	// to the model when they were granted access to the service.
+	GrantedModelAccess bool
+}
+
+func newServiceUser(args ServiceUserArgs) *serviceUser {
+	return &serviceUser{
+		Name_:               args.Name.Canonical(),
+		Access_:             args.Access,
+		CreatedBy_:          args.CreatedBy.Canonical(),
+		DateCreated_:        args.DateCreated,
+		GrantedModelAccess_: args.GrantedModelAccess,
+	}
+}
+
+type serviceUser struct {
+	Name_               string    `yaml:"name"`
+	Access_             string    `yaml:"access"`
+	CreatedBy_          string    `yaml:"created-by"`
+	DateCreated_        time.Time `yaml:"date-created"`
+	GrantedModelAccess_ bool      `yaml:"granted-model-access,omitempty"`
+}
+
+// Name implements ServiceUser.
+func (u *serviceUser) Name() names.UserTag {
+	return names.NewUserTag(u.Name_)
+}
+
+// Access implements ServiceUser.
+func (u *serviceUser) Access() string {
+	return u.Access_
+}
+
+// CreatedBy implements ServiceUser.
+func (u *serviceUser) CreatedBy() names.UserTag {
+	return names.NewUserTag(u.CreatedBy_)
+}
+
+// DateCreated implements ServiceUser.
+func (u *serviceUser) DateCreated() time.Time {
+	return u.DateCreated_
+}
+
+// GrantedModelAccess implements ServiceUser.
+func (u *serviceUser) GrantedModelAccess() bool {
+	return u.GrantedModelAccess_
+}
+
+// importServiceUsers imports the users of a service. They are versioned
+// along with the service, so the importFunc is chosen by the service
+// version.
+func importServiceUsers(sourceList []interface{}, importFunc serviceUserDeserializationFunc) ([]*serviceUser, error) {
+	result := make([]*serviceUser, 0, len(sourceList))
+	for i, value := range sourceList {
+		source, ok := value.(map[string]interface{})
+		if !ok {
+			return nil, errors.Errorf("unexpected value for service user %d, %T", i, value)
+		}
+		user, err := importFunc(source)
+		if err != nil {
+			return nil, errors.Annotatef(err, "service user %d", i)
+		}
+		result = append(result, user)
+	}
+	return result, nil
+}
+
+type serviceUserDeserializationFunc func(map[string]interface{}) (*serviceUser, error)
+
+func importServiceUserV1(source map[string]interface{}) (*serviceUser, error) {
+	fields := schema.Fields{
+		"name":         schema.String(),
+		"access":       schema.String(),
+		"created-by":   schema.String(),
+		"date-created": schema.Time(),
+
+		"granted-model-access": schema.Bool(),
+	}
+	defaults := schema.Defaults{
+		"granted-model-access": false,
+	}
+	checker := schema.FieldMap(fields, defaults)
+	coerced, err := checker.Coerce(source, nil)
+	if err != nil {
+		return nil, errors.Annotatef(err, "service user v1 schema check failed")
+	}
+	valid := coerced.(map[string]interface{})
+	// From here we know that the map returned from the schema coercion
+	// contains fields of the right type.
+	return &serviceUser{
+		Name_:        valid["name"].(string),
+		Access_:      valid["access"].(string),
+		CreatedBy_:   valid["created-by"].(string),
+		DateCreated_: valid["date-created"].(time.Time),
+
+		GrantedModelAccess_: valid["granted-model-access"].(bool),
+	}, nil
+}
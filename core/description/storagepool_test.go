@@ -0,0 +1,106 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package description
+
+import (
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/yaml.v2"
+)
+
+type StoragePoolSerializationSuite struct {
+	SliceSerializationSuite
+}
+
+var _ = gc.Suite(&StoragePoolSerializationSuite{})
+
+func (s *StoragePoolSerializationSuite) SetUpTest(c *gc.C) {
+	s.SliceSerializationSuite.SetUpTest(c)
+	s.importName = "storagepools"
+	s.sliceName = "pools"
+	s.importFunc = func(m map[string]interface{}) (interface{}, error) {
+		return importStoragePools(m)
+	}
+	s.testFields = func(m map[string]interface{}) {
+		m["pools"] = []interface{}{}
+	}
+}
+
+func testStoragePoolMap() map[interface{}]interface{} {
+	return map[interface{}]interface{}{
+		"name":     "test",
+		"provider": "magic",
+		"attributes": map[interface{}]interface{}{
+			"method": "madness",
+		},
+	}
+}
+
+func testStoragePool() *storagepool {
+	return newStoragePool(testStoragePoolArgs())
+}
+
+func testStoragePoolArgs() StoragePoolArgs {
+	return StoragePoolArgs{
+		Name:     "test",
+		Provider: "magic",
+		Attributes: map[string]interface{}{
+			"method": "madness",
+		},
+	}
+}
+
+func (s *StoragePoolSerializationSuite) TestNewStoragePool(c *gc.C) {
+	storagepool := testStoragePool()
+
+	c.Check(storagepool.Name(), gc.Equals, "test")
+	c.Check(storagepool.Provider(), gc.Equals, "magic")
+	c.Check(storagepool.Attributes(), jc.DeepEquals, map[string]interface{}{
+		"method": "madness",
+	})
+}
+
+func (s *StoragePoolSerializationSuite) TestStoragePoolMatches(c *gc.C) {
+	bytes, err := yaml.Marshal(testStoragePool())
+	c.Assert(err, jc.ErrorIsNil)
+
+	var source map[interface{}]interface{}
+	err = yaml.Unmarshal(bytes, &source)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(source, jc.DeepEquals, testStoragePoolMap())
+}
+
+func (s *StoragePoolSerializationSuite) exportImport(c *gc.C, pool *storagepool) *storagepool {
+	initial := storagepools{
+		Version: 1,
+		Pools_:  []*storagepool{pool},
+	}
+
+	bytes, err := yaml.Marshal(initial)
+	c.Assert(err, jc.ErrorIsNil)
+
+	var source map[string]interface{}
+	err = yaml.Unmarshal(bytes, &source)
+	c.Assert(err, jc.ErrorIsNil)
+
+	pools, err := importStoragePools(source)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(pools, gc.HasLen, 1)
+	return pools[0]
+}
+
+func (s *StoragePoolSerializationSuite) TestParsingSerializedData(c *gc.C) {
+	original := testStoragePool()
+	storagepool := s.exportImport(c, original)
+	c.Assert(storagepool, jc.DeepEquals, original)
+}
+
+func (s *StoragePoolSerializationSuite) TestParsingNoAttributes(c *gc.C) {
+	original := newStoragePool(StoragePoolArgs{
+		Name:     "test",
+		Provider: "magic",
+	})
+	storagepool := s.exportImport(c, original)
+	c.Assert(storagepool, jc.DeepEquals, original)
+}
@@ -0,0 +1,77 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package description
+
+import (
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/yaml.v2"
+)
+
+type SubnetSerializationSuite struct {
+	SliceSerializationSuite
+}
+
+var _ = gc.Suite(&SubnetSerializationSuite{})
+
+func (s *SubnetSerializationSuite) SetUpTest(c *gc.C) {
+	s.SliceSerializationSuite.SetUpTest(c)
+	s.importName = "subnets"
+	s.sliceName = "subnets"
+	s.importFunc = func(m map[string]interface{}) (interface{}, error) {
+		return importSubnets(m)
+	}
+	s.testFields = func(m map[string]interface{}) {
+		m["subnets"] = []interface{}{}
+	}
+}
+
+func testSubnetArgs() SubnetArgs {
+	return SubnetArgs{
+		CIDR:              "10.0.0.0/24",
+		ProviderID:        "magic",
+		VLANTag:           64,
+		AvailabilityZone:  "bar",
+		SpaceName:         "foo",
+		AllocatableIPHigh: "10.0.0.254",
+		AllocatableIPLow:  "10.0.0.2",
+	}
+}
+
+func (s *SubnetSerializationSuite) TestNewSubnet(c *gc.C) {
+	subnet := newSubnet(testSubnetArgs())
+	c.Check(subnet.CIDR(), gc.Equals, "10.0.0.0/24")
+	c.Check(subnet.ProviderID(), gc.Equals, "magic")
+	c.Check(subnet.VLANTag(), gc.Equals, 64)
+	c.Check(subnet.AvailabilityZone(), gc.Equals, "bar")
+	c.Check(subnet.SpaceName(), gc.Equals, "foo")
+	c.Check(subnet.AllocatableIPHigh(), gc.Equals, "10.0.0.254")
+	c.Check(subnet.AllocatableIPLow(), gc.Equals, "10.0.0.2")
+}
+
+func (s *SubnetSerializationSuite) TestInvalidCIDR(c *gc.C) {
+	subnet := newSubnet(SubnetArgs{CIDR: "10.0.0.0"})
+	c.Assert(subnet.Validate(), gc.ErrorMatches, `subnet CIDR "10.0.0.0" not valid`)
+}
+
+func (s *SubnetSerializationSuite) TestParsingSerializedData(c *gc.C) {
+	initial := subnets{
+		Version: 1,
+		Subnets_: []*subnet{
+			newSubnet(testSubnetArgs()),
+			newSubnet(SubnetArgs{CIDR: "10.0.1.0/24"}),
+		},
+	}
+
+	bytes, err := yaml.Marshal(initial)
+	c.Assert(err, jc.ErrorIsNil)
+
+	var source map[string]interface{}
+	err = yaml.Unmarshal(bytes, &source)
+	c.Assert(err, jc.ErrorIsNil)
+
+	subnets, err := importSubnets(source)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(subnets, jc.DeepEquals, initial.Subnets_)
+}
@@ -41,6 +41,9 @@ type unit struct {
 	Annotations_ `yaml:"annotations,omitempty"`
 
 	Constraints_ *constraints `yaml:"constraints,omitempty"`
+
+	Resources_ unitResources `yaml:"resources"`
+	Payloads_  payloads      `yaml:"payloads"`
 }
 
 // UnitArgs is an argument struct used to add a Unit to a Service in the Model.
@@ -62,7 +65,7 @@ func newUnit(args UnitArgs) *unit {
 	for _, s := range args.Subordinates {
 		subordinates = append(subordinates, s.Id())
 	}
-	return &unit{
+	u := &unit{
 		Name_:                  args.Tag.Id(),
 		Machine_:               args.Machine.Id(),
 		PasswordHash_:          args.PasswordHash,
@@ -73,6 +76,9 @@ func newUnit(args UnitArgs) *unit {
 		WorkloadStatusHistory_: newStatusHistory(),
 		AgentStatusHistory_:    newStatusHistory(),
 	}
+	u.setResources(nil)
+	u.setPayloads(nil)
+	return u
 }
 
 // Tag implements Unit.
@@ -184,6 +190,52 @@ func (u *unit) SetAgentStatusHistory(args []StatusArgs) {
 	u.AgentStatusHistory_.SetStatusHistory(args)
 }
 
+// Resources implements Unit.
+func (u *unit) Resources() []UnitResource {
+	result := make([]UnitResource, len(u.Resources_.Resources_))
+	for i, r := range u.Resources_.Resources_ {
+		result[i] = r
+	}
+	return result
+}
+
+// AddResource implements Unit.
+func (u *unit) AddResource(args UnitResourceArgs) UnitResource {
+	r := newUnitResource(args)
+	u.Resources_.Resources_ = append(u.Resources_.Resources_, r)
+	return r
+}
+
+func (u *unit) setResources(resourceList []*unitResource) {
+	u.Resources_ = unitResources{
+		Version:    1,
+		Resources_: resourceList,
+	}
+}
+
+// Payloads implements Unit.
+func (u *unit) Payloads() []Payload {
+	result := make([]Payload, len(u.Payloads_.Payloads_))
+	for i, p := range u.Payloads_.Payloads_ {
+		result[i] = p
+	}
+	return result
+}
+
+// AddPayload implements Unit.
+func (u *unit) AddPayload(args PayloadArgs) Payload {
+	p := newPayload(args)
+	u.Payloads_.Payloads_ = append(u.Payloads_.Payloads_, p)
+	return p
+}
+
+func (u *unit) setPayloads(payloadList []*payload) {
+	u.Payloads_ = payloads{
+		Version:   1,
+		Payloads_: payloadList,
+	}
+}
+
 // Constraints implements HasConstraints.
 func (u *unit) Constraints() Constraints {
 	if u.Constraints_ == nil {
@@ -211,6 +263,11 @@ func (u *unit) Validate() error {
 	if u.Tools_ == nil {
 		return errors.NotValidf("unit %q missing tools", u.Name_)
 	}
+	for _, p := range u.Payloads_.Payloads_ {
+		if err := p.Validate(); err != nil {
+			return errors.Annotatef(err, "unit %q", u.Name_)
+		}
+	}
 	return nil
 }
 
@@ -271,6 +328,9 @@ func importUnitV1(source map[string]interface{}) (*unit, error) {
 
 		"meter-status-code": schema.String(),
 		"meter-status-info": schema.String(),
+
+		"resources": schema.StringMap(schema.Any()),
+		"payloads":  schema.StringMap(schema.Any()),
 	}
 	defaults := schema.Defaults{
 		"principal":         "",
@@ -340,5 +400,17 @@ func importUnitV1(source map[string]interface{}) (*unit, error) {
 	}
 	result.WorkloadStatus_ = workloadStatus
 
+	resources, err := importUnitResources(valid["resources"].(map[string]interface{}))
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	result.setResources(resources)
+
+	payloads, err := importPayloads(valid["payloads"].(map[string]interface{}))
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	result.setPayloads(payloads)
+
 	return result, nil
 }
@@ -38,6 +38,14 @@ func minimalUnitMap() map[interface{}]interface{} {
 		"workload-status-history": emptyStatusHistoryMap(),
 		"password-hash":           "secure-hash",
 		"tools":                   minimalAgentToolsMap(),
+		"resources": map[interface{}]interface{}{
+			"version":   1,
+			"resources": []interface{}{},
+		},
+		"payloads": map[interface{}]interface{}{
+			"version":  1,
+			"payloads": []interface{}{},
+		},
 	}
 }
 
@@ -191,3 +199,34 @@ func (s *UnitSerializationSuite) TestWorkloadStatusHistory(c *gc.C) {
 		c.Check(point.Updated(), gc.Equals, args[i].Updated)
 	}
 }
+
+func (s *UnitSerializationSuite) TestResources(c *gc.C) {
+	initial := minimalUnit()
+	initial.AddResource(UnitResourceArgs{
+		Name:     "config",
+		Revision: testResourceRevisionArgs(),
+	})
+
+	unit := s.exportImport(c, initial)
+	resources := unit.Resources()
+	c.Assert(resources, gc.HasLen, 1)
+	c.Check(resources[0].Name(), gc.Equals, "config")
+	c.Check(resources[0].Revision(), jc.DeepEquals, newResourceRevision(testResourceRevisionArgs()))
+}
+
+func (s *UnitSerializationSuite) TestPayloads(c *gc.C) {
+	initial := minimalUnit()
+	initial.AddPayload(testPayloadArgs())
+
+	unit := s.exportImport(c, initial)
+	payloads := unit.Payloads()
+	c.Assert(payloads, gc.HasLen, 1)
+	c.Check(payloads[0], jc.DeepEquals, newPayload(testPayloadArgs()))
+}
+
+func (s *UnitSerializationSuite) TestPayloadValidated(c *gc.C) {
+	initial := minimalUnit()
+	initial.AddPayload(PayloadArgs{Name: "spam"})
+	err := initial.Validate()
+	c.Assert(err, gc.ErrorMatches, `unit "ubuntu/0": payload "spam" missing raw id not valid`)
+}
@@ -23,6 +23,7 @@ type UserArgs struct {
 	DateCreated    time.Time
 	LastConnection time.Time
 	ReadOnly       bool
+	Access         string
 }
 
 func newUser(args UserArgs) *user {
@@ -32,6 +33,7 @@ func newUser(args UserArgs) *user {
 		CreatedBy_:   args.CreatedBy.Canonical(),
 		DateCreated_: args.DateCreated,
 		ReadOnly_:    args.ReadOnly,
+		Access_:      args.Access,
 	}
 	if !args.LastConnection.IsZero() {
 		value := args.LastConnection
@@ -49,6 +51,7 @@ type user struct {
 	// so use a pointer in the struct.
 	LastConnection_ *time.Time `yaml:"last-connection,omitempty"`
 	ReadOnly_       bool       `yaml:"read-only,omitempty"`
+	Access_         string     `yaml:"access,omitempty"`
 }
 
 // Name implements User.
@@ -85,6 +88,19 @@ func (u *user) ReadOnly() bool {
 	return u.ReadOnly_
 }
 
+// Access implements User.
+func (u *user) Access() string {
+	if u.Access_ != "" {
+		return u.Access_
+	}
+	// Models serialized before the access was recorded only
+	// distinguish between read only users and admins.
+	if u.ReadOnly_ {
+		return "read"
+	}
+	return "admin"
+}
+
 func importUsers(source map[string]interface{}) ([]*user, error) {
 	checker := versionedChecker("users")
 	coerced, err := checker.Coerce(source, nil)
@@ -130,6 +146,7 @@ func importUserV1(source map[string]interface{}) (*user, error) {
 		"display-name":    schema.String(),
 		"created-by":      schema.String(),
 		"read-only":       schema.Bool(),
+		"access":          schema.String(),
 		"date-created":    schema.Time(),
 		"last-connection": schema.Time(),
 	}
@@ -139,6 +156,7 @@ func importUserV1(source map[string]interface{}) (*user, error) {
 		"display-name":    "",
 		"last-connection": time.Time{},
 		"read-only":       false,
+		"access":          "",
 	}
 	checker := schema.FieldMap(fields, defaults)
 	coerced, err := checker.Coerce(source, nil)
@@ -155,6 +173,7 @@ func importUserV1(source map[string]interface{}) (*user, error) {
 		CreatedBy_:   valid["created-by"].(string),
 		DateCreated_: valid["date-created"].(time.Time),
 		ReadOnly_:    valid["read-only"].(bool),
+		Access_:      valid["access"].(string),
 	}
 
 	lastConn := valid["last-connection"].(time.Time)
@@ -0,0 +1,163 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package description
+
+import (
+	"time"
+
+	"github.com/juju/errors"
+	"github.com/juju/names"
+	"github.com/juju/schema"
+)
+
+type volumesnapshots struct {
+	Version    int               `yaml:"version"`
+	Snapshots_ []*volumesnapshot `yaml:"snapshots"`
+}
+
+type volumesnapshot struct {
+	ID_         string    `yaml:"id"`
+	VolumeID_   string    `yaml:"volume-id"`
+	StorageID_  string    `yaml:"storage-id,omitempty"`
+	Pool_       string    `yaml:"pool"`
+	SnapshotID_ string    `yaml:"snapshot-id"`
+	Size_       uint64    `yaml:"size"`
+	Created_    time.Time `yaml:"created"`
+}
+
+// VolumeSnapshotArgs is an argument struct used to add a volume snapshot
+// to the Model.
+type VolumeSnapshotArgs struct {
+	ID         string
+	Volume     names.VolumeTag
+	Storage    names.StorageTag
+	Pool       string
+	SnapshotID string
+	Size       uint64
+	Created    time.Time
+}
+
+func newVolumeSnapshot(args VolumeSnapshotArgs) *volumesnapshot {
+	return &volumesnapshot{
+		ID_:         args.ID,
+		VolumeID_:   args.Volume.Id(),
+		StorageID_:  args.Storage.Id(),
+		Pool_:       args.Pool,
+		SnapshotID_: args.SnapshotID,
+		Size_:       args.Size,
+		Created_:    args.Created,
+	}
+}
+
+// ID implements VolumeSnapshot.
+func (s *volumesnapshot) ID() string {
+	return s.ID_
+}
+
+// Volume implements VolumeSnapshot.
+func (s *volumesnapshot) Volume() names.VolumeTag {
+	return names.NewVolumeTag(s.VolumeID_)
+}
+
+// Storage implements VolumeSnapshot.
+func (s *volumesnapshot) Storage() names.StorageTag {
+	if s.StorageID_ == "" {
+		return names.StorageTag{}
+	}
+	return names.NewStorageTag(s.StorageID_)
+}
+
+// Pool implements VolumeSnapshot.
+func (s *volumesnapshot) Pool() string {
+	return s.Pool_
+}
+
+// SnapshotID implements VolumeSnapshot.
+func (s *volumesnapshot) SnapshotID() string {
+	return s.SnapshotID_
+}
+
+// Size implements VolumeSnapshot.
+func (s *volumesnapshot) Size() uint64 {
+	return s.Size_
+}
+
+// Created implements VolumeSnapshot.
+func (s *volumesnapshot) Created() time.Time {
+	return s.Created_
+}
+
+func importVolumeSnapshots(source map[string]interface{}) ([]*volumesnapshot, error) {
+	checker := versionedChecker("snapshots")
+	coerced, err := checker.Coerce(source, nil)
+	if err != nil {
+		return nil, errors.Annotatef(err, "volumesnapshots version schema check failed")
+	}
+	valid := coerced.(map[string]interface{})
+
+	version := int(valid["version"].(int64))
+	importFunc, ok := volumeSnapshotDeserializationFuncs[version]
+	if !ok {
+		return nil, errors.NotValidf("version %d", version)
+	}
+	sourceList := valid["snapshots"].([]interface{})
+	return importVolumeSnapshotList(sourceList, importFunc)
+}
+
+func importVolumeSnapshotList(sourceList []interface{}, importFunc volumeSnapshotDeserializationFunc) ([]*volumesnapshot, error) {
+	result := make([]*volumesnapshot, 0, len(sourceList))
+	for i, value := range sourceList {
+		source, ok := value.(map[string]interface{})
+		if !ok {
+			return nil, errors.Errorf("unexpected value for volumesnapshot %d, %T", i, value)
+		}
+		snapshot, err := importFunc(source)
+		if err != nil {
+			return nil, errors.Annotatef(err, "volumesnapshot %d", i)
+		}
+		result = append(result, snapshot)
+	}
+	return result, nil
+}
+
+type volumeSnapshotDeserializationFunc func(map[string]interface{}) (*volumesnapshot, error)
+
+var volumeSnapshotDeserializationFuncs = map[int]volumeSnapshotDeserializationFunc{
+	1: importVolumeSnapshotV1,
+}
+
+func importVolumeSnapshotV1(source map[string]interface{}) (*volumesnapshot, error) {
+	fields := schema.Fields{
+		"id":          schema.String(),
+		"volume-id":   schema.String(),
+		"storage-id":  schema.String(),
+		"pool":        schema.String(),
+		"snapshot-id": schema.String(),
+		"size":        schema.Uint(),
+		"created":     schema.Time(),
+	}
+	defaults := schema.Defaults{
+		"storage-id": "",
+	}
+	checker := schema.FieldMap(fields, defaults)
+
+	coerced, err := checker.Coerce(source, nil)
+	if err != nil {
+		return nil, errors.Annotatef(err, "volumesnapshot v1 schema check failed")
+	}
+	valid := coerced.(map[string]interface{})
+	// From here we know that the map returned from the schema coercion
+	// contains fields of the right type.
+
+	result := &volumesnapshot{
+		ID_:         valid["id"].(string),
+		VolumeID_:   valid["volume-id"].(string),
+		StorageID_:  valid["storage-id"].(string),
+		Pool_:       valid["pool"].(string),
+		SnapshotID_: valid["snapshot-id"].(string),
+		Size_:       valid["size"].(uint64),
+		Created_:    valid["created"].(time.Time),
+	}
+	return result, nil
+}
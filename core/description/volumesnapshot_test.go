@@ -0,0 +1,89 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package description
+
+import (
+	"time"
+
+	"github.com/juju/names"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/yaml.v2"
+)
+
+type VolumeSnapshotSerializationSuite struct {
+	SliceSerializationSuite
+}
+
+var _ = gc.Suite(&VolumeSnapshotSerializationSuite{})
+
+func (s *VolumeSnapshotSerializationSuite) SetUpTest(c *gc.C) {
+	s.SliceSerializationSuite.SetUpTest(c)
+	s.importName = "volumesnapshots"
+	s.sliceName = "snapshots"
+	s.importFunc = func(m map[string]interface{}) (interface{}, error) {
+		return importVolumeSnapshots(m)
+	}
+	s.testFields = func(m map[string]interface{}) {
+		m["snapshots"] = []interface{}{}
+	}
+}
+
+func testVolumeSnapshotArgs() VolumeSnapshotArgs {
+	return VolumeSnapshotArgs{
+		ID:         "1",
+		Volume:     names.NewVolumeTag("0/0"),
+		Storage:    names.NewStorageTag("data/0"),
+		Pool:       "fast",
+		SnapshotID: "snap-0",
+		Size:       1024,
+		Created:    time.Date(2016, 6, 1, 12, 34, 56, 0, time.UTC),
+	}
+}
+
+func (s *VolumeSnapshotSerializationSuite) TestNewVolumeSnapshot(c *gc.C) {
+	snapshot := newVolumeSnapshot(testVolumeSnapshotArgs())
+
+	c.Check(snapshot.ID(), gc.Equals, "1")
+	c.Check(snapshot.Volume(), gc.Equals, names.NewVolumeTag("0/0"))
+	c.Check(snapshot.Storage(), gc.Equals, names.NewStorageTag("data/0"))
+	c.Check(snapshot.Pool(), gc.Equals, "fast")
+	c.Check(snapshot.SnapshotID(), gc.Equals, "snap-0")
+	c.Check(snapshot.Size(), gc.Equals, uint64(1024))
+	c.Check(snapshot.Created(), gc.Equals, time.Date(2016, 6, 1, 12, 34, 56, 0, time.UTC))
+}
+
+func (s *VolumeSnapshotSerializationSuite) exportImport(c *gc.C, snapshot *volumesnapshot) *volumesnapshot {
+	initial := volumesnapshots{
+		Version:    1,
+		Snapshots_: []*volumesnapshot{snapshot},
+	}
+
+	bytes, err := yaml.Marshal(initial)
+	c.Assert(err, jc.ErrorIsNil)
+
+	var source map[string]interface{}
+	err = yaml.Unmarshal(bytes, &source)
+	c.Assert(err, jc.ErrorIsNil)
+
+	snapshots, err := importVolumeSnapshots(source)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(snapshots, gc.HasLen, 1)
+	return snapshots[0]
+}
+
+func (s *VolumeSnapshotSerializationSuite) TestParsingSerializedData(c *gc.C) {
+	original := newVolumeSnapshot(testVolumeSnapshotArgs())
+	snapshot := s.exportImport(c, original)
+	c.Assert(snapshot, jc.DeepEquals, original)
+}
+
+func (s *VolumeSnapshotSerializationSuite) TestParsingNoStorage(c *gc.C) {
+	args := testVolumeSnapshotArgs()
+	args.Storage = names.StorageTag{}
+	original := newVolumeSnapshot(args)
+	snapshot := s.exportImport(c, original)
+	c.Assert(snapshot, jc.DeepEquals, original)
+	c.Assert(snapshot.Storage(), gc.Equals, names.StorageTag{})
+}
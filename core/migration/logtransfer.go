@@ -0,0 +1,10 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package migration
+
+// LogTransferSink is the name used to record the timestamp of the
+// last log record transferred to the target controller for a
+// migrated model. It allows an interrupted log transfer to continue
+// from where it stopped.
+const LogTransferSink = "migration-logtransfer"
@@ -0,0 +1,29 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package migration
+
+import (
+	"github.com/juju/names"
+	"github.com/juju/version"
+)
+
+// ModelInfo is used to report basic details about a model being
+// migrated. It is passed to the target controller so that it can
+// check that it is able to accept the model.
+type ModelInfo struct {
+	UUID         string
+	Owner        names.UserTag
+	Name         string
+	AgentVersion version.Number
+	ProviderType string
+
+	// CloudAttrs holds the model's values for the config attributes
+	// which its provider restricts to those of the controller, such
+	// as the region. These identify the cloud the model is in.
+	CloudAttrs map[string]string
+
+	// CredentialAttrs holds the names of the credential attributes
+	// set in the model's config. The values are not included.
+	CredentialAttrs []string
+}
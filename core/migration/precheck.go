@@ -0,0 +1,29 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package migration
+
+import (
+	"strings"
+
+	"github.com/juju/errors"
+)
+
+// PrecheckError is returned when one or more of the checks performed
+// before a migration is allowed to import the model have failed.
+// Each failure is described by an entry in Reasons.
+type PrecheckError struct {
+	Reasons []string
+}
+
+// Error implements error.
+func (e *PrecheckError) Error() string {
+	return "prechecks failed: " + strings.Join(e.Reasons, "; ")
+}
+
+// IsPrecheckError returns true if the cause of err is a
+// *PrecheckError.
+func IsPrecheckError(err error) bool {
+	_, ok := errors.Cause(err).(*PrecheckError)
+	return ok
+}
@@ -26,6 +26,7 @@ import (
 	"github.com/juju/juju/cert"
 	"github.com/juju/juju/environs/tags"
 	"github.com/juju/juju/juju/osenv"
+	"github.com/juju/juju/logfwd/syslog"
 )
 
 var logger = loggo.GetLogger("juju.environs.config")
@@ -64,6 +65,10 @@ const (
 	// refresh addresses from the provider each time.
 	DefaultBootstrapSSHAddressesDelay int = 10
 
+	// DefaultMigrationMinionReportTimeout is how long the controller
+	// waits, by default, for a migrating model's agents to report.
+	DefaultMigrationMinionReportTimeout = 15 * time.Minute
+
 	// DefaultNumaControlPolicy should not be used by default.
 	// Only use numactl if user specifically requests it
 	DefaultNumaControlPolicy = false
@@ -88,6 +93,10 @@ const (
 	// config setting. Only non-zero, positive integer values will
 	// have effect.
 	DefaultLXCDefaultMTU = 0
+
+	// DefaultProvisionerConcurrency is the default number of machines
+	// the provisioner will start at the same time.
+	DefaultProvisionerConcurrency = 8
 )
 
 // TODO(katco-): Please grow this over time.
@@ -119,6 +128,9 @@ const (
 	// ProvisionerHarvestModeKey stores the key for this setting.
 	ProvisionerHarvestModeKey = "provisioner-harvest-mode"
 
+	// ProvisionerConcurrencyKey stores the key for this setting.
+	ProvisionerConcurrencyKey = "provisioner-concurrency"
+
 	// AgentStreamKey stores the key for this setting.
 	AgentStreamKey = "agent-stream"
 
@@ -197,6 +209,28 @@ const (
 	// automatically retry a hook that has failed
 	AutomaticallyRetryHooks = "automatically-retry-hooks"
 
+	// SyslogHostKey is the host:port of a remote syslog server to
+	// which the model's logs are forwarded. Log forwarding is
+	// disabled when it is not set.
+	SyslogHostKey = "syslog-host"
+
+	// SyslogCACertKey is the CA certificate used to verify the
+	// remote syslog server's certificate.
+	SyslogCACertKey = "syslog-ca-cert"
+
+	// SyslogClientCertKey is the certificate presented to the remote
+	// syslog server.
+	SyslogClientCertKey = "syslog-client-cert"
+
+	// SyslogClientKeyKey is the private key for the certificate
+	// presented to the remote syslog server.
+	SyslogClientKeyKey = "syslog-client-key"
+
+	// MigrationMinionReportTimeoutKey is how long the controller
+	// waits for the agents of a migrating model to report on each
+	// phase of the migration, as a duration string such as "15m".
+	MigrationMinionReportTimeoutKey = "migration-minion-report-timeout"
+
 	//
 	// Deprecated Settings Attributes
 	//
@@ -597,6 +631,12 @@ func Validate(cfg, old *Config) error {
 
 	}
 
+	if v, ok := cfg.defined[MigrationMinionReportTimeoutKey].(string); ok {
+		if _, err := time.ParseDuration(v); err != nil {
+			return errors.Annotate(err, "invalid migration minion report timeout")
+		}
+	}
+
 	if v, ok := cfg.defined[IdentityPublicKey].(string); ok {
 		var key bakery.PublicKey
 		if err := key.UnmarshalText([]byte(v)); err != nil {
@@ -639,11 +679,23 @@ func Validate(cfg, old *Config) error {
 		}
 	}
 
+	// Check the log forwarding settings are complete and usable, when set.
+	if syslogConfig, ok := cfg.LogFwdSyslog(); ok {
+		if err := syslogConfig.Validate(); err != nil {
+			return errors.Annotate(err, "invalid syslog forwarding config")
+		}
+	}
+
 	// Check LXCDefaultMTU is a positive integer, when set.
 	if lxcDefaultMTU, ok := cfg.LXCDefaultMTU(); ok && lxcDefaultMTU < 0 {
 		return errors.Errorf("%s: expected positive integer, got %v", LXCDefaultMTU, lxcDefaultMTU)
 	}
 
+	// Check the provisioner concurrency is a positive integer, when set.
+	if v, ok := cfg.defined[ProvisionerConcurrencyKey].(int); ok && v < 1 {
+		return errors.Errorf("%s: expected positive integer, got %v", ProvisionerConcurrencyKey, v)
+	}
+
 	cfg.defined = ProcessDeprecatedAttributes(cfg.defined)
 	return nil
 }
@@ -1056,6 +1108,18 @@ func (c *Config) AutomaticallyRetryHooks() bool {
 	}
 }
 
+// MigrationMinionReportTimeout returns how long the controller waits
+// for the agents of a migrating model to report on each phase of the
+// migration.
+func (c *Config) MigrationMinionReportTimeout() time.Duration {
+	if v, ok := c.defined[MigrationMinionReportTimeoutKey].(string); ok {
+		if d, err := time.ParseDuration(v); err == nil {
+			return d
+		}
+	}
+	return DefaultMigrationMinionReportTimeout
+}
+
 // ProvisionerHarvestMode reports the harvesting methodology the
 // provisioner should take.
 func (c *Config) ProvisionerHarvestMode() HarvestMode {
@@ -1125,6 +1189,15 @@ func (c *Config) LXCDefaultMTU() (int, bool) {
 	return v, ok
 }
 
+// ProvisionerConcurrency returns the maximum number of machines that
+// the provisioner will start at the same time.
+func (c *Config) ProvisionerConcurrency() int {
+	if v, ok := c.defined[ProvisionerConcurrencyKey].(int); ok {
+		return v
+	}
+	return DefaultProvisionerConcurrency
+}
+
 // DisableNetworkManagement reports whether Juju is allowed to
 // configure and manage networking inside the environment.
 func (c *Config) DisableNetworkManagement() (bool, bool) {
@@ -1139,6 +1212,21 @@ func (c *Config) IgnoreMachineAddresses() (bool, bool) {
 	return v, ok
 }
 
+// LogFwdSyslog returns the configuration for forwarding the model's
+// logs to a remote syslog server, and whether forwarding is enabled.
+func (c *Config) LogFwdSyslog() (*syslog.RawConfig, bool) {
+	host := c.asString(SyslogHostKey)
+	if host == "" {
+		return nil, false
+	}
+	return &syslog.RawConfig{
+		Host:       host,
+		CACert:     c.asString(SyslogCACertKey),
+		ClientCert: c.asString(SyslogClientCertKey),
+		ClientKey:  c.asString(SyslogClientKeyKey),
+	}, true
+}
+
 // StorageDefaultBlockSource returns the default block storage
 // source for the environment.
 func (c *Config) StorageDefaultBlockSource() (string, bool) {
@@ -1271,6 +1359,7 @@ var alwaysOptional = schema.Defaults{
 	"ca-private-key-path":        schema.Omit,
 	"logging-config":             schema.Omit,
 	ProvisionerHarvestModeKey:    schema.Omit,
+	ProvisionerConcurrencyKey:    schema.Omit,
 	"bootstrap-timeout":          schema.Omit,
 	"bootstrap-retry-delay":      schema.Omit,
 	"bootstrap-addresses-delay":  schema.Omit,
@@ -1294,10 +1383,17 @@ var alwaysOptional = schema.Defaults{
 	AllowLXCLoopMounts:           false,
 	ResourceTagsKey:              schema.Omit,
 	CloudImageBaseURL:            schema.Omit,
+	SyslogHostKey:                schema.Omit,
+	SyslogCACertKey:              schema.Omit,
+	SyslogClientCertKey:          schema.Omit,
+	SyslogClientKeyKey:           schema.Omit,
 
 	// AutomaticallyRetryHooks is assumed to be true if missing
 	AutomaticallyRetryHooks: schema.Omit,
 
+	// The migration minion report timeout defaults to 15 minutes.
+	MigrationMinionReportTimeoutKey: schema.Omit,
+
 	// Storage related config.
 	// Environ providers will specify their own defaults.
 	StorageDefaultBlockSourceKey: schema.Omit,
@@ -1789,6 +1885,11 @@ global or per instance security groups.`,
 		Values:      []interface{}{"all", "none", "unknown", "destroyed"},
 		Group:       environschema.EnvironGroup,
 	},
+	ProvisionerConcurrencyKey: {
+		Description: "The maximum number of machines the provisioner will start at the same time (default 8)",
+		Type:        environschema.Tint,
+		Group:       environschema.EnvironGroup,
+	},
 	ProvisionerSafeModeKey: {
 		Description: `Whether to run the provisioner in "destroyed" harvest mode (deprecated, superceded by provisioner-harvest-mode)`,
 		Type:        environschema.Tbool,
@@ -1884,4 +1985,30 @@ data of the store. (default false)`,
 		Type:        environschema.Tbool,
 		Group:       environschema.EnvironGroup,
 	},
+	SyslogHostKey: {
+		Description: "The host:port of a syslog server to which the model's logs are forwarded",
+		Type:        environschema.Tstring,
+		Group:       environschema.EnvironGroup,
+	},
+	SyslogCACertKey: {
+		Description: "The CA certificate used to verify the syslog server",
+		Type:        environschema.Tstring,
+		Group:       environschema.EnvironGroup,
+	},
+	SyslogClientCertKey: {
+		Description: "The client certificate presented to the syslog server",
+		Type:        environschema.Tstring,
+		Group:       environschema.EnvironGroup,
+	},
+	SyslogClientKeyKey: {
+		Description: "The private key for the syslog client certificate",
+		Type:        environschema.Tstring,
+		Group:       environschema.EnvironGroup,
+		Secret:      true,
+	},
+	MigrationMinionReportTimeoutKey: {
+		Description: "How long the controller waits for a migrating model's agents to report on each migration phase",
+		Type:        environschema.Tstring,
+		Group:       environschema.JujuGroup,
+	},
 }
@@ -26,6 +26,7 @@ import (
 	"github.com/juju/juju/cert"
 	"github.com/juju/juju/environs/config"
 	"github.com/juju/juju/juju/osenv"
+	"github.com/juju/juju/logfwd/syslog"
 	"github.com/juju/juju/testing"
 )
 
@@ -223,6 +224,19 @@ var configTests = []configTest{
 			"lxc-default-mtu": -42,
 		}),
 		err: `lxc-default-mtu: expected positive integer, got -42`,
+	}, {
+		about:       "Provisioner concurrency set explicitly",
+		useDefaults: config.UseDefaults,
+		attrs: minimalConfigAttrs.Merge(testing.Attrs{
+			"provisioner-concurrency": 16,
+		}),
+	}, {
+		about:       "Provisioner concurrency invalid (zero)",
+		useDefaults: config.UseDefaults,
+		attrs: minimalConfigAttrs.Merge(testing.Attrs{
+			"provisioner-concurrency": 0,
+		}),
+		err: `provisioner-concurrency: expected positive integer, got 0`,
 	}, {
 		about:       "CA cert & key from path",
 		useDefaults: config.UseDefaults,
@@ -830,6 +844,43 @@ var configTests = []configTest{
 			"identity-public-key": "o/yOqSNWncMo1GURWuez/dGR30TscmmuIxgjztpoHEY=",
 		}),
 	},
+	{
+		about:       "Syslog host without certificates",
+		useDefaults: config.UseDefaults,
+		attrs: minimalConfigAttrs.Merge(testing.Attrs{
+			"syslog-host": "syslog.example.com:6514",
+		}),
+		err: `invalid syslog forwarding config: empty CACert not valid`,
+	},
+	{
+		about:       "Syslog host without port",
+		useDefaults: config.UseDefaults,
+		attrs: minimalConfigAttrs.Merge(testing.Attrs{
+			"syslog-host":        "syslog.example.com",
+			"syslog-ca-cert":     testing.CACert,
+			"syslog-client-cert": testing.ServerCert,
+			"syslog-client-key":  testing.ServerKey,
+		}),
+		err: `invalid syslog forwarding config: bad Host: .*`,
+	},
+	{
+		about:       "Valid syslog forwarding config",
+		useDefaults: config.UseDefaults,
+		attrs: minimalConfigAttrs.Merge(testing.Attrs{
+			"syslog-host":        "syslog.example.com:6514",
+			"syslog-ca-cert":     testing.CACert,
+			"syslog-client-cert": testing.ServerCert,
+			"syslog-client-key":  testing.ServerKey,
+		}),
+	},
+	{
+		about:       "Invalid migration minion report timeout",
+		useDefaults: config.UseDefaults,
+		attrs: minimalConfigAttrs.Merge(testing.Attrs{
+			"migration-minion-report-timeout": "soon",
+		}),
+		err: `invalid migration minion report timeout: time: invalid duration "?soon"?`,
+	},
 }
 
 func missingAttributeNoDefault(attrName string) configTest {
@@ -989,6 +1040,15 @@ func (s *ConfigSuite) TestNoDefinedPrivateCert(c *gc.C) {
 	c.Assert(err, jc.ErrorIsNil)
 }
 
+func (s *ConfigSuite) TestProvisionerConcurrency(c *gc.C) {
+	s.addJujuFiles(c)
+	cfg := newTestConfig(c, testing.Attrs{})
+	c.Assert(cfg.ProvisionerConcurrency(), gc.Equals, config.DefaultProvisionerConcurrency)
+
+	cfg = newTestConfig(c, testing.Attrs{"provisioner-concurrency": 2})
+	c.Assert(cfg.ProvisionerConcurrency(), gc.Equals, 2)
+}
+
 func (s *ConfigSuite) TestSafeModeDeprecatesGracefully(c *gc.C) {
 
 	cfg, err := config.New(config.UseDefaults, testing.Attrs{
@@ -1605,6 +1665,42 @@ func (s *ConfigSuite) TestCloudImageBaseURLSet(c *gc.C) {
 	c.Assert(config.CloudImageBaseURL(), gc.Equals, "http://local.foo/query")
 }
 
+func (s *ConfigSuite) TestLogFwdSyslogNotSet(c *gc.C) {
+	s.addJujuFiles(c)
+	config := newTestConfig(c, testing.Attrs{})
+	_, ok := config.LogFwdSyslog()
+	c.Assert(ok, jc.IsFalse)
+}
+
+func (s *ConfigSuite) TestLogFwdSyslog(c *gc.C) {
+	s.addJujuFiles(c)
+	config := newTestConfig(c, testing.Attrs{
+		"syslog-host":        "syslog.example.com:6514",
+		"syslog-ca-cert":     testing.CACert,
+		"syslog-client-cert": testing.ServerCert,
+		"syslog-client-key":  testing.ServerKey,
+	})
+	syslogConfig, ok := config.LogFwdSyslog()
+	c.Assert(ok, jc.IsTrue)
+	c.Assert(syslogConfig, jc.DeepEquals, &syslog.RawConfig{
+		Host:       "syslog.example.com:6514",
+		CACert:     testing.CACert,
+		ClientCert: testing.ServerCert,
+		ClientKey:  testing.ServerKey,
+	})
+}
+
+func (s *ConfigSuite) TestMigrationMinionReportTimeoutDefault(c *gc.C) {
+	config := newTestConfig(c, testing.Attrs{})
+	c.Assert(config.MigrationMinionReportTimeout(), gc.Equals, 15*time.Minute)
+}
+
+func (s *ConfigSuite) TestMigrationMinionReportTimeout(c *gc.C) {
+	config := newTestConfig(c, testing.Attrs{
+		"migration-minion-report-timeout": "90s"})
+	c.Assert(config.MigrationMinionReportTimeout(), gc.Equals, 90*time.Second)
+}
+
 func (s *ConfigSuite) TestProxyValuesWithFallback(c *gc.C) {
 	s.addJujuFiles(c)
 
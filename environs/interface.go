@@ -245,6 +245,27 @@ type Firewaller interface {
 	Ports() ([]network.PortRange, error)
 }
 
+// IngressRuleFirewaller is an optional interface that an Environ may
+// implement if it can restrict opened ports to traffic from particular
+// source CIDRs. Instances of an Environ that implements this interface
+// must implement instance.IngressRuleFirewaller.
+type IngressRuleFirewaller interface {
+	// OpenIngressRules opens the given ingress rules for the whole
+	// environment. Must only be used if the environment was setup
+	// with the FwGlobal firewall mode.
+	OpenIngressRules(rules []network.IngressRule) error
+
+	// CloseIngressRules closes the given ingress rules for the whole
+	// environment. Must only be used if the environment was setup
+	// with the FwGlobal firewall mode.
+	CloseIngressRules(rules []network.IngressRule) error
+
+	// IngressRules returns the ingress rules opened for the whole
+	// environment. Must only be used if the environment was setup
+	// with the FwGlobal firewall mode.
+	IngressRules() ([]network.IngressRule, error)
+}
+
 // InstanceTagger is an interface that can be used for tagging instances.
 type InstanceTagger interface {
 	// TagInstance tags the given instance with the specified tags.
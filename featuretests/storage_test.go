@@ -235,6 +235,10 @@ ebs:
   provider: ebs
 loop:
   provider: loop
+nfs:
+  provider: nfs
+rbd:
+  provider: rbd
 rootfs:
   provider: rootfs
 tmpfs:
@@ -251,6 +255,8 @@ NAME    PROVIDER  ATTRS
 block   loop      it=works
 ebs     ebs       
 loop    loop      
+nfs     nfs       
+rbd     rbd       
 rootfs  rootfs    
 tmpfs   tmpfs     
 
@@ -54,6 +54,24 @@ type Instance interface {
 	Ports(machineId string) ([]network.PortRange, error)
 }
 
+// IngressRuleFirewaller is implemented by instances that can restrict
+// opened ports to traffic from particular source CIDRs.
+type IngressRuleFirewaller interface {
+	// OpenIngressRules opens the given ingress rules on the instance,
+	// which should have been started with the given machine id.
+	OpenIngressRules(machineId string, rules []network.IngressRule) error
+
+	// CloseIngressRules closes the given ingress rules on the
+	// instance, which should have been started with the given
+	// machine id.
+	CloseIngressRules(machineId string, rules []network.IngressRule) error
+
+	// IngressRules returns the ingress rules open on the instance,
+	// which should have been started with the given machine id. The
+	// rules are returned as sorted by network.SortIngressRules().
+	IngressRules(machineId string) ([]network.IngressRule, error)
+}
+
 // HardwareCharacteristics represents the characteristics of the instance (if known).
 // Attributes that are nil are unknown or not supported.
 type HardwareCharacteristics struct {
@@ -88,6 +106,39 @@ func IsRetryableCreationError(err error) bool {
 	return ok
 }
 
+// ZoneSpecificCreationError reports that an instance could not be
+// created in a particular availability zone (e.g. because the zone
+// has no capacity left for the requested instance type), and that
+// creating it in another zone may succeed.
+//
+// A provider that returns this error tries only one zone: the one
+// given by the placement directive or, when there is none, the one
+// the provider chose. Trying the other zones is left to the caller.
+type ZoneSpecificCreationError struct {
+	zone    string
+	message string
+}
+
+// Error returns the error message.
+func (e *ZoneSpecificCreationError) Error() string { return e.message }
+
+// Zone returns the name of the availability zone in which the
+// instance could not be created.
+func (e *ZoneSpecificCreationError) Zone() string { return e.zone }
+
+// NewZoneSpecificCreationError returns an error reporting that an
+// instance could not be created in the named availability zone.
+func NewZoneSpecificCreationError(zone, errorMessage string) *ZoneSpecificCreationError {
+	return &ZoneSpecificCreationError{zone, errorMessage}
+}
+
+// IsZoneSpecificCreationError returns true if the given error is
+// ZoneSpecificCreationError.
+func IsZoneSpecificCreationError(err error) bool {
+	_, ok := err.(*ZoneSpecificCreationError)
+	return ok
+}
+
 func (hc HardwareCharacteristics) String() string {
 	var strs []string
 	if hc.Arch != nil {
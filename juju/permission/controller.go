@@ -0,0 +1,43 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package permission
+
+import (
+	"github.com/juju/errors"
+)
+
+// ControllerAccess defines the permission that a user has on a
+// controller. Each level of access includes those below it.
+type ControllerAccess int
+
+const (
+	_ = iota
+
+	// ControllerLoginAccess allows a user to log in to the controller.
+	ControllerLoginAccess ControllerAccess = iota
+
+	// ControllerAddModelAccess allows a user to add models to the
+	// controller.
+	ControllerAddModelAccess ControllerAccess = iota
+
+	// ControllerSuperuserAccess allows a user full control over the
+	// controller and all of its models.
+	ControllerSuperuserAccess ControllerAccess = iota
+)
+
+// ParseControllerAccess parses a user-facing string representation of
+// a controller access permission into a logical representation.
+func ParseControllerAccess(access string) (ControllerAccess, error) {
+	var fail = ControllerAccess(0)
+	switch access {
+	case "login":
+		return ControllerLoginAccess, nil
+	case "addmodel":
+		return ControllerAddModelAccess, nil
+	case "superuser":
+		return ControllerSuperuserAccess, nil
+	default:
+		return fail, errors.Errorf("invalid controller access permission %q", access)
+	}
+}
@@ -0,0 +1,37 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package permission_test
+
+import (
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/juju/permission"
+)
+
+type controllerPermissionSuite struct{}
+
+var _ = gc.Suite(&controllerPermissionSuite{})
+
+func (s *controllerPermissionSuite) TestParseControllerAccessValid(c *gc.C) {
+	for _, test := range []struct {
+		access   string
+		expected permission.ControllerAccess
+	}{
+		{"login", permission.ControllerLoginAccess},
+		{"addmodel", permission.ControllerAddModelAccess},
+		{"superuser", permission.ControllerSuperuserAccess},
+	} {
+		access, err := permission.ParseControllerAccess(test.access)
+		c.Check(err, jc.ErrorIsNil)
+		c.Check(access, gc.Equals, test.expected)
+	}
+}
+
+func (s *controllerPermissionSuite) TestParseControllerAccessInvalid(c *gc.C) {
+	for _, access := range []string{"", "read", "admin", "preposterous"} {
+		_, err := permission.ParseControllerAccess(access)
+		c.Check(err, gc.ErrorMatches, "invalid controller access permission.*")
+	}
+}
@@ -31,6 +31,10 @@ func (s *permissionSuite) TestParseModelAccessValid(c *gc.C) {
 	c.Check(err, jc.ErrorIsNil)
 	c.Check(access, gc.Equals, permission.ModelWriteAccess)
 
+	access, err = permission.ParseModelAccess("admin")
+	c.Check(err, jc.ErrorIsNil)
+	c.Check(access, gc.Equals, permission.ModelAdminAccess)
+
 	access, err = permission.ParseModelAccess("orange")
 	c.Check(err, gc.ErrorMatches, "invalid model access permission.*")
 }
@@ -0,0 +1,33 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package permission
+
+import (
+	"github.com/juju/errors"
+)
+
+// ServiceAccess defines the permission that a user has on a service,
+// in addition to their access to the service's model.
+type ServiceAccess int
+
+const (
+	_ = iota
+
+	// ServiceOperateAccess allows a user to change the service's
+	// config, add and remove its units, run actions on its units and
+	// upgrade its charm.
+	ServiceOperateAccess ServiceAccess = iota
+)
+
+// ParseServiceAccess parses a user-facing string representation of a
+// service access permission into a logical representation.
+func ParseServiceAccess(access string) (ServiceAccess, error) {
+	var fail = ServiceAccess(0)
+	switch access {
+	case "operate":
+		return ServiceOperateAccess, nil
+	default:
+		return fail, errors.Errorf("invalid service access permission %q", access)
+	}
+}
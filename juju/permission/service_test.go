@@ -0,0 +1,35 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package permission_test
+
+import (
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/juju/permission"
+)
+
+type servicePermissionSuite struct{}
+
+var _ = gc.Suite(&servicePermissionSuite{})
+
+func (s *servicePermissionSuite) TestParseServiceAccessValid(c *gc.C) {
+	for _, test := range []struct {
+		access   string
+		expected permission.ServiceAccess
+	}{
+		{"operate", permission.ServiceOperateAccess},
+	} {
+		access, err := permission.ParseServiceAccess(test.access)
+		c.Check(err, jc.ErrorIsNil)
+		c.Check(access, gc.Equals, test.expected)
+	}
+}
+
+func (s *servicePermissionSuite) TestParseServiceAccessInvalid(c *gc.C) {
+	for _, access := range []string{"", "read", "write", "admin", "preposterous"} {
+		_, err := permission.ParseServiceAccess(access)
+		c.Check(err, gc.ErrorMatches, "invalid service access permission.*")
+	}
+}
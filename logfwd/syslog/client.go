@@ -0,0 +1,56 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package syslog
+
+import (
+	"crypto/tls"
+	"fmt"
+	"net"
+	"time"
+
+	"github.com/juju/errors"
+)
+
+// dialTimeout is how long to wait for a connection to the syslog
+// host to be established.
+const dialTimeout = 30 * time.Second
+
+// Client sends messages to a remote syslog host over TLS. Messages
+// are framed using octet counting, as described in RFC 6587.
+type Client struct {
+	conn net.Conn
+}
+
+// Open connects to the syslog host described by the given config.
+func Open(cfg RawConfig) (*Client, error) {
+	if err := cfg.Validate(); err != nil {
+		return nil, errors.Trace(err)
+	}
+	tlsConfig, err := cfg.TLSConfig()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	dialer := &net.Dialer{Timeout: dialTimeout}
+	conn, err := tls.DialWithDialer(dialer, "tcp", cfg.Host, tlsConfig)
+	if err != nil {
+		return nil, errors.Annotatef(err, "connecting to syslog host %q", cfg.Host)
+	}
+	return &Client{conn: conn}, nil
+}
+
+// Send sends the given messages to the syslog host.
+func (c *Client) Send(msgs ...Message) error {
+	for _, msg := range msgs {
+		text := msg.String()
+		if _, err := fmt.Fprintf(c.conn, "%d %s", len(text), text); err != nil {
+			return errors.Annotate(err, "sending message to syslog host")
+		}
+	}
+	return nil
+}
+
+// Close closes the connection to the syslog host.
+func (c *Client) Close() error {
+	return errors.Trace(c.conn.Close())
+}
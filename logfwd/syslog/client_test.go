@@ -0,0 +1,74 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package syslog_test
+
+import (
+	"time"
+
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/logfwd/syslog"
+	syslogtesting "github.com/juju/juju/logfwd/syslog/testing"
+	coretesting "github.com/juju/juju/testing"
+)
+
+type ClientSuite struct {
+	testing.IsolationSuite
+	server *syslogtesting.Server
+}
+
+var _ = gc.Suite(&ClientSuite{})
+
+func (s *ClientSuite) SetUpTest(c *gc.C) {
+	s.IsolationSuite.SetUpTest(c)
+	server, err := syslogtesting.NewServer()
+	c.Assert(err, jc.ErrorIsNil)
+	s.server = server
+	s.AddCleanup(func(*gc.C) { s.server.Close() })
+}
+
+func (s *ClientSuite) TestSend(c *gc.C) {
+	cfg, err := s.server.Config()
+	c.Assert(err, jc.ErrorIsNil)
+	client, err := syslog.Open(cfg)
+	c.Assert(err, jc.ErrorIsNil)
+	defer client.Close()
+
+	msgs := []syslog.Message{{
+		Severity: syslog.SeverityWarning,
+		Msg:      "first",
+	}, {
+		Severity: syslog.SeverityInformational,
+		Msg:      "second message with spaces",
+	}}
+	err = client.Send(msgs...)
+	c.Assert(err, jc.ErrorIsNil)
+
+	for _, msg := range msgs {
+		select {
+		case received := <-s.server.Messages():
+			c.Check(received, gc.Equals, msg.String())
+		case <-time.After(coretesting.LongWait):
+			c.Fatalf("timed out waiting for message")
+		}
+	}
+}
+
+func (s *ClientSuite) TestOpenInvalidConfig(c *gc.C) {
+	_, err := syslog.Open(syslog.RawConfig{})
+
+	c.Check(err, gc.ErrorMatches, "empty Host not valid")
+}
+
+func (s *ClientSuite) TestOpenUntrustedHost(c *gc.C) {
+	cfg, err := s.server.Config()
+	c.Assert(err, jc.ErrorIsNil)
+	cfg.CACert = coretesting.OtherCACert
+
+	_, err = syslog.Open(cfg)
+
+	c.Check(err, gc.ErrorMatches, `connecting to syslog host ".*": .*certificate.*`)
+}
@@ -0,0 +1,76 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package syslog
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"net"
+
+	"github.com/juju/errors"
+
+	"github.com/juju/juju/cert"
+)
+
+// RawConfig holds the raw configuration data for a connection to a
+// syslog forwarding target.
+type RawConfig struct {
+	// Host is the host-port of the syslog host. The port is required.
+	Host string
+
+	// CACert is the PEM-encoded CA certificate used to verify the
+	// syslog host's certificate.
+	CACert string
+
+	// ClientCert is the PEM-encoded certificate presented to the
+	// syslog host.
+	ClientCert string
+
+	// ClientKey is the PEM-encoded private key for ClientCert.
+	ClientKey string
+}
+
+// Validate ensures that the config is currently valid.
+func (cfg RawConfig) Validate() error {
+	if cfg.Host == "" {
+		return errors.NotValidf("empty Host")
+	}
+	if _, _, err := net.SplitHostPort(cfg.Host); err != nil {
+		return errors.NewNotValid(err, "bad Host")
+	}
+	if _, err := cfg.TLSConfig(); err != nil {
+		return errors.Trace(err)
+	}
+	return nil
+}
+
+// TLSConfig returns the TLS configuration used to connect to the
+// syslog host.
+func (cfg RawConfig) TLSConfig() (*tls.Config, error) {
+	if cfg.CACert == "" {
+		return nil, errors.NotValidf("empty CACert")
+	}
+	caCert, err := cert.ParseCert(cfg.CACert)
+	if err != nil {
+		return nil, errors.NewNotValid(err, "bad CACert")
+	}
+	rootCAs := x509.NewCertPool()
+	rootCAs.AddCert(caCert)
+
+	if cfg.ClientCert == "" {
+		return nil, errors.NotValidf("empty ClientCert")
+	}
+	if cfg.ClientKey == "" {
+		return nil, errors.NotValidf("empty ClientKey")
+	}
+	clientCert, err := tls.X509KeyPair([]byte(cfg.ClientCert), []byte(cfg.ClientKey))
+	if err != nil {
+		return nil, errors.NewNotValid(err, "bad ClientCert or ClientKey")
+	}
+
+	return &tls.Config{
+		RootCAs:      rootCAs,
+		Certificates: []tls.Certificate{clientCert},
+	}, nil
+}
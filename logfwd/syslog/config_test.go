@@ -0,0 +1,89 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package syslog_test
+
+import (
+	"time"
+
+	"github.com/juju/errors"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/cert"
+	"github.com/juju/juju/logfwd/syslog"
+	coretesting "github.com/juju/juju/testing"
+)
+
+type ConfigSuite struct {
+	testing.IsolationSuite
+}
+
+var _ = gc.Suite(&ConfigSuite{})
+
+func (s *ConfigSuite) validConfig(c *gc.C) syslog.RawConfig {
+	clientCert, clientKey, err := cert.NewClient(coretesting.CACert, coretesting.CAKey, time.Now().AddDate(1, 0, 0))
+	c.Assert(err, jc.ErrorIsNil)
+	return syslog.RawConfig{
+		Host:       "syslog.example.com:6514",
+		CACert:     coretesting.CACert,
+		ClientCert: clientCert,
+		ClientKey:  clientKey,
+	}
+}
+
+func (s *ConfigSuite) TestValidateValid(c *gc.C) {
+	cfg := s.validConfig(c)
+
+	err := cfg.Validate()
+
+	c.Check(err, jc.ErrorIsNil)
+}
+
+func (s *ConfigSuite) TestTLSConfig(c *gc.C) {
+	cfg := s.validConfig(c)
+
+	tlsConfig, err := cfg.TLSConfig()
+	c.Assert(err, jc.ErrorIsNil)
+
+	c.Check(tlsConfig.Certificates, gc.HasLen, 1)
+	c.Check(tlsConfig.RootCAs.Subjects(), gc.HasLen, 1)
+}
+
+func (s *ConfigSuite) TestValidateInvalid(c *gc.C) {
+	for i, test := range []struct {
+		update func(*syslog.RawConfig)
+		err    string
+	}{{
+		update: func(cfg *syslog.RawConfig) { cfg.Host = "" },
+		err:    "empty Host not valid",
+	}, {
+		update: func(cfg *syslog.RawConfig) { cfg.Host = "syslog.example.com" },
+		err:    "bad Host: .*missing port.*",
+	}, {
+		update: func(cfg *syslog.RawConfig) { cfg.CACert = "" },
+		err:    "empty CACert not valid",
+	}, {
+		update: func(cfg *syslog.RawConfig) { cfg.CACert = "not a cert" },
+		err:    "bad CACert: .*",
+	}, {
+		update: func(cfg *syslog.RawConfig) { cfg.ClientCert = "" },
+		err:    "empty ClientCert not valid",
+	}, {
+		update: func(cfg *syslog.RawConfig) { cfg.ClientKey = "" },
+		err:    "empty ClientKey not valid",
+	}, {
+		update: func(cfg *syslog.RawConfig) { cfg.ClientKey = coretesting.CAKey },
+		err:    "bad ClientCert or ClientKey: .*",
+	}} {
+		c.Logf("test %d", i)
+		cfg := s.validConfig(c)
+		test.update(&cfg)
+
+		err := cfg.Validate()
+
+		c.Check(err, jc.Satisfies, errors.IsNotValid)
+		c.Check(err, gc.ErrorMatches, test.err)
+	}
+}
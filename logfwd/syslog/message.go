@@ -0,0 +1,166 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package syslog
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/juju/loggo"
+)
+
+// Facility identifies the kind of program that produced a message.
+type Facility int
+
+// These are the facilities defined by RFC 5424 that are of interest
+// to juju.
+const (
+	FacilityUser   Facility = 1
+	FacilityDaemon Facility = 3
+)
+
+// Severity is the syslog severity of a message.
+type Severity int
+
+// These are the severities defined by RFC 5424.
+const (
+	SeverityEmergency Severity = iota
+	SeverityAlert
+	SeverityCritical
+	SeverityError
+	SeverityWarning
+	SeverityNotice
+	SeverityInformational
+	SeverityDebug
+)
+
+// SeverityForLevel returns the syslog severity that corresponds to
+// the given log level.
+func SeverityForLevel(level loggo.Level) Severity {
+	switch level {
+	case loggo.CRITICAL:
+		return SeverityCritical
+	case loggo.ERROR:
+		return SeverityError
+	case loggo.WARNING:
+		return SeverityWarning
+	case loggo.INFO:
+		return SeverityInformational
+	default:
+		return SeverityDebug
+	}
+}
+
+// EnterpriseNumber is the IANA private enterprise number under which
+// juju's structured data elements are defined.
+const EnterpriseNumber = 28978
+
+// Param is a single structured data parameter.
+type Param struct {
+	Name  string
+	Value string
+}
+
+// Element is a single structured data element.
+type Element struct {
+	// ID identifies the element. It should be of the form
+	// "name@<enterprise number>".
+	ID     string
+	Params []Param
+}
+
+// Message is a single syslog message, as described in RFC 5424.
+type Message struct {
+	Facility  Facility
+	Severity  Severity
+	Timestamp time.Time
+	Hostname  string
+	AppName   string
+	ProcID    string
+	MsgID     string
+
+	// StructuredData holds any structured data elements to send
+	// along with the message.
+	StructuredData []Element
+
+	// Msg is the free-form text of the message.
+	Msg string
+}
+
+const (
+	nilValue        = "-"
+	timestampFormat = "2006-01-02T15:04:05.000000Z07:00"
+)
+
+// String returns the RFC 5424 representation of the message.
+func (m Message) String() string {
+	var buf bytes.Buffer
+	fmt.Fprintf(&buf, "<%d>1 ", int(m.Facility)*8+int(m.Severity))
+	if m.Timestamp.IsZero() {
+		buf.WriteString(nilValue)
+	} else {
+		buf.WriteString(m.Timestamp.UTC().Format(timestampFormat))
+	}
+	buf.WriteByte(' ')
+	buf.WriteString(header(m.Hostname, 255))
+	buf.WriteByte(' ')
+	buf.WriteString(header(m.AppName, 48))
+	buf.WriteByte(' ')
+	buf.WriteString(header(m.ProcID, 128))
+	buf.WriteByte(' ')
+	buf.WriteString(header(m.MsgID, 32))
+	buf.WriteByte(' ')
+	if len(m.StructuredData) == 0 {
+		buf.WriteString(nilValue)
+	}
+	for _, element := range m.StructuredData {
+		buf.WriteByte('[')
+		buf.WriteString(sdName(element.ID))
+		for _, param := range element.Params {
+			fmt.Fprintf(&buf, ` %s="%s"`, sdName(param.Name), sdEscaper.Replace(param.Value))
+		}
+		buf.WriteByte(']')
+	}
+	if m.Msg != "" {
+		buf.WriteByte(' ')
+		buf.WriteString(m.Msg)
+	}
+	return buf.String()
+}
+
+// header returns the given header field restricted to printable
+// US-ASCII and to at most maxLen characters, or the nil value if
+// it is empty.
+func header(value string, maxLen int) string {
+	value = strings.Map(func(r rune) rune {
+		if r < 33 || r > 126 {
+			return -1
+		}
+		return r
+	}, value)
+	if value == "" {
+		return nilValue
+	}
+	if len(value) > maxLen {
+		value = value[:maxLen]
+	}
+	return value
+}
+
+// sdName returns the given structured data name with the characters
+// that are not allowed in SD-NAME removed.
+func sdName(name string) string {
+	return strings.Map(func(r rune) rune {
+		if r < 33 || r > 126 || r == '=' || r == ']' || r == '"' {
+			return -1
+		}
+		return r
+	}, name)
+}
+
+// sdEscaper escapes the characters that must be escaped in a
+// structured data parameter value.
+var sdEscaper = strings.NewReplacer(`"`, `\"`, `\`, `\\`, `]`, `\]`)
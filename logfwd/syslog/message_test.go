@@ -0,0 +1,92 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package syslog_test
+
+import (
+	"time"
+
+	"github.com/juju/loggo"
+	"github.com/juju/testing"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/logfwd/syslog"
+)
+
+type MessageSuite struct {
+	testing.IsolationSuite
+}
+
+var _ = gc.Suite(&MessageSuite{})
+
+func (s *MessageSuite) TestStringFull(c *gc.C) {
+	msg := syslog.Message{
+		Facility:  syslog.FacilityUser,
+		Severity:  syslog.SeverityError,
+		Timestamp: time.Date(2016, 6, 1, 12, 30, 15, 123456000, time.UTC),
+		Hostname:  "machine-0.deadbeef",
+		AppName:   "jujud-machine-0",
+		MsgID:     "juju",
+		StructuredData: []syslog.Element{{
+			ID: "model@28978",
+			Params: []syslog.Param{
+				{Name: "uuid", Value: "deadbeef"},
+				{Name: "module", Value: "juju.worker"},
+			},
+		}},
+		Msg: "it broke",
+	}
+
+	c.Check(msg.String(), gc.Equals, `<11>1 2016-06-01T12:30:15.123456Z machine-0.deadbeef jujud-machine-0 - juju [model@28978 uuid="deadbeef" module="juju.worker"] it broke`)
+}
+
+func (s *MessageSuite) TestStringMinimal(c *gc.C) {
+	msg := syslog.Message{
+		Facility: syslog.FacilityDaemon,
+		Severity: syslog.SeverityDebug,
+	}
+
+	c.Check(msg.String(), gc.Equals, `<31>1 - - - - - -`)
+}
+
+func (s *MessageSuite) TestStringLocalTimestamp(c *gc.C) {
+	loc := time.FixedZone("somewhere", 2*60*60)
+	msg := syslog.Message{
+		Timestamp: time.Date(2016, 6, 1, 14, 30, 15, 0, loc),
+	}
+
+	c.Check(msg.String(), gc.Equals, `<0>1 2016-06-01T12:30:15.000000Z - - - - -`)
+}
+
+func (s *MessageSuite) TestStringEscapesStructuredData(c *gc.C) {
+	msg := syslog.Message{
+		StructuredData: []syslog.Element{{
+			ID:     "origin@28978",
+			Params: []syslog.Param{{Name: "location", Value: `a"b\c]d`}},
+		}},
+	}
+
+	c.Check(msg.String(), gc.Equals, `<0>1 - - - - - [origin@28978 location="a\"b\\c\]d"]`)
+}
+
+func (s *MessageSuite) TestStringCleansHeaders(c *gc.C) {
+	msg := syslog.Message{
+		Hostname: "my host",
+		AppName:  "app\tname",
+	}
+
+	c.Check(msg.String(), gc.Equals, `<0>1 - myhost appname - - -`)
+}
+
+func (s *MessageSuite) TestSeverityForLevel(c *gc.C) {
+	for level, expected := range map[loggo.Level]syslog.Severity{
+		loggo.CRITICAL: syslog.SeverityCritical,
+		loggo.ERROR:    syslog.SeverityError,
+		loggo.WARNING:  syslog.SeverityWarning,
+		loggo.INFO:     syslog.SeverityInformational,
+		loggo.DEBUG:    syslog.SeverityDebug,
+		loggo.TRACE:    syslog.SeverityDebug,
+	} {
+		c.Check(syslog.SeverityForLevel(level), gc.Equals, expected, gc.Commentf("%v", level))
+	}
+}
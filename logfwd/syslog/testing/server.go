@@ -0,0 +1,145 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package testing
+
+import (
+	"bufio"
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"io"
+	"net"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/juju/errors"
+
+	"github.com/juju/juju/cert"
+	"github.com/juju/juju/logfwd/syslog"
+	coretesting "github.com/juju/juju/testing"
+)
+
+// Server is an in-process syslog listener that accepts TLS
+// connections and reports the octet-counted messages it receives.
+type Server struct {
+	listener net.Listener
+	messages chan string
+
+	mu    sync.Mutex
+	conns []net.Conn
+	wg    sync.WaitGroup
+}
+
+// NewServer starts a syslog listener on the loopback interface. The
+// listener's certificate is signed by the testing CA, and clients
+// must present a certificate.
+func NewServer() (*Server, error) {
+	srvCert, srvKey, err := cert.NewServer(coretesting.CACert, coretesting.CAKey, time.Now().AddDate(1, 0, 0), []string{"localhost"})
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	tlsCert, err := tls.X509KeyPair([]byte(srvCert), []byte(srvKey))
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	clientCAs := x509.NewCertPool()
+	clientCAs.AddCert(coretesting.CACertX509)
+	listener, err := tls.Listen("tcp", "localhost:0", &tls.Config{
+		Certificates: []tls.Certificate{tlsCert},
+		ClientCAs:    clientCAs,
+		ClientAuth:   tls.RequireAndVerifyClientCert,
+	})
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	srv := &Server{
+		listener: listener,
+		messages: make(chan string, 1000),
+	}
+	srv.wg.Add(1)
+	go srv.serve()
+	return srv, nil
+}
+
+// Config returns a syslog config that will connect to the server.
+func (srv *Server) Config() (syslog.RawConfig, error) {
+	clientCert, clientKey, err := cert.NewClient(coretesting.CACert, coretesting.CAKey, time.Now().AddDate(1, 0, 0))
+	if err != nil {
+		return syslog.RawConfig{}, errors.Trace(err)
+	}
+	_, port, err := net.SplitHostPort(srv.listener.Addr().String())
+	if err != nil {
+		return syslog.RawConfig{}, errors.Trace(err)
+	}
+	return syslog.RawConfig{
+		Host:       net.JoinHostPort("localhost", port),
+		CACert:     coretesting.CACert,
+		ClientCert: clientCert,
+		ClientKey:  clientKey,
+	}, nil
+}
+
+// Messages returns a channel on which each message received by the
+// server is delivered.
+func (srv *Server) Messages() <-chan string {
+	return srv.messages
+}
+
+// Close stops the server and closes all client connections.
+func (srv *Server) Close() error {
+	err := srv.listener.Close()
+	srv.mu.Lock()
+	for _, conn := range srv.conns {
+		conn.Close()
+	}
+	srv.mu.Unlock()
+	srv.wg.Wait()
+	return errors.Trace(err)
+}
+
+func (srv *Server) serve() {
+	defer srv.wg.Done()
+	for {
+		conn, err := srv.listener.Accept()
+		if err != nil {
+			return
+		}
+		srv.mu.Lock()
+		srv.conns = append(srv.conns, conn)
+		srv.mu.Unlock()
+		srv.wg.Add(1)
+		go srv.handle(conn)
+	}
+}
+
+func (srv *Server) handle(conn net.Conn) {
+	defer srv.wg.Done()
+	defer conn.Close()
+	reader := bufio.NewReader(conn)
+	for {
+		msg, err := readFrame(reader)
+		if err != nil {
+			return
+		}
+		srv.messages <- msg
+	}
+}
+
+// readFrame reads a single octet-counted message.
+func readFrame(reader *bufio.Reader) (string, error) {
+	prefix, err := reader.ReadString(' ')
+	if err != nil {
+		return "", err
+	}
+	length, err := strconv.Atoi(prefix[:len(prefix)-1])
+	if err != nil {
+		return "", fmt.Errorf("bad frame length %q", prefix)
+	}
+	buf := make([]byte, length)
+	if _, err := io.ReadFull(reader, buf); err != nil {
+		return "", err
+	}
+	return string(buf), nil
+}
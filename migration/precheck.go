@@ -0,0 +1,443 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package migration
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/juju/errors"
+	"github.com/juju/names"
+	"github.com/juju/version"
+
+	"github.com/juju/juju/cloud"
+	coremigration "github.com/juju/juju/core/migration"
+	"github.com/juju/juju/environs"
+	"github.com/juju/juju/environs/config"
+	"github.com/juju/juju/state"
+	"github.com/juju/juju/status"
+	"github.com/juju/juju/tools"
+)
+
+// PrecheckBackend defines the interface to query Juju's state
+// for migration prechecks.
+type PrecheckBackend interface {
+	AgentVersion() (version.Number, error)
+	ProviderType() (string, error)
+	NeedsCleanup() (bool, error)
+	IsUpgrading() (bool, error)
+	UnfinishedActionCount() (int, error)
+	AllMachines() ([]PrecheckMachine, error)
+	AllServices() ([]PrecheckService, error)
+}
+
+// TargetPrecheckBackend defines the interface to query the target
+// controller's state for migration prechecks.
+type TargetPrecheckBackend interface {
+	PrecheckBackend
+	AllModels() ([]PrecheckModel, error)
+
+	// CloudAttrs returns the controller's values for the config
+	// attributes which identify its cloud (see CloudAttrs).
+	CloudAttrs() (map[string]string, error)
+}
+
+// PrecheckModel describes the state interface a model as needed by
+// the migration prechecks.
+type PrecheckModel interface {
+	UUID() string
+	Name() string
+	Owner() names.UserTag
+}
+
+// PrecheckMachine describes the state interface for a machine needed
+// by migration prechecks.
+type PrecheckMachine interface {
+	Id() string
+	Life() state.Life
+	Status() (status.StatusInfo, error)
+	AgentTools() (*tools.Tools, error)
+}
+
+// PrecheckService describes the state interface for a service needed
+// by migration prechecks.
+type PrecheckService interface {
+	Name() string
+	Life() state.Life
+	AllUnits() ([]PrecheckUnit, error)
+}
+
+// PrecheckUnit describes state interface for a unit needed by
+// migration prechecks.
+type PrecheckUnit interface {
+	Name() string
+	Life() state.Life
+	AgentStatus() (status.StatusInfo, error)
+	AgentTools() (*tools.Tools, error)
+}
+
+// SourcePrecheck checks the state of the source controller and of
+// the model being migrated to make sure that the migration is
+// likely to succeed. A *coremigration.PrecheckError describing each
+// problem found is returned if any of the checks fail. Any other
+// error indicates that the checks could not be performed.
+func SourcePrecheck(backend, controllerBackend PrecheckBackend) error {
+	var reasons []string
+
+	modelReasons, err := checkModel(backend)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	reasons = append(reasons, modelReasons...)
+
+	controllerReasons, err := checkController(controllerBackend, "source")
+	if err != nil {
+		return errors.Annotate(err, "checking source controller")
+	}
+	reasons = append(reasons, controllerReasons...)
+
+	return precheckResult(reasons)
+}
+
+// TargetPrecheck checks the state of the target controller to make
+// sure that it is able to accept the model described by modelInfo.
+// A *coremigration.PrecheckError describing each problem found is
+// returned if any of the checks fail. Any other error indicates that
+// the checks could not be performed.
+func TargetPrecheck(backend TargetPrecheckBackend, modelInfo coremigration.ModelInfo) error {
+	reasons, err := checkController(backend, "target")
+	if err != nil {
+		return errors.Annotate(err, "checking target controller")
+	}
+
+	controllerVersion, err := backend.AgentVersion()
+	if err != nil {
+		return errors.Annotate(err, "retrieving target controller version")
+	}
+	if controllerVersion.Compare(modelInfo.AgentVersion) < 0 {
+		reasons = append(reasons, fmt.Sprintf(
+			"target controller version (%s) is older than model version (%s)",
+			controllerVersion, modelInfo.AgentVersion))
+	}
+
+	providerType, err := backend.ProviderType()
+	if err != nil {
+		return errors.Annotate(err, "retrieving target controller provider type")
+	}
+	provider, err := environs.Provider(modelInfo.ProviderType)
+	if err != nil {
+		reasons = append(reasons, fmt.Sprintf(
+			"target controller does not support provider type %q", modelInfo.ProviderType))
+	} else if providerType != modelInfo.ProviderType {
+		reasons = append(reasons, fmt.Sprintf(
+			"target controller cloud type (%s) does not match model cloud type (%s)",
+			providerType, modelInfo.ProviderType))
+	} else {
+		cloudAttrs, err := backend.CloudAttrs()
+		if err != nil {
+			return errors.Annotate(err, "retrieving target controller cloud")
+		}
+		reasons = append(reasons, checkCloudAttrs(cloudAttrs, modelInfo.CloudAttrs)...)
+		if !hasCredentials(provider.CredentialSchemas(), modelInfo.CredentialAttrs) {
+			reasons = append(reasons, fmt.Sprintf(
+				"model has no complete credentials for provider type %q", modelInfo.ProviderType))
+		}
+	}
+
+	models, err := backend.AllModels()
+	if err != nil {
+		return errors.Annotate(err, "retrieving models")
+	}
+	for _, model := range models {
+		if model.UUID() == modelInfo.UUID {
+			reasons = append(reasons, fmt.Sprintf(
+				"model with UUID %s already exists on target controller", modelInfo.UUID))
+		} else if model.Owner().Canonical() == modelInfo.Owner.Canonical() && model.Name() == modelInfo.Name {
+			reasons = append(reasons, fmt.Sprintf(
+				"model named %q already exists for %s on target controller",
+				modelInfo.Name, modelInfo.Owner.Canonical()))
+		}
+	}
+
+	return precheckResult(reasons)
+}
+
+// CloudAttrs returns the values of the config attributes which the
+// model's provider requires hosted models to share with their
+// controller, such as the region. A controller can only manage models
+// with the same values, so these identify the cloud the model is in.
+func CloudAttrs(cfg *config.Config) (map[string]string, error) {
+	provider, err := environs.Provider(cfg.Type())
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	allAttrs := cfg.AllAttrs()
+	attrs := make(map[string]string)
+	for _, name := range provider.RestrictedConfigAttributes() {
+		if value, ok := allAttrs[name]; ok {
+			attrs[name] = fmt.Sprint(value)
+		}
+	}
+	return attrs, nil
+}
+
+// CredentialAttrs returns the sorted names of the credential
+// attributes, as defined by the provider's credential schemas, which
+// are set in the model config.
+func CredentialAttrs(cfg *config.Config) ([]string, error) {
+	provider, err := environs.Provider(cfg.Type())
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	allAttrs := cfg.AllAttrs()
+	seen := make(map[string]bool)
+	var attrNames []string
+	for _, schema := range provider.CredentialSchemas() {
+		for _, attr := range schema {
+			if seen[attr.Name] {
+				continue
+			}
+			seen[attr.Name] = true
+			if value, ok := allAttrs[attr.Name]; ok && value != "" {
+				attrNames = append(attrNames, attr.Name)
+			}
+		}
+	}
+	sort.Strings(attrNames)
+	return attrNames, nil
+}
+
+// checkCloudAttrs returns a description of each cloud attribute of
+// the model which differs from that of the target controller.
+func checkCloudAttrs(controllerAttrs, modelAttrs map[string]string) []string {
+	seen := make(map[string]bool)
+	for name := range controllerAttrs {
+		seen[name] = true
+	}
+	for name := range modelAttrs {
+		seen[name] = true
+	}
+	sorted := make([]string, 0, len(seen))
+	for name := range seen {
+		sorted = append(sorted, name)
+	}
+	sort.Strings(sorted)
+
+	var reasons []string
+	for _, name := range sorted {
+		if controllerAttrs[name] != modelAttrs[name] {
+			reasons = append(reasons, fmt.Sprintf(
+				"target controller cloud %s (%q) does not match model cloud %s (%q)",
+				name, controllerAttrs[name], name, modelAttrs[name]))
+		}
+	}
+	return reasons
+}
+
+// hasCredentials reports whether the named attributes include every
+// required attribute of one of the credential schemas.
+func hasCredentials(schemas map[cloud.AuthType]cloud.CredentialSchema, attrs []string) bool {
+	if len(schemas) == 0 {
+		return true
+	}
+	set := make(map[string]bool)
+	for _, name := range attrs {
+		set[name] = true
+	}
+	for _, schema := range schemas {
+		complete := true
+		for _, attr := range schema {
+			if !attr.Optional && !set[attr.Name] {
+				complete = false
+				break
+			}
+		}
+		if complete {
+			return true
+		}
+	}
+	return false
+}
+
+// checkModel returns a description of each problem with the model
+// being migrated that would prevent a migration.
+func checkModel(backend PrecheckBackend) ([]string, error) {
+	reasons, err := checkCommon(backend, "model")
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+
+	modelVersion, err := backend.AgentVersion()
+	if err != nil {
+		return nil, errors.Annotate(err, "retrieving model version")
+	}
+
+	machineReasons, err := checkMachines(backend, modelVersion)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	reasons = append(reasons, machineReasons...)
+
+	unitReasons, err := checkUnits(backend, modelVersion)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	reasons = append(reasons, unitReasons...)
+
+	actionCount, err := backend.UnfinishedActionCount()
+	if err != nil {
+		return nil, errors.Annotate(err, "checking actions")
+	}
+	if actionCount > 0 {
+		reasons = append(reasons, fmt.Sprintf("model has %d pending or running action(s)", actionCount))
+	}
+
+	return reasons, nil
+}
+
+// checkController returns a description of each problem with a
+// controller that would prevent a migration.
+func checkController(backend PrecheckBackend, label string) ([]string, error) {
+	reasons, err := checkCommon(backend, label+" controller")
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+
+	controllerVersion, err := backend.AgentVersion()
+	if err != nil {
+		return nil, errors.Annotate(err, "retrieving controller version")
+	}
+	machineReasons, err := checkMachines(backend, controllerVersion)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	for _, reason := range machineReasons {
+		reasons = append(reasons, label+" controller "+reason)
+	}
+	return reasons, nil
+}
+
+func checkCommon(backend PrecheckBackend, label string) ([]string, error) {
+	var reasons []string
+
+	upgrading, err := backend.IsUpgrading()
+	if err != nil {
+		return nil, errors.Annotate(err, "checking for upgrades")
+	}
+	if upgrading {
+		reasons = append(reasons, label+" is being upgraded")
+	}
+
+	needsCleanup, err := backend.NeedsCleanup()
+	if err != nil {
+		return nil, errors.Annotate(err, "checking cleanups")
+	}
+	if needsCleanup {
+		reasons = append(reasons, label+" has pending cleanups")
+	}
+	return reasons, nil
+}
+
+func checkMachines(backend PrecheckBackend, expectedVersion version.Number) ([]string, error) {
+	machines, err := backend.AllMachines()
+	if err != nil {
+		return nil, errors.Annotate(err, "retrieving machines")
+	}
+
+	var reasons []string
+	for _, machine := range machines {
+		if machine.Life() != state.Alive {
+			reasons = append(reasons, fmt.Sprintf("machine %s is %s", machine.Id(), machine.Life()))
+			continue
+		}
+
+		statusInfo, err := machine.Status()
+		if err != nil {
+			return nil, errors.Annotatef(err, "retrieving machine %s status", machine.Id())
+		}
+		if statusInfo.Status != status.StatusStarted {
+			reasons = append(reasons, fmt.Sprintf("machine %s not running (%s)", machine.Id(), statusInfo.Status))
+			continue
+		}
+
+		reason, err := checkAgentTools(machine, "machine "+machine.Id(), expectedVersion)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		if reason != "" {
+			reasons = append(reasons, reason)
+		}
+	}
+	return reasons, nil
+}
+
+func checkUnits(backend PrecheckBackend, expectedVersion version.Number) ([]string, error) {
+	services, err := backend.AllServices()
+	if err != nil {
+		return nil, errors.Annotate(err, "retrieving services")
+	}
+
+	var reasons []string
+	for _, service := range services {
+		if service.Life() != state.Alive {
+			reasons = append(reasons, fmt.Sprintf("service %s is %s", service.Name(), service.Life()))
+			continue
+		}
+
+		units, err := service.AllUnits()
+		if err != nil {
+			return nil, errors.Annotatef(err, "retrieving units for %s", service.Name())
+		}
+		for _, unit := range units {
+			if unit.Life() != state.Alive {
+				reasons = append(reasons, fmt.Sprintf("unit %s is %s", unit.Name(), unit.Life()))
+				continue
+			}
+
+			statusInfo, err := unit.AgentStatus()
+			if err != nil {
+				return nil, errors.Annotatef(err, "retrieving unit %s agent status", unit.Name())
+			}
+			switch statusInfo.Status {
+			case status.StatusError, status.StatusLost, status.StatusFailed:
+				reasons = append(reasons, fmt.Sprintf("unit %s agent is in %s state", unit.Name(), statusInfo.Status))
+				continue
+			}
+
+			reason, err := checkAgentTools(unit, "unit "+unit.Name(), expectedVersion)
+			if err != nil {
+				return nil, errors.Trace(err)
+			}
+			if reason != "" {
+				reasons = append(reasons, reason)
+			}
+		}
+	}
+	return reasons, nil
+}
+
+type agentToolsGetter interface {
+	AgentTools() (*tools.Tools, error)
+}
+
+func checkAgentTools(agent agentToolsGetter, label string, expectedVersion version.Number) (string, error) {
+	agentTools, err := agent.AgentTools()
+	if errors.IsNotFound(err) {
+		return fmt.Sprintf("%s agent has not reported its version", label), nil
+	} else if err != nil {
+		return "", errors.Annotatef(err, "retrieving agent tools for %s", label)
+	}
+	if agentTools.Version.Number != expectedVersion {
+		return fmt.Sprintf("%s agent version (%s) doesn't match configured agent version (%s)",
+			label, agentTools.Version.Number, expectedVersion), nil
+	}
+	return "", nil
+}
+
+func precheckResult(reasons []string) error {
+	if len(reasons) > 0 {
+		return &coremigration.PrecheckError{Reasons: reasons}
+	}
+	return nil
+}
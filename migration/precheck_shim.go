@@ -0,0 +1,111 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package migration
+
+import (
+	"github.com/juju/errors"
+	"github.com/juju/version"
+
+	"github.com/juju/juju/state"
+)
+
+// PrecheckShim wraps a *state.State to implement PrecheckBackend.
+func PrecheckShim(st *state.State) TargetPrecheckBackend {
+	return &precheckShim{st}
+}
+
+// precheckShim is required to allow the use of *state.State with the
+// migration prechecks, as some of the State methods return concrete
+// types which don't match the interfaces the prechecks need.
+type precheckShim struct {
+	*state.State
+}
+
+// AgentVersion implements PrecheckBackend.
+func (s *precheckShim) AgentVersion() (version.Number, error) {
+	cfg, err := s.State.ModelConfig()
+	if err != nil {
+		return version.Zero, errors.Trace(err)
+	}
+	vers, ok := cfg.AgentVersion()
+	if !ok {
+		return version.Zero, errors.New("no model agent version")
+	}
+	return vers, nil
+}
+
+// ProviderType implements PrecheckBackend.
+func (s *precheckShim) ProviderType() (string, error) {
+	cfg, err := s.State.ModelConfig()
+	if err != nil {
+		return "", errors.Trace(err)
+	}
+	return cfg.Type(), nil
+}
+
+// CloudAttrs implements TargetPrecheckBackend.
+func (s *precheckShim) CloudAttrs() (map[string]string, error) {
+	cfg, err := s.State.ModelConfig()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return CloudAttrs(cfg)
+}
+
+// AllMachines implements PrecheckBackend.
+func (s *precheckShim) AllMachines() ([]PrecheckMachine, error) {
+	machines, err := s.State.AllMachines()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	out := make([]PrecheckMachine, 0, len(machines))
+	for _, machine := range machines {
+		out = append(out, machine)
+	}
+	return out, nil
+}
+
+// AllServices implements PrecheckBackend.
+func (s *precheckShim) AllServices() ([]PrecheckService, error) {
+	services, err := s.State.AllServices()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	out := make([]PrecheckService, 0, len(services))
+	for _, service := range services {
+		out = append(out, &precheckServiceShim{service})
+	}
+	return out, nil
+}
+
+// AllModels implements TargetPrecheckBackend.
+func (s *precheckShim) AllModels() ([]PrecheckModel, error) {
+	models, err := s.State.AllModels()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	out := make([]PrecheckModel, 0, len(models))
+	for _, model := range models {
+		out = append(out, model)
+	}
+	return out, nil
+}
+
+// precheckServiceShim implements PrecheckService.
+type precheckServiceShim struct {
+	*state.Service
+}
+
+// AllUnits implements PrecheckService.
+func (s *precheckServiceShim) AllUnits() ([]PrecheckUnit, error) {
+	units, err := s.Service.AllUnits()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	out := make([]PrecheckUnit, 0, len(units))
+	for _, unit := range units {
+		out = append(out, unit)
+	}
+	return out, nil
+}
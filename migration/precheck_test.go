@@ -0,0 +1,457 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package migration_test
+
+import (
+	"github.com/juju/errors"
+	"github.com/juju/names"
+	jc "github.com/juju/testing/checkers"
+	"github.com/juju/version"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/cloud"
+	coremigration "github.com/juju/juju/core/migration"
+	"github.com/juju/juju/environs"
+	"github.com/juju/juju/migration"
+	_ "github.com/juju/juju/provider/dummy"
+	"github.com/juju/juju/state"
+	"github.com/juju/juju/status"
+	"github.com/juju/juju/testing"
+	"github.com/juju/juju/tools"
+)
+
+var backendVersion = version.MustParse("1.2.3")
+
+func init() {
+	environs.RegisterProvider("precheck-userpass", userpassProvider{})
+}
+
+// userpassProvider is a provider whose credentials require a
+// username and password.
+type userpassProvider struct {
+	environs.EnvironProvider
+}
+
+func (userpassProvider) CredentialSchemas() map[cloud.AuthType]cloud.CredentialSchema {
+	return map[cloud.AuthType]cloud.CredentialSchema{
+		cloud.UserPassAuthType: {
+			{"username", cloud.CredentialAttr{}},
+			{"password", cloud.CredentialAttr{Hidden: true}},
+			{"domain", cloud.CredentialAttr{Optional: true}},
+		},
+	}
+}
+
+type SourcePrecheckSuite struct {
+	testing.BaseSuite
+}
+
+var _ = gc.Suite(&SourcePrecheckSuite{})
+
+func (*SourcePrecheckSuite) TestSuccess(c *gc.C) {
+	backend := newHappyBackend()
+	err := migration.SourcePrecheck(backend, newHappyBackend())
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (*SourcePrecheckSuite) TestUpgrading(c *gc.C) {
+	backend := newHappyBackend()
+	backend.upgrading = true
+	err := migration.SourcePrecheck(backend, newHappyBackend())
+	assertReasons(c, err, "model is being upgraded")
+}
+
+func (*SourcePrecheckSuite) TestIsUpgradingError(c *gc.C) {
+	backend := newHappyBackend()
+	backend.upgradingErr = errors.New("boom")
+	err := migration.SourcePrecheck(backend, newHappyBackend())
+	c.Assert(err, gc.ErrorMatches, "checking for upgrades: boom")
+	c.Assert(coremigration.IsPrecheckError(err), jc.IsFalse)
+}
+
+func (*SourcePrecheckSuite) TestCleanupsPending(c *gc.C) {
+	backend := newHappyBackend()
+	backend.needsCleanup = true
+	err := migration.SourcePrecheck(backend, newHappyBackend())
+	assertReasons(c, err, "model has pending cleanups")
+}
+
+func (*SourcePrecheckSuite) TestActionsPending(c *gc.C) {
+	backend := newHappyBackend()
+	backend.actionCount = 3
+	err := migration.SourcePrecheck(backend, newHappyBackend())
+	assertReasons(c, err, `model has 3 pending or running action\(s\)`)
+}
+
+func (*SourcePrecheckSuite) TestDyingMachine(c *gc.C) {
+	backend := newHappyBackend()
+	backend.machines[0].life = state.Dying
+	err := migration.SourcePrecheck(backend, newHappyBackend())
+	assertReasons(c, err, "machine 0 is dying")
+}
+
+func (*SourcePrecheckSuite) TestMachineInError(c *gc.C) {
+	backend := newHappyBackend()
+	backend.machines[0].status = status.StatusError
+	err := migration.SourcePrecheck(backend, newHappyBackend())
+	assertReasons(c, err, `machine 0 not running \(error\)`)
+}
+
+func (*SourcePrecheckSuite) TestMachineVersionMismatch(c *gc.C) {
+	backend := newHappyBackend()
+	backend.machines[0].version = version.MustParse("1.2.4")
+	err := migration.SourcePrecheck(backend, newHappyBackend())
+	assertReasons(c, err,
+		`machine 0 agent version \(1.2.4\) doesn't match configured agent version \(1.2.3\)`)
+}
+
+func (*SourcePrecheckSuite) TestMachineNoTools(c *gc.C) {
+	backend := newHappyBackend()
+	backend.machines[0].toolsErr = errors.NotFoundf("tools")
+	err := migration.SourcePrecheck(backend, newHappyBackend())
+	assertReasons(c, err, "machine 0 agent has not reported its version")
+}
+
+func (*SourcePrecheckSuite) TestDyingService(c *gc.C) {
+	backend := newHappyBackend()
+	backend.services[0].life = state.Dying
+	err := migration.SourcePrecheck(backend, newHappyBackend())
+	assertReasons(c, err, "service foo is dying")
+}
+
+func (*SourcePrecheckSuite) TestUnitInError(c *gc.C) {
+	backend := newHappyBackend()
+	backend.services[0].units[0].status = status.StatusError
+	err := migration.SourcePrecheck(backend, newHappyBackend())
+	assertReasons(c, err, "unit foo/0 agent is in error state")
+}
+
+func (*SourcePrecheckSuite) TestDeadUnit(c *gc.C) {
+	backend := newHappyBackend()
+	backend.services[0].units[0].life = state.Dead
+	err := migration.SourcePrecheck(backend, newHappyBackend())
+	assertReasons(c, err, "unit foo/0 is dead")
+}
+
+func (*SourcePrecheckSuite) TestControllerProblems(c *gc.C) {
+	controller := newHappyBackend()
+	controller.upgrading = true
+	controller.machines[0].life = state.Dying
+	err := migration.SourcePrecheck(newHappyBackend(), controller)
+	assertReasons(c, err,
+		"source controller is being upgraded",
+		"source controller machine 0 is dying",
+	)
+}
+
+func (*SourcePrecheckSuite) TestMultipleReasons(c *gc.C) {
+	backend := newHappyBackend()
+	backend.needsCleanup = true
+	backend.machines[0].life = state.Dying
+	err := migration.SourcePrecheck(backend, newHappyBackend())
+	assertReasons(c, err,
+		"model has pending cleanups",
+		"machine 0 is dying",
+	)
+}
+
+type TargetPrecheckSuite struct {
+	testing.BaseSuite
+	modelInfo coremigration.ModelInfo
+}
+
+var _ = gc.Suite(&TargetPrecheckSuite{})
+
+func (s *TargetPrecheckSuite) SetUpTest(c *gc.C) {
+	s.BaseSuite.SetUpTest(c)
+	s.modelInfo = coremigration.ModelInfo{
+		UUID:         "model-uuid",
+		Owner:        names.NewUserTag("owner"),
+		Name:         "model-name",
+		AgentVersion: backendVersion,
+		ProviderType: "dummy",
+	}
+}
+
+func (s *TargetPrecheckSuite) TestSuccess(c *gc.C) {
+	err := migration.TargetPrecheck(newHappyBackend(), s.modelInfo)
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *TargetPrecheckSuite) TestModelVersionAheadOfTarget(c *gc.C) {
+	s.modelInfo.AgentVersion = version.MustParse("1.2.4")
+	err := migration.TargetPrecheck(newHappyBackend(), s.modelInfo)
+	assertReasons(c, err,
+		`target controller version \(1.2.3\) is older than model version \(1.2.4\)`)
+}
+
+func (s *TargetPrecheckSuite) TestUnknownProviderType(c *gc.C) {
+	s.modelInfo.ProviderType = "nope"
+	err := migration.TargetPrecheck(newHappyBackend(), s.modelInfo)
+	assertReasons(c, err, `target controller does not support provider type "nope"`)
+}
+
+func (s *TargetPrecheckSuite) TestProviderTypeMismatch(c *gc.C) {
+	backend := newHappyBackend()
+	backend.providerType = "other"
+	err := migration.TargetPrecheck(backend, s.modelInfo)
+	assertReasons(c, err,
+		`target controller cloud type \(other\) does not match model cloud type \(dummy\)`)
+}
+
+func (s *TargetPrecheckSuite) TestModelNameTaken(c *gc.C) {
+	backend := newHappyBackend()
+	backend.models = append(backend.models, &fakeModel{
+		uuid:  "other-uuid",
+		name:  "model-name",
+		owner: names.NewUserTag("owner"),
+	})
+	err := migration.TargetPrecheck(backend, s.modelInfo)
+	assertReasons(c, err,
+		`model named "model-name" already exists for owner@local on target controller`)
+}
+
+func (s *TargetPrecheckSuite) TestModelNameTakenComparesCanonicalOwner(c *gc.C) {
+	backend := newHappyBackend()
+	backend.models = append(backend.models, &fakeModel{
+		uuid:  "other-uuid",
+		name:  "model-name",
+		owner: names.NewUserTag("owner@local"),
+	})
+	err := migration.TargetPrecheck(backend, s.modelInfo)
+	assertReasons(c, err,
+		`model named "model-name" already exists for owner@local on target controller`)
+}
+
+func (s *TargetPrecheckSuite) TestModelNameTakenByOtherOwner(c *gc.C) {
+	backend := newHappyBackend()
+	backend.models = append(backend.models, &fakeModel{
+		uuid:  "other-uuid",
+		name:  "model-name",
+		owner: names.NewUserTag("someone-else"),
+	})
+	err := migration.TargetPrecheck(backend, s.modelInfo)
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *TargetPrecheckSuite) TestModelUUIDExists(c *gc.C) {
+	backend := newHappyBackend()
+	backend.models = append(backend.models, &fakeModel{
+		uuid:  "model-uuid",
+		name:  "different",
+		owner: names.NewUserTag("owner"),
+	})
+	err := migration.TargetPrecheck(backend, s.modelInfo)
+	assertReasons(c, err, "model with UUID model-uuid already exists on target controller")
+}
+
+func (s *TargetPrecheckSuite) TestCloudMismatch(c *gc.C) {
+	backend := newHappyBackend()
+	backend.cloudAttrs = map[string]string{"region": "east", "vpc-id": "vpc-1"}
+	s.modelInfo.CloudAttrs = map[string]string{"region": "west", "vpc-id": "vpc-1"}
+	err := migration.TargetPrecheck(backend, s.modelInfo)
+	assertReasons(c, err,
+		`target controller cloud region \("east"\) does not match model cloud region \("west"\)`)
+}
+
+func (s *TargetPrecheckSuite) TestCredentials(c *gc.C) {
+	backend := newHappyBackend()
+	backend.providerType = "precheck-userpass"
+	s.modelInfo.ProviderType = "precheck-userpass"
+	s.modelInfo.CredentialAttrs = []string{"password", "username"}
+	err := migration.TargetPrecheck(backend, s.modelInfo)
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *TargetPrecheckSuite) TestIncompleteCredentials(c *gc.C) {
+	backend := newHappyBackend()
+	backend.providerType = "precheck-userpass"
+	s.modelInfo.ProviderType = "precheck-userpass"
+	s.modelInfo.CredentialAttrs = []string{"domain", "username"}
+	err := migration.TargetPrecheck(backend, s.modelInfo)
+	assertReasons(c, err,
+		`model has no complete credentials for provider type "precheck-userpass"`)
+}
+
+func (s *TargetPrecheckSuite) TestControllerMachineInError(c *gc.C) {
+	backend := newHappyBackend()
+	backend.machines[0].status = status.StatusError
+	err := migration.TargetPrecheck(backend, s.modelInfo)
+	assertReasons(c, err, `target controller machine 0 not running \(error\)`)
+}
+
+func assertReasons(c *gc.C, err error, expected ...string) {
+	c.Assert(err, gc.NotNil)
+	precheckErr, ok := err.(*coremigration.PrecheckError)
+	c.Assert(ok, jc.IsTrue, gc.Commentf("unexpected error: %v", err))
+	c.Assert(precheckErr.Reasons, gc.HasLen, len(expected))
+	for i, reason := range precheckErr.Reasons {
+		c.Check(reason, gc.Matches, expected[i])
+	}
+}
+
+func newHappyBackend() *fakeBackend {
+	return &fakeBackend{
+		providerType: "dummy",
+		machines: []*fakeMachine{{
+			id:      "0",
+			life:    state.Alive,
+			status:  status.StatusStarted,
+			version: backendVersion,
+		}},
+		services: []*fakeService{{
+			name: "foo",
+			life: state.Alive,
+			units: []*fakeUnit{{
+				name:    "foo/0",
+				life:    state.Alive,
+				status:  status.StatusIdle,
+				version: backendVersion,
+			}},
+		}},
+		models: []migration.PrecheckModel{&fakeModel{
+			uuid:  "controller-uuid",
+			name:  "admin",
+			owner: names.NewUserTag("admin"),
+		}},
+	}
+}
+
+type fakeBackend struct {
+	providerType string
+	upgrading    bool
+	upgradingErr error
+	needsCleanup bool
+	actionCount  int
+	machines     []*fakeMachine
+	services     []*fakeService
+	models       []migration.PrecheckModel
+	cloudAttrs   map[string]string
+}
+
+func (b *fakeBackend) AgentVersion() (version.Number, error) {
+	return backendVersion, nil
+}
+
+func (b *fakeBackend) ProviderType() (string, error) {
+	return b.providerType, nil
+}
+
+func (b *fakeBackend) NeedsCleanup() (bool, error) {
+	return b.needsCleanup, nil
+}
+
+func (b *fakeBackend) IsUpgrading() (bool, error) {
+	return b.upgrading, b.upgradingErr
+}
+
+func (b *fakeBackend) UnfinishedActionCount() (int, error) {
+	return b.actionCount, nil
+}
+
+func (b *fakeBackend) AllMachines() ([]migration.PrecheckMachine, error) {
+	out := make([]migration.PrecheckMachine, len(b.machines))
+	for i, machine := range b.machines {
+		out[i] = machine
+	}
+	return out, nil
+}
+
+func (b *fakeBackend) AllServices() ([]migration.PrecheckService, error) {
+	out := make([]migration.PrecheckService, len(b.services))
+	for i, service := range b.services {
+		out[i] = service
+	}
+	return out, nil
+}
+
+func (b *fakeBackend) AllModels() ([]migration.PrecheckModel, error) {
+	return b.models, nil
+}
+
+func (b *fakeBackend) CloudAttrs() (map[string]string, error) {
+	return b.cloudAttrs, nil
+}
+
+type fakeModel struct {
+	uuid  string
+	name  string
+	owner names.UserTag
+}
+
+func (m *fakeModel) UUID() string         { return m.uuid }
+func (m *fakeModel) Name() string         { return m.name }
+func (m *fakeModel) Owner() names.UserTag { return m.owner }
+
+type fakeMachine struct {
+	id       string
+	life     state.Life
+	status   status.Status
+	version  version.Number
+	toolsErr error
+}
+
+func (m *fakeMachine) Id() string {
+	return m.id
+}
+
+func (m *fakeMachine) Life() state.Life {
+	return m.life
+}
+
+func (m *fakeMachine) Status() (status.StatusInfo, error) {
+	return status.StatusInfo{Status: m.status}, nil
+}
+
+func (m *fakeMachine) AgentTools() (*tools.Tools, error) {
+	if m.toolsErr != nil {
+		return nil, m.toolsErr
+	}
+	return &tools.Tools{Version: version.Binary{Number: m.version}}, nil
+}
+
+type fakeService struct {
+	name  string
+	life  state.Life
+	units []*fakeUnit
+}
+
+func (s *fakeService) Name() string {
+	return s.name
+}
+
+func (s *fakeService) Life() state.Life {
+	return s.life
+}
+
+func (s *fakeService) AllUnits() ([]migration.PrecheckUnit, error) {
+	out := make([]migration.PrecheckUnit, len(s.units))
+	for i, unit := range s.units {
+		out[i] = unit
+	}
+	return out, nil
+}
+
+type fakeUnit struct {
+	name    string
+	life    state.Life
+	status  status.Status
+	version version.Number
+}
+
+func (u *fakeUnit) Name() string {
+	return u.name
+}
+
+func (u *fakeUnit) Life() state.Life {
+	return u.life
+}
+
+func (u *fakeUnit) AgentStatus() (status.StatusInfo, error) {
+	return status.StatusInfo{Status: u.status}, nil
+}
+
+func (u *fakeUnit) AgentTools() (*tools.Tools, error) {
+	return &tools.Tools{Version: version.Binary{Number: u.version}}, nil
+}
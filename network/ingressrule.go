@@ -0,0 +1,114 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package network
+
+import (
+	"fmt"
+	"net"
+	"sort"
+
+	"github.com/juju/errors"
+)
+
+// AnySourceCIDR is the source CIDR of an ingress rule that allows
+// access from anywhere.
+const AnySourceCIDR = "0.0.0.0/0"
+
+// IngressRule represents a port range that is open to traffic coming
+// from the given source CIDR.
+type IngressRule struct {
+	PortRange  PortRange
+	SourceCIDR string
+}
+
+// NewIngressRules returns an ingress rule for each combination of
+// the given port range and source CIDRs. If no source CIDRs are given,
+// the port range is opened to AnySourceCIDR.
+func NewIngressRules(portRange PortRange, sourceCIDRs ...string) []IngressRule {
+	if len(sourceCIDRs) == 0 {
+		sourceCIDRs = []string{AnySourceCIDR}
+	}
+	rules := make([]IngressRule, len(sourceCIDRs))
+	for i, cidr := range sourceCIDRs {
+		rules[i] = IngressRule{PortRange: portRange, SourceCIDR: cidr}
+	}
+	return rules
+}
+
+// UnrestrictedIngressRules returns ingress rules that open the given
+// port ranges to traffic from anywhere.
+func UnrestrictedIngressRules(ports []PortRange) []IngressRule {
+	rules := make([]IngressRule, len(ports))
+	for i, portRange := range ports {
+		rules[i] = IngressRule{PortRange: portRange, SourceCIDR: AnySourceCIDR}
+	}
+	return rules
+}
+
+// Validate determines if the ingress rule is valid.
+func (r IngressRule) Validate() error {
+	if err := r.PortRange.Validate(); err != nil {
+		return errors.Trace(err)
+	}
+	return ValidateSourceCIDR(r.SourceCIDR)
+}
+
+// IsRestricted reports whether the rule allows access from only part
+// of the network, rather than from anywhere.
+func (r IngressRule) IsRestricted() bool {
+	return r.SourceCIDR != AnySourceCIDR
+}
+
+func (r IngressRule) String() string {
+	return fmt.Sprintf("%s from %s", r.PortRange, r.SourceCIDR)
+}
+
+func (r IngressRule) GoString() string {
+	return r.String()
+}
+
+// ValidateSourceCIDR returns an error if the given source CIDR is not
+// a valid CIDR in canonical form (e.g. "10.0.0.0/8", not "10.1.2.3/8").
+func ValidateSourceCIDR(cidr string) error {
+	_, ipNet, err := net.ParseCIDR(cidr)
+	if err != nil {
+		return errors.NotValidf("source CIDR %q", cidr)
+	}
+	if ipNet.String() != cidr {
+		return errors.NotValidf("source CIDR %q (did you mean %q?)", cidr, ipNet.String())
+	}
+	return nil
+}
+
+// PortRanges returns the port ranges of the given ingress rules,
+// returning an error if any of the rules is restricted to a source
+// CIDR. It is used with providers that cannot restrict the source of
+// the traffic for an open port.
+func PortRanges(rules []IngressRule) ([]PortRange, error) {
+	ports := make([]PortRange, len(rules))
+	for i, rule := range rules {
+		if rule.IsRestricted() {
+			return nil, errors.NotSupportedf("restricting %v to source CIDR %s", rule.PortRange, rule.SourceCIDR)
+		}
+		ports[i] = rule.PortRange
+	}
+	return ports, nil
+}
+
+type ingressRuleSlice []IngressRule
+
+func (r ingressRuleSlice) Len() int      { return len(r) }
+func (r ingressRuleSlice) Swap(i, j int) { r[i], r[j] = r[j], r[i] }
+func (r ingressRuleSlice) Less(i, j int) bool {
+	if r[i].PortRange != r[j].PortRange {
+		return portRangeSlice{r[i].PortRange, r[j].PortRange}.Less(0, 1)
+	}
+	return r[i].SourceCIDR < r[j].SourceCIDR
+}
+
+// SortIngressRules sorts the given rules by port range, as
+// SortPortRanges does, then by source CIDR.
+func SortIngressRules(rules []IngressRule) {
+	sort.Sort(ingressRuleSlice(rules))
+}
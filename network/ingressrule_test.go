@@ -0,0 +1,116 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package network_test
+
+import (
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/network"
+	"github.com/juju/juju/testing"
+)
+
+type IngressRuleSuite struct {
+	testing.BaseSuite
+}
+
+var _ = gc.Suite(&IngressRuleSuite{})
+
+func (*IngressRuleSuite) TestNewIngressRules(c *gc.C) {
+	portRange := network.MustParsePortRange("80/tcp")
+	c.Assert(network.NewIngressRules(portRange), jc.DeepEquals, []network.IngressRule{
+		{PortRange: portRange, SourceCIDR: "0.0.0.0/0"},
+	})
+	c.Assert(network.NewIngressRules(portRange, "10.0.0.0/8", "192.168.1.0/24"), jc.DeepEquals, []network.IngressRule{
+		{PortRange: portRange, SourceCIDR: "10.0.0.0/8"},
+		{PortRange: portRange, SourceCIDR: "192.168.1.0/24"},
+	})
+}
+
+func (*IngressRuleSuite) TestUnrestrictedIngressRules(c *gc.C) {
+	rules := network.UnrestrictedIngressRules([]network.PortRange{
+		network.MustParsePortRange("80/tcp"),
+		network.MustParsePortRange("53/udp"),
+	})
+	c.Assert(rules, jc.DeepEquals, []network.IngressRule{
+		{network.MustParsePortRange("80/tcp"), "0.0.0.0/0"},
+		{network.MustParsePortRange("53/udp"), "0.0.0.0/0"},
+	})
+}
+
+func (*IngressRuleSuite) TestString(c *gc.C) {
+	rule := network.IngressRule{
+		PortRange:  network.MustParsePortRange("8000-8080/tcp"),
+		SourceCIDR: "10.0.0.0/8",
+	}
+	c.Assert(rule.String(), gc.Equals, "8000-8080/tcp from 10.0.0.0/8")
+}
+
+func (*IngressRuleSuite) TestIsRestricted(c *gc.C) {
+	portRange := network.MustParsePortRange("80/tcp")
+	c.Assert(network.IngressRule{portRange, "0.0.0.0/0"}.IsRestricted(), jc.IsFalse)
+	c.Assert(network.IngressRule{portRange, "10.0.0.0/8"}.IsRestricted(), jc.IsTrue)
+}
+
+func (*IngressRuleSuite) TestValidate(c *gc.C) {
+	portRange := network.MustParsePortRange("80/tcp")
+	for i, test := range []struct {
+		rule network.IngressRule
+		err  string
+	}{{
+		rule: network.IngressRule{portRange, "0.0.0.0/0"},
+	}, {
+		rule: network.IngressRule{portRange, "2001:db8::/32"},
+	}, {
+		rule: network.IngressRule{network.PortRange{80, 70, "tcp"}, "0.0.0.0/0"},
+		err:  "invalid port range 80-70/tcp",
+	}, {
+		rule: network.IngressRule{portRange, "10.0.0.1"},
+		err:  `source CIDR "10.0.0.1" not valid`,
+	}, {
+		rule: network.IngressRule{portRange, "10.1.2.3/8"},
+		err:  `source CIDR "10.1.2.3/8" \(did you mean "10.0.0.0/8"\?\) not valid`,
+	}} {
+		c.Logf("test %d: %v", i, test.rule)
+		err := test.rule.Validate()
+		if test.err == "" {
+			c.Check(err, jc.ErrorIsNil)
+		} else {
+			c.Check(err, gc.ErrorMatches, test.err)
+		}
+	}
+}
+
+func (*IngressRuleSuite) TestPortRanges(c *gc.C) {
+	ports, err := network.PortRanges([]network.IngressRule{
+		{network.MustParsePortRange("80/tcp"), "0.0.0.0/0"},
+		{network.MustParsePortRange("53/udp"), "0.0.0.0/0"},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(ports, jc.DeepEquals, []network.PortRange{
+		network.MustParsePortRange("80/tcp"),
+		network.MustParsePortRange("53/udp"),
+	})
+
+	_, err = network.PortRanges([]network.IngressRule{
+		{network.MustParsePortRange("80/tcp"), "10.0.0.0/8"},
+	})
+	c.Assert(err, gc.ErrorMatches, "restricting 80/tcp to source CIDR 10.0.0.0/8 not supported")
+}
+
+func (*IngressRuleSuite) TestSortIngressRules(c *gc.C) {
+	rules := []network.IngressRule{
+		{network.MustParsePortRange("80/tcp"), "192.168.0.0/16"},
+		{network.MustParsePortRange("53/udp"), "0.0.0.0/0"},
+		{network.MustParsePortRange("80/tcp"), "10.0.0.0/8"},
+		{network.MustParsePortRange("22/tcp"), "0.0.0.0/0"},
+	}
+	network.SortIngressRules(rules)
+	c.Assert(rules, jc.DeepEquals, []network.IngressRule{
+		{network.MustParsePortRange("22/tcp"), "0.0.0.0/0"},
+		{network.MustParsePortRange("80/tcp"), "10.0.0.0/8"},
+		{network.MustParsePortRange("80/tcp"), "192.168.0.0/16"},
+		{network.MustParsePortRange("53/udp"), "0.0.0.0/0"},
+	})
+}
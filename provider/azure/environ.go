@@ -348,6 +348,13 @@ func (env *azureEnviron) ConstraintsValidator() (constraints.Validator, error) {
 		constraints.CpuPower,
 		constraints.Tags,
 		constraints.VirtType,
+		// The compute API used by this provider has no notion of
+		// availability zones; machines are spread using availability
+		// sets instead. The constraint is rejected rather than
+		// ignored, as ignoring it could start machines outside the
+		// zones the user asked for. Supporting it requires moving
+		// to a compute API version with zonal virtual machines.
+		constraints.Zones,
 	})
 	validator.RegisterVocabulary(
 		constraints.Arch,
@@ -830,6 +830,12 @@ func (s *environSuite) TestConstraintsValidatorUnsupported(c *gc.C) {
 	c.Assert(unsupported, jc.SameContents, []string{"tags", "cpu-power", "virt-type"})
 }
 
+func (s *environSuite) TestConstraintsValidatorRejectsZones(c *gc.C) {
+	validator := s.constraintsValidator(c)
+	_, err := validator.Validate(constraints.MustParse("arch=amd64 zones=westus-1"))
+	c.Assert(err, gc.ErrorMatches, `constraint "zones" is not supported`)
+}
+
 func (s *environSuite) TestConstraintsValidatorVocabulary(c *gc.C) {
 	validator := s.constraintsValidator(c)
 	_, err := validator.Validate(constraints.MustParse("arch=armhf"))
@@ -185,6 +185,12 @@ func (inst *azureInstance) internalNetworkAddress() (jujunetwork.Address, error)
 
 // OpenPorts is specified in the Instance interface.
 func (inst *azureInstance) OpenPorts(machineId string, ports []jujunetwork.PortRange) error {
+	return inst.OpenIngressRules(machineId, jujunetwork.UnrestrictedIngressRules(ports))
+}
+
+// OpenIngressRules is specified in the instance.IngressRuleFirewaller
+// interface.
+func (inst *azureInstance) OpenIngressRules(machineId string, rules []jujunetwork.IngressRule) error {
 	inst.env.mu.Lock()
 	nsgClient := network.SecurityGroupsClient{inst.env.network}
 	securityRuleClient := network.SecurityRulesClient{inst.env.network}
@@ -216,8 +222,9 @@ func (inst *azureInstance) OpenPorts(machineId string, ports []jujunetwork.PortR
 	// NSG in memory, so we can easily tell which priorities are available.
 	vmName := resourceName(names.NewMachineTag(machineId))
 	prefix := instanceNetworkSecurityRulePrefix(instance.Id(vmName))
-	for _, ports := range ports {
-		ruleName := securityRuleName(prefix, ports)
+	for _, ingressRule := range rules {
+		ports := ingressRule.PortRange
+		ruleName := securityRuleName(prefix, ingressRule)
 
 		// Check if the rule already exists; OpenPorts must be idempotent.
 		var found bool
@@ -235,7 +242,7 @@ func (inst *azureInstance) OpenPorts(machineId string, ports []jujunetwork.PortR
 
 		priority, err := nextSecurityRulePriority(nsg, securityRuleInternalMax+1, securityRuleMax)
 		if err != nil {
-			return errors.Annotatef(err, "getting security rule priority for %s", ports)
+			return errors.Annotatef(err, "getting security rule priority for %s", ingressRule)
 		}
 
 		var protocol network.SecurityRuleProtocol
@@ -255,13 +262,20 @@ func (inst *azureInstance) OpenPorts(machineId string, ports []jujunetwork.PortR
 			portRange = fmt.Sprint(ports.FromPort)
 		}
 
+		sourceAddressPrefix := "*"
+		description := ports.String()
+		if ingressRule.IsRestricted() {
+			sourceAddressPrefix = ingressRule.SourceCIDR
+			description = ingressRule.String()
+		}
+
 		rule := network.SecurityRule{
 			Properties: &network.SecurityRulePropertiesFormat{
-				Description:              to.StringPtr(ports.String()),
+				Description:              to.StringPtr(description),
 				Protocol:                 protocol,
 				SourcePortRange:          to.StringPtr("*"),
 				DestinationPortRange:     to.StringPtr(portRange),
-				SourceAddressPrefix:      to.StringPtr("*"),
+				SourceAddressPrefix:      to.StringPtr(sourceAddressPrefix),
 				DestinationAddressPrefix: to.StringPtr(internalNetworkAddress.Value),
 				Access:    network.Allow,
 				Priority:  to.IntPtr(priority),
@@ -274,7 +288,7 @@ func (inst *azureInstance) OpenPorts(machineId string, ports []jujunetwork.PortR
 			)
 			return result.Response, err
 		}); err != nil {
-			return errors.Annotatef(err, "creating security rule for %s", ports)
+			return errors.Annotatef(err, "creating security rule for %s", ingressRule)
 		}
 		securityRules = append(securityRules, rule)
 	}
@@ -283,6 +297,12 @@ func (inst *azureInstance) OpenPorts(machineId string, ports []jujunetwork.PortR
 
 // ClosePorts is specified in the Instance interface.
 func (inst *azureInstance) ClosePorts(machineId string, ports []jujunetwork.PortRange) error {
+	return inst.CloseIngressRules(machineId, jujunetwork.UnrestrictedIngressRules(ports))
+}
+
+// CloseIngressRules is specified in the instance.IngressRuleFirewaller
+// interface.
+func (inst *azureInstance) CloseIngressRules(machineId string, rules []jujunetwork.IngressRule) error {
 	inst.env.mu.Lock()
 	securityRuleClient := network.SecurityRulesClient{inst.env.network}
 	inst.env.mu.Unlock()
@@ -292,8 +312,8 @@ func (inst *azureInstance) ClosePorts(machineId string, ports []jujunetwork.Port
 	// on changes made by the provisioner.
 	vmName := resourceName(names.NewMachineTag(machineId))
 	prefix := instanceNetworkSecurityRulePrefix(instance.Id(vmName))
-	for _, ports := range ports {
-		ruleName := securityRuleName(prefix, ports)
+	for _, rule := range rules {
+		ruleName := securityRuleName(prefix, rule)
 		logger.Debugf("deleting security rule %q", ruleName)
 		var result autorest.Response
 		if err := inst.env.callAPI(func() (autorest.Response, error) {
@@ -311,8 +331,25 @@ func (inst *azureInstance) ClosePorts(machineId string, ports []jujunetwork.Port
 	return nil
 }
 
-// Ports is specified in the Instance interface.
-func (inst *azureInstance) Ports(machineId string) (ports []jujunetwork.PortRange, err error) {
+// Ports is specified in the Instance interface. Only the ports that
+// are open to any source are returned.
+func (inst *azureInstance) Ports(machineId string) ([]jujunetwork.PortRange, error) {
+	rules, err := inst.IngressRules(machineId)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	var ports []jujunetwork.PortRange
+	for _, rule := range rules {
+		if !rule.IsRestricted() {
+			ports = append(ports, rule.PortRange)
+		}
+	}
+	return ports, nil
+}
+
+// IngressRules is specified in the instance.IngressRuleFirewaller
+// interface.
+func (inst *azureInstance) IngressRules(machineId string) (rules []jujunetwork.IngressRule, err error) {
 	inst.env.mu.Lock()
 	nsgClient := network.SecurityGroupsClient{inst.env.network}
 	inst.env.mu.Unlock()
@@ -371,12 +408,19 @@ func (inst *azureInstance) Ports(machineId string) (ports []jujunetwork.PortRang
 		default:
 			protocols = []string{"tcp", "udp"}
 		}
+		sourceCIDR := to.String(rule.Properties.SourceAddressPrefix)
+		if sourceCIDR == "" || sourceCIDR == "*" {
+			sourceCIDR = jujunetwork.AnySourceCIDR
+		}
 		for _, protocol := range protocols {
 			portRange.Protocol = protocol
-			ports = append(ports, portRange)
+			rules = append(rules, jujunetwork.IngressRule{
+				PortRange:  portRange,
+				SourceCIDR: sourceCIDR,
+			})
 		}
 	}
-	return ports, nil
+	return rules, nil
 }
 
 // deleteInstanceNetworkSecurityRules deletes network security rules in the
@@ -428,12 +472,20 @@ func instanceNetworkSecurityRulePrefix(id instance.Id) string {
 	return string(id) + "-"
 }
 
-// securityRuleName returns the security rule name for the given port range,
-// and prefix returned by instanceNetworkSecurityRulePrefix.
-func securityRuleName(prefix string, ports jujunetwork.PortRange) string {
+// securityRuleName returns the security rule name for the given ingress
+// rule, and prefix returned by instanceNetworkSecurityRulePrefix. Rules
+// restricted to a source CIDR have the CIDR appended to the name, with
+// the characters not allowed in rule names replaced by "-".
+func securityRuleName(prefix string, rule jujunetwork.IngressRule) string {
+	ports := rule.PortRange
 	ruleName := fmt.Sprintf("%s%s-%d", prefix, ports.Protocol, ports.FromPort)
 	if ports.FromPort != ports.ToPort {
 		ruleName += fmt.Sprintf("-%d", ports.ToPort)
 	}
+	if rule.IsRestricted() {
+		ruleName += "-" + ruleNameReplacer.Replace(rule.SourceCIDR)
+	}
 	return ruleName
 }
+
+var ruleNameReplacer = strings.NewReplacer("/", "-", ":", "-")
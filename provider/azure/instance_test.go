@@ -500,6 +500,90 @@ func (s *instanceSuite) TestInstanceOpenPortsAlreadyOpen(c *gc.C) {
 	})
 }
 
+func (s *instanceSuite) TestInstanceOpenIngressRulesRestricted(c *gc.C) {
+	internalSubnetId := path.Join(
+		"/subscriptions", fakeSubscriptionId,
+		"resourceGroups/juju-testenv-model-deadbeef-0bad-400d-8000-4b1d0d06f00d",
+		"providers/Microsoft.Network/virtualnetworks/juju-internal-network/subnets/juju-internal-subnet",
+	)
+	ipConfiguration := network.InterfaceIPConfiguration{
+		Properties: &network.InterfaceIPConfigurationPropertiesFormat{
+			PrivateIPAddress: to.StringPtr("10.0.0.4"),
+			Subnet: &network.SubResource{
+				ID: to.StringPtr(internalSubnetId),
+			},
+		},
+	}
+	s.networkInterfaces = []network.Interface{
+		makeNetworkInterface("nic-0", "machine-0", ipConfiguration),
+	}
+
+	inst := s.getInstance(c)
+	okSender := mocks.NewSender()
+	okSender.EmitContent("{}")
+	nsgSender := networkSecurityGroupSender(nil)
+	s.sender = azuretesting.Senders{nsgSender, okSender}
+
+	err := inst.OpenIngressRules("0", []jujunetwork.IngressRule{{
+		PortRange:  jujunetwork.PortRange{Protocol: "tcp", FromPort: 22, ToPort: 22},
+		SourceCIDR: "192.168.0.0/16",
+	}})
+	c.Assert(err, jc.ErrorIsNil)
+
+	c.Assert(s.requests, gc.HasLen, 2)
+	c.Assert(s.requests[1].Method, gc.Equals, "PUT")
+	c.Assert(s.requests[1].URL.Path, gc.Equals, securityRulePath("machine-0-tcp-22-192.168.0.0-16"))
+	assertRequestBody(c, s.requests[1], &network.SecurityRule{
+		Properties: &network.SecurityRulePropertiesFormat{
+			Description:              to.StringPtr("22/tcp from 192.168.0.0/16"),
+			Protocol:                 network.TCP,
+			SourcePortRange:          to.StringPtr("*"),
+			SourceAddressPrefix:      to.StringPtr("192.168.0.0/16"),
+			DestinationPortRange:     to.StringPtr("22"),
+			DestinationAddressPrefix: to.StringPtr("10.0.0.4"),
+			Access:    network.Allow,
+			Priority:  to.IntPtr(200),
+			Direction: network.Inbound,
+		},
+	})
+}
+
+func (s *instanceSuite) TestInstanceIngressRules(c *gc.C) {
+	inst := s.getInstance(c)
+	nsgSender := networkSecurityGroupSender([]network.SecurityRule{{
+		Name: to.StringPtr("machine-0-tcp-80"),
+		Properties: &network.SecurityRulePropertiesFormat{
+			Protocol:             network.TCP,
+			DestinationPortRange: to.StringPtr("80"),
+			SourceAddressPrefix:  to.StringPtr("*"),
+			Access:               network.Allow,
+			Priority:             to.IntPtr(200),
+			Direction:            network.Inbound,
+		},
+	}, {
+		Name: to.StringPtr("machine-0-tcp-22-192.168.0.0-16"),
+		Properties: &network.SecurityRulePropertiesFormat{
+			Protocol:             network.TCP,
+			DestinationPortRange: to.StringPtr("22"),
+			SourceAddressPrefix:  to.StringPtr("192.168.0.0/16"),
+			Access:               network.Allow,
+			Priority:             to.IntPtr(201),
+			Direction:            network.Inbound,
+		},
+	}})
+	s.sender = azuretesting.Senders{nsgSender}
+
+	rules, err := inst.IngressRules("0")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(rules, jc.DeepEquals, []jujunetwork.IngressRule{{
+		PortRange:  jujunetwork.PortRange{Protocol: "tcp", FromPort: 80, ToPort: 80},
+		SourceCIDR: "0.0.0.0/0",
+	}, {
+		PortRange:  jujunetwork.PortRange{Protocol: "tcp", FromPort: 22, ToPort: 22},
+		SourceCIDR: "192.168.0.0/16",
+	}})
+}
+
 func (s *instanceSuite) TestInstanceOpenPortsNoInternalAddress(c *gc.C) {
 	err := s.getInstance(c).OpenPorts("0", nil)
 	c.Assert(err, gc.ErrorMatches, "internal network address not found")
@@ -34,6 +34,20 @@ type BlobStorageClient interface {
 	//
 	// See https://godoc.org/github.com/Azure/azure-sdk-for-go/storage#BlobStorageClient.DeleteBlobIfExists
 	DeleteBlobIfExists(container, name string) (bool, error)
+
+	// CopyBlob starts a blob copy operation and waits for the operation
+	// to complete. sourceBlob parameter must be a canonical URL to the
+	// blob.
+	//
+	// See https://godoc.org/github.com/Azure/azure-sdk-for-go/storage#BlobStorageClient.CopyBlob
+	CopyBlob(container, name, sourceBlob string) error
+
+	// CreateContainerIfNotExists creates a blob container if it does
+	// not exist. Returns true if container is newly created or false
+	// if container already exists.
+	//
+	// See https://godoc.org/github.com/Azure/azure-sdk-for-go/storage#BlobStorageClient.CreateContainerIfNotExists
+	CreateContainerIfNotExists(name string, access storage.ContainerAccessType) (bool, error)
 }
 
 // NewClientFunc is the type of the NewClient function.
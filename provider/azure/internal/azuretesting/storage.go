@@ -15,6 +15,7 @@ type MockStorageClient struct {
 
 	ListBlobsFunc          func(container string, _ storage.ListBlobsParameters) (storage.BlobListResponse, error)
 	DeleteBlobIfExistsFunc func(container, name string) (bool, error)
+	CopyBlobFunc           func(container, name, sourceBlob string) error
 }
 
 // NewClient exists to satisfy users who want a NewClientFunc.
@@ -48,3 +49,16 @@ func (c *MockStorageClient) DeleteBlobIfExists(container, name string) (bool, er
 	}
 	return false, c.NextErr()
 }
+
+func (c *MockStorageClient) CopyBlob(container, name, sourceBlob string) error {
+	c.MethodCall(c, "CopyBlob", container, name, sourceBlob)
+	if c.CopyBlobFunc != nil {
+		return c.CopyBlobFunc(container, name, sourceBlob)
+	}
+	return c.NextErr()
+}
+
+func (c *MockStorageClient) CreateContainerIfNotExists(name string, access storage.ContainerAccessType) (bool, error) {
+	c.MethodCall(c, "CreateContainerIfNotExists", name, access)
+	return false, c.NextErr()
+}
@@ -37,6 +37,10 @@ const (
 	// backing data disks.
 	dataDiskVHDContainer = "datavhds"
 
+	// snapshotVHDContainer is the name of the blob container for VHDs
+	// holding snapshots of data disks.
+	snapshotVHDContainer = "snapshotvhds"
+
 	// vhdExtension is the filename extension we give to VHDs we create.
 	vhdExtension = ".vhd"
 )
@@ -111,6 +115,8 @@ type azureVolumeSource struct {
 	env *azureEnviron
 }
 
+var _ storage.VolumeSnapshotter = (*azureVolumeSource)(nil)
+
 // CreateVolumes is specified on the storage.VolumeSource interface.
 func (v *azureVolumeSource) CreateVolumes(params []storage.VolumeParams) (_ []storage.CreateVolumesResult, err error) {
 
@@ -173,7 +179,9 @@ func (v *azureVolumeSource) CreateVolumes(params []storage.VolumeParams) (_ []st
 
 // createVolume updates the provided VirtualMachine's StorageProfile with the
 // parameters for creating a new data disk. We don't actually interact with
-// the Azure API until after all changes to the VirtualMachine are made.
+// the Azure compute API until after all changes to the VirtualMachine are
+// made. If the volume is to be created from a snapshot, the snapshot's VHD
+// is copied into the data disk container, and attached to the machine.
 func (v *azureVolumeSource) createVolume(
 	vm *compute.VirtualMachine,
 	p storage.VolumeParams,
@@ -197,6 +205,18 @@ func (v *azureVolumeSource) createVolume(
 		Caching:      compute.ReadWrite,
 		CreateOption: compute.Empty,
 	}
+	if p.SnapshotId != "" {
+		snapshotSizeInGib, err := v.copySnapshot(p.SnapshotId, dataDiskName)
+		if err != nil {
+			return nil, nil, errors.Annotatef(err, "copying snapshot %q", p.SnapshotId)
+		}
+		// The VHD's size is fixed when it is attached, so we
+		// report the size of the snapshot rather than the size
+		// requested.
+		sizeInGib = snapshotSizeInGib
+		dataDisk.DiskSizeGB = nil
+		dataDisk.CreateOption = compute.Attach
+	}
 
 	var dataDisks []compute.DataDisk
 	if vm.Properties.StorageProfile.DataDisks != nil {
@@ -247,6 +267,11 @@ func (v *azureVolumeSource) ListVolumes() ([]string, error) {
 
 // listBlobs returns a list of blobs in the data-disk container.
 func (v *azureVolumeSource) listBlobs() ([]azurestorage.Blob, error) {
+	return v.listContainerBlobs(dataDiskVHDContainer)
+}
+
+// listContainerBlobs returns a list of blobs in the specified container.
+func (v *azureVolumeSource) listContainerBlobs(container string) ([]azurestorage.Blob, error) {
 	client, err := v.env.getStorageClient()
 	if err != nil {
 		return nil, errors.Trace(err)
@@ -256,7 +281,7 @@ func (v *azureVolumeSource) listBlobs() ([]azurestorage.Blob, error) {
 	// TODO(axw) consider taking a set of IDs and computing the
 	//           longest common prefix to pass in the parameters
 	response, err := blobsClient.ListBlobs(
-		dataDiskVHDContainer, azurestorage.ListBlobsParameters{},
+		container, azurestorage.ListBlobsParameters{},
 	)
 	if err != nil {
 		if err, ok := err.(azurestorage.AzureStorageServiceError); ok {
@@ -321,6 +346,138 @@ func (v *azureVolumeSource) DestroyVolumes(volumeIds []string) ([]error, error)
 	return results, nil
 }
 
+// CreateSnapshots is specified on the storage.VolumeSnapshotter interface.
+//
+// Snapshots of data disks are made by copying the VHD backing the disk
+// into the snapshot container. Azure requires that the disk is not being
+// written to while the copy is made for the snapshot to be consistent.
+func (v *azureVolumeSource) CreateSnapshots(params []storage.SnapshotParams) ([]storage.CreateSnapshotsResult, error) {
+	blobs, err := v.listBlobs()
+	if err != nil {
+		return nil, errors.Annotate(err, "listing volumes")
+	}
+	byVolumeId := make(map[string]azurestorage.Blob)
+	for _, blob := range blobs {
+		if volumeId, ok := blobVolumeId(blob); ok {
+			byVolumeId[volumeId] = blob
+		}
+	}
+
+	client, err := v.env.getStorageClient()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	blobsClient := client.GetBlobService()
+	if _, err := blobsClient.CreateContainerIfNotExists(
+		snapshotVHDContainer, azurestorage.ContainerAccessTypePrivate,
+	); err != nil {
+		return nil, errors.Annotate(err, "creating snapshot container")
+	}
+
+	results := make([]storage.CreateSnapshotsResult, len(params))
+	for i, p := range params {
+		blob, ok := byVolumeId[p.VolumeId]
+		if !ok {
+			results[i].Error = errors.NotFoundf("volume %q", p.VolumeId)
+			continue
+		}
+		uuid, err := utils.NewUUID()
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		snapshotId := fmt.Sprintf("%s-snapshot-%s", p.VolumeId, uuid)
+		sourceURI := dataDiskVhdRoot(
+			v.env.config.storageEndpoint, v.env.config.storageAccount,
+		) + blob.Name
+		if err := blobsClient.CopyBlob(
+			snapshotVHDContainer, snapshotId+vhdExtension, sourceURI,
+		); err != nil {
+			results[i].Error = errors.Annotatef(err, "copying %q", blob.Name)
+			continue
+		}
+		results[i].Snapshot = &storage.SnapshotInfo{
+			SnapshotId: snapshotId,
+			VolumeId:   p.VolumeId,
+			Size:       uint64(blob.Properties.ContentLength / (1024 * 1024)),
+		}
+	}
+	return results, nil
+}
+
+// ListSnapshots is specified on the storage.VolumeSnapshotter interface.
+func (v *azureVolumeSource) ListSnapshots() ([]string, error) {
+	blobs, err := v.listContainerBlobs(snapshotVHDContainer)
+	if err != nil {
+		return nil, errors.Annotate(err, "listing snapshots")
+	}
+	snapshotIds := make([]string, 0, len(blobs))
+	for _, blob := range blobs {
+		if !strings.HasSuffix(blob.Name, vhdExtension) {
+			continue
+		}
+		snapshotIds = append(snapshotIds, blob.Name[:len(blob.Name)-len(vhdExtension)])
+	}
+	return snapshotIds, nil
+}
+
+// DestroySnapshots is specified on the storage.VolumeSnapshotter interface.
+func (v *azureVolumeSource) DestroySnapshots(snapshotIds []string) ([]error, error) {
+	client, err := v.env.getStorageClient()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	blobsClient := client.GetBlobService()
+	results := make([]error, len(snapshotIds))
+	for i, snapshotId := range snapshotIds {
+		_, err := blobsClient.DeleteBlobIfExists(
+			snapshotVHDContainer, snapshotId+vhdExtension,
+		)
+		results[i] = err
+	}
+	return results, nil
+}
+
+// copySnapshot copies the VHD for the specified snapshot into the data
+// disk container with the given name, returning the size of the VHD in
+// GiB.
+func (v *azureVolumeSource) copySnapshot(snapshotId, dataDiskName string) (uint64, error) {
+	blobs, err := v.listContainerBlobs(snapshotVHDContainer)
+	if err != nil {
+		return 0, errors.Annotate(err, "listing snapshots")
+	}
+	var snapshotBlob *azurestorage.Blob
+	for i, blob := range blobs {
+		if blob.Name == snapshotId+vhdExtension {
+			snapshotBlob = &blobs[i]
+			break
+		}
+	}
+	if snapshotBlob == nil {
+		return 0, errors.NotFoundf("snapshot %q", snapshotId)
+	}
+
+	client, err := v.env.getStorageClient()
+	if err != nil {
+		return 0, errors.Trace(err)
+	}
+	blobsClient := client.GetBlobService()
+	if _, err := blobsClient.CreateContainerIfNotExists(
+		dataDiskVHDContainer, azurestorage.ContainerAccessTypePrivate,
+	); err != nil {
+		return 0, errors.Annotate(err, "creating data disk container")
+	}
+	sourceURI := snapshotVhdRoot(
+		v.env.config.storageEndpoint, v.env.config.storageAccount,
+	) + snapshotBlob.Name
+	if err := blobsClient.CopyBlob(
+		dataDiskVHDContainer, dataDiskName+vhdExtension, sourceURI,
+	); err != nil {
+		return 0, errors.Trace(err)
+	}
+	sizeInMib := uint64(snapshotBlob.Properties.ContentLength / (1024 * 1024))
+	return mibToGib(sizeInMib), nil
+}
+
 // ValidateVolumeParams is specified on the storage.VolumeSource interface.
 func (v *azureVolumeSource) ValidateVolumeParams(params storage.VolumeParams) error {
 	if mibToGib(params.Size) > volumeSizeMaxGiB {
@@ -660,6 +817,12 @@ func dataDiskVhdRoot(storageEndpoint, storageAccountName string) string {
 	return blobContainerURL(storageEndpoint, storageAccountName, dataDiskVHDContainer)
 }
 
+// snapshotVhdRoot returns the URL to the blob container in which we store
+// the VHDs for data disk snapshots for the environment.
+func snapshotVhdRoot(storageEndpoint, storageAccountName string) string {
+	return blobContainerURL(storageEndpoint, storageAccountName, snapshotVHDContainer)
+}
+
 // blobContainer returns the URL to the named blob container.
 func blobContainerURL(storageEndpoint, storageAccountName, container string) string {
 	return fmt.Sprintf(
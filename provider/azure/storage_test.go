@@ -339,6 +339,83 @@ func (s *storageSuite) TestDestroyVolumes(c *gc.C) {
 	s.storageClient.CheckCall(c, 2, "DeleteBlobIfExists", "datavhds", "volume-42.vhd")
 }
 
+func (s *storageSuite) TestCreateSnapshots(c *gc.C) {
+	s.storageClient.ListBlobsFunc = func(
+		container string,
+		params azurestorage.ListBlobsParameters,
+	) (azurestorage.BlobListResponse, error) {
+		return azurestorage.BlobListResponse{
+			Blobs: []azurestorage.Blob{{
+				Name: "volume-0.vhd",
+				Properties: azurestorage.BlobProperties{
+					ContentLength: 1024 * 1024 * 1024, // 1GiB
+				},
+			}},
+		}, nil
+	}
+
+	volumeSource := s.volumeSource(c)
+	snapshotter, ok := volumeSource.(storage.VolumeSnapshotter)
+	c.Assert(ok, jc.IsTrue)
+	results, err := snapshotter.CreateSnapshots([]storage.SnapshotParams{
+		{VolumeId: "volume-0"},
+		{VolumeId: "volume-42"},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, gc.HasLen, 2)
+	c.Assert(results[0].Error, jc.ErrorIsNil)
+	c.Assert(results[0].Snapshot.VolumeId, gc.Equals, "volume-0")
+	c.Assert(results[0].Snapshot.Size, gc.Equals, uint64(1024))
+	c.Assert(results[0].Snapshot.SnapshotId, gc.Matches, "volume-0-snapshot-.*")
+	c.Assert(results[1].Error, gc.ErrorMatches, `volume "volume-42" not found`)
+
+	s.storageClient.CheckCallNames(c,
+		"NewClient", "ListBlobs",
+		"NewClient", "CreateContainerIfNotExists", "CopyBlob",
+	)
+	s.storageClient.CheckCall(c, 3, "CreateContainerIfNotExists", "snapshotvhds", azurestorage.ContainerAccessTypePrivate)
+	s.storageClient.CheckCall(c, 4, "CopyBlob",
+		"snapshotvhds", results[0].Snapshot.SnapshotId+".vhd",
+		fmt.Sprintf("https://%s.blob.storage.azurestack.local/datavhds/volume-0.vhd", fakeStorageAccount),
+	)
+}
+
+func (s *storageSuite) TestListSnapshots(c *gc.C) {
+	s.storageClient.ListBlobsFunc = func(
+		container string,
+		params azurestorage.ListBlobsParameters,
+	) (azurestorage.BlobListResponse, error) {
+		return azurestorage.BlobListResponse{
+			Blobs: []azurestorage.Blob{{
+				Name: "volume-0-snapshot-0.vhd",
+			}, {
+				Name: "junk",
+			}},
+		}, nil
+	}
+
+	volumeSource := s.volumeSource(c)
+	snapshotIds, err := volumeSource.(storage.VolumeSnapshotter).ListSnapshots()
+	c.Assert(err, jc.ErrorIsNil)
+	s.storageClient.CheckCallNames(c, "NewClient", "ListBlobs")
+	s.storageClient.CheckCall(c, 1, "ListBlobs", "snapshotvhds", azurestorage.ListBlobsParameters{})
+	c.Assert(snapshotIds, jc.DeepEquals, []string{"volume-0-snapshot-0"})
+}
+
+func (s *storageSuite) TestDestroySnapshots(c *gc.C) {
+	volumeSource := s.volumeSource(c)
+	results, err := volumeSource.(storage.VolumeSnapshotter).DestroySnapshots([]string{
+		"volume-0-snapshot-0", "volume-0-snapshot-1",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, gc.HasLen, 2)
+	c.Assert(results[0], jc.ErrorIsNil)
+	c.Assert(results[1], jc.ErrorIsNil)
+	s.storageClient.CheckCallNames(c, "NewClient", "DeleteBlobIfExists", "DeleteBlobIfExists")
+	s.storageClient.CheckCall(c, 1, "DeleteBlobIfExists", "snapshotvhds", "volume-0-snapshot-0.vhd")
+	s.storageClient.CheckCall(c, 2, "DeleteBlobIfExists", "snapshotvhds", "volume-0-snapshot-1.vhd")
+}
+
 func (s *storageSuite) TestAttachVolumes(c *gc.C) {
 	// machine-1 has a single data disk with LUN 0.
 	machine1DataDisks := []compute.DataDisk{{
@@ -5,7 +5,11 @@ package common
 
 import (
 	"sort"
+	"strings"
 
+	"github.com/juju/errors"
+
+	"github.com/juju/juju/constraints"
 	"github.com/juju/juju/environs"
 	"github.com/juju/juju/instance"
 )
@@ -164,3 +168,92 @@ func DistributeInstances(env ZonedEnviron, candidates, group []instance.Id) ([]i
 	}
 	return eligible, nil
 }
+
+// StartInstanceInOtherZones is called when an instance could not be
+// started because of a problem with the availability zone it was
+// started in, as reported by zoneErr. Each of the other available
+// zones allowed by the constraints is tried in turn, preferring those
+// with the fewest instances in the distribution group, until an
+// instance is started or an error that is not specific to a zone is
+// returned. The names of the zones tried, starting with the one that
+// caused zoneErr, are returned with the result.
+func StartInstanceInOtherZones(
+	env ZonedEnviron,
+	args environs.StartInstanceParams,
+	zoneErr error,
+) (*environs.StartInstanceResult, []string, error) {
+	triedZones := []string{errors.Cause(zoneErr).(*instance.ZoneSpecificCreationError).Zone()}
+	var group []instance.Id
+	if args.DistributionGroup != nil {
+		var err error
+		group, err = args.DistributionGroup()
+		if err != nil {
+			return nil, triedZones, errors.Annotate(err, "cannot get distribution group")
+		}
+	}
+	zoneInstances, err := AvailabilityZoneAllocations(env, group)
+	if err != nil {
+		return nil, triedZones, errors.Annotate(err, "cannot get availability zones")
+	}
+	err = zoneErr
+	for _, zone := range zoneInstances {
+		if zoneTried(triedZones, zone.ZoneName) || !args.Constraints.IncludesZone(zone.ZoneName) {
+			continue
+		}
+		logger.Infof(
+			"cannot start instance in availability zone %q, trying %q: %v",
+			triedZones[len(triedZones)-1], zone.ZoneName, err,
+		)
+		args.Placement = "zone=" + zone.ZoneName
+		triedZones = append(triedZones, zone.ZoneName)
+		var result *environs.StartInstanceResult
+		result, err = env.StartInstance(args)
+		if err == nil || !instance.IsZoneSpecificCreationError(errors.Cause(err)) {
+			return result, triedZones, err
+		}
+	}
+	return nil, triedZones, err
+}
+
+func zoneTried(triedZones []string, zone string) bool {
+	for _, tried := range triedZones {
+		if tried == zone {
+			return true
+		}
+	}
+	return false
+}
+
+// ZonesAllowedByConstraints returns the given availability zone names,
+// in their original order, that are allowed by the zones constraint.
+// An error is returned if the constraint allows none of them.
+func ZonesAllowedByConstraints(zoneNames []string, cons constraints.Value) ([]string, error) {
+	if !cons.HasZones() {
+		return zoneNames, nil
+	}
+	var allowed []string
+	for _, zone := range zoneNames {
+		if cons.IncludesZone(zone) {
+			allowed = append(allowed, zone)
+		}
+	}
+	if len(allowed) == 0 {
+		return nil, errors.Errorf(
+			"no available zones match the zones constraint %q",
+			strings.Join(*cons.Zones, ","),
+		)
+	}
+	return allowed, nil
+}
+
+// CheckZoneAllowedByConstraints returns an error if the zones
+// constraint does not allow the named availability zone.
+func CheckZoneAllowedByConstraints(zone string, cons constraints.Value) error {
+	if !cons.IncludesZone(zone) {
+		return errors.Errorf(
+			"availability zone %q is not allowed by the zones constraint %q",
+			zone, strings.Join(*cons.Zones, ","),
+		)
+	}
+	return nil
+}
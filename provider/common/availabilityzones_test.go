@@ -9,6 +9,7 @@ import (
 	jc "github.com/juju/testing/checkers"
 	gc "gopkg.in/check.v1"
 
+	"github.com/juju/juju/constraints"
 	"github.com/juju/juju/environs"
 	"github.com/juju/juju/instance"
 	"github.com/juju/juju/provider/common"
@@ -254,3 +255,26 @@ func (s *AvailabilityZoneSuite) TestDistributeInstances(c *gc.C) {
 		c.Assert(eligible, jc.SameContents, test.eligible)
 	}
 }
+
+func (s *AvailabilityZoneSuite) TestZonesAllowedByConstraints(c *gc.C) {
+	zones := []string{"az3", "az1", "az2"}
+	allowed, err := common.ZonesAllowedByConstraints(zones, constraints.MustParse("mem=4G"))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(allowed, jc.DeepEquals, zones)
+
+	allowed, err = common.ZonesAllowedByConstraints(zones, constraints.MustParse("zones=az2,az3"))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(allowed, jc.DeepEquals, []string{"az3", "az2"})
+
+	_, err = common.ZonesAllowedByConstraints(zones, constraints.MustParse("zones=az4"))
+	c.Assert(err, gc.ErrorMatches, `no available zones match the zones constraint "az4"`)
+}
+
+func (s *AvailabilityZoneSuite) TestCheckZoneAllowedByConstraints(c *gc.C) {
+	err := common.CheckZoneAllowedByConstraints("az1", constraints.Value{})
+	c.Assert(err, jc.ErrorIsNil)
+	err = common.CheckZoneAllowedByConstraints("az1", constraints.MustParse("zones=az1,az2"))
+	c.Assert(err, jc.ErrorIsNil)
+	err = common.CheckZoneAllowedByConstraints("az3", constraints.MustParse("zones=az1,az2"))
+	c.Assert(err, gc.ErrorMatches, `availability zone "az3" is not allowed by the zones constraint "az1,az2"`)
+}
@@ -135,14 +135,22 @@ func BootstrapInstance(ctx environs.BootstrapContext, env environs.Environ, args
 		fmt.Fprintf(ctx.GetStderr(), "%s      \r", info)
 		return nil
 	}
-	result, err := env.StartInstance(environs.StartInstanceParams{
+	startInstanceParams := environs.StartInstanceParams{
 		Constraints:    args.BootstrapConstraints,
 		Tools:          availableTools,
 		InstanceConfig: instanceConfig,
 		Placement:      args.Placement,
 		ImageMetadata:  imageMetadata,
 		StatusCallback: instanceStatus,
-	})
+	}
+	result, err := env.StartInstance(startInstanceParams)
+	if err != nil && instance.IsZoneSpecificCreationError(errors.Cause(err)) {
+		// Try the other availability zones, as the provisioner does,
+		// unless the instance was placed explicitly.
+		if zonedEnviron, ok := env.(ZonedEnviron); ok && args.Placement == "" {
+			result, _, err = StartInstanceInOtherZones(zonedEnviron, startInstanceParams, err)
+		}
+	}
 	if err != nil {
 		return nil, "", nil, errors.Annotate(err, "cannot start bootstrap instance")
 	}
@@ -116,5 +116,36 @@ func destroyVolumes(
 	if len(errStrings) > 0 {
 		return errors.Errorf("destroying volumes: %s", strings.Join(errStrings, ", "))
 	}
+
+	if snapshotter, ok := volumeSource.(storage.VolumeSnapshotter); ok {
+		if err := destroySnapshots(snapshotter); err != nil {
+			return errors.Trace(err)
+		}
+	}
+	return nil
+}
+
+func destroySnapshots(snapshotter storage.VolumeSnapshotter) error {
+	snapshotIds, err := snapshotter.ListSnapshots()
+	if err != nil {
+		return errors.Annotate(err, "listing snapshots")
+	}
+	if len(snapshotIds) == 0 {
+		return nil
+	}
+
+	var errStrings []string
+	errs, err := snapshotter.DestroySnapshots(snapshotIds)
+	if err != nil {
+		return errors.Annotate(err, "destroying snapshots")
+	}
+	for _, err := range errs {
+		if err != nil {
+			errStrings = append(errStrings, err.Error())
+		}
+	}
+	if len(errStrings) > 0 {
+		return errors.Errorf("destroying snapshots: %s", strings.Join(errStrings, ", "))
+	}
 	return nil
 }
@@ -164,6 +164,50 @@ func (s *DestroySuite) TestDestroyEnvScopedVolumes(c *gc.C) {
 	volumeSource.CheckCalls(c, []gitjujutesting.StubCall{
 		{"ListVolumes", nil},
 		{"DestroyVolumes", []interface{}{[]string{"vol-0", "vol-1", "vol-2"}}},
+		{"ListSnapshots", nil},
+	})
+}
+
+func (s *DestroySuite) TestDestroyEnvScopedSnapshots(c *gc.C) {
+	volumeSource := &dummy.VolumeSource{
+		ListVolumesFunc: func() ([]string, error) {
+			return nil, nil
+		},
+		DestroyVolumesFunc: func(ids []string) ([]error, error) {
+			return make([]error, len(ids)), nil
+		},
+		ListSnapshotsFunc: func() ([]string, error) {
+			return []string{"snap-0", "snap-1"}, nil
+		},
+		DestroySnapshotsFunc: func(ids []string) ([]error, error) {
+			return []error{nil, errors.New("cannot destroy snap-1")}, nil
+		},
+	}
+	staticProvider := &dummy.StorageProvider{
+		IsDynamic:    true,
+		StorageScope: storage.ScopeEnviron,
+		VolumeSourceFunc: func(*config.Config, *storage.Config) (storage.VolumeSource, error) {
+			return volumeSource, nil
+		},
+	}
+	registry.RegisterProvider("environ", staticProvider)
+	defer registry.RegisterProvider("environ", nil)
+	registry.RegisterEnvironStorageProviders("anything, really", "environ")
+	defer registry.ResetEnvironStorageProviders("anything, really")
+
+	env := &mockEnviron{
+		config: configGetter(c),
+		allInstances: func() ([]instance.Instance, error) {
+			return nil, environs.ErrNoInstances
+		},
+	}
+	err := common.Destroy(env)
+	c.Assert(err, gc.ErrorMatches, "destroying storage: destroying snapshots: cannot destroy snap-1")
+	volumeSource.CheckCalls(c, []gitjujutesting.StubCall{
+		{"ListVolumes", nil},
+		{"DestroyVolumes", []interface{}{[]string(nil)}},
+		{"ListSnapshots", nil},
+		{"DestroySnapshots", []interface{}{[]string{"snap-0", "snap-1"}}},
 	})
 }
 
@@ -209,6 +209,7 @@ type OpOpenPorts struct {
 	MachineId  string
 	InstanceId instance.Id
 	Ports      []network.PortRange
+	Rules      []network.IngressRule
 }
 
 type OpClosePorts struct {
@@ -216,6 +217,7 @@ type OpClosePorts struct {
 	MachineId  string
 	InstanceId instance.Id
 	Ports      []network.PortRange
+	Rules      []network.IngressRule
 }
 
 type OpPutFile struct {
@@ -246,7 +248,7 @@ type environState struct {
 	maxId           int // maximum instance id allocated so far.
 	maxAddr         int // maximum allocated address last byte
 	insts           map[instance.Id]*dummyInstance
-	globalPorts     map[network.PortRange]bool
+	globalRules     map[network.IngressRule]bool
 	bootstrapped    bool
 	apiListener     net.Listener
 	apiServer       *apiserver.Server
@@ -383,7 +385,7 @@ func newState(name string, ops chan<- Operation, policy state.Policy) *environSt
 		ops:         ops,
 		statePolicy: policy,
 		insts:       make(map[instance.Id]*dummyInstance),
-		globalPorts: make(map[network.PortRange]bool),
+		globalRules: make(map[network.IngressRule]bool),
 	}
 	return s
 }
@@ -718,7 +720,7 @@ func (e *environ) Bootstrap(ctx environs.BootstrapContext, args environs.Bootstr
 	i := &dummyInstance{
 		id:           BootstrapInstanceId,
 		addresses:    network.NewAddresses("localhost"),
-		ports:        make(map[network.PortRange]bool),
+		rules:        make(map[network.IngressRule]bool),
 		machineId:    agent.BootstrapMachineId,
 		series:       series,
 		firewallMode: e.Config().FirewallMode(),
@@ -929,7 +931,7 @@ func (e *environ) StartInstance(args environs.StartInstanceParams) (*environs.St
 	i := &dummyInstance{
 		id:           instance.Id(idString),
 		addresses:    addrs,
-		ports:        make(map[network.PortRange]bool),
+		rules:        make(map[network.IngressRule]bool),
 		machineId:    machineId,
 		series:       series,
 		firewallMode: e.Config().FirewallMode(),
@@ -1469,6 +1471,24 @@ func (e *environ) AllInstances() ([]instance.Instance, error) {
 }
 
 func (e *environ) OpenPorts(ports []network.PortRange) error {
+	return e.OpenIngressRules(network.UnrestrictedIngressRules(ports))
+}
+
+func (e *environ) ClosePorts(ports []network.PortRange) error {
+	return e.CloseIngressRules(network.UnrestrictedIngressRules(ports))
+}
+
+func (e *environ) Ports() (ports []network.PortRange, err error) {
+	rules, err := e.IngressRules()
+	if err != nil {
+		return nil, err
+	}
+	return rulePortRanges(rules), nil
+}
+
+// OpenIngressRules is specified in the environs.IngressRuleFirewaller
+// interface.
+func (e *environ) OpenIngressRules(rules []network.IngressRule) error {
 	if mode := e.ecfg().FirewallMode(); mode != config.FwGlobal {
 		return fmt.Errorf("invalid firewall mode %q for opening ports on model", mode)
 	}
@@ -1478,13 +1498,15 @@ func (e *environ) OpenPorts(ports []network.PortRange) error {
 	}
 	estate.mu.Lock()
 	defer estate.mu.Unlock()
-	for _, p := range ports {
-		estate.globalPorts[p] = true
+	for _, r := range rules {
+		estate.globalRules[r] = true
 	}
 	return nil
 }
 
-func (e *environ) ClosePorts(ports []network.PortRange) error {
+// CloseIngressRules is specified in the environs.IngressRuleFirewaller
+// interface.
+func (e *environ) CloseIngressRules(rules []network.IngressRule) error {
 	if mode := e.ecfg().FirewallMode(); mode != config.FwGlobal {
 		return fmt.Errorf("invalid firewall mode %q for closing ports on model", mode)
 	}
@@ -1494,13 +1516,15 @@ func (e *environ) ClosePorts(ports []network.PortRange) error {
 	}
 	estate.mu.Lock()
 	defer estate.mu.Unlock()
-	for _, p := range ports {
-		delete(estate.globalPorts, p)
+	for _, r := range rules {
+		delete(estate.globalRules, r)
 	}
 	return nil
 }
 
-func (e *environ) Ports() (ports []network.PortRange, err error) {
+// IngressRules is specified in the environs.IngressRuleFirewaller
+// interface.
+func (e *environ) IngressRules() (rules []network.IngressRule, err error) {
 	if mode := e.ecfg().FirewallMode(); mode != config.FwGlobal {
 		return nil, fmt.Errorf("invalid firewall mode %q for retrieving ports from model", mode)
 	}
@@ -1510,20 +1534,34 @@ func (e *environ) Ports() (ports []network.PortRange, err error) {
 	}
 	estate.mu.Lock()
 	defer estate.mu.Unlock()
-	for p := range estate.globalPorts {
-		ports = append(ports, p)
+	for r := range estate.globalRules {
+		rules = append(rules, r)
 	}
-	network.SortPortRanges(ports)
+	network.SortIngressRules(rules)
 	return
 }
 
+// rulePortRanges returns the distinct port ranges of the given rules.
+func rulePortRanges(rules []network.IngressRule) []network.PortRange {
+	var ports []network.PortRange
+	seen := make(map[network.PortRange]bool)
+	for _, r := range rules {
+		if !seen[r.PortRange] {
+			seen[r.PortRange] = true
+			ports = append(ports, r.PortRange)
+		}
+	}
+	network.SortPortRanges(ports)
+	return ports
+}
+
 func (*environ) Provider() environs.EnvironProvider {
 	return &dummy
 }
 
 type dummyInstance struct {
 	state        *environState
-	ports        map[network.PortRange]bool
+	rules        map[network.IngressRule]bool
 	id           instance.Id
 	status       string
 	machineId    string
@@ -1606,8 +1644,26 @@ func (inst *dummyInstance) Addresses() ([]network.Address, error) {
 }
 
 func (inst *dummyInstance) OpenPorts(machineId string, ports []network.PortRange) error {
+	return inst.OpenIngressRules(machineId, network.UnrestrictedIngressRules(ports))
+}
+
+func (inst *dummyInstance) ClosePorts(machineId string, ports []network.PortRange) error {
+	return inst.CloseIngressRules(machineId, network.UnrestrictedIngressRules(ports))
+}
+
+func (inst *dummyInstance) Ports(machineId string) (ports []network.PortRange, err error) {
+	rules, err := inst.IngressRules(machineId)
+	if err != nil {
+		return nil, err
+	}
+	return rulePortRanges(rules), nil
+}
+
+// OpenIngressRules is specified in the instance.IngressRuleFirewaller
+// interface.
+func (inst *dummyInstance) OpenIngressRules(machineId string, rules []network.IngressRule) error {
 	defer delay()
-	logger.Infof("openPorts %s, %#v", machineId, ports)
+	logger.Infof("openIngressRules %s, %#v", machineId, rules)
 	if inst.firewallMode != config.FwInstance {
 		return fmt.Errorf("invalid firewall mode %q for opening ports on instance",
 			inst.firewallMode)
@@ -1624,15 +1680,18 @@ func (inst *dummyInstance) OpenPorts(machineId string, ports []network.PortRange
 		Env:        inst.state.name,
 		MachineId:  machineId,
 		InstanceId: inst.Id(),
-		Ports:      ports,
+		Ports:      rulePortRanges(rules),
+		Rules:      rules,
 	}
-	for _, p := range ports {
-		inst.ports[p] = true
+	for _, r := range rules {
+		inst.rules[r] = true
 	}
 	return nil
 }
 
-func (inst *dummyInstance) ClosePorts(machineId string, ports []network.PortRange) error {
+// CloseIngressRules is specified in the instance.IngressRuleFirewaller
+// interface.
+func (inst *dummyInstance) CloseIngressRules(machineId string, rules []network.IngressRule) error {
 	defer delay()
 	if inst.firewallMode != config.FwInstance {
 		return fmt.Errorf("invalid firewall mode %q for closing ports on instance",
@@ -1650,15 +1709,18 @@ func (inst *dummyInstance) ClosePorts(machineId string, ports []network.PortRang
 		Env:        inst.state.name,
 		MachineId:  machineId,
 		InstanceId: inst.Id(),
-		Ports:      ports,
+		Ports:      rulePortRanges(rules),
+		Rules:      rules,
 	}
-	for _, p := range ports {
-		delete(inst.ports, p)
+	for _, r := range rules {
+		delete(inst.rules, r)
 	}
 	return nil
 }
 
-func (inst *dummyInstance) Ports(machineId string) (ports []network.PortRange, err error) {
+// IngressRules is specified in the instance.IngressRuleFirewaller
+// interface.
+func (inst *dummyInstance) IngressRules(machineId string) (rules []network.IngressRule, err error) {
 	defer delay()
 	if inst.firewallMode != config.FwInstance {
 		return nil, fmt.Errorf("invalid firewall mode %q for retrieving ports from instance",
@@ -1672,10 +1734,10 @@ func (inst *dummyInstance) Ports(machineId string) (ports []network.PortRange, e
 	if err := inst.checkBroken("Ports"); err != nil {
 		return nil, err
 	}
-	for p := range inst.ports {
-		ports = append(ports, p)
+	for r := range inst.rules {
+		rules = append(rules, r)
 	}
-	network.SortPortRanges(ports)
+	network.SortIngressRules(rules)
 	return
 }
 
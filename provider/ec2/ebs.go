@@ -57,6 +57,7 @@ const (
 	deviceInUse        = "InvalidDevice.InUse"
 	attachmentNotFound = "InvalidAttachment.NotFound"
 	volumeNotFound     = "InvalidVolume.NotFound"
+	snapshotNotFound   = "InvalidSnapshot.NotFound"
 )
 
 const (
@@ -235,6 +236,8 @@ type ebsVolumeSource struct {
 }
 
 var _ storage.VolumeSource = (*ebsVolumeSource)(nil)
+var _ storage.VolumeImporter = (*ebsVolumeSource)(nil)
+var _ storage.VolumeSnapshotter = (*ebsVolumeSource)(nil)
 
 // parseVolumeOptions uses storage volume parameters to make a struct used to create volumes.
 func parseVolumeOptions(size uint64, attrs map[string]interface{}) (_ ec2.CreateVolume, _ error) {
@@ -332,6 +335,7 @@ func (v *ebsVolumeSource) createVolume(p storage.VolumeParams, instances instanc
 	}
 	vol, _ := parseVolumeOptions(p.Size, p.Attributes)
 	vol.AvailZone = inst.AvailZone
+	vol.SnapshotId = p.SnapshotId
 	resp, err := v.ec2.CreateVolume(vol)
 	if err != nil {
 		return nil, nil, errors.Trace(err)
@@ -428,6 +432,103 @@ func (v *ebsVolumeSource) DescribeVolumes(volIds []string) ([]storage.DescribeVo
 	return results, nil
 }
 
+// ImportVolume is specified on the storage.VolumeImporter interface.
+func (v *ebsVolumeSource) ImportVolume(volumeId string, resourceTags map[string]string) (storage.VolumeInfo, error) {
+	vol, err := describeVolume(v.ec2, volumeId)
+	if err != nil {
+		return storage.VolumeInfo{}, errors.Annotatef(err, "getting volume %q", volumeId)
+	}
+	if vol.Status != volumeStatusAvailable {
+		return storage.VolumeInfo{}, errors.Errorf(
+			"cannot import volume %q with status %q", volumeId, vol.Status,
+		)
+	}
+	// Refuse to import a volume that belongs to another model or
+	// controller; tagging it would take it from its owner.
+	for _, tag := range vol.Tags {
+		switch tag.Key {
+		case tags.JujuModel, tags.JujuController:
+			if value, ok := resourceTags[tag.Key]; ok && value != tag.Value {
+				return storage.VolumeInfo{}, errors.Errorf(
+					"cannot import volume %q with tag %s=%q", volumeId, tag.Key, tag.Value,
+				)
+			}
+		}
+	}
+	if err := tagResources(v.ec2, resourceTags, volumeId); err != nil {
+		return storage.VolumeInfo{}, errors.Annotatef(err, "tagging volume %q", volumeId)
+	}
+	return storage.VolumeInfo{
+		VolumeId:   vol.Id,
+		Size:       gibToMib(uint64(vol.Size)),
+		Persistent: true,
+	}, nil
+}
+
+// CreateSnapshots is specified on the storage.VolumeSnapshotter interface.
+func (v *ebsVolumeSource) CreateSnapshots(params []storage.SnapshotParams) ([]storage.CreateSnapshotsResult, error) {
+	results := make([]storage.CreateSnapshotsResult, len(params))
+	for i, p := range params {
+		snapshot, err := v.createSnapshot(p)
+		if err != nil {
+			results[i].Error = errors.Annotatef(err, "creating snapshot of %q", p.VolumeId)
+			continue
+		}
+		results[i].Snapshot = snapshot
+	}
+	return results, nil
+}
+
+func (v *ebsVolumeSource) createSnapshot(p storage.SnapshotParams) (*storage.SnapshotInfo, error) {
+	vol, err := describeVolume(v.ec2, p.VolumeId)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	resp, err := v.ec2.CreateSnapshot(p.VolumeId, "juju snapshot of "+p.VolumeId)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	snapshotId := resp.Snapshot.Id
+	if err := tagResources(v.ec2, p.ResourceTags, snapshotId); err != nil {
+		if _, err := v.ec2.DeleteSnapshots([]string{snapshotId}); err != nil {
+			logger.Warningf("error cleaning up snapshot %v: %v", snapshotId, err)
+		}
+		return nil, errors.Annotate(err, "tagging snapshot")
+	}
+	return &storage.SnapshotInfo{
+		SnapshotId: snapshotId,
+		VolumeId:   p.VolumeId,
+		Size:       gibToMib(uint64(vol.Size)),
+	}, nil
+}
+
+// ListSnapshots is specified on the storage.VolumeSnapshotter interface.
+func (v *ebsVolumeSource) ListSnapshots() ([]string, error) {
+	filter := ec2.NewFilter()
+	filter.Add("tag:"+tags.JujuModel, v.modelUUID)
+	resp, err := v.ec2.Snapshots(nil, filter)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	snapshotIds := make([]string, len(resp.Snapshots))
+	for i, snapshot := range resp.Snapshots {
+		snapshotIds[i] = snapshot.Id
+	}
+	return snapshotIds, nil
+}
+
+// DestroySnapshots is specified on the storage.VolumeSnapshotter interface.
+func (v *ebsVolumeSource) DestroySnapshots(snapshotIds []string) ([]error, error) {
+	results := make([]error, len(snapshotIds))
+	for i, snapshotId := range snapshotIds {
+		_, err := v.ec2.DeleteSnapshots([]string{snapshotId})
+		if err != nil && ec2ErrCode(err) != snapshotNotFound {
+			results[i] = errors.Annotatef(err, "destroying %q", snapshotId)
+		}
+	}
+	return results, nil
+}
+
 // DestroyVolumes is specified on the storage.VolumeSource interface.
 func (v *ebsVolumeSource) DestroyVolumes(volIds []string) ([]error, error) {
 	return destroyVolumes(v.ec2, volIds), nil
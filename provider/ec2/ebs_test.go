@@ -416,6 +416,69 @@ func (s *ebsVolumeSuite) TestDescribeVolumesNotFound(c *gc.C) {
 	c.Assert(vols[0].Error, gc.ErrorMatches, "vol-42 not found")
 }
 
+func (s *ebsVolumeSuite) TestImportVolume(c *gc.C) {
+	vs := s.volumeSource(c, nil)
+	s.assertCreateVolumes(c, vs, "")
+
+	importer := vs.(storage.VolumeImporter)
+	info, err := importer.ImportVolume("vol-0", map[string]string{"foo": "bar"})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(info, jc.DeepEquals, storage.VolumeInfo{
+		VolumeId:   "vol-0",
+		Size:       10240,
+		Persistent: true,
+	})
+
+	ec2Client := ec2.StorageEC2(vs)
+	ec2Vols, err := ec2Client.Volumes([]string{"vol-0"}, nil)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(ec2Vols.Volumes, gc.HasLen, 1)
+	c.Assert(ec2Vols.Volumes[0].Tags, jc.SameContents, []awsec2.Tag{
+		{"juju-model-uuid", "deadbeef-0bad-400d-8000-4b1d0d06f00d"},
+		{"Name", "juju-sample-volume-0"},
+		{"foo", "bar"},
+	})
+}
+
+func (s *ebsVolumeSuite) TestImportVolumeOtherModel(c *gc.C) {
+	vs := s.volumeSource(c, nil)
+	s.assertCreateVolumes(c, vs, "")
+
+	importer := vs.(storage.VolumeImporter)
+	_, err := importer.ImportVolume("vol-0", map[string]string{
+		tags.JujuModel: "someone-elses-model",
+	})
+	c.Assert(err, gc.ErrorMatches, `cannot import volume "vol-0" with tag juju-model-uuid="deadbeef-0bad-400d-8000-4b1d0d06f00d"`)
+
+	// The volume's tags must not have been changed.
+	ec2Client := ec2.StorageEC2(vs)
+	ec2Vols, err := ec2Client.Volumes([]string{"vol-0"}, nil)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(ec2Vols.Volumes, gc.HasLen, 1)
+	c.Assert(ec2Vols.Volumes[0].Tags, jc.SameContents, []awsec2.Tag{
+		{"juju-model-uuid", "deadbeef-0bad-400d-8000-4b1d0d06f00d"},
+		{"Name", "juju-sample-volume-0"},
+	})
+}
+
+func (s *ebsVolumeSuite) TestImportVolumeNotFound(c *gc.C) {
+	vs := s.volumeSource(c, nil)
+	importer := vs.(storage.VolumeImporter)
+	_, err := importer.ImportVolume("vol-42", nil)
+	c.Assert(err, gc.ErrorMatches, `getting volume "vol-42": .*`)
+}
+
+func (s *ebsVolumeSuite) TestImportVolumeInUse(c *gc.C) {
+	vs := s.volumeSource(c, nil)
+	params := s.setupAttachVolumesTest(c, vs, ec2test.Running)
+	_, err := vs.AttachVolumes(params)
+	c.Assert(err, jc.ErrorIsNil)
+
+	importer := vs.(storage.VolumeImporter)
+	_, err = importer.ImportVolume("vol-0", nil)
+	c.Assert(err, gc.ErrorMatches, `cannot import volume "vol-0" with status "in-use"`)
+}
+
 func (s *ebsVolumeSuite) TestListVolumes(c *gc.C) {
 	vs := s.volumeSource(c, nil)
 	s.assertCreateVolumes(c, vs, "")
@@ -443,6 +506,121 @@ func (s *ebsVolumeSuite) TestListVolumesIgnoresRootDisks(c *gc.C) {
 	c.Assert(volIds, gc.HasLen, 0)
 }
 
+func (s *ebsVolumeSuite) snapshotParams(volumeId string) storage.SnapshotParams {
+	return storage.SnapshotParams{
+		VolumeId: volumeId,
+		ResourceTags: map[string]string{
+			tags.JujuModel: s.TestConfig["uuid"].(string),
+		},
+	}
+}
+
+func (s *ebsVolumeSuite) TestCreateSnapshots(c *gc.C) {
+	vs := s.volumeSource(c, nil)
+	s.assertCreateVolumes(c, vs, "")
+
+	snapshotter := vs.(storage.VolumeSnapshotter)
+	results, err := snapshotter.CreateSnapshots([]storage.SnapshotParams{
+		s.snapshotParams("vol-0"),
+		s.snapshotParams("vol-42"),
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, gc.HasLen, 2)
+	c.Assert(results[0].Error, jc.ErrorIsNil)
+	snapshotId := results[0].Snapshot.SnapshotId
+	c.Assert(results[0].Snapshot, jc.DeepEquals, &storage.SnapshotInfo{
+		SnapshotId: snapshotId,
+		VolumeId:   "vol-0",
+		Size:       10240,
+	})
+	c.Assert(results[1].Error, gc.ErrorMatches, `creating snapshot of "vol-42": .*`)
+
+	ec2Client := ec2.StorageEC2(vs)
+	resp, err := ec2Client.Snapshots([]string{snapshotId}, nil)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(resp.Snapshots, gc.HasLen, 1)
+	c.Assert(resp.Snapshots[0].VolumeId, gc.Equals, "vol-0")
+	c.Assert(resp.Snapshots[0].Tags, jc.SameContents, []awsec2.Tag{
+		{tags.JujuModel, s.TestConfig["uuid"].(string)},
+	})
+}
+
+func (s *ebsVolumeSuite) TestCreateSnapshotsTagFailureDeletesSnapshot(c *gc.C) {
+	vs := s.volumeSource(c, nil)
+	s.assertCreateVolumes(c, vs, "")
+
+	s.PatchValue(ec2.TagResources, func(*awsec2.EC2, map[string]string, ...string) error {
+		return errors.New("tagging failed")
+	})
+	snapshotter := vs.(storage.VolumeSnapshotter)
+	results, err := snapshotter.CreateSnapshots([]storage.SnapshotParams{
+		s.snapshotParams("vol-0"),
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, gc.HasLen, 1)
+	c.Assert(results[0].Error, gc.ErrorMatches, `creating snapshot of "vol-0": tagging snapshot: tagging failed`)
+
+	// The untagged snapshot must not be left behind.
+	ec2Client := ec2.StorageEC2(vs)
+	resp, err := ec2Client.Snapshots(nil, nil)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(resp.Snapshots, gc.HasLen, 0)
+}
+
+func (s *ebsVolumeSuite) TestListSnapshots(c *gc.C) {
+	vs := s.volumeSource(c, nil)
+	s.assertCreateVolumes(c, vs, "")
+
+	snapshotter := vs.(storage.VolumeSnapshotter)
+	results, err := snapshotter.CreateSnapshots([]storage.SnapshotParams{
+		s.snapshotParams("vol-0"),
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, gc.HasLen, 1)
+	c.Assert(results[0].Error, jc.ErrorIsNil)
+
+	// Snapshots that are not tagged with the model's UUID, such as
+	// those belonging to other models, are not listed.
+	ec2Client := ec2.StorageEC2(vs)
+	_, err = ec2Client.CreateSnapshot("vol-1", "not ours")
+	c.Assert(err, jc.ErrorIsNil)
+
+	snapshotIds, err := snapshotter.ListSnapshots()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(snapshotIds, jc.DeepEquals, []string{results[0].Snapshot.SnapshotId})
+}
+
+func (s *ebsVolumeSuite) TestDestroySnapshots(c *gc.C) {
+	vs := s.volumeSource(c, nil)
+	s.assertCreateVolumes(c, vs, "")
+
+	snapshotter := vs.(storage.VolumeSnapshotter)
+	results, err := snapshotter.CreateSnapshots([]storage.SnapshotParams{
+		s.snapshotParams("vol-0"),
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, gc.HasLen, 1)
+	c.Assert(results[0].Error, jc.ErrorIsNil)
+	snapshotId := results[0].Snapshot.SnapshotId
+
+	errs, err := snapshotter.DestroySnapshots([]string{snapshotId})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(errs, jc.DeepEquals, []error{nil})
+
+	ec2Client := ec2.StorageEC2(vs)
+	resp, err := ec2Client.Snapshots(nil, nil)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(resp.Snapshots, gc.HasLen, 0)
+}
+
+func (s *ebsVolumeSuite) TestDestroySnapshotsNotFoundReturnsNil(c *gc.C) {
+	vs := s.volumeSource(c, nil)
+	snapshotter := vs.(storage.VolumeSnapshotter)
+	errs, err := snapshotter.DestroySnapshots([]string{"snap-42"})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(errs, jc.DeepEquals, []error{nil})
+}
+
 func (s *ebsVolumeSuite) TestCreateVolumesErrors(c *gc.C) {
 	vs := s.volumeSource(c, nil)
 	volume0 := names.NewVolumeTag("0")
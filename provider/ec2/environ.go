@@ -425,12 +425,17 @@ func (e *environ) StartInstance(args environs.StartInstanceParams) (_ *environs.
 		if placement.availabilityZone.State != availableState {
 			return nil, errors.Errorf("availability zone %q is %s", placement.availabilityZone.Name, placement.availabilityZone.State)
 		}
+		if err := common.CheckZoneAllowedByConstraints(placement.availabilityZone.Name, args.Constraints); err != nil {
+			return nil, errors.Trace(err)
+		}
 		availabilityZones = append(availabilityZones, placement.availabilityZone.Name)
 	}
 
 	// If no availability zone is specified, then automatically spread across
 	// the known zones for optimal spread across the instance distribution
-	// group.
+	// group. Only the first zone is tried; if it is constrained, a
+	// ZoneSpecificCreationError is returned so the caller can try the
+	// others (see common.StartInstanceInOtherZones).
 	var zoneInstances []common.AvailabilityZoneInstances
 	if len(availabilityZones) == 0 {
 		var err error
@@ -451,6 +456,10 @@ func (e *environ) StartInstance(args environs.StartInstanceParams) (_ *environs.
 		if len(availabilityZones) == 0 {
 			return nil, errors.New("failed to determine availability zones")
 		}
+		availabilityZones, err = common.ZonesAllowedByConstraints(availabilityZones, args.Constraints)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
 	}
 
 	arches := args.Tools.Arches()
@@ -497,9 +506,9 @@ func (e *environ) StartInstance(args environs.StartInstanceParams) (_ *environs.
 
 	// If --constraints spaces=foo was passed, the provisioner will populate
 	// args.SubnetsToZones map. In AWS a subnet can span only one zone, so here
-	// we build the reverse map zonesToSubnets, which we will use to below in
-	// the RunInstance loop to provide an explicit subnet ID, rather than just
-	// AZ. This ensures instances in the same group (units of a service or all
+	// we build the reverse map zonesToSubnets, which we will use to below
+	// when running the instance to provide an explicit subnet ID, rather than
+	// just AZ. This ensures instances in the same group (units of a service or all
 	// instances when adding a machine manually) will still be evenly
 	// distributed across AZs, but only within subnets of the space constraint.
 	//
@@ -509,7 +518,8 @@ func (e *environ) StartInstance(args environs.StartInstanceParams) (_ *environs.
 	}
 
 	var instResp *ec2.RunInstancesResp
-	commonRunArgs := &ec2.RunInstances{
+	zone := availabilityZones[0]
+	runArgs := &ec2.RunInstances{
 		MinCount:            1,
 		MaxCount:            1,
 		UserData:            userData,
@@ -517,51 +527,49 @@ func (e *environ) StartInstance(args environs.StartInstanceParams) (_ *environs.
 		SecurityGroups:      groups,
 		BlockDeviceMappings: blockDeviceMappings,
 		ImageId:             spec.Image.Id,
+		AvailZone:           zone,
+	}
+
+	var subnetIDsForZone []string
+	var subnetErr error
+	if e.ecfg().vpcID() != "" && !args.Constraints.HaveSpaces() {
+		subnetIDsForZone, subnetErr = getVPCSubnetIDsForAvailabilityZone(e.ec2(), e.ecfg().vpcID(), zone)
+	} else if e.ecfg().vpcID() == "" && args.Constraints.HaveSpaces() {
+		subnetIDsForZone, subnetErr = findSubnetIDsForAvailabilityZone(zone, args.SubnetsToZones)
+	}
+
+	switch {
+	case subnetErr != nil && errors.IsNotFound(subnetErr):
+		// Treat the zone as constrained, so that another is tried.
+		return nil, errors.Wrap(subnetErr, instance.NewZoneSpecificCreationError(
+			zone, fmt.Sprintf("no matching subnets in availability zone %q", zone),
+		))
+	case subnetErr != nil:
+		return nil, errors.Annotatef(subnetErr, "getting subnets for zone %q", zone)
+	case len(subnetIDsForZone) > 1:
+		// With multiple equally suitable subnets, picking one at random
+		// will allow for better instance spread within the same zone, and
+		// still work correctly if we happen to pick a constrained subnet
+		// (we'll just treat this the same way we treat constrained zones
+		// and retry).
+		runArgs.SubnetId = subnetIDsForZone[rand.Intn(len(subnetIDsForZone))]
+		logger.Infof(
+			"selected random subnet %q from all matching in zone %q: %v",
+			runArgs.SubnetId, zone, subnetIDsForZone,
+		)
+	case len(subnetIDsForZone) == 1:
+		runArgs.SubnetId = subnetIDsForZone[0]
+		logger.Infof("selected subnet %q in zone %q", runArgs.SubnetId, zone)
 	}
 
-	for _, zone := range availabilityZones {
-		runArgs := commonRunArgs
-		runArgs.AvailZone = zone
-
-		var subnetIDsForZone []string
-		var subnetErr error
-		if e.ecfg().vpcID() != "" && !args.Constraints.HaveSpaces() {
-			subnetIDsForZone, subnetErr = getVPCSubnetIDsForAvailabilityZone(e.ec2(), e.ecfg().vpcID(), zone)
-		} else if e.ecfg().vpcID() == "" && args.Constraints.HaveSpaces() {
-			subnetIDsForZone, subnetErr = findSubnetIDsForAvailabilityZone(zone, args.SubnetsToZones)
-		}
-
-		switch {
-		case subnetErr != nil && errors.IsNotFound(subnetErr):
-			logger.Infof("no matching subnets in zone %q; assuming zone is constrained and trying another", zone)
-			continue
-		case subnetErr != nil:
-			return nil, errors.Annotatef(subnetErr, "getting subnets for zone %q", zone)
-		case len(subnetIDsForZone) > 1:
-			// With multiple equally suitable subnets, picking one at random
-			// will allow for better instance spread within the same zone, and
-			// still work correctly if we happen to pick a constrained subnet
-			// (we'll just treat this the same way we treat constrained zones
-			// and retry).
-			runArgs.SubnetId = subnetIDsForZone[rand.Intn(len(subnetIDsForZone))]
-			logger.Infof(
-				"selected random subnet %q from all matching in zone %q: %v",
-				runArgs.SubnetId, zone, subnetIDsForZone,
-			)
-		case len(subnetIDsForZone) == 1:
-			runArgs.SubnetId = subnetIDsForZone[0]
-			logger.Infof("selected subnet %q in zone %q", runArgs.SubnetId, zone)
-		}
-
-		instResp, err = runInstances(e.ec2(), runArgs)
-		if err == nil || !isZoneOrSubnetConstrainedError(err) {
-			break
-		}
-
-		logger.Infof("%q is constrained, trying another availability zone", zone)
-	}
-
+	instResp, err = runInstances(e.ec2(), runArgs)
 	if err != nil {
+		if isZoneOrSubnetConstrainedError(err) {
+			// It may be possible to start the instance in another zone.
+			err = errors.Wrap(err, instance.NewZoneSpecificCreationError(
+				zone, fmt.Sprintf("availability zone %q is constrained: %v", zone, err),
+			))
+		}
 		return nil, errors.Annotate(err, "cannot run instances")
 	}
 	if len(instResp.Instances) != 1 {
@@ -613,10 +621,12 @@ func (e *environ) StartInstance(args environs.StartInstanceParams) (_ *environs.
 	}, nil
 }
 
+var tagResources = _tagResources
+
 // tagResources calls ec2.CreateTags, tagging each of the specified resources
 // with the given tags. tagResources will retry for a short period of time
 // if it receives a *.NotFound error response from EC2.
-func tagResources(e *ec2.EC2, tags map[string]string, resourceIds ...string) error {
+func _tagResources(e *ec2.EC2, tags map[string]string, resourceIds ...string) error {
 	if len(tags) == 0 {
 		return nil
 	}
@@ -1258,37 +1268,37 @@ func (e *environ) allControllerManagedVolumes() ([]string, error) {
 	return listVolumes(e.ec2(), filter)
 }
 
-func portsToIPPerms(ports []network.PortRange) []ec2.IPPerm {
-	ipPerms := make([]ec2.IPPerm, len(ports))
-	for i, p := range ports {
+func rulesToIPPerms(rules []network.IngressRule) []ec2.IPPerm {
+	ipPerms := make([]ec2.IPPerm, len(rules))
+	for i, r := range rules {
 		ipPerms[i] = ec2.IPPerm{
-			Protocol:  p.Protocol,
-			FromPort:  p.FromPort,
-			ToPort:    p.ToPort,
-			SourceIPs: []string{"0.0.0.0/0"},
+			Protocol:  r.PortRange.Protocol,
+			FromPort:  r.PortRange.FromPort,
+			ToPort:    r.PortRange.ToPort,
+			SourceIPs: []string{r.SourceCIDR},
 		}
 	}
 	return ipPerms
 }
 
-func (e *environ) openPortsInGroup(name string, ports []network.PortRange) error {
-	if len(ports) == 0 {
+func (e *environ) openRulesInGroup(name string, rules []network.IngressRule) error {
+	if len(rules) == 0 {
 		return nil
 	}
-	// Give permissions for anyone to access the given ports.
+	// Give permissions for the given sources to access the given ports.
 	g, err := e.groupByName(name)
 	if err != nil {
 		return err
 	}
-	ipPerms := portsToIPPerms(ports)
+	ipPerms := rulesToIPPerms(rules)
 	_, err = e.ec2().AuthorizeSecurityGroup(g, ipPerms)
 	if err != nil && ec2ErrCode(err) == "InvalidPermission.Duplicate" {
-		if len(ports) == 1 {
+		if len(rules) == 1 {
 			return nil
 		}
-		// If there's more than one port and we get a duplicate error,
-		// then we go through authorizing each port individually,
-		// otherwise the ports that were *not* duplicates will have
+		// If there's more than one rule and we get a duplicate error,
+		// then we go through authorizing each rule individually,
+		// otherwise the rules that were *not* duplicates will have
 		// been ignored
 		for i := range ipPerms {
 			_, err := e.ec2().AuthorizeSecurityGroup(g, ipPerms[i:i+1])
@@ -1304,74 +1314,111 @@ func (e *environ) openPortsInGroup(name string, ports []network.PortRange) error
 	return nil
 }
 
-func (e *environ) closePortsInGroup(name string, ports []network.PortRange) error {
-	if len(ports) == 0 {
+func (e *environ) closeRulesInGroup(name string, rules []network.IngressRule) error {
+	if len(rules) == 0 {
 		return nil
 	}
-	// Revoke permissions for anyone to access the given ports.
-	// Note that ec2 allows the revocation of permissions that aren't
-	// granted, so this is naturally idempotent.
+	// Revoke permissions for the given sources to access the given
+	// ports. Note that ec2 allows the revocation of permissions that
+	// aren't granted, so this is naturally idempotent.
 	g, err := e.groupByName(name)
 	if err != nil {
 		return err
 	}
-	_, err = e.ec2().RevokeSecurityGroup(g, portsToIPPerms(ports))
+	_, err = e.ec2().RevokeSecurityGroup(g, rulesToIPPerms(rules))
 	if err != nil {
 		return fmt.Errorf("cannot close ports: %v", err)
 	}
 	return nil
 }
 
-func (e *environ) portsInGroup(name string) (ports []network.PortRange, err error) {
+func (e *environ) rulesInGroup(name string) (rules []network.IngressRule, err error) {
 	group, err := e.groupInfoByName(name)
 	if err != nil {
 		return nil, err
 	}
 	for _, p := range group.IPPerms {
-		if len(p.SourceIPs) != 1 {
+		if len(p.SourceIPs) == 0 {
 			logger.Warningf("unexpected IP permission found: %v", p)
 			continue
 		}
-		ports = append(ports, network.PortRange{
+		portRange := network.PortRange{
 			Protocol: p.Protocol,
 			FromPort: p.FromPort,
 			ToPort:   p.ToPort,
-		})
+		}
+		rules = append(rules, network.NewIngressRules(portRange, p.SourceIPs...)...)
+	}
+	network.SortIngressRules(rules)
+	return rules, nil
+}
+
+// rulePortRanges returns the distinct port ranges of the given rules.
+func rulePortRanges(rules []network.IngressRule) []network.PortRange {
+	var ports []network.PortRange
+	seen := make(map[network.PortRange]bool)
+	for _, r := range rules {
+		if !seen[r.PortRange] {
+			seen[r.PortRange] = true
+			ports = append(ports, r.PortRange)
+		}
 	}
 	network.SortPortRanges(ports)
-	return ports, nil
+	return ports
 }
 
 func (e *environ) OpenPorts(ports []network.PortRange) error {
+	return e.OpenIngressRules(network.UnrestrictedIngressRules(ports))
+}
+
+func (e *environ) ClosePorts(ports []network.PortRange) error {
+	return e.CloseIngressRules(network.UnrestrictedIngressRules(ports))
+}
+
+func (e *environ) Ports() ([]network.PortRange, error) {
+	rules, err := e.IngressRules()
+	if err != nil {
+		return nil, err
+	}
+	return rulePortRanges(rules), nil
+}
+
+// OpenIngressRules is specified in the environs.IngressRuleFirewaller
+// interface.
+func (e *environ) OpenIngressRules(rules []network.IngressRule) error {
 	if e.Config().FirewallMode() != config.FwGlobal {
 		return fmt.Errorf("invalid firewall mode %q for opening ports on model",
 			e.Config().FirewallMode())
 	}
-	if err := e.openPortsInGroup(e.globalGroupName(), ports); err != nil {
+	if err := e.openRulesInGroup(e.globalGroupName(), rules); err != nil {
 		return err
 	}
-	logger.Infof("opened ports in global group: %v", ports)
+	logger.Infof("opened ingress rules in global group: %v", rules)
 	return nil
 }
 
-func (e *environ) ClosePorts(ports []network.PortRange) error {
+// CloseIngressRules is specified in the environs.IngressRuleFirewaller
+// interface.
+func (e *environ) CloseIngressRules(rules []network.IngressRule) error {
 	if e.Config().FirewallMode() != config.FwGlobal {
 		return fmt.Errorf("invalid firewall mode %q for closing ports on model",
 			e.Config().FirewallMode())
 	}
-	if err := e.closePortsInGroup(e.globalGroupName(), ports); err != nil {
+	if err := e.closeRulesInGroup(e.globalGroupName(), rules); err != nil {
 		return err
 	}
-	logger.Infof("closed ports in global group: %v", ports)
+	logger.Infof("closed ingress rules in global group: %v", rules)
 	return nil
 }
 
-func (e *environ) Ports() ([]network.PortRange, error) {
+// IngressRules is specified in the environs.IngressRuleFirewaller
+// interface.
+func (e *environ) IngressRules() ([]network.IngressRule, error) {
 	if e.Config().FirewallMode() != config.FwGlobal {
 		return nil, fmt.Errorf("invalid firewall mode %q for retrieving ports from model",
 			e.Config().FirewallMode())
 	}
-	return e.portsInGroup(e.globalGroupName())
+	return e.rulesInGroup(e.globalGroupName())
 }
 
 func (*environ) Provider() environs.EnvironProvider {
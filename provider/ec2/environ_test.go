@@ -103,7 +103,7 @@ func pInt(i uint64) *uint64 {
 	return &i
 }
 
-func (*Suite) TestPortsToIPPerms(c *gc.C) {
+func (*Suite) TestRulesToIPPerms(c *gc.C) {
 	testCases := []struct {
 		about    string
 		ports    []network.PortRange
@@ -160,7 +160,23 @@ func (*Suite) TestPortsToIPPerms(c *gc.C) {
 
 	for i, t := range testCases {
 		c.Logf("test %d: %s", i, t.about)
-		ipperms := portsToIPPerms(t.ports)
+		ipperms := rulesToIPPerms(network.UnrestrictedIngressRules(t.ports))
 		c.Assert(ipperms, gc.DeepEquals, t.expected)
 	}
 }
+
+func (*Suite) TestRulesToIPPermsSourceCIDRs(c *gc.C) {
+	portRange := network.PortRange{FromPort: 80, ToPort: 80, Protocol: "tcp"}
+	ipperms := rulesToIPPerms(network.NewIngressRules(portRange, "10.0.0.0/8", "192.168.1.0/24"))
+	c.Assert(ipperms, gc.DeepEquals, []amzec2.IPPerm{{
+		Protocol:  "tcp",
+		FromPort:  80,
+		ToPort:    80,
+		SourceIPs: []string{"10.0.0.0/8"},
+	}, {
+		Protocol:  "tcp",
+		FromPort:  80,
+		ToPort:    80,
+		SourceIPs: []string{"192.168.1.0/24"},
+	}})
+}
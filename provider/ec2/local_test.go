@@ -844,23 +844,26 @@ var azNoDefaultSubnetErr = &amzec2.Error{
 	Message: "No default subnet for availability zone: ''us-east-1e''.",
 }
 
-func (t *localServerSuite) TestStartInstanceAvailZoneAllConstrained(c *gc.C) {
-	t.testStartInstanceAvailZoneAllConstrained(c, azConstrainedErr)
+func (t *localServerSuite) TestStartInstanceAvailZoneConstrained(c *gc.C) {
+	t.testStartInstanceAvailZoneConstrained(c, azConstrainedErr)
 }
 
 func (t *localServerSuite) TestStartInstanceVolumeTypeNotAvailable(c *gc.C) {
-	t.testStartInstanceAvailZoneAllConstrained(c, azVolumeTypeNotAvailableInZoneErr)
+	t.testStartInstanceAvailZoneConstrained(c, azVolumeTypeNotAvailableInZoneErr)
 }
 
-func (t *localServerSuite) TestStartInstanceAvailZoneAllInsufficientInstanceCapacity(c *gc.C) {
-	t.testStartInstanceAvailZoneAllConstrained(c, azInsufficientInstanceCapacityErr)
+func (t *localServerSuite) TestStartInstanceAvailZoneInsufficientInstanceCapacity(c *gc.C) {
+	t.testStartInstanceAvailZoneConstrained(c, azInsufficientInstanceCapacityErr)
 }
 
-func (t *localServerSuite) TestStartInstanceAvailZoneAllNoDefaultSubnet(c *gc.C) {
-	t.testStartInstanceAvailZoneAllConstrained(c, azNoDefaultSubnetErr)
+func (t *localServerSuite) TestStartInstanceAvailZoneNoDefaultSubnet(c *gc.C) {
+	t.testStartInstanceAvailZoneConstrained(c, azNoDefaultSubnetErr)
 }
 
-func (t *localServerSuite) testStartInstanceAvailZoneAllConstrained(c *gc.C, runInstancesError *amzec2.Error) {
+// testStartInstanceAvailZoneConstrained checks that only the first of
+// the zones is tried, and that a zone-specific error is returned so
+// that the caller can try the others.
+func (t *localServerSuite) testStartInstanceAvailZoneConstrained(c *gc.C, runInstancesError *amzec2.Error) {
 	env := t.Prepare(c)
 	err := bootstrap.Bootstrap(envtesting.BootstrapContext(c), env, bootstrap.BootstrapParams{})
 	c.Assert(err, jc.ErrorIsNil)
@@ -879,11 +882,65 @@ func (t *localServerSuite) testStartInstanceAvailZoneAllConstrained(c *gc.C, run
 	})
 	_, _, _, err = testing.StartInstance(env, "1")
 	c.Assert(err, gc.ErrorMatches, fmt.Sprintf(
-		"cannot run instances: %s \\(%s\\)",
+		`cannot run instances: availability zone "az1" is constrained: %s \(%s\)`,
 		regexp.QuoteMeta(runInstancesError.Message),
 		runInstancesError.Code,
 	))
-	c.Assert(azArgs, gc.DeepEquals, []string{"az1", "az2"})
+	cause := errors.Cause(err)
+	c.Assert(cause, jc.Satisfies, instance.IsZoneSpecificCreationError)
+	c.Assert(cause.(*instance.ZoneSpecificCreationError).Zone(), gc.Equals, "az1")
+	c.Assert(azArgs, gc.DeepEquals, []string{"az1"})
+}
+
+func (t *localServerSuite) TestStartInstanceZonesConstraint(c *gc.C) {
+	env := t.Prepare(c)
+	err := bootstrap.Bootstrap(envtesting.BootstrapContext(c), env, bootstrap.BootstrapParams{})
+	c.Assert(err, jc.ErrorIsNil)
+
+	mock := mockAvailabilityZoneAllocations{
+		result: []common.AvailabilityZoneInstances{
+			{ZoneName: "az1"}, {ZoneName: "az2"}, {ZoneName: "az3"},
+		},
+	}
+	t.PatchValue(ec2.AvailabilityZoneAllocations, mock.AvailabilityZoneAllocations)
+
+	var azArgs []string
+	t.PatchValue(ec2.RunInstances, func(e *amzec2.EC2, ri *amzec2.RunInstances) (*amzec2.RunInstancesResp, error) {
+		azArgs = append(azArgs, ri.AvailZone)
+		return nil, azConstrainedErr
+	})
+	_, _, _, err = testing.StartInstanceWithConstraints(env, "1", constraints.MustParse("zones=az3,az2"))
+	c.Assert(err, gc.ErrorMatches, "cannot run instances: .*")
+	c.Assert(azArgs, gc.DeepEquals, []string{"az2"})
+}
+
+func (t *localServerSuite) TestStartInstanceZonesConstraintPlacement(c *gc.C) {
+	env := t.Prepare(c)
+	err := bootstrap.Bootstrap(envtesting.BootstrapContext(c), env, bootstrap.BootstrapParams{})
+	c.Assert(err, jc.ErrorIsNil)
+
+	params := environs.StartInstanceParams{
+		Placement:   "zone=test-available",
+		Constraints: constraints.MustParse("zones=test-unavailable"),
+	}
+	_, err = testing.StartInstanceWithParams(env, "1", params)
+	c.Assert(err, gc.ErrorMatches, `availability zone "test-available" is not allowed by the zones constraint "test-unavailable"`)
+}
+
+func (t *localServerSuite) TestStartInstanceAvailZonePlacementConstrained(c *gc.C) {
+	env := t.Prepare(c)
+	err := bootstrap.Bootstrap(envtesting.BootstrapContext(c), env, bootstrap.BootstrapParams{})
+	c.Assert(err, jc.ErrorIsNil)
+
+	t.PatchValue(ec2.RunInstances, func(e *amzec2.EC2, ri *amzec2.RunInstances) (*amzec2.RunInstancesResp, error) {
+		return nil, azInsufficientInstanceCapacityErr
+	})
+	params := environs.StartInstanceParams{Placement: "zone=test-available"}
+	_, err = testing.StartInstanceWithParams(env, "1", params)
+	c.Assert(err, gc.ErrorMatches, `cannot run instances: availability zone "test-available" is constrained: .*`)
+	cause := errors.Cause(err)
+	c.Assert(cause, jc.Satisfies, instance.IsZoneSpecificCreationError)
+	c.Assert(cause.(*instance.ZoneSpecificCreationError).Zone(), gc.Equals, "test-available")
 }
 
 // addTestingSubnets adds a testing default VPC with 3 subnets in the EC2 test
@@ -1010,20 +1067,21 @@ func (t *localServerSuite) TestStartInstanceAvailZoneOneNoDefaultSubnetErr(c *gc
 	t.testStartInstanceAvailZoneOneConstrained(c, azNoDefaultSubnetErr)
 }
 
+// testStartInstanceAvailZoneOneConstrained checks that an instance
+// that cannot be started in one zone is started in another by
+// common.StartInstanceInOtherZones, as the provisioner does.
 func (t *localServerSuite) testStartInstanceAvailZoneOneConstrained(c *gc.C, runInstancesError *amzec2.Error) {
+	// Make a second zone available.
+	zones := append([]amzec2.AvailabilityZoneInfo{}, t.srv.zones...)
+	zones[1].State = "available"
+	t.srv.ec2srv.SetAvailabilityZones(zones)
+
 	env := t.Prepare(c)
 	err := bootstrap.Bootstrap(envtesting.BootstrapContext(c), env, bootstrap.BootstrapParams{})
 	c.Assert(err, jc.ErrorIsNil)
 
-	mock := mockAvailabilityZoneAllocations{
-		result: []common.AvailabilityZoneInstances{
-			{ZoneName: "az1"}, {ZoneName: "az2"},
-		},
-	}
-	t.PatchValue(ec2.AvailabilityZoneAllocations, mock.AvailabilityZoneAllocations)
-
 	// The first call to RunInstances fails with an error indicating the AZ
-	// is constrained. The second attempt succeeds, and so allocates to az2.
+	// is constrained. The second attempt succeeds in the other zone.
 	var azArgs []string
 	realRunInstances := *ec2.RunInstances
 	t.PatchValue(ec2.RunInstances, func(e *amzec2.EC2, ri *amzec2.RunInstances) (*amzec2.RunInstancesResp, error) {
@@ -1033,10 +1091,28 @@ func (t *localServerSuite) testStartInstanceAvailZoneOneConstrained(c *gc.C, run
 		}
 		return realRunInstances(e, ri)
 	})
-	inst, hwc := testing.AssertStartInstance(c, env, "1")
-	c.Assert(azArgs, gc.DeepEquals, []string{"az1", "az2"})
-	c.Assert(ec2.InstanceEC2(inst).AvailZone, gc.Equals, "az2")
-	c.Check(*hwc.AvailabilityZone, gc.Equals, "az2")
+	failover := &zoneFailoverEnviron{ZonedEnviron: env.(common.ZonedEnviron)}
+	inst, hwc := testing.AssertStartInstance(c, failover, "1")
+	c.Assert(failover.triedZones, jc.SameContents, []string{"test-available", "test-impaired"})
+	c.Assert(azArgs, gc.DeepEquals, failover.triedZones)
+	c.Assert(ec2.InstanceEC2(inst).AvailZone, gc.Equals, azArgs[1])
+	c.Check(*hwc.AvailabilityZone, gc.Equals, azArgs[1])
+}
+
+// zoneFailoverEnviron tries the other availability zones when an
+// instance cannot be started in the zone chosen by the environ, as
+// the provisioner does.
+type zoneFailoverEnviron struct {
+	common.ZonedEnviron
+	triedZones []string
+}
+
+func (e *zoneFailoverEnviron) StartInstance(args environs.StartInstanceParams) (*environs.StartInstanceResult, error) {
+	result, err := e.ZonedEnviron.StartInstance(args)
+	if err != nil && instance.IsZoneSpecificCreationError(errors.Cause(err)) {
+		result, e.triedZones, err = common.StartInstanceInOtherZones(e.ZonedEnviron, args, err)
+	}
+	return result, err
 }
 
 func (t *localServerSuite) TestAddresses(c *gc.C) {
@@ -13,6 +13,7 @@ import (
 	"github.com/juju/utils/set"
 
 	"github.com/juju/juju/environs/config"
+	"github.com/juju/juju/environs/tags"
 	"github.com/juju/juju/provider/gce/google"
 	"github.com/juju/juju/storage"
 )
@@ -28,6 +29,8 @@ func init() {
 type storageProvider struct{}
 
 var _ storage.Provider = (*storageProvider)(nil)
+var _ storage.VolumeImporter = (*volumeSource)(nil)
+var _ storage.VolumeSnapshotter = (*volumeSource)(nil)
 
 func (g *storageProvider) ValidateConfig(cfg *storage.Config) error {
 	return nil
@@ -195,6 +198,7 @@ func (v *volumeSource) createOneVolume(p storage.VolumeParams, instances instanc
 		Name:               volumeName,
 		PersistentDiskType: persistentType,
 		Description:        v.modelUUID,
+		SourceSnapshot:     p.SnapshotId,
 	}
 
 	gceDisks, err := v.gce.CreateDisks(zone, []google.DiskSpec{disk})
@@ -266,7 +270,7 @@ func isValidVolume(volumeName string) bool {
 }
 
 func (v *volumeSource) destroyOneVolume(volName string) error {
-	zone, _, err := parseVolumeId(volName)
+	zone, err := v.volumeZone(volName)
 	if err != nil {
 		return errors.Annotatef(err, "invalid volume id %q", volName)
 	}
@@ -316,7 +320,7 @@ func (v *volumeSource) DescribeVolumes(volNames []string) ([]storage.DescribeVol
 }
 
 func (v *volumeSource) describeOneVolume(volName string) (storage.DescribeVolumesResult, error) {
-	zone, _, err := parseVolumeId(volName)
+	zone, err := v.volumeZone(volName)
 	if err != nil {
 		return storage.DescribeVolumesResult{}, errors.Annotatef(err, "cannot describe %q", volName)
 	}
@@ -334,6 +338,166 @@ func (v *volumeSource) describeOneVolume(volName string) (storage.DescribeVolume
 	return desc, nil
 }
 
+// ImportVolume is specified on the storage.VolumeImporter interface.
+//
+// Disks created by juju are named <zone>--<uuid>; any other disk is
+// looked up by name across the availability zones. The compute API
+// this provider uses has no disk labels, and a disk's description
+// cannot be changed after it is created, so the resource tags cannot
+// be recorded on the disk. Instead, the description, which holds the
+// owning model's UUID for disks juju creates, is checked against the
+// importing model.
+func (v *volumeSource) ImportVolume(volName string, resourceTags map[string]string) (storage.VolumeInfo, error) {
+	var disk *google.Disk
+	zone, _, err := parseVolumeId(volName)
+	if err == nil {
+		disk, err = v.gce.Disk(zone, volName)
+	} else {
+		_, disk, err = v.findDisk(volName)
+	}
+	if err != nil {
+		return storage.VolumeInfo{}, errors.Annotatef(err, "cannot import %q", volName)
+	}
+	if disk.Status != google.StatusReady {
+		return storage.VolumeInfo{}, errors.Errorf(
+			"cannot import volume %q with status %q", volName, disk.Status,
+		)
+	}
+	if len(disk.Users) > 0 {
+		return storage.VolumeInfo{}, errors.Errorf(
+			"cannot import volume %q attached to %s", volName, strings.Join(disk.Users, ", "),
+		)
+	}
+	// Refuse to import a disk that belongs to another model.
+	modelUUID := v.modelUUID
+	if value, ok := resourceTags[tags.JujuModel]; ok {
+		modelUUID = value
+	}
+	if disk.Description != "" && disk.Description != modelUUID {
+		return storage.VolumeInfo{}, errors.Errorf(
+			"cannot import volume %q with description %q", volName, disk.Description,
+		)
+	}
+	return storage.VolumeInfo{
+		VolumeId:   disk.Name,
+		Size:       disk.Size,
+		Persistent: true,
+	}, nil
+}
+
+// findDisk looks up the named disk in each availability zone, and
+// returns the zone it lives in along with the disk itself.
+func (v *volumeSource) findDisk(volName string) (string, *google.Disk, error) {
+	azs, err := v.gce.AvailabilityZones("")
+	if err != nil {
+		return "", nil, errors.Annotate(err, "cannot determine availability zones")
+	}
+	var found *google.Disk
+	var foundZone string
+	for _, zone := range azs {
+		disks, err := v.gce.Disks(zone.Name())
+		if err != nil {
+			return "", nil, errors.Trace(err)
+		}
+		for _, disk := range disks {
+			if disk.Name != volName {
+				continue
+			}
+			if found != nil {
+				return "", nil, errors.Errorf(
+					"disk %q exists in zones %q and %q", volName, foundZone, zone.Name(),
+				)
+			}
+			found, foundZone = disk, zone.Name()
+		}
+	}
+	if found == nil {
+		return "", nil, errors.NotFoundf("disk %q", volName)
+	}
+	return foundZone, found, nil
+}
+
+// volumeZone returns the zone of the named volume. The zone is part of
+// the name of volumes created by juju; imported disks are looked up.
+func (v *volumeSource) volumeZone(volName string) (string, error) {
+	if zone, _, err := parseVolumeId(volName); err == nil {
+		return zone, nil
+	}
+	zone, _, err := v.findDisk(volName)
+	return zone, errors.Trace(err)
+}
+
+func nameSnapshot() (string, error) {
+	snapshotUUID, err := utils.NewUUID()
+	if err != nil {
+		return "", errors.Annotate(err, "cannot generate uuid to name the snapshot")
+	}
+	return "juju-snapshot-" + snapshotUUID.String(), nil
+}
+
+// CreateSnapshots is specified on the storage.VolumeSnapshotter interface.
+//
+// GCE snapshots are global, so the snapshot ID is the snapshot name,
+// and volumes may be created from it in any zone.
+func (v *volumeSource) CreateSnapshots(params []storage.SnapshotParams) ([]storage.CreateSnapshotsResult, error) {
+	results := make([]storage.CreateSnapshotsResult, len(params))
+	for i, p := range params {
+		snapshot, err := v.createOneSnapshot(p)
+		if err != nil {
+			results[i].Error = errors.Annotatef(err, "creating snapshot of %q", p.VolumeId)
+			continue
+		}
+		results[i].Snapshot = snapshot
+	}
+	return results, nil
+}
+
+func (v *volumeSource) createOneSnapshot(p storage.SnapshotParams) (*storage.SnapshotInfo, error) {
+	zone, err := v.volumeZone(p.VolumeId)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	snapshotName, err := nameSnapshot()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	snapshot, err := v.gce.CreateSnapshot(zone, p.VolumeId, snapshotName, v.modelUUID)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &storage.SnapshotInfo{
+		SnapshotId: snapshot.Name,
+		VolumeId:   p.VolumeId,
+		Size:       snapshot.Size,
+	}, nil
+}
+
+// ListSnapshots is specified on the storage.VolumeSnapshotter interface.
+func (v *volumeSource) ListSnapshots() ([]string, error) {
+	snapshots, err := v.gce.Snapshots()
+	if err != nil {
+		return nil, errors.Annotate(err, "cannot list snapshots")
+	}
+	var snapshotNames []string
+	for _, snapshot := range snapshots {
+		if snapshot.Description == v.modelUUID {
+			snapshotNames = append(snapshotNames, snapshot.Name)
+		}
+	}
+	return snapshotNames, nil
+}
+
+// DestroySnapshots is specified on the storage.VolumeSnapshotter interface.
+func (v *volumeSource) DestroySnapshots(snapshotNames []string) ([]error, error) {
+	results := make([]error, len(snapshotNames))
+	for i, snapshotName := range snapshotNames {
+		if err := v.gce.RemoveSnapshot(snapshotName); err != nil {
+			results[i] = errors.Annotatef(err, "cannot destroy snapshot %q", snapshotName)
+		}
+	}
+	return results, nil
+}
+
 // TODO(perrito666) These rules are yet to be defined.
 func (v *volumeSource) ValidateVolumeParams(params storage.VolumeParams) error {
 	return nil
@@ -366,7 +530,7 @@ func (v *volumeSource) AttachVolumes(attachParams []storage.VolumeAttachmentPara
 }
 
 func (v *volumeSource) attachOneVolume(volumeName string, mode google.DiskMode, instanceId string) (*google.AttachedDisk, error) {
-	zone, _, err := parseVolumeId(volumeName)
+	zone, err := v.volumeZone(volumeName)
 	if err != nil {
 		return nil, errors.Annotate(err, "invalid volume name")
 	}
@@ -399,7 +563,7 @@ func (v *volumeSource) DetachVolumes(attachParams []storage.VolumeAttachmentPara
 func (v *volumeSource) detachOneVolume(attachParam storage.VolumeAttachmentParams) error {
 	instId := attachParam.InstanceId
 	volumeName := attachParam.VolumeId
-	zone, _, err := parseVolumeId(volumeName)
+	zone, err := v.volumeZone(volumeName)
 	if err != nil {
 		return errors.Annotatef(err, "%q is not a valid volume id", volumeName)
 	}
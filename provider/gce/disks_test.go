@@ -4,6 +4,7 @@
 package gce_test
 
 import (
+	"github.com/juju/errors"
 	"github.com/juju/names"
 	jc "github.com/juju/testing/checkers"
 	gc "gopkg.in/check.v1"
@@ -261,6 +262,101 @@ func (s *volumeSourceSuite) TestDescribeVolumes(c *gc.C) {
 	c.Assert(call[0].ID, gc.Equals, volName)
 }
 
+func (s *volumeSourceSuite) TestImportVolume(c *gc.C) {
+	s.FakeConn.GoogleDisk = s.BaseDisk
+	volName := "home-zone--c930380d-8337-4bf5-b07a-9dbb5ae771e4"
+	importer := s.source.(storage.VolumeImporter)
+	info, err := importer.ImportVolume(volName, map[string]string{"foo": "bar"})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(info, jc.DeepEquals, storage.VolumeInfo{
+		VolumeId:   volName,
+		Size:       1024,
+		Persistent: true,
+	})
+
+	diskCalled, call := s.FakeConn.WasCalled("Disk")
+	c.Check(call, gc.HasLen, 1)
+	c.Assert(diskCalled, jc.IsTrue)
+	c.Assert(call[0].ZoneName, gc.Equals, "home-zone")
+	c.Assert(call[0].ID, gc.Equals, volName)
+}
+
+func (s *volumeSourceSuite) TestImportVolumeNotReady(c *gc.C) {
+	s.BaseDisk.Status = google.StatusCreating
+	s.FakeConn.GoogleDisk = s.BaseDisk
+	volName := "home-zone--c930380d-8337-4bf5-b07a-9dbb5ae771e4"
+	importer := s.source.(storage.VolumeImporter)
+	_, err := importer.ImportVolume(volName, nil)
+	c.Assert(err, gc.ErrorMatches, `cannot import volume ".*" with status "CREATING"`)
+}
+
+func (s *volumeSourceSuite) TestImportVolumeByName(c *gc.C) {
+	s.BaseDisk.Name = "some-disk"
+	s.BaseDisk.Description = ""
+	s.FakeConn.GoogleDisks = []*google.Disk{s.BaseDisk}
+	s.FakeConn.Zones = []google.AvailabilityZone{google.NewZone("home-zone", "Ready", "", "")}
+	importer := s.source.(storage.VolumeImporter)
+	info, err := importer.ImportVolume("some-disk", nil)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(info, jc.DeepEquals, storage.VolumeInfo{
+		VolumeId:   "some-disk",
+		Size:       1024,
+		Persistent: true,
+	})
+
+	disksCalled, call := s.FakeConn.WasCalled("Disks")
+	c.Check(call, gc.HasLen, 1)
+	c.Assert(disksCalled, jc.IsTrue)
+	c.Assert(call[0].ZoneName, gc.Equals, "home-zone")
+}
+
+func (s *volumeSourceSuite) TestImportVolumeNotFound(c *gc.C) {
+	s.FakeConn.Zones = []google.AvailabilityZone{google.NewZone("home-zone", "Ready", "", "")}
+	importer := s.source.(storage.VolumeImporter)
+	_, err := importer.ImportVolume("some-disk", nil)
+	c.Assert(err, gc.ErrorMatches, `cannot import "some-disk": disk "some-disk" not found`)
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+}
+
+func (s *volumeSourceSuite) TestImportVolumeAttached(c *gc.C) {
+	s.BaseDisk.Users = []string{"instance-0"}
+	s.FakeConn.GoogleDisk = s.BaseDisk
+	importer := s.source.(storage.VolumeImporter)
+	_, err := importer.ImportVolume(s.BaseDisk.Name, nil)
+	c.Assert(err, gc.ErrorMatches, `cannot import volume ".*" attached to instance-0`)
+}
+
+func (s *volumeSourceSuite) TestImportVolumeForeign(c *gc.C) {
+	s.BaseDisk.Description = "other-model-uuid"
+	s.FakeConn.GoogleDisk = s.BaseDisk
+	importer := s.source.(storage.VolumeImporter)
+	_, err := importer.ImportVolume(s.BaseDisk.Name, nil)
+	c.Assert(err, gc.ErrorMatches, `cannot import volume ".*" with description "other-model-uuid"`)
+}
+
+func (s *volumeSourceSuite) TestAttachVolumesByName(c *gc.C) {
+	s.BaseDisk.Name = "some-disk"
+	s.FakeConn.GoogleDisks = []*google.Disk{s.BaseDisk}
+	s.FakeConn.Zones = []google.AvailabilityZone{google.NewZone("home-zone", "Ready", "", "")}
+	s.FakeConn.AttachedDisk = &google.AttachedDisk{
+		VolumeName: "some-disk",
+		DeviceName: "home-zone-1234567",
+		Mode:       "READ_WRITE",
+	}
+	params := *s.attachmentParams
+	params.VolumeId = "some-disk"
+	res, err := s.source.AttachVolumes([]storage.VolumeAttachmentParams{params})
+	c.Check(err, jc.ErrorIsNil)
+	c.Assert(res, gc.HasLen, 1)
+	c.Assert(res[0].Error, jc.ErrorIsNil)
+
+	attachCalled, call := s.FakeConn.WasCalled("AttachDisk")
+	c.Check(call, gc.HasLen, 1)
+	c.Assert(attachCalled, jc.IsTrue)
+	c.Assert(call[0].ZoneName, gc.Equals, "home-zone")
+	c.Assert(call[0].VolumeName, gc.Equals, "some-disk")
+}
+
 func (s *volumeSourceSuite) TestAttachVolumes(c *gc.C) {
 	volName := "home-zone--c930380d-8337-4bf5-b07a-9dbb5ae771e4"
 	attachments := []storage.VolumeAttachmentParams{*s.attachmentParams}
@@ -302,3 +398,58 @@ func (s *volumeSourceSuite) TestDetachVolumes(c *gc.C) {
 	c.Assert(call[0].InstanceId, gc.Equals, string(s.instId))
 	c.Assert(call[0].VolumeName, gc.Equals, volName)
 }
+
+func (s *volumeSourceSuite) TestCreateSnapshots(c *gc.C) {
+	s.FakeConn.Snapshot = &google.Snapshot{
+		Name:        "juju-snapshot-0",
+		Description: s.BaseDisk.Description,
+		Size:        1024,
+		SourceDisk:  s.BaseDisk.Name,
+	}
+	snapshotter := s.source.(storage.VolumeSnapshotter)
+	results, err := snapshotter.CreateSnapshots([]storage.SnapshotParams{
+		{VolumeId: s.BaseDisk.Name},
+		{VolumeId: "some-disk"},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, gc.HasLen, 2)
+	c.Assert(results[0].Error, jc.ErrorIsNil)
+	c.Assert(results[0].Snapshot, jc.DeepEquals, &storage.SnapshotInfo{
+		SnapshotId: "juju-snapshot-0",
+		VolumeId:   s.BaseDisk.Name,
+		Size:       1024,
+	})
+	c.Assert(results[1].Error, gc.ErrorMatches, `creating snapshot of "some-disk": disk "some-disk" not found`)
+
+	called, calls := s.FakeConn.WasCalled("CreateSnapshot")
+	c.Assert(called, jc.IsTrue)
+	c.Assert(calls, gc.HasLen, 1)
+	c.Assert(calls[0].ZoneName, gc.Equals, "home-zone")
+	c.Assert(calls[0].VolumeName, gc.Equals, s.BaseDisk.Name)
+	c.Assert(calls[0].ID, gc.Matches, "juju-snapshot-.*")
+	c.Assert(calls[0].Description, gc.Equals, s.Env.Config().UUID())
+}
+
+func (s *volumeSourceSuite) TestListSnapshots(c *gc.C) {
+	s.FakeConn.Snapshots = []*google.Snapshot{{
+		Name:        "juju-snapshot-0",
+		Description: s.Env.Config().UUID(),
+	}, {
+		Name:        "juju-snapshot-1",
+		Description: "a-different-model-uuid",
+	}}
+	snapshotNames, err := s.source.(storage.VolumeSnapshotter).ListSnapshots()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(snapshotNames, jc.DeepEquals, []string{"juju-snapshot-0"})
+}
+
+func (s *volumeSourceSuite) TestDestroySnapshots(c *gc.C) {
+	errs, err := s.source.(storage.VolumeSnapshotter).DestroySnapshots([]string{"juju-snapshot-0"})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(errs, jc.DeepEquals, []error{nil})
+
+	called, calls := s.FakeConn.WasCalled("RemoveSnapshot")
+	c.Assert(called, jc.IsTrue)
+	c.Assert(calls, gc.HasLen, 1)
+	c.Assert(calls[0].ID, gc.Equals, "juju-snapshot-0")
+}
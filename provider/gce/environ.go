@@ -30,6 +30,10 @@ type gceConnection interface {
 	OpenPorts(fwname string, ports ...network.PortRange) error
 	ClosePorts(fwname string, ports ...network.PortRange) error
 
+	IngressRules(fwname string) ([]network.IngressRule, error)
+	OpenIngressRules(fwname string, rules ...network.IngressRule) error
+	CloseIngressRules(fwname string, rules ...network.IngressRule) error
+
 	AvailabilityZones(region string) ([]google.AvailabilityZone, error)
 
 	// Storage related methods.
@@ -52,6 +56,14 @@ type gceConnection interface {
 	DetachDisk(zone, instanceId, volumeName string) error
 	// InstanceDisks returns a list of the disks attached to the passed instance.
 	InstanceDisks(zone, instanceId string) ([]*google.AttachedDisk, error)
+	// CreateSnapshot will snapshot the disk identified by <volumeName> in
+	// <zone>, naming the snapshot <snapshotName>, and return a Snapshot
+	// representing it or error.
+	CreateSnapshot(zone, volumeName, snapshotName, description string) (*google.Snapshot, error)
+	// Snapshots will return a list of the snapshots in the project.
+	Snapshots() ([]*google.Snapshot, error)
+	// RemoveSnapshot will destroy the snapshot identified by <name>.
+	RemoveSnapshot(name string) error
 }
 
 type environ struct {
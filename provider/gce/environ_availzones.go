@@ -93,6 +93,9 @@ func (env *environ) parseAvailabilityZones(args environs.StartInstanceParams) ([
 			return nil, errors.Trace(err)
 		}
 		// TODO(ericsnow) Fail if placement.Zone is not in the env's configured region?
+		if err := common.CheckZoneAllowedByConstraints(placement.Zone.Name(), args.Constraints); err != nil {
+			return nil, errors.Trace(err)
+		}
 		return []string{placement.Zone.Name()}, nil
 	}
 
@@ -122,5 +125,9 @@ func (env *environ) parseAvailabilityZones(args environs.StartInstanceParams) ([
 		return nil, errors.NotFoundf("failed to determine availability zones")
 	}
 
+	zoneNames, err = common.ZonesAllowedByConstraints(zoneNames, args.Constraints)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
 	return zoneNames, nil
 }
@@ -7,6 +7,7 @@ import (
 	jc "github.com/juju/testing/checkers"
 	gc "gopkg.in/check.v1"
 
+	"github.com/juju/juju/network"
 	"github.com/juju/juju/provider/gce"
 )
 
@@ -57,6 +58,29 @@ func (s *environNetSuite) TestClosePortsAPI(c *gc.C) {
 	c.Check(s.FakeConn.Calls[0].PortRanges, jc.DeepEquals, s.Ports)
 }
 
+func (s *environNetSuite) TestOpenIngressRulesAPI(c *gc.C) {
+	fwname := gce.GlobalFirewallName(s.Env)
+	rules := network.NewIngressRules(s.Ports[0], "10.0.0.0/8")
+	err := s.Env.OpenIngressRules(rules)
+	c.Assert(err, jc.ErrorIsNil)
+
+	c.Check(s.FakeConn.Calls, gc.HasLen, 1)
+	c.Check(s.FakeConn.Calls[0].FuncName, gc.Equals, "OpenIngressRules")
+	c.Check(s.FakeConn.Calls[0].FirewallName, gc.Equals, fwname)
+	c.Check(s.FakeConn.Calls[0].Rules, jc.DeepEquals, rules)
+}
+
+func (s *environNetSuite) TestIngressRules(c *gc.C) {
+	rules := network.NewIngressRules(s.Ports[0], "10.0.0.0/8")
+	s.FakeConn.Rules = rules
+
+	result, err := s.Env.IngressRules()
+	c.Assert(err, jc.ErrorIsNil)
+
+	c.Check(result, jc.DeepEquals, rules)
+	c.Check(s.FakeConn.Calls[0].FuncName, gc.Equals, "IngressRules")
+}
+
 func (s *environNetSuite) TestPorts(c *gc.C) {
 	s.FakeConn.PortRanges = s.Ports
 
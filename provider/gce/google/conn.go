@@ -35,6 +35,9 @@ type rawConnectionWrapper interface {
 	// the named firewall and returns it. If the firewall is not found,
 	// errors.NotFound is returned.
 	GetFirewall(projectID, name string) (*compute.Firewall, error)
+	// ListFirewalls sends an API request to GCE for the firewalls in
+	// the project for which the name starts with the provided prefix.
+	ListFirewalls(projectID, prefix string) ([]*compute.Firewall, error)
 	// AddFirewall requests GCE to add a firewall with the provided info.
 	// If the firewall already exists then an error will be returned.
 	// The call blocks until the firewall is added or the request fails.
@@ -70,6 +73,16 @@ type rawConnectionWrapper interface {
 	// InstanceDisks returns the disks attached to the instance identified
 	// by instanceId
 	InstanceDisks(project, zone, instanceId string) ([]*compute.AttachedDisk, error)
+	// CreateSnapshot will create a snapshot, matching the specified
+	// spec, of the disk identified by diskId.
+	CreateSnapshot(project, zone, diskId string, spec *compute.Snapshot) error
+	// ListSnapshots returns a list of snapshots available for a given
+	// project.
+	ListSnapshots(project string) ([]*compute.Snapshot, error)
+	// GetSnapshot will return the snapshot correspondent to the passed id.
+	GetSnapshot(project, id string) (*compute.Snapshot, error)
+	// RemoveSnapshot will delete the snapshot identified by id.
+	RemoveSnapshot(project, id string) error
 }
 
 // TODO(ericsnow) Add specific error types for common failures
@@ -142,3 +142,37 @@ func (gce *Connection) InstanceDisks(zone, instanceId string) ([]*AttachedDisk,
 	}
 	return att, nil
 }
+
+// CreateSnapshot implements storage section of gceConnection.
+func (gce *Connection) CreateSnapshot(zone, volumeName, snapshotName, description string) (*Snapshot, error) {
+	spec := &compute.Snapshot{
+		Name:        snapshotName,
+		Description: description,
+	}
+	if err := gce.raw.CreateSnapshot(gce.projectID, zone, volumeName, spec); err != nil {
+		return nil, errors.Annotatef(err, "cannot create snapshot %q", snapshotName)
+	}
+	snapshot, err := gce.raw.GetSnapshot(gce.projectID, snapshotName)
+	if err != nil {
+		return nil, errors.Annotatef(err, "cannot get snapshot %q", snapshotName)
+	}
+	return NewSnapshot(snapshot), nil
+}
+
+// Snapshots implements storage section of gceConnection.
+func (gce *Connection) Snapshots() ([]*Snapshot, error) {
+	computeSnapshots, err := gce.raw.ListSnapshots(gce.projectID)
+	if err != nil {
+		return nil, errors.Annotate(err, "cannot list snapshots")
+	}
+	snapshots := make([]*Snapshot, len(computeSnapshots))
+	for i, snapshot := range computeSnapshots {
+		snapshots[i] = NewSnapshot(snapshot)
+	}
+	return snapshots, nil
+}
+
+// RemoveSnapshot implements storage section of gceConnection.
+func (gce *Connection) RemoveSnapshot(name string) error {
+	return gce.raw.RemoveSnapshot(gce.projectID, name)
+}
@@ -142,3 +142,60 @@ func (s *connSuite) TestConnectionInstanceDisks(c *gc.C) {
 	c.Check(s.FakeConn.Calls[0].ZoneName, gc.Equals, "home-zone")
 	c.Check(s.FakeConn.Calls[0].InstanceId, gc.Equals, "a-fake-instance")
 }
+
+func (s *connSuite) TestConnectionCreateSnapshot(c *gc.C) {
+	s.FakeConn.Snapshot = &compute.Snapshot{
+		Name:        "juju-snapshot-0",
+		Description: "a-model-uuid",
+		DiskSizeGb:  2,
+		SourceDisk:  "https://bogus/url/project/aproject/zone/azone/disk/" + fakeVolName,
+		Status:      "READY",
+	}
+	snapshot, err := s.Conn.CreateSnapshot("home-zone", fakeVolName, "juju-snapshot-0", "a-model-uuid")
+	c.Check(err, jc.ErrorIsNil)
+	c.Assert(snapshot, jc.DeepEquals, &google.Snapshot{
+		Name:        "juju-snapshot-0",
+		Description: "a-model-uuid",
+		Size:        2048,
+		SourceDisk:  fakeVolName,
+		Status:      "READY",
+	})
+
+	c.Check(s.FakeConn.Calls, gc.HasLen, 2)
+	c.Check(s.FakeConn.Calls[0].FuncName, gc.Equals, "CreateSnapshot")
+	c.Check(s.FakeConn.Calls[0].ProjectID, gc.Equals, "spam")
+	c.Check(s.FakeConn.Calls[0].ZoneName, gc.Equals, "home-zone")
+	c.Check(s.FakeConn.Calls[0].ID, gc.Equals, fakeVolName)
+	c.Check(s.FakeConn.Calls[0].Snapshot, jc.DeepEquals, &compute.Snapshot{
+		Name:        "juju-snapshot-0",
+		Description: "a-model-uuid",
+	})
+	c.Check(s.FakeConn.Calls[1].FuncName, gc.Equals, "GetSnapshot")
+	c.Check(s.FakeConn.Calls[1].ID, gc.Equals, "juju-snapshot-0")
+}
+
+func (s *connSuite) TestConnectionSnapshots(c *gc.C) {
+	s.FakeConn.Snapshots = []*compute.Snapshot{{
+		Name:       "juju-snapshot-0",
+		DiskSizeGb: 1,
+	}}
+	snapshots, err := s.Conn.Snapshots()
+	c.Check(err, jc.ErrorIsNil)
+	c.Assert(snapshots, gc.HasLen, 1)
+	c.Assert(snapshots[0].Name, gc.Equals, "juju-snapshot-0")
+	c.Assert(snapshots[0].Size, gc.Equals, uint64(1024))
+
+	c.Check(s.FakeConn.Calls, gc.HasLen, 1)
+	c.Check(s.FakeConn.Calls[0].FuncName, gc.Equals, "ListSnapshots")
+	c.Check(s.FakeConn.Calls[0].ProjectID, gc.Equals, "spam")
+}
+
+func (s *connSuite) TestConnectionRemoveSnapshot(c *gc.C) {
+	err := s.Conn.RemoveSnapshot("juju-snapshot-0")
+	c.Check(err, jc.ErrorIsNil)
+
+	c.Check(s.FakeConn.Calls, gc.HasLen, 1)
+	c.Check(s.FakeConn.Calls[0].FuncName, gc.Equals, "RemoveSnapshot")
+	c.Check(s.FakeConn.Calls[0].ProjectID, gc.Equals, "spam")
+	c.Check(s.FakeConn.Calls[0].ID, gc.Equals, "juju-snapshot-0")
+}
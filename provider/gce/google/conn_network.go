@@ -4,7 +4,12 @@
 package google
 
 import (
+	"crypto/sha1"
+	"fmt"
+	"sort"
+
 	"github.com/juju/errors"
+	"google.golang.org/api/compute/v1"
 
 	"github.com/juju/juju/network"
 )
@@ -20,7 +25,11 @@ func (gce Connection) Ports(fwname string) ([]network.PortRange, error) {
 	if err != nil {
 		return nil, errors.Annotate(err, "while getting ports from GCE")
 	}
+	return firewallPorts(firewall)
+}
 
+// firewallPorts returns the port ranges allowed by the firewall.
+func firewallPorts(firewall *compute.Firewall) ([]network.PortRange, error) {
 	var ports []network.PortRange
 	for _, allowed := range firewall.Allowed {
 		for _, portRangeStr := range allowed.Ports {
@@ -43,10 +52,14 @@ func (gce Connection) Ports(fwname string) ([]network.PortRange, error) {
 // ports it already has open. The call blocks until the ports are
 // opened or the request fails.
 func (gce Connection) OpenPorts(fwname string, ports ...network.PortRange) error {
+	return gce.openPorts(fwname, fwname, network.AnySourceCIDR, ports)
+}
+
+func (gce Connection) openPorts(name, target, sourceCIDR string, ports []network.PortRange) error {
 	// TODO(ericsnow) Short-circuit if ports is empty.
 
 	// Compose the full set of open ports.
-	currentPorts, err := gce.Ports(fwname)
+	currentPorts, err := gce.Ports(name)
 	if err != nil {
 		return errors.Trace(err)
 	}
@@ -59,7 +72,7 @@ func (gce Connection) OpenPorts(fwname string, ports ...network.PortRange) error
 	// Send the request, depending on the current ports.
 	if currentPortsSet.IsEmpty() {
 		// Create a new firewall.
-		firewall := firewallSpec(fwname, inputPortsSet)
+		firewall := sourceFirewallSpec(name, target, sourceCIDR, inputPortsSet)
 		if err := gce.raw.AddFirewall(gce.projectID, firewall); err != nil {
 			return errors.Annotatef(err, "opening port(s) %+v", ports)
 		}
@@ -68,8 +81,8 @@ func (gce Connection) OpenPorts(fwname string, ports ...network.PortRange) error
 
 	// Update an existing firewall.
 	newPortsSet := currentPortsSet.Union(inputPortsSet)
-	firewall := firewallSpec(fwname, newPortsSet)
-	if err := gce.raw.UpdateFirewall(gce.projectID, fwname, firewall); err != nil {
+	firewall := sourceFirewallSpec(name, target, sourceCIDR, newPortsSet)
+	if err := gce.raw.UpdateFirewall(gce.projectID, name, firewall); err != nil {
 		return errors.Annotatef(err, "opening port(s) %+v", ports)
 	}
 	return nil
@@ -82,8 +95,12 @@ func (gce Connection) OpenPorts(fwname string, ports ...network.PortRange) error
 // match the provided port ranges. The call blocks until the ports are
 // closed or the request fails.
 func (gce Connection) ClosePorts(fwname string, ports ...network.PortRange) error {
+	return gce.closePorts(fwname, fwname, network.AnySourceCIDR, ports)
+}
+
+func (gce Connection) closePorts(name, target, sourceCIDR string, ports []network.PortRange) error {
 	// Compose the full set of open ports.
-	currentPorts, err := gce.Ports(fwname)
+	currentPorts, err := gce.Ports(name)
 	if err != nil {
 		return errors.Trace(err)
 	}
@@ -98,16 +115,108 @@ func (gce Connection) ClosePorts(fwname string, ports ...network.PortRange) erro
 	if newPortsSet.IsEmpty() {
 		// Delete a firewall.
 		// TODO(ericsnow) Handle case where firewall does not exist.
-		if err := gce.raw.RemoveFirewall(gce.projectID, fwname); err != nil {
+		if err := gce.raw.RemoveFirewall(gce.projectID, name); err != nil {
 			return errors.Annotatef(err, "closing port(s) %+v", ports)
 		}
 		return nil
 	}
 
 	// Update an existing firewall.
-	firewall := firewallSpec(fwname, newPortsSet)
-	if err := gce.raw.UpdateFirewall(gce.projectID, fwname, firewall); err != nil {
+	firewall := sourceFirewallSpec(name, target, sourceCIDR, newPortsSet)
+	if err := gce.raw.UpdateFirewall(gce.projectID, name, firewall); err != nil {
 		return errors.Annotatef(err, "closing port(s) %+v", ports)
 	}
 	return nil
 }
+
+// IngressRules returns the ingress rules for instances tagged with the
+// given firewall name. A GCE firewall has a single set of source
+// ranges, so the rules are spread over the named firewall, which is
+// open to any source, and one firewall for each source CIDR that ports
+// have been restricted to (see sourceFirewallName).
+func (gce Connection) IngressRules(fwname string) ([]network.IngressRule, error) {
+	firewalls, err := gce.raw.ListFirewalls(gce.projectID, fwname)
+	if err != nil {
+		return nil, errors.Annotate(err, "while getting ingress rules from GCE")
+	}
+
+	var rules []network.IngressRule
+	for _, firewall := range firewalls {
+		// Firewalls for other targets may share the prefix (e.g. the
+		// global firewall name is a prefix of the machine ones).
+		if len(firewall.TargetTags) != 1 || firewall.TargetTags[0] != fwname {
+			continue
+		}
+		ports, err := firewallPorts(firewall)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		for _, sourceCIDR := range firewall.SourceRanges {
+			for _, portRange := range ports {
+				rules = append(rules, network.IngressRule{
+					PortRange:  portRange,
+					SourceCIDR: sourceCIDR,
+				})
+			}
+		}
+	}
+	network.SortIngressRules(rules)
+	return rules, nil
+}
+
+// OpenIngressRules sends requests to the GCE API to open the provided
+// ingress rules for instances tagged with the given firewall name.
+// The call blocks until the rules are opened or a request fails.
+func (gce Connection) OpenIngressRules(fwname string, rules ...network.IngressRule) error {
+	bySource := rulesBySource(rules)
+	for _, sourceCIDR := range sortedSources(bySource) {
+		name := sourceFirewallName(fwname, sourceCIDR)
+		if err := gce.openPorts(name, fwname, sourceCIDR, bySource[sourceCIDR]); err != nil {
+			return errors.Trace(err)
+		}
+	}
+	return nil
+}
+
+// CloseIngressRules sends requests to the GCE API to close the provided
+// ingress rules for instances tagged with the given firewall name.
+// The call blocks until the rules are closed or a request fails.
+func (gce Connection) CloseIngressRules(fwname string, rules ...network.IngressRule) error {
+	bySource := rulesBySource(rules)
+	for _, sourceCIDR := range sortedSources(bySource) {
+		name := sourceFirewallName(fwname, sourceCIDR)
+		if err := gce.closePorts(name, fwname, sourceCIDR, bySource[sourceCIDR]); err != nil {
+			return errors.Trace(err)
+		}
+	}
+	return nil
+}
+
+// sourceFirewallName returns the name of the firewall that opens ports
+// to the given source CIDR for instances tagged with fwname. Ports open
+// to any source use fwname itself; otherwise a short hash of the CIDR
+// is appended, since firewall names may not contain "." or "/" and are
+// limited to 63 characters.
+func sourceFirewallName(fwname, sourceCIDR string) string {
+	if sourceCIDR == network.AnySourceCIDR {
+		return fwname
+	}
+	return fmt.Sprintf("%s-%x", fwname, sha1.Sum([]byte(sourceCIDR)))[:len(fwname)+9]
+}
+
+func rulesBySource(rules []network.IngressRule) map[string][]network.PortRange {
+	bySource := make(map[string][]network.PortRange)
+	for _, rule := range rules {
+		bySource[rule.SourceCIDR] = append(bySource[rule.SourceCIDR], rule.PortRange)
+	}
+	return bySource
+}
+
+func sortedSources(bySource map[string][]network.PortRange) []string {
+	sources := make([]string, 0, len(bySource))
+	for sourceCIDR := range bySource {
+		sources = append(sources, sourceCIDR)
+	}
+	sort.Strings(sources)
+	return sources
+}
@@ -12,6 +12,7 @@ import (
 	gc "gopkg.in/check.v1"
 
 	"github.com/juju/juju/network"
+	"github.com/juju/juju/provider/gce/google"
 )
 
 func (s *connSuite) TestConnectionPorts(c *gc.C) {
@@ -173,3 +174,91 @@ func (s *connSuite) TestConnectionClosePortsUpdate(c *gc.C) {
 		}},
 	})
 }
+
+func (s *connSuite) TestConnectionIngressRules(c *gc.C) {
+	s.FakeConn.Firewalls = []*compute.Firewall{{
+		Name:         "spam",
+		TargetTags:   []string{"spam"},
+		SourceRanges: []string{"0.0.0.0/0"},
+		Allowed: []*compute.FirewallAllowed{{
+			IPProtocol: "tcp",
+			Ports:      []string{"80"},
+		}},
+	}, {
+		Name:         "spam-10174f2d",
+		TargetTags:   []string{"spam"},
+		SourceRanges: []string{"10.0.0.0/8"},
+		Allowed: []*compute.FirewallAllowed{{
+			IPProtocol: "tcp",
+			Ports:      []string{"22"},
+		}},
+	}, {
+		Name:         "spam-machine-0",
+		TargetTags:   []string{"spam-machine-0"},
+		SourceRanges: []string{"0.0.0.0/0"},
+		Allowed: []*compute.FirewallAllowed{{
+			IPProtocol: "tcp",
+			Ports:      []string{"443"},
+		}},
+	}}
+
+	rules, err := s.Conn.IngressRules("spam")
+	c.Assert(err, jc.ErrorIsNil)
+
+	c.Check(rules, jc.DeepEquals, []network.IngressRule{
+		{network.MustParsePortRange("22/tcp"), "10.0.0.0/8"},
+		{network.MustParsePortRange("80/tcp"), "0.0.0.0/0"},
+	})
+	c.Check(s.FakeConn.Calls, gc.HasLen, 1)
+	c.Check(s.FakeConn.Calls[0].FuncName, gc.Equals, "ListFirewalls")
+	c.Check(s.FakeConn.Calls[0].Prefix, gc.Equals, "spam")
+}
+
+func (s *connSuite) TestConnectionOpenIngressRulesRestricted(c *gc.C) {
+	s.FakeConn.Err = errors.NotFoundf("spam")
+
+	err := s.Conn.OpenIngressRules("spam", network.IngressRule{
+		PortRange:  network.MustParsePortRange("22/tcp"),
+		SourceCIDR: "10.0.0.0/8",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	name := google.SourceFirewallName("spam", "10.0.0.0/8")
+	c.Check(name, gc.Matches, "spam-[0-9a-f]{8}")
+	c.Check(s.FakeConn.Calls, gc.HasLen, 2)
+	c.Check(s.FakeConn.Calls[0].FuncName, gc.Equals, "GetFirewall")
+	c.Check(s.FakeConn.Calls[0].Name, gc.Equals, name)
+	c.Check(s.FakeConn.Calls[1].FuncName, gc.Equals, "AddFirewall")
+	c.Check(s.FakeConn.Calls[1].Firewall, jc.DeepEquals, &compute.Firewall{
+		Name:         name,
+		TargetTags:   []string{"spam"},
+		SourceRanges: []string{"10.0.0.0/8"},
+		Allowed: []*compute.FirewallAllowed{{
+			IPProtocol: "tcp",
+			Ports:      []string{"22"},
+		}},
+	})
+}
+
+func (s *connSuite) TestConnectionCloseIngressRulesRestricted(c *gc.C) {
+	name := google.SourceFirewallName("spam", "10.0.0.0/8")
+	s.FakeConn.Firewall = &compute.Firewall{
+		Name:         name,
+		TargetTags:   []string{"spam"},
+		SourceRanges: []string{"10.0.0.0/8"},
+		Allowed: []*compute.FirewallAllowed{{
+			IPProtocol: "tcp",
+			Ports:      []string{"22"},
+		}},
+	}
+
+	err := s.Conn.CloseIngressRules("spam", network.IngressRule{
+		PortRange:  network.MustParsePortRange("22/tcp"),
+		SourceCIDR: "10.0.0.0/8",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	c.Check(s.FakeConn.Calls, gc.HasLen, 2)
+	c.Check(s.FakeConn.Calls[1].FuncName, gc.Equals, "RemoveFirewall")
+	c.Check(s.FakeConn.Calls[1].Name, gc.Equals, name)
+}
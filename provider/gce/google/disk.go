@@ -114,6 +114,9 @@ type DiskSpec struct {
 	// Description was picked because it is not mutable (actually no field is) for disks.
 	// There is a metadata API but it is not supported for disks for the moment.
 	Description string
+	// SourceSnapshot is the name of the snapshot from which the disk
+	// should be initialized, if any. (detached only)
+	SourceSnapshot string
 }
 
 // TooSmall checks the spec's size hint and indicates whether or not
@@ -175,13 +178,17 @@ func (ds *DiskSpec) newDetached() (*compute.Disk, error) {
 	if ds.PersistentDiskType == DiskLocalSSD {
 		return nil, errors.New("cannot create local ssd disks detached")
 	}
-	return &compute.Disk{
+	disk := &compute.Disk{
 		Name:        ds.Name,
 		SizeGb:      int64(ds.SizeGB()),
 		SourceImage: ds.ImageURL,
 		Type:        string(ds.PersistentDiskType),
 		Description: ds.Description,
-	}, nil
+	}
+	if ds.SourceSnapshot != "" {
+		disk.SourceSnapshot = "global/snapshots/" + ds.SourceSnapshot
+	}
+	return disk, nil
 }
 
 // AttachedDisk represents a disk that is attached to an instance.
@@ -214,6 +221,8 @@ type Disk struct {
 	Zone string
 	// DiskStatus holds the status of he aforementioned disk.
 	Status DiskStatus
+	// Users holds the links to the instances the disk is attached to.
+	Users []string
 }
 
 func NewDisk(cd *compute.Disk) *Disk {
@@ -225,6 +234,32 @@ func NewDisk(cd *compute.Disk) *Disk {
 		Type:        DiskType(cd.Type),
 		Zone:        cd.Zone,
 		Status:      DiskStatus(cd.Status),
+		Users:       cd.Users,
 	}
 	return d
 }
+
+// Snapshot represents a point-in-time copy of a persistent disk.
+type Snapshot struct {
+	// Name is a unique identifier string for each snapshot.
+	Name string
+	// Description holds the description field for a snapshot, we
+	// store env UUID here.
+	Description string
+	// Size is the size of the snapshotted disk, in mbit.
+	Size uint64
+	// SourceDisk is the name of the disk that was snapshotted.
+	SourceDisk string
+	// Status holds the status of the snapshot.
+	Status string
+}
+
+func NewSnapshot(cs *compute.Snapshot) *Snapshot {
+	return &Snapshot{
+		Name:        cs.Name,
+		Description: cs.Description,
+		Size:        gibToMib(cs.DiskSizeGb),
+		SourceDisk:  sourceToVolumeName(cs.SourceDisk),
+		Status:      cs.Status,
+	}
+}
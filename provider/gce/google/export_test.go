@@ -10,12 +10,13 @@ import (
 var (
 	NewRawConnection = &newRawConnection
 
-	NewInstanceRaw    = newInstance
-	PackMetadata      = packMetadata
-	UnpackMetadata    = unpackMetadata
-	FormatMachineType = formatMachineType
-	FirewallSpec      = firewallSpec
-	ExtractAddresses  = extractAddresses
+	NewInstanceRaw     = newInstance
+	PackMetadata       = packMetadata
+	UnpackMetadata     = unpackMetadata
+	FormatMachineType  = formatMachineType
+	FirewallSpec       = firewallSpec
+	SourceFirewallName = sourceFirewallName
+	ExtractAddresses   = extractAddresses
 )
 
 func SetRawConn(conn *Connection, raw rawConnectionWrapper) {
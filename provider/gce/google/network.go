@@ -58,14 +58,21 @@ func (ns *NetworkSpec) newInterface(name string) *compute.NetworkInterface {
 // firewallSpec expands a port range set in to compute.FirewallAllowed
 // and returns a compute.Firewall for the provided name.
 func firewallSpec(name string, ps network.PortSet) *compute.Firewall {
+	return sourceFirewallSpec(name, name, network.AnySourceCIDR, ps)
+}
+
+// sourceFirewallSpec returns a compute.Firewall for the provided name
+// that opens the port range set to traffic from the source CIDR, for
+// instances tagged with the target.
+func sourceFirewallSpec(name, target, sourceCIDR string, ps network.PortSet) *compute.Firewall {
 	firewall := compute.Firewall{
 		// Allowed is set below.
 		// Description is not set.
 		Name: name,
 		// Network: (defaults to global)
 		// SourceTags is not set.
-		TargetTags:   []string{name},
-		SourceRanges: []string{"0.0.0.0/0"},
+		TargetTags:   []string{target},
+		SourceRanges: []string{sourceCIDR},
 	}
 
 	for _, protocol := range ps.Protocols() {
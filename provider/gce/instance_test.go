@@ -8,6 +8,7 @@ import (
 	gc "gopkg.in/check.v1"
 
 	"github.com/juju/juju/instance"
+	"github.com/juju/juju/network"
 	"github.com/juju/juju/provider/gce"
 	"github.com/juju/juju/provider/gce/google"
 )
@@ -68,6 +69,17 @@ func (s *instanceSuite) TestClosePortsAPI(c *gc.C) {
 	c.Check(s.FakeConn.Calls[0].PortRanges, jc.DeepEquals, s.Ports)
 }
 
+func (s *instanceSuite) TestCloseIngressRulesAPI(c *gc.C) {
+	rules := network.NewIngressRules(s.Ports[0], "10.0.0.0/8")
+	err := s.Instance.CloseIngressRules("spam", rules)
+	c.Assert(err, jc.ErrorIsNil)
+
+	c.Check(s.FakeConn.Calls, gc.HasLen, 1)
+	c.Check(s.FakeConn.Calls[0].FuncName, gc.Equals, "CloseIngressRules")
+	c.Check(s.FakeConn.Calls[0].FirewallName, gc.Equals, s.InstName)
+	c.Check(s.FakeConn.Calls[0].Rules, jc.DeepEquals, rules)
+}
+
 func (s *instanceSuite) TestPorts(c *gc.C) {
 	s.FakeConn.PortRanges = s.Ports
 
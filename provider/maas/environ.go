@@ -860,6 +860,9 @@ func (environ *maasEnviron) StartInstance(args environs.StartInstanceParams) (
 		}
 		switch {
 		case placement.zoneName != "":
+			if err := common.CheckZoneAllowedByConstraints(placement.zoneName, args.Constraints); err != nil {
+				return nil, errors.Trace(err)
+			}
 			availabilityZones = append(availabilityZones, placement.zoneName)
 		default:
 			nodeName = placement.nodeName
@@ -891,6 +894,10 @@ func (environ *maasEnviron) StartInstance(args environs.StartInstanceParams) (
 				availabilityZones = append(availabilityZones, z.ZoneName)
 			}
 		}
+		availabilityZones, err = common.ZonesAllowedByConstraints(availabilityZones, args.Constraints)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
 	}
 	if len(availabilityZones) == 0 {
 		availabilityZones = []string{""}
@@ -138,6 +138,14 @@ func (s *environSuite) TestConstraintsValidator(c *gc.C) {
 	c.Assert(unsupported, jc.SameContents, []string{"cpu-power", "instance-type", "tags", "virt-type"})
 }
 
+func (s *environSuite) TestConstraintsValidatorRejectsZones(c *gc.C) {
+	validator, err := s.env.ConstraintsValidator()
+	c.Assert(err, jc.ErrorIsNil)
+	cons := constraints.MustParse("mem=1G zones=az1")
+	_, err = validator.Validate(cons)
+	c.Assert(err, gc.ErrorMatches, `constraint "zones" is not supported`)
+}
+
 type bootstrapSuite struct {
 	coretesting.FakeJujuXDGDataHomeSuite
 	env *manualEnviron
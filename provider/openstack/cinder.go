@@ -99,6 +99,8 @@ type cinderVolumeSource struct {
 }
 
 var _ storage.VolumeSource = (*cinderVolumeSource)(nil)
+var _ storage.VolumeImporter = (*cinderVolumeSource)(nil)
+var _ storage.VolumeSnapshotter = (*cinderVolumeSource)(nil)
 
 // CreateVolumes implements storage.VolumeSource.
 func (s *cinderVolumeSource) CreateVolumes(args []storage.VolumeParams) ([]storage.CreateVolumesResult, error) {
@@ -127,6 +129,7 @@ func (s *cinderVolumeSource) createVolume(arg storage.VolumeParams) (*storage.Vo
 		// TODO(axw) use the AZ of the initially attached machine.
 		AvailabilityZone: "",
 		Metadata:         metadata,
+		SnapshotId:       arg.SnapshotId,
 	})
 	if err != nil {
 		return nil, errors.Trace(err)
@@ -209,6 +212,97 @@ func (s *cinderVolumeSource) DescribeVolumes(volumeIds []string) ([]storage.Desc
 	return results, nil
 }
 
+// ImportVolume implements storage.VolumeImporter.
+func (s *cinderVolumeSource) ImportVolume(volumeId string, resourceTags map[string]string) (storage.VolumeInfo, error) {
+	volume, err := s.storageAdapter.GetVolume(volumeId)
+	if err != nil {
+		return storage.VolumeInfo{}, errors.Annotatef(err, "getting volume %q", volumeId)
+	}
+	if volume.Status != volumeStatusAvailable {
+		return storage.VolumeInfo{}, errors.Errorf(
+			"cannot import volume %q with status %q", volumeId, volume.Status,
+		)
+	}
+	// Refuse to import a volume that belongs to another model or
+	// controller; tagging it would take it from its owner.
+	for _, key := range []string{tags.JujuModel, tags.JujuController} {
+		owner, ok := volume.Metadata[key]
+		if !ok {
+			continue
+		}
+		if value, ok := resourceTags[key]; ok && value != owner {
+			return storage.VolumeInfo{}, errors.Errorf(
+				"cannot import volume %q with metadata %s=%q", volumeId, key, owner,
+			)
+		}
+	}
+	if _, err := s.storageAdapter.SetVolumeMetadata(volumeId, resourceTags); err != nil {
+		return storage.VolumeInfo{}, errors.Annotatef(err, "tagging volume %q", volumeId)
+	}
+	return cinderToJujuVolumeInfo(volume), nil
+}
+
+// CreateSnapshots implements storage.VolumeSnapshotter.
+func (s *cinderVolumeSource) CreateSnapshots(args []storage.SnapshotParams) ([]storage.CreateSnapshotsResult, error) {
+	results := make([]storage.CreateSnapshotsResult, len(args))
+	for i, arg := range args {
+		snapshot, err := s.createSnapshot(arg)
+		if err != nil {
+			results[i].Error = errors.Annotatef(err, "creating snapshot of %q", arg.VolumeId)
+			continue
+		}
+		results[i].Snapshot = snapshot
+	}
+	return results, nil
+}
+
+func (s *cinderVolumeSource) createSnapshot(arg storage.SnapshotParams) (*storage.SnapshotInfo, error) {
+	snapshot, err := s.storageAdapter.CreateSnapshot(cinder.CreateSnapshotSnapshotParams{
+		VolumeId: arg.VolumeId,
+		Name:     "juju-snapshot-" + arg.VolumeId,
+		// Cinder snapshots cannot be given metadata when they are
+		// created, so we record the model UUID in the description
+		// in order to list the model's snapshots.
+		Description: s.modelUUID,
+		// Volumes are snapshotted whether or not they are in use.
+		Force: true,
+	})
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &storage.SnapshotInfo{
+		SnapshotId: snapshot.ID,
+		VolumeId:   arg.VolumeId,
+		Size:       uint64(snapshot.Size * 1024),
+	}, nil
+}
+
+// ListSnapshots implements storage.VolumeSnapshotter.
+func (s *cinderVolumeSource) ListSnapshots() ([]string, error) {
+	snapshots, err := s.storageAdapter.GetSnapshotsDetail()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	var snapshotIds []string
+	for _, snapshot := range snapshots {
+		if snapshot.Description == s.modelUUID {
+			snapshotIds = append(snapshotIds, snapshot.ID)
+		}
+	}
+	return snapshotIds, nil
+}
+
+// DestroySnapshots implements storage.VolumeSnapshotter.
+func (s *cinderVolumeSource) DestroySnapshots(snapshotIds []string) ([]error, error) {
+	results := make([]error, len(snapshotIds))
+	for i, snapshotId := range snapshotIds {
+		if err := s.storageAdapter.DeleteSnapshot(snapshotId); err != nil {
+			results[i] = errors.Annotatef(err, "destroying %q", snapshotId)
+		}
+	}
+	return results, nil
+}
+
 // DestroyVolumes implements storage.VolumeSource.
 func (s *cinderVolumeSource) DestroyVolumes(volumeIds []string) ([]error, error) {
 	return destroyVolumes(s.storageAdapter, volumeIds), nil
@@ -415,6 +509,10 @@ type openstackStorage interface {
 	GetVolumesDetail() ([]cinder.Volume, error)
 	DeleteVolume(volumeId string) error
 	CreateVolume(cinder.CreateVolumeVolumeParams) (*cinder.Volume, error)
+	SetVolumeMetadata(volumeId string, metadata map[string]string) (map[string]string, error)
+	CreateSnapshot(cinder.CreateSnapshotSnapshotParams) (*cinder.Snapshot, error)
+	GetSnapshotsDetail() ([]cinder.Snapshot, error)
+	DeleteSnapshot(snapshotId string) error
 	AttachVolume(serverId, volumeId, mountPoint string) (*nova.VolumeAttachment, error)
 	DetachVolume(serverId, attachmentId string) error
 	ListVolumeAttachments(serverId string) ([]nova.VolumeAttachment, error)
@@ -495,6 +593,24 @@ func (ga *openstackStorageAdapter) GetVolumesDetail() ([]cinder.Volume, error) {
 	return resp.Volumes, nil
 }
 
+// CreateSnapshot is part of the openstackStorage interface.
+func (ga *openstackStorageAdapter) CreateSnapshot(args cinder.CreateSnapshotSnapshotParams) (*cinder.Snapshot, error) {
+	resp, err := ga.cinderClient.CreateSnapshot(args)
+	if err != nil {
+		return nil, err
+	}
+	return &resp.Snapshot, nil
+}
+
+// GetSnapshotsDetail is part of the openstackStorage interface.
+func (ga *openstackStorageAdapter) GetSnapshotsDetail() ([]cinder.Snapshot, error) {
+	resp, err := ga.cinderClient.GetSnapshotsDetail()
+	if err != nil {
+		return nil, err
+	}
+	return resp.Snapshots, nil
+}
+
 // GetVolume is part of the openstackStorage interface.
 func (ga *openstackStorageAdapter) GetVolume(volumeId string) (*cinder.Volume, error) {
 	resp, err := ga.cinderClient.GetVolume(volumeId)
@@ -253,6 +253,150 @@ func (s *cinderVolumeSourceSuite) TestDescribeVolumes(c *gc.C) {
 	}})
 }
 
+func (s *cinderVolumeSourceSuite) TestImportVolume(c *gc.C) {
+	mockAdapter := &mockAdapter{
+		getVolume: func(volumeId string) (*cinder.Volume, error) {
+			return &cinder.Volume{
+				ID:     volumeId,
+				Size:   mockVolSize / 1024,
+				Status: "available",
+			}, nil
+		},
+	}
+	volSource := openstack.NewCinderVolumeSource(mockAdapter)
+	c.Assert(volSource, gc.Implements, new(storage.VolumeImporter))
+	resourceTags := map[string]string{"foo": "bar"}
+	info, err := volSource.(storage.VolumeImporter).ImportVolume(mockVolId, resourceTags)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(info, jc.DeepEquals, storage.VolumeInfo{
+		VolumeId:   mockVolId,
+		Size:       mockVolSize,
+		Persistent: true,
+	})
+	mockAdapter.CheckCalls(c, []gitjujutesting.StubCall{
+		{"GetVolume", []interface{}{mockVolId}},
+		{"SetVolumeMetadata", []interface{}{mockVolId, resourceTags}},
+	})
+}
+
+func (s *cinderVolumeSourceSuite) TestCreateSnapshots(c *gc.C) {
+	mockAdapter := &mockAdapter{
+		createSnapshot: func(args cinder.CreateSnapshotSnapshotParams) (*cinder.Snapshot, error) {
+			if args.VolumeId != mockVolId {
+				return nil, errors.New("no volume for you")
+			}
+			return &cinder.Snapshot{
+				ID:       "snap-0",
+				VolumeID: args.VolumeId,
+				Size:     mockVolSize / 1024,
+			}, nil
+		},
+	}
+	volSource := openstack.NewCinderVolumeSource(mockAdapter)
+	c.Assert(volSource, gc.Implements, new(storage.VolumeSnapshotter))
+	results, err := volSource.(storage.VolumeSnapshotter).CreateSnapshots([]storage.SnapshotParams{
+		{VolumeId: mockVolId},
+		{VolumeId: "nope"},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, gc.HasLen, 2)
+	c.Assert(results[0].Error, jc.ErrorIsNil)
+	c.Assert(results[0].Snapshot, jc.DeepEquals, &storage.SnapshotInfo{
+		SnapshotId: "snap-0",
+		VolumeId:   mockVolId,
+		Size:       mockVolSize,
+	})
+	c.Assert(results[1].Error, gc.ErrorMatches, `creating snapshot of "nope": no volume for you`)
+	mockAdapter.CheckCalls(c, []gitjujutesting.StubCall{
+		{"CreateSnapshot", []interface{}{cinder.CreateSnapshotSnapshotParams{
+			VolumeId:    mockVolId,
+			Name:        "juju-snapshot-" + mockVolId,
+			Description: testing.ModelTag.Id(),
+			Force:       true,
+		}}},
+		{"CreateSnapshot", []interface{}{cinder.CreateSnapshotSnapshotParams{
+			VolumeId:    "nope",
+			Name:        "juju-snapshot-nope",
+			Description: testing.ModelTag.Id(),
+			Force:       true,
+		}}},
+	})
+}
+
+func (s *cinderVolumeSourceSuite) TestListSnapshots(c *gc.C) {
+	mockAdapter := &mockAdapter{
+		getSnapshotsDetail: func() ([]cinder.Snapshot, error) {
+			return []cinder.Snapshot{{
+				ID:          "snap-0",
+				Description: testing.ModelTag.Id(),
+			}, {
+				ID:          "snap-1",
+				Description: "someone else's snapshot",
+			}}, nil
+		},
+	}
+	volSource := openstack.NewCinderVolumeSource(mockAdapter)
+	snapshotIds, err := volSource.(storage.VolumeSnapshotter).ListSnapshots()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(snapshotIds, jc.DeepEquals, []string{"snap-0"})
+}
+
+func (s *cinderVolumeSourceSuite) TestDestroySnapshots(c *gc.C) {
+	mockAdapter := &mockAdapter{
+		deleteSnapshot: func(snapshotId string) error {
+			if snapshotId == "snap-1" {
+				return errors.New("snapshot is busy")
+			}
+			return nil
+		},
+	}
+	volSource := openstack.NewCinderVolumeSource(mockAdapter)
+	errs, err := volSource.(storage.VolumeSnapshotter).DestroySnapshots([]string{"snap-0", "snap-1"})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(errs, gc.HasLen, 2)
+	c.Assert(errs[0], jc.ErrorIsNil)
+	c.Assert(errs[1], gc.ErrorMatches, `destroying "snap-1": snapshot is busy`)
+	mockAdapter.CheckCalls(c, []gitjujutesting.StubCall{
+		{"DeleteSnapshot", []interface{}{"snap-0"}},
+		{"DeleteSnapshot", []interface{}{"snap-1"}},
+	})
+}
+
+func (s *cinderVolumeSourceSuite) TestImportVolumeInUse(c *gc.C) {
+	mockAdapter := &mockAdapter{
+		getVolume: func(volumeId string) (*cinder.Volume, error) {
+			return &cinder.Volume{
+				ID:     volumeId,
+				Status: "in-use",
+			}, nil
+		},
+	}
+	volSource := openstack.NewCinderVolumeSource(mockAdapter)
+	_, err := volSource.(storage.VolumeImporter).ImportVolume(mockVolId, nil)
+	c.Assert(err, gc.ErrorMatches, `cannot import volume "`+mockVolId+`" with status "in-use"`)
+	mockAdapter.CheckCallNames(c, "GetVolume")
+}
+
+func (s *cinderVolumeSourceSuite) TestImportVolumeOtherController(c *gc.C) {
+	mockAdapter := &mockAdapter{
+		getVolume: func(volumeId string) (*cinder.Volume, error) {
+			return &cinder.Volume{
+				ID:     volumeId,
+				Status: "available",
+				Metadata: map[string]string{
+					tags.JujuController: "someone-elses-controller",
+				},
+			}, nil
+		},
+	}
+	volSource := openstack.NewCinderVolumeSource(mockAdapter)
+	_, err := volSource.(storage.VolumeImporter).ImportVolume(mockVolId, map[string]string{
+		tags.JujuController: testing.ModelTag.Id(),
+	})
+	c.Assert(err, gc.ErrorMatches, `cannot import volume "`+mockVolId+`" with metadata juju-controller-uuid="someone-elses-controller"`)
+	mockAdapter.CheckCallNames(c, "GetVolume")
+}
+
 func (s *cinderVolumeSourceSuite) TestDestroyVolumes(c *gc.C) {
 	mockAdapter := &mockAdapter{}
 	volSource := openstack.NewCinderVolumeSource(mockAdapter)
@@ -435,6 +579,10 @@ type mockAdapter struct {
 	getVolumesDetail      func() ([]cinder.Volume, error)
 	deleteVolume          func(string) error
 	createVolume          func(cinder.CreateVolumeVolumeParams) (*cinder.Volume, error)
+	setVolumeMetadata     func(string, map[string]string) (map[string]string, error)
+	createSnapshot        func(cinder.CreateSnapshotSnapshotParams) (*cinder.Snapshot, error)
+	getSnapshotsDetail    func() ([]cinder.Snapshot, error)
+	deleteSnapshot        func(string) error
 	attachVolume          func(string, string, string) (*nova.VolumeAttachment, error)
 	volumeStatusNotifier  func(string, string, int, time.Duration) <-chan error
 	detachVolume          func(string, string) error
@@ -476,6 +624,38 @@ func (ma *mockAdapter) CreateVolume(args cinder.CreateVolumeVolumeParams) (*cind
 	return nil, errors.NotImplementedf("CreateVolume")
 }
 
+func (ma *mockAdapter) SetVolumeMetadata(volumeId string, metadata map[string]string) (map[string]string, error) {
+	ma.MethodCall(ma, "SetVolumeMetadata", volumeId, metadata)
+	if ma.setVolumeMetadata != nil {
+		return ma.setVolumeMetadata(volumeId, metadata)
+	}
+	return metadata, nil
+}
+
+func (ma *mockAdapter) CreateSnapshot(args cinder.CreateSnapshotSnapshotParams) (*cinder.Snapshot, error) {
+	ma.MethodCall(ma, "CreateSnapshot", args)
+	if ma.createSnapshot != nil {
+		return ma.createSnapshot(args)
+	}
+	return nil, errors.NotImplementedf("CreateSnapshot")
+}
+
+func (ma *mockAdapter) GetSnapshotsDetail() ([]cinder.Snapshot, error) {
+	ma.MethodCall(ma, "GetSnapshotsDetail")
+	if ma.getSnapshotsDetail != nil {
+		return ma.getSnapshotsDetail()
+	}
+	return nil, nil
+}
+
+func (ma *mockAdapter) DeleteSnapshot(snapshotId string) error {
+	ma.MethodCall(ma, "DeleteSnapshot", snapshotId)
+	if ma.deleteSnapshot != nil {
+		return ma.deleteSnapshot(snapshotId)
+	}
+	return nil
+}
+
 func (ma *mockAdapter) AttachVolume(serverId, volumeId, mountPoint string) (*nova.VolumeAttachment, error) {
 	ma.MethodCall(ma, "AttachVolume", serverId, volumeId, mountPoint)
 	if ma.attachVolume != nil {
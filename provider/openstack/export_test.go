@@ -493,8 +493,9 @@ func ResolveNetwork(e environs.Environ, networkName string) (string, error) {
 	return e.(*Environ).resolveNetwork(networkName)
 }
 
-var PortsToRuleInfo = portsToRuleInfo
+var RulesToRuleInfo = rulesToRuleInfo
 var RuleMatchesPortRange = ruleMatchesPortRange
+var RuleMatchesIngressRule = ruleMatchesIngressRule
 
 var MakeServiceURL = &makeServiceURL
 var ProviderInstance = providerInstance
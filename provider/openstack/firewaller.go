@@ -63,6 +63,34 @@ type Firewaller interface {
 	InstancePorts(inst instance.Instance, machineId string) ([]network.PortRange, error)
 }
 
+// IngressRuleFirewaller is implemented by Firewallers that can
+// restrict open ports to traffic from particular source CIDRs.
+type IngressRuleFirewaller interface {
+	// OpenIngressRules opens the given ingress rules for the whole
+	// environment.
+	OpenIngressRules(rules []network.IngressRule) error
+
+	// CloseIngressRules closes the given ingress rules for the whole
+	// environment.
+	CloseIngressRules(rules []network.IngressRule) error
+
+	// IngressRules returns the ingress rules opened for the whole
+	// environment.
+	IngressRules() ([]network.IngressRule, error)
+
+	// OpenInstanceIngressRules opens the given ingress rules for the
+	// specified instance.
+	OpenInstanceIngressRules(inst instance.Instance, machineId string, rules []network.IngressRule) error
+
+	// CloseInstanceIngressRules closes the given ingress rules for
+	// the specified instance.
+	CloseInstanceIngressRules(inst instance.Instance, machineId string, rules []network.IngressRule) error
+
+	// InstanceIngressRules returns the ingress rules opened for the
+	// specified instance.
+	InstanceIngressRules(inst instance.Instance, machineId string) ([]network.IngressRule, error)
+}
+
 type firewallerFactory struct {
 }
 
@@ -291,88 +319,121 @@ func deleteSecurityGroup(novaclient *nova.Client, name, id string) {
 
 // OpenPorts implements Firewaller interface.
 func (c *defaultFirewaller) OpenPorts(ports []network.PortRange) error {
+	return c.OpenIngressRules(network.UnrestrictedIngressRules(ports))
+}
+
+// ClosePorts implements Firewaller interface.
+func (c *defaultFirewaller) ClosePorts(ports []network.PortRange) error {
+	return c.CloseIngressRules(network.UnrestrictedIngressRules(ports))
+}
+
+// Ports implements Firewaller interface.
+func (c *defaultFirewaller) Ports() ([]network.PortRange, error) {
+	rules, err := c.IngressRules()
+	if err != nil {
+		return nil, err
+	}
+	return rulePortRanges(rules), nil
+}
+
+// OpenInstancePorts implements Firewaller interface.
+func (c *defaultFirewaller) OpenInstancePorts(inst instance.Instance, machineId string, ports []network.PortRange) error {
+	return c.OpenInstanceIngressRules(inst, machineId, network.UnrestrictedIngressRules(ports))
+}
+
+// CloseInstancePorts implements Firewaller interface.
+func (c *defaultFirewaller) CloseInstancePorts(inst instance.Instance, machineId string, ports []network.PortRange) error {
+	return c.CloseInstanceIngressRules(inst, machineId, network.UnrestrictedIngressRules(ports))
+}
+
+// InstancePorts implements Firewaller interface.
+func (c *defaultFirewaller) InstancePorts(inst instance.Instance, machineId string) ([]network.PortRange, error) {
+	rules, err := c.InstanceIngressRules(inst, machineId)
+	if err != nil {
+		return nil, err
+	}
+	return rulePortRanges(rules), nil
+}
+
+// OpenIngressRules implements IngressRuleFirewaller interface.
+func (c *defaultFirewaller) OpenIngressRules(rules []network.IngressRule) error {
 	if c.environ.Config().FirewallMode() != config.FwGlobal {
 		return fmt.Errorf("invalid firewall mode %q for opening ports on model",
 			c.environ.Config().FirewallMode())
 	}
-	if err := c.openPortsInGroup(c.globalGroupName(), ports); err != nil {
+	if err := c.openRulesInGroup(c.globalGroupName(), rules); err != nil {
 		return err
 	}
-	logger.Infof("opened ports in global group: %v", ports)
+	logger.Infof("opened ingress rules in global group: %v", rules)
 	return nil
 }
 
-// ClosePorts implements Firewaller interface.
-func (c *defaultFirewaller) ClosePorts(ports []network.PortRange) error {
+// CloseIngressRules implements IngressRuleFirewaller interface.
+func (c *defaultFirewaller) CloseIngressRules(rules []network.IngressRule) error {
 	if c.environ.Config().FirewallMode() != config.FwGlobal {
 		return fmt.Errorf("invalid firewall mode %q for closing ports on model",
 			c.environ.Config().FirewallMode())
 	}
-	if err := c.closePortsInGroup(c.globalGroupName(), ports); err != nil {
+	if err := c.closeRulesInGroup(c.globalGroupName(), rules); err != nil {
 		return err
 	}
-	logger.Infof("closed ports in global group: %v", ports)
+	logger.Infof("closed ingress rules in global group: %v", rules)
 	return nil
 }
 
-// Ports implements Firewaller interface.
-func (c *defaultFirewaller) Ports() ([]network.PortRange, error) {
+// IngressRules implements IngressRuleFirewaller interface.
+func (c *defaultFirewaller) IngressRules() ([]network.IngressRule, error) {
 	if c.environ.Config().FirewallMode() != config.FwGlobal {
 		return nil, fmt.Errorf("invalid firewall mode %q for retrieving ports from model",
 			c.environ.Config().FirewallMode())
 	}
-	return c.portsInGroup(c.globalGroupName())
+	return c.rulesInGroup(c.globalGroupName())
 }
 
-// OpenInstancePorts implements Firewaller interface.
-func (c *defaultFirewaller) OpenInstancePorts(inst instance.Instance, machineId string, ports []network.PortRange) error {
+// OpenInstanceIngressRules implements IngressRuleFirewaller interface.
+func (c *defaultFirewaller) OpenInstanceIngressRules(inst instance.Instance, machineId string, rules []network.IngressRule) error {
 	if c.environ.Config().FirewallMode() != config.FwInstance {
 		return fmt.Errorf("invalid firewall mode %q for opening ports on instance",
 			c.environ.Config().FirewallMode())
 	}
 	name := c.machineGroupName(machineId)
-	if err := c.openPortsInGroup(name, ports); err != nil {
+	if err := c.openRulesInGroup(name, rules); err != nil {
 		return err
 	}
-	logger.Infof("opened ports in security group %s: %v", name, ports)
+	logger.Infof("opened ingress rules in security group %s: %v", name, rules)
 	return nil
 }
 
-// CloseInstancePorts implements Firewaller interface.
-func (c *defaultFirewaller) CloseInstancePorts(inst instance.Instance, machineId string, ports []network.PortRange) error {
+// CloseInstanceIngressRules implements IngressRuleFirewaller interface.
+func (c *defaultFirewaller) CloseInstanceIngressRules(inst instance.Instance, machineId string, rules []network.IngressRule) error {
 	if c.environ.Config().FirewallMode() != config.FwInstance {
 		return fmt.Errorf("invalid firewall mode %q for closing ports on instance",
 			c.environ.Config().FirewallMode())
 	}
 	name := c.machineGroupName(machineId)
-	if err := c.closePortsInGroup(name, ports); err != nil {
+	if err := c.closeRulesInGroup(name, rules); err != nil {
 		return err
 	}
-	logger.Infof("closed ports in security group %s: %v", name, ports)
+	logger.Infof("closed ingress rules in security group %s: %v", name, rules)
 	return nil
 }
 
-// InstancePorts implements Firewaller interface.
-func (c *defaultFirewaller) InstancePorts(inst instance.Instance, machineId string) ([]network.PortRange, error) {
+// InstanceIngressRules implements IngressRuleFirewaller interface.
+func (c *defaultFirewaller) InstanceIngressRules(inst instance.Instance, machineId string) ([]network.IngressRule, error) {
 	if c.environ.Config().FirewallMode() != config.FwInstance {
 		return nil, fmt.Errorf("invalid firewall mode %q for retrieving ports from instance",
 			c.environ.Config().FirewallMode())
 	}
-	name := c.machineGroupName(machineId)
-	portRanges, err := c.portsInGroup(name)
-	if err != nil {
-		return nil, err
-	}
-	return portRanges, nil
+	return c.rulesInGroup(c.machineGroupName(machineId))
 }
 
-func (c *defaultFirewaller) openPortsInGroup(name string, portRanges []network.PortRange) error {
+func (c *defaultFirewaller) openRulesInGroup(name string, ingressRules []network.IngressRule) error {
 	novaclient := c.environ.nova()
 	group, err := novaclient.SecurityGroupByName(name)
 	if err != nil {
 		return err
 	}
-	rules := portsToRuleInfo(group.Id, portRanges)
+	rules := rulesToRuleInfo(group.Id, ingressRules)
 	for _, rule := range rules {
 		_, err := novaclient.CreateSecurityGroupRule(rule)
 		if err != nil {
@@ -393,8 +454,24 @@ func ruleMatchesPortRange(rule nova.SecurityGroupRule, portRange network.PortRan
 		*rule.ToPort == portRange.ToPort
 }
 
-func (c *defaultFirewaller) closePortsInGroup(name string, portRanges []network.PortRange) error {
-	if len(portRanges) == 0 {
+// ruleMatchesIngressRule checks if supplied nova security group rule
+// matches the ingress rule. Rules without a CIDR are open to traffic
+// from anywhere.
+func ruleMatchesIngressRule(rule nova.SecurityGroupRule, ingressRule network.IngressRule) bool {
+	return ruleMatchesPortRange(rule, ingressRule.PortRange) &&
+		ruleSourceCIDR(rule) == ingressRule.SourceCIDR
+}
+
+// ruleSourceCIDR returns the source CIDR of the nova security group rule.
+func ruleSourceCIDR(rule nova.SecurityGroupRule) string {
+	if cidr := rule.IPRange["cidr"]; cidr != "" {
+		return cidr
+	}
+	return network.AnySourceCIDR
+}
+
+func (c *defaultFirewaller) closeRulesInGroup(name string, ingressRules []network.IngressRule) error {
+	if len(ingressRules) == 0 {
 		return nil
 	}
 	novaclient := c.environ.nova()
@@ -403,9 +480,9 @@ func (c *defaultFirewaller) closePortsInGroup(name string, portRanges []network.
 		return err
 	}
 	// TODO: Hey look ma, it's quadratic
-	for _, portRange := range portRanges {
+	for _, ingressRule := range ingressRules {
 		for _, p := range (*group).Rules {
-			if !ruleMatchesPortRange(p, portRange) {
+			if !ruleMatchesIngressRule(p, ingressRule) {
 				continue
 			}
 			err := novaclient.DeleteSecurityGroupRule(p.Id)
@@ -418,20 +495,37 @@ func (c *defaultFirewaller) closePortsInGroup(name string, portRanges []network.
 	return nil
 }
 
-func (c *defaultFirewaller) portsInGroup(name string) (portRanges []network.PortRange, err error) {
+func (c *defaultFirewaller) rulesInGroup(name string) (rules []network.IngressRule, err error) {
 	group, err := c.environ.nova().SecurityGroupByName(name)
 	if err != nil {
 		return nil, err
 	}
 	for _, p := range (*group).Rules {
-		portRanges = append(portRanges, network.PortRange{
-			Protocol: *p.IPProtocol,
-			FromPort: *p.FromPort,
-			ToPort:   *p.ToPort,
+		rules = append(rules, network.IngressRule{
+			PortRange: network.PortRange{
+				Protocol: *p.IPProtocol,
+				FromPort: *p.FromPort,
+				ToPort:   *p.ToPort,
+			},
+			SourceCIDR: ruleSourceCIDR(p),
 		})
 	}
-	network.SortPortRanges(portRanges)
-	return portRanges, nil
+	network.SortIngressRules(rules)
+	return rules, nil
+}
+
+// rulePortRanges returns the distinct port ranges of the given rules.
+func rulePortRanges(rules []network.IngressRule) []network.PortRange {
+	var ports []network.PortRange
+	seen := make(map[network.PortRange]bool)
+	for _, r := range rules {
+		if !seen[r.PortRange] {
+			seen[r.PortRange] = true
+			ports = append(ports, r.PortRange)
+		}
+	}
+	network.SortPortRanges(ports)
+	return ports
 }
 
 func (c *defaultFirewaller) globalGroupName() string {
@@ -395,6 +395,45 @@ func (inst *openstackInstance) Ports(machineId string) ([]network.PortRange, err
 	return inst.e.firewaller.InstancePorts(inst, machineId)
 }
 
+// OpenIngressRules is specified in the instance.IngressRuleFirewaller
+// interface.
+func (inst *openstackInstance) OpenIngressRules(machineId string, rules []network.IngressRule) error {
+	if fw, ok := inst.e.firewaller.(IngressRuleFirewaller); ok {
+		return fw.OpenInstanceIngressRules(inst, machineId, rules)
+	}
+	ports, err := network.PortRanges(rules)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	return inst.e.firewaller.OpenInstancePorts(inst, machineId, ports)
+}
+
+// CloseIngressRules is specified in the instance.IngressRuleFirewaller
+// interface.
+func (inst *openstackInstance) CloseIngressRules(machineId string, rules []network.IngressRule) error {
+	if fw, ok := inst.e.firewaller.(IngressRuleFirewaller); ok {
+		return fw.CloseInstanceIngressRules(inst, machineId, rules)
+	}
+	ports, err := network.PortRanges(rules)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	return inst.e.firewaller.CloseInstancePorts(inst, machineId, ports)
+}
+
+// IngressRules is specified in the instance.IngressRuleFirewaller
+// interface.
+func (inst *openstackInstance) IngressRules(machineId string) ([]network.IngressRule, error) {
+	if fw, ok := inst.e.firewaller.(IngressRuleFirewaller); ok {
+		return fw.InstanceIngressRules(inst, machineId)
+	}
+	ports, err := inst.e.firewaller.InstancePorts(inst, machineId)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return network.UnrestrictedIngressRules(ports), nil
+}
+
 func (e *Environ) ecfg() *environConfig {
 	e.ecfgMutex.Lock()
 	ecfg := e.ecfgUnlocked
@@ -876,6 +915,9 @@ func (e *Environ) StartInstance(args environs.StartInstanceParams) (*environs.St
 		if !placement.availabilityZone.State.Available {
 			return nil, errors.Errorf("availability zone %q is unavailable", placement.availabilityZone.Name)
 		}
+		if err := common.CheckZoneAllowedByConstraints(placement.availabilityZone.Name, args.Constraints); err != nil {
+			return nil, errors.Trace(err)
+		}
 		availabilityZones = append(availabilityZones, placement.availabilityZone.Name)
 	}
 
@@ -902,6 +944,10 @@ func (e *Environ) StartInstance(args environs.StartInstanceParams) (*environs.St
 				availabilityZones = append(availabilityZones, zone.ZoneName)
 			}
 		}
+		availabilityZones, err = common.ZonesAllowedByConstraints(availabilityZones, args.Constraints)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
 		if len(availabilityZones) == 0 {
 			// No explicitly selectable zones available, so use an unspecified zone.
 			availabilityZones = []string{""}
@@ -1338,16 +1384,16 @@ func (e *Environ) machinesFilter() *nova.Filter {
 	return filter
 }
 
-// portsToRuleInfo maps port ranges to nova rules
-func portsToRuleInfo(groupId string, ports []network.PortRange) []nova.RuleInfo {
-	rules := make([]nova.RuleInfo, len(ports))
-	for i, portRange := range ports {
+// rulesToRuleInfo maps ingress rules to nova rules
+func rulesToRuleInfo(groupId string, ingressRules []network.IngressRule) []nova.RuleInfo {
+	rules := make([]nova.RuleInfo, len(ingressRules))
+	for i, r := range ingressRules {
 		rules[i] = nova.RuleInfo{
 			ParentGroupId: groupId,
-			FromPort:      portRange.FromPort,
-			ToPort:        portRange.ToPort,
-			IPProtocol:    portRange.Protocol,
-			Cidr:          "0.0.0.0/0",
+			FromPort:      r.PortRange.FromPort,
+			ToPort:        r.PortRange.ToPort,
+			IPProtocol:    r.PortRange.Protocol,
+			Cidr:          r.SourceCIDR,
 		}
 	}
 	return rules
@@ -1365,6 +1411,46 @@ func (e *Environ) Ports() ([]network.PortRange, error) {
 	return e.firewaller.Ports()
 }
 
+// OpenIngressRules is specified in the environs.IngressRuleFirewaller
+// interface. If the firewaller cannot restrict the source of traffic,
+// only unrestricted rules may be opened.
+func (e *Environ) OpenIngressRules(rules []network.IngressRule) error {
+	if fw, ok := e.firewaller.(IngressRuleFirewaller); ok {
+		return fw.OpenIngressRules(rules)
+	}
+	ports, err := network.PortRanges(rules)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	return e.firewaller.OpenPorts(ports)
+}
+
+// CloseIngressRules is specified in the environs.IngressRuleFirewaller
+// interface.
+func (e *Environ) CloseIngressRules(rules []network.IngressRule) error {
+	if fw, ok := e.firewaller.(IngressRuleFirewaller); ok {
+		return fw.CloseIngressRules(rules)
+	}
+	ports, err := network.PortRanges(rules)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	return e.firewaller.ClosePorts(ports)
+}
+
+// IngressRules is specified in the environs.IngressRuleFirewaller
+// interface.
+func (e *Environ) IngressRules() ([]network.IngressRule, error) {
+	if fw, ok := e.firewaller.(IngressRuleFirewaller); ok {
+		return fw.IngressRules()
+	}
+	ports, err := e.firewaller.Ports()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return network.UnrestrictedIngressRules(ports), nil
+}
+
 func (e *Environ) Provider() environs.EnvironProvider {
 	return providerInstance
 }
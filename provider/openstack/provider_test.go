@@ -213,7 +213,7 @@ func (t *localTests) TestGetServerAddresses(c *gc.C) {
 	}
 }
 
-func (*localTests) TestPortsToRuleInfo(c *gc.C) {
+func (*localTests) TestRulesToRuleInfo(c *gc.C) {
 	groupId := "groupid"
 	testCases := []struct {
 		about    string
@@ -275,12 +275,43 @@ func (*localTests) TestPortsToRuleInfo(c *gc.C) {
 
 	for i, t := range testCases {
 		c.Logf("test %d: %s", i, t.about)
-		rules := PortsToRuleInfo(groupId, t.ports)
+		rules := RulesToRuleInfo(groupId, network.UnrestrictedIngressRules(t.ports))
 		c.Check(len(rules), gc.Equals, len(t.expected))
 		c.Check(rules, gc.DeepEquals, t.expected)
 	}
 }
 
+func (*localTests) TestRulesToRuleInfoSourceCIDRs(c *gc.C) {
+	portRange := network.PortRange{FromPort: 80, ToPort: 80, Protocol: "tcp"}
+	rules := RulesToRuleInfo("groupid", network.NewIngressRules(portRange, "10.0.0.0/8"))
+	c.Check(rules, gc.DeepEquals, []nova.RuleInfo{{
+		IPProtocol:    "tcp",
+		FromPort:      80,
+		ToPort:        80,
+		Cidr:          "10.0.0.0/8",
+		ParentGroupId: "groupid",
+	}})
+}
+
+func (*localTests) TestRuleMatchesIngressRule(c *gc.C) {
+	proto := "tcp"
+	port := 80
+	portRange := network.PortRange{FromPort: 80, ToPort: 80, Protocol: "tcp"}
+	rule := nova.SecurityGroupRule{
+		IPProtocol: &proto,
+		FromPort:   &port,
+		ToPort:     &port,
+		IPRange:    map[string]string{"cidr": "10.0.0.0/8"},
+	}
+	c.Check(RuleMatchesIngressRule(rule, network.IngressRule{portRange, "10.0.0.0/8"}), jc.IsTrue)
+	c.Check(RuleMatchesIngressRule(rule, network.IngressRule{portRange, "0.0.0.0/0"}), jc.IsFalse)
+
+	// Rules without a CIDR are open to traffic from anywhere.
+	rule.IPRange = nil
+	c.Check(RuleMatchesIngressRule(rule, network.IngressRule{portRange, "0.0.0.0/0"}), jc.IsTrue)
+	c.Check(RuleMatchesIngressRule(rule, network.IngressRule{portRange, "10.0.0.0/8"}), jc.IsFalse)
+}
+
 func (*localTests) TestRuleMatchesPortRange(c *gc.C) {
 	proto_tcp := "tcp"
 	proto_udp := "udp"
@@ -9,6 +9,7 @@ import (
 	"github.com/juju/errors"
 	"github.com/juju/loggo"
 	"github.com/juju/names"
+	jujutxn "github.com/juju/txn"
 	"github.com/juju/utils"
 	"gopkg.in/mgo.v2"
 	"gopkg.in/mgo.v2/bson"
@@ -45,6 +46,10 @@ const (
 
 	// ActionRunning indicates that the Action is currently running.
 	ActionRunning ActionStatus = "running"
+
+	// ActionAborting indicates that a running Action has been cancelled,
+	// and is waiting for its receiver to stop it.
+	ActionAborting ActionStatus = "aborting"
 )
 
 type actionNotificationDoc struct {
@@ -64,6 +69,11 @@ type actionNotificationDoc struct {
 	// ActionID is the unique identifier for the Action this notification
 	// represents.
 	ActionID string `bson:"actionid"`
+
+	// Aborting is set when the running Action this notification
+	// represents is cancelled. The change to the document is what
+	// tells the receiver to stop the Action.
+	Aborting bool `bson:"aborting,omitempty"`
 }
 
 type actionDoc struct {
@@ -88,6 +98,10 @@ type actionDoc struct {
 	// Enqueued is the time the action was added.
 	Enqueued time.Time `bson:"enqueued"`
 
+	// Timeout is the maximum time the action may run for once it has
+	// started; zero means the action may run for as long as it likes.
+	Timeout time.Duration `bson:"timeout,omitempty"`
+
 	// Started reflects the time the action began running.
 	Started time.Time `bson:"started"`
 
@@ -136,6 +150,12 @@ func (a *action) Parameters() map[string]interface{} {
 	return a.doc.Parameters
 }
 
+// Timeout returns the maximum time the action may run for, or zero
+// if it has no time limit.
+func (a *action) Timeout() time.Duration {
+	return a.doc.Timeout
+}
+
 // Enqueued returns the time the action was added to state as a pending
 // Action.
 func (a *action) Enqueued() time.Time {
@@ -207,11 +227,68 @@ func (a *action) Finish(results ActionResults) (Action, error) {
 	return a.removeAndLog(results.Status, results.Results, results.Message)
 }
 
+// Cancel stops the action from running. A pending action is marked
+// as cancelled immediately; a running action is marked as aborting,
+// and its receiver is notified so that it can stop the action and
+// record it as cancelled.
+func (a *action) Cancel() (Action, error) {
+	buildTxn := func(attempt int) ([]txn.Op, error) {
+		if attempt > 0 {
+			current, err := a.st.Action(a.Id())
+			if err != nil {
+				return nil, errors.Trace(err)
+			}
+			a.doc = current.(*action).doc
+		}
+		switch a.doc.Status {
+		case ActionPending:
+			ops := a.finishOps(ActionCancelled, nil, "action cancelled")
+			// The action must not have started in the meantime,
+			// or its receiver would never find out.
+			ops[0].Assert = bson.D{{"status", ActionPending}}
+			return ops, nil
+		case ActionRunning:
+			return []txn.Op{{
+				C:      actionsC,
+				Id:     a.doc.DocId,
+				Assert: bson.D{{"status", ActionRunning}},
+				Update: bson.D{{"$set", bson.D{
+					{"status", ActionAborting},
+				}}},
+			}, {
+				C:      actionNotificationsC,
+				Id:     a.st.docID(ensureActionMarker(a.Receiver()) + a.Id()),
+				Assert: txn.DocExists,
+				Update: bson.D{{"$set", bson.D{
+					{"aborting", true},
+				}}},
+			}}, nil
+		case ActionAborting:
+			return nil, jujutxn.ErrNoOperations
+		}
+		return nil, errors.Errorf("action %s is already %s", a.Id(), a.doc.Status)
+	}
+	if err := a.st.run(buildTxn); err != nil {
+		return nil, errors.Annotatef(err, "cannot cancel action %s", a.Id())
+	}
+	return a.st.Action(a.Id())
+}
+
 // removeAndLog takes the action off of the pending queue, and creates
 // an actionresult to capture the outcome of the action. It asserts that
 // the action is not already completed.
 func (a *action) removeAndLog(finalStatus ActionStatus, results map[string]interface{}, message string) (Action, error) {
-	err := a.st.runTransaction([]txn.Op{
+	err := a.st.runTransaction(a.finishOps(finalStatus, results, message))
+	if err != nil {
+		return nil, err
+	}
+	return a.st.Action(a.Id())
+}
+
+// finishOps returns the operations needed to record the outcome of
+// the action and take it off the pending queue.
+func (a *action) finishOps(finalStatus ActionStatus, results map[string]interface{}, message string) []txn.Op {
+	return []txn.Op{
 		{
 			C:  actionsC,
 			Id: a.doc.DocId,
@@ -231,11 +308,7 @@ func (a *action) removeAndLog(finalStatus ActionStatus, results map[string]inter
 			C:      actionNotificationsC,
 			Id:     a.st.docID(ensureActionMarker(a.Receiver()) + a.Id()),
 			Remove: true,
-		}})
-	if err != nil {
-		return nil, err
-	}
-	return a.st.Action(a.Id())
+		}}
 }
 
 // newAction builds an Action for the given State and actionDoc.
@@ -247,7 +320,7 @@ func newAction(st *State, adoc actionDoc) Action {
 }
 
 // newActionDoc builds the actionDoc with the given name and parameters.
-func newActionDoc(st *State, receiverTag names.Tag, actionName string, parameters map[string]interface{}) (actionDoc, actionNotificationDoc, error) {
+func newActionDoc(st *State, receiverTag names.Tag, actionName string, parameters map[string]interface{}, timeout time.Duration) (actionDoc, actionNotificationDoc, error) {
 	prefix := ensureActionMarker(receiverTag.Id())
 	actionId, err := NewUUID()
 	if err != nil {
@@ -262,6 +335,7 @@ func newActionDoc(st *State, receiverTag names.Tag, actionName string, parameter
 			Name:       actionName,
 			Parameters: parameters,
 			Enqueued:   nowToTheSecond(),
+			Timeout:    timeout,
 			Status:     ActionPending,
 		}, actionNotificationDoc{
 			DocId:     st.docID(prefix + actionId.String()),
@@ -338,16 +412,25 @@ func (st *State) FindActionsByName(name string) ([]Action, error) {
 
 // EnqueueAction
 func (st *State) EnqueueAction(receiver names.Tag, actionName string, payload map[string]interface{}) (Action, error) {
+	return st.enqueueAction(receiver, actionName, payload, 0)
+}
+
+// enqueueAction adds an action for the receiver that may run for at
+// most the given timeout; a zero timeout means no limit.
+func (st *State) enqueueAction(receiver names.Tag, actionName string, payload map[string]interface{}, timeout time.Duration) (Action, error) {
 	if len(actionName) == 0 {
 		return nil, errors.New("action name required")
 	}
+	if timeout < 0 {
+		return nil, errors.NotValidf("negative timeout %v", timeout)
+	}
 
 	receiverCollectionName, receiverId, err := st.tagToCollectionAndId(receiver)
 	if err != nil {
 		return nil, errors.Trace(err)
 	}
 
-	doc, ndoc, err := newActionDoc(st, receiver, actionName, payload)
+	doc, ndoc, err := newActionDoc(st, receiver, actionName, payload, timeout)
 	if err != nil {
 		return nil, errors.Trace(err)
 	}
@@ -412,9 +495,9 @@ func (st *State) matchingActionsPending(ar ActionReceiver) ([]Action, error) {
 }
 
 // matchingActionsRunning finds actions that match ActionReceiver and
-// that are running.
+// that are running or aborting.
 func (st *State) matchingActionsRunning(ar ActionReceiver) ([]Action, error) {
-	completed := bson.D{{"status", ActionRunning}}
+	completed := bson.D{{"status", bson.D{{"$in", []ActionStatus{ActionRunning, ActionAborting}}}}}
 	return st.matchingActionsByReceiverAndStatus(ar.Tag(), completed)
 }
 
@@ -446,3 +529,17 @@ func (st *State) matchingActionsByReceiverAndStatus(tag names.Tag, statusConditi
 	}
 	return actions, errors.Trace(iter.Close())
 }
+
+// UnfinishedActionCount returns the number of actions in the model
+// that are pending, running or aborting.
+func (st *State) UnfinishedActionCount() (int, error) {
+	actionsCollection, closer := st.getCollection(actionsC)
+	defer closer()
+
+	sel := bson.D{{"status", bson.D{{"$in", []ActionStatus{ActionPending, ActionRunning, ActionAborting}}}}}
+	count, err := actionsCollection.Find(sel).Count()
+	if err != nil {
+		return 0, errors.Trace(err)
+	}
+	return count, nil
+}
@@ -7,6 +7,7 @@ import (
 	"encoding/hex"
 	"fmt"
 	"strings"
+	"time"
 
 	"github.com/juju/errors"
 	"github.com/juju/names"
@@ -463,6 +464,113 @@ func (s *ActionSuite) TestComplete(c *gc.C) {
 	c.Assert(len(actions), gc.Equals, 0)
 }
 
+func (s *ActionSuite) TestAddActionWithTimeout(c *gc.C) {
+	a, err := s.unit.AddActionWithTimeout("snapshot", nil, 5*time.Minute)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(a.Timeout(), gc.Equals, 5*time.Minute)
+
+	a, err = s.State.Action(a.Id())
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(a.Timeout(), gc.Equals, 5*time.Minute)
+
+	a, err = s.unit.AddAction("snapshot", nil)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(a.Timeout(), gc.Equals, time.Duration(0))
+}
+
+func (s *ActionSuite) TestAddActionWithNegativeTimeout(c *gc.C) {
+	_, err := s.unit.AddActionWithTimeout("snapshot", nil, -time.Second)
+	c.Assert(err, gc.ErrorMatches, "negative timeout -1s not valid")
+	c.Assert(err, jc.Satisfies, errors.IsNotValid)
+}
+
+func (s *ActionSuite) TestCancelPending(c *gc.C) {
+	a, err := s.unit.AddAction("snapshot", nil)
+	c.Assert(err, jc.ErrorIsNil)
+
+	cancelled, err := s.unit.CancelAction(a)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cancelled.Status(), gc.Equals, state.ActionCancelled)
+	_, message := cancelled.Results()
+	c.Assert(message, gc.Equals, "action cancelled")
+	c.Assert(cancelled.Completed().IsZero(), jc.IsFalse)
+
+	// A cancelled action can't be started.
+	_, err = cancelled.Begin()
+	c.Assert(err, gc.ErrorMatches, ".*transaction aborted")
+}
+
+func (s *ActionSuite) TestCancelRunning(c *gc.C) {
+	a, err := s.unit.AddAction("snapshot", nil)
+	c.Assert(err, jc.ErrorIsNil)
+	running, err := a.Begin()
+	c.Assert(err, jc.ErrorIsNil)
+
+	aborting, err := s.unit.CancelAction(running)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(aborting.Status(), gc.Equals, state.ActionAborting)
+
+	// The action is still reported as running until the unit
+	// stops it.
+	actions, err := s.unit.RunningActions()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(actions, gc.HasLen, 1)
+	count, err := s.State.UnfinishedActionCount()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(count, gc.Equals, 1)
+
+	// Cancelling again is a no-op.
+	aborting, err = s.unit.CancelAction(aborting)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(aborting.Status(), gc.Equals, state.ActionAborting)
+
+	finished, err := aborting.Finish(state.ActionResults{Status: state.ActionCancelled})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(finished.Status(), gc.Equals, state.ActionCancelled)
+}
+
+func (s *ActionSuite) TestCancelFinished(c *gc.C) {
+	a, err := s.unit.AddAction("snapshot", nil)
+	c.Assert(err, jc.ErrorIsNil)
+	completed, err := a.Finish(state.ActionResults{Status: state.ActionCompleted})
+	c.Assert(err, jc.ErrorIsNil)
+
+	_, err = s.unit.CancelAction(completed)
+	c.Assert(err, gc.ErrorMatches, "cannot cancel action .*: action .* is already completed")
+}
+
+func (s *ActionSuite) TestCancelStaleAction(c *gc.C) {
+	a, err := s.unit.AddAction("snapshot", nil)
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = a.Begin()
+	c.Assert(err, jc.ErrorIsNil)
+
+	// a still believes the action is pending.
+	aborting, err := s.unit.CancelAction(a)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(aborting.Status(), gc.Equals, state.ActionAborting)
+}
+
+func (s *ActionSuite) TestCancelRunningNotifiesReceiver(c *gc.C) {
+	a, err := s.unit.AddAction("snapshot", nil)
+	c.Assert(err, jc.ErrorIsNil)
+
+	w := s.unit.WatchActionNotifications()
+	defer statetesting.AssertStop(c, w)
+	wc := statetesting.NewStringsWatcherC(c, s.State, w)
+	wc.AssertChange(a.Id())
+	wc.AssertNoChange()
+
+	running, err := a.Begin()
+	c.Assert(err, jc.ErrorIsNil)
+	wc.AssertNoChange()
+
+	_, err = s.unit.CancelAction(running)
+	c.Assert(err, jc.ErrorIsNil)
+	wc.AssertChange(a.Id())
+	wc.AssertNoChange()
+}
+
 func (s *ActionSuite) TestFindActionTagsByPrefix(c *gc.C) {
 	prefix := "feedbeef"
 	uuidMock := uuidMockHelper{}
@@ -519,6 +627,33 @@ func (s *ActionSuite) TestFindActionsByName(c *gc.C) {
 	}
 }
 
+func (s *ActionSuite) TestUnfinishedActionCount(c *gc.C) {
+	count, err := s.State.UnfinishedActionCount()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(count, gc.Equals, 0)
+
+	pending, err := s.State.EnqueueAction(s.unit.Tag(), "snapshot", nil)
+	c.Assert(err, jc.ErrorIsNil)
+	running, err := s.State.EnqueueAction(s.unit.Tag(), "snapshot", nil)
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = running.Begin()
+	c.Assert(err, jc.ErrorIsNil)
+	finished, err := s.State.EnqueueAction(s.unit.Tag(), "snapshot", nil)
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = finished.Finish(state.ActionResults{Status: state.ActionCompleted})
+	c.Assert(err, jc.ErrorIsNil)
+
+	count, err = s.State.UnfinishedActionCount()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(count, gc.Equals, 2)
+
+	_, err = pending.Finish(state.ActionResults{Status: state.ActionCancelled})
+	c.Assert(err, jc.ErrorIsNil)
+	count, err = s.State.UnfinishedActionCount()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(count, gc.Equals, 1)
+}
+
 func (s *ActionSuite) TestActionsWatcherEmitsInitialChanges(c *gc.C) {
 	// LP-1391914 :: idPrefixWatcher fails watcher contract to send
 	// initial Change event
@@ -878,6 +1013,9 @@ var _ state.ActionReceiver = (*mockAR)(nil)
 func (r mockAR) AddAction(name string, payload map[string]interface{}) (state.Action, error) {
 	return nil, nil
 }
+func (r mockAR) AddActionWithTimeout(string, map[string]interface{}, time.Duration) (state.Action, error) {
+	return nil, nil
+}
 func (r mockAR) CancelAction(state.Action) (state.Action, error) { return nil, nil }
 func (r mockAR) WatchActionNotifications() state.StringsWatcher  { return nil }
 func (r mockAR) Actions() ([]state.Action, error)                { return nil, nil }
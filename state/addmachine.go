@@ -588,8 +588,35 @@ func (st *State) machineStorageOps(
 		})
 	}
 
-	// TODO(axw) handle args.filesystemAttachments, args.volumeAttachments
-	// when we handle attaching to existing (e.g. shared) storage.
+	// Attach existing filesystems and volumes, such as those of storage
+	// detached from another unit.
+	for filesystemTag, attachmentParams := range args.filesystemAttachments {
+		f, err := st.filesystemByTag(filesystemTag)
+		if err != nil {
+			return nil, nil, nil, errors.Trace(err)
+		}
+		filesystemOps = append(filesystemOps, machineStorageIncrefOp(filesystemsC, filesystemTag.Id()))
+		storageTag, _ := f.Storage()
+		fsAttachments = append(fsAttachments, filesystemAttachmentTemplate{
+			filesystemTag, storageTag, attachmentParams,
+		})
+		if volumeTag, err := f.Volume(); err == nil {
+			// The filesystem is backed by a volume, so attach
+			// the volume too.
+			volumeOps = append(volumeOps, machineStorageIncrefOp(volumesC, volumeTag.Id()))
+			volumeAttachments = append(volumeAttachments, volumeAttachmentTemplate{
+				volumeTag, VolumeAttachmentParams{},
+			})
+		} else if err != ErrNoBackingVolume {
+			return nil, nil, nil, errors.Trace(err)
+		}
+	}
+	for volumeTag, attachmentParams := range args.volumeAttachments {
+		volumeOps = append(volumeOps, machineStorageIncrefOp(volumesC, volumeTag.Id()))
+		volumeAttachments = append(volumeAttachments, volumeAttachmentTemplate{
+			volumeTag, attachmentParams,
+		})
+	}
 
 	ops := make([]txn.Op, 0, len(filesystemOps)+len(volumeOps)+len(fsAttachments)+len(volumeAttachments))
 	if len(fsAttachments) > 0 {
@@ -17,6 +17,13 @@ var (
 	txnLogSizeTests = 1000000
 )
 
+// The capped collection used for the audit log also defaults to 10MB,
+// and is reduced to 1MB in tests for the same reason.
+var (
+	auditLogSize      = 10000000
+	auditLogSizeTests = 1000000
+)
+
 // allCollections should be the single source of truth for information about
 // any collection we use. It's broken up into 4 main sections:
 //
@@ -124,6 +131,16 @@ func allCollections() collectionSchema {
 		// one model migration document exists per environment.
 		migrationsActiveC: {global: true},
 
+		// This collection records the reports made by the
+		// migration minion workers of a model's agents as they
+		// complete each migration phase.
+		migrationsMinionSyncC: {
+			global: true,
+			indexes: []mgo.Index{{
+				Key: []string{"migration-id"},
+			}},
+		},
+
 		// This collection holds user information that's not specific to any
 		// one model.
 		usersC: {
@@ -134,12 +151,40 @@ func allCollections() collectionSchema {
 			}},
 		},
 
+		// This collection holds the access each user has on the
+		// controller, as opposed to any one model.
+		controllerUsersC: {
+			global: true,
+		},
+
 		// This collection holds the last time the user connected to the API server.
 		userLastLoginC: {
 			global:    true,
 			rawAccess: true,
 		},
 
+		// This collection holds the most recent failed attempts to log
+		// in as each user.
+		userFailedLoginsC: {
+			global:    true,
+			rawAccess: true,
+		},
+
+		// This collection holds a structured record of the API calls
+		// made by users that may have changed the controller or one of
+		// its models. Old entries are discarded as new ones are added.
+		auditLogC: {
+			global:    true,
+			rawAccess: true,
+			explicitCreate: &mgo.CollectionInfo{
+				Capped:   true,
+				MaxBytes: auditLogSize,
+			},
+			indexes: []mgo.Index{{
+				Key: []string{"timestamp"},
+			}},
+		},
+
 		// This collection is used as a unique key restraint. The _id field is
 		// a concatenation of multiple fields that form a compound index,
 		// allowing us to ensure users cannot have the same name for two
@@ -179,6 +224,10 @@ func allCollections() collectionSchema {
 		// given collection.
 		modelUsersC: {},
 
+		// This collection holds the access users have been granted on
+		// individual services, in addition to their model access.
+		serviceUsersC: {},
+
 		// This collection holds the last time the model user connected
 		// to the model.
 		modelUserLastConnectionC: {
@@ -284,6 +333,11 @@ func allCollections() collectionSchema {
 		},
 		volumeAttachmentsC: {},
 
+		// This collection records provider snapshots of volumes. The
+		// snapshots are kept after the snapshotted volume is removed,
+		// so that new volumes may still be created from them.
+		volumeSnapshotsC: {},
+
 		// -----
 
 		// These collections hold information associated with networking.
@@ -400,6 +454,7 @@ const (
 	actionsC                 = "actions"
 	annotationsC             = "annotations"
 	assignUnitC              = "assignUnits"
+	auditLogC                = "auditlog"
 	bakeryStorageItemsC      = "bakeryStorageItems"
 	blockDevicesC            = "blockdevices"
 	blocksC                  = "blocks"
@@ -409,6 +464,7 @@ const (
 	constraintsC             = "constraints"
 	containerRefsC           = "containerRefs"
 	controllersC             = "controllers"
+	controllerUsersC         = "controllerusers"
 	filesystemAttachmentsC   = "filesystemAttachments"
 	filesystemsC             = "filesystems"
 	guimetadataC             = "guimetadata"
@@ -424,6 +480,7 @@ const (
 	minUnitsC                = "minunits"
 	migrationsStatusC        = "migrations.status"
 	migrationsActiveC        = "migrations.active"
+	migrationsMinionSyncC    = "migrations.minionsync"
 	migrationsC              = "migrations"
 	modelUserLastConnectionC = "modelUserLastConnection"
 	modelUsersC              = "modelusers"
@@ -435,6 +492,7 @@ const (
 	relationsC               = "relations"
 	restoreInfoC             = "restoreInfo"
 	sequenceC                = "sequence"
+	serviceUsersC            = "serviceusers"
 	servicesC                = "services"
 	endpointBindingsC        = "endpointbindings"
 	settingsC                = "settings"
@@ -455,11 +513,13 @@ const (
 	txnsC                    = "txns"
 	unitsC                   = "units"
 	upgradeInfoC             = "upgradeInfo"
+	userFailedLoginsC        = "userFailedLogins"
 	userLastLoginC           = "userLastLogin"
 	usermodelnameC           = "usermodelname"
 	usersC                   = "users"
 	volumeAttachmentsC       = "volumeattachments"
 	volumesC                 = "volumes"
+	volumeSnapshotsC         = "volumesnapshots"
 	// "payloads" (see payload/persistence/mongo.go)
 	// "resources" (see resource/persistence/mongo.go)
 )
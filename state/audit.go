@@ -0,0 +1,142 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state
+
+import (
+	"strings"
+	"time"
+
+	"github.com/juju/errors"
+	"gopkg.in/mgo.v2/bson"
+
+	"github.com/juju/juju/audit"
+)
+
+// auditLogDoc records a single API call made by a user.
+type auditLogDoc struct {
+	Id        bson.ObjectId `bson:"_id"`
+	Timestamp time.Time     `bson:"timestamp"`
+	User      string        `bson:"user"`
+	ModelUUID string        `bson:"model-uuid"`
+	Facade    string        `bson:"facade"`
+	Version   int           `bson:"version"`
+	Method    string        `bson:"method"`
+	Args      string        `bson:"args,omitempty"`
+	Error     string        `bson:"error,omitempty"`
+	ErrorCode string        `bson:"error-code,omitempty"`
+}
+
+// PutAuditEntry records the given audit log entry.
+func (st *State) PutAuditEntry(entry audit.Entry) error {
+	if err := entry.Validate(); err != nil {
+		return errors.Trace(err)
+	}
+	auditLog, closer := st.getRawCollection(auditLogC)
+	defer closer()
+
+	err := auditLog.Insert(&auditLogDoc{
+		Id:        bson.NewObjectId(),
+		Timestamp: entry.Timestamp.UTC(),
+		User:      entry.User,
+		ModelUUID: entry.ModelUUID,
+		Facade:    entry.Facade,
+		Version:   entry.Version,
+		Method:    entry.Method,
+		Args:      entry.Args,
+		Error:     entry.Error,
+		ErrorCode: entry.ErrorCode,
+	})
+	return errors.Annotate(err, "cannot write audit log entry")
+}
+
+// AuditLogFilter restricts the entries returned by AuditEntries.
+// Zero-valued fields do not restrict the results.
+type AuditLogFilter struct {
+	// User is the tag of the user who made the calls.
+	User string
+
+	// ModelUUID identifies the model the calls were made to.
+	ModelUUID string
+
+	// Method is the name of the API method called. It may be given
+	// either as "Method" or as "Facade.Method".
+	Method string
+
+	// After and Before bound the times of the calls. Both bounds
+	// are inclusive.
+	After  time.Time
+	Before time.Time
+
+	// Limit is the maximum number of entries to return. The most
+	// recent entries are returned.
+	Limit int
+}
+
+// AuditEntries returns the audit log entries that match the given
+// filter, oldest first.
+func (st *State) AuditEntries(filter AuditLogFilter) ([]audit.Entry, error) {
+	auditLog, closer := st.getRawCollection(auditLogC)
+	defer closer()
+
+	query := bson.D{}
+	if filter.User != "" {
+		query = append(query, bson.DocElem{"user", filter.User})
+	}
+	if filter.ModelUUID != "" {
+		query = append(query, bson.DocElem{"model-uuid", filter.ModelUUID})
+	}
+	if filter.Method != "" {
+		if facade, method, ok := splitFacadeMethod(filter.Method); ok {
+			query = append(query, bson.DocElem{"facade", facade})
+			query = append(query, bson.DocElem{"method", method})
+		} else {
+			query = append(query, bson.DocElem{"method", filter.Method})
+		}
+	}
+	timestamp := bson.M{}
+	if !filter.After.IsZero() {
+		timestamp["$gte"] = filter.After.UTC()
+	}
+	if !filter.Before.IsZero() {
+		timestamp["$lte"] = filter.Before.UTC()
+	}
+	if len(timestamp) > 0 {
+		query = append(query, bson.DocElem{"timestamp", timestamp})
+	}
+
+	q := auditLog.Find(query).Sort("-timestamp", "-_id")
+	if filter.Limit > 0 {
+		q = q.Limit(filter.Limit)
+	}
+	var docs []auditLogDoc
+	if err := q.All(&docs); err != nil {
+		return nil, errors.Annotate(err, "cannot read audit log")
+	}
+
+	entries := make([]audit.Entry, len(docs))
+	for i, doc := range docs {
+		// The docs are newest first; reverse them.
+		entries[len(docs)-1-i] = audit.Entry{
+			Timestamp: doc.Timestamp.UTC(),
+			User:      doc.User,
+			ModelUUID: doc.ModelUUID,
+			Facade:    doc.Facade,
+			Version:   doc.Version,
+			Method:    doc.Method,
+			Args:      doc.Args,
+			Error:     doc.Error,
+			ErrorCode: doc.ErrorCode,
+		}
+	}
+	return entries, nil
+}
+
+// splitFacadeMethod splits a "Facade.Method" string.
+func splitFacadeMethod(s string) (facade, method string, ok bool) {
+	parts := strings.SplitN(s, ".", 2)
+	if len(parts) != 2 {
+		return "", "", false
+	}
+	return parts[0], parts[1], true
+}
@@ -0,0 +1,124 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state_test
+
+import (
+	"time"
+
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/audit"
+	"github.com/juju/juju/state"
+)
+
+type AuditSuite struct {
+	ConnSuite
+	t0 time.Time
+}
+
+var _ = gc.Suite(&AuditSuite{})
+
+func (s *AuditSuite) SetUpTest(c *gc.C) {
+	s.ConnSuite.SetUpTest(c)
+	s.t0 = time.Date(2016, 6, 1, 12, 0, 0, 0, time.UTC)
+}
+
+func (s *AuditSuite) entry(offset time.Duration, user, facade, method string) audit.Entry {
+	return audit.Entry{
+		Timestamp: s.t0.Add(offset),
+		User:      user,
+		ModelUUID: s.State.ModelUUID(),
+		Facade:    facade,
+		Version:   1,
+		Method:    method,
+		Args:      `{"entities":[]}`,
+	}
+}
+
+func (s *AuditSuite) putEntries(c *gc.C, entries ...audit.Entry) {
+	for _, entry := range entries {
+		err := s.State.PutAuditEntry(entry)
+		c.Assert(err, jc.ErrorIsNil)
+	}
+}
+
+func (s *AuditSuite) TestPutInvalid(c *gc.C) {
+	entry := s.entry(0, "user-bob@local", "Service", "Destroy")
+	entry.Method = ""
+
+	err := s.State.PutAuditEntry(entry)
+
+	c.Check(err, jc.Satisfies, errors.IsNotValid)
+}
+
+func (s *AuditSuite) TestRoundTrip(c *gc.C) {
+	entry := s.entry(0, "user-bob@local", "Service", "Destroy")
+	entry.Error = "permission denied"
+	entry.ErrorCode = "unauthorized access"
+	s.putEntries(c, entry)
+
+	entries, err := s.State.AuditEntries(state.AuditLogFilter{})
+	c.Assert(err, jc.ErrorIsNil)
+
+	c.Check(entries, jc.DeepEquals, []audit.Entry{entry})
+}
+
+func (s *AuditSuite) TestOldestFirst(c *gc.C) {
+	second := s.entry(time.Minute, "user-bob@local", "Service", "Destroy")
+	first := s.entry(0, "user-bob@local", "Service", "Deploy")
+	s.putEntries(c, second, first)
+
+	entries, err := s.State.AuditEntries(state.AuditLogFilter{})
+	c.Assert(err, jc.ErrorIsNil)
+
+	c.Check(entries, jc.DeepEquals, []audit.Entry{first, second})
+}
+
+func (s *AuditSuite) TestFilters(c *gc.C) {
+	bobDeploy := s.entry(0, "user-bob@local", "Service", "Deploy")
+	aliceDeploy := s.entry(time.Minute, "user-alice@local", "Service", "Deploy")
+	bobDestroy := s.entry(2*time.Minute, "user-bob@local", "Service", "Destroy")
+	bobAddMachines := s.entry(3*time.Minute, "user-bob@local", "Client", "AddMachines")
+	s.putEntries(c, bobDeploy, aliceDeploy, bobDestroy, bobAddMachines)
+
+	for i, test := range []struct {
+		about    string
+		filter   state.AuditLogFilter
+		expected []audit.Entry
+	}{{
+		about:    "user",
+		filter:   state.AuditLogFilter{User: "user-alice@local"},
+		expected: []audit.Entry{aliceDeploy},
+	}, {
+		about:    "method",
+		filter:   state.AuditLogFilter{Method: "Deploy"},
+		expected: []audit.Entry{bobDeploy, aliceDeploy},
+	}, {
+		about:    "facade and method",
+		filter:   state.AuditLogFilter{Method: "Client.AddMachines"},
+		expected: []audit.Entry{bobAddMachines},
+	}, {
+		about:    "model",
+		filter:   state.AuditLogFilter{ModelUUID: "deadbeef-0bad-400d-8000-4b1d0d06f00d"},
+		expected: []audit.Entry{},
+	}, {
+		about: "time range",
+		filter: state.AuditLogFilter{
+			After:  s.t0.Add(time.Minute),
+			Before: s.t0.Add(2 * time.Minute),
+		},
+		expected: []audit.Entry{aliceDeploy, bobDestroy},
+	}, {
+		about:    "limit",
+		filter:   state.AuditLogFilter{User: "user-bob@local", Limit: 2},
+		expected: []audit.Entry{bobDestroy, bobAddMachines},
+	}} {
+		c.Logf("test %d: %s", i, test.about)
+		entries, err := s.State.AuditEntries(test.filter)
+		c.Assert(err, jc.ErrorIsNil)
+		c.Check(entries, jc.DeepEquals, test.expected)
+	}
+}
@@ -30,6 +30,7 @@ const (
 	cleanupAttachmentsForDyingFilesystem cleanupKind = "filesystemAttachments"
 	cleanupModelsForDyingController      cleanupKind = "models"
 	cleanupMachinesForDyingModel         cleanupKind = "modelMachines"
+	cleanupServiceUsersForRemovedService cleanupKind = "serviceUsers"
 )
 
 // cleanupDoc represents a potentially large set of documents that should be
@@ -105,6 +106,8 @@ func (st *State) Cleanup() (err error) {
 			err = st.cleanupModelsForDyingController()
 		case cleanupMachinesForDyingModel:
 			err = st.cleanupMachinesForDyingModel()
+		case cleanupServiceUsersForRemovedService:
+			err = st.cleanupServiceUsers(doc.Prefix)
 		default:
 			handler, ok := cleanupHandlers[doc.Kind]
 			if !ok {
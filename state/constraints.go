@@ -27,6 +27,7 @@ type constraintsDoc struct {
 	Container    *instance.ContainerType
 	Tags         *[]string
 	Spaces       *[]string
+	Zones        *[]string
 }
 
 func (doc constraintsDoc) value() constraints.Value {
@@ -40,6 +41,7 @@ func (doc constraintsDoc) value() constraints.Value {
 		Container:    doc.Container,
 		Tags:         doc.Tags,
 		Spaces:       doc.Spaces,
+		Zones:        doc.Zones,
 	}
 }
 
@@ -54,6 +56,7 @@ func newConstraintsDoc(st *State, cons constraints.Value) constraintsDoc {
 		Container:    cons.Container,
 		Tags:         cons.Tags,
 		Spaces:       cons.Spaces,
+		Zones:        cons.Zones,
 	}
 }
 
@@ -0,0 +1,147 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state
+
+import (
+	"strings"
+	"time"
+
+	"github.com/juju/errors"
+	"github.com/juju/names"
+	jujutxn "github.com/juju/txn"
+	"gopkg.in/mgo.v2"
+	"gopkg.in/mgo.v2/bson"
+	"gopkg.in/mgo.v2/txn"
+)
+
+// ControllerAccess represents the level of access granted to a user on
+// the controller.
+type ControllerAccess string
+
+const (
+	// ControllerLoginAccess allows a user to log in to the controller.
+	ControllerLoginAccess ControllerAccess = "login"
+
+	// ControllerAddModelAccess allows a user to add models to the
+	// controller, as well as to log in.
+	ControllerAddModelAccess ControllerAccess = "addmodel"
+
+	// ControllerSuperuserAccess allows a user full control over the
+	// controller and all of its models.
+	ControllerSuperuserAccess ControllerAccess = "superuser"
+)
+
+// controllerAccessLevels orders the controller access levels, so that
+// each includes all those below it.
+var controllerAccessLevels = map[ControllerAccess]int{
+	ControllerLoginAccess:     1,
+	ControllerAddModelAccess:  2,
+	ControllerSuperuserAccess: 3,
+}
+
+// Validate returns an error if the access is not a known controller
+// access level.
+func (a ControllerAccess) Validate() error {
+	if _, ok := controllerAccessLevels[a]; !ok {
+		return errors.NotValidf("controller access %q", a)
+	}
+	return nil
+}
+
+// EqualOrGreaterThan returns whether the access includes all that is
+// allowed by the other access.
+func (a ControllerAccess) EqualOrGreaterThan(other ControllerAccess) bool {
+	return controllerAccessLevels[a] >= controllerAccessLevels[other]
+}
+
+// controllerUserDoc records the access a user has on the controller.
+// Local users are given login access when they are added; external
+// users have no access to the controller, beyond that given by their
+// access to models, until it is granted.
+type controllerUserDoc struct {
+	ID          string           `bson:"_id"`
+	UserName    string           `bson:"user"`
+	Access      ControllerAccess `bson:"access"`
+	CreatedBy   string           `bson:"createdby"`
+	DateCreated time.Time        `bson:"datecreated"`
+}
+
+func controllerUserID(user names.UserTag) string {
+	return strings.ToLower(user.Canonical())
+}
+
+func createControllerUserOp(user names.UserTag, createdBy string, access ControllerAccess, dateCreated time.Time) txn.Op {
+	return txn.Op{
+		C:      controllerUsersC,
+		Id:     controllerUserID(user),
+		Assert: txn.DocMissing,
+		Insert: &controllerUserDoc{
+			ID:          controllerUserID(user),
+			UserName:    user.Canonical(),
+			Access:      access,
+			CreatedBy:   createdBy,
+			DateCreated: dateCreated,
+		},
+	}
+}
+
+// ControllerAccess returns the access the user has on the controller.
+// It returns an error satisfying errors.IsNotFound if the user has
+// not been granted any.
+func (st *State) ControllerAccess(user names.UserTag) (ControllerAccess, error) {
+	controllerUsers, closer := st.getCollection(controllerUsersC)
+	defer closer()
+
+	var doc controllerUserDoc
+	err := controllerUsers.FindId(controllerUserID(user)).One(&doc)
+	if err == mgo.ErrNotFound {
+		return "", errors.NotFoundf("controller access for %q", user.Canonical())
+	} else if err != nil {
+		return "", errors.Trace(err)
+	}
+	return doc.Access, nil
+}
+
+// SetControllerAccess sets the access the user has on the controller,
+// granting it if the user had none.
+func (st *State) SetControllerAccess(user names.UserTag, access ControllerAccess, createdBy names.UserTag) error {
+	if err := access.Validate(); err != nil {
+		return errors.Trace(err)
+	}
+	buildTxn := func(attempt int) ([]txn.Op, error) {
+		current, err := st.ControllerAccess(user)
+		if errors.IsNotFound(err) {
+			return []txn.Op{createControllerUserOp(user, createdBy.Canonical(), access, nowToTheSecond())}, nil
+		} else if err != nil {
+			return nil, errors.Trace(err)
+		}
+		if current == access {
+			return nil, jujutxn.ErrNoOperations
+		}
+		return []txn.Op{{
+			C:      controllerUsersC,
+			Id:     controllerUserID(user),
+			Assert: txn.DocExists,
+			Update: bson.D{{"$set", bson.D{{"access", access}}}},
+		}}, nil
+	}
+	err := st.run(buildTxn)
+	return errors.Annotatef(err, "cannot set controller access for %q", user.Canonical())
+}
+
+// RemoveControllerAccess removes all of the user's access to the
+// controller.
+func (st *State) RemoveControllerAccess(user names.UserTag) error {
+	ops := []txn.Op{{
+		C:      controllerUsersC,
+		Id:     controllerUserID(user),
+		Assert: txn.DocExists,
+		Remove: true,
+	}}
+	err := st.runTransaction(ops)
+	if err == txn.ErrAborted {
+		return errors.NotFoundf("controller access for %q", user.Canonical())
+	}
+	return errors.Trace(err)
+}
@@ -0,0 +1,90 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state_test
+
+import (
+	"github.com/juju/errors"
+	"github.com/juju/names"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/state"
+	"github.com/juju/juju/testing/factory"
+)
+
+type ControllerUserSuite struct {
+	ConnSuite
+}
+
+var _ = gc.Suite(&ControllerUserSuite{})
+
+func (s *ControllerUserSuite) TestOwnerIsSuperuser(c *gc.C) {
+	access, err := s.State.ControllerAccess(s.Owner)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(access, gc.Equals, state.ControllerSuperuserAccess)
+}
+
+func (s *ControllerUserSuite) TestAddUserGrantsLogin(c *gc.C) {
+	user := s.Factory.MakeUser(c, &factory.UserParams{NoModelUser: true})
+	access, err := s.State.ControllerAccess(user.UserTag())
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(access, gc.Equals, state.ControllerLoginAccess)
+}
+
+func (s *ControllerUserSuite) TestExternalUserHasNoAccess(c *gc.C) {
+	_, err := s.State.ControllerAccess(names.NewUserTag("bob@remote"))
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+	c.Assert(err, gc.ErrorMatches, `controller access for "bob@remote" not found`)
+}
+
+func (s *ControllerUserSuite) TestSetControllerAccess(c *gc.C) {
+	user := names.NewUserTag("bob@remote")
+	err := s.State.SetControllerAccess(user, state.ControllerAddModelAccess, s.Owner)
+	c.Assert(err, jc.ErrorIsNil)
+	access, err := s.State.ControllerAccess(user)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(access, gc.Equals, state.ControllerAddModelAccess)
+
+	err = s.State.SetControllerAccess(user, state.ControllerLoginAccess, s.Owner)
+	c.Assert(err, jc.ErrorIsNil)
+	access, err = s.State.ControllerAccess(user)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(access, gc.Equals, state.ControllerLoginAccess)
+
+	// Setting the same access again is a no-op.
+	err = s.State.SetControllerAccess(user, state.ControllerLoginAccess, s.Owner)
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *ControllerUserSuite) TestSetControllerAccessCaseInsensitive(c *gc.C) {
+	err := s.State.SetControllerAccess(names.NewUserTag("Bob@remote"), state.ControllerAddModelAccess, s.Owner)
+	c.Assert(err, jc.ErrorIsNil)
+	access, err := s.State.ControllerAccess(names.NewUserTag("bob@remote"))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(access, gc.Equals, state.ControllerAddModelAccess)
+}
+
+func (s *ControllerUserSuite) TestSetControllerAccessInvalid(c *gc.C) {
+	err := s.State.SetControllerAccess(names.NewUserTag("bob@remote"), state.ControllerAccess("root"), s.Owner)
+	c.Assert(err, jc.Satisfies, errors.IsNotValid)
+	c.Assert(err, gc.ErrorMatches, `controller access "root" not valid`)
+}
+
+func (s *ControllerUserSuite) TestRemoveControllerAccess(c *gc.C) {
+	user := s.Factory.MakeUser(c, &factory.UserParams{NoModelUser: true})
+	err := s.State.RemoveControllerAccess(user.UserTag())
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = s.State.ControllerAccess(user.UserTag())
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+
+	err = s.State.RemoveControllerAccess(user.UserTag())
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+}
+
+func (s *ControllerUserSuite) TestControllerAccessEqualOrGreaterThan(c *gc.C) {
+	c.Assert(state.ControllerSuperuserAccess.EqualOrGreaterThan(state.ControllerAddModelAccess), jc.IsTrue)
+	c.Assert(state.ControllerAddModelAccess.EqualOrGreaterThan(state.ControllerLoginAccess), jc.IsTrue)
+	c.Assert(state.ControllerLoginAccess.EqualOrGreaterThan(state.ControllerLoginAccess), jc.IsTrue)
+	c.Assert(state.ControllerLoginAccess.EqualOrGreaterThan(state.ControllerAddModelAccess), jc.IsFalse)
+}
@@ -247,6 +247,7 @@ func GetPasswordHash(e Authenticator) string {
 
 func init() {
 	txnLogSize = txnLogSizeTests
+	auditLogSize = auditLogSizeTests
 }
 
 // TxnRevno returns the txn-revno field of the document
@@ -135,6 +135,11 @@ type FilesystemParams struct {
 	// the filesystem's lifecycle will be bound.
 	binding names.Tag
 
+	// snapshotId, if non-empty, is the storage provider's ID for
+	// the snapshot from which the filesystem's backing volume is
+	// to be created.
+	snapshotId string
+
 	Pool string `bson:"pool"`
 	Size uint64 `bson:"size"`
 }
@@ -737,6 +742,11 @@ func (st *State) addFilesystemOps(params FilesystemParams, machineId string) ([]
 	if err != nil {
 		return nil, names.FilesystemTag{}, names.VolumeTag{}, errors.Trace(err)
 	}
+	if provider.Supports(storage.StorageKindFilesystem) && params.snapshotId != "" {
+		return nil, names.FilesystemTag{}, names.VolumeTag{}, errors.NotSupportedf(
+			"creating filesystem from volume snapshot with storage pool %q", params.Pool,
+		)
+	}
 	if !provider.Supports(storage.StorageKindFilesystem) {
 		var volumeOps []txn.Op
 		volumeParams := VolumeParams{
@@ -744,6 +754,7 @@ func (st *State) addFilesystemOps(params FilesystemParams, machineId string) ([]
 			filesystemTag, // volume is bound to filesystem
 			params.Pool,
 			params.Size,
+			params.snapshotId,
 		}
 		volumeOps, volumeTag, err = st.addVolumeOps(volumeParams, machineId)
 		if err != nil {
@@ -778,6 +789,171 @@ func (st *State) addFilesystemOps(params FilesystemParams, machineId string) ([]
 	return ops, filesystemTag, volumeTag, nil
 }
 
+// AddExistingFilesystem imports an existing, already-provisioned
+// filesystem into the model, along with its backing volume if it has
+// one. A storage instance with the given storage name and no owner is
+// created for the filesystem, so that it may be attached to a unit;
+// the storage instance's tag is returned. The filesystem and volume
+// start out detached.
+func (st *State) AddExistingFilesystem(
+	info FilesystemInfo,
+	backingVolume *VolumeInfo,
+	storageName string,
+) (_ names.StorageTag, err error) {
+	defer errors.DeferredAnnotatef(&err, "cannot add existing filesystem")
+	if err := st.validateAddExistingFilesystem(info, backingVolume, storageName); err != nil {
+		return names.StorageTag{}, errors.Trace(err)
+	}
+	storageId, err := newStorageInstanceId(st, storageName)
+	if err != nil {
+		return names.StorageTag{}, errors.Trace(err)
+	}
+	storageTag := names.NewStorageTag(storageId)
+	filesystemId, err := newFilesystemId(st, "")
+	if err != nil {
+		return names.StorageTag{}, errors.Annotate(err, "cannot generate filesystem name")
+	}
+	filesystemTag := names.NewFilesystemTag(filesystemId)
+
+	var ops []txn.Op
+	var volumeId string
+	if backingVolume != nil {
+		volumeId, err = newVolumeName(st, "")
+		if err != nil {
+			return names.StorageTag{}, errors.Annotate(err, "cannot generate volume name")
+		}
+		ops = append(ops,
+			createStatusOp(st, volumeGlobalKey(volumeId), statusDoc{
+				Status:  status.StatusDetached,
+				Updated: time.Now().UnixNano(),
+			}),
+			txn.Op{
+				C:      volumesC,
+				Id:     volumeId,
+				Assert: txn.DocMissing,
+				Insert: &volumeDoc{
+					Name:      volumeId,
+					StorageId: storageId,
+					Binding:   filesystemTag.String(), // volume is bound to filesystem
+					Info:      backingVolume,
+				},
+			},
+		)
+	}
+	ops = append(ops,
+		createStatusOp(st, filesystemGlobalKey(filesystemId), statusDoc{
+			Status:  status.StatusDetached,
+			Updated: time.Now().UnixNano(),
+		}),
+		txn.Op{
+			C:      filesystemsC,
+			Id:     filesystemId,
+			Assert: txn.DocMissing,
+			Insert: &filesystemDoc{
+				FilesystemId: filesystemId,
+				VolumeId:     volumeId,
+				StorageId:    storageId,
+				Binding:      storageTag.String(),
+				Info:         &info,
+			},
+		},
+		txn.Op{
+			C:      storageInstancesC,
+			Id:     storageId,
+			Assert: txn.DocMissing,
+			Insert: &storageInstanceDoc{
+				Id:          storageId,
+				Kind:        StorageKindFilesystem,
+				StorageName: storageName,
+			},
+		},
+	)
+	if err := st.runTransaction(ops); err != nil {
+		return names.StorageTag{}, errors.Trace(err)
+	}
+	return storageTag, nil
+}
+
+// validateAddExistingFilesystem returns an error if the information
+// describing an existing filesystem, or its backing volume, is not
+// complete, or refers to a pool that cannot provide it.
+func (st *State) validateAddExistingFilesystem(
+	info FilesystemInfo,
+	backingVolume *VolumeInfo,
+	storageName string,
+) error {
+	if !names.IsValidStorage(storageName + "/0") {
+		return errors.NotValidf("storage name %q", storageName)
+	}
+	if backingVolume == nil {
+		if info.FilesystemId == "" {
+			return errors.NotValidf("filesystem info missing filesystem ID")
+		}
+		if err := validateStoragePool(st, info.Pool, storage.StorageKindFilesystem, nil); err != nil {
+			return errors.Trace(err)
+		}
+		filesystems, err := st.filesystems(bson.D{{"info.filesystemid", info.FilesystemId}})
+		if err != nil {
+			return errors.Trace(err)
+		}
+		for _, f := range filesystems {
+			if err := validateProviderIdUnique(st, info.Pool, f.doc.Info.Pool); err != nil {
+				return errors.Annotatef(err, "filesystem %q", info.FilesystemId)
+			}
+		}
+		return nil
+	}
+	if info.FilesystemId != "" {
+		return errors.New("filesystem with a backing volume must not have a filesystem ID")
+	}
+	if backingVolume.VolumeId == "" {
+		return errors.NotValidf("backing volume info missing volume ID")
+	}
+	if backingVolume.Pool != info.Pool {
+		return errors.Errorf(
+			"backing volume pool %q different to filesystem pool %q",
+			backingVolume.Pool, info.Pool,
+		)
+	}
+	if err := validateStoragePool(st, backingVolume.Pool, storage.StorageKindBlock, nil); err != nil {
+		return errors.Trace(err)
+	}
+	volumes, err := st.volumes(bson.D{{"info.volumeid", backingVolume.VolumeId}})
+	if err != nil {
+		return errors.Trace(err)
+	}
+	for _, v := range volumes {
+		if err := validateProviderIdUnique(st, backingVolume.Pool, v.doc.Info.Pool); err != nil {
+			return errors.Annotatef(err, "volume %q", backingVolume.VolumeId)
+		}
+	}
+	return nil
+}
+
+// validateProviderIdUnique returns an error if storage being added to
+// the given pool has the same provider ID as existing storage in the
+// existing pool, and the pools are managed by the same storage
+// provider. Provider IDs are only unique within a storage provider.
+func validateProviderIdUnique(st *State, pool, existingPool string) error {
+	if pool != existingPool {
+		providerType, _, err := poolStorageProvider(st, pool)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		existingProviderType, _, err := poolStorageProvider(st, existingPool)
+		if errors.IsNotFound(err) {
+			// The existing storage's pool has been removed, so
+			// we cannot tell which provider manages it. Err on
+			// the side of caution, and treat it as a duplicate.
+		} else if err != nil {
+			return errors.Trace(err)
+		} else if providerType != existingProviderType {
+			return nil
+		}
+	}
+	return errors.AlreadyExistsf("storage with the same provider ID")
+}
+
 func (st *State) filesystemParamsWithDefaults(params FilesystemParams) (FilesystemParams, error) {
 	if params.Pool != "" {
 		return params, nil
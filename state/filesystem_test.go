@@ -12,6 +12,7 @@ import (
 
 	"github.com/juju/juju/state"
 	"github.com/juju/juju/state/testing"
+	"github.com/juju/juju/status"
 )
 
 type FilesystemStateSuite struct {
@@ -1032,3 +1033,177 @@ func (s *FilesystemStateSuite) setupFilesystemAttachment(c *gc.C, pool string) (
 	assertMachineStorageRefs(c, s.State, machine.MachineTag())
 	return s.filesystem(c, attachments[0].Filesystem()), machine
 }
+
+func (s *FilesystemStateSuite) TestAddExistingFilesystem(c *gc.C) {
+	fsInfo := state.FilesystemInfo{
+		FilesystemId: "foo",
+		Pool:         "environscoped",
+		Size:         123,
+	}
+	storageTag, err := s.State.AddExistingFilesystem(fsInfo, nil, "pgdata")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(storageTag, gc.Equals, names.NewStorageTag("pgdata/0"))
+
+	storageInstance, err := s.State.StorageInstance(storageTag)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(storageInstance.Kind(), gc.Equals, state.StorageKindFilesystem)
+	c.Assert(storageInstance.StorageName(), gc.Equals, "pgdata")
+	_, ok := storageInstance.Owner()
+	c.Assert(ok, jc.IsFalse)
+
+	filesystem := s.storageInstanceFilesystem(c, storageTag)
+	info, err := filesystem.Info()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(info, jc.DeepEquals, fsInfo)
+	_, err = filesystem.Volume()
+	c.Assert(err, gc.Equals, state.ErrNoBackingVolume)
+	c.Assert(filesystem.LifeBinding(), gc.Equals, storageTag)
+	fsStatus, err := filesystem.Status()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(fsStatus.Status, gc.Equals, status.StatusDetached)
+}
+
+func (s *FilesystemStateSuite) TestAddExistingFilesystemWithBackingVolume(c *gc.C) {
+	fsInfo := state.FilesystemInfo{
+		Pool: "persistent-block",
+		Size: 1024,
+	}
+	volInfo := state.VolumeInfo{
+		VolumeId:   "vol-123",
+		Pool:       "persistent-block",
+		Size:       1024,
+		Persistent: true,
+	}
+	storageTag, err := s.State.AddExistingFilesystem(fsInfo, &volInfo, "pgdata")
+	c.Assert(err, jc.ErrorIsNil)
+
+	filesystem := s.storageInstanceFilesystem(c, storageTag)
+	info, err := filesystem.Info()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(info, jc.DeepEquals, fsInfo)
+
+	volume := s.filesystemVolume(c, filesystem.FilesystemTag())
+	vinfo, err := volume.Info()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(vinfo, jc.DeepEquals, volInfo)
+	c.Assert(volume.LifeBinding(), gc.Equals, filesystem.FilesystemTag())
+	storageInstanceVolume := s.storageInstanceVolume(c, storageTag)
+	c.Assert(storageInstanceVolume.VolumeTag(), gc.Equals, volume.VolumeTag())
+}
+
+func (s *FilesystemStateSuite) TestAddExistingFilesystemInvalid(c *gc.C) {
+	volInfo := state.VolumeInfo{VolumeId: "vol-123", Pool: "persistent-block", Size: 1024}
+	for i, t := range []struct {
+		fsInfo  state.FilesystemInfo
+		volInfo *state.VolumeInfo
+		name    string
+		err     string
+	}{{
+		fsInfo: state.FilesystemInfo{FilesystemId: "foo", Pool: "environscoped"},
+		name:   "0data",
+		err:    `storage name "0data" not valid`,
+	}, {
+		fsInfo: state.FilesystemInfo{Pool: "environscoped"},
+		name:   "data",
+		err:    `filesystem info missing filesystem ID not valid`,
+	}, {
+		fsInfo: state.FilesystemInfo{FilesystemId: "foo", Pool: "environscoped-block"},
+		name:   "data",
+		err:    `"environscoped-block" provider does not support "filesystem" storage`,
+	}, {
+		fsInfo:  state.FilesystemInfo{FilesystemId: "foo", Pool: "persistent-block"},
+		volInfo: &volInfo,
+		name:    "data",
+		err:     `filesystem with a backing volume must not have a filesystem ID`,
+	}, {
+		fsInfo:  state.FilesystemInfo{Pool: "persistent-block"},
+		volInfo: &state.VolumeInfo{Pool: "persistent-block"},
+		name:    "data",
+		err:     `backing volume info missing volume ID not valid`,
+	}, {
+		fsInfo:  state.FilesystemInfo{Pool: "environscoped"},
+		volInfo: &volInfo,
+		name:    "data",
+		err:     `backing volume pool "persistent-block" different to filesystem pool "environscoped"`,
+	}, {
+		fsInfo:  state.FilesystemInfo{Pool: "nope"},
+		volInfo: &state.VolumeInfo{VolumeId: "vol-123", Pool: "nope"},
+		name:    "data",
+		err:     `.*pool "nope" not found`,
+	}} {
+		c.Logf("test %d", i)
+		_, err := s.State.AddExistingFilesystem(t.fsInfo, t.volInfo, t.name)
+		c.Check(err, gc.ErrorMatches, "cannot add existing filesystem: "+t.err)
+	}
+	storageInstances, err := s.State.AllStorageInstances()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(storageInstances, gc.HasLen, 0)
+}
+
+func (s *FilesystemStateSuite) TestAddExistingFilesystemDuplicate(c *gc.C) {
+	fsInfo := state.FilesystemInfo{FilesystemId: "foo", Pool: "environscoped"}
+	_, err := s.State.AddExistingFilesystem(fsInfo, nil, "pgdata")
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = s.State.AddExistingFilesystem(fsInfo, nil, "pgdata")
+	c.Assert(err, gc.ErrorMatches, `cannot add existing filesystem: filesystem "foo": storage with the same provider ID already exists`)
+	c.Assert(err, jc.Satisfies, errors.IsAlreadyExists)
+}
+
+func (s *FilesystemStateSuite) TestAddExistingFilesystemDuplicateBackingVolume(c *gc.C) {
+	fsInfo := state.FilesystemInfo{Pool: "persistent-block", Size: 1024}
+	volInfo := state.VolumeInfo{VolumeId: "vol-123", Pool: "persistent-block", Size: 1024}
+	_, err := s.State.AddExistingFilesystem(fsInfo, &volInfo, "pgdata")
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = s.State.AddExistingFilesystem(fsInfo, &volInfo, "pgdata")
+	c.Assert(err, gc.ErrorMatches, `cannot add existing filesystem: volume "vol-123": storage with the same provider ID already exists`)
+	c.Assert(err, jc.Satisfies, errors.IsAlreadyExists)
+
+	storageInstances, err := s.State.AllStorageInstances()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(storageInstances, gc.HasLen, 1)
+}
+
+func (s *FilesystemStateSuite) TestAddExistingFilesystemAttachStorage(c *gc.C) {
+	fsInfo := state.FilesystemInfo{Pool: "persistent-block", Size: 1024}
+	volInfo := state.VolumeInfo{
+		VolumeId:   "vol-123",
+		Pool:       "persistent-block",
+		Size:       1024,
+		Persistent: true,
+	}
+	storageTag, err := s.State.AddExistingFilesystem(fsInfo, &volInfo, "data")
+	c.Assert(err, jc.ErrorIsNil)
+	filesystem := s.storageInstanceFilesystem(c, storageTag)
+	volume := s.filesystemVolume(c, filesystem.FilesystemTag())
+
+	ch := s.createStorageCharm(c, "storage-filesystem-optional", charm.Storage{
+		Name:     "data",
+		Type:     charm.StorageFilesystem,
+		CountMin: 0,
+		CountMax: 1,
+		Location: "/srv",
+	})
+	service := s.AddTestingService(c, "storage-filesystem-optional", ch)
+	unit, err := service.AddUnit()
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.State.AssignUnit(unit, state.AssignCleanEmpty)
+	c.Assert(err, jc.ErrorIsNil)
+	machineId, err := unit.AssignedMachineId()
+	c.Assert(err, jc.ErrorIsNil)
+	machineTag := names.NewMachineTag(machineId)
+
+	err = s.State.AttachStorage(storageTag, unit.UnitTag())
+	c.Assert(err, jc.ErrorIsNil)
+	storageInstance, err := s.State.StorageInstance(storageTag)
+	c.Assert(err, jc.ErrorIsNil)
+	owner, ok := storageInstance.Owner()
+	c.Assert(ok, jc.IsTrue)
+	c.Assert(owner, gc.Equals, unit.UnitTag())
+
+	// The imported filesystem and its backing volume are both
+	// attached to the unit's machine.
+	filesystemAttachment := s.filesystemAttachment(c, machineTag, filesystem.FilesystemTag())
+	c.Assert(filesystemAttachment.Life(), gc.Equals, state.Alive)
+	volumeAttachment := s.volumeAttachment(c, machineTag, volume.VolumeTag())
+	c.Assert(volumeAttachment.Life(), gc.Equals, state.Alive)
+}
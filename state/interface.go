@@ -141,6 +141,11 @@ type ActionReceiver interface {
 	// ActionReceiver.
 	AddAction(name string, payload map[string]interface{}) (Action, error)
 
+	// AddActionWithTimeout queues an action as AddAction does, but the
+	// action will be stopped if it runs for longer than timeout. A zero
+	// timeout means the action may run for as long as it likes.
+	AddActionWithTimeout(name string, payload map[string]interface{}, timeout time.Duration) (Action, error)
+
 	// CancelAction removes a pending Action from the queue for this
 	// ActionReceiver and marks it as cancelled.
 	CancelAction(action Action) (Action, error)
@@ -192,6 +197,10 @@ type Action interface {
 	// definition of the Action.
 	Parameters() map[string]interface{}
 
+	// Timeout returns the maximum time the action may run for once
+	// started, or zero if it has no time limit.
+	Timeout() time.Duration
+
 	// Enqueued returns the time the action was added to state as a pending
 	// Action.
 	Enqueued() time.Time
@@ -219,4 +228,9 @@ type Action interface {
 	// Finish removes action from the pending queue and captures the output
 	// and end state of the action.
 	Finish(results ActionResults) (Action, error)
+
+	// Cancel stops the action from running. A pending action is
+	// cancelled immediately; a running action is marked as aborting
+	// until its receiver stops it.
+	Cancel() (Action, error)
 }
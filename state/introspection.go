@@ -0,0 +1,33 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state
+
+import (
+	"github.com/juju/juju/utils/prometheus"
+)
+
+// Metrics describing the load on mongo and the allwatcher. They are
+// shared by all State instances in the process.
+var (
+	txnRetries = prometheus.NewCounter(
+		"juju_state_txn_retries_total",
+		"Number of times a transaction was retried after its assertions failed.",
+	)
+	allWatcherEntries = prometheus.NewGauge(
+		"juju_state_allwatcher_entries",
+		"Number of entities, including removed ones not yet seen by all watchers, held by allwatchers.",
+	)
+	allWatcherWaiting = prometheus.NewGauge(
+		"juju_state_allwatcher_waiting_watchers",
+		"Number of allwatcher clients with outstanding requests for changes.",
+	)
+)
+
+func init() {
+	prometheus.MustRegister(
+		txnRetries,
+		allWatcherEntries,
+		allWatcherWaiting,
+	)
+}
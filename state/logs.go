@@ -171,6 +171,53 @@ func (logger *DbLogger) Close() {
 	}
 }
 
+// LogImporter writes log records for a model which are being
+// transferred from another controller as part of a model migration.
+// Unlike DbLogger, the entity and timestamp of each record are
+// preserved.
+type LogImporter struct {
+	logsColl  *mgo.Collection
+	modelUUID string
+}
+
+// NewLogImporter returns a LogImporter which writes logs for the
+// model associated with st.
+func NewLogImporter(st LoggingState) *LogImporter {
+	_, logsColl := initLogsSession(st)
+	return &LogImporter{
+		logsColl:  logsColl,
+		modelUUID: st.ModelUUID(),
+	}
+}
+
+// Import writes a batch of log records to the database.
+func (importer *LogImporter) Import(records []*LogRecord) error {
+	if len(records) == 0 {
+		return nil
+	}
+	docs := make([]interface{}, len(records))
+	for i, r := range records {
+		docs[i] = &logDoc{
+			Id:        bson.NewObjectId(),
+			Time:      r.Time,
+			ModelUUID: importer.modelUUID,
+			Entity:    r.Entity,
+			Module:    r.Module,
+			Location:  r.Location,
+			Level:     r.Level,
+			Message:   r.Message,
+		}
+	}
+	return errors.Trace(importer.logsColl.Insert(docs...))
+}
+
+// Close cleans up resources used by the LogImporter instance.
+func (importer *LogImporter) Close() {
+	if importer.logsColl != nil {
+		importer.logsColl.Database.Session.Close()
+	}
+}
+
 // LogTailer allows for retrieval of Juju's logs from MongoDB. It
 // first returns any matching already recorded logs and then waits for
 // additional matching logs as they appear.
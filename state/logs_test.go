@@ -111,6 +111,58 @@ func (s *LogsSuite) TestDbLogger(c *gc.C) {
 	c.Assert(docs[1]["x"], gc.Equals, "oh noes")
 }
 
+func (s *LogsSuite) TestLogImporter(c *gc.C) {
+	importer := state.NewLogImporter(s.State)
+	defer importer.Close()
+	t0 := time.Now().Truncate(time.Millisecond) // MongoDB only stores timestamps with ms precision.
+	t1 := t0.Add(time.Second)
+	err := importer.Import([]*state.LogRecord{{
+		Time:     t0,
+		Entity:   "machine-1",
+		Module:   "some.where",
+		Location: "foo.go:99",
+		Level:    loggo.INFO,
+		Message:  "all is well",
+	}, {
+		Time:     t1,
+		Entity:   "unit-foo-0",
+		Module:   "else.where",
+		Location: "bar.go:42",
+		Level:    loggo.ERROR,
+		Message:  "oh noes",
+	}})
+	c.Assert(err, jc.ErrorIsNil)
+
+	var docs []bson.M
+	err = s.logsColl.Find(nil).Sort("t").All(&docs)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(docs, gc.HasLen, 2)
+
+	c.Assert(docs[0]["t"], gc.Equals, t0)
+	c.Assert(docs[0]["e"], gc.Equals, s.State.ModelUUID())
+	c.Assert(docs[0]["n"], gc.Equals, "machine-1")
+	c.Assert(docs[0]["m"], gc.Equals, "some.where")
+	c.Assert(docs[0]["l"], gc.Equals, "foo.go:99")
+	c.Assert(docs[0]["v"], gc.Equals, int(loggo.INFO))
+	c.Assert(docs[0]["x"], gc.Equals, "all is well")
+
+	c.Assert(docs[1]["t"], gc.Equals, t1)
+	c.Assert(docs[1]["e"], gc.Equals, s.State.ModelUUID())
+	c.Assert(docs[1]["n"], gc.Equals, "unit-foo-0")
+	c.Assert(docs[1]["m"], gc.Equals, "else.where")
+	c.Assert(docs[1]["l"], gc.Equals, "bar.go:42")
+	c.Assert(docs[1]["v"], gc.Equals, int(loggo.ERROR))
+	c.Assert(docs[1]["x"], gc.Equals, "oh noes")
+}
+
+func (s *LogsSuite) TestLogImporterEmptyBatch(c *gc.C) {
+	importer := state.NewLogImporter(s.State)
+	defer importer.Close()
+	err := importer.Import(nil)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.countLogs(c, s.State), gc.Equals, 0)
+}
+
 func (s *LogsSuite) TestPruneLogsByTime(c *gc.C) {
 	dbLogger := state.NewDbLogger(s.State, names.NewMachineTag("22"))
 	defer dbLogger.Close()
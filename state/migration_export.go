@@ -4,6 +4,8 @@
 package state
 
 import (
+	"encoding/hex"
+	"sort"
 	"strings"
 	"time"
 
@@ -14,6 +16,8 @@ import (
 	"gopkg.in/mgo.v2/bson"
 
 	"github.com/juju/juju/core/description"
+	"github.com/juju/juju/payload"
+	"github.com/juju/juju/storage/poolmanager"
 )
 
 // Export the current model for the State.
@@ -43,6 +47,9 @@ func (st *State) Export() (description.Model, error) {
 	if err := export.readAllConstraints(); err != nil {
 		return nil, errors.Trace(err)
 	}
+	if err := export.readAllStorageConstraints(); err != nil {
+		return nil, errors.Trace(err)
+	}
 
 	envConfig, found := export.settings[modelGlobalKey]
 	if !found {
@@ -78,12 +85,18 @@ func (st *State) Export() (description.Model, error) {
 	if err := export.machines(); err != nil {
 		return nil, errors.Trace(err)
 	}
+	if err := export.networking(); err != nil {
+		return nil, errors.Trace(err)
+	}
 	if err := export.services(); err != nil {
 		return nil, errors.Trace(err)
 	}
 	if err := export.relations(); err != nil {
 		return nil, errors.Trace(err)
 	}
+	if err := export.storage(); err != nil {
+		return nil, errors.Trace(err)
+	}
 
 	if err := export.model.Validate(); err != nil {
 		return nil, errors.Trace(err)
@@ -100,14 +113,24 @@ type exporter struct {
 	model   description.Model
 	logger  loggo.Logger
 
-	annotations   map[string]annotatorDoc
-	constraints   map[string]bson.M
-	settings      map[string]settingsDoc
-	status        map[string]bson.M
-	statusHistory map[string][]historicalStatusDoc
+	annotations        map[string]annotatorDoc
+	constraints        map[string]bson.M
+	storageConstraints map[string]storageConstraintsDoc
+	settings           map[string]settingsDoc
+	status             map[string]bson.M
+	statusHistory      map[string][]historicalStatusDoc
 	// Map of service name to units. Populated as part
 	// of the services export.
 	units map[string][]*Unit
+	// Maps of service name to service resources, unit name to
+	// unit resources, and unit name to payloads. Populated as part
+	// of the services export.
+	serviceResources map[string][]serviceResourceDocs
+	unitResources    map[string][]resourceDoc
+	payloads         map[string][]payload.FullPayloadInfo
+	endpointBindings map[string]bindingsMap
+	// Map of service name to the access granted on it.
+	serviceUsers map[string][]serviceUserDoc
 }
 
 func (e *exporter) sequences() error {
@@ -163,6 +186,7 @@ func (e *exporter) modelUsers() error {
 			DateCreated:    user.DateCreated(),
 			LastConnection: lastConn,
 			ReadOnly:       user.ReadOnly(),
+			Access:         string(user.Access()),
 		}
 		e.model.AddUser(arg)
 	}
@@ -398,6 +422,19 @@ func (e *exporter) services() error {
 
 	leaders := e.readServiceLeaders()
 
+	if err := e.readAllResources(); err != nil {
+		return errors.Trace(err)
+	}
+	if err := e.readAllPayloads(); err != nil {
+		return errors.Trace(err)
+	}
+	if err := e.readAllEndpointBindings(); err != nil {
+		return errors.Trace(err)
+	}
+	if err := e.readAllServiceUsers(); err != nil {
+		return errors.Trace(err)
+	}
+
 	for _, service := range services {
 		serviceUnits := e.units[service.Name()]
 		leader := leaders[service.Name()]
@@ -442,14 +479,28 @@ func (e *exporter) addService(service *Service, refcounts map[string]int, units
 		CharmModifiedVersion: service.doc.CharmModifiedVersion,
 		ForceCharm:           service.doc.ForceCharm,
 		Exposed:              service.doc.Exposed,
+		ExposedCIDRs:         service.doc.ExposedCIDRs,
 		MinUnits:             service.doc.MinUnits,
 		Settings:             serviceSettingsDoc.Settings,
 		SettingsRefCount:     refCount,
 		Leader:               leader,
 		LeadershipSettings:   leadershipSettingsDoc.Settings,
 		MetricsCredentials:   service.doc.MetricCredentials,
+		EndpointBindings:     e.endpointBindings[service.globalKey()],
+	}
+	if constraints, found := e.storageConstraints[service.globalKey()]; found {
+		args.StorageConstraints = e.storageConstraintsArgs(constraints.Constraints)
 	}
 	exService := e.model.AddService(args)
+	for _, docs := range e.serviceResources[service.Name()] {
+		exResource := exService.AddResource(description.ResourceArgs{
+			Name: docs.service.Name,
+		})
+		exResource.SetRevision(e.resourceRevisionArgs(docs.service))
+		if docs.charmStore != nil {
+			exResource.SetCharmStoreRevision(e.resourceRevisionArgs(docs.charmStore))
+		}
+	}
 	// Find the current service status.
 	globalKey := service.globalKey()
 	statusArgs, err := e.statusArgs(globalKey)
@@ -466,6 +517,17 @@ func (e *exporter) addService(service *Service, refcounts map[string]int, units
 	}
 	exService.SetConstraints(constraintsArgs)
 
+	for _, doc := range e.serviceUsers[service.Name()] {
+		exService.AddUser(description.ServiceUserArgs{
+			Name:        names.NewUserTag(doc.UserName),
+			Access:      string(doc.Access),
+			CreatedBy:   names.NewUserTag(doc.CreatedBy),
+			DateCreated: doc.DateCreated,
+
+			GrantedModelAccess: doc.GrantedModelAccess,
+		})
+	}
+
 	for _, unit := range units {
 		agentKey := unit.globalAgentKey()
 		unitMeterStatus, found := meterStatus[agentKey]
@@ -522,8 +584,146 @@ func (e *exporter) addService(service *Service, refcounts map[string]int, units
 			return errors.Trace(err)
 		}
 		exUnit.SetConstraints(constraintsArgs)
+
+		for _, doc := range e.unitResources[unit.Name()] {
+			exUnit.AddResource(description.UnitResourceArgs{
+				Name:     doc.Name,
+				Revision: e.resourceRevisionArgs(&doc),
+			})
+		}
+		for _, p := range e.payloads[unit.Name()] {
+			exUnit.AddPayload(description.PayloadArgs{
+				Name:   p.Name,
+				Type:   p.Type,
+				RawID:  p.ID,
+				State:  p.Status,
+				Labels: p.Labels,
+			})
+		}
+	}
+
+	return nil
+}
+
+// serviceResourceDocs holds the resource document for a service
+// resource, along with the latest charm store revision document
+// for that resource, if there is one.
+type serviceResourceDocs struct {
+	service    *resourceDoc
+	charmStore *resourceDoc
+}
+
+func (e *exporter) readAllResources() error {
+	coll, closer := e.st.getCollection(resourcesC)
+	defer closer()
+
+	var docs []resourceDoc
+	if err := coll.Find(nil).All(&docs); err != nil {
+		return errors.Annotate(err, "cannot get all resources")
+	}
+	e.logger.Debugf("found %d resource docs", len(docs))
+
+	serviceDocs := make(map[string]*resourceDoc)
+	charmStoreDocs := make(map[string]*resourceDoc)
+	e.unitResources = make(map[string][]resourceDoc)
+	for i := range docs {
+		doc := &docs[i]
+		id := e.st.localID(doc.DocID)
+		switch {
+		case doc.PendingID != "", strings.HasSuffix(id, resourcesStagedIDSuffix):
+			// Pending and staged resources are transient, and are
+			// not migrated.
+			e.logger.Debugf("skipping transient resource doc %q", id)
+		case doc.UnitID != "":
+			e.unitResources[doc.UnitID] = append(e.unitResources[doc.UnitID], *doc)
+		case strings.HasSuffix(id, resourcesCharmstoreIDSuffix):
+			charmStoreDocs[doc.ID] = doc
+		default:
+			serviceDocs[doc.ID] = doc
+		}
+	}
+
+	// Sort the resource ids so the ordering of the exported resources
+	// is stable.
+	var ids []string
+	for id := range serviceDocs {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+	e.serviceResources = make(map[string][]serviceResourceDocs)
+	for _, id := range ids {
+		doc := serviceDocs[id]
+		e.serviceResources[doc.ServiceID] = append(e.serviceResources[doc.ServiceID], serviceResourceDocs{
+			service:    doc,
+			charmStore: charmStoreDocs[id],
+		})
+	}
+	return nil
+}
+
+func (e *exporter) resourceRevisionArgs(doc *resourceDoc) description.ResourceRevisionArgs {
+	return description.ResourceRevisionArgs{
+		Revision:       doc.Revision,
+		Type:           doc.Type,
+		Path:           doc.Path,
+		Description:    doc.Description,
+		Origin:         doc.Origin,
+		FingerprintHex: hex.EncodeToString(doc.Fingerprint),
+		Size:           doc.Size,
+		Timestamp:      doc.Timestamp,
+		Username:       doc.Username,
+	}
+}
+
+func (e *exporter) readAllPayloads() error {
+	envPayloads, err := e.st.EnvPayloads()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	payloads, err := envPayloads.ListAll()
+	if err != nil {
+		return errors.Annotate(err, "cannot get all payloads")
+	}
+	e.logger.Debugf("found %d payloads", len(payloads))
+
+	e.payloads = make(map[string][]payload.FullPayloadInfo)
+	for _, p := range payloads {
+		e.payloads[p.Unit] = append(e.payloads[p.Unit], p)
+	}
+	return nil
+}
+
+func (e *exporter) readAllEndpointBindings() error {
+	coll, closer := e.st.getCollection(endpointBindingsC)
+	defer closer()
+
+	var docs []endpointBindingsDoc
+	if err := coll.Find(nil).All(&docs); err != nil {
+		return errors.Annotate(err, "cannot get all endpoint bindings")
+	}
+	e.logger.Debugf("found %d endpoint bindings docs", len(docs))
+
+	e.endpointBindings = make(map[string]bindingsMap)
+	for _, doc := range docs {
+		e.endpointBindings[e.st.localID(doc.DocID)] = doc.Bindings
+	}
+	return nil
+}
+
+func (e *exporter) readAllServiceUsers() error {
+	coll, closer := e.st.getCollection(serviceUsersC)
+	defer closer()
+
+	var docs []serviceUserDoc
+	if err := coll.Find(nil).All(&docs); err != nil {
+		return errors.Annotate(err, "cannot get all service users")
 	}
+	e.logger.Debugf("found %d service users docs", len(docs))
 
+	e.serviceUsers = make(map[string][]serviceUserDoc)
+	for _, doc := range docs {
+		e.serviceUsers[doc.ServiceName] = append(e.serviceUsers[doc.ServiceName], doc)
+	}
 	return nil
 }
 
@@ -577,6 +777,458 @@ func (e *exporter) relations() error {
 	return nil
 }
 
+func (e *exporter) networking() error {
+	if err := e.spaces(); err != nil {
+		return errors.Trace(err)
+	}
+	if err := e.subnets(); err != nil {
+		return errors.Trace(err)
+	}
+	if err := e.linkLayerDevices(); err != nil {
+		return errors.Trace(err)
+	}
+	if err := e.ipAddresses(); err != nil {
+		return errors.Trace(err)
+	}
+	return nil
+}
+
+func (e *exporter) spaces() error {
+	coll, closer := e.st.getCollection(spacesC)
+	defer closer()
+
+	var docs []spaceDoc
+	if err := coll.Find(nil).All(&docs); err != nil {
+		return errors.Annotate(err, "cannot get all spaces")
+	}
+	e.logger.Debugf("found %d spaces", len(docs))
+
+	for _, doc := range docs {
+		e.model.AddSpace(description.SpaceArgs{
+			Name:       doc.Name,
+			Public:     doc.IsPublic,
+			ProviderID: e.localProviderID(doc.ProviderId),
+		})
+	}
+	return nil
+}
+
+func (e *exporter) subnets() error {
+	coll, closer := e.st.getCollection(subnetsC)
+	defer closer()
+
+	var docs []subnetDoc
+	if err := coll.Find(nil).All(&docs); err != nil {
+		return errors.Annotate(err, "cannot get all subnets")
+	}
+	e.logger.Debugf("found %d subnets", len(docs))
+
+	for _, doc := range docs {
+		e.model.AddSubnet(description.SubnetArgs{
+			CIDR:              doc.CIDR,
+			ProviderID:        e.localProviderID(doc.ProviderId),
+			VLANTag:           doc.VLANTag,
+			AvailabilityZone:  doc.AvailabilityZone,
+			SpaceName:         doc.SpaceName,
+			AllocatableIPHigh: doc.AllocatableIPHigh,
+			AllocatableIPLow:  doc.AllocatableIPLow,
+		})
+	}
+	return nil
+}
+
+func (e *exporter) linkLayerDevices() error {
+	coll, closer := e.st.getCollection(linkLayerDevicesC)
+	defer closer()
+
+	var docs []linkLayerDeviceDoc
+	if err := coll.Find(nil).All(&docs); err != nil {
+		return errors.Annotate(err, "cannot get all link-layer devices")
+	}
+	e.logger.Debugf("found %d link-layer devices", len(docs))
+
+	for _, doc := range docs {
+		e.model.AddLinkLayerDevice(description.LinkLayerDeviceArgs{
+			Name:        doc.Name,
+			MTU:         doc.MTU,
+			ProviderID:  e.localProviderID(doc.ProviderID),
+			MachineID:   doc.MachineID,
+			Type:        string(doc.Type),
+			MACAddress:  doc.MACAddress,
+			IsAutoStart: doc.IsAutoStart,
+			IsUp:        doc.IsUp,
+			ParentName:  doc.ParentName,
+		})
+	}
+	return nil
+}
+
+func (e *exporter) ipAddresses() error {
+	coll, closer := e.st.getCollection(ipAddressesC)
+	defer closer()
+
+	var docs []ipAddressDoc
+	if err := coll.Find(nil).All(&docs); err != nil {
+		return errors.Annotate(err, "cannot get all ip addresses")
+	}
+	e.logger.Debugf("found %d ip addresses", len(docs))
+
+	for _, doc := range docs {
+		e.model.AddIPAddress(description.IPAddressArgs{
+			ProviderID:       e.localProviderID(doc.ProviderID),
+			DeviceName:       doc.DeviceName,
+			MachineID:        doc.MachineID,
+			SubnetCIDR:       doc.SubnetCIDR,
+			ConfigMethod:     string(doc.ConfigMethod),
+			Value:            doc.Value,
+			DNSServers:       doc.DNSServers,
+			DNSSearchDomains: doc.DNSSearchDomains,
+			GatewayAddress:   doc.GatewayAddress,
+		})
+	}
+	return nil
+}
+
+// localProviderID strips the model UUID prefix from a stored
+// provider id. Provider ids are stored prefixed so they are unique
+// across models, but the prefix is meaningless in another controller.
+func (e *exporter) localProviderID(providerID string) string {
+	if providerID == "" {
+		return ""
+	}
+	return e.st.localID(providerID)
+}
+
+func (e *exporter) storage() error {
+	if err := e.storageInstances(); err != nil {
+		return errors.Trace(err)
+	}
+	if err := e.volumes(); err != nil {
+		return errors.Trace(err)
+	}
+	if err := e.volumeSnapshots(); err != nil {
+		return errors.Trace(err)
+	}
+	if err := e.filesystems(); err != nil {
+		return errors.Trace(err)
+	}
+	if err := e.storagePools(); err != nil {
+		return errors.Trace(err)
+	}
+	return nil
+}
+
+func (e *exporter) storageInstances() error {
+	coll, closer := e.st.getCollection(storageInstancesC)
+	defer closer()
+
+	var docs []storageInstanceDoc
+	if err := coll.Find(nil).All(&docs); err != nil {
+		return errors.Annotate(err, "cannot get all storage instances")
+	}
+	e.logger.Debugf("found %d storage instances", len(docs))
+
+	attachments, err := e.readAllStorageAttachments()
+	if err != nil {
+		return errors.Trace(err)
+	}
+
+	for _, doc := range docs {
+		// Storage that has been detached from its unit has no owner.
+		var owner names.Tag
+		if doc.Owner != "" {
+			owner, err = names.ParseTag(doc.Owner)
+			if err != nil {
+				return errors.Annotatef(err, "storage %s owner", doc.Id)
+			}
+		}
+		e.model.AddStorage(description.StorageArgs{
+			Tag:         names.NewStorageTag(doc.Id),
+			Kind:        doc.Kind.String(),
+			Owner:       owner,
+			Name:        doc.StorageName,
+			Attachments: attachments[doc.Id],
+		})
+	}
+	return nil
+}
+
+func (e *exporter) readAllStorageAttachments() (map[string][]names.UnitTag, error) {
+	coll, closer := e.st.getCollection(storageAttachmentsC)
+	defer closer()
+
+	var docs []storageAttachmentDoc
+	if err := coll.Find(nil).All(&docs); err != nil {
+		return nil, errors.Annotate(err, "cannot get all storage attachments")
+	}
+	e.logger.Debugf("found %d storage attachments", len(docs))
+
+	result := make(map[string][]names.UnitTag)
+	for _, doc := range docs {
+		result[doc.StorageInstance] = append(result[doc.StorageInstance], names.NewUnitTag(doc.Unit))
+	}
+	return result, nil
+}
+
+func (e *exporter) volumes() error {
+	coll, closer := e.st.getCollection(volumesC)
+	defer closer()
+
+	var docs []volumeDoc
+	if err := coll.Find(nil).All(&docs); err != nil {
+		return errors.Annotate(err, "cannot get all volumes")
+	}
+	e.logger.Debugf("found %d volumes", len(docs))
+
+	attachments, err := e.readAllVolumeAttachments()
+	if err != nil {
+		return errors.Trace(err)
+	}
+
+	for _, doc := range docs {
+		if err := e.addVolume(doc, attachments[doc.Name]); err != nil {
+			return errors.Trace(err)
+		}
+	}
+	return nil
+}
+
+func (e *exporter) addVolume(doc volumeDoc, attachments []volumeAttachmentDoc) error {
+	args := description.VolumeArgs{
+		Tag: names.NewVolumeTag(doc.Name),
+	}
+	if doc.StorageId != "" {
+		args.Storage = names.NewStorageTag(doc.StorageId)
+	}
+	if doc.Binding != "" {
+		binding, err := names.ParseTag(doc.Binding)
+		if err != nil {
+			return errors.Annotatef(err, "volume %s binding", doc.Name)
+		}
+		args.Binding = binding
+	}
+	if doc.Info != nil {
+		args.Provisioned = true
+		args.Size = doc.Info.Size
+		args.Pool = doc.Info.Pool
+		args.HardwareID = doc.Info.HardwareId
+		args.VolumeID = doc.Info.VolumeId
+		args.Persistent = doc.Info.Persistent
+	} else if doc.Params != nil {
+		args.Size = doc.Params.Size
+		args.Pool = doc.Params.Pool
+	}
+	args.RequestedSize = doc.RequestedSize
+	exVolume := e.model.AddVolume(args)
+
+	globalKey := volumeGlobalKey(doc.Name)
+	statusArgs, err := e.statusArgs(globalKey)
+	if err != nil {
+		return errors.Annotatef(err, "status for volume %s", doc.Name)
+	}
+	exVolume.SetStatus(statusArgs)
+	exVolume.SetStatusHistory(e.statusHistoryArgs(globalKey))
+
+	for _, attachment := range attachments {
+		attachArgs := description.VolumeAttachmentArgs{
+			Machine: names.NewMachineTag(attachment.Machine),
+		}
+		if info := attachment.Info; info != nil {
+			attachArgs.Provisioned = true
+			attachArgs.ReadOnly = info.ReadOnly
+			attachArgs.DeviceName = info.DeviceName
+			attachArgs.DeviceLink = info.DeviceLink
+			attachArgs.BusAddress = info.BusAddress
+		} else if params := attachment.Params; params != nil {
+			attachArgs.ReadOnly = params.ReadOnly
+		}
+		exVolume.AddAttachment(attachArgs)
+	}
+	return nil
+}
+
+func (e *exporter) readAllVolumeAttachments() (map[string][]volumeAttachmentDoc, error) {
+	coll, closer := e.st.getCollection(volumeAttachmentsC)
+	defer closer()
+
+	var docs []volumeAttachmentDoc
+	if err := coll.Find(nil).All(&docs); err != nil {
+		return nil, errors.Annotate(err, "cannot get all volume attachments")
+	}
+	e.logger.Debugf("found %d volume attachments", len(docs))
+
+	result := make(map[string][]volumeAttachmentDoc)
+	for _, doc := range docs {
+		result[doc.Volume] = append(result[doc.Volume], doc)
+	}
+	return result, nil
+}
+
+func (e *exporter) filesystems() error {
+	coll, closer := e.st.getCollection(filesystemsC)
+	defer closer()
+
+	var docs []filesystemDoc
+	if err := coll.Find(nil).All(&docs); err != nil {
+		return errors.Annotate(err, "cannot get all filesystems")
+	}
+	e.logger.Debugf("found %d filesystems", len(docs))
+
+	attachments, err := e.readAllFilesystemAttachments()
+	if err != nil {
+		return errors.Trace(err)
+	}
+
+	for _, doc := range docs {
+		if err := e.addFilesystem(doc, attachments[doc.FilesystemId]); err != nil {
+			return errors.Trace(err)
+		}
+	}
+	return nil
+}
+
+func (e *exporter) addFilesystem(doc filesystemDoc, attachments []filesystemAttachmentDoc) error {
+	args := description.FilesystemArgs{
+		Tag: names.NewFilesystemTag(doc.FilesystemId),
+	}
+	if doc.StorageId != "" {
+		args.Storage = names.NewStorageTag(doc.StorageId)
+	}
+	if doc.VolumeId != "" {
+		args.Volume = names.NewVolumeTag(doc.VolumeId)
+	}
+	if doc.Binding != "" {
+		binding, err := names.ParseTag(doc.Binding)
+		if err != nil {
+			return errors.Annotatef(err, "filesystem %s binding", doc.FilesystemId)
+		}
+		args.Binding = binding
+	}
+	if doc.Info != nil {
+		args.Provisioned = true
+		args.Size = doc.Info.Size
+		args.Pool = doc.Info.Pool
+		args.FilesystemID = doc.Info.FilesystemId
+	} else if doc.Params != nil {
+		args.Size = doc.Params.Size
+		args.Pool = doc.Params.Pool
+	}
+	exFilesystem := e.model.AddFilesystem(args)
+
+	globalKey := filesystemGlobalKey(doc.FilesystemId)
+	statusArgs, err := e.statusArgs(globalKey)
+	if err != nil {
+		return errors.Annotatef(err, "status for filesystem %s", doc.FilesystemId)
+	}
+	exFilesystem.SetStatus(statusArgs)
+	exFilesystem.SetStatusHistory(e.statusHistoryArgs(globalKey))
+
+	for _, attachment := range attachments {
+		attachArgs := description.FilesystemAttachmentArgs{
+			Machine: names.NewMachineTag(attachment.Machine),
+		}
+		if info := attachment.Info; info != nil {
+			attachArgs.Provisioned = true
+			attachArgs.MountPoint = info.MountPoint
+			attachArgs.ReadOnly = info.ReadOnly
+		} else if params := attachment.Params; params != nil {
+			attachArgs.MountPoint = params.Location
+			attachArgs.ReadOnly = params.ReadOnly
+		}
+		exFilesystem.AddAttachment(attachArgs)
+	}
+	return nil
+}
+
+func (e *exporter) readAllFilesystemAttachments() (map[string][]filesystemAttachmentDoc, error) {
+	coll, closer := e.st.getCollection(filesystemAttachmentsC)
+	defer closer()
+
+	var docs []filesystemAttachmentDoc
+	if err := coll.Find(nil).All(&docs); err != nil {
+		return nil, errors.Annotate(err, "cannot get all filesystem attachments")
+	}
+	e.logger.Debugf("found %d filesystem attachments", len(docs))
+
+	result := make(map[string][]filesystemAttachmentDoc)
+	for _, doc := range docs {
+		result[doc.Filesystem] = append(result[doc.Filesystem], doc)
+	}
+	return result, nil
+}
+
+func (e *exporter) volumeSnapshots() error {
+	coll, closer := e.st.getCollection(volumeSnapshotsC)
+	defer closer()
+
+	var docs []volumeSnapshotDoc
+	if err := coll.Find(nil).Sort("created").All(&docs); err != nil {
+		return errors.Annotate(err, "cannot get all volume snapshots")
+	}
+	e.logger.Debugf("found %d volume snapshots", len(docs))
+
+	for _, doc := range docs {
+		args := description.VolumeSnapshotArgs{
+			ID:         doc.Id,
+			Volume:     names.NewVolumeTag(doc.Volume),
+			Pool:       doc.Pool,
+			SnapshotID: doc.SnapshotId,
+			Size:       doc.Size,
+			Created:    doc.Created.UTC(),
+		}
+		if doc.StorageId != "" {
+			args.Storage = names.NewStorageTag(doc.StorageId)
+		}
+		e.model.AddVolumeSnapshot(args)
+	}
+	return nil
+}
+
+func (e *exporter) storagePools() error {
+	pm := poolmanager.New(NewStateSettings(e.st))
+	poolConfigs, err := pm.List()
+	if err != nil {
+		return errors.Annotate(err, "listing pools")
+	}
+	for _, cfg := range poolConfigs {
+		e.model.AddStoragePool(description.StoragePoolArgs{
+			Name:       cfg.Name(),
+			Provider:   string(cfg.Provider()),
+			Attributes: cfg.Attrs(),
+		})
+	}
+	return nil
+}
+
+func (e *exporter) readAllStorageConstraints() error {
+	coll, closer := e.st.getCollection(storageConstraintsC)
+	defer closer()
+
+	var docs []storageConstraintsDoc
+	if err := coll.Find(nil).All(&docs); err != nil {
+		return errors.Annotate(err, "failed to read storage constraints")
+	}
+	e.logger.Debugf("read %d storage constraint documents", len(docs))
+
+	e.storageConstraints = make(map[string]storageConstraintsDoc)
+	for _, doc := range docs {
+		e.storageConstraints[e.st.localID(doc.DocID)] = doc
+	}
+	return nil
+}
+
+func (e *exporter) storageConstraintsArgs(cons map[string]StorageConstraints) map[string]description.StorageConstraintArgs {
+	result := make(map[string]description.StorageConstraintArgs)
+	for key, value := range cons {
+		result[key] = description.StorageConstraintArgs{
+			Pool:  value.Pool,
+			Size:  value.Size,
+			Count: value.Count,
+		}
+	}
+	return result
+}
+
 func (e *exporter) readAllRelationScopes() (set.Strings, error) {
 	relationScopes, closer := e.st.getCollection(relationScopesC)
 	defer closer()
@@ -869,6 +1521,7 @@ func (e *exporter) constraintsArgs(globalKey string) (description.ConstraintsArg
 		RootDisk:     optionalInt("rootdisk"),
 		Spaces:       optionalStringSlice("spaces"),
 		Tags:         optionalStringSlice("tags"),
+		Zones:        optionalStringSlice("zones"),
 	}
 	if optionalErr != nil {
 		return description.ConstraintsArgs{}, errors.Trace(optionalErr)
@@ -4,6 +4,7 @@
 package state_test
 
 import (
+	"bytes"
 	"math/rand"
 	"time"
 
@@ -11,11 +12,17 @@ import (
 	jc "github.com/juju/testing/checkers"
 	"github.com/juju/version"
 	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/charm.v6-unstable"
 
 	"github.com/juju/juju/constraints"
 	"github.com/juju/juju/core/description"
+	"github.com/juju/juju/network"
+	"github.com/juju/juju/payload"
 	"github.com/juju/juju/state"
 	"github.com/juju/juju/status"
+	"github.com/juju/juju/storage/poolmanager"
+	"github.com/juju/juju/storage/provider"
+	"github.com/juju/juju/storage/provider/registry"
 	"github.com/juju/juju/testing/factory"
 )
 
@@ -83,6 +90,28 @@ func (s *MigrationSuite) makeServiceWithLeader(c *gc.C, serviceName string, coun
 	c.Assert(err, jc.ErrorIsNil)
 }
 
+func (s *MigrationSuite) makeUnitWithStorage(c *gc.C) (*state.Service, *state.Unit, names.StorageTag) {
+	pool := "loop-pool"
+	pm := poolmanager.New(state.NewStateSettings(s.State))
+	_, err := pm.Create(pool, provider.LoopProviderType, map[string]interface{}{
+		"foo": "bar",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	registry.RegisterEnvironStorageProviders("someprovider", provider.LoopProviderType)
+
+	ch := s.AddTestingCharm(c, "storage-block")
+	storage := map[string]state.StorageConstraints{
+		"data": makeStorageCons(pool, 1024, 1),
+	}
+	service := s.AddTestingServiceWithStorage(c, "storage-block", ch, storage)
+	unit, err := service.AddUnit()
+	c.Assert(err, jc.ErrorIsNil)
+	machine := s.Factory.MakeMachine(c, nil)
+	err = unit.AssignToMachine(machine)
+	c.Assert(err, jc.ErrorIsNil)
+	return service, unit, names.NewStorageTag("data/0")
+}
+
 type MigrationExportSuite struct {
 	MigrationSuite
 }
@@ -173,6 +202,7 @@ func (s *MigrationExportSuite) TestModelUsers(c *gc.C) {
 	c.Assert(exportedAdmin.DateCreated(), gc.Equals, owner.DateCreated())
 	c.Assert(exportedAdmin.LastConnection(), gc.Equals, lastConnection)
 	c.Assert(exportedAdmin.ReadOnly(), jc.IsFalse)
+	c.Assert(exportedAdmin.Access(), gc.Equals, "admin")
 
 	c.Assert(exportedBob.Name(), gc.Equals, bobTag)
 	c.Assert(exportedBob.DisplayName(), gc.Equals, "")
@@ -180,6 +210,7 @@ func (s *MigrationExportSuite) TestModelUsers(c *gc.C) {
 	c.Assert(exportedBob.DateCreated(), gc.Equals, bob.DateCreated())
 	c.Assert(exportedBob.LastConnection(), gc.Equals, lastConnection)
 	c.Assert(exportedBob.ReadOnly(), jc.IsTrue)
+	c.Assert(exportedBob.Access(), gc.Equals, "read")
 }
 
 func (s *MigrationExportSuite) TestMachines(c *gc.C) {
@@ -271,6 +302,25 @@ func (s *MigrationExportSuite) TestServices(c *gc.C) {
 	s.checkStatusHistory(c, history[:addedHistoryCount], status.StatusActive)
 }
 
+func (s *MigrationExportSuite) TestServiceUsers(c *gc.C) {
+	service := s.Factory.MakeService(c, nil)
+	user := names.NewUserTag("bob@external")
+	err := s.State.SetServiceAccess(service.Name(), user, state.ServiceOperateAccess, s.Owner)
+	c.Assert(err, jc.ErrorIsNil)
+
+	model, err := s.State.Export()
+	c.Assert(err, jc.ErrorIsNil)
+
+	services := model.Services()
+	c.Assert(services, gc.HasLen, 1)
+	users := services[0].Users()
+	c.Assert(users, gc.HasLen, 1)
+	c.Check(users[0].Name(), gc.Equals, user)
+	c.Check(users[0].Access(), gc.Equals, "operate")
+	c.Check(users[0].CreatedBy(), gc.Equals, s.Owner)
+	c.Check(users[0].GrantedModelAccess(), jc.IsTrue)
+}
+
 func (s *MigrationExportSuite) TestMultipleServices(c *gc.C) {
 	s.Factory.MakeService(c, &factory.ServiceParams{Name: "first"})
 	s.Factory.MakeService(c, &factory.ServiceParams{Name: "second"})
@@ -434,3 +484,335 @@ type goodToken struct{}
 func (*goodToken) Check(interface{}) error {
 	return nil
 }
+
+func (s *MigrationExportSuite) TestStorage(c *gc.C) {
+	_, unit, storageTag := s.makeUnitWithStorage(c)
+
+	model, err := s.State.Export()
+	c.Assert(err, jc.ErrorIsNil)
+
+	services := model.Services()
+	c.Assert(services, gc.HasLen, 1)
+	c.Assert(services[0].StorageConstraints(), gc.HasLen, 1)
+	cons := services[0].StorageConstraints()["data"]
+	c.Check(cons.Pool(), gc.Equals, "loop-pool")
+	c.Check(cons.Size(), gc.Equals, uint64(1024))
+	c.Check(cons.Count(), gc.Equals, uint64(1))
+
+	storages := model.Storages()
+	c.Assert(storages, gc.HasLen, 1)
+
+	storage := storages[0]
+	c.Check(storage.Tag(), gc.Equals, storageTag)
+	c.Check(storage.Kind(), gc.Equals, "block")
+	owner, err := storage.Owner()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(owner, gc.Equals, unit.Tag())
+	c.Check(storage.Name(), gc.Equals, "data")
+	c.Check(storage.Attachments(), jc.DeepEquals, []names.UnitTag{unit.UnitTag()})
+
+	pools := model.StoragePools()
+	c.Assert(pools, gc.HasLen, 1)
+	c.Check(pools[0].Name(), gc.Equals, "loop-pool")
+	c.Check(pools[0].Provider(), gc.Equals, "loop")
+	c.Check(pools[0].Attributes(), jc.DeepEquals, map[string]interface{}{
+		"foo": "bar",
+	})
+}
+
+func (s *MigrationExportSuite) TestVolumeRequestedSize(c *gc.C) {
+	_, _, storageTag := s.makeUnitWithStorage(c)
+	volume, err := s.State.StorageInstanceVolume(storageTag)
+	c.Assert(err, jc.ErrorIsNil)
+	volTag := volume.VolumeTag()
+	err = s.State.SetVolumeInfo(volTag, state.VolumeInfo{
+		Size:     1500,
+		VolumeId: "volume id",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.State.ResizeVolume(volTag, 3000)
+	c.Assert(err, jc.ErrorIsNil)
+
+	model, err := s.State.Export()
+	c.Assert(err, jc.ErrorIsNil)
+
+	volumes := model.Volumes()
+	c.Assert(volumes, gc.HasLen, 1)
+	c.Check(volumes[0].Size(), gc.Equals, uint64(1500))
+	c.Check(volumes[0].RequestedSize(), gc.Equals, uint64(3000))
+}
+
+func (s *MigrationExportSuite) TestVolumeSnapshots(c *gc.C) {
+	_, _, storageTag := s.makeUnitWithStorage(c)
+	volume, err := s.State.StorageInstanceVolume(storageTag)
+	c.Assert(err, jc.ErrorIsNil)
+	volTag := volume.VolumeTag()
+	err = s.State.SetVolumeInfo(volTag, state.VolumeInfo{
+		Size:     1500,
+		VolumeId: "volume id",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	snapshot, err := s.State.AddVolumeSnapshot(volTag, state.VolumeSnapshotInfo{
+		SnapshotId: "snap-0",
+		Size:       1500,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	// Reload the snapshot so that its creation time has the
+	// precision that is stored in the database.
+	snapshot, err = s.State.VolumeSnapshot(snapshot.Id())
+	c.Assert(err, jc.ErrorIsNil)
+
+	model, err := s.State.Export()
+	c.Assert(err, jc.ErrorIsNil)
+
+	snapshots := model.VolumeSnapshots()
+	c.Assert(snapshots, gc.HasLen, 1)
+	exported := snapshots[0]
+	c.Check(exported.ID(), gc.Equals, snapshot.Id())
+	c.Check(exported.Volume(), gc.Equals, volTag)
+	c.Check(exported.Storage(), gc.Equals, storageTag)
+	c.Check(exported.Pool(), gc.Equals, "loop-pool")
+	c.Check(exported.SnapshotID(), gc.Equals, "snap-0")
+	c.Check(exported.Size(), gc.Equals, uint64(1500))
+	c.Check(exported.Created(), gc.Equals, snapshot.Created())
+}
+
+func (s *MigrationExportSuite) TestVolumes(c *gc.C) {
+	_, unit, storageTag := s.makeUnitWithStorage(c)
+	machineId, err := unit.AssignedMachineId()
+	c.Assert(err, jc.ErrorIsNil)
+	machineTag := names.NewMachineTag(machineId)
+
+	volume, err := s.State.StorageInstanceVolume(storageTag)
+	c.Assert(err, jc.ErrorIsNil)
+	volTag := volume.VolumeTag()
+	err = s.State.SetVolumeInfo(volTag, state.VolumeInfo{
+		HardwareId: "magic",
+		Size:       1500,
+		VolumeId:   "volume id",
+		Persistent: true,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.State.SetVolumeAttachmentInfo(machineTag, volTag, state.VolumeAttachmentInfo{
+		DeviceName: "device name",
+		DeviceLink: "device link",
+		BusAddress: "bus address",
+		ReadOnly:   true,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	model, err := s.State.Export()
+	c.Assert(err, jc.ErrorIsNil)
+
+	volumes := model.Volumes()
+	c.Assert(volumes, gc.HasLen, 1)
+
+	exported := volumes[0]
+	c.Check(exported.Tag(), gc.Equals, volTag)
+	c.Check(exported.Storage(), gc.Equals, storageTag)
+	c.Check(exported.Provisioned(), jc.IsTrue)
+	c.Check(exported.Size(), gc.Equals, uint64(1500))
+	c.Check(exported.Pool(), gc.Equals, "loop-pool")
+	c.Check(exported.HardwareID(), gc.Equals, "magic")
+	c.Check(exported.VolumeID(), gc.Equals, "volume id")
+	c.Check(exported.Persistent(), jc.IsTrue)
+	c.Check(exported.RequestedSize(), gc.Equals, uint64(0))
+	c.Check(exported.Validate(), jc.ErrorIsNil)
+
+	attachments := exported.Attachments()
+	c.Assert(attachments, gc.HasLen, 1)
+	attachment := attachments[0]
+	c.Check(attachment.Machine(), gc.Equals, machineTag)
+	c.Check(attachment.Provisioned(), jc.IsTrue)
+	c.Check(attachment.ReadOnly(), jc.IsTrue)
+	c.Check(attachment.DeviceName(), gc.Equals, "device name")
+	c.Check(attachment.DeviceLink(), gc.Equals, "device link")
+	c.Check(attachment.BusAddress(), gc.Equals, "bus address")
+}
+
+func (s *MigrationExportSuite) TestSpaces(c *gc.C) {
+	_, err := s.State.AddSpace("one", network.Id("provider"), nil, true)
+	c.Assert(err, jc.ErrorIsNil)
+
+	model, err := s.State.Export()
+	c.Assert(err, jc.ErrorIsNil)
+
+	spaces := model.Spaces()
+	c.Assert(spaces, gc.HasLen, 1)
+	space := spaces[0]
+	c.Assert(space.Name(), gc.Equals, "one")
+	c.Assert(space.ProviderID(), gc.Equals, "provider")
+	c.Assert(space.Public(), jc.IsTrue)
+}
+
+func (s *MigrationExportSuite) TestSubnets(c *gc.C) {
+	_, err := s.State.AddSpace("bam", "", nil, false)
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = s.State.AddSubnet(state.SubnetInfo{
+		CIDR:             "10.0.0.0/24",
+		ProviderId:       network.Id("foo"),
+		VLANTag:          64,
+		AvailabilityZone: "bar",
+		SpaceName:        "bam",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	model, err := s.State.Export()
+	c.Assert(err, jc.ErrorIsNil)
+
+	subnets := model.Subnets()
+	c.Assert(subnets, gc.HasLen, 1)
+	subnet := subnets[0]
+	c.Assert(subnet.CIDR(), gc.Equals, "10.0.0.0/24")
+	c.Assert(subnet.ProviderID(), gc.Equals, "foo")
+	c.Assert(subnet.VLANTag(), gc.Equals, 64)
+	c.Assert(subnet.AvailabilityZone(), gc.Equals, "bar")
+	c.Assert(subnet.SpaceName(), gc.Equals, "bam")
+}
+
+func (s *MigrationExportSuite) TestLinkLayerDevicesAndAddresses(c *gc.C) {
+	_, err := s.State.AddSubnet(state.SubnetInfo{CIDR: "0.1.2.0/24"})
+	c.Assert(err, jc.ErrorIsNil)
+	machine := s.Factory.MakeMachine(c, &factory.MachineParams{
+		Constraints: constraints.MustParse("arch=amd64 mem=8G"),
+	})
+	err = machine.SetLinkLayerDevices(state.LinkLayerDeviceArgs{
+		Name:       "bridge",
+		Type:       state.BridgeDevice,
+		MACAddress: "aa:bb:cc:dd:ee:f0",
+		IsUp:       true,
+	}, state.LinkLayerDeviceArgs{
+		Name:       "eth0",
+		Type:       state.EthernetDevice,
+		MACAddress: "aa:bb:cc:dd:ee:f1",
+		ProviderID: network.Id("magic"),
+		ParentName: "bridge",
+		MTU:        1500,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	err = machine.SetDevicesAddresses(state.LinkLayerDeviceAddress{
+		DeviceName:     "eth0",
+		ConfigMethod:   state.StaticAddress,
+		CIDRAddress:    "0.1.2.3/24",
+		DNSServers:     []string{"bam", "mam"},
+		GatewayAddress: "0.1.2.1",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	model, err := s.State.Export()
+	c.Assert(err, jc.ErrorIsNil)
+
+	devices := model.LinkLayerDevices()
+	c.Assert(devices, gc.HasLen, 2)
+	byName := make(map[string]description.LinkLayerDevice)
+	for _, device := range devices {
+		c.Check(device.MachineID(), gc.Equals, machine.Id())
+		byName[device.Name()] = device
+	}
+	eth0 := byName["eth0"]
+	c.Assert(eth0, gc.NotNil)
+	c.Check(eth0.Type(), gc.Equals, "ethernet")
+	c.Check(eth0.MACAddress(), gc.Equals, "aa:bb:cc:dd:ee:f1")
+	c.Check(eth0.ProviderID(), gc.Equals, "magic")
+	c.Check(eth0.ParentName(), gc.Equals, "bridge")
+	c.Check(eth0.MTU(), gc.Equals, uint(1500))
+
+	addresses := model.IPAddresses()
+	c.Assert(addresses, gc.HasLen, 1)
+	addr := addresses[0]
+	c.Check(addr.Value(), gc.Equals, "0.1.2.3")
+	c.Check(addr.MachineID(), gc.Equals, machine.Id())
+	c.Check(addr.DeviceName(), gc.Equals, "eth0")
+	c.Check(addr.SubnetCIDR(), gc.Equals, "0.1.2.0/24")
+	c.Check(addr.ConfigMethod(), gc.Equals, "static")
+	c.Check(addr.DNSServers(), jc.DeepEquals, []string{"bam", "mam"})
+	c.Check(addr.GatewayAddress(), gc.Equals, "0.1.2.1")
+}
+
+func (s *MigrationExportSuite) TestEndpointBindings(c *gc.C) {
+	_, err := s.State.AddSpace("one", "", nil, true)
+	c.Assert(err, jc.ErrorIsNil)
+	ch := s.AddTestingCharm(c, "mysql")
+	_, err = s.State.AddService(state.AddServiceArgs{
+		Name:  "mysql",
+		Owner: s.Owner.String(),
+		Charm: ch,
+		EndpointBindings: map[string]string{
+			"server": "one",
+		},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	model, err := s.State.Export()
+	c.Assert(err, jc.ErrorIsNil)
+
+	services := model.Services()
+	c.Assert(services, gc.HasLen, 1)
+	bindings := services[0].EndpointBindings()
+	c.Assert(bindings["server"], gc.Equals, "one")
+}
+
+func (s *MigrationExportSuite) TestResources(c *gc.C) {
+	ch := s.AddTestingCharm(c, "wordpress")
+	s.AddTestingService(c, "a-service", ch)
+
+	st, err := s.State.Resources()
+	c.Assert(err, jc.ErrorIsNil)
+	data := "spamspamspam"
+	res := newResource(c, "spam", data)
+	_, err = st.SetResource("a-service", res.Username, res.Resource, bytes.NewBufferString(data))
+	c.Assert(err, jc.ErrorIsNil)
+
+	model, err := s.State.Export()
+	c.Assert(err, jc.ErrorIsNil)
+
+	services := model.Services()
+	c.Assert(services, gc.HasLen, 1)
+	resources := services[0].Resources()
+	c.Assert(resources, gc.HasLen, 1)
+	exported := resources[0]
+	c.Check(exported.Name(), gc.Equals, "spam")
+	c.Check(exported.CharmStoreRevision(), gc.IsNil)
+	revision := exported.Revision()
+	c.Assert(revision, gc.NotNil)
+	c.Check(revision.Revision(), gc.Equals, res.Revision)
+	c.Check(revision.Type(), gc.Equals, res.Type.String())
+	c.Check(revision.Path(), gc.Equals, res.Path)
+	c.Check(revision.Origin(), gc.Equals, res.Origin.String())
+	c.Check(revision.FingerprintHex(), gc.Equals, res.Fingerprint.Hex())
+	c.Check(revision.Size(), gc.Equals, res.Size)
+	c.Check(revision.Username(), gc.Equals, res.Username)
+}
+
+func (s *MigrationExportSuite) TestPayloads(c *gc.C) {
+	unit := s.Factory.MakeUnit(c, nil)
+	up, err := s.State.UnitPayloads(unit)
+	c.Assert(err, jc.ErrorIsNil)
+	err = up.Track(payload.Payload{
+		PayloadClass: charm.PayloadClass{
+			Name: "spam",
+			Type: "docker",
+		},
+		ID:     "idfoo",
+		Status: payload.StateRunning,
+		Labels: []string{"a", "b"},
+		Unit:   unit.Name(),
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	model, err := s.State.Export()
+	c.Assert(err, jc.ErrorIsNil)
+
+	services := model.Services()
+	c.Assert(services, gc.HasLen, 1)
+	units := services[0].Units()
+	c.Assert(units, gc.HasLen, 1)
+	payloads := units[0].Payloads()
+	c.Assert(payloads, gc.HasLen, 1)
+	exported := payloads[0]
+	c.Check(exported.Name(), gc.Equals, "spam")
+	c.Check(exported.Type(), gc.Equals, "docker")
+	c.Check(exported.RawID(), gc.Equals, "idfoo")
+	c.Check(exported.State(), gc.Equals, payload.StateRunning)
+	c.Check(exported.Labels(), jc.DeepEquals, []string{"a", "b"})
+}
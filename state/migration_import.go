@@ -4,10 +4,13 @@
 package state
 
 import (
+	"encoding/hex"
+	"fmt"
 	"time"
 
 	"github.com/juju/errors"
 	"github.com/juju/loggo"
+	"github.com/juju/names"
 	"github.com/juju/version"
 	"gopkg.in/juju/charm.v6-unstable"
 	"gopkg.in/mgo.v2/bson"
@@ -17,7 +20,10 @@ import (
 	"github.com/juju/juju/core/description"
 	"github.com/juju/juju/environs/config"
 	"github.com/juju/juju/instance"
+	"github.com/juju/juju/payload"
 	"github.com/juju/juju/status"
+	"github.com/juju/juju/storage"
+	"github.com/juju/juju/storage/poolmanager"
 	"github.com/juju/juju/tools"
 )
 
@@ -88,12 +94,23 @@ func (st *State) Import(model description.Model) (_ *Model, _ *State, err error)
 	if err := restore.machines(); err != nil {
 		return nil, nil, errors.Annotate(err, "machines")
 	}
+	// Spaces need to exist before the services, so the endpoint
+	// bindings refer to known spaces.
+	if err := restore.networking(); err != nil {
+		return nil, nil, errors.Annotate(err, "networking")
+	}
 	if err := restore.services(); err != nil {
 		return nil, nil, errors.Annotate(err, "services")
 	}
+	if err := restore.payloads(); err != nil {
+		return nil, nil, errors.Annotate(err, "payloads")
+	}
 	if err := restore.relations(); err != nil {
 		return nil, nil, errors.Annotate(err, "relations")
 	}
+	if err := restore.storage(); err != nil {
+		return nil, nil, errors.Annotate(err, "storage")
+	}
 
 	// NOTE: at the end of the import make sure that the mode of the model
 	// is set to "imported" not "active" (or whatever we call it). This way
@@ -186,9 +203,9 @@ func (i *importer) modelUsers() error {
 	modelUUID := i.dbModel.UUID()
 	var ops []txn.Op
 	for _, user := range users {
-		access := ModelAdminAccess
-		if user.ReadOnly() {
-			access = ModelReadAccess
+		access := ModelAccess(user.Access())
+		if err := access.Validate(); err != nil {
+			return errors.Annotatef(err, "user %s", user.Name().Canonical())
 		}
 		ops = append(ops, createModelUserOp(
 			modelUUID,
@@ -538,14 +555,33 @@ func (i *importer) service(s description.Service) error {
 	// TODO: update never set malarky... maybe...
 
 	ops := addServiceOps(i.st, addServiceOpsArgs{
-		serviceDoc:  sdoc,
-		statusDoc:   statusDoc,
-		constraints: i.constraints(s.Constraints()),
-		// storage          TODO,
+		serviceDoc:         sdoc,
+		statusDoc:          statusDoc,
+		constraints:        i.constraints(s.Constraints()),
+		storage:            i.storageConstraints(s.StorageConstraints()),
 		settings:           s.Settings(),
 		settingsRefCount:   s.SettingsRefCount(),
 		leadershipSettings: s.LeadershipSettings(),
 	})
+	// The charm may not be in the model yet, so the bindings can't be
+	// validated against the charm metadata. The source model has done
+	// this already.
+	if bindings := s.EndpointBindings(); len(bindings) > 0 {
+		ops = append(ops, txn.Op{
+			C:      endpointBindingsC,
+			Id:     serviceGlobalKey(s.Name()),
+			Assert: txn.DocMissing,
+			Insert: endpointBindingsDoc{
+				Bindings: bindings,
+			},
+		})
+	}
+	ops = append(ops, i.serviceResourceOps(s)...)
+	userOps, err := i.serviceUserOps(s)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	ops = append(ops, userOps...)
 
 	if err := i.st.runTransaction(ops); err != nil {
 		return errors.Trace(err)
@@ -629,6 +665,18 @@ func (i *importer) unit(s description.Service, u description.Unit) error {
 		ops = append(ops, createConstraintsOp(i.st, agentGlobalKey, i.constraints(cons)))
 	}
 
+	for _, res := range u.Resources() {
+		doc := i.makeResourceDoc(s.Name(), res.Name(), res.Revision())
+		doc.DocID = unitResourceID(doc.ID, u.Name())
+		doc.UnitID = u.Name()
+		ops = append(ops, txn.Op{
+			C:      resourcesC,
+			Id:     doc.DocID,
+			Assert: txn.DocMissing,
+			Insert: doc,
+		})
+	}
+
 	if err := i.st.runTransaction(ops); err != nil {
 		return errors.Trace(err)
 	}
@@ -649,6 +697,116 @@ func (i *importer) unit(s description.Service, u description.Unit) error {
 	return nil
 }
 
+// serviceUserOps returns the operations to insert the documents
+// recording the access users have been granted to the service.
+func (i *importer) serviceUserOps(s description.Service) ([]txn.Op, error) {
+	var ops []txn.Op
+	for _, user := range s.Users() {
+		access := ServiceAccess(user.Access())
+		if err := access.Validate(); err != nil {
+			return nil, errors.Annotatef(err, "user %q", user.Name().Canonical())
+		}
+		ops = append(ops, createServiceUserOp(
+			i.st, s.Name(), user.Name(), user.CreatedBy().Canonical(), access, user.DateCreated(),
+			user.GrantedModelAccess(),
+		))
+	}
+	return ops, nil
+}
+
+// serviceResourceOps returns the operations to insert the resource
+// documents for the service. The resource blobs are not part of the
+// model description, and are uploaded separately once the model has
+// been imported, so the documents are inserted without a storage path.
+func (i *importer) serviceResourceOps(s description.Service) []txn.Op {
+	var ops []txn.Op
+	for _, res := range s.Resources() {
+		doc := i.makeResourceDoc(s.Name(), res.Name(), res.Revision())
+		doc.DocID = serviceResourceID(doc.ID)
+		ops = append(ops, txn.Op{
+			C:      resourcesC,
+			Id:     doc.DocID,
+			Assert: txn.DocMissing,
+			Insert: doc,
+		})
+		if csRevision := res.CharmStoreRevision(); csRevision != nil {
+			doc := i.makeResourceDoc(s.Name(), res.Name(), csRevision)
+			doc.DocID = charmStoreResourceID(doc.ID)
+			ops = append(ops, txn.Op{
+				C:      resourcesC,
+				Id:     doc.DocID,
+				Assert: txn.DocMissing,
+				Insert: doc,
+			})
+		}
+	}
+	return ops
+}
+
+func (i *importer) makeResourceDoc(serviceID, name string, rev description.ResourceRevision) *resourceDoc {
+	// The fingerprint has been validated on export, and an empty one
+	// is valid for placeholder resources.
+	fingerprint, err := hex.DecodeString(rev.FingerprintHex())
+	if err != nil {
+		i.logger.Warningf("resource %s/%s has bad fingerprint %q", serviceID, name, rev.FingerprintHex())
+	}
+	return &resourceDoc{
+		ID:          fmt.Sprintf("%s/%s", serviceID, name),
+		ServiceID:   serviceID,
+		Name:        name,
+		Type:        rev.Type(),
+		Path:        rev.Path(),
+		Description: rev.Description(),
+		Origin:      rev.Origin(),
+		Revision:    rev.Revision(),
+		Fingerprint: fingerprint,
+		Size:        rev.Size(),
+		Username:    rev.Username(),
+		Timestamp:   rev.Timestamp(),
+	}
+}
+
+func (i *importer) payloads() error {
+	i.logger.Debugf("importing payloads")
+	for _, s := range i.model.Services() {
+		units := make(map[string]*Unit)
+		for _, unit := range i.serviceUnits[s.Name()] {
+			units[unit.Name()] = unit
+		}
+		for _, u := range s.Units() {
+			if len(u.Payloads()) == 0 {
+				continue
+			}
+			unit, found := units[u.Name()]
+			if !found {
+				return errors.NotFoundf("unit %q", u.Name())
+			}
+			unitPayloads, err := i.st.UnitPayloads(unit)
+			if err != nil {
+				return errors.Trace(err)
+			}
+			for _, p := range u.Payloads() {
+				err := unitPayloads.Track(payload.Payload{
+					PayloadClass: charm.PayloadClass{
+						Name: p.Name(),
+						Type: p.Type(),
+					},
+					ID:     p.RawID(),
+					Status: p.State(),
+					Labels: p.Labels(),
+					Unit:   u.Name(),
+				})
+				if err != nil {
+					i.logger.Errorf("error importing payload %s for unit %s: %s", p.Name(), u.Name(), err)
+					return errors.Annotate(err, u.Name())
+				}
+			}
+		}
+	}
+	i.logger.Debugf("importing payloads succeeded")
+	return nil
+}
+
 func (i *importer) makeServiceDoc(s description.Service) (*serviceDoc, error) {
 	charmUrl, err := charm.ParseURL(s.CharmURL())
 	if err != nil {
@@ -667,6 +825,7 @@ func (i *importer) makeServiceDoc(s description.Service) (*serviceDoc, error) {
 		UnitCount:            len(s.Units()),
 		RelationCount:        i.relationCount(s.Name()),
 		Exposed:              s.Exposed(),
+		ExposedCIDRs:         s.ExposedCIDRs(),
 		MinUnits:             s.MinUnits(),
 		MetricCredentials:    s.MetricsCredentials(),
 	}, nil
@@ -705,20 +864,32 @@ func (i *importer) makeUnitDoc(s description.Service, u description.Unit) (*unit
 	}
 
 	return &unitDoc{
-		Name:         u.Name(),
-		Service:      s.Name(),
-		Series:       s.Series(),
-		CharmURL:     charmUrl,
-		Principal:    u.Principal().Id(),
-		Subordinates: subordinates,
-		// StorageAttachmentCount int `bson:"storageattachmentcount"`
-		MachineId:    u.Machine().Id(),
-		Tools:        i.makeTools(u.Tools()),
-		Life:         Alive,
-		PasswordHash: u.PasswordHash(),
+		Name:                   u.Name(),
+		Service:                s.Name(),
+		Series:                 s.Series(),
+		CharmURL:               charmUrl,
+		Principal:              u.Principal().Id(),
+		Subordinates:           subordinates,
+		StorageAttachmentCount: i.unitStorageAttachmentCount(u.Tag()),
+		MachineId:              u.Machine().Id(),
+		Tools:                  i.makeTools(u.Tools()),
+		Life:                   Alive,
+		PasswordHash:           u.PasswordHash(),
 	}, nil
 }
 
+func (i *importer) unitStorageAttachmentCount(unit names.UnitTag) int {
+	count := 0
+	for _, storage := range i.model.Storages() {
+		for _, tag := range storage.Attachments() {
+			if tag == unit {
+				count++
+			}
+		}
+	}
+	return count
+}
+
 func (i *importer) relations() error {
 	i.logger.Debugf("importing relations")
 	for _, r := range i.model.Relations() {
@@ -800,6 +971,527 @@ func (i *importer) makeRelationDoc(rel description.Relation) *relationDoc {
 	return doc
 }
 
+func (i *importer) networking() error {
+	if err := i.spaces(); err != nil {
+		return errors.Annotate(err, "spaces")
+	}
+	if err := i.subnets(); err != nil {
+		return errors.Annotate(err, "subnets")
+	}
+	if err := i.linkLayerDevices(); err != nil {
+		return errors.Annotate(err, "link-layer devices")
+	}
+	if err := i.ipAddresses(); err != nil {
+		return errors.Annotate(err, "ip addresses")
+	}
+	return nil
+}
+
+// modelProviderID returns the provider id prefixed with the model
+// UUID, as provider ids are stored in the database.
+func (i *importer) modelProviderID(providerID string) string {
+	if providerID == "" {
+		return ""
+	}
+	return i.st.docID(providerID)
+}
+
+func (i *importer) spaces() error {
+	i.logger.Debugf("importing spaces")
+	var ops []txn.Op
+	for _, space := range i.model.Spaces() {
+		ops = append(ops, txn.Op{
+			C:      spacesC,
+			Id:     space.Name(),
+			Assert: txn.DocMissing,
+			Insert: &spaceDoc{
+				Life:       Alive,
+				Name:       space.Name(),
+				IsPublic:   space.Public(),
+				ProviderId: i.modelProviderID(space.ProviderID()),
+			},
+		})
+	}
+	if err := i.st.runTransaction(ops); err != nil {
+		return errors.Trace(err)
+	}
+	i.logger.Debugf("importing spaces succeeded")
+	return nil
+}
+
+func (i *importer) subnets() error {
+	i.logger.Debugf("importing subnets")
+	var ops []txn.Op
+	for _, subnet := range i.model.Subnets() {
+		ops = append(ops, txn.Op{
+			C:      subnetsC,
+			Id:     subnet.CIDR(),
+			Assert: txn.DocMissing,
+			Insert: &subnetDoc{
+				Life:              Alive,
+				ProviderId:        i.modelProviderID(subnet.ProviderID()),
+				CIDR:              subnet.CIDR(),
+				AllocatableIPHigh: subnet.AllocatableIPHigh(),
+				AllocatableIPLow:  subnet.AllocatableIPLow(),
+				VLANTag:           subnet.VLANTag(),
+				AvailabilityZone:  subnet.AvailabilityZone(),
+				SpaceName:         subnet.SpaceName(),
+			},
+		})
+	}
+	if err := i.st.runTransaction(ops); err != nil {
+		return errors.Trace(err)
+	}
+	i.logger.Debugf("importing subnets succeeded")
+	return nil
+}
+
+func (i *importer) linkLayerDevices() error {
+	i.logger.Debugf("importing link-layer devices")
+	devices := i.model.LinkLayerDevices()
+
+	// Count the children of each device, so the refs documents
+	// can be written with the right values.
+	numChildren := make(map[string]int)
+	for _, device := range devices {
+		parentKey, err := i.linkLayerDeviceParentGlobalKey(device)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		if parentKey != "" {
+			numChildren[parentKey]++
+		}
+	}
+
+	modelUUID := i.st.ModelUUID()
+	var ops []txn.Op
+	for _, device := range devices {
+		globalKey := linkLayerDeviceGlobalKey(device.MachineID(), device.Name())
+		docID := i.st.docID(globalKey)
+		ops = append(ops, insertLinkLayerDeviceDocOp(&linkLayerDeviceDoc{
+			DocID:       docID,
+			Name:        device.Name(),
+			ModelUUID:   modelUUID,
+			MTU:         device.MTU(),
+			ProviderID:  i.modelProviderID(device.ProviderID()),
+			MachineID:   device.MachineID(),
+			Type:        LinkLayerDeviceType(device.Type()),
+			MACAddress:  device.MACAddress(),
+			IsAutoStart: device.IsAutoStart(),
+			IsUp:        device.IsUp(),
+			ParentName:  device.ParentName(),
+		}), txn.Op{
+			C:      linkLayerDevicesRefsC,
+			Id:     docID,
+			Assert: txn.DocMissing,
+			Insert: &linkLayerDevicesRefsDoc{
+				DocID:       docID,
+				ModelUUID:   modelUUID,
+				NumChildren: numChildren[globalKey],
+			},
+		})
+	}
+	if err := i.st.runTransaction(ops); err != nil {
+		return errors.Trace(err)
+	}
+	i.logger.Debugf("importing link-layer devices succeeded")
+	return nil
+}
+
+// linkLayerDeviceParentGlobalKey returns the global key of the parent
+// of the device, if it has one. The parent name is either the name of
+// another device on the same machine, or the global key of a device on
+// the host machine of a container.
+func (i *importer) linkLayerDeviceParentGlobalKey(device description.LinkLayerDevice) (string, error) {
+	parentName := device.ParentName()
+	if parentName == "" {
+		return "", nil
+	}
+	hostMachineID, _, err := parseLinkLayerDeviceParentNameAsGlobalKey(parentName)
+	if err != nil {
+		return "", errors.Trace(err)
+	}
+	if hostMachineID != "" {
+		return parentName, nil
+	}
+	return linkLayerDeviceGlobalKey(device.MachineID(), parentName), nil
+}
+
+func (i *importer) ipAddresses() error {
+	i.logger.Debugf("importing ip addresses")
+	modelUUID := i.st.ModelUUID()
+	var ops []txn.Op
+	for _, addr := range i.model.IPAddresses() {
+		globalKey := ipAddressGlobalKey(addr.MachineID(), addr.DeviceName(), addr.Value())
+		ops = append(ops, insertIPAddressDocOp(&ipAddressDoc{
+			DocID:            i.st.docID(globalKey),
+			ModelUUID:        modelUUID,
+			ProviderID:       i.modelProviderID(addr.ProviderID()),
+			DeviceName:       addr.DeviceName(),
+			MachineID:        addr.MachineID(),
+			SubnetCIDR:       addr.SubnetCIDR(),
+			ConfigMethod:     AddressConfigMethod(addr.ConfigMethod()),
+			Value:            addr.Value(),
+			DNSServers:       addr.DNSServers(),
+			DNSSearchDomains: addr.DNSSearchDomains(),
+			GatewayAddress:   addr.GatewayAddress(),
+		}))
+	}
+	if err := i.st.runTransaction(ops); err != nil {
+		return errors.Trace(err)
+	}
+	i.logger.Debugf("importing ip addresses succeeded")
+	return nil
+}
+
+func (i *importer) storage() error {
+	if err := i.storagePools(); err != nil {
+		return errors.Annotate(err, "storage pools")
+	}
+	if err := i.storageInstances(); err != nil {
+		return errors.Annotate(err, "storage instances")
+	}
+	if err := i.volumes(); err != nil {
+		return errors.Annotate(err, "volumes")
+	}
+	if err := i.volumeSnapshots(); err != nil {
+		return errors.Annotate(err, "volume snapshots")
+	}
+	if err := i.filesystems(); err != nil {
+		return errors.Annotate(err, "filesystems")
+	}
+	return nil
+}
+
+func (i *importer) storagePools() error {
+	i.logger.Debugf("importing storage pools")
+	pm := poolmanager.New(NewStateSettings(i.st))
+	for _, pool := range i.model.StoragePools() {
+		_, err := pm.Create(pool.Name(), storage.ProviderType(pool.Provider()), pool.Attributes())
+		if err != nil {
+			return errors.Annotatef(err, "creating pool %q", pool.Name())
+		}
+	}
+	i.logger.Debugf("importing storage pools succeeded")
+	return nil
+}
+
+func (i *importer) storageInstances() error {
+	i.logger.Debugf("importing storage instances")
+	for _, storage := range i.model.Storages() {
+		if err := i.storageInstance(storage); err != nil {
+			i.logger.Errorf("error importing storage %s: %s", storage.Tag().Id(), err)
+			return errors.Annotate(err, storage.Tag().Id())
+		}
+	}
+	i.logger.Debugf("importing storage instances succeeded")
+	return nil
+}
+
+func (i *importer) storageInstance(storage description.Storage) error {
+	owner, err := storage.Owner()
+	if err != nil {
+		return errors.Annotate(err, "storage owner")
+	}
+	attachments := storage.Attachments()
+	tag := storage.Tag()
+	doc := &storageInstanceDoc{
+		Id:              tag.Id(),
+		Kind:            parseStorageKind(storage.Kind()),
+		Life:            Alive,
+		StorageName:     storage.Name(),
+		AttachmentCount: len(attachments),
+	}
+	if owner != nil {
+		doc.Owner = owner.String()
+		curl, err := i.storageOwnerCharmURL(owner)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		doc.CharmURL = curl
+	}
+	ops := []txn.Op{{
+		C:      storageInstancesC,
+		Id:     tag.Id(),
+		Assert: txn.DocMissing,
+		Insert: doc,
+	}}
+	for _, unit := range attachments {
+		ops = append(ops, createStorageAttachmentOp(tag, unit))
+	}
+
+	if err := i.st.runTransaction(ops); err != nil {
+		return errors.Trace(err)
+	}
+	return nil
+}
+
+// storageOwnerCharmURL returns the URL of the charm of the service that
+// owns, or whose unit owns, the storage instance. Storage instances
+// record the charm they were created with.
+func (i *importer) storageOwnerCharmURL(owner names.Tag) (*charm.URL, error) {
+	var serviceName string
+	switch owner := owner.(type) {
+	case names.ServiceTag:
+		serviceName = owner.Id()
+	case names.UnitTag:
+		var err error
+		serviceName, err = names.UnitService(owner.Id())
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+	default:
+		return nil, errors.NotValidf("storage owner %q", owner)
+	}
+	for _, service := range i.model.Services() {
+		if service.Name() == serviceName {
+			return charm.ParseURL(service.CharmURL())
+		}
+	}
+	return nil, errors.NotFoundf("service %q", serviceName)
+}
+
+func (i *importer) volumes() error {
+	i.logger.Debugf("importing volumes")
+	for _, volume := range i.model.Volumes() {
+		if err := i.volume(volume); err != nil {
+			i.logger.Errorf("error importing volume %s: %s", volume.Tag().Id(), err)
+			return errors.Annotate(err, volume.Tag().Id())
+		}
+	}
+	i.logger.Debugf("importing volumes succeeded")
+	return nil
+}
+
+func (i *importer) volumeSnapshots() error {
+	i.logger.Debugf("importing volume snapshots")
+	var ops []txn.Op
+	for _, snapshot := range i.model.VolumeSnapshots() {
+		// The snapshot's volume may have been removed since the
+		// snapshot was taken, so we do not assert that it exists.
+		doc := &volumeSnapshotDoc{
+			Id:         snapshot.ID(),
+			Volume:     snapshot.Volume().Id(),
+			StorageId:  snapshot.Storage().Id(),
+			Pool:       snapshot.Pool(),
+			SnapshotId: snapshot.SnapshotID(),
+			Size:       snapshot.Size(),
+			Created:    snapshot.Created(),
+		}
+		ops = append(ops, txn.Op{
+			C:      volumeSnapshotsC,
+			Id:     doc.Id,
+			Assert: txn.DocMissing,
+			Insert: doc,
+		})
+	}
+	if len(ops) > 0 {
+		if err := i.st.runTransaction(ops); err != nil {
+			return errors.Trace(err)
+		}
+	}
+	i.logger.Debugf("importing volume snapshots succeeded")
+	return nil
+}
+
+func (i *importer) volume(volume description.Volume) error {
+	attachments := volume.Attachments()
+	tag := volume.Tag()
+	binding, err := volume.Binding()
+	if err != nil {
+		return errors.Annotate(err, "volume binding")
+	}
+	doc := &volumeDoc{
+		Name:            tag.Id(),
+		StorageId:       volume.Storage().Id(),
+		Life:            Alive,
+		AttachmentCount: len(attachments),
+		RequestedSize:   volume.RequestedSize(),
+	}
+	if binding != nil {
+		doc.Binding = binding.String()
+	}
+	if volume.Provisioned() {
+		doc.Info = &VolumeInfo{
+			HardwareId: volume.HardwareID(),
+			Size:       volume.Size(),
+			Pool:       volume.Pool(),
+			VolumeId:   volume.VolumeID(),
+			Persistent: volume.Persistent(),
+		}
+	} else {
+		doc.Params = &VolumeParams{
+			Size: volume.Size(),
+			Pool: volume.Pool(),
+		}
+	}
+
+	status := volume.Status()
+	if status == nil {
+		return errors.NotValidf("missing status")
+	}
+	globalKey := volumeGlobalKey(tag.Id())
+	ops := []txn.Op{
+		createStatusOp(i.st, globalKey, i.makeStatusDoc(status)),
+		{
+			C:      volumesC,
+			Id:     tag.Id(),
+			Assert: txn.DocMissing,
+			Insert: doc,
+		},
+	}
+	for _, attachment := range attachments {
+		machineId := attachment.Machine().Id()
+		attachmentDoc := &volumeAttachmentDoc{
+			Volume:  tag.Id(),
+			Machine: machineId,
+			Life:    Alive,
+		}
+		if attachment.Provisioned() {
+			attachmentDoc.Info = &VolumeAttachmentInfo{
+				DeviceName: attachment.DeviceName(),
+				DeviceLink: attachment.DeviceLink(),
+				BusAddress: attachment.BusAddress(),
+				ReadOnly:   attachment.ReadOnly(),
+			}
+		} else {
+			attachmentDoc.Params = &VolumeAttachmentParams{
+				ReadOnly: attachment.ReadOnly(),
+			}
+		}
+		ops = append(ops, txn.Op{
+			C:      volumeAttachmentsC,
+			Id:     volumeAttachmentId(machineId, tag.Id()),
+			Assert: txn.DocMissing,
+			Insert: attachmentDoc,
+		}, txn.Op{
+			C:      machinesC,
+			Id:     machineId,
+			Assert: txn.DocExists,
+			Update: bson.M{"$addToSet": bson.M{"volumes": tag.Id()}},
+		})
+	}
+
+	if err := i.st.runTransaction(ops); err != nil {
+		return errors.Trace(err)
+	}
+	if err := i.importStatusHistory(globalKey, volume.StatusHistory()); err != nil {
+		return errors.Trace(err)
+	}
+	return nil
+}
+
+func (i *importer) filesystems() error {
+	i.logger.Debugf("importing filesystems")
+	for _, filesystem := range i.model.Filesystems() {
+		if err := i.filesystem(filesystem); err != nil {
+			i.logger.Errorf("error importing filesystem %s: %s", filesystem.Tag().Id(), err)
+			return errors.Annotate(err, filesystem.Tag().Id())
+		}
+	}
+	i.logger.Debugf("importing filesystems succeeded")
+	return nil
+}
+
+func (i *importer) filesystem(filesystem description.Filesystem) error {
+	attachments := filesystem.Attachments()
+	tag := filesystem.Tag()
+	binding, err := filesystem.Binding()
+	if err != nil {
+		return errors.Annotate(err, "filesystem binding")
+	}
+	doc := &filesystemDoc{
+		FilesystemId:    tag.Id(),
+		StorageId:       filesystem.Storage().Id(),
+		VolumeId:        filesystem.Volume().Id(),
+		Life:            Alive,
+		AttachmentCount: len(attachments),
+	}
+	if binding != nil {
+		doc.Binding = binding.String()
+	}
+	if filesystem.Provisioned() {
+		doc.Info = &FilesystemInfo{
+			Size:         filesystem.Size(),
+			Pool:         filesystem.Pool(),
+			FilesystemId: filesystem.FilesystemID(),
+		}
+	} else {
+		doc.Params = &FilesystemParams{
+			Size: filesystem.Size(),
+			Pool: filesystem.Pool(),
+		}
+	}
+
+	status := filesystem.Status()
+	if status == nil {
+		return errors.NotValidf("missing status")
+	}
+	globalKey := filesystemGlobalKey(tag.Id())
+	ops := []txn.Op{
+		createStatusOp(i.st, globalKey, i.makeStatusDoc(status)),
+		{
+			C:      filesystemsC,
+			Id:     tag.Id(),
+			Assert: txn.DocMissing,
+			Insert: doc,
+		},
+	}
+	for _, attachment := range attachments {
+		machineId := attachment.Machine().Id()
+		attachmentDoc := &filesystemAttachmentDoc{
+			Filesystem: tag.Id(),
+			Machine:    machineId,
+			Life:       Alive,
+		}
+		if attachment.Provisioned() {
+			attachmentDoc.Info = &FilesystemAttachmentInfo{
+				MountPoint: attachment.MountPoint(),
+				ReadOnly:   attachment.ReadOnly(),
+			}
+		} else {
+			attachmentDoc.Params = &FilesystemAttachmentParams{
+				Location: attachment.MountPoint(),
+				ReadOnly: attachment.ReadOnly(),
+			}
+		}
+		ops = append(ops, txn.Op{
+			C:      filesystemAttachmentsC,
+			Id:     filesystemAttachmentId(machineId, tag.Id()),
+			Assert: txn.DocMissing,
+			Insert: attachmentDoc,
+		}, txn.Op{
+			C:      machinesC,
+			Id:     machineId,
+			Assert: txn.DocExists,
+			Update: bson.M{"$addToSet": bson.M{"filesystems": tag.Id()}},
+		})
+	}
+
+	if err := i.st.runTransaction(ops); err != nil {
+		return errors.Trace(err)
+	}
+	if err := i.importStatusHistory(globalKey, filesystem.StatusHistory()); err != nil {
+		return errors.Trace(err)
+	}
+	return nil
+}
+
+func (i *importer) storageConstraints(cons map[string]description.StorageConstraint) map[string]StorageConstraints {
+	if len(cons) == 0 {
+		return nil
+	}
+	result := make(map[string]StorageConstraints)
+	for key, value := range cons {
+		result[key] = StorageConstraints{
+			Pool:  value.Pool(),
+			Size:  value.Size(),
+			Count: value.Count(),
+		}
+	}
+	return result
+}
+
 func (i *importer) importStatusHistory(globalKey string, history []description.Status) error {
 	docs := make([]interface{}, len(history))
 	for i, statusVal := range history {
@@ -854,5 +1546,8 @@ func (i *importer) constraints(cons description.Constraints) constraints.Value {
 	if tags := cons.Tags(); len(tags) > 0 {
 		result.Tags = &tags
 	}
+	if zones := cons.Zones(); len(zones) > 0 {
+		result.Zones = &zones
+	}
 	return result
 }
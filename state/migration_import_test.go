@@ -4,6 +4,7 @@
 package state_test
 
 import (
+	"bytes"
 	"time"
 
 	"github.com/juju/errors"
@@ -12,12 +13,16 @@ import (
 	"github.com/juju/utils"
 	"github.com/juju/version"
 	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/charm.v6-unstable"
 
 	"github.com/juju/juju/constraints"
 	"github.com/juju/juju/core/description"
 	"github.com/juju/juju/network"
+	"github.com/juju/juju/payload"
 	"github.com/juju/juju/state"
 	"github.com/juju/juju/status"
+	"github.com/juju/juju/storage/poolmanager"
+	"github.com/juju/juju/storage/provider"
 	"github.com/juju/juju/testing/factory"
 )
 
@@ -157,6 +162,7 @@ func (s *MigrationImportSuite) AssertUserEqual(c *gc.C, newUser, oldUser *state.
 	c.Assert(newUser.CreatedBy(), gc.Equals, oldUser.CreatedBy())
 	c.Assert(newUser.DateCreated(), gc.Equals, oldUser.DateCreated())
 	c.Assert(newUser.ReadOnly(), gc.Equals, oldUser.ReadOnly())
+	c.Assert(newUser.Access(), gc.Equals, oldUser.Access())
 
 	connTime, err := oldUser.LastConnection()
 	if state.IsNeverConnectedError(err) {
@@ -286,7 +292,7 @@ func (s *MigrationImportSuite) TestServices(c *gc.C) {
 	err = service.SetMetricCredentials([]byte("sekrit"))
 	c.Assert(err, jc.ErrorIsNil)
 	// Expose the service.
-	c.Assert(service.SetExposed(), jc.ErrorIsNil)
+	c.Assert(service.SetExposedCIDRs([]string{"10.0.0.0/8"}), jc.ErrorIsNil)
 	err = s.State.SetAnnotations(service, testAnnotations)
 	c.Assert(err, jc.ErrorIsNil)
 	s.primeStatusHistory(c, service, status.StatusActive, 5)
@@ -308,6 +314,7 @@ func (s *MigrationImportSuite) TestServices(c *gc.C) {
 	c.Assert(imported.ServiceTag(), gc.Equals, exported.ServiceTag())
 	c.Assert(imported.Series(), gc.Equals, exported.Series())
 	c.Assert(imported.IsExposed(), gc.Equals, exported.IsExposed())
+	c.Assert(imported.ExposedCIDRs(), jc.DeepEquals, exported.ExposedCIDRs())
 	c.Assert(imported.MetricCredentials(), jc.DeepEquals, exported.MetricCredentials())
 
 	exportedConfig, err := exported.ConfigSettings()
@@ -464,6 +471,178 @@ func (s *MigrationImportSuite) TestUnitsOpenPorts(c *gc.C) {
 	})
 }
 
+func (s *MigrationImportSuite) TestSpacesAndSubnets(c *gc.C) {
+	space, err := s.State.AddSpace("one", network.Id("provider"), nil, true)
+	c.Assert(err, jc.ErrorIsNil)
+	original, err := s.State.AddSubnet(state.SubnetInfo{
+		CIDR:             "10.0.0.0/24",
+		ProviderId:       network.Id("foo"),
+		VLANTag:          64,
+		AvailabilityZone: "bar",
+		SpaceName:        "one",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	_, newSt := s.importModel(c)
+	defer newSt.Close()
+
+	spaces, err := newSt.AllSpaces()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(spaces, gc.HasLen, 1)
+	imported := spaces[0]
+	c.Assert(imported.Name(), gc.Equals, space.Name())
+	c.Assert(imported.ProviderId(), gc.Equals, space.ProviderId())
+
+	subnet, err := newSt.Subnet(original.CIDR())
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(subnet.ProviderId(), gc.Equals, original.ProviderId())
+	c.Assert(subnet.VLANTag(), gc.Equals, original.VLANTag())
+	c.Assert(subnet.AvailabilityZone(), gc.Equals, original.AvailabilityZone())
+	c.Assert(subnet.SpaceName(), gc.Equals, original.SpaceName())
+}
+
+func (s *MigrationImportSuite) TestLinkLayerDevicesAndAddresses(c *gc.C) {
+	_, err := s.State.AddSubnet(state.SubnetInfo{CIDR: "0.1.2.0/24"})
+	c.Assert(err, jc.ErrorIsNil)
+	machine := s.Factory.MakeMachine(c, nil)
+	err = machine.SetLinkLayerDevices(state.LinkLayerDeviceArgs{
+		Name:       "bridge",
+		Type:       state.BridgeDevice,
+		MACAddress: "aa:bb:cc:dd:ee:f0",
+		IsUp:       true,
+	}, state.LinkLayerDeviceArgs{
+		Name:       "eth0",
+		Type:       state.EthernetDevice,
+		MACAddress: "aa:bb:cc:dd:ee:f1",
+		ProviderID: network.Id("magic"),
+		ParentName: "bridge",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	err = machine.SetDevicesAddresses(state.LinkLayerDeviceAddress{
+		DeviceName:   "eth0",
+		ConfigMethod: state.StaticAddress,
+		CIDRAddress:  "0.1.2.3/24",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	_, newSt := s.importModel(c)
+	defer newSt.Close()
+
+	imported, err := newSt.Machine(machine.Id())
+	c.Assert(err, jc.ErrorIsNil)
+	eth0, err := imported.LinkLayerDevice("eth0")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(eth0.ProviderID(), gc.Equals, network.Id("magic"))
+	c.Assert(eth0.MACAddress(), gc.Equals, "aa:bb:cc:dd:ee:f1")
+	bridge, err := eth0.ParentDevice()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(bridge.Name(), gc.Equals, "bridge")
+	// The references document must record the child device, so the
+	// parent can't be removed while it has children.
+	err = bridge.Remove()
+	c.Assert(err, jc.Satisfies, state.IsParentDeviceHasChildrenError)
+
+	addresses, err := imported.AllAddresses()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(addresses, gc.HasLen, 1)
+	c.Assert(addresses[0].Value(), gc.Equals, "0.1.2.3")
+	c.Assert(addresses[0].DeviceName(), gc.Equals, "eth0")
+	c.Assert(addresses[0].SubnetCIDR(), gc.Equals, "0.1.2.0/24")
+}
+
+func (s *MigrationImportSuite) TestEndpointBindings(c *gc.C) {
+	_, err := s.State.AddSpace("one", "", nil, true)
+	c.Assert(err, jc.ErrorIsNil)
+	ch := state.AddTestingCharm(c, s.State, "mysql")
+	_, err = s.State.AddService(state.AddServiceArgs{
+		Name:  "mysql",
+		Owner: s.Owner.String(),
+		Charm: ch,
+		EndpointBindings: map[string]string{
+			"server": "one",
+		},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	_, newSt := s.importModel(c)
+	defer newSt.Close()
+
+	service, err := newSt.Service("mysql")
+	c.Assert(err, jc.ErrorIsNil)
+	bindings, err := service.EndpointBindings()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(bindings["server"], gc.Equals, "one")
+}
+
+func (s *MigrationImportSuite) TestServiceUsers(c *gc.C) {
+	service := s.Factory.MakeService(c, nil)
+	user := names.NewUserTag("bob@external")
+	err := s.State.SetServiceAccess(service.Name(), user, state.ServiceOperateAccess, s.Owner)
+	c.Assert(err, jc.ErrorIsNil)
+
+	_, newSt := s.importModel(c)
+	defer newSt.Close()
+
+	access, err := newSt.ServiceAccess(service.Name(), user)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(access, gc.Equals, state.ServiceOperateAccess)
+	users, err := newSt.ServiceUsers(service.Name())
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(users, gc.HasLen, 1)
+	c.Assert(users[0].GrantedModelAccess(), jc.IsTrue)
+}
+
+func (s *MigrationImportSuite) TestResources(c *gc.C) {
+	ch := state.AddTestingCharm(c, s.State, "wordpress")
+	state.AddTestingService(c, s.State, "a-service", ch, s.Owner)
+
+	st, err := s.State.Resources()
+	c.Assert(err, jc.ErrorIsNil)
+	data := "spamspamspam"
+	res := newResource(c, "spam", data)
+	original, err := st.SetResource("a-service", res.Username, res.Resource, bytes.NewBufferString(data))
+	c.Assert(err, jc.ErrorIsNil)
+
+	_, newSt := s.importModel(c)
+	defer newSt.Close()
+
+	newResources, err := newSt.Resources()
+	c.Assert(err, jc.ErrorIsNil)
+	imported, err := newResources.GetResource("a-service", "spam")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(imported.Resource, jc.DeepEquals, original.Resource)
+	c.Assert(imported.Username, gc.Equals, original.Username)
+	c.Assert(imported.Timestamp.Unix(), gc.Equals, original.Timestamp.Unix())
+}
+
+func (s *MigrationImportSuite) TestPayloads(c *gc.C) {
+	unit := s.Factory.MakeUnit(c, nil)
+	up, err := s.State.UnitPayloads(unit)
+	c.Assert(err, jc.ErrorIsNil)
+	original := payload.Payload{
+		PayloadClass: charm.PayloadClass{
+			Name: "spam",
+			Type: "docker",
+		},
+		ID:     "idfoo",
+		Status: payload.StateRunning,
+		Labels: []string{"a", "b"},
+		Unit:   unit.Name(),
+	}
+	err = up.Track(original)
+	c.Assert(err, jc.ErrorIsNil)
+
+	_, newSt := s.importModel(c)
+	defer newSt.Close()
+
+	envPayloads, err := newSt.EnvPayloads()
+	c.Assert(err, jc.ErrorIsNil)
+	payloads, err := envPayloads.ListAll()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(payloads, gc.HasLen, 1)
+	c.Assert(payloads[0].Payload, jc.DeepEquals, original)
+}
+
 func (s *MigrationImportSuite) TestDestroyEmptyModel(c *gc.C) {
 	newModel, newSt := s.importModel(c)
 	defer newSt.Close()
@@ -515,3 +694,144 @@ func (m *mockModel) Config() map[string]interface{} {
 	c["name"] = m.name
 	return c
 }
+
+func (s *MigrationImportSuite) TestVolumeRequestedSize(c *gc.C) {
+	_, _, storageTag := s.makeUnitWithStorage(c)
+	volume, err := s.State.StorageInstanceVolume(storageTag)
+	c.Assert(err, jc.ErrorIsNil)
+	volTag := volume.VolumeTag()
+	err = s.State.SetVolumeInfo(volTag, state.VolumeInfo{
+		Size:     1500,
+		VolumeId: "volume id",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.State.ResizeVolume(volTag, 3000)
+	c.Assert(err, jc.ErrorIsNil)
+
+	_, newSt := s.importModel(c)
+	defer newSt.Close()
+
+	importedVolume, err := newSt.Volume(volTag)
+	c.Assert(err, jc.ErrorIsNil)
+	size, ok := importedVolume.RequestedSize()
+	c.Check(ok, jc.IsTrue)
+	c.Check(size, gc.Equals, uint64(3000))
+}
+
+func (s *MigrationImportSuite) TestVolumeSnapshots(c *gc.C) {
+	_, _, storageTag := s.makeUnitWithStorage(c)
+	volume, err := s.State.StorageInstanceVolume(storageTag)
+	c.Assert(err, jc.ErrorIsNil)
+	volTag := volume.VolumeTag()
+	err = s.State.SetVolumeInfo(volTag, state.VolumeInfo{
+		Size:     1500,
+		VolumeId: "volume id",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	snapshot, err := s.State.AddVolumeSnapshot(volTag, state.VolumeSnapshotInfo{
+		SnapshotId: "snap-0",
+		Size:       1500,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	// Reload the snapshot so that its creation time has the
+	// precision that is stored in the database.
+	snapshot, err = s.State.VolumeSnapshot(snapshot.Id())
+	c.Assert(err, jc.ErrorIsNil)
+
+	_, newSt := s.importModel(c)
+	defer newSt.Close()
+
+	imported, err := newSt.VolumeSnapshot(snapshot.Id())
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(imported.VolumeTag(), gc.Equals, volTag)
+	storage, ok := imported.StorageInstance()
+	c.Check(ok, jc.IsTrue)
+	c.Check(storage, gc.Equals, storageTag)
+	c.Check(imported.Pool(), gc.Equals, snapshot.Pool())
+	c.Check(imported.SnapshotId(), gc.Equals, "snap-0")
+	c.Check(imported.Size(), gc.Equals, uint64(1500))
+	c.Check(imported.Created(), gc.Equals, snapshot.Created())
+}
+
+func (s *MigrationImportSuite) TestStorage(c *gc.C) {
+	_, unit, storageTag := s.makeUnitWithStorage(c)
+	machineId, err := unit.AssignedMachineId()
+	c.Assert(err, jc.ErrorIsNil)
+	machineTag := names.NewMachineTag(machineId)
+
+	volume, err := s.State.StorageInstanceVolume(storageTag)
+	c.Assert(err, jc.ErrorIsNil)
+	volTag := volume.VolumeTag()
+	volInfo := state.VolumeInfo{
+		HardwareId: "magic",
+		Size:       1500,
+		VolumeId:   "volume id",
+		Persistent: true,
+	}
+	err = s.State.SetVolumeInfo(volTag, volInfo)
+	c.Assert(err, jc.ErrorIsNil)
+	attachmentInfo := state.VolumeAttachmentInfo{
+		DeviceName: "device name",
+		DeviceLink: "device link",
+		BusAddress: "bus address",
+		ReadOnly:   true,
+	}
+	err = s.State.SetVolumeAttachmentInfo(machineTag, volTag, attachmentInfo)
+	c.Assert(err, jc.ErrorIsNil)
+
+	_, newSt := s.importModel(c)
+	defer newSt.Close()
+
+	importedService, err := newSt.Service("storage-block")
+	c.Assert(err, jc.ErrorIsNil)
+	cons, err := importedService.StorageConstraints()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(cons, jc.DeepEquals, map[string]state.StorageConstraints{
+		"data": makeStorageCons("loop-pool", 1024, 1),
+	})
+
+	instance, err := newSt.StorageInstance(storageTag)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(instance.Kind(), gc.Equals, state.StorageKindBlock)
+	owner, ok := instance.Owner()
+	c.Check(ok, jc.IsTrue)
+	c.Check(owner, gc.Equals, unit.Tag())
+	c.Check(instance.StorageName(), gc.Equals, "data")
+	curl, _ := importedService.CharmURL()
+	c.Check(instance.CharmURL(), jc.DeepEquals, curl)
+
+	attachments, err := newSt.UnitStorageAttachments(unit.UnitTag())
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(attachments, gc.HasLen, 1)
+	c.Check(attachments[0].StorageInstance(), gc.Equals, storageTag)
+
+	importedVolume, err := newSt.Volume(volTag)
+	c.Assert(err, jc.ErrorIsNil)
+	importedStorage, err := importedVolume.StorageInstance()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(importedStorage, gc.Equals, storageTag)
+	info, err := importedVolume.Info()
+	c.Assert(err, jc.ErrorIsNil)
+	volInfo.Pool = "loop-pool"
+	c.Check(info, jc.DeepEquals, volInfo)
+	volStatus, err := importedVolume.Status()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(volStatus.Status, gc.Equals, status.StatusPending)
+
+	importedAttachment, err := newSt.VolumeAttachment(machineTag, volTag)
+	c.Assert(err, jc.ErrorIsNil)
+	importedAttachmentInfo, err := importedAttachment.Info()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(importedAttachmentInfo, jc.DeepEquals, attachmentInfo)
+
+	machine, err := newSt.Machine(machineId)
+	c.Assert(err, jc.ErrorIsNil)
+	machineAttachments, err := newSt.MachineVolumeAttachments(machine.MachineTag())
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(machineAttachments, gc.HasLen, 1)
+
+	pm := poolmanager.New(state.NewStateSettings(newSt))
+	pool, err := pm.Get("loop-pool")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(pool.Provider(), gc.Equals, provider.LoopProviderType)
+}
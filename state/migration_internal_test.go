@@ -38,6 +38,10 @@ func (s *MigrationSuite) TestKnownCollections(c *gc.C) {
 		servicesC,
 		unitsC,
 		meterStatusC, // red / green status for metrics of units
+		endpointBindingsC,
+		serviceUsersC,
+		"payloads",
+		"resources",
 
 		// settings reference counts are only used for services
 		settingsrefsC,
@@ -45,6 +49,23 @@ func (s *MigrationSuite) TestKnownCollections(c *gc.C) {
 		// relation
 		relationsC,
 		relationScopesC,
+
+		// storage
+		filesystemsC,
+		filesystemAttachmentsC,
+		storageInstancesC,
+		storageAttachmentsC,
+		storageConstraintsC,
+		volumesC,
+		volumeAttachmentsC,
+		volumeSnapshotsC,
+
+		// network
+		ipAddressesC,
+		linkLayerDevicesC,
+		linkLayerDevicesRefsC,
+		subnetsC,
+		spacesC,
 	)
 
 	ignoredCollections := set.NewStrings(
@@ -60,6 +81,11 @@ func (s *MigrationSuite) TestKnownCollections(c *gc.C) {
 		// Users aren't migrated.
 		usersC,
 		userLastLoginC,
+		userFailedLoginsC,
+		// Controller access is controller global, not migrated.
+		controllerUsersC,
+		// The audit log is controller global, not migrated.
+		auditLogC,
 		// userenvnameC is just to provide a unique key constraint.
 		usermodelnameC,
 		// Metrics aren't migrated.
@@ -86,6 +112,7 @@ func (s *MigrationSuite) TestKnownCollections(c *gc.C) {
 		migrationsC,
 		migrationsStatusC,
 		migrationsActiveC,
+		migrationsMinionSyncC,
 
 		// The container ref document is primarily there to keep track
 		// of a particular machine's containers. The migration format
@@ -112,6 +139,10 @@ func (s *MigrationSuite) TestKnownCollections(c *gc.C) {
 		// The SSH host keys for each machine will be reported as each
 		// machine agent starts up.
 		sshHostKeysC,
+
+		// The block devices for each machine will be reported as each
+		// machine agent starts up.
+		blockDevicesC,
 	)
 
 	// THIS SET WILL BE REMOVED WHEN MIGRATIONS ARE COMPLETE
@@ -124,26 +155,6 @@ func (s *MigrationSuite) TestKnownCollections(c *gc.C) {
 
 		// service / unit
 		charmsC,
-		"payloads",
-		"resources",
-		endpointBindingsC,
-
-		// storage
-		blockDevicesC,
-		filesystemsC,
-		filesystemAttachmentsC,
-		storageInstancesC,
-		storageAttachmentsC,
-		storageConstraintsC,
-		volumesC,
-		volumeAttachmentsC,
-
-		// network
-		ipAddressesC,
-		linkLayerDevicesC,
-		linkLayerDevicesRefsC,
-		subnetsC,
-		spacesC,
 
 		// actions
 		actionsC,
@@ -244,16 +255,17 @@ func (s *MigrationSuite) TestMachineDocFields(c *gc.C) {
 		"SupportedContainers",
 		"SupportedContainersKnown",
 		"Tools",
+		// Volumes and Filesystems are rebuilt from the attachments.
+		"Volumes",
+		"Filesystems",
 
 		// Ignored at this stage, could be an issue if mongo 3.0 isn't
 		// available.
 		"StopMongoUntilVersion",
 	)
 	todo := set.NewStrings(
-		"Volumes",
 		"NoVote",
 		"Clean",
-		"Filesystems",
 		"HasVote",
 	)
 	s.AssertExportedFields(c, machineDoc{}, fields.Union(todo))
@@ -309,6 +321,7 @@ func (s *MigrationSuite) TestServiceDocFields(c *gc.C) {
 		"CharmModifiedVersion",
 		"ForceCharm",
 		"Exposed",
+		"ExposedCIDRs",
 		"MinUnits",
 		"MetricCredentials",
 	)
@@ -354,12 +367,10 @@ func (s *MigrationSuite) TestUnitDocFields(c *gc.C) {
 		"Ports",
 		"PublicAddress",
 		"PrivateAddress",
-	)
-	todo := set.NewStrings(
+		// StorageAttachmentCount is derived from the storage attachments.
 		"StorageAttachmentCount",
 	)
-
-	s.AssertExportedFields(c, unitDoc{}, fields.Union(todo))
+	s.AssertExportedFields(c, unitDoc{}, fields)
 }
 
 func (s *MigrationSuite) TestPortsDocFields(c *gc.C) {
@@ -495,6 +506,7 @@ func (s *MigrationSuite) TestConstraintsDocFields(c *gc.C) {
 		"Container",
 		"Tags",
 		"Spaces",
+		"Zones",
 	)
 	s.AssertExportedFields(c, constraintsDoc{}, fields)
 }
@@ -513,6 +525,130 @@ func (s *MigrationSuite) TestHistoricalStatusDocFields(c *gc.C) {
 	s.AssertExportedFields(c, historicalStatusDoc{}, fields)
 }
 
+func (s *MigrationSuite) TestStorageInstanceDocFields(c *gc.C) {
+	fields := set.NewStrings(
+		// DocID is the model + storage id
+		"DocID",
+		// ModelUUID shouldn't be exported, and is inherited
+		// from the model definition.
+		"ModelUUID",
+		"Id",
+		"Kind",
+		// Life isn't migrated as we only migrate live things.
+		"Life",
+		"Owner",
+		"StorageName",
+		// AttachmentCount is derived from the attachments.
+		"AttachmentCount",
+		// CharmURL comes from the owning service.
+		"CharmURL",
+	)
+	s.AssertExportedFields(c, storageInstanceDoc{}, fields)
+}
+
+func (s *MigrationSuite) TestStorageAttachmentDocFields(c *gc.C) {
+	fields := set.NewStrings(
+		"DocID",
+		// ModelUUID shouldn't be exported, and is inherited
+		// from the model definition.
+		"ModelUUID",
+		"Unit",
+		"StorageInstance",
+		// Life isn't migrated as we only migrate live things.
+		"Life",
+	)
+	s.AssertExportedFields(c, storageAttachmentDoc{}, fields)
+}
+
+func (s *MigrationSuite) TestVolumeDocFields(c *gc.C) {
+	fields := set.NewStrings(
+		"DocID",
+		// ModelUUID shouldn't be exported, and is inherited
+		// from the model definition.
+		"ModelUUID",
+		"Name",
+		// Life isn't migrated as we only migrate live things.
+		"Life",
+		"StorageId",
+		// AttachmentCount is derived from the attachments.
+		"AttachmentCount",
+		"Binding",
+		"Info",
+		"Params",
+		"RequestedSize",
+	)
+	s.AssertExportedFields(c, volumeDoc{}, fields)
+}
+
+func (s *MigrationSuite) TestVolumeAttachmentDocFields(c *gc.C) {
+	fields := set.NewStrings(
+		"DocID",
+		// ModelUUID shouldn't be exported, and is inherited
+		// from the model definition.
+		"ModelUUID",
+		"Volume",
+		"Machine",
+		// Life isn't migrated as we only migrate live things.
+		"Life",
+		"Info",
+		"Params",
+	)
+	s.AssertExportedFields(c, volumeAttachmentDoc{}, fields)
+}
+
+func (s *MigrationSuite) TestVolumeSnapshotDocFields(c *gc.C) {
+	fields := set.NewStrings(
+		"DocID",
+		// ModelUUID shouldn't be exported, and is inherited
+		// from the model definition.
+		"ModelUUID",
+		"Id",
+		"Volume",
+		"StorageId",
+		"Pool",
+		"SnapshotId",
+		"Size",
+		"Created",
+	)
+	s.AssertExportedFields(c, volumeSnapshotDoc{}, fields)
+}
+
+func (s *MigrationSuite) TestFilesystemDocFields(c *gc.C) {
+	fields := set.NewStrings(
+		"DocID",
+		// ModelUUID shouldn't be exported, and is inherited
+		// from the model definition.
+		"ModelUUID",
+		"FilesystemId",
+		// Life isn't migrated as we only migrate live things.
+		"Life",
+		"StorageId",
+		"VolumeId",
+		// AttachmentCount is derived from the attachments.
+		"AttachmentCount",
+		"Binding",
+		"Info",
+		"Params",
+	)
+	s.AssertExportedFields(c, filesystemDoc{}, fields)
+}
+
+func (s *MigrationSuite) TestFilesystemAttachmentDocFields(c *gc.C) {
+	fields := set.NewStrings(
+		"DocID",
+		// ModelUUID shouldn't be exported, and is inherited
+		// from the model definition.
+		"ModelUUID",
+		"Filesystem",
+		"Machine",
+		// Life isn't migrated as we only migrate live things.
+		"Life",
+		"Info",
+		"Params",
+	)
+	s.AssertExportedFields(c, filesystemAttachmentDoc{}, fields)
+}
+
 func (s *MigrationSuite) AssertExportedFields(c *gc.C, doc interface{}, fields set.Strings) {
 	expected := getExportedFields(doc)
 	unknown := expected.Difference(fields)
@@ -10,6 +10,7 @@ import (
 
 	"github.com/juju/errors"
 	"github.com/juju/names"
+	"github.com/juju/utils/set"
 	"gopkg.in/mgo.v2"
 	"gopkg.in/mgo.v2/bson"
 	"gopkg.in/mgo.v2/txn"
@@ -74,6 +75,29 @@ type ModelMigration interface {
 	// Refresh updates the contents of the ModelMigration from the
 	// underlying state.
 	Refresh() error
+
+	// MinionReport records a report from a migration minion worker
+	// about the success or failure to complete its actions for a
+	// given migration phase.
+	MinionReport(tag names.Tag, phase migration.Phase, success bool) error
+
+	// MinionReports returns details of the minions that have reported
+	// success or failure for the current migration phase, as well as
+	// those which are yet to report.
+	MinionReports() (*MinionReports, error)
+
+	// WatchMinionReports returns a notify watcher which triggers when
+	// a migration minion has reported back about the success or
+	// failure of its actions for the current migration phase.
+	WatchMinionReports() (NotifyWatcher, error)
+}
+
+// MinionReports indicates the sync status of the agents of a model
+// for a migration phase.
+type MinionReports struct {
+	Succeeded []names.Tag
+	Failed    []names.Tag
+	Unknown   []names.Tag
 }
 
 // modelMigration is an implementation of ModelMigration.
@@ -155,6 +179,32 @@ type modelMigStatusDoc struct {
 	StatusMessage string `bson:"status-message"`
 }
 
+// modelMigMinionSyncDoc records the success or failure of a
+// migration minion worker for a migration phase. These are written
+// into migrationsMinionSyncC.
+type modelMigMinionSyncDoc struct {
+	// Id has the format "migration-id:phase:entity-key".
+	Id string `bson:"_id"`
+
+	// MigrationId is the id of the migration the report is for.
+	MigrationId string `bson:"migration-id"`
+
+	// Phase is the migration phase the report is for.
+	Phase string `bson:"phase"`
+
+	// EntityKey is the global key of the agent which made the
+	// report.
+	EntityKey string `bson:"entity-key"`
+
+	// Time holds the time the report was received (stored as per
+	// UnixNano).
+	Time int64 `bson:"time"`
+
+	// Success is true if the minion completed its actions for the
+	// phase without problems.
+	Success bool `bson:"success"`
+}
+
 // Id implements ModelMigration.
 func (mig *modelMigration) Id() string {
 	return mig.doc.Id
@@ -319,6 +369,182 @@ func (mig *modelMigration) Refresh() error {
 	return nil
 }
 
+// MinionReport implements ModelMigration.
+func (mig *modelMigration) MinionReport(tag names.Tag, phase migration.Phase, success bool) error {
+	globalKey, err := agentTagToGlobalKey(tag)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	docID := mig.minionReportId(phase, globalKey)
+	doc := modelMigMinionSyncDoc{
+		Id:          docID,
+		MigrationId: mig.Id(),
+		Phase:       phase.String(),
+		EntityKey:   globalKey,
+		Time:        GetClock().Now().UnixNano(),
+		Success:     success,
+	}
+	ops := []txn.Op{{
+		C:      migrationsMinionSyncC,
+		Id:     docID,
+		Insert: &doc,
+		Assert: txn.DocMissing,
+	}}
+	err = mig.st.runTransaction(ops)
+	if errors.Cause(err) == txn.ErrAborted {
+		// A report has already been made for this agent and
+		// phase. That's fine as long as it agrees with this one.
+		coll, closer := mig.st.getCollection(migrationsMinionSyncC)
+		defer closer()
+		var existingDoc modelMigMinionSyncDoc
+		err := coll.FindId(docID).Select(bson.M{"success": 1}).One(&existingDoc)
+		if err != nil {
+			return errors.Annotate(err, "checking existing report")
+		}
+		if existingDoc.Success != success {
+			return errors.Errorf("conflicting reports received for %s/%s/%s",
+				mig.Id(), phase.String(), tag)
+		}
+		return nil
+	} else if err != nil {
+		return errors.Trace(err)
+	}
+	return nil
+}
+
+// MinionReports implements ModelMigration.
+func (mig *modelMigration) MinionReports() (*MinionReports, error) {
+	all, err := mig.getAllAgents()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+
+	phase, err := mig.Phase()
+	if err != nil {
+		return nil, errors.Annotate(err, "retrieving phase")
+	}
+
+	coll, closer := mig.st.getCollection(migrationsMinionSyncC)
+	defer closer()
+	query := coll.Find(bson.M{
+		"migration-id": mig.Id(),
+		"phase":        phase.String(),
+	})
+	query = query.Select(bson.M{
+		"entity-key": 1,
+		"success":    1,
+	})
+	var docs []modelMigMinionSyncDoc
+	if err := query.All(&docs); err != nil {
+		return nil, errors.Annotate(err, "retrieving minion reports")
+	}
+
+	succeeded := set.NewTags()
+	failed := set.NewTags()
+	for _, doc := range docs {
+		tag, err := globalKeyToAgentTag(doc.EntityKey)
+		if err != nil {
+			return nil, errors.Annotatef(err, "processing minion report %q", doc.Id)
+		}
+		if doc.Success {
+			succeeded.Add(tag)
+		} else {
+			failed.Add(tag)
+		}
+		all.Remove(tag)
+	}
+
+	return &MinionReports{
+		Succeeded: succeeded.SortedValues(),
+		Failed:    failed.SortedValues(),
+		Unknown:   all.SortedValues(),
+	}, nil
+}
+
+// WatchMinionReports implements ModelMigration.
+func (mig *modelMigration) WatchMinionReports() (NotifyWatcher, error) {
+	phase, err := mig.Phase()
+	if err != nil {
+		return nil, errors.Annotate(err, "retrieving phase")
+	}
+	prefix := mig.minionReportId(phase, "")
+	return newMinionReportsWatcher(mig.st, prefix), nil
+}
+
+func (mig *modelMigration) minionReportId(phase migration.Phase, globalKey string) string {
+	return fmt.Sprintf("%s:%s:%s", mig.Id(), phase.String(), globalKey)
+}
+
+// getAllAgents returns the tags of all the machine and unit agents
+// in the migrating model which are expected to report back.
+func (mig *modelMigration) getAllAgents() (set.Tags, error) {
+	machineTags, err := mig.loadAgentTags(machinesC, "machineid",
+		func(id string) names.Tag { return names.NewMachineTag(id) },
+	)
+	if err != nil {
+		return nil, errors.Annotate(err, "loading machine tags")
+	}
+
+	unitTags, err := mig.loadAgentTags(unitsC, "name",
+		func(name string) names.Tag { return names.NewUnitTag(name) },
+	)
+	if err != nil {
+		return nil, errors.Annotate(err, "loading unit names")
+	}
+
+	return machineTags.Union(unitTags), nil
+}
+
+func (mig *modelMigration) loadAgentTags(collName, fieldName string, convert func(string) names.Tag) (
+	set.Tags, error,
+) {
+	// During migrations no machines or units are being provisioned
+	// or destroyed so a simple query of the collections will do.
+	coll, closer := mig.st.getCollection(collName)
+	defer closer()
+	var docs []bson.M
+	err := coll.Find(bson.M{"life": bson.M{"$ne": Dead}}).Select(bson.M{fieldName: 1}).All(&docs)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+
+	out := set.NewTags()
+	for _, doc := range docs {
+		v, ok := doc[fieldName].(string)
+		if !ok {
+			return nil, errors.Errorf("invalid %s value encountered: %v", fieldName, doc[fieldName])
+		}
+		out.Add(convert(v))
+	}
+	return out, nil
+}
+
+func agentTagToGlobalKey(tag names.Tag) (string, error) {
+	switch t := tag.(type) {
+	case names.MachineTag:
+		return machineGlobalKey(t.Id()), nil
+	case names.UnitTag:
+		return unitGlobalKey(t.Id()), nil
+	default:
+		return "", errors.Errorf("%s is not an agent", tag)
+	}
+}
+
+func globalKeyToAgentTag(globalKey string) (names.Tag, error) {
+	if len(globalKey) < 3 || globalKey[1] != '#' {
+		return nil, errors.NotValidf("global key %q", globalKey)
+	}
+	id := globalKey[2:]
+	switch globalKey[0] {
+	case 'm':
+		return names.NewMachineTag(id), nil
+	case 'u':
+		return names.NewUnitTag(id), nil
+	default:
+		return nil, errors.NotValidf("global key %q", globalKey)
+	}
+}
+
 // ModelMigrationSpec holds the information required to create a
 // ModelMigration instance.
 type ModelMigrationSpec struct {
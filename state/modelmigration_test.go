@@ -18,6 +18,7 @@ import (
 	"github.com/juju/juju/state"
 	statetesting "github.com/juju/juju/state/testing"
 	coretesting "github.com/juju/juju/testing"
+	"github.com/juju/juju/testing/factory"
 )
 
 type ModelMigrationSuite struct {
@@ -542,6 +543,112 @@ func (s *ModelMigrationSuite) TestWatchMigrationStatusMultiModel(c *gc.C) {
 	wc3.AssertNoChange()
 }
 
+func (s *ModelMigrationSuite) TestMinionReports(c *gc.C) {
+	// Create some machines and units to report with.
+	factory2 := factory.NewFactory(s.State2)
+	m0 := factory2.MakeMachine(c, nil)
+	u0 := factory2.MakeUnit(c, &factory.UnitParams{Machine: m0})
+	m1 := factory2.MakeMachine(c, nil)
+	m2 := factory2.MakeMachine(c, nil)
+
+	mig, err := s.State2.CreateModelMigration(s.stdSpec)
+	c.Assert(err, jc.ErrorIsNil)
+
+	const phase = migration.QUIESCE
+	c.Assert(mig.SetPhase(phase), jc.ErrorIsNil)
+
+	c.Assert(mig.MinionReport(m0.Tag(), phase, true), jc.ErrorIsNil)
+	c.Assert(mig.MinionReport(m1.Tag(), phase, false), jc.ErrorIsNil)
+	c.Assert(mig.MinionReport(u0.Tag(), phase, true), jc.ErrorIsNil)
+
+	reports, err := mig.MinionReports()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(reports.Succeeded, jc.SameContents, []names.Tag{m0.Tag(), u0.Tag()})
+	c.Check(reports.Failed, jc.SameContents, []names.Tag{m1.Tag()})
+	c.Check(reports.Unknown, jc.SameContents, []names.Tag{m2.Tag()})
+}
+
+func (s *ModelMigrationSuite) TestMinionReportsOtherPhase(c *gc.C) {
+	factory2 := factory.NewFactory(s.State2)
+	m0 := factory2.MakeMachine(c, nil)
+
+	mig, err := s.State2.CreateModelMigration(s.stdSpec)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(mig.SetPhase(migration.QUIESCE), jc.ErrorIsNil)
+	c.Assert(mig.MinionReport(m0.Tag(), migration.QUIESCE, true), jc.ErrorIsNil)
+
+	// Reports for earlier phases aren't counted.
+	c.Assert(mig.SetPhase(migration.READONLY), jc.ErrorIsNil)
+	reports, err := mig.MinionReports()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(reports.Succeeded, gc.HasLen, 0)
+	c.Check(reports.Failed, gc.HasLen, 0)
+	c.Check(reports.Unknown, jc.SameContents, []names.Tag{m0.Tag()})
+}
+
+func (s *ModelMigrationSuite) TestDuplicateMinionReportsOk(c *gc.C) {
+	mig, err := s.State2.CreateModelMigration(s.stdSpec)
+	c.Assert(err, jc.ErrorIsNil)
+
+	tag := names.NewMachineTag("42")
+	c.Assert(mig.MinionReport(tag, migration.QUIESCE, false), jc.ErrorIsNil)
+	c.Assert(mig.MinionReport(tag, migration.QUIESCE, false), jc.ErrorIsNil)
+}
+
+func (s *ModelMigrationSuite) TestConflictingMinionReports(c *gc.C) {
+	mig, err := s.State2.CreateModelMigration(s.stdSpec)
+	c.Assert(err, jc.ErrorIsNil)
+
+	tag := names.NewMachineTag("42")
+	c.Assert(mig.MinionReport(tag, migration.QUIESCE, true), jc.ErrorIsNil)
+	err = mig.MinionReport(tag, migration.QUIESCE, false)
+	c.Check(err, gc.ErrorMatches, "conflicting reports received for .+/QUIESCE/machine-42")
+}
+
+func (s *ModelMigrationSuite) TestMinionReportNotAgent(c *gc.C) {
+	mig, err := s.State2.CreateModelMigration(s.stdSpec)
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = mig.MinionReport(names.NewUserTag("bob"), migration.QUIESCE, true)
+	c.Check(err, gc.ErrorMatches, "user-bob is not an agent")
+}
+
+func (s *ModelMigrationSuite) TestWatchMinionReports(c *gc.C) {
+	mig, wc := s.createMigAndWatchReports(c, s.State2)
+	wc.AssertOneChange() // initial event
+
+	// A report should trigger the watcher.
+	c.Assert(mig.MinionReport(names.NewMachineTag("0"), migration.QUIESCE, true), jc.ErrorIsNil)
+	wc.AssertOneChange()
+
+	// A report for a different phase shouldn't trigger the watcher.
+	c.Assert(mig.MinionReport(names.NewMachineTag("1"), migration.IMPORT, true), jc.ErrorIsNil)
+	wc.AssertNoChange()
+
+	// A report for a different migration shouldn't trigger the watcher.
+	State3 := s.Factory.MakeModel(c, nil)
+	s.AddCleanup(func(*gc.C) { State3.Close() })
+	mig2, err := State3.CreateModelMigration(s.stdSpec)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(mig2.SetPhase(migration.QUIESCE), jc.ErrorIsNil)
+	c.Assert(mig2.MinionReport(names.NewMachineTag("0"), migration.QUIESCE, true), jc.ErrorIsNil)
+	wc.AssertNoChange()
+}
+
+func (s *ModelMigrationSuite) createMigAndWatchReports(c *gc.C, st *state.State) (
+	state.ModelMigration, statetesting.NotifyWatcherC,
+) {
+	mig, err := st.CreateModelMigration(s.stdSpec)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(mig.SetPhase(migration.QUIESCE), jc.ErrorIsNil)
+
+	w, err := mig.WatchMinionReports()
+	c.Assert(err, jc.ErrorIsNil)
+	s.AddCleanup(func(c *gc.C) { statetesting.AssertStop(c, w) })
+	wc := statetesting.NewNotifyWatcherC(c, st, w)
+	return mig, wc
+}
+
 func (s *ModelMigrationSuite) createStatusWatcher(c *gc.C, st *state.State) (
 	state.NotifyWatcher, statetesting.NotifyWatcherC,
 ) {
@@ -46,10 +46,38 @@ const (
 	// being able to make any changes.
 	ModelReadAccess ModelAccess = "read"
 
+	// ModelWriteAccess allows a user to make changes to a model, such
+	// as deploying and scaling services and running actions, but not
+	// to destroy it or change who may access it.
+	ModelWriteAccess ModelAccess = "write"
+
 	// ModelAdminAccess allows a user full control over the model.
 	ModelAdminAccess ModelAccess = "admin"
 )
 
+// modelAccessLevels orders the model access levels, so that each
+// includes all those below it.
+var modelAccessLevels = map[ModelAccess]int{
+	ModelReadAccess:  1,
+	ModelWriteAccess: 2,
+	ModelAdminAccess: 3,
+}
+
+// Validate returns an error if the access is not a known model access
+// level.
+func (a ModelAccess) Validate() error {
+	if _, ok := modelAccessLevels[a]; !ok {
+		return errors.NotValidf("model access %q", a)
+	}
+	return nil
+}
+
+// EqualOrGreaterThan returns whether the access includes all that is
+// allowed by the other access.
+func (a ModelAccess) EqualOrGreaterThan(other ModelAccess) bool {
+	return modelAccessLevels[a] >= modelAccessLevels[other]
+}
+
 // modelUserLastConnectionDoc is updated by the apiserver whenever the user
 // connects over the API. This update is not done using mgo.txn so the values
 // could well change underneath a normal transaction and as such, it should
@@ -111,9 +139,7 @@ func (e *ModelUser) Access() ModelAccess {
 
 // SetAccess changes the user's access permissions on the model.
 func (e *ModelUser) SetAccess(access ModelAccess) error {
-	switch access {
-	case ModelReadAccess, ModelAdminAccess:
-	default:
+	if err := access.Validate(); err != nil {
 		return errors.Errorf("invalid model access %q", access)
 	}
 	op := txn.Op{
@@ -215,11 +241,29 @@ type ModelUserSpec struct {
 
 // AddModelUser adds a new user to the database.
 func (st *State) AddModelUser(spec ModelUserSpec) (*ModelUser, error) {
+	op, err := st.addModelUserOp(spec)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	err = st.runTransaction([]txn.Op{op})
+	if err == txn.ErrAborted {
+		err = errors.AlreadyExistsf("model user %q", spec.User.Canonical())
+	}
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	// Re-read from DB to get the multi-env updated values.
+	return st.ModelUser(spec.User)
+}
+
+// addModelUserOp returns the operation to add the model user described
+// by the spec, after checking that the users it refers to exist.
+func (st *State) addModelUserOp(spec ModelUserSpec) (txn.Op, error) {
 	// Ensure local user exists in state before adding them as an model user.
 	if spec.User.IsLocal() {
 		localUser, err := st.User(spec.User)
 		if err != nil {
-			return nil, errors.Annotate(err, fmt.Sprintf("user %q does not exist locally", spec.User.Name()))
+			return txn.Op{}, errors.Annotate(err, fmt.Sprintf("user %q does not exist locally", spec.User.Name()))
 		}
 		if spec.DisplayName == "" {
 			spec.DisplayName = localUser.DisplayName()
@@ -229,7 +273,7 @@ func (st *State) AddModelUser(spec ModelUserSpec) (*ModelUser, error) {
 	// Ensure local createdBy user exists.
 	if spec.CreatedBy.IsLocal() {
 		if _, err := st.User(spec.CreatedBy); err != nil {
-			return nil, errors.Annotatef(err, "createdBy user %q does not exist locally", spec.CreatedBy.Name())
+			return txn.Op{}, errors.Annotatef(err, "createdBy user %q does not exist locally", spec.CreatedBy.Name())
 		}
 	}
 
@@ -239,16 +283,7 @@ func (st *State) AddModelUser(spec ModelUserSpec) (*ModelUser, error) {
 	}
 
 	modelUUID := st.ModelUUID()
-	op := createModelUserOp(modelUUID, spec.User, spec.CreatedBy, spec.DisplayName, nowToTheSecond(), spec.Access)
-	err := st.runTransaction([]txn.Op{op})
-	if err == txn.ErrAborted {
-		err = errors.AlreadyExistsf("model user %q", spec.User.Canonical())
-	}
-	if err != nil {
-		return nil, errors.Trace(err)
-	}
-	// Re-read from DB to get the multi-env updated values.
-	return st.ModelUser(spec.User)
+	return createModelUserOp(modelUUID, spec.User, spec.CreatedBy, spec.DisplayName, nowToTheSecond(), spec.Access), nil
 }
 
 // modelUserID returns the document id of the model user
@@ -348,9 +383,17 @@ func (st *State) ModelsForUser(user names.UserTag) ([]*UserModel, error) {
 	return result, nil
 }
 
-// IsControllerAdministrator returns true if the user specified has access to the
+// IsControllerAdministrator returns true if the user specified has
+// superuser access to the controller, or admin access to the
 // controller model (the system model).
 func (st *State) IsControllerAdministrator(user names.UserTag) (bool, error) {
+	access, err := st.ControllerAccess(user)
+	if err == nil && access == ControllerSuperuserAccess {
+		return true, nil
+	} else if err != nil && !errors.IsNotFound(err) {
+		return false, errors.Trace(err)
+	}
+
 	ssinfo, err := st.ControllerInfo()
 	if err != nil {
 		return false, errors.Annotate(err, "could not get controller info")
@@ -102,6 +102,39 @@ func (s *ModelUserSuite) TestSetAccessModelUser(c *gc.C) {
 	c.Assert(modelUser.Access(), gc.Equals, state.ModelReadAccess)
 }
 
+func (s *ModelUserSuite) TestSetAccessWriteModelUser(c *gc.C) {
+	user := s.Factory.MakeUser(c, &factory.UserParams{Name: "validusername", NoModelUser: true})
+	modelUser, err := s.State.AddModelUser(state.ModelUserSpec{
+		User: user.UserTag(), CreatedBy: s.Owner, Access: state.ModelReadAccess})
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = modelUser.SetAccess(state.ModelWriteAccess)
+	c.Assert(err, jc.ErrorIsNil)
+
+	modelUser, err = s.State.ModelUser(user.UserTag())
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(modelUser.ReadOnly(), jc.IsFalse)
+	c.Assert(modelUser.Access(), gc.Equals, state.ModelWriteAccess)
+}
+
+func (s *ModelUserSuite) TestSetAccessInvalid(c *gc.C) {
+	user := s.Factory.MakeUser(c, &factory.UserParams{Name: "validusername", NoModelUser: true})
+	modelUser, err := s.State.AddModelUser(state.ModelUserSpec{
+		User: user.UserTag(), CreatedBy: s.Owner, Access: state.ModelReadAccess})
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = modelUser.SetAccess(state.ModelAccess("owner"))
+	c.Assert(err, gc.ErrorMatches, `invalid model access "owner"`)
+}
+
+func (s *ModelUserSuite) TestModelAccessEqualOrGreaterThan(c *gc.C) {
+	c.Assert(state.ModelAdminAccess.EqualOrGreaterThan(state.ModelWriteAccess), jc.IsTrue)
+	c.Assert(state.ModelWriteAccess.EqualOrGreaterThan(state.ModelWriteAccess), jc.IsTrue)
+	c.Assert(state.ModelWriteAccess.EqualOrGreaterThan(state.ModelReadAccess), jc.IsTrue)
+	c.Assert(state.ModelReadAccess.EqualOrGreaterThan(state.ModelWriteAccess), jc.IsFalse)
+	c.Assert(state.ModelWriteAccess.EqualOrGreaterThan(state.ModelAdminAccess), jc.IsFalse)
+}
+
 func (s *ModelUserSuite) TestCaseUserNameVsId(c *gc.C) {
 	model, err := s.State.Model()
 	c.Assert(err, jc.ErrorIsNil)
@@ -374,6 +407,21 @@ func (s *ModelUserSuite) TestIsControllerAdministrator(c *gc.C) {
 	c.Assert(isAdmin, jc.IsFalse)
 }
 
+func (s *ModelUserSuite) TestIsControllerAdministratorSuperuser(c *gc.C) {
+	user := s.Factory.MakeUser(c, &factory.UserParams{NoModelUser: true})
+	err := s.State.SetControllerAccess(user.UserTag(), state.ControllerAddModelAccess, s.Owner)
+	c.Assert(err, jc.ErrorIsNil)
+	isAdmin, err := s.State.IsControllerAdministrator(user.UserTag())
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(isAdmin, jc.IsFalse)
+
+	err = s.State.SetControllerAccess(user.UserTag(), state.ControllerSuperuserAccess, s.Owner)
+	c.Assert(err, jc.ErrorIsNil)
+	isAdmin, err = s.State.IsControllerAdministrator(user.UserTag())
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(isAdmin, jc.IsTrue)
+}
+
 func (s *ModelUserSuite) TestIsControllerAdministratorFromOtherState(c *gc.C) {
 	user := s.Factory.MakeUser(c, &factory.UserParams{NoModelUser: true})
 
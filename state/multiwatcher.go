@@ -118,6 +118,11 @@ type storeManager struct {
 	// Each entry in the waiting map holds a linked list of Next requests
 	// outstanding for the associated Multiwatcher.
 	waiting map[*Multiwatcher]*request
+
+	// reportedEntries and reportedWaiting hold the sizes of all and
+	// waiting last added to the allwatcher metrics.
+	reportedEntries int
+	reportedWaiting int
 }
 
 // Backing is the interface required by the storeManager to access the
@@ -212,6 +217,7 @@ func (sm *storeManager) loop() error {
 	in := make(chan watcher.Change)
 	sm.backing.Watch(in)
 	defer sm.backing.Unwatch(in)
+	defer sm.reportBacklog(0, 0)
 	// We have no idea what changes the watcher might be trying to
 	// send us while getAll proceeds, but we don't mind, because
 	// storeManager.changed is idempotent with respect to both updates
@@ -221,6 +227,7 @@ func (sm *storeManager) loop() error {
 	if err := sm.backing.GetAll(sm.all); err != nil {
 		return err
 	}
+	sm.reportBacklog(sm.all.list.Len(), len(sm.waiting))
 	for {
 		select {
 		case <-sm.tomb.Dying():
@@ -233,9 +240,20 @@ func (sm *storeManager) loop() error {
 			sm.handle(req)
 		}
 		sm.respond()
+		sm.reportBacklog(sm.all.list.Len(), len(sm.waiting))
 	}
 }
 
+// reportBacklog updates the allwatcher metrics, which are shared by
+// all storeManagers in the process, with the current sizes of this
+// storeManager's store and outstanding requests.
+func (sm *storeManager) reportBacklog(entries, waiting int) {
+	allWatcherEntries.Add(int64(entries - sm.reportedEntries))
+	allWatcherWaiting.Add(int64(waiting - sm.reportedWaiting))
+	sm.reportedEntries = entries
+	sm.reportedWaiting = waiting
+}
+
 // Stop stops the storeManager.
 func (sm *storeManager) Stop() error {
 	sm.tomb.Kill(nil)
@@ -568,3 +568,46 @@ func getOrCreatePorts(st *State, machineID, subnetID string) (*Ports, error) {
 	}
 	return ports, nil
 }
+
+// IngressRules returns the ingress rules for the ports opened on the
+// machine, in all subnets, by units of exposed services. The ports of
+// a service exposed to specific source CIDRs are only opened to those
+// CIDRs. The rules are sorted by network.SortIngressRules.
+func (m *Machine) IngressRules() ([]network.IngressRule, error) {
+	allPorts, err := m.AllPorts()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	services := make(map[string]*Service)
+	seen := make(map[network.IngressRule]bool)
+	var rules []network.IngressRule
+	for _, ports := range allPorts {
+		for portRange, unitName := range ports.AllPortRanges() {
+			serviceName, err := names.UnitService(unitName)
+			if err != nil {
+				return nil, errors.Trace(err)
+			}
+			service, ok := services[serviceName]
+			if !ok {
+				service, err = m.st.Service(serviceName)
+				if errors.IsNotFound(err) {
+					continue
+				} else if err != nil {
+					return nil, errors.Trace(err)
+				}
+				services[serviceName] = service
+			}
+			if !service.IsExposed() {
+				continue
+			}
+			for _, rule := range network.NewIngressRules(portRange, service.ExposedCIDRs()...) {
+				if !seen[rule] {
+					seen[rule] = true
+					rules = append(rules, rule)
+				}
+			}
+		}
+	}
+	network.SortIngressRules(rules)
+	return rules, nil
+}
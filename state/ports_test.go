@@ -443,6 +443,87 @@ func (s *PortsDocSuite) TestWatchPorts(c *gc.C) {
 	wc.AssertNoChange()
 }
 
+func (s *PortsDocSuite) TestMachineIngressRules(c *gc.C) {
+	err := s.portsOnSubnet.OpenPorts(state.PortRange{
+		FromPort: 80,
+		ToPort:   80,
+		UnitName: s.unit1.Name(),
+		Protocol: "tcp",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.portsWithoutSubnet.OpenPorts(state.PortRange{
+		FromPort: 443,
+		ToPort:   443,
+		UnitName: s.unit2.Name(),
+		Protocol: "tcp",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	// No rules while the service is not exposed.
+	rules, err := s.machine.IngressRules()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(rules, gc.HasLen, 0)
+
+	err = s.service.SetExposed()
+	c.Assert(err, jc.ErrorIsNil)
+	rules, err = s.machine.IngressRules()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(rules, jc.DeepEquals, []network.IngressRule{
+		{network.PortRange{80, 80, "tcp"}, "0.0.0.0/0"},
+		{network.PortRange{443, 443, "tcp"}, "0.0.0.0/0"},
+	})
+
+	err = s.service.SetExposedCIDRs([]string{"10.0.0.0/8", "192.168.0.0/16"})
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.service.Refresh()
+	c.Assert(err, jc.ErrorIsNil)
+	rules, err = s.machine.IngressRules()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(rules, jc.DeepEquals, []network.IngressRule{
+		{network.PortRange{80, 80, "tcp"}, "10.0.0.0/8"},
+		{network.PortRange{80, 80, "tcp"}, "192.168.0.0/16"},
+		{network.PortRange{443, 443, "tcp"}, "10.0.0.0/8"},
+		{network.PortRange{443, 443, "tcp"}, "192.168.0.0/16"},
+	})
+}
+
+func (s *PortsDocSuite) TestWatchMachineIngressRules(c *gc.C) {
+	w := s.machine.WatchIngressRules()
+	defer statetesting.AssertStop(c, w)
+	wc := statetesting.NewNotifyWatcherC(c, s.State, w)
+	wc.AssertOneChange()
+
+	// Opening a port on the machine triggers a change.
+	err := s.portsOnSubnet.OpenPorts(state.PortRange{
+		FromPort: 80,
+		ToPort:   80,
+		UnitName: s.unit1.Name(),
+		Protocol: "tcp",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	wc.AssertOneChange()
+
+	// So does exposing the service.
+	err = s.service.SetExposed()
+	c.Assert(err, jc.ErrorIsNil)
+	wc.AssertOneChange()
+
+	// Opening a port on another machine does not.
+	f := factory.NewFactory(s.State)
+	machine := f.MakeMachine(c, &factory.MachineParams{Series: "quantal"})
+	wc.AssertOneChange()
+	ports, err := state.GetOrCreatePorts(s.State, machine.Id(), "")
+	c.Assert(err, jc.ErrorIsNil)
+	err = ports.OpenPorts(state.PortRange{
+		FromPort: 80,
+		ToPort:   80,
+		UnitName: s.unit2.Name(),
+		Protocol: "tcp",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	wc.AssertNoChange()
+}
+
 type PortRangeSuite struct{}
 
 var _ = gc.Suite(&PortRangeSuite{})
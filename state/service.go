@@ -23,6 +23,7 @@ import (
 
 	"github.com/juju/juju/constraints"
 	"github.com/juju/juju/core/leadership"
+	"github.com/juju/juju/network"
 	"github.com/juju/juju/status"
 )
 
@@ -48,6 +49,7 @@ type serviceDoc struct {
 	UnitCount            int        `bson:"unitcount"`
 	RelationCount        int        `bson:"relationcount"`
 	Exposed              bool       `bson:"exposed"`
+	ExposedCIDRs         []string   `bson:"exposed-cidrs,omitempty"`
 	MinUnits             int        `bson:"minunits"`
 	OwnerTag             string     `bson:"ownertag"`
 	TxnRevno             int64      `bson:"txn-revno"`
@@ -271,6 +273,26 @@ func (s *Service) removeOps(asserts bson.D) []txn.Op {
 		removeStatusOp(s.st, s.globalKey()),
 		removeModelServiceRefOp(s.st, s.Name()),
 	}
+	return append(ops, s.removeServiceUsersOps()...)
+}
+
+// removeServiceUsersOps returns the operations required to remove the
+// access granted to users on the service.
+func (s *Service) removeServiceUsersOps() []txn.Op {
+	users, err := s.st.ServiceUsers(s.Name())
+	if err != nil {
+		// Leave the access to be removed once the service has gone.
+		logger.Warningf("cannot read access for service %q: %v", s.Name(), err)
+		return []txn.Op{s.st.newCleanupOp(cleanupServiceUsersForRemovedService, s.Name())}
+	}
+	ops := make([]txn.Op, len(users))
+	for i, user := range users {
+		ops[i] = txn.Op{
+			C:      serviceUsersC,
+			Id:     serviceUserID(s.Name(), user.UserTag()),
+			Remove: true,
+		}
+	}
 	return ops
 }
 
@@ -281,29 +303,59 @@ func (s *Service) IsExposed() bool {
 	return s.doc.Exposed
 }
 
-// SetExposed marks the service as exposed.
+// SetExposed marks the service as exposed to traffic from anywhere.
 // See ClearExposed and IsExposed.
 func (s *Service) SetExposed() error {
-	return s.setExposed(true)
+	return s.setExposed(true, nil)
 }
 
-// ClearExposed removes the exposed flag from the service.
+// SetExposedCIDRs marks the service as exposed only to traffic from
+// the given source CIDRs. If no CIDRs are given, the service is exposed
+// to traffic from anywhere, as with SetExposed.
+// See ClearExposed and ExposedCIDRs.
+func (s *Service) SetExposedCIDRs(cidrs []string) error {
+	for _, cidr := range cidrs {
+		if err := network.ValidateSourceCIDR(cidr); err != nil {
+			return errors.Annotatef(err, "cannot expose service %q", s)
+		}
+	}
+	return s.setExposed(true, cidrs)
+}
+
+// ClearExposed removes the exposed flag, and any source CIDRs, from
+// the service.
 // See SetExposed and IsExposed.
 func (s *Service) ClearExposed() error {
-	return s.setExposed(false)
+	return s.setExposed(false, nil)
+}
+
+// ExposedCIDRs returns the source CIDRs to which the service's open
+// ports are exposed. An empty result for an exposed service means
+// that its ports are exposed to traffic from anywhere.
+func (s *Service) ExposedCIDRs() []string {
+	return s.doc.ExposedCIDRs
 }
 
-func (s *Service) setExposed(exposed bool) (err error) {
+func (s *Service) setExposed(exposed bool, cidrs []string) (err error) {
+	set := bson.D{{"exposed", exposed}}
+	var update bson.D
+	if len(cidrs) == 0 {
+		cidrs = nil
+		update = bson.D{{"$set", set}, {"$unset", bson.D{{"exposed-cidrs", nil}}}}
+	} else {
+		update = bson.D{{"$set", append(set, bson.DocElem{"exposed-cidrs", cidrs})}}
+	}
 	ops := []txn.Op{{
 		C:      servicesC,
 		Id:     s.doc.DocID,
 		Assert: isAliveDoc,
-		Update: bson.D{{"$set", bson.D{{"exposed", exposed}}}},
+		Update: update,
 	}}
 	if err := s.st.runTransaction(ops); err != nil {
 		return fmt.Errorf("cannot set exposed flag for service %q to %v: %v", s, exposed, onAbort(err, errNotAlive))
 	}
 	s.doc.Exposed = exposed
+	s.doc.ExposedCIDRs = cidrs
 	return nil
 }
 
@@ -1021,6 +1073,7 @@ func (s *Service) unitStorageOps(unitName string, cons map[string]StorageConstra
 		s.st, tag, meta, url, cons,
 		s.doc.Series,
 		false, // unit is not assigned yet; don't create machine storage
+		"",    // no snapshot
 	)
 	if err != nil {
 		return nil, -1, errors.Trace(err)
@@ -0,0 +1,305 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state
+
+import (
+	"strings"
+	"time"
+
+	"github.com/juju/errors"
+	"github.com/juju/names"
+	jujutxn "github.com/juju/txn"
+	"gopkg.in/mgo.v2"
+	"gopkg.in/mgo.v2/bson"
+	"gopkg.in/mgo.v2/txn"
+)
+
+// ServiceAccess represents the level of access granted to a user on a
+// single service. Service access is in addition to the user's access
+// to the model: it allows a user with read only access to the model
+// to make changes to the service.
+type ServiceAccess string
+
+const (
+	// ServiceOperateAccess allows a user to change the service's
+	// config, add and remove its units, run actions on its units and
+	// upgrade its charm.
+	ServiceOperateAccess ServiceAccess = "operate"
+)
+
+// Validate returns an error if the access is not a known service
+// access level.
+func (a ServiceAccess) Validate() error {
+	if a != ServiceOperateAccess {
+		return errors.NotValidf("service access %q", a)
+	}
+	return nil
+}
+
+// ServiceUser represents the access a single user has been granted
+// on a single service.
+type ServiceUser struct {
+	doc serviceUserDoc
+}
+
+type serviceUserDoc struct {
+	ID          string        `bson:"_id"`
+	ModelUUID   string        `bson:"model-uuid"`
+	ServiceName string        `bson:"service"`
+	UserName    string        `bson:"user"`
+	Access      ServiceAccess `bson:"access"`
+	CreatedBy   string        `bson:"createdby"`
+	DateCreated time.Time     `bson:"datecreated"`
+
+	// GrantedModelAccess records that the user was given read access
+	// to the model because they had none when they were granted
+	// access to a service. The model access is removed along with the
+	// user's last service access; until then, one of the user's
+	// service access documents records it.
+	GrantedModelAccess bool `bson:"grantedmodelaccess,omitempty"`
+}
+
+// ServiceName returns the name of the service.
+func (u *ServiceUser) ServiceName() string {
+	return u.doc.ServiceName
+}
+
+// UserTag returns the tag of the user.
+func (u *ServiceUser) UserTag() names.UserTag {
+	return names.NewUserTag(u.doc.UserName)
+}
+
+// UserName returns the canonical name of the user.
+func (u *ServiceUser) UserName() string {
+	return u.doc.UserName
+}
+
+// Access returns the access the user has on the service.
+func (u *ServiceUser) Access() ServiceAccess {
+	return u.doc.Access
+}
+
+// CreatedBy returns the name of the user that granted the access.
+func (u *ServiceUser) CreatedBy() string {
+	return u.doc.CreatedBy
+}
+
+// DateCreated returns when the access was granted.
+func (u *ServiceUser) DateCreated() time.Time {
+	return u.doc.DateCreated.UTC()
+}
+
+// GrantedModelAccess returns whether the user was given read access to
+// the model because they had no access to it when they were granted
+// access to the service. Such model access is removed when the user's
+// last access to a service in the model is removed.
+func (u *ServiceUser) GrantedModelAccess() bool {
+	return u.doc.GrantedModelAccess
+}
+
+// serviceUserID returns the local id of the document recording the
+// user's access to the service.
+func serviceUserID(serviceName string, user names.UserTag) string {
+	return serviceName + "#" + strings.ToLower(user.Canonical())
+}
+
+func createServiceUserOp(st *State, serviceName string, user names.UserTag, createdBy string, access ServiceAccess, dateCreated time.Time, grantedModelAccess bool) txn.Op {
+	return txn.Op{
+		C:      serviceUsersC,
+		Id:     serviceUserID(serviceName, user),
+		Assert: txn.DocMissing,
+		Insert: &serviceUserDoc{
+			ID:                 serviceUserID(serviceName, user),
+			ModelUUID:          st.ModelUUID(),
+			ServiceName:        serviceName,
+			UserName:           user.Canonical(),
+			Access:             access,
+			CreatedBy:          createdBy,
+			DateCreated:        dateCreated,
+			GrantedModelAccess: grantedModelAccess,
+		},
+	}
+}
+
+// ServiceAccess returns the access the user has been granted on the
+// service. It returns an error satisfying errors.IsNotFound if the user
+// has not been granted any.
+func (st *State) ServiceAccess(serviceName string, user names.UserTag) (ServiceAccess, error) {
+	serviceUsers, closer := st.getCollection(serviceUsersC)
+	defer closer()
+
+	var doc serviceUserDoc
+	err := serviceUsers.FindId(serviceUserID(serviceName, user)).One(&doc)
+	if err == mgo.ErrNotFound {
+		return "", errors.NotFoundf("service access for %q on %q", user.Canonical(), serviceName)
+	} else if err != nil {
+		return "", errors.Trace(err)
+	}
+	return doc.Access, nil
+}
+
+// SetServiceAccess sets the access the user has on the service,
+// granting it if the user had none. The service must be alive.
+//
+// Users need access to a service's model to use their access to the
+// service, so users with no access to the model are given read access
+// to it. Read access to the model lets the user see all of it, not
+// just the service. The model access is removed again when the user's
+// last service access is removed by RemoveServiceAccess.
+func (st *State) SetServiceAccess(serviceName string, user names.UserTag, access ServiceAccess, createdBy names.UserTag) error {
+	if err := access.Validate(); err != nil {
+		return errors.Trace(err)
+	}
+	buildTxn := func(attempt int) ([]txn.Op, error) {
+		service, err := st.Service(serviceName)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		if service.Life() != Alive {
+			return nil, errors.Errorf("service is not alive")
+		}
+		ops := []txn.Op{{
+			C:      servicesC,
+			Id:     service.doc.DocID,
+			Assert: isAliveDoc,
+		}}
+		current, err := st.ServiceAccess(serviceName, user)
+		if errors.IsNotFound(err) {
+			var grantedModelAccess bool
+			if _, err := st.ModelUser(user); errors.IsNotFound(err) {
+				modelUserOp, err := st.addModelUserOp(ModelUserSpec{
+					User:      user,
+					CreatedBy: createdBy,
+					Access:    ModelReadAccess,
+				})
+				if err != nil {
+					return nil, errors.Trace(err)
+				}
+				ops = append(ops, modelUserOp)
+				grantedModelAccess = true
+			} else if err != nil {
+				return nil, errors.Trace(err)
+			}
+			return append(ops, createServiceUserOp(
+				st, serviceName, user, createdBy.Canonical(), access, nowToTheSecond(), grantedModelAccess,
+			)), nil
+		} else if err != nil {
+			return nil, errors.Trace(err)
+		}
+		if current == access {
+			return nil, jujutxn.ErrNoOperations
+		}
+		return append(ops, txn.Op{
+			C:      serviceUsersC,
+			Id:     serviceUserID(serviceName, user),
+			Assert: txn.DocExists,
+			Update: bson.D{{"$set", bson.D{{"access", access}}}},
+		}), nil
+	}
+	err := st.run(buildTxn)
+	return errors.Annotatef(err, "cannot set access for %q on service %q", user.Canonical(), serviceName)
+}
+
+// RemoveServiceAccess removes all of the user's access to the service.
+// If the user was given read access to the model when they were granted
+// access to a service, and this is the last of their service access,
+// the model access is removed too, provided it is still read access.
+func (st *State) RemoveServiceAccess(serviceName string, user names.UserTag) error {
+	buildTxn := func(attempt int) ([]txn.Op, error) {
+		serviceUsers, err := st.UserServiceAccess(user)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		var removed *ServiceUser
+		var remaining []*ServiceUser
+		for _, serviceUser := range serviceUsers {
+			if serviceUser.ServiceName() == serviceName {
+				removed = serviceUser
+			} else {
+				remaining = append(remaining, serviceUser)
+			}
+		}
+		if removed == nil {
+			return nil, errors.NotFoundf("service access for %q on %q", user.Canonical(), serviceName)
+		}
+		ops := []txn.Op{{
+			C:      serviceUsersC,
+			Id:     serviceUserID(serviceName, user),
+			Assert: txn.DocExists,
+			Remove: true,
+		}}
+		if !removed.GrantedModelAccess() {
+			return ops, nil
+		}
+		if len(remaining) > 0 {
+			// The user still has access to other services, so
+			// they keep the model access; record it on one of them.
+			return append(ops, txn.Op{
+				C:      serviceUsersC,
+				Id:     serviceUserID(remaining[0].ServiceName(), user),
+				Assert: txn.DocExists,
+				Update: bson.D{{"$set", bson.D{{"grantedmodelaccess", true}}}},
+			}), nil
+		}
+		modelUser, err := st.ModelUser(user)
+		if errors.IsNotFound(err) {
+			return ops, nil
+		} else if err != nil {
+			return nil, errors.Trace(err)
+		}
+		if modelUser.Access() != ModelReadAccess {
+			// The user has since been granted more access to
+			// the model, so it is no longer implied by their
+			// service access.
+			return ops, nil
+		}
+		return append(ops, txn.Op{
+			C:      modelUsersC,
+			Id:     modelUserID(user),
+			Assert: bson.D{{"access", ModelReadAccess}},
+			Remove: true,
+		}), nil
+	}
+	return errors.Trace(st.run(buildTxn))
+}
+
+// ServiceUsers returns the access granted to users on the service.
+func (st *State) ServiceUsers(serviceName string) ([]*ServiceUser, error) {
+	return st.serviceUsers(bson.D{{"service", serviceName}})
+}
+
+// UserServiceAccess returns the access the user has been granted on
+// services in the model.
+func (st *State) UserServiceAccess(user names.UserTag) ([]*ServiceUser, error) {
+	return st.serviceUsers(bson.D{{"user", user.Canonical()}})
+}
+
+func (st *State) serviceUsers(sel bson.D) ([]*ServiceUser, error) {
+	serviceUsers, closer := st.getCollection(serviceUsersC)
+	defer closer()
+
+	var docs []serviceUserDoc
+	if err := serviceUsers.Find(sel).All(&docs); err != nil {
+		return nil, errors.Trace(err)
+	}
+	result := make([]*ServiceUser, len(docs))
+	for i, doc := range docs {
+		result[i] = &ServiceUser{doc: doc}
+	}
+	return result, nil
+}
+
+// cleanupServiceUsers removes the access granted on a service that
+// has been removed.
+func (st *State) cleanupServiceUsers(serviceName string) error {
+	serviceUsers, closer := st.getCollection(serviceUsersC)
+	defer closer()
+	// The service is gone, so nothing refers to these documents, and
+	// they are safe to delete directly.
+	serviceUsersW := serviceUsers.Writeable()
+	if _, err := serviceUsersW.RemoveAll(bson.D{{"service", serviceName}}); err != nil {
+		return errors.Annotatef(err, "cannot remove access for service %q", serviceName)
+	}
+	return nil
+}
@@ -0,0 +1,189 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state_test
+
+import (
+	"github.com/juju/errors"
+	"github.com/juju/names"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/state"
+	"github.com/juju/juju/testing/factory"
+)
+
+type ServiceUserSuite struct {
+	ConnSuite
+	service *state.Service
+	user    names.UserTag
+}
+
+var _ = gc.Suite(&ServiceUserSuite{})
+
+func (s *ServiceUserSuite) SetUpTest(c *gc.C) {
+	s.ConnSuite.SetUpTest(c)
+	s.service = s.Factory.MakeService(c, &factory.ServiceParams{Name: "wordpress"})
+	s.user = names.NewUserTag("bob@remote")
+}
+
+func (s *ServiceUserSuite) TestNoAccess(c *gc.C) {
+	_, err := s.State.ServiceAccess("wordpress", s.user)
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+	c.Assert(err, gc.ErrorMatches, `service access for "bob@remote" on "wordpress" not found`)
+}
+
+func (s *ServiceUserSuite) TestSetServiceAccess(c *gc.C) {
+	err := s.State.SetServiceAccess("wordpress", s.user, state.ServiceOperateAccess, s.Owner)
+	c.Assert(err, jc.ErrorIsNil)
+	access, err := s.State.ServiceAccess("wordpress", s.user)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(access, gc.Equals, state.ServiceOperateAccess)
+
+	// Setting the same access again is a no-op.
+	err = s.State.SetServiceAccess("wordpress", s.user, state.ServiceOperateAccess, s.Owner)
+	c.Assert(err, jc.ErrorIsNil)
+
+	users, err := s.State.ServiceUsers("wordpress")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(users, gc.HasLen, 1)
+	c.Check(users[0].ServiceName(), gc.Equals, "wordpress")
+	c.Check(users[0].UserTag(), gc.Equals, s.user)
+	c.Check(users[0].Access(), gc.Equals, state.ServiceOperateAccess)
+	c.Check(users[0].CreatedBy(), gc.Equals, s.Owner.Canonical())
+}
+
+func (s *ServiceUserSuite) TestSetServiceAccessGrantsModelAccess(c *gc.C) {
+	err := s.State.SetServiceAccess("wordpress", s.user, state.ServiceOperateAccess, s.Owner)
+	c.Assert(err, jc.ErrorIsNil)
+
+	modelUser, err := s.State.ModelUser(s.user)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(modelUser.Access(), gc.Equals, state.ModelReadAccess)
+	c.Assert(modelUser.CreatedBy(), gc.Equals, s.Owner.Canonical())
+	users, err := s.State.ServiceUsers("wordpress")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(users, gc.HasLen, 1)
+	c.Assert(users[0].GrantedModelAccess(), jc.IsTrue)
+}
+
+func (s *ServiceUserSuite) TestSetServiceAccessExistingModelAccess(c *gc.C) {
+	_, err := s.State.AddModelUser(state.ModelUserSpec{
+		User:      s.user,
+		CreatedBy: s.Owner,
+		Access:    state.ModelReadAccess,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.State.SetServiceAccess("wordpress", s.user, state.ServiceOperateAccess, s.Owner)
+	c.Assert(err, jc.ErrorIsNil)
+
+	users, err := s.State.ServiceUsers("wordpress")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(users, gc.HasLen, 1)
+	c.Assert(users[0].GrantedModelAccess(), jc.IsFalse)
+
+	// The model access was not granted with the service access, so
+	// it is kept when the service access is removed.
+	err = s.State.RemoveServiceAccess("wordpress", s.user)
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = s.State.ModelUser(s.user)
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *ServiceUserSuite) TestSetServiceAccessInvalid(c *gc.C) {
+	err := s.State.SetServiceAccess("wordpress", s.user, state.ServiceAccess("admin"), s.Owner)
+	c.Assert(err, jc.Satisfies, errors.IsNotValid)
+	c.Assert(err, gc.ErrorMatches, `service access "admin" not valid`)
+}
+
+func (s *ServiceUserSuite) TestSetServiceAccessServiceNotFound(c *gc.C) {
+	err := s.State.SetServiceAccess("mysql", s.user, state.ServiceOperateAccess, s.Owner)
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+}
+
+func (s *ServiceUserSuite) TestUserServiceAccess(c *gc.C) {
+	s.Factory.MakeService(c, &factory.ServiceParams{
+		Name:  "mysql",
+		Charm: s.Factory.MakeCharm(c, &factory.CharmParams{Name: "mysql"}),
+	})
+	err := s.State.SetServiceAccess("wordpress", s.user, state.ServiceOperateAccess, s.Owner)
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.State.SetServiceAccess("mysql", s.user, state.ServiceOperateAccess, s.Owner)
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.State.SetServiceAccess("mysql", names.NewUserTag("mary@remote"), state.ServiceOperateAccess, s.Owner)
+	c.Assert(err, jc.ErrorIsNil)
+
+	users, err := s.State.UserServiceAccess(s.user)
+	c.Assert(err, jc.ErrorIsNil)
+	access := make(map[string]state.ServiceAccess)
+	for _, user := range users {
+		access[user.ServiceName()] = user.Access()
+	}
+	c.Assert(access, jc.DeepEquals, map[string]state.ServiceAccess{
+		"wordpress": state.ServiceOperateAccess,
+		"mysql":     state.ServiceOperateAccess,
+	})
+}
+
+func (s *ServiceUserSuite) TestRemoveServiceAccess(c *gc.C) {
+	err := s.State.SetServiceAccess("wordpress", s.user, state.ServiceOperateAccess, s.Owner)
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.State.RemoveServiceAccess("wordpress", s.user)
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = s.State.ServiceAccess("wordpress", s.user)
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+
+	err = s.State.RemoveServiceAccess("wordpress", s.user)
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+
+	// The model access given with the service access is gone too.
+	_, err = s.State.ModelUser(s.user)
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+}
+
+func (s *ServiceUserSuite) TestRemoveServiceAccessKeepsModelAccessForOtherServices(c *gc.C) {
+	s.Factory.MakeService(c, &factory.ServiceParams{
+		Name:  "mysql",
+		Charm: s.Factory.MakeCharm(c, &factory.CharmParams{Name: "mysql"}),
+	})
+	err := s.State.SetServiceAccess("wordpress", s.user, state.ServiceOperateAccess, s.Owner)
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.State.SetServiceAccess("mysql", s.user, state.ServiceOperateAccess, s.Owner)
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = s.State.RemoveServiceAccess("wordpress", s.user)
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = s.State.ModelUser(s.user)
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = s.State.RemoveServiceAccess("mysql", s.user)
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = s.State.ModelUser(s.user)
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+}
+
+func (s *ServiceUserSuite) TestRemoveServiceAccessKeepsGreaterModelAccess(c *gc.C) {
+	err := s.State.SetServiceAccess("wordpress", s.user, state.ServiceOperateAccess, s.Owner)
+	c.Assert(err, jc.ErrorIsNil)
+	modelUser, err := s.State.ModelUser(s.user)
+	c.Assert(err, jc.ErrorIsNil)
+	err = modelUser.SetAccess(state.ModelWriteAccess)
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = s.State.RemoveServiceAccess("wordpress", s.user)
+	c.Assert(err, jc.ErrorIsNil)
+	modelUser, err = s.State.ModelUser(s.user)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(modelUser.Access(), gc.Equals, state.ModelWriteAccess)
+}
+
+func (s *ServiceUserSuite) TestRemoveServiceRemovesAccess(c *gc.C) {
+	err := s.State.SetServiceAccess("wordpress", s.user, state.ServiceOperateAccess, s.Owner)
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.service.Destroy()
+	c.Assert(err, jc.ErrorIsNil)
+
+	users, err := s.State.ServiceUsers("wordpress")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(users, gc.HasLen, 0)
+}
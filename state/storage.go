@@ -35,8 +35,10 @@ type StorageInstance interface {
 	Kind() StorageKind
 
 	// Owner returns the tag of the service or unit that owns this storage
-	// instance.
-	Owner() names.Tag
+	// instance, and a boolean indicating whether or not there is an owner.
+	// Storage that has been detached from its unit has no owner until it
+	// is attached to another.
+	Owner() (names.Tag, bool)
 
 	// StorageName returns the name of the storage, as defined in the charm
 	// storage metadata. This does not uniquely identify storage instances,
@@ -76,6 +78,31 @@ const (
 	StorageKindFilesystem
 )
 
+// String returns a human readable name for the storage kind.
+func (k StorageKind) String() string {
+	switch k {
+	case StorageKindBlock:
+		return "block"
+	case StorageKindFilesystem:
+		return "filesystem"
+	default:
+		return "unknown"
+	}
+}
+
+// parseStorageKind returns the storage kind matching the specified
+// name, as returned by StorageKind.String.
+func parseStorageKind(kind string) StorageKind {
+	switch kind {
+	case "block":
+		return StorageKindBlock
+	case "filesystem":
+		return StorageKindFilesystem
+	default:
+		return StorageKindUnknown
+	}
+}
+
 type storageInstance struct {
 	st  *State
 	doc storageInstanceDoc
@@ -93,14 +120,17 @@ func (s *storageInstance) Kind() StorageKind {
 	return s.doc.Kind
 }
 
-func (s *storageInstance) Owner() names.Tag {
+func (s *storageInstance) Owner() (names.Tag, bool) {
+	if s.doc.Owner == "" {
+		return nil, false
+	}
 	tag, err := names.ParseTag(s.doc.Owner)
 	if err != nil {
 		// This should be impossible; we do not expose
 		// a means of modifying the owner tag.
 		panic(err)
 	}
-	return tag
+	return tag, true
 }
 
 func (s *storageInstance) StorageName() string {
@@ -124,10 +154,14 @@ type storageInstanceDoc struct {
 	Id              string      `bson:"id"`
 	Kind            StorageKind `bson:"storagekind"`
 	Life            Life        `bson:"life"`
-	Owner           string      `bson:"owner"`
+	Owner           string      `bson:"owner"` // empty if detached
 	StorageName     string      `bson:"storagename"`
 	AttachmentCount int         `bson:"attachmentcount"`
 	CharmURL        *charm.URL  `bson:"charmurl"`
+
+	// Snapshot is the ID of the volume snapshot from which
+	// the storage instance's volume is to be created, if any.
+	Snapshot string `bson:"snapshot,omitempty"`
 }
 
 type storageAttachment struct {
@@ -330,6 +364,9 @@ func removeStorageInstanceOps(
 // instances to be created, keyed on the storage name. These constraints
 // will be correlated with the charm storage metadata for validation
 // and supplementing.
+//
+// If the snapshot ID is non-empty, the volumes for the storage
+// instances will be created from the volume snapshot with that ID.
 func createStorageOps(
 	st *State,
 	entity names.Tag,
@@ -338,6 +375,7 @@ func createStorageOps(
 	cons map[string]StorageConstraints,
 	series string,
 	machineOpsNeeded bool,
+	snapshotId string,
 ) (ops []txn.Op, numStorageAttachments int, err error) {
 
 	type template struct {
@@ -404,6 +442,7 @@ func createStorageOps(
 				Owner:       owner,
 				StorageName: t.storageName,
 				CharmURL:    curl,
+				Snapshot:    snapshotId,
 			}
 			if unit, ok := entity.(names.UnitTag); ok {
 				doc.AttachmentCount = 1
@@ -688,6 +727,16 @@ func removeStorageAttachmentOps(
 			return ops, nil
 		}
 	}
+	if si.doc.Owner == "" && si.doc.Life == Alive {
+		// The storage has been detached from the unit, and will
+		// outlive it; detach its volume or filesystem from the
+		// unit's machine as well, so it may be attached elsewhere.
+		detachOps, err := detachUnitMachineStorageOps(st, si.StorageTag(), names.NewUnitTag(s.doc.Unit))
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		ops = append(ops, detachOps...)
+	}
 	decrefOp := txn.Op{
 		C:      storageInstancesC,
 		Id:     si.doc.Id,
@@ -715,6 +764,364 @@ func removeStorageAttachmentOps(
 	return ops, nil
 }
 
+// DetachStorage ensures that the storage instance will be detached from the
+// unit at some point, without being destroyed. The unit gives up ownership
+// of the storage, which may then outlive the unit and later be attached to
+// another unit with AttachStorage.
+//
+// The storage attachment is marked Dying, so that the unit's
+// storage-detaching hook runs before it is removed; when it is removed,
+// the storage's volume or filesystem is detached from the unit's machine.
+// Only storage owned by the unit, and backed by storage that will outlive
+// the machine, may be detached.
+func (st *State) DetachStorage(storage names.StorageTag, unit names.UnitTag) (err error) {
+	defer errors.DeferredAnnotatef(&err, "cannot detach storage %s from unit %s", storage.Id(), unit.Id())
+	buildTxn := func(attempt int) ([]txn.Op, error) {
+		si, err := st.storageInstance(storage)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		if si.doc.Owner == "" {
+			// The storage has already been detached.
+			return nil, jujutxn.ErrNoOperations
+		}
+		ops, err := st.detachStorageOps(si, unit)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		if err := st.validateUnitStorageDetach(unit, si); err != nil {
+			return nil, errors.Trace(err)
+		}
+		return ops, nil
+	}
+	return st.run(buildTxn)
+}
+
+// DetachUnitStorage detaches all of the storage owned by the unit, as
+// DetachStorage does, but without requiring that the unit keep the storage
+// its charm requires. It is intended for removing a unit while keeping its
+// storage; no storage is detached unless all of it may be.
+func (st *State) DetachUnitStorage(unit names.UnitTag) (err error) {
+	defer errors.DeferredAnnotatef(&err, "cannot detach storage from unit %s", unit.Id())
+	buildTxn := func(attempt int) ([]txn.Op, error) {
+		coll, closer := st.getCollection(storageInstancesC)
+		defer closer()
+
+		var docs []storageInstanceDoc
+		if err := coll.Find(bson.D{{"owner", unit.String()}}).All(&docs); err != nil {
+			return nil, errors.Annotatef(err, "cannot get storage instances for unit %s", unit.Id())
+		}
+		var ops []txn.Op
+		for _, doc := range docs {
+			detachOps, err := st.detachStorageOps(&storageInstance{st, doc}, unit)
+			if err != nil {
+				return nil, errors.Annotatef(err, "storage %s", doc.Id)
+			}
+			ops = append(ops, detachOps...)
+		}
+		if len(ops) == 0 {
+			return nil, jujutxn.ErrNoOperations
+		}
+		return ops, nil
+	}
+	return st.run(buildTxn)
+}
+
+// detachStorageOps returns the operations to release the unit's ownership
+// of the storage instance, and destroy its attachment to the unit.
+func (st *State) detachStorageOps(si *storageInstance, unit names.UnitTag) ([]txn.Op, error) {
+	if si.doc.Life != Alive {
+		return nil, errors.New("storage is not alive")
+	}
+	if si.doc.Owner != unit.String() {
+		return nil, errors.Errorf("storage is not owned by unit %s", unit.Id())
+	}
+	s, err := st.storageAttachment(si.StorageTag(), unit)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if err := validateStorageDetachable(st, si.StorageTag()); err != nil {
+		return nil, errors.Trace(err)
+	}
+	ops := []txn.Op{{
+		C:      storageInstancesC,
+		Id:     si.doc.Id,
+		Assert: bson.D{{"owner", unit.String()}, {"life", Alive}},
+		Update: bson.D{{"$set", bson.D{{"owner", ""}}}},
+	}}
+	if s.doc.Life == Alive {
+		ops = append(ops, destroyStorageAttachmentOps(si.StorageTag(), unit)...)
+	}
+	return ops, nil
+}
+
+// validateStorageDetachable returns an error if the volume or filesystem
+// backing the storage instance would not outlive the machine it is
+// attached to, and so cannot be detached from it.
+func validateStorageDetachable(st *State, storage names.StorageTag) error {
+	volume, err := st.storageInstanceVolume(storage)
+	if errors.IsNotFound(err) {
+		if _, err := st.storageInstanceFilesystem(storage); err == nil {
+			// Filesystems that are not backed by volumes
+			// are always bound to their machine.
+			return errors.New("filesystem is bound to its machine")
+		} else if !errors.IsNotFound(err) {
+			return errors.Trace(err)
+		}
+		// The storage has not been assigned to a machine yet,
+		// so there is nothing that could be lost.
+		return nil
+	} else if err != nil {
+		return errors.Trace(err)
+	}
+	info, err := volume.Info()
+	if errors.IsNotProvisioned(err) {
+		return errors.Errorf("volume %s is not yet provisioned", volume.Tag().Id())
+	} else if err != nil {
+		return errors.Trace(err)
+	}
+	if !info.Persistent {
+		return errors.Errorf("volume %s is not persistent", volume.Tag().Id())
+	}
+	return nil
+}
+
+// validateUnitStorageDetach returns an error if detaching the storage
+// instance would leave the unit with fewer instances of the storage than
+// its charm requires. Storage may always be detached from units that are
+// no longer alive.
+func (st *State) validateUnitStorageDetach(unit names.UnitTag, si *storageInstance) error {
+	u, err := st.Unit(unit.Id())
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if u.Life() != Alive {
+		return nil
+	}
+	s, err := u.Service()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	ch, _, err := s.Charm()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	charmStorage, ok := ch.Meta().Storage[si.doc.StorageName]
+	if !ok {
+		return nil
+	}
+	count, err := st.countEntityStorageInstancesForName(unit, si.doc.StorageName)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if int(count)-1 < charmStorage.CountMin {
+		return errors.Errorf(
+			"charm requires at least %d %q storage instances",
+			charmStorage.CountMin, si.doc.StorageName,
+		)
+	}
+	return nil
+}
+
+// detachUnitMachineStorageOps returns the operations to detach the volume
+// or filesystem of the storage instance from the machine the unit is
+// assigned to, if any.
+func detachUnitMachineStorageOps(st *State, storage names.StorageTag, unit names.UnitTag) ([]txn.Op, error) {
+	u, err := st.Unit(unit.Id())
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	machineId, err := u.AssignedMachineId()
+	if errors.IsNotAssigned(err) {
+		return nil, nil
+	} else if err != nil {
+		return nil, errors.Trace(err)
+	}
+	machine := names.NewMachineTag(machineId)
+
+	filesystem, err := st.storageInstanceFilesystem(storage)
+	if err == nil {
+		// A volume backing the filesystem is detached when the
+		// filesystem attachment is removed.
+		attachment, err := st.FilesystemAttachment(machine, filesystem.FilesystemTag())
+		if errors.IsNotFound(err) {
+			return nil, nil
+		} else if err != nil {
+			return nil, errors.Trace(err)
+		}
+		if attachment.Life() != Alive {
+			return nil, nil
+		}
+		return detachFilesystemOps(machine, filesystem.FilesystemTag()), nil
+	} else if !errors.IsNotFound(err) {
+		return nil, errors.Trace(err)
+	}
+
+	volume, err := st.storageInstanceVolume(storage)
+	if errors.IsNotFound(err) {
+		return nil, nil
+	} else if err != nil {
+		return nil, errors.Trace(err)
+	}
+	attachment, err := st.VolumeAttachment(machine, volume.VolumeTag())
+	if errors.IsNotFound(err) {
+		return nil, nil
+	} else if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if attachment.Life() != Alive {
+		return nil, nil
+	}
+	return detachVolumeOps(machine, volume.VolumeTag()), nil
+}
+
+// AttachStorage attaches storage that has been detached from its unit to
+// the specified unit, which takes ownership of it. The storage must be
+// for storage of the same name and kind in the unit's charm, and its
+// volume or filesystem must have been detached from its previous machine.
+// If the unit is assigned to a machine, the storage will be attached to
+// that machine.
+func (st *State) AttachStorage(storage names.StorageTag, unit names.UnitTag) (err error) {
+	defer errors.DeferredAnnotatef(&err, "cannot attach storage %s to unit %s", storage.Id(), unit.Id())
+	buildTxn := func(attempt int) ([]txn.Op, error) {
+		si, err := st.storageInstance(storage)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		if si.doc.Life != Alive {
+			return nil, errors.New("storage is not alive")
+		}
+		if si.doc.Owner != "" {
+			if si.doc.Owner == unit.String() {
+				return nil, jujutxn.ErrNoOperations
+			}
+			owner, _ := si.Owner()
+			return nil, errors.Errorf("storage is owned by %s", names.ReadableString(owner))
+		}
+		if si.doc.AttachmentCount != 0 {
+			return nil, errors.New("storage is still being detached")
+		}
+		if err := validateStorageMachineDetached(st, storage); err != nil {
+			return nil, errors.Trace(err)
+		}
+
+		u, err := st.Unit(unit.Id())
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		if u.Life() != Alive {
+			return nil, unitNotAliveErr
+		}
+		s, err := u.Service()
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		ch, _, err := s.Charm()
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		if err := st.validateUnitStorageAttach(ch.Meta(), unit, si); err != nil {
+			return nil, errors.Trace(err)
+		}
+
+		ops := []txn.Op{{
+			C:  storageInstancesC,
+			Id: si.doc.Id,
+			Assert: bson.D{
+				{"owner", ""},
+				{"life", Alive},
+				{"attachmentcount", 0},
+			},
+			Update: bson.D{
+				{"$set", bson.D{{"owner", unit.String()}}},
+				{"$inc", bson.D{{"attachmentcount", 1}}},
+			},
+		}, createStorageAttachmentOp(storage, unit), {
+			C:      unitsC,
+			Id:     u.doc.DocID,
+			Assert: isAliveDoc,
+			Update: bson.D{{"$inc", bson.D{{"storageattachmentcount", 1}}}},
+		}}
+
+		// If the unit is already assigned to a machine, attach
+		// the storage's volume or filesystem to the machine.
+		cons, err := u.StorageConstraints()
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		owned := &storageInstance{st, si.doc}
+		owned.doc.Owner = unit.String()
+		machineOps, err := unitAssignedMachineStorageOps(
+			st, unit, ch.Meta(), cons, u.Series(), owned,
+		)
+		if err == nil {
+			ops = append(ops, machineOps...)
+		} else if !errors.IsNotAssigned(err) {
+			return nil, errors.Trace(err)
+		}
+		return ops, nil
+	}
+	return st.run(buildTxn)
+}
+
+// validateUnitStorageAttach returns an error if the storage instance is
+// not compatible with the charm's storage of the same name, or if
+// attaching it would give the unit more instances of the storage than
+// the charm allows.
+func (st *State) validateUnitStorageAttach(charmMeta *charm.Meta, unit names.UnitTag, si *storageInstance) error {
+	charmStorage, ok := charmMeta.Storage[si.doc.StorageName]
+	if !ok {
+		return errors.NotFoundf("charm storage %q", si.doc.StorageName)
+	}
+	if charmStorage.Shared {
+		return errors.Errorf("charm storage %q is shared", si.doc.StorageName)
+	}
+	var kind StorageKind
+	switch charmStorage.Type {
+	case charm.StorageBlock:
+		kind = StorageKindBlock
+	case charm.StorageFilesystem:
+		kind = StorageKindFilesystem
+	}
+	if kind != si.doc.Kind {
+		return errors.Errorf(
+			"storage kind %q does not match charm storage kind %q",
+			si.doc.Kind, charmStorage.Type,
+		)
+	}
+	count, err := st.countEntityStorageInstancesForName(unit, si.doc.StorageName)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if charmStorage.CountMax >= 0 && int(count)+1 > charmStorage.CountMax {
+		return errors.Errorf(
+			"charm allows at most %d %q storage instances",
+			charmStorage.CountMax, si.doc.StorageName,
+		)
+	}
+	return nil
+}
+
+// validateStorageMachineDetached returns an error if the volume or
+// filesystem backing the storage instance is still attached to a machine.
+func validateStorageMachineDetached(st *State, storage names.StorageTag) error {
+	if filesystem, err := st.storageInstanceFilesystem(storage); err == nil {
+		if filesystem.doc.AttachmentCount > 0 {
+			return errors.New("filesystem is still attached to a machine")
+		}
+	} else if !errors.IsNotFound(err) {
+		return errors.Trace(err)
+	}
+	if volume, err := st.storageInstanceVolume(storage); err == nil {
+		if volume.doc.AttachmentCount > 0 {
+			return errors.New("volume is still attached to a machine")
+		}
+	} else if !errors.IsNotFound(err) {
+		return errors.Trace(err)
+	}
+	return nil
+}
+
 // removeStorageInstancesOps returns the transaction operations to remove all
 // storage instances owned by the specified entity.
 func removeStorageInstancesOps(st *State, owner names.Tag) ([]txn.Op, error) {
@@ -1083,13 +1490,46 @@ func (st *State) AddStorageForUnit(
 		return errors.Annotatef(err, "getting charm for unit %q", u.Tag().Id())
 	}
 
-	return st.addStorageForUnit(ch, u, name, cons)
+	return st.addStorageForUnit(ch, u, name, cons, "")
+}
+
+// AddStorageForUnitFromSnapshot adds storage instances to the given
+// unit as AddStorageForUnit does, creating the volumes for the storage
+// instances from the specified volume snapshot. The storage is created
+// in the pool that the snapshotted volume was created in, and is at
+// least as large as the snapshotted volume.
+func (st *State) AddStorageForUnitFromSnapshot(
+	tag names.UnitTag, name string, cons StorageConstraints, snapshotId string,
+) error {
+	snapshot, err := st.volumeSnapshotForStorage(snapshotId, cons)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	cons.Pool = snapshot.Pool()
+	if cons.Size < snapshot.Size() {
+		cons.Size = snapshot.Size()
+	}
+
+	u, err := st.Unit(tag.Id())
+	if err != nil {
+		return errors.Trace(err)
+	}
+	s, err := u.Service()
+	if err != nil {
+		return errors.Annotatef(err, "getting service for unit %v", u.Tag().Id())
+	}
+	ch, _, err := s.Charm()
+	if err != nil {
+		return errors.Annotatef(err, "getting charm for unit %q", u.Tag().Id())
+	}
+	return st.addStorageForUnit(ch, u, name, cons, snapshot.Id())
 }
 
 // addStorage adds storage instances to given unit as specified.
 func (st *State) addStorageForUnit(
 	ch *Charm, u *Unit,
 	name string, cons StorageConstraints,
+	snapshotId string,
 ) error {
 	all, err := u.StorageConstraints()
 	if err != nil {
@@ -1136,7 +1576,7 @@ func (st *State) addStorageForUnit(
 		if err != nil {
 			return nil, errors.Trace(err)
 		}
-		ops, err := st.constructAddUnitStorageOps(ch, u, name, completeCons)
+		ops, err := st.constructAddUnitStorageOps(ch, u, name, completeCons, snapshotId)
 		if err != nil {
 			return nil, errors.Trace(err)
 		}
@@ -1172,7 +1612,7 @@ func (st *State) validateUnitStorage(
 }
 
 func (st *State) constructAddUnitStorageOps(
-	ch *Charm, u *Unit, name string, cons StorageConstraints,
+	ch *Charm, u *Unit, name string, cons StorageConstraints, snapshotId string,
 ) ([]txn.Op, error) {
 	// Create storage db operations
 	storageOps, _, err := createStorageOps(
@@ -1183,6 +1623,7 @@ func (st *State) constructAddUnitStorageOps(
 		map[string]StorageConstraints{name: cons},
 		u.Series(),
 		true, // create machine storage
+		snapshotId,
 	)
 	if err != nil {
 		return nil, errors.Trace(err)
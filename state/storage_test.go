@@ -579,7 +579,9 @@ func (s *StorageStateSuite) TestAllStorageInstances(c *gc.C) {
 	for _, one := range all {
 		c.Assert(one.Kind(), gc.DeepEquals, state.StorageKindBlock)
 		c.Assert(nameSet.Contains(one.StorageName()), jc.IsTrue)
-		c.Assert(ownerSet.Contains(one.Owner().String()), jc.IsTrue)
+		owner, ok := one.Owner()
+		c.Assert(ok, jc.IsTrue)
+		c.Assert(ownerSet.Contains(owner.String()), jc.IsTrue)
 	}
 }
 
@@ -828,6 +830,173 @@ func (s *StorageStateSuite) TestDestroyUnitStorageAttachments(c *gc.C) {
 	c.Assert(err, jc.ErrorIsNil)
 }
 
+// setupDetachableStorage adds a service with two units, each of which
+// has persistent "data" and "allecto" storage. The "allecto" storage is
+// optional, so it may be detached from units that are alive.
+func (s *StorageStateSuite) setupDetachableStorage(c *gc.C) (*state.Unit, *state.Unit) {
+	ch := s.AddTestingCharm(c, "storage-block")
+	storage := map[string]state.StorageConstraints{
+		"data":    makeStorageCons("persistent-block", 1024, 1),
+		"allecto": makeStorageCons("persistent-block", 1024, 1),
+	}
+	service := s.AddTestingServiceWithStorage(c, "storage-block", ch, storage)
+	u0, err := service.AddUnit()
+	c.Assert(err, jc.ErrorIsNil)
+	u1, err := service.AddUnit()
+	c.Assert(err, jc.ErrorIsNil)
+	return u0, u1
+}
+
+func (s *StorageStateSuite) TestDetachAttachStorage(c *gc.C) {
+	u0, u1 := s.setupDetachableStorage(c)
+	storageTag := names.NewStorageTag("allecto/0")
+
+	err := s.State.DetachStorage(storageTag, u0.UnitTag())
+	c.Assert(err, jc.ErrorIsNil)
+	si, err := s.State.StorageInstance(storageTag)
+	c.Assert(err, jc.ErrorIsNil)
+	_, ok := si.Owner()
+	c.Assert(ok, jc.IsFalse)
+	attachment, err := s.State.StorageAttachment(storageTag, u0.UnitTag())
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(attachment.Life(), gc.Equals, state.Dying)
+
+	// Detaching again is a no-op.
+	err = s.State.DetachStorage(storageTag, u0.UnitTag())
+	c.Assert(err, jc.ErrorIsNil)
+
+	// The storage outlives its attachment to the unit.
+	err = s.State.RemoveStorageAttachment(storageTag, u0.UnitTag())
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.storageInstanceExists(c, storageTag), jc.IsTrue)
+
+	err = s.State.AttachStorage(storageTag, u1.UnitTag())
+	c.Assert(err, jc.ErrorIsNil)
+	si, err = s.State.StorageInstance(storageTag)
+	c.Assert(err, jc.ErrorIsNil)
+	owner, ok := si.Owner()
+	c.Assert(ok, jc.IsTrue)
+	c.Assert(owner, gc.Equals, u1.Tag())
+	attachment, err = s.State.StorageAttachment(storageTag, u1.UnitTag())
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(attachment.Life(), gc.Equals, state.Alive)
+}
+
+func (s *StorageStateSuite) TestDetachStorageRequiredByCharm(c *gc.C) {
+	u0, _ := s.setupDetachableStorage(c)
+	err := s.State.DetachStorage(names.NewStorageTag("data/1"), u0.UnitTag())
+	c.Assert(err, gc.ErrorMatches, `cannot detach storage data/1 from unit storage-block/0: charm requires at least 1 "data" storage instances`)
+}
+
+func (s *StorageStateSuite) TestDetachStorageNotOwned(c *gc.C) {
+	u0, _ := s.setupDetachableStorage(c)
+	err := s.State.DetachStorage(names.NewStorageTag("allecto/2"), u0.UnitTag())
+	c.Assert(err, gc.ErrorMatches, `cannot detach storage allecto/2 from unit storage-block/0: storage is not owned by unit storage-block/0`)
+}
+
+func (s *StorageStateSuite) TestDetachStorageNotPersistent(c *gc.C) {
+	_, u, storageTag := s.setupSingleStorage(c, "block", "loop-pool")
+	err := s.State.AssignUnit(u, state.AssignCleanEmpty)
+	c.Assert(err, jc.ErrorIsNil)
+	volume := s.storageInstanceVolume(c, storageTag)
+
+	err = s.State.DetachUnitStorage(u.UnitTag())
+	c.Assert(err, gc.ErrorMatches, `cannot detach storage from unit storage-block/0: storage data/0: volume .* is not yet provisioned`)
+
+	err = s.State.SetVolumeInfo(volume.VolumeTag(), state.VolumeInfo{VolumeId: "vol-123", Size: 1024})
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.State.DetachUnitStorage(u.UnitTag())
+	c.Assert(err, gc.ErrorMatches, `cannot detach storage from unit storage-block/0: storage data/0: volume .* is not persistent`)
+}
+
+func (s *StorageStateSuite) TestDetachUnitStorage(c *gc.C) {
+	u0, _ := s.setupDetachableStorage(c)
+	err := s.State.DetachUnitStorage(u0.UnitTag())
+	c.Assert(err, jc.ErrorIsNil)
+
+	attachments, err := s.State.UnitStorageAttachments(u0.UnitTag())
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(attachments, gc.HasLen, 2)
+	for _, a := range attachments {
+		c.Assert(a.Life(), gc.Equals, state.Dying)
+		si, err := s.State.StorageInstance(a.StorageInstance())
+		c.Assert(err, jc.ErrorIsNil)
+		_, ok := si.Owner()
+		c.Assert(ok, jc.IsFalse)
+	}
+}
+
+func (s *StorageStateSuite) TestAttachStorageStillDetaching(c *gc.C) {
+	u0, u1 := s.setupDetachableStorage(c)
+	storageTag := names.NewStorageTag("allecto/0")
+	err := s.State.DetachStorage(storageTag, u0.UnitTag())
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.State.AttachStorage(storageTag, u1.UnitTag())
+	c.Assert(err, gc.ErrorMatches, `cannot attach storage allecto/0 to unit storage-block/1: storage is still being detached`)
+}
+
+func (s *StorageStateSuite) TestAttachStorageOwned(c *gc.C) {
+	_, u1 := s.setupDetachableStorage(c)
+	err := s.State.AttachStorage(names.NewStorageTag("allecto/0"), u1.UnitTag())
+	c.Assert(err, gc.ErrorMatches, `cannot attach storage allecto/0 to unit storage-block/1: storage is owned by unit storage-block/0`)
+}
+
+func (s *StorageStateSuite) TestAttachStorageExceedsCharmCount(c *gc.C) {
+	u0, u1 := s.setupDetachableStorage(c)
+	storageTag := names.NewStorageTag("data/1")
+	err := s.State.DetachUnitStorage(u0.UnitTag())
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.State.RemoveStorageAttachment(storageTag, u0.UnitTag())
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.State.AttachStorage(storageTag, u1.UnitTag())
+	c.Assert(err, gc.ErrorMatches, `cannot attach storage data/1 to unit storage-block/1: charm allows at most 1 "data" storage instances`)
+}
+
+func (s *StorageStateSuite) TestDetachStorageDetachesVolume(c *gc.C) {
+	u0, u1 := s.setupDetachableStorage(c)
+	storageTag := names.NewStorageTag("allecto/0")
+	err := s.State.AssignUnit(u0, state.AssignCleanEmpty)
+	c.Assert(err, jc.ErrorIsNil)
+	machineId, err := u0.AssignedMachineId()
+	c.Assert(err, jc.ErrorIsNil)
+	machineTag := names.NewMachineTag(machineId)
+	volume := s.storageInstanceVolume(c, storageTag)
+	err = s.State.SetVolumeInfo(volume.VolumeTag(), state.VolumeInfo{
+		VolumeId: "vol-123", Size: 1024, Persistent: true,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = s.State.DetachStorage(storageTag, u0.UnitTag())
+	c.Assert(err, jc.ErrorIsNil)
+	attachment := s.volumeAttachment(c, machineTag, volume.VolumeTag())
+	c.Assert(attachment.Life(), gc.Equals, state.Alive)
+
+	// The volume is detached from the machine once the unit has
+	// finished with the storage.
+	err = s.State.RemoveStorageAttachment(storageTag, u0.UnitTag())
+	c.Assert(err, jc.ErrorIsNil)
+	attachment = s.volumeAttachment(c, machineTag, volume.VolumeTag())
+	c.Assert(attachment.Life(), gc.Equals, state.Dying)
+
+	err = s.State.AssignUnit(u1, state.AssignCleanEmpty)
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.State.AttachStorage(storageTag, u1.UnitTag())
+	c.Assert(err, gc.ErrorMatches, `cannot attach storage allecto/0 to unit storage-block/1: volume is still attached to a machine`)
+
+	// Once the volume has been detached from the first machine, the
+	// storage may be attached to the unit on another.
+	err = s.State.RemoveVolumeAttachment(machineTag, volume.VolumeTag())
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.State.AttachStorage(storageTag, u1.UnitTag())
+	c.Assert(err, jc.ErrorIsNil)
+	machineId1, err := u1.AssignedMachineId()
+	c.Assert(err, jc.ErrorIsNil)
+	attachment = s.volumeAttachment(c, names.NewMachineTag(machineId1), volume.VolumeTag())
+	c.Assert(attachment.Life(), gc.Equals, state.Alive)
+	volume = s.volume(c, volume.VolumeTag())
+	c.Assert(volume.Life(), gc.Equals, state.Alive)
+}
+
 func (s *StorageStateSuite) TestStorageLocationConflictIdentical(c *gc.C) {
 	s.testStorageLocationConflict(
 		c, "/srv", "/srv",
@@ -86,6 +86,9 @@ func (r *multiModelRunner) RunTransaction(ops []txn.Op) error {
 // these collections.
 func (r *multiModelRunner) Run(transactions jujutxn.TransactionSource) error {
 	return r.rawRunner.Run(func(attempt int) ([]txn.Op, error) {
+		if attempt > 0 {
+			txnRetries.Inc()
+		}
 		ops, err := transactions(attempt)
 		if err != nil {
 			// Don't use Trace here as jujutxn doens't use juju/errors
@@ -1692,6 +1692,22 @@ func machineStorageParamsForStorageInstance(
 ) (*machineStorageParams, error) {
 
 	charmStorage := charmMeta.Storage[storage.StorageName()]
+	owner, _ := storage.Owner()
+	cons := allCons[storage.StorageName()]
+
+	var snapshotId string
+	snapshot, err := storageInstanceSnapshot(st, storage)
+	if err != nil {
+		return nil, errors.Trace(err)
+	} else if snapshot != nil {
+		// Volumes created from a snapshot must be created in the
+		// pool of the snapshotted volume, and be at least as large.
+		cons.Pool = snapshot.Pool()
+		if cons.Size < snapshot.Size() {
+			cons.Size = snapshot.Size()
+		}
+		snapshotId = snapshot.SnapshotId()
+	}
 
 	var volumes []MachineVolumeParams
 	var filesystems []MachineFilesystemParams
@@ -1703,27 +1719,27 @@ func machineStorageParamsForStorageInstance(
 		volumeAttachmentParams := VolumeAttachmentParams{
 			charmStorage.ReadOnly,
 		}
-		if unit == storage.Owner() {
-			// The storage instance is owned by the unit, so we'll need
-			// to create a volume.
-			cons := allCons[storage.StorageName()]
+		volume, err := st.StorageInstanceVolume(storage.StorageTag())
+		if errors.IsNotFound(err) && unit == owner {
+			// The storage instance is owned by the unit, and has
+			// no volume yet, so we'll need to create one.
 			volumeParams := VolumeParams{
-				storage: storage.StorageTag(),
-				binding: storage.StorageTag(),
-				Pool:    cons.Pool,
-				Size:    cons.Size,
+				storage:    storage.StorageTag(),
+				binding:    storage.StorageTag(),
+				Pool:       cons.Pool,
+				Size:       cons.Size,
+				SnapshotId: snapshotId,
 			}
 			volumes = append(volumes, MachineVolumeParams{
 				volumeParams, volumeAttachmentParams,
 			})
+		} else if err != nil {
+			return nil, errors.Annotatef(err, "getting volume for storage %q", storage.Tag().Id())
 		} else {
-			// The storage instance is owned by the service, so there
-			// should be a (shared) volume already, for which we will
-			// just add an attachment.
-			volume, err := st.StorageInstanceVolume(storage.StorageTag())
-			if err != nil {
-				return nil, errors.Annotatef(err, "getting volume for storage %q", storage.Tag().Id())
-			}
+			// The storage instance is owned by the service, or
+			// was detached from another unit, so there is a
+			// volume already, for which we will just add an
+			// attachment.
 			volumeAttachments[volume.VolumeTag()] = volumeAttachmentParams
 		}
 	case StorageKindFilesystem:
@@ -1739,27 +1755,27 @@ func machineStorageParamsForStorageInstance(
 			location,
 			charmStorage.ReadOnly,
 		}
-		if unit == storage.Owner() {
-			// The storage instance is owned by the unit, so we'll need
-			// to create a filesystem.
-			cons := allCons[storage.StorageName()]
+		filesystem, err := st.StorageInstanceFilesystem(storage.StorageTag())
+		if errors.IsNotFound(err) && unit == owner {
+			// The storage instance is owned by the unit, and has
+			// no filesystem yet, so we'll need to create one.
 			filesystemParams := FilesystemParams{
-				storage: storage.StorageTag(),
-				binding: storage.StorageTag(),
-				Pool:    cons.Pool,
-				Size:    cons.Size,
+				storage:    storage.StorageTag(),
+				binding:    storage.StorageTag(),
+				snapshotId: snapshotId,
+				Pool:       cons.Pool,
+				Size:       cons.Size,
 			}
 			filesystems = append(filesystems, MachineFilesystemParams{
 				filesystemParams, filesystemAttachmentParams,
 			})
+		} else if err != nil {
+			return nil, errors.Annotatef(err, "getting filesystem for storage %q", storage.Tag().Id())
 		} else {
-			// The storage instance is owned by the service, so there
-			// should be a (shared) filesystem already, for which we will
-			// just add an attachment.
-			filesystem, err := st.StorageInstanceFilesystem(storage.StorageTag())
-			if err != nil {
-				return nil, errors.Annotatef(err, "getting filesystem for storage %q", storage.Tag().Id())
-			}
+			// The storage instance is owned by the service, or
+			// was detached from another unit, so there is a
+			// filesystem already, for which we will just add an
+			// attachment.
 			filesystemAttachments[filesystem.FilesystemTag()] = filesystemAttachmentParams
 		}
 	default:
@@ -2054,6 +2070,11 @@ type ActionSpecsByName map[string]charm.ActionSpec
 // this Unit, and returns its ID.  Note that the use of spec.InsertDefaults
 // mutates payload.
 func (u *Unit) AddAction(name string, payload map[string]interface{}) (Action, error) {
+	return u.AddActionWithTimeout(name, payload, 0)
+}
+
+// AddActionWithTimeout is part of the ActionReceiver interface.
+func (u *Unit) AddActionWithTimeout(name string, payload map[string]interface{}, timeout time.Duration) (Action, error) {
 	if len(name) == 0 {
 		return nil, errors.New("no action name given")
 	}
@@ -2079,7 +2100,7 @@ func (u *Unit) AddAction(name string, payload map[string]interface{}) (Action, e
 	if err != nil {
 		return nil, err
 	}
-	return u.st.EnqueueAction(u.Tag(), name, payloadWithDefaults)
+	return u.st.enqueueAction(u.Tag(), name, payloadWithDefaults, timeout)
 }
 
 // ActionSpecs gets the ActionSpec map for the Unit's charm.
@@ -2109,9 +2130,10 @@ func (u *Unit) ActionSpecs() (ActionSpecsByName, error) {
 }
 
 // CancelAction removes a pending Action from the queue for this
-// ActionReceiver and marks it as cancelled.
+// ActionReceiver and marks it as cancelled. A running Action is
+// marked as aborting until the unit stops it.
 func (u *Unit) CancelAction(action Action) (Action, error) {
-	return action.Finish(ActionResults{Status: ActionCancelled})
+	return action.Cancel()
 }
 
 // WatchActionNotifications starts and returns a StringsWatcher that
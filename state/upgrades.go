@@ -248,3 +248,90 @@ func addDefaultBindingsToServices(st *State) error {
 func AddDefaultEndpointBindingsToServices(st *State) error {
 	return runForAllEnvStates(st, addDefaultBindingsToServices)
 }
+
+// AddControllerUsers records the controller access of existing users,
+// which was previously implied: local users are given addmodel access,
+// as any local user could previously add models, and administrators of
+// the controller model are given superuser access. Model users whose
+// access was never recorded are given read access, which is what was
+// previously assumed.
+func AddControllerUsers(st *State) error {
+	info, err := st.ControllerInfo()
+	if err != nil {
+		return errors.Annotate(err, "cannot get controller info")
+	}
+
+	controllerUsers, closer := st.getRawCollection(controllerUsersC)
+	defer closer()
+	var existingDocs []controllerUserDoc
+	if err := controllerUsers.Find(nil).All(&existingDocs); err != nil {
+		return errors.Trace(err)
+	}
+	existing := make(map[string]ControllerAccess)
+	for _, doc := range existingDocs {
+		existing[doc.ID] = doc.Access
+	}
+
+	var ops []txn.Op
+	setAccess := func(user names.UserTag, createdBy string, dateCreated time.Time, access ControllerAccess) {
+		id := controllerUserID(user)
+		current, ok := existing[id]
+		switch {
+		case !ok:
+			ops = append(ops, createControllerUserOp(user, createdBy, access, dateCreated))
+		case !current.EqualOrGreaterThan(access):
+			ops = append(ops, txn.Op{
+				C:      controllerUsersC,
+				Id:     id,
+				Assert: txn.DocExists,
+				Update: bson.D{{"$set", bson.D{{"access", access}}}},
+			})
+		default:
+			return
+		}
+		existing[id] = access
+	}
+
+	users, closer := st.getRawCollection(usersC)
+	defer closer()
+	var userDoc userDoc
+	iter := users.Find(nil).Iter()
+	for iter.Next(&userDoc) {
+		setAccess(names.NewLocalUserTag(userDoc.Name), userDoc.CreatedBy, userDoc.DateCreated, ControllerAddModelAccess)
+	}
+	if err := iter.Close(); err != nil {
+		return errors.Annotate(err, "cannot read users")
+	}
+
+	modelUsers, closer := st.getRawCollection(modelUsersC)
+	defer closer()
+	var modelUserDoc modelUserDoc
+	iter = modelUsers.Find(bson.D{
+		{"model-uuid", info.ModelTag.Id()},
+		{"access", ModelAdminAccess},
+	}).Iter()
+	for iter.Next(&modelUserDoc) {
+		setAccess(names.NewUserTag(modelUserDoc.UserName), modelUserDoc.CreatedBy, modelUserDoc.DateCreated, ControllerSuperuserAccess)
+	}
+	if err := iter.Close(); err != nil {
+		return errors.Annotate(err, "cannot read controller model users")
+	}
+
+	upgradesLogger.Debugf("setting undefined model access to %q", ModelReadAccess)
+	iter = modelUsers.Find(bson.D{
+		{"access", bson.D{{"$in", []interface{}{ModelUndefinedAccess, nil}}}},
+	}).Select(bson.D{{"_id", 1}}).Iter()
+	var doc bson.M
+	for iter.Next(&doc) {
+		ops = append(ops, txn.Op{
+			C:      modelUsersC,
+			Id:     doc["_id"],
+			Assert: txn.DocExists,
+			Update: bson.D{{"$set", bson.D{{"access", ModelReadAccess}}}},
+		})
+	}
+	if err := iter.Close(); err != nil {
+		return errors.Annotate(err, "cannot read model users")
+	}
+	return st.runRawTransaction(ops)
+}
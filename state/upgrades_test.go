@@ -5,6 +5,7 @@ package state
 
 import (
 	"github.com/juju/errors"
+	"github.com/juju/names"
 	jc "github.com/juju/testing/checkers"
 	gc "gopkg.in/check.v1"
 	"gopkg.in/mgo.v2"
@@ -578,3 +579,48 @@ func (s *upgradesSuite) TestAddDefaultEndpointBindingsToServices(c *gc.C) {
 func (s *upgradesSuite) TestAddDefaultEndpointBindingsToServicesIdempotent(c *gc.C) {
 	s.testAddDefaultEndpointBindingsToServices(c, true)
 }
+
+func (s *upgradesSuite) TestAddControllerUsers(c *gc.C) {
+	// Remove the controller users that would not have existed
+	// before the upgrade.
+	controllerUsers, closer := s.state.getRawCollection(controllerUsersC)
+	defer closer()
+	_, err := controllerUsers.RemoveAll(nil)
+	c.Assert(err, jc.ErrorIsNil)
+
+	_, err = s.state.AddUser("bob", "Bob", "password", s.owner.Canonical())
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = controllerUsers.RemoveId("bob@local")
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = s.state.AddModelUser(ModelUserSpec{
+		User:      names.NewUserTag("mary@remote"),
+		CreatedBy: s.owner,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	modelUsers, closer := s.state.getRawCollection(modelUsersC)
+	defer closer()
+	err = modelUsers.UpdateId(s.state.docID("mary@remote"), bson.D{{"$unset", bson.D{{"access", 1}}}})
+	c.Assert(err, jc.ErrorIsNil)
+
+	assertUpgraded := func() {
+		access, err := s.state.ControllerAccess(s.owner)
+		c.Assert(err, jc.ErrorIsNil)
+		c.Assert(access, gc.Equals, ControllerSuperuserAccess)
+		access, err = s.state.ControllerAccess(names.NewLocalUserTag("bob"))
+		c.Assert(err, jc.ErrorIsNil)
+		c.Assert(access, gc.Equals, ControllerAddModelAccess)
+		_, err = s.state.ControllerAccess(names.NewUserTag("mary@remote"))
+		c.Assert(err, jc.Satisfies, errors.IsNotFound)
+
+		modelUser, err := s.state.ModelUser(names.NewUserTag("mary@remote"))
+		c.Assert(err, jc.ErrorIsNil)
+		c.Assert(modelUser.Access(), gc.Equals, ModelReadAccess)
+	}
+	err = AddControllerUsers(s.state)
+	c.Assert(err, jc.ErrorIsNil)
+	assertUpgraded()
+
+	err = AddControllerUsers(s.state)
+	c.Assert(err, jc.ErrorIsNil, gc.Commentf("idempotency check failed!"))
+	assertUpgraded()
+}
@@ -85,12 +85,16 @@ func (st *State) addUser(name, displayName, password, creator string, secretKey
 		user.doc.PasswordSalt = salt
 	}
 
+	// Local users may log in to the controller until that access
+	// is revoked.
 	ops := []txn.Op{{
 		C:      usersC,
 		Id:     nameToLower,
 		Assert: txn.DocMissing,
 		Insert: &user.doc,
-	}}
+	}, createControllerUserOp(
+		names.NewLocalUserTag(name), creator, ControllerLoginAccess, user.doc.DateCreated,
+	)}
 	err := st.runTransaction(ops)
 	if err == txn.ErrAborted {
 		err = errors.AlreadyExistsf("user")
@@ -314,6 +318,83 @@ func (u *User) UpdateLastLogin() (err error) {
 	return errors.Trace(err)
 }
 
+// maxFailedLogins is the number of failed login attempts recorded
+// for each user; older attempts are discarded.
+const maxFailedLogins = 10
+
+// FailedLogin records an unsuccessful attempt to log in as a user.
+type FailedLogin struct {
+	// Time holds when the attempt was made, in UTC.
+	Time time.Time
+
+	// RemoteAddress holds the address the attempt was made from.
+	RemoteAddress string
+}
+
+type userFailedLoginsDoc struct {
+	DocID    string           `bson:"_id"`
+	Failures []failedLoginDoc `bson:"failures"`
+}
+
+type failedLoginDoc struct {
+	Time          time.Time `bson:"time"`
+	RemoteAddress string    `bson:"remote-address"`
+}
+
+// FailedLogins returns the most recent failed attempts to log in as
+// the user, oldest first.
+func (u *User) FailedLogins() ([]FailedLogin, error) {
+	failedLogins, closer := u.st.getRawCollection(userFailedLoginsC)
+	defer closer()
+
+	var doc userFailedLoginsDoc
+	err := failedLogins.FindId(u.doc.DocID).One(&doc)
+	if err == mgo.ErrNotFound {
+		return nil, nil
+	} else if err != nil {
+		return nil, errors.Trace(err)
+	}
+	result := make([]FailedLogin, len(doc.Failures))
+	for i, failure := range doc.Failures {
+		result[i] = FailedLogin{
+			Time:          failure.Time.UTC(),
+			RemoteAddress: failure.RemoteAddress,
+		}
+	}
+	return result, nil
+}
+
+// RecordFailedLogin records a failed attempt to log in as the user
+// from the given remote address. As with UpdateLastLogin, this is not
+// done using mgo.txn, and concurrent failures may overwrite each
+// other: the record is informational only.
+func (u *User) RecordFailedLogin(remoteAddress string) error {
+	failedLogins, closer := u.st.getCollection(userFailedLoginsC)
+	defer closer()
+
+	failedLoginsW := failedLogins.Writeable()
+
+	// Update the safe mode of the underlying session to not require
+	// write majority, nor sync to disk.
+	session := failedLoginsW.Underlying().Database.Session
+	session.SetSafe(&mgo.Safe{})
+
+	doc := userFailedLoginsDoc{DocID: u.doc.DocID}
+	err := failedLogins.FindId(u.doc.DocID).One(&doc)
+	if err != nil && err != mgo.ErrNotFound {
+		return errors.Trace(err)
+	}
+	doc.Failures = append(doc.Failures, failedLoginDoc{
+		Time:          nowToTheSecond(),
+		RemoteAddress: remoteAddress,
+	})
+	if len(doc.Failures) > maxFailedLogins {
+		doc.Failures = doc.Failures[len(doc.Failures)-maxFailedLogins:]
+	}
+	_, err = failedLoginsW.UpsertId(doc.DocID, doc)
+	return errors.Trace(err)
+}
+
 // SecretKey returns the user's secret key, if any.
 func (u *User) SecretKey() []byte {
 	return u.doc.SecretKey
@@ -4,6 +4,7 @@
 package state_test
 
 import (
+	"fmt"
 	"regexp"
 	"time"
 
@@ -101,6 +102,30 @@ func (s *UserSuite) TestUpdateLastLogin(c *gc.C) {
 		lastLogin.Equal(now), jc.IsTrue)
 }
 
+func (s *UserSuite) TestFailedLoginsNone(c *gc.C) {
+	user := s.Factory.MakeUser(c, nil)
+	failures, err := user.FailedLogins()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(failures, gc.HasLen, 0)
+}
+
+func (s *UserSuite) TestRecordFailedLogin(c *gc.C) {
+	now := time.Now().Round(time.Second).UTC()
+	user := s.Factory.MakeUser(c, nil)
+	for i := 1; i <= 12; i++ {
+		err := user.RecordFailedLogin(fmt.Sprintf("10.0.0.%d", i))
+		c.Assert(err, jc.ErrorIsNil)
+	}
+	failures, err := user.FailedLogins()
+	c.Assert(err, jc.ErrorIsNil)
+	// Only the most recent failures are kept.
+	c.Assert(failures, gc.HasLen, 10)
+	for i, failure := range failures {
+		c.Check(failure.RemoteAddress, gc.Equals, fmt.Sprintf("10.0.0.%d", i+3))
+		c.Check(failure.Time.Before(now), jc.IsFalse)
+	}
+}
+
 func (s *UserSuite) TestSetPassword(c *gc.C) {
 	user := s.Factory.MakeUser(c, nil)
 	testSetPassword(c, func() (state.Authenticator, error) {
@@ -46,6 +46,12 @@ type Volume interface {
 	// if it has not already been provisioned. Params returns true if the
 	// returned parameters are usable for provisioning, otherwise false.
 	Params() (VolumeParams, bool)
+
+	// RequestedSize returns the size, in MiB, that the volume has been
+	// requested to grow to. RequestedSize returns true if a resize has
+	// been requested and the volume has not yet been resized, otherwise
+	// false.
+	RequestedSize() (uint64, bool)
 }
 
 // VolumeAttachment describes an attachment of a volume to a machine.
@@ -91,6 +97,10 @@ type volumeDoc struct {
 	Binding         string        `bson:"binding,omitempty"`
 	Info            *VolumeInfo   `bson:"info,omitempty"`
 	Params          *VolumeParams `bson:"params,omitempty"`
+
+	// RequestedSize, if non-zero, is the size in MiB that the
+	// volume has been requested to grow to.
+	RequestedSize uint64 `bson:"requestedsize,omitempty"`
 }
 
 // volumeAttachmentDoc records information about a volume attachment.
@@ -117,6 +127,10 @@ type VolumeParams struct {
 
 	Pool string `bson:"pool"`
 	Size uint64 `bson:"size"`
+
+	// SnapshotId, if non-empty, is the storage provider's ID for
+	// the snapshot from which the volume is to be created.
+	SnapshotId string `bson:"snapshotid,omitempty"`
 }
 
 // VolumeInfo describes information about a volume.
@@ -236,6 +250,14 @@ func (v *volume) Params() (VolumeParams, bool) {
 	return *v.doc.Params, true
 }
 
+// RequestedSize is required to implement Volume.
+func (v *volume) RequestedSize() (uint64, bool) {
+	if v.doc.RequestedSize == 0 {
+		return 0, false
+	}
+	return v.doc.RequestedSize, true
+}
+
 // Status is required to implement StatusGetter.
 func (v *volume) Status() (status.StatusInfo, error) {
 	return v.st.VolumeStatus(v.VolumeTag())
@@ -570,6 +592,18 @@ func removeVolumeAttachmentOps(m names.MachineTag, v *volume) []txn.Op {
 	}}
 }
 
+// machineStorageIncrefOp returns a txn.Op that will increment the attachment
+// count for a given machine storage entity (volume or filesystem), which
+// must be Alive. It is used when attaching existing storage to a machine.
+func machineStorageIncrefOp(collection, id string) txn.Op {
+	return txn.Op{
+		C:      collection,
+		Id:     id,
+		Assert: isAliveDoc,
+		Update: bson.D{{"$inc", bson.D{{"attachmentcount", 1}}}},
+	}
+}
+
 // machineStorageDecrefOp returns a txn.Op that will decrement the attachment
 // count for a given machine storage entity (volume or filesystem), given its
 // current attachment count and lifecycle state. If the attachment count goes
@@ -710,6 +744,44 @@ func (st *State) RemoveVolume(tag names.VolumeTag) (err error) {
 	return st.run(buildTxn)
 }
 
+// ResizeVolume requests that the volume be grown to the specified size,
+// in MiB. The volume must be alive and provisioned, and the new size must
+// be greater than the volume's current size. The storage provisioner
+// responsible for the volume will resize it, and record the new size with
+// SetVolumeInfo.
+func (st *State) ResizeVolume(tag names.VolumeTag, size uint64) (err error) {
+	defer errors.DeferredAnnotatef(&err, "cannot resize volume %s", tag.Id())
+	buildTxn := func(attempt int) ([]txn.Op, error) {
+		v, err := st.volumeByTag(tag)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		if v.doc.Life != Alive {
+			return nil, errors.New("volume is not alive")
+		}
+		info, err := v.Info()
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		if size <= info.Size {
+			return nil, errors.Errorf(
+				"new size %dMiB must be greater than current size %dMiB",
+				size, info.Size,
+			)
+		}
+		if v.doc.RequestedSize == size {
+			return nil, jujutxn.ErrNoOperations
+		}
+		return []txn.Op{{
+			C:      volumesC,
+			Id:     v.doc.Name,
+			Assert: append(bson.D{{"info.size", info.Size}}, isAliveDoc...),
+			Update: bson.D{{"$set", bson.D{{"requestedsize", size}}}},
+		}}, nil
+	}
+	return st.run(buildTxn)
+}
+
 // newVolumeName returns a unique volume name.
 // If the machine ID supplied is non-empty, the
 // volume ID will incorporate it as the volume's
@@ -960,6 +1032,7 @@ func (st *State) SetVolumeInfo(tag names.VolumeTag, info VolumeInfo) (err error)
 		// we set info for the first time, ensuring that
 		// params and info are mutually exclusive.
 		var unsetParams bool
+		var honouredSize uint64
 		var ops []txn.Op
 		if params, ok := v.Params(); ok {
 			info.Pool = params.Pool
@@ -970,11 +1043,28 @@ func (st *State) SetVolumeInfo(tag names.VolumeTag, info VolumeInfo) (err error)
 			if err != nil {
 				return nil, err
 			}
+			// The pool is only known to state, and providers do
+			// not report the hardware ID or persistence when
+			// resizing a volume, so keep the existing values.
+			if info.Pool == "" {
+				info.Pool = oldInfo.Pool
+			}
+			if info.HardwareId == "" {
+				info.HardwareId = oldInfo.HardwareId
+			}
+			if !info.Persistent {
+				info.Persistent = oldInfo.Persistent
+			}
 			if err := validateVolumeInfoChange(info, oldInfo); err != nil {
 				return nil, err
 			}
+			// Once the volume has grown to the requested
+			// size, the resize request has been honoured.
+			if requestedSize, ok := v.RequestedSize(); ok && info.Size >= requestedSize {
+				honouredSize = requestedSize
+			}
 		}
-		ops = append(ops, setVolumeInfoOps(tag, info, unsetParams)...)
+		ops = append(ops, setVolumeInfoOps(tag, info, unsetParams, honouredSize)...)
 		return ops, nil
 	}
 	return st.run(buildTxn)
@@ -996,15 +1086,26 @@ func validateVolumeInfoChange(newInfo, oldInfo VolumeInfo) error {
 	return nil
 }
 
-func setVolumeInfoOps(tag names.VolumeTag, info VolumeInfo, unsetParams bool) []txn.Op {
+// setVolumeInfoOps returns the operations to set the volume's info. If
+// honouredSize is non-zero, the volume's requested size is unset, provided
+// that it has not changed from honouredSize.
+func setVolumeInfoOps(tag names.VolumeTag, info VolumeInfo, unsetParams bool, honouredSize uint64) []txn.Op {
 	asserts := isAliveDoc
 	update := bson.D{
 		{"$set", bson.D{{"info", &info}}},
 	}
+	var unset bson.D
 	if unsetParams {
 		asserts = append(asserts, bson.DocElem{"info", bson.D{{"$exists", false}}})
 		asserts = append(asserts, bson.DocElem{"params", bson.D{{"$exists", true}}})
-		update = append(update, bson.DocElem{"$unset", bson.D{{"params", nil}}})
+		unset = append(unset, bson.DocElem{"params", nil})
+	}
+	if honouredSize != 0 {
+		asserts = append(asserts, bson.DocElem{"requestedsize", honouredSize})
+		unset = append(unset, bson.DocElem{"requestedsize", nil})
+	}
+	if len(unset) > 0 {
+		update = append(update, bson.DocElem{"$unset", unset})
 	}
 	return []txn.Op{{
 		C:      volumesC,
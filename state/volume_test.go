@@ -226,16 +226,112 @@ func (s *VolumeStateSuite) TestSetVolumeInfoImmutable(c *gc.C) {
 	c.Assert(err, jc.ErrorIsNil)
 
 	// The first call to SetVolumeInfo takes the pool name from
-	// the params; the second does not, but it must not change
-	// either. Callers are expected to get the existing info and
-	// update it, leaving immutable values intact.
+	// the params; later calls may leave it unset, in which case
+	// the existing pool is kept, but must not change it.
 	err = s.State.SetVolumeInfo(volume.VolumeTag(), volumeInfoSet)
-	c.Assert(err, gc.ErrorMatches, `cannot set info for volume "0/0": cannot change pool from "loop-pool" to ""`)
-
+	c.Assert(err, jc.ErrorIsNil)
+	volumeInfoSet.Pool = "other-pool"
+	err = s.State.SetVolumeInfo(volume.VolumeTag(), volumeInfoSet)
+	c.Assert(err, gc.ErrorMatches, `cannot set info for volume "0/0": cannot change pool from "loop-pool" to "other-pool"`)
 	volumeInfoSet.Pool = "loop-pool"
+	volumeInfoSet.VolumeId = "other-volume"
+	err = s.State.SetVolumeInfo(volume.VolumeTag(), volumeInfoSet)
+	c.Assert(err, gc.ErrorMatches, `cannot set info for volume "0/0": cannot change volume ID from "vol-ume" to "other-volume"`)
+
+	volumeInfoSet.VolumeId = "vol-ume"
 	s.assertVolumeInfo(c, volumeTag, volumeInfoSet)
 }
 
+func (s *VolumeStateSuite) setupProvisionedVolume(c *gc.C) names.VolumeTag {
+	_, u, storageTag := s.setupSingleStorage(c, "block", "loop-pool")
+	err := s.State.AssignUnit(u, state.AssignCleanEmpty)
+	c.Assert(err, jc.ErrorIsNil)
+	volumeTag := s.storageInstanceVolume(c, storageTag).VolumeTag()
+	err = s.State.SetVolumeInfo(volumeTag, state.VolumeInfo{
+		Size: 1024, VolumeId: "vol-ume", HardwareId: "hw", Persistent: true,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	return volumeTag
+}
+
+func (s *VolumeStateSuite) TestResizeVolume(c *gc.C) {
+	volumeTag := s.setupProvisionedVolume(c)
+	_, ok := s.volume(c, volumeTag).RequestedSize()
+	c.Assert(ok, jc.IsFalse)
+
+	err := s.State.ResizeVolume(volumeTag, 2048)
+	c.Assert(err, jc.ErrorIsNil)
+	size, ok := s.volume(c, volumeTag).RequestedSize()
+	c.Assert(ok, jc.IsTrue)
+	c.Assert(size, gc.Equals, uint64(2048))
+
+	// Requesting the same size again is a no-op.
+	err = s.State.ResizeVolume(volumeTag, 2048)
+	c.Assert(err, jc.ErrorIsNil)
+
+	// Setting info with a smaller size leaves the request pending.
+	// As when the storage provisioner reports a resize, the pool,
+	// hardware ID and persistence are not set, and are kept.
+	err = s.State.SetVolumeInfo(volumeTag, state.VolumeInfo{
+		Size: 1536, VolumeId: "vol-ume",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	_, ok = s.volume(c, volumeTag).RequestedSize()
+	c.Assert(ok, jc.IsTrue)
+
+	// Once the volume has grown, the request is cleared.
+	err = s.State.SetVolumeInfo(volumeTag, state.VolumeInfo{Size: 2048, VolumeId: "vol-ume"})
+	c.Assert(err, jc.ErrorIsNil)
+	_, ok = s.volume(c, volumeTag).RequestedSize()
+	c.Assert(ok, jc.IsFalse)
+	s.assertVolumeInfo(c, volumeTag, state.VolumeInfo{
+		Size: 2048, VolumeId: "vol-ume", HardwareId: "hw", Persistent: true, Pool: "loop-pool",
+	})
+}
+
+func (s *VolumeStateSuite) TestResizeVolumeNotGrowing(c *gc.C) {
+	volumeTag := s.setupProvisionedVolume(c)
+	err := s.State.ResizeVolume(volumeTag, 1024)
+	c.Assert(err, gc.ErrorMatches, `cannot resize volume 0/0: new size 1024MiB must be greater than current size 1024MiB`)
+	_, ok := s.volume(c, volumeTag).RequestedSize()
+	c.Assert(ok, jc.IsFalse)
+}
+
+func (s *VolumeStateSuite) TestResizeVolumeNotProvisioned(c *gc.C) {
+	_, u, storageTag := s.setupSingleStorage(c, "block", "loop-pool")
+	err := s.State.AssignUnit(u, state.AssignCleanEmpty)
+	c.Assert(err, jc.ErrorIsNil)
+	volumeTag := s.storageInstanceVolume(c, storageTag).VolumeTag()
+	err = s.State.ResizeVolume(volumeTag, 2048)
+	c.Assert(err, jc.Satisfies, errors.IsNotProvisioned)
+}
+
+func (s *VolumeStateSuite) TestResizeVolumeNotAlive(c *gc.C) {
+	volumeTag := s.setupProvisionedVolume(c)
+	err := s.State.DestroyVolume(volumeTag)
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.State.ResizeVolume(volumeTag, 2048)
+	c.Assert(err, gc.ErrorMatches, `cannot resize volume 0/0: volume is not alive`)
+}
+
+func (s *VolumeStateSuite) TestWatchVolume(c *gc.C) {
+	volumeTag := s.setupProvisionedVolume(c)
+	w := s.State.WatchVolume(volumeTag)
+	defer testing.AssertStop(c, w)
+	wc := testing.NewNotifyWatcherC(c, s.State, w)
+	wc.AssertOneChange()
+
+	err := s.State.ResizeVolume(volumeTag, 2048)
+	c.Assert(err, jc.ErrorIsNil)
+	wc.AssertOneChange()
+
+	err = s.State.SetVolumeInfo(volumeTag, state.VolumeInfo{
+		Size: 2048, VolumeId: "vol-ume", Pool: "loop-pool",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	wc.AssertOneChange()
+}
+
 func (s *VolumeStateSuite) TestWatchVolumeAttachment(c *gc.C) {
 	_, u, storageTag := s.setupSingleStorage(c, "block", "loop-pool")
 	err := s.State.AssignUnit(u, state.AssignCleanEmpty)
@@ -307,6 +403,36 @@ func (s *VolumeStateSuite) TestWatchModelVolumes(c *gc.C) {
 	wc.AssertNoChange()
 }
 
+func (s *VolumeStateSuite) TestWatchModelVolumeResizes(c *gc.C) {
+	service := s.setupMixedScopeStorageService(c, "block")
+	u, err := service.AddUnit()
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.State.AssignUnit(u, state.AssignCleanEmpty)
+	c.Assert(err, jc.ErrorIsNil)
+
+	w := s.State.WatchModelVolumeResizes()
+	defer testing.AssertStop(c, w)
+	wc := testing.NewStringsWatcherC(c, s.State, w)
+	wc.AssertChangeInSingleEvent("0") // initial
+	wc.AssertNoChange()
+
+	volumeTag := names.NewVolumeTag("0")
+	err = s.State.SetVolumeInfo(volumeTag, state.VolumeInfo{Size: 1024, VolumeId: "vol-0"})
+	c.Assert(err, jc.ErrorIsNil)
+	wc.AssertChangeInSingleEvent("0")
+	wc.AssertNoChange()
+
+	err = s.State.ResizeVolume(volumeTag, 2048)
+	c.Assert(err, jc.ErrorIsNil)
+	wc.AssertChangeInSingleEvent("0")
+	wc.AssertNoChange()
+
+	// Changes to machine-scoped volumes are not reported.
+	err = s.State.SetVolumeInfo(names.NewVolumeTag("0/1"), state.VolumeInfo{Size: 1024, VolumeId: "vol-1"})
+	c.Assert(err, jc.ErrorIsNil)
+	wc.AssertNoChange()
+}
+
 func (s *VolumeStateSuite) TestWatchEnvironVolumeAttachments(c *gc.C) {
 	service := s.setupMixedScopeStorageService(c, "block")
 	addUnit := func() {
@@ -0,0 +1,249 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/juju/errors"
+	"github.com/juju/names"
+	jujutxn "github.com/juju/txn"
+	"gopkg.in/mgo.v2"
+	"gopkg.in/mgo.v2/bson"
+	"gopkg.in/mgo.v2/txn"
+)
+
+// VolumeSnapshot describes a point-in-time copy of a volume, made by
+// the storage provider that manages the volume.
+type VolumeSnapshot interface {
+	// Id returns the ID of the snapshot, unique within the model.
+	Id() string
+
+	// VolumeTag returns the tag of the volume the snapshot was made from.
+	VolumeTag() names.VolumeTag
+
+	// StorageInstance returns the tag of the storage instance that the
+	// snapshotted volume was assigned to, and a boolean indicating
+	// whether or not the volume was assigned to a storage instance.
+	StorageInstance() (names.StorageTag, bool)
+
+	// Pool returns the name of the storage pool that the snapshotted
+	// volume was created in. Volumes created from the snapshot must be
+	// created in the same pool.
+	Pool() string
+
+	// SnapshotId returns the storage provider's unique ID for the
+	// snapshot.
+	SnapshotId() string
+
+	// Size returns the size of the snapshotted volume, in MiB.
+	Size() uint64
+
+	// Created returns the time at which the snapshot was recorded.
+	Created() time.Time
+}
+
+type volumeSnapshot struct {
+	doc volumeSnapshotDoc
+}
+
+type volumeSnapshotDoc struct {
+	DocID      string    `bson:"_id"`
+	ModelUUID  string    `bson:"model-uuid"`
+	Id         string    `bson:"id"`
+	Volume     string    `bson:"volumeid"`
+	StorageId  string    `bson:"storageid,omitempty"`
+	Pool       string    `bson:"pool"`
+	SnapshotId string    `bson:"snapshotid"`
+	Size       uint64    `bson:"size"`
+	Created    time.Time `bson:"created"`
+}
+
+// VolumeSnapshotInfo describes information about a volume snapshot,
+// as reported by the storage provider.
+type VolumeSnapshotInfo struct {
+	// SnapshotId is the storage provider's unique ID for the snapshot.
+	SnapshotId string
+
+	// Size is the size of the snapshotted volume, in MiB.
+	Size uint64
+}
+
+// Id is required to implement VolumeSnapshot.
+func (s *volumeSnapshot) Id() string {
+	return s.doc.Id
+}
+
+// VolumeTag is required to implement VolumeSnapshot.
+func (s *volumeSnapshot) VolumeTag() names.VolumeTag {
+	return names.NewVolumeTag(s.doc.Volume)
+}
+
+// StorageInstance is required to implement VolumeSnapshot.
+func (s *volumeSnapshot) StorageInstance() (names.StorageTag, bool) {
+	if s.doc.StorageId == "" {
+		return names.StorageTag{}, false
+	}
+	return names.NewStorageTag(s.doc.StorageId), true
+}
+
+// Pool is required to implement VolumeSnapshot.
+func (s *volumeSnapshot) Pool() string {
+	return s.doc.Pool
+}
+
+// SnapshotId is required to implement VolumeSnapshot.
+func (s *volumeSnapshot) SnapshotId() string {
+	return s.doc.SnapshotId
+}
+
+// Size is required to implement VolumeSnapshot.
+func (s *volumeSnapshot) Size() uint64 {
+	return s.doc.Size
+}
+
+// Created is required to implement VolumeSnapshot.
+func (s *volumeSnapshot) Created() time.Time {
+	return s.doc.Created.UTC()
+}
+
+// AddVolumeSnapshot records a snapshot of the specified volume, which
+// the volume's storage provider has created. The volume must have been
+// provisioned.
+func (st *State) AddVolumeSnapshot(tag names.VolumeTag, info VolumeSnapshotInfo) (VolumeSnapshot, error) {
+	if info.SnapshotId == "" {
+		return nil, errors.NotValidf("empty snapshot ID")
+	}
+	v, err := st.volumeByTag(tag)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	volumeInfo, err := v.Info()
+	if err != nil {
+		return nil, errors.Annotate(err, "cannot snapshot volume")
+	}
+	seq, err := st.sequence("volumesnapshot")
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	doc := volumeSnapshotDoc{
+		Id:         fmt.Sprint(seq),
+		Volume:     tag.Id(),
+		StorageId:  v.doc.StorageId,
+		Pool:       volumeInfo.Pool,
+		SnapshotId: info.SnapshotId,
+		Size:       info.Size,
+		Created:    time.Now(),
+	}
+	ops := []txn.Op{{
+		C:      volumesC,
+		Id:     tag.Id(),
+		Assert: txn.DocExists,
+	}, {
+		C:      volumeSnapshotsC,
+		Id:     doc.Id,
+		Assert: txn.DocMissing,
+		Insert: &doc,
+	}}
+	if err := st.runTransaction(ops); err != nil {
+		return nil, errors.Annotatef(err, "cannot add snapshot of volume %q", tag.Id())
+	}
+	return &volumeSnapshot{doc}, nil
+}
+
+// VolumeSnapshot returns the volume snapshot with the specified ID.
+func (st *State) VolumeSnapshot(id string) (VolumeSnapshot, error) {
+	snapshots, closer := st.getCollection(volumeSnapshotsC)
+	defer closer()
+
+	var doc volumeSnapshotDoc
+	err := snapshots.FindId(id).One(&doc)
+	if err == mgo.ErrNotFound {
+		return nil, errors.NotFoundf("volume snapshot %q", id)
+	} else if err != nil {
+		return nil, errors.Annotatef(err, "cannot get volume snapshot %q", id)
+	}
+	return &volumeSnapshot{doc}, nil
+}
+
+// AllVolumeSnapshots returns all of the volume snapshots recorded in
+// the model.
+func (st *State) AllVolumeSnapshots() ([]VolumeSnapshot, error) {
+	snapshots, closer := st.getCollection(volumeSnapshotsC)
+	defer closer()
+
+	var docs []volumeSnapshotDoc
+	if err := snapshots.Find(nil).Sort("created").All(&docs); err != nil {
+		return nil, errors.Annotate(err, "cannot get volume snapshots")
+	}
+	result := make([]VolumeSnapshot, len(docs))
+	for i, doc := range docs {
+		result[i] = &volumeSnapshot{doc}
+	}
+	return result, nil
+}
+
+// RemoveVolumeSnapshot removes the record of the volume snapshot with
+// the specified ID. The snapshot itself must already have been destroyed
+// by the storage provider. A snapshot cannot be removed while storage
+// instances that are to be created from it remain.
+func (st *State) RemoveVolumeSnapshot(id string) (err error) {
+	defer errors.DeferredAnnotatef(&err, "cannot remove volume snapshot %q", id)
+	buildTxn := func(attempt int) ([]txn.Op, error) {
+		if _, err := st.VolumeSnapshot(id); errors.IsNotFound(err) {
+			return nil, jujutxn.ErrNoOperations
+		} else if err != nil {
+			return nil, errors.Trace(err)
+		}
+		storageInstances, closer := st.getCollection(storageInstancesC)
+		defer closer()
+		var doc storageInstanceDoc
+		err := storageInstances.Find(bson.D{{"snapshot", id}}).One(&doc)
+		if err == nil {
+			return nil, errors.Errorf("snapshot is used by storage %q", doc.Id)
+		} else if err != mgo.ErrNotFound {
+			return nil, errors.Annotate(err, "cannot get storage instances")
+		}
+		return []txn.Op{{
+			C:      volumeSnapshotsC,
+			Id:     id,
+			Assert: txn.DocExists,
+			Remove: true,
+		}}, nil
+	}
+	return st.run(buildTxn)
+}
+
+// volumeSnapshotForStorage returns the volume snapshot with the
+// specified ID, checking that volumes created from it may be given to
+// storage with the specified constraints.
+func (st *State) volumeSnapshotForStorage(id string, cons StorageConstraints) (VolumeSnapshot, error) {
+	snapshot, err := st.VolumeSnapshot(id)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if cons.Pool != "" && cons.Pool != snapshot.Pool() {
+		return nil, errors.NotValidf(
+			"storage pool %q for snapshot %q taken in pool %q",
+			cons.Pool, id, snapshot.Pool(),
+		)
+	}
+	return snapshot, nil
+}
+
+// storageInstanceSnapshot returns the volume snapshot from which the
+// storage instance's volume is to be created, or nil if the volume is
+// not to be created from a snapshot.
+func storageInstanceSnapshot(st *State, storage StorageInstance) (VolumeSnapshot, error) {
+	s, ok := storage.(*storageInstance)
+	if !ok || s.doc.Snapshot == "" {
+		return nil, nil
+	}
+	snapshot, err := st.VolumeSnapshot(s.doc.Snapshot)
+	if err != nil {
+		return nil, errors.Annotatef(err, "getting snapshot for storage %q", s.doc.Id)
+	}
+	return snapshot, nil
+}
@@ -0,0 +1,165 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state_test
+
+import (
+	"github.com/juju/errors"
+	"github.com/juju/names"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/state"
+)
+
+type VolumeSnapshotSuite struct {
+	StorageStateSuiteBase
+	unit      *state.Unit
+	volumeTag names.VolumeTag
+}
+
+var _ = gc.Suite(&VolumeSnapshotSuite{})
+
+func (s *VolumeSnapshotSuite) SetUpTest(c *gc.C) {
+	s.StorageStateSuiteBase.SetUpTest(c)
+	var storageTag names.StorageTag
+	_, s.unit, storageTag = s.setupSingleStorage(c, "block", "persistent-block")
+	err := s.State.AssignUnit(s.unit, state.AssignCleanEmpty)
+	c.Assert(err, jc.ErrorIsNil)
+	s.volumeTag = s.storageInstanceVolume(c, storageTag).VolumeTag()
+}
+
+func (s *VolumeSnapshotSuite) setVolumeInfo(c *gc.C) {
+	err := s.State.SetVolumeInfo(s.volumeTag, state.VolumeInfo{
+		VolumeId:   "vol-0",
+		Size:       1024,
+		Persistent: true,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *VolumeSnapshotSuite) addVolumeSnapshot(c *gc.C) state.VolumeSnapshot {
+	s.setVolumeInfo(c)
+	snapshot, err := s.State.AddVolumeSnapshot(s.volumeTag, state.VolumeSnapshotInfo{
+		SnapshotId: "snap-0",
+		Size:       1024,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	return snapshot
+}
+
+func (s *VolumeSnapshotSuite) TestAddVolumeSnapshot(c *gc.C) {
+	snapshot := s.addVolumeSnapshot(c)
+	c.Assert(snapshot.VolumeTag(), gc.Equals, s.volumeTag)
+	c.Assert(snapshot.Pool(), gc.Equals, "persistent-block")
+	c.Assert(snapshot.SnapshotId(), gc.Equals, "snap-0")
+	c.Assert(snapshot.Size(), gc.Equals, uint64(1024))
+	storageTag, ok := snapshot.StorageInstance()
+	c.Assert(ok, jc.IsTrue)
+	c.Assert(storageTag, gc.Equals, names.NewStorageTag("data/0"))
+
+	stored, err := s.State.VolumeSnapshot(snapshot.Id())
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(stored.SnapshotId(), gc.Equals, "snap-0")
+	c.Assert(stored.Created().IsZero(), jc.IsFalse)
+
+	all, err := s.State.AllVolumeSnapshots()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(all, gc.HasLen, 1)
+	c.Assert(all[0].Id(), gc.Equals, snapshot.Id())
+}
+
+func (s *VolumeSnapshotSuite) TestAddVolumeSnapshotUnprovisioned(c *gc.C) {
+	_, err := s.State.AddVolumeSnapshot(s.volumeTag, state.VolumeSnapshotInfo{
+		SnapshotId: "snap-0",
+		Size:       1024,
+	})
+	c.Assert(err, jc.Satisfies, errors.IsNotProvisioned)
+}
+
+func (s *VolumeSnapshotSuite) TestAddVolumeSnapshotEmptySnapshotId(c *gc.C) {
+	s.setVolumeInfo(c)
+	_, err := s.State.AddVolumeSnapshot(s.volumeTag, state.VolumeSnapshotInfo{Size: 1024})
+	c.Assert(err, jc.Satisfies, errors.IsNotValid)
+}
+
+func (s *VolumeSnapshotSuite) TestVolumeSnapshotNotFound(c *gc.C) {
+	_, err := s.State.VolumeSnapshot("42")
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+	c.Assert(err, gc.ErrorMatches, `volume snapshot "42" not found`)
+}
+
+func (s *VolumeSnapshotSuite) TestRemoveVolumeSnapshot(c *gc.C) {
+	snapshot := s.addVolumeSnapshot(c)
+	err := s.State.RemoveVolumeSnapshot(snapshot.Id())
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = s.State.VolumeSnapshot(snapshot.Id())
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+
+	// Removing a snapshot that no longer exists is not an error.
+	err = s.State.RemoveVolumeSnapshot(snapshot.Id())
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *VolumeSnapshotSuite) TestRemoveVolumeSnapshotInUse(c *gc.C) {
+	snapshot := s.addVolumeSnapshot(c)
+	err := s.State.AddStorageForUnitFromSnapshot(
+		s.unit.UnitTag(), "allecto",
+		state.StorageConstraints{Size: 512, Count: 1},
+		snapshot.Id(),
+	)
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.State.RemoveVolumeSnapshot(snapshot.Id())
+	c.Assert(err, gc.ErrorMatches, `cannot remove volume snapshot "0": snapshot is used by storage "allecto/[0-9]+"`)
+}
+
+func (s *VolumeSnapshotSuite) TestAddStorageForUnitFromSnapshot(c *gc.C) {
+	snapshot := s.addVolumeSnapshot(c)
+	err := s.State.AddStorageForUnitFromSnapshot(
+		s.unit.UnitTag(), "allecto",
+		state.StorageConstraints{Size: 512, Count: 1},
+		snapshot.Id(),
+	)
+	c.Assert(err, jc.ErrorIsNil)
+
+	attachments, err := s.State.UnitStorageAttachments(s.unit.UnitTag())
+	c.Assert(err, jc.ErrorIsNil)
+	var volume state.Volume
+	for _, attachment := range attachments {
+		storageInstance, err := s.State.StorageInstance(attachment.StorageInstance())
+		c.Assert(err, jc.ErrorIsNil)
+		if storageInstance.StorageName() == "allecto" {
+			volume = s.storageInstanceVolume(c, storageInstance.StorageTag())
+		}
+	}
+	c.Assert(volume, gc.NotNil)
+	volumeParams, ok := volume.Params()
+	c.Assert(ok, jc.IsTrue)
+	// The volume is created in the snapshot's pool, and is
+	// at least as large as the snapshotted volume.
+	c.Assert(volumeParams, jc.DeepEquals, state.VolumeParams{
+		Pool:       "persistent-block",
+		Size:       1024,
+		SnapshotId: "snap-0",
+	})
+}
+
+func (s *VolumeSnapshotSuite) TestAddStorageForUnitFromSnapshotPoolMismatch(c *gc.C) {
+	snapshot := s.addVolumeSnapshot(c)
+	err := s.State.AddStorageForUnitFromSnapshot(
+		s.unit.UnitTag(), "allecto",
+		state.StorageConstraints{Pool: "loop-pool", Count: 1},
+		snapshot.Id(),
+	)
+	c.Assert(err, jc.Satisfies, errors.IsNotValid)
+	c.Assert(err, gc.ErrorMatches, `storage pool "loop-pool" for snapshot "0" taken in pool "persistent-block" not valid`)
+}
+
+func (s *VolumeSnapshotSuite) TestAddStorageForUnitFromSnapshotNotFound(c *gc.C) {
+	err := s.State.AddStorageForUnitFromSnapshot(
+		s.unit.UnitTag(), "allecto",
+		state.StorageConstraints{Count: 1},
+		"42",
+	)
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+}
@@ -246,6 +246,34 @@ func (st *State) watchMachineStorage(m names.MachineTag, collection string) Stri
 	return newLifecycleWatcher(st, collection, members, filter, nil)
 }
 
+// WatchModelVolumeResizes returns a StringsWatcher that notifies of
+// changes to model-scoped volumes, including requests to resize them.
+func (st *State) WatchModelVolumeResizes() StringsWatcher {
+	filter := func(id interface{}) bool {
+		k, err := st.strictLocalID(id.(string))
+		if err != nil {
+			return false
+		}
+		return !strings.Contains(k, "/")
+	}
+	return newcollectionWatcher(st, colWCfg{col: volumesC, filter: filter})
+}
+
+// WatchMachineVolumeResizes returns a StringsWatcher that notifies of
+// changes to volumes scoped to the specified machine, including requests
+// to resize them.
+func (st *State) WatchMachineVolumeResizes(m names.MachineTag) StringsWatcher {
+	prefix := m.Id() + "/"
+	filter := func(id interface{}) bool {
+		k, err := st.strictLocalID(id.(string))
+		if err != nil {
+			return false
+		}
+		return strings.HasPrefix(k, prefix)
+	}
+	return newcollectionWatcher(st, colWCfg{col: volumesC, filter: filter})
+}
+
 // WatchEnvironVolumeAttachments returns a StringsWatcher that notifies of
 // changes to the lifecycles of all volume attachments related to environ-
 // scoped volumes.
@@ -1473,6 +1501,11 @@ func (st *State) WatchVolumeAttachment(m names.MachineTag, v names.VolumeTag) No
 	return newEntityWatcher(st, volumeAttachmentsC, st.docID(id))
 }
 
+// WatchVolume returns a watcher for observing changes to a volume.
+func (st *State) WatchVolume(v names.VolumeTag) NotifyWatcher {
+	return newEntityWatcher(st, volumesC, st.docID(v.Id()))
+}
+
 // WatchFilesystemAttachment returns a watcher for observing changes
 // to a filesystem attachment.
 func (st *State) WatchFilesystemAttachment(m names.MachineTag, f names.FilesystemTag) NotifyWatcher {
@@ -2573,6 +2606,81 @@ func (w *rebootWatcher) loop() error {
 	}
 }
 
+// WatchIngressRules returns a NotifyWatcher that notifies when the
+// ingress rules of the machine, or the set of machine addresses in the
+// model, may have changed: that is, when the ports opened on the
+// machine change, or any service or machine in the model changes.
+func (m *Machine) WatchIngressRules() NotifyWatcher {
+	return newIngressRulesWatcher(m.st, m.Id())
+}
+
+// ingressRulesWatcher notifies about changes that may affect the
+// ingress rules of a machine.
+type ingressRulesWatcher struct {
+	commonWatcher
+	machineId string
+	out       chan struct{}
+}
+
+var _ NotifyWatcher = (*ingressRulesWatcher)(nil)
+
+func newIngressRulesWatcher(st *State, machineId string) NotifyWatcher {
+	w := &ingressRulesWatcher{
+		commonWatcher: commonWatcher{st: st},
+		machineId:     machineId,
+		out:           make(chan struct{}),
+	}
+	go func() {
+		defer w.tomb.Done()
+		defer close(w.out)
+		w.tomb.Kill(w.loop())
+	}()
+	return w
+}
+
+// Changes returns the event channel for w.
+func (w *ingressRulesWatcher) Changes() <-chan struct{} {
+	return w.out
+}
+
+func (w *ingressRulesWatcher) loop() error {
+	in := make(chan watcher.Change)
+	portsFilter := func(key interface{}) bool {
+		if id, ok := key.(string); ok {
+			if id, err := w.st.strictLocalID(id); err == nil {
+				parts, err := extractPortsIDParts(id)
+				return err == nil && parts[machineIDPart] == w.machineId
+			}
+			return false
+		}
+		w.tomb.Kill(fmt.Errorf("expected string, got %T: %v", key, key))
+		return false
+	}
+	w.st.watcher.WatchCollectionWithFilter(openedPortsC, in, portsFilter)
+	defer w.st.watcher.UnwatchCollection(openedPortsC, in)
+	w.st.watcher.WatchCollectionWithFilter(servicesC, in, w.st.isForStateEnv)
+	defer w.st.watcher.UnwatchCollection(servicesC, in)
+	w.st.watcher.WatchCollectionWithFilter(machinesC, in, w.st.isForStateEnv)
+	defer w.st.watcher.UnwatchCollection(machinesC, in)
+
+	out := w.out
+	for {
+		select {
+		case <-w.tomb.Dying():
+			return tomb.ErrDying
+		case <-w.st.watcher.Dead():
+			return stateWatcherDeadError(w.st.watcher.Err())
+		case ch := <-in:
+			if _, ok := collect(ch, in, w.tomb.Dying()); !ok {
+				return tomb.ErrDying
+			}
+			out = w.out
+		case out <- struct{}{}:
+			out = nil
+		}
+	}
+}
+
 // blockDevicesWatcher notifies about changes to all block devices
 // associated with a machine.
 type blockDevicesWatcher struct {
@@ -2791,3 +2899,59 @@ func (w *migrationStatusWatcher) loop() error {
 		}
 	}
 }
+
+// minionReportsWatcher reports when migration minions report back
+// for a specific migration phase.
+type minionReportsWatcher struct {
+	commonWatcher
+	prefix string
+	sink   chan struct{}
+}
+
+func newMinionReportsWatcher(st *State, prefix string) NotifyWatcher {
+	w := &minionReportsWatcher{
+		commonWatcher: commonWatcher{st: st},
+		prefix:        prefix,
+		sink:          make(chan struct{}),
+	}
+	go func() {
+		defer w.tomb.Done()
+		defer close(w.sink)
+		w.tomb.Kill(w.loop())
+	}()
+	return w
+}
+
+// Changes returns the event channel for this watcher.
+func (w *minionReportsWatcher) Changes() <-chan struct{} {
+	return w.sink
+}
+
+func (w *minionReportsWatcher) loop() error {
+	in := make(chan watcher.Change)
+	filter := func(id interface{}) bool {
+		if id, ok := id.(string); ok {
+			return strings.HasPrefix(id, w.prefix)
+		}
+		return false
+	}
+	w.st.watcher.WatchCollectionWithFilter(migrationsMinionSyncC, in, filter)
+	defer w.st.watcher.UnwatchCollection(migrationsMinionSyncC, in)
+
+	out := w.sink // out set so that initial event is sent.
+	for {
+		select {
+		case <-w.tomb.Dying():
+			return tomb.ErrDying
+		case <-w.st.watcher.Dead():
+			return stateWatcherDeadError(w.st.watcher.Err())
+		case change := <-in:
+			if _, ok := collect(change, in, w.tomb.Dying()); !ok {
+				return tomb.ErrDying
+			}
+			out = w.sink
+		case out <- struct{}{}:
+			out = nil
+		}
+	}
+}
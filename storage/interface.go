@@ -108,6 +108,51 @@ type VolumeSource interface {
 	DetachVolumes(params []VolumeAttachmentParams) ([]error, error)
 }
 
+// VolumeImporter provides an interface for importing volumes that were
+// not created by Juju into the model. A VolumeSource may optionally
+// implement VolumeImporter, if the storage provider supports importing
+// volumes.
+type VolumeImporter interface {
+	// ImportVolume prepares the volume with the specified provider
+	// volume ID to be managed by Juju, tagging it with the given
+	// resource tags if the storage provider supports tags, and
+	// returns the information to record for the volume.
+	//
+	// ImportVolume must return an error if the volume is in use.
+	ImportVolume(volumeId string, resourceTags map[string]string) (VolumeInfo, error)
+}
+
+// VolumeSnapshotter provides an interface for creating, listing and
+// destroying snapshots of volumes. A VolumeSource may optionally
+// implement VolumeSnapshotter, if the storage provider supports volume
+// snapshots. A VolumeSource that implements VolumeSnapshotter must
+// honour VolumeParams.SnapshotId when creating volumes.
+type VolumeSnapshotter interface {
+	// CreateSnapshots creates snapshots of the volumes with the
+	// specified provider volume IDs.
+	CreateSnapshots(params []SnapshotParams) ([]CreateSnapshotsResult, error)
+
+	// ListSnapshots lists the provider snapshot IDs for every
+	// snapshot created by this volume source.
+	ListSnapshots() ([]string, error)
+
+	// DestroySnapshots destroys the snapshots with the specified
+	// provider snapshot IDs.
+	DestroySnapshots(snapshotIds []string) ([]error, error)
+}
+
+// VolumeResizer provides an interface for growing volumes. A
+// VolumeSource may optionally implement VolumeResizer, if the storage
+// provider supports resizing volumes while they are attached.
+type VolumeResizer interface {
+	// ResizeVolumes grows the volumes with the specified provider
+	// volume IDs to at least the requested sizes, and returns the
+	// updated information for each volume.
+	//
+	// ResizeVolumes must not shrink volumes.
+	ResizeVolumes(params []VolumeResizeParams) ([]ResizeVolumesResult, error)
+}
+
 // FilesystemSource provides an interface for creating, destroying and
 // describing filesystems in the environment. A FilesystemSource is
 // configured in a particular way, and corresponds to a storage "pool".
@@ -164,6 +209,11 @@ type VolumeParams struct {
 	// storage provider supports tags.
 	ResourceTags map[string]string
 
+	// SnapshotId, if non-empty, is the provider snapshot ID of the
+	// snapshot from which to create the volume. Only VolumeSources
+	// that implement VolumeSnapshotter will be given a SnapshotId.
+	SnapshotId string
+
 	// Attachment identifies the machine that the volume should be attached
 	// to initially, or nil if the volume should not be attached to any
 	// machine. Some providers, such as MAAS, do not support dynamic
@@ -212,6 +262,10 @@ type AttachmentParams struct {
 
 	// ReadOnly indicates that the storage should be attached as read-only.
 	ReadOnly bool
+
+	// Attributes is the set of provider-specific attributes of the
+	// storage pool that the volume or filesystem was created in.
+	Attributes map[string]interface{}
 }
 
 // FilesystemParams is a fully specified set of parameters for filesystem creation,
@@ -266,6 +320,50 @@ type CreateVolumesResult struct {
 	Error            error
 }
 
+// SnapshotParams is a fully specified set of parameters for creating
+// a snapshot of a volume.
+type SnapshotParams struct {
+	// VolumeId is the provider volume ID of the volume to snapshot.
+	VolumeId string
+
+	// ResourceTags is a set of tags to set on the created snapshot,
+	// if the storage provider supports tags.
+	ResourceTags map[string]string
+}
+
+// CreateSnapshotsResult contains the result of a
+// VolumeSnapshotter.CreateSnapshots call for one snapshot. Snapshot
+// should only be used if Error is nil.
+type CreateSnapshotsResult struct {
+	Snapshot *SnapshotInfo
+	Error    error
+}
+
+// VolumeResizeParams is a fully specified set of parameters for
+// resizing a volume.
+type VolumeResizeParams struct {
+	// Tag is the tag of the volume to resize.
+	Tag names.VolumeTag
+
+	// VolumeId is the provider volume ID of the volume to resize.
+	VolumeId string
+
+	// Size is the requested size of the volume, in MiB.
+	Size uint64
+
+	// Provider is the name of the storage provider that manages
+	// the volume.
+	Provider ProviderType
+}
+
+// ResizeVolumesResult contains the result of a
+// VolumeResizer.ResizeVolumes call for one volume. VolumeInfo
+// should only be used if Error is nil.
+type ResizeVolumesResult struct {
+	VolumeInfo *VolumeInfo
+	Error      error
+}
+
 // DescribeVolumesResult contains the result of a VolumeSource.DescribeVolumes call
 // for one volume. Volume should only be used if Error is nil.
 type DescribeVolumesResult struct {
@@ -4,7 +4,10 @@
 package provider
 
 import (
+	"fmt"
+
 	"github.com/juju/errors"
+	"github.com/juju/names"
 
 	"github.com/juju/juju/storage"
 )
@@ -15,6 +18,8 @@ var errNoMountPoint = errors.New("filesystem mount point not specified")
 func CommonProviders() map[storage.ProviderType]storage.Provider {
 	return map[storage.ProviderType]storage.Provider{
 		LoopProviderType:   &loopProvider{logAndExec},
+		NfsProviderType:    &nfsProvider{logAndExec},
+		RbdProviderType:    &rbdProvider{logAndExec},
 		RootfsProviderType: &rootfsProvider{logAndExec},
 		TmpfsProviderType:  &tmpfsProvider{logAndExec},
 	}
@@ -25,3 +30,10 @@ func CommonProviders() map[storage.ProviderType]storage.Provider {
 func ValidateConfig(p storage.Provider, cfg *storage.Config) error {
 	return p.ValidateConfig(cfg)
 }
+
+// sharedResourceName returns the name to give to a resource created
+// on storage that may be shared by several models, such as a Ceph pool
+// or an NFS export.
+func sharedResourceName(modelUUID string, tag names.Tag) string {
+	return fmt.Sprintf("juju-%s-%s", modelUUID, tag)
+}
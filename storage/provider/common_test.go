@@ -27,6 +27,8 @@ func (s *providerCommonSuite) TestCommonProvidersExported(c *gc.C) {
 	}
 	c.Assert(common, jc.SameContents, []storage.ProviderType{
 		provider.LoopProviderType,
+		provider.NfsProviderType,
+		provider.RbdProviderType,
 		provider.RootfsProviderType,
 		provider.TmpfsProviderType,
 	})
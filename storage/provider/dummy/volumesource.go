@@ -20,6 +20,11 @@ type VolumeSource struct {
 	ValidateVolumeParamsFunc func(storage.VolumeParams) error
 	AttachVolumesFunc        func([]storage.VolumeAttachmentParams) ([]storage.AttachVolumesResult, error)
 	DetachVolumesFunc        func([]storage.VolumeAttachmentParams) ([]error, error)
+	ImportVolumeFunc         func(string, map[string]string) (storage.VolumeInfo, error)
+	CreateSnapshotsFunc      func([]storage.SnapshotParams) ([]storage.CreateSnapshotsResult, error)
+	ListSnapshotsFunc        func() ([]string, error)
+	DestroySnapshotsFunc     func([]string) ([]error, error)
+	ResizeVolumesFunc        func([]storage.VolumeResizeParams) ([]storage.ResizeVolumesResult, error)
 }
 
 // CreateVolumes is defined on storage.VolumeSource.
@@ -84,3 +89,48 @@ func (s *VolumeSource) DetachVolumes(params []storage.VolumeAttachmentParams) ([
 	}
 	return nil, errors.NotImplementedf("DetachVolumes")
 }
+
+// ImportVolume is defined on storage.VolumeImporter.
+func (s *VolumeSource) ImportVolume(volumeId string, resourceTags map[string]string) (storage.VolumeInfo, error) {
+	s.MethodCall(s, "ImportVolume", volumeId, resourceTags)
+	if s.ImportVolumeFunc != nil {
+		return s.ImportVolumeFunc(volumeId, resourceTags)
+	}
+	return storage.VolumeInfo{}, errors.NotImplementedf("ImportVolume")
+}
+
+// CreateSnapshots is defined on storage.VolumeSnapshotter.
+func (s *VolumeSource) CreateSnapshots(params []storage.SnapshotParams) ([]storage.CreateSnapshotsResult, error) {
+	s.MethodCall(s, "CreateSnapshots", params)
+	if s.CreateSnapshotsFunc != nil {
+		return s.CreateSnapshotsFunc(params)
+	}
+	return nil, errors.NotImplementedf("CreateSnapshots")
+}
+
+// ListSnapshots is defined on storage.VolumeSnapshotter.
+func (s *VolumeSource) ListSnapshots() ([]string, error) {
+	s.MethodCall(s, "ListSnapshots")
+	if s.ListSnapshotsFunc != nil {
+		return s.ListSnapshotsFunc()
+	}
+	return nil, nil
+}
+
+// DestroySnapshots is defined on storage.VolumeSnapshotter.
+func (s *VolumeSource) DestroySnapshots(snapshotIds []string) ([]error, error) {
+	s.MethodCall(s, "DestroySnapshots", snapshotIds)
+	if s.DestroySnapshotsFunc != nil {
+		return s.DestroySnapshotsFunc(snapshotIds)
+	}
+	return nil, errors.NotImplementedf("DestroySnapshots")
+}
+
+// ResizeVolumes is defined on storage.VolumeResizer.
+func (s *VolumeSource) ResizeVolumes(params []storage.VolumeResizeParams) ([]storage.ResizeVolumesResult, error) {
+	s.MethodCall(s, "ResizeVolumes", params)
+	if s.ResizeVolumesFunc != nil {
+		return s.ResizeVolumesFunc(params)
+	}
+	return nil, errors.NotImplementedf("ResizeVolumes")
+}
@@ -129,6 +129,31 @@ func TmpfsProvider(run func(string, ...string) (string, error)) storage.Provider
 	return &tmpfsProvider{run}
 }
 
+func NfsFilesystemSource(storageDir string, attrs map[string]interface{}, modelUUID string, run func(string, ...string) (string, error)) storage.FilesystemSource {
+	return &nfsFilesystemSource{
+		&MockDirFuncs{
+			osDirFuncs{run},
+			set.NewStrings(),
+		},
+		run,
+		storageDir,
+		attrs,
+		modelUUID,
+	}
+}
+
+func NfsProvider(run func(string, ...string) (string, error)) storage.Provider {
+	return &nfsProvider{run}
+}
+
+func RbdVolumeSource(attrs map[string]interface{}, modelUUID string, run func(string, ...string) (string, error)) storage.VolumeSource {
+	return &rbdVolumeSource{run, attrs, modelUUID}
+}
+
+func RbdProvider(run func(string, ...string) (string, error)) storage.Provider {
+	return &rbdProvider{run}
+}
+
 // MountedDirs returns all the Dirs which have been created during any CreateFilesystem calls
 // on the specified filesystem source..
 func MountedDirs(fsSource storage.FilesystemSource) set.Strings {
@@ -5,9 +5,11 @@ package provider
 
 import (
 	"fmt"
+	"io/ioutil"
 	"os"
 	"path"
 	"path/filepath"
+	"strconv"
 	"strings"
 
 	"github.com/juju/errors"
@@ -100,6 +102,8 @@ type loopVolumeSource struct {
 }
 
 var _ storage.VolumeSource = (*loopVolumeSource)(nil)
+var _ storage.VolumeSnapshotter = (*loopVolumeSource)(nil)
+var _ storage.VolumeResizer = (*loopVolumeSource)(nil)
 
 // CreateVolumes is defined on the VolumeSource interface.
 func (lvs *loopVolumeSource) CreateVolumes(args []storage.VolumeParams) ([]storage.CreateVolumesResult, error) {
@@ -120,6 +124,17 @@ func (lvs *loopVolumeSource) createVolume(params storage.VolumeParams) (storage.
 	if err := ensureDir(lvs.dirFuncs, filepath.Dir(loopFilePath)); err != nil {
 		return storage.Volume{}, errors.Trace(err)
 	}
+	if params.SnapshotId != "" {
+		snapshotFilePath, err := lvs.snapshotFilePath(params.SnapshotId)
+		if err != nil {
+			return storage.Volume{}, errors.Trace(err)
+		}
+		if err := copyBlockFile(lvs.run, snapshotFilePath, loopFilePath); err != nil {
+			return storage.Volume{}, errors.Annotate(err, "could not restore snapshot")
+		}
+	}
+	// If the volume was restored from a snapshot, fallocate will
+	// extend the restored file to the requested size, if necessary.
 	if err := createBlockFile(lvs.run, loopFilePath, params.Size); err != nil {
 		return storage.Volume{}, errors.Annotate(err, "could not create block file")
 	}
@@ -239,6 +254,175 @@ func (lvs *loopVolumeSource) detachVolume(tag names.VolumeTag) error {
 	return nil
 }
 
+// ResizeVolumes is defined on the VolumeResizer interface.
+func (lvs *loopVolumeSource) ResizeVolumes(args []storage.VolumeResizeParams) ([]storage.ResizeVolumesResult, error) {
+	results := make([]storage.ResizeVolumesResult, len(args))
+	for i, arg := range args {
+		if err := lvs.resizeVolume(arg); err != nil {
+			results[i].Error = errors.Annotatef(err, "resizing volume %s", arg.Tag.Id())
+			continue
+		}
+		results[i].VolumeInfo = &storage.VolumeInfo{
+			VolumeId: arg.VolumeId,
+			Size:     arg.Size,
+		}
+	}
+	return results, nil
+}
+
+func (lvs *loopVolumeSource) resizeVolume(arg storage.VolumeResizeParams) error {
+	loopFilePath := lvs.volumeFilePath(arg.Tag)
+	if _, err := os.Stat(loopFilePath); err != nil {
+		return errors.Annotate(err, "locating loop backing file")
+	}
+	// fallocate will extend the file to the requested size,
+	// leaving the existing contents intact.
+	if err := createBlockFile(lvs.run, loopFilePath, arg.Size); err != nil {
+		return errors.Trace(err)
+	}
+	// Any loop devices attached to the file must be told to
+	// pick up the new size of the backing file.
+	deviceNames, err := associatedLoopDevices(lvs.run, loopFilePath)
+	if err != nil {
+		return errors.Annotate(err, "locating loop device")
+	}
+	for _, deviceName := range deviceNames {
+		if err := resizeLoopDevice(lvs.run, deviceName); err != nil {
+			return errors.Trace(err)
+		}
+	}
+	return nil
+}
+
+// loopSnapshotsDir is the name of the directory, within the storage
+// directory, in which snapshots of loop volumes are stored.
+const loopSnapshotsDir = "snapshots"
+
+func (lvs *loopVolumeSource) snapshotsDir() string {
+	return filepath.Join(lvs.storageDir, loopSnapshotsDir)
+}
+
+// snapshotFilePath returns the path of the file holding the snapshot
+// with the specified ID. Snapshot IDs have the format
+// "<volume ID>-snapshot-<n>".
+func (lvs *loopVolumeSource) snapshotFilePath(snapshotId string) (string, error) {
+	pos := strings.LastIndex(snapshotId, "-snapshot-")
+	if pos == -1 {
+		return "", errors.Errorf("invalid loop snapshot ID %q", snapshotId)
+	}
+	if _, err := names.ParseVolumeTag(snapshotId[:pos]); err != nil {
+		return "", errors.Errorf("invalid loop snapshot ID %q", snapshotId)
+	}
+	if _, err := strconv.ParseUint(snapshotId[pos+len("-snapshot-"):], 10, 64); err != nil {
+		return "", errors.Errorf("invalid loop snapshot ID %q", snapshotId)
+	}
+	return filepath.Join(lvs.snapshotsDir(), snapshotId), nil
+}
+
+// CreateSnapshots is defined on the VolumeSnapshotter interface.
+func (lvs *loopVolumeSource) CreateSnapshots(args []storage.SnapshotParams) ([]storage.CreateSnapshotsResult, error) {
+	results := make([]storage.CreateSnapshotsResult, len(args))
+	for i, arg := range args {
+		snapshot, err := lvs.createSnapshot(arg)
+		if err != nil {
+			results[i].Error = errors.Annotatef(err, "creating snapshot of %q", arg.VolumeId)
+			continue
+		}
+		results[i].Snapshot = snapshot
+	}
+	return results, nil
+}
+
+func (lvs *loopVolumeSource) createSnapshot(arg storage.SnapshotParams) (*storage.SnapshotInfo, error) {
+	tag, err := names.ParseVolumeTag(arg.VolumeId)
+	if err != nil {
+		return nil, errors.Errorf("invalid loop volume ID %q", arg.VolumeId)
+	}
+	loopFilePath := lvs.volumeFilePath(tag)
+	info, err := os.Stat(loopFilePath)
+	if err != nil {
+		return nil, errors.Annotate(err, "getting loop backing file size")
+	}
+	if err := ensureDir(lvs.dirFuncs, lvs.snapshotsDir()); err != nil {
+		return nil, errors.Trace(err)
+	}
+	existing, err := lvs.ListSnapshots()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	// Snapshots of a volume are numbered from zero, in the order
+	// in which they were created.
+	var seq uint64
+	prefix := arg.VolumeId + "-snapshot-"
+	for _, snapshotId := range existing {
+		if !strings.HasPrefix(snapshotId, prefix) {
+			continue
+		}
+		n, err := strconv.ParseUint(snapshotId[len(prefix):], 10, 64)
+		if err == nil && n >= seq {
+			seq = n + 1
+		}
+	}
+	snapshotId := fmt.Sprintf("%s%d", prefix, seq)
+	snapshotFilePath := filepath.Join(lvs.snapshotsDir(), snapshotId)
+	if err := copyBlockFile(lvs.run, loopFilePath, snapshotFilePath); err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &storage.SnapshotInfo{
+		SnapshotId: snapshotId,
+		VolumeId:   arg.VolumeId,
+		Size:       uint64(info.Size()) / (1024 * 1024),
+	}, nil
+}
+
+// ListSnapshots is defined on the VolumeSnapshotter interface.
+func (lvs *loopVolumeSource) ListSnapshots() ([]string, error) {
+	fileInfos, err := ioutil.ReadDir(lvs.snapshotsDir())
+	if os.IsNotExist(err) {
+		return nil, nil
+	} else if err != nil {
+		return nil, errors.Annotate(err, "listing loop snapshots")
+	}
+	snapshotIds := make([]string, 0, len(fileInfos))
+	for _, fileInfo := range fileInfos {
+		snapshotIds = append(snapshotIds, fileInfo.Name())
+	}
+	return snapshotIds, nil
+}
+
+// DestroySnapshots is defined on the VolumeSnapshotter interface.
+func (lvs *loopVolumeSource) DestroySnapshots(snapshotIds []string) ([]error, error) {
+	results := make([]error, len(snapshotIds))
+	for i, snapshotId := range snapshotIds {
+		if err := lvs.destroySnapshot(snapshotId); err != nil {
+			results[i] = errors.Annotatef(err, "destroying %q", snapshotId)
+		}
+	}
+	return results, nil
+}
+
+func (lvs *loopVolumeSource) destroySnapshot(snapshotId string) error {
+	snapshotFilePath, err := lvs.snapshotFilePath(snapshotId)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	err = os.Remove(snapshotFilePath)
+	if err != nil && !os.IsNotExist(err) {
+		return errors.Annotate(err, "removing loop snapshot file")
+	}
+	return nil
+}
+
+// copyBlockFile copies the file at the source path to the target
+// path, preserving holes in sparse files.
+func copyBlockFile(run runCommandFunc, sourcePath, targetPath string) error {
+	_, err := run("cp", "--sparse=always", sourcePath, targetPath)
+	if err != nil {
+		return errors.Annotatef(err, "copying %q to %q", sourcePath, targetPath)
+	}
+	return nil
+}
+
 // createBlockFile creates a file at the specified path, with the
 // given size in mebibytes.
 func createBlockFile(run runCommandFunc, filePath string, sizeInMiB uint64) error {
@@ -289,6 +473,16 @@ func detachLoopDevice(run runCommandFunc, deviceName string) error {
 	return err
 }
 
+// resizeLoopDevice updates the size of the loop device with the
+// specified name to match the size of its backing file.
+func resizeLoopDevice(run runCommandFunc, deviceName string) error {
+	_, err := run("losetup", "-c", path.Join("/dev", deviceName))
+	if err != nil {
+		return errors.Annotatef(err, "resizing loop device %q", deviceName)
+	}
+	return nil
+}
+
 // associatedLoopDevices returns the device names of the loop devices
 // associated with the specified file path.
 func associatedLoopDevices(run runCommandFunc, filePath string) ([]string, error) {
@@ -125,6 +125,140 @@ func (s *loopSuite) TestCreateVolumesNoAttachment(c *gc.C) {
 	c.Assert(err, jc.ErrorIsNil)
 }
 
+func (s *loopSuite) TestCreateVolumesFromSnapshot(c *gc.C) {
+	source, _ := s.loopVolumeSource(c)
+	s.commands.expect("cp", "--sparse=always",
+		filepath.Join(s.storageDir, "snapshots", "volume-1-snapshot-0"),
+		filepath.Join(s.storageDir, "volume-0"),
+	)
+	s.commands.expect("fallocate", "-l", "2MiB", filepath.Join(s.storageDir, "volume-0"))
+
+	results, err := source.CreateVolumes([]storage.VolumeParams{{
+		Tag:        names.NewVolumeTag("0"),
+		Size:       2,
+		SnapshotId: "volume-1-snapshot-0",
+	}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, gc.HasLen, 1)
+	c.Assert(results[0].Error, jc.ErrorIsNil)
+	c.Assert(results[0].Volume, jc.DeepEquals, &storage.Volume{
+		names.NewVolumeTag("0"),
+		storage.VolumeInfo{
+			VolumeId: "volume-0",
+			Size:     2,
+		},
+	})
+}
+
+func (s *loopSuite) TestCreateSnapshots(c *gc.C) {
+	source, _ := s.loopVolumeSource(c)
+	err := ioutil.WriteFile(filepath.Join(s.storageDir, "volume-0"), nil, 0644)
+	c.Assert(err, jc.ErrorIsNil)
+	err = os.Truncate(filepath.Join(s.storageDir, "volume-0"), 2*1024*1024)
+	c.Assert(err, jc.ErrorIsNil)
+
+	// An earlier snapshot of volume-0 exists, so the
+	// new snapshot is numbered after it.
+	err = os.Mkdir(filepath.Join(s.storageDir, "snapshots"), 0755)
+	c.Assert(err, jc.ErrorIsNil)
+	err = ioutil.WriteFile(filepath.Join(s.storageDir, "snapshots", "volume-0-snapshot-0"), nil, 0644)
+	c.Assert(err, jc.ErrorIsNil)
+
+	s.commands.expect("cp", "--sparse=always",
+		filepath.Join(s.storageDir, "volume-0"),
+		filepath.Join(s.storageDir, "snapshots", "volume-0-snapshot-1"),
+	)
+	snapshotter := source.(storage.VolumeSnapshotter)
+	results, err := snapshotter.CreateSnapshots([]storage.SnapshotParams{
+		{VolumeId: "volume-0"},
+		{VolumeId: "volume-1"},
+		{VolumeId: "../super/important/stuff"},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, gc.HasLen, 3)
+	c.Assert(results[0].Error, jc.ErrorIsNil)
+	c.Assert(results[0].Snapshot, jc.DeepEquals, &storage.SnapshotInfo{
+		SnapshotId: "volume-0-snapshot-1",
+		VolumeId:   "volume-0",
+		Size:       2,
+	})
+	c.Assert(results[1].Error, gc.ErrorMatches, `creating snapshot of "volume-1": getting loop backing file size: .*`)
+	c.Assert(results[2].Error, gc.ErrorMatches, `.* invalid loop volume ID "\.\./super/important/stuff"`)
+}
+
+func (s *loopSuite) TestListSnapshots(c *gc.C) {
+	source, _ := s.loopVolumeSource(c)
+	snapshotter := source.(storage.VolumeSnapshotter)
+	snapshotIds, err := snapshotter.ListSnapshots()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(snapshotIds, gc.HasLen, 0)
+
+	err = os.Mkdir(filepath.Join(s.storageDir, "snapshots"), 0755)
+	c.Assert(err, jc.ErrorIsNil)
+	for _, snapshotId := range []string{"volume-0-snapshot-0", "volume-1-snapshot-0"} {
+		err = ioutil.WriteFile(filepath.Join(s.storageDir, "snapshots", snapshotId), nil, 0644)
+		c.Assert(err, jc.ErrorIsNil)
+	}
+	snapshotIds, err = snapshotter.ListSnapshots()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(snapshotIds, jc.SameContents, []string{"volume-0-snapshot-0", "volume-1-snapshot-0"})
+}
+
+func (s *loopSuite) TestDestroySnapshots(c *gc.C) {
+	source, _ := s.loopVolumeSource(c)
+	fileName := filepath.Join(s.storageDir, "snapshots", "volume-0-snapshot-0")
+	err := os.Mkdir(filepath.Dir(fileName), 0755)
+	c.Assert(err, jc.ErrorIsNil)
+	err = ioutil.WriteFile(fileName, nil, 0644)
+	c.Assert(err, jc.ErrorIsNil)
+
+	snapshotter := source.(storage.VolumeSnapshotter)
+	errs, err := snapshotter.DestroySnapshots([]string{
+		"volume-0-snapshot-0",
+		"../super/important/stuff",
+		"../../snapshot-0",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(errs, gc.HasLen, 3)
+	c.Assert(errs[0], jc.ErrorIsNil)
+	c.Assert(errs[1], gc.ErrorMatches, `.* invalid loop snapshot ID "\.\./super/important/stuff"`)
+	c.Assert(errs[2], gc.ErrorMatches, `.* invalid loop snapshot ID "\.\./\.\./snapshot-0"`)
+
+	_, err = os.Stat(fileName)
+	c.Assert(err, jc.Satisfies, os.IsNotExist)
+}
+
+func (s *loopSuite) TestResizeVolumes(c *gc.C) {
+	source, _ := s.loopVolumeSource(c)
+	fileName := filepath.Join(s.storageDir, "volume-0")
+	err := ioutil.WriteFile(fileName, nil, 0644)
+	c.Assert(err, jc.ErrorIsNil)
+
+	s.commands.expect("fallocate", "-l", "4MiB", fileName)
+	cmd := s.commands.expect("losetup", "-j", fileName)
+	cmd.respond("/dev/loop0: foo\n", nil)
+	s.commands.expect("losetup", "-c", "/dev/loop0")
+
+	resizer := source.(storage.VolumeResizer)
+	results, err := resizer.ResizeVolumes([]storage.VolumeResizeParams{{
+		Tag:      names.NewVolumeTag("0"),
+		VolumeId: "volume-0",
+		Size:     4,
+	}, {
+		Tag:      names.NewVolumeTag("1"),
+		VolumeId: "volume-1",
+		Size:     4,
+	}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, gc.HasLen, 2)
+	c.Assert(results[0].Error, jc.ErrorIsNil)
+	c.Assert(results[0].VolumeInfo, jc.DeepEquals, &storage.VolumeInfo{
+		VolumeId: "volume-0",
+		Size:     4,
+	})
+	c.Assert(results[1].Error, gc.ErrorMatches, `resizing volume 1: locating loop backing file: .*`)
+}
+
 func (s *loopSuite) TestDestroyVolumes(c *gc.C) {
 	source, _ := s.loopVolumeSource(c)
 	fileName := filepath.Join(s.storageDir, "volume-0")
@@ -0,0 +1,318 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package provider
+
+import (
+	"os"
+	"path"
+	"path/filepath"
+
+	"github.com/juju/errors"
+	"github.com/juju/schema"
+
+	"github.com/juju/juju/environs/config"
+	"github.com/juju/juju/storage"
+)
+
+const (
+	NfsProviderType = storage.ProviderType("nfs")
+
+	// Config attributes
+	NfsServer  = "server"  // host name or address of the NFS server
+	NfsExport  = "export"  // absolute path of the export on the server
+	NfsOptions = "options" // comma-separated mount options
+)
+
+// nfsProviders create storage sources which provide access to
+// directories on a shared NFS export.
+type nfsProvider struct {
+	// run is a function type used for running commands on the local machine.
+	run runCommandFunc
+}
+
+var (
+	_ storage.Provider = (*nfsProvider)(nil)
+)
+
+var nfsConfigFields = schema.Fields{
+	NfsServer:  schema.String(),
+	NfsExport:  schema.String(),
+	NfsOptions: schema.String(),
+}
+
+var nfsConfigChecker = schema.FieldMap(
+	nfsConfigFields,
+	schema.Defaults{
+		NfsOptions: "",
+	},
+)
+
+// nfsConfig holds the NFS pool attributes.
+type nfsConfig struct {
+	Server  string
+	Export  string
+	Options string
+}
+
+func newNfsConfig(attrs map[string]interface{}) (*nfsConfig, error) {
+	out, err := nfsConfigChecker.Coerce(attrs, nil)
+	if err != nil {
+		return nil, errors.Annotate(err, "validating NFS storage config")
+	}
+	coerced := out.(map[string]interface{})
+	nfsConfig := &nfsConfig{
+		Server:  coerced[NfsServer].(string),
+		Export:  coerced[NfsExport].(string),
+		Options: coerced[NfsOptions].(string),
+	}
+	if nfsConfig.Server == "" {
+		return nil, errors.New("NFS server not specified")
+	}
+	if !path.IsAbs(nfsConfig.Export) {
+		return nil, errors.Errorf("NFS export %q must be an absolute path", nfsConfig.Export)
+	}
+	return nfsConfig, nil
+}
+
+// source returns the NFS mount source for the directory with the
+// given name in the export.
+func (c *nfsConfig) source(name string) string {
+	return c.Server + ":" + path.Join(c.Export, name)
+}
+
+// ValidateConfig is defined on the Provider interface.
+func (p *nfsProvider) ValidateConfig(cfg *storage.Config) error {
+	_, err := newNfsConfig(cfg.Attrs())
+	return errors.Trace(err)
+}
+
+// VolumeSource is defined on the Provider interface.
+func (p *nfsProvider) VolumeSource(environConfig *config.Config, providerConfig *storage.Config) (storage.VolumeSource, error) {
+	return nil, errors.NotSupportedf("volumes")
+}
+
+// FilesystemSource is defined on the Provider interface.
+func (p *nfsProvider) FilesystemSource(environConfig *config.Config, sourceConfig *storage.Config) (storage.FilesystemSource, error) {
+	// The storage provisioner creates a source for each distinct
+	// storage pool, with the pool's attributes in the source config.
+	// The attributes are validated when they are needed.
+	storageDir, ok := sourceConfig.ValueString(storage.ConfigStorageDir)
+	if !ok || storageDir == "" {
+		return nil, errors.New("storage directory not specified")
+	}
+	return &nfsFilesystemSource{
+		&osDirFuncs{p.run},
+		p.run,
+		storageDir,
+		sourceConfig.Attrs(),
+		environConfig.UUID(),
+	}, nil
+}
+
+// Supports is defined on the Provider interface.
+func (*nfsProvider) Supports(k storage.StorageKind) bool {
+	return k == storage.StorageKindFilesystem
+}
+
+// Scope is defined on the Provider interface.
+func (*nfsProvider) Scope() storage.Scope {
+	return storage.ScopeMachine
+}
+
+// Dynamic is defined on the Provider interface.
+func (*nfsProvider) Dynamic() bool {
+	return true
+}
+
+// nfsFilesystemSource creates a directory on the NFS export for each
+// filesystem, and mounts that directory when the filesystem is attached.
+type nfsFilesystemSource struct {
+	dirFuncs   dirFuncs
+	run        runCommandFunc
+	storageDir string
+	attrs      map[string]interface{}
+	modelUUID  string
+}
+
+var _ storage.FilesystemSource = (*nfsFilesystemSource)(nil)
+
+// config returns the NFS configuration of the storage pool that the
+// source was created for.
+func (s *nfsFilesystemSource) config() (*nfsConfig, error) {
+	return newNfsConfig(s.attrs)
+}
+
+// ValidateFilesystemParams is defined on the FilesystemSource interface.
+func (s *nfsFilesystemSource) ValidateFilesystemParams(params storage.FilesystemParams) error {
+	_, err := newNfsConfig(params.Attributes)
+	return errors.Trace(err)
+}
+
+// CreateFilesystems is defined on the FilesystemSource interface.
+func (s *nfsFilesystemSource) CreateFilesystems(args []storage.FilesystemParams) ([]storage.CreateFilesystemsResult, error) {
+	results := make([]storage.CreateFilesystemsResult, len(args))
+	for i, arg := range args {
+		filesystem, err := s.createFilesystem(arg)
+		if err != nil {
+			results[i].Error = err
+			continue
+		}
+		results[i].Filesystem = filesystem
+	}
+	return results, nil
+}
+
+func (s *nfsFilesystemSource) createFilesystem(params storage.FilesystemParams) (*storage.Filesystem, error) {
+	cfg, err := newNfsConfig(params.Attributes)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	// The export may be shared by several models, so the directory
+	// name must identify the model as well as the filesystem.
+	filesystemId := sharedResourceName(s.modelUUID, params.Tag)
+	if err := s.withExport(cfg, func(exportDir string) error {
+		return ensureDir(s.dirFuncs, filepath.Join(exportDir, filesystemId))
+	}); err != nil {
+		return nil, errors.Annotate(err, "creating directory on NFS export")
+	}
+	// NFS does not limit the size of the directory, so the
+	// requested size is recorded as-is.
+	info := storage.FilesystemInfo{
+		FilesystemId: filesystemId,
+		Size:         params.Size,
+	}
+	return &storage.Filesystem{params.Tag, params.Volume, info}, nil
+}
+
+// DestroyFilesystems is defined on the FilesystemSource interface.
+func (s *nfsFilesystemSource) DestroyFilesystems(filesystemIds []string) ([]error, error) {
+	results := make([]error, len(filesystemIds))
+	for i, filesystemId := range filesystemIds {
+		if err := s.destroyFilesystem(filesystemId); err != nil {
+			results[i] = errors.Annotatef(err, "destroying %q", filesystemId)
+		}
+	}
+	return results, nil
+}
+
+func (s *nfsFilesystemSource) destroyFilesystem(filesystemId string) error {
+	cfg, err := s.config()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if err := s.withExport(cfg, func(exportDir string) error {
+		return os.RemoveAll(filepath.Join(exportDir, filesystemId))
+	}); err != nil {
+		return errors.Annotate(err, "removing directory from NFS export")
+	}
+	return nil
+}
+
+// withExport mounts the root of the NFS export in the storage
+// directory, calls f with the mount point, and then unmounts it.
+func (s *nfsFilesystemSource) withExport(cfg *nfsConfig, f func(exportDir string) error) error {
+	exportDir := filepath.Join(s.storageDir, "export")
+	if err := ensureDir(s.dirFuncs, exportDir); err != nil {
+		return errors.Trace(err)
+	}
+	// The export may have been left mounted if the agent was
+	// interrupted while the directory was being created or removed.
+	source, err := s.dirFuncs.mountPointSource(exportDir)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if source != cfg.source("") {
+		if err := ensureEmptyDir(s.dirFuncs, exportDir); err != nil {
+			return errors.Trace(err)
+		}
+		if _, err := s.run("mount", nfsMountArgs(cfg.source(""), exportDir, cfg.Options)...); err != nil {
+			return errors.Annotate(err, "cannot mount NFS export")
+		}
+	}
+	err = f(exportDir)
+	if _, unmountErr := s.run("umount", exportDir); unmountErr != nil && err == nil {
+		err = errors.Annotate(unmountErr, "cannot unmount NFS export")
+	}
+	return err
+}
+
+// AttachFilesystems is defined on the FilesystemSource interface.
+func (s *nfsFilesystemSource) AttachFilesystems(args []storage.FilesystemAttachmentParams) ([]storage.AttachFilesystemsResult, error) {
+	results := make([]storage.AttachFilesystemsResult, len(args))
+	for i, arg := range args {
+		attachment, err := s.attachFilesystem(arg)
+		if err != nil {
+			results[i].Error = err
+			continue
+		}
+		results[i].FilesystemAttachment = attachment
+	}
+	return results, nil
+}
+
+func (s *nfsFilesystemSource) attachFilesystem(arg storage.FilesystemAttachmentParams) (*storage.FilesystemAttachment, error) {
+	path := arg.Path
+	if path == "" {
+		return nil, errNoMountPoint
+	}
+	cfg, err := s.config()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if err := ensureDir(s.dirFuncs, path); err != nil {
+		return nil, errors.Trace(err)
+	}
+
+	// Check if the mount already exists.
+	nfsSource := cfg.source(arg.FilesystemId)
+	source, err := s.dirFuncs.mountPointSource(path)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if source != nfsSource {
+		if err := ensureEmptyDir(s.dirFuncs, path); err != nil {
+			return nil, err
+		}
+		options := cfg.Options
+		if arg.ReadOnly {
+			if options != "" {
+				options += ","
+			}
+			options += "ro"
+		}
+		if _, err := s.run("mount", nfsMountArgs(nfsSource, path, options)...); err != nil {
+			return nil, errors.Annotate(err, "cannot mount NFS filesystem")
+		}
+	}
+
+	return &storage.FilesystemAttachment{
+		arg.Filesystem,
+		arg.Machine,
+		storage.FilesystemAttachmentInfo{
+			Path:     path,
+			ReadOnly: arg.ReadOnly,
+		},
+	}, nil
+}
+
+// DetachFilesystems is defined on the FilesystemSource interface.
+func (s *nfsFilesystemSource) DetachFilesystems(args []storage.FilesystemAttachmentParams) ([]error, error) {
+	results := make([]error, len(args))
+	for i, arg := range args {
+		if err := maybeUnmount(s.run, s.dirFuncs, arg.Path); err != nil {
+			results[i] = err
+		}
+	}
+	return results, nil
+}
+
+// nfsMountArgs returns the arguments to "mount" for mounting the
+// given NFS source at the specified path.
+func nfsMountArgs(source, path, options string) []string {
+	args := []string{"-t", "nfs", source, path}
+	if options != "" {
+		args = append(args, "-o", options)
+	}
+	return args
+}
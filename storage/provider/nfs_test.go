@@ -0,0 +1,344 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package provider_test
+
+import (
+	"errors"
+	"path/filepath"
+	"runtime"
+
+	"github.com/juju/names"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/storage"
+	"github.com/juju/juju/storage/provider"
+	"github.com/juju/juju/testing"
+)
+
+var _ = gc.Suite(&nfsSuite{})
+
+type nfsSuite struct {
+	testing.BaseSuite
+	storageDir string
+	commands   *mockRunCommand
+}
+
+const nfsFilesystemId = "juju-deadbeef-0bad-400d-8000-4b1d0d06f00d-filesystem-1"
+
+func (s *nfsSuite) SetUpTest(c *gc.C) {
+	if runtime.GOOS == "windows" {
+		c.Skip("Tests relevant only on *nix systems")
+	}
+	s.BaseSuite.SetUpTest(c)
+	s.storageDir = c.MkDir()
+}
+
+func (s *nfsSuite) TearDownTest(c *gc.C) {
+	if s.commands != nil {
+		s.commands.assertDrained()
+	}
+	s.BaseSuite.TearDownTest(c)
+}
+
+func (s *nfsSuite) nfsProvider(c *gc.C) storage.Provider {
+	s.commands = &mockRunCommand{c: c}
+	return provider.NfsProvider(s.commands.run)
+}
+
+func (s *nfsSuite) nfsFilesystemSource(c *gc.C) storage.FilesystemSource {
+	return s.nfsFilesystemSourceWithAttributes(c, nfsAttributes())
+}
+
+func (s *nfsSuite) nfsFilesystemSourceWithAttributes(c *gc.C, attrs map[string]interface{}) storage.FilesystemSource {
+	s.commands = &mockRunCommand{c: c}
+	return provider.NfsFilesystemSource(s.storageDir, attrs, testing.ModelTag.Id(), s.commands.run)
+}
+
+func nfsAttributes() map[string]interface{} {
+	return map[string]interface{}{
+		"server":  "nfs.example.com",
+		"export":  "/srv/juju",
+		"options": "vers=4",
+	}
+}
+
+func (s *nfsSuite) TestFilesystemSource(c *gc.C) {
+	p := s.nfsProvider(c)
+	cfg, err := storage.NewConfig("name", provider.NfsProviderType, map[string]interface{}{})
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = p.FilesystemSource(testing.ModelConfig(c), cfg)
+	c.Assert(err, gc.ErrorMatches, "storage directory not specified")
+	attrs := nfsAttributes()
+	attrs["storage-dir"] = c.MkDir()
+	cfg, err = storage.NewConfig("name", provider.NfsProviderType, attrs)
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = p.FilesystemSource(testing.ModelConfig(c), cfg)
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *nfsSuite) TestVolumeSource(c *gc.C) {
+	p := s.nfsProvider(c)
+	cfg, err := storage.NewConfig("name", provider.NfsProviderType, nfsAttributes())
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = p.VolumeSource(nil, cfg)
+	c.Assert(err, gc.ErrorMatches, "volumes not supported")
+}
+
+func (s *nfsSuite) TestValidateConfig(c *gc.C) {
+	p := s.nfsProvider(c)
+	for i, test := range []struct {
+		attrs  map[string]interface{}
+		expect string
+	}{{
+		attrs:  map[string]interface{}{"export": "/srv/juju"},
+		expect: "validating NFS storage config: server: expected string, got nothing",
+	}, {
+		attrs:  map[string]interface{}{"server": "", "export": "/srv/juju"},
+		expect: "NFS server not specified",
+	}, {
+		attrs:  map[string]interface{}{"server": "nfs.example.com"},
+		expect: "validating NFS storage config: export: expected string, got nothing",
+	}, {
+		attrs:  map[string]interface{}{"server": "nfs.example.com", "export": "srv/juju"},
+		expect: `NFS export "srv/juju" must be an absolute path`,
+	}, {
+		attrs:  map[string]interface{}{"server": "nfs.example.com", "export": "/srv/juju", "options": 123},
+		expect: "validating NFS storage config: options: expected string, got int\\(123\\)",
+	}, {
+		attrs: map[string]interface{}{"server": "nfs.example.com", "export": "/srv/juju"},
+	}, {
+		attrs: nfsAttributes(),
+	}} {
+		c.Logf("test %d: %v", i, test.attrs)
+		cfg, err := storage.NewConfig("name", provider.NfsProviderType, test.attrs)
+		c.Assert(err, jc.ErrorIsNil)
+		err = p.ValidateConfig(cfg)
+		if test.expect == "" {
+			c.Check(err, jc.ErrorIsNil)
+		} else {
+			c.Check(err, gc.ErrorMatches, test.expect)
+		}
+	}
+}
+
+func (s *nfsSuite) TestSupports(c *gc.C) {
+	p := s.nfsProvider(c)
+	c.Assert(p.Supports(storage.StorageKindBlock), jc.IsFalse)
+	c.Assert(p.Supports(storage.StorageKindFilesystem), jc.IsTrue)
+}
+
+func (s *nfsSuite) TestScope(c *gc.C) {
+	p := s.nfsProvider(c)
+	c.Assert(p.Scope(), gc.Equals, storage.ScopeMachine)
+}
+
+func (s *nfsSuite) TestDynamic(c *gc.C) {
+	p := s.nfsProvider(c)
+	c.Assert(p.Dynamic(), jc.IsTrue)
+}
+
+func (s *nfsSuite) expectMountExport(mounted bool) {
+	exportDir := filepath.Join(s.storageDir, "export")
+	cmd := s.commands.expect("df", "--output=source", exportDir)
+	if mounted {
+		cmd.respond("header\nnfs.example.com:/srv/juju", nil)
+	} else {
+		cmd.respond("header\n/dev/sda1", nil)
+		s.commands.expect("mount", "-t", "nfs", "nfs.example.com:/srv/juju", exportDir, "-o", "vers=4")
+	}
+	s.commands.expect("umount", exportDir)
+}
+
+func (s *nfsSuite) TestCreateFilesystems(c *gc.C) {
+	source := s.nfsFilesystemSource(c)
+	s.expectMountExport(false)
+
+	results, err := source.CreateFilesystems([]storage.FilesystemParams{{
+		Tag:        names.NewFilesystemTag("1"),
+		Size:       1024,
+		Attributes: nfsAttributes(),
+	}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, jc.DeepEquals, []storage.CreateFilesystemsResult{{
+		Filesystem: &storage.Filesystem{
+			Tag: names.NewFilesystemTag("1"),
+			FilesystemInfo: storage.FilesystemInfo{
+				FilesystemId: nfsFilesystemId,
+				Size:         1024,
+			},
+		},
+	}})
+}
+
+func (s *nfsSuite) TestCreateFilesystemsExportAlreadyMounted(c *gc.C) {
+	source := s.nfsFilesystemSource(c)
+	s.expectMountExport(true)
+
+	results, err := source.CreateFilesystems([]storage.FilesystemParams{{
+		Tag:        names.NewFilesystemTag("1"),
+		Size:       1024,
+		Attributes: nfsAttributes(),
+	}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, gc.HasLen, 1)
+	c.Assert(results[0].Error, jc.ErrorIsNil)
+}
+
+func (s *nfsSuite) TestCreateFilesystemsMountFails(c *gc.C) {
+	source := s.nfsFilesystemSource(c)
+	exportDir := filepath.Join(s.storageDir, "export")
+	cmd := s.commands.expect("df", "--output=source", exportDir)
+	cmd.respond("header\n/dev/sda1", nil)
+	cmd = s.commands.expect("mount", "-t", "nfs", "nfs.example.com:/srv/juju", exportDir, "-o", "vers=4")
+	cmd.respond("", errors.New("access denied"))
+
+	results, err := source.CreateFilesystems([]storage.FilesystemParams{{
+		Tag:        names.NewFilesystemTag("1"),
+		Size:       1024,
+		Attributes: nfsAttributes(),
+	}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, gc.HasLen, 1)
+	c.Assert(results[0].Error, gc.ErrorMatches, "creating directory on NFS export: cannot mount NFS export: access denied")
+}
+
+func (s *nfsSuite) TestCreateFilesystemsInvalidConfig(c *gc.C) {
+	source := s.nfsFilesystemSource(c)
+	results, err := source.CreateFilesystems([]storage.FilesystemParams{{
+		Tag:  names.NewFilesystemTag("1"),
+		Size: 1024,
+	}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, gc.HasLen, 1)
+	c.Assert(results[0].Error, gc.ErrorMatches, "validating NFS storage config: .*: expected string, got nothing")
+}
+
+func (s *nfsSuite) TestValidateFilesystemParams(c *gc.C) {
+	source := s.nfsFilesystemSource(c)
+	err := source.ValidateFilesystemParams(storage.FilesystemParams{
+		Tag:        names.NewFilesystemTag("1"),
+		Attributes: nfsAttributes(),
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	err = source.ValidateFilesystemParams(storage.FilesystemParams{
+		Tag:        names.NewFilesystemTag("1"),
+		Attributes: map[string]interface{}{"server": "nfs.example.com", "export": "srv/juju"},
+	})
+	c.Assert(err, gc.ErrorMatches, `NFS export "srv/juju" must be an absolute path`)
+}
+
+func (s *nfsSuite) TestDestroyFilesystems(c *gc.C) {
+	source := s.nfsFilesystemSource(c)
+	s.expectMountExport(false)
+
+	results, err := source.DestroyFilesystems([]string{nfsFilesystemId})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, jc.DeepEquals, []error{nil})
+}
+
+func (s *nfsSuite) TestDestroyFilesystemsNoPoolAttributes(c *gc.C) {
+	source := s.nfsFilesystemSourceWithAttributes(c, nil)
+	results, err := source.DestroyFilesystems([]string{nfsFilesystemId})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, gc.HasLen, 1)
+	c.Assert(results[0], gc.ErrorMatches, `destroying ".*": validating NFS storage config: .*: expected string, got nothing`)
+}
+
+func (s *nfsSuite) TestAttachFilesystems(c *gc.C) {
+	source := s.nfsFilesystemSource(c)
+	cmd := s.commands.expect("df", "--output=source", "/var/lib/juju/storage/fs/foo")
+	cmd.respond("header\n/dev/sda1", nil)
+	s.commands.expect(
+		"mount", "-t", "nfs", "nfs.example.com:/srv/juju/"+nfsFilesystemId,
+		"/var/lib/juju/storage/fs/foo", "-o", "vers=4",
+	)
+
+	results, err := source.AttachFilesystems([]storage.FilesystemAttachmentParams{{
+		Filesystem:   names.NewFilesystemTag("1"),
+		FilesystemId: nfsFilesystemId,
+		Path:         "/var/lib/juju/storage/fs/foo",
+		AttachmentParams: storage.AttachmentParams{
+			Machine: names.NewMachineTag("2"),
+		},
+	}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, jc.DeepEquals, []storage.AttachFilesystemsResult{{
+		FilesystemAttachment: &storage.FilesystemAttachment{
+			Filesystem: names.NewFilesystemTag("1"),
+			Machine:    names.NewMachineTag("2"),
+			FilesystemAttachmentInfo: storage.FilesystemAttachmentInfo{
+				Path: "/var/lib/juju/storage/fs/foo",
+			},
+		},
+	}})
+}
+
+func (s *nfsSuite) TestAttachFilesystemsReadOnly(c *gc.C) {
+	source := s.nfsFilesystemSourceWithAttributes(c, map[string]interface{}{
+		"server": "nfs.example.com",
+		"export": "/srv/juju",
+	})
+	cmd := s.commands.expect("df", "--output=source", "/var/lib/juju/storage/fs/foo")
+	cmd.respond("header\n/dev/sda1", nil)
+	s.commands.expect(
+		"mount", "-t", "nfs", "nfs.example.com:/srv/juju/"+nfsFilesystemId,
+		"/var/lib/juju/storage/fs/foo", "-o", "ro",
+	)
+
+	results, err := source.AttachFilesystems([]storage.FilesystemAttachmentParams{{
+		Filesystem:   names.NewFilesystemTag("1"),
+		FilesystemId: nfsFilesystemId,
+		Path:         "/var/lib/juju/storage/fs/foo",
+		AttachmentParams: storage.AttachmentParams{
+			Machine:  names.NewMachineTag("2"),
+			ReadOnly: true,
+		},
+	}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, gc.HasLen, 1)
+	c.Assert(results[0].Error, jc.ErrorIsNil)
+	c.Assert(results[0].FilesystemAttachment.ReadOnly, jc.IsTrue)
+}
+
+func (s *nfsSuite) TestAttachFilesystemsAlreadyMounted(c *gc.C) {
+	source := s.nfsFilesystemSource(c)
+	cmd := s.commands.expect("df", "--output=source", "exists")
+	cmd.respond("header\nnfs.example.com:/srv/juju/"+nfsFilesystemId, nil)
+
+	results, err := source.AttachFilesystems([]storage.FilesystemAttachmentParams{{
+		Filesystem:   names.NewFilesystemTag("1"),
+		FilesystemId: nfsFilesystemId,
+		Path:         "exists",
+	}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, jc.DeepEquals, []storage.AttachFilesystemsResult{{
+		FilesystemAttachment: &storage.FilesystemAttachment{
+			Filesystem: names.NewFilesystemTag("1"),
+			FilesystemAttachmentInfo: storage.FilesystemAttachmentInfo{
+				Path: "exists",
+			},
+		},
+	}})
+}
+
+func (s *nfsSuite) TestAttachFilesystemsNoPathSpecified(c *gc.C) {
+	source := s.nfsFilesystemSource(c)
+	results, err := source.AttachFilesystems([]storage.FilesystemAttachmentParams{{
+		Filesystem:   names.NewFilesystemTag("1"),
+		FilesystemId: nfsFilesystemId,
+	}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results[0].Error, gc.ErrorMatches, "filesystem mount point not specified")
+}
+
+func (s *nfsSuite) TestDetachFilesystems(c *gc.C) {
+	source := s.nfsFilesystemSource(c)
+	testDetachFilesystems(c, s.commands, source, true)
+}
+
+func (s *nfsSuite) TestDetachFilesystemsUnattached(c *gc.C) {
+	source := s.nfsFilesystemSource(c)
+	testDetachFilesystems(c, s.commands, source, false)
+}
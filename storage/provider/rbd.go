@@ -0,0 +1,321 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package provider
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/juju/errors"
+	"github.com/juju/schema"
+
+	"github.com/juju/juju/environs/config"
+	"github.com/juju/juju/storage"
+)
+
+const (
+	RbdProviderType = storage.ProviderType("rbd")
+
+	// Config attributes
+	RbdMonitors = "monitors" // comma-separated Ceph monitor addresses
+	RbdPool     = "pool"     // name of the Ceph pool to create images in
+	RbdKeyring  = "keyring"  // path of the Ceph keyring on the machine
+
+	defaultRbdPool = "rbd"
+)
+
+// rbdProviders create volume sources which use Ceph RADOS block
+// devices.
+type rbdProvider struct {
+	// run is a function used for running commands on the local machine.
+	run runCommandFunc
+}
+
+var _ storage.Provider = (*rbdProvider)(nil)
+
+var rbdConfigFields = schema.Fields{
+	RbdMonitors: schema.String(),
+	RbdPool:     schema.String(),
+	RbdKeyring:  schema.String(),
+}
+
+var rbdConfigChecker = schema.FieldMap(
+	rbdConfigFields,
+	schema.Defaults{
+		RbdPool:    defaultRbdPool,
+		RbdKeyring: "",
+	},
+)
+
+// rbdConfig holds the RBD pool attributes.
+type rbdConfig struct {
+	Monitors string
+	Pool     string
+	Keyring  string
+}
+
+func newRbdConfig(attrs map[string]interface{}) (*rbdConfig, error) {
+	out, err := rbdConfigChecker.Coerce(attrs, nil)
+	if err != nil {
+		return nil, errors.Annotate(err, "validating RBD storage config")
+	}
+	coerced := out.(map[string]interface{})
+	rbdConfig := &rbdConfig{
+		Monitors: coerced[RbdMonitors].(string),
+		Pool:     coerced[RbdPool].(string),
+		Keyring:  coerced[RbdKeyring].(string),
+	}
+	if rbdConfig.Monitors == "" {
+		return nil, errors.New("Ceph monitors not specified")
+	}
+	if rbdConfig.Pool == "" {
+		return nil, errors.New("Ceph pool not specified")
+	}
+	return rbdConfig, nil
+}
+
+// args returns the arguments to "rbd" for running the given
+// sub-command against the configured cluster and pool.
+func (c *rbdConfig) args(subcommand string, args ...string) []string {
+	args = append([]string{subcommand}, args...)
+	args = append(args, "--pool", c.Pool, "-m", c.Monitors)
+	if c.Keyring != "" {
+		args = append(args, "--keyring", c.Keyring)
+	}
+	return args
+}
+
+// ValidateConfig is defined on the Provider interface.
+func (*rbdProvider) ValidateConfig(cfg *storage.Config) error {
+	_, err := newRbdConfig(cfg.Attrs())
+	return errors.Trace(err)
+}
+
+// VolumeSource is defined on the Provider interface.
+func (p *rbdProvider) VolumeSource(environConfig *config.Config, sourceConfig *storage.Config) (storage.VolumeSource, error) {
+	// The storage provisioner creates a source for each distinct
+	// storage pool, with the pool's attributes in the source config.
+	// The attributes are validated when they are needed, as a source
+	// may be created without them to perform operations that do not
+	// require access to the Ceph cluster.
+	return &rbdVolumeSource{
+		p.run,
+		sourceConfig.Attrs(),
+		environConfig.UUID(),
+	}, nil
+}
+
+// FilesystemSource is defined on the Provider interface.
+func (*rbdProvider) FilesystemSource(environConfig *config.Config, providerConfig *storage.Config) (storage.FilesystemSource, error) {
+	return nil, errors.NotSupportedf("filesystems")
+}
+
+// Supports is defined on the Provider interface.
+func (*rbdProvider) Supports(k storage.StorageKind) bool {
+	return k == storage.StorageKindBlock
+}
+
+// Scope is defined on the Provider interface.
+func (*rbdProvider) Scope() storage.Scope {
+	return storage.ScopeMachine
+}
+
+// Dynamic is defined on the Provider interface.
+func (*rbdProvider) Dynamic() bool {
+	return true
+}
+
+// rbdVolumeSource provides common functionality to handle
+// RBD images for the rbd provider.
+type rbdVolumeSource struct {
+	run       runCommandFunc
+	attrs     map[string]interface{}
+	modelUUID string
+}
+
+var _ storage.VolumeSource = (*rbdVolumeSource)(nil)
+
+// config returns the RBD configuration of the storage pool that the
+// source was created for.
+func (s *rbdVolumeSource) config() (*rbdConfig, error) {
+	return newRbdConfig(s.attrs)
+}
+
+// CreateVolumes is defined on the VolumeSource interface.
+func (s *rbdVolumeSource) CreateVolumes(args []storage.VolumeParams) ([]storage.CreateVolumesResult, error) {
+	results := make([]storage.CreateVolumesResult, len(args))
+	for i, arg := range args {
+		volume, err := s.createVolume(arg)
+		if err != nil {
+			results[i].Error = errors.Annotate(err, "creating volume")
+			continue
+		}
+		results[i].Volume = &volume
+	}
+	return results, nil
+}
+
+func (s *rbdVolumeSource) createVolume(params storage.VolumeParams) (storage.Volume, error) {
+	cfg, err := newRbdConfig(params.Attributes)
+	if err != nil {
+		return storage.Volume{}, errors.Trace(err)
+	}
+	// The Ceph pool may be shared by several models, so the image
+	// name must identify the model as well as the volume.
+	volumeId := sharedResourceName(s.modelUUID, params.Tag)
+	if _, err := s.run("rbd", cfg.args(
+		"create", volumeId, "--size", fmt.Sprint(params.Size),
+	)...); err != nil {
+		return storage.Volume{}, errors.Annotate(err, "creating RBD image")
+	}
+	// The image outlives the machine, but the volume source is
+	// machine-scoped; if the volume were persistent, nothing would be
+	// able to destroy the image once the machine is gone.
+	return storage.Volume{
+		params.Tag,
+		storage.VolumeInfo{
+			VolumeId: volumeId,
+			Size:     params.Size,
+		},
+	}, nil
+}
+
+// ListVolumes is defined on the VolumeSource interface.
+func (s *rbdVolumeSource) ListVolumes() ([]string, error) {
+	// The Ceph pool may contain images belonging to other models
+	// and controllers, so we do not attempt to list them.
+	return nil, errors.NotImplementedf("ListVolumes")
+}
+
+// DescribeVolumes is defined on the VolumeSource interface.
+func (s *rbdVolumeSource) DescribeVolumes(volumeIds []string) ([]storage.DescribeVolumesResult, error) {
+	return nil, errors.NotImplementedf("DescribeVolumes")
+}
+
+// DestroyVolumes is defined on the VolumeSource interface.
+func (s *rbdVolumeSource) DestroyVolumes(volumeIds []string) ([]error, error) {
+	results := make([]error, len(volumeIds))
+	for i, volumeId := range volumeIds {
+		if err := s.destroyVolume(volumeId); err != nil {
+			results[i] = errors.Annotatef(err, "destroying %q", volumeId)
+		}
+	}
+	return results, nil
+}
+
+func (s *rbdVolumeSource) destroyVolume(volumeId string) error {
+	cfg, err := s.config()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if _, err := s.run("rbd", cfg.args("rm", volumeId)...); err != nil {
+		return errors.Annotate(err, "removing RBD image")
+	}
+	return nil
+}
+
+// ValidateVolumeParams is defined on the VolumeSource interface.
+func (s *rbdVolumeSource) ValidateVolumeParams(params storage.VolumeParams) error {
+	_, err := newRbdConfig(params.Attributes)
+	return errors.Trace(err)
+}
+
+// AttachVolumes is defined on the VolumeSource interface.
+func (s *rbdVolumeSource) AttachVolumes(args []storage.VolumeAttachmentParams) ([]storage.AttachVolumesResult, error) {
+	results := make([]storage.AttachVolumesResult, len(args))
+	for i, arg := range args {
+		attachment, err := s.attachVolume(arg)
+		if err != nil {
+			results[i].Error = errors.Annotatef(err, "attaching volume %v", arg.Volume.Id())
+			continue
+		}
+		results[i].VolumeAttachment = attachment
+	}
+	return results, nil
+}
+
+func (s *rbdVolumeSource) attachVolume(arg storage.VolumeAttachmentParams) (*storage.VolumeAttachment, error) {
+	cfg, err := s.config()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	devicePath, err := s.mappedDevice(cfg, arg.VolumeId)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if devicePath == "" {
+		args := cfg.args("map", arg.VolumeId)
+		if arg.ReadOnly {
+			args = append(args, "--read-only")
+		}
+		output, err := s.run("rbd", args...)
+		if err != nil {
+			return nil, errors.Annotate(err, "mapping RBD image")
+		}
+		devicePath = strings.TrimSpace(output)
+	}
+	return &storage.VolumeAttachment{
+		arg.Volume,
+		arg.Machine,
+		storage.VolumeAttachmentInfo{
+			DeviceName: strings.TrimPrefix(devicePath, "/dev/"),
+			ReadOnly:   arg.ReadOnly,
+		},
+	}, nil
+}
+
+// DetachVolumes is defined on the VolumeSource interface.
+func (s *rbdVolumeSource) DetachVolumes(args []storage.VolumeAttachmentParams) ([]error, error) {
+	results := make([]error, len(args))
+	for i, arg := range args {
+		if err := s.detachVolume(arg.VolumeId); err != nil {
+			results[i] = errors.Annotatef(err, "detaching volume %s", arg.Volume.Id())
+		}
+	}
+	return results, nil
+}
+
+func (s *rbdVolumeSource) detachVolume(volumeId string) error {
+	cfg, err := s.config()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	devicePath, err := s.mappedDevice(cfg, volumeId)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if devicePath == "" {
+		// The image is not mapped, so there is nothing to do.
+		return nil
+	}
+	if _, err := s.run("rbd", "unmap", devicePath); err != nil {
+		return errors.Annotate(err, "unmapping RBD image")
+	}
+	return nil
+}
+
+// mappedDevice returns the path of the device that the given RBD
+// image is mapped to on this machine, or "" if it is not mapped.
+func (s *rbdVolumeSource) mappedDevice(cfg *rbdConfig, image string) (string, error) {
+	output, err := s.run("rbd", "showmapped")
+	if err != nil {
+		return "", errors.Annotate(err, "listing mapped RBD images")
+	}
+	// Newer releases of Ceph report the image namespace between the
+	// pool and the image, and leave it blank when there is none, so
+	// the image's column is not fixed. The device is always last.
+	lines := strings.Split(strings.TrimSpace(output), "\n")
+	for _, line := range lines[1:] {
+		fields := strings.Fields(line)
+		if len(fields) < 5 || fields[1] != cfg.Pool {
+			continue
+		}
+		for _, field := range fields[2 : len(fields)-2] {
+			if field == image {
+				return fields[len(fields)-1], nil
+			}
+		}
+	}
+	return "", nil
+}
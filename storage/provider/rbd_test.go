@@ -0,0 +1,350 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package provider_test
+
+import (
+	"errors"
+	"runtime"
+
+	"github.com/juju/names"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/storage"
+	"github.com/juju/juju/storage/provider"
+	"github.com/juju/juju/testing"
+)
+
+var _ = gc.Suite(&rbdSuite{})
+
+type rbdSuite struct {
+	testing.BaseSuite
+	commands *mockRunCommand
+}
+
+const rbdVolumeId = "juju-deadbeef-0bad-400d-8000-4b1d0d06f00d-volume-0"
+
+func (s *rbdSuite) SetUpTest(c *gc.C) {
+	if runtime.GOOS == "windows" {
+		c.Skip("Tests relevant only on *nix systems")
+	}
+	s.BaseSuite.SetUpTest(c)
+}
+
+func (s *rbdSuite) TearDownTest(c *gc.C) {
+	if s.commands != nil {
+		s.commands.assertDrained()
+	}
+	s.BaseSuite.TearDownTest(c)
+}
+
+func (s *rbdSuite) rbdProvider(c *gc.C) storage.Provider {
+	s.commands = &mockRunCommand{c: c}
+	return provider.RbdProvider(s.commands.run)
+}
+
+func (s *rbdSuite) rbdVolumeSource(c *gc.C) storage.VolumeSource {
+	s.commands = &mockRunCommand{c: c}
+	return provider.RbdVolumeSource(rbdAttributes(), testing.ModelTag.Id(), s.commands.run)
+}
+
+func rbdAttributes() map[string]interface{} {
+	return map[string]interface{}{
+		"monitors": "10.0.0.1,10.0.0.2",
+		"pool":     "juju",
+		"keyring":  "/etc/ceph/juju.keyring",
+	}
+}
+
+// rbdArgs returns the arguments to "rbd" expected for the given
+// sub-command when using rbdAttributes.
+func rbdArgs(args ...string) []string {
+	return append(args, "--pool", "juju", "-m", "10.0.0.1,10.0.0.2", "--keyring", "/etc/ceph/juju.keyring")
+}
+
+func (s *rbdSuite) TestVolumeSource(c *gc.C) {
+	p := s.rbdProvider(c)
+	attrs := rbdAttributes()
+	attrs["storage-dir"] = c.MkDir()
+	cfg, err := storage.NewConfig("name", provider.RbdProviderType, attrs)
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = p.VolumeSource(testing.ModelConfig(c), cfg)
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *rbdSuite) TestVolumeSourceNoPoolAttributes(c *gc.C) {
+	// A source may be created without the pool attributes; they are
+	// only required when the Ceph cluster is accessed.
+	p := s.rbdProvider(c)
+	cfg, err := storage.NewConfig("name", provider.RbdProviderType, map[string]interface{}{})
+	c.Assert(err, jc.ErrorIsNil)
+	source, err := p.VolumeSource(testing.ModelConfig(c), cfg)
+	c.Assert(err, jc.ErrorIsNil)
+
+	results, err := source.DestroyVolumes([]string{rbdVolumeId})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, gc.HasLen, 1)
+	c.Assert(results[0], gc.ErrorMatches, `destroying ".*": validating RBD storage config: monitors: expected string, got nothing`)
+}
+
+func (s *rbdSuite) TestFilesystemSource(c *gc.C) {
+	p := s.rbdProvider(c)
+	cfg, err := storage.NewConfig("name", provider.RbdProviderType, rbdAttributes())
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = p.FilesystemSource(nil, cfg)
+	c.Assert(err, gc.ErrorMatches, "filesystems not supported")
+}
+
+func (s *rbdSuite) TestValidateConfig(c *gc.C) {
+	p := s.rbdProvider(c)
+	for i, test := range []struct {
+		attrs  map[string]interface{}
+		expect string
+	}{{
+		attrs:  map[string]interface{}{},
+		expect: "validating RBD storage config: monitors: expected string, got nothing",
+	}, {
+		attrs:  map[string]interface{}{"monitors": ""},
+		expect: "Ceph monitors not specified",
+	}, {
+		attrs:  map[string]interface{}{"monitors": "10.0.0.1", "pool": ""},
+		expect: "Ceph pool not specified",
+	}, {
+		attrs:  map[string]interface{}{"monitors": "10.0.0.1", "keyring": false},
+		expect: "validating RBD storage config: keyring: expected string, got bool\\(false\\)",
+	}, {
+		attrs: map[string]interface{}{"monitors": "10.0.0.1"},
+	}, {
+		attrs: rbdAttributes(),
+	}} {
+		c.Logf("test %d: %v", i, test.attrs)
+		cfg, err := storage.NewConfig("name", provider.RbdProviderType, test.attrs)
+		c.Assert(err, jc.ErrorIsNil)
+		err = p.ValidateConfig(cfg)
+		if test.expect == "" {
+			c.Check(err, jc.ErrorIsNil)
+		} else {
+			c.Check(err, gc.ErrorMatches, test.expect)
+		}
+	}
+}
+
+func (s *rbdSuite) TestSupports(c *gc.C) {
+	p := s.rbdProvider(c)
+	c.Assert(p.Supports(storage.StorageKindBlock), jc.IsTrue)
+	c.Assert(p.Supports(storage.StorageKindFilesystem), jc.IsFalse)
+}
+
+func (s *rbdSuite) TestScope(c *gc.C) {
+	p := s.rbdProvider(c)
+	c.Assert(p.Scope(), gc.Equals, storage.ScopeMachine)
+}
+
+func (s *rbdSuite) TestDynamic(c *gc.C) {
+	p := s.rbdProvider(c)
+	c.Assert(p.Dynamic(), jc.IsTrue)
+}
+
+func (s *rbdSuite) TestCreateVolumes(c *gc.C) {
+	source := s.rbdVolumeSource(c)
+	s.commands.expect("rbd", rbdArgs("create", rbdVolumeId, "--size", "2048")...)
+
+	results, err := source.CreateVolumes([]storage.VolumeParams{{
+		Tag:        names.NewVolumeTag("0"),
+		Size:       2048,
+		Attributes: rbdAttributes(),
+	}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, jc.DeepEquals, []storage.CreateVolumesResult{{
+		Volume: &storage.Volume{
+			names.NewVolumeTag("0"),
+			storage.VolumeInfo{
+				VolumeId: rbdVolumeId,
+				Size:     2048,
+			},
+		},
+	}})
+}
+
+func (s *rbdSuite) TestCreateVolumesDefaultPool(c *gc.C) {
+	source := s.rbdVolumeSource(c)
+	s.commands.expect("rbd", "create", rbdVolumeId, "--size", "2048", "--pool", "rbd", "-m", "10.0.0.1")
+
+	results, err := source.CreateVolumes([]storage.VolumeParams{{
+		Tag:        names.NewVolumeTag("0"),
+		Size:       2048,
+		Attributes: map[string]interface{}{"monitors": "10.0.0.1"},
+	}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, gc.HasLen, 1)
+	c.Assert(results[0].Error, jc.ErrorIsNil)
+}
+
+func (s *rbdSuite) TestCreateVolumesFails(c *gc.C) {
+	source := s.rbdVolumeSource(c)
+	cmd := s.commands.expect("rbd", rbdArgs("create", rbdVolumeId, "--size", "2048")...)
+	cmd.respond("", errors.New("error connecting to the cluster"))
+
+	results, err := source.CreateVolumes([]storage.VolumeParams{{
+		Tag:        names.NewVolumeTag("0"),
+		Size:       2048,
+		Attributes: rbdAttributes(),
+	}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, gc.HasLen, 1)
+	c.Assert(results[0].Error, gc.ErrorMatches, "creating volume: creating RBD image: error connecting to the cluster")
+}
+
+func (s *rbdSuite) TestValidateVolumeParams(c *gc.C) {
+	source := s.rbdVolumeSource(c)
+	err := source.ValidateVolumeParams(storage.VolumeParams{
+		Tag:        names.NewVolumeTag("0"),
+		Attributes: rbdAttributes(),
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	err = source.ValidateVolumeParams(storage.VolumeParams{
+		Tag:        names.NewVolumeTag("0"),
+		Attributes: map[string]interface{}{"monitors": ""},
+	})
+	c.Assert(err, gc.ErrorMatches, "Ceph monitors not specified")
+}
+
+func (s *rbdSuite) TestDestroyVolumes(c *gc.C) {
+	source := s.rbdVolumeSource(c)
+	s.commands.expect("rbd", rbdArgs("rm", rbdVolumeId)...)
+
+	results, err := source.DestroyVolumes([]string{rbdVolumeId})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, jc.DeepEquals, []error{nil})
+}
+
+func (s *rbdSuite) TestDestroyVolumesFails(c *gc.C) {
+	source := s.rbdVolumeSource(c)
+	cmd := s.commands.expect("rbd", rbdArgs("rm", rbdVolumeId)...)
+	cmd.respond("", errors.New("image has watchers"))
+
+	results, err := source.DestroyVolumes([]string{rbdVolumeId})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, gc.HasLen, 1)
+	c.Assert(results[0], gc.ErrorMatches, `destroying ".*": removing RBD image: image has watchers`)
+}
+
+func (s *rbdSuite) TestAttachVolumes(c *gc.C) {
+	source := s.rbdVolumeSource(c)
+	s.commands.expect("rbd", "showmapped")
+	cmd := s.commands.expect("rbd", rbdArgs("map", rbdVolumeId)...)
+	cmd.respond("/dev/rbd0\n", nil)
+
+	results, err := source.AttachVolumes([]storage.VolumeAttachmentParams{{
+		Volume:   names.NewVolumeTag("0"),
+		VolumeId: rbdVolumeId,
+		AttachmentParams: storage.AttachmentParams{
+			Machine: names.NewMachineTag("0"),
+		},
+	}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, jc.DeepEquals, []storage.AttachVolumesResult{{
+		VolumeAttachment: &storage.VolumeAttachment{
+			names.NewVolumeTag("0"),
+			names.NewMachineTag("0"),
+			storage.VolumeAttachmentInfo{
+				DeviceName: "rbd0",
+			},
+		},
+	}})
+}
+
+func (s *rbdSuite) TestAttachVolumesReadOnly(c *gc.C) {
+	source := s.rbdVolumeSource(c)
+	s.commands.expect("rbd", "showmapped")
+	cmd := s.commands.expect("rbd", append(rbdArgs("map", rbdVolumeId), "--read-only")...)
+	cmd.respond("/dev/rbd1\n", nil)
+
+	results, err := source.AttachVolumes([]storage.VolumeAttachmentParams{{
+		Volume:   names.NewVolumeTag("0"),
+		VolumeId: rbdVolumeId,
+		AttachmentParams: storage.AttachmentParams{
+			Machine:  names.NewMachineTag("0"),
+			ReadOnly: true,
+		},
+	}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, jc.DeepEquals, []storage.AttachVolumesResult{{
+		VolumeAttachment: &storage.VolumeAttachment{
+			names.NewVolumeTag("0"),
+			names.NewMachineTag("0"),
+			storage.VolumeAttachmentInfo{
+				DeviceName: "rbd1",
+				ReadOnly:   true,
+			},
+		},
+	}})
+}
+
+func (s *rbdSuite) TestAttachVolumesAlreadyMapped(c *gc.C) {
+	source := s.rbdVolumeSource(c)
+	cmd := s.commands.expect("rbd", "showmapped")
+	cmd.respond(`
+id pool image                                                snap device
+0  rbd  other                                                -    /dev/rbd0
+1  juju `+rbdVolumeId+` -    /dev/rbd1
+`[1:], nil)
+
+	results, err := source.AttachVolumes([]storage.VolumeAttachmentParams{{
+		Volume:   names.NewVolumeTag("0"),
+		VolumeId: rbdVolumeId,
+		AttachmentParams: storage.AttachmentParams{
+			Machine: names.NewMachineTag("0"),
+		},
+	}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, gc.HasLen, 1)
+	c.Assert(results[0].Error, jc.ErrorIsNil)
+	c.Assert(results[0].VolumeAttachment.DeviceName, gc.Equals, "rbd1")
+}
+
+func (s *rbdSuite) TestAttachVolumesMapFails(c *gc.C) {
+	source := s.rbdVolumeSource(c)
+	s.commands.expect("rbd", "showmapped")
+	cmd := s.commands.expect("rbd", rbdArgs("map", rbdVolumeId)...)
+	cmd.respond("", errors.New("rbd: sysfs write failed"))
+
+	results, err := source.AttachVolumes([]storage.VolumeAttachmentParams{{
+		Volume:   names.NewVolumeTag("0"),
+		VolumeId: rbdVolumeId,
+	}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, gc.HasLen, 1)
+	c.Assert(results[0].Error, gc.ErrorMatches, "attaching volume 0: mapping RBD image: rbd: sysfs write failed")
+}
+
+func (s *rbdSuite) TestDetachVolumes(c *gc.C) {
+	source := s.rbdVolumeSource(c)
+	cmd := s.commands.expect("rbd", "showmapped")
+	// Newer releases of Ceph report the namespace of each image.
+	cmd.respond(`
+id pool namespace image                                                snap device
+0  juju           `+rbdVolumeId+` -    /dev/rbd0
+`[1:], nil)
+	s.commands.expect("rbd", "unmap", "/dev/rbd0")
+
+	results, err := source.DetachVolumes([]storage.VolumeAttachmentParams{{
+		Volume:   names.NewVolumeTag("0"),
+		VolumeId: rbdVolumeId,
+	}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, jc.DeepEquals, []error{nil})
+}
+
+func (s *rbdSuite) TestDetachVolumesNotMapped(c *gc.C) {
+	source := s.rbdVolumeSource(c)
+	s.commands.expect("rbd", "showmapped")
+
+	results, err := source.DetachVolumes([]storage.VolumeAttachmentParams{{
+		Volume:   names.NewVolumeTag("0"),
+		VolumeId: rbdVolumeId,
+	}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, jc.DeepEquals, []error{nil})
+}
@@ -47,4 +47,8 @@ type StorageAttachmentInfo struct {
 	// for a filesystem-kind storage attachment, and the device path
 	// for a block-kind.
 	Location string
+
+	// Size is the size of the volume backing the storage, in MiB,
+	// or zero if the storage is not backed by a volume.
+	Size uint64
 }
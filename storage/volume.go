@@ -32,6 +32,21 @@ type VolumeInfo struct {
 	Persistent bool
 }
 
+// SnapshotInfo describes a snapshot of a volume.
+type SnapshotInfo struct {
+	// SnapshotId is a unique provider-supplied ID for the snapshot.
+	SnapshotId string
+
+	// VolumeId is the provider volume ID of the volume that the
+	// snapshot was created from.
+	VolumeId string
+
+	// Size is the size of the volume that the snapshot was created
+	// from, in MiB. Volumes created from the snapshot must be at
+	// least this size.
+	Size uint64
+}
+
 // VolumeAttachment identifies and describes machine-specific volume
 // attachment information, including how the volume is exposed on the
 // machine.
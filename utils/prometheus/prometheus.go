@@ -0,0 +1,425 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+// Package prometheus provides a minimal set of metric types that can
+// be rendered in the Prometheus text exposition format, version 0.0.4.
+//
+// Metrics are created with a name and help text, and registered with a
+// Registry (usually DefaultRegistry) which renders them all when asked.
+// All metric types are safe for concurrent use.
+package prometheus
+
+import (
+	"fmt"
+	"io"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+
+	"github.com/juju/errors"
+)
+
+// ContentType is the content type of the exposition format written
+// by a Registry.
+const ContentType = "text/plain; version=0.0.4"
+
+// Collector is implemented by the metric types, and is used by a
+// Registry to render them.
+type Collector interface {
+	// Name returns the name of the metric family.
+	Name() string
+
+	// collect writes the metric family to w.
+	collect(w *familyWriter)
+}
+
+// desc holds the details common to all metric families.
+type desc struct {
+	name   string
+	help   string
+	labels []string
+}
+
+// Name is part of the Collector interface.
+func (d desc) Name() string {
+	return d.name
+}
+
+// Counter is a metric whose value only ever increases.
+type Counter struct {
+	// value is updated atomically, so it must come first to be
+	// 64-bit aligned on 32-bit platforms.
+	value uint64
+	desc
+}
+
+// NewCounter returns a new counter with the given name and help text.
+func NewCounter(name, help string) *Counter {
+	return &Counter{desc: desc{name: name, help: help}}
+}
+
+// Inc increments the counter by 1.
+func (c *Counter) Inc() {
+	atomic.AddUint64(&c.value, 1)
+}
+
+// Add increments the counter by n.
+func (c *Counter) Add(n uint64) {
+	atomic.AddUint64(&c.value, n)
+}
+
+// Value returns the current value of the counter.
+func (c *Counter) Value() uint64 {
+	return atomic.LoadUint64(&c.value)
+}
+
+func (c *Counter) collect(w *familyWriter) {
+	w.header(c.desc, "counter")
+	w.sample(c.name, nil, nil, float64(c.Value()))
+}
+
+// Gauge is a metric whose value may go up and down.
+type Gauge struct {
+	// value is updated atomically, so it must come first to be
+	// 64-bit aligned on 32-bit platforms.
+	value int64
+	desc
+}
+
+// NewGauge returns a new gauge with the given name and help text.
+func NewGauge(name, help string) *Gauge {
+	return &Gauge{desc: desc{name: name, help: help}}
+}
+
+// Set sets the gauge to v.
+func (g *Gauge) Set(v int64) {
+	atomic.StoreInt64(&g.value, v)
+}
+
+// Add adds n, which may be negative, to the gauge.
+func (g *Gauge) Add(n int64) {
+	atomic.AddInt64(&g.value, n)
+}
+
+// Inc increments the gauge by 1.
+func (g *Gauge) Inc() {
+	g.Add(1)
+}
+
+// Dec decrements the gauge by 1.
+func (g *Gauge) Dec() {
+	g.Add(-1)
+}
+
+// Value returns the current value of the gauge.
+func (g *Gauge) Value() int64 {
+	return atomic.LoadInt64(&g.value)
+}
+
+func (g *Gauge) collect(w *familyWriter) {
+	w.header(g.desc, "gauge")
+	w.sample(g.name, nil, nil, float64(g.Value()))
+}
+
+// CounterVec is a family of counters partitioned by label values.
+type CounterVec struct {
+	desc
+	mu       sync.Mutex
+	counters map[string]*labelledCounter
+}
+
+type labelledCounter struct {
+	// Counter must come first to keep its value 64-bit aligned.
+	Counter
+	values []string
+}
+
+// NewCounterVec returns a new counter family with the given name, help
+// text and label names.
+func NewCounterVec(name, help string, labels ...string) *CounterVec {
+	return &CounterVec{
+		desc:     desc{name: name, help: help, labels: labels},
+		counters: make(map[string]*labelledCounter),
+	}
+}
+
+// With returns the counter for the given label values, which must
+// match the label names of the family, creating it if necessary.
+func (v *CounterVec) With(values ...string) *Counter {
+	checkLabelValues(v.desc, values)
+	key := labelKey(values)
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	c, ok := v.counters[key]
+	if !ok {
+		c = &labelledCounter{values: values}
+		v.counters[key] = c
+	}
+	return &c.Counter
+}
+
+func (v *CounterVec) collect(w *familyWriter) {
+	w.header(v.desc, "counter")
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	keys := make([]string, 0, len(v.counters))
+	for key := range v.counters {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		c := v.counters[key]
+		w.sample(v.name, v.labels, c.values, float64(c.Value()))
+	}
+}
+
+// DefaultBuckets are the default histogram buckets, suitable for
+// measuring request durations in seconds.
+var DefaultBuckets = []float64{.005, .01, .025, .05, .1, .25, .5, 1, 2.5, 5, 10}
+
+// Histogram counts observations in configurable buckets, and keeps
+// the sum and count of all observations.
+type Histogram struct {
+	mu      sync.Mutex
+	buckets []float64
+	counts  []uint64
+	sum     float64
+	count   uint64
+}
+
+func newHistogram(buckets []float64) *Histogram {
+	return &Histogram{
+		buckets: buckets,
+		counts:  make([]uint64, len(buckets)),
+	}
+}
+
+// Observe records a single observation.
+func (h *Histogram) Observe(v float64) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	if i := sort.SearchFloat64s(h.buckets, v); i < len(h.buckets) {
+		h.counts[i]++
+	}
+	h.sum += v
+	h.count++
+}
+
+func (h *Histogram) write(w *familyWriter, name string, labels, values []string) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	bucketLabels := append(append([]string{}, labels...), "le")
+	var cumulative uint64
+	for i, upper := range h.buckets {
+		cumulative += h.counts[i]
+		le := append(append([]string{}, values...), formatFloat(upper))
+		w.sample(name+"_bucket", bucketLabels, le, float64(cumulative))
+	}
+	le := append(append([]string{}, values...), "+Inf")
+	w.sample(name+"_bucket", bucketLabels, le, float64(h.count))
+	w.sample(name+"_sum", labels, values, h.sum)
+	w.sample(name+"_count", labels, values, float64(h.count))
+}
+
+// HistogramVec is a family of histograms partitioned by label values.
+type HistogramVec struct {
+	desc
+	buckets []float64
+
+	mu         sync.Mutex
+	histograms map[string]*labelledHistogram
+}
+
+type labelledHistogram struct {
+	values []string
+	*Histogram
+}
+
+// NewHistogramVec returns a new histogram family with the given name,
+// help text, bucket upper bounds and label names. If buckets is nil,
+// DefaultBuckets is used.
+func NewHistogramVec(name, help string, buckets []float64, labels ...string) *HistogramVec {
+	if buckets == nil {
+		buckets = DefaultBuckets
+	}
+	buckets = append([]float64{}, buckets...)
+	sort.Float64s(buckets)
+	return &HistogramVec{
+		desc:       desc{name: name, help: help, labels: labels},
+		buckets:    buckets,
+		histograms: make(map[string]*labelledHistogram),
+	}
+}
+
+// With returns the histogram for the given label values, which must
+// match the label names of the family, creating it if necessary.
+func (v *HistogramVec) With(values ...string) *Histogram {
+	checkLabelValues(v.desc, values)
+	key := labelKey(values)
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	h, ok := v.histograms[key]
+	if !ok {
+		h = &labelledHistogram{values, newHistogram(v.buckets)}
+		v.histograms[key] = h
+	}
+	return h.Histogram
+}
+
+func (v *HistogramVec) collect(w *familyWriter) {
+	w.header(v.desc, "histogram")
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	keys := make([]string, 0, len(v.histograms))
+	for key := range v.histograms {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		h := v.histograms[key]
+		h.write(w, v.name, v.labels, h.values)
+	}
+}
+
+// Registry holds a set of collectors, and renders them in the text
+// exposition format.
+type Registry struct {
+	mu         sync.Mutex
+	collectors map[string]Collector
+}
+
+// NewRegistry returns a new, empty Registry.
+func NewRegistry() *Registry {
+	return &Registry{collectors: make(map[string]Collector)}
+}
+
+// DefaultRegistry is the registry used by the package-level Register
+// and MustRegister functions, and which the API server exposes.
+var DefaultRegistry = NewRegistry()
+
+// Register adds the collector to the registry. It is an error to
+// register two collectors with the same name.
+func (r *Registry) Register(c Collector) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if _, ok := r.collectors[c.Name()]; ok {
+		return errors.AlreadyExistsf("metric %q", c.Name())
+	}
+	r.collectors[c.Name()] = c
+	return nil
+}
+
+// MustRegister registers the collectors, panicking if any of them
+// cannot be registered.
+func (r *Registry) MustRegister(cs ...Collector) {
+	for _, c := range cs {
+		if err := r.Register(c); err != nil {
+			panic(err)
+		}
+	}
+}
+
+// Unregister removes the collector with the given name from the
+// registry, if it was registered.
+func (r *Registry) Unregister(name string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	delete(r.collectors, name)
+}
+
+// Write writes all the registered metric families to out, sorted
+// by name.
+func (r *Registry) Write(out io.Writer) error {
+	r.mu.Lock()
+	names := make([]string, 0, len(r.collectors))
+	for name := range r.collectors {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	collectors := make([]Collector, len(names))
+	for i, name := range names {
+		collectors[i] = r.collectors[name]
+	}
+	r.mu.Unlock()
+
+	w := &familyWriter{out: out}
+	for _, c := range collectors {
+		c.collect(w)
+	}
+	return errors.Trace(w.err)
+}
+
+// Register adds the collector to DefaultRegistry.
+func Register(c Collector) error {
+	return DefaultRegistry.Register(c)
+}
+
+// MustRegister adds the collectors to DefaultRegistry, panicking if
+// any of them cannot be registered.
+func MustRegister(cs ...Collector) {
+	DefaultRegistry.MustRegister(cs...)
+}
+
+// familyWriter writes metric families in the text exposition format,
+// remembering the first error encountered.
+type familyWriter struct {
+	out io.Writer
+	err error
+}
+
+func (w *familyWriter) printf(format string, args ...interface{}) {
+	if w.err != nil {
+		return
+	}
+	_, w.err = fmt.Fprintf(w.out, format, args...)
+}
+
+func (w *familyWriter) header(d desc, kind string) {
+	w.printf("# HELP %s %s\n", d.name, helpEscaper.Replace(d.help))
+	w.printf("# TYPE %s %s\n", d.name, kind)
+}
+
+func (w *familyWriter) sample(name string, labels, values []string, value float64) {
+	if len(labels) == 0 {
+		w.printf("%s %s\n", name, formatFloat(value))
+		return
+	}
+	pairs := make([]string, len(labels))
+	for i, label := range labels {
+		pairs[i] = fmt.Sprintf(`%s="%s"`, label, labelValueEscaper.Replace(values[i]))
+	}
+	w.printf("%s{%s} %s\n", name, strings.Join(pairs, ","), formatFloat(value))
+}
+
+var (
+	helpEscaper       = strings.NewReplacer(`\`, `\\`, "\n", `\n`)
+	labelValueEscaper = strings.NewReplacer(`\`, `\\`, "\n", `\n`, `"`, `\"`)
+)
+
+func formatFloat(v float64) string {
+	switch {
+	case math.IsInf(v, 1):
+		return "+Inf"
+	case math.IsInf(v, -1):
+		return "-Inf"
+	}
+	return strconv.FormatFloat(v, 'g', -1, 64)
+}
+
+func checkLabelValues(d desc, values []string) {
+	if len(values) != len(d.labels) {
+		panic(fmt.Sprintf(
+			"metric %q has %d labels, got %d values",
+			d.name, len(d.labels), len(values),
+		))
+	}
+}
+
+// labelKey returns a map key identifying the label values. The values
+// are separated by a byte that is not valid in UTF-8 text.
+func labelKey(values []string) string {
+	return strings.Join(values, "\xff")
+}
@@ -0,0 +1,26 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package prometheus
+
+import (
+	"unsafe"
+
+	gc "gopkg.in/check.v1"
+)
+
+type alignmentSuite struct{}
+
+var _ = gc.Suite(&alignmentSuite{})
+
+// Values updated with sync/atomic must be at the start of their
+// structs, as only that is guaranteed to be 64-bit aligned on 32-bit
+// platforms.
+func (*alignmentSuite) TestAtomicValuesFirst(c *gc.C) {
+	var counter Counter
+	c.Check(unsafe.Offsetof(counter.value), gc.Equals, uintptr(0))
+	var gauge Gauge
+	c.Check(unsafe.Offsetof(gauge.value), gc.Equals, uintptr(0))
+	var labelled labelledCounter
+	c.Check(unsafe.Offsetof(labelled.Counter), gc.Equals, uintptr(0))
+}
@@ -0,0 +1,106 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package prometheus_test
+
+import (
+	"bytes"
+
+	"github.com/juju/errors"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/utils/prometheus"
+)
+
+type PrometheusSuite struct {
+	testing.IsolationSuite
+}
+
+var _ = gc.Suite(&PrometheusSuite{})
+
+func (s *PrometheusSuite) render(c *gc.C, r *prometheus.Registry) string {
+	var buf bytes.Buffer
+	err := r.Write(&buf)
+	c.Assert(err, jc.ErrorIsNil)
+	return buf.String()
+}
+
+func (s *PrometheusSuite) TestCounterAndGauge(c *gc.C) {
+	counter := prometheus.NewCounter("test_total", "Things counted.")
+	gauge := prometheus.NewGauge("test_current", "Things now.\nWith a \\ newline.")
+	r := prometheus.NewRegistry()
+	r.MustRegister(gauge, counter)
+
+	counter.Inc()
+	counter.Add(2)
+	gauge.Set(5)
+	gauge.Dec()
+	c.Assert(counter.Value(), gc.Equals, uint64(3))
+	c.Assert(gauge.Value(), gc.Equals, int64(4))
+	c.Assert(s.render(c, r), gc.Equals, `
+# HELP test_current Things now.\nWith a \\ newline.
+# TYPE test_current gauge
+test_current 4
+# HELP test_total Things counted.
+# TYPE test_total counter
+test_total 3
+`[1:])
+}
+
+func (s *PrometheusSuite) TestCounterVec(c *gc.C) {
+	vec := prometheus.NewCounterVec("requests_total", "Requests.", "facade", "method")
+	r := prometheus.NewRegistry()
+	r.MustRegister(vec)
+
+	vec.With("Pinger", "Ping").Inc()
+	vec.With("Client", `Full"Status`).Add(2)
+	vec.With("Pinger", "Ping").Inc()
+	c.Assert(s.render(c, r), gc.Equals, `
+# HELP requests_total Requests.
+# TYPE requests_total counter
+requests_total{facade="Client",method="Full\"Status"} 2
+requests_total{facade="Pinger",method="Ping"} 2
+`[1:])
+}
+
+func (s *PrometheusSuite) TestCounterVecWrongLabels(c *gc.C) {
+	vec := prometheus.NewCounterVec("requests_total", "Requests.", "facade", "method")
+	c.Assert(func() { vec.With("Pinger") }, gc.PanicMatches,
+		`metric "requests_total" has 2 labels, got 1 values`,
+	)
+}
+
+func (s *PrometheusSuite) TestHistogramVec(c *gc.C) {
+	vec := prometheus.NewHistogramVec("duration_seconds", "Durations.", []float64{1, 0.5}, "facade")
+	r := prometheus.NewRegistry()
+	r.MustRegister(vec)
+
+	h := vec.With("Client")
+	h.Observe(0.25)
+	h.Observe(0.5)
+	h.Observe(0.75)
+	h.Observe(2)
+	c.Assert(s.render(c, r), gc.Equals, `
+# HELP duration_seconds Durations.
+# TYPE duration_seconds histogram
+duration_seconds_bucket{facade="Client",le="0.5"} 2
+duration_seconds_bucket{facade="Client",le="1"} 3
+duration_seconds_bucket{facade="Client",le="+Inf"} 4
+duration_seconds_sum{facade="Client"} 3.5
+duration_seconds_count{facade="Client"} 4
+`[1:])
+}
+
+func (s *PrometheusSuite) TestRegisterDuplicate(c *gc.C) {
+	r := prometheus.NewRegistry()
+	err := r.Register(prometheus.NewCounter("test_total", "Things."))
+	c.Assert(err, jc.ErrorIsNil)
+	err = r.Register(prometheus.NewGauge("test_total", "Other things."))
+	c.Assert(err, gc.ErrorMatches, `metric "test_total" already exists`)
+	c.Assert(err, jc.Satisfies, errors.IsAlreadyExists)
+
+	r.Unregister("test_total")
+	c.Assert(s.render(c, r), gc.Equals, "")
+}
@@ -229,6 +229,7 @@ func (engine *Engine) manifoldsReport() map[string]interface{} {
 			KeyInputs:      engine.manifolds[name].Inputs,
 			KeyReport:      info.report(),
 			KeyResourceLog: resourceLogReport(info.resourceLog),
+			KeyStartCount:  info.startCount,
 		}
 	}
 	return manifolds
@@ -466,6 +467,7 @@ func (engine *Engine) gotStarted(name string, worker worker.Worker, resourceLog
 		engine.current[name] = workerInfo{
 			worker:      worker,
 			resourceLog: resourceLog,
+			startCount:  info.startCount + 1,
 		}
 
 		// Any manifold that declares this one as an input needs to be restarted.
@@ -494,6 +496,7 @@ func (engine *Engine) gotStopped(name string, err error, resourceLog []resourceA
 	engine.current[name] = workerInfo{
 		err:         err,
 		resourceLog: resourceLog,
+		startCount:  info.startCount,
 	}
 	if engine.isDying() {
 		logger.Tracef("permanently stopped %q manifold worker (shutting down)", name)
@@ -503,7 +506,7 @@ func (engine *Engine) gotStopped(name string, err error, resourceLog []resourceA
 	// If we told the worker to stop, we should start it again immediately,
 	// whatever else happened.
 	if info.stopping {
-		engine.requestStart(name, engine.config.BounceDelay)
+		engine.requestRestart(name, engine.config.BounceDelay)
 	} else {
 		// If we didn't stop it ourselves, we need to interpret the error.
 		switch errors.Cause(err) {
@@ -517,14 +520,14 @@ func (engine *Engine) gotStopped(name string, err error, resourceLog []resourceA
 			// anyway).
 		case ErrBounce:
 			// The task exited but wanted to restart immediately.
-			engine.requestStart(name, engine.config.BounceDelay)
+			engine.requestRestart(name, engine.config.BounceDelay)
 		case ErrUninstall:
 			// The task should never run again, and can be removed completely.
 			engine.uninstall(name)
 		default:
 			// Something went wrong but we don't know what. Try again soon.
 			logger.Errorf("%q manifold worker returned unexpected error: %v", name, err)
-			engine.requestStart(name, engine.config.ErrorDelay)
+			engine.requestRestart(name, engine.config.ErrorDelay)
 		}
 	}
 
@@ -535,6 +538,14 @@ func (engine *Engine) gotStopped(name string, err error, resourceLog []resourceA
 	}
 }
 
+// requestRestart is like requestStart, but records that the manifold's
+// worker is being restarted after stopping. It must only be called from
+// the loop goroutine.
+func (engine *Engine) requestRestart(name string, delay time.Duration) {
+	workerRestarts.With(name).Inc()
+	engine.requestStart(name, delay)
+}
+
 // requestStop ensures that any running or starting worker will be stopped in the
 // near future. It must only be called from the loop goroutine.
 func (engine *Engine) requestStop(name string) {
@@ -603,6 +614,7 @@ type workerInfo struct {
 	worker      worker.Worker
 	err         error
 	resourceLog []resourceAccess
+	startCount  int
 }
 
 // stopped returns true unless the worker is either assigned or starting.
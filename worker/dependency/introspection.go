@@ -0,0 +1,20 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package dependency
+
+import (
+	"github.com/juju/juju/utils/prometheus"
+)
+
+// workerRestarts counts the restarts of manifold workers across all
+// engines in the process.
+var workerRestarts = prometheus.NewCounterVec(
+	"juju_dependency_worker_restarts_total",
+	"Number of times manifold workers were restarted, by manifold.",
+	"manifold",
+)
+
+func init() {
+	prometheus.MustRegister(workerRestarts)
+}
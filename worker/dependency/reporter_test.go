@@ -6,6 +6,7 @@ package dependency_test
 import (
 	"time"
 
+	"github.com/juju/errors"
 	"github.com/juju/testing"
 	jc "github.com/juju/testing/checkers"
 	gc "gopkg.in/check.v1"
@@ -101,6 +102,7 @@ func (s *ReportSuite) TestReportStopping(c *gc.C) {
 					"report": map[string]interface{}{
 						"key1": "hello there",
 					},
+					"start-count": 1,
 				},
 			},
 		})
@@ -132,6 +134,7 @@ func (s *ReportSuite) TestReportInputs(c *gc.C) {
 					"report": map[string]interface{}{
 						"key1": "hello there",
 					},
+					"start-count": 1,
 				},
 				"another task": map[string]interface{}{
 					"state":  "started",
@@ -145,12 +148,31 @@ func (s *ReportSuite) TestReportInputs(c *gc.C) {
 					"report": map[string]interface{}{
 						"key1": "hello there",
 					},
+					"start-count": 1,
 				},
 			},
 		})
 	})
 }
 
+func (s *ReportSuite) TestReportStartCount(c *gc.C) {
+	s.fix.run(c, func(engine *dependency.Engine) {
+		mh1 := newManifoldHarness()
+		err := engine.Install("task", mh1.Manifold())
+		c.Assert(err, jc.ErrorIsNil)
+		mh1.AssertOneStart(c)
+
+		mh1.InjectError(c, errors.New("boom"))
+		mh1.AssertOneStart(c)
+
+		report := engine.Report()
+		manifolds := report["manifolds"].(map[string]interface{})
+		task := manifolds["task"].(map[string]interface{})
+		c.Check(task["state"], gc.Equals, "started")
+		c.Check(task["start-count"], gc.Equals, 2)
+	})
+}
+
 func (s *ReportSuite) TestReportError(c *gc.C) {
 	s.fix.run(c, func(engine *dependency.Engine) {
 		mh1 := newManifoldHarness("missing")
@@ -174,7 +196,8 @@ func (s *ReportSuite) TestReportError(c *gc.C) {
 						"type":  "<nil>",
 						"error": dependency.ErrMissing,
 					}},
-					"report": (map[string]interface{})(nil),
+					"report":      (map[string]interface{})(nil),
+					"start-count": 0,
 				},
 			},
 		})
@@ -23,6 +23,10 @@ import (
 
 type machineRanges map[network.PortRange]bool
 
+// newEnviron is used to open the environment; it is a variable so
+// that it can be replaced in tests.
+var newEnviron = environs.New
+
 // Firewaller watches the state for port ranges opened or closed on
 // machines and reflects those changes onto the backing environment.
 // Uses Firewaller API V1.
@@ -39,7 +43,7 @@ type Firewaller struct {
 	serviceds       map[names.ServiceTag]*serviceData
 	exposedChange   chan *exposedChange
 	globalMode      bool
-	globalPortRef   map[network.PortRange]int
+	globalRuleRef   map[network.IngressRule]int
 	machinePorts    map[names.MachineTag]machineRanges
 }
 
@@ -78,7 +82,7 @@ func (fw *Firewaller) setUp() error {
 	// We won't "wait" actually, because the environ is already
 	// available and has a guaranteed valid config, but until
 	// WaitForEnviron goes away, this code needs to stay.
-	fw.environ, err = environ.WaitForEnviron(fw.modelWatcher, fw.st, environs.New, fw.catacomb.Dying())
+	fw.environ, err = environ.WaitForEnviron(fw.modelWatcher, fw.st, newEnviron, fw.catacomb.Dying())
 	if err != nil {
 		if err == environ.ErrWaitAborted {
 			return fw.catacomb.ErrDying()
@@ -89,7 +93,7 @@ func (fw *Firewaller) setUp() error {
 	case config.FwInstance:
 	case config.FwGlobal:
 		fw.globalMode = true
-		fw.globalPortRef = make(map[network.PortRange]int)
+		fw.globalRuleRef = make(map[network.IngressRule]int)
 	case config.FwNone:
 		logger.Infof("stopping firewaller (not required)")
 		fw.Kill()
@@ -182,6 +186,7 @@ func (fw *Firewaller) loop() error {
 			}
 		case change := <-fw.exposedChange:
 			change.serviced.exposed = change.exposed
+			change.serviced.cidrs = change.cidrs
 			unitds := []*unitData{}
 			for _, unitd := range change.serviced.unitds {
 				unitds = append(unitds, unitd)
@@ -200,7 +205,7 @@ func (fw *Firewaller) startMachine(tag names.MachineTag) error {
 		fw:           fw,
 		tag:          tag,
 		unitds:       make(map[names.UnitTag]*unitData),
-		openedPorts:  make([]network.PortRange, 0),
+		openedRules:  make([]network.IngressRule, 0),
 		definedPorts: make(map[network.PortRange]names.UnitTag),
 	}
 	m, err := machined.machine()
@@ -311,16 +316,21 @@ func (fw *Firewaller) startService(service *firewaller.Service) error {
 	if err != nil {
 		return err
 	}
+	cidrs, err := service.ExposedCIDRs()
+	if err != nil {
+		return err
+	}
 	serviced := &serviceData{
 		fw:      fw,
 		service: service,
 		exposed: exposed,
+		cidrs:   cidrs,
 		unitds:  make(map[names.UnitTag]*unitData),
 	}
 	err = catacomb.Invoke(catacomb.Plan{
 		Site: &serviced.catacomb,
 		Work: func() error {
-			return serviced.watchLoop(exposed)
+			return serviced.watchLoop(exposed, cidrs)
 		},
 	})
 	if err != nil {
@@ -337,11 +347,12 @@ func (fw *Firewaller) startService(service *firewaller.Service) error {
 // units and services with the opened and closed ports globally and
 // opens and closes the appropriate ports for the whole environment.
 func (fw *Firewaller) reconcileGlobal() error {
-	initialPortRanges, err := fw.environ.Ports()
+	initialRules, err := fw.environIngressRules()
 	if err != nil {
 		return err
 	}
-	collector := make(map[network.PortRange]bool)
+	_, restrictsSources := fw.environ.(environs.IngressRuleFirewaller)
+	collector := make(map[network.IngressRule]bool)
 	for _, machined := range fw.machineds {
 		for portRange, unitTag := range machined.definedPorts {
 			unitd, known := machined.unitds[unitTag]
@@ -349,31 +360,31 @@ func (fw *Firewaller) reconcileGlobal() error {
 				delete(machined.unitds, unitTag)
 				continue
 			}
-			if unitd.serviced.exposed {
-				collector[portRange] = true
+			for _, rule := range fw.serviceIngressRules(unitd.serviced, portRange, restrictsSources) {
+				collector[rule] = true
 			}
 		}
 	}
-	wantedPorts := []network.PortRange{}
-	for port := range collector {
-		wantedPorts = append(wantedPorts, port)
+	wantedRules := []network.IngressRule{}
+	for rule := range collector {
+		wantedRules = append(wantedRules, rule)
 	}
-	// Check which ports to open or to close.
-	toOpen := diffRanges(wantedPorts, initialPortRanges)
-	toClose := diffRanges(initialPortRanges, wantedPorts)
+	// Check which rules to open or to close.
+	toOpen := diffRules(wantedRules, initialRules)
+	toClose := diffRules(initialRules, wantedRules)
 	if len(toOpen) > 0 {
-		logger.Infof("opening global ports %v", toOpen)
-		if err := fw.environ.OpenPorts(toOpen); err != nil {
+		logger.Infof("opening global ingress rules %v", toOpen)
+		if err := fw.openEnvironRules(toOpen); err != nil {
 			return err
 		}
-		network.SortPortRanges(toOpen)
+		network.SortIngressRules(toOpen)
 	}
 	if len(toClose) > 0 {
-		logger.Infof("closing global ports %v", toClose)
-		if err := fw.environ.ClosePorts(toClose); err != nil {
+		logger.Infof("closing global ingress rules %v", toClose)
+		if err := fw.closeEnvironRules(toClose); err != nil {
 			return err
 		}
-		network.SortPortRanges(toClose)
+		network.SortIngressRules(toClose)
 	}
 	return nil
 }
@@ -407,31 +418,31 @@ func (fw *Firewaller) reconcileInstances() error {
 			return err
 		}
 		machineId := machined.tag.Id()
-		initialPortRanges, err := instances[0].Ports(machineId)
+		initialRules, err := instanceIngressRules(instances[0], machineId)
 		if err != nil {
 			return err
 		}
 
-		// Check which ports to open or to close.
-		toOpen := diffRanges(machined.openedPorts, initialPortRanges)
-		toClose := diffRanges(initialPortRanges, machined.openedPorts)
+		// Check which rules to open or to close.
+		toOpen := diffRules(machined.openedRules, initialRules)
+		toClose := diffRules(initialRules, machined.openedRules)
 		if len(toOpen) > 0 {
-			logger.Infof("opening instance port ranges %v for %q",
+			logger.Infof("opening instance ingress rules %v for %q",
 				toOpen, machined.tag)
-			if err := instances[0].OpenPorts(machineId, toOpen); err != nil {
+			if err := openInstanceRules(instances[0], machineId, toOpen); err != nil {
 				// TODO(mue) Add local retry logic.
 				return err
 			}
-			network.SortPortRanges(toOpen)
+			network.SortIngressRules(toOpen)
 		}
 		if len(toClose) > 0 {
-			logger.Infof("closing instance port ranges %v for %q",
+			logger.Infof("closing instance ingress rules %v for %q",
 				toClose, machined.tag)
-			if err := instances[0].ClosePorts(machineId, toClose); err != nil {
+			if err := closeInstanceRules(instances[0], machineId, toClose); err != nil {
 				// TODO(mue) Add local retry logic.
 				return err
 			}
-			network.SortPortRanges(toClose)
+			network.SortIngressRules(toClose)
 		}
 	}
 	return nil
@@ -552,68 +563,128 @@ func (fw *Firewaller) flushUnits(unitds []*unitData) error {
 
 // flushMachine opens and closes ports for the passed machine.
 func (fw *Firewaller) flushMachine(machined *machineData) error {
-	// Gather ports to open and close.
-	want := []network.PortRange{}
+	// Gather ingress rules to open and close. Whether rules can be
+	// restricted to source CIDRs is only checked when a service needs
+	// it, as it may require fetching the machine's instance.
+	want := []network.IngressRule{}
+	var checkedSources, restrictsSources bool
 	for portRange, unitTag := range machined.definedPorts {
 		unitd, known := machined.unitds[unitTag]
 		if !known {
 			delete(machined.unitds, unitTag)
 			continue
 		}
-		if unitd.serviced.exposed {
-			want = append(want, portRange)
+		if len(unitd.serviced.cidrs) > 0 && !checkedSources {
+			var err error
+			restrictsSources, err = fw.restrictsSources(machined)
+			if err != nil {
+				return errors.Trace(err)
+			}
+			checkedSources = true
 		}
+		want = append(want, fw.serviceIngressRules(unitd.serviced, portRange, restrictsSources)...)
+	}
+	toOpen := diffRules(want, machined.openedRules)
+	toClose := diffRules(machined.openedRules, want)
+	machined.openedRules = want
+	if fw.globalMode {
+		return fw.flushGlobalRules(toOpen, toClose)
 	}
-	toOpen := diffRanges(want, machined.openedPorts)
-	toClose := diffRanges(machined.openedPorts, want)
-	machined.openedPorts = want
+	return fw.flushInstanceRules(machined, toOpen, toClose)
+}
+
+// serviceIngressRules returns the ingress rules to open for a port
+// range opened by a unit of the given service. No rules are returned
+// if the service is not exposed. If the service is exposed only to
+// particular source CIDRs and restrictsSources is false, the port
+// range is left closed rather than being opened to everyone.
+func (fw *Firewaller) serviceIngressRules(serviced *serviceData, portRange network.PortRange, restrictsSources bool) []network.IngressRule {
+	if !serviced.exposed {
+		return nil
+	}
+	if len(serviced.cidrs) > 0 && !restrictsSources {
+		logger.Warningf(
+			"not opening port range %v for %q: the firewall cannot restrict access to source CIDRs %v",
+			portRange, serviced.service.Tag(), serviced.cidrs,
+		)
+		return nil
+	}
+	return network.NewIngressRules(portRange, serviced.cidrs...)
+}
+
+// restrictsSources reports whether the ingress rules opened for the
+// given machine can be restricted to particular source CIDRs. In
+// global mode that depends on the environment; in instance mode it
+// depends on the machine's instance, as some providers can only
+// restrict the sources of rules opened on instances.
+func (fw *Firewaller) restrictsSources(machined *machineData) (bool, error) {
 	if fw.globalMode {
-		return fw.flushGlobalPorts(toOpen, toClose)
+		_, ok := fw.environ.(environs.IngressRuleFirewaller)
+		return ok, nil
+	}
+	m, err := machined.machine()
+	if params.IsCodeNotFound(err) {
+		return false, nil
+	} else if err != nil {
+		return false, err
+	}
+	instanceId, err := m.InstanceId()
+	if params.IsCodeNotProvisioned(err) {
+		return false, nil
+	} else if err != nil {
+		return false, err
+	}
+	instances, err := fw.environ.Instances([]instance.Id{instanceId})
+	if err == environs.ErrNoInstances {
+		return false, nil
+	} else if err != nil {
+		return false, err
 	}
-	return fw.flushInstancePorts(machined, toOpen, toClose)
+	_, ok := instances[0].(instance.IngressRuleFirewaller)
+	return ok, nil
 }
 
-// flushGlobalPorts opens and closes global ports in the environment.
-// It keeps a reference count for ports so that only 0-to-1 and 1-to-0 events
-// modify the environment.
-func (fw *Firewaller) flushGlobalPorts(rawOpen, rawClose []network.PortRange) error {
-	// Filter which ports are really to open or close.
-	var toOpen, toClose []network.PortRange
-	for _, portRange := range rawOpen {
-		if fw.globalPortRef[portRange] == 0 {
-			toOpen = append(toOpen, portRange)
+// flushGlobalRules opens and closes global ingress rules in the
+// environment. It keeps a reference count for rules so that only
+// 0-to-1 and 1-to-0 events modify the environment.
+func (fw *Firewaller) flushGlobalRules(rawOpen, rawClose []network.IngressRule) error {
+	// Filter which rules are really to open or close.
+	var toOpen, toClose []network.IngressRule
+	for _, rule := range rawOpen {
+		if fw.globalRuleRef[rule] == 0 {
+			toOpen = append(toOpen, rule)
 		}
-		fw.globalPortRef[portRange]++
+		fw.globalRuleRef[rule]++
 	}
-	for _, portRange := range rawClose {
-		fw.globalPortRef[portRange]--
-		if fw.globalPortRef[portRange] == 0 {
-			toClose = append(toClose, portRange)
-			delete(fw.globalPortRef, portRange)
+	for _, rule := range rawClose {
+		fw.globalRuleRef[rule]--
+		if fw.globalRuleRef[rule] == 0 {
+			toClose = append(toClose, rule)
+			delete(fw.globalRuleRef, rule)
 		}
 	}
-	// Open and close the ports.
+	// Open and close the rules.
 	if len(toOpen) > 0 {
-		if err := fw.environ.OpenPorts(toOpen); err != nil {
+		if err := fw.openEnvironRules(toOpen); err != nil {
 			// TODO(mue) Add local retry logic.
 			return err
 		}
-		network.SortPortRanges(toOpen)
-		logger.Infof("opened port ranges %v in environment", toOpen)
+		network.SortIngressRules(toOpen)
+		logger.Infof("opened ingress rules %v in environment", toOpen)
 	}
 	if len(toClose) > 0 {
-		if err := fw.environ.ClosePorts(toClose); err != nil {
+		if err := fw.closeEnvironRules(toClose); err != nil {
 			// TODO(mue) Add local retry logic.
 			return err
 		}
-		network.SortPortRanges(toClose)
-		logger.Infof("closed port ranges %v in environment", toClose)
+		network.SortIngressRules(toClose)
+		logger.Infof("closed ingress rules %v in environment", toClose)
 	}
 	return nil
 }
 
-// flushInstancePorts opens and closes ports global on the machine.
-func (fw *Firewaller) flushInstancePorts(machined *machineData, toOpen, toClose []network.PortRange) error {
+// flushInstanceRules opens and closes ingress rules on the machine.
+func (fw *Firewaller) flushInstanceRules(machined *machineData, toOpen, toClose []network.IngressRule) error {
 	// If there's nothing to do, do nothing.
 	// This is important because when a machine is first created,
 	// it will have no instance id but also no open ports -
@@ -637,26 +708,103 @@ func (fw *Firewaller) flushInstancePorts(machined *machineData, toOpen, toClose
 	if err != nil {
 		return err
 	}
-	// Open and close the ports.
+	// Open and close the rules.
 	if len(toOpen) > 0 {
-		if err := instances[0].OpenPorts(machineId, toOpen); err != nil {
+		if err := openInstanceRules(instances[0], machineId, toOpen); err != nil {
 			// TODO(mue) Add local retry logic.
 			return err
 		}
-		network.SortPortRanges(toOpen)
-		logger.Infof("opened port ranges %v on %q", toOpen, machined.tag)
+		network.SortIngressRules(toOpen)
+		logger.Infof("opened ingress rules %v on %q", toOpen, machined.tag)
 	}
 	if len(toClose) > 0 {
-		if err := instances[0].ClosePorts(machineId, toClose); err != nil {
+		if err := closeInstanceRules(instances[0], machineId, toClose); err != nil {
 			// TODO(mue) Add local retry logic.
 			return err
 		}
-		network.SortPortRanges(toClose)
-		logger.Infof("closed port ranges %v on %q", toClose, machined.tag)
+		network.SortIngressRules(toClose)
+		logger.Infof("closed ingress rules %v on %q", toClose, machined.tag)
 	}
 	return nil
 }
 
+// environIngressRules returns the ingress rules open for the whole
+// environment. Port ranges opened by environments that cannot
+// restrict their source are open to traffic from anywhere.
+func (fw *Firewaller) environIngressRules() ([]network.IngressRule, error) {
+	if ingress, ok := fw.environ.(environs.IngressRuleFirewaller); ok {
+		return ingress.IngressRules()
+	}
+	ports, err := fw.environ.Ports()
+	if err != nil {
+		return nil, err
+	}
+	return network.UnrestrictedIngressRules(ports), nil
+}
+
+// openEnvironRules opens the given ingress rules for the whole
+// environment.
+func (fw *Firewaller) openEnvironRules(rules []network.IngressRule) error {
+	if ingress, ok := fw.environ.(environs.IngressRuleFirewaller); ok {
+		return ingress.OpenIngressRules(rules)
+	}
+	ports, err := network.PortRanges(rules)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	return fw.environ.OpenPorts(ports)
+}
+
+// closeEnvironRules closes the given ingress rules for the whole
+// environment.
+func (fw *Firewaller) closeEnvironRules(rules []network.IngressRule) error {
+	if ingress, ok := fw.environ.(environs.IngressRuleFirewaller); ok {
+		return ingress.CloseIngressRules(rules)
+	}
+	ports, err := network.PortRanges(rules)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	return fw.environ.ClosePorts(ports)
+}
+
+// instanceIngressRules returns the ingress rules open on the given
+// instance.
+func instanceIngressRules(inst instance.Instance, machineId string) ([]network.IngressRule, error) {
+	if ingress, ok := inst.(instance.IngressRuleFirewaller); ok {
+		return ingress.IngressRules(machineId)
+	}
+	ports, err := inst.Ports(machineId)
+	if err != nil {
+		return nil, err
+	}
+	return network.UnrestrictedIngressRules(ports), nil
+}
+
+// openInstanceRules opens the given ingress rules on the instance.
+func openInstanceRules(inst instance.Instance, machineId string, rules []network.IngressRule) error {
+	if ingress, ok := inst.(instance.IngressRuleFirewaller); ok {
+		return ingress.OpenIngressRules(machineId, rules)
+	}
+	ports, err := network.PortRanges(rules)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	return inst.OpenPorts(machineId, ports)
+}
+
+// closeInstanceRules closes the given ingress rules on the instance.
+func closeInstanceRules(inst instance.Instance, machineId string, rules []network.IngressRule) error {
+	if ingress, ok := inst.(instance.IngressRuleFirewaller); ok {
+		return ingress.CloseIngressRules(machineId, rules)
+	}
+	ports, err := network.PortRanges(rules)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	return inst.ClosePorts(machineId, ports)
+}
+
 // machineLifeChanged starts watching new machines when the firewaller
 // is starting, or when new machines come to life, and stops watching
 // machines that are dying.
@@ -751,7 +899,7 @@ type machineData struct {
 	fw          *Firewaller
 	tag         names.MachineTag
 	unitds      map[names.UnitTag]*unitData
-	openedPorts []network.PortRange
+	openedRules []network.IngressRule
 	// ports defined by units on this machine
 	definedPorts map[network.PortRange]names.UnitTag
 }
@@ -801,10 +949,12 @@ type unitData struct {
 	machined *machineData
 }
 
-// exposedChange contains the changed exposed flag for one specific service.
+// exposedChange contains the changed exposed flag and source CIDRs
+// for one specific service.
 type exposedChange struct {
 	serviced *serviceData
 	exposed  bool
+	cidrs    []string
 }
 
 // serviceData holds service details and watches exposure changes.
@@ -813,11 +963,13 @@ type serviceData struct {
 	fw       *Firewaller
 	service  *firewaller.Service
 	exposed  bool
+	cidrs    []string
 	unitds   map[names.UnitTag]*unitData
 }
 
-// watchLoop watches the service's exposed flag for changes.
-func (sd *serviceData) watchLoop(exposed bool) error {
+// watchLoop watches the service's exposed flag and source CIDRs for
+// changes.
+func (sd *serviceData) watchLoop(exposed bool, cidrs []string) error {
 	serviceWatcher, err := sd.service.Watch()
 	if err != nil {
 		return errors.Trace(err)
@@ -843,13 +995,17 @@ func (sd *serviceData) watchLoop(exposed bool) error {
 			if err != nil {
 				return errors.Trace(err)
 			}
-			if change == exposed {
+			changeCIDRs, err := sd.service.ExposedCIDRs()
+			if err != nil {
+				return errors.Trace(err)
+			}
+			if change == exposed && stringsEqual(changeCIDRs, cidrs) {
 				continue
 			}
 
-			exposed = change
+			exposed, cidrs = change, changeCIDRs
 			select {
-			case sd.fw.exposedChange <- &exposedChange{sd, change}:
+			case sd.fw.exposedChange <- &exposedChange{sd, change, changeCIDRs}:
 			case <-sd.catacomb.Dying():
 				return sd.catacomb.ErrDying()
 			}
@@ -867,8 +1023,8 @@ func (sd *serviceData) Wait() error {
 	return sd.catacomb.Wait()
 }
 
-// diffRanges returns all the port rangess that exist in A but not B.
-func diffRanges(A, B []network.PortRange) (missing []network.PortRange) {
+// diffRules returns all the ingress rules that exist in A but not B.
+func diffRules(A, B []network.IngressRule) (missing []network.IngressRule) {
 next:
 	for _, a := range A {
 		for _, b := range B {
@@ -881,6 +1037,20 @@ next:
 	return
 }
 
+// stringsEqual reports whether the two slices hold the same strings in
+// the same order.
+func stringsEqual(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
+
 // parsePortsKey parses a ports document global key coming from the ports
 // watcher (e.g. "42:0.1.2.0/24") and returns the machine and subnet tags from
 // its components (in the last example "machine-42" and "subnet-0.1.2.0/24").
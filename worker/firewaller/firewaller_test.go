@@ -13,6 +13,7 @@ import (
 
 	"github.com/juju/juju/api"
 	apifirewaller "github.com/juju/juju/api/firewaller"
+	"github.com/juju/juju/environs"
 	"github.com/juju/juju/environs/config"
 	"github.com/juju/juju/instance"
 	"github.com/juju/juju/juju"
@@ -114,6 +115,31 @@ func (s *firewallerBaseSuite) assertEnvironPorts(c *gc.C, expected []network.Por
 	}
 }
 
+// assertIngressRules retrieves the ingress rules using the given
+// function and compares them to the expected.
+func (s *firewallerBaseSuite) assertIngressRules(c *gc.C, get func() ([]network.IngressRule, error), expected []network.IngressRule) {
+	s.BackingState.StartSync()
+	start := time.Now()
+	for {
+		got, err := get()
+		if err != nil {
+			c.Fatal(err)
+			return
+		}
+		network.SortIngressRules(got)
+		network.SortIngressRules(expected)
+		if reflect.DeepEqual(got, expected) {
+			c.Succeed()
+			return
+		}
+		if time.Since(start) > coretesting.LongWait {
+			c.Fatalf("timed out: expected %v; got %v", expected, got)
+			return
+		}
+		time.Sleep(coretesting.ShortWait)
+	}
+}
+
 func (s *firewallerBaseSuite) addUnit(c *gc.C, svc *state.Service) (*state.Unit, *state.Machine) {
 	units, err := juju.AddUnits(s.State, svc, 1, nil)
 	c.Assert(err, jc.ErrorIsNil)
@@ -200,6 +226,112 @@ func (s *InstanceModeSuite) TestExposedService(c *gc.C) {
 	s.assertPorts(c, inst, m.Id(), []network.PortRange{{8080, 8080, "tcp"}})
 }
 
+func (s *InstanceModeSuite) TestServiceExposedToCIDRs(c *gc.C) {
+	fw, err := firewaller.NewFirewaller(s.firewaller)
+	c.Assert(err, jc.ErrorIsNil)
+	defer statetesting.AssertKillAndWait(c, fw)
+
+	svc := s.AddTestingService(c, "wordpress", s.charm)
+	err = svc.SetExposedCIDRs([]string{"10.0.0.0/8", "192.168.1.0/24"})
+	c.Assert(err, jc.ErrorIsNil)
+	u, m := s.addUnit(c, svc)
+	inst := s.startInstance(c, m)
+	ingress := inst.(instance.IngressRuleFirewaller)
+	rules := func() ([]network.IngressRule, error) {
+		return ingress.IngressRules(m.Id())
+	}
+
+	err = u.OpenPort("tcp", 80)
+	c.Assert(err, jc.ErrorIsNil)
+	s.assertIngressRules(c, rules, []network.IngressRule{
+		{network.PortRange{80, 80, "tcp"}, "10.0.0.0/8"},
+		{network.PortRange{80, 80, "tcp"}, "192.168.1.0/24"},
+	})
+
+	// Changing the source CIDRs changes the rules.
+	err = svc.SetExposedCIDRs([]string{"10.0.0.0/8"})
+	c.Assert(err, jc.ErrorIsNil)
+	s.assertIngressRules(c, rules, []network.IngressRule{
+		{network.PortRange{80, 80, "tcp"}, "10.0.0.0/8"},
+	})
+
+	// Exposing the service to everyone opens the port to everyone.
+	err = svc.SetExposed()
+	c.Assert(err, jc.ErrorIsNil)
+	s.assertIngressRules(c, rules, []network.IngressRule{
+		{network.PortRange{80, 80, "tcp"}, "0.0.0.0/0"},
+	})
+
+	err = svc.ClearExposed()
+	c.Assert(err, jc.ErrorIsNil)
+	s.assertIngressRules(c, rules, nil)
+}
+
+func (s *InstanceModeSuite) TestServiceExposedToCIDRsUnprovisioned(c *gc.C) {
+	fw, err := firewaller.NewFirewaller(s.firewaller)
+	c.Assert(err, jc.ErrorIsNil)
+	defer statetesting.AssertKillAndWait(c, fw)
+
+	svc := s.AddTestingService(c, "wordpress", s.charm)
+	err = svc.SetExposedCIDRs([]string{"10.0.0.0/8"})
+	c.Assert(err, jc.ErrorIsNil)
+	u, m := s.addUnit(c, svc)
+
+	// A port opened before the machine is provisioned must not
+	// stop the firewaller.
+	err = u.OpenPort("tcp", 80)
+	c.Assert(err, jc.ErrorIsNil)
+	inst := s.startInstance(c, m)
+	ingress := inst.(instance.IngressRuleFirewaller)
+	rules := func() ([]network.IngressRule, error) {
+		return ingress.IngressRules(m.Id())
+	}
+
+	// Once it is provisioned, changing the source CIDRs opens the rules.
+	err = svc.SetExposedCIDRs([]string{"10.0.0.0/8", "192.168.1.0/24"})
+	c.Assert(err, jc.ErrorIsNil)
+	s.assertIngressRules(c, rules, []network.IngressRule{
+		{network.PortRange{80, 80, "tcp"}, "10.0.0.0/8"},
+		{network.PortRange{80, 80, "tcp"}, "192.168.1.0/24"},
+	})
+}
+
+// instanceIngressOnlyEnviron hides the environ's implementation of
+// environs.IngressRuleFirewaller, leaving only the instances able to
+// restrict the sources of ingress rules.
+type instanceIngressOnlyEnviron struct {
+	environs.Environ
+}
+
+func (s *InstanceModeSuite) TestServiceExposedToCIDRsInstanceIngressOnly(c *gc.C) {
+	s.PatchValue(firewaller.NewEnviron, func(cfg *config.Config) (environs.Environ, error) {
+		env, err := environs.New(cfg)
+		if err != nil {
+			return nil, err
+		}
+		return instanceIngressOnlyEnviron{env}, nil
+	})
+	fw, err := firewaller.NewFirewaller(s.firewaller)
+	c.Assert(err, jc.ErrorIsNil)
+	defer statetesting.AssertKillAndWait(c, fw)
+
+	svc := s.AddTestingService(c, "wordpress", s.charm)
+	err = svc.SetExposedCIDRs([]string{"10.0.0.0/8"})
+	c.Assert(err, jc.ErrorIsNil)
+	u, m := s.addUnit(c, svc)
+	inst := s.startInstance(c, m)
+	ingress := inst.(instance.IngressRuleFirewaller)
+	rules := func() ([]network.IngressRule, error) {
+		return ingress.IngressRules(m.Id())
+	}
+
+	err = u.OpenPort("tcp", 80)
+	c.Assert(err, jc.ErrorIsNil)
+	s.assertIngressRules(c, rules, []network.IngressRule{
+		{network.PortRange{80, 80, "tcp"}, "10.0.0.0/8"},
+	})
+}
+
 func (s *InstanceModeSuite) TestMultipleExposedServices(c *gc.C) {
 	fw, err := firewaller.NewFirewaller(s.firewaller)
 	c.Assert(err, jc.ErrorIsNil)
@@ -670,6 +802,46 @@ func (s *GlobalModeSuite) TestGlobalMode(c *gc.C) {
 	s.assertEnvironPorts(c, nil)
 }
 
+func (s *GlobalModeSuite) TestGlobalModeExposedToCIDRs(c *gc.C) {
+	fw, err := firewaller.NewFirewaller(s.firewaller)
+	c.Assert(err, jc.ErrorIsNil)
+	defer statetesting.AssertKillAndWait(c, fw)
+	rules := s.Environ.(environs.IngressRuleFirewaller).IngressRules
+
+	svc1 := s.AddTestingService(c, "wordpress", s.charm)
+	err = svc1.SetExposedCIDRs([]string{"10.0.0.0/8"})
+	c.Assert(err, jc.ErrorIsNil)
+	u1, m1 := s.addUnit(c, svc1)
+	s.startInstance(c, m1)
+	err = u1.OpenPort("tcp", 80)
+	c.Assert(err, jc.ErrorIsNil)
+
+	svc2 := s.AddTestingService(c, "moinmoin", s.charm)
+	err = svc2.SetExposed()
+	c.Assert(err, jc.ErrorIsNil)
+	u2, m2 := s.addUnit(c, svc2)
+	s.startInstance(c, m2)
+	err = u2.OpenPort("tcp", 80)
+	c.Assert(err, jc.ErrorIsNil)
+
+	s.assertIngressRules(c, rules, []network.IngressRule{
+		{network.PortRange{80, 80, "tcp"}, "0.0.0.0/0"},
+		{network.PortRange{80, 80, "tcp"}, "10.0.0.0/8"},
+	})
+
+	// Closing the port on the unrestricted service leaves the
+	// restricted rule in place.
+	err = u2.ClosePort("tcp", 80)
+	c.Assert(err, jc.ErrorIsNil)
+	s.assertIngressRules(c, rules, []network.IngressRule{
+		{network.PortRange{80, 80, "tcp"}, "10.0.0.0/8"},
+	})
+
+	err = svc1.ClearExposed()
+	c.Assert(err, jc.ErrorIsNil)
+	s.assertIngressRules(c, rules, nil)
+}
+
 func (s *GlobalModeSuite) TestStartWithUnexposedService(c *gc.C) {
 	m, err := s.State.AddMachine("quantal", state.JobHostUnits)
 	c.Assert(err, jc.ErrorIsNil)
@@ -0,0 +1,258 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package hostfirewaller
+
+import (
+	"bytes"
+	"fmt"
+	"net"
+	"os/exec"
+	"strings"
+
+	"github.com/juju/errors"
+
+	"github.com/juju/juju/api/hostfirewaller"
+	"github.com/juju/juju/network"
+)
+
+// ChainName is the name of the iptables chain, and of the nftables
+// table, that holds the host firewall rules.
+const ChainName = "juju-ingress"
+
+// RunCommandFunc runs the named command with the given arguments,
+// writing input to its standard input, and returns its combined
+// output. We use this rather than os/exec directly for testing
+// purposes.
+type RunCommandFunc func(input, cmd string, args ...string) (string, error)
+
+// RunCommand is a RunCommandFunc that runs commands on the local
+// machine.
+func RunCommand(input, cmd string, args ...string) (string, error) {
+	logger.Tracef("running: %s %s", cmd, strings.Join(args, " "))
+	c := exec.Command(cmd, args...)
+	c.Stdin = strings.NewReader(input)
+	output, err := c.CombinedOutput()
+	if err != nil {
+		output := strings.TrimSpace(string(output))
+		if len(output) > 0 {
+			err = errors.Annotate(err, output)
+		}
+	}
+	return string(output), err
+}
+
+// NewIPTablesFirewall returns a Firewall that maintains the
+// juju-ingress chain with iptables and ip6tables, jumping to it from
+// the INPUT chain. The chain is replaced atomically with
+// iptables-restore, so setting the same rules again has no effect.
+func NewIPTablesFirewall(run RunCommandFunc) Firewall {
+	return &iptablesFirewall{run: run}
+}
+
+type iptablesFirewall struct {
+	run RunCommandFunc
+}
+
+var iptablesFamilies = []struct {
+	iptables string
+	restore  string
+	ipv6     bool
+}{
+	{"iptables", "iptables-restore", false},
+	{"ip6tables", "ip6tables-restore", true},
+}
+
+// SetRules is part of the Firewall interface.
+func (fw *iptablesFirewall) SetRules(rules hostfirewaller.Rules) error {
+	for _, family := range iptablesFamilies {
+		var buf bytes.Buffer
+		fmt.Fprintln(&buf, "*filter")
+		// Declaring the chain creates it, or flushes it if it
+		// already exists.
+		fmt.Fprintf(&buf, ":%s - [0:0]\n", ChainName)
+		for _, rule := range chainRules(rules, family.ipv6) {
+			fmt.Fprintf(&buf, "-A %s%s\n", ChainName, rule.iptables())
+		}
+		fmt.Fprintln(&buf, "COMMIT")
+		if _, err := fw.run(buf.String(), family.restore, "--noflush"); err != nil {
+			return errors.Annotatef(err, "setting %s rules", family.iptables)
+		}
+
+		// Jump to the chain from INPUT, unless it already does.
+		jump := []string{"INPUT", "-j", ChainName}
+		if _, err := fw.run("", family.iptables, append([]string{"-C"}, jump...)...); err == nil {
+			continue
+		}
+		if _, err := fw.run("", family.iptables, append([]string{"-I"}, jump...)...); err != nil {
+			return errors.Annotatef(err, "adding %s jump to %s", family.iptables, ChainName)
+		}
+	}
+	return nil
+}
+
+// NewNFTablesFirewall returns a Firewall that maintains the
+// juju-ingress table with nft. The table is replaced atomically, so
+// setting the same rules again has no effect.
+func NewNFTablesFirewall(run RunCommandFunc) Firewall {
+	return &nftablesFirewall{run: run}
+}
+
+type nftablesFirewall struct {
+	run RunCommandFunc
+}
+
+// SetRules is part of the Firewall interface.
+func (fw *nftablesFirewall) SetRules(rules hostfirewaller.Rules) error {
+	var buf bytes.Buffer
+	// Adding the table first means deleting it always succeeds; the
+	// whole file is applied as a single transaction.
+	fmt.Fprintf(&buf, "add table inet %s\n", ChainName)
+	fmt.Fprintf(&buf, "delete table inet %s\n", ChainName)
+	fmt.Fprintf(&buf, "table inet %s {\n", ChainName)
+	fmt.Fprintln(&buf, "\tchain input {")
+	fmt.Fprintln(&buf, "\t\ttype filter hook input priority 0; policy accept;")
+	// The table covers both address families, so the rules of both
+	// are merged, keeping all of those that allow traffic ahead of
+	// those that drop it. Rules that do not depend on the address
+	// family are generated for both, and only written once.
+	var allow, drop []string
+	seen := make(map[string]bool)
+	for _, ipv6 := range []bool{false, true} {
+		for _, rule := range chainRules(rules, ipv6) {
+			line := rule.nft()
+			if seen[line] {
+				continue
+			}
+			seen[line] = true
+			if rule.drop {
+				drop = append(drop, line)
+			} else {
+				allow = append(allow, line)
+			}
+		}
+	}
+	for _, line := range append(allow, drop...) {
+		fmt.Fprintf(&buf, "\t\t%s\n", line)
+	}
+	fmt.Fprintln(&buf, "\t}")
+	fmt.Fprintln(&buf, "}")
+	if _, err := fw.run(buf.String(), "nft", "-f", "-"); err != nil {
+		return errors.Annotate(err, "setting nftables rules")
+	}
+	return nil
+}
+
+// chainRule is a single rule in the host firewall, independent of
+// the tool used to apply it.
+type chainRule struct {
+	loopback    bool
+	established bool
+	sourceCIDR  string
+	ipv6        bool
+	portRange   *network.PortRange
+	drop        bool
+}
+
+// chainRules returns the rules for the given address family, in the
+// order they must be applied: traffic that is allowed returns before
+// reaching the rules that drop traffic to the opened ports.
+func chainRules(rules hostfirewaller.Rules, ipv6 bool) []chainRule {
+	result := []chainRule{
+		{loopback: true},
+		{established: true},
+	}
+	for _, cidr := range rules.InternalCIDRs {
+		if isIPv6CIDR(cidr) == ipv6 {
+			result = append(result, chainRule{sourceCIDR: cidr, ipv6: ipv6})
+		}
+	}
+	for _, rule := range rules.IngressRules {
+		if !isSupportedProtocol(rule.PortRange.Protocol) {
+			continue
+		}
+		portRange := rule.PortRange
+		if !rule.IsRestricted() {
+			result = append(result, chainRule{portRange: &portRange, ipv6: ipv6})
+		} else if isIPv6CIDR(rule.SourceCIDR) == ipv6 {
+			result = append(result, chainRule{
+				sourceCIDR: rule.SourceCIDR,
+				portRange:  &portRange,
+				ipv6:       ipv6,
+			})
+		}
+	}
+	for _, portRange := range rules.OpenedPorts {
+		if !isSupportedProtocol(portRange.Protocol) {
+			continue
+		}
+		portRange := portRange
+		result = append(result, chainRule{portRange: &portRange, drop: true, ipv6: ipv6})
+	}
+	return result
+}
+
+// iptables returns the rule specification as iptables arguments,
+// with a leading space.
+func (r chainRule) iptables() string {
+	var spec string
+	switch {
+	case r.loopback:
+		spec = " -i lo"
+	case r.established:
+		spec = " -m conntrack --ctstate ESTABLISHED,RELATED"
+	}
+	if r.sourceCIDR != "" {
+		spec += " -s " + r.sourceCIDR
+	}
+	if r.portRange != nil {
+		spec += fmt.Sprintf(" -p %s -m %s --dport %d", r.portRange.Protocol, r.portRange.Protocol, r.portRange.FromPort)
+		if r.portRange.ToPort != r.portRange.FromPort {
+			spec += fmt.Sprintf(":%d", r.portRange.ToPort)
+		}
+	}
+	if r.drop {
+		return spec + " -j DROP"
+	}
+	return spec + " -j RETURN"
+}
+
+// nft returns the rule as an nftables statement.
+func (r chainRule) nft() string {
+	var parts []string
+	switch {
+	case r.loopback:
+		parts = append(parts, `iifname "lo"`)
+	case r.established:
+		parts = append(parts, "ct state established,related")
+	}
+	if r.sourceCIDR != "" {
+		family := "ip"
+		if r.ipv6 {
+			family = "ip6"
+		}
+		parts = append(parts, fmt.Sprintf("%s saddr %s", family, r.sourceCIDR))
+	}
+	if r.portRange != nil {
+		ports := fmt.Sprint(r.portRange.FromPort)
+		if r.portRange.ToPort != r.portRange.FromPort {
+			ports += fmt.Sprintf("-%d", r.portRange.ToPort)
+		}
+		parts = append(parts, fmt.Sprintf("%s dport %s", r.portRange.Protocol, ports))
+	}
+	if r.drop {
+		parts = append(parts, "drop")
+	} else {
+		parts = append(parts, "accept")
+	}
+	return strings.Join(parts, " ")
+}
+
+func isIPv6CIDR(cidr string) bool {
+	ip, _, err := net.ParseCIDR(cidr)
+	return err == nil && ip.To4() == nil
+}
+
+func isSupportedProtocol(protocol string) bool {
+	return protocol == "tcp" || protocol == "udp"
+}
@@ -0,0 +1,148 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package hostfirewaller_test
+
+import (
+	"github.com/juju/errors"
+	jujutesting "github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	apihostfirewaller "github.com/juju/juju/api/hostfirewaller"
+	"github.com/juju/juju/network"
+	"github.com/juju/juju/worker/hostfirewaller"
+)
+
+type FirewallSuite struct {
+	jujutesting.IsolationSuite
+
+	stub  jujutesting.Stub
+	rules apihostfirewaller.Rules
+}
+
+var _ = gc.Suite(&FirewallSuite{})
+
+func (s *FirewallSuite) SetUpTest(c *gc.C) {
+	s.IsolationSuite.SetUpTest(c)
+	s.stub = jujutesting.Stub{}
+	s.rules = apihostfirewaller.Rules{
+		IngressRules: []network.IngressRule{
+			{PortRange: network.MustParsePortRange("80/tcp"), SourceCIDR: "0.0.0.0/0"},
+			{PortRange: network.MustParsePortRange("8000-8080/tcp"), SourceCIDR: "10.0.0.0/8"},
+			{PortRange: network.MustParsePortRange("8000-8080/tcp"), SourceCIDR: "2001:db8::/32"},
+		},
+		OpenedPorts: []network.PortRange{
+			network.MustParsePortRange("80/tcp"),
+			network.MustParsePortRange("3306/tcp"),
+			network.MustParsePortRange("8000-8080/tcp"),
+		},
+		InternalCIDRs: []string{"10.1.2.3/32", "2001:db8::1/128"},
+	}
+}
+
+// run is a fake hostfirewaller.RunCommandFunc that records the
+// commands run, and fails with the stub's next error.
+func (s *FirewallSuite) run(input, cmd string, args ...string) (string, error) {
+	s.stub.AddCall(cmd, append([]interface{}{input}, stringsToInterfaces(args)...)...)
+	return "", s.stub.NextErr()
+}
+
+func stringsToInterfaces(s []string) []interface{} {
+	result := make([]interface{}, len(s))
+	for i, v := range s {
+		result[i] = v
+	}
+	return result
+}
+
+const expectIPv4Rules = `*filter
+:juju-ingress - [0:0]
+-A juju-ingress -i lo -j RETURN
+-A juju-ingress -m conntrack --ctstate ESTABLISHED,RELATED -j RETURN
+-A juju-ingress -s 10.1.2.3/32 -j RETURN
+-A juju-ingress -p tcp -m tcp --dport 80 -j RETURN
+-A juju-ingress -s 10.0.0.0/8 -p tcp -m tcp --dport 8000:8080 -j RETURN
+-A juju-ingress -p tcp -m tcp --dport 80 -j DROP
+-A juju-ingress -p tcp -m tcp --dport 3306 -j DROP
+-A juju-ingress -p tcp -m tcp --dport 8000:8080 -j DROP
+COMMIT
+`
+
+const expectIPv6Rules = `*filter
+:juju-ingress - [0:0]
+-A juju-ingress -i lo -j RETURN
+-A juju-ingress -m conntrack --ctstate ESTABLISHED,RELATED -j RETURN
+-A juju-ingress -s 2001:db8::1/128 -j RETURN
+-A juju-ingress -p tcp -m tcp --dport 80 -j RETURN
+-A juju-ingress -s 2001:db8::/32 -p tcp -m tcp --dport 8000:8080 -j RETURN
+-A juju-ingress -p tcp -m tcp --dport 80 -j DROP
+-A juju-ingress -p tcp -m tcp --dport 3306 -j DROP
+-A juju-ingress -p tcp -m tcp --dport 8000:8080 -j DROP
+COMMIT
+`
+
+func (s *FirewallSuite) TestIPTablesSetRules(c *gc.C) {
+	// The jump from INPUT is missing, so it is added.
+	s.stub.SetErrors(nil, errors.New("no such rule"), nil, nil, nil)
+	fw := hostfirewaller.NewIPTablesFirewall(s.run)
+	err := fw.SetRules(s.rules)
+	c.Assert(err, jc.ErrorIsNil)
+	s.stub.CheckCalls(c, []jujutesting.StubCall{
+		{"iptables-restore", []interface{}{expectIPv4Rules, "--noflush"}},
+		{"iptables", []interface{}{"", "-C", "INPUT", "-j", "juju-ingress"}},
+		{"iptables", []interface{}{"", "-I", "INPUT", "-j", "juju-ingress"}},
+		{"ip6tables-restore", []interface{}{expectIPv6Rules, "--noflush"}},
+		{"ip6tables", []interface{}{"", "-C", "INPUT", "-j", "juju-ingress"}},
+	})
+}
+
+func (s *FirewallSuite) TestIPTablesSetRulesIdempotent(c *gc.C) {
+	fw := hostfirewaller.NewIPTablesFirewall(s.run)
+	err := fw.SetRules(s.rules)
+	c.Assert(err, jc.ErrorIsNil)
+	calls := s.stub.Calls()
+	s.stub.ResetCalls()
+
+	// Setting the same rules again runs the same commands, which
+	// replace the chain with an identical one, and leave the
+	// existing jump alone.
+	err = fw.SetRules(s.rules)
+	c.Assert(err, jc.ErrorIsNil)
+	s.stub.CheckCalls(c, calls)
+	s.stub.CheckCallNames(c,
+		"iptables-restore", "iptables",
+		"ip6tables-restore", "ip6tables",
+	)
+}
+
+func (s *FirewallSuite) TestIPTablesSetRulesError(c *gc.C) {
+	s.stub.SetErrors(errors.New("bad rule"))
+	fw := hostfirewaller.NewIPTablesFirewall(s.run)
+	err := fw.SetRules(s.rules)
+	c.Assert(err, gc.ErrorMatches, "setting iptables rules: bad rule")
+}
+
+func (s *FirewallSuite) TestNFTablesSetRules(c *gc.C) {
+	fw := hostfirewaller.NewNFTablesFirewall(s.run)
+	err := fw.SetRules(s.rules)
+	c.Assert(err, jc.ErrorIsNil)
+	s.stub.CheckCalls(c, []jujutesting.StubCall{{"nft", []interface{}{`add table inet juju-ingress
+delete table inet juju-ingress
+table inet juju-ingress {
+	chain input {
+		type filter hook input priority 0; policy accept;
+		iifname "lo" accept
+		ct state established,related accept
+		ip saddr 10.1.2.3/32 accept
+		tcp dport 80 accept
+		ip saddr 10.0.0.0/8 tcp dport 8000-8080 accept
+		ip6 saddr 2001:db8::1/128 accept
+		ip6 saddr 2001:db8::/32 tcp dport 8000-8080 accept
+		tcp dport 80 drop
+		tcp dport 3306 drop
+		tcp dport 8000-8080 drop
+	}
+}
+`, "-f", "-"}}})
+}
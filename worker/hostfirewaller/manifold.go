@@ -0,0 +1,117 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package hostfirewaller
+
+import (
+	"runtime"
+
+	"github.com/juju/errors"
+	"github.com/juju/names"
+
+	"github.com/juju/juju/agent"
+	"github.com/juju/juju/api/base"
+	"github.com/juju/juju/worker"
+	"github.com/juju/juju/worker/dependency"
+)
+
+// providerTypes holds the providers whose machines have no firewall
+// other than their own.
+var providerTypes = map[string]bool{
+	"manual": true,
+	"lxd":    true,
+}
+
+// ManifoldConfig defines the names of the manifolds on which the
+// hostfirewaller worker depends.
+type ManifoldConfig struct {
+	AgentName     string
+	APICallerName string
+
+	NewFacade   func(base.APICaller) (Facade, error)
+	NewFirewall func() (Firewall, error)
+	NewWorker   func(Config) (worker.Worker, error)
+}
+
+// validate is called by start to check for bad configuration.
+func (config ManifoldConfig) validate() error {
+	if config.AgentName == "" {
+		return errors.NotValidf("empty AgentName")
+	}
+	if config.APICallerName == "" {
+		return errors.NotValidf("empty APICallerName")
+	}
+	if config.NewFacade == nil {
+		return errors.NotValidf("nil NewFacade")
+	}
+	if config.NewFirewall == nil {
+		return errors.NotValidf("nil NewFirewall")
+	}
+	if config.NewWorker == nil {
+		return errors.NotValidf("nil NewWorker")
+	}
+	return nil
+}
+
+// start is a StartFunc for a Worker manifold.
+func (config ManifoldConfig) start(context dependency.Context) (worker.Worker, error) {
+	if runtime.GOOS != "linux" {
+		logger.Debugf("host firewall only supported on linux")
+		return nil, dependency.ErrUninstall
+	}
+
+	if err := config.validate(); err != nil {
+		return nil, errors.Trace(err)
+	}
+	var a agent.Agent
+	if err := context.Get(config.AgentName, &a); err != nil {
+		return nil, errors.Trace(err)
+	}
+	agentConfig := a.CurrentConfig()
+	tag := agentConfig.Tag()
+	if _, ok := tag.(names.MachineTag); !ok {
+		return nil, errors.New("hostfirewaller may only be used with a machine agent")
+	}
+	if providerType := agentConfig.Value(agent.ProviderType); !providerTypes[providerType] {
+		logger.Debugf("host firewall not needed with %q provider", providerType)
+		return nil, dependency.ErrUninstall
+	}
+
+	var apiCaller base.APICaller
+	if err := context.Get(config.APICallerName, &apiCaller); err != nil {
+		return nil, errors.Trace(err)
+	}
+	facade, err := config.NewFacade(apiCaller)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	firewall, err := config.NewFirewall()
+	if errors.IsNotFound(err) {
+		logger.Warningf("cannot manage host firewall: %v", err)
+		return nil, dependency.ErrUninstall
+	} else if err != nil {
+		return nil, errors.Trace(err)
+	}
+
+	worker, err := config.NewWorker(Config{
+		Facade:    facade,
+		MachineId: tag.Id(),
+		Firewall:  firewall,
+	})
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return worker, nil
+}
+
+// Manifold returns a dependency manifold that runs the hostfirewaller
+// worker.
+func Manifold(config ManifoldConfig) dependency.Manifold {
+	return dependency.Manifold{
+		Inputs: []string{
+			config.AgentName,
+			config.APICallerName,
+		},
+		Start: config.start,
+	}
+}
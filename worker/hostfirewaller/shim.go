@@ -0,0 +1,38 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package hostfirewaller
+
+import (
+	"os/exec"
+
+	"github.com/juju/errors"
+
+	"github.com/juju/juju/api/base"
+	apihostfirewaller "github.com/juju/juju/api/hostfirewaller"
+	"github.com/juju/juju/worker"
+)
+
+func NewFacade(apiCaller base.APICaller) (Facade, error) {
+	return apihostfirewaller.NewFacade(apiCaller), nil
+}
+
+func NewWorker(config Config) (worker.Worker, error) {
+	worker, err := New(config)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return worker, nil
+}
+
+// NewFirewall returns a Firewall using iptables if it is installed,
+// or nftables otherwise.
+func NewFirewall() (Firewall, error) {
+	if _, err := exec.LookPath("iptables-restore"); err == nil {
+		return NewIPTablesFirewall(RunCommand), nil
+	}
+	if _, err := exec.LookPath("nft"); err == nil {
+		return NewNFTablesFirewall(RunCommand), nil
+	}
+	return nil, errors.NotFoundf("iptables or nft")
+}
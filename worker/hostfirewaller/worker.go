@@ -0,0 +1,112 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+// Package hostfirewaller implements a worker that enforces the
+// exposure of services using the firewall of the machine itself, for
+// providers that have no firewall of their own (e.g. manual and lxd).
+//
+// The worker maintains a dedicated chain (or nftables table) that
+// drops traffic to the ports opened by units on the machine, except
+// when it comes from the loopback interface, from another machine in
+// the model, or from a source the port's service has been exposed to.
+// Traffic to any other port is left alone.
+package hostfirewaller
+
+import (
+	"reflect"
+
+	"github.com/juju/errors"
+	"github.com/juju/loggo"
+
+	"github.com/juju/juju/api/hostfirewaller"
+	"github.com/juju/juju/watcher"
+	"github.com/juju/juju/worker"
+)
+
+var logger = loggo.GetLogger("juju.worker.hostfirewaller")
+
+// Facade exposes controller functionality to a Worker.
+type Facade interface {
+	WatchHostFirewallRules(machineId string) (watcher.NotifyWatcher, error)
+	HostFirewallRules(machineId string) (hostfirewaller.Rules, error)
+}
+
+// Firewall applies rules to the firewall of the machine. SetRules must
+// replace any rules previously set, and must be idempotent.
+type Firewall interface {
+	SetRules(rules hostfirewaller.Rules) error
+}
+
+// Config defines the parameters of the hostfirewaller worker.
+type Config struct {
+	Facade    Facade
+	MachineId string
+	Firewall  Firewall
+}
+
+// Validate returns an error if Config cannot drive a hostfirewaller.
+func (config Config) Validate() error {
+	if config.Facade == nil {
+		return errors.NotValidf("nil Facade")
+	}
+	if config.MachineId == "" {
+		return errors.NotValidf("empty MachineId")
+	}
+	if config.Firewall == nil {
+		return errors.NotValidf("nil Firewall")
+	}
+	return nil
+}
+
+// New returns a Worker backed by config, or an error.
+func New(config Config) (worker.Worker, error) {
+	if err := config.Validate(); err != nil {
+		return nil, errors.Trace(err)
+	}
+	w, err := watcher.NewNotifyWorker(watcher.NotifyConfig{
+		Handler: &handler{config: config},
+	})
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return w, nil
+}
+
+// handler implements watcher.NotifyHandler, applying the machine's
+// rules to the firewall whenever they change.
+type handler struct {
+	config  Config
+	applied *hostfirewaller.Rules
+}
+
+// SetUp is part of the watcher.NotifyHandler interface.
+func (h *handler) SetUp() (watcher.NotifyWatcher, error) {
+	return h.config.Facade.WatchHostFirewallRules(h.config.MachineId)
+}
+
+// Handle is part of the watcher.NotifyHandler interface.
+func (h *handler) Handle(_ <-chan struct{}) error {
+	rules, err := h.config.Facade.HostFirewallRules(h.config.MachineId)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if h.applied != nil && reflect.DeepEqual(rules, *h.applied) {
+		return nil
+	}
+	if err := h.config.Firewall.SetRules(rules); err != nil {
+		return errors.Annotate(err, "cannot set host firewall rules")
+	}
+	logger.Debugf(
+		"host firewall rules set: %d ingress rules for %d opened port ranges",
+		len(rules.IngressRules), len(rules.OpenedPorts),
+	)
+	h.applied = &rules
+	return nil
+}
+
+// TearDown is part of the watcher.NotifyHandler interface.
+func (h *handler) TearDown() error {
+	// The rules are left in place, so the ports stay closed while
+	// the agent is restarting.
+	return nil
+}
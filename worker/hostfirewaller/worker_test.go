@@ -0,0 +1,185 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package hostfirewaller_test
+
+import (
+	"time"
+
+	"github.com/juju/errors"
+	jujutesting "github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"launchpad.net/tomb"
+
+	apihostfirewaller "github.com/juju/juju/api/hostfirewaller"
+	"github.com/juju/juju/network"
+	coretesting "github.com/juju/juju/testing"
+	"github.com/juju/juju/watcher"
+	"github.com/juju/juju/worker/hostfirewaller"
+	"github.com/juju/juju/worker/workertest"
+)
+
+type WorkerSuite struct {
+	jujutesting.IsolationSuite
+
+	facade   *mockFacade
+	firewall *mockFirewall
+	config   hostfirewaller.Config
+}
+
+var _ = gc.Suite(&WorkerSuite{})
+
+func (s *WorkerSuite) SetUpTest(c *gc.C) {
+	s.IsolationSuite.SetUpTest(c)
+	s.facade = &mockFacade{
+		watcher: newMockNotifyWatcher(),
+		rulesCh: make(chan apihostfirewaller.Rules, 1),
+		rules: apihostfirewaller.Rules{
+			IngressRules: []network.IngressRule{
+				{network.MustParsePortRange("80/tcp"), "0.0.0.0/0"},
+			},
+			OpenedPorts: []network.PortRange{network.MustParsePortRange("80/tcp")},
+		},
+	}
+	s.firewall = &mockFirewall{set: make(chan apihostfirewaller.Rules, 10)}
+	s.config = hostfirewaller.Config{
+		Facade:    s.facade,
+		MachineId: "42",
+		Firewall:  s.firewall,
+	}
+}
+
+func (s *WorkerSuite) TestInvalidConfig(c *gc.C) {
+	s.config.Firewall = nil
+	_, err := hostfirewaller.New(s.config)
+	c.Check(err, gc.ErrorMatches, "nil Firewall not valid")
+	s.facade.stub.CheckNoCalls(c)
+}
+
+func (s *WorkerSuite) TestSetsRules(c *gc.C) {
+	w, err := hostfirewaller.New(s.config)
+	c.Assert(err, jc.ErrorIsNil)
+	defer workertest.CleanKill(c, w)
+
+	s.facade.watcher.changes <- struct{}{}
+	c.Assert(s.nextRules(c), jc.DeepEquals, s.facade.rules)
+	s.facade.stub.CheckCalls(c, []jujutesting.StubCall{
+		{"WatchHostFirewallRules", []interface{}{"42"}},
+		{"HostFirewallRules", []interface{}{"42"}},
+	})
+}
+
+func (s *WorkerSuite) TestSkipsUnchangedRules(c *gc.C) {
+	w, err := hostfirewaller.New(s.config)
+	c.Assert(err, jc.ErrorIsNil)
+	defer workertest.CleanKill(c, w)
+
+	s.facade.watcher.changes <- struct{}{}
+	s.nextRules(c)
+
+	// The same rules are not applied again.
+	s.facade.watcher.changes <- struct{}{}
+	s.assertNoRules(c)
+
+	// Changed rules are.
+	s.facade.setRules(apihostfirewaller.Rules{
+		OpenedPorts: []network.PortRange{network.MustParsePortRange("80/tcp")},
+	})
+	s.facade.watcher.changes <- struct{}{}
+	c.Assert(s.nextRules(c).IngressRules, gc.HasLen, 0)
+}
+
+func (s *WorkerSuite) TestSetRulesError(c *gc.C) {
+	s.firewall.err = errors.New("iptables failed")
+	w, err := hostfirewaller.New(s.config)
+	c.Assert(err, jc.ErrorIsNil)
+
+	s.facade.watcher.changes <- struct{}{}
+	err = workertest.CheckKilled(c, w)
+	c.Assert(err, gc.ErrorMatches, "cannot set host firewall rules: iptables failed")
+}
+
+func (s *WorkerSuite) nextRules(c *gc.C) apihostfirewaller.Rules {
+	select {
+	case rules := <-s.firewall.set:
+		return rules
+	case <-time.After(coretesting.LongWait):
+		c.Fatalf("timed out waiting for rules to be set")
+	}
+	panic("unreachable")
+}
+
+func (s *WorkerSuite) assertNoRules(c *gc.C) {
+	select {
+	case rules := <-s.firewall.set:
+		c.Fatalf("unexpected rules set: %v", rules)
+	case <-time.After(coretesting.ShortWait):
+	}
+}
+
+type mockFacade struct {
+	stub    jujutesting.Stub
+	watcher *mockNotifyWatcher
+	rules   apihostfirewaller.Rules
+	rulesCh chan apihostfirewaller.Rules
+}
+
+func (f *mockFacade) WatchHostFirewallRules(machineId string) (watcher.NotifyWatcher, error) {
+	f.stub.AddCall("WatchHostFirewallRules", machineId)
+	return f.watcher, f.stub.NextErr()
+}
+
+func (f *mockFacade) HostFirewallRules(machineId string) (apihostfirewaller.Rules, error) {
+	f.stub.AddCall("HostFirewallRules", machineId)
+	select {
+	case f.rules = <-f.rulesCh:
+	default:
+	}
+	return f.rules, f.stub.NextErr()
+}
+
+// setRules arranges for the next call to HostFirewallRules to
+// return the given rules, without racing with the worker.
+func (f *mockFacade) setRules(rules apihostfirewaller.Rules) {
+	f.rulesCh <- rules
+}
+
+type mockFirewall struct {
+	set chan apihostfirewaller.Rules
+	err error
+}
+
+func (f *mockFirewall) SetRules(rules apihostfirewaller.Rules) error {
+	if f.err != nil {
+		return f.err
+	}
+	f.set <- rules
+	return nil
+}
+
+type mockNotifyWatcher struct {
+	tomb    tomb.Tomb
+	changes chan struct{}
+}
+
+func newMockNotifyWatcher() *mockNotifyWatcher {
+	w := &mockNotifyWatcher{changes: make(chan struct{}, 1)}
+	go func() {
+		defer w.tomb.Done()
+		<-w.tomb.Dying()
+	}()
+	return w
+}
+
+func (w *mockNotifyWatcher) Kill() {
+	w.tomb.Kill(nil)
+}
+
+func (w *mockNotifyWatcher) Wait() error {
+	return w.tomb.Wait()
+}
+
+func (w *mockNotifyWatcher) Changes() watcher.NotifyChannel {
+	return w.changes
+}
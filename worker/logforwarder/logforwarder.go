@@ -0,0 +1,252 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+// Package logforwarder provides a worker that forwards a model's logs
+// to a remote syslog host, when one is configured for the model.
+package logforwarder
+
+import (
+	"time"
+
+	"github.com/juju/errors"
+	"github.com/juju/loggo"
+	"github.com/juju/names"
+
+	"github.com/juju/juju/environs/config"
+	"github.com/juju/juju/logfwd/syslog"
+	"github.com/juju/juju/state"
+	"github.com/juju/juju/worker"
+	"github.com/juju/juju/worker/catacomb"
+)
+
+var logger = loggo.GetLogger("juju.worker.logforwarder")
+
+// Backend defines the State functionality used by the log forwarder.
+type Backend interface {
+	Model() (*state.Model, error)
+	ModelConfig() (*config.Config, error)
+	WatchForModelConfigChanges() state.NotifyWatcher
+}
+
+// LastSentTracker records the timestamp of the most recent log record
+// that was forwarded, so that forwarding can resume from that point.
+type LastSentTracker interface {
+	Get() (time.Time, error)
+	Set(time.Time) error
+}
+
+// Sender is a connection to a log sink.
+type Sender interface {
+	Send(...syslog.Message) error
+	Close() error
+}
+
+// NewLogTailerFunc returns a LogTailer for the model's logs.
+type NewLogTailerFunc func(*state.LogTailerParams) (state.LogTailer, error)
+
+// OpenSinkFunc opens a connection to the syslog host described by
+// the given config.
+type OpenSinkFunc func(syslog.RawConfig) (Sender, error)
+
+// OpenSyslog is an OpenSinkFunc that connects to a real syslog host.
+func OpenSyslog(cfg syslog.RawConfig) (Sender, error) {
+	return syslog.Open(cfg)
+}
+
+// Config holds the dependencies and configuration necessary to run
+// a log forwarder.
+type Config struct {
+	Backend      Backend
+	LastSent     LastSentTracker
+	NewLogTailer NewLogTailerFunc
+	OpenSink     OpenSinkFunc
+}
+
+// Validate returns an error if config cannot be expected to drive
+// a functional log forwarder.
+func (config Config) Validate() error {
+	if config.Backend == nil {
+		return errors.NotValidf("nil Backend")
+	}
+	if config.LastSent == nil {
+		return errors.NotValidf("nil LastSent")
+	}
+	if config.NewLogTailer == nil {
+		return errors.NotValidf("nil NewLogTailer")
+	}
+	if config.OpenSink == nil {
+		return errors.NotValidf("nil OpenSink")
+	}
+	return nil
+}
+
+// New returns a worker that watches the model's config and, while
+// syslog forwarding is enabled, forwards the model's logs to the
+// configured syslog host. The worker stops without error when the
+// model is dead or has been removed.
+func New(config Config) (worker.Worker, error) {
+	if err := config.Validate(); err != nil {
+		return nil, errors.Trace(err)
+	}
+	lf := &logForwarder{config: config}
+	err := catacomb.Invoke(catacomb.Plan{
+		Site: &lf.catacomb,
+		Work: lf.loop,
+	})
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return lf, nil
+}
+
+type logForwarder struct {
+	catacomb catacomb.Catacomb
+	config   Config
+
+	// current holds the syslog config in use, or nil if
+	// forwarding is disabled.
+	current *syslog.RawConfig
+	sender  worker.Worker
+}
+
+// Kill is part of the worker.Worker interface.
+func (lf *logForwarder) Kill() {
+	lf.catacomb.Kill(nil)
+}
+
+// Wait is part of the worker.Worker interface.
+func (lf *logForwarder) Wait() error {
+	return lf.catacomb.Wait()
+}
+
+func (lf *logForwarder) loop() error {
+	model, err := lf.config.Backend.Model()
+	if errors.IsNotFound(err) {
+		return nil
+	} else if err != nil {
+		return errors.Trace(err)
+	}
+	modelWatcher := model.Watch()
+	if err := lf.catacomb.Add(modelWatcher); err != nil {
+		return errors.Trace(err)
+	}
+	configWatcher := lf.config.Backend.WatchForModelConfigChanges()
+	if err := lf.catacomb.Add(configWatcher); err != nil {
+		return errors.Trace(err)
+	}
+
+	for {
+		select {
+		case <-lf.catacomb.Dying():
+			return lf.catacomb.ErrDying()
+		case _, ok := <-modelWatcher.Changes():
+			if !ok {
+				return errors.New("model watcher closed")
+			}
+			if err := model.Refresh(); errors.IsNotFound(err) {
+				return nil
+			} else if err != nil {
+				return errors.Trace(err)
+			}
+			if model.Life() == state.Dead {
+				return nil
+			}
+		case _, ok := <-configWatcher.Changes():
+			if !ok {
+				return errors.New("model config watcher closed")
+			}
+			if err := lf.configChanged(); err != nil {
+				return errors.Trace(err)
+			}
+		}
+	}
+}
+
+// configChanged starts, stops or restarts the sender according to
+// the model's current syslog forwarding config.
+func (lf *logForwarder) configChanged() error {
+	modelConfig, err := lf.config.Backend.ModelConfig()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	syslogConfig, enabled := modelConfig.LogFwdSyslog()
+	if !enabled {
+		syslogConfig = nil
+	}
+	if syslogConfig == nil && lf.current == nil {
+		return nil
+	}
+	if syslogConfig != nil && lf.current != nil && *syslogConfig == *lf.current {
+		return nil
+	}
+
+	if lf.sender != nil {
+		logger.Debugf("stopping log forwarding to %q", lf.current.Host)
+		if err := worker.Stop(lf.sender); err != nil {
+			return errors.Trace(err)
+		}
+		lf.sender = nil
+	}
+	lf.current = syslogConfig
+	if syslogConfig == nil {
+		return nil
+	}
+
+	logger.Infof("forwarding logs to %q", syslogConfig.Host)
+	sender, err := newSender(senderConfig{
+		syslogConfig: *syslogConfig,
+		lastSent:     lf.config.LastSent,
+		newLogTailer: lf.config.NewLogTailer,
+		openSink:     lf.config.OpenSink,
+	})
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if err := lf.catacomb.Add(sender); err != nil {
+		return errors.Trace(err)
+	}
+	lf.sender = sender
+	return nil
+}
+
+// sinkName identifies the syslog sink when recording the timestamp
+// of the last forwarded log record.
+const sinkName = "syslog"
+
+// NewForModel returns a log forwarder for the model with the given
+// UUID, using a State opened from the supplied controller State.
+// The model's State is closed when the worker stops.
+func NewForModel(st *state.State, modelUUID string) (worker.Worker, error) {
+	modelSt, err := st.ForModel(names.NewModelTag(modelUUID))
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	w, err := New(Config{
+		Backend:  modelSt,
+		LastSent: state.NewLastSentLogger(modelSt, sinkName),
+		NewLogTailer: func(params *state.LogTailerParams) (state.LogTailer, error) {
+			return state.NewLogTailer(modelSt, params)
+		},
+		OpenSink: OpenSyslog,
+	})
+	if err != nil {
+		modelSt.Close()
+		return nil, errors.Trace(err)
+	}
+	return &stateCloser{Worker: w, st: modelSt}, nil
+}
+
+// stateCloser closes a State once the worker using it has stopped.
+type stateCloser struct {
+	worker.Worker
+	st *state.State
+}
+
+// Wait is part of the worker.Worker interface.
+func (w *stateCloser) Wait() error {
+	err := w.Worker.Wait()
+	if closeErr := w.st.Close(); closeErr != nil {
+		logger.Errorf("while closing model state: %v", closeErr)
+	}
+	return err
+}
@@ -0,0 +1,239 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package logforwarder_test
+
+import (
+	"time"
+
+	"github.com/juju/errors"
+	"github.com/juju/loggo"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	syslogtesting "github.com/juju/juju/logfwd/syslog/testing"
+	"github.com/juju/juju/state"
+	statetesting "github.com/juju/juju/state/testing"
+	coretesting "github.com/juju/juju/testing"
+	"github.com/juju/juju/worker"
+	"github.com/juju/juju/worker/logforwarder"
+	"github.com/juju/juju/worker/workertest"
+)
+
+type LogForwarderSuite struct {
+	statetesting.StateSuite
+	server   *syslogtesting.Server
+	lastSent *state.DbLoggerLastSent
+	tailers  chan *stubTailer
+}
+
+var _ = gc.Suite(&LogForwarderSuite{})
+
+func (s *LogForwarderSuite) SetUpTest(c *gc.C) {
+	s.StateSuite.SetUpTest(c)
+	server, err := syslogtesting.NewServer()
+	c.Assert(err, jc.ErrorIsNil)
+	s.server = server
+	s.AddCleanup(func(*gc.C) { s.server.Close() })
+	s.lastSent = state.NewLastSentLogger(s.State, "test")
+	s.tailers = make(chan *stubTailer, 10)
+}
+
+func (s *LogForwarderSuite) newLogTailer(params *state.LogTailerParams) (state.LogTailer, error) {
+	tailer := &stubTailer{
+		params: params,
+		logs:   make(chan *state.LogRecord),
+		dying:  make(chan struct{}),
+	}
+	s.tailers <- tailer
+	return tailer, nil
+}
+
+func (s *LogForwarderSuite) startWorker(c *gc.C) worker.Worker {
+	w, err := logforwarder.New(logforwarder.Config{
+		Backend:      s.State,
+		LastSent:     s.lastSent,
+		NewLogTailer: s.newLogTailer,
+		OpenSink:     logforwarder.OpenSyslog,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	s.AddCleanup(func(c *gc.C) { workertest.DirtyKill(c, w) })
+	return w
+}
+
+func (s *LogForwarderSuite) enableForwarding(c *gc.C) {
+	cfg, err := s.server.Config()
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.State.UpdateModelConfig(map[string]interface{}{
+		"syslog-host":        cfg.Host,
+		"syslog-ca-cert":     cfg.CACert,
+		"syslog-client-cert": cfg.ClientCert,
+		"syslog-client-key":  cfg.ClientKey,
+	}, nil, nil)
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *LogForwarderSuite) nextTailer(c *gc.C) *stubTailer {
+	select {
+	case tailer := <-s.tailers:
+		return tailer
+	case <-time.After(coretesting.LongWait):
+		c.Fatalf("timed out waiting for log tailer")
+	}
+	panic("unreachable")
+}
+
+func (s *LogForwarderSuite) assertNoTailer(c *gc.C) {
+	select {
+	case <-s.tailers:
+		c.Fatalf("unexpected log tailer")
+	case <-time.After(coretesting.ShortWait):
+	}
+}
+
+func (s *LogForwarderSuite) nextMessage(c *gc.C) string {
+	select {
+	case msg := <-s.server.Messages():
+		return msg
+	case <-time.After(coretesting.LongWait):
+		c.Fatalf("timed out waiting for syslog message")
+	}
+	panic("unreachable")
+}
+
+func (s *LogForwarderSuite) TestValidate(c *gc.C) {
+	_, err := logforwarder.New(logforwarder.Config{})
+	c.Check(err, jc.Satisfies, errors.IsNotValid)
+	c.Check(err, gc.ErrorMatches, "nil Backend not valid")
+}
+
+func (s *LogForwarderSuite) TestDisabledByDefault(c *gc.C) {
+	w := s.startWorker(c)
+
+	s.assertNoTailer(c)
+	workertest.CleanKill(c, w)
+}
+
+func (s *LogForwarderSuite) TestForwardsLogs(c *gc.C) {
+	s.enableForwarding(c)
+	w := s.startWorker(c)
+
+	tailer := s.nextTailer(c)
+	c.Check(tailer.params.StartTime.IsZero(), jc.IsTrue)
+
+	t0 := time.Date(2016, 6, 1, 12, 0, 0, 0, time.UTC)
+	tailer.send(c, &state.LogRecord{
+		Time:      t0,
+		Entity:    "machine-0",
+		Module:    "juju.worker",
+		Location:  "worker.go:42",
+		Level:     loggo.WARNING,
+		Message:   "look out",
+		ModelUUID: s.State.ModelUUID(),
+	})
+
+	msg := s.nextMessage(c)
+	c.Check(msg, gc.Equals,
+		`<12>1 2016-06-01T12:00:00.000000Z machine-0 juju - - `+
+			`[model@28978 model-uuid="`+s.State.ModelUUID()+`"]`+
+			`[log@28978 module="juju.worker" source="worker.go:42"] look out`,
+	)
+	s.assertLastSent(c, t0)
+	workertest.CleanKill(c, w)
+}
+
+func (s *LogForwarderSuite) TestResumesFromLastSent(c *gc.C) {
+	t0 := time.Date(2016, 6, 1, 12, 0, 0, 0, time.UTC)
+	err := s.lastSent.Set(t0)
+	c.Assert(err, jc.ErrorIsNil)
+	s.enableForwarding(c)
+	w := s.startWorker(c)
+
+	tailer := s.nextTailer(c)
+	c.Check(tailer.params.StartTime, gc.Equals, t0)
+	workertest.CleanKill(c, w)
+}
+
+func (s *LogForwarderSuite) TestEnabledByConfigChange(c *gc.C) {
+	w := s.startWorker(c)
+	s.assertNoTailer(c)
+
+	s.enableForwarding(c)
+	s.nextTailer(c)
+	workertest.CleanKill(c, w)
+}
+
+func (s *LogForwarderSuite) TestDisabledByConfigChange(c *gc.C) {
+	s.enableForwarding(c)
+	w := s.startWorker(c)
+	tailer := s.nextTailer(c)
+
+	err := s.State.UpdateModelConfig(nil, []string{"syslog-host"}, nil)
+	c.Assert(err, jc.ErrorIsNil)
+
+	select {
+	case <-tailer.dying:
+	case <-time.After(coretesting.LongWait):
+		c.Fatalf("timed out waiting for log tailer to stop")
+	}
+	s.assertNoTailer(c)
+	workertest.CleanKill(c, w)
+}
+
+func (s *LogForwarderSuite) TestSinkUnreachable(c *gc.C) {
+	s.enableForwarding(c)
+	s.server.Close()
+	w := s.startWorker(c)
+
+	err := workertest.CheckKilled(c, w)
+	c.Check(err, gc.ErrorMatches, `connecting to syslog host ".*": .*`)
+}
+
+func (s *LogForwarderSuite) assertLastSent(c *gc.C, expected time.Time) {
+	for a := coretesting.LongAttempt.Start(); a.Next(); {
+		lastSent, err := s.lastSent.Get()
+		if errors.Cause(err) == state.ErrNeverForwarded {
+			continue
+		}
+		c.Assert(err, jc.ErrorIsNil)
+		if lastSent.Equal(expected) {
+			return
+		}
+	}
+	c.Fatalf("last sent timestamp not recorded")
+}
+
+type stubTailer struct {
+	params *state.LogTailerParams
+	logs   chan *state.LogRecord
+	dying  chan struct{}
+}
+
+func (t *stubTailer) send(c *gc.C, rec *state.LogRecord) {
+	select {
+	case t.logs <- rec:
+	case <-time.After(coretesting.LongWait):
+		c.Fatalf("timed out sending log record")
+	}
+}
+
+// Logs is part of the state.LogTailer interface.
+func (t *stubTailer) Logs() <-chan *state.LogRecord {
+	return t.logs
+}
+
+// Dying is part of the state.LogTailer interface.
+func (t *stubTailer) Dying() <-chan struct{} {
+	return t.dying
+}
+
+// Stop is part of the state.LogTailer interface.
+func (t *stubTailer) Stop() error {
+	close(t.dying)
+	return nil
+}
+
+// Err is part of the state.LogTailer interface.
+func (t *stubTailer) Err() error {
+	return nil
+}
@@ -0,0 +1,157 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package logforwarder
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/juju/errors"
+
+	"github.com/juju/juju/logfwd/syslog"
+	"github.com/juju/juju/state"
+	"github.com/juju/juju/worker/catacomb"
+)
+
+// maxBatchSize is the largest number of log records that will be
+// sent before the last sent timestamp is recorded.
+const maxBatchSize = 100
+
+type senderConfig struct {
+	syslogConfig syslog.RawConfig
+	lastSent     LastSentTracker
+	newLogTailer NewLogTailerFunc
+	openSink     OpenSinkFunc
+}
+
+// sender tails the model's logs and sends them to a single syslog
+// host, starting from the last record that was successfully sent.
+type sender struct {
+	catacomb catacomb.Catacomb
+	config   senderConfig
+}
+
+func newSender(config senderConfig) (*sender, error) {
+	s := &sender{config: config}
+	err := catacomb.Invoke(catacomb.Plan{
+		Site: &s.catacomb,
+		Work: s.loop,
+	})
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return s, nil
+}
+
+// Kill is part of the worker.Worker interface.
+func (s *sender) Kill() {
+	s.catacomb.Kill(nil)
+}
+
+// Wait is part of the worker.Worker interface.
+func (s *sender) Wait() error {
+	return s.catacomb.Wait()
+}
+
+func (s *sender) loop() error {
+	sink, err := s.config.openSink(s.config.syslogConfig)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	defer sink.Close()
+
+	// Records with the last sent timestamp are sent again, as the
+	// timestamps don't distinguish between records logged at the
+	// same time. Receivers may see a few duplicates after a restart,
+	// but no records are lost.
+	start, err := s.config.lastSent.Get()
+	if errors.Cause(err) == state.ErrNeverForwarded {
+		start = time.Time{}
+	} else if err != nil {
+		return errors.Trace(err)
+	}
+	logger.Debugf("forwarding logs from %v", start)
+
+	tailer, err := s.config.newLogTailer(&state.LogTailerParams{
+		StartTime: start,
+	})
+	if err != nil {
+		return errors.Trace(err)
+	}
+	defer tailer.Stop()
+
+	logs := tailer.Logs()
+	for {
+		select {
+		case <-s.catacomb.Dying():
+			return s.catacomb.ErrDying()
+		case rec, ok := <-logs:
+			if !ok {
+				return errors.Annotate(tailer.Err(), "log tailer stopped")
+			}
+			batch := []*state.LogRecord{rec}
+		batching:
+			for len(batch) < maxBatchSize {
+				select {
+				case rec, ok := <-logs:
+					if !ok {
+						break batching
+					}
+					batch = append(batch, rec)
+				default:
+					break batching
+				}
+			}
+			if err := s.send(sink, batch); err != nil {
+				return errors.Trace(err)
+			}
+		}
+	}
+}
+
+// send sends the given records to the sink and then records the
+// timestamp of the last one.
+func (s *sender) send(sink Sender, batch []*state.LogRecord) error {
+	msgs := make([]syslog.Message, len(batch))
+	for i, rec := range batch {
+		msgs[i] = newMessage(rec)
+	}
+	if err := sink.Send(msgs...); err != nil {
+		return errors.Trace(err)
+	}
+	last := batch[len(batch)-1].Time
+	if err := s.config.lastSent.Set(last); err != nil {
+		return errors.Annotate(err, "recording last sent timestamp")
+	}
+	return nil
+}
+
+// newMessage converts a log record into a syslog message.
+func newMessage(rec *state.LogRecord) syslog.Message {
+	return syslog.Message{
+		Facility:  syslog.FacilityUser,
+		Severity:  syslog.SeverityForLevel(rec.Level),
+		Timestamp: rec.Time,
+		Hostname:  rec.Entity,
+		AppName:   "juju",
+		StructuredData: []syslog.Element{{
+			ID: modelElementID,
+			Params: []syslog.Param{
+				{Name: "model-uuid", Value: rec.ModelUUID},
+			},
+		}, {
+			ID: logElementID,
+			Params: []syslog.Param{
+				{Name: "module", Value: rec.Module},
+				{Name: "source", Value: rec.Location},
+			},
+		}},
+		Msg: rec.Message,
+	}
+}
+
+var (
+	modelElementID = fmt.Sprintf("model@%d", syslog.EnterpriseNumber)
+	logElementID   = fmt.Sprintf("log@%d", syslog.EnterpriseNumber)
+)
@@ -69,6 +69,16 @@ func (ctx *limitedContext) ActionData() (*context.ActionData, error) {
 	return nil, jujuc.ErrRestrictedContext
 }
 
+// CancelAction implements runner.Context.
+func (ctx *limitedContext) CancelAction() error {
+	return jujuc.ErrRestrictedContext
+}
+
+// ExpireAction implements runner.Context.
+func (ctx *limitedContext) ExpireAction() error {
+	return jujuc.ErrRestrictedContext
+}
+
 // Flush implementes runner.Context.
 func (ctx *limitedContext) Flush(_ string, err error) error {
 	return err
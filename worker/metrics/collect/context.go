@@ -76,6 +76,16 @@ func (ctx *hookContext) ActionData() (*context.ActionData, error) {
 	return nil, jujuc.ErrRestrictedContext
 }
 
+// CancelAction implements runner.Context.
+func (ctx *hookContext) CancelAction() error {
+	return jujuc.ErrRestrictedContext
+}
+
+// ExpireAction implements runner.Context.
+func (ctx *hookContext) ExpireAction() error {
+	return jujuc.ErrRestrictedContext
+}
+
 // HasExecutionSetUnitStatus implements runner.Context.
 func (ctx *hookContext) HasExecutionSetUnitStatus() bool { return false }
 
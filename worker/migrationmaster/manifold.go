@@ -4,7 +4,11 @@
 package migrationmaster
 
 import (
+	"time"
+
 	"github.com/juju/errors"
+	"github.com/juju/utils/clock"
+
 	"github.com/juju/juju/api/base"
 	"github.com/juju/juju/worker"
 	"github.com/juju/juju/worker/dependency"
@@ -16,6 +20,9 @@ import (
 type ManifoldConfig struct {
 	APICallerName string
 	FortressName  string
+	ClockName     string
+
+	MinionReportTimeout time.Duration
 
 	NewFacade func(base.APICaller) (Facade, error)
 	NewWorker func(Config) (worker.Worker, error)
@@ -29,6 +36,12 @@ func (config ManifoldConfig) validate() error {
 	if config.FortressName == "" {
 		return errors.NotValidf("empty FortressName")
 	}
+	if config.ClockName == "" {
+		return errors.NotValidf("empty ClockName")
+	}
+	if config.MinionReportTimeout <= 0 {
+		return errors.NotValidf("non-positive MinionReportTimeout")
+	}
 	if config.NewFacade == nil {
 		return errors.NotValidf("nil NewFacade")
 	}
@@ -51,13 +64,19 @@ func (config ManifoldConfig) start(context dependency.Context) (worker.Worker, e
 	if err := context.Get(config.FortressName, &guard); err != nil {
 		return nil, errors.Trace(err)
 	}
+	var clock clock.Clock
+	if err := context.Get(config.ClockName, &clock); err != nil {
+		return nil, errors.Trace(err)
+	}
 	facade, err := config.NewFacade(apiCaller)
 	if err != nil {
 		return nil, errors.Trace(err)
 	}
 	worker, err := config.NewWorker(Config{
-		Facade: facade,
-		Guard:  guard,
+		Facade:              facade,
+		Guard:               guard,
+		Clock:               clock,
+		MinionReportTimeout: config.MinionReportTimeout,
 	})
 	if err != nil {
 		return nil, errors.Trace(err)
@@ -68,7 +87,11 @@ func (config ManifoldConfig) start(context dependency.Context) (worker.Worker, e
 // Manifold packages a Worker for use in a dependency.Engine.
 func Manifold(config ManifoldConfig) dependency.Manifold {
 	return dependency.Manifold{
-		Inputs: []string{config.APICallerName, config.FortressName},
-		Start:  config.start,
+		Inputs: []string{
+			config.APICallerName,
+			config.FortressName,
+			config.ClockName,
+		},
+		Start: config.start,
 	}
 }
@@ -4,15 +4,23 @@
 package migrationmaster
 
 import (
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"strings"
 	"time"
 
 	"github.com/juju/errors"
 	"github.com/juju/loggo"
+	"github.com/juju/names"
+	"github.com/juju/utils/clock"
 
 	"github.com/juju/juju/api"
 	"github.com/juju/juju/api/migrationmaster"
 	"github.com/juju/juju/api/migrationtarget"
 	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/core/description"
 	"github.com/juju/juju/core/migration"
 	"github.com/juju/juju/watcher"
 	"github.com/juju/juju/worker/catacomb"
@@ -45,15 +53,50 @@ type Facade interface {
 	// migration.
 	SetPhase(migration.Phase) error
 
+	// SetStatusMessage sets a human readable message regarding the
+	// progress of a migration.
+	SetStatusMessage(string) error
+
+	// ModelInfo returns basic information about the model to be
+	// migrated.
+	ModelInfo() (migration.ModelInfo, error)
+
+	// Prechecks performs pre-migration checks on the model and
+	// source controller.
+	Prechecks() error
+
 	// Export returns a serialized representation of the model
 	// associated with the API connection.
 	Export() ([]byte, error)
+
+	// StreamModelLog returns a LogStream which reports the log
+	// records of the model, starting at the given time.
+	StreamModelLog(time.Time) (migrationmaster.LogStream, error)
+
+	// OpenResource returns a reader for the content of the named
+	// resource of a service in the model.
+	OpenResource(service, name string) (io.ReadCloser, error)
+
+	// WatchMinionReports returns a watcher which reports when a
+	// migration minion has made a report for the current migration
+	// phase.
+	WatchMinionReports() (watcher.NotifyWatcher, error)
+
+	// MinionReports returns details of the reports made by migration
+	// minions to the controller for the current migration phase.
+	MinionReports() (migrationmaster.MinionReports, error)
 }
 
 // Config defines the operation of a Worker.
 type Config struct {
 	Facade Facade
 	Guard  fortress.Guard
+	Clock  clock.Clock
+
+	// MinionReportTimeout is the maximum time to wait for all of
+	// the model's agents to report back during the QUIESCE and
+	// VALIDATION phases.
+	MinionReportTimeout time.Duration
 }
 
 // Validate returns an error if config cannot drive a Worker.
@@ -64,6 +107,12 @@ func (config Config) Validate() error {
 	if config.Guard == nil {
 		return errors.NotValidf("nil Guard")
 	}
+	if config.Clock == nil {
+		return errors.NotValidf("nil Clock")
+	}
+	if config.MinionReportTimeout <= 0 {
+		return errors.NotValidf("non-positive MinionReportTimeout")
+	}
 	return nil
 }
 
@@ -123,19 +172,19 @@ func (w *Worker) run() error {
 		var err error
 		switch phase {
 		case migration.QUIESCE:
-			phase, err = w.doQUIESCE()
+			phase, err = w.doQUIESCE(status)
 		case migration.READONLY:
 			phase, err = w.doREADONLY()
 		case migration.PRECHECK:
-			phase, err = w.doPRECHECK()
+			phase, err = w.doPRECHECK(status.TargetInfo)
 		case migration.IMPORT:
 			phase, err = w.doIMPORT(status.TargetInfo)
 		case migration.VALIDATION:
-			phase, err = w.doVALIDATION(status.TargetInfo, status.ModelUUID)
+			phase, err = w.doVALIDATION(status)
 		case migration.SUCCESS:
 			phase, err = w.doSUCCESS()
 		case migration.LOGTRANSFER:
-			phase, err = w.doLOGTRANSFER()
+			phase, err = w.doLOGTRANSFER(status.TargetInfo, status.ModelUUID)
 		case migration.REAP:
 			phase, err = w.doREAP()
 		case migration.ABORT:
@@ -182,8 +231,15 @@ func (w *Worker) killed() bool {
 	}
 }
 
-func (w *Worker) doQUIESCE() (migration.Phase, error) {
-	// TODO(mjs) - Wait for all agents to report back.
+func (w *Worker) doQUIESCE(status migrationmaster.MigrationStatus) (migration.Phase, error) {
+	// Wait for all agents to report that they have quiesced.
+	ok, err := w.waitForMinions(status, migration.QUIESCE, "quiesce")
+	if err != nil {
+		return migration.UNKNOWN, errors.Trace(err)
+	}
+	if !ok {
+		return migration.ABORT, nil
+	}
 	return migration.READONLY, nil
 }
 
@@ -192,11 +248,54 @@ func (w *Worker) doREADONLY() (migration.Phase, error) {
 	return migration.PRECHECK, nil
 }
 
-func (w *Worker) doPRECHECK() (migration.Phase, error) {
-	// TODO(mjs) - To be implemented.
+func (w *Worker) doPRECHECK(targetInfo migration.TargetInfo) (migration.Phase, error) {
+	logger.Infof("performing source prechecks")
+	if err := w.config.Facade.Prechecks(); err != nil {
+		return w.precheckFailed("source", err)
+	}
+
+	model, err := w.config.Facade.ModelInfo()
+	if err != nil {
+		return w.precheckFailed("source", errors.Annotate(err, "retrieving model info"))
+	}
+
+	logger.Infof("performing target prechecks")
+	if err := targetPrecheck(targetInfo, model); err != nil {
+		return w.precheckFailed("target", err)
+	}
+
 	return migration.IMPORT, nil
 }
 
+// precheckFailed records why the prechecks on the source or target
+// controller failed in the migration's status message, and returns
+// the ABORT phase. An error is only returned if the status message
+// couldn't be set.
+func (w *Worker) precheckFailed(side string, err error) (migration.Phase, error) {
+	var message string
+	if migration.IsPrecheckError(err) {
+		message = fmt.Sprintf("%s %v", side, err)
+	} else {
+		message = fmt.Sprintf("%s prechecks could not be run: %v", side, err)
+	}
+	logger.Errorf("%s", message)
+	if err := w.config.Facade.SetStatusMessage(message); err != nil {
+		return migration.UNKNOWN, errors.Annotate(err, "failed to set status message")
+	}
+	return migration.ABORT, nil
+}
+
+func targetPrecheck(targetInfo migration.TargetInfo, model migration.ModelInfo) error {
+	conn, err := openAPIConn(targetInfo)
+	if err != nil {
+		return errors.Annotate(err, "connecting to target controller")
+	}
+	defer conn.Close()
+
+	targetClient := migrationtarget.NewClient(conn)
+	return targetClient.Prechecks(model)
+}
+
 func (w *Worker) doIMPORT(targetInfo migration.TargetInfo) (migration.Phase, error) {
 	logger.Infof("exporting model")
 	bytes, err := w.config.Facade.Export()
@@ -221,15 +320,85 @@ func (w *Worker) doIMPORT(targetInfo migration.TargetInfo) (migration.Phase, err
 		return migration.ABORT, nil
 	}
 
+	logger.Infof("uploading resources to target controller")
+	err = w.uploadResources(targetClient, bytes)
+	if err != nil {
+		logger.Errorf("failed to upload resources to target controller: %v", err)
+		return migration.ABORT, nil
+	}
+
 	return migration.VALIDATION, nil
 }
 
-func (w *Worker) doVALIDATION(targetInfo migration.TargetInfo, modelUUID string) (migration.Phase, error) {
-	// TODO(mjs) - Wait for all agents to report back.
+// uploadResources sends the content of the resources of the exported
+// model to the target controller. The resource metadata is part of
+// the serialized model, so only resources which have had content
+// uploaded need to be sent.
+func (w *Worker) uploadResources(targetClient migrationtarget.Client, bytes []byte) error {
+	model, err := description.Deserialize(bytes)
+	if err != nil {
+		return errors.Annotate(err, "reading exported model")
+	}
+	modelUUID := model.Tag().Id()
+	for _, service := range model.Services() {
+		for _, res := range service.Resources() {
+			revision := res.Revision()
+			if revision == nil || revision.Timestamp().IsZero() {
+				// Placeholder resources have no content.
+				continue
+			}
+			logger.Debugf("uploading resource %s/%s", service.Name(), res.Name())
+			err := w.uploadResource(targetClient, modelUUID, service.Name(), res.Name())
+			if err != nil {
+				return errors.Annotatef(err, "resource %s/%s", service.Name(), res.Name())
+			}
+		}
+	}
+	return nil
+}
+
+// uploadResource copies a single resource from the source controller
+// to the target controller. The content is spooled to a temporary
+// file first, as the upload needs to be able to rewind its input.
+func (w *Worker) uploadResource(targetClient migrationtarget.Client, modelUUID, service, name string) error {
+	reader, err := w.config.Facade.OpenResource(service, name)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	defer reader.Close()
+
+	spool, err := ioutil.TempFile("", "juju-migration-resource")
+	if err != nil {
+		return errors.Trace(err)
+	}
+	defer func() {
+		spool.Close()
+		os.Remove(spool.Name())
+	}()
+	if _, err := io.Copy(spool, reader); err != nil {
+		return errors.Annotate(err, "reading resource")
+	}
+	if _, err := spool.Seek(0, os.SEEK_SET); err != nil {
+		return errors.Trace(err)
+	}
+	return errors.Trace(targetClient.UploadResource(modelUUID, service, name, spool))
+}
+
+func (w *Worker) doVALIDATION(status migrationmaster.MigrationStatus) (migration.Phase, error) {
+	// Wait for all agents to report that they can connect to the
+	// target controller.
+	ok, err := w.waitForMinions(status, migration.VALIDATION, "validate")
+	if err != nil {
+		return migration.UNKNOWN, errors.Trace(err)
+	}
+	if !ok {
+		return migration.ABORT, nil
+	}
 
 	// Once all agents have validated, activate the model.
-	err := activateModel(targetInfo, modelUUID)
+	err = activateModel(status.TargetInfo, status.ModelUUID)
 	if err != nil {
+		logger.Errorf("failed to activate model on target controller: %v", err)
 		return migration.ABORT, nil
 	}
 	return migration.SUCCESS, nil
@@ -255,8 +424,71 @@ func (w *Worker) doSUCCESS() (migration.Phase, error) {
 	return migration.LOGTRANSFER, nil
 }
 
-func (w *Worker) doLOGTRANSFER() (migration.Phase, error) {
-	// TODO(mjs) - To be implemented.
+func (w *Worker) doLOGTRANSFER(targetInfo migration.TargetInfo, modelUUID string) (migration.Phase, error) {
+	logger.Infof("transferring logs to target controller")
+	conn, err := openAPIConn(targetInfo)
+	if err != nil {
+		return migration.UNKNOWN, errors.Annotate(err, "connecting to target controller")
+	}
+	defer conn.Close()
+	targetClient := migrationtarget.NewClient(conn)
+
+	// If a previous attempt at transferring the logs was
+	// interrupted, pick up from where it got to. The target only
+	// records a time once every record with that time has been
+	// written, so records at the resume time itself are skipped.
+	latestLogTime, err := targetClient.LatestLogTime(modelUUID)
+	if err != nil {
+		return migration.UNKNOWN, errors.Annotate(err, "getting time of latest transferred log")
+	}
+	if !latestLogTime.IsZero() {
+		logger.Infof("resuming log transfer from %s", latestLogTime)
+	}
+
+	logSource, err := w.config.Facade.StreamModelLog(latestLogTime)
+	if err != nil {
+		return migration.UNKNOWN, errors.Annotate(err, "opening source log stream")
+	}
+	defer logSource.Close()
+
+	logTarget, err := targetClient.OpenLogTransferStream(modelUUID)
+	if err != nil {
+		return migration.UNKNOWN, errors.Annotate(err, "opening target log stream")
+	}
+	defer logTarget.Close()
+
+	// Closing the source stream unblocks any pending read if the
+	// worker is killed during the transfer.
+	done := make(chan struct{})
+	defer close(done)
+	go func() {
+		select {
+		case <-w.catacomb.Dying():
+			logSource.Close()
+		case <-done:
+		}
+	}()
+
+	var count int
+	for {
+		record, err := logSource.Next()
+		if err == io.EOF {
+			break
+		} else if err != nil {
+			if w.killed() {
+				return migration.UNKNOWN, w.catacomb.ErrDying()
+			}
+			return migration.UNKNOWN, errors.Annotate(err, "reading source logs")
+		}
+		if !latestLogTime.IsZero() && !record.Time.After(latestLogTime) {
+			continue
+		}
+		if err := logTarget.WriteJSON(record); err != nil {
+			return migration.UNKNOWN, errors.Annotate(err, "sending logs to target")
+		}
+		count++
+	}
+	logger.Infof("transferred %d log records", count)
 	return migration.REAP, nil
 }
 
@@ -323,6 +555,105 @@ func (w *Worker) waitForActiveMigration() (migrationmaster.MigrationStatus, erro
 	}
 }
 
+// waitForMinions waits for all of the model's agents to report back
+// for the given migration phase. It returns true if they all reported
+// success. If any agent reports failure, or if some agents don't
+// report before MinionReportTimeout has passed, the agents concerned
+// are recorded in the migration's status message and false is
+// returned.
+func (w *Worker) waitForMinions(
+	status migrationmaster.MigrationStatus,
+	expectedPhase migration.Phase,
+	infoPrefix string,
+) (bool, error) {
+	watch, err := w.config.Facade.WatchMinionReports()
+	if err != nil {
+		return false, errors.Annotate(err, "watching minion reports")
+	}
+	if err := w.catacomb.Add(watch); err != nil {
+		return false, errors.Trace(err)
+	}
+	defer watch.Kill()
+
+	getReports := func() (migrationmaster.MinionReports, error) {
+		reports, err := w.config.Facade.MinionReports()
+		if err != nil {
+			return reports, errors.Annotate(err, "retrieving minion reports")
+		}
+		err = validateMinionReports(reports, status, expectedPhase)
+		return reports, errors.Trace(err)
+	}
+
+	logger.Infof("waiting for agents to report back")
+	timeout := w.config.Clock.After(w.config.MinionReportTimeout)
+	for {
+		select {
+		case <-w.catacomb.Dying():
+			return false, w.catacomb.ErrDying()
+
+		case <-timeout:
+			reports, err := getReports()
+			if err != nil {
+				return false, errors.Trace(err)
+			}
+			message := fmt.Sprintf("%s agents failed to report in time: %s",
+				infoPrefix, formatTags(reports.Unknown))
+			return false, w.minionsFailed(message)
+
+		case <-watch.Changes():
+			reports, err := getReports()
+			if err != nil {
+				return false, errors.Trace(err)
+			}
+			if len(reports.Failed) > 0 {
+				message := fmt.Sprintf("%s failed on some agents: %s",
+					infoPrefix, formatTags(reports.Failed))
+				return false, w.minionsFailed(message)
+			}
+			if len(reports.Unknown) == 0 {
+				logger.Infof("all agents reported success (%d)", reports.SuccessCount)
+				return true, nil
+			}
+			logger.Debugf("%d agents still to report", len(reports.Unknown))
+		}
+	}
+}
+
+// minionsFailed records why the migration's agents didn't complete a
+// migration phase in the migration's status message. An error is
+// only returned if the status message couldn't be set.
+func (w *Worker) minionsFailed(message string) error {
+	logger.Errorf("%s", message)
+	if err := w.config.Facade.SetStatusMessage(message); err != nil {
+		return errors.Annotate(err, "failed to set status message")
+	}
+	return nil
+}
+
+func validateMinionReports(
+	reports migrationmaster.MinionReports,
+	status migrationmaster.MigrationStatus,
+	expectedPhase migration.Phase,
+) error {
+	if reports.MigrationId != status.MigrationId {
+		return errors.Errorf("unexpected migration id in minion reports, got %v, expected %v",
+			reports.MigrationId, status.MigrationId)
+	}
+	if reports.Phase != expectedPhase {
+		return errors.Errorf("minion reports phase (%s) does not match migration phase (%s)",
+			reports.Phase, expectedPhase)
+	}
+	return nil
+}
+
+func formatTags(tags []names.Tag) string {
+	out := make([]string, len(tags))
+	for i, tag := range tags {
+		out[i] = tag.String()
+	}
+	return strings.Join(out, ", ")
+}
+
 func openAPIConn(targetInfo migration.TargetInfo) (api.Connection, error) {
 	apiInfo := &api.Info{
 		Addrs:    targetInfo.Addrs,
@@ -4,17 +4,28 @@
 package migrationmaster_test
 
 import (
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
 	"time"
 
 	"github.com/juju/errors"
+	"github.com/juju/httprequest"
 	"github.com/juju/names"
 	jujutesting "github.com/juju/testing"
 	jc "github.com/juju/testing/checkers"
+	"github.com/juju/version"
 	gc "gopkg.in/check.v1"
 
 	"github.com/juju/juju/api"
+	"github.com/juju/juju/api/base"
 	masterapi "github.com/juju/juju/api/migrationmaster"
 	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/core/description"
 	"github.com/juju/juju/core/migration"
 	coretesting "github.com/juju/juju/testing"
 	"github.com/juju/juju/watcher"
@@ -27,6 +38,7 @@ import (
 
 type Suite struct {
 	coretesting.BaseSuite
+	clock         *coretesting.Clock
 	stub          *jujutesting.Stub
 	connection    *stubConnection
 	connectionErr error
@@ -35,7 +47,8 @@ type Suite struct {
 var _ = gc.Suite(&Suite{})
 
 var (
-	fakeSerializedModel = []byte("model")
+	fakeSerializedModel = mustSerializeModel(nil)
+	minionReportTimeout = 15 * time.Minute
 	modelTagString      = names.NewModelTag("model-uuid").String()
 
 	// Define stub calls that commonly appear in tests here to allow reuse.
@@ -51,6 +64,18 @@ var (
 			api.DialOpts{},
 		},
 	}
+	prechecksCall = jujutesting.StubCall{
+		"APICall:MigrationTarget.Prechecks",
+		[]interface{}{
+			params.MigrationModelInfo{
+				UUID:         "model-uuid",
+				Name:         "model-name",
+				OwnerTag:     names.NewUserTag("owner").String(),
+				AgentVersion: version.MustParse("1.2.3"),
+				ProviderType: "dummy",
+			},
+		},
+	}
 	importCall = jujutesting.StubCall{
 		"APICall:MigrationTarget.Import",
 		[]interface{}{
@@ -63,6 +88,19 @@ var (
 			params.ModelArgs{ModelTag: modelTagString},
 		},
 	}
+	latestLogTimeCall = jujutesting.StubCall{
+		"APICall:MigrationTarget.LatestLogTime",
+		[]interface{}{
+			params.ModelArgs{ModelTag: modelTagString},
+		},
+	}
+	openLogTransferStreamCall = jujutesting.StubCall{
+		"ConnectStream",
+		[]interface{}{
+			"/migrate/logtransfer",
+			url.Values{"model-uuid": []string{"model-uuid"}},
+		},
+	}
 	connCloseCall = jujutesting.StubCall{"Connection.Close", nil}
 	abortCall     = jujutesting.StubCall{
 		"APICall:MigrationTarget.Abort",
@@ -75,13 +113,26 @@ var (
 func (s *Suite) SetUpTest(c *gc.C) {
 	s.BaseSuite.SetUpTest(c)
 
+	s.clock = coretesting.NewClock(time.Now())
 	s.stub = new(jujutesting.Stub)
-	s.connection = &stubConnection{stub: s.stub}
+	s.connection = &stubConnection{
+		stub:      s.stub,
+		logStream: &mockStream{},
+	}
 	s.connectionErr = nil
 	s.PatchValue(migrationmaster.ApiOpen, s.apiOpen)
 	s.PatchValue(migrationmaster.TempSuccessSleep, time.Millisecond)
 }
 
+func (s *Suite) makeConfig(masterClient *stubMasterClient, guard fortress.Guard) migrationmaster.Config {
+	return migrationmaster.Config{
+		Facade:              masterClient,
+		Guard:               guard,
+		Clock:               s.clock,
+		MinionReportTimeout: minionReportTimeout,
+	}
+}
+
 func (s *Suite) apiOpen(info *api.Info, dialOpts api.DialOpts) (api.Connection, error) {
 	s.stub.AddCall("apiOpen", info, dialOpts)
 	if s.connectionErr != nil {
@@ -96,10 +147,7 @@ func (s *Suite) triggerMigration(masterClient *stubMasterClient) {
 
 func (s *Suite) TestSuccessfulMigration(c *gc.C) {
 	masterClient := newStubMasterClient(s.stub)
-	worker, err := migrationmaster.New(migrationmaster.Config{
-		Facade: masterClient,
-		Guard:  newStubGuard(s.stub),
-	})
+	worker, err := migrationmaster.New(s.makeConfig(masterClient, newStubGuard(s.stub)))
 	c.Assert(err, jc.ErrorIsNil)
 	s.triggerMigration(masterClient)
 
@@ -113,19 +161,33 @@ func (s *Suite) TestSuccessfulMigration(c *gc.C) {
 		{"masterClient.Watch", nil},
 		{"masterClient.GetMigrationStatus", nil},
 		{"guard.Lockdown", nil},
+		{"masterClient.WatchMinionReports", nil},
+		{"masterClient.MinionReports", nil},
 		{"masterClient.SetPhase", []interface{}{migration.READONLY}},
 		{"masterClient.SetPhase", []interface{}{migration.PRECHECK}},
+		{"masterClient.Prechecks", nil},
+		{"masterClient.ModelInfo", nil},
+		apiOpenCall,
+		prechecksCall,
+		connCloseCall,
 		{"masterClient.SetPhase", []interface{}{migration.IMPORT}},
 		{"masterClient.Export", nil},
 		apiOpenCall,
 		importCall,
 		connCloseCall,
 		{"masterClient.SetPhase", []interface{}{migration.VALIDATION}},
+		{"masterClient.WatchMinionReports", nil},
+		{"masterClient.MinionReports", nil},
 		apiOpenCall,
 		activateCall,
 		connCloseCall,
 		{"masterClient.SetPhase", []interface{}{migration.SUCCESS}},
 		{"masterClient.SetPhase", []interface{}{migration.LOGTRANSFER}},
+		apiOpenCall,
+		latestLogTimeCall,
+		{"masterClient.StreamModelLog", []interface{}{time.Time{}}},
+		openLogTransferStreamCall,
+		connCloseCall,
 		{"masterClient.SetPhase", []interface{}{migration.REAP}},
 		{"masterClient.SetPhase", []interface{}{migration.DONE}},
 	})
@@ -135,10 +197,7 @@ func (s *Suite) TestMigrationResume(c *gc.C) {
 	// Test that a partially complete migration can be resumed.
 
 	masterClient := newStubMasterClient(s.stub)
-	worker, err := migrationmaster.New(migrationmaster.Config{
-		Facade: masterClient,
-		Guard:  newStubGuard(s.stub),
-	})
+	worker, err := migrationmaster.New(s.makeConfig(masterClient, newStubGuard(s.stub)))
 	c.Assert(err, jc.ErrorIsNil)
 	masterClient.status.Phase = migration.SUCCESS
 	s.triggerMigration(masterClient)
@@ -151,19 +210,98 @@ func (s *Suite) TestMigrationResume(c *gc.C) {
 		{"masterClient.GetMigrationStatus", nil},
 		{"guard.Lockdown", nil},
 		{"masterClient.SetPhase", []interface{}{migration.LOGTRANSFER}},
+		apiOpenCall,
+		latestLogTimeCall,
+		{"masterClient.StreamModelLog", []interface{}{time.Time{}}},
+		openLogTransferStreamCall,
+		connCloseCall,
+		{"masterClient.SetPhase", []interface{}{migration.REAP}},
+		{"masterClient.SetPhase", []interface{}{migration.DONE}},
+	})
+}
+
+func (s *Suite) TestLogTransfer(c *gc.C) {
+	masterClient := newStubMasterClient(s.stub)
+	masterClient.status.Phase = migration.LOGTRANSFER
+	masterClient.logs = []params.LogRecord{
+		{Message: "the first", Entity: "machine-0"},
+		{Message: "the second", Entity: "unit-foo-0"},
+	}
+	worker, err := migrationmaster.New(s.makeConfig(masterClient, newStubGuard(s.stub)))
+	c.Assert(err, jc.ErrorIsNil)
+	s.triggerMigration(masterClient)
+
+	err = workertest.CheckKilled(c, worker)
+	c.Assert(errors.Cause(err), gc.Equals, dependency.ErrUninstall)
+
+	c.Assert(s.connection.logStream.written, jc.DeepEquals, masterClient.logs)
+	c.Assert(s.connection.logStream.closed, jc.IsTrue)
+	c.Assert(masterClient.logSource.closed, jc.IsTrue)
+}
+
+func (s *Suite) TestLogTransferResume(c *gc.C) {
+	masterClient := newStubMasterClient(s.stub)
+	masterClient.status.Phase = migration.LOGTRANSFER
+	latest := time.Date(2016, 6, 1, 10, 2, 3, 0, time.UTC)
+	s.connection.latestLogTime = latest
+	masterClient.logs = []params.LogRecord{
+		{Time: latest, Message: "already sent"},
+		{Time: latest.Add(time.Millisecond), Message: "not yet sent"},
+	}
+	worker, err := migrationmaster.New(s.makeConfig(masterClient, newStubGuard(s.stub)))
+	c.Assert(err, jc.ErrorIsNil)
+	s.triggerMigration(masterClient)
+
+	err = workertest.CheckKilled(c, worker)
+	c.Assert(errors.Cause(err), gc.Equals, dependency.ErrUninstall)
+
+	s.stub.CheckCalls(c, []jujutesting.StubCall{
+		{"masterClient.Watch", nil},
+		{"masterClient.GetMigrationStatus", nil},
+		{"guard.Lockdown", nil},
+		apiOpenCall,
+		latestLogTimeCall,
+		{"masterClient.StreamModelLog", []interface{}{latest}},
+		openLogTransferStreamCall,
+		connCloseCall,
 		{"masterClient.SetPhase", []interface{}{migration.REAP}},
 		{"masterClient.SetPhase", []interface{}{migration.DONE}},
 	})
+	// Records at the resume time have already been transferred.
+	c.Assert(s.connection.logStream.written, jc.DeepEquals, masterClient.logs[1:])
+}
+
+func (s *Suite) TestLogTransferSendFailure(c *gc.C) {
+	masterClient := newStubMasterClient(s.stub)
+	masterClient.status.Phase = migration.LOGTRANSFER
+	masterClient.logs = []params.LogRecord{{Message: "the first"}}
+	s.connection.logStream.writeErr = errors.New("boom")
+	worker, err := migrationmaster.New(s.makeConfig(masterClient, newStubGuard(s.stub)))
+	c.Assert(err, jc.ErrorIsNil)
+	s.triggerMigration(masterClient)
+
+	// The worker exits so that the transfer is retried when it is
+	// restarted; the phase isn't changed.
+	err = workertest.CheckKilled(c, worker)
+	c.Assert(err, gc.ErrorMatches, "sending logs to target: boom")
+
+	s.stub.CheckCalls(c, []jujutesting.StubCall{
+		{"masterClient.Watch", nil},
+		{"masterClient.GetMigrationStatus", nil},
+		{"guard.Lockdown", nil},
+		apiOpenCall,
+		latestLogTimeCall,
+		{"masterClient.StreamModelLog", []interface{}{time.Time{}}},
+		openLogTransferStreamCall,
+		connCloseCall,
+	})
 }
 
 func (s *Suite) TestPreviouslyAbortedMigration(c *gc.C) {
 	masterClient := newStubMasterClient(s.stub)
 	masterClient.status.Phase = migration.ABORTDONE
 	s.triggerMigration(masterClient)
-	worker, err := migrationmaster.New(migrationmaster.Config{
-		Facade: masterClient,
-		Guard:  newStubGuard(s.stub),
-	})
+	worker, err := migrationmaster.New(s.makeConfig(masterClient, newStubGuard(s.stub)))
 	c.Assert(err, jc.ErrorIsNil)
 	workertest.CheckAlive(c, worker)
 	workertest.CleanKill(c, worker)
@@ -175,10 +313,7 @@ func (s *Suite) TestPreviouslyCompletedMigration(c *gc.C) {
 	masterClient := newStubMasterClient(s.stub)
 	masterClient.status.Phase = migration.DONE
 	s.triggerMigration(masterClient)
-	worker, err := migrationmaster.New(migrationmaster.Config{
-		Facade: masterClient,
-		Guard:  newStubGuard(s.stub),
-	})
+	worker, err := migrationmaster.New(s.makeConfig(masterClient, newStubGuard(s.stub)))
 	c.Assert(err, jc.ErrorIsNil)
 
 	err = workertest.CheckKilled(c, worker)
@@ -193,10 +328,7 @@ func (s *Suite) TestPreviouslyCompletedMigration(c *gc.C) {
 func (s *Suite) TestWatchFailure(c *gc.C) {
 	masterClient := newStubMasterClient(s.stub)
 	masterClient.watchErr = errors.New("boom")
-	worker, err := migrationmaster.New(migrationmaster.Config{
-		Facade: masterClient,
-		Guard:  newStubGuard(s.stub),
-	})
+	worker, err := migrationmaster.New(s.makeConfig(masterClient, newStubGuard(s.stub)))
 	c.Assert(err, jc.ErrorIsNil)
 	err = workertest.CheckKilled(c, worker)
 	c.Assert(err, gc.ErrorMatches, "watching for migration: boom")
@@ -205,10 +337,7 @@ func (s *Suite) TestWatchFailure(c *gc.C) {
 func (s *Suite) TestStatusError(c *gc.C) {
 	masterClient := newStubMasterClient(s.stub)
 	masterClient.statusErr = errors.New("splat")
-	worker, err := migrationmaster.New(migrationmaster.Config{
-		Facade: masterClient,
-		Guard:  newStubGuard(s.stub),
-	})
+	worker, err := migrationmaster.New(s.makeConfig(masterClient, newStubGuard(s.stub)))
 	c.Assert(err, jc.ErrorIsNil)
 	s.triggerMigration(masterClient)
 
@@ -223,10 +352,7 @@ func (s *Suite) TestStatusError(c *gc.C) {
 func (s *Suite) TestStatusNotFound(c *gc.C) {
 	masterClient := newStubMasterClient(s.stub)
 	masterClient.statusErr = &params.Error{Code: params.CodeNotFound}
-	worker, err := migrationmaster.New(migrationmaster.Config{
-		Facade: masterClient,
-		Guard:  newStubGuard(s.stub),
-	})
+	worker, err := migrationmaster.New(s.makeConfig(masterClient, newStubGuard(s.stub)))
 	c.Assert(err, jc.ErrorIsNil)
 	s.triggerMigration(masterClient)
 
@@ -245,10 +371,7 @@ func (s *Suite) TestUnlockError(c *gc.C) {
 	masterClient.statusErr = &params.Error{Code: params.CodeNotFound}
 	guard := newStubGuard(s.stub)
 	guard.unlockErr = errors.New("pow")
-	worker, err := migrationmaster.New(migrationmaster.Config{
-		Facade: masterClient,
-		Guard:  guard,
-	})
+	worker, err := migrationmaster.New(s.makeConfig(masterClient, guard))
 	c.Assert(err, jc.ErrorIsNil)
 	s.triggerMigration(masterClient)
 
@@ -266,10 +389,7 @@ func (s *Suite) TestLockdownError(c *gc.C) {
 	masterClient := newStubMasterClient(s.stub)
 	guard := newStubGuard(s.stub)
 	guard.lockdownErr = errors.New("biff")
-	worker, err := migrationmaster.New(migrationmaster.Config{
-		Facade: masterClient,
-		Guard:  guard,
-	})
+	worker, err := migrationmaster.New(s.makeConfig(masterClient, guard))
 	c.Assert(err, jc.ErrorIsNil)
 	s.triggerMigration(masterClient)
 
@@ -286,10 +406,7 @@ func (s *Suite) TestLockdownError(c *gc.C) {
 func (s *Suite) TestExportFailure(c *gc.C) {
 	masterClient := newStubMasterClient(s.stub)
 	masterClient.exportErr = errors.New("boom")
-	worker, err := migrationmaster.New(migrationmaster.Config{
-		Facade: masterClient,
-		Guard:  newStubGuard(s.stub),
-	})
+	worker, err := migrationmaster.New(s.makeConfig(masterClient, newStubGuard(s.stub)))
 	c.Assert(err, jc.ErrorIsNil)
 	s.triggerMigration(masterClient)
 
@@ -300,8 +417,15 @@ func (s *Suite) TestExportFailure(c *gc.C) {
 		{"masterClient.Watch", nil},
 		{"masterClient.GetMigrationStatus", nil},
 		{"guard.Lockdown", nil},
+		{"masterClient.WatchMinionReports", nil},
+		{"masterClient.MinionReports", nil},
 		{"masterClient.SetPhase", []interface{}{migration.READONLY}},
 		{"masterClient.SetPhase", []interface{}{migration.PRECHECK}},
+		{"masterClient.Prechecks", nil},
+		{"masterClient.ModelInfo", nil},
+		apiOpenCall,
+		prechecksCall,
+		connCloseCall,
 		{"masterClient.SetPhase", []interface{}{migration.IMPORT}},
 		{"masterClient.Export", nil},
 		{"masterClient.SetPhase", []interface{}{migration.ABORT}},
@@ -314,10 +438,7 @@ func (s *Suite) TestExportFailure(c *gc.C) {
 
 func (s *Suite) TestAPIOpenFailure(c *gc.C) {
 	masterClient := newStubMasterClient(s.stub)
-	worker, err := migrationmaster.New(migrationmaster.Config{
-		Facade: masterClient,
-		Guard:  newStubGuard(s.stub),
-	})
+	worker, err := migrationmaster.New(s.makeConfig(masterClient, newStubGuard(s.stub)))
 	c.Assert(err, jc.ErrorIsNil)
 	s.connectionErr = errors.New("boom")
 	s.triggerMigration(masterClient)
@@ -329,11 +450,16 @@ func (s *Suite) TestAPIOpenFailure(c *gc.C) {
 		{"masterClient.Watch", nil},
 		{"masterClient.GetMigrationStatus", nil},
 		{"guard.Lockdown", nil},
+		{"masterClient.WatchMinionReports", nil},
+		{"masterClient.MinionReports", nil},
 		{"masterClient.SetPhase", []interface{}{migration.READONLY}},
 		{"masterClient.SetPhase", []interface{}{migration.PRECHECK}},
-		{"masterClient.SetPhase", []interface{}{migration.IMPORT}},
-		{"masterClient.Export", nil},
+		{"masterClient.Prechecks", nil},
+		{"masterClient.ModelInfo", nil},
 		apiOpenCall,
+		{"masterClient.SetStatusMessage", []interface{}{
+			"target prechecks could not be run: connecting to target controller: boom",
+		}},
 		{"masterClient.SetPhase", []interface{}{migration.ABORT}},
 		apiOpenCall,
 		{"masterClient.SetPhase", []interface{}{migration.ABORTDONE}},
@@ -342,10 +468,7 @@ func (s *Suite) TestAPIOpenFailure(c *gc.C) {
 
 func (s *Suite) TestImportFailure(c *gc.C) {
 	masterClient := newStubMasterClient(s.stub)
-	worker, err := migrationmaster.New(migrationmaster.Config{
-		Facade: masterClient,
-		Guard:  newStubGuard(s.stub),
-	})
+	worker, err := migrationmaster.New(s.makeConfig(masterClient, newStubGuard(s.stub)))
 	c.Assert(err, jc.ErrorIsNil)
 	s.connection.importErr = errors.New("boom")
 	s.triggerMigration(masterClient)
@@ -357,8 +480,15 @@ func (s *Suite) TestImportFailure(c *gc.C) {
 		{"masterClient.Watch", nil},
 		{"masterClient.GetMigrationStatus", nil},
 		{"guard.Lockdown", nil},
+		{"masterClient.WatchMinionReports", nil},
+		{"masterClient.MinionReports", nil},
 		{"masterClient.SetPhase", []interface{}{migration.READONLY}},
 		{"masterClient.SetPhase", []interface{}{migration.PRECHECK}},
+		{"masterClient.Prechecks", nil},
+		{"masterClient.ModelInfo", nil},
+		apiOpenCall,
+		prechecksCall,
+		connCloseCall,
 		{"masterClient.SetPhase", []interface{}{migration.IMPORT}},
 		{"masterClient.Export", nil},
 		apiOpenCall,
@@ -372,6 +502,338 @@ func (s *Suite) TestImportFailure(c *gc.C) {
 	})
 }
 
+func (s *Suite) TestResourceUpload(c *gc.C) {
+	serialized := mustSerializeModel(addTestResources)
+	masterClient := newStubMasterClient(s.stub)
+	masterClient.serializedModel = serialized
+	worker, err := migrationmaster.New(s.makeConfig(masterClient, newStubGuard(s.stub)))
+	c.Assert(err, jc.ErrorIsNil)
+	s.triggerMigration(masterClient)
+
+	err = workertest.CheckKilled(c, worker)
+	c.Assert(errors.Cause(err), gc.Equals, dependency.ErrUninstall)
+
+	// Only the resource with content is uploaded, once the model
+	// has been imported.
+	s.checkCallsAfterImport(c, serialized, []jujutesting.StubCall{
+		{"masterClient.OpenResource", []interface{}{"mysql", "blob"}},
+		{"UploadResource", []interface{}{
+			"PUT",
+			"/migrate/resourceupload?model-uuid=model-uuid&name=blob&service=mysql",
+			"content of mysql/blob",
+		}},
+		connCloseCall,
+		{"masterClient.SetPhase", []interface{}{migration.VALIDATION}},
+	})
+}
+
+func (s *Suite) TestResourceUploadFailure(c *gc.C) {
+	serialized := mustSerializeModel(addTestResources)
+	masterClient := newStubMasterClient(s.stub)
+	masterClient.serializedModel = serialized
+	masterClient.openResourceErr = errors.New("boom")
+	worker, err := migrationmaster.New(s.makeConfig(masterClient, newStubGuard(s.stub)))
+	c.Assert(err, jc.ErrorIsNil)
+	s.triggerMigration(masterClient)
+
+	err = workertest.CheckKilled(c, worker)
+	c.Assert(err, gc.Equals, migrationmaster.ErrDoneForNow)
+
+	s.checkCallsAfterImport(c, serialized, []jujutesting.StubCall{
+		{"masterClient.OpenResource", []interface{}{"mysql", "blob"}},
+		connCloseCall,
+		{"masterClient.SetPhase", []interface{}{migration.ABORT}},
+		apiOpenCall,
+		abortCall,
+		connCloseCall,
+		{"masterClient.SetPhase", []interface{}{migration.ABORTDONE}},
+	})
+}
+
+// checkCallsAfterImport checks that the calls made straight after
+// the model was imported into the target controller are as expected.
+func (s *Suite) checkCallsAfterImport(c *gc.C, serialized []byte, expected []jujutesting.StubCall) {
+	calls := s.stub.Calls()
+	for i, call := range calls {
+		if call.FuncName != importCall.FuncName {
+			continue
+		}
+		c.Assert(call.Args, jc.DeepEquals, []interface{}{params.SerializedModel{Bytes: serialized}})
+		after := calls[i+1:]
+		c.Assert(len(after) >= len(expected), jc.IsTrue)
+		c.Assert(after[:len(expected)], jc.DeepEquals, expected)
+		return
+	}
+	c.Fatalf("model not imported")
+}
+
+func (s *Suite) TestSourcePrecheckFailure(c *gc.C) {
+	masterClient := newStubMasterClient(s.stub)
+	masterClient.prechecksErr = &migration.PrecheckError{
+		Reasons: []string{"machine 0 is dying", "model has pending cleanups"},
+	}
+	worker, err := migrationmaster.New(s.makeConfig(masterClient, newStubGuard(s.stub)))
+	c.Assert(err, jc.ErrorIsNil)
+	s.triggerMigration(masterClient)
+
+	err = workertest.CheckKilled(c, worker)
+	c.Assert(err, gc.Equals, migrationmaster.ErrDoneForNow)
+
+	s.stub.CheckCalls(c, []jujutesting.StubCall{
+		{"masterClient.Watch", nil},
+		{"masterClient.GetMigrationStatus", nil},
+		{"guard.Lockdown", nil},
+		{"masterClient.WatchMinionReports", nil},
+		{"masterClient.MinionReports", nil},
+		{"masterClient.SetPhase", []interface{}{migration.READONLY}},
+		{"masterClient.SetPhase", []interface{}{migration.PRECHECK}},
+		{"masterClient.Prechecks", nil},
+		{"masterClient.SetStatusMessage", []interface{}{
+			"source prechecks failed: machine 0 is dying; model has pending cleanups",
+		}},
+		{"masterClient.SetPhase", []interface{}{migration.ABORT}},
+		apiOpenCall,
+		abortCall,
+		connCloseCall,
+		{"masterClient.SetPhase", []interface{}{migration.ABORTDONE}},
+	})
+}
+
+func (s *Suite) TestTargetPrecheckFailure(c *gc.C) {
+	masterClient := newStubMasterClient(s.stub)
+	worker, err := migrationmaster.New(s.makeConfig(masterClient, newStubGuard(s.stub)))
+	c.Assert(err, jc.ErrorIsNil)
+	s.connection.precheckFailures = []string{"target controller is being upgraded"}
+	s.triggerMigration(masterClient)
+
+	err = workertest.CheckKilled(c, worker)
+	c.Assert(err, gc.Equals, migrationmaster.ErrDoneForNow)
+
+	s.stub.CheckCalls(c, []jujutesting.StubCall{
+		{"masterClient.Watch", nil},
+		{"masterClient.GetMigrationStatus", nil},
+		{"guard.Lockdown", nil},
+		{"masterClient.WatchMinionReports", nil},
+		{"masterClient.MinionReports", nil},
+		{"masterClient.SetPhase", []interface{}{migration.READONLY}},
+		{"masterClient.SetPhase", []interface{}{migration.PRECHECK}},
+		{"masterClient.Prechecks", nil},
+		{"masterClient.ModelInfo", nil},
+		apiOpenCall,
+		prechecksCall,
+		connCloseCall,
+		{"masterClient.SetStatusMessage", []interface{}{
+			"target prechecks failed: target controller is being upgraded",
+		}},
+		{"masterClient.SetPhase", []interface{}{migration.ABORT}},
+		apiOpenCall,
+		abortCall,
+		connCloseCall,
+		{"masterClient.SetPhase", []interface{}{migration.ABORTDONE}},
+	})
+}
+
+func (s *Suite) TestQUIESCEMinionFailure(c *gc.C) {
+	masterClient := newStubMasterClient(s.stub)
+	masterClient.minionReports = map[migration.Phase]masterapi.MinionReports{
+		migration.QUIESCE: {
+			MigrationId:  "model-uuid:2",
+			Phase:        migration.QUIESCE,
+			SuccessCount: 1,
+			Failed: []names.Tag{
+				names.NewMachineTag("1"),
+				names.NewUnitTag("foo/0"),
+			},
+		},
+	}
+	worker, err := migrationmaster.New(s.makeConfig(masterClient, newStubGuard(s.stub)))
+	c.Assert(err, jc.ErrorIsNil)
+	s.triggerMigration(masterClient)
+
+	err = workertest.CheckKilled(c, worker)
+	c.Assert(err, gc.Equals, migrationmaster.ErrDoneForNow)
+
+	s.stub.CheckCalls(c, []jujutesting.StubCall{
+		{"masterClient.Watch", nil},
+		{"masterClient.GetMigrationStatus", nil},
+		{"guard.Lockdown", nil},
+		{"masterClient.WatchMinionReports", nil},
+		{"masterClient.MinionReports", nil},
+		{"masterClient.SetStatusMessage", []interface{}{
+			"quiesce failed on some agents: machine-1, unit-foo-0",
+		}},
+		{"masterClient.SetPhase", []interface{}{migration.ABORT}},
+		apiOpenCall,
+		abortCall,
+		connCloseCall,
+		{"masterClient.SetPhase", []interface{}{migration.ABORTDONE}},
+	})
+}
+
+func (s *Suite) TestQUIESCEMinionTimeout(c *gc.C) {
+	masterClient := newStubMasterClient(s.stub)
+	masterClient.minionReports = map[migration.Phase]masterapi.MinionReports{
+		migration.QUIESCE: {
+			MigrationId:  "model-uuid:2",
+			Phase:        migration.QUIESCE,
+			SuccessCount: 1,
+			Unknown: []names.Tag{
+				names.NewMachineTag("2"),
+				names.NewUnitTag("bar/1"),
+			},
+		},
+	}
+	worker, err := migrationmaster.New(s.makeConfig(masterClient, newStubGuard(s.stub)))
+	c.Assert(err, jc.ErrorIsNil)
+	s.triggerMigration(masterClient)
+
+	// Wait for the worker to retrieve the initial reports and then
+	// let the timeout expire.
+	s.waitForStubCalls(c, 5)
+	s.clock.Advance(minionReportTimeout)
+
+	err = workertest.CheckKilled(c, worker)
+	c.Assert(err, gc.Equals, migrationmaster.ErrDoneForNow)
+
+	s.stub.CheckCalls(c, []jujutesting.StubCall{
+		{"masterClient.Watch", nil},
+		{"masterClient.GetMigrationStatus", nil},
+		{"guard.Lockdown", nil},
+		{"masterClient.WatchMinionReports", nil},
+		{"masterClient.MinionReports", nil},
+		{"masterClient.MinionReports", nil},
+		{"masterClient.SetStatusMessage", []interface{}{
+			"quiesce agents failed to report in time: machine-2, unit-bar-1",
+		}},
+		{"masterClient.SetPhase", []interface{}{migration.ABORT}},
+		apiOpenCall,
+		abortCall,
+		connCloseCall,
+		{"masterClient.SetPhase", []interface{}{migration.ABORTDONE}},
+	})
+}
+
+func (s *Suite) TestQUIESCEMinionsReportLate(c *gc.C) {
+	masterClient := newStubMasterClient(s.stub)
+	masterClient.minionReports = map[migration.Phase]masterapi.MinionReports{
+		migration.QUIESCE: {
+			MigrationId:  "model-uuid:2",
+			Phase:        migration.QUIESCE,
+			SuccessCount: 1,
+			Unknown:      []names.Tag{names.NewMachineTag("2")},
+		},
+	}
+	// Stop once the worker has moved past QUIESCE.
+	masterClient.prechecksErr = errors.New("stop here")
+	worker, err := migrationmaster.New(s.makeConfig(masterClient, newStubGuard(s.stub)))
+	c.Assert(err, jc.ErrorIsNil)
+	defer workertest.DirtyKill(c, worker)
+	s.triggerMigration(masterClient)
+
+	// Wait for the initial reports to be retrieved.
+	s.waitForStubCalls(c, 5)
+
+	// The last agent reports in.
+	masterClient.setMinionReports(nil)
+	masterClient.minionReportsChan <- struct{}{}
+
+	err = workertest.CheckKilled(c, worker)
+	c.Assert(err, gc.Equals, migrationmaster.ErrDoneForNow)
+
+	s.stub.CheckCallNames(c,
+		"masterClient.Watch",
+		"masterClient.GetMigrationStatus",
+		"guard.Lockdown",
+		"masterClient.WatchMinionReports",
+		"masterClient.MinionReports",
+		"masterClient.MinionReports",
+		"masterClient.SetPhase",
+		"masterClient.SetPhase",
+		"masterClient.Prechecks",
+		"masterClient.SetStatusMessage",
+		"masterClient.SetPhase",
+		"apiOpen",
+		"APICall:MigrationTarget.Abort",
+		"Connection.Close",
+		"masterClient.SetPhase",
+	)
+	s.stub.CheckCall(c, 6, "masterClient.SetPhase", migration.READONLY)
+}
+
+func (s *Suite) TestVALIDATIONMinionFailure(c *gc.C) {
+	masterClient := newStubMasterClient(s.stub)
+	masterClient.status.Phase = migration.VALIDATION
+	masterClient.minionReports = map[migration.Phase]masterapi.MinionReports{
+		migration.VALIDATION: {
+			MigrationId: "model-uuid:2",
+			Phase:       migration.VALIDATION,
+			Failed:      []names.Tag{names.NewMachineTag("0")},
+		},
+	}
+	worker, err := migrationmaster.New(s.makeConfig(masterClient, newStubGuard(s.stub)))
+	c.Assert(err, jc.ErrorIsNil)
+	s.triggerMigration(masterClient)
+
+	err = workertest.CheckKilled(c, worker)
+	c.Assert(err, gc.Equals, migrationmaster.ErrDoneForNow)
+
+	// The model isn't activated on the target controller.
+	s.stub.CheckCalls(c, []jujutesting.StubCall{
+		{"masterClient.Watch", nil},
+		{"masterClient.GetMigrationStatus", nil},
+		{"guard.Lockdown", nil},
+		{"masterClient.WatchMinionReports", nil},
+		{"masterClient.MinionReports", nil},
+		{"masterClient.SetStatusMessage", []interface{}{
+			"validate failed on some agents: machine-0",
+		}},
+		{"masterClient.SetPhase", []interface{}{migration.ABORT}},
+		apiOpenCall,
+		abortCall,
+		connCloseCall,
+		{"masterClient.SetPhase", []interface{}{migration.ABORTDONE}},
+	})
+}
+
+func (s *Suite) TestMinionReportsWrongMigration(c *gc.C) {
+	masterClient := newStubMasterClient(s.stub)
+	masterClient.minionReports = map[migration.Phase]masterapi.MinionReports{
+		migration.QUIESCE: {
+			MigrationId: "model-uuid:1",
+			Phase:       migration.QUIESCE,
+		},
+	}
+	worker, err := migrationmaster.New(s.makeConfig(masterClient, newStubGuard(s.stub)))
+	c.Assert(err, jc.ErrorIsNil)
+	s.triggerMigration(masterClient)
+
+	err = workertest.CheckKilled(c, worker)
+	c.Assert(err, gc.ErrorMatches,
+		"unexpected migration id in minion reports, got model-uuid:1, expected model-uuid:2")
+}
+
+func (s *Suite) TestMinionReportsError(c *gc.C) {
+	masterClient := newStubMasterClient(s.stub)
+	masterClient.minionReportsErr = errors.New("boom")
+	worker, err := migrationmaster.New(s.makeConfig(masterClient, newStubGuard(s.stub)))
+	c.Assert(err, jc.ErrorIsNil)
+	s.triggerMigration(masterClient)
+
+	err = workertest.CheckKilled(c, worker)
+	c.Assert(err, gc.ErrorMatches, "retrieving minion reports: boom")
+}
+
+// waitForStubCalls waits until at least count calls have been made
+// to the stub.
+func (s *Suite) waitForStubCalls(c *gc.C, count int) {
+	for a := coretesting.LongAttempt.Start(); a.Next(); {
+		if len(s.stub.Calls()) >= count {
+			return
+		}
+	}
+	c.Fatalf("timed out waiting for %d stub calls, saw %v", count, s.stub.Calls())
+}
+
 func newStubGuard(stub *jujutesting.Stub) *stubGuard {
 	return &stubGuard{stub: stub}
 }
@@ -397,9 +859,10 @@ func newStubMasterClient(stub *jujutesting.Stub) *stubMasterClient {
 		stub:           stub,
 		watcherChanges: make(chan struct{}, 1),
 		status: masterapi.MigrationStatus{
-			ModelUUID: "model-uuid",
-			Attempt:   2,
-			Phase:     migration.QUIESCE,
+			MigrationId: "model-uuid:2",
+			ModelUUID:   "model-uuid",
+			Attempt:     2,
+			Phase:       migration.QUIESCE,
 			TargetInfo: migration.TargetInfo{
 				ControllerTag: names.NewModelTag("controller-uuid"),
 				Addrs:         []string{"1.2.3.4:5"},
@@ -413,12 +876,23 @@ func newStubMasterClient(stub *jujutesting.Stub) *stubMasterClient {
 
 type stubMasterClient struct {
 	masterapi.Client
-	stub           *jujutesting.Stub
-	watcherChanges chan struct{}
-	watchErr       error
-	status         masterapi.MigrationStatus
-	statusErr      error
-	exportErr      error
+	stub            *jujutesting.Stub
+	watcherChanges  chan struct{}
+	watchErr        error
+	status          masterapi.MigrationStatus
+	statusErr       error
+	prechecksErr    error
+	exportErr       error
+	serializedModel []byte
+	openResourceErr error
+	logs            []params.LogRecord
+	logSource       *mockLogSource
+
+	phase             migration.Phase
+	mu                sync.Mutex
+	minionReports     map[migration.Phase]masterapi.MinionReports
+	minionReportsErr  error
+	minionReportsChan chan struct{}
 }
 
 func (c *stubMasterClient) Watch() (watcher.NotifyWatcher, error) {
@@ -435,19 +909,114 @@ func (c *stubMasterClient) GetMigrationStatus() (masterapi.MigrationStatus, erro
 	if c.statusErr != nil {
 		return masterapi.MigrationStatus{}, c.statusErr
 	}
+	c.phase = c.status.Phase
 	return c.status, nil
 }
 
+func (c *stubMasterClient) Prechecks() error {
+	c.stub.AddCall("masterClient.Prechecks")
+	return c.prechecksErr
+}
+
+func (c *stubMasterClient) ModelInfo() (migration.ModelInfo, error) {
+	c.stub.AddCall("masterClient.ModelInfo")
+	return migration.ModelInfo{
+		UUID:         "model-uuid",
+		Name:         "model-name",
+		Owner:        names.NewUserTag("owner"),
+		AgentVersion: version.MustParse("1.2.3"),
+		ProviderType: "dummy",
+	}, nil
+}
+
+func (c *stubMasterClient) SetStatusMessage(message string) error {
+	c.stub.AddCall("masterClient.SetStatusMessage", message)
+	return nil
+}
+
 func (c *stubMasterClient) Export() ([]byte, error) {
 	c.stub.AddCall("masterClient.Export")
 	if c.exportErr != nil {
 		return nil, c.exportErr
 	}
+	if c.serializedModel != nil {
+		return c.serializedModel, nil
+	}
 	return fakeSerializedModel, nil
 }
 
+func (c *stubMasterClient) OpenResource(service, name string) (io.ReadCloser, error) {
+	c.stub.AddCall("masterClient.OpenResource", service, name)
+	if c.openResourceErr != nil {
+		return nil, c.openResourceErr
+	}
+	content := fmt.Sprintf("content of %s/%s", service, name)
+	return ioutil.NopCloser(strings.NewReader(content)), nil
+}
+
 func (c *stubMasterClient) SetPhase(phase migration.Phase) error {
 	c.stub.AddCall("masterClient.SetPhase", phase)
+	c.phase = phase
+	return nil
+}
+
+func (c *stubMasterClient) WatchMinionReports() (watcher.NotifyWatcher, error) {
+	c.stub.AddCall("masterClient.WatchMinionReports")
+	// Each watcher sends an initial event.
+	changes := make(chan struct{}, 1)
+	changes <- struct{}{}
+	c.minionReportsChan = changes
+	return newMockWatcher(changes), nil
+}
+
+func (c *stubMasterClient) MinionReports() (masterapi.MinionReports, error) {
+	// The call is recorded once the reports have been looked up so
+	// that tests can safely change the reports after seeing it.
+	defer c.stub.AddCall("masterClient.MinionReports")
+	if c.minionReportsErr != nil {
+		return masterapi.MinionReports{}, c.minionReportsErr
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if reports, ok := c.minionReports[c.phase]; ok {
+		return reports, nil
+	}
+	// By default, all the agents report success.
+	return masterapi.MinionReports{
+		MigrationId:  c.status.MigrationId,
+		Phase:        c.phase,
+		SuccessCount: 3,
+	}, nil
+}
+
+func (c *stubMasterClient) setMinionReports(reports map[migration.Phase]masterapi.MinionReports) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.minionReports = reports
+}
+
+func (c *stubMasterClient) StreamModelLog(start time.Time) (masterapi.LogStream, error) {
+	c.stub.AddCall("masterClient.StreamModelLog", start)
+	c.logSource = &mockLogSource{records: c.logs}
+	return c.logSource, nil
+}
+
+type mockLogSource struct {
+	records []params.LogRecord
+	closed  bool
+}
+
+func (s *mockLogSource) Next() (params.LogRecord, error) {
+	if len(s.records) == 0 {
+		return params.LogRecord{}, io.EOF
+	}
+	record := s.records[0]
+	s.records = s.records[1:]
+	return record, nil
+}
+
+func (s *mockLogSource) Close() error {
+	s.closed = true
 	return nil
 }
 
@@ -469,29 +1038,144 @@ func (w *mockWatcher) Changes() watcher.NotifyChannel {
 
 type stubConnection struct {
 	api.Connection
-	stub      *jujutesting.Stub
-	importErr error
+	stub             *jujutesting.Stub
+	precheckFailures []string
+	importErr        error
+	latestLogTime    time.Time
+	logStream        *mockStream
 }
 
 func (c *stubConnection) BestFacadeVersion(string) int {
 	return 1
 }
 
-func (c *stubConnection) APICall(objType string, version int, id, request string, params, response interface{}) error {
-	c.stub.AddCall("APICall:"+objType+"."+request, params)
+func (c *stubConnection) APICall(objType string, version int, id, request string, args, response interface{}) error {
+	c.stub.AddCall("APICall:"+objType+"."+request, args)
 
 	if objType == "MigrationTarget" {
 		switch request {
+		case "Prechecks":
+			*(response.(*params.MigrationPrecheckResult)) = params.MigrationPrecheckResult{
+				Failures: c.precheckFailures,
+			}
+			return nil
 		case "Import":
 			return c.importErr
 		case "Activate":
 			return nil
+		case "LatestLogTime":
+			*(response.(*time.Time)) = c.latestLogTime
+			return nil
 		}
 	}
 	return errors.New("unexpected API call")
 }
 
+func (c *stubConnection) ConnectStream(path string, attrs url.Values) (base.Stream, error) {
+	c.stub.AddCall("ConnectStream", path, attrs)
+	return c.logStream, nil
+}
+
+func (c *stubConnection) HTTPClient() (*httprequest.Client, error) {
+	return &httprequest.Client{Doer: &stubDoer{stub: c.stub}}, nil
+}
+
 func (c *stubConnection) Close() error {
 	c.stub.AddCall("Connection.Close")
 	return nil
 }
+
+type stubDoer struct {
+	stub *jujutesting.Stub
+}
+
+func (d *stubDoer) Do(req *http.Request) (*http.Response, error) {
+	return d.DoWithBody(req, nil)
+}
+
+func (d *stubDoer) DoWithBody(req *http.Request, body io.ReadSeeker) (*http.Response, error) {
+	var content []byte
+	if body != nil {
+		var err error
+		content, err = ioutil.ReadAll(body)
+		if err != nil {
+			return nil, err
+		}
+	}
+	d.stub.AddCall("UploadResource", req.Method, req.URL.String(), string(content))
+	return &http.Response{
+		StatusCode: http.StatusOK,
+		Header:     http.Header{"Content-Type": {params.ContentTypeJSON}},
+		Body:       ioutil.NopCloser(strings.NewReader("{}")),
+	}, nil
+}
+
+type mockStream struct {
+	base.Stream
+	written  []params.LogRecord
+	writeErr error
+	closed   bool
+}
+
+func (s *mockStream) WriteJSON(v interface{}) error {
+	if s.writeErr != nil {
+		return s.writeErr
+	}
+	s.written = append(s.written, v.(params.LogRecord))
+	return nil
+}
+
+func (s *mockStream) Close() error {
+	s.closed = true
+	return nil
+}
+
+// mustSerializeModel returns a serialized model for the migration,
+// allowing the caller to add to the model first.
+func mustSerializeModel(modify func(description.Model)) []byte {
+	model := description.NewModel(description.ModelArgs{
+		Owner: names.NewUserTag("owner"),
+		Config: map[string]interface{}{
+			"uuid": "model-uuid",
+			"name": "model-name",
+		},
+	})
+	if modify != nil {
+		modify(model)
+	}
+	bytes, err := description.Serialize(model)
+	if err != nil {
+		panic(err)
+	}
+	return bytes
+}
+
+// addTestResources adds a service with two resources to the model,
+// only one of which has content.
+func addTestResources(model description.Model) {
+	service := model.AddService(description.ServiceArgs{
+		Tag:      names.NewServiceTag("mysql"),
+		CharmURL: "cs:trusty/mysql-1",
+	})
+	service.SetStatus(description.StatusArgs{
+		Value:   "active",
+		Updated: time.Date(2016, 6, 1, 12, 0, 0, 0, time.UTC),
+	})
+	blob := service.AddResource(description.ResourceArgs{Name: "blob"})
+	blob.SetRevision(description.ResourceRevisionArgs{
+		Revision:       1,
+		Type:           "file",
+		Path:           "blob.tgz",
+		Origin:         "upload",
+		FingerprintHex: "aabbcc",
+		Size:           21,
+		Timestamp:      time.Date(2016, 6, 1, 12, 0, 0, 0, time.UTC),
+		Username:       "bob",
+	})
+	placeholder := service.AddResource(description.ResourceArgs{Name: "placeholder"})
+	placeholder.SetRevision(description.ResourceRevisionArgs{
+		Type:   "file",
+		Path:   "placeholder.tgz",
+		Origin: "store",
+	})
+}
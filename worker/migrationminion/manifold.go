@@ -6,6 +6,7 @@ package migrationminion
 import (
 	"github.com/juju/errors"
 	"github.com/juju/juju/agent"
+	"github.com/juju/juju/api"
 	"github.com/juju/juju/api/base"
 	"github.com/juju/juju/worker"
 	"github.com/juju/juju/worker/dependency"
@@ -19,6 +20,7 @@ type ManifoldConfig struct {
 	APICallerName string
 	FortressName  string
 
+	APIOpen   api.OpenFunc
 	NewFacade func(base.APICaller) (Facade, error)
 	NewWorker func(Config) (worker.Worker, error)
 }
@@ -34,6 +36,9 @@ func (config ManifoldConfig) validate() error {
 	if config.FortressName == "" {
 		return errors.NotValidf("empty FortressName")
 	}
+	if config.APIOpen == nil {
+		return errors.NotValidf("nil APIOpen")
+	}
 	if config.NewFacade == nil {
 		return errors.NotValidf("nil NewFacade")
 	}
@@ -66,9 +71,10 @@ func (config ManifoldConfig) start(context dependency.Context) (worker.Worker, e
 		return nil, errors.Trace(err)
 	}
 	worker, err := config.NewWorker(Config{
-		Agent:  agent,
-		Facade: facade,
-		Guard:  guard,
+		Agent:   agent,
+		Facade:  facade,
+		Guard:   guard,
+		APIOpen: config.APIOpen,
 	})
 	if err != nil {
 		return nil, errors.Trace(err)
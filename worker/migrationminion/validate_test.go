@@ -6,6 +6,7 @@ package migrationminion_test
 import (
 	"github.com/juju/errors"
 	"github.com/juju/juju/agent"
+	"github.com/juju/juju/api"
 	"github.com/juju/juju/worker/fortress"
 	"github.com/juju/juju/worker/migrationminion"
 	"github.com/juju/testing"
@@ -42,11 +43,18 @@ func (*ValidateSuite) TestMissingFacade(c *gc.C) {
 	checkNotValid(c, config, "nil Facade not valid")
 }
 
+func (*ValidateSuite) TestMissingAPIOpen(c *gc.C) {
+	config := validConfig()
+	config.APIOpen = nil
+	checkNotValid(c, config, "nil APIOpen not valid")
+}
+
 func validConfig() migrationminion.Config {
 	return migrationminion.Config{
-		Agent:  struct{ agent.Agent }{},
-		Guard:  struct{ fortress.Guard }{},
-		Facade: struct{ migrationminion.Facade }{},
+		Agent:   struct{ agent.Agent }{},
+		Guard:   struct{ fortress.Guard }{},
+		Facade:  struct{ migrationminion.Facade }{},
+		APIOpen: func(*api.Info, api.DialOpts) (api.Connection, error) { return nil, nil },
 	}
 }
 
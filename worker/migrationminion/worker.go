@@ -8,6 +8,7 @@ import (
 	"github.com/juju/loggo"
 
 	"github.com/juju/juju/agent"
+	"github.com/juju/juju/api"
 	"github.com/juju/juju/core/migration"
 	"github.com/juju/juju/network"
 	"github.com/juju/juju/watcher"
@@ -25,13 +26,18 @@ type Facade interface {
 	// for the migration for the model associated with the API
 	// connection.
 	Watch() (watcher.MigrationStatusWatcher, error)
+
+	// Report allows this minion to report whether it successfully
+	// completed its activities for a given migration phase.
+	Report(migrationId string, phase migration.Phase, success bool) error
 }
 
 // Config defines the operation of a Worker.
 type Config struct {
-	Agent  agent.Agent
-	Facade Facade
-	Guard  fortress.Guard
+	Agent   agent.Agent
+	Facade  Facade
+	Guard   fortress.Guard
+	APIOpen api.OpenFunc
 }
 
 // Validate returns an error if config cannot drive a Worker.
@@ -45,6 +51,9 @@ func (config Config) Validate() error {
 	if config.Guard == nil {
 		return errors.NotValidf("nil Guard")
 	}
+	if config.APIOpen == nil {
+		return errors.NotValidf("nil APIOpen")
+	}
 	return nil
 }
 
@@ -121,14 +130,18 @@ func (w *Worker) handle(status watcher.MigrationStatus) error {
 
 	switch status.Phase {
 	case migration.QUIESCE:
-		// TODO(mjs) - once Will's stable mode work comes
-		// together this worker will only start up when a
-		// migration is active. Here the minion should report
-		// to the controller that it is running so that the
+		// The fortress is now locked down, so report to the
+		// controller that this agent has quiesced so that the
 		// migration can progress to READONLY.
+		err := w.report(status, true)
+		if err != nil {
+			return errors.Trace(err)
+		}
 	case migration.VALIDATION:
-		// TODO(mjs) - check connection to the target
-		// controller here and report success/failure.
+		err := w.doVALIDATION(status)
+		if err != nil {
+			return errors.Trace(err)
+		}
 	case migration.SUCCESS:
 		err := w.doSUCCESS(status.TargetAPIAddrs, status.TargetCACert)
 		if err != nil {
@@ -145,6 +158,46 @@ func (w *Worker) handle(status watcher.MigrationStatus) error {
 	return nil
 }
 
+// doVALIDATION checks that the agent is able to connect to the
+// target controller and reports the outcome back to the source
+// controller.
+func (w *Worker) doVALIDATION(status watcher.MigrationStatus) error {
+	err := w.validate(status)
+	if err != nil {
+		// Don't return this error because it would cause the
+		// migration minion to restart and the failure would be
+		// reported again. The migration master will abort the
+		// migration.
+		logger.Errorf("validation failed: %v", err)
+	}
+	return errors.Trace(w.report(status, err == nil))
+}
+
+func (w *Worker) validate(status watcher.MigrationStatus) error {
+	info, ok := w.config.Agent.CurrentConfig().APIInfo()
+	if !ok {
+		return errors.New("no API connection details")
+	}
+	info.Addrs = status.TargetAPIAddrs
+	info.CACert = status.TargetCACert
+
+	// Use zero DialOpts (no retries) because the worker must stay
+	// responsive to Kill requests. We don't want it to be blocked by
+	// a long set of retry attempts.
+	conn, err := w.config.APIOpen(info, api.DialOpts{})
+	if err != nil {
+		return errors.Annotate(err, "connecting to target controller")
+	}
+	conn.Close()
+	return nil
+}
+
+func (w *Worker) report(status watcher.MigrationStatus, success bool) error {
+	logger.Debugf("reporting back for phase %s: %v", status.Phase, success)
+	err := w.config.Facade.Report(status.MigrationId, status.Phase, success)
+	return errors.Annotate(err, "failed to report phase progress")
+}
+
 func (w *Worker) doSUCCESS(targetAddrs []string, caCert string) error {
 	hps, err := apiAddrsToHostPorts(targetAddrs)
 	if err != nil {
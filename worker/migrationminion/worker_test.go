@@ -4,15 +4,18 @@
 package migrationminion_test
 
 import (
+	"reflect"
 	"sync"
 	"time"
 
 	"github.com/juju/errors"
+	"github.com/juju/names"
 	jujutesting "github.com/juju/testing"
 	jc "github.com/juju/testing/checkers"
 	gc "gopkg.in/check.v1"
 
 	"github.com/juju/juju/agent"
+	"github.com/juju/juju/api"
 	"github.com/juju/juju/core/migration"
 	"github.com/juju/juju/network"
 	coretesting "github.com/juju/juju/testing"
@@ -31,6 +34,23 @@ type Suite struct {
 	agent  *stubAgent
 }
 
+func (s *Suite) makeConfig() migrationminion.Config {
+	return migrationminion.Config{
+		Facade:  s.client,
+		Guard:   s.guard,
+		Agent:   s.agent,
+		APIOpen: s.apiOpen,
+	}
+}
+
+func (s *Suite) apiOpen(info *api.Info, dialOpts api.DialOpts) (api.Connection, error) {
+	s.stub.AddCall("API open", info)
+	if err := s.stub.NextErr(); err != nil {
+		return nil, err
+	}
+	return &stubConnection{stub: s.stub}, nil
+}
+
 var _ = gc.Suite(&Suite{})
 
 func (s *Suite) SetUpTest(c *gc.C) {
@@ -42,11 +62,7 @@ func (s *Suite) SetUpTest(c *gc.C) {
 }
 
 func (s *Suite) TestStartAndStop(c *gc.C) {
-	w, err := migrationminion.New(migrationminion.Config{
-		Facade: s.client,
-		Guard:  s.guard,
-		Agent:  s.agent,
-	})
+	w, err := migrationminion.New(s.makeConfig())
 	c.Assert(err, jc.ErrorIsNil)
 	workertest.CleanKill(c, w)
 	s.stub.CheckCallNames(c, "Watch")
@@ -54,11 +70,7 @@ func (s *Suite) TestStartAndStop(c *gc.C) {
 
 func (s *Suite) TestWatchFailure(c *gc.C) {
 	s.client.watchErr = errors.New("boom")
-	w, err := migrationminion.New(migrationminion.Config{
-		Facade: s.client,
-		Guard:  s.guard,
-		Agent:  s.agent,
-	})
+	w, err := migrationminion.New(s.makeConfig())
 	c.Assert(err, jc.ErrorIsNil)
 	err = workertest.CheckKilled(c, w)
 	c.Check(err, gc.ErrorMatches, "setting up watcher: boom")
@@ -66,11 +78,7 @@ func (s *Suite) TestWatchFailure(c *gc.C) {
 
 func (s *Suite) TestClosedWatcherChannel(c *gc.C) {
 	close(s.client.watcher.changes)
-	w, err := migrationminion.New(migrationminion.Config{
-		Facade: s.client,
-		Guard:  s.guard,
-		Agent:  s.agent,
-	})
+	w, err := migrationminion.New(s.makeConfig())
 	c.Assert(err, jc.ErrorIsNil)
 	err = workertest.CheckKilled(c, w)
 	c.Check(err, gc.ErrorMatches, "watcher channel closed")
@@ -81,11 +89,7 @@ func (s *Suite) TestUnlockError(c *gc.C) {
 		Phase: migration.NONE,
 	}
 	s.guard.unlockErr = errors.New("squish")
-	w, err := migrationminion.New(migrationminion.Config{
-		Facade: s.client,
-		Guard:  s.guard,
-		Agent:  s.agent,
-	})
+	w, err := migrationminion.New(s.makeConfig())
 	c.Assert(err, jc.ErrorIsNil)
 
 	err = workertest.CheckKilled(c, w)
@@ -98,11 +102,7 @@ func (s *Suite) TestLockdownError(c *gc.C) {
 		Phase: migration.QUIESCE,
 	}
 	s.guard.lockdownErr = errors.New("squash")
-	w, err := migrationminion.New(migrationminion.Config{
-		Facade: s.client,
-		Guard:  s.guard,
-		Agent:  s.agent,
-	})
+	w, err := migrationminion.New(s.makeConfig())
 	c.Assert(err, jc.ErrorIsNil)
 
 	err = workertest.CheckKilled(c, w)
@@ -114,11 +114,7 @@ func (s *Suite) TestNONE(c *gc.C) {
 	s.client.watcher.changes <- watcher.MigrationStatus{
 		Phase: migration.NONE,
 	}
-	w, err := migrationminion.New(migrationminion.Config{
-		Facade: s.client,
-		Guard:  s.guard,
-		Agent:  s.agent,
-	})
+	w, err := migrationminion.New(s.makeConfig())
 	c.Assert(err, jc.ErrorIsNil)
 
 	workertest.CheckAlive(c, w)
@@ -133,11 +129,7 @@ func (s *Suite) TestSUCCESS(c *gc.C) {
 		TargetAPIAddrs: addrs,
 		TargetCACert:   "top secret",
 	}
-	w, err := migrationminion.New(migrationminion.Config{
-		Facade: s.client,
-		Guard:  s.guard,
-		Agent:  s.agent,
-	})
+	w, err := migrationminion.New(s.makeConfig())
 	c.Assert(err, jc.ErrorIsNil)
 
 	select {
@@ -151,6 +143,102 @@ func (s *Suite) TestSUCCESS(c *gc.C) {
 	s.stub.CheckCallNames(c, "Watch", "Lockdown")
 }
 
+func (s *Suite) TestQUIESCE(c *gc.C) {
+	s.client.watcher.changes <- watcher.MigrationStatus{
+		MigrationId: "id",
+		Phase:       migration.QUIESCE,
+	}
+	w, err := migrationminion.New(s.makeConfig())
+	c.Assert(err, jc.ErrorIsNil)
+	defer workertest.CleanKill(c, w)
+
+	s.waitForStubCalls(c, []string{
+		"Watch",
+		"Lockdown",
+		"Report",
+	})
+	s.stub.CheckCall(c, 2, "Report", "id", migration.QUIESCE, true)
+}
+
+func (s *Suite) TestQUIESCEReportFailure(c *gc.C) {
+	s.client.watcher.changes <- watcher.MigrationStatus{
+		MigrationId: "id",
+		Phase:       migration.QUIESCE,
+	}
+	s.client.reportErr = errors.New("boom")
+	w, err := migrationminion.New(s.makeConfig())
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = workertest.CheckKilled(c, w)
+	c.Check(err, gc.ErrorMatches, "failed to report phase progress: boom")
+}
+
+func (s *Suite) TestVALIDATION(c *gc.C) {
+	s.client.watcher.changes <- watcher.MigrationStatus{
+		MigrationId:    "id",
+		Phase:          migration.VALIDATION,
+		TargetAPIAddrs: []string{"1.1.1.1:1", "2.2.2.2:2"},
+		TargetCACert:   "top secret",
+	}
+	w, err := migrationminion.New(s.makeConfig())
+	c.Assert(err, jc.ErrorIsNil)
+	defer workertest.CleanKill(c, w)
+
+	s.waitForStubCalls(c, []string{
+		"Watch",
+		"Lockdown",
+		"API open",
+		"API close",
+		"Report",
+	})
+	s.stub.CheckCall(c, 2, "API open", &api.Info{
+		Addrs:    []string{"1.1.1.1:1", "2.2.2.2:2"},
+		CACert:   "top secret",
+		Tag:      names.NewMachineTag("99"),
+		Password: "sekret",
+		Nonce:    "nonce",
+	})
+	s.stub.CheckCall(c, 4, "Report", "id", migration.VALIDATION, true)
+}
+
+func (s *Suite) TestVALIDATIONCantConnect(c *gc.C) {
+	s.client.watcher.changes <- watcher.MigrationStatus{
+		MigrationId: "id",
+		Phase:       migration.VALIDATION,
+	}
+	s.stub.SetErrors(errors.New("nope"))
+	w, err := migrationminion.New(s.makeConfig())
+	c.Assert(err, jc.ErrorIsNil)
+	defer workertest.CleanKill(c, w)
+
+	s.waitForStubCalls(c, []string{
+		"Watch",
+		"Lockdown",
+		"API open",
+		"Report",
+	})
+	s.stub.CheckCall(c, 3, "Report", "id", migration.VALIDATION, false)
+}
+
+func (s *Suite) waitForStubCalls(c *gc.C, expectedCallNames []string) {
+	var callNames []string
+	for a := coretesting.LongAttempt.Start(); a.Next(); {
+		callNames = stubCallNames(s.stub)
+		if reflect.DeepEqual(callNames, expectedCallNames) {
+			return
+		}
+	}
+	c.Fatalf("failed to see expected calls. saw: %v", callNames)
+}
+
+func stubCallNames(stub *jujutesting.Stub) []string {
+	var out []string
+	for _, call := range stub.Calls() {
+		out = append(out, call.FuncName)
+	}
+	return out
+}
+
 func newStubGuard(stub *jujutesting.Stub) *stubGuard {
 	return &stubGuard{stub: stub}
 }
@@ -179,9 +267,10 @@ func newStubMinionClient(stub *jujutesting.Stub) *stubMinionClient {
 }
 
 type stubMinionClient struct {
-	stub     *jujutesting.Stub
-	watcher  *stubWatcher
-	watchErr error
+	stub      *jujutesting.Stub
+	watcher   *stubWatcher
+	watchErr  error
+	reportErr error
 }
 
 func (c *stubMinionClient) Watch() (watcher.MigrationStatusWatcher, error) {
@@ -192,6 +281,11 @@ func (c *stubMinionClient) Watch() (watcher.MigrationStatusWatcher, error) {
 	return c.watcher, nil
 }
 
+func (c *stubMinionClient) Report(id string, phase migration.Phase, success bool) error {
+	c.stub.MethodCall(c, "Report", id, phase, success)
+	return c.reportErr
+}
+
 func newStubWatcher() *stubWatcher {
 	return &stubWatcher{
 		Worker:  workertest.NewErrorWorker(nil),
@@ -254,8 +348,28 @@ func (mc *stubConfig) SetAPIHostPorts(servers [][]network.HostPort) {
 	}
 }
 
+func (mc *stubConfig) APIInfo() (*api.Info, bool) {
+	return &api.Info{
+		Addrs:    []string{"0.1.2.3:1234"},
+		CACert:   "ca cert",
+		Tag:      names.NewMachineTag("99"),
+		Password: "sekret",
+		Nonce:    "nonce",
+	}, true
+}
+
 func (mc *stubConfig) SetCACert(cert string) {
 	mc.mu.Lock()
 	defer mc.mu.Unlock()
 	mc.caCert = cert
 }
+
+type stubConnection struct {
+	api.Connection
+	stub *jujutesting.Stub
+}
+
+func (c *stubConnection) Close() error {
+	c.stub.AddCall("API close")
+	return nil
+}
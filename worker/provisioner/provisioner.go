@@ -159,6 +159,7 @@ func (p *provisioner) getStartTask(harvestMode config.HarvestMode) (ProvisionerT
 		envCfg.ImageStream(),
 		secureServerConnection,
 		RetryStrategy{retryDelay: retryStrategyDelay, retryCount: retryStrategyCount},
+		envCfg.ProvisionerConcurrency(),
 	)
 	if err != nil {
 		return nil, errors.Trace(err)
@@ -237,6 +238,7 @@ func (p *environProvisioner) loop() error {
 				return errors.Annotate(err, "loaded invalid model configuration")
 			}
 			task.SetHarvestMode(modelConfig.ProvisionerHarvestMode())
+			task.SetConcurrency(modelConfig.ProvisionerConcurrency())
 		}
 	}
 }
@@ -330,6 +332,7 @@ func (p *containerProvisioner) loop() error {
 			}
 			p.configObserver.notify(modelConfig)
 			task.SetHarvestMode(modelConfig.ProvisionerHarvestMode())
+			task.SetConcurrency(modelConfig.ProvisionerConcurrency())
 		}
 	}
 }
@@ -6,6 +6,7 @@ package provisioner
 import (
 	"fmt"
 	"regexp"
+	"sync"
 	"time"
 
 	"github.com/juju/errors"
@@ -24,6 +25,7 @@ import (
 	"github.com/juju/juju/environs/simplestreams"
 	"github.com/juju/juju/instance"
 	"github.com/juju/juju/network"
+	"github.com/juju/juju/provider/common"
 	"github.com/juju/juju/status"
 	"github.com/juju/juju/storage"
 	coretools "github.com/juju/juju/tools"
@@ -41,6 +43,10 @@ type ProvisionerTask interface {
 	// should harvest machines. See config.HarvestMode for
 	// documentation of behavior.
 	SetHarvestMode(mode config.HarvestMode)
+
+	// SetConcurrency sets the maximum number of machines that the
+	// provisioner task will start at the same time.
+	SetConcurrency(n int)
 }
 
 type MachineGetter interface {
@@ -72,6 +78,7 @@ func NewProvisionerTask(
 	imageStream string,
 	secureServerConnection bool,
 	retryStartInstanceStrategy RetryStrategy,
+	concurrency int,
 ) (ProvisionerTask, error) {
 	machineChanges := machineWatcher.Changes()
 	workers := []worker.Worker{machineWatcher}
@@ -94,6 +101,10 @@ func NewProvisionerTask(
 		imageStream:                imageStream,
 		secureServerConnection:     secureServerConnection,
 		retryStartInstanceStrategy: retryStartInstanceStrategy,
+		concurrency:                concurrency,
+		concurrencyChan:            make(chan int, 1),
+		starting:                   make(map[string]bool),
+		startDone:                  make(chan startResult),
 	}
 	err := catacomb.Invoke(catacomb.Plan{
 		Site: &task.catacomb,
@@ -120,12 +131,31 @@ type provisionerTask struct {
 	harvestMode                config.HarvestMode
 	harvestModeChan            chan config.HarvestMode
 	retryStartInstanceStrategy RetryStrategy
+	concurrency                int
+	concurrencyChan            chan int
+	// queued holds the machines waiting to be started, in order.
+	queued []*apiprovisioner.Machine
+	// starting holds the ids of the machines that are queued or
+	// being started; inFlight counts those being started.
+	starting  map[string]bool
+	inFlight  int
+	startDone chan startResult
+	startWG   sync.WaitGroup
+	// harvestDeferred records that unknown instances were left
+	// running because machines were being started.
+	harvestDeferred bool
 	// instance id -> instance
 	instances map[instance.Id]instance.Instance
 	// machine id -> machine
 	machines map[string]*apiprovisioner.Machine
 }
 
+// startResult reports the outcome of starting a machine.
+type startResult struct {
+	machine *apiprovisioner.Machine
+	err     error
+}
+
 // Kill implements worker.Worker.Kill.
 func (task *provisionerTask) Kill() {
 	task.catacomb.Kill(nil)
@@ -137,6 +167,9 @@ func (task *provisionerTask) Wait() error {
 }
 
 func (task *provisionerTask) loop() error {
+	// Machines are started in the background; don't leave any
+	// starts running after the task has finished.
+	defer task.startWG.Wait()
 
 	// Don't allow the harvesting mode to change until we have read at
 	// least one set of changes, which will populate the task.machines
@@ -175,6 +208,24 @@ func (task *provisionerTask) loop() error {
 					return errors.Annotate(err, "failed to process machines after safe mode disabled")
 				}
 			}
+		case concurrency := <-task.concurrencyChan:
+			if concurrency != task.concurrency {
+				logger.Infof("provisioner concurrency changed to %d", concurrency)
+				task.concurrency = concurrency
+				task.dispatchMachines()
+			}
+		case result := <-task.startDone:
+			task.inFlight--
+			delete(task.starting, result.machine.Id())
+			if result.err != nil {
+				return errors.Trace(result.err)
+			}
+			task.dispatchMachines()
+			if task.inFlight == 0 && task.harvestDeferred {
+				if err := task.processMachines(nil); err != nil {
+					return errors.Annotate(err, "failed to process machines after starting machines")
+				}
+			}
 		case <-task.retryChanges:
 			if err := task.processMachinesWithTransientErrors(); err != nil {
 				return errors.Annotate(err, "failed to process machines with transient errors")
@@ -191,6 +242,14 @@ func (task *provisionerTask) SetHarvestMode(mode config.HarvestMode) {
 	}
 }
 
+// SetConcurrency implements ProvisionerTask.SetConcurrency().
+func (task *provisionerTask) SetConcurrency(n int) {
+	select {
+	case task.concurrencyChan <- n:
+	case <-task.catacomb.Dying():
+	}
+}
+
 func (task *provisionerTask) processMachinesWithTransientErrors() error {
 	machines, statusResults, err := task.machineGetter.MachinesWithTransientErrors()
 	if err != nil {
@@ -244,6 +303,15 @@ func (task *provisionerTask) processMachines(ids []string) error {
 			instanceIds(unknown),
 		)
 		unknown = nil
+	} else if len(unknown) > 0 && task.inFlight > 0 {
+		// An instance that is being started is not yet recorded
+		// against its machine, so it would look unknown. Leave
+		// unknown instances until no machines are being started.
+		logger.Infof("machines are being started; unknown instances not stopped %v", instanceIds(unknown))
+		task.harvestDeferred = true
+		unknown = nil
+	} else {
+		task.harvestDeferred = false
 	}
 	if task.harvestMode.HarvestNone() || !task.harvestMode.HarvestDestroyed() {
 		logger.Infof(
@@ -270,6 +338,7 @@ func (task *provisionerTask) processMachines(ids []string) error {
 	}
 
 	// Remove any dead machines from state.
+	task.dequeueMachines(dead)
 	for _, machine := range dead {
 		logger.Infof("removing dead machine %q", machine)
 		if err := machine.Remove(); err != nil {
@@ -548,6 +617,7 @@ func constructStartInstanceParams(
 			storage.ProviderType(v.Provider),
 			v.Attributes,
 			v.Tags,
+			v.SnapshotId,
 			&storage.VolumeAttachmentParams{
 				AttachmentParams: storage.AttachmentParams{
 					Machine:  machineTag,
@@ -615,55 +685,118 @@ func (task *provisionerTask) maintainMachines(machines []*apiprovisioner.Machine
 	return nil
 }
 
+// startMachines queues the given machines to be started, and starts
+// as many of them as task.concurrency allows. The instances are started
+// in the background, so that a slow or retrying machine does not hold
+// up the task; the outcome of each start is reported on task.startDone.
+// Failing to start one machine is recorded in that machine's status and
+// does not prevent the others from being started.
 func (task *provisionerTask) startMachines(machines []*apiprovisioner.Machine) error {
 	for _, m := range machines {
-
-		pInfo, err := m.ProvisioningInfo()
-		if err != nil {
-			return task.setErrorStatus("fetching provisioning info for machine %q: %v", m, err)
+		if task.starting[m.Id()] {
+			logger.Debugf("machine %q is already being started", m)
+			continue
 		}
+		task.starting[m.Id()] = true
+		task.queued = append(task.queued, m)
+	}
+	task.dispatchMachines()
+	return nil
+}
 
-		instanceCfg, err := task.constructInstanceConfig(m, task.auth, pInfo)
-		if err != nil {
-			return task.setErrorStatus("creating instance config for machine %q: %v", m, err)
+// dequeueMachines removes the given machines from the queue of machines
+// waiting to be started. Machines already being started are unaffected.
+func (task *provisionerTask) dequeueMachines(machines []*apiprovisioner.Machine) {
+	remove := make(map[string]bool)
+	for _, m := range machines {
+		remove[m.Id()] = true
+	}
+	queued := task.queued[:0]
+	for _, m := range task.queued {
+		if remove[m.Id()] {
+			delete(task.starting, m.Id())
+			continue
 		}
+		queued = append(queued, m)
+	}
+	task.queued = queued
+}
 
-		assocProvInfoAndMachCfg(pInfo, instanceCfg)
+// dispatchMachines starts queued machines until task.concurrency
+// machines are being started.
+func (task *provisionerTask) dispatchMachines() {
+	concurrency := task.concurrency
+	if concurrency < 1 {
+		concurrency = 1
+	}
+	for len(task.queued) > 0 && task.inFlight < concurrency {
+		m := task.queued[0]
+		task.queued = task.queued[1:]
+		task.inFlight++
+		task.startWG.Add(1)
+		go func() {
+			defer task.startWG.Done()
+			err := task.provisionMachine(m)
+			select {
+			case task.startDone <- startResult{m, err}:
+			case <-task.catacomb.Dying():
+			}
+		}()
+	}
+}
 
-		var arch string
-		if pInfo.Constraints.Arch != nil {
-			arch = *pInfo.Constraints.Arch
-		}
+// provisionMachine gathers everything needed to start an instance for
+// the machine, and starts it.
+func (task *provisionerTask) provisionMachine(m *apiprovisioner.Machine) error {
+	pInfo, err := m.ProvisioningInfo()
+	if err != nil {
+		return task.setErrorStatus("fetching provisioning info for machine %q: %v", m, err)
+	}
 
-		possibleTools, err := task.toolsFinder.FindTools(
-			jujuversion.Current,
-			pInfo.Series,
-			arch,
-		)
-		if err != nil {
-			return task.setErrorStatus("cannot find tools for machine %q: %v", m, err)
-		}
+	instanceCfg, err := task.constructInstanceConfig(m, task.auth, pInfo)
+	if err != nil {
+		return task.setErrorStatus("creating instance config for machine %q: %v", m, err)
+	}
 
-		startInstanceParams, err := constructStartInstanceParams(
-			m,
-			instanceCfg,
-			pInfo,
-			possibleTools,
-		)
-		if err != nil {
-			return task.setErrorStatus("cannot construct params for machine %q: %v", m, err)
-		}
+	assocProvInfoAndMachCfg(pInfo, instanceCfg)
 
-		if err := task.startMachine(m, pInfo, startInstanceParams); err != nil {
-			return errors.Annotatef(err, "cannot start machine %v", m)
-		}
+	var arch string
+	if pInfo.Constraints.Arch != nil {
+		arch = *pInfo.Constraints.Arch
+	}
+
+	possibleTools, err := task.toolsFinder.FindTools(
+		jujuversion.Current,
+		pInfo.Series,
+		arch,
+	)
+	if err != nil {
+		return task.setErrorStatus("cannot find tools for machine %q: %v", m, err)
+	}
+
+	startInstanceParams, err := constructStartInstanceParams(
+		m,
+		instanceCfg,
+		pInfo,
+		possibleTools,
+	)
+	if err != nil {
+		return task.setErrorStatus("cannot construct params for machine %q: %v", m, err)
+	}
+
+	if err := task.startMachine(m, pInfo, startInstanceParams); err != nil {
+		return errors.Annotatef(err, "cannot start machine %v", m)
 	}
 	return nil
 }
 
 func (task *provisionerTask) setErrorStatus(message string, machine *apiprovisioner.Machine, err error) error {
+	return task.setErrorStatusWithData(message, machine, err, nil)
+}
+
+func (task *provisionerTask) setErrorStatusWithData(message string, machine *apiprovisioner.Machine, err error, data map[string]interface{}) error {
 	logger.Errorf(message, machine, err)
-	if err1 := machine.SetStatus(status.StatusError, err.Error(), nil); err1 != nil {
+	if err1 := machine.SetStatus(status.StatusError, err.Error(), data); err1 != nil {
 		// Something is wrong with this machine, better report it back.
 		return errors.Annotatef(err1, "cannot set error status for machine %q", machine)
 	}
@@ -676,13 +809,29 @@ func (task *provisionerTask) startMachine(
 	startInstanceParams environs.StartInstanceParams,
 ) error {
 
+	// triedZones accumulates the availability zones tried across
+	// every attempt, and is recorded in the machine's status
+	// whatever the outcome.
+	var triedZones []string
 	result, err := task.broker.StartInstance(startInstanceParams)
+	if err != nil && instance.IsZoneSpecificCreationError(errors.Cause(err)) {
+		result, triedZones, err = task.startInstanceInOtherZones(machine, startInstanceParams, err)
+		if err != nil && instance.IsZoneSpecificCreationError(errors.Cause(err)) {
+			return task.setErrorStatusWithData(
+				"cannot start instance for machine %q: %v", machine, err,
+				triedZonesData(triedZones),
+			)
+		}
+	}
 	if err != nil {
 		if !instance.IsRetryableCreationError(errors.Cause(err)) {
 			// Set the state to error, so the machine will be skipped next
 			// time until the error is resolved, but don't return an
 			// error; just keep going with the other machines.
-			return task.setErrorStatus("cannot start instance for machine %q: %v", machine, err)
+			return task.setErrorStatusWithData(
+				"cannot start instance for machine %q: %v", machine, err,
+				triedZonesData(triedZones),
+			)
 		}
 		logger.Infof("retryable error received on start instance: %v", err)
 		for count := task.retryStartInstanceStrategy.retryCount; count > 0; count-- {
@@ -698,13 +847,27 @@ func (task *provisionerTask) startMachine(
 			if err == nil {
 				break
 			}
+			if zoneErr, ok := errors.Cause(err).(*instance.ZoneSpecificCreationError); ok {
+				triedZones = addTriedZone(triedZones, zoneErr.Zone())
+			}
 			// If this was the last attempt and an error was received, set the error
 			// status on the machine.
 			if count == 1 {
-				return task.setErrorStatus("cannot start instance for machine %q: %v", machine, err)
+				return task.setErrorStatusWithData(
+					"cannot start instance for machine %q: %v", machine, err,
+					triedZonesData(triedZones),
+				)
 			}
 		}
 	}
+	if len(triedZones) > 0 {
+		// The machine is not yet provisioned, so it may be set back
+		// to pending to record the zones tried before the instance
+		// was started.
+		if err := machine.SetStatus(status.StatusPending, "", triedZonesData(triedZones)); err != nil {
+			logger.Warningf("cannot record tried zones for machine %v: %v", machine, err)
+		}
+	}
 
 	inst := result.Instance
 	hardware := result.Hardware
@@ -733,6 +896,45 @@ func (task *provisionerTask) startMachine(
 	return nil
 }
 
+// startInstanceInOtherZones is called when an instance could not be
+// started because of a problem with the availability zone it was
+// started in. Unless the machine was placed explicitly, the other
+// availability zones are tried (see common.StartInstanceInOtherZones).
+// The names of the zones tried are returned with the result.
+func (task *provisionerTask) startInstanceInOtherZones(
+	machine *apiprovisioner.Machine,
+	startInstanceParams environs.StartInstanceParams,
+	zoneErr error,
+) (*environs.StartInstanceResult, []string, error) {
+	zonedEnviron, ok := task.broker.(common.ZonedEnviron)
+	if !ok || startInstanceParams.Placement != "" {
+		triedZones := []string{errors.Cause(zoneErr).(*instance.ZoneSpecificCreationError).Zone()}
+		return nil, triedZones, zoneErr
+	}
+	logger.Infof("cannot start machine %s: %v", machine, zoneErr)
+	return common.StartInstanceInOtherZones(zonedEnviron, startInstanceParams, zoneErr)
+}
+
+// triedZonesData returns the status data recording the availability
+// zones tried when starting an instance, or nil if none were recorded.
+func triedZonesData(triedZones []string) map[string]interface{} {
+	if len(triedZones) == 0 {
+		return nil
+	}
+	return map[string]interface{}{"tried-zones": triedZones}
+}
+
+// addTriedZone returns triedZones with zone appended, unless it has
+// already been tried.
+func addTriedZone(triedZones []string, zone string) []string {
+	for _, tried := range triedZones {
+		if tried == zone {
+			return triedZones
+		}
+	}
+	return append(triedZones, zone)
+}
+
 type provisioningInfo struct {
 	Constraints    constraints.Value
 	Series         string
@@ -7,6 +7,7 @@ import (
 	"fmt"
 	"strconv"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/juju/errors"
@@ -36,6 +37,7 @@ import (
 	"github.com/juju/juju/instance"
 	"github.com/juju/juju/juju/testing"
 	"github.com/juju/juju/network"
+	"github.com/juju/juju/provider/common"
 	"github.com/juju/juju/provider/dummy"
 	"github.com/juju/juju/state"
 	"github.com/juju/juju/state/cloudimagemetadata"
@@ -1223,6 +1225,17 @@ func (s *ProvisionerSuite) newProvisionerTask(
 	machineGetter provisioner.MachineGetter,
 	toolsFinder provisioner.ToolsFinder,
 ) provisioner.ProvisionerTask {
+	return s.newProvisionerTaskWithConcurrency(c, harvestingMethod, broker, machineGetter, toolsFinder, 1)
+}
+
+func (s *ProvisionerSuite) newProvisionerTaskWithConcurrency(
+	c *gc.C,
+	harvestingMethod config.HarvestMode,
+	broker environs.InstanceBroker,
+	machineGetter provisioner.MachineGetter,
+	toolsFinder provisioner.ToolsFinder,
+	concurrency int,
+) provisioner.ProvisionerTask {
 
 	machineWatcher, err := s.provisioner.WatchModelMachines()
 	c.Assert(err, jc.ErrorIsNil)
@@ -1245,6 +1258,7 @@ func (s *ProvisionerSuite) newProvisionerTask(
 		imagemetadata.ReleasedStream,
 		true,
 		retryStrategy,
+		concurrency,
 	)
 	c.Assert(err, jc.ErrorIsNil)
 	return w
@@ -1408,8 +1422,269 @@ func (s *ProvisionerSuite) TestProvisionerObservesMachineJobs(c *gc.C) {
 	}
 }
 
+func (s *ProvisionerSuite) TestProvisionerStartsMachinesConcurrently(c *gc.C) {
+	m1, err := s.addMachine()
+	c.Assert(err, jc.ErrorIsNil)
+	m2, err := s.addMachine()
+	c.Assert(err, jc.ErrorIsNil)
+
+	broker := &blockingBroker{
+		Environ: s.Environ,
+		started: make(chan string, 2),
+		unblock: make(chan struct{}),
+	}
+	task := s.newProvisionerTaskWithConcurrency(c, config.HarvestAll, broker, s.provisioner, mockToolsFinder{}, 2)
+	defer stop(c, task)
+
+	// Both machines are being started before either start completes.
+	started := make(map[string]bool)
+	for i := 0; i < 2; i++ {
+		select {
+		case id := <-broker.started:
+			started[id] = true
+		case <-time.After(coretesting.LongWait):
+			c.Fatalf("timed out waiting for instances to be started")
+		}
+	}
+	c.Assert(started, jc.DeepEquals, map[string]bool{m1.Id(): true, m2.Id(): true})
+	close(broker.unblock)
+
+	for _, m := range []*state.Machine{m1, m2} {
+		m := m
+		s.waitMachine(c, m, func() bool {
+			c.Assert(m.Refresh(), jc.ErrorIsNil)
+			_, err := m.InstanceId()
+			return err == nil
+		})
+	}
+}
+
+func (s *ProvisionerSuite) TestProvisionerHandlesChangesWhileStarting(c *gc.C) {
+	broker := &blockingBroker{
+		Environ: s.Environ,
+		started: make(chan string, 2),
+		unblock: make(chan struct{}),
+	}
+	task := s.newProvisionerTaskWithConcurrency(c, config.HarvestAll, broker, s.provisioner, mockToolsFinder{}, 1)
+	defer stop(c, task)
+
+	m1, err := s.addMachine()
+	c.Assert(err, jc.ErrorIsNil)
+	select {
+	case id := <-broker.started:
+		c.Assert(id, gc.Equals, m1.Id())
+	case <-time.After(coretesting.LongWait):
+		c.Fatalf("timed out waiting for instance to be started")
+	}
+
+	// The start of machine 1 is blocked, but a second machine can
+	// still be added and removed; it is never started.
+	m2, err := s.addMachine()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(m2.Destroy(), jc.ErrorIsNil)
+	s.waitRemoved(c, m2)
+
+	close(broker.unblock)
+	s.waitMachine(c, m1, func() bool {
+		c.Assert(m1.Refresh(), jc.ErrorIsNil)
+		_, err := m1.InstanceId()
+		return err == nil
+	})
+	select {
+	case id := <-broker.started:
+		c.Fatalf("unexpected start of machine %q", id)
+	default:
+	}
+}
+
+func (s *ProvisionerSuite) TestProvisionerTriesOtherZones(c *gc.C) {
+	broker := &zonedBroker{Environ: s.Environ, startZone: "zone3"}
+	task := s.newProvisionerTask(c, config.HarvestAll, broker, s.provisioner, mockToolsFinder{})
+	defer stop(c, task)
+
+	m, err := s.addMachine()
+	c.Assert(err, jc.ErrorIsNil)
+	s.checkStartInstance(c, m)
+
+	// The provider chose zone1 itself; the other zones are tried
+	// in order of population, then name.
+	c.Assert(broker.getPlacements(), jc.DeepEquals, []string{"", "zone=zone2", "zone=zone3"})
+
+	// The zones tried are recorded even though the instance started.
+	t0 := time.Now()
+	for time.Since(t0) < coretesting.LongWait {
+		statusInfo, err := m.Status()
+		c.Assert(err, jc.ErrorIsNil)
+		if len(statusInfo.Data) == 0 {
+			time.Sleep(coretesting.ShortWait)
+			continue
+		}
+		c.Assert(statusInfo.Status, gc.Equals, status.StatusPending)
+		c.Assert(statusInfo.Data, jc.DeepEquals, map[string]interface{}{
+			"tried-zones": []interface{}{"zone1", "zone2", "zone3"},
+		})
+		return
+	}
+	c.Fatal("Test took too long to complete")
+}
+
+func (s *ProvisionerSuite) TestProvisionerRecordsTriedZonesOnOtherError(c *gc.C) {
+	broker := &zonedBroker{Environ: s.Environ, failZone: "zone2"}
+	task := s.newProvisionerTask(c, config.HarvestAll, broker, s.provisioner, mockToolsFinder{})
+	defer stop(c, task)
+
+	m, err := s.addMachine()
+	c.Assert(err, jc.ErrorIsNil)
+	s.checkNoOperations(c)
+
+	t0 := time.Now()
+	for time.Since(t0) < coretesting.LongWait {
+		statusInfo, err := m.Status()
+		c.Assert(err, jc.ErrorIsNil)
+		if statusInfo.Status == status.StatusPending {
+			time.Sleep(coretesting.ShortWait)
+			continue
+		}
+		c.Assert(statusInfo.Status, gc.Equals, status.StatusError)
+		c.Assert(statusInfo.Message, gc.Equals, "no instances for you")
+		c.Assert(statusInfo.Data, jc.DeepEquals, map[string]interface{}{
+			"tried-zones": []interface{}{"zone1", "zone2"},
+		})
+		return
+	}
+	c.Fatal("Test took too long to complete")
+}
+
+func (s *ProvisionerSuite) TestProvisionerRecordsTriedZones(c *gc.C) {
+	broker := &zonedBroker{Environ: s.Environ}
+	task := s.newProvisionerTask(c, config.HarvestAll, broker, s.provisioner, mockToolsFinder{})
+	defer stop(c, task)
+
+	m, err := s.addMachine()
+	c.Assert(err, jc.ErrorIsNil)
+	s.checkNoOperations(c)
+
+	t0 := time.Now()
+	for time.Since(t0) < coretesting.LongWait {
+		statusInfo, err := m.Status()
+		c.Assert(err, jc.ErrorIsNil)
+		if statusInfo.Status == status.StatusPending {
+			time.Sleep(coretesting.ShortWait)
+			continue
+		}
+		c.Assert(statusInfo.Status, gc.Equals, status.StatusError)
+		c.Assert(statusInfo.Message, gc.Equals, `zone "zone3" is out of capacity`)
+		c.Assert(statusInfo.Data, jc.DeepEquals, map[string]interface{}{
+			"tried-zones": []interface{}{"zone1", "zone2", "zone3"},
+		})
+		return
+	}
+	c.Fatal("Test took too long to complete")
+}
+
+func (s *ProvisionerSuite) TestProvisionerDoesNotTryOtherZonesForPlacedMachines(c *gc.C) {
+	broker := &zonedBroker{Environ: s.Environ, startZone: "zone3"}
+	task := s.newProvisionerTask(c, config.HarvestAll, broker, s.provisioner, mockToolsFinder{})
+	defer stop(c, task)
+
+	m, err := s.BackingState.AddOneMachine(state.MachineTemplate{
+		Series:      series.LatestLts(),
+		Jobs:        []state.MachineJob{state.JobHostUnits},
+		Constraints: s.defaultConstraints,
+		Placement:   "zone=zone2",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	s.checkNoOperations(c)
+
+	t0 := time.Now()
+	for time.Since(t0) < coretesting.LongWait {
+		statusInfo, err := m.Status()
+		c.Assert(err, jc.ErrorIsNil)
+		if statusInfo.Status == status.StatusPending {
+			time.Sleep(coretesting.ShortWait)
+			continue
+		}
+		c.Assert(statusInfo.Status, gc.Equals, status.StatusError)
+		c.Assert(statusInfo.Data, jc.DeepEquals, map[string]interface{}{
+			"tried-zones": []interface{}{"zone2"},
+		})
+		c.Assert(broker.getPlacements(), jc.DeepEquals, []string{"zone=zone2"})
+		return
+	}
+	c.Fatal("Test took too long to complete")
+}
+
+// zonedBroker is a broker with three availability zones. It starts
+// instances only in startZone, fails with an error that is not
+// zone-specific in failZone, and fails with a zone-specific error in
+// the other zones. When no zone is given, it chooses zone1.
+type zonedBroker struct {
+	environs.Environ
+	startZone string
+	failZone  string
+
+	mu         sync.Mutex
+	placements []string
+}
+
+func (b *zonedBroker) AvailabilityZones() ([]common.AvailabilityZone, error) {
+	return []common.AvailabilityZone{
+		&mockAvailabilityZone{"zone1", true},
+		&mockAvailabilityZone{"zone2", true},
+		&mockAvailabilityZone{"zone3", true},
+		&mockAvailabilityZone{"zone4", false},
+	}, nil
+}
+
+func (b *zonedBroker) InstanceAvailabilityZoneNames(ids []instance.Id) ([]string, error) {
+	zones := make([]string, len(ids))
+	for i := range zones {
+		zones[i] = "zone1"
+	}
+	return zones, nil
+}
+
+func (b *zonedBroker) StartInstance(args environs.StartInstanceParams) (*environs.StartInstanceResult, error) {
+	b.mu.Lock()
+	b.placements = append(b.placements, args.Placement)
+	b.mu.Unlock()
+	zone := "zone1"
+	if args.Placement != "" {
+		zone = strings.TrimPrefix(args.Placement, "zone=")
+	}
+	if zone == b.failZone {
+		return nil, errors.New("no instances for you")
+	}
+	if zone != b.startZone {
+		return nil, instance.NewZoneSpecificCreationError(zone, fmt.Sprintf("zone %q is out of capacity", zone))
+	}
+	args.Placement = ""
+	return b.Environ.StartInstance(args)
+}
+
+func (b *zonedBroker) getPlacements() []string {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return append([]string(nil), b.placements...)
+}
+
+type mockAvailabilityZone struct {
+	name      string
+	available bool
+}
+
+func (z *mockAvailabilityZone) Name() string {
+	return z.name
+}
+
+func (z *mockAvailabilityZone) Available() bool {
+	return z.available
+}
+
 type mockBroker struct {
 	environs.Environ
+
+	mu         sync.Mutex
 	retryCount map[string]int
 	ids        []string
 }
@@ -1419,17 +1694,34 @@ func (b *mockBroker) StartInstance(args environs.StartInstanceParams) (*environs
 	// Machines 3 is provisioned after some attempts have been made.
 	// Machine 4 is never provisioned.
 	id := args.InstanceConfig.MachineId
+	b.mu.Lock()
 	// record ids so we can call checkStartInstance in the appropriate order.
 	b.ids = append(b.ids, id)
 	retries := b.retryCount[id]
 	if (id != "3" && id != "4") || retries > 2 {
+		b.mu.Unlock()
 		return b.Environ.StartInstance(args)
 	} else {
 		b.retryCount[id] = retries + 1
 	}
+	b.mu.Unlock()
 	return nil, fmt.Errorf("error: some error")
 }
 
+// blockingBroker reports each machine it is asked to start, and
+// does not start any instance until unblock is closed.
+type blockingBroker struct {
+	environs.Environ
+	started chan string
+	unblock chan struct{}
+}
+
+func (b *blockingBroker) StartInstance(args environs.StartInstanceParams) (*environs.StartInstanceResult, error) {
+	b.started <- args.InstanceConfig.MachineId
+	<-b.unblock
+	return b.Environ.StartInstance(args)
+}
+
 type mockToolsFinder struct {
 }
 
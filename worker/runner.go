@@ -42,6 +42,7 @@ type runner struct {
 	stopc         chan string
 	donec         chan doneInfo
 	startedc      chan startInfo
+	reportc       chan chan map[string]interface{}
 	isFatal       func(error) bool
 	moreImportant func(err0, err1 error) bool
 
@@ -81,6 +82,7 @@ func NewRunner(isFatal func(error) bool, moreImportant func(err0, err1 error) bo
 		stopc:         make(chan string),
 		donec:         make(chan doneInfo),
 		startedc:      make(chan startInfo),
+		reportc:       make(chan chan map[string]interface{}),
 		isFatal:       isFatal,
 		moreImportant: moreImportant,
 		restartDelay:  restartDelay,
@@ -133,6 +135,23 @@ func (runner *runner) Kill() {
 	runner.tomb.Kill(nil)
 }
 
+// Report returns a map describing the state of the runner and of each
+// of its workers. It implements dependency.Reporter, so that runners
+// run by a dependency engine are included in its report.
+func (runner *runner) Report() map[string]interface{} {
+	reply := make(chan map[string]interface{})
+	select {
+	case runner.reportc <- reply:
+		// This is safe so long as the loop sends a result.
+		return <-reply
+	case <-runner.tomb.Dead():
+		return map[string]interface{}{
+			"state": "stopped",
+			"error": runner.tomb.Err(),
+		}
+	}
+}
+
 // Stop kills the given worker and waits for it to exit.
 func Stop(worker Worker) error {
 	worker.Kill()
@@ -144,6 +163,48 @@ type workerInfo struct {
 	worker       Worker
 	restartDelay time.Duration
 	stopping     bool
+
+	// err holds the error returned by the last worker, and startCount
+	// the number of workers started; both are for use in reports.
+	err        error
+	startCount int
+}
+
+// state returns the state of the worker, for use in reports.
+func (info *workerInfo) state() string {
+	switch {
+	case info.stopping:
+		return "stopping"
+	case info.worker != nil:
+		return "started"
+	}
+	return "starting"
+}
+
+// report returns a map describing the runner's workers.
+func report(workers map[string]*workerInfo, isDying bool) map[string]interface{} {
+	state := "started"
+	if isDying {
+		state = "stopping"
+	}
+	workersReport := make(map[string]interface{})
+	for id, info := range workers {
+		workerReport := map[string]interface{}{
+			"state":       info.state(),
+			"error":       info.err,
+			"start-count": info.startCount,
+		}
+		if reporter, ok := info.worker.(interface {
+			Report() map[string]interface{}
+		}); ok {
+			workerReport["report"] = reporter.Report()
+		}
+		workersReport[id] = workerReport
+	}
+	return map[string]interface{}{
+		"state":   state,
+		"workers": workersReport,
+	}
 }
 
 func (runner *runner) run() error {
@@ -193,6 +254,8 @@ func (runner *runner) run() error {
 			// the new start function.
 			info.start = req.start
 			info.restartDelay = 0
+		case reply := <-runner.reportc:
+			reply <- report(workers, isDying)
 		case id := <-runner.stopc:
 			logger.Debugf("stop %q", id)
 			if info := workers[id]; info != nil {
@@ -202,12 +265,15 @@ func (runner *runner) run() error {
 			logger.Debugf("%q started", info.id)
 			workerInfo := workers[info.id]
 			workerInfo.worker = info.worker
+			workerInfo.startCount++
 			if isDying || workerInfo.stopping {
 				killWorker(info.id, workerInfo)
 			}
 		case info := <-runner.donec:
 			logger.Debugf("%q done: %v", info.id, info.err)
 			workerInfo := workers[info.id]
+			workerInfo.worker = nil
+			workerInfo.err = info.err
 			if !workerInfo.stopping && info.err == nil {
 				logger.Debugf("removing %q from known workers", info.id)
 				delete(workers, info.id)
@@ -17,6 +17,7 @@ import (
 
 	"github.com/juju/juju/testing"
 	"github.com/juju/juju/worker"
+	"github.com/juju/juju/worker/dependency"
 	workertesting "github.com/juju/juju/worker/testing"
 )
 
@@ -84,6 +85,48 @@ func (*runnerSuite) TestOneWorkerRestart(c *gc.C) {
 	starter.assertStarted(c, false)
 }
 
+func (*runnerSuite) TestReport(c *gc.C) {
+	runner := worker.NewRunner(noneFatal, noImportance, time.Millisecond)
+	starter := newTestWorkerStarter()
+	err := runner.StartWorker("id", testWorkerStart(starter))
+	c.Assert(err, jc.ErrorIsNil)
+	starter.assertStarted(c, true)
+	dieErr := errors.New("an error")
+	starter.die <- dieErr
+	starter.assertStarted(c, false)
+	starter.assertStarted(c, true)
+
+	reporter, ok := runner.(dependency.Reporter)
+	c.Assert(ok, jc.IsTrue)
+	var report map[string]interface{}
+	for a := testing.LongAttempt.Start(); a.Next(); {
+		// The start notification is sent before the runner is told
+		// about the new worker.
+		report = reporter.Report()
+		workers := report["workers"].(map[string]interface{})
+		if workers["id"].(map[string]interface{})["start-count"] == 2 {
+			break
+		}
+	}
+	c.Check(report, jc.DeepEquals, map[string]interface{}{
+		"state": "started",
+		"workers": map[string]interface{}{
+			"id": map[string]interface{}{
+				"state":       "started",
+				"error":       dieErr,
+				"start-count": 2,
+			},
+		},
+	})
+
+	c.Assert(worker.Stop(runner), gc.IsNil)
+	starter.assertStarted(c, false)
+	c.Check(reporter.Report(), jc.DeepEquals, map[string]interface{}{
+		"state": "stopped",
+		"error": nil,
+	})
+}
+
 func (*runnerSuite) TestOneWorkerStartFatalError(c *gc.C) {
 	runner := worker.NewRunner(allFatal, noImportance, time.Millisecond)
 	starter := newTestWorkerStarter()
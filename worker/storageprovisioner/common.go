@@ -4,7 +4,9 @@
 package storageprovisioner
 
 import (
+	"fmt"
 	"path/filepath"
+	"sort"
 
 	"github.com/juju/errors"
 	"github.com/juju/names"
@@ -121,8 +123,8 @@ func setStatus(ctx *context, statuses []params.EntityStatusArgs) {
 
 var errNonDynamic = errors.New("non-dynamic storage provider")
 
-// volumeSource returns a volume source given a name, provider type,
-// environment config and storage directory.
+// volumeSource returns a volume source given a provider type, storage
+// pool attributes, environment config and storage directory.
 //
 // TODO(axw) move this to the main storageprovisioner, and have
 // it watch for changes to storage source configurations, updating
@@ -131,10 +133,11 @@ var errNonDynamic = errors.New("non-dynamic storage provider")
 func volumeSource(
 	environConfig *config.Config,
 	baseStorageDir string,
-	sourceName string,
 	providerType storage.ProviderType,
+	attrs map[string]interface{},
 ) (storage.VolumeSource, error) {
-	provider, sourceConfig, err := sourceParams(providerType, sourceName, baseStorageDir)
+	sourceName := string(providerType)
+	provider, sourceConfig, err := sourceParams(providerType, sourceName, baseStorageDir, attrs)
 	if err != nil {
 		return nil, errors.Annotatef(err, "getting storage source %q params", sourceName)
 	}
@@ -148,8 +151,8 @@ func volumeSource(
 	return source, nil
 }
 
-// filesystemSource returns a filesystem source given a name, provider type,
-// environment config and storage directory.
+// filesystemSource returns a filesystem source given a provider type,
+// storage pool attributes, environment config and storage directory.
 //
 // TODO(axw) move this to the main storageprovisioner, and have
 // it watch for changes to storage source configurations, updating
@@ -158,10 +161,11 @@ func volumeSource(
 func filesystemSource(
 	environConfig *config.Config,
 	baseStorageDir string,
-	sourceName string,
 	providerType storage.ProviderType,
+	attrs map[string]interface{},
 ) (storage.FilesystemSource, error) {
-	provider, sourceConfig, err := sourceParams(providerType, sourceName, baseStorageDir)
+	sourceName := string(providerType)
+	provider, sourceConfig, err := sourceParams(providerType, sourceName, baseStorageDir, attrs)
 	if err != nil {
 		return nil, errors.Annotatef(err, "getting storage source %q params", sourceName)
 	}
@@ -172,12 +176,22 @@ func filesystemSource(
 	return source, nil
 }
 
-func sourceParams(providerType storage.ProviderType, sourceName, baseStorageDir string) (storage.Provider, *storage.Config, error) {
+// sourceParams returns the storage provider and source configuration for
+// the specified provider type. The source configuration is made up of the
+// storage pool attributes, and the storage directory if one is specified.
+func sourceParams(
+	providerType storage.ProviderType,
+	sourceName, baseStorageDir string,
+	poolAttrs map[string]interface{},
+) (storage.Provider, *storage.Config, error) {
 	provider, err := registry.StorageProvider(providerType)
 	if err != nil {
 		return nil, nil, errors.Annotate(err, "getting provider")
 	}
 	attrs := make(map[string]interface{})
+	for k, v := range poolAttrs {
+		attrs[k] = v
+	}
 	if baseStorageDir != "" {
 		storageDir := filepath.Join(baseStorageDir, sourceName)
 		attrs[storage.ConfigStorageDir] = storageDir
@@ -189,6 +203,26 @@ func sourceParams(providerType storage.ProviderType, sourceName, baseStorageDir
 	return provider, sourceConfig, nil
 }
 
+// sourceKey returns the key that identifies the storage source for the
+// given provider type and storage pool attributes. Pools of the same
+// provider type with different attributes, such as pools for two Ceph
+// clusters, must be handled by different sources.
+func sourceKey(providerType storage.ProviderType, attrs map[string]interface{}) string {
+	if len(attrs) == 0 {
+		return string(providerType)
+	}
+	keys := make([]string, 0, len(attrs))
+	for k := range attrs {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	key := string(providerType)
+	for _, k := range keys {
+		key += fmt.Sprintf(" %s=%v", k, attrs[k])
+	}
+	return key
+}
+
 func copyMachineStorageIds(src []watcher.MachineStorageId) []params.MachineStorageId {
 	dst := make([]params.MachineStorageId, len(src))
 	for i, msid := range src {
@@ -480,6 +480,7 @@ func filesystemAttachmentParamsFromParams(in params.FilesystemAttachmentParams)
 			Machine:    machineTag,
 			InstanceId: instance.Id(in.InstanceId),
 			ReadOnly:   in.ReadOnly,
+			Attributes: in.Attributes,
 		},
 		Filesystem:   filesystemTag,
 		FilesystemId: in.FilesystemId,
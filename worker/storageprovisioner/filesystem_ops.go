@@ -333,13 +333,11 @@ func filesystemParamsBySource(
 	params []storage.FilesystemParams,
 	managedFilesystemSource storage.FilesystemSource,
 ) (map[string][]storage.FilesystemParams, map[string]storage.FilesystemSource, error) {
-	// TODO(axw) later we may have multiple instantiations (sources)
-	// for a storage provider, e.g. multiple Ceph installations. For
-	// now we assume a single source for each provider type, with no
-	// configuration.
+	// There is a source for each distinct storage pool configuration,
+	// so that each source is configured with the pool's attributes.
 	filesystemSources := make(map[string]storage.FilesystemSource)
 	for _, params := range params {
-		sourceName := string(params.Provider)
+		sourceName := sourceKey(params.Provider, params.Attributes)
 		if _, ok := filesystemSources[sourceName]; ok {
 			continue
 		}
@@ -348,7 +346,7 @@ func filesystemParamsBySource(
 			continue
 		}
 		filesystemSource, err := filesystemSource(
-			environConfig, baseStorageDir, sourceName, params.Provider,
+			environConfig, baseStorageDir, params.Provider, params.Attributes,
 		)
 		if errors.Cause(err) == errNonDynamic {
 			filesystemSource = nil
@@ -359,7 +357,7 @@ func filesystemParamsBySource(
 	}
 	paramsBySource := make(map[string][]storage.FilesystemParams)
 	for _, params := range params {
-		sourceName := string(params.Provider)
+		sourceName := sourceKey(params.Provider, params.Attributes)
 		filesystemSource := filesystemSources[sourceName]
 		if filesystemSource == nil {
 			// Ignore nil filesystem sources; this means that the
@@ -396,14 +394,12 @@ func filesystemAttachmentParamsBySource(
 	filesystems map[names.FilesystemTag]storage.Filesystem,
 	managedFilesystemSource storage.FilesystemSource,
 ) (map[string][]storage.FilesystemAttachmentParams, map[string]storage.FilesystemSource, error) {
-	// TODO(axw) later we may have multiple instantiations (sources)
-	// for a storage provider, e.g. multiple Ceph installations. For
-	// now we assume a single source for each provider type, with no
-	// configuration.
+	// There is a source for each distinct storage pool configuration,
+	// so that each source is configured with the pool's attributes.
 	filesystemSources := make(map[string]storage.FilesystemSource)
 	paramsBySource := make(map[string][]storage.FilesystemAttachmentParams)
 	for _, params := range params {
-		sourceName := string(params.Provider)
+		sourceName := sourceKey(params.Provider, params.Attributes)
 		paramsBySource[sourceName] = append(paramsBySource[sourceName], params)
 		if _, ok := filesystemSources[sourceName]; ok {
 			continue
@@ -414,7 +410,7 @@ func filesystemAttachmentParamsBySource(
 			continue
 		}
 		filesystemSource, err := filesystemSource(
-			environConfig, baseStorageDir, sourceName, params.Provider,
+			environConfig, baseStorageDir, params.Provider, params.Attributes,
 		)
 		if err != nil {
 			return nil, nil, errors.Annotate(err, "getting filesystem source")
@@ -61,6 +61,11 @@ type VolumeAccessor interface {
 	// that this storage provisioner is responsible for.
 	WatchVolumeAttachments() (watcher.MachineStorageIdsWatcher, error)
 
+	// WatchVolumeResizes watches for changes to volumes that this
+	// storage provisioner is responsible for, including requests
+	// to resize them.
+	WatchVolumeResizes() (watcher.StringsWatcher, error)
+
 	// Volumes returns details of volumes with the specified tags.
 	Volumes([]names.VolumeTag) ([]params.VolumeResult, error)
 
@@ -76,6 +81,10 @@ type VolumeAccessor interface {
 	// with the specified tags.
 	VolumeParams([]names.VolumeTag) ([]params.VolumeParamsResult, error)
 
+	// VolumeResizeParams returns the parameters for resizing the
+	// volumes with the specified tags.
+	VolumeResizeParams([]names.VolumeTag) ([]params.VolumeResizeParamsResult, error)
+
 	// VolumeAttachmentParams returns the parameters for creating the
 	// volume attachments with the specified tags.
 	VolumeAttachmentParams([]params.MachineStorageId) ([]params.VolumeAttachmentParamsResult, error)
@@ -213,6 +222,7 @@ func (w *storageProvisioner) loop() error {
 		volumesChanges               watcher.StringsChannel
 		filesystemsChanges           watcher.StringsChannel
 		volumeAttachmentsChanges     watcher.MachineStorageIdsChannel
+		volumeResizesChanges         watcher.StringsChannel
 		filesystemAttachmentsChanges watcher.MachineStorageIdsChannel
 		machineBlockDevicesChanges   <-chan struct{}
 	)
@@ -267,6 +277,15 @@ func (w *storageProvisioner) loop() error {
 		}
 		volumeAttachmentsChanges = volumeAttachmentsWatcher.Changes()
 
+		volumeResizesWatcher, err := w.config.Volumes.WatchVolumeResizes()
+		if err != nil {
+			return errors.Annotate(err, "watching volume resizes")
+		}
+		if err := w.catacomb.Add(volumeResizesWatcher); err != nil {
+			return errors.Trace(err)
+		}
+		volumeResizesChanges = volumeResizesWatcher.Changes()
+
 		filesystemAttachmentsWatcher, err := w.config.Filesystems.WatchFilesystemAttachments()
 		if err != nil {
 			return errors.Annotate(err, "watching filesystem attachments")
@@ -339,6 +358,13 @@ func (w *storageProvisioner) loop() error {
 			if err := volumeAttachmentsChanged(&ctx, changes); err != nil {
 				return errors.Trace(err)
 			}
+		case changes, ok := <-volumeResizesChanges:
+			if !ok {
+				return errors.New("volume resizes watcher closed")
+			}
+			if err := volumeResizesChanged(&ctx, changes); err != nil {
+				return errors.Trace(err)
+			}
 		case changes, ok := <-filesystemsChanges:
 			if !ok {
 				return errors.New("filesystems watcher closed")
@@ -380,6 +406,7 @@ func processSchedule(ctx *context) error {
 	destroyVolumeOps := make(map[names.VolumeTag]*destroyVolumeOp)
 	attachVolumeOps := make(map[params.MachineStorageId]*attachVolumeOp)
 	detachVolumeOps := make(map[params.MachineStorageId]*detachVolumeOp)
+	resizeVolumeOps := make(map[names.VolumeTag]*resizeVolumeOp)
 	createFilesystemOps := make(map[names.FilesystemTag]*createFilesystemOp)
 	destroyFilesystemOps := make(map[names.FilesystemTag]*destroyFilesystemOp)
 	attachFilesystemOps := make(map[params.MachineStorageId]*attachFilesystemOp)
@@ -396,6 +423,8 @@ func processSchedule(ctx *context) error {
 			attachVolumeOps[key.(params.MachineStorageId)] = op
 		case *detachVolumeOp:
 			detachVolumeOps[key.(params.MachineStorageId)] = op
+		case *resizeVolumeOp:
+			resizeVolumeOps[op.args.Tag] = op
 		case *createFilesystemOp:
 			createFilesystemOps[key.(names.FilesystemTag)] = op
 		case *destroyFilesystemOp:
@@ -426,6 +455,11 @@ func processSchedule(ctx *context) error {
 			return errors.Annotate(err, "attaching volumes")
 		}
 	}
+	if len(resizeVolumeOps) > 0 {
+		if err := resizeVolumes(ctx, resizeVolumeOps); err != nil {
+			return errors.Annotate(err, "resizing volumes")
+		}
+	}
 	if len(destroyFilesystemOps) > 0 {
 		if err := destroyFilesystems(ctx, destroyFilesystemOps); err != nil {
 			return errors.Annotate(err, "destroying filesystems")
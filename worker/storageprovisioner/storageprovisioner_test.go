@@ -1165,6 +1165,35 @@ func (s *storageProvisionerSuite) TestResourceTags(c *gc.C) {
 	}}})
 }
 
+func (s *storageProvisionerSuite) TestVolumeSourceConfiguredWithPoolAttributes(c *gc.C) {
+	volumeInfoSet := make(chan interface{})
+	volumeAccessor := newMockVolumeAccessor()
+	volumeAccessor.provisionedMachines["machine-1"] = instance.Id("already-provisioned-1")
+	volumeAccessor.setVolumeInfo = func(volumes []params.Volume) ([]params.ErrorResult, error) {
+		defer close(volumeInfoSet)
+		return nil, nil
+	}
+
+	var sourceConfigs []map[string]interface{}
+	s.provider.volumeSourceFunc = func(envConfig *config.Config, sourceConfig *storage.Config) (storage.VolumeSource, error) {
+		sourceConfigs = append(sourceConfigs, sourceConfig.Attrs())
+		return &dummyVolumeSource{}, nil
+	}
+
+	args := &workerArgs{volumes: volumeAccessor}
+	worker := newStorageProvisioner(c, args)
+	defer func() { c.Assert(worker.Wait(), gc.IsNil) }()
+	defer worker.Kill()
+
+	volumeAccessor.volumesWatcher.changes <- []string{"1"}
+	args.environ.watcher.changes <- struct{}{}
+	waitChannel(c, volumeInfoSet, "waiting for volume info to be set")
+	c.Assert(sourceConfigs, gc.Not(gc.HasLen), 0)
+	for _, attrs := range sourceConfigs {
+		c.Assert(attrs, jc.DeepEquals, map[string]interface{}{"persistent": true})
+	}
+}
+
 func (s *storageProvisionerSuite) TestSetVolumeInfoErrorStopsWorker(c *gc.C) {
 	volumeAccessor := newMockVolumeAccessor()
 	volumeAccessor.provisionedMachines["machine-1"] = instance.Id("already-provisioned-1")
@@ -1708,6 +1737,97 @@ func (s *storageProvisionerSuite) TestDestroyFilesystems(c *gc.C) {
 	assertNoEvent(c, removedChan, "filesystems removed")
 }
 
+func (s *storageProvisionerSuite) TestResizeVolumes(c *gc.C) {
+	volumeAccessor := newMockVolumeAccessor()
+	volumeAccessor.resizeParams["volume-1"] = params.VolumeResizeParams{
+		VolumeTag: "volume-1",
+		VolumeId:  "vol-1",
+		Size:      2048,
+		Provider:  "dummy",
+	}
+
+	resizedChan := make(chan interface{}, 1)
+	s.provider.resizeVolumesFunc = func(args []storage.VolumeResizeParams) ([]storage.ResizeVolumesResult, error) {
+		resizedChan <- args
+		return []storage.ResizeVolumesResult{{
+			VolumeInfo: &storage.VolumeInfo{VolumeId: "vol-1", Size: 2048},
+		}}, nil
+	}
+
+	volumeInfoSet := make(chan interface{}, 1)
+	volumeAccessor.setVolumeInfo = func(volumes []params.Volume) ([]params.ErrorResult, error) {
+		volumeInfoSet <- volumes
+		return make([]params.ErrorResult, len(volumes)), nil
+	}
+
+	args := &workerArgs{volumes: volumeAccessor}
+	worker := newStorageProvisioner(c, args)
+	defer func() { c.Assert(worker.Wait(), gc.IsNil) }()
+	defer worker.Kill()
+
+	volumeAccessor.resizesWatcher.changes <- []string{"1", "2"}
+	args.environ.watcher.changes <- struct{}{}
+
+	resized := waitChannel(c, resizedChan, "waiting for volume to be resized")
+	c.Assert(resized, jc.DeepEquals, []storage.VolumeResizeParams{{
+		Tag:      names.NewVolumeTag("1"),
+		VolumeId: "vol-1",
+		Size:     2048,
+		Provider: "dummy",
+	}})
+	volumes := waitChannel(c, volumeInfoSet, "waiting for volume info to be set")
+	c.Assert(volumes, jc.DeepEquals, []params.Volume{{
+		VolumeTag: "volume-1",
+		Info: params.VolumeInfo{
+			VolumeId: "vol-1",
+			Size:     2048,
+		},
+	}})
+	c.Assert(args.statusSetter.args, jc.DeepEquals, []params.EntityStatusArgs{
+		{Tag: "volume-1", Status: "detached"},
+	})
+}
+
+func (s *storageProvisionerSuite) TestResizeVolumesNotSupported(c *gc.C) {
+	volumeAccessor := newMockVolumeAccessor()
+	volumeAccessor.resizeParams["volume-1"] = params.VolumeResizeParams{
+		VolumeTag: "volume-1",
+		VolumeId:  "vol-1",
+		Size:      2048,
+		Provider:  "dummy",
+	}
+	s.provider.volumeSourceFunc = func(*config.Config, *storage.Config) (storage.VolumeSource, error) {
+		// Hide the dummy volume source's ResizeVolumes method.
+		return struct{ storage.VolumeSource }{&dummyVolumeSource{provider: s.provider}}, nil
+	}
+
+	statusSet := make(chan interface{}, 1)
+	statusSetter := &mockStatusSetter{}
+	statusSetter.setStatus = func(args []params.EntityStatusArgs) error {
+		statusSet <- args
+		return nil
+	}
+	volumeAccessor.setVolumeInfo = func(volumes []params.Volume) ([]params.ErrorResult, error) {
+		c.Errorf("unexpected call to SetVolumeInfo: %v", volumes)
+		return make([]params.ErrorResult, len(volumes)), nil
+	}
+
+	args := &workerArgs{volumes: volumeAccessor, statusSetter: statusSetter}
+	worker := newStorageProvisioner(c, args)
+	defer func() { c.Assert(worker.Wait(), gc.IsNil) }()
+	defer worker.Kill()
+
+	volumeAccessor.resizesWatcher.changes <- []string{"1"}
+	args.environ.watcher.changes <- struct{}{}
+
+	statuses := waitChannel(c, statusSet, "waiting for volume status to be set")
+	c.Assert(statuses, jc.DeepEquals, []params.EntityStatusArgs{{
+		Tag:    "volume-1",
+		Status: "error",
+		Info:   `resizing volumes with storage provider "dummy" not supported`,
+	}})
+}
+
 func newStorageProvisioner(c *gc.C, args *workerArgs) worker.Worker {
 	if args == nil {
 		args = &workerArgs{}
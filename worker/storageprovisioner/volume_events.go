@@ -54,6 +54,41 @@ func volumesChanged(ctx *context, changes []string) error {
 	return nil
 }
 
+// volumeResizesChanged is called when the volumes with the provided IDs
+// have been seen to have changed, possibly because they have been
+// requested to be resized.
+func volumeResizesChanged(ctx *context, changes []string) error {
+	tags := make([]names.VolumeTag, len(changes))
+	for i, change := range changes {
+		tags[i] = names.NewVolumeTag(change)
+	}
+	resizeParamsResults, err := ctx.config.Volumes.VolumeResizeParams(tags)
+	if err != nil {
+		return errors.Annotate(err, "getting volume resize params")
+	}
+	var ops []scheduleOp
+	for i, result := range resizeParamsResults {
+		tag := tags[i]
+		ctx.schedule.Remove(resizeVolumeKey{tag})
+		if params.IsCodeNotFound(result.Error) {
+			// There is no pending request to resize the volume.
+			continue
+		} else if result.Error != nil {
+			return errors.Annotatef(
+				result.Error, "getting resize parameters for volume %s", tag.Id(),
+			)
+		}
+		resizeParams, err := volumeResizeParamsFromParams(result.Result)
+		if err != nil {
+			return errors.Annotate(err, "getting volume resize parameters")
+		}
+		logger.Debugf("volume %s is to be resized to %dMiB", tag.Id(), resizeParams.Size)
+		ops = append(ops, &resizeVolumeOp{args: resizeParams})
+	}
+	scheduleOperations(ctx, ops...)
+	return nil
+}
+
 // volumeAttachmentsChanged is called when the lifecycle states of the volume
 // attachments with the provided IDs have been seen to have changed.
 func volumeAttachmentsChanged(ctx *context, watcherIds []watcher.MachineStorageId) error {
@@ -431,6 +466,19 @@ func volumeFromParams(in params.Volume) (storage.Volume, error) {
 	}, nil
 }
 
+func volumeResizeParamsFromParams(in params.VolumeResizeParams) (storage.VolumeResizeParams, error) {
+	volumeTag, err := names.ParseVolumeTag(in.VolumeTag)
+	if err != nil {
+		return storage.VolumeResizeParams{}, errors.Trace(err)
+	}
+	return storage.VolumeResizeParams{
+		Tag:      volumeTag,
+		VolumeId: in.VolumeId,
+		Size:     in.Size,
+		Provider: storage.ProviderType(in.Provider),
+	}, nil
+}
+
 func volumeParamsFromParams(in params.VolumeParams) (storage.VolumeParams, error) {
 	volumeTag, err := names.ParseVolumeTag(in.VolumeTag)
 	if err != nil {
@@ -464,6 +512,7 @@ func volumeParamsFromParams(in params.VolumeParams) (storage.VolumeParams, error
 				Machine:    machineTag,
 				InstanceId: instance.Id(in.Attachment.InstanceId),
 				ReadOnly:   in.Attachment.ReadOnly,
+				Attributes: in.Attachment.Attributes,
 			},
 			Volume: volumeTag,
 		}
@@ -474,6 +523,7 @@ func volumeParamsFromParams(in params.VolumeParams) (storage.VolumeParams, error
 		providerType,
 		in.Attributes,
 		in.Tags,
+		in.SnapshotId,
 		attachment,
 	}, nil
 }
@@ -493,6 +543,7 @@ func volumeAttachmentParamsFromParams(in params.VolumeAttachmentParams) (storage
 			Machine:    machineTag,
 			InstanceId: instance.Id(in.InstanceId),
 			ReadOnly:   in.ReadOnly,
+			Attributes: in.Attributes,
 		},
 		Volume:   volumeTag,
 		VolumeId: in.VolumeId,
@@ -260,6 +260,101 @@ func destroyVolumes(ctx *context, ops map[names.VolumeTag]*destroyVolumeOp) erro
 	return nil
 }
 
+// resizeVolumes resizes volumes with the specified parameters.
+func resizeVolumes(ctx *context, ops map[names.VolumeTag]*resizeVolumeOp) error {
+	paramsBySource := make(map[string][]storage.VolumeResizeParams)
+	for _, op := range ops {
+		sourceName := string(op.args.Provider)
+		paramsBySource[sourceName] = append(paramsBySource[sourceName], op.args)
+	}
+	var reschedule []scheduleOp
+	var volumes []storage.Volume
+	var statuses []params.EntityStatusArgs
+	for sourceName, resizeParams := range paramsBySource {
+		logger.Debugf("resizing volumes from %q: %v", sourceName, resizeParams)
+		var resizer storage.VolumeResizer
+		volumeSource, err := volumeSource(
+			ctx.modelConfig, ctx.config.StorageDir, resizeParams[0].Provider, nil,
+		)
+		if err != nil && errors.Cause(err) != errNonDynamic {
+			return errors.Annotate(err, "getting volume source")
+		}
+		if volumeSource != nil {
+			resizer, _ = volumeSource.(storage.VolumeResizer)
+		}
+		if resizer == nil {
+			// The storage provider cannot resize volumes, so there
+			// is no point in retrying; record the error against the
+			// volumes so the user can see why they were not resized.
+			err := errors.NotSupportedf("resizing volumes with storage provider %q", sourceName)
+			for _, p := range resizeParams {
+				statuses = append(statuses, params.EntityStatusArgs{
+					Tag:    p.Tag.String(),
+					Status: status.StatusError,
+					Info:   err.Error(),
+				})
+			}
+			continue
+		}
+		results, err := resizer.ResizeVolumes(resizeParams)
+		if err != nil {
+			return errors.Annotatef(err, "resizing volumes from source %q", sourceName)
+		}
+		for i, result := range results {
+			tag := resizeParams[i].Tag
+			if result.Error != nil {
+				// Failed to resize volume; reschedule and update status.
+				reschedule = append(reschedule, ops[tag])
+				statuses = append(statuses, params.EntityStatusArgs{
+					Tag:    tag.String(),
+					Status: status.StatusError,
+					Info:   result.Error.Error(),
+				})
+				logger.Debugf("failed to resize %s: %v", names.ReadableString(tag), result.Error)
+				continue
+			}
+			volumes = append(volumes, storage.Volume{Tag: tag, VolumeInfo: *result.VolumeInfo})
+			statuses = append(statuses, params.EntityStatusArgs{
+				Tag:    tag.String(),
+				Status: volumeAttachedStatus(ctx, tag),
+			})
+		}
+	}
+	scheduleOperations(ctx, reschedule...)
+	setStatus(ctx, statuses)
+	if len(volumes) == 0 {
+		return nil
+	}
+	errorResults, err := ctx.config.Volumes.SetVolumeInfo(volumesFromStorage(volumes))
+	if err != nil {
+		return errors.Annotate(err, "publishing resized volumes to state")
+	}
+	for i, result := range errorResults {
+		if result.Error != nil {
+			logger.Errorf(
+				"publishing resized volume %s to state: %v",
+				volumes[i].Tag.Id(),
+				result.Error,
+			)
+			continue
+		}
+		updateVolume(ctx, volumes[i])
+	}
+	return nil
+}
+
+// volumeAttachedStatus returns the status to record for a volume
+// that is otherwise healthy, depending on whether or not it is known
+// to be attached to a machine.
+func volumeAttachedStatus(ctx *context, tag names.VolumeTag) status.Status {
+	for id := range ctx.volumeAttachments {
+		if id.AttachmentTag == tag.String() {
+			return status.StatusAttached
+		}
+	}
+	return status.StatusDetached
+}
+
 // detachVolumes destroys volume attachments with the specified parameters.
 func detachVolumes(ctx *context, ops map[params.MachineStorageId]*detachVolumeOp) error {
 	volumeAttachmentParams := make([]storage.VolumeAttachmentParams, 0, len(ops))
@@ -329,18 +424,16 @@ func volumeParamsBySource(
 	baseStorageDir string,
 	params []storage.VolumeParams,
 ) (map[string][]storage.VolumeParams, map[string]storage.VolumeSource, error) {
-	// TODO(axw) later we may have multiple instantiations (sources)
-	// for a storage provider, e.g. multiple Ceph installations. For
-	// now we assume a single source for each provider type, with no
-	// configuration.
+	// There is a source for each distinct storage pool configuration,
+	// so that each source is configured with the pool's attributes.
 	volumeSources := make(map[string]storage.VolumeSource)
 	for _, params := range params {
-		sourceName := string(params.Provider)
+		sourceName := sourceKey(params.Provider, params.Attributes)
 		if _, ok := volumeSources[sourceName]; ok {
 			continue
 		}
 		volumeSource, err := volumeSource(
-			environConfig, baseStorageDir, sourceName, params.Provider,
+			environConfig, baseStorageDir, params.Provider, params.Attributes,
 		)
 		if errors.Cause(err) == errNonDynamic {
 			volumeSource = nil
@@ -351,7 +444,7 @@ func volumeParamsBySource(
 	}
 	paramsBySource := make(map[string][]storage.VolumeParams)
 	for _, params := range params {
-		sourceName := string(params.Provider)
+		sourceName := sourceKey(params.Provider, params.Attributes)
 		volumeSource := volumeSources[sourceName]
 		if volumeSource == nil {
 			// Ignore nil volume sources; this means that the
@@ -370,7 +463,12 @@ func validateVolumeParams(
 	valid := make([]storage.VolumeParams, 0, len(volumeParams))
 	results := make([]error, len(volumeParams))
 	for i, params := range volumeParams {
-		err := volumeSource.ValidateVolumeParams(params)
+		var err error
+		if _, ok := volumeSource.(storage.VolumeSnapshotter); !ok && params.SnapshotId != "" {
+			err = errors.NotSupportedf("creating volume from snapshot with provider %q", params.Provider)
+		} else {
+			err = volumeSource.ValidateVolumeParams(params)
+		}
 		if err == nil {
 			valid = append(valid, params)
 		}
@@ -385,20 +483,18 @@ func volumeAttachmentParamsBySource(
 	baseStorageDir string,
 	params []storage.VolumeAttachmentParams,
 ) (map[string][]storage.VolumeAttachmentParams, map[string]storage.VolumeSource, error) {
-	// TODO(axw) later we may have multiple instantiations (sources)
-	// for a storage provider, e.g. multiple Ceph installations. For
-	// now we assume a single source for each provider type, with no
-	// configuration.
+	// There is a source for each distinct storage pool configuration,
+	// so that each source is configured with the pool's attributes.
 	volumeSources := make(map[string]storage.VolumeSource)
 	paramsBySource := make(map[string][]storage.VolumeAttachmentParams)
 	for _, params := range params {
-		sourceName := string(params.Provider)
+		sourceName := sourceKey(params.Provider, params.Attributes)
 		paramsBySource[sourceName] = append(paramsBySource[sourceName], params)
 		if _, ok := volumeSources[sourceName]; ok {
 			continue
 		}
 		volumeSource, err := volumeSource(
-			environConfig, baseStorageDir, sourceName, params.Provider,
+			environConfig, baseStorageDir, params.Provider, params.Attributes,
 		)
 		if err != nil {
 			return nil, nil, errors.Annotate(err, "getting volume source")
@@ -482,3 +578,20 @@ func (op *detachVolumeOp) key() interface{} {
 		AttachmentTag: op.args.Volume.String(),
 	}
 }
+
+type resizeVolumeOp struct {
+	exponentialBackoff
+	args storage.VolumeResizeParams
+}
+
+// resizeVolumeKey is the schedule key for a resizeVolumeOp. It is
+// distinct from the volume tags used to key operations that create
+// and destroy volumes, so that a volume may be resized while other
+// operations on it are scheduled.
+type resizeVolumeKey struct {
+	tag names.VolumeTag
+}
+
+func (op *resizeVolumeOp) key() interface{} {
+	return resizeVolumeKey{op.args.Tag}
+}
@@ -0,0 +1,56 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package uniter
+
+import "sync"
+
+// actionCancels connects the remote state watcher, which learns that
+// a running action has been cancelled, with the operation running the
+// action, which must kill the action's process. The operation blocks
+// the resolver loop while it runs, so the cancellation cannot be
+// delivered through the remote state snapshot.
+type actionCancels struct {
+	mu        sync.Mutex
+	cancelled map[string]chan struct{}
+}
+
+func newActionCancels() *actionCancels {
+	return &actionCancels{cancelled: make(map[string]chan struct{})}
+}
+
+// channel returns the channel for the given action, creating it if
+// necessary. It must be called with c.mu held.
+func (c *actionCancels) channel(actionId string) chan struct{} {
+	ch, ok := c.cancelled[actionId]
+	if !ok {
+		ch = make(chan struct{})
+		c.cancelled[actionId] = ch
+	}
+	return ch
+}
+
+// cancel records that the given action has been cancelled.
+func (c *actionCancels) cancel(actionId string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	ch := c.channel(actionId)
+	select {
+	case <-ch:
+	default:
+		close(ch)
+	}
+}
+
+// watch returns a channel that is closed when the given action is
+// cancelled, and a function that forgets the action once it is no
+// longer running.
+func (c *actionCancels) watch(actionId string) (<-chan struct{}, func()) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.channel(actionId), func() {
+		c.mu.Lock()
+		defer c.mu.Unlock()
+		delete(c.cancelled, actionId)
+	}
+}
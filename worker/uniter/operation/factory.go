@@ -6,6 +6,7 @@ package operation
 import (
 	"github.com/juju/errors"
 	"github.com/juju/names"
+	"github.com/juju/utils/clock"
 	corecharm "gopkg.in/juju/charm.v6-unstable"
 
 	"github.com/juju/juju/worker/uniter/charm"
@@ -20,6 +21,15 @@ type FactoryParams struct {
 	Callbacks      Callbacks
 	Abort          <-chan struct{}
 	MetricSpoolDir string
+
+	// ActionCancelled, if not nil, returns a channel that is closed
+	// when the action with the given id is cancelled while it runs,
+	// and a function to call once the action is no longer running.
+	ActionCancelled func(actionId string) (<-chan struct{}, func())
+
+	// Clock is used to enforce action timeouts. If it is nil,
+	// actions are allowed to run for as long as they like.
+	Clock clock.Clock
 }
 
 // NewFactory returns a Factory that creates Operations backed by the supplied
@@ -102,6 +112,8 @@ func (f *factory) NewAction(actionId string) (Operation, error) {
 		actionId:      actionId,
 		callbacks:     f.config.Callbacks,
 		runnerFactory: f.config.RunnerFactory,
+		cancelled:     f.config.ActionCancelled,
+		clock:         f.config.Clock,
 	}, nil
 }
 
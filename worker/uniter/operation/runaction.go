@@ -5,8 +5,10 @@ package operation
 
 import (
 	"fmt"
+	"time"
 
 	"github.com/juju/errors"
+	"github.com/juju/utils/clock"
 
 	"github.com/juju/juju/worker/uniter/runner"
 )
@@ -16,9 +18,12 @@ type runAction struct {
 
 	callbacks     Callbacks
 	runnerFactory runner.Factory
+	cancelled     func(actionId string) (<-chan struct{}, func())
+	clock         clock.Clock
 
-	name   string
-	runner runner.Runner
+	name    string
+	timeout time.Duration
+	runner  runner.Runner
 
 	RequiresMachineLock
 }
@@ -54,6 +59,7 @@ func (ra *runAction) Prepare(state State) (*State, error) {
 		return nil, errors.Trace(err)
 	}
 	ra.name = actionData.Name
+	ra.timeout = actionData.Timeout
 	ra.runner = rnr
 	return stateChange{
 		Kind:     RunAction,
@@ -72,6 +78,22 @@ func (ra *runAction) Execute(state State) (*State, error) {
 		return nil, err
 	}
 
+	var cancelled <-chan struct{}
+	if ra.cancelled != nil {
+		var release func()
+		cancelled, release = ra.cancelled(ra.actionId)
+		defer release()
+	}
+	var expired <-chan time.Time
+	if ra.timeout > 0 && ra.clock != nil {
+		expired = ra.clock.After(ra.timeout)
+	}
+	if cancelled != nil || expired != nil {
+		done := make(chan struct{})
+		defer close(done)
+		go ra.killOnStop(cancelled, expired, done)
+	}
+
 	err := ra.runner.RunAction(ra.name)
 	if err != nil {
 		// This indicates an actual error -- an action merely failing should
@@ -86,6 +108,24 @@ func (ra *runAction) Execute(state State) (*State, error) {
 	}.apply(state), nil
 }
 
+// killOnStop kills the running action if it is cancelled, or if it
+// expires, before done is closed.
+func (ra *runAction) killOnStop(cancelled <-chan struct{}, expired <-chan time.Time, done <-chan struct{}) {
+	select {
+	case <-cancelled:
+		logger.Infof("cancelling action %s", ra.actionId)
+		if err := ra.runner.Context().CancelAction(); err != nil {
+			logger.Errorf("cannot cancel action %s: %v", ra.actionId, err)
+		}
+	case <-expired:
+		logger.Infof("action %s timed out after %v", ra.actionId, ra.timeout)
+		if err := ra.runner.Context().ExpireAction(); err != nil {
+			logger.Errorf("cannot stop action %s: %v", ra.actionId, err)
+		}
+	case <-done:
+	}
+}
+
 // Commit preserves the recorded hook, and returns a neutral state.
 // Commit is part of the Operation interface.
 func (ra *runAction) Commit(state State) (*State, error) {
@@ -4,12 +4,15 @@
 package operation_test
 
 import (
+	"time"
+
 	"github.com/juju/errors"
 	"github.com/juju/testing"
 	jc "github.com/juju/testing/checkers"
 	gc "gopkg.in/check.v1"
 	"gopkg.in/juju/charm.v6-unstable/hooks"
 
+	coretesting "github.com/juju/juju/testing"
 	"github.com/juju/juju/worker/uniter/hook"
 	"github.com/juju/juju/worker/uniter/operation"
 	"github.com/juju/juju/worker/uniter/runner"
@@ -227,6 +230,67 @@ func (s *RunActionSuite) TestExecuteSuccess(c *gc.C) {
 	}
 }
 
+func (s *RunActionSuite) TestExecuteCancelled(c *gc.C) {
+	runnerFactory := NewRunActionRunnerFactory(nil)
+	mockRunner := runnerFactory.MockNewActionRunner.runner
+	mockContext := mockRunner.context.(*MockContext)
+	mockContext.cancelled = make(chan struct{})
+	// The action runs until it is cancelled.
+	mockRunner.MockRunAction.wait = mockContext.cancelled
+
+	cancelled := make(chan struct{})
+	close(cancelled)
+	var watched []string
+	released := false
+	factory := operation.NewFactory(operation.FactoryParams{
+		RunnerFactory: runnerFactory,
+		Callbacks:     &RunActionCallbacks{},
+		ActionCancelled: func(actionId string) (<-chan struct{}, func()) {
+			watched = append(watched, actionId)
+			return cancelled, func() { released = true }
+		},
+	})
+	op, err := factory.NewAction(someActionId)
+	c.Assert(err, jc.ErrorIsNil)
+	midState, err := op.Prepare(operation.State{})
+	c.Assert(err, jc.ErrorIsNil)
+
+	_, err = op.Execute(*midState)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(watched, jc.DeepEquals, []string{someActionId})
+	c.Assert(released, jc.IsTrue)
+	mockContext.CheckCallNames(c, "Prepare", "CancelAction")
+}
+
+func (s *RunActionSuite) TestExecuteTimedOut(c *gc.C) {
+	runnerFactory := NewRunActionRunnerFactory(nil)
+	mockRunner := runnerFactory.MockNewActionRunner.runner
+	mockContext := mockRunner.context.(*MockContext)
+	mockContext.actionData.Timeout = time.Minute
+	mockContext.cancelled = make(chan struct{})
+	// The action runs until it is stopped.
+	mockRunner.MockRunAction.wait = mockContext.cancelled
+
+	clock := coretesting.NewClock(time.Time{})
+	factory := operation.NewFactory(operation.FactoryParams{
+		RunnerFactory: runnerFactory,
+		Callbacks:     &RunActionCallbacks{},
+		Clock:         clock,
+	})
+	op, err := factory.NewAction(someActionId)
+	c.Assert(err, jc.ErrorIsNil)
+	midState, err := op.Prepare(operation.State{})
+	c.Assert(err, jc.ErrorIsNil)
+
+	go func() {
+		<-clock.Alarms()
+		clock.Advance(time.Minute)
+	}()
+	_, err = op.Execute(*midState)
+	c.Assert(err, jc.ErrorIsNil)
+	mockContext.CheckCallNames(c, "Prepare", "ExpireAction")
+}
+
 func (s *RunActionSuite) TestCommit(c *gc.C) {
 	var stateChangeTests = []struct {
 		description string
@@ -290,6 +290,7 @@ type MockContext struct {
 	actionData      *context.ActionData
 	setStatusCalled bool
 	status          jujuc.StatusInfo
+	cancelled       chan struct{}
 }
 
 func (mock *MockContext) ActionData() (*context.ActionData, error) {
@@ -326,13 +327,29 @@ func (mock *MockContext) Prepare() error {
 	return mock.NextErr()
 }
 
+func (mock *MockContext) CancelAction() error {
+	mock.MethodCall(mock, "CancelAction")
+	close(mock.cancelled)
+	return mock.NextErr()
+}
+
+func (mock *MockContext) ExpireAction() error {
+	mock.MethodCall(mock, "ExpireAction")
+	close(mock.cancelled)
+	return mock.NextErr()
+}
+
 type MockRunAction struct {
 	gotName *string
 	err     error
+	wait    <-chan struct{}
 }
 
 func (mock *MockRunAction) Call(actionName string) error {
 	mock.gotName = &actionName
+	if mock.wait != nil {
+		<-mock.wait
+	}
 	return mock.err
 }
 
@@ -82,4 +82,8 @@ type StorageSnapshot struct {
 	Life     params.Life
 	Attached bool
 	Location string
+
+	// Size is the size of the volume backing the storage, in MiB,
+	// or zero if the storage is not backed by a volume.
+	Size uint64
 }
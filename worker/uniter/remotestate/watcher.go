@@ -35,6 +35,7 @@ type RemoteStateWatcher struct {
 	updateStatusChannel       func() <-chan time.Time
 	commandChannel            <-chan string
 	retryHookChannel          <-chan struct{}
+	cancelAction              func(actionId string)
 
 	catacomb catacomb.Catacomb
 
@@ -52,6 +53,11 @@ type WatcherConfig struct {
 	CommandChannel      <-chan string
 	RetryHookChannel    <-chan struct{}
 	UnitTag             names.UnitTag
+
+	// CancelAction, if not nil, is called when an action that has
+	// already been reported is reported again, which happens when
+	// the action is cancelled while it is running.
+	CancelAction func(actionId string)
 }
 
 // NewWatcher returns a RemoteStateWatcher that handles state changes pertaining to the
@@ -67,6 +73,7 @@ func NewWatcher(config WatcherConfig) (*RemoteStateWatcher, error) {
 		updateStatusChannel:       config.UpdateStatusChannel,
 		commandChannel:            config.CommandChannel,
 		retryHookChannel:          config.RetryHookChannel,
+		cancelAction:              config.CancelAction,
 		// Note: it is important that the out channel be buffered!
 		// The remote state watcher will perform a non-blocking send
 		// on the channel to wake up the observer. It is non-blocking
@@ -634,7 +641,18 @@ func (w *RemoteStateWatcher) storageAttachmentChanged(change storageAttachmentCh
 func (w *RemoteStateWatcher) actionsChanged(actions []string) error {
 	w.mu.Lock()
 	defer w.mu.Unlock()
-	w.current.Actions = append(w.current.Actions, actions...)
+	known := make(map[string]bool)
+	for _, action := range w.current.Actions {
+		known[action] = true
+	}
+	for _, action := range actions {
+		if !known[action] {
+			w.current.Actions = append(w.current.Actions, action)
+			known[action] = true
+		} else if w.cancelAction != nil {
+			w.cancelAction(action)
+		}
+	}
 	return nil
 }
 
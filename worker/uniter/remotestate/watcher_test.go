@@ -24,6 +24,7 @@ type WatcherSuite struct {
 	leadership *mockLeadershipTracker
 	watcher    *remotestate.RemoteStateWatcher
 	clock      *testing.Clock
+	cancelled  chan string
 }
 
 // Duration is arbitrary, we'll trigger the ticker
@@ -70,11 +71,15 @@ func (s *WatcherSuite) SetUpTest(c *gc.C) {
 		return s.clock.After(statusTickDuration)
 	}
 
+	s.cancelled = make(chan string, 1)
 	w, err := remotestate.NewWatcher(remotestate.WatcherConfig{
 		State:               s.st,
 		LeadershipTracker:   s.leadership,
 		UnitTag:             s.st.unit.tag,
 		UpdateStatusChannel: statusTicker,
+		CancelAction: func(actionId string) {
+			s.cancelled <- actionId
+		},
 	})
 	c.Assert(err, jc.ErrorIsNil)
 	s.watcher = w
@@ -195,6 +200,24 @@ func (s *WatcherSuite) TestActionsReceived(c *gc.C) {
 	c.Assert(s.watcher.Snapshot().Actions, gc.DeepEquals, []string{"an-action"})
 }
 
+func (s *WatcherSuite) TestActionReceivedAgainIsCancelled(c *gc.C) {
+	signalAll(s.st, s.leadership)
+	assertNotifyEvent(c, s.watcher.RemoteStateChanged(), "waiting for remote state change")
+
+	s.st.unit.actionWatcher.changes <- []string{"an-action"}
+	assertNotifyEvent(c, s.watcher.RemoteStateChanged(), "waiting for remote state change")
+
+	s.st.unit.actionWatcher.changes <- []string{"an-action", "another-action"}
+	assertNotifyEvent(c, s.watcher.RemoteStateChanged(), "waiting for remote state change")
+	c.Assert(s.watcher.Snapshot().Actions, gc.DeepEquals, []string{"an-action", "another-action"})
+	select {
+	case actionId := <-s.cancelled:
+		c.Assert(actionId, gc.Equals, "an-action")
+	case <-time.After(testing.LongWait):
+		c.Fatalf("timed out waiting for action to be cancelled")
+	}
+}
+
 func (s *WatcherSuite) TestClearResolvedMode(c *gc.C) {
 	s.st.unit.resolved = params.ResolvedRetryHooks
 	signalAll(s.st, s.leadership)
@@ -274,6 +297,7 @@ func (s *WatcherSuite) TestStorageChanged(c *gc.C) {
 		Life:       params.Dying,
 		Kind:       params.StorageKindBlock,
 		Location:   "malta",
+		Size:       1024,
 	}
 
 	// We should not see any event until the storage attachment watchers
@@ -293,6 +317,7 @@ func (s *WatcherSuite) TestStorageChanged(c *gc.C) {
 			Kind:     params.StorageKindBlock,
 			Attached: true,
 			Location: "malta",
+			Size:     1024,
 		},
 	})
 
@@ -179,6 +179,14 @@ type HookContext struct {
 	// like a juju-run command or a hook
 	process HookProcess
 
+	// actionStopStatus records that the running action has been
+	// stopped before it finished, because it was cancelled or ran for
+	// too long, and that its process should be killed. It holds the
+	// status the action will be finished with, and actionStopMessage
+	// the accompanying message.
+	actionStopStatus  string
+	actionStopMessage string
+
 	// rebootPriority tells us when the hook wants to reboot. If rebootPriority is jujuc.RebootNow
 	// the hook will be killed and requeued
 	rebootPriority jujuc.RebootPriority
@@ -272,6 +280,20 @@ func (ctx *HookContext) SetProcess(process HookProcess) {
 	mutex.Lock()
 	defer mutex.Unlock()
 	ctx.process = process
+	if ctx.actionStopStatus != "" && process != nil {
+		// The action was stopped before its process started.
+		if err := process.Kill(); err != nil {
+			logger.Infof("kill returned: %s", err)
+		}
+	}
+}
+
+// actionStopped returns the status and message recorded when the
+// running action was stopped, and whether it was stopped at all.
+func (ctx *HookContext) actionStopped() (status, message string, stopped bool) {
+	mutex.Lock()
+	defer mutex.Unlock()
+	return ctx.actionStopStatus, ctx.actionStopMessage, ctx.actionStopStatus != ""
 }
 
 func (ctx *HookContext) Id() string {
@@ -563,6 +585,40 @@ func (c *HookContext) ActionData() (*ActionData, error) {
 	return c.actionData, nil
 }
 
+// CancelAction kills the process running the context's action, and
+// records the action as cancelled when the context is flushed.
+func (ctx *HookContext) CancelAction() error {
+	return ctx.stopAction(params.ActionCancelled, "action cancelled")
+}
+
+// ExpireAction kills the process running the context's action, and
+// records the action as failed because it timed out when the context
+// is flushed.
+func (ctx *HookContext) ExpireAction() error {
+	return ctx.stopAction(params.ActionFailed, "action timed out")
+}
+
+// stopAction kills the process running the context's action, and
+// records the status and message the action will finish with.
+func (ctx *HookContext) stopAction(status, message string) error {
+	if ctx.actionData == nil {
+		return errors.New("not running an action")
+	}
+	mutex.Lock()
+	if ctx.actionStopStatus == "" {
+		ctx.actionStopStatus = status
+		ctx.actionStopMessage = message
+	}
+	mutex.Unlock()
+
+	err := ctx.killCharmHook()
+	if err == ErrNoProcess {
+		// The process will be killed when it is started.
+		return nil
+	}
+	return errors.Trace(err)
+}
+
 // HookVars returns an os.Environ-style list of strings necessary to run a hook
 // such that it can know what environment it's operating in, and can call back
 // into context.
@@ -739,7 +795,11 @@ func (ctx *HookContext) finalizeAction(err, unhandledErr error) error {
 
 	// If we had an action error, we'll simply encapsulate it in the response
 	// and discard the error state.  Actions should not error the uniter.
-	if err != nil {
+	// A stopped action's process was killed, so its error is expected.
+	if stopStatus, stopMessage, stopped := ctx.actionStopped(); stopped {
+		message = stopMessage
+		status = stopStatus
+	} else if err != nil {
 		message = err.Error()
 		if IsMissingHookError(err) {
 			message = fmt.Sprintf("action not implemented on unit %q", ctx.unitName)
@@ -282,6 +282,41 @@ func (s *InterfaceSuite) TestSetActionMessage(c *gc.C) {
 	c.Check(actionData.ResultsMessage, gc.Equals, "because reasons")
 }
 
+func (s *InterfaceSuite) TestCancelActionNotAction(c *gc.C) {
+	ctx := context.HookContext{}
+	err := ctx.CancelAction()
+	c.Assert(err, gc.ErrorMatches, "not running an action")
+}
+
+func (s *InterfaceSuite) TestCancelActionBeforeProcessStarts(c *gc.C) {
+	var killed bool
+	p := &mockProcess{func() error {
+		killed = true
+		return nil
+	}}
+	hctx := context.GetStubActionContext(nil)
+	err := hctx.CancelAction()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(killed, jc.IsFalse)
+
+	// The process is killed as soon as it is recorded.
+	hctx.SetProcess(p)
+	c.Assert(killed, jc.IsTrue)
+}
+
+func (s *InterfaceSuite) TestExpireActionKillsProcess(c *gc.C) {
+	var killed bool
+	p := &mockProcess{func() error {
+		killed = true
+		return nil
+	}}
+	hctx := context.GetStubActionContext(nil)
+	hctx.SetProcess(p)
+	err := hctx.ExpireAction()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(killed, jc.IsTrue)
+}
+
 func (s *InterfaceSuite) TestRequestRebootAfterHook(c *gc.C) {
 	var killed bool
 	p := &mockProcess{func() error {
@@ -136,8 +136,28 @@ func (s *storageResolver) nextHookOp(
 		storageAttachment, ok := s.storage.storageAttachments[tag]
 		if ok && storageAttachment.attached {
 			// Once the storage is attached, we only care about
-			// lifecycle state changes.
-			return nil, resolver.ErrNoOperation
+			// lifecycle state changes, and the storage growing.
+			if storageAttachment.size == 0 {
+				// The size previously reported to the charm
+				// was not recorded, so we cannot tell whether
+				// the storage has grown since. Record the
+				// current size so later growth is reported.
+				if snap.Size != 0 {
+					if err := storageAttachment.SetSize(snap.Size); err != nil {
+						return nil, errors.Trace(err)
+					}
+				}
+				return nil, resolver.ErrNoOperation
+			}
+			if snap.Size <= storageAttachment.size {
+				return nil, resolver.ErrNoOperation
+			}
+			// The storage has been resized since we last told
+			// the charm about it. Run "storage-resized" so the
+			// charm can make use of the additional space.
+			hookInfo.Kind = hook.StorageResized
+			hookInfo.StorageSize = snap.Size
+			break
 		}
 		// The storage-attached hook has not been committed, so add the
 		// storage to the pending set.
@@ -151,6 +171,7 @@ func (s *storageResolver) nextHookOp(
 		// The storage is alive, but we haven't previously run the
 		// "storage-attached" hook. Do so now.
 		hookInfo.Kind = hooks.StorageAttached
+		hookInfo.StorageSize = snap.Size
 	case params.Dying:
 		storageAttachment, ok := s.storage.storageAttachments[tag]
 		if !ok || !storageAttachment.attached {
@@ -25,6 +25,11 @@ type state struct {
 	// attached records the uniter's knowledge of the
 	// storage attachment state.
 	attached bool
+
+	// size records the size of the storage, in MiB, last
+	// reported to the charm by a storage-attached or
+	// storage-resized hook. Zero means the size is unknown.
+	size uint64
 }
 
 // ValidateHook returns an error if the supplied hook.Info does not represent
@@ -45,6 +50,13 @@ func (s *state) ValidateHook(hi hook.Info) (err error) {
 		if !s.attached {
 			return errors.New("storage not attached")
 		}
+	case hook.StorageResized:
+		if !s.attached {
+			return errors.New("storage not attached")
+		}
+		if hi.StorageSize <= s.size {
+			return errors.Errorf("storage size %dMiB not greater than %dMiB", hi.StorageSize, s.size)
+		}
 	}
 	return nil
 }
@@ -85,6 +97,7 @@ func readStateFile(dirPath string, tag names.StorageTag) (d *stateFile, err erro
 		return nil, errors.Errorf("invalid storage state file %q: missing 'attached'", d.path)
 	}
 	d.state.attached = *info.Attached
+	d.state.size = info.Size
 	return d, nil
 }
 
@@ -130,12 +143,30 @@ func (d *stateFile) CommitHook(hi hook.Info) (err error) {
 		return d.Remove()
 	}
 	attached := true
-	di := diskInfo{&attached}
+	di := diskInfo{Attached: &attached, Size: hi.StorageSize}
 	if err := utils.WriteYaml(d.path, &di); err != nil {
 		return err
 	}
 	// If write was successful, update own state.
 	d.state.attached = true
+	d.state.size = hi.StorageSize
+	return nil
+}
+
+// SetSize records the size of the attached storage, without running
+// a hook. It is used to record the size of storage attached before
+// sizes were recorded in the state file.
+func (d *stateFile) SetSize(size uint64) (err error) {
+	defer errors.DeferredAnnotatef(&err, "failed to write size for %q on state directory", d.storage.Id())
+	if !d.state.attached {
+		return errors.New("storage not attached")
+	}
+	attached := true
+	di := diskInfo{Attached: &attached, Size: size}
+	if err := utils.WriteYaml(d.path, &di); err != nil {
+		return err
+	}
+	d.state.size = size
 	return nil
 }
 
@@ -151,5 +182,6 @@ func (d *stateFile) Remove() error {
 
 // diskInfo defines the storage attachment data serialization.
 type diskInfo struct {
-	Attached *bool `yaml:"attached,omitempty"`
+	Attached *bool  `yaml:"attached,omitempty"`
+	Size     uint64 `yaml:"size,omitempty"`
 }
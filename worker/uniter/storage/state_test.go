@@ -170,6 +170,39 @@ func (s *stateSuite) TestCommitHook(c *gc.C) {
 	}
 }
 
+func (s *stateSuite) TestCommitHookRecordsSize(c *gc.C) {
+	dir := c.MkDir()
+	state, err := storage.ReadStateFile(dir, names.NewStorageTag("data/0"))
+	c.Assert(err, jc.ErrorIsNil)
+	stateFile := filepath.Join(dir, "data-0")
+
+	err = state.CommitHook(hook.Info{
+		Kind:        hooks.StorageAttached,
+		StorageId:   "data-0",
+		StorageSize: 1024,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(storage.StateSize(state), gc.Equals, uint64(1024))
+
+	for i := 0; i < 2; i++ {
+		err := state.CommitHook(hook.Info{
+			Kind:        hook.StorageResized,
+			StorageId:   "data-0",
+			StorageSize: 2048,
+		})
+		c.Assert(err, jc.ErrorIsNil)
+		c.Assert(storage.StateAttached(state), jc.IsTrue)
+		c.Assert(storage.StateSize(state), gc.Equals, uint64(2048))
+	}
+	data, err := ioutil.ReadFile(stateFile)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(string(data), gc.Equals, "attached: true\nsize: 2048\n")
+
+	state, err = storage.ReadStateFile(dir, names.NewStorageTag("data/0"))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(storage.StateSize(state), gc.Equals, uint64(2048))
+}
+
 func (s *stateSuite) TestValidateHook(c *gc.C) {
 	const unattached = false
 	const attached = true
@@ -199,4 +232,16 @@ func (s *stateSuite) TestValidateHook(c *gc.C) {
 	assertValidates(true, hooks.StorageDetaching)
 	assertValidateFails(false, hooks.StorageDetaching, `inappropriate "storage-detaching" hook for storage "data/0": storage not attached`)
 	assertValidateFails(true, hooks.StorageAttached, `inappropriate "storage-attached" hook for storage "data/0": storage already attached`)
+	assertValidateFails(false, hook.StorageResized, `inappropriate "storage-resized" hook for storage "data/0": storage not attached`)
+}
+
+func (s *stateSuite) TestValidateResizeHook(c *gc.C) {
+	validate := func(size uint64) error {
+		return storage.ValidateResizeHook(
+			names.NewStorageTag("data/0"), 1024,
+			hook.Info{Kind: hook.StorageResized, StorageId: "data/0", StorageSize: size},
+		)
+	}
+	c.Assert(validate(2048), jc.ErrorIsNil)
+	c.Assert(validate(1024), gc.ErrorMatches, `inappropriate "storage-resized" hook for storage "data/0": storage size 1024MiB not greater than 1024MiB`)
 }
@@ -88,6 +88,9 @@ type Uniter struct {
 	commands       runcommands.Commands
 	commandChannel chan string
 
+	// actionCancels delivers the cancellation of running actions.
+	actionCancels *actionCancels
+
 	// The execution observer is only used in tests at this stage. Should this
 	// need to be extended, perhaps a list of observers would be needed.
 	observer UniterExecutionObserver
@@ -241,6 +244,7 @@ func (u *Uniter) loop(unitTag names.UnitTag) (err error) {
 				UpdateStatusChannel: u.updateStatusAt,
 				CommandChannel:      u.commandChannel,
 				RetryHookChannel:    retryHookChan,
+				CancelAction:        u.actionCancels.cancel,
 			})
 		if err != nil {
 			return errors.Trace(err)
@@ -443,6 +447,7 @@ func (u *Uniter) init(unitTag names.UnitTag) (err error) {
 	u.storage = storageAttachments
 	u.commands = runcommands.NewCommands()
 	u.commandChannel = make(chan string)
+	u.actionCancels = newActionCancels()
 
 	deployer, err := charm.NewDeployer(
 		u.paths.State.CharmDir,
@@ -466,11 +471,13 @@ func (u *Uniter) init(unitTag names.UnitTag) (err error) {
 		return errors.Trace(err)
 	}
 	u.operationFactory = operation.NewFactory(operation.FactoryParams{
-		Deployer:       u.deployer,
-		RunnerFactory:  runnerFactory,
-		Callbacks:      &operationCallbacks{u},
-		Abort:          u.catacomb.Dying(),
-		MetricSpoolDir: u.paths.GetMetricsSpoolDir(),
+		Deployer:        u.deployer,
+		RunnerFactory:   runnerFactory,
+		Callbacks:       &operationCallbacks{u},
+		Abort:           u.catacomb.Dying(),
+		MetricSpoolDir:  u.paths.GetMetricsSpoolDir(),
+		ActionCancelled: u.actionCancels.watch,
+		Clock:           u.clock,
 	})
 
 	operationExecutor, err := u.newOperationExecutor(u.paths.State.OperationsFile, u.getServiceCharmURL, u.acquireExecutionLock)